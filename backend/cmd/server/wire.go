@@ -72,7 +72,14 @@ func provideCleanup(
 	accountExpiry *service.AccountExpiryService,
 	subscriptionExpiry *service.SubscriptionExpiryService,
 	usageCleanup *service.UsageCleanupService,
+	messageBatch *service.MessageBatchService,
+	webhook *service.WebhookService,
+	opsCapture *service.OpsCaptureService,
+	balanceLedger *service.BalanceLedgerService,
+	balanceAlert *service.BalanceAlertService,
+	invoice *service.InvoiceService,
 	pricing *service.PricingService,
+	modelPrices *service.ModelPriceService,
 	emailQueue *service.EmailQueueService,
 	billingCache *service.BillingCacheService,
 	oauth *service.OAuthService,
@@ -138,6 +145,12 @@ func provideCleanup(
 				}
 				return nil
 			}},
+			{"MessageBatchService", func() error {
+				if messageBatch != nil {
+					messageBatch.Stop()
+				}
+				return nil
+			}},
 			{"TokenRefreshService", func() error {
 				tokenRefresh.Stop()
 				return nil
@@ -150,10 +163,34 @@ func provideCleanup(
 				subscriptionExpiry.Stop()
 				return nil
 			}},
+			{"WebhookService", func() error {
+				webhook.Stop()
+				return nil
+			}},
+			{"OpsCaptureService", func() error {
+				opsCapture.Stop()
+				return nil
+			}},
+			{"BalanceLedgerService", func() error {
+				balanceLedger.Stop()
+				return nil
+			}},
+			{"BalanceAlertService", func() error {
+				balanceAlert.Stop()
+				return nil
+			}},
+			{"InvoiceService", func() error {
+				invoice.Stop()
+				return nil
+			}},
 			{"PricingService", func() error {
 				pricing.Stop()
 				return nil
 			}},
+			{"ModelPriceService", func() error {
+				modelPrices.Stop()
+				return nil
+			}},
 			{"EmailQueueService", func() error {
 				emailQueue.Stop()
 				return nil
@@ -53,12 +53,22 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	emailQueueService := service.ProvideEmailQueueService(emailService)
 	promoCodeRepository := repository.NewPromoCodeRepository(client)
 	billingCache := repository.NewBillingCache(redisClient)
+	billingReservationCache := repository.NewBillingReservationCache(redisClient)
+	pricingRemoteClient := repository.ProvidePricingRemoteClient(configConfig)
+	pricingService, err := service.ProvidePricingService(configConfig, pricingRemoteClient)
+	if err != nil {
+		return nil, err
+	}
+	modelPriceRepository := repository.NewModelPriceRepository(db)
+	groupRepository := repository.NewGroupRepository(client, db)
+	modelPriceService := service.ProvideModelPriceService(modelPriceRepository, groupRepository)
+	billingService := service.ProvideBillingService(configConfig, pricingService, modelPriceService)
 	userSubscriptionRepository := repository.NewUserSubscriptionRepository(client)
-	billingCacheService := service.NewBillingCacheService(billingCache, userRepository, userSubscriptionRepository, configConfig)
 	apiKeyRepository := repository.NewAPIKeyRepository(client)
-	groupRepository := repository.NewGroupRepository(client, db)
+	organizationRepository := repository.NewOrganizationRepository(db)
+	billingCacheService := service.ProvideBillingCacheService(billingCache, billingReservationCache, billingService, userRepository, userSubscriptionRepository, apiKeyRepository, organizationRepository, configConfig)
 	apiKeyCache := repository.NewAPIKeyCache(redisClient)
-	apiKeyService := service.NewAPIKeyService(apiKeyRepository, userRepository, groupRepository, userSubscriptionRepository, apiKeyCache, configConfig)
+	apiKeyService := service.ProvideAPIKeyService(apiKeyRepository, userRepository, groupRepository, userSubscriptionRepository, apiKeyCache, organizationRepository, configConfig)
 	apiKeyAuthCacheInvalidator := service.ProvideAPIKeyAuthCacheInvalidator(apiKeyService)
 	promoService := service.NewPromoService(promoCodeRepository, userRepository, billingCacheService, client, apiKeyAuthCacheInvalidator)
 	authService := service.NewAuthService(userRepository, configConfig, settingService, emailService, turnstileService, emailQueueService, promoService)
@@ -70,7 +80,13 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	totpCache := repository.NewTotpCache(redisClient)
 	totpService := service.NewTotpService(userRepository, secretEncryptor, totpCache, settingService, emailService, emailQueueService)
 	authHandler := handler.NewAuthHandler(configConfig, authService, userService, settingService, promoService, totpService)
-	userHandler := handler.NewUserHandler(userService)
+	balanceTransactionRepository := repository.NewBalanceTransactionRepository(db)
+	timingWheelService, err := service.ProvideTimingWheelService()
+	if err != nil {
+		return nil, err
+	}
+	balanceLedgerService := service.ProvideBalanceLedgerService(balanceTransactionRepository, timingWheelService)
+	userHandler := handler.NewUserHandler(userService, balanceLedgerService)
 	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
 	usageLogRepository := repository.NewUsageLogRepository(client, db)
 	usageService := service.NewUsageService(usageLogRepository, userRepository, client, apiKeyAuthCacheInvalidator)
@@ -84,10 +100,6 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	dashboardAggregationRepository := repository.NewDashboardAggregationRepository(db)
 	dashboardStatsCache := repository.NewDashboardCache(redisClient, configConfig)
 	dashboardService := service.NewDashboardService(usageLogRepository, dashboardAggregationRepository, dashboardStatsCache, configConfig)
-	timingWheelService, err := service.ProvideTimingWheelService()
-	if err != nil {
-		return nil, err
-	}
 	dashboardAggregationService := service.ProvideDashboardAggregationService(dashboardAggregationRepository, timingWheelService, configConfig)
 	dashboardHandler := admin.NewDashboardHandler(dashboardService, dashboardAggregationService)
 	schedulerCache := repository.NewSchedulerCache(redisClient)
@@ -96,7 +108,7 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	proxyExitInfoProber := repository.NewProxyExitInfoProber(configConfig)
 	proxyLatencyCache := repository.NewProxyLatencyCache(redisClient)
 	adminService := service.NewAdminService(userRepository, groupRepository, accountRepository, proxyRepository, apiKeyRepository, redeemCodeRepository, billingCacheService, proxyExitInfoProber, proxyLatencyCache, apiKeyAuthCacheInvalidator)
-	adminUserHandler := admin.NewUserHandler(adminService)
+	adminUserHandler := admin.NewUserHandler(adminService, balanceLedgerService)
 	groupHandler := admin.NewGroupHandler(adminService)
 	claudeOAuthClient := repository.NewClaudeOAuthClient()
 	oAuthService := service.NewOAuthService(proxyRepository, claudeOAuthClient)
@@ -111,7 +123,10 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	timeoutCounterCache := repository.NewTimeoutCounterCache(redisClient)
 	geminiTokenCache := repository.NewGeminiTokenCache(redisClient)
 	compositeTokenCacheInvalidator := service.NewCompositeTokenCacheInvalidator(geminiTokenCache)
-	rateLimitService := service.ProvideRateLimitService(accountRepository, usageLogRepository, configConfig, geminiQuotaService, tempUnschedCache, timeoutCounterCache, settingService, compositeTokenCacheInvalidator)
+	webhookRepository := repository.NewWebhookRepository(db)
+	webhookSender := repository.NewWebhookSender(configConfig)
+	webhookService := service.ProvideWebhookService(webhookRepository, webhookSender, timingWheelService, configConfig)
+	rateLimitService := service.ProvideRateLimitService(accountRepository, usageLogRepository, configConfig, geminiQuotaService, tempUnschedCache, timeoutCounterCache, settingService, compositeTokenCacheInvalidator, webhookService)
 	httpUpstream := repository.NewHTTPUpstream(configConfig)
 	claudeUsageFetcher := repository.NewClaudeUsageFetcher(httpUpstream)
 	antigravityQuotaFetcher := service.NewAntigravityQuotaFetcher(proxyRepository)
@@ -124,7 +139,8 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	antigravityGatewayService := service.NewAntigravityGatewayService(accountRepository, gatewayCache, antigravityTokenProvider, rateLimitService, httpUpstream, settingService)
 	accountTestService := service.NewAccountTestService(accountRepository, geminiTokenProvider, antigravityGatewayService, httpUpstream, configConfig)
 	concurrencyCache := repository.ProvideConcurrencyCache(redisClient, configConfig)
-	concurrencyService := service.ProvideConcurrencyService(concurrencyCache, accountRepository, configConfig)
+	slotWaitQueueCache := repository.ProvideSlotWaitQueueCache(redisClient, configConfig)
+	concurrencyService := service.ProvideConcurrencyService(concurrencyCache, slotWaitQueueCache, accountRepository, configConfig)
 	crsSyncService := service.NewCRSSyncService(accountRepository, proxyRepository, oAuthService, openAIOAuthService, geminiOAuthService, configConfig)
 	sessionLimitCache := repository.ProvideSessionLimitCache(redisClient, configConfig)
 	accountHandler := admin.NewAccountHandler(adminService, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService, rateLimitService, accountUsageService, accountTestService, concurrencyService, crsSyncService, sessionLimitCache, compositeTokenCacheInvalidator)
@@ -138,24 +154,25 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	opsRepository := repository.NewOpsRepository(db)
 	schedulerOutboxRepository := repository.NewSchedulerOutboxRepository(db)
 	schedulerSnapshotService := service.ProvideSchedulerSnapshotService(schedulerCache, schedulerOutboxRepository, accountRepository, groupRepository, configConfig)
-	pricingRemoteClient := repository.ProvidePricingRemoteClient(configConfig)
-	pricingService, err := service.ProvidePricingService(configConfig, pricingRemoteClient)
-	if err != nil {
-		return nil, err
-	}
-	billingService := service.NewBillingService(configConfig, pricingService)
+	apiKeyRateLimitCache := repository.NewAPIKeyRateLimitCache(redisClient)
+	apiKeyRateLimitService := service.NewAPIKeyRateLimitService(apiKeyRateLimitCache)
 	identityService := service.NewIdentityService(identityCache)
 	deferredService := service.ProvideDeferredService(accountRepository, timingWheelService)
 	claudeTokenProvider := service.NewClaudeTokenProvider(accountRepository, geminiTokenCache, oAuthService)
-	gatewayService := service.NewGatewayService(accountRepository, groupRepository, usageLogRepository, userRepository, userSubscriptionRepository, gatewayCache, configConfig, schedulerSnapshotService, concurrencyService, billingService, rateLimitService, billingCacheService, identityService, httpUpstream, deferredService, claudeTokenProvider, sessionLimitCache)
+	schedulingStrategyService := service.NewSchedulingStrategyService(usageLogRepository, sessionLimitCache)
+	accountCircuitBreakerCache := repository.NewAccountCircuitBreakerCache(redisClient)
+	accountCircuitBreakerService := service.NewAccountCircuitBreakerService(accountCircuitBreakerCache, configConfig)
+	gatewayService := service.NewGatewayService(accountRepository, groupRepository, usageLogRepository, userRepository, userSubscriptionRepository, gatewayCache, configConfig, schedulerSnapshotService, concurrencyService, billingService, rateLimitService, billingCacheService, apiKeyRateLimitService, identityService, httpUpstream, deferredService, claudeTokenProvider, sessionLimitCache, schedulingStrategyService, accountCircuitBreakerService)
 	openAITokenProvider := service.NewOpenAITokenProvider(accountRepository, geminiTokenCache, openAIOAuthService)
-	openAIGatewayService := service.NewOpenAIGatewayService(accountRepository, usageLogRepository, userRepository, userSubscriptionRepository, gatewayCache, configConfig, schedulerSnapshotService, concurrencyService, billingService, rateLimitService, billingCacheService, httpUpstream, deferredService, openAITokenProvider)
+	openAIGatewayService := service.NewOpenAIGatewayService(accountRepository, usageLogRepository, userRepository, userSubscriptionRepository, gatewayCache, configConfig, schedulerSnapshotService, concurrencyService, billingService, rateLimitService, billingCacheService, apiKeyRateLimitService, httpUpstream, deferredService, openAITokenProvider, schedulingStrategyService, accountCircuitBreakerService)
 	geminiMessagesCompatService := service.NewGeminiMessagesCompatService(accountRepository, groupRepository, gatewayCache, schedulerSnapshotService, geminiTokenProvider, rateLimitService, httpUpstream, antigravityGatewayService, configConfig)
-	opsService := service.NewOpsService(opsRepository, settingRepository, configConfig, accountRepository, concurrencyService, gatewayService, openAIGatewayService, geminiMessagesCompatService, antigravityGatewayService)
+	opsService := service.NewOpsService(opsRepository, settingRepository, configConfig, accountRepository, concurrencyService, gatewayService, openAIGatewayService, geminiMessagesCompatService, antigravityGatewayService, accountCircuitBreakerService)
 	weChatAPIClient := repository.NewWeChatAPIClient()
 	weChatQRCodeService := service.NewWeChatQRCodeService(settingService, weChatAPIClient)
 	settingHandler := admin.NewSettingHandler(settingService, emailService, turnstileService, opsService, weChatQRCodeService)
-	opsHandler := admin.NewOpsHandler(opsService)
+	opsCaptureRepository := repository.NewOpsCaptureRepository(db)
+	opsCaptureService := service.ProvideOpsCaptureService(opsCaptureRepository, secretEncryptor, timingWheelService, configConfig)
+	opsHandler := admin.NewOpsHandler(opsService, opsCaptureService)
 	updateCache := repository.NewUpdateCache(redisClient)
 	gitHubReleaseClient := repository.ProvideGitHubReleaseClient(configConfig)
 	serviceBuildInfo := provideServiceBuildInfo(buildInfo)
@@ -169,30 +186,53 @@ func initializeApplication(buildInfo handler.BuildInfo) (*Application, error) {
 	userAttributeValueRepository := repository.NewUserAttributeValueRepository(client)
 	userAttributeService := service.NewUserAttributeService(userAttributeDefinitionRepository, userAttributeValueRepository)
 	userAttributeHandler := admin.NewUserAttributeHandler(userAttributeService)
-	adminHandlers := handler.ProvideAdminHandlers(dashboardHandler, adminUserHandler, groupHandler, accountHandler, oAuthHandler, openAIOAuthHandler, geminiOAuthHandler, antigravityOAuthHandler, proxyHandler, adminRedeemHandler, promoHandler, settingHandler, opsHandler, systemHandler, adminSubscriptionHandler, adminUsageHandler, userAttributeHandler)
-	gatewayHandler := handler.NewGatewayHandler(gatewayService, geminiMessagesCompatService, antigravityGatewayService, userService, concurrencyService, billingCacheService, configConfig)
-	openAIGatewayHandler := handler.NewOpenAIGatewayHandler(openAIGatewayService, concurrencyService, billingCacheService, configConfig)
+	webhookHandler := admin.NewWebhookHandler(webhookService)
+	modelPriceHandler := admin.NewModelPriceHandler(modelPriceService, billingService)
+	auditLogRepository := repository.NewAuditLogRepository(db)
+	auditLogService := service.NewAuditLogService(auditLogRepository)
+	auditHandler := admin.NewAuditHandler(auditLogService, adminService, promoService, subscriptionService, settingService, webhookService)
+	invoiceRepository := repository.NewInvoiceRepository(db)
+	invoiceService := service.ProvideInvoiceService(invoiceRepository, userRepository, settingService, timingWheelService, configConfig)
+	invoiceHandler := admin.NewInvoiceHandler(invoiceService)
+	organizationService := service.NewOrganizationService(organizationRepository, userRepository, client, emailService, settingService, billingCacheService, apiKeyAuthCacheInvalidator)
+	organizationHandler := admin.NewOrganizationHandler(organizationService)
+	adminHandlers := handler.ProvideAdminHandlers(dashboardHandler, adminUserHandler, groupHandler, accountHandler, oAuthHandler, openAIOAuthHandler, geminiOAuthHandler, antigravityOAuthHandler, proxyHandler, adminRedeemHandler, promoHandler, settingHandler, opsHandler, systemHandler, adminSubscriptionHandler, adminUsageHandler, userAttributeHandler, webhookHandler, modelPriceHandler, auditHandler, invoiceHandler, organizationHandler)
+	responseCache := repository.NewResponseCache(redisClient)
+	responseCacheService := service.NewResponseCacheService(responseCache, configConfig)
+	guardrailService := service.NewGuardrailService()
+	gatewayHandler := handler.NewGatewayHandler(gatewayService, geminiMessagesCompatService, antigravityGatewayService, openAIGatewayService, userService, concurrencyService, billingCacheService, apiKeyRateLimitService, responseCacheService, guardrailService, opsCaptureService, configConfig)
+	openAIGatewayHandler := handler.NewOpenAIGatewayHandler(openAIGatewayService, gatewayService, concurrencyService, billingCacheService, apiKeyRateLimitService, responseCacheService, guardrailService, opsCaptureService, configConfig)
+	chatCompletionsHandler := handler.NewChatCompletionsHandler(gatewayHandler, openAIGatewayHandler)
+	embeddingsHandler := handler.NewEmbeddingsHandler(gatewayService, geminiMessagesCompatService, openAIGatewayService, concurrencyService, billingCacheService, apiKeyRateLimitService, configConfig)
+	messageBatchRepository := repository.NewMessageBatchRepository(db)
+	messageBatchService := service.ProvideMessageBatchService(messageBatchRepository, accountRepository, apiKeyRepository, userSubscriptionRepository, gatewayService, httpUpstream, timingWheelService, configConfig)
+	messageBatchHandler := handler.NewMessageBatchHandler(gatewayService, messageBatchService, billingCacheService, apiKeyRateLimitService, configConfig)
 	handlerSettingHandler := handler.ProvideSettingHandler(settingService, buildInfo)
 	totpHandler := handler.NewTotpHandler(totpService)
 	userUsageReportRepository := repository.NewUserUsageReportRepository(client, db)
 	userUsageReportService := service.NewUserUsageReportService(userRepository, usageService, settingService, emailService, userUsageReportRepository)
 	userUsageReportHandler := handler.NewUserUsageReportHandler(userUsageReportService, settingService, userRepository)
-	handlers := handler.ProvideHandlers(authHandler, userHandler, apiKeyHandler, usageHandler, redeemHandler, subscriptionHandler, adminHandlers, gatewayHandler, openAIGatewayHandler, handlerSettingHandler, totpHandler, userUsageReportHandler)
+	handlerInvoiceHandler := handler.NewInvoiceHandler(invoiceService)
+	handlerOrganizationHandler := handler.NewOrganizationHandler(organizationService, settingService)
+	balanceAlertRepository := repository.NewBalanceAlertRepository(db)
+	balanceAlertService := service.ProvideBalanceAlertService(balanceAlertRepository, userRepository, redeemCodeRepository, userSubscriptionRepository, redeemService, emailService, settingService, webhookService, billingCacheService)
+	balanceAlertHandler := handler.NewBalanceAlertHandler(balanceAlertService)
+	handlers := handler.ProvideHandlers(authHandler, userHandler, apiKeyHandler, usageHandler, redeemHandler, subscriptionHandler, adminHandlers, gatewayHandler, openAIGatewayHandler, chatCompletionsHandler, embeddingsHandler, messageBatchHandler, handlerSettingHandler, totpHandler, userUsageReportHandler, handlerInvoiceHandler, handlerOrganizationHandler, balanceAlertHandler)
 	jwtAuthMiddleware := middleware.NewJWTAuthMiddleware(authService, userService)
 	adminAuthMiddleware := middleware.NewAdminAuthMiddleware(authService, userService, settingService)
-	apiKeyAuthMiddleware := middleware.NewAPIKeyAuthMiddleware(apiKeyService, subscriptionService, configConfig)
-	engine := server.ProvideRouter(configConfig, handlers, jwtAuthMiddleware, adminAuthMiddleware, apiKeyAuthMiddleware, apiKeyService, subscriptionService, opsService, settingService, redisClient)
+	apiKeyAuthMiddleware := middleware.NewAPIKeyAuthMiddleware(apiKeyService, subscriptionService, billingCacheService, configConfig)
+	engine := server.ProvideRouter(configConfig, handlers, jwtAuthMiddleware, adminAuthMiddleware, apiKeyAuthMiddleware, apiKeyService, subscriptionService, billingCacheService, opsService, billingService, settingService, redisClient)
 	httpServer := server.ProvideHTTPServer(configConfig, engine)
 	opsMetricsCollector := service.ProvideOpsMetricsCollector(opsRepository, settingRepository, accountRepository, concurrencyService, db, redisClient, configConfig)
 	opsAggregationService := service.ProvideOpsAggregationService(opsRepository, settingRepository, db, redisClient, configConfig)
-	opsAlertEvaluatorService := service.ProvideOpsAlertEvaluatorService(opsService, opsRepository, emailService, redisClient, configConfig)
+	opsAlertEvaluatorService := service.ProvideOpsAlertEvaluatorService(opsService, opsRepository, emailService, redisClient, webhookService, configConfig)
 	opsCleanupService := service.ProvideOpsCleanupService(opsRepository, db, redisClient, configConfig)
 	opsScheduledReportService := service.ProvideOpsScheduledReportService(opsService, userService, emailService, redisClient, configConfig)
-	tokenRefreshService := service.ProvideTokenRefreshService(accountRepository, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService, compositeTokenCacheInvalidator, configConfig)
-	accountExpiryService := service.ProvideAccountExpiryService(accountRepository)
-	subscriptionExpiryService := service.ProvideSubscriptionExpiryService(userSubscriptionRepository)
+	tokenRefreshService := service.ProvideTokenRefreshService(accountRepository, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService, compositeTokenCacheInvalidator, webhookService, configConfig)
+	accountExpiryService := service.ProvideAccountExpiryService(accountRepository, webhookService)
+	subscriptionExpiryService := service.ProvideSubscriptionExpiryService(userSubscriptionRepository, webhookService)
 	userUsageReportScheduler := service.ProvideUserUsageReportScheduler(userUsageReportService, settingService, userUsageReportRepository, redisClient)
-	v := provideCleanup(client, redisClient, opsMetricsCollector, opsAggregationService, opsAlertEvaluatorService, opsCleanupService, opsScheduledReportService, schedulerSnapshotService, tokenRefreshService, accountExpiryService, subscriptionExpiryService, usageCleanupService, pricingService, emailQueueService, billingCacheService, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService, userUsageReportScheduler)
+	v := provideCleanup(client, redisClient, opsMetricsCollector, opsAggregationService, opsAlertEvaluatorService, opsCleanupService, opsScheduledReportService, schedulerSnapshotService, tokenRefreshService, accountExpiryService, subscriptionExpiryService, usageCleanupService, messageBatchService, webhookService, opsCaptureService, balanceLedgerService, balanceAlertService, invoiceService, pricingService, modelPriceService, emailQueueService, billingCacheService, oAuthService, openAIOAuthService, geminiOAuthService, antigravityOAuthService, userUsageReportScheduler)
 	application := &Application{
 		Server:  httpServer,
 		Cleanup: v,
@@ -227,7 +267,14 @@ func provideCleanup(
 	accountExpiry *service.AccountExpiryService,
 	subscriptionExpiry *service.SubscriptionExpiryService,
 	usageCleanup *service.UsageCleanupService,
+	messageBatch *service.MessageBatchService,
+	webhook *service.WebhookService,
+	opsCapture *service.OpsCaptureService,
+	balanceLedger *service.BalanceLedgerService,
+	balanceAlert *service.BalanceAlertService,
+	invoice *service.InvoiceService,
 	pricing *service.PricingService,
+	modelPrices *service.ModelPriceService,
 	emailQueue *service.EmailQueueService,
 	billingCache *service.BillingCacheService,
 	oauth *service.OAuthService,
@@ -292,6 +339,12 @@ func provideCleanup(
 				}
 				return nil
 			}},
+			{"MessageBatchService", func() error {
+				if messageBatch != nil {
+					messageBatch.Stop()
+				}
+				return nil
+			}},
 			{"TokenRefreshService", func() error {
 				tokenRefresh.Stop()
 				return nil
@@ -304,10 +357,34 @@ func provideCleanup(
 				subscriptionExpiry.Stop()
 				return nil
 			}},
+			{"WebhookService", func() error {
+				webhook.Stop()
+				return nil
+			}},
+			{"OpsCaptureService", func() error {
+				opsCapture.Stop()
+				return nil
+			}},
+			{"BalanceLedgerService", func() error {
+				balanceLedger.Stop()
+				return nil
+			}},
+			{"BalanceAlertService", func() error {
+				balanceAlert.Stop()
+				return nil
+			}},
+			{"InvoiceService", func() error {
+				invoice.Stop()
+				return nil
+			}},
 			{"PricingService", func() error {
 				pricing.Stop()
 				return nil
 			}},
+			{"ModelPriceService", func() error {
+				modelPrices.Stop()
+				return nil
+			}},
 			{"EmailQueueService", func() error {
 				emailQueue.Stop()
 				return nil
@@ -125,6 +125,110 @@ func (_c *APIKeyCreate) SetIPBlacklist(v []string) *APIKeyCreate {
 	return _c
 }
 
+// SetQuotaUsd sets the "quota_usd" field.
+func (_c *APIKeyCreate) SetQuotaUsd(v float64) *APIKeyCreate {
+	_c.mutation.SetQuotaUsd(v)
+	return _c
+}
+
+// SetNillableQuotaUsd sets the "quota_usd" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableQuotaUsd(v *float64) *APIKeyCreate {
+	if v != nil {
+		_c.SetQuotaUsd(*v)
+	}
+	return _c
+}
+
+// SetUsedUsd sets the "used_usd" field.
+func (_c *APIKeyCreate) SetUsedUsd(v float64) *APIKeyCreate {
+	_c.mutation.SetUsedUsd(v)
+	return _c
+}
+
+// SetNillableUsedUsd sets the "used_usd" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableUsedUsd(v *float64) *APIKeyCreate {
+	if v != nil {
+		_c.SetUsedUsd(*v)
+	}
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *APIKeyCreate) SetExpiresAt(v time.Time) *APIKeyCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableExpiresAt(v *time.Time) *APIKeyCreate {
+	if v != nil {
+		_c.SetExpiresAt(*v)
+	}
+	return _c
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (_c *APIKeyCreate) SetAllowedModels(v []string) *APIKeyCreate {
+	_c.mutation.SetAllowedModels(v)
+	return _c
+}
+
+// SetMaxTokensCap sets the "max_tokens_cap" field.
+func (_c *APIKeyCreate) SetMaxTokensCap(v int) *APIKeyCreate {
+	_c.mutation.SetMaxTokensCap(v)
+	return _c
+}
+
+// SetNillableMaxTokensCap sets the "max_tokens_cap" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableMaxTokensCap(v *int) *APIKeyCreate {
+	if v != nil {
+		_c.SetMaxTokensCap(*v)
+	}
+	return _c
+}
+
+// SetRpmLimit sets the "rpm_limit" field.
+func (_c *APIKeyCreate) SetRpmLimit(v int) *APIKeyCreate {
+	_c.mutation.SetRpmLimit(v)
+	return _c
+}
+
+// SetNillableRpmLimit sets the "rpm_limit" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableRpmLimit(v *int) *APIKeyCreate {
+	if v != nil {
+		_c.SetRpmLimit(*v)
+	}
+	return _c
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (_c *APIKeyCreate) SetTpmLimit(v int) *APIKeyCreate {
+	_c.mutation.SetTpmLimit(v)
+	return _c
+}
+
+// SetNillableTpmLimit sets the "tpm_limit" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableTpmLimit(v *int) *APIKeyCreate {
+	if v != nil {
+		_c.SetTpmLimit(*v)
+	}
+	return _c
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_c *APIKeyCreate) SetOrganizationID(v int64) *APIKeyCreate {
+	_c.mutation.SetOrganizationID(v)
+	return _c
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_c *APIKeyCreate) SetNillableOrganizationID(v *int64) *APIKeyCreate {
+	if v != nil {
+		_c.SetOrganizationID(*v)
+	}
+	return _c
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_c *APIKeyCreate) SetUser(v *User) *APIKeyCreate {
 	return _c.SetUserID(v.ID)
@@ -205,6 +309,10 @@ func (_c *APIKeyCreate) defaults() error {
 		v := apikey.DefaultStatus
 		_c.mutation.SetStatus(v)
 	}
+	if _, ok := _c.mutation.UsedUsd(); !ok {
+		v := apikey.DefaultUsedUsd
+		_c.mutation.SetUsedUsd(v)
+	}
 	return nil
 }
 
@@ -243,6 +351,9 @@ func (_c *APIKeyCreate) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "APIKey.status": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.UsedUsd(); !ok {
+		return &ValidationError{Name: "used_usd", err: errors.New(`ent: missing required field "APIKey.used_usd"`)}
+	}
 	if len(_c.mutation.UserIDs()) == 0 {
 		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "APIKey.user"`)}
 	}
@@ -305,6 +416,38 @@ func (_c *APIKeyCreate) createSpec() (*APIKey, *sqlgraph.CreateSpec) {
 		_spec.SetField(apikey.FieldIPBlacklist, field.TypeJSON, value)
 		_node.IPBlacklist = value
 	}
+	if value, ok := _c.mutation.QuotaUsd(); ok {
+		_spec.SetField(apikey.FieldQuotaUsd, field.TypeFloat64, value)
+		_node.QuotaUsd = &value
+	}
+	if value, ok := _c.mutation.UsedUsd(); ok {
+		_spec.SetField(apikey.FieldUsedUsd, field.TypeFloat64, value)
+		_node.UsedUsd = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(apikey.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = &value
+	}
+	if value, ok := _c.mutation.AllowedModels(); ok {
+		_spec.SetField(apikey.FieldAllowedModels, field.TypeJSON, value)
+		_node.AllowedModels = value
+	}
+	if value, ok := _c.mutation.MaxTokensCap(); ok {
+		_spec.SetField(apikey.FieldMaxTokensCap, field.TypeInt, value)
+		_node.MaxTokensCap = &value
+	}
+	if value, ok := _c.mutation.RpmLimit(); ok {
+		_spec.SetField(apikey.FieldRpmLimit, field.TypeInt, value)
+		_node.RpmLimit = &value
+	}
+	if value, ok := _c.mutation.TpmLimit(); ok {
+		_spec.SetField(apikey.FieldTpmLimit, field.TypeInt, value)
+		_node.TpmLimit = &value
+	}
+	if value, ok := _c.mutation.OrganizationID(); ok {
+		_spec.SetField(apikey.FieldOrganizationID, field.TypeInt64, value)
+		_node.OrganizationID = &value
+	}
 	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -539,6 +682,180 @@ func (u *APIKeyUpsert) ClearIPBlacklist() *APIKeyUpsert {
 	return u
 }
 
+// SetQuotaUsd sets the "quota_usd" field.
+func (u *APIKeyUpsert) SetQuotaUsd(v float64) *APIKeyUpsert {
+	u.Set(apikey.FieldQuotaUsd, v)
+	return u
+}
+
+// UpdateQuotaUsd sets the "quota_usd" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateQuotaUsd() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldQuotaUsd)
+	return u
+}
+
+// AddQuotaUsd adds v to the "quota_usd" field.
+func (u *APIKeyUpsert) AddQuotaUsd(v float64) *APIKeyUpsert {
+	u.Add(apikey.FieldQuotaUsd, v)
+	return u
+}
+
+// ClearQuotaUsd clears the value of the "quota_usd" field.
+func (u *APIKeyUpsert) ClearQuotaUsd() *APIKeyUpsert {
+	u.SetNull(apikey.FieldQuotaUsd)
+	return u
+}
+
+// SetUsedUsd sets the "used_usd" field.
+func (u *APIKeyUpsert) SetUsedUsd(v float64) *APIKeyUpsert {
+	u.Set(apikey.FieldUsedUsd, v)
+	return u
+}
+
+// UpdateUsedUsd sets the "used_usd" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateUsedUsd() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldUsedUsd)
+	return u
+}
+
+// AddUsedUsd adds v to the "used_usd" field.
+func (u *APIKeyUpsert) AddUsedUsd(v float64) *APIKeyUpsert {
+	u.Add(apikey.FieldUsedUsd, v)
+	return u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *APIKeyUpsert) SetExpiresAt(v time.Time) *APIKeyUpsert {
+	u.Set(apikey.FieldExpiresAt, v)
+	return u
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateExpiresAt() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldExpiresAt)
+	return u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (u *APIKeyUpsert) ClearExpiresAt() *APIKeyUpsert {
+	u.SetNull(apikey.FieldExpiresAt)
+	return u
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (u *APIKeyUpsert) SetAllowedModels(v []string) *APIKeyUpsert {
+	u.Set(apikey.FieldAllowedModels, v)
+	return u
+}
+
+// UpdateAllowedModels sets the "allowed_models" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateAllowedModels() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldAllowedModels)
+	return u
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (u *APIKeyUpsert) ClearAllowedModels() *APIKeyUpsert {
+	u.SetNull(apikey.FieldAllowedModels)
+	return u
+}
+
+// SetMaxTokensCap sets the "max_tokens_cap" field.
+func (u *APIKeyUpsert) SetMaxTokensCap(v int) *APIKeyUpsert {
+	u.Set(apikey.FieldMaxTokensCap, v)
+	return u
+}
+
+// UpdateMaxTokensCap sets the "max_tokens_cap" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateMaxTokensCap() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldMaxTokensCap)
+	return u
+}
+
+// AddMaxTokensCap adds v to the "max_tokens_cap" field.
+func (u *APIKeyUpsert) AddMaxTokensCap(v int) *APIKeyUpsert {
+	u.Add(apikey.FieldMaxTokensCap, v)
+	return u
+}
+
+// ClearMaxTokensCap clears the value of the "max_tokens_cap" field.
+func (u *APIKeyUpsert) ClearMaxTokensCap() *APIKeyUpsert {
+	u.SetNull(apikey.FieldMaxTokensCap)
+	return u
+}
+
+// SetRpmLimit sets the "rpm_limit" field.
+func (u *APIKeyUpsert) SetRpmLimit(v int) *APIKeyUpsert {
+	u.Set(apikey.FieldRpmLimit, v)
+	return u
+}
+
+// UpdateRpmLimit sets the "rpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateRpmLimit() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldRpmLimit)
+	return u
+}
+
+// AddRpmLimit adds v to the "rpm_limit" field.
+func (u *APIKeyUpsert) AddRpmLimit(v int) *APIKeyUpsert {
+	u.Add(apikey.FieldRpmLimit, v)
+	return u
+}
+
+// ClearRpmLimit clears the value of the "rpm_limit" field.
+func (u *APIKeyUpsert) ClearRpmLimit() *APIKeyUpsert {
+	u.SetNull(apikey.FieldRpmLimit)
+	return u
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (u *APIKeyUpsert) SetTpmLimit(v int) *APIKeyUpsert {
+	u.Set(apikey.FieldTpmLimit, v)
+	return u
+}
+
+// UpdateTpmLimit sets the "tpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateTpmLimit() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldTpmLimit)
+	return u
+}
+
+// AddTpmLimit adds v to the "tpm_limit" field.
+func (u *APIKeyUpsert) AddTpmLimit(v int) *APIKeyUpsert {
+	u.Add(apikey.FieldTpmLimit, v)
+	return u
+}
+
+// ClearTpmLimit clears the value of the "tpm_limit" field.
+func (u *APIKeyUpsert) ClearTpmLimit() *APIKeyUpsert {
+	u.SetNull(apikey.FieldTpmLimit)
+	return u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *APIKeyUpsert) SetOrganizationID(v int64) *APIKeyUpsert {
+	u.Set(apikey.FieldOrganizationID, v)
+	return u
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *APIKeyUpsert) UpdateOrganizationID() *APIKeyUpsert {
+	u.SetExcluded(apikey.FieldOrganizationID)
+	return u
+}
+
+// AddOrganizationID adds v to the "organization_id" field.
+func (u *APIKeyUpsert) AddOrganizationID(v int64) *APIKeyUpsert {
+	u.Add(apikey.FieldOrganizationID, v)
+	return u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (u *APIKeyUpsert) ClearOrganizationID() *APIKeyUpsert {
+	u.SetNull(apikey.FieldOrganizationID)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -738,6 +1055,209 @@ func (u *APIKeyUpsertOne) ClearIPBlacklist() *APIKeyUpsertOne {
 	})
 }
 
+// SetQuotaUsd sets the "quota_usd" field.
+func (u *APIKeyUpsertOne) SetQuotaUsd(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetQuotaUsd(v)
+	})
+}
+
+// AddQuotaUsd adds v to the "quota_usd" field.
+func (u *APIKeyUpsertOne) AddQuotaUsd(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddQuotaUsd(v)
+	})
+}
+
+// UpdateQuotaUsd sets the "quota_usd" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateQuotaUsd() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateQuotaUsd()
+	})
+}
+
+// ClearQuotaUsd clears the value of the "quota_usd" field.
+func (u *APIKeyUpsertOne) ClearQuotaUsd() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearQuotaUsd()
+	})
+}
+
+// SetUsedUsd sets the "used_usd" field.
+func (u *APIKeyUpsertOne) SetUsedUsd(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsedUsd(v)
+	})
+}
+
+// AddUsedUsd adds v to the "used_usd" field.
+func (u *APIKeyUpsertOne) AddUsedUsd(v float64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddUsedUsd(v)
+	})
+}
+
+// UpdateUsedUsd sets the "used_usd" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateUsedUsd() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsedUsd()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *APIKeyUpsertOne) SetExpiresAt(v time.Time) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateExpiresAt() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (u *APIKeyUpsertOne) ClearExpiresAt() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearExpiresAt()
+	})
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (u *APIKeyUpsertOne) SetAllowedModels(v []string) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetAllowedModels(v)
+	})
+}
+
+// UpdateAllowedModels sets the "allowed_models" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateAllowedModels() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateAllowedModels()
+	})
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (u *APIKeyUpsertOne) ClearAllowedModels() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearAllowedModels()
+	})
+}
+
+// SetMaxTokensCap sets the "max_tokens_cap" field.
+func (u *APIKeyUpsertOne) SetMaxTokensCap(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetMaxTokensCap(v)
+	})
+}
+
+// AddMaxTokensCap adds v to the "max_tokens_cap" field.
+func (u *APIKeyUpsertOne) AddMaxTokensCap(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddMaxTokensCap(v)
+	})
+}
+
+// UpdateMaxTokensCap sets the "max_tokens_cap" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateMaxTokensCap() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateMaxTokensCap()
+	})
+}
+
+// ClearMaxTokensCap clears the value of the "max_tokens_cap" field.
+func (u *APIKeyUpsertOne) ClearMaxTokensCap() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearMaxTokensCap()
+	})
+}
+
+// SetRpmLimit sets the "rpm_limit" field.
+func (u *APIKeyUpsertOne) SetRpmLimit(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetRpmLimit(v)
+	})
+}
+
+// AddRpmLimit adds v to the "rpm_limit" field.
+func (u *APIKeyUpsertOne) AddRpmLimit(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddRpmLimit(v)
+	})
+}
+
+// UpdateRpmLimit sets the "rpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateRpmLimit() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateRpmLimit()
+	})
+}
+
+// ClearRpmLimit clears the value of the "rpm_limit" field.
+func (u *APIKeyUpsertOne) ClearRpmLimit() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearRpmLimit()
+	})
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (u *APIKeyUpsertOne) SetTpmLimit(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetTpmLimit(v)
+	})
+}
+
+// AddTpmLimit adds v to the "tpm_limit" field.
+func (u *APIKeyUpsertOne) AddTpmLimit(v int) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddTpmLimit(v)
+	})
+}
+
+// UpdateTpmLimit sets the "tpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateTpmLimit() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateTpmLimit()
+	})
+}
+
+// ClearTpmLimit clears the value of the "tpm_limit" field.
+func (u *APIKeyUpsertOne) ClearTpmLimit() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearTpmLimit()
+	})
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *APIKeyUpsertOne) SetOrganizationID(v int64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetOrganizationID(v)
+	})
+}
+
+// AddOrganizationID adds v to the "organization_id" field.
+func (u *APIKeyUpsertOne) AddOrganizationID(v int64) *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddOrganizationID(v)
+	})
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *APIKeyUpsertOne) UpdateOrganizationID() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateOrganizationID()
+	})
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (u *APIKeyUpsertOne) ClearOrganizationID() *APIKeyUpsertOne {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearOrganizationID()
+	})
+}
+
 // Exec executes the query.
 func (u *APIKeyUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1103,6 +1623,209 @@ func (u *APIKeyUpsertBulk) ClearIPBlacklist() *APIKeyUpsertBulk {
 	})
 }
 
+// SetQuotaUsd sets the "quota_usd" field.
+func (u *APIKeyUpsertBulk) SetQuotaUsd(v float64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetQuotaUsd(v)
+	})
+}
+
+// AddQuotaUsd adds v to the "quota_usd" field.
+func (u *APIKeyUpsertBulk) AddQuotaUsd(v float64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddQuotaUsd(v)
+	})
+}
+
+// UpdateQuotaUsd sets the "quota_usd" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateQuotaUsd() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateQuotaUsd()
+	})
+}
+
+// ClearQuotaUsd clears the value of the "quota_usd" field.
+func (u *APIKeyUpsertBulk) ClearQuotaUsd() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearQuotaUsd()
+	})
+}
+
+// SetUsedUsd sets the "used_usd" field.
+func (u *APIKeyUpsertBulk) SetUsedUsd(v float64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetUsedUsd(v)
+	})
+}
+
+// AddUsedUsd adds v to the "used_usd" field.
+func (u *APIKeyUpsertBulk) AddUsedUsd(v float64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddUsedUsd(v)
+	})
+}
+
+// UpdateUsedUsd sets the "used_usd" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateUsedUsd() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateUsedUsd()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *APIKeyUpsertBulk) SetExpiresAt(v time.Time) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateExpiresAt() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (u *APIKeyUpsertBulk) ClearExpiresAt() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearExpiresAt()
+	})
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (u *APIKeyUpsertBulk) SetAllowedModels(v []string) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetAllowedModels(v)
+	})
+}
+
+// UpdateAllowedModels sets the "allowed_models" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateAllowedModels() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateAllowedModels()
+	})
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (u *APIKeyUpsertBulk) ClearAllowedModels() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearAllowedModels()
+	})
+}
+
+// SetMaxTokensCap sets the "max_tokens_cap" field.
+func (u *APIKeyUpsertBulk) SetMaxTokensCap(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetMaxTokensCap(v)
+	})
+}
+
+// AddMaxTokensCap adds v to the "max_tokens_cap" field.
+func (u *APIKeyUpsertBulk) AddMaxTokensCap(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddMaxTokensCap(v)
+	})
+}
+
+// UpdateMaxTokensCap sets the "max_tokens_cap" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateMaxTokensCap() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateMaxTokensCap()
+	})
+}
+
+// ClearMaxTokensCap clears the value of the "max_tokens_cap" field.
+func (u *APIKeyUpsertBulk) ClearMaxTokensCap() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearMaxTokensCap()
+	})
+}
+
+// SetRpmLimit sets the "rpm_limit" field.
+func (u *APIKeyUpsertBulk) SetRpmLimit(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetRpmLimit(v)
+	})
+}
+
+// AddRpmLimit adds v to the "rpm_limit" field.
+func (u *APIKeyUpsertBulk) AddRpmLimit(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddRpmLimit(v)
+	})
+}
+
+// UpdateRpmLimit sets the "rpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateRpmLimit() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateRpmLimit()
+	})
+}
+
+// ClearRpmLimit clears the value of the "rpm_limit" field.
+func (u *APIKeyUpsertBulk) ClearRpmLimit() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearRpmLimit()
+	})
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (u *APIKeyUpsertBulk) SetTpmLimit(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetTpmLimit(v)
+	})
+}
+
+// AddTpmLimit adds v to the "tpm_limit" field.
+func (u *APIKeyUpsertBulk) AddTpmLimit(v int) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddTpmLimit(v)
+	})
+}
+
+// UpdateTpmLimit sets the "tpm_limit" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateTpmLimit() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateTpmLimit()
+	})
+}
+
+// ClearTpmLimit clears the value of the "tpm_limit" field.
+func (u *APIKeyUpsertBulk) ClearTpmLimit() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearTpmLimit()
+	})
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *APIKeyUpsertBulk) SetOrganizationID(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.SetOrganizationID(v)
+	})
+}
+
+// AddOrganizationID adds v to the "organization_id" field.
+func (u *APIKeyUpsertBulk) AddOrganizationID(v int64) *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.AddOrganizationID(v)
+	})
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *APIKeyUpsertBulk) UpdateOrganizationID() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.UpdateOrganizationID()
+	})
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (u *APIKeyUpsertBulk) ClearOrganizationID() *APIKeyUpsertBulk {
+	return u.Update(func(s *APIKeyUpsert) {
+		s.ClearOrganizationID()
+	})
+}
+
 // Exec executes the query.
 func (u *APIKeyUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
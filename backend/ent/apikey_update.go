@@ -170,6 +170,200 @@ func (_u *APIKeyUpdate) ClearIPBlacklist() *APIKeyUpdate {
 	return _u
 }
 
+// SetQuotaUsd sets the "quota_usd" field.
+func (_u *APIKeyUpdate) SetQuotaUsd(v float64) *APIKeyUpdate {
+	_u.mutation.ResetQuotaUsd()
+	_u.mutation.SetQuotaUsd(v)
+	return _u
+}
+
+// SetNillableQuotaUsd sets the "quota_usd" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableQuotaUsd(v *float64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetQuotaUsd(*v)
+	}
+	return _u
+}
+
+// AddQuotaUsd adds value to the "quota_usd" field.
+func (_u *APIKeyUpdate) AddQuotaUsd(v float64) *APIKeyUpdate {
+	_u.mutation.AddQuotaUsd(v)
+	return _u
+}
+
+// ClearQuotaUsd clears the value of the "quota_usd" field.
+func (_u *APIKeyUpdate) ClearQuotaUsd() *APIKeyUpdate {
+	_u.mutation.ClearQuotaUsd()
+	return _u
+}
+
+// SetUsedUsd sets the "used_usd" field.
+func (_u *APIKeyUpdate) SetUsedUsd(v float64) *APIKeyUpdate {
+	_u.mutation.ResetUsedUsd()
+	_u.mutation.SetUsedUsd(v)
+	return _u
+}
+
+// SetNillableUsedUsd sets the "used_usd" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableUsedUsd(v *float64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetUsedUsd(*v)
+	}
+	return _u
+}
+
+// AddUsedUsd adds value to the "used_usd" field.
+func (_u *APIKeyUpdate) AddUsedUsd(v float64) *APIKeyUpdate {
+	_u.mutation.AddUsedUsd(v)
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *APIKeyUpdate) SetExpiresAt(v time.Time) *APIKeyUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableExpiresAt(v *time.Time) *APIKeyUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *APIKeyUpdate) ClearExpiresAt() *APIKeyUpdate {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (_u *APIKeyUpdate) SetAllowedModels(v []string) *APIKeyUpdate {
+	_u.mutation.SetAllowedModels(v)
+	return _u
+}
+
+// AppendAllowedModels appends value to the "allowed_models" field.
+func (_u *APIKeyUpdate) AppendAllowedModels(v []string) *APIKeyUpdate {
+	_u.mutation.AppendAllowedModels(v)
+	return _u
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (_u *APIKeyUpdate) ClearAllowedModels() *APIKeyUpdate {
+	_u.mutation.ClearAllowedModels()
+	return _u
+}
+
+// SetMaxTokensCap sets the "max_tokens_cap" field.
+func (_u *APIKeyUpdate) SetMaxTokensCap(v int) *APIKeyUpdate {
+	_u.mutation.ResetMaxTokensCap()
+	_u.mutation.SetMaxTokensCap(v)
+	return _u
+}
+
+// SetNillableMaxTokensCap sets the "max_tokens_cap" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableMaxTokensCap(v *int) *APIKeyUpdate {
+	if v != nil {
+		_u.SetMaxTokensCap(*v)
+	}
+	return _u
+}
+
+// AddMaxTokensCap adds value to the "max_tokens_cap" field.
+func (_u *APIKeyUpdate) AddMaxTokensCap(v int) *APIKeyUpdate {
+	_u.mutation.AddMaxTokensCap(v)
+	return _u
+}
+
+// ClearMaxTokensCap clears the value of the "max_tokens_cap" field.
+func (_u *APIKeyUpdate) ClearMaxTokensCap() *APIKeyUpdate {
+	_u.mutation.ClearMaxTokensCap()
+	return _u
+}
+
+// SetRpmLimit sets the "rpm_limit" field.
+func (_u *APIKeyUpdate) SetRpmLimit(v int) *APIKeyUpdate {
+	_u.mutation.ResetRpmLimit()
+	_u.mutation.SetRpmLimit(v)
+	return _u
+}
+
+// SetNillableRpmLimit sets the "rpm_limit" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableRpmLimit(v *int) *APIKeyUpdate {
+	if v != nil {
+		_u.SetRpmLimit(*v)
+	}
+	return _u
+}
+
+// AddRpmLimit adds value to the "rpm_limit" field.
+func (_u *APIKeyUpdate) AddRpmLimit(v int) *APIKeyUpdate {
+	_u.mutation.AddRpmLimit(v)
+	return _u
+}
+
+// ClearRpmLimit clears the value of the "rpm_limit" field.
+func (_u *APIKeyUpdate) ClearRpmLimit() *APIKeyUpdate {
+	_u.mutation.ClearRpmLimit()
+	return _u
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (_u *APIKeyUpdate) SetTpmLimit(v int) *APIKeyUpdate {
+	_u.mutation.ResetTpmLimit()
+	_u.mutation.SetTpmLimit(v)
+	return _u
+}
+
+// SetNillableTpmLimit sets the "tpm_limit" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableTpmLimit(v *int) *APIKeyUpdate {
+	if v != nil {
+		_u.SetTpmLimit(*v)
+	}
+	return _u
+}
+
+// AddTpmLimit adds value to the "tpm_limit" field.
+func (_u *APIKeyUpdate) AddTpmLimit(v int) *APIKeyUpdate {
+	_u.mutation.AddTpmLimit(v)
+	return _u
+}
+
+// ClearTpmLimit clears the value of the "tpm_limit" field.
+func (_u *APIKeyUpdate) ClearTpmLimit() *APIKeyUpdate {
+	_u.mutation.ClearTpmLimit()
+	return _u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_u *APIKeyUpdate) SetOrganizationID(v int64) *APIKeyUpdate {
+	_u.mutation.ResetOrganizationID()
+	_u.mutation.SetOrganizationID(v)
+	return _u
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_u *APIKeyUpdate) SetNillableOrganizationID(v *int64) *APIKeyUpdate {
+	if v != nil {
+		_u.SetOrganizationID(*v)
+	}
+	return _u
+}
+
+// AddOrganizationID adds value to the "organization_id" field.
+func (_u *APIKeyUpdate) AddOrganizationID(v int64) *APIKeyUpdate {
+	_u.mutation.AddOrganizationID(v)
+	return _u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (_u *APIKeyUpdate) ClearOrganizationID() *APIKeyUpdate {
+	_u.mutation.ClearOrganizationID()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *APIKeyUpdate) SetUser(v *User) *APIKeyUpdate {
 	return _u.SetUserID(v.ID)
@@ -350,6 +544,74 @@ func (_u *APIKeyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.IPBlacklistCleared() {
 		_spec.ClearField(apikey.FieldIPBlacklist, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.QuotaUsd(); ok {
+		_spec.SetField(apikey.FieldQuotaUsd, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedQuotaUsd(); ok {
+		_spec.AddField(apikey.FieldQuotaUsd, field.TypeFloat64, value)
+	}
+	if _u.mutation.QuotaUsdCleared() {
+		_spec.ClearField(apikey.FieldQuotaUsd, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.UsedUsd(); ok {
+		_spec.SetField(apikey.FieldUsedUsd, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedUsedUsd(); ok {
+		_spec.AddField(apikey.FieldUsedUsd, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(apikey.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(apikey.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.AllowedModels(); ok {
+		_spec.SetField(apikey.FieldAllowedModels, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedModels(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldAllowedModels, value)
+		})
+	}
+	if _u.mutation.AllowedModelsCleared() {
+		_spec.ClearField(apikey.FieldAllowedModels, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.MaxTokensCap(); ok {
+		_spec.SetField(apikey.FieldMaxTokensCap, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxTokensCap(); ok {
+		_spec.AddField(apikey.FieldMaxTokensCap, field.TypeInt, value)
+	}
+	if _u.mutation.MaxTokensCapCleared() {
+		_spec.ClearField(apikey.FieldMaxTokensCap, field.TypeInt)
+	}
+	if value, ok := _u.mutation.RpmLimit(); ok {
+		_spec.SetField(apikey.FieldRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRpmLimit(); ok {
+		_spec.AddField(apikey.FieldRpmLimit, field.TypeInt, value)
+	}
+	if _u.mutation.RpmLimitCleared() {
+		_spec.ClearField(apikey.FieldRpmLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.TpmLimit(); ok {
+		_spec.SetField(apikey.FieldTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTpmLimit(); ok {
+		_spec.AddField(apikey.FieldTpmLimit, field.TypeInt, value)
+	}
+	if _u.mutation.TpmLimitCleared() {
+		_spec.ClearField(apikey.FieldTpmLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.OrganizationID(); ok {
+		_spec.SetField(apikey.FieldOrganizationID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedOrganizationID(); ok {
+		_spec.AddField(apikey.FieldOrganizationID, field.TypeInt64, value)
+	}
+	if _u.mutation.OrganizationIDCleared() {
+		_spec.ClearField(apikey.FieldOrganizationID, field.TypeInt64)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -611,6 +873,200 @@ func (_u *APIKeyUpdateOne) ClearIPBlacklist() *APIKeyUpdateOne {
 	return _u
 }
 
+// SetQuotaUsd sets the "quota_usd" field.
+func (_u *APIKeyUpdateOne) SetQuotaUsd(v float64) *APIKeyUpdateOne {
+	_u.mutation.ResetQuotaUsd()
+	_u.mutation.SetQuotaUsd(v)
+	return _u
+}
+
+// SetNillableQuotaUsd sets the "quota_usd" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableQuotaUsd(v *float64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetQuotaUsd(*v)
+	}
+	return _u
+}
+
+// AddQuotaUsd adds value to the "quota_usd" field.
+func (_u *APIKeyUpdateOne) AddQuotaUsd(v float64) *APIKeyUpdateOne {
+	_u.mutation.AddQuotaUsd(v)
+	return _u
+}
+
+// ClearQuotaUsd clears the value of the "quota_usd" field.
+func (_u *APIKeyUpdateOne) ClearQuotaUsd() *APIKeyUpdateOne {
+	_u.mutation.ClearQuotaUsd()
+	return _u
+}
+
+// SetUsedUsd sets the "used_usd" field.
+func (_u *APIKeyUpdateOne) SetUsedUsd(v float64) *APIKeyUpdateOne {
+	_u.mutation.ResetUsedUsd()
+	_u.mutation.SetUsedUsd(v)
+	return _u
+}
+
+// SetNillableUsedUsd sets the "used_usd" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableUsedUsd(v *float64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetUsedUsd(*v)
+	}
+	return _u
+}
+
+// AddUsedUsd adds value to the "used_usd" field.
+func (_u *APIKeyUpdateOne) AddUsedUsd(v float64) *APIKeyUpdateOne {
+	_u.mutation.AddUsedUsd(v)
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *APIKeyUpdateOne) SetExpiresAt(v time.Time) *APIKeyUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableExpiresAt(v *time.Time) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *APIKeyUpdateOne) ClearExpiresAt() *APIKeyUpdateOne {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (_u *APIKeyUpdateOne) SetAllowedModels(v []string) *APIKeyUpdateOne {
+	_u.mutation.SetAllowedModels(v)
+	return _u
+}
+
+// AppendAllowedModels appends value to the "allowed_models" field.
+func (_u *APIKeyUpdateOne) AppendAllowedModels(v []string) *APIKeyUpdateOne {
+	_u.mutation.AppendAllowedModels(v)
+	return _u
+}
+
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (_u *APIKeyUpdateOne) ClearAllowedModels() *APIKeyUpdateOne {
+	_u.mutation.ClearAllowedModels()
+	return _u
+}
+
+// SetMaxTokensCap sets the "max_tokens_cap" field.
+func (_u *APIKeyUpdateOne) SetMaxTokensCap(v int) *APIKeyUpdateOne {
+	_u.mutation.ResetMaxTokensCap()
+	_u.mutation.SetMaxTokensCap(v)
+	return _u
+}
+
+// SetNillableMaxTokensCap sets the "max_tokens_cap" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableMaxTokensCap(v *int) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetMaxTokensCap(*v)
+	}
+	return _u
+}
+
+// AddMaxTokensCap adds value to the "max_tokens_cap" field.
+func (_u *APIKeyUpdateOne) AddMaxTokensCap(v int) *APIKeyUpdateOne {
+	_u.mutation.AddMaxTokensCap(v)
+	return _u
+}
+
+// ClearMaxTokensCap clears the value of the "max_tokens_cap" field.
+func (_u *APIKeyUpdateOne) ClearMaxTokensCap() *APIKeyUpdateOne {
+	_u.mutation.ClearMaxTokensCap()
+	return _u
+}
+
+// SetRpmLimit sets the "rpm_limit" field.
+func (_u *APIKeyUpdateOne) SetRpmLimit(v int) *APIKeyUpdateOne {
+	_u.mutation.ResetRpmLimit()
+	_u.mutation.SetRpmLimit(v)
+	return _u
+}
+
+// SetNillableRpmLimit sets the "rpm_limit" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableRpmLimit(v *int) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetRpmLimit(*v)
+	}
+	return _u
+}
+
+// AddRpmLimit adds value to the "rpm_limit" field.
+func (_u *APIKeyUpdateOne) AddRpmLimit(v int) *APIKeyUpdateOne {
+	_u.mutation.AddRpmLimit(v)
+	return _u
+}
+
+// ClearRpmLimit clears the value of the "rpm_limit" field.
+func (_u *APIKeyUpdateOne) ClearRpmLimit() *APIKeyUpdateOne {
+	_u.mutation.ClearRpmLimit()
+	return _u
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (_u *APIKeyUpdateOne) SetTpmLimit(v int) *APIKeyUpdateOne {
+	_u.mutation.ResetTpmLimit()
+	_u.mutation.SetTpmLimit(v)
+	return _u
+}
+
+// SetNillableTpmLimit sets the "tpm_limit" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableTpmLimit(v *int) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetTpmLimit(*v)
+	}
+	return _u
+}
+
+// AddTpmLimit adds value to the "tpm_limit" field.
+func (_u *APIKeyUpdateOne) AddTpmLimit(v int) *APIKeyUpdateOne {
+	_u.mutation.AddTpmLimit(v)
+	return _u
+}
+
+// ClearTpmLimit clears the value of the "tpm_limit" field.
+func (_u *APIKeyUpdateOne) ClearTpmLimit() *APIKeyUpdateOne {
+	_u.mutation.ClearTpmLimit()
+	return _u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_u *APIKeyUpdateOne) SetOrganizationID(v int64) *APIKeyUpdateOne {
+	_u.mutation.ResetOrganizationID()
+	_u.mutation.SetOrganizationID(v)
+	return _u
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_u *APIKeyUpdateOne) SetNillableOrganizationID(v *int64) *APIKeyUpdateOne {
+	if v != nil {
+		_u.SetOrganizationID(*v)
+	}
+	return _u
+}
+
+// AddOrganizationID adds value to the "organization_id" field.
+func (_u *APIKeyUpdateOne) AddOrganizationID(v int64) *APIKeyUpdateOne {
+	_u.mutation.AddOrganizationID(v)
+	return _u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (_u *APIKeyUpdateOne) ClearOrganizationID() *APIKeyUpdateOne {
+	_u.mutation.ClearOrganizationID()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *APIKeyUpdateOne) SetUser(v *User) *APIKeyUpdateOne {
 	return _u.SetUserID(v.ID)
@@ -821,6 +1277,74 @@ func (_u *APIKeyUpdateOne) sqlSave(ctx context.Context) (_node *APIKey, err erro
 	if _u.mutation.IPBlacklistCleared() {
 		_spec.ClearField(apikey.FieldIPBlacklist, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.QuotaUsd(); ok {
+		_spec.SetField(apikey.FieldQuotaUsd, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedQuotaUsd(); ok {
+		_spec.AddField(apikey.FieldQuotaUsd, field.TypeFloat64, value)
+	}
+	if _u.mutation.QuotaUsdCleared() {
+		_spec.ClearField(apikey.FieldQuotaUsd, field.TypeFloat64)
+	}
+	if value, ok := _u.mutation.UsedUsd(); ok {
+		_spec.SetField(apikey.FieldUsedUsd, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedUsedUsd(); ok {
+		_spec.AddField(apikey.FieldUsedUsd, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(apikey.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(apikey.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.AllowedModels(); ok {
+		_spec.SetField(apikey.FieldAllowedModels, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedModels(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldAllowedModels, value)
+		})
+	}
+	if _u.mutation.AllowedModelsCleared() {
+		_spec.ClearField(apikey.FieldAllowedModels, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.MaxTokensCap(); ok {
+		_spec.SetField(apikey.FieldMaxTokensCap, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxTokensCap(); ok {
+		_spec.AddField(apikey.FieldMaxTokensCap, field.TypeInt, value)
+	}
+	if _u.mutation.MaxTokensCapCleared() {
+		_spec.ClearField(apikey.FieldMaxTokensCap, field.TypeInt)
+	}
+	if value, ok := _u.mutation.RpmLimit(); ok {
+		_spec.SetField(apikey.FieldRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRpmLimit(); ok {
+		_spec.AddField(apikey.FieldRpmLimit, field.TypeInt, value)
+	}
+	if _u.mutation.RpmLimitCleared() {
+		_spec.ClearField(apikey.FieldRpmLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.TpmLimit(); ok {
+		_spec.SetField(apikey.FieldTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTpmLimit(); ok {
+		_spec.AddField(apikey.FieldTpmLimit, field.TypeInt, value)
+	}
+	if _u.mutation.TpmLimitCleared() {
+		_spec.ClearField(apikey.FieldTpmLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.OrganizationID(); ok {
+		_spec.SetField(apikey.FieldOrganizationID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedOrganizationID(); ok {
+		_spec.AddField(apikey.FieldOrganizationID, field.TypeInt64, value)
+	}
+	if _u.mutation.OrganizationIDCleared() {
+		_spec.ClearField(apikey.FieldOrganizationID, field.TypeInt64)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
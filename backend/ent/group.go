@@ -60,6 +60,28 @@ type Group struct {
 	ModelRouting map[string][]int64 `json:"model_routing,omitempty"`
 	// 是否启用模型路由配置
 	ModelRoutingEnabled bool `json:"model_routing_enabled,omitempty"`
+	// 分组内 API Key 默认每分钟请求数上限
+	DefaultRpmLimit *int `json:"default_rpm_limit,omitempty"`
+	// 分组内 API Key 默认每分钟 token 数上限
+	DefaultTpmLimit *int `json:"default_tpm_limit,omitempty"`
+	// 是否对确定性非流式请求启用响应缓存
+	ResponseCacheEnabled bool `json:"response_cache_enabled,omitempty"`
+	// 响应缓存有效期（秒），为空使用全局默认值
+	ResponseCacheTTLSeconds *int `json:"response_cache_ttl_seconds,omitempty"`
+	// 缓存命中计费比例：0 表示免费，1 表示原价
+	ResponseCacheRate float64 `json:"response_cache_rate,omitempty"`
+	// 模型降级链：模型模式 -> [{group_id, model}]
+	ModelFallbacks json.RawMessage `json:"model_fallbacks,omitempty"`
+	// 护栏配置：拒绝列表、PII 脱敏、最大提示词长度、系统提示词
+	Guardrails json.RawMessage `json:"guardrails,omitempty"`
+	// 调度策略：空为默认，lowest_cost/lowest_latency/least_outstanding/weighted_round_robin
+	SchedulingStrategy string `json:"scheduling_strategy,omitempty"`
+	// 加权轮询权重：账号ID -> 权重
+	SchedulingWeights map[int64]int `json:"scheduling_weights,omitempty"`
+	// 对冲延迟（毫秒）：首字节超过该时间未到达时向第二个账号发起相同请求，0 表示关闭
+	HedgeDelayMs int `json:"hedge_delay_ms,omitempty"`
+	// 订阅分组的公平排队权重：用户未单独设置权重时使用，0 表示默认权重 1
+	QueueWeight int `json:"queue_weight,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the GroupQuery when eager-loading is set.
 	Edges        GroupEdges `json:"edges"`
@@ -166,15 +188,15 @@ func (*Group) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case group.FieldModelRouting:
+		case group.FieldModelRouting, group.FieldModelFallbacks, group.FieldGuardrails, group.FieldSchedulingWeights:
 			values[i] = new([]byte)
-		case group.FieldIsExclusive, group.FieldClaudeCodeOnly, group.FieldModelRoutingEnabled:
+		case group.FieldIsExclusive, group.FieldClaudeCodeOnly, group.FieldModelRoutingEnabled, group.FieldResponseCacheEnabled:
 			values[i] = new(sql.NullBool)
-		case group.FieldRateMultiplier, group.FieldDailyLimitUsd, group.FieldWeeklyLimitUsd, group.FieldMonthlyLimitUsd, group.FieldImagePrice1k, group.FieldImagePrice2k, group.FieldImagePrice4k:
+		case group.FieldRateMultiplier, group.FieldDailyLimitUsd, group.FieldWeeklyLimitUsd, group.FieldMonthlyLimitUsd, group.FieldImagePrice1k, group.FieldImagePrice2k, group.FieldImagePrice4k, group.FieldResponseCacheRate:
 			values[i] = new(sql.NullFloat64)
-		case group.FieldID, group.FieldDefaultValidityDays, group.FieldFallbackGroupID:
+		case group.FieldID, group.FieldDefaultValidityDays, group.FieldFallbackGroupID, group.FieldDefaultRpmLimit, group.FieldDefaultTpmLimit, group.FieldResponseCacheTTLSeconds, group.FieldHedgeDelayMs, group.FieldQueueWeight:
 			values[i] = new(sql.NullInt64)
-		case group.FieldName, group.FieldDescription, group.FieldStatus, group.FieldPlatform, group.FieldSubscriptionType:
+		case group.FieldName, group.FieldDescription, group.FieldStatus, group.FieldPlatform, group.FieldSubscriptionType, group.FieldSchedulingStrategy:
 			values[i] = new(sql.NullString)
 		case group.FieldCreatedAt, group.FieldUpdatedAt, group.FieldDeletedAt:
 			values[i] = new(sql.NullTime)
@@ -336,6 +358,81 @@ func (_m *Group) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.ModelRoutingEnabled = value.Bool
 			}
+		case group.FieldDefaultRpmLimit:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field default_rpm_limit", values[i])
+			} else if value.Valid {
+				_m.DefaultRpmLimit = new(int)
+				*_m.DefaultRpmLimit = int(value.Int64)
+			}
+		case group.FieldDefaultTpmLimit:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field default_tpm_limit", values[i])
+			} else if value.Valid {
+				_m.DefaultTpmLimit = new(int)
+				*_m.DefaultTpmLimit = int(value.Int64)
+			}
+		case group.FieldResponseCacheEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field response_cache_enabled", values[i])
+			} else if value.Valid {
+				_m.ResponseCacheEnabled = value.Bool
+			}
+		case group.FieldResponseCacheTTLSeconds:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field response_cache_ttl_seconds", values[i])
+			} else if value.Valid {
+				_m.ResponseCacheTTLSeconds = new(int)
+				*_m.ResponseCacheTTLSeconds = int(value.Int64)
+			}
+		case group.FieldResponseCacheRate:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field response_cache_rate", values[i])
+			} else if value.Valid {
+				_m.ResponseCacheRate = value.Float64
+			}
+		case group.FieldModelFallbacks:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field model_fallbacks", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.ModelFallbacks); err != nil {
+					return fmt.Errorf("unmarshal field model_fallbacks: %w", err)
+				}
+			}
+		case group.FieldGuardrails:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field guardrails", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Guardrails); err != nil {
+					return fmt.Errorf("unmarshal field guardrails: %w", err)
+				}
+			}
+		case group.FieldSchedulingStrategy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field scheduling_strategy", values[i])
+			} else if value.Valid {
+				_m.SchedulingStrategy = value.String
+			}
+		case group.FieldSchedulingWeights:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field scheduling_weights", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.SchedulingWeights); err != nil {
+					return fmt.Errorf("unmarshal field scheduling_weights: %w", err)
+				}
+			}
+		case group.FieldHedgeDelayMs:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field hedge_delay_ms", values[i])
+			} else if value.Valid {
+				_m.HedgeDelayMs = int(value.Int64)
+			}
+		case group.FieldQueueWeight:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field queue_weight", values[i])
+			} else if value.Valid {
+				_m.QueueWeight = int(value.Int64)
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -492,6 +589,45 @@ func (_m *Group) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("model_routing_enabled=")
 	builder.WriteString(fmt.Sprintf("%v", _m.ModelRoutingEnabled))
+	builder.WriteString(", ")
+	if v := _m.DefaultRpmLimit; v != nil {
+		builder.WriteString("default_rpm_limit=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.DefaultTpmLimit; v != nil {
+		builder.WriteString("default_tpm_limit=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("response_cache_enabled=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ResponseCacheEnabled))
+	builder.WriteString(", ")
+	if v := _m.ResponseCacheTTLSeconds; v != nil {
+		builder.WriteString("response_cache_ttl_seconds=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("response_cache_rate=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ResponseCacheRate))
+	builder.WriteString(", ")
+	builder.WriteString("model_fallbacks=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ModelFallbacks))
+	builder.WriteString(", ")
+	builder.WriteString("guardrails=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Guardrails))
+	builder.WriteString(", ")
+	builder.WriteString("scheduling_strategy=")
+	builder.WriteString(_m.SchedulingStrategy)
+	builder.WriteString(", ")
+	builder.WriteString("scheduling_weights=")
+	builder.WriteString(fmt.Sprintf("%v", _m.SchedulingWeights))
+	builder.WriteString(", ")
+	builder.WriteString("hedge_delay_ms=")
+	builder.WriteString(fmt.Sprintf("%v", _m.HedgeDelayMs))
+	builder.WriteString(", ")
+	builder.WriteString("queue_weight=")
+	builder.WriteString(fmt.Sprintf("%v", _m.QueueWeight))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -57,6 +57,28 @@ const (
 	FieldModelRouting = "model_routing"
 	// FieldModelRoutingEnabled holds the string denoting the model_routing_enabled field in the database.
 	FieldModelRoutingEnabled = "model_routing_enabled"
+	// FieldDefaultRpmLimit holds the string denoting the default_rpm_limit field in the database.
+	FieldDefaultRpmLimit = "default_rpm_limit"
+	// FieldDefaultTpmLimit holds the string denoting the default_tpm_limit field in the database.
+	FieldDefaultTpmLimit = "default_tpm_limit"
+	// FieldResponseCacheEnabled holds the string denoting the response_cache_enabled field in the database.
+	FieldResponseCacheEnabled = "response_cache_enabled"
+	// FieldResponseCacheTTLSeconds holds the string denoting the response_cache_ttl_seconds field in the database.
+	FieldResponseCacheTTLSeconds = "response_cache_ttl_seconds"
+	// FieldResponseCacheRate holds the string denoting the response_cache_rate field in the database.
+	FieldResponseCacheRate = "response_cache_rate"
+	// FieldModelFallbacks holds the string denoting the model_fallbacks field in the database.
+	FieldModelFallbacks = "model_fallbacks"
+	// FieldGuardrails holds the string denoting the guardrails field in the database.
+	FieldGuardrails = "guardrails"
+	// FieldSchedulingStrategy holds the string denoting the scheduling_strategy field in the database.
+	FieldSchedulingStrategy = "scheduling_strategy"
+	// FieldSchedulingWeights holds the string denoting the scheduling_weights field in the database.
+	FieldSchedulingWeights = "scheduling_weights"
+	// FieldHedgeDelayMs holds the string denoting the hedge_delay_ms field in the database.
+	FieldHedgeDelayMs = "hedge_delay_ms"
+	// FieldQueueWeight holds the string denoting the queue_weight field in the database.
+	FieldQueueWeight = "queue_weight"
 	// EdgeAPIKeys holds the string denoting the api_keys edge name in mutations.
 	EdgeAPIKeys = "api_keys"
 	// EdgeRedeemCodes holds the string denoting the redeem_codes edge name in mutations.
@@ -153,6 +175,17 @@ var Columns = []string{
 	FieldFallbackGroupID,
 	FieldModelRouting,
 	FieldModelRoutingEnabled,
+	FieldDefaultRpmLimit,
+	FieldDefaultTpmLimit,
+	FieldResponseCacheEnabled,
+	FieldResponseCacheTTLSeconds,
+	FieldResponseCacheRate,
+	FieldModelFallbacks,
+	FieldGuardrails,
+	FieldSchedulingStrategy,
+	FieldSchedulingWeights,
+	FieldHedgeDelayMs,
+	FieldQueueWeight,
 }
 
 var (
@@ -212,6 +245,18 @@ var (
 	DefaultClaudeCodeOnly bool
 	// DefaultModelRoutingEnabled holds the default value on creation for the "model_routing_enabled" field.
 	DefaultModelRoutingEnabled bool
+	// DefaultResponseCacheEnabled holds the default value on creation for the "response_cache_enabled" field.
+	DefaultResponseCacheEnabled bool
+	// DefaultResponseCacheRate holds the default value on creation for the "response_cache_rate" field.
+	DefaultResponseCacheRate float64
+	// DefaultSchedulingStrategy holds the default value on creation for the "scheduling_strategy" field.
+	DefaultSchedulingStrategy string
+	// SchedulingStrategyValidator is a validator for the "scheduling_strategy" field. It is called by the builders before save.
+	SchedulingStrategyValidator func(string) error
+	// DefaultHedgeDelayMs holds the default value on creation for the "hedge_delay_ms" field.
+	DefaultHedgeDelayMs int
+	// DefaultQueueWeight holds the default value on creation for the "queue_weight" field.
+	DefaultQueueWeight int
 )
 
 // OrderOption defines the ordering options for the Group queries.
@@ -322,6 +367,46 @@ func ByModelRoutingEnabled(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldModelRoutingEnabled, opts...).ToFunc()
 }
 
+// ByDefaultRpmLimit orders the results by the default_rpm_limit field.
+func ByDefaultRpmLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDefaultRpmLimit, opts...).ToFunc()
+}
+
+// ByDefaultTpmLimit orders the results by the default_tpm_limit field.
+func ByDefaultTpmLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDefaultTpmLimit, opts...).ToFunc()
+}
+
+// ByResponseCacheEnabled orders the results by the response_cache_enabled field.
+func ByResponseCacheEnabled(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResponseCacheEnabled, opts...).ToFunc()
+}
+
+// ByResponseCacheTTLSeconds orders the results by the response_cache_ttl_seconds field.
+func ByResponseCacheTTLSeconds(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResponseCacheTTLSeconds, opts...).ToFunc()
+}
+
+// ByResponseCacheRate orders the results by the response_cache_rate field.
+func ByResponseCacheRate(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResponseCacheRate, opts...).ToFunc()
+}
+
+// BySchedulingStrategy orders the results by the scheduling_strategy field.
+func BySchedulingStrategy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSchedulingStrategy, opts...).ToFunc()
+}
+
+// ByHedgeDelayMs orders the results by the hedge_delay_ms field.
+func ByHedgeDelayMs(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHedgeDelayMs, opts...).ToFunc()
+}
+
+// ByQueueWeight orders the results by the queue_weight field.
+func ByQueueWeight(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldQueueWeight, opts...).ToFunc()
+}
+
 // ByAPIKeysCount orders the results by api_keys count.
 func ByAPIKeysCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -155,6 +155,46 @@ func ModelRoutingEnabled(v bool) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldModelRoutingEnabled, v))
 }
 
+// DefaultRpmLimit applies equality check predicate on the "default_rpm_limit" field. It's identical to DefaultRpmLimitEQ.
+func DefaultRpmLimit(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultRpmLimit, v))
+}
+
+// DefaultTpmLimit applies equality check predicate on the "default_tpm_limit" field. It's identical to DefaultTpmLimitEQ.
+func DefaultTpmLimit(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultTpmLimit, v))
+}
+
+// ResponseCacheEnabled applies equality check predicate on the "response_cache_enabled" field. It's identical to ResponseCacheEnabledEQ.
+func ResponseCacheEnabled(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheEnabled, v))
+}
+
+// ResponseCacheTTLSeconds applies equality check predicate on the "response_cache_ttl_seconds" field. It's identical to ResponseCacheTTLSecondsEQ.
+func ResponseCacheTTLSeconds(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheRate applies equality check predicate on the "response_cache_rate" field. It's identical to ResponseCacheRateEQ.
+func ResponseCacheRate(v float64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheRate, v))
+}
+
+// SchedulingStrategy applies equality check predicate on the "scheduling_strategy" field. It's identical to SchedulingStrategyEQ.
+func SchedulingStrategy(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldSchedulingStrategy, v))
+}
+
+// HedgeDelayMs applies equality check predicate on the "hedge_delay_ms" field. It's identical to HedgeDelayMsEQ.
+func HedgeDelayMs(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldHedgeDelayMs, v))
+}
+
+// QueueWeight applies equality check predicate on the "queue_weight" field. It's identical to QueueWeightEQ.
+func QueueWeight(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldQueueWeight, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldCreatedAt, v))
@@ -1090,6 +1130,381 @@ func ModelRoutingEnabledNEQ(v bool) predicate.Group {
 	return predicate.Group(sql.FieldNEQ(FieldModelRoutingEnabled, v))
 }
 
+// DefaultRpmLimitEQ applies the EQ predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitNEQ applies the NEQ predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitIn applies the In predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldDefaultRpmLimit, vs...))
+}
+
+// DefaultRpmLimitNotIn applies the NotIn predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldDefaultRpmLimit, vs...))
+}
+
+// DefaultRpmLimitGT applies the GT predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitGTE applies the GTE predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitLT applies the LT predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitLTE applies the LTE predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitIsNil applies the IsNil predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldDefaultRpmLimit))
+}
+
+// DefaultRpmLimitNotNil applies the NotNil predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldDefaultRpmLimit))
+}
+
+// DefaultTpmLimitEQ applies the EQ predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitNEQ applies the NEQ predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitIn applies the In predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldDefaultTpmLimit, vs...))
+}
+
+// DefaultTpmLimitNotIn applies the NotIn predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldDefaultTpmLimit, vs...))
+}
+
+// DefaultTpmLimitGT applies the GT predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitGTE applies the GTE predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitLT applies the LT predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitLTE applies the LTE predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitIsNil applies the IsNil predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldDefaultTpmLimit))
+}
+
+// DefaultTpmLimitNotNil applies the NotNil predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldDefaultTpmLimit))
+}
+
+// ResponseCacheEnabledEQ applies the EQ predicate on the "response_cache_enabled" field.
+func ResponseCacheEnabledEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheEnabled, v))
+}
+
+// ResponseCacheEnabledNEQ applies the NEQ predicate on the "response_cache_enabled" field.
+func ResponseCacheEnabledNEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldResponseCacheEnabled, v))
+}
+
+// ResponseCacheTTLSecondsEQ applies the EQ predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsNEQ applies the NEQ predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsIn applies the In predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldResponseCacheTTLSeconds, vs...))
+}
+
+// ResponseCacheTTLSecondsNotIn applies the NotIn predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldResponseCacheTTLSeconds, vs...))
+}
+
+// ResponseCacheTTLSecondsGT applies the GT predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsGTE applies the GTE predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsLT applies the LT predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsLTE applies the LTE predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsIsNil applies the IsNil predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldResponseCacheTTLSeconds))
+}
+
+// ResponseCacheTTLSecondsNotNil applies the NotNil predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldResponseCacheTTLSeconds))
+}
+
+// ResponseCacheRateEQ applies the EQ predicate on the "response_cache_rate" field.
+func ResponseCacheRateEQ(v float64) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheRate, v))
+}
+
+// ResponseCacheRateNEQ applies the NEQ predicate on the "response_cache_rate" field.
+func ResponseCacheRateNEQ(v float64) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldResponseCacheRate, v))
+}
+
+// ResponseCacheRateIn applies the In predicate on the "response_cache_rate" field.
+func ResponseCacheRateIn(vs ...float64) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldResponseCacheRate, vs...))
+}
+
+// ResponseCacheRateNotIn applies the NotIn predicate on the "response_cache_rate" field.
+func ResponseCacheRateNotIn(vs ...float64) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldResponseCacheRate, vs...))
+}
+
+// ResponseCacheRateGT applies the GT predicate on the "response_cache_rate" field.
+func ResponseCacheRateGT(v float64) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldResponseCacheRate, v))
+}
+
+// ResponseCacheRateGTE applies the GTE predicate on the "response_cache_rate" field.
+func ResponseCacheRateGTE(v float64) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldResponseCacheRate, v))
+}
+
+// ResponseCacheRateLT applies the LT predicate on the "response_cache_rate" field.
+func ResponseCacheRateLT(v float64) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldResponseCacheRate, v))
+}
+
+// ResponseCacheRateLTE applies the LTE predicate on the "response_cache_rate" field.
+func ResponseCacheRateLTE(v float64) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldResponseCacheRate, v))
+}
+
+// ModelFallbacksIsNil applies the IsNil predicate on the "model_fallbacks" field.
+func ModelFallbacksIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldModelFallbacks))
+}
+
+// ModelFallbacksNotNil applies the NotNil predicate on the "model_fallbacks" field.
+func ModelFallbacksNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldModelFallbacks))
+}
+
+// GuardrailsIsNil applies the IsNil predicate on the "guardrails" field.
+func GuardrailsIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldGuardrails))
+}
+
+// GuardrailsNotNil applies the NotNil predicate on the "guardrails" field.
+func GuardrailsNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldGuardrails))
+}
+
+// SchedulingStrategyEQ applies the EQ predicate on the "scheduling_strategy" field.
+func SchedulingStrategyEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyNEQ applies the NEQ predicate on the "scheduling_strategy" field.
+func SchedulingStrategyNEQ(v string) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyIn applies the In predicate on the "scheduling_strategy" field.
+func SchedulingStrategyIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldSchedulingStrategy, vs...))
+}
+
+// SchedulingStrategyNotIn applies the NotIn predicate on the "scheduling_strategy" field.
+func SchedulingStrategyNotIn(vs ...string) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldSchedulingStrategy, vs...))
+}
+
+// SchedulingStrategyGT applies the GT predicate on the "scheduling_strategy" field.
+func SchedulingStrategyGT(v string) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyGTE applies the GTE predicate on the "scheduling_strategy" field.
+func SchedulingStrategyGTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyLT applies the LT predicate on the "scheduling_strategy" field.
+func SchedulingStrategyLT(v string) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyLTE applies the LTE predicate on the "scheduling_strategy" field.
+func SchedulingStrategyLTE(v string) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyContains applies the Contains predicate on the "scheduling_strategy" field.
+func SchedulingStrategyContains(v string) predicate.Group {
+	return predicate.Group(sql.FieldContains(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyHasPrefix applies the HasPrefix predicate on the "scheduling_strategy" field.
+func SchedulingStrategyHasPrefix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasPrefix(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyHasSuffix applies the HasSuffix predicate on the "scheduling_strategy" field.
+func SchedulingStrategyHasSuffix(v string) predicate.Group {
+	return predicate.Group(sql.FieldHasSuffix(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyEqualFold applies the EqualFold predicate on the "scheduling_strategy" field.
+func SchedulingStrategyEqualFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldEqualFold(FieldSchedulingStrategy, v))
+}
+
+// SchedulingStrategyContainsFold applies the ContainsFold predicate on the "scheduling_strategy" field.
+func SchedulingStrategyContainsFold(v string) predicate.Group {
+	return predicate.Group(sql.FieldContainsFold(FieldSchedulingStrategy, v))
+}
+
+// SchedulingWeightsIsNil applies the IsNil predicate on the "scheduling_weights" field.
+func SchedulingWeightsIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldSchedulingWeights))
+}
+
+// SchedulingWeightsNotNil applies the NotNil predicate on the "scheduling_weights" field.
+func SchedulingWeightsNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldSchedulingWeights))
+}
+
+// HedgeDelayMsEQ applies the EQ predicate on the "hedge_delay_ms" field.
+func HedgeDelayMsEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldHedgeDelayMs, v))
+}
+
+// HedgeDelayMsNEQ applies the NEQ predicate on the "hedge_delay_ms" field.
+func HedgeDelayMsNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldHedgeDelayMs, v))
+}
+
+// HedgeDelayMsIn applies the In predicate on the "hedge_delay_ms" field.
+func HedgeDelayMsIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldHedgeDelayMs, vs...))
+}
+
+// HedgeDelayMsNotIn applies the NotIn predicate on the "hedge_delay_ms" field.
+func HedgeDelayMsNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldHedgeDelayMs, vs...))
+}
+
+// HedgeDelayMsGT applies the GT predicate on the "hedge_delay_ms" field.
+func HedgeDelayMsGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldHedgeDelayMs, v))
+}
+
+// HedgeDelayMsGTE applies the GTE predicate on the "hedge_delay_ms" field.
+func HedgeDelayMsGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldHedgeDelayMs, v))
+}
+
+// HedgeDelayMsLT applies the LT predicate on the "hedge_delay_ms" field.
+func HedgeDelayMsLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldHedgeDelayMs, v))
+}
+
+// HedgeDelayMsLTE applies the LTE predicate on the "hedge_delay_ms" field.
+func HedgeDelayMsLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldHedgeDelayMs, v))
+}
+
+// QueueWeightEQ applies the EQ predicate on the "queue_weight" field.
+func QueueWeightEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldQueueWeight, v))
+}
+
+// QueueWeightNEQ applies the NEQ predicate on the "queue_weight" field.
+func QueueWeightNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldQueueWeight, v))
+}
+
+// QueueWeightIn applies the In predicate on the "queue_weight" field.
+func QueueWeightIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldQueueWeight, vs...))
+}
+
+// QueueWeightNotIn applies the NotIn predicate on the "queue_weight" field.
+func QueueWeightNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldQueueWeight, vs...))
+}
+
+// QueueWeightGT applies the GT predicate on the "queue_weight" field.
+func QueueWeightGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldQueueWeight, v))
+}
+
+// QueueWeightGTE applies the GTE predicate on the "queue_weight" field.
+func QueueWeightGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldQueueWeight, v))
+}
+
+// QueueWeightLT applies the LT predicate on the "queue_weight" field.
+func QueueWeightLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldQueueWeight, v))
+}
+
+// QueueWeightLTE applies the LTE predicate on the "queue_weight" field.
+func QueueWeightLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldQueueWeight, v))
+}
+
 // HasAPIKeys applies the HasEdge predicate on the "api_keys" edge.
 func HasAPIKeys() predicate.Group {
 	return predicate.Group(func(s *sql.Selector) {
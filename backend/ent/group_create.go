@@ -4,6 +4,7 @@ package ent
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -306,6 +307,136 @@ func (_c *GroupCreate) SetNillableModelRoutingEnabled(v *bool) *GroupCreate {
 	return _c
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (_c *GroupCreate) SetDefaultRpmLimit(v int) *GroupCreate {
+	_c.mutation.SetDefaultRpmLimit(v)
+	return _c
+}
+
+// SetNillableDefaultRpmLimit sets the "default_rpm_limit" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableDefaultRpmLimit(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetDefaultRpmLimit(*v)
+	}
+	return _c
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (_c *GroupCreate) SetDefaultTpmLimit(v int) *GroupCreate {
+	_c.mutation.SetDefaultTpmLimit(v)
+	return _c
+}
+
+// SetNillableDefaultTpmLimit sets the "default_tpm_limit" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableDefaultTpmLimit(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetDefaultTpmLimit(*v)
+	}
+	return _c
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (_c *GroupCreate) SetResponseCacheEnabled(v bool) *GroupCreate {
+	_c.mutation.SetResponseCacheEnabled(v)
+	return _c
+}
+
+// SetNillableResponseCacheEnabled sets the "response_cache_enabled" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableResponseCacheEnabled(v *bool) *GroupCreate {
+	if v != nil {
+		_c.SetResponseCacheEnabled(*v)
+	}
+	return _c
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (_c *GroupCreate) SetResponseCacheTTLSeconds(v int) *GroupCreate {
+	_c.mutation.SetResponseCacheTTLSeconds(v)
+	return _c
+}
+
+// SetNillableResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableResponseCacheTTLSeconds(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetResponseCacheTTLSeconds(*v)
+	}
+	return _c
+}
+
+// SetResponseCacheRate sets the "response_cache_rate" field.
+func (_c *GroupCreate) SetResponseCacheRate(v float64) *GroupCreate {
+	_c.mutation.SetResponseCacheRate(v)
+	return _c
+}
+
+// SetNillableResponseCacheRate sets the "response_cache_rate" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableResponseCacheRate(v *float64) *GroupCreate {
+	if v != nil {
+		_c.SetResponseCacheRate(*v)
+	}
+	return _c
+}
+
+// SetModelFallbacks sets the "model_fallbacks" field.
+func (_c *GroupCreate) SetModelFallbacks(v json.RawMessage) *GroupCreate {
+	_c.mutation.SetModelFallbacks(v)
+	return _c
+}
+
+// SetGuardrails sets the "guardrails" field.
+func (_c *GroupCreate) SetGuardrails(v json.RawMessage) *GroupCreate {
+	_c.mutation.SetGuardrails(v)
+	return _c
+}
+
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (_c *GroupCreate) SetSchedulingStrategy(v string) *GroupCreate {
+	_c.mutation.SetSchedulingStrategy(v)
+	return _c
+}
+
+// SetNillableSchedulingStrategy sets the "scheduling_strategy" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableSchedulingStrategy(v *string) *GroupCreate {
+	if v != nil {
+		_c.SetSchedulingStrategy(*v)
+	}
+	return _c
+}
+
+// SetSchedulingWeights sets the "scheduling_weights" field.
+func (_c *GroupCreate) SetSchedulingWeights(v map[int64]int) *GroupCreate {
+	_c.mutation.SetSchedulingWeights(v)
+	return _c
+}
+
+// SetHedgeDelayMs sets the "hedge_delay_ms" field.
+func (_c *GroupCreate) SetHedgeDelayMs(v int) *GroupCreate {
+	_c.mutation.SetHedgeDelayMs(v)
+	return _c
+}
+
+// SetNillableHedgeDelayMs sets the "hedge_delay_ms" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableHedgeDelayMs(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetHedgeDelayMs(*v)
+	}
+	return _c
+}
+
+// SetQueueWeight sets the "queue_weight" field.
+func (_c *GroupCreate) SetQueueWeight(v int) *GroupCreate {
+	_c.mutation.SetQueueWeight(v)
+	return _c
+}
+
+// SetNillableQueueWeight sets the "queue_weight" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableQueueWeight(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetQueueWeight(*v)
+	}
+	return _c
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_c *GroupCreate) AddAPIKeyIDs(ids ...int64) *GroupCreate {
 	_c.mutation.AddAPIKeyIDs(ids...)
@@ -479,6 +610,26 @@ func (_c *GroupCreate) defaults() error {
 		v := group.DefaultModelRoutingEnabled
 		_c.mutation.SetModelRoutingEnabled(v)
 	}
+	if _, ok := _c.mutation.ResponseCacheEnabled(); !ok {
+		v := group.DefaultResponseCacheEnabled
+		_c.mutation.SetResponseCacheEnabled(v)
+	}
+	if _, ok := _c.mutation.ResponseCacheRate(); !ok {
+		v := group.DefaultResponseCacheRate
+		_c.mutation.SetResponseCacheRate(v)
+	}
+	if _, ok := _c.mutation.SchedulingStrategy(); !ok {
+		v := group.DefaultSchedulingStrategy
+		_c.mutation.SetSchedulingStrategy(v)
+	}
+	if _, ok := _c.mutation.HedgeDelayMs(); !ok {
+		v := group.DefaultHedgeDelayMs
+		_c.mutation.SetHedgeDelayMs(v)
+	}
+	if _, ok := _c.mutation.QueueWeight(); !ok {
+		v := group.DefaultQueueWeight
+		_c.mutation.SetQueueWeight(v)
+	}
 	return nil
 }
 
@@ -537,6 +688,26 @@ func (_c *GroupCreate) check() error {
 	if _, ok := _c.mutation.ModelRoutingEnabled(); !ok {
 		return &ValidationError{Name: "model_routing_enabled", err: errors.New(`ent: missing required field "Group.model_routing_enabled"`)}
 	}
+	if _, ok := _c.mutation.ResponseCacheEnabled(); !ok {
+		return &ValidationError{Name: "response_cache_enabled", err: errors.New(`ent: missing required field "Group.response_cache_enabled"`)}
+	}
+	if _, ok := _c.mutation.ResponseCacheRate(); !ok {
+		return &ValidationError{Name: "response_cache_rate", err: errors.New(`ent: missing required field "Group.response_cache_rate"`)}
+	}
+	if _, ok := _c.mutation.SchedulingStrategy(); !ok {
+		return &ValidationError{Name: "scheduling_strategy", err: errors.New(`ent: missing required field "Group.scheduling_strategy"`)}
+	}
+	if v, ok := _c.mutation.SchedulingStrategy(); ok {
+		if err := group.SchedulingStrategyValidator(v); err != nil {
+			return &ValidationError{Name: "scheduling_strategy", err: fmt.Errorf(`ent: validator failed for field "Group.scheduling_strategy": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.HedgeDelayMs(); !ok {
+		return &ValidationError{Name: "hedge_delay_ms", err: errors.New(`ent: missing required field "Group.hedge_delay_ms"`)}
+	}
+	if _, ok := _c.mutation.QueueWeight(); !ok {
+		return &ValidationError{Name: "queue_weight", err: errors.New(`ent: missing required field "Group.queue_weight"`)}
+	}
 	return nil
 }
 
@@ -648,6 +819,50 @@ func (_c *GroupCreate) createSpec() (*Group, *sqlgraph.CreateSpec) {
 		_spec.SetField(group.FieldModelRoutingEnabled, field.TypeBool, value)
 		_node.ModelRoutingEnabled = value
 	}
+	if value, ok := _c.mutation.DefaultRpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+		_node.DefaultRpmLimit = &value
+	}
+	if value, ok := _c.mutation.DefaultTpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+		_node.DefaultTpmLimit = &value
+	}
+	if value, ok := _c.mutation.ResponseCacheEnabled(); ok {
+		_spec.SetField(group.FieldResponseCacheEnabled, field.TypeBool, value)
+		_node.ResponseCacheEnabled = value
+	}
+	if value, ok := _c.mutation.ResponseCacheTTLSeconds(); ok {
+		_spec.SetField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+		_node.ResponseCacheTTLSeconds = &value
+	}
+	if value, ok := _c.mutation.ResponseCacheRate(); ok {
+		_spec.SetField(group.FieldResponseCacheRate, field.TypeFloat64, value)
+		_node.ResponseCacheRate = value
+	}
+	if value, ok := _c.mutation.ModelFallbacks(); ok {
+		_spec.SetField(group.FieldModelFallbacks, field.TypeJSON, value)
+		_node.ModelFallbacks = value
+	}
+	if value, ok := _c.mutation.Guardrails(); ok {
+		_spec.SetField(group.FieldGuardrails, field.TypeJSON, value)
+		_node.Guardrails = value
+	}
+	if value, ok := _c.mutation.SchedulingStrategy(); ok {
+		_spec.SetField(group.FieldSchedulingStrategy, field.TypeString, value)
+		_node.SchedulingStrategy = value
+	}
+	if value, ok := _c.mutation.SchedulingWeights(); ok {
+		_spec.SetField(group.FieldSchedulingWeights, field.TypeJSON, value)
+		_node.SchedulingWeights = value
+	}
+	if value, ok := _c.mutation.HedgeDelayMs(); ok {
+		_spec.SetField(group.FieldHedgeDelayMs, field.TypeInt, value)
+		_node.HedgeDelayMs = value
+	}
+	if value, ok := _c.mutation.QueueWeight(); ok {
+		_spec.SetField(group.FieldQueueWeight, field.TypeInt, value)
+		_node.QueueWeight = value
+	}
 	if nodes := _c.mutation.APIKeysIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1158,6 +1373,210 @@ func (u *GroupUpsert) UpdateModelRoutingEnabled() *GroupUpsert {
 	return u
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (u *GroupUpsert) SetDefaultRpmLimit(v int) *GroupUpsert {
+	u.Set(group.FieldDefaultRpmLimit, v)
+	return u
+}
+
+// UpdateDefaultRpmLimit sets the "default_rpm_limit" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateDefaultRpmLimit() *GroupUpsert {
+	u.SetExcluded(group.FieldDefaultRpmLimit)
+	return u
+}
+
+// AddDefaultRpmLimit adds v to the "default_rpm_limit" field.
+func (u *GroupUpsert) AddDefaultRpmLimit(v int) *GroupUpsert {
+	u.Add(group.FieldDefaultRpmLimit, v)
+	return u
+}
+
+// ClearDefaultRpmLimit clears the value of the "default_rpm_limit" field.
+func (u *GroupUpsert) ClearDefaultRpmLimit() *GroupUpsert {
+	u.SetNull(group.FieldDefaultRpmLimit)
+	return u
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (u *GroupUpsert) SetDefaultTpmLimit(v int) *GroupUpsert {
+	u.Set(group.FieldDefaultTpmLimit, v)
+	return u
+}
+
+// UpdateDefaultTpmLimit sets the "default_tpm_limit" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateDefaultTpmLimit() *GroupUpsert {
+	u.SetExcluded(group.FieldDefaultTpmLimit)
+	return u
+}
+
+// AddDefaultTpmLimit adds v to the "default_tpm_limit" field.
+func (u *GroupUpsert) AddDefaultTpmLimit(v int) *GroupUpsert {
+	u.Add(group.FieldDefaultTpmLimit, v)
+	return u
+}
+
+// ClearDefaultTpmLimit clears the value of the "default_tpm_limit" field.
+func (u *GroupUpsert) ClearDefaultTpmLimit() *GroupUpsert {
+	u.SetNull(group.FieldDefaultTpmLimit)
+	return u
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (u *GroupUpsert) SetResponseCacheEnabled(v bool) *GroupUpsert {
+	u.Set(group.FieldResponseCacheEnabled, v)
+	return u
+}
+
+// UpdateResponseCacheEnabled sets the "response_cache_enabled" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateResponseCacheEnabled() *GroupUpsert {
+	u.SetExcluded(group.FieldResponseCacheEnabled)
+	return u
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (u *GroupUpsert) SetResponseCacheTTLSeconds(v int) *GroupUpsert {
+	u.Set(group.FieldResponseCacheTTLSeconds, v)
+	return u
+}
+
+// UpdateResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateResponseCacheTTLSeconds() *GroupUpsert {
+	u.SetExcluded(group.FieldResponseCacheTTLSeconds)
+	return u
+}
+
+// AddResponseCacheTTLSeconds adds v to the "response_cache_ttl_seconds" field.
+func (u *GroupUpsert) AddResponseCacheTTLSeconds(v int) *GroupUpsert {
+	u.Add(group.FieldResponseCacheTTLSeconds, v)
+	return u
+}
+
+// ClearResponseCacheTTLSeconds clears the value of the "response_cache_ttl_seconds" field.
+func (u *GroupUpsert) ClearResponseCacheTTLSeconds() *GroupUpsert {
+	u.SetNull(group.FieldResponseCacheTTLSeconds)
+	return u
+}
+
+// SetResponseCacheRate sets the "response_cache_rate" field.
+func (u *GroupUpsert) SetResponseCacheRate(v float64) *GroupUpsert {
+	u.Set(group.FieldResponseCacheRate, v)
+	return u
+}
+
+// UpdateResponseCacheRate sets the "response_cache_rate" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateResponseCacheRate() *GroupUpsert {
+	u.SetExcluded(group.FieldResponseCacheRate)
+	return u
+}
+
+// AddResponseCacheRate adds v to the "response_cache_rate" field.
+func (u *GroupUpsert) AddResponseCacheRate(v float64) *GroupUpsert {
+	u.Add(group.FieldResponseCacheRate, v)
+	return u
+}
+
+// SetModelFallbacks sets the "model_fallbacks" field.
+func (u *GroupUpsert) SetModelFallbacks(v json.RawMessage) *GroupUpsert {
+	u.Set(group.FieldModelFallbacks, v)
+	return u
+}
+
+// UpdateModelFallbacks sets the "model_fallbacks" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateModelFallbacks() *GroupUpsert {
+	u.SetExcluded(group.FieldModelFallbacks)
+	return u
+}
+
+// ClearModelFallbacks clears the value of the "model_fallbacks" field.
+func (u *GroupUpsert) ClearModelFallbacks() *GroupUpsert {
+	u.SetNull(group.FieldModelFallbacks)
+	return u
+}
+
+// SetGuardrails sets the "guardrails" field.
+func (u *GroupUpsert) SetGuardrails(v json.RawMessage) *GroupUpsert {
+	u.Set(group.FieldGuardrails, v)
+	return u
+}
+
+// UpdateGuardrails sets the "guardrails" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateGuardrails() *GroupUpsert {
+	u.SetExcluded(group.FieldGuardrails)
+	return u
+}
+
+// ClearGuardrails clears the value of the "guardrails" field.
+func (u *GroupUpsert) ClearGuardrails() *GroupUpsert {
+	u.SetNull(group.FieldGuardrails)
+	return u
+}
+
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (u *GroupUpsert) SetSchedulingStrategy(v string) *GroupUpsert {
+	u.Set(group.FieldSchedulingStrategy, v)
+	return u
+}
+
+// UpdateSchedulingStrategy sets the "scheduling_strategy" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateSchedulingStrategy() *GroupUpsert {
+	u.SetExcluded(group.FieldSchedulingStrategy)
+	return u
+}
+
+// SetSchedulingWeights sets the "scheduling_weights" field.
+func (u *GroupUpsert) SetSchedulingWeights(v map[int64]int) *GroupUpsert {
+	u.Set(group.FieldSchedulingWeights, v)
+	return u
+}
+
+// UpdateSchedulingWeights sets the "scheduling_weights" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateSchedulingWeights() *GroupUpsert {
+	u.SetExcluded(group.FieldSchedulingWeights)
+	return u
+}
+
+// ClearSchedulingWeights clears the value of the "scheduling_weights" field.
+func (u *GroupUpsert) ClearSchedulingWeights() *GroupUpsert {
+	u.SetNull(group.FieldSchedulingWeights)
+	return u
+}
+
+// SetHedgeDelayMs sets the "hedge_delay_ms" field.
+func (u *GroupUpsert) SetHedgeDelayMs(v int) *GroupUpsert {
+	u.Set(group.FieldHedgeDelayMs, v)
+	return u
+}
+
+// UpdateHedgeDelayMs sets the "hedge_delay_ms" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateHedgeDelayMs() *GroupUpsert {
+	u.SetExcluded(group.FieldHedgeDelayMs)
+	return u
+}
+
+// AddHedgeDelayMs adds v to the "hedge_delay_ms" field.
+func (u *GroupUpsert) AddHedgeDelayMs(v int) *GroupUpsert {
+	u.Add(group.FieldHedgeDelayMs, v)
+	return u
+}
+
+// SetQueueWeight sets the "queue_weight" field.
+func (u *GroupUpsert) SetQueueWeight(v int) *GroupUpsert {
+	u.Set(group.FieldQueueWeight, v)
+	return u
+}
+
+// UpdateQueueWeight sets the "queue_weight" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateQueueWeight() *GroupUpsert {
+	u.SetExcluded(group.FieldQueueWeight)
+	return u
+}
+
+// AddQueueWeight adds v to the "queue_weight" field.
+func (u *GroupUpsert) AddQueueWeight(v int) *GroupUpsert {
+	u.Add(group.FieldQueueWeight, v)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -1616,6 +2035,244 @@ func (u *GroupUpsertOne) UpdateModelRoutingEnabled() *GroupUpsertOne {
 	})
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (u *GroupUpsertOne) SetDefaultRpmLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultRpmLimit(v)
+	})
+}
+
+// AddDefaultRpmLimit adds v to the "default_rpm_limit" field.
+func (u *GroupUpsertOne) AddDefaultRpmLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultRpmLimit(v)
+	})
+}
+
+// UpdateDefaultRpmLimit sets the "default_rpm_limit" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateDefaultRpmLimit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultRpmLimit()
+	})
+}
+
+// ClearDefaultRpmLimit clears the value of the "default_rpm_limit" field.
+func (u *GroupUpsertOne) ClearDefaultRpmLimit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearDefaultRpmLimit()
+	})
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (u *GroupUpsertOne) SetDefaultTpmLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultTpmLimit(v)
+	})
+}
+
+// AddDefaultTpmLimit adds v to the "default_tpm_limit" field.
+func (u *GroupUpsertOne) AddDefaultTpmLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultTpmLimit(v)
+	})
+}
+
+// UpdateDefaultTpmLimit sets the "default_tpm_limit" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateDefaultTpmLimit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultTpmLimit()
+	})
+}
+
+// ClearDefaultTpmLimit clears the value of the "default_tpm_limit" field.
+func (u *GroupUpsertOne) ClearDefaultTpmLimit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearDefaultTpmLimit()
+	})
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (u *GroupUpsertOne) SetResponseCacheEnabled(v bool) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheEnabled(v)
+	})
+}
+
+// UpdateResponseCacheEnabled sets the "response_cache_enabled" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateResponseCacheEnabled() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheEnabled()
+	})
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertOne) SetResponseCacheTTLSeconds(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheTTLSeconds(v)
+	})
+}
+
+// AddResponseCacheTTLSeconds adds v to the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertOne) AddResponseCacheTTLSeconds(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddResponseCacheTTLSeconds(v)
+	})
+}
+
+// UpdateResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateResponseCacheTTLSeconds() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheTTLSeconds()
+	})
+}
+
+// ClearResponseCacheTTLSeconds clears the value of the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertOne) ClearResponseCacheTTLSeconds() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearResponseCacheTTLSeconds()
+	})
+}
+
+// SetResponseCacheRate sets the "response_cache_rate" field.
+func (u *GroupUpsertOne) SetResponseCacheRate(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheRate(v)
+	})
+}
+
+// AddResponseCacheRate adds v to the "response_cache_rate" field.
+func (u *GroupUpsertOne) AddResponseCacheRate(v float64) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddResponseCacheRate(v)
+	})
+}
+
+// UpdateResponseCacheRate sets the "response_cache_rate" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateResponseCacheRate() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheRate()
+	})
+}
+
+// SetModelFallbacks sets the "model_fallbacks" field.
+func (u *GroupUpsertOne) SetModelFallbacks(v json.RawMessage) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetModelFallbacks(v)
+	})
+}
+
+// UpdateModelFallbacks sets the "model_fallbacks" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateModelFallbacks() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateModelFallbacks()
+	})
+}
+
+// ClearModelFallbacks clears the value of the "model_fallbacks" field.
+func (u *GroupUpsertOne) ClearModelFallbacks() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearModelFallbacks()
+	})
+}
+
+// SetGuardrails sets the "guardrails" field.
+func (u *GroupUpsertOne) SetGuardrails(v json.RawMessage) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetGuardrails(v)
+	})
+}
+
+// UpdateGuardrails sets the "guardrails" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateGuardrails() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateGuardrails()
+	})
+}
+
+// ClearGuardrails clears the value of the "guardrails" field.
+func (u *GroupUpsertOne) ClearGuardrails() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearGuardrails()
+	})
+}
+
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (u *GroupUpsertOne) SetSchedulingStrategy(v string) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSchedulingStrategy(v)
+	})
+}
+
+// UpdateSchedulingStrategy sets the "scheduling_strategy" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateSchedulingStrategy() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSchedulingStrategy()
+	})
+}
+
+// SetSchedulingWeights sets the "scheduling_weights" field.
+func (u *GroupUpsertOne) SetSchedulingWeights(v map[int64]int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSchedulingWeights(v)
+	})
+}
+
+// UpdateSchedulingWeights sets the "scheduling_weights" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateSchedulingWeights() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSchedulingWeights()
+	})
+}
+
+// ClearSchedulingWeights clears the value of the "scheduling_weights" field.
+func (u *GroupUpsertOne) ClearSchedulingWeights() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearSchedulingWeights()
+	})
+}
+
+// SetHedgeDelayMs sets the "hedge_delay_ms" field.
+func (u *GroupUpsertOne) SetHedgeDelayMs(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetHedgeDelayMs(v)
+	})
+}
+
+// AddHedgeDelayMs adds v to the "hedge_delay_ms" field.
+func (u *GroupUpsertOne) AddHedgeDelayMs(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddHedgeDelayMs(v)
+	})
+}
+
+// UpdateHedgeDelayMs sets the "hedge_delay_ms" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateHedgeDelayMs() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateHedgeDelayMs()
+	})
+}
+
+// SetQueueWeight sets the "queue_weight" field.
+func (u *GroupUpsertOne) SetQueueWeight(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetQueueWeight(v)
+	})
+}
+
+// AddQueueWeight adds v to the "queue_weight" field.
+func (u *GroupUpsertOne) AddQueueWeight(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddQueueWeight(v)
+	})
+}
+
+// UpdateQueueWeight sets the "queue_weight" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateQueueWeight() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateQueueWeight()
+	})
+}
+
 // Exec executes the query.
 func (u *GroupUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -2240,6 +2897,244 @@ func (u *GroupUpsertBulk) UpdateModelRoutingEnabled() *GroupUpsertBulk {
 	})
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (u *GroupUpsertBulk) SetDefaultRpmLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultRpmLimit(v)
+	})
+}
+
+// AddDefaultRpmLimit adds v to the "default_rpm_limit" field.
+func (u *GroupUpsertBulk) AddDefaultRpmLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultRpmLimit(v)
+	})
+}
+
+// UpdateDefaultRpmLimit sets the "default_rpm_limit" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateDefaultRpmLimit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultRpmLimit()
+	})
+}
+
+// ClearDefaultRpmLimit clears the value of the "default_rpm_limit" field.
+func (u *GroupUpsertBulk) ClearDefaultRpmLimit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearDefaultRpmLimit()
+	})
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (u *GroupUpsertBulk) SetDefaultTpmLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultTpmLimit(v)
+	})
+}
+
+// AddDefaultTpmLimit adds v to the "default_tpm_limit" field.
+func (u *GroupUpsertBulk) AddDefaultTpmLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultTpmLimit(v)
+	})
+}
+
+// UpdateDefaultTpmLimit sets the "default_tpm_limit" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateDefaultTpmLimit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultTpmLimit()
+	})
+}
+
+// ClearDefaultTpmLimit clears the value of the "default_tpm_limit" field.
+func (u *GroupUpsertBulk) ClearDefaultTpmLimit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearDefaultTpmLimit()
+	})
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (u *GroupUpsertBulk) SetResponseCacheEnabled(v bool) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheEnabled(v)
+	})
+}
+
+// UpdateResponseCacheEnabled sets the "response_cache_enabled" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateResponseCacheEnabled() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheEnabled()
+	})
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertBulk) SetResponseCacheTTLSeconds(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheTTLSeconds(v)
+	})
+}
+
+// AddResponseCacheTTLSeconds adds v to the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertBulk) AddResponseCacheTTLSeconds(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddResponseCacheTTLSeconds(v)
+	})
+}
+
+// UpdateResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateResponseCacheTTLSeconds() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheTTLSeconds()
+	})
+}
+
+// ClearResponseCacheTTLSeconds clears the value of the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertBulk) ClearResponseCacheTTLSeconds() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearResponseCacheTTLSeconds()
+	})
+}
+
+// SetResponseCacheRate sets the "response_cache_rate" field.
+func (u *GroupUpsertBulk) SetResponseCacheRate(v float64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheRate(v)
+	})
+}
+
+// AddResponseCacheRate adds v to the "response_cache_rate" field.
+func (u *GroupUpsertBulk) AddResponseCacheRate(v float64) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddResponseCacheRate(v)
+	})
+}
+
+// UpdateResponseCacheRate sets the "response_cache_rate" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateResponseCacheRate() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheRate()
+	})
+}
+
+// SetModelFallbacks sets the "model_fallbacks" field.
+func (u *GroupUpsertBulk) SetModelFallbacks(v json.RawMessage) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetModelFallbacks(v)
+	})
+}
+
+// UpdateModelFallbacks sets the "model_fallbacks" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateModelFallbacks() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateModelFallbacks()
+	})
+}
+
+// ClearModelFallbacks clears the value of the "model_fallbacks" field.
+func (u *GroupUpsertBulk) ClearModelFallbacks() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearModelFallbacks()
+	})
+}
+
+// SetGuardrails sets the "guardrails" field.
+func (u *GroupUpsertBulk) SetGuardrails(v json.RawMessage) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetGuardrails(v)
+	})
+}
+
+// UpdateGuardrails sets the "guardrails" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateGuardrails() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateGuardrails()
+	})
+}
+
+// ClearGuardrails clears the value of the "guardrails" field.
+func (u *GroupUpsertBulk) ClearGuardrails() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearGuardrails()
+	})
+}
+
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (u *GroupUpsertBulk) SetSchedulingStrategy(v string) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSchedulingStrategy(v)
+	})
+}
+
+// UpdateSchedulingStrategy sets the "scheduling_strategy" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateSchedulingStrategy() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSchedulingStrategy()
+	})
+}
+
+// SetSchedulingWeights sets the "scheduling_weights" field.
+func (u *GroupUpsertBulk) SetSchedulingWeights(v map[int64]int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetSchedulingWeights(v)
+	})
+}
+
+// UpdateSchedulingWeights sets the "scheduling_weights" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateSchedulingWeights() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateSchedulingWeights()
+	})
+}
+
+// ClearSchedulingWeights clears the value of the "scheduling_weights" field.
+func (u *GroupUpsertBulk) ClearSchedulingWeights() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearSchedulingWeights()
+	})
+}
+
+// SetHedgeDelayMs sets the "hedge_delay_ms" field.
+func (u *GroupUpsertBulk) SetHedgeDelayMs(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetHedgeDelayMs(v)
+	})
+}
+
+// AddHedgeDelayMs adds v to the "hedge_delay_ms" field.
+func (u *GroupUpsertBulk) AddHedgeDelayMs(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddHedgeDelayMs(v)
+	})
+}
+
+// UpdateHedgeDelayMs sets the "hedge_delay_ms" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateHedgeDelayMs() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateHedgeDelayMs()
+	})
+}
+
+// SetQueueWeight sets the "queue_weight" field.
+func (u *GroupUpsertBulk) SetQueueWeight(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetQueueWeight(v)
+	})
+}
+
+// AddQueueWeight adds v to the "queue_weight" field.
+func (u *GroupUpsertBulk) AddQueueWeight(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddQueueWeight(v)
+	})
+}
+
+// UpdateQueueWeight sets the "queue_weight" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateQueueWeight() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateQueueWeight()
+	})
+}
+
 // Exec executes the query.
 func (u *GroupUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
@@ -4,12 +4,14 @@ package ent
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/Wei-Shaw/sub2api/ent/account"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
@@ -421,6 +423,226 @@ func (_u *GroupUpdate) SetNillableModelRoutingEnabled(v *bool) *GroupUpdate {
 	return _u
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (_u *GroupUpdate) SetDefaultRpmLimit(v int) *GroupUpdate {
+	_u.mutation.ResetDefaultRpmLimit()
+	_u.mutation.SetDefaultRpmLimit(v)
+	return _u
+}
+
+// SetNillableDefaultRpmLimit sets the "default_rpm_limit" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableDefaultRpmLimit(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetDefaultRpmLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultRpmLimit adds value to the "default_rpm_limit" field.
+func (_u *GroupUpdate) AddDefaultRpmLimit(v int) *GroupUpdate {
+	_u.mutation.AddDefaultRpmLimit(v)
+	return _u
+}
+
+// ClearDefaultRpmLimit clears the value of the "default_rpm_limit" field.
+func (_u *GroupUpdate) ClearDefaultRpmLimit() *GroupUpdate {
+	_u.mutation.ClearDefaultRpmLimit()
+	return _u
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (_u *GroupUpdate) SetDefaultTpmLimit(v int) *GroupUpdate {
+	_u.mutation.ResetDefaultTpmLimit()
+	_u.mutation.SetDefaultTpmLimit(v)
+	return _u
+}
+
+// SetNillableDefaultTpmLimit sets the "default_tpm_limit" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableDefaultTpmLimit(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetDefaultTpmLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultTpmLimit adds value to the "default_tpm_limit" field.
+func (_u *GroupUpdate) AddDefaultTpmLimit(v int) *GroupUpdate {
+	_u.mutation.AddDefaultTpmLimit(v)
+	return _u
+}
+
+// ClearDefaultTpmLimit clears the value of the "default_tpm_limit" field.
+func (_u *GroupUpdate) ClearDefaultTpmLimit() *GroupUpdate {
+	_u.mutation.ClearDefaultTpmLimit()
+	return _u
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (_u *GroupUpdate) SetResponseCacheEnabled(v bool) *GroupUpdate {
+	_u.mutation.SetResponseCacheEnabled(v)
+	return _u
+}
+
+// SetNillableResponseCacheEnabled sets the "response_cache_enabled" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableResponseCacheEnabled(v *bool) *GroupUpdate {
+	if v != nil {
+		_u.SetResponseCacheEnabled(*v)
+	}
+	return _u
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdate) SetResponseCacheTTLSeconds(v int) *GroupUpdate {
+	_u.mutation.ResetResponseCacheTTLSeconds()
+	_u.mutation.SetResponseCacheTTLSeconds(v)
+	return _u
+}
+
+// SetNillableResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableResponseCacheTTLSeconds(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetResponseCacheTTLSeconds(*v)
+	}
+	return _u
+}
+
+// AddResponseCacheTTLSeconds adds value to the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdate) AddResponseCacheTTLSeconds(v int) *GroupUpdate {
+	_u.mutation.AddResponseCacheTTLSeconds(v)
+	return _u
+}
+
+// ClearResponseCacheTTLSeconds clears the value of the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdate) ClearResponseCacheTTLSeconds() *GroupUpdate {
+	_u.mutation.ClearResponseCacheTTLSeconds()
+	return _u
+}
+
+// SetResponseCacheRate sets the "response_cache_rate" field.
+func (_u *GroupUpdate) SetResponseCacheRate(v float64) *GroupUpdate {
+	_u.mutation.ResetResponseCacheRate()
+	_u.mutation.SetResponseCacheRate(v)
+	return _u
+}
+
+// SetNillableResponseCacheRate sets the "response_cache_rate" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableResponseCacheRate(v *float64) *GroupUpdate {
+	if v != nil {
+		_u.SetResponseCacheRate(*v)
+	}
+	return _u
+}
+
+// AddResponseCacheRate adds value to the "response_cache_rate" field.
+func (_u *GroupUpdate) AddResponseCacheRate(v float64) *GroupUpdate {
+	_u.mutation.AddResponseCacheRate(v)
+	return _u
+}
+
+// SetModelFallbacks sets the "model_fallbacks" field.
+func (_u *GroupUpdate) SetModelFallbacks(v json.RawMessage) *GroupUpdate {
+	_u.mutation.SetModelFallbacks(v)
+	return _u
+}
+
+// AppendModelFallbacks appends value to the "model_fallbacks" field.
+func (_u *GroupUpdate) AppendModelFallbacks(v json.RawMessage) *GroupUpdate {
+	_u.mutation.AppendModelFallbacks(v)
+	return _u
+}
+
+// ClearModelFallbacks clears the value of the "model_fallbacks" field.
+func (_u *GroupUpdate) ClearModelFallbacks() *GroupUpdate {
+	_u.mutation.ClearModelFallbacks()
+	return _u
+}
+
+// SetGuardrails sets the "guardrails" field.
+func (_u *GroupUpdate) SetGuardrails(v json.RawMessage) *GroupUpdate {
+	_u.mutation.SetGuardrails(v)
+	return _u
+}
+
+// AppendGuardrails appends value to the "guardrails" field.
+func (_u *GroupUpdate) AppendGuardrails(v json.RawMessage) *GroupUpdate {
+	_u.mutation.AppendGuardrails(v)
+	return _u
+}
+
+// ClearGuardrails clears the value of the "guardrails" field.
+func (_u *GroupUpdate) ClearGuardrails() *GroupUpdate {
+	_u.mutation.ClearGuardrails()
+	return _u
+}
+
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (_u *GroupUpdate) SetSchedulingStrategy(v string) *GroupUpdate {
+	_u.mutation.SetSchedulingStrategy(v)
+	return _u
+}
+
+// SetNillableSchedulingStrategy sets the "scheduling_strategy" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableSchedulingStrategy(v *string) *GroupUpdate {
+	if v != nil {
+		_u.SetSchedulingStrategy(*v)
+	}
+	return _u
+}
+
+// SetSchedulingWeights sets the "scheduling_weights" field.
+func (_u *GroupUpdate) SetSchedulingWeights(v map[int64]int) *GroupUpdate {
+	_u.mutation.SetSchedulingWeights(v)
+	return _u
+}
+
+// ClearSchedulingWeights clears the value of the "scheduling_weights" field.
+func (_u *GroupUpdate) ClearSchedulingWeights() *GroupUpdate {
+	_u.mutation.ClearSchedulingWeights()
+	return _u
+}
+
+// SetHedgeDelayMs sets the "hedge_delay_ms" field.
+func (_u *GroupUpdate) SetHedgeDelayMs(v int) *GroupUpdate {
+	_u.mutation.ResetHedgeDelayMs()
+	_u.mutation.SetHedgeDelayMs(v)
+	return _u
+}
+
+// SetNillableHedgeDelayMs sets the "hedge_delay_ms" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableHedgeDelayMs(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetHedgeDelayMs(*v)
+	}
+	return _u
+}
+
+// AddHedgeDelayMs adds value to the "hedge_delay_ms" field.
+func (_u *GroupUpdate) AddHedgeDelayMs(v int) *GroupUpdate {
+	_u.mutation.AddHedgeDelayMs(v)
+	return _u
+}
+
+// SetQueueWeight sets the "queue_weight" field.
+func (_u *GroupUpdate) SetQueueWeight(v int) *GroupUpdate {
+	_u.mutation.ResetQueueWeight()
+	_u.mutation.SetQueueWeight(v)
+	return _u
+}
+
+// SetNillableQueueWeight sets the "queue_weight" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableQueueWeight(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetQueueWeight(*v)
+	}
+	return _u
+}
+
+// AddQueueWeight adds value to the "queue_weight" field.
+func (_u *GroupUpdate) AddQueueWeight(v int) *GroupUpdate {
+	_u.mutation.AddQueueWeight(v)
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *GroupUpdate) AddAPIKeyIDs(ids ...int64) *GroupUpdate {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -706,6 +928,11 @@ func (_u *GroupUpdate) check() error {
 			return &ValidationError{Name: "subscription_type", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_type": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.SchedulingStrategy(); ok {
+		if err := group.SchedulingStrategyValidator(v); err != nil {
+			return &ValidationError{Name: "scheduling_strategy", err: fmt.Errorf(`ent: validator failed for field "Group.scheduling_strategy": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -838,6 +1065,85 @@ func (_u *GroupUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.ModelRoutingEnabled(); ok {
 		_spec.SetField(group.FieldModelRoutingEnabled, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.DefaultRpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultRpmLimit(); ok {
+		_spec.AddField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+	}
+	if _u.mutation.DefaultRpmLimitCleared() {
+		_spec.ClearField(group.FieldDefaultRpmLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.DefaultTpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultTpmLimit(); ok {
+		_spec.AddField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+	}
+	if _u.mutation.DefaultTpmLimitCleared() {
+		_spec.ClearField(group.FieldDefaultTpmLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ResponseCacheEnabled(); ok {
+		_spec.SetField(group.FieldResponseCacheEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ResponseCacheTTLSeconds(); ok {
+		_spec.SetField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedResponseCacheTTLSeconds(); ok {
+		_spec.AddField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+	}
+	if _u.mutation.ResponseCacheTTLSecondsCleared() {
+		_spec.ClearField(group.FieldResponseCacheTTLSeconds, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ResponseCacheRate(); ok {
+		_spec.SetField(group.FieldResponseCacheRate, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedResponseCacheRate(); ok {
+		_spec.AddField(group.FieldResponseCacheRate, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.ModelFallbacks(); ok {
+		_spec.SetField(group.FieldModelFallbacks, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedModelFallbacks(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldModelFallbacks, value)
+		})
+	}
+	if _u.mutation.ModelFallbacksCleared() {
+		_spec.ClearField(group.FieldModelFallbacks, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Guardrails(); ok {
+		_spec.SetField(group.FieldGuardrails, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedGuardrails(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldGuardrails, value)
+		})
+	}
+	if _u.mutation.GuardrailsCleared() {
+		_spec.ClearField(group.FieldGuardrails, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.SchedulingStrategy(); ok {
+		_spec.SetField(group.FieldSchedulingStrategy, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.SchedulingWeights(); ok {
+		_spec.SetField(group.FieldSchedulingWeights, field.TypeJSON, value)
+	}
+	if _u.mutation.SchedulingWeightsCleared() {
+		_spec.ClearField(group.FieldSchedulingWeights, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.HedgeDelayMs(); ok {
+		_spec.SetField(group.FieldHedgeDelayMs, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedHedgeDelayMs(); ok {
+		_spec.AddField(group.FieldHedgeDelayMs, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.QueueWeight(); ok {
+		_spec.SetField(group.FieldQueueWeight, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedQueueWeight(); ok {
+		_spec.AddField(group.FieldQueueWeight, field.TypeInt, value)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1539,6 +1845,226 @@ func (_u *GroupUpdateOne) SetNillableModelRoutingEnabled(v *bool) *GroupUpdateOn
 	return _u
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (_u *GroupUpdateOne) SetDefaultRpmLimit(v int) *GroupUpdateOne {
+	_u.mutation.ResetDefaultRpmLimit()
+	_u.mutation.SetDefaultRpmLimit(v)
+	return _u
+}
+
+// SetNillableDefaultRpmLimit sets the "default_rpm_limit" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableDefaultRpmLimit(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetDefaultRpmLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultRpmLimit adds value to the "default_rpm_limit" field.
+func (_u *GroupUpdateOne) AddDefaultRpmLimit(v int) *GroupUpdateOne {
+	_u.mutation.AddDefaultRpmLimit(v)
+	return _u
+}
+
+// ClearDefaultRpmLimit clears the value of the "default_rpm_limit" field.
+func (_u *GroupUpdateOne) ClearDefaultRpmLimit() *GroupUpdateOne {
+	_u.mutation.ClearDefaultRpmLimit()
+	return _u
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (_u *GroupUpdateOne) SetDefaultTpmLimit(v int) *GroupUpdateOne {
+	_u.mutation.ResetDefaultTpmLimit()
+	_u.mutation.SetDefaultTpmLimit(v)
+	return _u
+}
+
+// SetNillableDefaultTpmLimit sets the "default_tpm_limit" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableDefaultTpmLimit(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetDefaultTpmLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultTpmLimit adds value to the "default_tpm_limit" field.
+func (_u *GroupUpdateOne) AddDefaultTpmLimit(v int) *GroupUpdateOne {
+	_u.mutation.AddDefaultTpmLimit(v)
+	return _u
+}
+
+// ClearDefaultTpmLimit clears the value of the "default_tpm_limit" field.
+func (_u *GroupUpdateOne) ClearDefaultTpmLimit() *GroupUpdateOne {
+	_u.mutation.ClearDefaultTpmLimit()
+	return _u
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (_u *GroupUpdateOne) SetResponseCacheEnabled(v bool) *GroupUpdateOne {
+	_u.mutation.SetResponseCacheEnabled(v)
+	return _u
+}
+
+// SetNillableResponseCacheEnabled sets the "response_cache_enabled" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableResponseCacheEnabled(v *bool) *GroupUpdateOne {
+	if v != nil {
+		_u.SetResponseCacheEnabled(*v)
+	}
+	return _u
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdateOne) SetResponseCacheTTLSeconds(v int) *GroupUpdateOne {
+	_u.mutation.ResetResponseCacheTTLSeconds()
+	_u.mutation.SetResponseCacheTTLSeconds(v)
+	return _u
+}
+
+// SetNillableResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableResponseCacheTTLSeconds(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetResponseCacheTTLSeconds(*v)
+	}
+	return _u
+}
+
+// AddResponseCacheTTLSeconds adds value to the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdateOne) AddResponseCacheTTLSeconds(v int) *GroupUpdateOne {
+	_u.mutation.AddResponseCacheTTLSeconds(v)
+	return _u
+}
+
+// ClearResponseCacheTTLSeconds clears the value of the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdateOne) ClearResponseCacheTTLSeconds() *GroupUpdateOne {
+	_u.mutation.ClearResponseCacheTTLSeconds()
+	return _u
+}
+
+// SetResponseCacheRate sets the "response_cache_rate" field.
+func (_u *GroupUpdateOne) SetResponseCacheRate(v float64) *GroupUpdateOne {
+	_u.mutation.ResetResponseCacheRate()
+	_u.mutation.SetResponseCacheRate(v)
+	return _u
+}
+
+// SetNillableResponseCacheRate sets the "response_cache_rate" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableResponseCacheRate(v *float64) *GroupUpdateOne {
+	if v != nil {
+		_u.SetResponseCacheRate(*v)
+	}
+	return _u
+}
+
+// AddResponseCacheRate adds value to the "response_cache_rate" field.
+func (_u *GroupUpdateOne) AddResponseCacheRate(v float64) *GroupUpdateOne {
+	_u.mutation.AddResponseCacheRate(v)
+	return _u
+}
+
+// SetModelFallbacks sets the "model_fallbacks" field.
+func (_u *GroupUpdateOne) SetModelFallbacks(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.SetModelFallbacks(v)
+	return _u
+}
+
+// AppendModelFallbacks appends value to the "model_fallbacks" field.
+func (_u *GroupUpdateOne) AppendModelFallbacks(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.AppendModelFallbacks(v)
+	return _u
+}
+
+// ClearModelFallbacks clears the value of the "model_fallbacks" field.
+func (_u *GroupUpdateOne) ClearModelFallbacks() *GroupUpdateOne {
+	_u.mutation.ClearModelFallbacks()
+	return _u
+}
+
+// SetGuardrails sets the "guardrails" field.
+func (_u *GroupUpdateOne) SetGuardrails(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.SetGuardrails(v)
+	return _u
+}
+
+// AppendGuardrails appends value to the "guardrails" field.
+func (_u *GroupUpdateOne) AppendGuardrails(v json.RawMessage) *GroupUpdateOne {
+	_u.mutation.AppendGuardrails(v)
+	return _u
+}
+
+// ClearGuardrails clears the value of the "guardrails" field.
+func (_u *GroupUpdateOne) ClearGuardrails() *GroupUpdateOne {
+	_u.mutation.ClearGuardrails()
+	return _u
+}
+
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (_u *GroupUpdateOne) SetSchedulingStrategy(v string) *GroupUpdateOne {
+	_u.mutation.SetSchedulingStrategy(v)
+	return _u
+}
+
+// SetNillableSchedulingStrategy sets the "scheduling_strategy" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableSchedulingStrategy(v *string) *GroupUpdateOne {
+	if v != nil {
+		_u.SetSchedulingStrategy(*v)
+	}
+	return _u
+}
+
+// SetSchedulingWeights sets the "scheduling_weights" field.
+func (_u *GroupUpdateOne) SetSchedulingWeights(v map[int64]int) *GroupUpdateOne {
+	_u.mutation.SetSchedulingWeights(v)
+	return _u
+}
+
+// ClearSchedulingWeights clears the value of the "scheduling_weights" field.
+func (_u *GroupUpdateOne) ClearSchedulingWeights() *GroupUpdateOne {
+	_u.mutation.ClearSchedulingWeights()
+	return _u
+}
+
+// SetHedgeDelayMs sets the "hedge_delay_ms" field.
+func (_u *GroupUpdateOne) SetHedgeDelayMs(v int) *GroupUpdateOne {
+	_u.mutation.ResetHedgeDelayMs()
+	_u.mutation.SetHedgeDelayMs(v)
+	return _u
+}
+
+// SetNillableHedgeDelayMs sets the "hedge_delay_ms" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableHedgeDelayMs(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetHedgeDelayMs(*v)
+	}
+	return _u
+}
+
+// AddHedgeDelayMs adds value to the "hedge_delay_ms" field.
+func (_u *GroupUpdateOne) AddHedgeDelayMs(v int) *GroupUpdateOne {
+	_u.mutation.AddHedgeDelayMs(v)
+	return _u
+}
+
+// SetQueueWeight sets the "queue_weight" field.
+func (_u *GroupUpdateOne) SetQueueWeight(v int) *GroupUpdateOne {
+	_u.mutation.ResetQueueWeight()
+	_u.mutation.SetQueueWeight(v)
+	return _u
+}
+
+// SetNillableQueueWeight sets the "queue_weight" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableQueueWeight(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetQueueWeight(*v)
+	}
+	return _u
+}
+
+// AddQueueWeight adds value to the "queue_weight" field.
+func (_u *GroupUpdateOne) AddQueueWeight(v int) *GroupUpdateOne {
+	_u.mutation.AddQueueWeight(v)
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *GroupUpdateOne) AddAPIKeyIDs(ids ...int64) *GroupUpdateOne {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -1837,6 +2363,11 @@ func (_u *GroupUpdateOne) check() error {
 			return &ValidationError{Name: "subscription_type", err: fmt.Errorf(`ent: validator failed for field "Group.subscription_type": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.SchedulingStrategy(); ok {
+		if err := group.SchedulingStrategyValidator(v); err != nil {
+			return &ValidationError{Name: "scheduling_strategy", err: fmt.Errorf(`ent: validator failed for field "Group.scheduling_strategy": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -1986,6 +2517,85 @@ func (_u *GroupUpdateOne) sqlSave(ctx context.Context) (_node *Group, err error)
 	if value, ok := _u.mutation.ModelRoutingEnabled(); ok {
 		_spec.SetField(group.FieldModelRoutingEnabled, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.DefaultRpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultRpmLimit(); ok {
+		_spec.AddField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+	}
+	if _u.mutation.DefaultRpmLimitCleared() {
+		_spec.ClearField(group.FieldDefaultRpmLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.DefaultTpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultTpmLimit(); ok {
+		_spec.AddField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+	}
+	if _u.mutation.DefaultTpmLimitCleared() {
+		_spec.ClearField(group.FieldDefaultTpmLimit, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ResponseCacheEnabled(); ok {
+		_spec.SetField(group.FieldResponseCacheEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ResponseCacheTTLSeconds(); ok {
+		_spec.SetField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedResponseCacheTTLSeconds(); ok {
+		_spec.AddField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+	}
+	if _u.mutation.ResponseCacheTTLSecondsCleared() {
+		_spec.ClearField(group.FieldResponseCacheTTLSeconds, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ResponseCacheRate(); ok {
+		_spec.SetField(group.FieldResponseCacheRate, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedResponseCacheRate(); ok {
+		_spec.AddField(group.FieldResponseCacheRate, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.ModelFallbacks(); ok {
+		_spec.SetField(group.FieldModelFallbacks, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedModelFallbacks(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldModelFallbacks, value)
+		})
+	}
+	if _u.mutation.ModelFallbacksCleared() {
+		_spec.ClearField(group.FieldModelFallbacks, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Guardrails(); ok {
+		_spec.SetField(group.FieldGuardrails, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedGuardrails(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, group.FieldGuardrails, value)
+		})
+	}
+	if _u.mutation.GuardrailsCleared() {
+		_spec.ClearField(group.FieldGuardrails, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.SchedulingStrategy(); ok {
+		_spec.SetField(group.FieldSchedulingStrategy, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.SchedulingWeights(); ok {
+		_spec.SetField(group.FieldSchedulingWeights, field.TypeJSON, value)
+	}
+	if _u.mutation.SchedulingWeightsCleared() {
+		_spec.ClearField(group.FieldSchedulingWeights, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.HedgeDelayMs(); ok {
+		_spec.SetField(group.FieldHedgeDelayMs, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedHedgeDelayMs(); ok {
+		_spec.AddField(group.FieldHedgeDelayMs, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.QueueWeight(); ok {
+		_spec.SetField(group.FieldQueueWeight, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedQueueWeight(); ok {
+		_spec.AddField(group.FieldQueueWeight, field.TypeInt, value)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
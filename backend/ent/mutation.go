@@ -61,30 +61,45 @@ const (
 // APIKeyMutation represents an operation that mutates the APIKey nodes in the graph.
 type APIKeyMutation struct {
 	config
-	op                 Op
-	typ                string
-	id                 *int64
-	created_at         *time.Time
-	updated_at         *time.Time
-	deleted_at         *time.Time
-	key                *string
-	name               *string
-	status             *string
-	ip_whitelist       *[]string
-	appendip_whitelist []string
-	ip_blacklist       *[]string
-	appendip_blacklist []string
-	clearedFields      map[string]struct{}
-	user               *int64
-	cleareduser        bool
-	group              *int64
-	clearedgroup       bool
-	usage_logs         map[int64]struct{}
-	removedusage_logs  map[int64]struct{}
-	clearedusage_logs  bool
-	done               bool
-	oldValue           func(context.Context) (*APIKey, error)
-	predicates         []predicate.APIKey
+	op                   Op
+	typ                  string
+	id                   *int64
+	created_at           *time.Time
+	updated_at           *time.Time
+	deleted_at           *time.Time
+	key                  *string
+	name                 *string
+	status               *string
+	ip_whitelist         *[]string
+	appendip_whitelist   []string
+	ip_blacklist         *[]string
+	appendip_blacklist   []string
+	quota_usd            *float64
+	addquota_usd         *float64
+	used_usd             *float64
+	addused_usd          *float64
+	expires_at           *time.Time
+	allowed_models       *[]string
+	appendallowed_models []string
+	max_tokens_cap       *int
+	addmax_tokens_cap    *int
+	rpm_limit            *int
+	addrpm_limit         *int
+	tpm_limit            *int
+	addtpm_limit         *int
+	organization_id      *int64
+	addorganization_id   *int64
+	clearedFields        map[string]struct{}
+	user                 *int64
+	cleareduser          bool
+	group                *int64
+	clearedgroup         bool
+	usage_logs           map[int64]struct{}
+	removedusage_logs    map[int64]struct{}
+	clearedusage_logs    bool
+	done                 bool
+	oldValue             func(context.Context) (*APIKey, error)
+	predicates           []predicate.APIKey
 }
 
 var _ ent.Mutation = (*APIKeyMutation)(nil)
@@ -629,2371 +644,3818 @@ func (m *APIKeyMutation) ResetIPBlacklist() {
 	delete(m.clearedFields, apikey.FieldIPBlacklist)
 }
 
-// ClearUser clears the "user" edge to the User entity.
-func (m *APIKeyMutation) ClearUser() {
-	m.cleareduser = true
-	m.clearedFields[apikey.FieldUserID] = struct{}{}
+// SetQuotaUsd sets the "quota_usd" field.
+func (m *APIKeyMutation) SetQuotaUsd(f float64) {
+	m.quota_usd = &f
+	m.addquota_usd = nil
 }
 
-// UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *APIKeyMutation) UserCleared() bool {
-	return m.cleareduser
+// QuotaUsd returns the value of the "quota_usd" field in the mutation.
+func (m *APIKeyMutation) QuotaUsd() (r float64, exists bool) {
+	v := m.quota_usd
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// UserIDs returns the "user" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// UserID instead. It exists only for internal usage by the builders.
-func (m *APIKeyMutation) UserIDs() (ids []int64) {
-	if id := m.user; id != nil {
-		ids = append(ids, *id)
+// OldQuotaUsd returns the old "quota_usd" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldQuotaUsd(ctx context.Context) (v *float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQuotaUsd is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQuotaUsd requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQuotaUsd: %w", err)
+	}
+	return oldValue.QuotaUsd, nil
 }
 
-// ResetUser resets all changes to the "user" edge.
-func (m *APIKeyMutation) ResetUser() {
-	m.user = nil
-	m.cleareduser = false
+// AddQuotaUsd adds f to the "quota_usd" field.
+func (m *APIKeyMutation) AddQuotaUsd(f float64) {
+	if m.addquota_usd != nil {
+		*m.addquota_usd += f
+	} else {
+		m.addquota_usd = &f
+	}
 }
 
-// ClearGroup clears the "group" edge to the Group entity.
-func (m *APIKeyMutation) ClearGroup() {
-	m.clearedgroup = true
-	m.clearedFields[apikey.FieldGroupID] = struct{}{}
+// AddedQuotaUsd returns the value that was added to the "quota_usd" field in this mutation.
+func (m *APIKeyMutation) AddedQuotaUsd() (r float64, exists bool) {
+	v := m.addquota_usd
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// GroupCleared reports if the "group" edge to the Group entity was cleared.
-func (m *APIKeyMutation) GroupCleared() bool {
-	return m.GroupIDCleared() || m.clearedgroup
+// ClearQuotaUsd clears the value of the "quota_usd" field.
+func (m *APIKeyMutation) ClearQuotaUsd() {
+	m.quota_usd = nil
+	m.addquota_usd = nil
+	m.clearedFields[apikey.FieldQuotaUsd] = struct{}{}
 }
 
-// GroupIDs returns the "group" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// GroupID instead. It exists only for internal usage by the builders.
-func (m *APIKeyMutation) GroupIDs() (ids []int64) {
-	if id := m.group; id != nil {
-		ids = append(ids, *id)
+// QuotaUsdCleared returns if the "quota_usd" field was cleared in this mutation.
+func (m *APIKeyMutation) QuotaUsdCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldQuotaUsd]
+	return ok
+}
+
+// ResetQuotaUsd resets all changes to the "quota_usd" field.
+func (m *APIKeyMutation) ResetQuotaUsd() {
+	m.quota_usd = nil
+	m.addquota_usd = nil
+	delete(m.clearedFields, apikey.FieldQuotaUsd)
+}
+
+// SetUsedUsd sets the "used_usd" field.
+func (m *APIKeyMutation) SetUsedUsd(f float64) {
+	m.used_usd = &f
+	m.addused_usd = nil
+}
+
+// UsedUsd returns the value of the "used_usd" field in the mutation.
+func (m *APIKeyMutation) UsedUsd() (r float64, exists bool) {
+	v := m.used_usd
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// ResetGroup resets all changes to the "group" edge.
-func (m *APIKeyMutation) ResetGroup() {
-	m.group = nil
-	m.clearedgroup = false
+// OldUsedUsd returns the old "used_usd" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldUsedUsd(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsedUsd is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsedUsd requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsedUsd: %w", err)
+	}
+	return oldValue.UsedUsd, nil
 }
 
-// AddUsageLogIDs adds the "usage_logs" edge to the UsageLog entity by ids.
-func (m *APIKeyMutation) AddUsageLogIDs(ids ...int64) {
-	if m.usage_logs == nil {
-		m.usage_logs = make(map[int64]struct{})
+// AddUsedUsd adds f to the "used_usd" field.
+func (m *APIKeyMutation) AddUsedUsd(f float64) {
+	if m.addused_usd != nil {
+		*m.addused_usd += f
+	} else {
+		m.addused_usd = &f
 	}
-	for i := range ids {
-		m.usage_logs[ids[i]] = struct{}{}
+}
+
+// AddedUsedUsd returns the value that was added to the "used_usd" field in this mutation.
+func (m *APIKeyMutation) AddedUsedUsd() (r float64, exists bool) {
+	v := m.addused_usd
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// ClearUsageLogs clears the "usage_logs" edge to the UsageLog entity.
-func (m *APIKeyMutation) ClearUsageLogs() {
-	m.clearedusage_logs = true
+// ResetUsedUsd resets all changes to the "used_usd" field.
+func (m *APIKeyMutation) ResetUsedUsd() {
+	m.used_usd = nil
+	m.addused_usd = nil
 }
 
-// UsageLogsCleared reports if the "usage_logs" edge to the UsageLog entity was cleared.
-func (m *APIKeyMutation) UsageLogsCleared() bool {
-	return m.clearedusage_logs
+// SetExpiresAt sets the "expires_at" field.
+func (m *APIKeyMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
 }
 
-// RemoveUsageLogIDs removes the "usage_logs" edge to the UsageLog entity by IDs.
-func (m *APIKeyMutation) RemoveUsageLogIDs(ids ...int64) {
-	if m.removedusage_logs == nil {
-		m.removedusage_logs = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.usage_logs, ids[i])
-		m.removedusage_logs[ids[i]] = struct{}{}
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *APIKeyMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedUsageLogs returns the removed IDs of the "usage_logs" edge to the UsageLog entity.
-func (m *APIKeyMutation) RemovedUsageLogsIDs() (ids []int64) {
-	for id := range m.removedusage_logs {
-		ids = append(ids, id)
+// OldExpiresAt returns the old "expires_at" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
 }
 
-// UsageLogsIDs returns the "usage_logs" edge IDs in the mutation.
-func (m *APIKeyMutation) UsageLogsIDs() (ids []int64) {
-	for id := range m.usage_logs {
-		ids = append(ids, id)
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *APIKeyMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[apikey.FieldExpiresAt] = struct{}{}
+}
+
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *APIKeyMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldExpiresAt]
+	return ok
+}
+
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *APIKeyMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, apikey.FieldExpiresAt)
+}
+
+// SetAllowedModels sets the "allowed_models" field.
+func (m *APIKeyMutation) SetAllowedModels(s []string) {
+	m.allowed_models = &s
+	m.appendallowed_models = nil
+}
+
+// AllowedModels returns the value of the "allowed_models" field in the mutation.
+func (m *APIKeyMutation) AllowedModels() (r []string, exists bool) {
+	v := m.allowed_models
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// ResetUsageLogs resets all changes to the "usage_logs" edge.
-func (m *APIKeyMutation) ResetUsageLogs() {
-	m.usage_logs = nil
-	m.clearedusage_logs = false
-	m.removedusage_logs = nil
+// OldAllowedModels returns the old "allowed_models" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldAllowedModels(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAllowedModels is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAllowedModels requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAllowedModels: %w", err)
+	}
+	return oldValue.AllowedModels, nil
 }
 
-// Where appends a list predicates to the APIKeyMutation builder.
-func (m *APIKeyMutation) Where(ps ...predicate.APIKey) {
-	m.predicates = append(m.predicates, ps...)
+// AppendAllowedModels adds s to the "allowed_models" field.
+func (m *APIKeyMutation) AppendAllowedModels(s []string) {
+	m.appendallowed_models = append(m.appendallowed_models, s...)
 }
 
-// WhereP appends storage-level predicates to the APIKeyMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *APIKeyMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.APIKey, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
+// AppendedAllowedModels returns the list of values that were appended to the "allowed_models" field in this mutation.
+func (m *APIKeyMutation) AppendedAllowedModels() ([]string, bool) {
+	if len(m.appendallowed_models) == 0 {
+		return nil, false
 	}
-	m.Where(p...)
+	return m.appendallowed_models, true
 }
 
-// Op returns the operation name.
-func (m *APIKeyMutation) Op() Op {
-	return m.op
+// ClearAllowedModels clears the value of the "allowed_models" field.
+func (m *APIKeyMutation) ClearAllowedModels() {
+	m.allowed_models = nil
+	m.appendallowed_models = nil
+	m.clearedFields[apikey.FieldAllowedModels] = struct{}{}
 }
 
-// SetOp allows setting the mutation operation.
-func (m *APIKeyMutation) SetOp(op Op) {
-	m.op = op
+// AllowedModelsCleared returns if the "allowed_models" field was cleared in this mutation.
+func (m *APIKeyMutation) AllowedModelsCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldAllowedModels]
+	return ok
 }
 
-// Type returns the node type of this mutation (APIKey).
-func (m *APIKeyMutation) Type() string {
-	return m.typ
+// ResetAllowedModels resets all changes to the "allowed_models" field.
+func (m *APIKeyMutation) ResetAllowedModels() {
+	m.allowed_models = nil
+	m.appendallowed_models = nil
+	delete(m.clearedFields, apikey.FieldAllowedModels)
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *APIKeyMutation) Fields() []string {
-	fields := make([]string, 0, 10)
-	if m.created_at != nil {
-		fields = append(fields, apikey.FieldCreatedAt)
-	}
-	if m.updated_at != nil {
-		fields = append(fields, apikey.FieldUpdatedAt)
-	}
-	if m.deleted_at != nil {
-		fields = append(fields, apikey.FieldDeletedAt)
-	}
-	if m.user != nil {
-		fields = append(fields, apikey.FieldUserID)
+// SetMaxTokensCap sets the "max_tokens_cap" field.
+func (m *APIKeyMutation) SetMaxTokensCap(i int) {
+	m.max_tokens_cap = &i
+	m.addmax_tokens_cap = nil
+}
+
+// MaxTokensCap returns the value of the "max_tokens_cap" field in the mutation.
+func (m *APIKeyMutation) MaxTokensCap() (r int, exists bool) {
+	v := m.max_tokens_cap
+	if v == nil {
+		return
 	}
-	if m.key != nil {
-		fields = append(fields, apikey.FieldKey)
+	return *v, true
+}
+
+// OldMaxTokensCap returns the old "max_tokens_cap" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldMaxTokensCap(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxTokensCap is only allowed on UpdateOne operations")
 	}
-	if m.name != nil {
-		fields = append(fields, apikey.FieldName)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxTokensCap requires an ID field in the mutation")
 	}
-	if m.group != nil {
-		fields = append(fields, apikey.FieldGroupID)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxTokensCap: %w", err)
 	}
-	if m.status != nil {
-		fields = append(fields, apikey.FieldStatus)
+	return oldValue.MaxTokensCap, nil
+}
+
+// AddMaxTokensCap adds i to the "max_tokens_cap" field.
+func (m *APIKeyMutation) AddMaxTokensCap(i int) {
+	if m.addmax_tokens_cap != nil {
+		*m.addmax_tokens_cap += i
+	} else {
+		m.addmax_tokens_cap = &i
 	}
-	if m.ip_whitelist != nil {
-		fields = append(fields, apikey.FieldIPWhitelist)
+}
+
+// AddedMaxTokensCap returns the value that was added to the "max_tokens_cap" field in this mutation.
+func (m *APIKeyMutation) AddedMaxTokensCap() (r int, exists bool) {
+	v := m.addmax_tokens_cap
+	if v == nil {
+		return
 	}
-	if m.ip_blacklist != nil {
-		fields = append(fields, apikey.FieldIPBlacklist)
+	return *v, true
+}
+
+// ClearMaxTokensCap clears the value of the "max_tokens_cap" field.
+func (m *APIKeyMutation) ClearMaxTokensCap() {
+	m.max_tokens_cap = nil
+	m.addmax_tokens_cap = nil
+	m.clearedFields[apikey.FieldMaxTokensCap] = struct{}{}
+}
+
+// MaxTokensCapCleared returns if the "max_tokens_cap" field was cleared in this mutation.
+func (m *APIKeyMutation) MaxTokensCapCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldMaxTokensCap]
+	return ok
+}
+
+// ResetMaxTokensCap resets all changes to the "max_tokens_cap" field.
+func (m *APIKeyMutation) ResetMaxTokensCap() {
+	m.max_tokens_cap = nil
+	m.addmax_tokens_cap = nil
+	delete(m.clearedFields, apikey.FieldMaxTokensCap)
+}
+
+// SetRpmLimit sets the "rpm_limit" field.
+func (m *APIKeyMutation) SetRpmLimit(i int) {
+	m.rpm_limit = &i
+	m.addrpm_limit = nil
+}
+
+// RpmLimit returns the value of the "rpm_limit" field in the mutation.
+func (m *APIKeyMutation) RpmLimit() (r int, exists bool) {
+	v := m.rpm_limit
+	if v == nil {
+		return
 	}
-	return fields
+	return *v, true
 }
 
-// Field returns the value of a field with the given name. The second boolean
-// return value indicates that this field was not set, or was not defined in the
-// schema.
-func (m *APIKeyMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case apikey.FieldCreatedAt:
-		return m.CreatedAt()
-	case apikey.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case apikey.FieldDeletedAt:
-		return m.DeletedAt()
-	case apikey.FieldUserID:
-		return m.UserID()
-	case apikey.FieldKey:
-		return m.Key()
-	case apikey.FieldName:
-		return m.Name()
-	case apikey.FieldGroupID:
-		return m.GroupID()
-	case apikey.FieldStatus:
-		return m.Status()
-	case apikey.FieldIPWhitelist:
-		return m.IPWhitelist()
-	case apikey.FieldIPBlacklist:
-		return m.IPBlacklist()
+// OldRpmLimit returns the old "rpm_limit" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldRpmLimit(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRpmLimit is only allowed on UpdateOne operations")
 	}
-	return nil, false
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRpmLimit requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRpmLimit: %w", err)
+	}
+	return oldValue.RpmLimit, nil
 }
 
-// OldField returns the old value of the field from the database. An error is
-// returned if the mutation operation is not UpdateOne, or the query to the
-// database failed.
-func (m *APIKeyMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	switch name {
-	case apikey.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case apikey.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case apikey.FieldDeletedAt:
-		return m.OldDeletedAt(ctx)
-	case apikey.FieldUserID:
-		return m.OldUserID(ctx)
-	case apikey.FieldKey:
-		return m.OldKey(ctx)
-	case apikey.FieldName:
-		return m.OldName(ctx)
-	case apikey.FieldGroupID:
-		return m.OldGroupID(ctx)
-	case apikey.FieldStatus:
-		return m.OldStatus(ctx)
-	case apikey.FieldIPWhitelist:
-		return m.OldIPWhitelist(ctx)
-	case apikey.FieldIPBlacklist:
-		return m.OldIPBlacklist(ctx)
+// AddRpmLimit adds i to the "rpm_limit" field.
+func (m *APIKeyMutation) AddRpmLimit(i int) {
+	if m.addrpm_limit != nil {
+		*m.addrpm_limit += i
+	} else {
+		m.addrpm_limit = &i
 	}
-	return nil, fmt.Errorf("unknown APIKey field %s", name)
 }
 
-// SetField sets the value of a field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *APIKeyMutation) SetField(name string, value ent.Value) error {
-	switch name {
-	case apikey.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
-		return nil
-	case apikey.FieldUpdatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetUpdatedAt(v)
-		return nil
-	case apikey.FieldDeletedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetDeletedAt(v)
-		return nil
-	case apikey.FieldUserID:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetUserID(v)
-		return nil
-	case apikey.FieldKey:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetKey(v)
-		return nil
-	case apikey.FieldName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetName(v)
-		return nil
-	case apikey.FieldGroupID:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetGroupID(v)
-		return nil
-	case apikey.FieldStatus:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetStatus(v)
-		return nil
-	case apikey.FieldIPWhitelist:
-		v, ok := value.([]string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetIPWhitelist(v)
-		return nil
-	case apikey.FieldIPBlacklist:
-		v, ok := value.([]string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetIPBlacklist(v)
-		return nil
+// AddedRpmLimit returns the value that was added to the "rpm_limit" field in this mutation.
+func (m *APIKeyMutation) AddedRpmLimit() (r int, exists bool) {
+	v := m.addrpm_limit
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown APIKey field %s", name)
+	return *v, true
 }
 
-// AddedFields returns all numeric fields that were incremented/decremented during
-// this mutation.
-func (m *APIKeyMutation) AddedFields() []string {
-	var fields []string
-	return fields
+// ClearRpmLimit clears the value of the "rpm_limit" field.
+func (m *APIKeyMutation) ClearRpmLimit() {
+	m.rpm_limit = nil
+	m.addrpm_limit = nil
+	m.clearedFields[apikey.FieldRpmLimit] = struct{}{}
 }
 
-// AddedField returns the numeric value that was incremented/decremented on a field
-// with the given name. The second boolean return value indicates that this field
-// was not set, or was not defined in the schema.
-func (m *APIKeyMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	}
-	return nil, false
+// RpmLimitCleared returns if the "rpm_limit" field was cleared in this mutation.
+func (m *APIKeyMutation) RpmLimitCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldRpmLimit]
+	return ok
 }
 
-// AddField adds the value to the field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *APIKeyMutation) AddField(name string, value ent.Value) error {
-	switch name {
+// ResetRpmLimit resets all changes to the "rpm_limit" field.
+func (m *APIKeyMutation) ResetRpmLimit() {
+	m.rpm_limit = nil
+	m.addrpm_limit = nil
+	delete(m.clearedFields, apikey.FieldRpmLimit)
+}
+
+// SetTpmLimit sets the "tpm_limit" field.
+func (m *APIKeyMutation) SetTpmLimit(i int) {
+	m.tpm_limit = &i
+	m.addtpm_limit = nil
+}
+
+// TpmLimit returns the value of the "tpm_limit" field in the mutation.
+func (m *APIKeyMutation) TpmLimit() (r int, exists bool) {
+	v := m.tpm_limit
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown APIKey numeric field %s", name)
+	return *v, true
 }
 
-// ClearedFields returns all nullable fields that were cleared during this
-// mutation.
-func (m *APIKeyMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(apikey.FieldDeletedAt) {
-		fields = append(fields, apikey.FieldDeletedAt)
+// OldTpmLimit returns the old "tpm_limit" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldTpmLimit(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTpmLimit is only allowed on UpdateOne operations")
 	}
-	if m.FieldCleared(apikey.FieldGroupID) {
-		fields = append(fields, apikey.FieldGroupID)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTpmLimit requires an ID field in the mutation")
 	}
-	if m.FieldCleared(apikey.FieldIPWhitelist) {
-		fields = append(fields, apikey.FieldIPWhitelist)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTpmLimit: %w", err)
 	}
-	if m.FieldCleared(apikey.FieldIPBlacklist) {
-		fields = append(fields, apikey.FieldIPBlacklist)
+	return oldValue.TpmLimit, nil
+}
+
+// AddTpmLimit adds i to the "tpm_limit" field.
+func (m *APIKeyMutation) AddTpmLimit(i int) {
+	if m.addtpm_limit != nil {
+		*m.addtpm_limit += i
+	} else {
+		m.addtpm_limit = &i
 	}
-	return fields
 }
 
-// FieldCleared returns a boolean indicating if a field with the given name was
-// cleared in this mutation.
-func (m *APIKeyMutation) FieldCleared(name string) bool {
-	_, ok := m.clearedFields[name]
+// AddedTpmLimit returns the value that was added to the "tpm_limit" field in this mutation.
+func (m *APIKeyMutation) AddedTpmLimit() (r int, exists bool) {
+	v := m.addtpm_limit
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearTpmLimit clears the value of the "tpm_limit" field.
+func (m *APIKeyMutation) ClearTpmLimit() {
+	m.tpm_limit = nil
+	m.addtpm_limit = nil
+	m.clearedFields[apikey.FieldTpmLimit] = struct{}{}
+}
+
+// TpmLimitCleared returns if the "tpm_limit" field was cleared in this mutation.
+func (m *APIKeyMutation) TpmLimitCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldTpmLimit]
 	return ok
 }
 
-// ClearField clears the value of the field with the given name. It returns an
-// error if the field is not defined in the schema.
-func (m *APIKeyMutation) ClearField(name string) error {
-	switch name {
-	case apikey.FieldDeletedAt:
-		m.ClearDeletedAt()
-		return nil
-	case apikey.FieldGroupID:
-		m.ClearGroupID()
-		return nil
-	case apikey.FieldIPWhitelist:
-		m.ClearIPWhitelist()
-		return nil
-	case apikey.FieldIPBlacklist:
-		m.ClearIPBlacklist()
-		return nil
-	}
-	return fmt.Errorf("unknown APIKey nullable field %s", name)
+// ResetTpmLimit resets all changes to the "tpm_limit" field.
+func (m *APIKeyMutation) ResetTpmLimit() {
+	m.tpm_limit = nil
+	m.addtpm_limit = nil
+	delete(m.clearedFields, apikey.FieldTpmLimit)
 }
 
-// ResetField resets all changes in the mutation for the field with the given name.
-// It returns an error if the field is not defined in the schema.
-func (m *APIKeyMutation) ResetField(name string) error {
-	switch name {
-	case apikey.FieldCreatedAt:
-		m.ResetCreatedAt()
-		return nil
-	case apikey.FieldUpdatedAt:
-		m.ResetUpdatedAt()
-		return nil
-	case apikey.FieldDeletedAt:
-		m.ResetDeletedAt()
-		return nil
-	case apikey.FieldUserID:
-		m.ResetUserID()
-		return nil
-	case apikey.FieldKey:
-		m.ResetKey()
-		return nil
-	case apikey.FieldName:
-		m.ResetName()
-		return nil
-	case apikey.FieldGroupID:
-		m.ResetGroupID()
-		return nil
-	case apikey.FieldStatus:
-		m.ResetStatus()
-		return nil
-	case apikey.FieldIPWhitelist:
-		m.ResetIPWhitelist()
-		return nil
-	case apikey.FieldIPBlacklist:
-		m.ResetIPBlacklist()
-		return nil
+// SetOrganizationID sets the "organization_id" field.
+func (m *APIKeyMutation) SetOrganizationID(i int64) {
+	m.organization_id = &i
+	m.addorganization_id = nil
+}
+
+// OrganizationID returns the value of the "organization_id" field in the mutation.
+func (m *APIKeyMutation) OrganizationID() (r int64, exists bool) {
+	v := m.organization_id
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown APIKey field %s", name)
+	return *v, true
 }
 
-// AddedEdges returns all edge names that were set/added in this mutation.
-func (m *APIKeyMutation) AddedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.user != nil {
-		edges = append(edges, apikey.EdgeUser)
+// OldOrganizationID returns the old "organization_id" field's value of the APIKey entity.
+// If the APIKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *APIKeyMutation) OldOrganizationID(ctx context.Context) (v *int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOrganizationID is only allowed on UpdateOne operations")
 	}
-	if m.group != nil {
-		edges = append(edges, apikey.EdgeGroup)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOrganizationID requires an ID field in the mutation")
 	}
-	if m.usage_logs != nil {
-		edges = append(edges, apikey.EdgeUsageLogs)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOrganizationID: %w", err)
 	}
-	return edges
+	return oldValue.OrganizationID, nil
 }
 
-// AddedIDs returns all IDs (to other nodes) that were added for the given edge
-// name in this mutation.
-func (m *APIKeyMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case apikey.EdgeUser:
-		if id := m.user; id != nil {
-			return []ent.Value{*id}
-		}
-	case apikey.EdgeGroup:
-		if id := m.group; id != nil {
-			return []ent.Value{*id}
-		}
-	case apikey.EdgeUsageLogs:
-		ids := make([]ent.Value, 0, len(m.usage_logs))
-		for id := range m.usage_logs {
-			ids = append(ids, id)
-		}
-		return ids
+// AddOrganizationID adds i to the "organization_id" field.
+func (m *APIKeyMutation) AddOrganizationID(i int64) {
+	if m.addorganization_id != nil {
+		*m.addorganization_id += i
+	} else {
+		m.addorganization_id = &i
 	}
-	return nil
 }
 
-// RemovedEdges returns all edge names that were removed in this mutation.
-func (m *APIKeyMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.removedusage_logs != nil {
-		edges = append(edges, apikey.EdgeUsageLogs)
+// AddedOrganizationID returns the value that was added to the "organization_id" field in this mutation.
+func (m *APIKeyMutation) AddedOrganizationID() (r int64, exists bool) {
+	v := m.addorganization_id
+	if v == nil {
+		return
 	}
-	return edges
+	return *v, true
 }
 
-// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
-// the given name in this mutation.
-func (m *APIKeyMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case apikey.EdgeUsageLogs:
-		ids := make([]ent.Value, 0, len(m.removedusage_logs))
-		for id := range m.removedusage_logs {
-			ids = append(ids, id)
-		}
-		return ids
-	}
-	return nil
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (m *APIKeyMutation) ClearOrganizationID() {
+	m.organization_id = nil
+	m.addorganization_id = nil
+	m.clearedFields[apikey.FieldOrganizationID] = struct{}{}
 }
 
-// ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *APIKeyMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.cleareduser {
-		edges = append(edges, apikey.EdgeUser)
-	}
-	if m.clearedgroup {
-		edges = append(edges, apikey.EdgeGroup)
-	}
-	if m.clearedusage_logs {
-		edges = append(edges, apikey.EdgeUsageLogs)
-	}
-	return edges
+// OrganizationIDCleared returns if the "organization_id" field was cleared in this mutation.
+func (m *APIKeyMutation) OrganizationIDCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldOrganizationID]
+	return ok
 }
 
-// EdgeCleared returns a boolean which indicates if the edge with the given name
-// was cleared in this mutation.
-func (m *APIKeyMutation) EdgeCleared(name string) bool {
-	switch name {
-	case apikey.EdgeUser:
-		return m.cleareduser
-	case apikey.EdgeGroup:
-		return m.clearedgroup
-	case apikey.EdgeUsageLogs:
-		return m.clearedusage_logs
-	}
-	return false
+// ResetOrganizationID resets all changes to the "organization_id" field.
+func (m *APIKeyMutation) ResetOrganizationID() {
+	m.organization_id = nil
+	m.addorganization_id = nil
+	delete(m.clearedFields, apikey.FieldOrganizationID)
 }
 
-// ClearEdge clears the value of the edge with the given name. It returns an error
-// if that edge is not defined in the schema.
-func (m *APIKeyMutation) ClearEdge(name string) error {
-	switch name {
-	case apikey.EdgeUser:
-		m.ClearUser()
-		return nil
-	case apikey.EdgeGroup:
-		m.ClearGroup()
-		return nil
-	}
-	return fmt.Errorf("unknown APIKey unique edge %s", name)
+// ClearUser clears the "user" edge to the User entity.
+func (m *APIKeyMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[apikey.FieldUserID] = struct{}{}
 }
 
-// ResetEdge resets all changes to the edge with the given name in this mutation.
-// It returns an error if the edge is not defined in the schema.
-func (m *APIKeyMutation) ResetEdge(name string) error {
-	switch name {
-	case apikey.EdgeUser:
-		m.ResetUser()
-		return nil
-	case apikey.EdgeGroup:
-		m.ResetGroup()
-		return nil
-	case apikey.EdgeUsageLogs:
-		m.ResetUsageLogs()
-		return nil
-	}
-	return fmt.Errorf("unknown APIKey edge %s", name)
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *APIKeyMutation) UserCleared() bool {
+	return m.cleareduser
 }
 
-// AccountMutation represents an operation that mutates the Account nodes in the graph.
-type AccountMutation struct {
-	config
-	op                    Op
-	typ                   string
-	id                    *int64
-	created_at            *time.Time
-	updated_at            *time.Time
-	deleted_at            *time.Time
-	name                  *string
-	notes                 *string
-	platform              *string
-	_type                 *string
-	credentials           *map[string]interface{}
-	extra                 *map[string]interface{}
-	concurrency           *int
-	addconcurrency        *int
-	priority              *int
-	addpriority           *int
-	rate_multiplier       *float64
-	addrate_multiplier    *float64
-	status                *string
-	error_message         *string
-	last_used_at          *time.Time
-	expires_at            *time.Time
-	auto_pause_on_expired *bool
-	schedulable           *bool
-	rate_limited_at       *time.Time
-	rate_limit_reset_at   *time.Time
-	overload_until        *time.Time
-	session_window_start  *time.Time
-	session_window_end    *time.Time
-	session_window_status *string
-	clearedFields         map[string]struct{}
-	groups                map[int64]struct{}
-	removedgroups         map[int64]struct{}
-	clearedgroups         bool
-	proxy                 *int64
-	clearedproxy          bool
-	usage_logs            map[int64]struct{}
-	removedusage_logs     map[int64]struct{}
-	clearedusage_logs     bool
-	done                  bool
-	oldValue              func(context.Context) (*Account, error)
-	predicates            []predicate.Account
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *APIKeyMutation) UserIDs() (ids []int64) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
+	}
+	return
 }
 
-var _ ent.Mutation = (*AccountMutation)(nil)
-
-// accountOption allows management of the mutation configuration using functional options.
-type accountOption func(*AccountMutation)
+// ResetUser resets all changes to the "user" edge.
+func (m *APIKeyMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
+}
 
-// newAccountMutation creates new mutation for the Account entity.
-func newAccountMutation(c config, op Op, opts ...accountOption) *AccountMutation {
-	m := &AccountMutation{
-		config:        c,
-		op:            op,
-		typ:           TypeAccount,
-		clearedFields: make(map[string]struct{}),
-	}
-	for _, opt := range opts {
-		opt(m)
-	}
-	return m
+// ClearGroup clears the "group" edge to the Group entity.
+func (m *APIKeyMutation) ClearGroup() {
+	m.clearedgroup = true
+	m.clearedFields[apikey.FieldGroupID] = struct{}{}
 }
 
-// withAccountID sets the ID field of the mutation.
-func withAccountID(id int64) accountOption {
-	return func(m *AccountMutation) {
-		var (
-			err   error
-			once  sync.Once
-			value *Account
-		)
-		m.oldValue = func(ctx context.Context) (*Account, error) {
-			once.Do(func() {
-				if m.done {
-					err = errors.New("querying old values post mutation is not allowed")
-				} else {
-					value, err = m.Client().Account.Get(ctx, id)
-				}
-			})
-			return value, err
-		}
-		m.id = &id
-	}
+// GroupCleared reports if the "group" edge to the Group entity was cleared.
+func (m *APIKeyMutation) GroupCleared() bool {
+	return m.GroupIDCleared() || m.clearedgroup
 }
 
-// withAccount sets the old Account of the mutation.
-func withAccount(node *Account) accountOption {
-	return func(m *AccountMutation) {
-		m.oldValue = func(context.Context) (*Account, error) {
-			return node, nil
-		}
-		m.id = &node.ID
+// GroupIDs returns the "group" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// GroupID instead. It exists only for internal usage by the builders.
+func (m *APIKeyMutation) GroupIDs() (ids []int64) {
+	if id := m.group; id != nil {
+		ids = append(ids, *id)
 	}
+	return
 }
 
-// Client returns a new `ent.Client` from the mutation. If the mutation was
-// executed in a transaction (ent.Tx), a transactional client is returned.
-func (m AccountMutation) Client() *Client {
-	client := &Client{config: m.config}
-	client.init()
-	return client
+// ResetGroup resets all changes to the "group" edge.
+func (m *APIKeyMutation) ResetGroup() {
+	m.group = nil
+	m.clearedgroup = false
 }
 
-// Tx returns an `ent.Tx` for mutations that were executed in transactions;
-// it returns an error otherwise.
-func (m AccountMutation) Tx() (*Tx, error) {
-	if _, ok := m.driver.(*txDriver); !ok {
-		return nil, errors.New("ent: mutation is not running in a transaction")
+// AddUsageLogIDs adds the "usage_logs" edge to the UsageLog entity by ids.
+func (m *APIKeyMutation) AddUsageLogIDs(ids ...int64) {
+	if m.usage_logs == nil {
+		m.usage_logs = make(map[int64]struct{})
+	}
+	for i := range ids {
+		m.usage_logs[ids[i]] = struct{}{}
 	}
-	tx := &Tx{config: m.config}
-	tx.init()
-	return tx, nil
 }
 
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *AccountMutation) ID() (id int64, exists bool) {
-	if m.id == nil {
-		return
-	}
-	return *m.id, true
+// ClearUsageLogs clears the "usage_logs" edge to the UsageLog entity.
+func (m *APIKeyMutation) ClearUsageLogs() {
+	m.clearedusage_logs = true
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *AccountMutation) IDs(ctx context.Context) ([]int64, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []int64{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Account.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
-	}
+// UsageLogsCleared reports if the "usage_logs" edge to the UsageLog entity was cleared.
+func (m *APIKeyMutation) UsageLogsCleared() bool {
+	return m.clearedusage_logs
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *AccountMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// RemoveUsageLogIDs removes the "usage_logs" edge to the UsageLog entity by IDs.
+func (m *APIKeyMutation) RemoveUsageLogIDs(ids ...int64) {
+	if m.removedusage_logs == nil {
+		m.removedusage_logs = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.usage_logs, ids[i])
+		m.removedusage_logs[ids[i]] = struct{}{}
+	}
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *AccountMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
-	if v == nil {
-		return
+// RemovedUsageLogs returns the removed IDs of the "usage_logs" edge to the UsageLog entity.
+func (m *APIKeyMutation) RemovedUsageLogsIDs() (ids []int64) {
+	for id := range m.removedusage_logs {
+		ids = append(ids, id)
 	}
-	return *v, true
+	return
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+// UsageLogsIDs returns the "usage_logs" edge IDs in the mutation.
+func (m *APIKeyMutation) UsageLogsIDs() (ids []int64) {
+	for id := range m.usage_logs {
+		ids = append(ids, id)
 	}
-	return oldValue.CreatedAt, nil
+	return
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *AccountMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetUsageLogs resets all changes to the "usage_logs" edge.
+func (m *APIKeyMutation) ResetUsageLogs() {
+	m.usage_logs = nil
+	m.clearedusage_logs = false
+	m.removedusage_logs = nil
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *AccountMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// Where appends a list predicates to the APIKeyMutation builder.
+func (m *APIKeyMutation) Where(ps ...predicate.APIKey) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *AccountMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
-	if v == nil {
-		return
+// WhereP appends storage-level predicates to the APIKeyMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *APIKeyMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.APIKey, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
 	}
-	return *v, true
+	m.Where(p...)
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
-	}
-	return oldValue.UpdatedAt, nil
+// Op returns the operation name.
+func (m *APIKeyMutation) Op() Op {
+	return m.op
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *AccountMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// SetOp allows setting the mutation operation.
+func (m *APIKeyMutation) SetOp(op Op) {
+	m.op = op
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (m *AccountMutation) SetDeletedAt(t time.Time) {
-	m.deleted_at = &t
+// Type returns the node type of this mutation (APIKey).
+func (m *APIKeyMutation) Type() string {
+	return m.typ
 }
 
-// DeletedAt returns the value of the "deleted_at" field in the mutation.
-func (m *AccountMutation) DeletedAt() (r time.Time, exists bool) {
-	v := m.deleted_at
-	if v == nil {
-		return
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *APIKeyMutation) Fields() []string {
+	fields := make([]string, 0, 18)
+	if m.created_at != nil {
+		fields = append(fields, apikey.FieldCreatedAt)
 	}
-	return *v, true
-}
-
-// OldDeletedAt returns the old "deleted_at" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
+	if m.updated_at != nil {
+		fields = append(fields, apikey.FieldUpdatedAt)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+	if m.deleted_at != nil {
+		fields = append(fields, apikey.FieldDeletedAt)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+	if m.user != nil {
+		fields = append(fields, apikey.FieldUserID)
 	}
-	return oldValue.DeletedAt, nil
-}
-
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (m *AccountMutation) ClearDeletedAt() {
-	m.deleted_at = nil
-	m.clearedFields[account.FieldDeletedAt] = struct{}{}
-}
-
-// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
-func (m *AccountMutation) DeletedAtCleared() bool {
-	_, ok := m.clearedFields[account.FieldDeletedAt]
-	return ok
-}
-
-// ResetDeletedAt resets all changes to the "deleted_at" field.
-func (m *AccountMutation) ResetDeletedAt() {
-	m.deleted_at = nil
-	delete(m.clearedFields, account.FieldDeletedAt)
-}
-
-// SetName sets the "name" field.
-func (m *AccountMutation) SetName(s string) {
-	m.name = &s
-}
-
-// Name returns the value of the "name" field in the mutation.
-func (m *AccountMutation) Name() (r string, exists bool) {
-	v := m.name
-	if v == nil {
-		return
+	if m.key != nil {
+		fields = append(fields, apikey.FieldKey)
 	}
-	return *v, true
-}
-
-// OldName returns the old "name" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldName(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	if m.name != nil {
+		fields = append(fields, apikey.FieldName)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
+	if m.group != nil {
+		fields = append(fields, apikey.FieldGroupID)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	if m.status != nil {
+		fields = append(fields, apikey.FieldStatus)
 	}
-	return oldValue.Name, nil
-}
-
-// ResetName resets all changes to the "name" field.
-func (m *AccountMutation) ResetName() {
-	m.name = nil
-}
-
-// SetNotes sets the "notes" field.
-func (m *AccountMutation) SetNotes(s string) {
-	m.notes = &s
-}
-
-// Notes returns the value of the "notes" field in the mutation.
-func (m *AccountMutation) Notes() (r string, exists bool) {
-	v := m.notes
-	if v == nil {
-		return
+	if m.ip_whitelist != nil {
+		fields = append(fields, apikey.FieldIPWhitelist)
 	}
-	return *v, true
-}
-
-// OldNotes returns the old "notes" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldNotes(ctx context.Context) (v *string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldNotes is only allowed on UpdateOne operations")
+	if m.ip_blacklist != nil {
+		fields = append(fields, apikey.FieldIPBlacklist)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldNotes requires an ID field in the mutation")
+	if m.quota_usd != nil {
+		fields = append(fields, apikey.FieldQuotaUsd)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldNotes: %w", err)
+	if m.used_usd != nil {
+		fields = append(fields, apikey.FieldUsedUsd)
 	}
-	return oldValue.Notes, nil
-}
-
-// ClearNotes clears the value of the "notes" field.
-func (m *AccountMutation) ClearNotes() {
-	m.notes = nil
-	m.clearedFields[account.FieldNotes] = struct{}{}
-}
-
-// NotesCleared returns if the "notes" field was cleared in this mutation.
-func (m *AccountMutation) NotesCleared() bool {
-	_, ok := m.clearedFields[account.FieldNotes]
-	return ok
-}
-
-// ResetNotes resets all changes to the "notes" field.
-func (m *AccountMutation) ResetNotes() {
-	m.notes = nil
-	delete(m.clearedFields, account.FieldNotes)
-}
-
-// SetPlatform sets the "platform" field.
-func (m *AccountMutation) SetPlatform(s string) {
-	m.platform = &s
-}
-
-// Platform returns the value of the "platform" field in the mutation.
-func (m *AccountMutation) Platform() (r string, exists bool) {
-	v := m.platform
-	if v == nil {
-		return
+	if m.expires_at != nil {
+		fields = append(fields, apikey.FieldExpiresAt)
 	}
-	return *v, true
-}
-
-// OldPlatform returns the old "platform" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldPlatform(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPlatform is only allowed on UpdateOne operations")
+	if m.allowed_models != nil {
+		fields = append(fields, apikey.FieldAllowedModels)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPlatform requires an ID field in the mutation")
+	if m.max_tokens_cap != nil {
+		fields = append(fields, apikey.FieldMaxTokensCap)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPlatform: %w", err)
+	if m.rpm_limit != nil {
+		fields = append(fields, apikey.FieldRpmLimit)
 	}
-	return oldValue.Platform, nil
+	if m.tpm_limit != nil {
+		fields = append(fields, apikey.FieldTpmLimit)
+	}
+	if m.organization_id != nil {
+		fields = append(fields, apikey.FieldOrganizationID)
+	}
+	return fields
 }
 
-// ResetPlatform resets all changes to the "platform" field.
-func (m *AccountMutation) ResetPlatform() {
-	m.platform = nil
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *APIKeyMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case apikey.FieldCreatedAt:
+		return m.CreatedAt()
+	case apikey.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case apikey.FieldDeletedAt:
+		return m.DeletedAt()
+	case apikey.FieldUserID:
+		return m.UserID()
+	case apikey.FieldKey:
+		return m.Key()
+	case apikey.FieldName:
+		return m.Name()
+	case apikey.FieldGroupID:
+		return m.GroupID()
+	case apikey.FieldStatus:
+		return m.Status()
+	case apikey.FieldIPWhitelist:
+		return m.IPWhitelist()
+	case apikey.FieldIPBlacklist:
+		return m.IPBlacklist()
+	case apikey.FieldQuotaUsd:
+		return m.QuotaUsd()
+	case apikey.FieldUsedUsd:
+		return m.UsedUsd()
+	case apikey.FieldExpiresAt:
+		return m.ExpiresAt()
+	case apikey.FieldAllowedModels:
+		return m.AllowedModels()
+	case apikey.FieldMaxTokensCap:
+		return m.MaxTokensCap()
+	case apikey.FieldRpmLimit:
+		return m.RpmLimit()
+	case apikey.FieldTpmLimit:
+		return m.TpmLimit()
+	case apikey.FieldOrganizationID:
+		return m.OrganizationID()
+	}
+	return nil, false
 }
 
-// SetType sets the "type" field.
-func (m *AccountMutation) SetType(s string) {
-	m._type = &s
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *APIKeyMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case apikey.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case apikey.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case apikey.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case apikey.FieldUserID:
+		return m.OldUserID(ctx)
+	case apikey.FieldKey:
+		return m.OldKey(ctx)
+	case apikey.FieldName:
+		return m.OldName(ctx)
+	case apikey.FieldGroupID:
+		return m.OldGroupID(ctx)
+	case apikey.FieldStatus:
+		return m.OldStatus(ctx)
+	case apikey.FieldIPWhitelist:
+		return m.OldIPWhitelist(ctx)
+	case apikey.FieldIPBlacklist:
+		return m.OldIPBlacklist(ctx)
+	case apikey.FieldQuotaUsd:
+		return m.OldQuotaUsd(ctx)
+	case apikey.FieldUsedUsd:
+		return m.OldUsedUsd(ctx)
+	case apikey.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case apikey.FieldAllowedModels:
+		return m.OldAllowedModels(ctx)
+	case apikey.FieldMaxTokensCap:
+		return m.OldMaxTokensCap(ctx)
+	case apikey.FieldRpmLimit:
+		return m.OldRpmLimit(ctx)
+	case apikey.FieldTpmLimit:
+		return m.OldTpmLimit(ctx)
+	case apikey.FieldOrganizationID:
+		return m.OldOrganizationID(ctx)
+	}
+	return nil, fmt.Errorf("unknown APIKey field %s", name)
 }
 
-// GetType returns the value of the "type" field in the mutation.
-func (m *AccountMutation) GetType() (r string, exists bool) {
-	v := m._type
-	if v == nil {
-		return
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *APIKeyMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case apikey.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case apikey.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case apikey.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case apikey.FieldUserID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case apikey.FieldKey:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetKey(v)
+		return nil
+	case apikey.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case apikey.FieldGroupID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetGroupID(v)
+		return nil
+	case apikey.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case apikey.FieldIPWhitelist:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIPWhitelist(v)
+		return nil
+	case apikey.FieldIPBlacklist:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIPBlacklist(v)
+		return nil
+	case apikey.FieldQuotaUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQuotaUsd(v)
+		return nil
+	case apikey.FieldUsedUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsedUsd(v)
+		return nil
+	case apikey.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	case apikey.FieldAllowedModels:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowedModels(v)
+		return nil
+	case apikey.FieldMaxTokensCap:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxTokensCap(v)
+		return nil
+	case apikey.FieldRpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRpmLimit(v)
+		return nil
+	case apikey.FieldTpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTpmLimit(v)
+		return nil
+	case apikey.FieldOrganizationID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOrganizationID(v)
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown APIKey field %s", name)
 }
 
-// OldType returns the old "type" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldType(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *APIKeyMutation) AddedFields() []string {
+	var fields []string
+	if m.addquota_usd != nil {
+		fields = append(fields, apikey.FieldQuotaUsd)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
+	if m.addused_usd != nil {
+		fields = append(fields, apikey.FieldUsedUsd)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+	if m.addmax_tokens_cap != nil {
+		fields = append(fields, apikey.FieldMaxTokensCap)
 	}
-	return oldValue.Type, nil
-}
-
-// ResetType resets all changes to the "type" field.
-func (m *AccountMutation) ResetType() {
-	m._type = nil
+	if m.addrpm_limit != nil {
+		fields = append(fields, apikey.FieldRpmLimit)
+	}
+	if m.addtpm_limit != nil {
+		fields = append(fields, apikey.FieldTpmLimit)
+	}
+	if m.addorganization_id != nil {
+		fields = append(fields, apikey.FieldOrganizationID)
+	}
+	return fields
 }
 
-// SetCredentials sets the "credentials" field.
-func (m *AccountMutation) SetCredentials(value map[string]interface{}) {
-	m.credentials = &value
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *APIKeyMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case apikey.FieldQuotaUsd:
+		return m.AddedQuotaUsd()
+	case apikey.FieldUsedUsd:
+		return m.AddedUsedUsd()
+	case apikey.FieldMaxTokensCap:
+		return m.AddedMaxTokensCap()
+	case apikey.FieldRpmLimit:
+		return m.AddedRpmLimit()
+	case apikey.FieldTpmLimit:
+		return m.AddedTpmLimit()
+	case apikey.FieldOrganizationID:
+		return m.AddedOrganizationID()
+	}
+	return nil, false
 }
 
-// Credentials returns the value of the "credentials" field in the mutation.
-func (m *AccountMutation) Credentials() (r map[string]interface{}, exists bool) {
-	v := m.credentials
-	if v == nil {
-		return
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *APIKeyMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case apikey.FieldQuotaUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddQuotaUsd(v)
+		return nil
+	case apikey.FieldUsedUsd:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddUsedUsd(v)
+		return nil
+	case apikey.FieldMaxTokensCap:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxTokensCap(v)
+		return nil
+	case apikey.FieldRpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRpmLimit(v)
+		return nil
+	case apikey.FieldTpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTpmLimit(v)
+		return nil
+	case apikey.FieldOrganizationID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddOrganizationID(v)
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown APIKey numeric field %s", name)
 }
 
-// OldCredentials returns the old "credentials" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldCredentials(ctx context.Context) (v map[string]interface{}, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCredentials is only allowed on UpdateOne operations")
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *APIKeyMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(apikey.FieldDeletedAt) {
+		fields = append(fields, apikey.FieldDeletedAt)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCredentials requires an ID field in the mutation")
+	if m.FieldCleared(apikey.FieldGroupID) {
+		fields = append(fields, apikey.FieldGroupID)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCredentials: %w", err)
+	if m.FieldCleared(apikey.FieldIPWhitelist) {
+		fields = append(fields, apikey.FieldIPWhitelist)
 	}
-	return oldValue.Credentials, nil
-}
-
-// ResetCredentials resets all changes to the "credentials" field.
-func (m *AccountMutation) ResetCredentials() {
-	m.credentials = nil
-}
-
-// SetExtra sets the "extra" field.
-func (m *AccountMutation) SetExtra(value map[string]interface{}) {
-	m.extra = &value
-}
-
-// Extra returns the value of the "extra" field in the mutation.
-func (m *AccountMutation) Extra() (r map[string]interface{}, exists bool) {
-	v := m.extra
-	if v == nil {
-		return
+	if m.FieldCleared(apikey.FieldIPBlacklist) {
+		fields = append(fields, apikey.FieldIPBlacklist)
 	}
-	return *v, true
-}
-
-// OldExtra returns the old "extra" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldExtra(ctx context.Context) (v map[string]interface{}, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldExtra is only allowed on UpdateOne operations")
+	if m.FieldCleared(apikey.FieldQuotaUsd) {
+		fields = append(fields, apikey.FieldQuotaUsd)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldExtra requires an ID field in the mutation")
+	if m.FieldCleared(apikey.FieldExpiresAt) {
+		fields = append(fields, apikey.FieldExpiresAt)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldExtra: %w", err)
+	if m.FieldCleared(apikey.FieldAllowedModels) {
+		fields = append(fields, apikey.FieldAllowedModels)
 	}
-	return oldValue.Extra, nil
+	if m.FieldCleared(apikey.FieldMaxTokensCap) {
+		fields = append(fields, apikey.FieldMaxTokensCap)
+	}
+	if m.FieldCleared(apikey.FieldRpmLimit) {
+		fields = append(fields, apikey.FieldRpmLimit)
+	}
+	if m.FieldCleared(apikey.FieldTpmLimit) {
+		fields = append(fields, apikey.FieldTpmLimit)
+	}
+	if m.FieldCleared(apikey.FieldOrganizationID) {
+		fields = append(fields, apikey.FieldOrganizationID)
+	}
+	return fields
 }
 
-// ResetExtra resets all changes to the "extra" field.
-func (m *AccountMutation) ResetExtra() {
-	m.extra = nil
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *APIKeyMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
 }
 
-// SetProxyID sets the "proxy_id" field.
-func (m *AccountMutation) SetProxyID(i int64) {
-	m.proxy = &i
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *APIKeyMutation) ClearField(name string) error {
+	switch name {
+	case apikey.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	case apikey.FieldGroupID:
+		m.ClearGroupID()
+		return nil
+	case apikey.FieldIPWhitelist:
+		m.ClearIPWhitelist()
+		return nil
+	case apikey.FieldIPBlacklist:
+		m.ClearIPBlacklist()
+		return nil
+	case apikey.FieldQuotaUsd:
+		m.ClearQuotaUsd()
+		return nil
+	case apikey.FieldExpiresAt:
+		m.ClearExpiresAt()
+		return nil
+	case apikey.FieldAllowedModels:
+		m.ClearAllowedModels()
+		return nil
+	case apikey.FieldMaxTokensCap:
+		m.ClearMaxTokensCap()
+		return nil
+	case apikey.FieldRpmLimit:
+		m.ClearRpmLimit()
+		return nil
+	case apikey.FieldTpmLimit:
+		m.ClearTpmLimit()
+		return nil
+	case apikey.FieldOrganizationID:
+		m.ClearOrganizationID()
+		return nil
+	}
+	return fmt.Errorf("unknown APIKey nullable field %s", name)
 }
 
-// ProxyID returns the value of the "proxy_id" field in the mutation.
-func (m *AccountMutation) ProxyID() (r int64, exists bool) {
-	v := m.proxy
-	if v == nil {
-		return
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *APIKeyMutation) ResetField(name string) error {
+	switch name {
+	case apikey.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case apikey.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case apikey.FieldDeletedAt:
+		m.ResetDeletedAt()
+		return nil
+	case apikey.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case apikey.FieldKey:
+		m.ResetKey()
+		return nil
+	case apikey.FieldName:
+		m.ResetName()
+		return nil
+	case apikey.FieldGroupID:
+		m.ResetGroupID()
+		return nil
+	case apikey.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case apikey.FieldIPWhitelist:
+		m.ResetIPWhitelist()
+		return nil
+	case apikey.FieldIPBlacklist:
+		m.ResetIPBlacklist()
+		return nil
+	case apikey.FieldQuotaUsd:
+		m.ResetQuotaUsd()
+		return nil
+	case apikey.FieldUsedUsd:
+		m.ResetUsedUsd()
+		return nil
+	case apikey.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	case apikey.FieldAllowedModels:
+		m.ResetAllowedModels()
+		return nil
+	case apikey.FieldMaxTokensCap:
+		m.ResetMaxTokensCap()
+		return nil
+	case apikey.FieldRpmLimit:
+		m.ResetRpmLimit()
+		return nil
+	case apikey.FieldTpmLimit:
+		m.ResetTpmLimit()
+		return nil
+	case apikey.FieldOrganizationID:
+		m.ResetOrganizationID()
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown APIKey field %s", name)
 }
 
-// OldProxyID returns the old "proxy_id" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldProxyID(ctx context.Context) (v *int64, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldProxyID is only allowed on UpdateOne operations")
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *APIKeyMutation) AddedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.user != nil {
+		edges = append(edges, apikey.EdgeUser)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldProxyID requires an ID field in the mutation")
+	if m.group != nil {
+		edges = append(edges, apikey.EdgeGroup)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldProxyID: %w", err)
+	if m.usage_logs != nil {
+		edges = append(edges, apikey.EdgeUsageLogs)
 	}
-	return oldValue.ProxyID, nil
+	return edges
 }
 
-// ClearProxyID clears the value of the "proxy_id" field.
-func (m *AccountMutation) ClearProxyID() {
-	m.proxy = nil
-	m.clearedFields[account.FieldProxyID] = struct{}{}
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *APIKeyMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case apikey.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	case apikey.EdgeGroup:
+		if id := m.group; id != nil {
+			return []ent.Value{*id}
+		}
+	case apikey.EdgeUsageLogs:
+		ids := make([]ent.Value, 0, len(m.usage_logs))
+		for id := range m.usage_logs {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
 }
 
-// ProxyIDCleared returns if the "proxy_id" field was cleared in this mutation.
-func (m *AccountMutation) ProxyIDCleared() bool {
-	_, ok := m.clearedFields[account.FieldProxyID]
-	return ok
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *APIKeyMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.removedusage_logs != nil {
+		edges = append(edges, apikey.EdgeUsageLogs)
+	}
+	return edges
 }
 
-// ResetProxyID resets all changes to the "proxy_id" field.
-func (m *AccountMutation) ResetProxyID() {
-	m.proxy = nil
-	delete(m.clearedFields, account.FieldProxyID)
-}
-
-// SetConcurrency sets the "concurrency" field.
-func (m *AccountMutation) SetConcurrency(i int) {
-	m.concurrency = &i
-	m.addconcurrency = nil
-}
-
-// Concurrency returns the value of the "concurrency" field in the mutation.
-func (m *AccountMutation) Concurrency() (r int, exists bool) {
-	v := m.concurrency
-	if v == nil {
-		return
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *APIKeyMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case apikey.EdgeUsageLogs:
+		ids := make([]ent.Value, 0, len(m.removedusage_logs))
+		for id := range m.removedusage_logs {
+			ids = append(ids, id)
+		}
+		return ids
 	}
-	return *v, true
+	return nil
 }
 
-// OldConcurrency returns the old "concurrency" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldConcurrency(ctx context.Context) (v int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldConcurrency is only allowed on UpdateOne operations")
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *APIKeyMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.cleareduser {
+		edges = append(edges, apikey.EdgeUser)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldConcurrency requires an ID field in the mutation")
+	if m.clearedgroup {
+		edges = append(edges, apikey.EdgeGroup)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldConcurrency: %w", err)
+	if m.clearedusage_logs {
+		edges = append(edges, apikey.EdgeUsageLogs)
 	}
-	return oldValue.Concurrency, nil
+	return edges
 }
 
-// AddConcurrency adds i to the "concurrency" field.
-func (m *AccountMutation) AddConcurrency(i int) {
-	if m.addconcurrency != nil {
-		*m.addconcurrency += i
-	} else {
-		m.addconcurrency = &i
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *APIKeyMutation) EdgeCleared(name string) bool {
+	switch name {
+	case apikey.EdgeUser:
+		return m.cleareduser
+	case apikey.EdgeGroup:
+		return m.clearedgroup
+	case apikey.EdgeUsageLogs:
+		return m.clearedusage_logs
 	}
+	return false
 }
 
-// AddedConcurrency returns the value that was added to the "concurrency" field in this mutation.
-func (m *AccountMutation) AddedConcurrency() (r int, exists bool) {
-	v := m.addconcurrency
-	if v == nil {
-		return
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *APIKeyMutation) ClearEdge(name string) error {
+	switch name {
+	case apikey.EdgeUser:
+		m.ClearUser()
+		return nil
+	case apikey.EdgeGroup:
+		m.ClearGroup()
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown APIKey unique edge %s", name)
 }
 
-// ResetConcurrency resets all changes to the "concurrency" field.
-func (m *AccountMutation) ResetConcurrency() {
-	m.concurrency = nil
-	m.addconcurrency = nil
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *APIKeyMutation) ResetEdge(name string) error {
+	switch name {
+	case apikey.EdgeUser:
+		m.ResetUser()
+		return nil
+	case apikey.EdgeGroup:
+		m.ResetGroup()
+		return nil
+	case apikey.EdgeUsageLogs:
+		m.ResetUsageLogs()
+		return nil
+	}
+	return fmt.Errorf("unknown APIKey edge %s", name)
 }
 
-// SetPriority sets the "priority" field.
-func (m *AccountMutation) SetPriority(i int) {
-	m.priority = &i
-	m.addpriority = nil
+// AccountMutation represents an operation that mutates the Account nodes in the graph.
+type AccountMutation struct {
+	config
+	op                    Op
+	typ                   string
+	id                    *int64
+	created_at            *time.Time
+	updated_at            *time.Time
+	deleted_at            *time.Time
+	name                  *string
+	notes                 *string
+	platform              *string
+	_type                 *string
+	credentials           *map[string]interface{}
+	extra                 *map[string]interface{}
+	concurrency           *int
+	addconcurrency        *int
+	priority              *int
+	addpriority           *int
+	rate_multiplier       *float64
+	addrate_multiplier    *float64
+	status                *string
+	error_message         *string
+	last_used_at          *time.Time
+	expires_at            *time.Time
+	auto_pause_on_expired *bool
+	schedulable           *bool
+	rate_limited_at       *time.Time
+	rate_limit_reset_at   *time.Time
+	overload_until        *time.Time
+	session_window_start  *time.Time
+	session_window_end    *time.Time
+	session_window_status *string
+	clearedFields         map[string]struct{}
+	groups                map[int64]struct{}
+	removedgroups         map[int64]struct{}
+	clearedgroups         bool
+	proxy                 *int64
+	clearedproxy          bool
+	usage_logs            map[int64]struct{}
+	removedusage_logs     map[int64]struct{}
+	clearedusage_logs     bool
+	done                  bool
+	oldValue              func(context.Context) (*Account, error)
+	predicates            []predicate.Account
 }
 
-// Priority returns the value of the "priority" field in the mutation.
-func (m *AccountMutation) Priority() (r int, exists bool) {
-	v := m.priority
-	if v == nil {
-		return
-	}
-	return *v, true
-}
+var _ ent.Mutation = (*AccountMutation)(nil)
 
-// OldPriority returns the old "priority" field's value of the Account entity.
-// If the Account object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldPriority(ctx context.Context) (v int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPriority is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPriority requires an ID field in the mutation")
+// accountOption allows management of the mutation configuration using functional options.
+type accountOption func(*AccountMutation)
+
+// newAccountMutation creates new mutation for the Account entity.
+func newAccountMutation(c config, op Op, opts ...accountOption) *AccountMutation {
+	m := &AccountMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeAccount,
+		clearedFields: make(map[string]struct{}),
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPriority: %w", err)
+	for _, opt := range opts {
+		opt(m)
 	}
-	return oldValue.Priority, nil
+	return m
 }
 
-// AddPriority adds i to the "priority" field.
-func (m *AccountMutation) AddPriority(i int) {
-	if m.addpriority != nil {
-		*m.addpriority += i
-	} else {
-		m.addpriority = &i
+// withAccountID sets the ID field of the mutation.
+func withAccountID(id int64) accountOption {
+	return func(m *AccountMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Account
+		)
+		m.oldValue = func(ctx context.Context) (*Account, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Account.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
 	}
 }
 
-// AddedPriority returns the value that was added to the "priority" field in this mutation.
-func (m *AccountMutation) AddedPriority() (r int, exists bool) {
-	v := m.addpriority
-	if v == nil {
-		return
+// withAccount sets the old Account of the mutation.
+func withAccount(node *Account) accountOption {
+	return func(m *AccountMutation) {
+		m.oldValue = func(context.Context) (*Account, error) {
+			return node, nil
+		}
+		m.id = &node.ID
 	}
-	return *v, true
 }
 
-// ResetPriority resets all changes to the "priority" field.
-func (m *AccountMutation) ResetPriority() {
-	m.priority = nil
-	m.addpriority = nil
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m AccountMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
 }
 
-// SetRateMultiplier sets the "rate_multiplier" field.
-func (m *AccountMutation) SetRateMultiplier(f float64) {
-	m.rate_multiplier = &f
-	m.addrate_multiplier = nil
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m AccountMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// RateMultiplier returns the value of the "rate_multiplier" field in the mutation.
-func (m *AccountMutation) RateMultiplier() (r float64, exists bool) {
-	v := m.rate_multiplier
-	if v == nil {
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *AccountMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *AccountMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Account.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *AccountMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *AccountMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRateMultiplier returns the old "rate_multiplier" field's value of the Account entity.
+// OldCreatedAt returns the old "created_at" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldRateMultiplier(ctx context.Context) (v float64, err error) {
+func (m *AccountMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRateMultiplier is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRateMultiplier requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRateMultiplier: %w", err)
-	}
-	return oldValue.RateMultiplier, nil
-}
-
-// AddRateMultiplier adds f to the "rate_multiplier" field.
-func (m *AccountMutation) AddRateMultiplier(f float64) {
-	if m.addrate_multiplier != nil {
-		*m.addrate_multiplier += f
-	} else {
-		m.addrate_multiplier = &f
-	}
-}
-
-// AddedRateMultiplier returns the value that was added to the "rate_multiplier" field in this mutation.
-func (m *AccountMutation) AddedRateMultiplier() (r float64, exists bool) {
-	v := m.addrate_multiplier
-	if v == nil {
-		return
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return *v, true
+	return oldValue.CreatedAt, nil
 }
 
-// ResetRateMultiplier resets all changes to the "rate_multiplier" field.
-func (m *AccountMutation) ResetRateMultiplier() {
-	m.rate_multiplier = nil
-	m.addrate_multiplier = nil
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *AccountMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// SetStatus sets the "status" field.
-func (m *AccountMutation) SetStatus(s string) {
-	m.status = &s
+// SetUpdatedAt sets the "updated_at" field.
+func (m *AccountMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
 }
 
-// Status returns the value of the "status" field in the mutation.
-func (m *AccountMutation) Status() (r string, exists bool) {
-	v := m.status
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *AccountMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldStatus returns the old "status" field's value of the Account entity.
+// OldUpdatedAt returns the old "updated_at" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldStatus(ctx context.Context) (v string, err error) {
+func (m *AccountMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldStatus requires an ID field in the mutation")
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
-	return oldValue.Status, nil
+	return oldValue.UpdatedAt, nil
 }
 
-// ResetStatus resets all changes to the "status" field.
-func (m *AccountMutation) ResetStatus() {
-	m.status = nil
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *AccountMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// SetErrorMessage sets the "error_message" field.
-func (m *AccountMutation) SetErrorMessage(s string) {
-	m.error_message = &s
+// SetDeletedAt sets the "deleted_at" field.
+func (m *AccountMutation) SetDeletedAt(t time.Time) {
+	m.deleted_at = &t
 }
 
-// ErrorMessage returns the value of the "error_message" field in the mutation.
-func (m *AccountMutation) ErrorMessage() (r string, exists bool) {
-	v := m.error_message
+// DeletedAt returns the value of the "deleted_at" field in the mutation.
+func (m *AccountMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deleted_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldErrorMessage returns the old "error_message" field's value of the Account entity.
+// OldDeletedAt returns the old "deleted_at" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldErrorMessage(ctx context.Context) (v *string, err error) {
+func (m *AccountMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldErrorMessage is only allowed on UpdateOne operations")
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldErrorMessage requires an ID field in the mutation")
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldErrorMessage: %w", err)
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
 	}
-	return oldValue.ErrorMessage, nil
+	return oldValue.DeletedAt, nil
 }
 
-// ClearErrorMessage clears the value of the "error_message" field.
-func (m *AccountMutation) ClearErrorMessage() {
-	m.error_message = nil
-	m.clearedFields[account.FieldErrorMessage] = struct{}{}
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (m *AccountMutation) ClearDeletedAt() {
+	m.deleted_at = nil
+	m.clearedFields[account.FieldDeletedAt] = struct{}{}
 }
 
-// ErrorMessageCleared returns if the "error_message" field was cleared in this mutation.
-func (m *AccountMutation) ErrorMessageCleared() bool {
-	_, ok := m.clearedFields[account.FieldErrorMessage]
+// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
+func (m *AccountMutation) DeletedAtCleared() bool {
+	_, ok := m.clearedFields[account.FieldDeletedAt]
 	return ok
 }
 
-// ResetErrorMessage resets all changes to the "error_message" field.
-func (m *AccountMutation) ResetErrorMessage() {
-	m.error_message = nil
-	delete(m.clearedFields, account.FieldErrorMessage)
+// ResetDeletedAt resets all changes to the "deleted_at" field.
+func (m *AccountMutation) ResetDeletedAt() {
+	m.deleted_at = nil
+	delete(m.clearedFields, account.FieldDeletedAt)
 }
 
-// SetLastUsedAt sets the "last_used_at" field.
-func (m *AccountMutation) SetLastUsedAt(t time.Time) {
-	m.last_used_at = &t
+// SetName sets the "name" field.
+func (m *AccountMutation) SetName(s string) {
+	m.name = &s
 }
 
-// LastUsedAt returns the value of the "last_used_at" field in the mutation.
-func (m *AccountMutation) LastUsedAt() (r time.Time, exists bool) {
-	v := m.last_used_at
+// Name returns the value of the "name" field in the mutation.
+func (m *AccountMutation) Name() (r string, exists bool) {
+	v := m.name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldLastUsedAt returns the old "last_used_at" field's value of the Account entity.
+// OldName returns the old "name" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldLastUsedAt(ctx context.Context) (v *time.Time, err error) {
+func (m *AccountMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLastUsedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLastUsedAt requires an ID field in the mutation")
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLastUsedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return oldValue.LastUsedAt, nil
-}
-
-// ClearLastUsedAt clears the value of the "last_used_at" field.
-func (m *AccountMutation) ClearLastUsedAt() {
-	m.last_used_at = nil
-	m.clearedFields[account.FieldLastUsedAt] = struct{}{}
-}
-
-// LastUsedAtCleared returns if the "last_used_at" field was cleared in this mutation.
-func (m *AccountMutation) LastUsedAtCleared() bool {
-	_, ok := m.clearedFields[account.FieldLastUsedAt]
-	return ok
+	return oldValue.Name, nil
 }
 
-// ResetLastUsedAt resets all changes to the "last_used_at" field.
-func (m *AccountMutation) ResetLastUsedAt() {
-	m.last_used_at = nil
-	delete(m.clearedFields, account.FieldLastUsedAt)
+// ResetName resets all changes to the "name" field.
+func (m *AccountMutation) ResetName() {
+	m.name = nil
 }
 
-// SetExpiresAt sets the "expires_at" field.
-func (m *AccountMutation) SetExpiresAt(t time.Time) {
-	m.expires_at = &t
+// SetNotes sets the "notes" field.
+func (m *AccountMutation) SetNotes(s string) {
+	m.notes = &s
 }
 
-// ExpiresAt returns the value of the "expires_at" field in the mutation.
-func (m *AccountMutation) ExpiresAt() (r time.Time, exists bool) {
-	v := m.expires_at
+// Notes returns the value of the "notes" field in the mutation.
+func (m *AccountMutation) Notes() (r string, exists bool) {
+	v := m.notes
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldExpiresAt returns the old "expires_at" field's value of the Account entity.
+// OldNotes returns the old "notes" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
+func (m *AccountMutation) OldNotes(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldNotes is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+		return v, errors.New("OldNotes requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldNotes: %w", err)
 	}
-	return oldValue.ExpiresAt, nil
+	return oldValue.Notes, nil
 }
 
-// ClearExpiresAt clears the value of the "expires_at" field.
-func (m *AccountMutation) ClearExpiresAt() {
-	m.expires_at = nil
-	m.clearedFields[account.FieldExpiresAt] = struct{}{}
+// ClearNotes clears the value of the "notes" field.
+func (m *AccountMutation) ClearNotes() {
+	m.notes = nil
+	m.clearedFields[account.FieldNotes] = struct{}{}
 }
 
-// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
-func (m *AccountMutation) ExpiresAtCleared() bool {
-	_, ok := m.clearedFields[account.FieldExpiresAt]
+// NotesCleared returns if the "notes" field was cleared in this mutation.
+func (m *AccountMutation) NotesCleared() bool {
+	_, ok := m.clearedFields[account.FieldNotes]
 	return ok
 }
 
-// ResetExpiresAt resets all changes to the "expires_at" field.
-func (m *AccountMutation) ResetExpiresAt() {
-	m.expires_at = nil
-	delete(m.clearedFields, account.FieldExpiresAt)
+// ResetNotes resets all changes to the "notes" field.
+func (m *AccountMutation) ResetNotes() {
+	m.notes = nil
+	delete(m.clearedFields, account.FieldNotes)
 }
 
-// SetAutoPauseOnExpired sets the "auto_pause_on_expired" field.
-func (m *AccountMutation) SetAutoPauseOnExpired(b bool) {
-	m.auto_pause_on_expired = &b
+// SetPlatform sets the "platform" field.
+func (m *AccountMutation) SetPlatform(s string) {
+	m.platform = &s
 }
 
-// AutoPauseOnExpired returns the value of the "auto_pause_on_expired" field in the mutation.
-func (m *AccountMutation) AutoPauseOnExpired() (r bool, exists bool) {
-	v := m.auto_pause_on_expired
+// Platform returns the value of the "platform" field in the mutation.
+func (m *AccountMutation) Platform() (r string, exists bool) {
+	v := m.platform
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAutoPauseOnExpired returns the old "auto_pause_on_expired" field's value of the Account entity.
+// OldPlatform returns the old "platform" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldAutoPauseOnExpired(ctx context.Context) (v bool, err error) {
+func (m *AccountMutation) OldPlatform(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAutoPauseOnExpired is only allowed on UpdateOne operations")
+		return v, errors.New("OldPlatform is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAutoPauseOnExpired requires an ID field in the mutation")
+		return v, errors.New("OldPlatform requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAutoPauseOnExpired: %w", err)
+		return v, fmt.Errorf("querying old value for OldPlatform: %w", err)
 	}
-	return oldValue.AutoPauseOnExpired, nil
+	return oldValue.Platform, nil
 }
 
-// ResetAutoPauseOnExpired resets all changes to the "auto_pause_on_expired" field.
-func (m *AccountMutation) ResetAutoPauseOnExpired() {
-	m.auto_pause_on_expired = nil
+// ResetPlatform resets all changes to the "platform" field.
+func (m *AccountMutation) ResetPlatform() {
+	m.platform = nil
 }
 
-// SetSchedulable sets the "schedulable" field.
-func (m *AccountMutation) SetSchedulable(b bool) {
-	m.schedulable = &b
+// SetType sets the "type" field.
+func (m *AccountMutation) SetType(s string) {
+	m._type = &s
 }
 
-// Schedulable returns the value of the "schedulable" field in the mutation.
-func (m *AccountMutation) Schedulable() (r bool, exists bool) {
-	v := m.schedulable
+// GetType returns the value of the "type" field in the mutation.
+func (m *AccountMutation) GetType() (r string, exists bool) {
+	v := m._type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSchedulable returns the old "schedulable" field's value of the Account entity.
+// OldType returns the old "type" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldSchedulable(ctx context.Context) (v bool, err error) {
+func (m *AccountMutation) OldType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSchedulable is only allowed on UpdateOne operations")
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSchedulable requires an ID field in the mutation")
+		return v, errors.New("OldType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSchedulable: %w", err)
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
 	}
-	return oldValue.Schedulable, nil
+	return oldValue.Type, nil
 }
 
-// ResetSchedulable resets all changes to the "schedulable" field.
-func (m *AccountMutation) ResetSchedulable() {
-	m.schedulable = nil
+// ResetType resets all changes to the "type" field.
+func (m *AccountMutation) ResetType() {
+	m._type = nil
 }
 
-// SetRateLimitedAt sets the "rate_limited_at" field.
-func (m *AccountMutation) SetRateLimitedAt(t time.Time) {
-	m.rate_limited_at = &t
+// SetCredentials sets the "credentials" field.
+func (m *AccountMutation) SetCredentials(value map[string]interface{}) {
+	m.credentials = &value
 }
 
-// RateLimitedAt returns the value of the "rate_limited_at" field in the mutation.
-func (m *AccountMutation) RateLimitedAt() (r time.Time, exists bool) {
-	v := m.rate_limited_at
+// Credentials returns the value of the "credentials" field in the mutation.
+func (m *AccountMutation) Credentials() (r map[string]interface{}, exists bool) {
+	v := m.credentials
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRateLimitedAt returns the old "rate_limited_at" field's value of the Account entity.
+// OldCredentials returns the old "credentials" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldRateLimitedAt(ctx context.Context) (v *time.Time, err error) {
+func (m *AccountMutation) OldCredentials(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRateLimitedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldCredentials is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRateLimitedAt requires an ID field in the mutation")
+		return v, errors.New("OldCredentials requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRateLimitedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldCredentials: %w", err)
 	}
-	return oldValue.RateLimitedAt, nil
-}
-
-// ClearRateLimitedAt clears the value of the "rate_limited_at" field.
-func (m *AccountMutation) ClearRateLimitedAt() {
-	m.rate_limited_at = nil
-	m.clearedFields[account.FieldRateLimitedAt] = struct{}{}
-}
-
-// RateLimitedAtCleared returns if the "rate_limited_at" field was cleared in this mutation.
-func (m *AccountMutation) RateLimitedAtCleared() bool {
-	_, ok := m.clearedFields[account.FieldRateLimitedAt]
-	return ok
+	return oldValue.Credentials, nil
 }
 
-// ResetRateLimitedAt resets all changes to the "rate_limited_at" field.
-func (m *AccountMutation) ResetRateLimitedAt() {
-	m.rate_limited_at = nil
-	delete(m.clearedFields, account.FieldRateLimitedAt)
+// ResetCredentials resets all changes to the "credentials" field.
+func (m *AccountMutation) ResetCredentials() {
+	m.credentials = nil
 }
 
-// SetRateLimitResetAt sets the "rate_limit_reset_at" field.
-func (m *AccountMutation) SetRateLimitResetAt(t time.Time) {
-	m.rate_limit_reset_at = &t
+// SetExtra sets the "extra" field.
+func (m *AccountMutation) SetExtra(value map[string]interface{}) {
+	m.extra = &value
 }
 
-// RateLimitResetAt returns the value of the "rate_limit_reset_at" field in the mutation.
-func (m *AccountMutation) RateLimitResetAt() (r time.Time, exists bool) {
-	v := m.rate_limit_reset_at
+// Extra returns the value of the "extra" field in the mutation.
+func (m *AccountMutation) Extra() (r map[string]interface{}, exists bool) {
+	v := m.extra
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRateLimitResetAt returns the old "rate_limit_reset_at" field's value of the Account entity.
+// OldExtra returns the old "extra" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldRateLimitResetAt(ctx context.Context) (v *time.Time, err error) {
+func (m *AccountMutation) OldExtra(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRateLimitResetAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldExtra is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRateLimitResetAt requires an ID field in the mutation")
+		return v, errors.New("OldExtra requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRateLimitResetAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldExtra: %w", err)
 	}
-	return oldValue.RateLimitResetAt, nil
-}
-
-// ClearRateLimitResetAt clears the value of the "rate_limit_reset_at" field.
-func (m *AccountMutation) ClearRateLimitResetAt() {
-	m.rate_limit_reset_at = nil
-	m.clearedFields[account.FieldRateLimitResetAt] = struct{}{}
-}
-
-// RateLimitResetAtCleared returns if the "rate_limit_reset_at" field was cleared in this mutation.
-func (m *AccountMutation) RateLimitResetAtCleared() bool {
-	_, ok := m.clearedFields[account.FieldRateLimitResetAt]
-	return ok
+	return oldValue.Extra, nil
 }
 
-// ResetRateLimitResetAt resets all changes to the "rate_limit_reset_at" field.
-func (m *AccountMutation) ResetRateLimitResetAt() {
-	m.rate_limit_reset_at = nil
-	delete(m.clearedFields, account.FieldRateLimitResetAt)
+// ResetExtra resets all changes to the "extra" field.
+func (m *AccountMutation) ResetExtra() {
+	m.extra = nil
 }
 
-// SetOverloadUntil sets the "overload_until" field.
-func (m *AccountMutation) SetOverloadUntil(t time.Time) {
-	m.overload_until = &t
+// SetProxyID sets the "proxy_id" field.
+func (m *AccountMutation) SetProxyID(i int64) {
+	m.proxy = &i
 }
 
-// OverloadUntil returns the value of the "overload_until" field in the mutation.
-func (m *AccountMutation) OverloadUntil() (r time.Time, exists bool) {
-	v := m.overload_until
+// ProxyID returns the value of the "proxy_id" field in the mutation.
+func (m *AccountMutation) ProxyID() (r int64, exists bool) {
+	v := m.proxy
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldOverloadUntil returns the old "overload_until" field's value of the Account entity.
+// OldProxyID returns the old "proxy_id" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldOverloadUntil(ctx context.Context) (v *time.Time, err error) {
+func (m *AccountMutation) OldProxyID(ctx context.Context) (v *int64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldOverloadUntil is only allowed on UpdateOne operations")
+		return v, errors.New("OldProxyID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldOverloadUntil requires an ID field in the mutation")
+		return v, errors.New("OldProxyID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldOverloadUntil: %w", err)
+		return v, fmt.Errorf("querying old value for OldProxyID: %w", err)
 	}
-	return oldValue.OverloadUntil, nil
+	return oldValue.ProxyID, nil
 }
 
-// ClearOverloadUntil clears the value of the "overload_until" field.
-func (m *AccountMutation) ClearOverloadUntil() {
-	m.overload_until = nil
-	m.clearedFields[account.FieldOverloadUntil] = struct{}{}
+// ClearProxyID clears the value of the "proxy_id" field.
+func (m *AccountMutation) ClearProxyID() {
+	m.proxy = nil
+	m.clearedFields[account.FieldProxyID] = struct{}{}
 }
 
-// OverloadUntilCleared returns if the "overload_until" field was cleared in this mutation.
-func (m *AccountMutation) OverloadUntilCleared() bool {
-	_, ok := m.clearedFields[account.FieldOverloadUntil]
+// ProxyIDCleared returns if the "proxy_id" field was cleared in this mutation.
+func (m *AccountMutation) ProxyIDCleared() bool {
+	_, ok := m.clearedFields[account.FieldProxyID]
 	return ok
 }
 
-// ResetOverloadUntil resets all changes to the "overload_until" field.
-func (m *AccountMutation) ResetOverloadUntil() {
-	m.overload_until = nil
-	delete(m.clearedFields, account.FieldOverloadUntil)
+// ResetProxyID resets all changes to the "proxy_id" field.
+func (m *AccountMutation) ResetProxyID() {
+	m.proxy = nil
+	delete(m.clearedFields, account.FieldProxyID)
 }
 
-// SetSessionWindowStart sets the "session_window_start" field.
-func (m *AccountMutation) SetSessionWindowStart(t time.Time) {
-	m.session_window_start = &t
+// SetConcurrency sets the "concurrency" field.
+func (m *AccountMutation) SetConcurrency(i int) {
+	m.concurrency = &i
+	m.addconcurrency = nil
 }
 
-// SessionWindowStart returns the value of the "session_window_start" field in the mutation.
-func (m *AccountMutation) SessionWindowStart() (r time.Time, exists bool) {
-	v := m.session_window_start
+// Concurrency returns the value of the "concurrency" field in the mutation.
+func (m *AccountMutation) Concurrency() (r int, exists bool) {
+	v := m.concurrency
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSessionWindowStart returns the old "session_window_start" field's value of the Account entity.
+// OldConcurrency returns the old "concurrency" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldSessionWindowStart(ctx context.Context) (v *time.Time, err error) {
+func (m *AccountMutation) OldConcurrency(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSessionWindowStart is only allowed on UpdateOne operations")
+		return v, errors.New("OldConcurrency is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSessionWindowStart requires an ID field in the mutation")
+		return v, errors.New("OldConcurrency requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSessionWindowStart: %w", err)
+		return v, fmt.Errorf("querying old value for OldConcurrency: %w", err)
 	}
-	return oldValue.SessionWindowStart, nil
+	return oldValue.Concurrency, nil
 }
 
-// ClearSessionWindowStart clears the value of the "session_window_start" field.
-func (m *AccountMutation) ClearSessionWindowStart() {
-	m.session_window_start = nil
-	m.clearedFields[account.FieldSessionWindowStart] = struct{}{}
+// AddConcurrency adds i to the "concurrency" field.
+func (m *AccountMutation) AddConcurrency(i int) {
+	if m.addconcurrency != nil {
+		*m.addconcurrency += i
+	} else {
+		m.addconcurrency = &i
+	}
 }
 
-// SessionWindowStartCleared returns if the "session_window_start" field was cleared in this mutation.
-func (m *AccountMutation) SessionWindowStartCleared() bool {
-	_, ok := m.clearedFields[account.FieldSessionWindowStart]
-	return ok
+// AddedConcurrency returns the value that was added to the "concurrency" field in this mutation.
+func (m *AccountMutation) AddedConcurrency() (r int, exists bool) {
+	v := m.addconcurrency
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetSessionWindowStart resets all changes to the "session_window_start" field.
-func (m *AccountMutation) ResetSessionWindowStart() {
-	m.session_window_start = nil
-	delete(m.clearedFields, account.FieldSessionWindowStart)
+// ResetConcurrency resets all changes to the "concurrency" field.
+func (m *AccountMutation) ResetConcurrency() {
+	m.concurrency = nil
+	m.addconcurrency = nil
 }
 
-// SetSessionWindowEnd sets the "session_window_end" field.
-func (m *AccountMutation) SetSessionWindowEnd(t time.Time) {
-	m.session_window_end = &t
+// SetPriority sets the "priority" field.
+func (m *AccountMutation) SetPriority(i int) {
+	m.priority = &i
+	m.addpriority = nil
 }
 
-// SessionWindowEnd returns the value of the "session_window_end" field in the mutation.
-func (m *AccountMutation) SessionWindowEnd() (r time.Time, exists bool) {
-	v := m.session_window_end
+// Priority returns the value of the "priority" field in the mutation.
+func (m *AccountMutation) Priority() (r int, exists bool) {
+	v := m.priority
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSessionWindowEnd returns the old "session_window_end" field's value of the Account entity.
+// OldPriority returns the old "priority" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldSessionWindowEnd(ctx context.Context) (v *time.Time, err error) {
+func (m *AccountMutation) OldPriority(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSessionWindowEnd is only allowed on UpdateOne operations")
+		return v, errors.New("OldPriority is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSessionWindowEnd requires an ID field in the mutation")
+		return v, errors.New("OldPriority requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSessionWindowEnd: %w", err)
+		return v, fmt.Errorf("querying old value for OldPriority: %w", err)
 	}
-	return oldValue.SessionWindowEnd, nil
+	return oldValue.Priority, nil
 }
 
-// ClearSessionWindowEnd clears the value of the "session_window_end" field.
-func (m *AccountMutation) ClearSessionWindowEnd() {
-	m.session_window_end = nil
-	m.clearedFields[account.FieldSessionWindowEnd] = struct{}{}
-}
+// AddPriority adds i to the "priority" field.
+func (m *AccountMutation) AddPriority(i int) {
+	if m.addpriority != nil {
+		*m.addpriority += i
+	} else {
+		m.addpriority = &i
+	}
+}
 
-// SessionWindowEndCleared returns if the "session_window_end" field was cleared in this mutation.
-func (m *AccountMutation) SessionWindowEndCleared() bool {
-	_, ok := m.clearedFields[account.FieldSessionWindowEnd]
-	return ok
+// AddedPriority returns the value that was added to the "priority" field in this mutation.
+func (m *AccountMutation) AddedPriority() (r int, exists bool) {
+	v := m.addpriority
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetSessionWindowEnd resets all changes to the "session_window_end" field.
-func (m *AccountMutation) ResetSessionWindowEnd() {
-	m.session_window_end = nil
-	delete(m.clearedFields, account.FieldSessionWindowEnd)
+// ResetPriority resets all changes to the "priority" field.
+func (m *AccountMutation) ResetPriority() {
+	m.priority = nil
+	m.addpriority = nil
 }
 
-// SetSessionWindowStatus sets the "session_window_status" field.
-func (m *AccountMutation) SetSessionWindowStatus(s string) {
-	m.session_window_status = &s
+// SetRateMultiplier sets the "rate_multiplier" field.
+func (m *AccountMutation) SetRateMultiplier(f float64) {
+	m.rate_multiplier = &f
+	m.addrate_multiplier = nil
 }
 
-// SessionWindowStatus returns the value of the "session_window_status" field in the mutation.
-func (m *AccountMutation) SessionWindowStatus() (r string, exists bool) {
-	v := m.session_window_status
+// RateMultiplier returns the value of the "rate_multiplier" field in the mutation.
+func (m *AccountMutation) RateMultiplier() (r float64, exists bool) {
+	v := m.rate_multiplier
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSessionWindowStatus returns the old "session_window_status" field's value of the Account entity.
+// OldRateMultiplier returns the old "rate_multiplier" field's value of the Account entity.
 // If the Account object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AccountMutation) OldSessionWindowStatus(ctx context.Context) (v *string, err error) {
+func (m *AccountMutation) OldRateMultiplier(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSessionWindowStatus is only allowed on UpdateOne operations")
+		return v, errors.New("OldRateMultiplier is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSessionWindowStatus requires an ID field in the mutation")
+		return v, errors.New("OldRateMultiplier requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSessionWindowStatus: %w", err)
+		return v, fmt.Errorf("querying old value for OldRateMultiplier: %w", err)
 	}
-	return oldValue.SessionWindowStatus, nil
-}
-
-// ClearSessionWindowStatus clears the value of the "session_window_status" field.
-func (m *AccountMutation) ClearSessionWindowStatus() {
-	m.session_window_status = nil
-	m.clearedFields[account.FieldSessionWindowStatus] = struct{}{}
-}
-
-// SessionWindowStatusCleared returns if the "session_window_status" field was cleared in this mutation.
-func (m *AccountMutation) SessionWindowStatusCleared() bool {
-	_, ok := m.clearedFields[account.FieldSessionWindowStatus]
-	return ok
+	return oldValue.RateMultiplier, nil
 }
 
-// ResetSessionWindowStatus resets all changes to the "session_window_status" field.
-func (m *AccountMutation) ResetSessionWindowStatus() {
-	m.session_window_status = nil
-	delete(m.clearedFields, account.FieldSessionWindowStatus)
+// AddRateMultiplier adds f to the "rate_multiplier" field.
+func (m *AccountMutation) AddRateMultiplier(f float64) {
+	if m.addrate_multiplier != nil {
+		*m.addrate_multiplier += f
+	} else {
+		m.addrate_multiplier = &f
+	}
 }
 
-// AddGroupIDs adds the "groups" edge to the Group entity by ids.
-func (m *AccountMutation) AddGroupIDs(ids ...int64) {
-	if m.groups == nil {
-		m.groups = make(map[int64]struct{})
-	}
-	for i := range ids {
-		m.groups[ids[i]] = struct{}{}
+// AddedRateMultiplier returns the value that was added to the "rate_multiplier" field in this mutation.
+func (m *AccountMutation) AddedRateMultiplier() (r float64, exists bool) {
+	v := m.addrate_multiplier
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// ClearGroups clears the "groups" edge to the Group entity.
-func (m *AccountMutation) ClearGroups() {
-	m.clearedgroups = true
+// ResetRateMultiplier resets all changes to the "rate_multiplier" field.
+func (m *AccountMutation) ResetRateMultiplier() {
+	m.rate_multiplier = nil
+	m.addrate_multiplier = nil
 }
 
-// GroupsCleared reports if the "groups" edge to the Group entity was cleared.
-func (m *AccountMutation) GroupsCleared() bool {
-	return m.clearedgroups
+// SetStatus sets the "status" field.
+func (m *AccountMutation) SetStatus(s string) {
+	m.status = &s
 }
 
-// RemoveGroupIDs removes the "groups" edge to the Group entity by IDs.
-func (m *AccountMutation) RemoveGroupIDs(ids ...int64) {
-	if m.removedgroups == nil {
-		m.removedgroups = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.groups, ids[i])
-		m.removedgroups[ids[i]] = struct{}{}
+// Status returns the value of the "status" field in the mutation.
+func (m *AccountMutation) Status() (r string, exists bool) {
+	v := m.status
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedGroups returns the removed IDs of the "groups" edge to the Group entity.
-func (m *AccountMutation) RemovedGroupsIDs() (ids []int64) {
-	for id := range m.removedgroups {
-		ids = append(ids, id)
+// OldStatus returns the old "status" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldStatus(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
 	}
-	return
-}
-
-// GroupsIDs returns the "groups" edge IDs in the mutation.
-func (m *AccountMutation) GroupsIDs() (ids []int64) {
-	for id := range m.groups {
-		ids = append(ids, id)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
 	}
-	return
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	}
+	return oldValue.Status, nil
 }
 
-// ResetGroups resets all changes to the "groups" edge.
-func (m *AccountMutation) ResetGroups() {
-	m.groups = nil
-	m.clearedgroups = false
-	m.removedgroups = nil
+// ResetStatus resets all changes to the "status" field.
+func (m *AccountMutation) ResetStatus() {
+	m.status = nil
 }
 
-// ClearProxy clears the "proxy" edge to the Proxy entity.
-func (m *AccountMutation) ClearProxy() {
-	m.clearedproxy = true
-	m.clearedFields[account.FieldProxyID] = struct{}{}
+// SetErrorMessage sets the "error_message" field.
+func (m *AccountMutation) SetErrorMessage(s string) {
+	m.error_message = &s
 }
 
-// ProxyCleared reports if the "proxy" edge to the Proxy entity was cleared.
-func (m *AccountMutation) ProxyCleared() bool {
-	return m.ProxyIDCleared() || m.clearedproxy
+// ErrorMessage returns the value of the "error_message" field in the mutation.
+func (m *AccountMutation) ErrorMessage() (r string, exists bool) {
+	v := m.error_message
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ProxyIDs returns the "proxy" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ProxyID instead. It exists only for internal usage by the builders.
-func (m *AccountMutation) ProxyIDs() (ids []int64) {
-	if id := m.proxy; id != nil {
-		ids = append(ids, *id)
+// OldErrorMessage returns the old "error_message" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldErrorMessage(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldErrorMessage is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldErrorMessage requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldErrorMessage: %w", err)
+	}
+	return oldValue.ErrorMessage, nil
 }
 
-// ResetProxy resets all changes to the "proxy" edge.
-func (m *AccountMutation) ResetProxy() {
-	m.proxy = nil
-	m.clearedproxy = false
+// ClearErrorMessage clears the value of the "error_message" field.
+func (m *AccountMutation) ClearErrorMessage() {
+	m.error_message = nil
+	m.clearedFields[account.FieldErrorMessage] = struct{}{}
 }
 
-// AddUsageLogIDs adds the "usage_logs" edge to the UsageLog entity by ids.
-func (m *AccountMutation) AddUsageLogIDs(ids ...int64) {
-	if m.usage_logs == nil {
-		m.usage_logs = make(map[int64]struct{})
-	}
-	for i := range ids {
-		m.usage_logs[ids[i]] = struct{}{}
-	}
+// ErrorMessageCleared returns if the "error_message" field was cleared in this mutation.
+func (m *AccountMutation) ErrorMessageCleared() bool {
+	_, ok := m.clearedFields[account.FieldErrorMessage]
+	return ok
 }
 
-// ClearUsageLogs clears the "usage_logs" edge to the UsageLog entity.
-func (m *AccountMutation) ClearUsageLogs() {
-	m.clearedusage_logs = true
+// ResetErrorMessage resets all changes to the "error_message" field.
+func (m *AccountMutation) ResetErrorMessage() {
+	m.error_message = nil
+	delete(m.clearedFields, account.FieldErrorMessage)
 }
 
-// UsageLogsCleared reports if the "usage_logs" edge to the UsageLog entity was cleared.
-func (m *AccountMutation) UsageLogsCleared() bool {
-	return m.clearedusage_logs
+// SetLastUsedAt sets the "last_used_at" field.
+func (m *AccountMutation) SetLastUsedAt(t time.Time) {
+	m.last_used_at = &t
 }
 
-// RemoveUsageLogIDs removes the "usage_logs" edge to the UsageLog entity by IDs.
-func (m *AccountMutation) RemoveUsageLogIDs(ids ...int64) {
-	if m.removedusage_logs == nil {
-		m.removedusage_logs = make(map[int64]struct{})
-	}
-	for i := range ids {
-		delete(m.usage_logs, ids[i])
-		m.removedusage_logs[ids[i]] = struct{}{}
+// LastUsedAt returns the value of the "last_used_at" field in the mutation.
+func (m *AccountMutation) LastUsedAt() (r time.Time, exists bool) {
+	v := m.last_used_at
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedUsageLogs returns the removed IDs of the "usage_logs" edge to the UsageLog entity.
-func (m *AccountMutation) RemovedUsageLogsIDs() (ids []int64) {
-	for id := range m.removedusage_logs {
-		ids = append(ids, id)
+// OldLastUsedAt returns the old "last_used_at" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldLastUsedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastUsedAt is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastUsedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastUsedAt: %w", err)
+	}
+	return oldValue.LastUsedAt, nil
 }
 
-// UsageLogsIDs returns the "usage_logs" edge IDs in the mutation.
-func (m *AccountMutation) UsageLogsIDs() (ids []int64) {
-	for id := range m.usage_logs {
-		ids = append(ids, id)
-	}
-	return
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (m *AccountMutation) ClearLastUsedAt() {
+	m.last_used_at = nil
+	m.clearedFields[account.FieldLastUsedAt] = struct{}{}
 }
 
-// ResetUsageLogs resets all changes to the "usage_logs" edge.
-func (m *AccountMutation) ResetUsageLogs() {
-	m.usage_logs = nil
-	m.clearedusage_logs = false
-	m.removedusage_logs = nil
+// LastUsedAtCleared returns if the "last_used_at" field was cleared in this mutation.
+func (m *AccountMutation) LastUsedAtCleared() bool {
+	_, ok := m.clearedFields[account.FieldLastUsedAt]
+	return ok
 }
 
-// Where appends a list predicates to the AccountMutation builder.
-func (m *AccountMutation) Where(ps ...predicate.Account) {
-	m.predicates = append(m.predicates, ps...)
+// ResetLastUsedAt resets all changes to the "last_used_at" field.
+func (m *AccountMutation) ResetLastUsedAt() {
+	m.last_used_at = nil
+	delete(m.clearedFields, account.FieldLastUsedAt)
 }
 
-// WhereP appends storage-level predicates to the AccountMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *AccountMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Account, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
+// SetExpiresAt sets the "expires_at" field.
+func (m *AccountMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
+}
+
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *AccountMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
 	}
-	m.Where(p...)
+	return *v, true
 }
 
-// Op returns the operation name.
-func (m *AccountMutation) Op() Op {
-	return m.op
+// OldExpiresAt returns the old "expires_at" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
 }
 
-// SetOp allows setting the mutation operation.
-func (m *AccountMutation) SetOp(op Op) {
-	m.op = op
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *AccountMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[account.FieldExpiresAt] = struct{}{}
 }
 
-// Type returns the node type of this mutation (Account).
-func (m *AccountMutation) Type() string {
-	return m.typ
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *AccountMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[account.FieldExpiresAt]
+	return ok
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *AccountMutation) Fields() []string {
-	fields := make([]string, 0, 25)
-	if m.created_at != nil {
-		fields = append(fields, account.FieldCreatedAt)
-	}
-	if m.updated_at != nil {
-		fields = append(fields, account.FieldUpdatedAt)
-	}
-	if m.deleted_at != nil {
-		fields = append(fields, account.FieldDeletedAt)
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *AccountMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, account.FieldExpiresAt)
+}
+
+// SetAutoPauseOnExpired sets the "auto_pause_on_expired" field.
+func (m *AccountMutation) SetAutoPauseOnExpired(b bool) {
+	m.auto_pause_on_expired = &b
+}
+
+// AutoPauseOnExpired returns the value of the "auto_pause_on_expired" field in the mutation.
+func (m *AccountMutation) AutoPauseOnExpired() (r bool, exists bool) {
+	v := m.auto_pause_on_expired
+	if v == nil {
+		return
 	}
-	if m.name != nil {
-		fields = append(fields, account.FieldName)
+	return *v, true
+}
+
+// OldAutoPauseOnExpired returns the old "auto_pause_on_expired" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldAutoPauseOnExpired(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAutoPauseOnExpired is only allowed on UpdateOne operations")
 	}
-	if m.notes != nil {
-		fields = append(fields, account.FieldNotes)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAutoPauseOnExpired requires an ID field in the mutation")
 	}
-	if m.platform != nil {
-		fields = append(fields, account.FieldPlatform)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAutoPauseOnExpired: %w", err)
 	}
-	if m._type != nil {
-		fields = append(fields, account.FieldType)
+	return oldValue.AutoPauseOnExpired, nil
+}
+
+// ResetAutoPauseOnExpired resets all changes to the "auto_pause_on_expired" field.
+func (m *AccountMutation) ResetAutoPauseOnExpired() {
+	m.auto_pause_on_expired = nil
+}
+
+// SetSchedulable sets the "schedulable" field.
+func (m *AccountMutation) SetSchedulable(b bool) {
+	m.schedulable = &b
+}
+
+// Schedulable returns the value of the "schedulable" field in the mutation.
+func (m *AccountMutation) Schedulable() (r bool, exists bool) {
+	v := m.schedulable
+	if v == nil {
+		return
 	}
-	if m.credentials != nil {
-		fields = append(fields, account.FieldCredentials)
+	return *v, true
+}
+
+// OldSchedulable returns the old "schedulable" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldSchedulable(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSchedulable is only allowed on UpdateOne operations")
 	}
-	if m.extra != nil {
-		fields = append(fields, account.FieldExtra)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSchedulable requires an ID field in the mutation")
 	}
-	if m.proxy != nil {
-		fields = append(fields, account.FieldProxyID)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSchedulable: %w", err)
 	}
-	if m.concurrency != nil {
-		fields = append(fields, account.FieldConcurrency)
+	return oldValue.Schedulable, nil
+}
+
+// ResetSchedulable resets all changes to the "schedulable" field.
+func (m *AccountMutation) ResetSchedulable() {
+	m.schedulable = nil
+}
+
+// SetRateLimitedAt sets the "rate_limited_at" field.
+func (m *AccountMutation) SetRateLimitedAt(t time.Time) {
+	m.rate_limited_at = &t
+}
+
+// RateLimitedAt returns the value of the "rate_limited_at" field in the mutation.
+func (m *AccountMutation) RateLimitedAt() (r time.Time, exists bool) {
+	v := m.rate_limited_at
+	if v == nil {
+		return
 	}
-	if m.priority != nil {
-		fields = append(fields, account.FieldPriority)
+	return *v, true
+}
+
+// OldRateLimitedAt returns the old "rate_limited_at" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldRateLimitedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRateLimitedAt is only allowed on UpdateOne operations")
 	}
-	if m.rate_multiplier != nil {
-		fields = append(fields, account.FieldRateMultiplier)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRateLimitedAt requires an ID field in the mutation")
 	}
-	if m.status != nil {
-		fields = append(fields, account.FieldStatus)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRateLimitedAt: %w", err)
 	}
-	if m.error_message != nil {
-		fields = append(fields, account.FieldErrorMessage)
+	return oldValue.RateLimitedAt, nil
+}
+
+// ClearRateLimitedAt clears the value of the "rate_limited_at" field.
+func (m *AccountMutation) ClearRateLimitedAt() {
+	m.rate_limited_at = nil
+	m.clearedFields[account.FieldRateLimitedAt] = struct{}{}
+}
+
+// RateLimitedAtCleared returns if the "rate_limited_at" field was cleared in this mutation.
+func (m *AccountMutation) RateLimitedAtCleared() bool {
+	_, ok := m.clearedFields[account.FieldRateLimitedAt]
+	return ok
+}
+
+// ResetRateLimitedAt resets all changes to the "rate_limited_at" field.
+func (m *AccountMutation) ResetRateLimitedAt() {
+	m.rate_limited_at = nil
+	delete(m.clearedFields, account.FieldRateLimitedAt)
+}
+
+// SetRateLimitResetAt sets the "rate_limit_reset_at" field.
+func (m *AccountMutation) SetRateLimitResetAt(t time.Time) {
+	m.rate_limit_reset_at = &t
+}
+
+// RateLimitResetAt returns the value of the "rate_limit_reset_at" field in the mutation.
+func (m *AccountMutation) RateLimitResetAt() (r time.Time, exists bool) {
+	v := m.rate_limit_reset_at
+	if v == nil {
+		return
 	}
-	if m.last_used_at != nil {
-		fields = append(fields, account.FieldLastUsedAt)
+	return *v, true
+}
+
+// OldRateLimitResetAt returns the old "rate_limit_reset_at" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldRateLimitResetAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRateLimitResetAt is only allowed on UpdateOne operations")
 	}
-	if m.expires_at != nil {
-		fields = append(fields, account.FieldExpiresAt)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRateLimitResetAt requires an ID field in the mutation")
 	}
-	if m.auto_pause_on_expired != nil {
-		fields = append(fields, account.FieldAutoPauseOnExpired)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRateLimitResetAt: %w", err)
 	}
-	if m.schedulable != nil {
-		fields = append(fields, account.FieldSchedulable)
+	return oldValue.RateLimitResetAt, nil
+}
+
+// ClearRateLimitResetAt clears the value of the "rate_limit_reset_at" field.
+func (m *AccountMutation) ClearRateLimitResetAt() {
+	m.rate_limit_reset_at = nil
+	m.clearedFields[account.FieldRateLimitResetAt] = struct{}{}
+}
+
+// RateLimitResetAtCleared returns if the "rate_limit_reset_at" field was cleared in this mutation.
+func (m *AccountMutation) RateLimitResetAtCleared() bool {
+	_, ok := m.clearedFields[account.FieldRateLimitResetAt]
+	return ok
+}
+
+// ResetRateLimitResetAt resets all changes to the "rate_limit_reset_at" field.
+func (m *AccountMutation) ResetRateLimitResetAt() {
+	m.rate_limit_reset_at = nil
+	delete(m.clearedFields, account.FieldRateLimitResetAt)
+}
+
+// SetOverloadUntil sets the "overload_until" field.
+func (m *AccountMutation) SetOverloadUntil(t time.Time) {
+	m.overload_until = &t
+}
+
+// OverloadUntil returns the value of the "overload_until" field in the mutation.
+func (m *AccountMutation) OverloadUntil() (r time.Time, exists bool) {
+	v := m.overload_until
+	if v == nil {
+		return
 	}
-	if m.rate_limited_at != nil {
-		fields = append(fields, account.FieldRateLimitedAt)
+	return *v, true
+}
+
+// OldOverloadUntil returns the old "overload_until" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldOverloadUntil(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOverloadUntil is only allowed on UpdateOne operations")
 	}
-	if m.rate_limit_reset_at != nil {
-		fields = append(fields, account.FieldRateLimitResetAt)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOverloadUntil requires an ID field in the mutation")
 	}
-	if m.overload_until != nil {
-		fields = append(fields, account.FieldOverloadUntil)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOverloadUntil: %w", err)
 	}
-	if m.session_window_start != nil {
-		fields = append(fields, account.FieldSessionWindowStart)
+	return oldValue.OverloadUntil, nil
+}
+
+// ClearOverloadUntil clears the value of the "overload_until" field.
+func (m *AccountMutation) ClearOverloadUntil() {
+	m.overload_until = nil
+	m.clearedFields[account.FieldOverloadUntil] = struct{}{}
+}
+
+// OverloadUntilCleared returns if the "overload_until" field was cleared in this mutation.
+func (m *AccountMutation) OverloadUntilCleared() bool {
+	_, ok := m.clearedFields[account.FieldOverloadUntil]
+	return ok
+}
+
+// ResetOverloadUntil resets all changes to the "overload_until" field.
+func (m *AccountMutation) ResetOverloadUntil() {
+	m.overload_until = nil
+	delete(m.clearedFields, account.FieldOverloadUntil)
+}
+
+// SetSessionWindowStart sets the "session_window_start" field.
+func (m *AccountMutation) SetSessionWindowStart(t time.Time) {
+	m.session_window_start = &t
+}
+
+// SessionWindowStart returns the value of the "session_window_start" field in the mutation.
+func (m *AccountMutation) SessionWindowStart() (r time.Time, exists bool) {
+	v := m.session_window_start
+	if v == nil {
+		return
 	}
-	if m.session_window_end != nil {
-		fields = append(fields, account.FieldSessionWindowEnd)
+	return *v, true
+}
+
+// OldSessionWindowStart returns the old "session_window_start" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldSessionWindowStart(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionWindowStart is only allowed on UpdateOne operations")
 	}
-	if m.session_window_status != nil {
-		fields = append(fields, account.FieldSessionWindowStatus)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionWindowStart requires an ID field in the mutation")
 	}
-	return fields
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionWindowStart: %w", err)
+	}
+	return oldValue.SessionWindowStart, nil
 }
 
-// Field returns the value of a field with the given name. The second boolean
-// return value indicates that this field was not set, or was not defined in the
-// schema.
-func (m *AccountMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case account.FieldCreatedAt:
-		return m.CreatedAt()
-	case account.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case account.FieldDeletedAt:
-		return m.DeletedAt()
-	case account.FieldName:
-		return m.Name()
-	case account.FieldNotes:
-		return m.Notes()
-	case account.FieldPlatform:
-		return m.Platform()
-	case account.FieldType:
-		return m.GetType()
-	case account.FieldCredentials:
-		return m.Credentials()
-	case account.FieldExtra:
-		return m.Extra()
-	case account.FieldProxyID:
-		return m.ProxyID()
-	case account.FieldConcurrency:
-		return m.Concurrency()
-	case account.FieldPriority:
-		return m.Priority()
-	case account.FieldRateMultiplier:
-		return m.RateMultiplier()
-	case account.FieldStatus:
-		return m.Status()
-	case account.FieldErrorMessage:
-		return m.ErrorMessage()
-	case account.FieldLastUsedAt:
-		return m.LastUsedAt()
-	case account.FieldExpiresAt:
-		return m.ExpiresAt()
-	case account.FieldAutoPauseOnExpired:
-		return m.AutoPauseOnExpired()
-	case account.FieldSchedulable:
-		return m.Schedulable()
-	case account.FieldRateLimitedAt:
-		return m.RateLimitedAt()
-	case account.FieldRateLimitResetAt:
-		return m.RateLimitResetAt()
-	case account.FieldOverloadUntil:
-		return m.OverloadUntil()
-	case account.FieldSessionWindowStart:
-		return m.SessionWindowStart()
-	case account.FieldSessionWindowEnd:
-		return m.SessionWindowEnd()
-	case account.FieldSessionWindowStatus:
-		return m.SessionWindowStatus()
-	}
-	return nil, false
+// ClearSessionWindowStart clears the value of the "session_window_start" field.
+func (m *AccountMutation) ClearSessionWindowStart() {
+	m.session_window_start = nil
+	m.clearedFields[account.FieldSessionWindowStart] = struct{}{}
 }
 
-// OldField returns the old value of the field from the database. An error is
-// returned if the mutation operation is not UpdateOne, or the query to the
-// database failed.
-func (m *AccountMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	switch name {
-	case account.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case account.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case account.FieldDeletedAt:
-		return m.OldDeletedAt(ctx)
-	case account.FieldName:
-		return m.OldName(ctx)
-	case account.FieldNotes:
-		return m.OldNotes(ctx)
-	case account.FieldPlatform:
-		return m.OldPlatform(ctx)
-	case account.FieldType:
-		return m.OldType(ctx)
-	case account.FieldCredentials:
-		return m.OldCredentials(ctx)
-	case account.FieldExtra:
-		return m.OldExtra(ctx)
-	case account.FieldProxyID:
-		return m.OldProxyID(ctx)
-	case account.FieldConcurrency:
-		return m.OldConcurrency(ctx)
-	case account.FieldPriority:
-		return m.OldPriority(ctx)
-	case account.FieldRateMultiplier:
-		return m.OldRateMultiplier(ctx)
-	case account.FieldStatus:
-		return m.OldStatus(ctx)
-	case account.FieldErrorMessage:
-		return m.OldErrorMessage(ctx)
-	case account.FieldLastUsedAt:
-		return m.OldLastUsedAt(ctx)
-	case account.FieldExpiresAt:
-		return m.OldExpiresAt(ctx)
-	case account.FieldAutoPauseOnExpired:
-		return m.OldAutoPauseOnExpired(ctx)
-	case account.FieldSchedulable:
-		return m.OldSchedulable(ctx)
-	case account.FieldRateLimitedAt:
-		return m.OldRateLimitedAt(ctx)
-	case account.FieldRateLimitResetAt:
-		return m.OldRateLimitResetAt(ctx)
-	case account.FieldOverloadUntil:
-		return m.OldOverloadUntil(ctx)
-	case account.FieldSessionWindowStart:
-		return m.OldSessionWindowStart(ctx)
-	case account.FieldSessionWindowEnd:
-		return m.OldSessionWindowEnd(ctx)
-	case account.FieldSessionWindowStatus:
-		return m.OldSessionWindowStatus(ctx)
+// SessionWindowStartCleared returns if the "session_window_start" field was cleared in this mutation.
+func (m *AccountMutation) SessionWindowStartCleared() bool {
+	_, ok := m.clearedFields[account.FieldSessionWindowStart]
+	return ok
+}
+
+// ResetSessionWindowStart resets all changes to the "session_window_start" field.
+func (m *AccountMutation) ResetSessionWindowStart() {
+	m.session_window_start = nil
+	delete(m.clearedFields, account.FieldSessionWindowStart)
+}
+
+// SetSessionWindowEnd sets the "session_window_end" field.
+func (m *AccountMutation) SetSessionWindowEnd(t time.Time) {
+	m.session_window_end = &t
+}
+
+// SessionWindowEnd returns the value of the "session_window_end" field in the mutation.
+func (m *AccountMutation) SessionWindowEnd() (r time.Time, exists bool) {
+	v := m.session_window_end
+	if v == nil {
+		return
 	}
-	return nil, fmt.Errorf("unknown Account field %s", name)
+	return *v, true
 }
 
-// SetField sets the value of a field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *AccountMutation) SetField(name string, value ent.Value) error {
-	switch name {
-	case account.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
+// OldSessionWindowEnd returns the old "session_window_end" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldSessionWindowEnd(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionWindowEnd is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionWindowEnd requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionWindowEnd: %w", err)
+	}
+	return oldValue.SessionWindowEnd, nil
+}
+
+// ClearSessionWindowEnd clears the value of the "session_window_end" field.
+func (m *AccountMutation) ClearSessionWindowEnd() {
+	m.session_window_end = nil
+	m.clearedFields[account.FieldSessionWindowEnd] = struct{}{}
+}
+
+// SessionWindowEndCleared returns if the "session_window_end" field was cleared in this mutation.
+func (m *AccountMutation) SessionWindowEndCleared() bool {
+	_, ok := m.clearedFields[account.FieldSessionWindowEnd]
+	return ok
+}
+
+// ResetSessionWindowEnd resets all changes to the "session_window_end" field.
+func (m *AccountMutation) ResetSessionWindowEnd() {
+	m.session_window_end = nil
+	delete(m.clearedFields, account.FieldSessionWindowEnd)
+}
+
+// SetSessionWindowStatus sets the "session_window_status" field.
+func (m *AccountMutation) SetSessionWindowStatus(s string) {
+	m.session_window_status = &s
+}
+
+// SessionWindowStatus returns the value of the "session_window_status" field in the mutation.
+func (m *AccountMutation) SessionWindowStatus() (r string, exists bool) {
+	v := m.session_window_status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionWindowStatus returns the old "session_window_status" field's value of the Account entity.
+// If the Account object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AccountMutation) OldSessionWindowStatus(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionWindowStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionWindowStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionWindowStatus: %w", err)
+	}
+	return oldValue.SessionWindowStatus, nil
+}
+
+// ClearSessionWindowStatus clears the value of the "session_window_status" field.
+func (m *AccountMutation) ClearSessionWindowStatus() {
+	m.session_window_status = nil
+	m.clearedFields[account.FieldSessionWindowStatus] = struct{}{}
+}
+
+// SessionWindowStatusCleared returns if the "session_window_status" field was cleared in this mutation.
+func (m *AccountMutation) SessionWindowStatusCleared() bool {
+	_, ok := m.clearedFields[account.FieldSessionWindowStatus]
+	return ok
+}
+
+// ResetSessionWindowStatus resets all changes to the "session_window_status" field.
+func (m *AccountMutation) ResetSessionWindowStatus() {
+	m.session_window_status = nil
+	delete(m.clearedFields, account.FieldSessionWindowStatus)
+}
+
+// AddGroupIDs adds the "groups" edge to the Group entity by ids.
+func (m *AccountMutation) AddGroupIDs(ids ...int64) {
+	if m.groups == nil {
+		m.groups = make(map[int64]struct{})
+	}
+	for i := range ids {
+		m.groups[ids[i]] = struct{}{}
+	}
+}
+
+// ClearGroups clears the "groups" edge to the Group entity.
+func (m *AccountMutation) ClearGroups() {
+	m.clearedgroups = true
+}
+
+// GroupsCleared reports if the "groups" edge to the Group entity was cleared.
+func (m *AccountMutation) GroupsCleared() bool {
+	return m.clearedgroups
+}
+
+// RemoveGroupIDs removes the "groups" edge to the Group entity by IDs.
+func (m *AccountMutation) RemoveGroupIDs(ids ...int64) {
+	if m.removedgroups == nil {
+		m.removedgroups = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.groups, ids[i])
+		m.removedgroups[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedGroups returns the removed IDs of the "groups" edge to the Group entity.
+func (m *AccountMutation) RemovedGroupsIDs() (ids []int64) {
+	for id := range m.removedgroups {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// GroupsIDs returns the "groups" edge IDs in the mutation.
+func (m *AccountMutation) GroupsIDs() (ids []int64) {
+	for id := range m.groups {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetGroups resets all changes to the "groups" edge.
+func (m *AccountMutation) ResetGroups() {
+	m.groups = nil
+	m.clearedgroups = false
+	m.removedgroups = nil
+}
+
+// ClearProxy clears the "proxy" edge to the Proxy entity.
+func (m *AccountMutation) ClearProxy() {
+	m.clearedproxy = true
+	m.clearedFields[account.FieldProxyID] = struct{}{}
+}
+
+// ProxyCleared reports if the "proxy" edge to the Proxy entity was cleared.
+func (m *AccountMutation) ProxyCleared() bool {
+	return m.ProxyIDCleared() || m.clearedproxy
+}
+
+// ProxyIDs returns the "proxy" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ProxyID instead. It exists only for internal usage by the builders.
+func (m *AccountMutation) ProxyIDs() (ids []int64) {
+	if id := m.proxy; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetProxy resets all changes to the "proxy" edge.
+func (m *AccountMutation) ResetProxy() {
+	m.proxy = nil
+	m.clearedproxy = false
+}
+
+// AddUsageLogIDs adds the "usage_logs" edge to the UsageLog entity by ids.
+func (m *AccountMutation) AddUsageLogIDs(ids ...int64) {
+	if m.usage_logs == nil {
+		m.usage_logs = make(map[int64]struct{})
+	}
+	for i := range ids {
+		m.usage_logs[ids[i]] = struct{}{}
+	}
+}
+
+// ClearUsageLogs clears the "usage_logs" edge to the UsageLog entity.
+func (m *AccountMutation) ClearUsageLogs() {
+	m.clearedusage_logs = true
+}
+
+// UsageLogsCleared reports if the "usage_logs" edge to the UsageLog entity was cleared.
+func (m *AccountMutation) UsageLogsCleared() bool {
+	return m.clearedusage_logs
+}
+
+// RemoveUsageLogIDs removes the "usage_logs" edge to the UsageLog entity by IDs.
+func (m *AccountMutation) RemoveUsageLogIDs(ids ...int64) {
+	if m.removedusage_logs == nil {
+		m.removedusage_logs = make(map[int64]struct{})
+	}
+	for i := range ids {
+		delete(m.usage_logs, ids[i])
+		m.removedusage_logs[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedUsageLogs returns the removed IDs of the "usage_logs" edge to the UsageLog entity.
+func (m *AccountMutation) RemovedUsageLogsIDs() (ids []int64) {
+	for id := range m.removedusage_logs {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// UsageLogsIDs returns the "usage_logs" edge IDs in the mutation.
+func (m *AccountMutation) UsageLogsIDs() (ids []int64) {
+	for id := range m.usage_logs {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetUsageLogs resets all changes to the "usage_logs" edge.
+func (m *AccountMutation) ResetUsageLogs() {
+	m.usage_logs = nil
+	m.clearedusage_logs = false
+	m.removedusage_logs = nil
+}
+
+// Where appends a list predicates to the AccountMutation builder.
+func (m *AccountMutation) Where(ps ...predicate.Account) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the AccountMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *AccountMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Account, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *AccountMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *AccountMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Account).
+func (m *AccountMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *AccountMutation) Fields() []string {
+	fields := make([]string, 0, 25)
+	if m.created_at != nil {
+		fields = append(fields, account.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, account.FieldUpdatedAt)
+	}
+	if m.deleted_at != nil {
+		fields = append(fields, account.FieldDeletedAt)
+	}
+	if m.name != nil {
+		fields = append(fields, account.FieldName)
+	}
+	if m.notes != nil {
+		fields = append(fields, account.FieldNotes)
+	}
+	if m.platform != nil {
+		fields = append(fields, account.FieldPlatform)
+	}
+	if m._type != nil {
+		fields = append(fields, account.FieldType)
+	}
+	if m.credentials != nil {
+		fields = append(fields, account.FieldCredentials)
+	}
+	if m.extra != nil {
+		fields = append(fields, account.FieldExtra)
+	}
+	if m.proxy != nil {
+		fields = append(fields, account.FieldProxyID)
+	}
+	if m.concurrency != nil {
+		fields = append(fields, account.FieldConcurrency)
+	}
+	if m.priority != nil {
+		fields = append(fields, account.FieldPriority)
+	}
+	if m.rate_multiplier != nil {
+		fields = append(fields, account.FieldRateMultiplier)
+	}
+	if m.status != nil {
+		fields = append(fields, account.FieldStatus)
+	}
+	if m.error_message != nil {
+		fields = append(fields, account.FieldErrorMessage)
+	}
+	if m.last_used_at != nil {
+		fields = append(fields, account.FieldLastUsedAt)
+	}
+	if m.expires_at != nil {
+		fields = append(fields, account.FieldExpiresAt)
+	}
+	if m.auto_pause_on_expired != nil {
+		fields = append(fields, account.FieldAutoPauseOnExpired)
+	}
+	if m.schedulable != nil {
+		fields = append(fields, account.FieldSchedulable)
+	}
+	if m.rate_limited_at != nil {
+		fields = append(fields, account.FieldRateLimitedAt)
+	}
+	if m.rate_limit_reset_at != nil {
+		fields = append(fields, account.FieldRateLimitResetAt)
+	}
+	if m.overload_until != nil {
+		fields = append(fields, account.FieldOverloadUntil)
+	}
+	if m.session_window_start != nil {
+		fields = append(fields, account.FieldSessionWindowStart)
+	}
+	if m.session_window_end != nil {
+		fields = append(fields, account.FieldSessionWindowEnd)
+	}
+	if m.session_window_status != nil {
+		fields = append(fields, account.FieldSessionWindowStatus)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *AccountMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case account.FieldCreatedAt:
+		return m.CreatedAt()
+	case account.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case account.FieldDeletedAt:
+		return m.DeletedAt()
+	case account.FieldName:
+		return m.Name()
+	case account.FieldNotes:
+		return m.Notes()
+	case account.FieldPlatform:
+		return m.Platform()
+	case account.FieldType:
+		return m.GetType()
+	case account.FieldCredentials:
+		return m.Credentials()
+	case account.FieldExtra:
+		return m.Extra()
+	case account.FieldProxyID:
+		return m.ProxyID()
+	case account.FieldConcurrency:
+		return m.Concurrency()
+	case account.FieldPriority:
+		return m.Priority()
+	case account.FieldRateMultiplier:
+		return m.RateMultiplier()
+	case account.FieldStatus:
+		return m.Status()
+	case account.FieldErrorMessage:
+		return m.ErrorMessage()
+	case account.FieldLastUsedAt:
+		return m.LastUsedAt()
+	case account.FieldExpiresAt:
+		return m.ExpiresAt()
+	case account.FieldAutoPauseOnExpired:
+		return m.AutoPauseOnExpired()
+	case account.FieldSchedulable:
+		return m.Schedulable()
+	case account.FieldRateLimitedAt:
+		return m.RateLimitedAt()
+	case account.FieldRateLimitResetAt:
+		return m.RateLimitResetAt()
+	case account.FieldOverloadUntil:
+		return m.OverloadUntil()
+	case account.FieldSessionWindowStart:
+		return m.SessionWindowStart()
+	case account.FieldSessionWindowEnd:
+		return m.SessionWindowEnd()
+	case account.FieldSessionWindowStatus:
+		return m.SessionWindowStatus()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *AccountMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case account.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case account.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case account.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case account.FieldName:
+		return m.OldName(ctx)
+	case account.FieldNotes:
+		return m.OldNotes(ctx)
+	case account.FieldPlatform:
+		return m.OldPlatform(ctx)
+	case account.FieldType:
+		return m.OldType(ctx)
+	case account.FieldCredentials:
+		return m.OldCredentials(ctx)
+	case account.FieldExtra:
+		return m.OldExtra(ctx)
+	case account.FieldProxyID:
+		return m.OldProxyID(ctx)
+	case account.FieldConcurrency:
+		return m.OldConcurrency(ctx)
+	case account.FieldPriority:
+		return m.OldPriority(ctx)
+	case account.FieldRateMultiplier:
+		return m.OldRateMultiplier(ctx)
+	case account.FieldStatus:
+		return m.OldStatus(ctx)
+	case account.FieldErrorMessage:
+		return m.OldErrorMessage(ctx)
+	case account.FieldLastUsedAt:
+		return m.OldLastUsedAt(ctx)
+	case account.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case account.FieldAutoPauseOnExpired:
+		return m.OldAutoPauseOnExpired(ctx)
+	case account.FieldSchedulable:
+		return m.OldSchedulable(ctx)
+	case account.FieldRateLimitedAt:
+		return m.OldRateLimitedAt(ctx)
+	case account.FieldRateLimitResetAt:
+		return m.OldRateLimitResetAt(ctx)
+	case account.FieldOverloadUntil:
+		return m.OldOverloadUntil(ctx)
+	case account.FieldSessionWindowStart:
+		return m.OldSessionWindowStart(ctx)
+	case account.FieldSessionWindowEnd:
+		return m.OldSessionWindowEnd(ctx)
+	case account.FieldSessionWindowStatus:
+		return m.OldSessionWindowStatus(ctx)
+	}
+	return nil, fmt.Errorf("unknown Account field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *AccountMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case account.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case account.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case account.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case account.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case account.FieldNotes:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNotes(v)
+		return nil
+	case account.FieldPlatform:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPlatform(v)
+		return nil
+	case account.FieldType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetType(v)
+		return nil
+	case account.FieldCredentials:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCredentials(v)
+		return nil
+	case account.FieldExtra:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExtra(v)
+		return nil
+	case account.FieldProxyID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProxyID(v)
+		return nil
+	case account.FieldConcurrency:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetConcurrency(v)
+		return nil
+	case account.FieldPriority:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPriority(v)
 		return nil
-	case account.FieldUpdatedAt:
+	case account.FieldRateMultiplier:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRateMultiplier(v)
+		return nil
+	case account.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case account.FieldErrorMessage:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetErrorMessage(v)
+		return nil
+	case account.FieldLastUsedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
+		m.SetLastUsedAt(v)
 		return nil
-	case account.FieldDeletedAt:
+	case account.FieldExpiresAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDeletedAt(v)
+		m.SetExpiresAt(v)
+		return nil
+	case account.FieldAutoPauseOnExpired:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAutoPauseOnExpired(v)
+		return nil
+	case account.FieldSchedulable:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSchedulable(v)
+		return nil
+	case account.FieldRateLimitedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRateLimitedAt(v)
+		return nil
+	case account.FieldRateLimitResetAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRateLimitResetAt(v)
+		return nil
+	case account.FieldOverloadUntil:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOverloadUntil(v)
+		return nil
+	case account.FieldSessionWindowStart:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionWindowStart(v)
+		return nil
+	case account.FieldSessionWindowEnd:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionWindowEnd(v)
+		return nil
+	case account.FieldSessionWindowStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionWindowStatus(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Account field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *AccountMutation) AddedFields() []string {
+	var fields []string
+	if m.addconcurrency != nil {
+		fields = append(fields, account.FieldConcurrency)
+	}
+	if m.addpriority != nil {
+		fields = append(fields, account.FieldPriority)
+	}
+	if m.addrate_multiplier != nil {
+		fields = append(fields, account.FieldRateMultiplier)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *AccountMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case account.FieldConcurrency:
+		return m.AddedConcurrency()
+	case account.FieldPriority:
+		return m.AddedPriority()
+	case account.FieldRateMultiplier:
+		return m.AddedRateMultiplier()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *AccountMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case account.FieldConcurrency:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddConcurrency(v)
+		return nil
+	case account.FieldPriority:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPriority(v)
+		return nil
+	case account.FieldRateMultiplier:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRateMultiplier(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Account numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *AccountMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(account.FieldDeletedAt) {
+		fields = append(fields, account.FieldDeletedAt)
+	}
+	if m.FieldCleared(account.FieldNotes) {
+		fields = append(fields, account.FieldNotes)
+	}
+	if m.FieldCleared(account.FieldProxyID) {
+		fields = append(fields, account.FieldProxyID)
+	}
+	if m.FieldCleared(account.FieldErrorMessage) {
+		fields = append(fields, account.FieldErrorMessage)
+	}
+	if m.FieldCleared(account.FieldLastUsedAt) {
+		fields = append(fields, account.FieldLastUsedAt)
+	}
+	if m.FieldCleared(account.FieldExpiresAt) {
+		fields = append(fields, account.FieldExpiresAt)
+	}
+	if m.FieldCleared(account.FieldRateLimitedAt) {
+		fields = append(fields, account.FieldRateLimitedAt)
+	}
+	if m.FieldCleared(account.FieldRateLimitResetAt) {
+		fields = append(fields, account.FieldRateLimitResetAt)
+	}
+	if m.FieldCleared(account.FieldOverloadUntil) {
+		fields = append(fields, account.FieldOverloadUntil)
+	}
+	if m.FieldCleared(account.FieldSessionWindowStart) {
+		fields = append(fields, account.FieldSessionWindowStart)
+	}
+	if m.FieldCleared(account.FieldSessionWindowEnd) {
+		fields = append(fields, account.FieldSessionWindowEnd)
+	}
+	if m.FieldCleared(account.FieldSessionWindowStatus) {
+		fields = append(fields, account.FieldSessionWindowStatus)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *AccountMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *AccountMutation) ClearField(name string) error {
+	switch name {
+	case account.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	case account.FieldNotes:
+		m.ClearNotes()
+		return nil
+	case account.FieldProxyID:
+		m.ClearProxyID()
+		return nil
+	case account.FieldErrorMessage:
+		m.ClearErrorMessage()
+		return nil
+	case account.FieldLastUsedAt:
+		m.ClearLastUsedAt()
+		return nil
+	case account.FieldExpiresAt:
+		m.ClearExpiresAt()
+		return nil
+	case account.FieldRateLimitedAt:
+		m.ClearRateLimitedAt()
+		return nil
+	case account.FieldRateLimitResetAt:
+		m.ClearRateLimitResetAt()
+		return nil
+	case account.FieldOverloadUntil:
+		m.ClearOverloadUntil()
+		return nil
+	case account.FieldSessionWindowStart:
+		m.ClearSessionWindowStart()
+		return nil
+	case account.FieldSessionWindowEnd:
+		m.ClearSessionWindowEnd()
+		return nil
+	case account.FieldSessionWindowStatus:
+		m.ClearSessionWindowStatus()
+		return nil
+	}
+	return fmt.Errorf("unknown Account nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *AccountMutation) ResetField(name string) error {
+	switch name {
+	case account.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case account.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case account.FieldDeletedAt:
+		m.ResetDeletedAt()
 		return nil
 	case account.FieldName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetName(v)
+		m.ResetName()
 		return nil
 	case account.FieldNotes:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetNotes(v)
+		m.ResetNotes()
 		return nil
 	case account.FieldPlatform:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPlatform(v)
+		m.ResetPlatform()
 		return nil
 	case account.FieldType:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetType(v)
+		m.ResetType()
 		return nil
 	case account.FieldCredentials:
-		v, ok := value.(map[string]interface{})
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCredentials(v)
+		m.ResetCredentials()
 		return nil
 	case account.FieldExtra:
-		v, ok := value.(map[string]interface{})
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetExtra(v)
+		m.ResetExtra()
 		return nil
 	case account.FieldProxyID:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetProxyID(v)
+		m.ResetProxyID()
 		return nil
 	case account.FieldConcurrency:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetConcurrency(v)
+		m.ResetConcurrency()
 		return nil
 	case account.FieldPriority:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPriority(v)
+		m.ResetPriority()
 		return nil
 	case account.FieldRateMultiplier:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetRateMultiplier(v)
+		m.ResetRateMultiplier()
 		return nil
 	case account.FieldStatus:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetStatus(v)
+		m.ResetStatus()
 		return nil
 	case account.FieldErrorMessage:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetErrorMessage(v)
+		m.ResetErrorMessage()
 		return nil
 	case account.FieldLastUsedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetLastUsedAt(v)
+		m.ResetLastUsedAt()
 		return nil
 	case account.FieldExpiresAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetExpiresAt(v)
+		m.ResetExpiresAt()
 		return nil
 	case account.FieldAutoPauseOnExpired:
-		v, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetAutoPauseOnExpired(v)
+		m.ResetAutoPauseOnExpired()
 		return nil
 	case account.FieldSchedulable:
-		v, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSchedulable(v)
+		m.ResetSchedulable()
 		return nil
 	case account.FieldRateLimitedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetRateLimitedAt(v)
+		m.ResetRateLimitedAt()
 		return nil
 	case account.FieldRateLimitResetAt:
-		v, ok := value.(time.Time)
+		m.ResetRateLimitResetAt()
+		return nil
+	case account.FieldOverloadUntil:
+		m.ResetOverloadUntil()
+		return nil
+	case account.FieldSessionWindowStart:
+		m.ResetSessionWindowStart()
+		return nil
+	case account.FieldSessionWindowEnd:
+		m.ResetSessionWindowEnd()
+		return nil
+	case account.FieldSessionWindowStatus:
+		m.ResetSessionWindowStatus()
+		return nil
+	}
+	return fmt.Errorf("unknown Account field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *AccountMutation) AddedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.groups != nil {
+		edges = append(edges, account.EdgeGroups)
+	}
+	if m.proxy != nil {
+		edges = append(edges, account.EdgeProxy)
+	}
+	if m.usage_logs != nil {
+		edges = append(edges, account.EdgeUsageLogs)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *AccountMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case account.EdgeGroups:
+		ids := make([]ent.Value, 0, len(m.groups))
+		for id := range m.groups {
+			ids = append(ids, id)
+		}
+		return ids
+	case account.EdgeProxy:
+		if id := m.proxy; id != nil {
+			return []ent.Value{*id}
+		}
+	case account.EdgeUsageLogs:
+		ids := make([]ent.Value, 0, len(m.usage_logs))
+		for id := range m.usage_logs {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *AccountMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.removedgroups != nil {
+		edges = append(edges, account.EdgeGroups)
+	}
+	if m.removedusage_logs != nil {
+		edges = append(edges, account.EdgeUsageLogs)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *AccountMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case account.EdgeGroups:
+		ids := make([]ent.Value, 0, len(m.removedgroups))
+		for id := range m.removedgroups {
+			ids = append(ids, id)
+		}
+		return ids
+	case account.EdgeUsageLogs:
+		ids := make([]ent.Value, 0, len(m.removedusage_logs))
+		for id := range m.removedusage_logs {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *AccountMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.clearedgroups {
+		edges = append(edges, account.EdgeGroups)
+	}
+	if m.clearedproxy {
+		edges = append(edges, account.EdgeProxy)
+	}
+	if m.clearedusage_logs {
+		edges = append(edges, account.EdgeUsageLogs)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *AccountMutation) EdgeCleared(name string) bool {
+	switch name {
+	case account.EdgeGroups:
+		return m.clearedgroups
+	case account.EdgeProxy:
+		return m.clearedproxy
+	case account.EdgeUsageLogs:
+		return m.clearedusage_logs
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *AccountMutation) ClearEdge(name string) error {
+	switch name {
+	case account.EdgeProxy:
+		m.ClearProxy()
+		return nil
+	}
+	return fmt.Errorf("unknown Account unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *AccountMutation) ResetEdge(name string) error {
+	switch name {
+	case account.EdgeGroups:
+		m.ResetGroups()
+		return nil
+	case account.EdgeProxy:
+		m.ResetProxy()
+		return nil
+	case account.EdgeUsageLogs:
+		m.ResetUsageLogs()
+		return nil
+	}
+	return fmt.Errorf("unknown Account edge %s", name)
+}
+
+// AccountGroupMutation represents an operation that mutates the AccountGroup nodes in the graph.
+type AccountGroupMutation struct {
+	config
+	op             Op
+	typ            string
+	priority       *int
+	addpriority    *int
+	created_at     *time.Time
+	clearedFields  map[string]struct{}
+	account        *int64
+	clearedaccount bool
+	group          *int64
+	clearedgroup   bool
+	done           bool
+	oldValue       func(context.Context) (*AccountGroup, error)
+	predicates     []predicate.AccountGroup
+}
+
+var _ ent.Mutation = (*AccountGroupMutation)(nil)
+
+// accountgroupOption allows management of the mutation configuration using functional options.
+type accountgroupOption func(*AccountGroupMutation)
+
+// newAccountGroupMutation creates new mutation for the AccountGroup entity.
+func newAccountGroupMutation(c config, op Op, opts ...accountgroupOption) *AccountGroupMutation {
+	m := &AccountGroupMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeAccountGroup,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m AccountGroupMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m AccountGroupMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetAccountID sets the "account_id" field.
+func (m *AccountGroupMutation) SetAccountID(i int64) {
+	m.account = &i
+}
+
+// AccountID returns the value of the "account_id" field in the mutation.
+func (m *AccountGroupMutation) AccountID() (r int64, exists bool) {
+	v := m.account
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetAccountID resets all changes to the "account_id" field.
+func (m *AccountGroupMutation) ResetAccountID() {
+	m.account = nil
+}
+
+// SetGroupID sets the "group_id" field.
+func (m *AccountGroupMutation) SetGroupID(i int64) {
+	m.group = &i
+}
+
+// GroupID returns the value of the "group_id" field in the mutation.
+func (m *AccountGroupMutation) GroupID() (r int64, exists bool) {
+	v := m.group
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetGroupID resets all changes to the "group_id" field.
+func (m *AccountGroupMutation) ResetGroupID() {
+	m.group = nil
+}
+
+// SetPriority sets the "priority" field.
+func (m *AccountGroupMutation) SetPriority(i int) {
+	m.priority = &i
+	m.addpriority = nil
+}
+
+// Priority returns the value of the "priority" field in the mutation.
+func (m *AccountGroupMutation) Priority() (r int, exists bool) {
+	v := m.priority
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// AddPriority adds i to the "priority" field.
+func (m *AccountGroupMutation) AddPriority(i int) {
+	if m.addpriority != nil {
+		*m.addpriority += i
+	} else {
+		m.addpriority = &i
+	}
+}
+
+// AddedPriority returns the value that was added to the "priority" field in this mutation.
+func (m *AccountGroupMutation) AddedPriority() (r int, exists bool) {
+	v := m.addpriority
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPriority resets all changes to the "priority" field.
+func (m *AccountGroupMutation) ResetPriority() {
+	m.priority = nil
+	m.addpriority = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *AccountGroupMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *AccountGroupMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *AccountGroupMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// ClearAccount clears the "account" edge to the Account entity.
+func (m *AccountGroupMutation) ClearAccount() {
+	m.clearedaccount = true
+	m.clearedFields[accountgroup.FieldAccountID] = struct{}{}
+}
+
+// AccountCleared reports if the "account" edge to the Account entity was cleared.
+func (m *AccountGroupMutation) AccountCleared() bool {
+	return m.clearedaccount
+}
+
+// AccountIDs returns the "account" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// AccountID instead. It exists only for internal usage by the builders.
+func (m *AccountGroupMutation) AccountIDs() (ids []int64) {
+	if id := m.account; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetAccount resets all changes to the "account" edge.
+func (m *AccountGroupMutation) ResetAccount() {
+	m.account = nil
+	m.clearedaccount = false
+}
+
+// ClearGroup clears the "group" edge to the Group entity.
+func (m *AccountGroupMutation) ClearGroup() {
+	m.clearedgroup = true
+	m.clearedFields[accountgroup.FieldGroupID] = struct{}{}
+}
+
+// GroupCleared reports if the "group" edge to the Group entity was cleared.
+func (m *AccountGroupMutation) GroupCleared() bool {
+	return m.clearedgroup
+}
+
+// GroupIDs returns the "group" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// GroupID instead. It exists only for internal usage by the builders.
+func (m *AccountGroupMutation) GroupIDs() (ids []int64) {
+	if id := m.group; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetGroup resets all changes to the "group" edge.
+func (m *AccountGroupMutation) ResetGroup() {
+	m.group = nil
+	m.clearedgroup = false
+}
+
+// Where appends a list predicates to the AccountGroupMutation builder.
+func (m *AccountGroupMutation) Where(ps ...predicate.AccountGroup) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the AccountGroupMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *AccountGroupMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.AccountGroup, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *AccountGroupMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *AccountGroupMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (AccountGroup).
+func (m *AccountGroupMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *AccountGroupMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.account != nil {
+		fields = append(fields, accountgroup.FieldAccountID)
+	}
+	if m.group != nil {
+		fields = append(fields, accountgroup.FieldGroupID)
+	}
+	if m.priority != nil {
+		fields = append(fields, accountgroup.FieldPriority)
+	}
+	if m.created_at != nil {
+		fields = append(fields, accountgroup.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *AccountGroupMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case accountgroup.FieldAccountID:
+		return m.AccountID()
+	case accountgroup.FieldGroupID:
+		return m.GroupID()
+	case accountgroup.FieldPriority:
+		return m.Priority()
+	case accountgroup.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *AccountGroupMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	return nil, errors.New("edge schema AccountGroup does not support getting old values")
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *AccountGroupMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case accountgroup.FieldAccountID:
+		v, ok := value.(int64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetRateLimitResetAt(v)
+		m.SetAccountID(v)
 		return nil
-	case account.FieldOverloadUntil:
-		v, ok := value.(time.Time)
+	case accountgroup.FieldGroupID:
+		v, ok := value.(int64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetOverloadUntil(v)
+		m.SetGroupID(v)
 		return nil
-	case account.FieldSessionWindowStart:
-		v, ok := value.(time.Time)
+	case accountgroup.FieldPriority:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSessionWindowStart(v)
+		m.SetPriority(v)
 		return nil
-	case account.FieldSessionWindowEnd:
+	case accountgroup.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSessionWindowEnd(v)
-		return nil
-	case account.FieldSessionWindowStatus:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSessionWindowStatus(v)
+		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Account field %s", name)
+	return fmt.Errorf("unknown AccountGroup field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *AccountMutation) AddedFields() []string {
+func (m *AccountGroupMutation) AddedFields() []string {
 	var fields []string
-	if m.addconcurrency != nil {
-		fields = append(fields, account.FieldConcurrency)
-	}
 	if m.addpriority != nil {
-		fields = append(fields, account.FieldPriority)
-	}
-	if m.addrate_multiplier != nil {
-		fields = append(fields, account.FieldRateMultiplier)
+		fields = append(fields, accountgroup.FieldPriority)
 	}
 	return fields
 }
@@ -3001,14 +4463,10 @@ func (m *AccountMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *AccountMutation) AddedField(name string) (ent.Value, bool) {
+func (m *AccountGroupMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case account.FieldConcurrency:
-		return m.AddedConcurrency()
-	case account.FieldPriority:
+	case accountgroup.FieldPriority:
 		return m.AddedPriority()
-	case account.FieldRateMultiplier:
-		return m.AddedRateMultiplier()
 	}
 	return nil, false
 }
@@ -3016,367 +4474,240 @@ func (m *AccountMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *AccountMutation) AddField(name string, value ent.Value) error {
+func (m *AccountGroupMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case account.FieldConcurrency:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddConcurrency(v)
-		return nil
-	case account.FieldPriority:
+	case accountgroup.FieldPriority:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.AddPriority(v)
 		return nil
-	case account.FieldRateMultiplier:
-		v, ok := value.(float64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddRateMultiplier(v)
-		return nil
-	}
-	return fmt.Errorf("unknown Account numeric field %s", name)
-}
-
-// ClearedFields returns all nullable fields that were cleared during this
-// mutation.
-func (m *AccountMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(account.FieldDeletedAt) {
-		fields = append(fields, account.FieldDeletedAt)
-	}
-	if m.FieldCleared(account.FieldNotes) {
-		fields = append(fields, account.FieldNotes)
-	}
-	if m.FieldCleared(account.FieldProxyID) {
-		fields = append(fields, account.FieldProxyID)
-	}
-	if m.FieldCleared(account.FieldErrorMessage) {
-		fields = append(fields, account.FieldErrorMessage)
-	}
-	if m.FieldCleared(account.FieldLastUsedAt) {
-		fields = append(fields, account.FieldLastUsedAt)
-	}
-	if m.FieldCleared(account.FieldExpiresAt) {
-		fields = append(fields, account.FieldExpiresAt)
-	}
-	if m.FieldCleared(account.FieldRateLimitedAt) {
-		fields = append(fields, account.FieldRateLimitedAt)
-	}
-	if m.FieldCleared(account.FieldRateLimitResetAt) {
-		fields = append(fields, account.FieldRateLimitResetAt)
-	}
-	if m.FieldCleared(account.FieldOverloadUntil) {
-		fields = append(fields, account.FieldOverloadUntil)
-	}
-	if m.FieldCleared(account.FieldSessionWindowStart) {
-		fields = append(fields, account.FieldSessionWindowStart)
-	}
-	if m.FieldCleared(account.FieldSessionWindowEnd) {
-		fields = append(fields, account.FieldSessionWindowEnd)
-	}
-	if m.FieldCleared(account.FieldSessionWindowStatus) {
-		fields = append(fields, account.FieldSessionWindowStatus)
-	}
-	return fields
-}
-
-// FieldCleared returns a boolean indicating if a field with the given name was
-// cleared in this mutation.
-func (m *AccountMutation) FieldCleared(name string) bool {
-	_, ok := m.clearedFields[name]
-	return ok
-}
-
-// ClearField clears the value of the field with the given name. It returns an
-// error if the field is not defined in the schema.
-func (m *AccountMutation) ClearField(name string) error {
-	switch name {
-	case account.FieldDeletedAt:
-		m.ClearDeletedAt()
-		return nil
-	case account.FieldNotes:
-		m.ClearNotes()
-		return nil
-	case account.FieldProxyID:
-		m.ClearProxyID()
-		return nil
-	case account.FieldErrorMessage:
-		m.ClearErrorMessage()
-		return nil
-	case account.FieldLastUsedAt:
-		m.ClearLastUsedAt()
-		return nil
-	case account.FieldExpiresAt:
-		m.ClearExpiresAt()
-		return nil
-	case account.FieldRateLimitedAt:
-		m.ClearRateLimitedAt()
-		return nil
-	case account.FieldRateLimitResetAt:
-		m.ClearRateLimitResetAt()
-		return nil
-	case account.FieldOverloadUntil:
-		m.ClearOverloadUntil()
-		return nil
-	case account.FieldSessionWindowStart:
-		m.ClearSessionWindowStart()
-		return nil
-	case account.FieldSessionWindowEnd:
-		m.ClearSessionWindowEnd()
-		return nil
-	case account.FieldSessionWindowStatus:
-		m.ClearSessionWindowStatus()
-		return nil
 	}
-	return fmt.Errorf("unknown Account nullable field %s", name)
-}
-
-// ResetField resets all changes in the mutation for the field with the given name.
-// It returns an error if the field is not defined in the schema.
-func (m *AccountMutation) ResetField(name string) error {
-	switch name {
-	case account.FieldCreatedAt:
-		m.ResetCreatedAt()
-		return nil
-	case account.FieldUpdatedAt:
-		m.ResetUpdatedAt()
-		return nil
-	case account.FieldDeletedAt:
-		m.ResetDeletedAt()
-		return nil
-	case account.FieldName:
-		m.ResetName()
-		return nil
-	case account.FieldNotes:
-		m.ResetNotes()
-		return nil
-	case account.FieldPlatform:
-		m.ResetPlatform()
-		return nil
-	case account.FieldType:
-		m.ResetType()
-		return nil
-	case account.FieldCredentials:
-		m.ResetCredentials()
-		return nil
-	case account.FieldExtra:
-		m.ResetExtra()
-		return nil
-	case account.FieldProxyID:
-		m.ResetProxyID()
-		return nil
-	case account.FieldConcurrency:
-		m.ResetConcurrency()
-		return nil
-	case account.FieldPriority:
-		m.ResetPriority()
-		return nil
-	case account.FieldRateMultiplier:
-		m.ResetRateMultiplier()
-		return nil
-	case account.FieldStatus:
-		m.ResetStatus()
-		return nil
-	case account.FieldErrorMessage:
-		m.ResetErrorMessage()
-		return nil
-	case account.FieldLastUsedAt:
-		m.ResetLastUsedAt()
-		return nil
-	case account.FieldExpiresAt:
-		m.ResetExpiresAt()
-		return nil
-	case account.FieldAutoPauseOnExpired:
-		m.ResetAutoPauseOnExpired()
-		return nil
-	case account.FieldSchedulable:
-		m.ResetSchedulable()
-		return nil
-	case account.FieldRateLimitedAt:
-		m.ResetRateLimitedAt()
-		return nil
-	case account.FieldRateLimitResetAt:
-		m.ResetRateLimitResetAt()
-		return nil
-	case account.FieldOverloadUntil:
-		m.ResetOverloadUntil()
+	return fmt.Errorf("unknown AccountGroup numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *AccountGroupMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *AccountGroupMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *AccountGroupMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown AccountGroup nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *AccountGroupMutation) ResetField(name string) error {
+	switch name {
+	case accountgroup.FieldAccountID:
+		m.ResetAccountID()
 		return nil
-	case account.FieldSessionWindowStart:
-		m.ResetSessionWindowStart()
+	case accountgroup.FieldGroupID:
+		m.ResetGroupID()
 		return nil
-	case account.FieldSessionWindowEnd:
-		m.ResetSessionWindowEnd()
+	case accountgroup.FieldPriority:
+		m.ResetPriority()
 		return nil
-	case account.FieldSessionWindowStatus:
-		m.ResetSessionWindowStatus()
+	case accountgroup.FieldCreatedAt:
+		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Account field %s", name)
+	return fmt.Errorf("unknown AccountGroup field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *AccountMutation) AddedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.groups != nil {
-		edges = append(edges, account.EdgeGroups)
-	}
-	if m.proxy != nil {
-		edges = append(edges, account.EdgeProxy)
+func (m *AccountGroupMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.account != nil {
+		edges = append(edges, accountgroup.EdgeAccount)
 	}
-	if m.usage_logs != nil {
-		edges = append(edges, account.EdgeUsageLogs)
+	if m.group != nil {
+		edges = append(edges, accountgroup.EdgeGroup)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *AccountMutation) AddedIDs(name string) []ent.Value {
+func (m *AccountGroupMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case account.EdgeGroups:
-		ids := make([]ent.Value, 0, len(m.groups))
-		for id := range m.groups {
-			ids = append(ids, id)
-		}
-		return ids
-	case account.EdgeProxy:
-		if id := m.proxy; id != nil {
+	case accountgroup.EdgeAccount:
+		if id := m.account; id != nil {
 			return []ent.Value{*id}
 		}
-	case account.EdgeUsageLogs:
-		ids := make([]ent.Value, 0, len(m.usage_logs))
-		for id := range m.usage_logs {
-			ids = append(ids, id)
+	case accountgroup.EdgeGroup:
+		if id := m.group; id != nil {
+			return []ent.Value{*id}
 		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *AccountMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.removedgroups != nil {
-		edges = append(edges, account.EdgeGroups)
-	}
-	if m.removedusage_logs != nil {
-		edges = append(edges, account.EdgeUsageLogs)
-	}
+func (m *AccountGroupMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *AccountMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case account.EdgeGroups:
-		ids := make([]ent.Value, 0, len(m.removedgroups))
-		for id := range m.removedgroups {
-			ids = append(ids, id)
-		}
-		return ids
-	case account.EdgeUsageLogs:
-		ids := make([]ent.Value, 0, len(m.removedusage_logs))
-		for id := range m.removedusage_logs {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *AccountGroupMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *AccountMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 3)
-	if m.clearedgroups {
-		edges = append(edges, account.EdgeGroups)
-	}
-	if m.clearedproxy {
-		edges = append(edges, account.EdgeProxy)
+func (m *AccountGroupMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedaccount {
+		edges = append(edges, accountgroup.EdgeAccount)
 	}
-	if m.clearedusage_logs {
-		edges = append(edges, account.EdgeUsageLogs)
+	if m.clearedgroup {
+		edges = append(edges, accountgroup.EdgeGroup)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *AccountMutation) EdgeCleared(name string) bool {
+func (m *AccountGroupMutation) EdgeCleared(name string) bool {
 	switch name {
-	case account.EdgeGroups:
-		return m.clearedgroups
-	case account.EdgeProxy:
-		return m.clearedproxy
-	case account.EdgeUsageLogs:
-		return m.clearedusage_logs
+	case accountgroup.EdgeAccount:
+		return m.clearedaccount
+	case accountgroup.EdgeGroup:
+		return m.clearedgroup
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *AccountMutation) ClearEdge(name string) error {
+func (m *AccountGroupMutation) ClearEdge(name string) error {
 	switch name {
-	case account.EdgeProxy:
-		m.ClearProxy()
+	case accountgroup.EdgeAccount:
+		m.ClearAccount()
+		return nil
+	case accountgroup.EdgeGroup:
+		m.ClearGroup()
 		return nil
 	}
-	return fmt.Errorf("unknown Account unique edge %s", name)
+	return fmt.Errorf("unknown AccountGroup unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *AccountMutation) ResetEdge(name string) error {
+func (m *AccountGroupMutation) ResetEdge(name string) error {
 	switch name {
-	case account.EdgeGroups:
-		m.ResetGroups()
-		return nil
-	case account.EdgeProxy:
-		m.ResetProxy()
+	case accountgroup.EdgeAccount:
+		m.ResetAccount()
 		return nil
-	case account.EdgeUsageLogs:
-		m.ResetUsageLogs()
+	case accountgroup.EdgeGroup:
+		m.ResetGroup()
 		return nil
 	}
-	return fmt.Errorf("unknown Account edge %s", name)
+	return fmt.Errorf("unknown AccountGroup edge %s", name)
 }
 
-// AccountGroupMutation represents an operation that mutates the AccountGroup nodes in the graph.
-type AccountGroupMutation struct {
+// GroupMutation represents an operation that mutates the Group nodes in the graph.
+type GroupMutation struct {
 	config
-	op             Op
-	typ            string
-	priority       *int
-	addpriority    *int
-	created_at     *time.Time
-	clearedFields  map[string]struct{}
-	account        *int64
-	clearedaccount bool
-	group          *int64
-	clearedgroup   bool
-	done           bool
-	oldValue       func(context.Context) (*AccountGroup, error)
-	predicates     []predicate.AccountGroup
+	op                            Op
+	typ                           string
+	id                            *int64
+	created_at                    *time.Time
+	updated_at                    *time.Time
+	deleted_at                    *time.Time
+	name                          *string
+	description                   *string
+	rate_multiplier               *float64
+	addrate_multiplier            *float64
+	is_exclusive                  *bool
+	status                        *string
+	platform                      *string
+	subscription_type             *string
+	daily_limit_usd               *float64
+	adddaily_limit_usd            *float64
+	weekly_limit_usd              *float64
+	addweekly_limit_usd           *float64
+	monthly_limit_usd             *float64
+	addmonthly_limit_usd          *float64
+	default_validity_days         *int
+	adddefault_validity_days      *int
+	image_price_1k                *float64
+	addimage_price_1k             *float64
+	image_price_2k                *float64
+	addimage_price_2k             *float64
+	image_price_4k                *float64
+	addimage_price_4k             *float64
+	claude_code_only              *bool
+	fallback_group_id             *int64
+	addfallback_group_id          *int64
+	model_routing                 *map[string][]int64
+	model_routing_enabled         *bool
+	default_rpm_limit             *int
+	adddefault_rpm_limit          *int
+	default_tpm_limit             *int
+	adddefault_tpm_limit          *int
+	response_cache_enabled        *bool
+	response_cache_ttl_seconds    *int
+	addresponse_cache_ttl_seconds *int
+	response_cache_rate           *float64
+	addresponse_cache_rate        *float64
+	model_fallbacks               *json.RawMessage
+	appendmodel_fallbacks         json.RawMessage
+	guardrails                    *json.RawMessage
+	appendguardrails              json.RawMessage
+	scheduling_strategy           *string
+	scheduling_weights            *map[int64]int
+	hedge_delay_ms                *int
+	addhedge_delay_ms             *int
+	queue_weight                  *int
+	addqueue_weight               *int
+	clearedFields                 map[string]struct{}
+	api_keys                      map[int64]struct{}
+	removedapi_keys               map[int64]struct{}
+	clearedapi_keys               bool
+	redeem_codes                  map[int64]struct{}
+	removedredeem_codes           map[int64]struct{}
+	clearedredeem_codes           bool
+	subscriptions                 map[int64]struct{}
+	removedsubscriptions          map[int64]struct{}
+	clearedsubscriptions          bool
+	usage_logs                    map[int64]struct{}
+	removedusage_logs             map[int64]struct{}
+	clearedusage_logs             bool
+	accounts                      map[int64]struct{}
+	removedaccounts               map[int64]struct{}
+	clearedaccounts               bool
+	allowed_users                 map[int64]struct{}
+	removedallowed_users          map[int64]struct{}
+	clearedallowed_users          bool
+	done                          bool
+	oldValue                      func(context.Context) (*Group, error)
+	predicates                    []predicate.Group
 }
 
-var _ ent.Mutation = (*AccountGroupMutation)(nil)
+var _ ent.Mutation = (*GroupMutation)(nil)
 
-// accountgroupOption allows management of the mutation configuration using functional options.
-type accountgroupOption func(*AccountGroupMutation)
+// groupOption allows management of the mutation configuration using functional options.
+type groupOption func(*GroupMutation)
 
-// newAccountGroupMutation creates new mutation for the AccountGroup entity.
-func newAccountGroupMutation(c config, op Op, opts ...accountgroupOption) *AccountGroupMutation {
-	m := &AccountGroupMutation{
+// newGroupMutation creates new mutation for the Group entity.
+func newGroupMutation(c config, op Op, opts ...groupOption) *GroupMutation {
+	m := &GroupMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeAccountGroup,
+		typ:           TypeGroup,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -3385,9 +4716,41 @@ func newAccountGroupMutation(c config, op Op, opts ...accountgroupOption) *Accou
 	return m
 }
 
+// withGroupID sets the ID field of the mutation.
+func withGroupID(id int64) groupOption {
+	return func(m *GroupMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Group
+		)
+		m.oldValue = func(ctx context.Context) (*Group, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Group.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withGroup sets the old Group of the mutation.
+func withGroup(node *Group) groupOption {
+	return func(m *GroupMutation) {
+		m.oldValue = func(context.Context) (*Group, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m AccountGroupMutation) Client() *Client {
+func (m GroupMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -3395,1673 +4758,1743 @@ func (m AccountGroupMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m AccountGroupMutation) Tx() (*Tx, error) {
+func (m GroupMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
 	tx := &Tx{config: m.config}
 	tx.init()
-	return tx, nil
-}
-
-// SetAccountID sets the "account_id" field.
-func (m *AccountGroupMutation) SetAccountID(i int64) {
-	m.account = &i
-}
-
-// AccountID returns the value of the "account_id" field in the mutation.
-func (m *AccountGroupMutation) AccountID() (r int64, exists bool) {
-	v := m.account
-	if v == nil {
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *GroupMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
 		return
 	}
-	return *v, true
+	return *m.id, true
 }
 
-// ResetAccountID resets all changes to the "account_id" field.
-func (m *AccountGroupMutation) ResetAccountID() {
-	m.account = nil
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *GroupMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Group.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
 }
 
-// SetGroupID sets the "group_id" field.
-func (m *AccountGroupMutation) SetGroupID(i int64) {
-	m.group = &i
+// SetCreatedAt sets the "created_at" field.
+func (m *GroupMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// GroupID returns the value of the "group_id" field in the mutation.
-func (m *AccountGroupMutation) GroupID() (r int64, exists bool) {
-	v := m.group
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *GroupMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetGroupID resets all changes to the "group_id" field.
-func (m *AccountGroupMutation) ResetGroupID() {
-	m.group = nil
+// OldCreatedAt returns the old "created_at" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
 }
 
-// SetPriority sets the "priority" field.
-func (m *AccountGroupMutation) SetPriority(i int) {
-	m.priority = &i
-	m.addpriority = nil
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *GroupMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// Priority returns the value of the "priority" field in the mutation.
-func (m *AccountGroupMutation) Priority() (r int, exists bool) {
-	v := m.priority
+// SetUpdatedAt sets the "updated_at" field.
+func (m *GroupMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *GroupMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// AddPriority adds i to the "priority" field.
-func (m *AccountGroupMutation) AddPriority(i int) {
-	if m.addpriority != nil {
-		*m.addpriority += i
-	} else {
-		m.addpriority = &i
+// OldUpdatedAt returns the old "updated_at" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
-}
-
-// AddedPriority returns the value that was added to the "priority" field in this mutation.
-func (m *AccountGroupMutation) AddedPriority() (r int, exists bool) {
-	v := m.addpriority
-	if v == nil {
-		return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
-	return *v, true
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
 }
 
-// ResetPriority resets all changes to the "priority" field.
-func (m *AccountGroupMutation) ResetPriority() {
-	m.priority = nil
-	m.addpriority = nil
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *GroupMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *AccountGroupMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetDeletedAt sets the "deleted_at" field.
+func (m *GroupMutation) SetDeletedAt(t time.Time) {
+	m.deleted_at = &t
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *AccountGroupMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// DeletedAt returns the value of the "deleted_at" field in the mutation.
+func (m *GroupMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deleted_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *AccountGroupMutation) ResetCreatedAt() {
-	m.created_at = nil
+// OldDeletedAt returns the old "deleted_at" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+	}
+	return oldValue.DeletedAt, nil
 }
 
-// ClearAccount clears the "account" edge to the Account entity.
-func (m *AccountGroupMutation) ClearAccount() {
-	m.clearedaccount = true
-	m.clearedFields[accountgroup.FieldAccountID] = struct{}{}
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (m *GroupMutation) ClearDeletedAt() {
+	m.deleted_at = nil
+	m.clearedFields[group.FieldDeletedAt] = struct{}{}
 }
 
-// AccountCleared reports if the "account" edge to the Account entity was cleared.
-func (m *AccountGroupMutation) AccountCleared() bool {
-	return m.clearedaccount
+// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
+func (m *GroupMutation) DeletedAtCleared() bool {
+	_, ok := m.clearedFields[group.FieldDeletedAt]
+	return ok
 }
 
-// AccountIDs returns the "account" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// AccountID instead. It exists only for internal usage by the builders.
-func (m *AccountGroupMutation) AccountIDs() (ids []int64) {
-	if id := m.account; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// ResetDeletedAt resets all changes to the "deleted_at" field.
+func (m *GroupMutation) ResetDeletedAt() {
+	m.deleted_at = nil
+	delete(m.clearedFields, group.FieldDeletedAt)
 }
 
-// ResetAccount resets all changes to the "account" edge.
-func (m *AccountGroupMutation) ResetAccount() {
-	m.account = nil
-	m.clearedaccount = false
+// SetName sets the "name" field.
+func (m *GroupMutation) SetName(s string) {
+	m.name = &s
 }
 
-// ClearGroup clears the "group" edge to the Group entity.
-func (m *AccountGroupMutation) ClearGroup() {
-	m.clearedgroup = true
-	m.clearedFields[accountgroup.FieldGroupID] = struct{}{}
+// Name returns the value of the "name" field in the mutation.
+func (m *GroupMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// GroupCleared reports if the "group" edge to the Group entity was cleared.
-func (m *AccountGroupMutation) GroupCleared() bool {
-	return m.clearedgroup
+// OldName returns the old "name" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
 }
 
-// GroupIDs returns the "group" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// GroupID instead. It exists only for internal usage by the builders.
-func (m *AccountGroupMutation) GroupIDs() (ids []int64) {
-	if id := m.group; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// ResetName resets all changes to the "name" field.
+func (m *GroupMutation) ResetName() {
+	m.name = nil
 }
 
-// ResetGroup resets all changes to the "group" edge.
-func (m *AccountGroupMutation) ResetGroup() {
-	m.group = nil
-	m.clearedgroup = false
+// SetDescription sets the "description" field.
+func (m *GroupMutation) SetDescription(s string) {
+	m.description = &s
 }
 
-// Where appends a list predicates to the AccountGroupMutation builder.
-func (m *AccountGroupMutation) Where(ps ...predicate.AccountGroup) {
-	m.predicates = append(m.predicates, ps...)
+// Description returns the value of the "description" field in the mutation.
+func (m *GroupMutation) Description() (r string, exists bool) {
+	v := m.description
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// WhereP appends storage-level predicates to the AccountGroupMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *AccountGroupMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.AccountGroup, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
+// OldDescription returns the old "description" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldDescription(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
 	}
-	m.Where(p...)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDescription requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+	}
+	return oldValue.Description, nil
+}
+
+// ClearDescription clears the value of the "description" field.
+func (m *GroupMutation) ClearDescription() {
+	m.description = nil
+	m.clearedFields[group.FieldDescription] = struct{}{}
 }
 
-// Op returns the operation name.
-func (m *AccountGroupMutation) Op() Op {
-	return m.op
+// DescriptionCleared returns if the "description" field was cleared in this mutation.
+func (m *GroupMutation) DescriptionCleared() bool {
+	_, ok := m.clearedFields[group.FieldDescription]
+	return ok
 }
 
-// SetOp allows setting the mutation operation.
-func (m *AccountGroupMutation) SetOp(op Op) {
-	m.op = op
+// ResetDescription resets all changes to the "description" field.
+func (m *GroupMutation) ResetDescription() {
+	m.description = nil
+	delete(m.clearedFields, group.FieldDescription)
 }
 
-// Type returns the node type of this mutation (AccountGroup).
-func (m *AccountGroupMutation) Type() string {
-	return m.typ
+// SetRateMultiplier sets the "rate_multiplier" field.
+func (m *GroupMutation) SetRateMultiplier(f float64) {
+	m.rate_multiplier = &f
+	m.addrate_multiplier = nil
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *AccountGroupMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.account != nil {
-		fields = append(fields, accountgroup.FieldAccountID)
+// RateMultiplier returns the value of the "rate_multiplier" field in the mutation.
+func (m *GroupMutation) RateMultiplier() (r float64, exists bool) {
+	v := m.rate_multiplier
+	if v == nil {
+		return
 	}
-	if m.group != nil {
-		fields = append(fields, accountgroup.FieldGroupID)
+	return *v, true
+}
+
+// OldRateMultiplier returns the old "rate_multiplier" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldRateMultiplier(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRateMultiplier is only allowed on UpdateOne operations")
 	}
-	if m.priority != nil {
-		fields = append(fields, accountgroup.FieldPriority)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRateMultiplier requires an ID field in the mutation")
 	}
-	if m.created_at != nil {
-		fields = append(fields, accountgroup.FieldCreatedAt)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRateMultiplier: %w", err)
 	}
-	return fields
+	return oldValue.RateMultiplier, nil
 }
 
-// Field returns the value of a field with the given name. The second boolean
-// return value indicates that this field was not set, or was not defined in the
-// schema.
-func (m *AccountGroupMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case accountgroup.FieldAccountID:
-		return m.AccountID()
-	case accountgroup.FieldGroupID:
-		return m.GroupID()
-	case accountgroup.FieldPriority:
-		return m.Priority()
-	case accountgroup.FieldCreatedAt:
-		return m.CreatedAt()
+// AddRateMultiplier adds f to the "rate_multiplier" field.
+func (m *GroupMutation) AddRateMultiplier(f float64) {
+	if m.addrate_multiplier != nil {
+		*m.addrate_multiplier += f
+	} else {
+		m.addrate_multiplier = &f
 	}
-	return nil, false
-}
-
-// OldField returns the old value of the field from the database. An error is
-// returned if the mutation operation is not UpdateOne, or the query to the
-// database failed.
-func (m *AccountGroupMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	return nil, errors.New("edge schema AccountGroup does not support getting old values")
 }
 
-// SetField sets the value of a field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *AccountGroupMutation) SetField(name string, value ent.Value) error {
-	switch name {
-	case accountgroup.FieldAccountID:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetAccountID(v)
-		return nil
-	case accountgroup.FieldGroupID:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetGroupID(v)
-		return nil
-	case accountgroup.FieldPriority:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPriority(v)
-		return nil
-	case accountgroup.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
-		return nil
+// AddedRateMultiplier returns the value that was added to the "rate_multiplier" field in this mutation.
+func (m *GroupMutation) AddedRateMultiplier() (r float64, exists bool) {
+	v := m.addrate_multiplier
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown AccountGroup field %s", name)
+	return *v, true
 }
 
-// AddedFields returns all numeric fields that were incremented/decremented during
-// this mutation.
-func (m *AccountGroupMutation) AddedFields() []string {
-	var fields []string
-	if m.addpriority != nil {
-		fields = append(fields, accountgroup.FieldPriority)
-	}
-	return fields
+// ResetRateMultiplier resets all changes to the "rate_multiplier" field.
+func (m *GroupMutation) ResetRateMultiplier() {
+	m.rate_multiplier = nil
+	m.addrate_multiplier = nil
 }
 
-// AddedField returns the numeric value that was incremented/decremented on a field
-// with the given name. The second boolean return value indicates that this field
-// was not set, or was not defined in the schema.
-func (m *AccountGroupMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case accountgroup.FieldPriority:
-		return m.AddedPriority()
-	}
-	return nil, false
+// SetIsExclusive sets the "is_exclusive" field.
+func (m *GroupMutation) SetIsExclusive(b bool) {
+	m.is_exclusive = &b
 }
 
-// AddField adds the value to the field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *AccountGroupMutation) AddField(name string, value ent.Value) error {
-	switch name {
-	case accountgroup.FieldPriority:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddPriority(v)
-		return nil
+// IsExclusive returns the value of the "is_exclusive" field in the mutation.
+func (m *GroupMutation) IsExclusive() (r bool, exists bool) {
+	v := m.is_exclusive
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown AccountGroup numeric field %s", name)
+	return *v, true
 }
 
-// ClearedFields returns all nullable fields that were cleared during this
-// mutation.
-func (m *AccountGroupMutation) ClearedFields() []string {
-	return nil
+// OldIsExclusive returns the old "is_exclusive" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldIsExclusive(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIsExclusive is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIsExclusive requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIsExclusive: %w", err)
+	}
+	return oldValue.IsExclusive, nil
 }
 
-// FieldCleared returns a boolean indicating if a field with the given name was
-// cleared in this mutation.
-func (m *AccountGroupMutation) FieldCleared(name string) bool {
-	_, ok := m.clearedFields[name]
-	return ok
+// ResetIsExclusive resets all changes to the "is_exclusive" field.
+func (m *GroupMutation) ResetIsExclusive() {
+	m.is_exclusive = nil
 }
 
-// ClearField clears the value of the field with the given name. It returns an
-// error if the field is not defined in the schema.
-func (m *AccountGroupMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown AccountGroup nullable field %s", name)
+// SetStatus sets the "status" field.
+func (m *GroupMutation) SetStatus(s string) {
+	m.status = &s
 }
 
-// ResetField resets all changes in the mutation for the field with the given name.
-// It returns an error if the field is not defined in the schema.
-func (m *AccountGroupMutation) ResetField(name string) error {
-	switch name {
-	case accountgroup.FieldAccountID:
-		m.ResetAccountID()
-		return nil
-	case accountgroup.FieldGroupID:
-		m.ResetGroupID()
-		return nil
-	case accountgroup.FieldPriority:
-		m.ResetPriority()
-		return nil
-	case accountgroup.FieldCreatedAt:
-		m.ResetCreatedAt()
-		return nil
+// Status returns the value of the "status" field in the mutation.
+func (m *GroupMutation) Status() (r string, exists bool) {
+	v := m.status
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown AccountGroup field %s", name)
+	return *v, true
 }
 
-// AddedEdges returns all edge names that were set/added in this mutation.
-func (m *AccountGroupMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.account != nil {
-		edges = append(edges, accountgroup.EdgeAccount)
+// OldStatus returns the old "status" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldStatus(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
 	}
-	if m.group != nil {
-		edges = append(edges, accountgroup.EdgeGroup)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
 	}
-	return edges
-}
-
-// AddedIDs returns all IDs (to other nodes) that were added for the given edge
-// name in this mutation.
-func (m *AccountGroupMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case accountgroup.EdgeAccount:
-		if id := m.account; id != nil {
-			return []ent.Value{*id}
-		}
-	case accountgroup.EdgeGroup:
-		if id := m.group; id != nil {
-			return []ent.Value{*id}
-		}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
 	}
-	return nil
+	return oldValue.Status, nil
 }
 
-// RemovedEdges returns all edge names that were removed in this mutation.
-func (m *AccountGroupMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
-	return edges
+// ResetStatus resets all changes to the "status" field.
+func (m *GroupMutation) ResetStatus() {
+	m.status = nil
 }
 
-// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
-// the given name in this mutation.
-func (m *AccountGroupMutation) RemovedIDs(name string) []ent.Value {
-	return nil
+// SetPlatform sets the "platform" field.
+func (m *GroupMutation) SetPlatform(s string) {
+	m.platform = &s
 }
 
-// ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *AccountGroupMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedaccount {
-		edges = append(edges, accountgroup.EdgeAccount)
-	}
-	if m.clearedgroup {
-		edges = append(edges, accountgroup.EdgeGroup)
+// Platform returns the value of the "platform" field in the mutation.
+func (m *GroupMutation) Platform() (r string, exists bool) {
+	v := m.platform
+	if v == nil {
+		return
 	}
-	return edges
+	return *v, true
 }
 
-// EdgeCleared returns a boolean which indicates if the edge with the given name
-// was cleared in this mutation.
-func (m *AccountGroupMutation) EdgeCleared(name string) bool {
-	switch name {
-	case accountgroup.EdgeAccount:
-		return m.clearedaccount
-	case accountgroup.EdgeGroup:
-		return m.clearedgroup
+// OldPlatform returns the old "platform" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldPlatform(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPlatform is only allowed on UpdateOne operations")
 	}
-	return false
-}
-
-// ClearEdge clears the value of the edge with the given name. It returns an error
-// if that edge is not defined in the schema.
-func (m *AccountGroupMutation) ClearEdge(name string) error {
-	switch name {
-	case accountgroup.EdgeAccount:
-		m.ClearAccount()
-		return nil
-	case accountgroup.EdgeGroup:
-		m.ClearGroup()
-		return nil
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPlatform requires an ID field in the mutation")
 	}
-	return fmt.Errorf("unknown AccountGroup unique edge %s", name)
-}
-
-// ResetEdge resets all changes to the edge with the given name in this mutation.
-// It returns an error if the edge is not defined in the schema.
-func (m *AccountGroupMutation) ResetEdge(name string) error {
-	switch name {
-	case accountgroup.EdgeAccount:
-		m.ResetAccount()
-		return nil
-	case accountgroup.EdgeGroup:
-		m.ResetGroup()
-		return nil
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPlatform: %w", err)
 	}
-	return fmt.Errorf("unknown AccountGroup edge %s", name)
+	return oldValue.Platform, nil
 }
 
-// GroupMutation represents an operation that mutates the Group nodes in the graph.
-type GroupMutation struct {
-	config
-	op                       Op
-	typ                      string
-	id                       *int64
-	created_at               *time.Time
-	updated_at               *time.Time
-	deleted_at               *time.Time
-	name                     *string
-	description              *string
-	rate_multiplier          *float64
-	addrate_multiplier       *float64
-	is_exclusive             *bool
-	status                   *string
-	platform                 *string
-	subscription_type        *string
-	daily_limit_usd          *float64
-	adddaily_limit_usd       *float64
-	weekly_limit_usd         *float64
-	addweekly_limit_usd      *float64
-	monthly_limit_usd        *float64
-	addmonthly_limit_usd     *float64
-	default_validity_days    *int
-	adddefault_validity_days *int
-	image_price_1k           *float64
-	addimage_price_1k        *float64
-	image_price_2k           *float64
-	addimage_price_2k        *float64
-	image_price_4k           *float64
-	addimage_price_4k        *float64
-	claude_code_only         *bool
-	fallback_group_id        *int64
-	addfallback_group_id     *int64
-	model_routing            *map[string][]int64
-	model_routing_enabled    *bool
-	clearedFields            map[string]struct{}
-	api_keys                 map[int64]struct{}
-	removedapi_keys          map[int64]struct{}
-	clearedapi_keys          bool
-	redeem_codes             map[int64]struct{}
-	removedredeem_codes      map[int64]struct{}
-	clearedredeem_codes      bool
-	subscriptions            map[int64]struct{}
-	removedsubscriptions     map[int64]struct{}
-	clearedsubscriptions     bool
-	usage_logs               map[int64]struct{}
-	removedusage_logs        map[int64]struct{}
-	clearedusage_logs        bool
-	accounts                 map[int64]struct{}
-	removedaccounts          map[int64]struct{}
-	clearedaccounts          bool
-	allowed_users            map[int64]struct{}
-	removedallowed_users     map[int64]struct{}
-	clearedallowed_users     bool
-	done                     bool
-	oldValue                 func(context.Context) (*Group, error)
-	predicates               []predicate.Group
+// ResetPlatform resets all changes to the "platform" field.
+func (m *GroupMutation) ResetPlatform() {
+	m.platform = nil
 }
 
-var _ ent.Mutation = (*GroupMutation)(nil)
+// SetSubscriptionType sets the "subscription_type" field.
+func (m *GroupMutation) SetSubscriptionType(s string) {
+	m.subscription_type = &s
+}
 
-// groupOption allows management of the mutation configuration using functional options.
-type groupOption func(*GroupMutation)
+// SubscriptionType returns the value of the "subscription_type" field in the mutation.
+func (m *GroupMutation) SubscriptionType() (r string, exists bool) {
+	v := m.subscription_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
 
-// newGroupMutation creates new mutation for the Group entity.
-func newGroupMutation(c config, op Op, opts ...groupOption) *GroupMutation {
-	m := &GroupMutation{
-		config:        c,
-		op:            op,
-		typ:           TypeGroup,
-		clearedFields: make(map[string]struct{}),
+// OldSubscriptionType returns the old "subscription_type" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldSubscriptionType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSubscriptionType is only allowed on UpdateOne operations")
 	}
-	for _, opt := range opts {
-		opt(m)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSubscriptionType requires an ID field in the mutation")
 	}
-	return m
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSubscriptionType: %w", err)
+	}
+	return oldValue.SubscriptionType, nil
 }
 
-// withGroupID sets the ID field of the mutation.
-func withGroupID(id int64) groupOption {
-	return func(m *GroupMutation) {
-		var (
-			err   error
-			once  sync.Once
-			value *Group
-		)
-		m.oldValue = func(ctx context.Context) (*Group, error) {
-			once.Do(func() {
-				if m.done {
-					err = errors.New("querying old values post mutation is not allowed")
-				} else {
-					value, err = m.Client().Group.Get(ctx, id)
-				}
-			})
-			return value, err
-		}
-		m.id = &id
-	}
+// ResetSubscriptionType resets all changes to the "subscription_type" field.
+func (m *GroupMutation) ResetSubscriptionType() {
+	m.subscription_type = nil
 }
 
-// withGroup sets the old Group of the mutation.
-func withGroup(node *Group) groupOption {
-	return func(m *GroupMutation) {
-		m.oldValue = func(context.Context) (*Group, error) {
-			return node, nil
-		}
-		m.id = &node.ID
+// SetDailyLimitUsd sets the "daily_limit_usd" field.
+func (m *GroupMutation) SetDailyLimitUsd(f float64) {
+	m.daily_limit_usd = &f
+	m.adddaily_limit_usd = nil
+}
+
+// DailyLimitUsd returns the value of the "daily_limit_usd" field in the mutation.
+func (m *GroupMutation) DailyLimitUsd() (r float64, exists bool) {
+	v := m.daily_limit_usd
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// Client returns a new `ent.Client` from the mutation. If the mutation was
-// executed in a transaction (ent.Tx), a transactional client is returned.
-func (m GroupMutation) Client() *Client {
-	client := &Client{config: m.config}
-	client.init()
-	return client
+// OldDailyLimitUsd returns the old "daily_limit_usd" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldDailyLimitUsd(ctx context.Context) (v *float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDailyLimitUsd is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDailyLimitUsd requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDailyLimitUsd: %w", err)
+	}
+	return oldValue.DailyLimitUsd, nil
 }
 
-// Tx returns an `ent.Tx` for mutations that were executed in transactions;
-// it returns an error otherwise.
-func (m GroupMutation) Tx() (*Tx, error) {
-	if _, ok := m.driver.(*txDriver); !ok {
-		return nil, errors.New("ent: mutation is not running in a transaction")
+// AddDailyLimitUsd adds f to the "daily_limit_usd" field.
+func (m *GroupMutation) AddDailyLimitUsd(f float64) {
+	if m.adddaily_limit_usd != nil {
+		*m.adddaily_limit_usd += f
+	} else {
+		m.adddaily_limit_usd = &f
 	}
-	tx := &Tx{config: m.config}
-	tx.init()
-	return tx, nil
 }
 
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *GroupMutation) ID() (id int64, exists bool) {
-	if m.id == nil {
+// AddedDailyLimitUsd returns the value that was added to the "daily_limit_usd" field in this mutation.
+func (m *GroupMutation) AddedDailyLimitUsd() (r float64, exists bool) {
+	v := m.adddaily_limit_usd
+	if v == nil {
 		return
 	}
-	return *m.id, true
+	return *v, true
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *GroupMutation) IDs(ctx context.Context) ([]int64, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []int64{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Group.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
-	}
+// ClearDailyLimitUsd clears the value of the "daily_limit_usd" field.
+func (m *GroupMutation) ClearDailyLimitUsd() {
+	m.daily_limit_usd = nil
+	m.adddaily_limit_usd = nil
+	m.clearedFields[group.FieldDailyLimitUsd] = struct{}{}
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *GroupMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// DailyLimitUsdCleared returns if the "daily_limit_usd" field was cleared in this mutation.
+func (m *GroupMutation) DailyLimitUsdCleared() bool {
+	_, ok := m.clearedFields[group.FieldDailyLimitUsd]
+	return ok
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *GroupMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// ResetDailyLimitUsd resets all changes to the "daily_limit_usd" field.
+func (m *GroupMutation) ResetDailyLimitUsd() {
+	m.daily_limit_usd = nil
+	m.adddaily_limit_usd = nil
+	delete(m.clearedFields, group.FieldDailyLimitUsd)
+}
+
+// SetWeeklyLimitUsd sets the "weekly_limit_usd" field.
+func (m *GroupMutation) SetWeeklyLimitUsd(f float64) {
+	m.weekly_limit_usd = &f
+	m.addweekly_limit_usd = nil
+}
+
+// WeeklyLimitUsd returns the value of the "weekly_limit_usd" field in the mutation.
+func (m *GroupMutation) WeeklyLimitUsd() (r float64, exists bool) {
+	v := m.weekly_limit_usd
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Group entity.
+// OldWeeklyLimitUsd returns the old "weekly_limit_usd" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *GroupMutation) OldWeeklyLimitUsd(ctx context.Context) (v *float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldWeeklyLimitUsd is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldWeeklyLimitUsd requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldWeeklyLimitUsd: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.WeeklyLimitUsd, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *GroupMutation) ResetCreatedAt() {
-	m.created_at = nil
+// AddWeeklyLimitUsd adds f to the "weekly_limit_usd" field.
+func (m *GroupMutation) AddWeeklyLimitUsd(f float64) {
+	if m.addweekly_limit_usd != nil {
+		*m.addweekly_limit_usd += f
+	} else {
+		m.addweekly_limit_usd = &f
+	}
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *GroupMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// AddedWeeklyLimitUsd returns the value that was added to the "weekly_limit_usd" field in this mutation.
+func (m *GroupMutation) AddedWeeklyLimitUsd() (r float64, exists bool) {
+	v := m.addweekly_limit_usd
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *GroupMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
+// ClearWeeklyLimitUsd clears the value of the "weekly_limit_usd" field.
+func (m *GroupMutation) ClearWeeklyLimitUsd() {
+	m.weekly_limit_usd = nil
+	m.addweekly_limit_usd = nil
+	m.clearedFields[group.FieldWeeklyLimitUsd] = struct{}{}
+}
+
+// WeeklyLimitUsdCleared returns if the "weekly_limit_usd" field was cleared in this mutation.
+func (m *GroupMutation) WeeklyLimitUsdCleared() bool {
+	_, ok := m.clearedFields[group.FieldWeeklyLimitUsd]
+	return ok
+}
+
+// ResetWeeklyLimitUsd resets all changes to the "weekly_limit_usd" field.
+func (m *GroupMutation) ResetWeeklyLimitUsd() {
+	m.weekly_limit_usd = nil
+	m.addweekly_limit_usd = nil
+	delete(m.clearedFields, group.FieldWeeklyLimitUsd)
+}
+
+// SetMonthlyLimitUsd sets the "monthly_limit_usd" field.
+func (m *GroupMutation) SetMonthlyLimitUsd(f float64) {
+	m.monthly_limit_usd = &f
+	m.addmonthly_limit_usd = nil
+}
+
+// MonthlyLimitUsd returns the value of the "monthly_limit_usd" field in the mutation.
+func (m *GroupMutation) MonthlyLimitUsd() (r float64, exists bool) {
+	v := m.monthly_limit_usd
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the Group entity.
+// OldMonthlyLimitUsd returns the old "monthly_limit_usd" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *GroupMutation) OldMonthlyLimitUsd(ctx context.Context) (v *float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldMonthlyLimitUsd is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+		return v, errors.New("OldMonthlyLimitUsd requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldMonthlyLimitUsd: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
+	return oldValue.MonthlyLimitUsd, nil
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *GroupMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// AddMonthlyLimitUsd adds f to the "monthly_limit_usd" field.
+func (m *GroupMutation) AddMonthlyLimitUsd(f float64) {
+	if m.addmonthly_limit_usd != nil {
+		*m.addmonthly_limit_usd += f
+	} else {
+		m.addmonthly_limit_usd = &f
+	}
 }
 
-// SetDeletedAt sets the "deleted_at" field.
-func (m *GroupMutation) SetDeletedAt(t time.Time) {
-	m.deleted_at = &t
+// AddedMonthlyLimitUsd returns the value that was added to the "monthly_limit_usd" field in this mutation.
+func (m *GroupMutation) AddedMonthlyLimitUsd() (r float64, exists bool) {
+	v := m.addmonthly_limit_usd
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// DeletedAt returns the value of the "deleted_at" field in the mutation.
-func (m *GroupMutation) DeletedAt() (r time.Time, exists bool) {
-	v := m.deleted_at
+// ClearMonthlyLimitUsd clears the value of the "monthly_limit_usd" field.
+func (m *GroupMutation) ClearMonthlyLimitUsd() {
+	m.monthly_limit_usd = nil
+	m.addmonthly_limit_usd = nil
+	m.clearedFields[group.FieldMonthlyLimitUsd] = struct{}{}
+}
+
+// MonthlyLimitUsdCleared returns if the "monthly_limit_usd" field was cleared in this mutation.
+func (m *GroupMutation) MonthlyLimitUsdCleared() bool {
+	_, ok := m.clearedFields[group.FieldMonthlyLimitUsd]
+	return ok
+}
+
+// ResetMonthlyLimitUsd resets all changes to the "monthly_limit_usd" field.
+func (m *GroupMutation) ResetMonthlyLimitUsd() {
+	m.monthly_limit_usd = nil
+	m.addmonthly_limit_usd = nil
+	delete(m.clearedFields, group.FieldMonthlyLimitUsd)
+}
+
+// SetDefaultValidityDays sets the "default_validity_days" field.
+func (m *GroupMutation) SetDefaultValidityDays(i int) {
+	m.default_validity_days = &i
+	m.adddefault_validity_days = nil
+}
+
+// DefaultValidityDays returns the value of the "default_validity_days" field in the mutation.
+func (m *GroupMutation) DefaultValidityDays() (r int, exists bool) {
+	v := m.default_validity_days
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDeletedAt returns the old "deleted_at" field's value of the Group entity.
+// OldDefaultValidityDays returns the old "default_validity_days" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
+func (m *GroupMutation) OldDefaultValidityDays(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldDefaultValidityDays is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+		return v, errors.New("OldDefaultValidityDays requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldDefaultValidityDays: %w", err)
 	}
-	return oldValue.DeletedAt, nil
+	return oldValue.DefaultValidityDays, nil
 }
 
-// ClearDeletedAt clears the value of the "deleted_at" field.
-func (m *GroupMutation) ClearDeletedAt() {
-	m.deleted_at = nil
-	m.clearedFields[group.FieldDeletedAt] = struct{}{}
+// AddDefaultValidityDays adds i to the "default_validity_days" field.
+func (m *GroupMutation) AddDefaultValidityDays(i int) {
+	if m.adddefault_validity_days != nil {
+		*m.adddefault_validity_days += i
+	} else {
+		m.adddefault_validity_days = &i
+	}
 }
 
-// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
-func (m *GroupMutation) DeletedAtCleared() bool {
-	_, ok := m.clearedFields[group.FieldDeletedAt]
-	return ok
+// AddedDefaultValidityDays returns the value that was added to the "default_validity_days" field in this mutation.
+func (m *GroupMutation) AddedDefaultValidityDays() (r int, exists bool) {
+	v := m.adddefault_validity_days
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetDeletedAt resets all changes to the "deleted_at" field.
-func (m *GroupMutation) ResetDeletedAt() {
-	m.deleted_at = nil
-	delete(m.clearedFields, group.FieldDeletedAt)
+// ResetDefaultValidityDays resets all changes to the "default_validity_days" field.
+func (m *GroupMutation) ResetDefaultValidityDays() {
+	m.default_validity_days = nil
+	m.adddefault_validity_days = nil
 }
 
-// SetName sets the "name" field.
-func (m *GroupMutation) SetName(s string) {
-	m.name = &s
+// SetImagePrice1k sets the "image_price_1k" field.
+func (m *GroupMutation) SetImagePrice1k(f float64) {
+	m.image_price_1k = &f
+	m.addimage_price_1k = nil
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *GroupMutation) Name() (r string, exists bool) {
-	v := m.name
+// ImagePrice1k returns the value of the "image_price_1k" field in the mutation.
+func (m *GroupMutation) ImagePrice1k() (r float64, exists bool) {
+	v := m.image_price_1k
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the Group entity.
+// OldImagePrice1k returns the old "image_price_1k" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *GroupMutation) OldImagePrice1k(ctx context.Context) (v *float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
+		return v, errors.New("OldImagePrice1k is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
+		return v, errors.New("OldImagePrice1k requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
+		return v, fmt.Errorf("querying old value for OldImagePrice1k: %w", err)
 	}
-	return oldValue.Name, nil
+	return oldValue.ImagePrice1k, nil
 }
 
-// ResetName resets all changes to the "name" field.
-func (m *GroupMutation) ResetName() {
-	m.name = nil
+// AddImagePrice1k adds f to the "image_price_1k" field.
+func (m *GroupMutation) AddImagePrice1k(f float64) {
+	if m.addimage_price_1k != nil {
+		*m.addimage_price_1k += f
+	} else {
+		m.addimage_price_1k = &f
+	}
 }
 
-// SetDescription sets the "description" field.
-func (m *GroupMutation) SetDescription(s string) {
-	m.description = &s
+// AddedImagePrice1k returns the value that was added to the "image_price_1k" field in this mutation.
+func (m *GroupMutation) AddedImagePrice1k() (r float64, exists bool) {
+	v := m.addimage_price_1k
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// Description returns the value of the "description" field in the mutation.
-func (m *GroupMutation) Description() (r string, exists bool) {
-	v := m.description
+// ClearImagePrice1k clears the value of the "image_price_1k" field.
+func (m *GroupMutation) ClearImagePrice1k() {
+	m.image_price_1k = nil
+	m.addimage_price_1k = nil
+	m.clearedFields[group.FieldImagePrice1k] = struct{}{}
+}
+
+// ImagePrice1kCleared returns if the "image_price_1k" field was cleared in this mutation.
+func (m *GroupMutation) ImagePrice1kCleared() bool {
+	_, ok := m.clearedFields[group.FieldImagePrice1k]
+	return ok
+}
+
+// ResetImagePrice1k resets all changes to the "image_price_1k" field.
+func (m *GroupMutation) ResetImagePrice1k() {
+	m.image_price_1k = nil
+	m.addimage_price_1k = nil
+	delete(m.clearedFields, group.FieldImagePrice1k)
+}
+
+// SetImagePrice2k sets the "image_price_2k" field.
+func (m *GroupMutation) SetImagePrice2k(f float64) {
+	m.image_price_2k = &f
+	m.addimage_price_2k = nil
+}
+
+// ImagePrice2k returns the value of the "image_price_2k" field in the mutation.
+func (m *GroupMutation) ImagePrice2k() (r float64, exists bool) {
+	v := m.image_price_2k
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDescription returns the old "description" field's value of the Group entity.
+// OldImagePrice2k returns the old "image_price_2k" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldDescription(ctx context.Context) (v *string, err error) {
+func (m *GroupMutation) OldImagePrice2k(ctx context.Context) (v *float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
+		return v, errors.New("OldImagePrice2k is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDescription requires an ID field in the mutation")
+		return v, errors.New("OldImagePrice2k requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+		return v, fmt.Errorf("querying old value for OldImagePrice2k: %w", err)
 	}
-	return oldValue.Description, nil
+	return oldValue.ImagePrice2k, nil
 }
 
-// ClearDescription clears the value of the "description" field.
-func (m *GroupMutation) ClearDescription() {
-	m.description = nil
-	m.clearedFields[group.FieldDescription] = struct{}{}
+// AddImagePrice2k adds f to the "image_price_2k" field.
+func (m *GroupMutation) AddImagePrice2k(f float64) {
+	if m.addimage_price_2k != nil {
+		*m.addimage_price_2k += f
+	} else {
+		m.addimage_price_2k = &f
+	}
 }
 
-// DescriptionCleared returns if the "description" field was cleared in this mutation.
-func (m *GroupMutation) DescriptionCleared() bool {
-	_, ok := m.clearedFields[group.FieldDescription]
+// AddedImagePrice2k returns the value that was added to the "image_price_2k" field in this mutation.
+func (m *GroupMutation) AddedImagePrice2k() (r float64, exists bool) {
+	v := m.addimage_price_2k
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearImagePrice2k clears the value of the "image_price_2k" field.
+func (m *GroupMutation) ClearImagePrice2k() {
+	m.image_price_2k = nil
+	m.addimage_price_2k = nil
+	m.clearedFields[group.FieldImagePrice2k] = struct{}{}
+}
+
+// ImagePrice2kCleared returns if the "image_price_2k" field was cleared in this mutation.
+func (m *GroupMutation) ImagePrice2kCleared() bool {
+	_, ok := m.clearedFields[group.FieldImagePrice2k]
 	return ok
 }
 
-// ResetDescription resets all changes to the "description" field.
-func (m *GroupMutation) ResetDescription() {
-	m.description = nil
-	delete(m.clearedFields, group.FieldDescription)
+// ResetImagePrice2k resets all changes to the "image_price_2k" field.
+func (m *GroupMutation) ResetImagePrice2k() {
+	m.image_price_2k = nil
+	m.addimage_price_2k = nil
+	delete(m.clearedFields, group.FieldImagePrice2k)
 }
 
-// SetRateMultiplier sets the "rate_multiplier" field.
-func (m *GroupMutation) SetRateMultiplier(f float64) {
-	m.rate_multiplier = &f
-	m.addrate_multiplier = nil
+// SetImagePrice4k sets the "image_price_4k" field.
+func (m *GroupMutation) SetImagePrice4k(f float64) {
+	m.image_price_4k = &f
+	m.addimage_price_4k = nil
 }
 
-// RateMultiplier returns the value of the "rate_multiplier" field in the mutation.
-func (m *GroupMutation) RateMultiplier() (r float64, exists bool) {
-	v := m.rate_multiplier
+// ImagePrice4k returns the value of the "image_price_4k" field in the mutation.
+func (m *GroupMutation) ImagePrice4k() (r float64, exists bool) {
+	v := m.image_price_4k
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRateMultiplier returns the old "rate_multiplier" field's value of the Group entity.
+// OldImagePrice4k returns the old "image_price_4k" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldRateMultiplier(ctx context.Context) (v float64, err error) {
+func (m *GroupMutation) OldImagePrice4k(ctx context.Context) (v *float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRateMultiplier is only allowed on UpdateOne operations")
+		return v, errors.New("OldImagePrice4k is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRateMultiplier requires an ID field in the mutation")
+		return v, errors.New("OldImagePrice4k requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRateMultiplier: %w", err)
+		return v, fmt.Errorf("querying old value for OldImagePrice4k: %w", err)
 	}
-	return oldValue.RateMultiplier, nil
+	return oldValue.ImagePrice4k, nil
 }
 
-// AddRateMultiplier adds f to the "rate_multiplier" field.
-func (m *GroupMutation) AddRateMultiplier(f float64) {
-	if m.addrate_multiplier != nil {
-		*m.addrate_multiplier += f
+// AddImagePrice4k adds f to the "image_price_4k" field.
+func (m *GroupMutation) AddImagePrice4k(f float64) {
+	if m.addimage_price_4k != nil {
+		*m.addimage_price_4k += f
 	} else {
-		m.addrate_multiplier = &f
+		m.addimage_price_4k = &f
 	}
 }
 
-// AddedRateMultiplier returns the value that was added to the "rate_multiplier" field in this mutation.
-func (m *GroupMutation) AddedRateMultiplier() (r float64, exists bool) {
-	v := m.addrate_multiplier
+// AddedImagePrice4k returns the value that was added to the "image_price_4k" field in this mutation.
+func (m *GroupMutation) AddedImagePrice4k() (r float64, exists bool) {
+	v := m.addimage_price_4k
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetRateMultiplier resets all changes to the "rate_multiplier" field.
-func (m *GroupMutation) ResetRateMultiplier() {
-	m.rate_multiplier = nil
-	m.addrate_multiplier = nil
+// ClearImagePrice4k clears the value of the "image_price_4k" field.
+func (m *GroupMutation) ClearImagePrice4k() {
+	m.image_price_4k = nil
+	m.addimage_price_4k = nil
+	m.clearedFields[group.FieldImagePrice4k] = struct{}{}
 }
 
-// SetIsExclusive sets the "is_exclusive" field.
-func (m *GroupMutation) SetIsExclusive(b bool) {
-	m.is_exclusive = &b
+// ImagePrice4kCleared returns if the "image_price_4k" field was cleared in this mutation.
+func (m *GroupMutation) ImagePrice4kCleared() bool {
+	_, ok := m.clearedFields[group.FieldImagePrice4k]
+	return ok
 }
 
-// IsExclusive returns the value of the "is_exclusive" field in the mutation.
-func (m *GroupMutation) IsExclusive() (r bool, exists bool) {
-	v := m.is_exclusive
+// ResetImagePrice4k resets all changes to the "image_price_4k" field.
+func (m *GroupMutation) ResetImagePrice4k() {
+	m.image_price_4k = nil
+	m.addimage_price_4k = nil
+	delete(m.clearedFields, group.FieldImagePrice4k)
+}
+
+// SetClaudeCodeOnly sets the "claude_code_only" field.
+func (m *GroupMutation) SetClaudeCodeOnly(b bool) {
+	m.claude_code_only = &b
+}
+
+// ClaudeCodeOnly returns the value of the "claude_code_only" field in the mutation.
+func (m *GroupMutation) ClaudeCodeOnly() (r bool, exists bool) {
+	v := m.claude_code_only
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldIsExclusive returns the old "is_exclusive" field's value of the Group entity.
+// OldClaudeCodeOnly returns the old "claude_code_only" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldIsExclusive(ctx context.Context) (v bool, err error) {
+func (m *GroupMutation) OldClaudeCodeOnly(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldIsExclusive is only allowed on UpdateOne operations")
+		return v, errors.New("OldClaudeCodeOnly is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldIsExclusive requires an ID field in the mutation")
+		return v, errors.New("OldClaudeCodeOnly requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldIsExclusive: %w", err)
+		return v, fmt.Errorf("querying old value for OldClaudeCodeOnly: %w", err)
 	}
-	return oldValue.IsExclusive, nil
+	return oldValue.ClaudeCodeOnly, nil
 }
 
-// ResetIsExclusive resets all changes to the "is_exclusive" field.
-func (m *GroupMutation) ResetIsExclusive() {
-	m.is_exclusive = nil
+// ResetClaudeCodeOnly resets all changes to the "claude_code_only" field.
+func (m *GroupMutation) ResetClaudeCodeOnly() {
+	m.claude_code_only = nil
 }
 
-// SetStatus sets the "status" field.
-func (m *GroupMutation) SetStatus(s string) {
-	m.status = &s
+// SetFallbackGroupID sets the "fallback_group_id" field.
+func (m *GroupMutation) SetFallbackGroupID(i int64) {
+	m.fallback_group_id = &i
+	m.addfallback_group_id = nil
 }
 
-// Status returns the value of the "status" field in the mutation.
-func (m *GroupMutation) Status() (r string, exists bool) {
-	v := m.status
+// FallbackGroupID returns the value of the "fallback_group_id" field in the mutation.
+func (m *GroupMutation) FallbackGroupID() (r int64, exists bool) {
+	v := m.fallback_group_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldStatus returns the old "status" field's value of the Group entity.
+// OldFallbackGroupID returns the old "fallback_group_id" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldStatus(ctx context.Context) (v string, err error) {
+func (m *GroupMutation) OldFallbackGroupID(ctx context.Context) (v *int64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+		return v, errors.New("OldFallbackGroupID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldStatus requires an ID field in the mutation")
+		return v, errors.New("OldFallbackGroupID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+		return v, fmt.Errorf("querying old value for OldFallbackGroupID: %w", err)
 	}
-	return oldValue.Status, nil
+	return oldValue.FallbackGroupID, nil
 }
 
-// ResetStatus resets all changes to the "status" field.
-func (m *GroupMutation) ResetStatus() {
-	m.status = nil
+// AddFallbackGroupID adds i to the "fallback_group_id" field.
+func (m *GroupMutation) AddFallbackGroupID(i int64) {
+	if m.addfallback_group_id != nil {
+		*m.addfallback_group_id += i
+	} else {
+		m.addfallback_group_id = &i
+	}
 }
 
-// SetPlatform sets the "platform" field.
-func (m *GroupMutation) SetPlatform(s string) {
-	m.platform = &s
+// AddedFallbackGroupID returns the value that was added to the "fallback_group_id" field in this mutation.
+func (m *GroupMutation) AddedFallbackGroupID() (r int64, exists bool) {
+	v := m.addfallback_group_id
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// Platform returns the value of the "platform" field in the mutation.
-func (m *GroupMutation) Platform() (r string, exists bool) {
-	v := m.platform
+// ClearFallbackGroupID clears the value of the "fallback_group_id" field.
+func (m *GroupMutation) ClearFallbackGroupID() {
+	m.fallback_group_id = nil
+	m.addfallback_group_id = nil
+	m.clearedFields[group.FieldFallbackGroupID] = struct{}{}
+}
+
+// FallbackGroupIDCleared returns if the "fallback_group_id" field was cleared in this mutation.
+func (m *GroupMutation) FallbackGroupIDCleared() bool {
+	_, ok := m.clearedFields[group.FieldFallbackGroupID]
+	return ok
+}
+
+// ResetFallbackGroupID resets all changes to the "fallback_group_id" field.
+func (m *GroupMutation) ResetFallbackGroupID() {
+	m.fallback_group_id = nil
+	m.addfallback_group_id = nil
+	delete(m.clearedFields, group.FieldFallbackGroupID)
+}
+
+// SetModelRouting sets the "model_routing" field.
+func (m *GroupMutation) SetModelRouting(value map[string][]int64) {
+	m.model_routing = &value
+}
+
+// ModelRouting returns the value of the "model_routing" field in the mutation.
+func (m *GroupMutation) ModelRouting() (r map[string][]int64, exists bool) {
+	v := m.model_routing
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPlatform returns the old "platform" field's value of the Group entity.
+// OldModelRouting returns the old "model_routing" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldPlatform(ctx context.Context) (v string, err error) {
+func (m *GroupMutation) OldModelRouting(ctx context.Context) (v map[string][]int64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPlatform is only allowed on UpdateOne operations")
+		return v, errors.New("OldModelRouting is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPlatform requires an ID field in the mutation")
+		return v, errors.New("OldModelRouting requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPlatform: %w", err)
+		return v, fmt.Errorf("querying old value for OldModelRouting: %w", err)
 	}
-	return oldValue.Platform, nil
+	return oldValue.ModelRouting, nil
 }
 
-// ResetPlatform resets all changes to the "platform" field.
-func (m *GroupMutation) ResetPlatform() {
-	m.platform = nil
+// ClearModelRouting clears the value of the "model_routing" field.
+func (m *GroupMutation) ClearModelRouting() {
+	m.model_routing = nil
+	m.clearedFields[group.FieldModelRouting] = struct{}{}
 }
 
-// SetSubscriptionType sets the "subscription_type" field.
-func (m *GroupMutation) SetSubscriptionType(s string) {
-	m.subscription_type = &s
+// ModelRoutingCleared returns if the "model_routing" field was cleared in this mutation.
+func (m *GroupMutation) ModelRoutingCleared() bool {
+	_, ok := m.clearedFields[group.FieldModelRouting]
+	return ok
 }
 
-// SubscriptionType returns the value of the "subscription_type" field in the mutation.
-func (m *GroupMutation) SubscriptionType() (r string, exists bool) {
-	v := m.subscription_type
+// ResetModelRouting resets all changes to the "model_routing" field.
+func (m *GroupMutation) ResetModelRouting() {
+	m.model_routing = nil
+	delete(m.clearedFields, group.FieldModelRouting)
+}
+
+// SetModelRoutingEnabled sets the "model_routing_enabled" field.
+func (m *GroupMutation) SetModelRoutingEnabled(b bool) {
+	m.model_routing_enabled = &b
+}
+
+// ModelRoutingEnabled returns the value of the "model_routing_enabled" field in the mutation.
+func (m *GroupMutation) ModelRoutingEnabled() (r bool, exists bool) {
+	v := m.model_routing_enabled
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSubscriptionType returns the old "subscription_type" field's value of the Group entity.
+// OldModelRoutingEnabled returns the old "model_routing_enabled" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldSubscriptionType(ctx context.Context) (v string, err error) {
+func (m *GroupMutation) OldModelRoutingEnabled(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSubscriptionType is only allowed on UpdateOne operations")
+		return v, errors.New("OldModelRoutingEnabled is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSubscriptionType requires an ID field in the mutation")
+		return v, errors.New("OldModelRoutingEnabled requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSubscriptionType: %w", err)
+		return v, fmt.Errorf("querying old value for OldModelRoutingEnabled: %w", err)
 	}
-	return oldValue.SubscriptionType, nil
+	return oldValue.ModelRoutingEnabled, nil
 }
 
-// ResetSubscriptionType resets all changes to the "subscription_type" field.
-func (m *GroupMutation) ResetSubscriptionType() {
-	m.subscription_type = nil
+// ResetModelRoutingEnabled resets all changes to the "model_routing_enabled" field.
+func (m *GroupMutation) ResetModelRoutingEnabled() {
+	m.model_routing_enabled = nil
 }
 
-// SetDailyLimitUsd sets the "daily_limit_usd" field.
-func (m *GroupMutation) SetDailyLimitUsd(f float64) {
-	m.daily_limit_usd = &f
-	m.adddaily_limit_usd = nil
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (m *GroupMutation) SetDefaultRpmLimit(i int) {
+	m.default_rpm_limit = &i
+	m.adddefault_rpm_limit = nil
 }
 
-// DailyLimitUsd returns the value of the "daily_limit_usd" field in the mutation.
-func (m *GroupMutation) DailyLimitUsd() (r float64, exists bool) {
-	v := m.daily_limit_usd
+// DefaultRpmLimit returns the value of the "default_rpm_limit" field in the mutation.
+func (m *GroupMutation) DefaultRpmLimit() (r int, exists bool) {
+	v := m.default_rpm_limit
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDailyLimitUsd returns the old "daily_limit_usd" field's value of the Group entity.
+// OldDefaultRpmLimit returns the old "default_rpm_limit" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldDailyLimitUsd(ctx context.Context) (v *float64, err error) {
+func (m *GroupMutation) OldDefaultRpmLimit(ctx context.Context) (v *int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDailyLimitUsd is only allowed on UpdateOne operations")
+		return v, errors.New("OldDefaultRpmLimit is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDailyLimitUsd requires an ID field in the mutation")
+		return v, errors.New("OldDefaultRpmLimit requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDailyLimitUsd: %w", err)
+		return v, fmt.Errorf("querying old value for OldDefaultRpmLimit: %w", err)
 	}
-	return oldValue.DailyLimitUsd, nil
+	return oldValue.DefaultRpmLimit, nil
 }
 
-// AddDailyLimitUsd adds f to the "daily_limit_usd" field.
-func (m *GroupMutation) AddDailyLimitUsd(f float64) {
-	if m.adddaily_limit_usd != nil {
-		*m.adddaily_limit_usd += f
+// AddDefaultRpmLimit adds i to the "default_rpm_limit" field.
+func (m *GroupMutation) AddDefaultRpmLimit(i int) {
+	if m.adddefault_rpm_limit != nil {
+		*m.adddefault_rpm_limit += i
 	} else {
-		m.adddaily_limit_usd = &f
+		m.adddefault_rpm_limit = &i
 	}
 }
 
-// AddedDailyLimitUsd returns the value that was added to the "daily_limit_usd" field in this mutation.
-func (m *GroupMutation) AddedDailyLimitUsd() (r float64, exists bool) {
-	v := m.adddaily_limit_usd
+// AddedDefaultRpmLimit returns the value that was added to the "default_rpm_limit" field in this mutation.
+func (m *GroupMutation) AddedDefaultRpmLimit() (r int, exists bool) {
+	v := m.adddefault_rpm_limit
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ClearDailyLimitUsd clears the value of the "daily_limit_usd" field.
-func (m *GroupMutation) ClearDailyLimitUsd() {
-	m.daily_limit_usd = nil
-	m.adddaily_limit_usd = nil
-	m.clearedFields[group.FieldDailyLimitUsd] = struct{}{}
+// ClearDefaultRpmLimit clears the value of the "default_rpm_limit" field.
+func (m *GroupMutation) ClearDefaultRpmLimit() {
+	m.default_rpm_limit = nil
+	m.adddefault_rpm_limit = nil
+	m.clearedFields[group.FieldDefaultRpmLimit] = struct{}{}
 }
 
-// DailyLimitUsdCleared returns if the "daily_limit_usd" field was cleared in this mutation.
-func (m *GroupMutation) DailyLimitUsdCleared() bool {
-	_, ok := m.clearedFields[group.FieldDailyLimitUsd]
+// DefaultRpmLimitCleared returns if the "default_rpm_limit" field was cleared in this mutation.
+func (m *GroupMutation) DefaultRpmLimitCleared() bool {
+	_, ok := m.clearedFields[group.FieldDefaultRpmLimit]
 	return ok
 }
 
-// ResetDailyLimitUsd resets all changes to the "daily_limit_usd" field.
-func (m *GroupMutation) ResetDailyLimitUsd() {
-	m.daily_limit_usd = nil
-	m.adddaily_limit_usd = nil
-	delete(m.clearedFields, group.FieldDailyLimitUsd)
+// ResetDefaultRpmLimit resets all changes to the "default_rpm_limit" field.
+func (m *GroupMutation) ResetDefaultRpmLimit() {
+	m.default_rpm_limit = nil
+	m.adddefault_rpm_limit = nil
+	delete(m.clearedFields, group.FieldDefaultRpmLimit)
 }
 
-// SetWeeklyLimitUsd sets the "weekly_limit_usd" field.
-func (m *GroupMutation) SetWeeklyLimitUsd(f float64) {
-	m.weekly_limit_usd = &f
-	m.addweekly_limit_usd = nil
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (m *GroupMutation) SetDefaultTpmLimit(i int) {
+	m.default_tpm_limit = &i
+	m.adddefault_tpm_limit = nil
 }
 
-// WeeklyLimitUsd returns the value of the "weekly_limit_usd" field in the mutation.
-func (m *GroupMutation) WeeklyLimitUsd() (r float64, exists bool) {
-	v := m.weekly_limit_usd
+// DefaultTpmLimit returns the value of the "default_tpm_limit" field in the mutation.
+func (m *GroupMutation) DefaultTpmLimit() (r int, exists bool) {
+	v := m.default_tpm_limit
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldWeeklyLimitUsd returns the old "weekly_limit_usd" field's value of the Group entity.
+// OldDefaultTpmLimit returns the old "default_tpm_limit" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldWeeklyLimitUsd(ctx context.Context) (v *float64, err error) {
+func (m *GroupMutation) OldDefaultTpmLimit(ctx context.Context) (v *int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldWeeklyLimitUsd is only allowed on UpdateOne operations")
+		return v, errors.New("OldDefaultTpmLimit is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldWeeklyLimitUsd requires an ID field in the mutation")
+		return v, errors.New("OldDefaultTpmLimit requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldWeeklyLimitUsd: %w", err)
+		return v, fmt.Errorf("querying old value for OldDefaultTpmLimit: %w", err)
 	}
-	return oldValue.WeeklyLimitUsd, nil
+	return oldValue.DefaultTpmLimit, nil
 }
 
-// AddWeeklyLimitUsd adds f to the "weekly_limit_usd" field.
-func (m *GroupMutation) AddWeeklyLimitUsd(f float64) {
-	if m.addweekly_limit_usd != nil {
-		*m.addweekly_limit_usd += f
+// AddDefaultTpmLimit adds i to the "default_tpm_limit" field.
+func (m *GroupMutation) AddDefaultTpmLimit(i int) {
+	if m.adddefault_tpm_limit != nil {
+		*m.adddefault_tpm_limit += i
 	} else {
-		m.addweekly_limit_usd = &f
+		m.adddefault_tpm_limit = &i
 	}
 }
 
-// AddedWeeklyLimitUsd returns the value that was added to the "weekly_limit_usd" field in this mutation.
-func (m *GroupMutation) AddedWeeklyLimitUsd() (r float64, exists bool) {
-	v := m.addweekly_limit_usd
+// AddedDefaultTpmLimit returns the value that was added to the "default_tpm_limit" field in this mutation.
+func (m *GroupMutation) AddedDefaultTpmLimit() (r int, exists bool) {
+	v := m.adddefault_tpm_limit
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ClearWeeklyLimitUsd clears the value of the "weekly_limit_usd" field.
-func (m *GroupMutation) ClearWeeklyLimitUsd() {
-	m.weekly_limit_usd = nil
-	m.addweekly_limit_usd = nil
-	m.clearedFields[group.FieldWeeklyLimitUsd] = struct{}{}
+// ClearDefaultTpmLimit clears the value of the "default_tpm_limit" field.
+func (m *GroupMutation) ClearDefaultTpmLimit() {
+	m.default_tpm_limit = nil
+	m.adddefault_tpm_limit = nil
+	m.clearedFields[group.FieldDefaultTpmLimit] = struct{}{}
 }
 
-// WeeklyLimitUsdCleared returns if the "weekly_limit_usd" field was cleared in this mutation.
-func (m *GroupMutation) WeeklyLimitUsdCleared() bool {
-	_, ok := m.clearedFields[group.FieldWeeklyLimitUsd]
+// DefaultTpmLimitCleared returns if the "default_tpm_limit" field was cleared in this mutation.
+func (m *GroupMutation) DefaultTpmLimitCleared() bool {
+	_, ok := m.clearedFields[group.FieldDefaultTpmLimit]
 	return ok
 }
 
-// ResetWeeklyLimitUsd resets all changes to the "weekly_limit_usd" field.
-func (m *GroupMutation) ResetWeeklyLimitUsd() {
-	m.weekly_limit_usd = nil
-	m.addweekly_limit_usd = nil
-	delete(m.clearedFields, group.FieldWeeklyLimitUsd)
+// ResetDefaultTpmLimit resets all changes to the "default_tpm_limit" field.
+func (m *GroupMutation) ResetDefaultTpmLimit() {
+	m.default_tpm_limit = nil
+	m.adddefault_tpm_limit = nil
+	delete(m.clearedFields, group.FieldDefaultTpmLimit)
 }
 
-// SetMonthlyLimitUsd sets the "monthly_limit_usd" field.
-func (m *GroupMutation) SetMonthlyLimitUsd(f float64) {
-	m.monthly_limit_usd = &f
-	m.addmonthly_limit_usd = nil
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (m *GroupMutation) SetResponseCacheEnabled(b bool) {
+	m.response_cache_enabled = &b
 }
 
-// MonthlyLimitUsd returns the value of the "monthly_limit_usd" field in the mutation.
-func (m *GroupMutation) MonthlyLimitUsd() (r float64, exists bool) {
-	v := m.monthly_limit_usd
+// ResponseCacheEnabled returns the value of the "response_cache_enabled" field in the mutation.
+func (m *GroupMutation) ResponseCacheEnabled() (r bool, exists bool) {
+	v := m.response_cache_enabled
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldMonthlyLimitUsd returns the old "monthly_limit_usd" field's value of the Group entity.
+// OldResponseCacheEnabled returns the old "response_cache_enabled" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldMonthlyLimitUsd(ctx context.Context) (v *float64, err error) {
+func (m *GroupMutation) OldResponseCacheEnabled(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldMonthlyLimitUsd is only allowed on UpdateOne operations")
+		return v, errors.New("OldResponseCacheEnabled is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldMonthlyLimitUsd requires an ID field in the mutation")
+		return v, errors.New("OldResponseCacheEnabled requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldMonthlyLimitUsd: %w", err)
-	}
-	return oldValue.MonthlyLimitUsd, nil
-}
-
-// AddMonthlyLimitUsd adds f to the "monthly_limit_usd" field.
-func (m *GroupMutation) AddMonthlyLimitUsd(f float64) {
-	if m.addmonthly_limit_usd != nil {
-		*m.addmonthly_limit_usd += f
-	} else {
-		m.addmonthly_limit_usd = &f
-	}
-}
-
-// AddedMonthlyLimitUsd returns the value that was added to the "monthly_limit_usd" field in this mutation.
-func (m *GroupMutation) AddedMonthlyLimitUsd() (r float64, exists bool) {
-	v := m.addmonthly_limit_usd
-	if v == nil {
-		return
+		return v, fmt.Errorf("querying old value for OldResponseCacheEnabled: %w", err)
 	}
-	return *v, true
-}
-
-// ClearMonthlyLimitUsd clears the value of the "monthly_limit_usd" field.
-func (m *GroupMutation) ClearMonthlyLimitUsd() {
-	m.monthly_limit_usd = nil
-	m.addmonthly_limit_usd = nil
-	m.clearedFields[group.FieldMonthlyLimitUsd] = struct{}{}
-}
-
-// MonthlyLimitUsdCleared returns if the "monthly_limit_usd" field was cleared in this mutation.
-func (m *GroupMutation) MonthlyLimitUsdCleared() bool {
-	_, ok := m.clearedFields[group.FieldMonthlyLimitUsd]
-	return ok
+	return oldValue.ResponseCacheEnabled, nil
 }
 
-// ResetMonthlyLimitUsd resets all changes to the "monthly_limit_usd" field.
-func (m *GroupMutation) ResetMonthlyLimitUsd() {
-	m.monthly_limit_usd = nil
-	m.addmonthly_limit_usd = nil
-	delete(m.clearedFields, group.FieldMonthlyLimitUsd)
+// ResetResponseCacheEnabled resets all changes to the "response_cache_enabled" field.
+func (m *GroupMutation) ResetResponseCacheEnabled() {
+	m.response_cache_enabled = nil
 }
 
-// SetDefaultValidityDays sets the "default_validity_days" field.
-func (m *GroupMutation) SetDefaultValidityDays(i int) {
-	m.default_validity_days = &i
-	m.adddefault_validity_days = nil
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (m *GroupMutation) SetResponseCacheTTLSeconds(i int) {
+	m.response_cache_ttl_seconds = &i
+	m.addresponse_cache_ttl_seconds = nil
 }
 
-// DefaultValidityDays returns the value of the "default_validity_days" field in the mutation.
-func (m *GroupMutation) DefaultValidityDays() (r int, exists bool) {
-	v := m.default_validity_days
+// ResponseCacheTTLSeconds returns the value of the "response_cache_ttl_seconds" field in the mutation.
+func (m *GroupMutation) ResponseCacheTTLSeconds() (r int, exists bool) {
+	v := m.response_cache_ttl_seconds
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDefaultValidityDays returns the old "default_validity_days" field's value of the Group entity.
+// OldResponseCacheTTLSeconds returns the old "response_cache_ttl_seconds" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldDefaultValidityDays(ctx context.Context) (v int, err error) {
+func (m *GroupMutation) OldResponseCacheTTLSeconds(ctx context.Context) (v *int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDefaultValidityDays is only allowed on UpdateOne operations")
+		return v, errors.New("OldResponseCacheTTLSeconds is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDefaultValidityDays requires an ID field in the mutation")
+		return v, errors.New("OldResponseCacheTTLSeconds requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDefaultValidityDays: %w", err)
+		return v, fmt.Errorf("querying old value for OldResponseCacheTTLSeconds: %w", err)
 	}
-	return oldValue.DefaultValidityDays, nil
+	return oldValue.ResponseCacheTTLSeconds, nil
 }
 
-// AddDefaultValidityDays adds i to the "default_validity_days" field.
-func (m *GroupMutation) AddDefaultValidityDays(i int) {
-	if m.adddefault_validity_days != nil {
-		*m.adddefault_validity_days += i
+// AddResponseCacheTTLSeconds adds i to the "response_cache_ttl_seconds" field.
+func (m *GroupMutation) AddResponseCacheTTLSeconds(i int) {
+	if m.addresponse_cache_ttl_seconds != nil {
+		*m.addresponse_cache_ttl_seconds += i
 	} else {
-		m.adddefault_validity_days = &i
+		m.addresponse_cache_ttl_seconds = &i
 	}
 }
 
-// AddedDefaultValidityDays returns the value that was added to the "default_validity_days" field in this mutation.
-func (m *GroupMutation) AddedDefaultValidityDays() (r int, exists bool) {
-	v := m.adddefault_validity_days
+// AddedResponseCacheTTLSeconds returns the value that was added to the "response_cache_ttl_seconds" field in this mutation.
+func (m *GroupMutation) AddedResponseCacheTTLSeconds() (r int, exists bool) {
+	v := m.addresponse_cache_ttl_seconds
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetDefaultValidityDays resets all changes to the "default_validity_days" field.
-func (m *GroupMutation) ResetDefaultValidityDays() {
-	m.default_validity_days = nil
-	m.adddefault_validity_days = nil
+// ClearResponseCacheTTLSeconds clears the value of the "response_cache_ttl_seconds" field.
+func (m *GroupMutation) ClearResponseCacheTTLSeconds() {
+	m.response_cache_ttl_seconds = nil
+	m.addresponse_cache_ttl_seconds = nil
+	m.clearedFields[group.FieldResponseCacheTTLSeconds] = struct{}{}
 }
 
-// SetImagePrice1k sets the "image_price_1k" field.
-func (m *GroupMutation) SetImagePrice1k(f float64) {
-	m.image_price_1k = &f
-	m.addimage_price_1k = nil
+// ResponseCacheTTLSecondsCleared returns if the "response_cache_ttl_seconds" field was cleared in this mutation.
+func (m *GroupMutation) ResponseCacheTTLSecondsCleared() bool {
+	_, ok := m.clearedFields[group.FieldResponseCacheTTLSeconds]
+	return ok
 }
 
-// ImagePrice1k returns the value of the "image_price_1k" field in the mutation.
-func (m *GroupMutation) ImagePrice1k() (r float64, exists bool) {
-	v := m.image_price_1k
+// ResetResponseCacheTTLSeconds resets all changes to the "response_cache_ttl_seconds" field.
+func (m *GroupMutation) ResetResponseCacheTTLSeconds() {
+	m.response_cache_ttl_seconds = nil
+	m.addresponse_cache_ttl_seconds = nil
+	delete(m.clearedFields, group.FieldResponseCacheTTLSeconds)
+}
+
+// SetResponseCacheRate sets the "response_cache_rate" field.
+func (m *GroupMutation) SetResponseCacheRate(f float64) {
+	m.response_cache_rate = &f
+	m.addresponse_cache_rate = nil
+}
+
+// ResponseCacheRate returns the value of the "response_cache_rate" field in the mutation.
+func (m *GroupMutation) ResponseCacheRate() (r float64, exists bool) {
+	v := m.response_cache_rate
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldImagePrice1k returns the old "image_price_1k" field's value of the Group entity.
+// OldResponseCacheRate returns the old "response_cache_rate" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldImagePrice1k(ctx context.Context) (v *float64, err error) {
+func (m *GroupMutation) OldResponseCacheRate(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldImagePrice1k is only allowed on UpdateOne operations")
+		return v, errors.New("OldResponseCacheRate is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldImagePrice1k requires an ID field in the mutation")
+		return v, errors.New("OldResponseCacheRate requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldImagePrice1k: %w", err)
+		return v, fmt.Errorf("querying old value for OldResponseCacheRate: %w", err)
 	}
-	return oldValue.ImagePrice1k, nil
+	return oldValue.ResponseCacheRate, nil
 }
 
-// AddImagePrice1k adds f to the "image_price_1k" field.
-func (m *GroupMutation) AddImagePrice1k(f float64) {
-	if m.addimage_price_1k != nil {
-		*m.addimage_price_1k += f
+// AddResponseCacheRate adds f to the "response_cache_rate" field.
+func (m *GroupMutation) AddResponseCacheRate(f float64) {
+	if m.addresponse_cache_rate != nil {
+		*m.addresponse_cache_rate += f
 	} else {
-		m.addimage_price_1k = &f
+		m.addresponse_cache_rate = &f
 	}
 }
 
-// AddedImagePrice1k returns the value that was added to the "image_price_1k" field in this mutation.
-func (m *GroupMutation) AddedImagePrice1k() (r float64, exists bool) {
-	v := m.addimage_price_1k
+// AddedResponseCacheRate returns the value that was added to the "response_cache_rate" field in this mutation.
+func (m *GroupMutation) AddedResponseCacheRate() (r float64, exists bool) {
+	v := m.addresponse_cache_rate
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ClearImagePrice1k clears the value of the "image_price_1k" field.
-func (m *GroupMutation) ClearImagePrice1k() {
-	m.image_price_1k = nil
-	m.addimage_price_1k = nil
-	m.clearedFields[group.FieldImagePrice1k] = struct{}{}
-}
-
-// ImagePrice1kCleared returns if the "image_price_1k" field was cleared in this mutation.
-func (m *GroupMutation) ImagePrice1kCleared() bool {
-	_, ok := m.clearedFields[group.FieldImagePrice1k]
-	return ok
-}
-
-// ResetImagePrice1k resets all changes to the "image_price_1k" field.
-func (m *GroupMutation) ResetImagePrice1k() {
-	m.image_price_1k = nil
-	m.addimage_price_1k = nil
-	delete(m.clearedFields, group.FieldImagePrice1k)
+// ResetResponseCacheRate resets all changes to the "response_cache_rate" field.
+func (m *GroupMutation) ResetResponseCacheRate() {
+	m.response_cache_rate = nil
+	m.addresponse_cache_rate = nil
 }
 
-// SetImagePrice2k sets the "image_price_2k" field.
-func (m *GroupMutation) SetImagePrice2k(f float64) {
-	m.image_price_2k = &f
-	m.addimage_price_2k = nil
+// SetModelFallbacks sets the "model_fallbacks" field.
+func (m *GroupMutation) SetModelFallbacks(jm json.RawMessage) {
+	m.model_fallbacks = &jm
+	m.appendmodel_fallbacks = nil
 }
 
-// ImagePrice2k returns the value of the "image_price_2k" field in the mutation.
-func (m *GroupMutation) ImagePrice2k() (r float64, exists bool) {
-	v := m.image_price_2k
+// ModelFallbacks returns the value of the "model_fallbacks" field in the mutation.
+func (m *GroupMutation) ModelFallbacks() (r json.RawMessage, exists bool) {
+	v := m.model_fallbacks
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldImagePrice2k returns the old "image_price_2k" field's value of the Group entity.
+// OldModelFallbacks returns the old "model_fallbacks" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldImagePrice2k(ctx context.Context) (v *float64, err error) {
+func (m *GroupMutation) OldModelFallbacks(ctx context.Context) (v json.RawMessage, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldImagePrice2k is only allowed on UpdateOne operations")
+		return v, errors.New("OldModelFallbacks is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldImagePrice2k requires an ID field in the mutation")
+		return v, errors.New("OldModelFallbacks requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldImagePrice2k: %w", err)
+		return v, fmt.Errorf("querying old value for OldModelFallbacks: %w", err)
 	}
-	return oldValue.ImagePrice2k, nil
+	return oldValue.ModelFallbacks, nil
 }
 
-// AddImagePrice2k adds f to the "image_price_2k" field.
-func (m *GroupMutation) AddImagePrice2k(f float64) {
-	if m.addimage_price_2k != nil {
-		*m.addimage_price_2k += f
-	} else {
-		m.addimage_price_2k = &f
-	}
+// AppendModelFallbacks adds jm to the "model_fallbacks" field.
+func (m *GroupMutation) AppendModelFallbacks(jm json.RawMessage) {
+	m.appendmodel_fallbacks = append(m.appendmodel_fallbacks, jm...)
 }
 
-// AddedImagePrice2k returns the value that was added to the "image_price_2k" field in this mutation.
-func (m *GroupMutation) AddedImagePrice2k() (r float64, exists bool) {
-	v := m.addimage_price_2k
-	if v == nil {
-		return
+// AppendedModelFallbacks returns the list of values that were appended to the "model_fallbacks" field in this mutation.
+func (m *GroupMutation) AppendedModelFallbacks() (json.RawMessage, bool) {
+	if len(m.appendmodel_fallbacks) == 0 {
+		return nil, false
 	}
-	return *v, true
+	return m.appendmodel_fallbacks, true
 }
 
-// ClearImagePrice2k clears the value of the "image_price_2k" field.
-func (m *GroupMutation) ClearImagePrice2k() {
-	m.image_price_2k = nil
-	m.addimage_price_2k = nil
-	m.clearedFields[group.FieldImagePrice2k] = struct{}{}
+// ClearModelFallbacks clears the value of the "model_fallbacks" field.
+func (m *GroupMutation) ClearModelFallbacks() {
+	m.model_fallbacks = nil
+	m.appendmodel_fallbacks = nil
+	m.clearedFields[group.FieldModelFallbacks] = struct{}{}
 }
 
-// ImagePrice2kCleared returns if the "image_price_2k" field was cleared in this mutation.
-func (m *GroupMutation) ImagePrice2kCleared() bool {
-	_, ok := m.clearedFields[group.FieldImagePrice2k]
+// ModelFallbacksCleared returns if the "model_fallbacks" field was cleared in this mutation.
+func (m *GroupMutation) ModelFallbacksCleared() bool {
+	_, ok := m.clearedFields[group.FieldModelFallbacks]
 	return ok
 }
-
-// ResetImagePrice2k resets all changes to the "image_price_2k" field.
-func (m *GroupMutation) ResetImagePrice2k() {
-	m.image_price_2k = nil
-	m.addimage_price_2k = nil
-	delete(m.clearedFields, group.FieldImagePrice2k)
-}
-
-// SetImagePrice4k sets the "image_price_4k" field.
-func (m *GroupMutation) SetImagePrice4k(f float64) {
-	m.image_price_4k = &f
-	m.addimage_price_4k = nil
+
+// ResetModelFallbacks resets all changes to the "model_fallbacks" field.
+func (m *GroupMutation) ResetModelFallbacks() {
+	m.model_fallbacks = nil
+	m.appendmodel_fallbacks = nil
+	delete(m.clearedFields, group.FieldModelFallbacks)
 }
 
-// ImagePrice4k returns the value of the "image_price_4k" field in the mutation.
-func (m *GroupMutation) ImagePrice4k() (r float64, exists bool) {
-	v := m.image_price_4k
+// SetGuardrails sets the "guardrails" field.
+func (m *GroupMutation) SetGuardrails(jm json.RawMessage) {
+	m.guardrails = &jm
+	m.appendguardrails = nil
+}
+
+// Guardrails returns the value of the "guardrails" field in the mutation.
+func (m *GroupMutation) Guardrails() (r json.RawMessage, exists bool) {
+	v := m.guardrails
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldImagePrice4k returns the old "image_price_4k" field's value of the Group entity.
+// OldGuardrails returns the old "guardrails" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldImagePrice4k(ctx context.Context) (v *float64, err error) {
+func (m *GroupMutation) OldGuardrails(ctx context.Context) (v json.RawMessage, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldImagePrice4k is only allowed on UpdateOne operations")
+		return v, errors.New("OldGuardrails is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldImagePrice4k requires an ID field in the mutation")
+		return v, errors.New("OldGuardrails requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldImagePrice4k: %w", err)
+		return v, fmt.Errorf("querying old value for OldGuardrails: %w", err)
 	}
-	return oldValue.ImagePrice4k, nil
+	return oldValue.Guardrails, nil
 }
 
-// AddImagePrice4k adds f to the "image_price_4k" field.
-func (m *GroupMutation) AddImagePrice4k(f float64) {
-	if m.addimage_price_4k != nil {
-		*m.addimage_price_4k += f
-	} else {
-		m.addimage_price_4k = &f
-	}
+// AppendGuardrails adds jm to the "guardrails" field.
+func (m *GroupMutation) AppendGuardrails(jm json.RawMessage) {
+	m.appendguardrails = append(m.appendguardrails, jm...)
 }
 
-// AddedImagePrice4k returns the value that was added to the "image_price_4k" field in this mutation.
-func (m *GroupMutation) AddedImagePrice4k() (r float64, exists bool) {
-	v := m.addimage_price_4k
-	if v == nil {
-		return
+// AppendedGuardrails returns the list of values that were appended to the "guardrails" field in this mutation.
+func (m *GroupMutation) AppendedGuardrails() (json.RawMessage, bool) {
+	if len(m.appendguardrails) == 0 {
+		return nil, false
 	}
-	return *v, true
+	return m.appendguardrails, true
 }
 
-// ClearImagePrice4k clears the value of the "image_price_4k" field.
-func (m *GroupMutation) ClearImagePrice4k() {
-	m.image_price_4k = nil
-	m.addimage_price_4k = nil
-	m.clearedFields[group.FieldImagePrice4k] = struct{}{}
+// ClearGuardrails clears the value of the "guardrails" field.
+func (m *GroupMutation) ClearGuardrails() {
+	m.guardrails = nil
+	m.appendguardrails = nil
+	m.clearedFields[group.FieldGuardrails] = struct{}{}
 }
 
-// ImagePrice4kCleared returns if the "image_price_4k" field was cleared in this mutation.
-func (m *GroupMutation) ImagePrice4kCleared() bool {
-	_, ok := m.clearedFields[group.FieldImagePrice4k]
+// GuardrailsCleared returns if the "guardrails" field was cleared in this mutation.
+func (m *GroupMutation) GuardrailsCleared() bool {
+	_, ok := m.clearedFields[group.FieldGuardrails]
 	return ok
 }
 
-// ResetImagePrice4k resets all changes to the "image_price_4k" field.
-func (m *GroupMutation) ResetImagePrice4k() {
-	m.image_price_4k = nil
-	m.addimage_price_4k = nil
-	delete(m.clearedFields, group.FieldImagePrice4k)
+// ResetGuardrails resets all changes to the "guardrails" field.
+func (m *GroupMutation) ResetGuardrails() {
+	m.guardrails = nil
+	m.appendguardrails = nil
+	delete(m.clearedFields, group.FieldGuardrails)
 }
 
-// SetClaudeCodeOnly sets the "claude_code_only" field.
-func (m *GroupMutation) SetClaudeCodeOnly(b bool) {
-	m.claude_code_only = &b
+// SetSchedulingStrategy sets the "scheduling_strategy" field.
+func (m *GroupMutation) SetSchedulingStrategy(s string) {
+	m.scheduling_strategy = &s
 }
 
-// ClaudeCodeOnly returns the value of the "claude_code_only" field in the mutation.
-func (m *GroupMutation) ClaudeCodeOnly() (r bool, exists bool) {
-	v := m.claude_code_only
+// SchedulingStrategy returns the value of the "scheduling_strategy" field in the mutation.
+func (m *GroupMutation) SchedulingStrategy() (r string, exists bool) {
+	v := m.scheduling_strategy
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldClaudeCodeOnly returns the old "claude_code_only" field's value of the Group entity.
+// OldSchedulingStrategy returns the old "scheduling_strategy" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldClaudeCodeOnly(ctx context.Context) (v bool, err error) {
+func (m *GroupMutation) OldSchedulingStrategy(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldClaudeCodeOnly is only allowed on UpdateOne operations")
+		return v, errors.New("OldSchedulingStrategy is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldClaudeCodeOnly requires an ID field in the mutation")
+		return v, errors.New("OldSchedulingStrategy requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldClaudeCodeOnly: %w", err)
+		return v, fmt.Errorf("querying old value for OldSchedulingStrategy: %w", err)
 	}
-	return oldValue.ClaudeCodeOnly, nil
+	return oldValue.SchedulingStrategy, nil
 }
 
-// ResetClaudeCodeOnly resets all changes to the "claude_code_only" field.
-func (m *GroupMutation) ResetClaudeCodeOnly() {
-	m.claude_code_only = nil
+// ResetSchedulingStrategy resets all changes to the "scheduling_strategy" field.
+func (m *GroupMutation) ResetSchedulingStrategy() {
+	m.scheduling_strategy = nil
 }
 
-// SetFallbackGroupID sets the "fallback_group_id" field.
-func (m *GroupMutation) SetFallbackGroupID(i int64) {
-	m.fallback_group_id = &i
-	m.addfallback_group_id = nil
+// SetSchedulingWeights sets the "scheduling_weights" field.
+func (m *GroupMutation) SetSchedulingWeights(value map[int64]int) {
+	m.scheduling_weights = &value
 }
 
-// FallbackGroupID returns the value of the "fallback_group_id" field in the mutation.
-func (m *GroupMutation) FallbackGroupID() (r int64, exists bool) {
-	v := m.fallback_group_id
+// SchedulingWeights returns the value of the "scheduling_weights" field in the mutation.
+func (m *GroupMutation) SchedulingWeights() (r map[int64]int, exists bool) {
+	v := m.scheduling_weights
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldFallbackGroupID returns the old "fallback_group_id" field's value of the Group entity.
+// OldSchedulingWeights returns the old "scheduling_weights" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldFallbackGroupID(ctx context.Context) (v *int64, err error) {
+func (m *GroupMutation) OldSchedulingWeights(ctx context.Context) (v map[int64]int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFallbackGroupID is only allowed on UpdateOne operations")
+		return v, errors.New("OldSchedulingWeights is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFallbackGroupID requires an ID field in the mutation")
+		return v, errors.New("OldSchedulingWeights requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFallbackGroupID: %w", err)
-	}
-	return oldValue.FallbackGroupID, nil
-}
-
-// AddFallbackGroupID adds i to the "fallback_group_id" field.
-func (m *GroupMutation) AddFallbackGroupID(i int64) {
-	if m.addfallback_group_id != nil {
-		*m.addfallback_group_id += i
-	} else {
-		m.addfallback_group_id = &i
-	}
-}
-
-// AddedFallbackGroupID returns the value that was added to the "fallback_group_id" field in this mutation.
-func (m *GroupMutation) AddedFallbackGroupID() (r int64, exists bool) {
-	v := m.addfallback_group_id
-	if v == nil {
-		return
+		return v, fmt.Errorf("querying old value for OldSchedulingWeights: %w", err)
 	}
-	return *v, true
+	return oldValue.SchedulingWeights, nil
 }
 
-// ClearFallbackGroupID clears the value of the "fallback_group_id" field.
-func (m *GroupMutation) ClearFallbackGroupID() {
-	m.fallback_group_id = nil
-	m.addfallback_group_id = nil
-	m.clearedFields[group.FieldFallbackGroupID] = struct{}{}
+// ClearSchedulingWeights clears the value of the "scheduling_weights" field.
+func (m *GroupMutation) ClearSchedulingWeights() {
+	m.scheduling_weights = nil
+	m.clearedFields[group.FieldSchedulingWeights] = struct{}{}
 }
 
-// FallbackGroupIDCleared returns if the "fallback_group_id" field was cleared in this mutation.
-func (m *GroupMutation) FallbackGroupIDCleared() bool {
-	_, ok := m.clearedFields[group.FieldFallbackGroupID]
+// SchedulingWeightsCleared returns if the "scheduling_weights" field was cleared in this mutation.
+func (m *GroupMutation) SchedulingWeightsCleared() bool {
+	_, ok := m.clearedFields[group.FieldSchedulingWeights]
 	return ok
 }
 
-// ResetFallbackGroupID resets all changes to the "fallback_group_id" field.
-func (m *GroupMutation) ResetFallbackGroupID() {
-	m.fallback_group_id = nil
-	m.addfallback_group_id = nil
-	delete(m.clearedFields, group.FieldFallbackGroupID)
+// ResetSchedulingWeights resets all changes to the "scheduling_weights" field.
+func (m *GroupMutation) ResetSchedulingWeights() {
+	m.scheduling_weights = nil
+	delete(m.clearedFields, group.FieldSchedulingWeights)
 }
 
-// SetModelRouting sets the "model_routing" field.
-func (m *GroupMutation) SetModelRouting(value map[string][]int64) {
-	m.model_routing = &value
+// SetHedgeDelayMs sets the "hedge_delay_ms" field.
+func (m *GroupMutation) SetHedgeDelayMs(i int) {
+	m.hedge_delay_ms = &i
+	m.addhedge_delay_ms = nil
 }
 
-// ModelRouting returns the value of the "model_routing" field in the mutation.
-func (m *GroupMutation) ModelRouting() (r map[string][]int64, exists bool) {
-	v := m.model_routing
+// HedgeDelayMs returns the value of the "hedge_delay_ms" field in the mutation.
+func (m *GroupMutation) HedgeDelayMs() (r int, exists bool) {
+	v := m.hedge_delay_ms
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldModelRouting returns the old "model_routing" field's value of the Group entity.
+// OldHedgeDelayMs returns the old "hedge_delay_ms" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldModelRouting(ctx context.Context) (v map[string][]int64, err error) {
+func (m *GroupMutation) OldHedgeDelayMs(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldModelRouting is only allowed on UpdateOne operations")
+		return v, errors.New("OldHedgeDelayMs is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldModelRouting requires an ID field in the mutation")
+		return v, errors.New("OldHedgeDelayMs requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldModelRouting: %w", err)
+		return v, fmt.Errorf("querying old value for OldHedgeDelayMs: %w", err)
 	}
-	return oldValue.ModelRouting, nil
+	return oldValue.HedgeDelayMs, nil
 }
 
-// ClearModelRouting clears the value of the "model_routing" field.
-func (m *GroupMutation) ClearModelRouting() {
-	m.model_routing = nil
-	m.clearedFields[group.FieldModelRouting] = struct{}{}
+// AddHedgeDelayMs adds i to the "hedge_delay_ms" field.
+func (m *GroupMutation) AddHedgeDelayMs(i int) {
+	if m.addhedge_delay_ms != nil {
+		*m.addhedge_delay_ms += i
+	} else {
+		m.addhedge_delay_ms = &i
+	}
 }
 
-// ModelRoutingCleared returns if the "model_routing" field was cleared in this mutation.
-func (m *GroupMutation) ModelRoutingCleared() bool {
-	_, ok := m.clearedFields[group.FieldModelRouting]
-	return ok
+// AddedHedgeDelayMs returns the value that was added to the "hedge_delay_ms" field in this mutation.
+func (m *GroupMutation) AddedHedgeDelayMs() (r int, exists bool) {
+	v := m.addhedge_delay_ms
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetModelRouting resets all changes to the "model_routing" field.
-func (m *GroupMutation) ResetModelRouting() {
-	m.model_routing = nil
-	delete(m.clearedFields, group.FieldModelRouting)
+// ResetHedgeDelayMs resets all changes to the "hedge_delay_ms" field.
+func (m *GroupMutation) ResetHedgeDelayMs() {
+	m.hedge_delay_ms = nil
+	m.addhedge_delay_ms = nil
 }
 
-// SetModelRoutingEnabled sets the "model_routing_enabled" field.
-func (m *GroupMutation) SetModelRoutingEnabled(b bool) {
-	m.model_routing_enabled = &b
+// SetQueueWeight sets the "queue_weight" field.
+func (m *GroupMutation) SetQueueWeight(i int) {
+	m.queue_weight = &i
+	m.addqueue_weight = nil
 }
 
-// ModelRoutingEnabled returns the value of the "model_routing_enabled" field in the mutation.
-func (m *GroupMutation) ModelRoutingEnabled() (r bool, exists bool) {
-	v := m.model_routing_enabled
+// QueueWeight returns the value of the "queue_weight" field in the mutation.
+func (m *GroupMutation) QueueWeight() (r int, exists bool) {
+	v := m.queue_weight
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldModelRoutingEnabled returns the old "model_routing_enabled" field's value of the Group entity.
+// OldQueueWeight returns the old "queue_weight" field's value of the Group entity.
 // If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldModelRoutingEnabled(ctx context.Context) (v bool, err error) {
+func (m *GroupMutation) OldQueueWeight(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldModelRoutingEnabled is only allowed on UpdateOne operations")
+		return v, errors.New("OldQueueWeight is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldModelRoutingEnabled requires an ID field in the mutation")
+		return v, errors.New("OldQueueWeight requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldModelRoutingEnabled: %w", err)
+		return v, fmt.Errorf("querying old value for OldQueueWeight: %w", err)
 	}
-	return oldValue.ModelRoutingEnabled, nil
+	return oldValue.QueueWeight, nil
 }
 
-// ResetModelRoutingEnabled resets all changes to the "model_routing_enabled" field.
-func (m *GroupMutation) ResetModelRoutingEnabled() {
-	m.model_routing_enabled = nil
+// AddQueueWeight adds i to the "queue_weight" field.
+func (m *GroupMutation) AddQueueWeight(i int) {
+	if m.addqueue_weight != nil {
+		*m.addqueue_weight += i
+	} else {
+		m.addqueue_weight = &i
+	}
+}
+
+// AddedQueueWeight returns the value that was added to the "queue_weight" field in this mutation.
+func (m *GroupMutation) AddedQueueWeight() (r int, exists bool) {
+	v := m.addqueue_weight
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetQueueWeight resets all changes to the "queue_weight" field.
+func (m *GroupMutation) ResetQueueWeight() {
+	m.queue_weight = nil
+	m.addqueue_weight = nil
 }
 
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by ids.
@@ -5422,7 +6855,7 @@ func (m *GroupMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *GroupMutation) Fields() []string {
-	fields := make([]string, 0, 21)
+	fields := make([]string, 0, 32)
 	if m.created_at != nil {
 		fields = append(fields, group.FieldCreatedAt)
 	}
@@ -5486,6 +6919,39 @@ func (m *GroupMutation) Fields() []string {
 	if m.model_routing_enabled != nil {
 		fields = append(fields, group.FieldModelRoutingEnabled)
 	}
+	if m.default_rpm_limit != nil {
+		fields = append(fields, group.FieldDefaultRpmLimit)
+	}
+	if m.default_tpm_limit != nil {
+		fields = append(fields, group.FieldDefaultTpmLimit)
+	}
+	if m.response_cache_enabled != nil {
+		fields = append(fields, group.FieldResponseCacheEnabled)
+	}
+	if m.response_cache_ttl_seconds != nil {
+		fields = append(fields, group.FieldResponseCacheTTLSeconds)
+	}
+	if m.response_cache_rate != nil {
+		fields = append(fields, group.FieldResponseCacheRate)
+	}
+	if m.model_fallbacks != nil {
+		fields = append(fields, group.FieldModelFallbacks)
+	}
+	if m.guardrails != nil {
+		fields = append(fields, group.FieldGuardrails)
+	}
+	if m.scheduling_strategy != nil {
+		fields = append(fields, group.FieldSchedulingStrategy)
+	}
+	if m.scheduling_weights != nil {
+		fields = append(fields, group.FieldSchedulingWeights)
+	}
+	if m.hedge_delay_ms != nil {
+		fields = append(fields, group.FieldHedgeDelayMs)
+	}
+	if m.queue_weight != nil {
+		fields = append(fields, group.FieldQueueWeight)
+	}
 	return fields
 }
 
@@ -5536,6 +7002,28 @@ func (m *GroupMutation) Field(name string) (ent.Value, bool) {
 		return m.ModelRouting()
 	case group.FieldModelRoutingEnabled:
 		return m.ModelRoutingEnabled()
+	case group.FieldDefaultRpmLimit:
+		return m.DefaultRpmLimit()
+	case group.FieldDefaultTpmLimit:
+		return m.DefaultTpmLimit()
+	case group.FieldResponseCacheEnabled:
+		return m.ResponseCacheEnabled()
+	case group.FieldResponseCacheTTLSeconds:
+		return m.ResponseCacheTTLSeconds()
+	case group.FieldResponseCacheRate:
+		return m.ResponseCacheRate()
+	case group.FieldModelFallbacks:
+		return m.ModelFallbacks()
+	case group.FieldGuardrails:
+		return m.Guardrails()
+	case group.FieldSchedulingStrategy:
+		return m.SchedulingStrategy()
+	case group.FieldSchedulingWeights:
+		return m.SchedulingWeights()
+	case group.FieldHedgeDelayMs:
+		return m.HedgeDelayMs()
+	case group.FieldQueueWeight:
+		return m.QueueWeight()
 	}
 	return nil, false
 }
@@ -5587,6 +7075,28 @@ func (m *GroupMutation) OldField(ctx context.Context, name string) (ent.Value, e
 		return m.OldModelRouting(ctx)
 	case group.FieldModelRoutingEnabled:
 		return m.OldModelRoutingEnabled(ctx)
+	case group.FieldDefaultRpmLimit:
+		return m.OldDefaultRpmLimit(ctx)
+	case group.FieldDefaultTpmLimit:
+		return m.OldDefaultTpmLimit(ctx)
+	case group.FieldResponseCacheEnabled:
+		return m.OldResponseCacheEnabled(ctx)
+	case group.FieldResponseCacheTTLSeconds:
+		return m.OldResponseCacheTTLSeconds(ctx)
+	case group.FieldResponseCacheRate:
+		return m.OldResponseCacheRate(ctx)
+	case group.FieldModelFallbacks:
+		return m.OldModelFallbacks(ctx)
+	case group.FieldGuardrails:
+		return m.OldGuardrails(ctx)
+	case group.FieldSchedulingStrategy:
+		return m.OldSchedulingStrategy(ctx)
+	case group.FieldSchedulingWeights:
+		return m.OldSchedulingWeights(ctx)
+	case group.FieldHedgeDelayMs:
+		return m.OldHedgeDelayMs(ctx)
+	case group.FieldQueueWeight:
+		return m.OldQueueWeight(ctx)
 	}
 	return nil, fmt.Errorf("unknown Group field %s", name)
 }
@@ -5743,6 +7253,83 @@ func (m *GroupMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetModelRoutingEnabled(v)
 		return nil
+	case group.FieldDefaultRpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDefaultRpmLimit(v)
+		return nil
+	case group.FieldDefaultTpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDefaultTpmLimit(v)
+		return nil
+	case group.FieldResponseCacheEnabled:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResponseCacheEnabled(v)
+		return nil
+	case group.FieldResponseCacheTTLSeconds:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResponseCacheTTLSeconds(v)
+		return nil
+	case group.FieldResponseCacheRate:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResponseCacheRate(v)
+		return nil
+	case group.FieldModelFallbacks:
+		v, ok := value.(json.RawMessage)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetModelFallbacks(v)
+		return nil
+	case group.FieldGuardrails:
+		v, ok := value.(json.RawMessage)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetGuardrails(v)
+		return nil
+	case group.FieldSchedulingStrategy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSchedulingStrategy(v)
+		return nil
+	case group.FieldSchedulingWeights:
+		v, ok := value.(map[int64]int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSchedulingWeights(v)
+		return nil
+	case group.FieldHedgeDelayMs:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHedgeDelayMs(v)
+		return nil
+	case group.FieldQueueWeight:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQueueWeight(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Group field %s", name)
 }
@@ -5778,6 +7365,24 @@ func (m *GroupMutation) AddedFields() []string {
 	if m.addfallback_group_id != nil {
 		fields = append(fields, group.FieldFallbackGroupID)
 	}
+	if m.adddefault_rpm_limit != nil {
+		fields = append(fields, group.FieldDefaultRpmLimit)
+	}
+	if m.adddefault_tpm_limit != nil {
+		fields = append(fields, group.FieldDefaultTpmLimit)
+	}
+	if m.addresponse_cache_ttl_seconds != nil {
+		fields = append(fields, group.FieldResponseCacheTTLSeconds)
+	}
+	if m.addresponse_cache_rate != nil {
+		fields = append(fields, group.FieldResponseCacheRate)
+	}
+	if m.addhedge_delay_ms != nil {
+		fields = append(fields, group.FieldHedgeDelayMs)
+	}
+	if m.addqueue_weight != nil {
+		fields = append(fields, group.FieldQueueWeight)
+	}
 	return fields
 }
 
@@ -5804,6 +7409,18 @@ func (m *GroupMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedImagePrice4k()
 	case group.FieldFallbackGroupID:
 		return m.AddedFallbackGroupID()
+	case group.FieldDefaultRpmLimit:
+		return m.AddedDefaultRpmLimit()
+	case group.FieldDefaultTpmLimit:
+		return m.AddedDefaultTpmLimit()
+	case group.FieldResponseCacheTTLSeconds:
+		return m.AddedResponseCacheTTLSeconds()
+	case group.FieldResponseCacheRate:
+		return m.AddedResponseCacheRate()
+	case group.FieldHedgeDelayMs:
+		return m.AddedHedgeDelayMs()
+	case group.FieldQueueWeight:
+		return m.AddedQueueWeight()
 	}
 	return nil, false
 }
@@ -5867,14 +7484,56 @@ func (m *GroupMutation) AddField(name string, value ent.Value) error {
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddImagePrice4k(v)
+		m.AddImagePrice4k(v)
+		return nil
+	case group.FieldFallbackGroupID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddFallbackGroupID(v)
+		return nil
+	case group.FieldDefaultRpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDefaultRpmLimit(v)
+		return nil
+	case group.FieldDefaultTpmLimit:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDefaultTpmLimit(v)
+		return nil
+	case group.FieldResponseCacheTTLSeconds:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddResponseCacheTTLSeconds(v)
+		return nil
+	case group.FieldResponseCacheRate:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddResponseCacheRate(v)
+		return nil
+	case group.FieldHedgeDelayMs:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddHedgeDelayMs(v)
 		return nil
-	case group.FieldFallbackGroupID:
-		v, ok := value.(int64)
+	case group.FieldQueueWeight:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddFallbackGroupID(v)
+		m.AddQueueWeight(v)
 		return nil
 	}
 	return fmt.Errorf("unknown Group numeric field %s", name)
@@ -5914,6 +7573,24 @@ func (m *GroupMutation) ClearedFields() []string {
 	if m.FieldCleared(group.FieldModelRouting) {
 		fields = append(fields, group.FieldModelRouting)
 	}
+	if m.FieldCleared(group.FieldDefaultRpmLimit) {
+		fields = append(fields, group.FieldDefaultRpmLimit)
+	}
+	if m.FieldCleared(group.FieldDefaultTpmLimit) {
+		fields = append(fields, group.FieldDefaultTpmLimit)
+	}
+	if m.FieldCleared(group.FieldResponseCacheTTLSeconds) {
+		fields = append(fields, group.FieldResponseCacheTTLSeconds)
+	}
+	if m.FieldCleared(group.FieldModelFallbacks) {
+		fields = append(fields, group.FieldModelFallbacks)
+	}
+	if m.FieldCleared(group.FieldGuardrails) {
+		fields = append(fields, group.FieldGuardrails)
+	}
+	if m.FieldCleared(group.FieldSchedulingWeights) {
+		fields = append(fields, group.FieldSchedulingWeights)
+	}
 	return fields
 }
 
@@ -5958,6 +7635,24 @@ func (m *GroupMutation) ClearField(name string) error {
 	case group.FieldModelRouting:
 		m.ClearModelRouting()
 		return nil
+	case group.FieldDefaultRpmLimit:
+		m.ClearDefaultRpmLimit()
+		return nil
+	case group.FieldDefaultTpmLimit:
+		m.ClearDefaultTpmLimit()
+		return nil
+	case group.FieldResponseCacheTTLSeconds:
+		m.ClearResponseCacheTTLSeconds()
+		return nil
+	case group.FieldModelFallbacks:
+		m.ClearModelFallbacks()
+		return nil
+	case group.FieldGuardrails:
+		m.ClearGuardrails()
+		return nil
+	case group.FieldSchedulingWeights:
+		m.ClearSchedulingWeights()
+		return nil
 	}
 	return fmt.Errorf("unknown Group nullable field %s", name)
 }
@@ -6029,6 +7724,39 @@ func (m *GroupMutation) ResetField(name string) error {
 	case group.FieldModelRoutingEnabled:
 		m.ResetModelRoutingEnabled()
 		return nil
+	case group.FieldDefaultRpmLimit:
+		m.ResetDefaultRpmLimit()
+		return nil
+	case group.FieldDefaultTpmLimit:
+		m.ResetDefaultTpmLimit()
+		return nil
+	case group.FieldResponseCacheEnabled:
+		m.ResetResponseCacheEnabled()
+		return nil
+	case group.FieldResponseCacheTTLSeconds:
+		m.ResetResponseCacheTTLSeconds()
+		return nil
+	case group.FieldResponseCacheRate:
+		m.ResetResponseCacheRate()
+		return nil
+	case group.FieldModelFallbacks:
+		m.ResetModelFallbacks()
+		return nil
+	case group.FieldGuardrails:
+		m.ResetGuardrails()
+		return nil
+	case group.FieldSchedulingStrategy:
+		m.ResetSchedulingStrategy()
+		return nil
+	case group.FieldSchedulingWeights:
+		m.ResetSchedulingWeights()
+		return nil
+	case group.FieldHedgeDelayMs:
+		m.ResetHedgeDelayMs()
+		return nil
+	case group.FieldQueueWeight:
+		m.ResetQueueWeight()
+		return nil
 	}
 	return fmt.Errorf("unknown Group field %s", name)
 }
@@ -11504,6 +13232,17 @@ type UsageLogMutation struct {
 	image_count                 *int
 	addimage_count              *int
 	image_size                  *string
+	cache_served                *bool
+	cache_saved_cost            *float64
+	addcache_saved_cost         *float64
+	hedged                      *bool
+	hedge_backup_won            *bool
+	client_disconnected         *bool
+	pricing_tier                *string
+	service_tier                *string
+	cost_detail                 *map[string]interface{}
+	organization_id             *int64
+	addorganization_id          *int64
 	created_at                  *time.Time
 	clearedFields               map[string]struct{}
 	user                        *int64
@@ -11521,1613 +13260,2004 @@ type UsageLogMutation struct {
 	predicates                  []predicate.UsageLog
 }
 
-var _ ent.Mutation = (*UsageLogMutation)(nil)
+var _ ent.Mutation = (*UsageLogMutation)(nil)
+
+// usagelogOption allows management of the mutation configuration using functional options.
+type usagelogOption func(*UsageLogMutation)
+
+// newUsageLogMutation creates new mutation for the UsageLog entity.
+func newUsageLogMutation(c config, op Op, opts ...usagelogOption) *UsageLogMutation {
+	m := &UsageLogMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeUsageLog,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withUsageLogID sets the ID field of the mutation.
+func withUsageLogID(id int64) usagelogOption {
+	return func(m *UsageLogMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *UsageLog
+		)
+		m.oldValue = func(ctx context.Context) (*UsageLog, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().UsageLog.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withUsageLog sets the old UsageLog of the mutation.
+func withUsageLog(node *UsageLog) usagelogOption {
+	return func(m *UsageLogMutation) {
+		m.oldValue = func(context.Context) (*UsageLog, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m UsageLogMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m UsageLogMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UsageLogMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UsageLogMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().UsageLog.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetUserID sets the "user_id" field.
+func (m *UsageLogMutation) SetUserID(i int64) {
+	m.user = &i
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *UsageLogMutation) UserID() (r int64, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the UsageLog entity.
+// If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UsageLogMutation) OldUserID(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *UsageLogMutation) ResetUserID() {
+	m.user = nil
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (m *UsageLogMutation) SetAPIKeyID(i int64) {
+	m.api_key = &i
+}
+
+// APIKeyID returns the value of the "api_key_id" field in the mutation.
+func (m *UsageLogMutation) APIKeyID() (r int64, exists bool) {
+	v := m.api_key
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAPIKeyID returns the old "api_key_id" field's value of the UsageLog entity.
+// If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UsageLogMutation) OldAPIKeyID(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAPIKeyID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAPIKeyID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAPIKeyID: %w", err)
+	}
+	return oldValue.APIKeyID, nil
+}
+
+// ResetAPIKeyID resets all changes to the "api_key_id" field.
+func (m *UsageLogMutation) ResetAPIKeyID() {
+	m.api_key = nil
+}
+
+// SetAccountID sets the "account_id" field.
+func (m *UsageLogMutation) SetAccountID(i int64) {
+	m.account = &i
+}
+
+// AccountID returns the value of the "account_id" field in the mutation.
+func (m *UsageLogMutation) AccountID() (r int64, exists bool) {
+	v := m.account
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAccountID returns the old "account_id" field's value of the UsageLog entity.
+// If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UsageLogMutation) OldAccountID(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAccountID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAccountID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAccountID: %w", err)
+	}
+	return oldValue.AccountID, nil
+}
+
+// ResetAccountID resets all changes to the "account_id" field.
+func (m *UsageLogMutation) ResetAccountID() {
+	m.account = nil
+}
+
+// SetRequestID sets the "request_id" field.
+func (m *UsageLogMutation) SetRequestID(s string) {
+	m.request_id = &s
+}
+
+// RequestID returns the value of the "request_id" field in the mutation.
+func (m *UsageLogMutation) RequestID() (r string, exists bool) {
+	v := m.request_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRequestID returns the old "request_id" field's value of the UsageLog entity.
+// If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UsageLogMutation) OldRequestID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequestID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequestID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequestID: %w", err)
+	}
+	return oldValue.RequestID, nil
+}
+
+// ResetRequestID resets all changes to the "request_id" field.
+func (m *UsageLogMutation) ResetRequestID() {
+	m.request_id = nil
+}
 
-// usagelogOption allows management of the mutation configuration using functional options.
-type usagelogOption func(*UsageLogMutation)
+// SetModel sets the "model" field.
+func (m *UsageLogMutation) SetModel(s string) {
+	m.model = &s
+}
 
-// newUsageLogMutation creates new mutation for the UsageLog entity.
-func newUsageLogMutation(c config, op Op, opts ...usagelogOption) *UsageLogMutation {
-	m := &UsageLogMutation{
-		config:        c,
-		op:            op,
-		typ:           TypeUsageLog,
-		clearedFields: make(map[string]struct{}),
+// Model returns the value of the "model" field in the mutation.
+func (m *UsageLogMutation) Model() (r string, exists bool) {
+	v := m.model
+	if v == nil {
+		return
 	}
-	for _, opt := range opts {
-		opt(m)
+	return *v, true
+}
+
+// OldModel returns the old "model" field's value of the UsageLog entity.
+// If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UsageLogMutation) OldModel(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldModel is only allowed on UpdateOne operations")
 	}
-	return m
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldModel requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldModel: %w", err)
+	}
+	return oldValue.Model, nil
 }
 
-// withUsageLogID sets the ID field of the mutation.
-func withUsageLogID(id int64) usagelogOption {
-	return func(m *UsageLogMutation) {
-		var (
-			err   error
-			once  sync.Once
-			value *UsageLog
-		)
-		m.oldValue = func(ctx context.Context) (*UsageLog, error) {
-			once.Do(func() {
-				if m.done {
-					err = errors.New("querying old values post mutation is not allowed")
-				} else {
-					value, err = m.Client().UsageLog.Get(ctx, id)
-				}
-			})
-			return value, err
-		}
-		m.id = &id
+// ResetModel resets all changes to the "model" field.
+func (m *UsageLogMutation) ResetModel() {
+	m.model = nil
+}
+
+// SetGroupID sets the "group_id" field.
+func (m *UsageLogMutation) SetGroupID(i int64) {
+	m.group = &i
+}
+
+// GroupID returns the value of the "group_id" field in the mutation.
+func (m *UsageLogMutation) GroupID() (r int64, exists bool) {
+	v := m.group
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// withUsageLog sets the old UsageLog of the mutation.
-func withUsageLog(node *UsageLog) usagelogOption {
-	return func(m *UsageLogMutation) {
-		m.oldValue = func(context.Context) (*UsageLog, error) {
-			return node, nil
-		}
-		m.id = &node.ID
+// OldGroupID returns the old "group_id" field's value of the UsageLog entity.
+// If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UsageLogMutation) OldGroupID(ctx context.Context) (v *int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldGroupID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldGroupID requires an ID field in the mutation")
 	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldGroupID: %w", err)
+	}
+	return oldValue.GroupID, nil
 }
 
-// Client returns a new `ent.Client` from the mutation. If the mutation was
-// executed in a transaction (ent.Tx), a transactional client is returned.
-func (m UsageLogMutation) Client() *Client {
-	client := &Client{config: m.config}
-	client.init()
-	return client
+// ClearGroupID clears the value of the "group_id" field.
+func (m *UsageLogMutation) ClearGroupID() {
+	m.group = nil
+	m.clearedFields[usagelog.FieldGroupID] = struct{}{}
 }
 
-// Tx returns an `ent.Tx` for mutations that were executed in transactions;
-// it returns an error otherwise.
-func (m UsageLogMutation) Tx() (*Tx, error) {
-	if _, ok := m.driver.(*txDriver); !ok {
-		return nil, errors.New("ent: mutation is not running in a transaction")
-	}
-	tx := &Tx{config: m.config}
-	tx.init()
-	return tx, nil
+// GroupIDCleared returns if the "group_id" field was cleared in this mutation.
+func (m *UsageLogMutation) GroupIDCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldGroupID]
+	return ok
 }
 
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *UsageLogMutation) ID() (id int64, exists bool) {
-	if m.id == nil {
+// ResetGroupID resets all changes to the "group_id" field.
+func (m *UsageLogMutation) ResetGroupID() {
+	m.group = nil
+	delete(m.clearedFields, usagelog.FieldGroupID)
+}
+
+// SetSubscriptionID sets the "subscription_id" field.
+func (m *UsageLogMutation) SetSubscriptionID(i int64) {
+	m.subscription = &i
+}
+
+// SubscriptionID returns the value of the "subscription_id" field in the mutation.
+func (m *UsageLogMutation) SubscriptionID() (r int64, exists bool) {
+	v := m.subscription
+	if v == nil {
 		return
 	}
-	return *m.id, true
+	return *v, true
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *UsageLogMutation) IDs(ctx context.Context) ([]int64, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []int64{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().UsageLog.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+// OldSubscriptionID returns the old "subscription_id" field's value of the UsageLog entity.
+// If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UsageLogMutation) OldSubscriptionID(ctx context.Context) (v *int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSubscriptionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSubscriptionID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSubscriptionID: %w", err)
 	}
+	return oldValue.SubscriptionID, nil
 }
 
-// SetUserID sets the "user_id" field.
-func (m *UsageLogMutation) SetUserID(i int64) {
-	m.user = &i
+// ClearSubscriptionID clears the value of the "subscription_id" field.
+func (m *UsageLogMutation) ClearSubscriptionID() {
+	m.subscription = nil
+	m.clearedFields[usagelog.FieldSubscriptionID] = struct{}{}
 }
 
-// UserID returns the value of the "user_id" field in the mutation.
-func (m *UsageLogMutation) UserID() (r int64, exists bool) {
-	v := m.user
+// SubscriptionIDCleared returns if the "subscription_id" field was cleared in this mutation.
+func (m *UsageLogMutation) SubscriptionIDCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldSubscriptionID]
+	return ok
+}
+
+// ResetSubscriptionID resets all changes to the "subscription_id" field.
+func (m *UsageLogMutation) ResetSubscriptionID() {
+	m.subscription = nil
+	delete(m.clearedFields, usagelog.FieldSubscriptionID)
+}
+
+// SetInputTokens sets the "input_tokens" field.
+func (m *UsageLogMutation) SetInputTokens(i int) {
+	m.input_tokens = &i
+	m.addinput_tokens = nil
+}
+
+// InputTokens returns the value of the "input_tokens" field in the mutation.
+func (m *UsageLogMutation) InputTokens() (r int, exists bool) {
+	v := m.input_tokens
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserID returns the old "user_id" field's value of the UsageLog entity.
+// OldInputTokens returns the old "input_tokens" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldUserID(ctx context.Context) (v int64, err error) {
+func (m *UsageLogMutation) OldInputTokens(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldInputTokens is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserID requires an ID field in the mutation")
+		return v, errors.New("OldInputTokens requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldInputTokens: %w", err)
 	}
-	return oldValue.UserID, nil
+	return oldValue.InputTokens, nil
 }
 
-// ResetUserID resets all changes to the "user_id" field.
-func (m *UsageLogMutation) ResetUserID() {
-	m.user = nil
+// AddInputTokens adds i to the "input_tokens" field.
+func (m *UsageLogMutation) AddInputTokens(i int) {
+	if m.addinput_tokens != nil {
+		*m.addinput_tokens += i
+	} else {
+		m.addinput_tokens = &i
+	}
 }
 
-// SetAPIKeyID sets the "api_key_id" field.
-func (m *UsageLogMutation) SetAPIKeyID(i int64) {
-	m.api_key = &i
+// AddedInputTokens returns the value that was added to the "input_tokens" field in this mutation.
+func (m *UsageLogMutation) AddedInputTokens() (r int, exists bool) {
+	v := m.addinput_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetInputTokens resets all changes to the "input_tokens" field.
+func (m *UsageLogMutation) ResetInputTokens() {
+	m.input_tokens = nil
+	m.addinput_tokens = nil
+}
+
+// SetOutputTokens sets the "output_tokens" field.
+func (m *UsageLogMutation) SetOutputTokens(i int) {
+	m.output_tokens = &i
+	m.addoutput_tokens = nil
 }
 
-// APIKeyID returns the value of the "api_key_id" field in the mutation.
-func (m *UsageLogMutation) APIKeyID() (r int64, exists bool) {
-	v := m.api_key
+// OutputTokens returns the value of the "output_tokens" field in the mutation.
+func (m *UsageLogMutation) OutputTokens() (r int, exists bool) {
+	v := m.output_tokens
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAPIKeyID returns the old "api_key_id" field's value of the UsageLog entity.
+// OldOutputTokens returns the old "output_tokens" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldAPIKeyID(ctx context.Context) (v int64, err error) {
+func (m *UsageLogMutation) OldOutputTokens(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAPIKeyID is only allowed on UpdateOne operations")
+		return v, errors.New("OldOutputTokens is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAPIKeyID requires an ID field in the mutation")
+		return v, errors.New("OldOutputTokens requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAPIKeyID: %w", err)
+		return v, fmt.Errorf("querying old value for OldOutputTokens: %w", err)
 	}
-	return oldValue.APIKeyID, nil
+	return oldValue.OutputTokens, nil
 }
 
-// ResetAPIKeyID resets all changes to the "api_key_id" field.
-func (m *UsageLogMutation) ResetAPIKeyID() {
-	m.api_key = nil
+// AddOutputTokens adds i to the "output_tokens" field.
+func (m *UsageLogMutation) AddOutputTokens(i int) {
+	if m.addoutput_tokens != nil {
+		*m.addoutput_tokens += i
+	} else {
+		m.addoutput_tokens = &i
+	}
 }
 
-// SetAccountID sets the "account_id" field.
-func (m *UsageLogMutation) SetAccountID(i int64) {
-	m.account = &i
+// AddedOutputTokens returns the value that was added to the "output_tokens" field in this mutation.
+func (m *UsageLogMutation) AddedOutputTokens() (r int, exists bool) {
+	v := m.addoutput_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// AccountID returns the value of the "account_id" field in the mutation.
-func (m *UsageLogMutation) AccountID() (r int64, exists bool) {
-	v := m.account
+// ResetOutputTokens resets all changes to the "output_tokens" field.
+func (m *UsageLogMutation) ResetOutputTokens() {
+	m.output_tokens = nil
+	m.addoutput_tokens = nil
+}
+
+// SetCacheCreationTokens sets the "cache_creation_tokens" field.
+func (m *UsageLogMutation) SetCacheCreationTokens(i int) {
+	m.cache_creation_tokens = &i
+	m.addcache_creation_tokens = nil
+}
+
+// CacheCreationTokens returns the value of the "cache_creation_tokens" field in the mutation.
+func (m *UsageLogMutation) CacheCreationTokens() (r int, exists bool) {
+	v := m.cache_creation_tokens
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAccountID returns the old "account_id" field's value of the UsageLog entity.
+// OldCacheCreationTokens returns the old "cache_creation_tokens" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldAccountID(ctx context.Context) (v int64, err error) {
+func (m *UsageLogMutation) OldCacheCreationTokens(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAccountID is only allowed on UpdateOne operations")
+		return v, errors.New("OldCacheCreationTokens is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAccountID requires an ID field in the mutation")
+		return v, errors.New("OldCacheCreationTokens requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAccountID: %w", err)
+		return v, fmt.Errorf("querying old value for OldCacheCreationTokens: %w", err)
 	}
-	return oldValue.AccountID, nil
+	return oldValue.CacheCreationTokens, nil
 }
 
-// ResetAccountID resets all changes to the "account_id" field.
-func (m *UsageLogMutation) ResetAccountID() {
-	m.account = nil
+// AddCacheCreationTokens adds i to the "cache_creation_tokens" field.
+func (m *UsageLogMutation) AddCacheCreationTokens(i int) {
+	if m.addcache_creation_tokens != nil {
+		*m.addcache_creation_tokens += i
+	} else {
+		m.addcache_creation_tokens = &i
+	}
 }
 
-// SetRequestID sets the "request_id" field.
-func (m *UsageLogMutation) SetRequestID(s string) {
-	m.request_id = &s
+// AddedCacheCreationTokens returns the value that was added to the "cache_creation_tokens" field in this mutation.
+func (m *UsageLogMutation) AddedCacheCreationTokens() (r int, exists bool) {
+	v := m.addcache_creation_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// RequestID returns the value of the "request_id" field in the mutation.
-func (m *UsageLogMutation) RequestID() (r string, exists bool) {
-	v := m.request_id
+// ResetCacheCreationTokens resets all changes to the "cache_creation_tokens" field.
+func (m *UsageLogMutation) ResetCacheCreationTokens() {
+	m.cache_creation_tokens = nil
+	m.addcache_creation_tokens = nil
+}
+
+// SetCacheReadTokens sets the "cache_read_tokens" field.
+func (m *UsageLogMutation) SetCacheReadTokens(i int) {
+	m.cache_read_tokens = &i
+	m.addcache_read_tokens = nil
+}
+
+// CacheReadTokens returns the value of the "cache_read_tokens" field in the mutation.
+func (m *UsageLogMutation) CacheReadTokens() (r int, exists bool) {
+	v := m.cache_read_tokens
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRequestID returns the old "request_id" field's value of the UsageLog entity.
+// OldCacheReadTokens returns the old "cache_read_tokens" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldRequestID(ctx context.Context) (v string, err error) {
+func (m *UsageLogMutation) OldCacheReadTokens(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRequestID is only allowed on UpdateOne operations")
+		return v, errors.New("OldCacheReadTokens is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRequestID requires an ID field in the mutation")
+		return v, errors.New("OldCacheReadTokens requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRequestID: %w", err)
+		return v, fmt.Errorf("querying old value for OldCacheReadTokens: %w", err)
 	}
-	return oldValue.RequestID, nil
+	return oldValue.CacheReadTokens, nil
 }
 
-// ResetRequestID resets all changes to the "request_id" field.
-func (m *UsageLogMutation) ResetRequestID() {
-	m.request_id = nil
+// AddCacheReadTokens adds i to the "cache_read_tokens" field.
+func (m *UsageLogMutation) AddCacheReadTokens(i int) {
+	if m.addcache_read_tokens != nil {
+		*m.addcache_read_tokens += i
+	} else {
+		m.addcache_read_tokens = &i
+	}
 }
 
-// SetModel sets the "model" field.
-func (m *UsageLogMutation) SetModel(s string) {
-	m.model = &s
+// AddedCacheReadTokens returns the value that was added to the "cache_read_tokens" field in this mutation.
+func (m *UsageLogMutation) AddedCacheReadTokens() (r int, exists bool) {
+	v := m.addcache_read_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// Model returns the value of the "model" field in the mutation.
-func (m *UsageLogMutation) Model() (r string, exists bool) {
-	v := m.model
+// ResetCacheReadTokens resets all changes to the "cache_read_tokens" field.
+func (m *UsageLogMutation) ResetCacheReadTokens() {
+	m.cache_read_tokens = nil
+	m.addcache_read_tokens = nil
+}
+
+// SetCacheCreation5mTokens sets the "cache_creation_5m_tokens" field.
+func (m *UsageLogMutation) SetCacheCreation5mTokens(i int) {
+	m.cache_creation_5m_tokens = &i
+	m.addcache_creation_5m_tokens = nil
+}
+
+// CacheCreation5mTokens returns the value of the "cache_creation_5m_tokens" field in the mutation.
+func (m *UsageLogMutation) CacheCreation5mTokens() (r int, exists bool) {
+	v := m.cache_creation_5m_tokens
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldModel returns the old "model" field's value of the UsageLog entity.
+// OldCacheCreation5mTokens returns the old "cache_creation_5m_tokens" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldModel(ctx context.Context) (v string, err error) {
+func (m *UsageLogMutation) OldCacheCreation5mTokens(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldModel is only allowed on UpdateOne operations")
+		return v, errors.New("OldCacheCreation5mTokens is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldModel requires an ID field in the mutation")
+		return v, errors.New("OldCacheCreation5mTokens requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldModel: %w", err)
+		return v, fmt.Errorf("querying old value for OldCacheCreation5mTokens: %w", err)
 	}
-	return oldValue.Model, nil
+	return oldValue.CacheCreation5mTokens, nil
 }
 
-// ResetModel resets all changes to the "model" field.
-func (m *UsageLogMutation) ResetModel() {
-	m.model = nil
+// AddCacheCreation5mTokens adds i to the "cache_creation_5m_tokens" field.
+func (m *UsageLogMutation) AddCacheCreation5mTokens(i int) {
+	if m.addcache_creation_5m_tokens != nil {
+		*m.addcache_creation_5m_tokens += i
+	} else {
+		m.addcache_creation_5m_tokens = &i
+	}
 }
 
-// SetGroupID sets the "group_id" field.
-func (m *UsageLogMutation) SetGroupID(i int64) {
-	m.group = &i
+// AddedCacheCreation5mTokens returns the value that was added to the "cache_creation_5m_tokens" field in this mutation.
+func (m *UsageLogMutation) AddedCacheCreation5mTokens() (r int, exists bool) {
+	v := m.addcache_creation_5m_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// GroupID returns the value of the "group_id" field in the mutation.
-func (m *UsageLogMutation) GroupID() (r int64, exists bool) {
-	v := m.group
+// ResetCacheCreation5mTokens resets all changes to the "cache_creation_5m_tokens" field.
+func (m *UsageLogMutation) ResetCacheCreation5mTokens() {
+	m.cache_creation_5m_tokens = nil
+	m.addcache_creation_5m_tokens = nil
+}
+
+// SetCacheCreation1hTokens sets the "cache_creation_1h_tokens" field.
+func (m *UsageLogMutation) SetCacheCreation1hTokens(i int) {
+	m.cache_creation_1h_tokens = &i
+	m.addcache_creation_1h_tokens = nil
+}
+
+// CacheCreation1hTokens returns the value of the "cache_creation_1h_tokens" field in the mutation.
+func (m *UsageLogMutation) CacheCreation1hTokens() (r int, exists bool) {
+	v := m.cache_creation_1h_tokens
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldGroupID returns the old "group_id" field's value of the UsageLog entity.
+// OldCacheCreation1hTokens returns the old "cache_creation_1h_tokens" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldGroupID(ctx context.Context) (v *int64, err error) {
+func (m *UsageLogMutation) OldCacheCreation1hTokens(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldGroupID is only allowed on UpdateOne operations")
+		return v, errors.New("OldCacheCreation1hTokens is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldGroupID requires an ID field in the mutation")
+		return v, errors.New("OldCacheCreation1hTokens requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldGroupID: %w", err)
+		return v, fmt.Errorf("querying old value for OldCacheCreation1hTokens: %w", err)
 	}
-	return oldValue.GroupID, nil
+	return oldValue.CacheCreation1hTokens, nil
 }
 
-// ClearGroupID clears the value of the "group_id" field.
-func (m *UsageLogMutation) ClearGroupID() {
-	m.group = nil
-	m.clearedFields[usagelog.FieldGroupID] = struct{}{}
+// AddCacheCreation1hTokens adds i to the "cache_creation_1h_tokens" field.
+func (m *UsageLogMutation) AddCacheCreation1hTokens(i int) {
+	if m.addcache_creation_1h_tokens != nil {
+		*m.addcache_creation_1h_tokens += i
+	} else {
+		m.addcache_creation_1h_tokens = &i
+	}
 }
 
-// GroupIDCleared returns if the "group_id" field was cleared in this mutation.
-func (m *UsageLogMutation) GroupIDCleared() bool {
-	_, ok := m.clearedFields[usagelog.FieldGroupID]
-	return ok
+// AddedCacheCreation1hTokens returns the value that was added to the "cache_creation_1h_tokens" field in this mutation.
+func (m *UsageLogMutation) AddedCacheCreation1hTokens() (r int, exists bool) {
+	v := m.addcache_creation_1h_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetGroupID resets all changes to the "group_id" field.
-func (m *UsageLogMutation) ResetGroupID() {
-	m.group = nil
-	delete(m.clearedFields, usagelog.FieldGroupID)
+// ResetCacheCreation1hTokens resets all changes to the "cache_creation_1h_tokens" field.
+func (m *UsageLogMutation) ResetCacheCreation1hTokens() {
+	m.cache_creation_1h_tokens = nil
+	m.addcache_creation_1h_tokens = nil
 }
 
-// SetSubscriptionID sets the "subscription_id" field.
-func (m *UsageLogMutation) SetSubscriptionID(i int64) {
-	m.subscription = &i
+// SetInputCost sets the "input_cost" field.
+func (m *UsageLogMutation) SetInputCost(f float64) {
+	m.input_cost = &f
+	m.addinput_cost = nil
 }
 
-// SubscriptionID returns the value of the "subscription_id" field in the mutation.
-func (m *UsageLogMutation) SubscriptionID() (r int64, exists bool) {
-	v := m.subscription
+// InputCost returns the value of the "input_cost" field in the mutation.
+func (m *UsageLogMutation) InputCost() (r float64, exists bool) {
+	v := m.input_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSubscriptionID returns the old "subscription_id" field's value of the UsageLog entity.
+// OldInputCost returns the old "input_cost" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldSubscriptionID(ctx context.Context) (v *int64, err error) {
+func (m *UsageLogMutation) OldInputCost(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSubscriptionID is only allowed on UpdateOne operations")
+		return v, errors.New("OldInputCost is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSubscriptionID requires an ID field in the mutation")
+		return v, errors.New("OldInputCost requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSubscriptionID: %w", err)
+		return v, fmt.Errorf("querying old value for OldInputCost: %w", err)
 	}
-	return oldValue.SubscriptionID, nil
+	return oldValue.InputCost, nil
 }
 
-// ClearSubscriptionID clears the value of the "subscription_id" field.
-func (m *UsageLogMutation) ClearSubscriptionID() {
-	m.subscription = nil
-	m.clearedFields[usagelog.FieldSubscriptionID] = struct{}{}
+// AddInputCost adds f to the "input_cost" field.
+func (m *UsageLogMutation) AddInputCost(f float64) {
+	if m.addinput_cost != nil {
+		*m.addinput_cost += f
+	} else {
+		m.addinput_cost = &f
+	}
 }
 
-// SubscriptionIDCleared returns if the "subscription_id" field was cleared in this mutation.
-func (m *UsageLogMutation) SubscriptionIDCleared() bool {
-	_, ok := m.clearedFields[usagelog.FieldSubscriptionID]
-	return ok
+// AddedInputCost returns the value that was added to the "input_cost" field in this mutation.
+func (m *UsageLogMutation) AddedInputCost() (r float64, exists bool) {
+	v := m.addinput_cost
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetSubscriptionID resets all changes to the "subscription_id" field.
-func (m *UsageLogMutation) ResetSubscriptionID() {
-	m.subscription = nil
-	delete(m.clearedFields, usagelog.FieldSubscriptionID)
+// ResetInputCost resets all changes to the "input_cost" field.
+func (m *UsageLogMutation) ResetInputCost() {
+	m.input_cost = nil
+	m.addinput_cost = nil
 }
 
-// SetInputTokens sets the "input_tokens" field.
-func (m *UsageLogMutation) SetInputTokens(i int) {
-	m.input_tokens = &i
-	m.addinput_tokens = nil
+// SetOutputCost sets the "output_cost" field.
+func (m *UsageLogMutation) SetOutputCost(f float64) {
+	m.output_cost = &f
+	m.addoutput_cost = nil
 }
 
-// InputTokens returns the value of the "input_tokens" field in the mutation.
-func (m *UsageLogMutation) InputTokens() (r int, exists bool) {
-	v := m.input_tokens
+// OutputCost returns the value of the "output_cost" field in the mutation.
+func (m *UsageLogMutation) OutputCost() (r float64, exists bool) {
+	v := m.output_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldInputTokens returns the old "input_tokens" field's value of the UsageLog entity.
+// OldOutputCost returns the old "output_cost" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldInputTokens(ctx context.Context) (v int, err error) {
+func (m *UsageLogMutation) OldOutputCost(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldInputTokens is only allowed on UpdateOne operations")
+		return v, errors.New("OldOutputCost is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldInputTokens requires an ID field in the mutation")
+		return v, errors.New("OldOutputCost requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldInputTokens: %w", err)
+		return v, fmt.Errorf("querying old value for OldOutputCost: %w", err)
 	}
-	return oldValue.InputTokens, nil
+	return oldValue.OutputCost, nil
 }
 
-// AddInputTokens adds i to the "input_tokens" field.
-func (m *UsageLogMutation) AddInputTokens(i int) {
-	if m.addinput_tokens != nil {
-		*m.addinput_tokens += i
+// AddOutputCost adds f to the "output_cost" field.
+func (m *UsageLogMutation) AddOutputCost(f float64) {
+	if m.addoutput_cost != nil {
+		*m.addoutput_cost += f
 	} else {
-		m.addinput_tokens = &i
+		m.addoutput_cost = &f
 	}
 }
 
-// AddedInputTokens returns the value that was added to the "input_tokens" field in this mutation.
-func (m *UsageLogMutation) AddedInputTokens() (r int, exists bool) {
-	v := m.addinput_tokens
+// AddedOutputCost returns the value that was added to the "output_cost" field in this mutation.
+func (m *UsageLogMutation) AddedOutputCost() (r float64, exists bool) {
+	v := m.addoutput_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetInputTokens resets all changes to the "input_tokens" field.
-func (m *UsageLogMutation) ResetInputTokens() {
-	m.input_tokens = nil
-	m.addinput_tokens = nil
+// ResetOutputCost resets all changes to the "output_cost" field.
+func (m *UsageLogMutation) ResetOutputCost() {
+	m.output_cost = nil
+	m.addoutput_cost = nil
 }
 
-// SetOutputTokens sets the "output_tokens" field.
-func (m *UsageLogMutation) SetOutputTokens(i int) {
-	m.output_tokens = &i
-	m.addoutput_tokens = nil
+// SetCacheCreationCost sets the "cache_creation_cost" field.
+func (m *UsageLogMutation) SetCacheCreationCost(f float64) {
+	m.cache_creation_cost = &f
+	m.addcache_creation_cost = nil
 }
 
-// OutputTokens returns the value of the "output_tokens" field in the mutation.
-func (m *UsageLogMutation) OutputTokens() (r int, exists bool) {
-	v := m.output_tokens
+// CacheCreationCost returns the value of the "cache_creation_cost" field in the mutation.
+func (m *UsageLogMutation) CacheCreationCost() (r float64, exists bool) {
+	v := m.cache_creation_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldOutputTokens returns the old "output_tokens" field's value of the UsageLog entity.
+// OldCacheCreationCost returns the old "cache_creation_cost" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldOutputTokens(ctx context.Context) (v int, err error) {
+func (m *UsageLogMutation) OldCacheCreationCost(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldOutputTokens is only allowed on UpdateOne operations")
+		return v, errors.New("OldCacheCreationCost is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldOutputTokens requires an ID field in the mutation")
+		return v, errors.New("OldCacheCreationCost requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldOutputTokens: %w", err)
+		return v, fmt.Errorf("querying old value for OldCacheCreationCost: %w", err)
 	}
-	return oldValue.OutputTokens, nil
+	return oldValue.CacheCreationCost, nil
 }
 
-// AddOutputTokens adds i to the "output_tokens" field.
-func (m *UsageLogMutation) AddOutputTokens(i int) {
-	if m.addoutput_tokens != nil {
-		*m.addoutput_tokens += i
+// AddCacheCreationCost adds f to the "cache_creation_cost" field.
+func (m *UsageLogMutation) AddCacheCreationCost(f float64) {
+	if m.addcache_creation_cost != nil {
+		*m.addcache_creation_cost += f
 	} else {
-		m.addoutput_tokens = &i
+		m.addcache_creation_cost = &f
 	}
 }
 
-// AddedOutputTokens returns the value that was added to the "output_tokens" field in this mutation.
-func (m *UsageLogMutation) AddedOutputTokens() (r int, exists bool) {
-	v := m.addoutput_tokens
+// AddedCacheCreationCost returns the value that was added to the "cache_creation_cost" field in this mutation.
+func (m *UsageLogMutation) AddedCacheCreationCost() (r float64, exists bool) {
+	v := m.addcache_creation_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetOutputTokens resets all changes to the "output_tokens" field.
-func (m *UsageLogMutation) ResetOutputTokens() {
-	m.output_tokens = nil
-	m.addoutput_tokens = nil
+// ResetCacheCreationCost resets all changes to the "cache_creation_cost" field.
+func (m *UsageLogMutation) ResetCacheCreationCost() {
+	m.cache_creation_cost = nil
+	m.addcache_creation_cost = nil
 }
 
-// SetCacheCreationTokens sets the "cache_creation_tokens" field.
-func (m *UsageLogMutation) SetCacheCreationTokens(i int) {
-	m.cache_creation_tokens = &i
-	m.addcache_creation_tokens = nil
+// SetCacheReadCost sets the "cache_read_cost" field.
+func (m *UsageLogMutation) SetCacheReadCost(f float64) {
+	m.cache_read_cost = &f
+	m.addcache_read_cost = nil
 }
 
-// CacheCreationTokens returns the value of the "cache_creation_tokens" field in the mutation.
-func (m *UsageLogMutation) CacheCreationTokens() (r int, exists bool) {
-	v := m.cache_creation_tokens
+// CacheReadCost returns the value of the "cache_read_cost" field in the mutation.
+func (m *UsageLogMutation) CacheReadCost() (r float64, exists bool) {
+	v := m.cache_read_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCacheCreationTokens returns the old "cache_creation_tokens" field's value of the UsageLog entity.
+// OldCacheReadCost returns the old "cache_read_cost" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldCacheCreationTokens(ctx context.Context) (v int, err error) {
+func (m *UsageLogMutation) OldCacheReadCost(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCacheCreationTokens is only allowed on UpdateOne operations")
+		return v, errors.New("OldCacheReadCost is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCacheCreationTokens requires an ID field in the mutation")
+		return v, errors.New("OldCacheReadCost requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCacheCreationTokens: %w", err)
+		return v, fmt.Errorf("querying old value for OldCacheReadCost: %w", err)
 	}
-	return oldValue.CacheCreationTokens, nil
+	return oldValue.CacheReadCost, nil
 }
 
-// AddCacheCreationTokens adds i to the "cache_creation_tokens" field.
-func (m *UsageLogMutation) AddCacheCreationTokens(i int) {
-	if m.addcache_creation_tokens != nil {
-		*m.addcache_creation_tokens += i
+// AddCacheReadCost adds f to the "cache_read_cost" field.
+func (m *UsageLogMutation) AddCacheReadCost(f float64) {
+	if m.addcache_read_cost != nil {
+		*m.addcache_read_cost += f
 	} else {
-		m.addcache_creation_tokens = &i
+		m.addcache_read_cost = &f
 	}
 }
 
-// AddedCacheCreationTokens returns the value that was added to the "cache_creation_tokens" field in this mutation.
-func (m *UsageLogMutation) AddedCacheCreationTokens() (r int, exists bool) {
-	v := m.addcache_creation_tokens
+// AddedCacheReadCost returns the value that was added to the "cache_read_cost" field in this mutation.
+func (m *UsageLogMutation) AddedCacheReadCost() (r float64, exists bool) {
+	v := m.addcache_read_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetCacheCreationTokens resets all changes to the "cache_creation_tokens" field.
-func (m *UsageLogMutation) ResetCacheCreationTokens() {
-	m.cache_creation_tokens = nil
-	m.addcache_creation_tokens = nil
+// ResetCacheReadCost resets all changes to the "cache_read_cost" field.
+func (m *UsageLogMutation) ResetCacheReadCost() {
+	m.cache_read_cost = nil
+	m.addcache_read_cost = nil
 }
 
-// SetCacheReadTokens sets the "cache_read_tokens" field.
-func (m *UsageLogMutation) SetCacheReadTokens(i int) {
-	m.cache_read_tokens = &i
-	m.addcache_read_tokens = nil
+// SetTotalCost sets the "total_cost" field.
+func (m *UsageLogMutation) SetTotalCost(f float64) {
+	m.total_cost = &f
+	m.addtotal_cost = nil
 }
 
-// CacheReadTokens returns the value of the "cache_read_tokens" field in the mutation.
-func (m *UsageLogMutation) CacheReadTokens() (r int, exists bool) {
-	v := m.cache_read_tokens
+// TotalCost returns the value of the "total_cost" field in the mutation.
+func (m *UsageLogMutation) TotalCost() (r float64, exists bool) {
+	v := m.total_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCacheReadTokens returns the old "cache_read_tokens" field's value of the UsageLog entity.
+// OldTotalCost returns the old "total_cost" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldCacheReadTokens(ctx context.Context) (v int, err error) {
+func (m *UsageLogMutation) OldTotalCost(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCacheReadTokens is only allowed on UpdateOne operations")
+		return v, errors.New("OldTotalCost is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCacheReadTokens requires an ID field in the mutation")
+		return v, errors.New("OldTotalCost requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCacheReadTokens: %w", err)
+		return v, fmt.Errorf("querying old value for OldTotalCost: %w", err)
 	}
-	return oldValue.CacheReadTokens, nil
+	return oldValue.TotalCost, nil
 }
 
-// AddCacheReadTokens adds i to the "cache_read_tokens" field.
-func (m *UsageLogMutation) AddCacheReadTokens(i int) {
-	if m.addcache_read_tokens != nil {
-		*m.addcache_read_tokens += i
+// AddTotalCost adds f to the "total_cost" field.
+func (m *UsageLogMutation) AddTotalCost(f float64) {
+	if m.addtotal_cost != nil {
+		*m.addtotal_cost += f
 	} else {
-		m.addcache_read_tokens = &i
+		m.addtotal_cost = &f
 	}
 }
 
-// AddedCacheReadTokens returns the value that was added to the "cache_read_tokens" field in this mutation.
-func (m *UsageLogMutation) AddedCacheReadTokens() (r int, exists bool) {
-	v := m.addcache_read_tokens
+// AddedTotalCost returns the value that was added to the "total_cost" field in this mutation.
+func (m *UsageLogMutation) AddedTotalCost() (r float64, exists bool) {
+	v := m.addtotal_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetCacheReadTokens resets all changes to the "cache_read_tokens" field.
-func (m *UsageLogMutation) ResetCacheReadTokens() {
-	m.cache_read_tokens = nil
-	m.addcache_read_tokens = nil
+// ResetTotalCost resets all changes to the "total_cost" field.
+func (m *UsageLogMutation) ResetTotalCost() {
+	m.total_cost = nil
+	m.addtotal_cost = nil
 }
 
-// SetCacheCreation5mTokens sets the "cache_creation_5m_tokens" field.
-func (m *UsageLogMutation) SetCacheCreation5mTokens(i int) {
-	m.cache_creation_5m_tokens = &i
-	m.addcache_creation_5m_tokens = nil
+// SetActualCost sets the "actual_cost" field.
+func (m *UsageLogMutation) SetActualCost(f float64) {
+	m.actual_cost = &f
+	m.addactual_cost = nil
 }
 
-// CacheCreation5mTokens returns the value of the "cache_creation_5m_tokens" field in the mutation.
-func (m *UsageLogMutation) CacheCreation5mTokens() (r int, exists bool) {
-	v := m.cache_creation_5m_tokens
+// ActualCost returns the value of the "actual_cost" field in the mutation.
+func (m *UsageLogMutation) ActualCost() (r float64, exists bool) {
+	v := m.actual_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCacheCreation5mTokens returns the old "cache_creation_5m_tokens" field's value of the UsageLog entity.
+// OldActualCost returns the old "actual_cost" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldCacheCreation5mTokens(ctx context.Context) (v int, err error) {
+func (m *UsageLogMutation) OldActualCost(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCacheCreation5mTokens is only allowed on UpdateOne operations")
+		return v, errors.New("OldActualCost is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCacheCreation5mTokens requires an ID field in the mutation")
+		return v, errors.New("OldActualCost requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCacheCreation5mTokens: %w", err)
+		return v, fmt.Errorf("querying old value for OldActualCost: %w", err)
 	}
-	return oldValue.CacheCreation5mTokens, nil
+	return oldValue.ActualCost, nil
 }
 
-// AddCacheCreation5mTokens adds i to the "cache_creation_5m_tokens" field.
-func (m *UsageLogMutation) AddCacheCreation5mTokens(i int) {
-	if m.addcache_creation_5m_tokens != nil {
-		*m.addcache_creation_5m_tokens += i
+// AddActualCost adds f to the "actual_cost" field.
+func (m *UsageLogMutation) AddActualCost(f float64) {
+	if m.addactual_cost != nil {
+		*m.addactual_cost += f
 	} else {
-		m.addcache_creation_5m_tokens = &i
+		m.addactual_cost = &f
 	}
 }
 
-// AddedCacheCreation5mTokens returns the value that was added to the "cache_creation_5m_tokens" field in this mutation.
-func (m *UsageLogMutation) AddedCacheCreation5mTokens() (r int, exists bool) {
-	v := m.addcache_creation_5m_tokens
+// AddedActualCost returns the value that was added to the "actual_cost" field in this mutation.
+func (m *UsageLogMutation) AddedActualCost() (r float64, exists bool) {
+	v := m.addactual_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetCacheCreation5mTokens resets all changes to the "cache_creation_5m_tokens" field.
-func (m *UsageLogMutation) ResetCacheCreation5mTokens() {
-	m.cache_creation_5m_tokens = nil
-	m.addcache_creation_5m_tokens = nil
+// ResetActualCost resets all changes to the "actual_cost" field.
+func (m *UsageLogMutation) ResetActualCost() {
+	m.actual_cost = nil
+	m.addactual_cost = nil
 }
 
-// SetCacheCreation1hTokens sets the "cache_creation_1h_tokens" field.
-func (m *UsageLogMutation) SetCacheCreation1hTokens(i int) {
-	m.cache_creation_1h_tokens = &i
-	m.addcache_creation_1h_tokens = nil
+// SetRateMultiplier sets the "rate_multiplier" field.
+func (m *UsageLogMutation) SetRateMultiplier(f float64) {
+	m.rate_multiplier = &f
+	m.addrate_multiplier = nil
 }
 
-// CacheCreation1hTokens returns the value of the "cache_creation_1h_tokens" field in the mutation.
-func (m *UsageLogMutation) CacheCreation1hTokens() (r int, exists bool) {
-	v := m.cache_creation_1h_tokens
+// RateMultiplier returns the value of the "rate_multiplier" field in the mutation.
+func (m *UsageLogMutation) RateMultiplier() (r float64, exists bool) {
+	v := m.rate_multiplier
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCacheCreation1hTokens returns the old "cache_creation_1h_tokens" field's value of the UsageLog entity.
+// OldRateMultiplier returns the old "rate_multiplier" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldCacheCreation1hTokens(ctx context.Context) (v int, err error) {
+func (m *UsageLogMutation) OldRateMultiplier(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCacheCreation1hTokens is only allowed on UpdateOne operations")
+		return v, errors.New("OldRateMultiplier is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCacheCreation1hTokens requires an ID field in the mutation")
+		return v, errors.New("OldRateMultiplier requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCacheCreation1hTokens: %w", err)
+		return v, fmt.Errorf("querying old value for OldRateMultiplier: %w", err)
 	}
-	return oldValue.CacheCreation1hTokens, nil
+	return oldValue.RateMultiplier, nil
 }
 
-// AddCacheCreation1hTokens adds i to the "cache_creation_1h_tokens" field.
-func (m *UsageLogMutation) AddCacheCreation1hTokens(i int) {
-	if m.addcache_creation_1h_tokens != nil {
-		*m.addcache_creation_1h_tokens += i
+// AddRateMultiplier adds f to the "rate_multiplier" field.
+func (m *UsageLogMutation) AddRateMultiplier(f float64) {
+	if m.addrate_multiplier != nil {
+		*m.addrate_multiplier += f
 	} else {
-		m.addcache_creation_1h_tokens = &i
+		m.addrate_multiplier = &f
 	}
 }
 
-// AddedCacheCreation1hTokens returns the value that was added to the "cache_creation_1h_tokens" field in this mutation.
-func (m *UsageLogMutation) AddedCacheCreation1hTokens() (r int, exists bool) {
-	v := m.addcache_creation_1h_tokens
+// AddedRateMultiplier returns the value that was added to the "rate_multiplier" field in this mutation.
+func (m *UsageLogMutation) AddedRateMultiplier() (r float64, exists bool) {
+	v := m.addrate_multiplier
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetCacheCreation1hTokens resets all changes to the "cache_creation_1h_tokens" field.
-func (m *UsageLogMutation) ResetCacheCreation1hTokens() {
-	m.cache_creation_1h_tokens = nil
-	m.addcache_creation_1h_tokens = nil
+// ResetRateMultiplier resets all changes to the "rate_multiplier" field.
+func (m *UsageLogMutation) ResetRateMultiplier() {
+	m.rate_multiplier = nil
+	m.addrate_multiplier = nil
 }
 
-// SetInputCost sets the "input_cost" field.
-func (m *UsageLogMutation) SetInputCost(f float64) {
-	m.input_cost = &f
-	m.addinput_cost = nil
+// SetAccountRateMultiplier sets the "account_rate_multiplier" field.
+func (m *UsageLogMutation) SetAccountRateMultiplier(f float64) {
+	m.account_rate_multiplier = &f
+	m.addaccount_rate_multiplier = nil
 }
 
-// InputCost returns the value of the "input_cost" field in the mutation.
-func (m *UsageLogMutation) InputCost() (r float64, exists bool) {
-	v := m.input_cost
+// AccountRateMultiplier returns the value of the "account_rate_multiplier" field in the mutation.
+func (m *UsageLogMutation) AccountRateMultiplier() (r float64, exists bool) {
+	v := m.account_rate_multiplier
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldInputCost returns the old "input_cost" field's value of the UsageLog entity.
+// OldAccountRateMultiplier returns the old "account_rate_multiplier" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldInputCost(ctx context.Context) (v float64, err error) {
+func (m *UsageLogMutation) OldAccountRateMultiplier(ctx context.Context) (v *float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldInputCost is only allowed on UpdateOne operations")
+		return v, errors.New("OldAccountRateMultiplier is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldInputCost requires an ID field in the mutation")
+		return v, errors.New("OldAccountRateMultiplier requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldInputCost: %w", err)
+		return v, fmt.Errorf("querying old value for OldAccountRateMultiplier: %w", err)
 	}
-	return oldValue.InputCost, nil
+	return oldValue.AccountRateMultiplier, nil
 }
 
-// AddInputCost adds f to the "input_cost" field.
-func (m *UsageLogMutation) AddInputCost(f float64) {
-	if m.addinput_cost != nil {
-		*m.addinput_cost += f
+// AddAccountRateMultiplier adds f to the "account_rate_multiplier" field.
+func (m *UsageLogMutation) AddAccountRateMultiplier(f float64) {
+	if m.addaccount_rate_multiplier != nil {
+		*m.addaccount_rate_multiplier += f
 	} else {
-		m.addinput_cost = &f
+		m.addaccount_rate_multiplier = &f
 	}
 }
 
-// AddedInputCost returns the value that was added to the "input_cost" field in this mutation.
-func (m *UsageLogMutation) AddedInputCost() (r float64, exists bool) {
-	v := m.addinput_cost
+// AddedAccountRateMultiplier returns the value that was added to the "account_rate_multiplier" field in this mutation.
+func (m *UsageLogMutation) AddedAccountRateMultiplier() (r float64, exists bool) {
+	v := m.addaccount_rate_multiplier
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetInputCost resets all changes to the "input_cost" field.
-func (m *UsageLogMutation) ResetInputCost() {
-	m.input_cost = nil
-	m.addinput_cost = nil
+// ClearAccountRateMultiplier clears the value of the "account_rate_multiplier" field.
+func (m *UsageLogMutation) ClearAccountRateMultiplier() {
+	m.account_rate_multiplier = nil
+	m.addaccount_rate_multiplier = nil
+	m.clearedFields[usagelog.FieldAccountRateMultiplier] = struct{}{}
 }
 
-// SetOutputCost sets the "output_cost" field.
-func (m *UsageLogMutation) SetOutputCost(f float64) {
-	m.output_cost = &f
-	m.addoutput_cost = nil
+// AccountRateMultiplierCleared returns if the "account_rate_multiplier" field was cleared in this mutation.
+func (m *UsageLogMutation) AccountRateMultiplierCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldAccountRateMultiplier]
+	return ok
 }
 
-// OutputCost returns the value of the "output_cost" field in the mutation.
-func (m *UsageLogMutation) OutputCost() (r float64, exists bool) {
-	v := m.output_cost
+// ResetAccountRateMultiplier resets all changes to the "account_rate_multiplier" field.
+func (m *UsageLogMutation) ResetAccountRateMultiplier() {
+	m.account_rate_multiplier = nil
+	m.addaccount_rate_multiplier = nil
+	delete(m.clearedFields, usagelog.FieldAccountRateMultiplier)
+}
+
+// SetBillingType sets the "billing_type" field.
+func (m *UsageLogMutation) SetBillingType(i int8) {
+	m.billing_type = &i
+	m.addbilling_type = nil
+}
+
+// BillingType returns the value of the "billing_type" field in the mutation.
+func (m *UsageLogMutation) BillingType() (r int8, exists bool) {
+	v := m.billing_type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldOutputCost returns the old "output_cost" field's value of the UsageLog entity.
+// OldBillingType returns the old "billing_type" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldOutputCost(ctx context.Context) (v float64, err error) {
+func (m *UsageLogMutation) OldBillingType(ctx context.Context) (v int8, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldOutputCost is only allowed on UpdateOne operations")
+		return v, errors.New("OldBillingType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldOutputCost requires an ID field in the mutation")
+		return v, errors.New("OldBillingType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldOutputCost: %w", err)
+		return v, fmt.Errorf("querying old value for OldBillingType: %w", err)
 	}
-	return oldValue.OutputCost, nil
+	return oldValue.BillingType, nil
 }
 
-// AddOutputCost adds f to the "output_cost" field.
-func (m *UsageLogMutation) AddOutputCost(f float64) {
-	if m.addoutput_cost != nil {
-		*m.addoutput_cost += f
+// AddBillingType adds i to the "billing_type" field.
+func (m *UsageLogMutation) AddBillingType(i int8) {
+	if m.addbilling_type != nil {
+		*m.addbilling_type += i
 	} else {
-		m.addoutput_cost = &f
+		m.addbilling_type = &i
 	}
 }
 
-// AddedOutputCost returns the value that was added to the "output_cost" field in this mutation.
-func (m *UsageLogMutation) AddedOutputCost() (r float64, exists bool) {
-	v := m.addoutput_cost
+// AddedBillingType returns the value that was added to the "billing_type" field in this mutation.
+func (m *UsageLogMutation) AddedBillingType() (r int8, exists bool) {
+	v := m.addbilling_type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetOutputCost resets all changes to the "output_cost" field.
-func (m *UsageLogMutation) ResetOutputCost() {
-	m.output_cost = nil
-	m.addoutput_cost = nil
+// ResetBillingType resets all changes to the "billing_type" field.
+func (m *UsageLogMutation) ResetBillingType() {
+	m.billing_type = nil
+	m.addbilling_type = nil
 }
 
-// SetCacheCreationCost sets the "cache_creation_cost" field.
-func (m *UsageLogMutation) SetCacheCreationCost(f float64) {
-	m.cache_creation_cost = &f
-	m.addcache_creation_cost = nil
+// SetStream sets the "stream" field.
+func (m *UsageLogMutation) SetStream(b bool) {
+	m.stream = &b
 }
 
-// CacheCreationCost returns the value of the "cache_creation_cost" field in the mutation.
-func (m *UsageLogMutation) CacheCreationCost() (r float64, exists bool) {
-	v := m.cache_creation_cost
+// Stream returns the value of the "stream" field in the mutation.
+func (m *UsageLogMutation) Stream() (r bool, exists bool) {
+	v := m.stream
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCacheCreationCost returns the old "cache_creation_cost" field's value of the UsageLog entity.
+// OldStream returns the old "stream" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldCacheCreationCost(ctx context.Context) (v float64, err error) {
+func (m *UsageLogMutation) OldStream(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCacheCreationCost is only allowed on UpdateOne operations")
+		return v, errors.New("OldStream is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCacheCreationCost requires an ID field in the mutation")
+		return v, errors.New("OldStream requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCacheCreationCost: %w", err)
-	}
-	return oldValue.CacheCreationCost, nil
-}
-
-// AddCacheCreationCost adds f to the "cache_creation_cost" field.
-func (m *UsageLogMutation) AddCacheCreationCost(f float64) {
-	if m.addcache_creation_cost != nil {
-		*m.addcache_creation_cost += f
-	} else {
-		m.addcache_creation_cost = &f
-	}
-}
-
-// AddedCacheCreationCost returns the value that was added to the "cache_creation_cost" field in this mutation.
-func (m *UsageLogMutation) AddedCacheCreationCost() (r float64, exists bool) {
-	v := m.addcache_creation_cost
-	if v == nil {
-		return
+		return v, fmt.Errorf("querying old value for OldStream: %w", err)
 	}
-	return *v, true
-}
-
-// ResetCacheCreationCost resets all changes to the "cache_creation_cost" field.
-func (m *UsageLogMutation) ResetCacheCreationCost() {
-	m.cache_creation_cost = nil
-	m.addcache_creation_cost = nil
+	return oldValue.Stream, nil
 }
 
-// SetCacheReadCost sets the "cache_read_cost" field.
-func (m *UsageLogMutation) SetCacheReadCost(f float64) {
-	m.cache_read_cost = &f
-	m.addcache_read_cost = nil
+// ResetStream resets all changes to the "stream" field.
+func (m *UsageLogMutation) ResetStream() {
+	m.stream = nil
 }
 
-// CacheReadCost returns the value of the "cache_read_cost" field in the mutation.
-func (m *UsageLogMutation) CacheReadCost() (r float64, exists bool) {
-	v := m.cache_read_cost
+// SetDurationMs sets the "duration_ms" field.
+func (m *UsageLogMutation) SetDurationMs(i int) {
+	m.duration_ms = &i
+	m.addduration_ms = nil
+}
+
+// DurationMs returns the value of the "duration_ms" field in the mutation.
+func (m *UsageLogMutation) DurationMs() (r int, exists bool) {
+	v := m.duration_ms
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCacheReadCost returns the old "cache_read_cost" field's value of the UsageLog entity.
+// OldDurationMs returns the old "duration_ms" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldCacheReadCost(ctx context.Context) (v float64, err error) {
+func (m *UsageLogMutation) OldDurationMs(ctx context.Context) (v *int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCacheReadCost is only allowed on UpdateOne operations")
+		return v, errors.New("OldDurationMs is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCacheReadCost requires an ID field in the mutation")
+		return v, errors.New("OldDurationMs requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCacheReadCost: %w", err)
+		return v, fmt.Errorf("querying old value for OldDurationMs: %w", err)
 	}
-	return oldValue.CacheReadCost, nil
+	return oldValue.DurationMs, nil
 }
 
-// AddCacheReadCost adds f to the "cache_read_cost" field.
-func (m *UsageLogMutation) AddCacheReadCost(f float64) {
-	if m.addcache_read_cost != nil {
-		*m.addcache_read_cost += f
+// AddDurationMs adds i to the "duration_ms" field.
+func (m *UsageLogMutation) AddDurationMs(i int) {
+	if m.addduration_ms != nil {
+		*m.addduration_ms += i
 	} else {
-		m.addcache_read_cost = &f
+		m.addduration_ms = &i
 	}
 }
 
-// AddedCacheReadCost returns the value that was added to the "cache_read_cost" field in this mutation.
-func (m *UsageLogMutation) AddedCacheReadCost() (r float64, exists bool) {
-	v := m.addcache_read_cost
+// AddedDurationMs returns the value that was added to the "duration_ms" field in this mutation.
+func (m *UsageLogMutation) AddedDurationMs() (r int, exists bool) {
+	v := m.addduration_ms
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetCacheReadCost resets all changes to the "cache_read_cost" field.
-func (m *UsageLogMutation) ResetCacheReadCost() {
-	m.cache_read_cost = nil
-	m.addcache_read_cost = nil
+// ClearDurationMs clears the value of the "duration_ms" field.
+func (m *UsageLogMutation) ClearDurationMs() {
+	m.duration_ms = nil
+	m.addduration_ms = nil
+	m.clearedFields[usagelog.FieldDurationMs] = struct{}{}
 }
 
-// SetTotalCost sets the "total_cost" field.
-func (m *UsageLogMutation) SetTotalCost(f float64) {
-	m.total_cost = &f
-	m.addtotal_cost = nil
+// DurationMsCleared returns if the "duration_ms" field was cleared in this mutation.
+func (m *UsageLogMutation) DurationMsCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldDurationMs]
+	return ok
 }
 
-// TotalCost returns the value of the "total_cost" field in the mutation.
-func (m *UsageLogMutation) TotalCost() (r float64, exists bool) {
-	v := m.total_cost
+// ResetDurationMs resets all changes to the "duration_ms" field.
+func (m *UsageLogMutation) ResetDurationMs() {
+	m.duration_ms = nil
+	m.addduration_ms = nil
+	delete(m.clearedFields, usagelog.FieldDurationMs)
+}
+
+// SetFirstTokenMs sets the "first_token_ms" field.
+func (m *UsageLogMutation) SetFirstTokenMs(i int) {
+	m.first_token_ms = &i
+	m.addfirst_token_ms = nil
+}
+
+// FirstTokenMs returns the value of the "first_token_ms" field in the mutation.
+func (m *UsageLogMutation) FirstTokenMs() (r int, exists bool) {
+	v := m.first_token_ms
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldTotalCost returns the old "total_cost" field's value of the UsageLog entity.
+// OldFirstTokenMs returns the old "first_token_ms" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldTotalCost(ctx context.Context) (v float64, err error) {
+func (m *UsageLogMutation) OldFirstTokenMs(ctx context.Context) (v *int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldTotalCost is only allowed on UpdateOne operations")
+		return v, errors.New("OldFirstTokenMs is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldTotalCost requires an ID field in the mutation")
+		return v, errors.New("OldFirstTokenMs requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldTotalCost: %w", err)
+		return v, fmt.Errorf("querying old value for OldFirstTokenMs: %w", err)
 	}
-	return oldValue.TotalCost, nil
+	return oldValue.FirstTokenMs, nil
 }
 
-// AddTotalCost adds f to the "total_cost" field.
-func (m *UsageLogMutation) AddTotalCost(f float64) {
-	if m.addtotal_cost != nil {
-		*m.addtotal_cost += f
+// AddFirstTokenMs adds i to the "first_token_ms" field.
+func (m *UsageLogMutation) AddFirstTokenMs(i int) {
+	if m.addfirst_token_ms != nil {
+		*m.addfirst_token_ms += i
 	} else {
-		m.addtotal_cost = &f
+		m.addfirst_token_ms = &i
 	}
 }
 
-// AddedTotalCost returns the value that was added to the "total_cost" field in this mutation.
-func (m *UsageLogMutation) AddedTotalCost() (r float64, exists bool) {
-	v := m.addtotal_cost
+// AddedFirstTokenMs returns the value that was added to the "first_token_ms" field in this mutation.
+func (m *UsageLogMutation) AddedFirstTokenMs() (r int, exists bool) {
+	v := m.addfirst_token_ms
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetTotalCost resets all changes to the "total_cost" field.
-func (m *UsageLogMutation) ResetTotalCost() {
-	m.total_cost = nil
-	m.addtotal_cost = nil
+// ClearFirstTokenMs clears the value of the "first_token_ms" field.
+func (m *UsageLogMutation) ClearFirstTokenMs() {
+	m.first_token_ms = nil
+	m.addfirst_token_ms = nil
+	m.clearedFields[usagelog.FieldFirstTokenMs] = struct{}{}
 }
 
-// SetActualCost sets the "actual_cost" field.
-func (m *UsageLogMutation) SetActualCost(f float64) {
-	m.actual_cost = &f
-	m.addactual_cost = nil
+// FirstTokenMsCleared returns if the "first_token_ms" field was cleared in this mutation.
+func (m *UsageLogMutation) FirstTokenMsCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldFirstTokenMs]
+	return ok
 }
 
-// ActualCost returns the value of the "actual_cost" field in the mutation.
-func (m *UsageLogMutation) ActualCost() (r float64, exists bool) {
-	v := m.actual_cost
+// ResetFirstTokenMs resets all changes to the "first_token_ms" field.
+func (m *UsageLogMutation) ResetFirstTokenMs() {
+	m.first_token_ms = nil
+	m.addfirst_token_ms = nil
+	delete(m.clearedFields, usagelog.FieldFirstTokenMs)
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (m *UsageLogMutation) SetUserAgent(s string) {
+	m.user_agent = &s
+}
+
+// UserAgent returns the value of the "user_agent" field in the mutation.
+func (m *UsageLogMutation) UserAgent() (r string, exists bool) {
+	v := m.user_agent
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldActualCost returns the old "actual_cost" field's value of the UsageLog entity.
+// OldUserAgent returns the old "user_agent" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldActualCost(ctx context.Context) (v float64, err error) {
+func (m *UsageLogMutation) OldUserAgent(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldActualCost is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserAgent is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldActualCost requires an ID field in the mutation")
+		return v, errors.New("OldUserAgent requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldActualCost: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserAgent: %w", err)
 	}
-	return oldValue.ActualCost, nil
+	return oldValue.UserAgent, nil
 }
 
-// AddActualCost adds f to the "actual_cost" field.
-func (m *UsageLogMutation) AddActualCost(f float64) {
-	if m.addactual_cost != nil {
-		*m.addactual_cost += f
-	} else {
-		m.addactual_cost = &f
-	}
+// ClearUserAgent clears the value of the "user_agent" field.
+func (m *UsageLogMutation) ClearUserAgent() {
+	m.user_agent = nil
+	m.clearedFields[usagelog.FieldUserAgent] = struct{}{}
 }
 
-// AddedActualCost returns the value that was added to the "actual_cost" field in this mutation.
-func (m *UsageLogMutation) AddedActualCost() (r float64, exists bool) {
-	v := m.addactual_cost
-	if v == nil {
-		return
-	}
-	return *v, true
+// UserAgentCleared returns if the "user_agent" field was cleared in this mutation.
+func (m *UsageLogMutation) UserAgentCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldUserAgent]
+	return ok
 }
 
-// ResetActualCost resets all changes to the "actual_cost" field.
-func (m *UsageLogMutation) ResetActualCost() {
-	m.actual_cost = nil
-	m.addactual_cost = nil
+// ResetUserAgent resets all changes to the "user_agent" field.
+func (m *UsageLogMutation) ResetUserAgent() {
+	m.user_agent = nil
+	delete(m.clearedFields, usagelog.FieldUserAgent)
 }
 
-// SetRateMultiplier sets the "rate_multiplier" field.
-func (m *UsageLogMutation) SetRateMultiplier(f float64) {
-	m.rate_multiplier = &f
-	m.addrate_multiplier = nil
+// SetIPAddress sets the "ip_address" field.
+func (m *UsageLogMutation) SetIPAddress(s string) {
+	m.ip_address = &s
 }
 
-// RateMultiplier returns the value of the "rate_multiplier" field in the mutation.
-func (m *UsageLogMutation) RateMultiplier() (r float64, exists bool) {
-	v := m.rate_multiplier
+// IPAddress returns the value of the "ip_address" field in the mutation.
+func (m *UsageLogMutation) IPAddress() (r string, exists bool) {
+	v := m.ip_address
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRateMultiplier returns the old "rate_multiplier" field's value of the UsageLog entity.
+// OldIPAddress returns the old "ip_address" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldRateMultiplier(ctx context.Context) (v float64, err error) {
+func (m *UsageLogMutation) OldIPAddress(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRateMultiplier is only allowed on UpdateOne operations")
+		return v, errors.New("OldIPAddress is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRateMultiplier requires an ID field in the mutation")
+		return v, errors.New("OldIPAddress requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRateMultiplier: %w", err)
+		return v, fmt.Errorf("querying old value for OldIPAddress: %w", err)
 	}
-	return oldValue.RateMultiplier, nil
+	return oldValue.IPAddress, nil
 }
 
-// AddRateMultiplier adds f to the "rate_multiplier" field.
-func (m *UsageLogMutation) AddRateMultiplier(f float64) {
-	if m.addrate_multiplier != nil {
-		*m.addrate_multiplier += f
-	} else {
-		m.addrate_multiplier = &f
-	}
+// ClearIPAddress clears the value of the "ip_address" field.
+func (m *UsageLogMutation) ClearIPAddress() {
+	m.ip_address = nil
+	m.clearedFields[usagelog.FieldIPAddress] = struct{}{}
 }
 
-// AddedRateMultiplier returns the value that was added to the "rate_multiplier" field in this mutation.
-func (m *UsageLogMutation) AddedRateMultiplier() (r float64, exists bool) {
-	v := m.addrate_multiplier
-	if v == nil {
-		return
-	}
-	return *v, true
+// IPAddressCleared returns if the "ip_address" field was cleared in this mutation.
+func (m *UsageLogMutation) IPAddressCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldIPAddress]
+	return ok
 }
 
-// ResetRateMultiplier resets all changes to the "rate_multiplier" field.
-func (m *UsageLogMutation) ResetRateMultiplier() {
-	m.rate_multiplier = nil
-	m.addrate_multiplier = nil
+// ResetIPAddress resets all changes to the "ip_address" field.
+func (m *UsageLogMutation) ResetIPAddress() {
+	m.ip_address = nil
+	delete(m.clearedFields, usagelog.FieldIPAddress)
 }
 
-// SetAccountRateMultiplier sets the "account_rate_multiplier" field.
-func (m *UsageLogMutation) SetAccountRateMultiplier(f float64) {
-	m.account_rate_multiplier = &f
-	m.addaccount_rate_multiplier = nil
+// SetImageCount sets the "image_count" field.
+func (m *UsageLogMutation) SetImageCount(i int) {
+	m.image_count = &i
+	m.addimage_count = nil
 }
 
-// AccountRateMultiplier returns the value of the "account_rate_multiplier" field in the mutation.
-func (m *UsageLogMutation) AccountRateMultiplier() (r float64, exists bool) {
-	v := m.account_rate_multiplier
+// ImageCount returns the value of the "image_count" field in the mutation.
+func (m *UsageLogMutation) ImageCount() (r int, exists bool) {
+	v := m.image_count
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAccountRateMultiplier returns the old "account_rate_multiplier" field's value of the UsageLog entity.
+// OldImageCount returns the old "image_count" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldAccountRateMultiplier(ctx context.Context) (v *float64, err error) {
+func (m *UsageLogMutation) OldImageCount(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAccountRateMultiplier is only allowed on UpdateOne operations")
+		return v, errors.New("OldImageCount is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAccountRateMultiplier requires an ID field in the mutation")
+		return v, errors.New("OldImageCount requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAccountRateMultiplier: %w", err)
+		return v, fmt.Errorf("querying old value for OldImageCount: %w", err)
 	}
-	return oldValue.AccountRateMultiplier, nil
+	return oldValue.ImageCount, nil
 }
 
-// AddAccountRateMultiplier adds f to the "account_rate_multiplier" field.
-func (m *UsageLogMutation) AddAccountRateMultiplier(f float64) {
-	if m.addaccount_rate_multiplier != nil {
-		*m.addaccount_rate_multiplier += f
+// AddImageCount adds i to the "image_count" field.
+func (m *UsageLogMutation) AddImageCount(i int) {
+	if m.addimage_count != nil {
+		*m.addimage_count += i
 	} else {
-		m.addaccount_rate_multiplier = &f
+		m.addimage_count = &i
 	}
 }
 
-// AddedAccountRateMultiplier returns the value that was added to the "account_rate_multiplier" field in this mutation.
-func (m *UsageLogMutation) AddedAccountRateMultiplier() (r float64, exists bool) {
-	v := m.addaccount_rate_multiplier
+// AddedImageCount returns the value that was added to the "image_count" field in this mutation.
+func (m *UsageLogMutation) AddedImageCount() (r int, exists bool) {
+	v := m.addimage_count
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ClearAccountRateMultiplier clears the value of the "account_rate_multiplier" field.
-func (m *UsageLogMutation) ClearAccountRateMultiplier() {
-	m.account_rate_multiplier = nil
-	m.addaccount_rate_multiplier = nil
-	m.clearedFields[usagelog.FieldAccountRateMultiplier] = struct{}{}
-}
-
-// AccountRateMultiplierCleared returns if the "account_rate_multiplier" field was cleared in this mutation.
-func (m *UsageLogMutation) AccountRateMultiplierCleared() bool {
-	_, ok := m.clearedFields[usagelog.FieldAccountRateMultiplier]
-	return ok
-}
-
-// ResetAccountRateMultiplier resets all changes to the "account_rate_multiplier" field.
-func (m *UsageLogMutation) ResetAccountRateMultiplier() {
-	m.account_rate_multiplier = nil
-	m.addaccount_rate_multiplier = nil
-	delete(m.clearedFields, usagelog.FieldAccountRateMultiplier)
+// ResetImageCount resets all changes to the "image_count" field.
+func (m *UsageLogMutation) ResetImageCount() {
+	m.image_count = nil
+	m.addimage_count = nil
 }
 
-// SetBillingType sets the "billing_type" field.
-func (m *UsageLogMutation) SetBillingType(i int8) {
-	m.billing_type = &i
-	m.addbilling_type = nil
+// SetImageSize sets the "image_size" field.
+func (m *UsageLogMutation) SetImageSize(s string) {
+	m.image_size = &s
 }
 
-// BillingType returns the value of the "billing_type" field in the mutation.
-func (m *UsageLogMutation) BillingType() (r int8, exists bool) {
-	v := m.billing_type
+// ImageSize returns the value of the "image_size" field in the mutation.
+func (m *UsageLogMutation) ImageSize() (r string, exists bool) {
+	v := m.image_size
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldBillingType returns the old "billing_type" field's value of the UsageLog entity.
+// OldImageSize returns the old "image_size" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldBillingType(ctx context.Context) (v int8, err error) {
+func (m *UsageLogMutation) OldImageSize(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldBillingType is only allowed on UpdateOne operations")
+		return v, errors.New("OldImageSize is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldBillingType requires an ID field in the mutation")
+		return v, errors.New("OldImageSize requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldBillingType: %w", err)
+		return v, fmt.Errorf("querying old value for OldImageSize: %w", err)
 	}
-	return oldValue.BillingType, nil
+	return oldValue.ImageSize, nil
 }
 
-// AddBillingType adds i to the "billing_type" field.
-func (m *UsageLogMutation) AddBillingType(i int8) {
-	if m.addbilling_type != nil {
-		*m.addbilling_type += i
-	} else {
-		m.addbilling_type = &i
-	}
+// ClearImageSize clears the value of the "image_size" field.
+func (m *UsageLogMutation) ClearImageSize() {
+	m.image_size = nil
+	m.clearedFields[usagelog.FieldImageSize] = struct{}{}
 }
 
-// AddedBillingType returns the value that was added to the "billing_type" field in this mutation.
-func (m *UsageLogMutation) AddedBillingType() (r int8, exists bool) {
-	v := m.addbilling_type
-	if v == nil {
-		return
-	}
-	return *v, true
+// ImageSizeCleared returns if the "image_size" field was cleared in this mutation.
+func (m *UsageLogMutation) ImageSizeCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldImageSize]
+	return ok
 }
 
-// ResetBillingType resets all changes to the "billing_type" field.
-func (m *UsageLogMutation) ResetBillingType() {
-	m.billing_type = nil
-	m.addbilling_type = nil
+// ResetImageSize resets all changes to the "image_size" field.
+func (m *UsageLogMutation) ResetImageSize() {
+	m.image_size = nil
+	delete(m.clearedFields, usagelog.FieldImageSize)
 }
 
-// SetStream sets the "stream" field.
-func (m *UsageLogMutation) SetStream(b bool) {
-	m.stream = &b
+// SetCacheServed sets the "cache_served" field.
+func (m *UsageLogMutation) SetCacheServed(b bool) {
+	m.cache_served = &b
 }
 
-// Stream returns the value of the "stream" field in the mutation.
-func (m *UsageLogMutation) Stream() (r bool, exists bool) {
-	v := m.stream
+// CacheServed returns the value of the "cache_served" field in the mutation.
+func (m *UsageLogMutation) CacheServed() (r bool, exists bool) {
+	v := m.cache_served
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldStream returns the old "stream" field's value of the UsageLog entity.
+// OldCacheServed returns the old "cache_served" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldStream(ctx context.Context) (v bool, err error) {
+func (m *UsageLogMutation) OldCacheServed(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldStream is only allowed on UpdateOne operations")
+		return v, errors.New("OldCacheServed is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldStream requires an ID field in the mutation")
+		return v, errors.New("OldCacheServed requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldStream: %w", err)
+		return v, fmt.Errorf("querying old value for OldCacheServed: %w", err)
 	}
-	return oldValue.Stream, nil
+	return oldValue.CacheServed, nil
 }
 
-// ResetStream resets all changes to the "stream" field.
-func (m *UsageLogMutation) ResetStream() {
-	m.stream = nil
+// ResetCacheServed resets all changes to the "cache_served" field.
+func (m *UsageLogMutation) ResetCacheServed() {
+	m.cache_served = nil
 }
 
-// SetDurationMs sets the "duration_ms" field.
-func (m *UsageLogMutation) SetDurationMs(i int) {
-	m.duration_ms = &i
-	m.addduration_ms = nil
+// SetCacheSavedCost sets the "cache_saved_cost" field.
+func (m *UsageLogMutation) SetCacheSavedCost(f float64) {
+	m.cache_saved_cost = &f
+	m.addcache_saved_cost = nil
 }
 
-// DurationMs returns the value of the "duration_ms" field in the mutation.
-func (m *UsageLogMutation) DurationMs() (r int, exists bool) {
-	v := m.duration_ms
+// CacheSavedCost returns the value of the "cache_saved_cost" field in the mutation.
+func (m *UsageLogMutation) CacheSavedCost() (r float64, exists bool) {
+	v := m.cache_saved_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDurationMs returns the old "duration_ms" field's value of the UsageLog entity.
+// OldCacheSavedCost returns the old "cache_saved_cost" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldDurationMs(ctx context.Context) (v *int, err error) {
+func (m *UsageLogMutation) OldCacheSavedCost(ctx context.Context) (v float64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDurationMs is only allowed on UpdateOne operations")
+		return v, errors.New("OldCacheSavedCost is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDurationMs requires an ID field in the mutation")
+		return v, errors.New("OldCacheSavedCost requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDurationMs: %w", err)
+		return v, fmt.Errorf("querying old value for OldCacheSavedCost: %w", err)
 	}
-	return oldValue.DurationMs, nil
+	return oldValue.CacheSavedCost, nil
 }
 
-// AddDurationMs adds i to the "duration_ms" field.
-func (m *UsageLogMutation) AddDurationMs(i int) {
-	if m.addduration_ms != nil {
-		*m.addduration_ms += i
+// AddCacheSavedCost adds f to the "cache_saved_cost" field.
+func (m *UsageLogMutation) AddCacheSavedCost(f float64) {
+	if m.addcache_saved_cost != nil {
+		*m.addcache_saved_cost += f
 	} else {
-		m.addduration_ms = &i
+		m.addcache_saved_cost = &f
 	}
 }
 
-// AddedDurationMs returns the value that was added to the "duration_ms" field in this mutation.
-func (m *UsageLogMutation) AddedDurationMs() (r int, exists bool) {
-	v := m.addduration_ms
+// AddedCacheSavedCost returns the value that was added to the "cache_saved_cost" field in this mutation.
+func (m *UsageLogMutation) AddedCacheSavedCost() (r float64, exists bool) {
+	v := m.addcache_saved_cost
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ClearDurationMs clears the value of the "duration_ms" field.
-func (m *UsageLogMutation) ClearDurationMs() {
-	m.duration_ms = nil
-	m.addduration_ms = nil
-	m.clearedFields[usagelog.FieldDurationMs] = struct{}{}
-}
-
-// DurationMsCleared returns if the "duration_ms" field was cleared in this mutation.
-func (m *UsageLogMutation) DurationMsCleared() bool {
-	_, ok := m.clearedFields[usagelog.FieldDurationMs]
-	return ok
-}
-
-// ResetDurationMs resets all changes to the "duration_ms" field.
-func (m *UsageLogMutation) ResetDurationMs() {
-	m.duration_ms = nil
-	m.addduration_ms = nil
-	delete(m.clearedFields, usagelog.FieldDurationMs)
+// ResetCacheSavedCost resets all changes to the "cache_saved_cost" field.
+func (m *UsageLogMutation) ResetCacheSavedCost() {
+	m.cache_saved_cost = nil
+	m.addcache_saved_cost = nil
 }
 
-// SetFirstTokenMs sets the "first_token_ms" field.
-func (m *UsageLogMutation) SetFirstTokenMs(i int) {
-	m.first_token_ms = &i
-	m.addfirst_token_ms = nil
+// SetHedged sets the "hedged" field.
+func (m *UsageLogMutation) SetHedged(b bool) {
+	m.hedged = &b
 }
 
-// FirstTokenMs returns the value of the "first_token_ms" field in the mutation.
-func (m *UsageLogMutation) FirstTokenMs() (r int, exists bool) {
-	v := m.first_token_ms
+// Hedged returns the value of the "hedged" field in the mutation.
+func (m *UsageLogMutation) Hedged() (r bool, exists bool) {
+	v := m.hedged
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldFirstTokenMs returns the old "first_token_ms" field's value of the UsageLog entity.
+// OldHedged returns the old "hedged" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldFirstTokenMs(ctx context.Context) (v *int, err error) {
+func (m *UsageLogMutation) OldHedged(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFirstTokenMs is only allowed on UpdateOne operations")
+		return v, errors.New("OldHedged is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFirstTokenMs requires an ID field in the mutation")
+		return v, errors.New("OldHedged requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFirstTokenMs: %w", err)
+		return v, fmt.Errorf("querying old value for OldHedged: %w", err)
 	}
-	return oldValue.FirstTokenMs, nil
+	return oldValue.Hedged, nil
 }
 
-// AddFirstTokenMs adds i to the "first_token_ms" field.
-func (m *UsageLogMutation) AddFirstTokenMs(i int) {
-	if m.addfirst_token_ms != nil {
-		*m.addfirst_token_ms += i
-	} else {
-		m.addfirst_token_ms = &i
-	}
+// ResetHedged resets all changes to the "hedged" field.
+func (m *UsageLogMutation) ResetHedged() {
+	m.hedged = nil
 }
 
-// AddedFirstTokenMs returns the value that was added to the "first_token_ms" field in this mutation.
-func (m *UsageLogMutation) AddedFirstTokenMs() (r int, exists bool) {
-	v := m.addfirst_token_ms
+// SetHedgeBackupWon sets the "hedge_backup_won" field.
+func (m *UsageLogMutation) SetHedgeBackupWon(b bool) {
+	m.hedge_backup_won = &b
+}
+
+// HedgeBackupWon returns the value of the "hedge_backup_won" field in the mutation.
+func (m *UsageLogMutation) HedgeBackupWon() (r bool, exists bool) {
+	v := m.hedge_backup_won
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ClearFirstTokenMs clears the value of the "first_token_ms" field.
-func (m *UsageLogMutation) ClearFirstTokenMs() {
-	m.first_token_ms = nil
-	m.addfirst_token_ms = nil
-	m.clearedFields[usagelog.FieldFirstTokenMs] = struct{}{}
-}
-
-// FirstTokenMsCleared returns if the "first_token_ms" field was cleared in this mutation.
-func (m *UsageLogMutation) FirstTokenMsCleared() bool {
-	_, ok := m.clearedFields[usagelog.FieldFirstTokenMs]
-	return ok
+// OldHedgeBackupWon returns the old "hedge_backup_won" field's value of the UsageLog entity.
+// If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UsageLogMutation) OldHedgeBackupWon(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHedgeBackupWon is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHedgeBackupWon requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHedgeBackupWon: %w", err)
+	}
+	return oldValue.HedgeBackupWon, nil
 }
 
-// ResetFirstTokenMs resets all changes to the "first_token_ms" field.
-func (m *UsageLogMutation) ResetFirstTokenMs() {
-	m.first_token_ms = nil
-	m.addfirst_token_ms = nil
-	delete(m.clearedFields, usagelog.FieldFirstTokenMs)
+// ResetHedgeBackupWon resets all changes to the "hedge_backup_won" field.
+func (m *UsageLogMutation) ResetHedgeBackupWon() {
+	m.hedge_backup_won = nil
 }
 
-// SetUserAgent sets the "user_agent" field.
-func (m *UsageLogMutation) SetUserAgent(s string) {
-	m.user_agent = &s
+// SetClientDisconnected sets the "client_disconnected" field.
+func (m *UsageLogMutation) SetClientDisconnected(b bool) {
+	m.client_disconnected = &b
 }
 
-// UserAgent returns the value of the "user_agent" field in the mutation.
-func (m *UsageLogMutation) UserAgent() (r string, exists bool) {
-	v := m.user_agent
+// ClientDisconnected returns the value of the "client_disconnected" field in the mutation.
+func (m *UsageLogMutation) ClientDisconnected() (r bool, exists bool) {
+	v := m.client_disconnected
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserAgent returns the old "user_agent" field's value of the UsageLog entity.
+// OldClientDisconnected returns the old "client_disconnected" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldUserAgent(ctx context.Context) (v *string, err error) {
+func (m *UsageLogMutation) OldClientDisconnected(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserAgent is only allowed on UpdateOne operations")
+		return v, errors.New("OldClientDisconnected is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserAgent requires an ID field in the mutation")
+		return v, errors.New("OldClientDisconnected requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserAgent: %w", err)
+		return v, fmt.Errorf("querying old value for OldClientDisconnected: %w", err)
 	}
-	return oldValue.UserAgent, nil
+	return oldValue.ClientDisconnected, nil
 }
 
-// ClearUserAgent clears the value of the "user_agent" field.
-func (m *UsageLogMutation) ClearUserAgent() {
-	m.user_agent = nil
-	m.clearedFields[usagelog.FieldUserAgent] = struct{}{}
+// ResetClientDisconnected resets all changes to the "client_disconnected" field.
+func (m *UsageLogMutation) ResetClientDisconnected() {
+	m.client_disconnected = nil
 }
 
-// UserAgentCleared returns if the "user_agent" field was cleared in this mutation.
-func (m *UsageLogMutation) UserAgentCleared() bool {
-	_, ok := m.clearedFields[usagelog.FieldUserAgent]
-	return ok
+// SetPricingTier sets the "pricing_tier" field.
+func (m *UsageLogMutation) SetPricingTier(s string) {
+	m.pricing_tier = &s
 }
 
-// ResetUserAgent resets all changes to the "user_agent" field.
-func (m *UsageLogMutation) ResetUserAgent() {
-	m.user_agent = nil
-	delete(m.clearedFields, usagelog.FieldUserAgent)
+// PricingTier returns the value of the "pricing_tier" field in the mutation.
+func (m *UsageLogMutation) PricingTier() (r string, exists bool) {
+	v := m.pricing_tier
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// SetIPAddress sets the "ip_address" field.
-func (m *UsageLogMutation) SetIPAddress(s string) {
-	m.ip_address = &s
+// OldPricingTier returns the old "pricing_tier" field's value of the UsageLog entity.
+// If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UsageLogMutation) OldPricingTier(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPricingTier is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPricingTier requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPricingTier: %w", err)
+	}
+	return oldValue.PricingTier, nil
 }
 
-// IPAddress returns the value of the "ip_address" field in the mutation.
-func (m *UsageLogMutation) IPAddress() (r string, exists bool) {
-	v := m.ip_address
+// ResetPricingTier resets all changes to the "pricing_tier" field.
+func (m *UsageLogMutation) ResetPricingTier() {
+	m.pricing_tier = nil
+}
+
+// SetServiceTier sets the "service_tier" field.
+func (m *UsageLogMutation) SetServiceTier(s string) {
+	m.service_tier = &s
+}
+
+// ServiceTier returns the value of the "service_tier" field in the mutation.
+func (m *UsageLogMutation) ServiceTier() (r string, exists bool) {
+	v := m.service_tier
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldIPAddress returns the old "ip_address" field's value of the UsageLog entity.
+// OldServiceTier returns the old "service_tier" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldIPAddress(ctx context.Context) (v *string, err error) {
+func (m *UsageLogMutation) OldServiceTier(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldIPAddress is only allowed on UpdateOne operations")
+		return v, errors.New("OldServiceTier is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldIPAddress requires an ID field in the mutation")
+		return v, errors.New("OldServiceTier requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldIPAddress: %w", err)
+		return v, fmt.Errorf("querying old value for OldServiceTier: %w", err)
 	}
-	return oldValue.IPAddress, nil
-}
-
-// ClearIPAddress clears the value of the "ip_address" field.
-func (m *UsageLogMutation) ClearIPAddress() {
-	m.ip_address = nil
-	m.clearedFields[usagelog.FieldIPAddress] = struct{}{}
-}
-
-// IPAddressCleared returns if the "ip_address" field was cleared in this mutation.
-func (m *UsageLogMutation) IPAddressCleared() bool {
-	_, ok := m.clearedFields[usagelog.FieldIPAddress]
-	return ok
+	return oldValue.ServiceTier, nil
 }
 
-// ResetIPAddress resets all changes to the "ip_address" field.
-func (m *UsageLogMutation) ResetIPAddress() {
-	m.ip_address = nil
-	delete(m.clearedFields, usagelog.FieldIPAddress)
+// ResetServiceTier resets all changes to the "service_tier" field.
+func (m *UsageLogMutation) ResetServiceTier() {
+	m.service_tier = nil
 }
 
-// SetImageCount sets the "image_count" field.
-func (m *UsageLogMutation) SetImageCount(i int) {
-	m.image_count = &i
-	m.addimage_count = nil
+// SetCostDetail sets the "cost_detail" field.
+func (m *UsageLogMutation) SetCostDetail(value map[string]interface{}) {
+	m.cost_detail = &value
 }
 
-// ImageCount returns the value of the "image_count" field in the mutation.
-func (m *UsageLogMutation) ImageCount() (r int, exists bool) {
-	v := m.image_count
+// CostDetail returns the value of the "cost_detail" field in the mutation.
+func (m *UsageLogMutation) CostDetail() (r map[string]interface{}, exists bool) {
+	v := m.cost_detail
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldImageCount returns the old "image_count" field's value of the UsageLog entity.
+// OldCostDetail returns the old "cost_detail" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldImageCount(ctx context.Context) (v int, err error) {
+func (m *UsageLogMutation) OldCostDetail(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldImageCount is only allowed on UpdateOne operations")
+		return v, errors.New("OldCostDetail is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldImageCount requires an ID field in the mutation")
+		return v, errors.New("OldCostDetail requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldImageCount: %w", err)
+		return v, fmt.Errorf("querying old value for OldCostDetail: %w", err)
 	}
-	return oldValue.ImageCount, nil
+	return oldValue.CostDetail, nil
 }
 
-// AddImageCount adds i to the "image_count" field.
-func (m *UsageLogMutation) AddImageCount(i int) {
-	if m.addimage_count != nil {
-		*m.addimage_count += i
-	} else {
-		m.addimage_count = &i
-	}
+// ClearCostDetail clears the value of the "cost_detail" field.
+func (m *UsageLogMutation) ClearCostDetail() {
+	m.cost_detail = nil
+	m.clearedFields[usagelog.FieldCostDetail] = struct{}{}
 }
 
-// AddedImageCount returns the value that was added to the "image_count" field in this mutation.
-func (m *UsageLogMutation) AddedImageCount() (r int, exists bool) {
-	v := m.addimage_count
-	if v == nil {
-		return
-	}
-	return *v, true
+// CostDetailCleared returns if the "cost_detail" field was cleared in this mutation.
+func (m *UsageLogMutation) CostDetailCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldCostDetail]
+	return ok
 }
 
-// ResetImageCount resets all changes to the "image_count" field.
-func (m *UsageLogMutation) ResetImageCount() {
-	m.image_count = nil
-	m.addimage_count = nil
+// ResetCostDetail resets all changes to the "cost_detail" field.
+func (m *UsageLogMutation) ResetCostDetail() {
+	m.cost_detail = nil
+	delete(m.clearedFields, usagelog.FieldCostDetail)
 }
 
-// SetImageSize sets the "image_size" field.
-func (m *UsageLogMutation) SetImageSize(s string) {
-	m.image_size = &s
+// SetOrganizationID sets the "organization_id" field.
+func (m *UsageLogMutation) SetOrganizationID(i int64) {
+	m.organization_id = &i
+	m.addorganization_id = nil
 }
 
-// ImageSize returns the value of the "image_size" field in the mutation.
-func (m *UsageLogMutation) ImageSize() (r string, exists bool) {
-	v := m.image_size
+// OrganizationID returns the value of the "organization_id" field in the mutation.
+func (m *UsageLogMutation) OrganizationID() (r int64, exists bool) {
+	v := m.organization_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldImageSize returns the old "image_size" field's value of the UsageLog entity.
+// OldOrganizationID returns the old "organization_id" field's value of the UsageLog entity.
 // If the UsageLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UsageLogMutation) OldImageSize(ctx context.Context) (v *string, err error) {
+func (m *UsageLogMutation) OldOrganizationID(ctx context.Context) (v *int64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldImageSize is only allowed on UpdateOne operations")
+		return v, errors.New("OldOrganizationID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldImageSize requires an ID field in the mutation")
+		return v, errors.New("OldOrganizationID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldImageSize: %w", err)
+		return v, fmt.Errorf("querying old value for OldOrganizationID: %w", err)
 	}
-	return oldValue.ImageSize, nil
+	return oldValue.OrganizationID, nil
 }
 
-// ClearImageSize clears the value of the "image_size" field.
-func (m *UsageLogMutation) ClearImageSize() {
-	m.image_size = nil
-	m.clearedFields[usagelog.FieldImageSize] = struct{}{}
+// AddOrganizationID adds i to the "organization_id" field.
+func (m *UsageLogMutation) AddOrganizationID(i int64) {
+	if m.addorganization_id != nil {
+		*m.addorganization_id += i
+	} else {
+		m.addorganization_id = &i
+	}
 }
 
-// ImageSizeCleared returns if the "image_size" field was cleared in this mutation.
-func (m *UsageLogMutation) ImageSizeCleared() bool {
-	_, ok := m.clearedFields[usagelog.FieldImageSize]
+// AddedOrganizationID returns the value that was added to the "organization_id" field in this mutation.
+func (m *UsageLogMutation) AddedOrganizationID() (r int64, exists bool) {
+	v := m.addorganization_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (m *UsageLogMutation) ClearOrganizationID() {
+	m.organization_id = nil
+	m.addorganization_id = nil
+	m.clearedFields[usagelog.FieldOrganizationID] = struct{}{}
+}
+
+// OrganizationIDCleared returns if the "organization_id" field was cleared in this mutation.
+func (m *UsageLogMutation) OrganizationIDCleared() bool {
+	_, ok := m.clearedFields[usagelog.FieldOrganizationID]
 	return ok
 }
 
-// ResetImageSize resets all changes to the "image_size" field.
-func (m *UsageLogMutation) ResetImageSize() {
-	m.image_size = nil
-	delete(m.clearedFields, usagelog.FieldImageSize)
+// ResetOrganizationID resets all changes to the "organization_id" field.
+func (m *UsageLogMutation) ResetOrganizationID() {
+	m.organization_id = nil
+	m.addorganization_id = nil
+	delete(m.clearedFields, usagelog.FieldOrganizationID)
 }
 
 // SetCreatedAt sets the "created_at" field.
@@ -13335,7 +15465,7 @@ func (m *UsageLogMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *UsageLogMutation) Fields() []string {
-	fields := make([]string, 0, 30)
+	fields := make([]string, 0, 39)
 	if m.user != nil {
 		fields = append(fields, usagelog.FieldUserID)
 	}
@@ -13423,6 +15553,33 @@ func (m *UsageLogMutation) Fields() []string {
 	if m.image_size != nil {
 		fields = append(fields, usagelog.FieldImageSize)
 	}
+	if m.cache_served != nil {
+		fields = append(fields, usagelog.FieldCacheServed)
+	}
+	if m.cache_saved_cost != nil {
+		fields = append(fields, usagelog.FieldCacheSavedCost)
+	}
+	if m.hedged != nil {
+		fields = append(fields, usagelog.FieldHedged)
+	}
+	if m.hedge_backup_won != nil {
+		fields = append(fields, usagelog.FieldHedgeBackupWon)
+	}
+	if m.client_disconnected != nil {
+		fields = append(fields, usagelog.FieldClientDisconnected)
+	}
+	if m.pricing_tier != nil {
+		fields = append(fields, usagelog.FieldPricingTier)
+	}
+	if m.service_tier != nil {
+		fields = append(fields, usagelog.FieldServiceTier)
+	}
+	if m.cost_detail != nil {
+		fields = append(fields, usagelog.FieldCostDetail)
+	}
+	if m.organization_id != nil {
+		fields = append(fields, usagelog.FieldOrganizationID)
+	}
 	if m.created_at != nil {
 		fields = append(fields, usagelog.FieldCreatedAt)
 	}
@@ -13492,6 +15649,24 @@ func (m *UsageLogMutation) Field(name string) (ent.Value, bool) {
 		return m.ImageCount()
 	case usagelog.FieldImageSize:
 		return m.ImageSize()
+	case usagelog.FieldCacheServed:
+		return m.CacheServed()
+	case usagelog.FieldCacheSavedCost:
+		return m.CacheSavedCost()
+	case usagelog.FieldHedged:
+		return m.Hedged()
+	case usagelog.FieldHedgeBackupWon:
+		return m.HedgeBackupWon()
+	case usagelog.FieldClientDisconnected:
+		return m.ClientDisconnected()
+	case usagelog.FieldPricingTier:
+		return m.PricingTier()
+	case usagelog.FieldServiceTier:
+		return m.ServiceTier()
+	case usagelog.FieldCostDetail:
+		return m.CostDetail()
+	case usagelog.FieldOrganizationID:
+		return m.OrganizationID()
 	case usagelog.FieldCreatedAt:
 		return m.CreatedAt()
 	}
@@ -13561,6 +15736,24 @@ func (m *UsageLogMutation) OldField(ctx context.Context, name string) (ent.Value
 		return m.OldImageCount(ctx)
 	case usagelog.FieldImageSize:
 		return m.OldImageSize(ctx)
+	case usagelog.FieldCacheServed:
+		return m.OldCacheServed(ctx)
+	case usagelog.FieldCacheSavedCost:
+		return m.OldCacheSavedCost(ctx)
+	case usagelog.FieldHedged:
+		return m.OldHedged(ctx)
+	case usagelog.FieldHedgeBackupWon:
+		return m.OldHedgeBackupWon(ctx)
+	case usagelog.FieldClientDisconnected:
+		return m.OldClientDisconnected(ctx)
+	case usagelog.FieldPricingTier:
+		return m.OldPricingTier(ctx)
+	case usagelog.FieldServiceTier:
+		return m.OldServiceTier(ctx)
+	case usagelog.FieldCostDetail:
+		return m.OldCostDetail(ctx)
+	case usagelog.FieldOrganizationID:
+		return m.OldOrganizationID(ctx)
 	case usagelog.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	}
@@ -13775,6 +15968,69 @@ func (m *UsageLogMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetImageSize(v)
 		return nil
+	case usagelog.FieldCacheServed:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCacheServed(v)
+		return nil
+	case usagelog.FieldCacheSavedCost:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCacheSavedCost(v)
+		return nil
+	case usagelog.FieldHedged:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHedged(v)
+		return nil
+	case usagelog.FieldHedgeBackupWon:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHedgeBackupWon(v)
+		return nil
+	case usagelog.FieldClientDisconnected:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClientDisconnected(v)
+		return nil
+	case usagelog.FieldPricingTier:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPricingTier(v)
+		return nil
+	case usagelog.FieldServiceTier:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetServiceTier(v)
+		return nil
+	case usagelog.FieldCostDetail:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCostDetail(v)
+		return nil
+	case usagelog.FieldOrganizationID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOrganizationID(v)
+		return nil
 	case usagelog.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
@@ -13844,6 +16100,12 @@ func (m *UsageLogMutation) AddedFields() []string {
 	if m.addimage_count != nil {
 		fields = append(fields, usagelog.FieldImageCount)
 	}
+	if m.addcache_saved_cost != nil {
+		fields = append(fields, usagelog.FieldCacheSavedCost)
+	}
+	if m.addorganization_id != nil {
+		fields = append(fields, usagelog.FieldOrganizationID)
+	}
 	return fields
 }
 
@@ -13888,6 +16150,10 @@ func (m *UsageLogMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedFirstTokenMs()
 	case usagelog.FieldImageCount:
 		return m.AddedImageCount()
+	case usagelog.FieldCacheSavedCost:
+		return m.AddedCacheSavedCost()
+	case usagelog.FieldOrganizationID:
+		return m.AddedOrganizationID()
 	}
 	return nil, false
 }
@@ -14023,6 +16289,20 @@ func (m *UsageLogMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddImageCount(v)
 		return nil
+	case usagelog.FieldCacheSavedCost:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCacheSavedCost(v)
+		return nil
+	case usagelog.FieldOrganizationID:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddOrganizationID(v)
+		return nil
 	}
 	return fmt.Errorf("unknown UsageLog numeric field %s", name)
 }
@@ -14055,6 +16335,12 @@ func (m *UsageLogMutation) ClearedFields() []string {
 	if m.FieldCleared(usagelog.FieldImageSize) {
 		fields = append(fields, usagelog.FieldImageSize)
 	}
+	if m.FieldCleared(usagelog.FieldCostDetail) {
+		fields = append(fields, usagelog.FieldCostDetail)
+	}
+	if m.FieldCleared(usagelog.FieldOrganizationID) {
+		fields = append(fields, usagelog.FieldOrganizationID)
+	}
 	return fields
 }
 
@@ -14093,6 +16379,12 @@ func (m *UsageLogMutation) ClearField(name string) error {
 	case usagelog.FieldImageSize:
 		m.ClearImageSize()
 		return nil
+	case usagelog.FieldCostDetail:
+		m.ClearCostDetail()
+		return nil
+	case usagelog.FieldOrganizationID:
+		m.ClearOrganizationID()
+		return nil
 	}
 	return fmt.Errorf("unknown UsageLog nullable field %s", name)
 }
@@ -14188,6 +16480,33 @@ func (m *UsageLogMutation) ResetField(name string) error {
 	case usagelog.FieldImageSize:
 		m.ResetImageSize()
 		return nil
+	case usagelog.FieldCacheServed:
+		m.ResetCacheServed()
+		return nil
+	case usagelog.FieldCacheSavedCost:
+		m.ResetCacheSavedCost()
+		return nil
+	case usagelog.FieldHedged:
+		m.ResetHedged()
+		return nil
+	case usagelog.FieldHedgeBackupWon:
+		m.ResetHedgeBackupWon()
+		return nil
+	case usagelog.FieldClientDisconnected:
+		m.ResetClientDisconnected()
+		return nil
+	case usagelog.FieldPricingTier:
+		m.ResetPricingTier()
+		return nil
+	case usagelog.FieldServiceTier:
+		m.ResetServiceTier()
+		return nil
+	case usagelog.FieldCostDetail:
+		m.ResetCostDetail()
+		return nil
+	case usagelog.FieldOrganizationID:
+		m.ResetOrganizationID()
+		return nil
 	case usagelog.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
@@ -14367,6 +16686,10 @@ type UserMutation struct {
 	usage_report_enabled          *bool
 	usage_report_schedule         *string
 	usage_report_timezone         *string
+	queue_priority                *int
+	addqueue_priority             *int
+	queue_weight                  *int
+	addqueue_weight               *int
 	clearedFields                 map[string]struct{}
 	api_keys                      map[int64]struct{}
 	removedapi_keys               map[int64]struct{}
@@ -15222,6 +17545,118 @@ func (m *UserMutation) ResetUsageReportTimezone() {
 	m.usage_report_timezone = nil
 }
 
+// SetQueuePriority sets the "queue_priority" field.
+func (m *UserMutation) SetQueuePriority(i int) {
+	m.queue_priority = &i
+	m.addqueue_priority = nil
+}
+
+// QueuePriority returns the value of the "queue_priority" field in the mutation.
+func (m *UserMutation) QueuePriority() (r int, exists bool) {
+	v := m.queue_priority
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldQueuePriority returns the old "queue_priority" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldQueuePriority(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQueuePriority is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQueuePriority requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQueuePriority: %w", err)
+	}
+	return oldValue.QueuePriority, nil
+}
+
+// AddQueuePriority adds i to the "queue_priority" field.
+func (m *UserMutation) AddQueuePriority(i int) {
+	if m.addqueue_priority != nil {
+		*m.addqueue_priority += i
+	} else {
+		m.addqueue_priority = &i
+	}
+}
+
+// AddedQueuePriority returns the value that was added to the "queue_priority" field in this mutation.
+func (m *UserMutation) AddedQueuePriority() (r int, exists bool) {
+	v := m.addqueue_priority
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetQueuePriority resets all changes to the "queue_priority" field.
+func (m *UserMutation) ResetQueuePriority() {
+	m.queue_priority = nil
+	m.addqueue_priority = nil
+}
+
+// SetQueueWeight sets the "queue_weight" field.
+func (m *UserMutation) SetQueueWeight(i int) {
+	m.queue_weight = &i
+	m.addqueue_weight = nil
+}
+
+// QueueWeight returns the value of the "queue_weight" field in the mutation.
+func (m *UserMutation) QueueWeight() (r int, exists bool) {
+	v := m.queue_weight
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldQueueWeight returns the old "queue_weight" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldQueueWeight(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQueueWeight is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQueueWeight requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQueueWeight: %w", err)
+	}
+	return oldValue.QueueWeight, nil
+}
+
+// AddQueueWeight adds i to the "queue_weight" field.
+func (m *UserMutation) AddQueueWeight(i int) {
+	if m.addqueue_weight != nil {
+		*m.addqueue_weight += i
+	} else {
+		m.addqueue_weight = &i
+	}
+}
+
+// AddedQueueWeight returns the value that was added to the "queue_weight" field in this mutation.
+func (m *UserMutation) AddedQueueWeight() (r int, exists bool) {
+	v := m.addqueue_weight
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetQueueWeight resets all changes to the "queue_weight" field.
+func (m *UserMutation) ResetQueueWeight() {
+	m.queue_weight = nil
+	m.addqueue_weight = nil
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by ids.
 func (m *UserMutation) AddAPIKeyIDs(ids ...int64) {
 	if m.api_keys == nil {
@@ -15688,7 +18123,7 @@ func (m *UserMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 18)
+	fields := make([]string, 0, 20)
 	if m.created_at != nil {
 		fields = append(fields, user.FieldCreatedAt)
 	}
@@ -15743,6 +18178,12 @@ func (m *UserMutation) Fields() []string {
 	if m.usage_report_timezone != nil {
 		fields = append(fields, user.FieldUsageReportTimezone)
 	}
+	if m.queue_priority != nil {
+		fields = append(fields, user.FieldQueuePriority)
+	}
+	if m.queue_weight != nil {
+		fields = append(fields, user.FieldQueueWeight)
+	}
 	return fields
 }
 
@@ -15787,6 +18228,10 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 		return m.UsageReportSchedule()
 	case user.FieldUsageReportTimezone:
 		return m.UsageReportTimezone()
+	case user.FieldQueuePriority:
+		return m.QueuePriority()
+	case user.FieldQueueWeight:
+		return m.QueueWeight()
 	}
 	return nil, false
 }
@@ -15832,6 +18277,10 @@ func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldUsageReportSchedule(ctx)
 	case user.FieldUsageReportTimezone:
 		return m.OldUsageReportTimezone(ctx)
+	case user.FieldQueuePriority:
+		return m.OldQueuePriority(ctx)
+	case user.FieldQueueWeight:
+		return m.OldQueueWeight(ctx)
 	}
 	return nil, fmt.Errorf("unknown User field %s", name)
 }
@@ -15967,6 +18416,20 @@ func (m *UserMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetUsageReportTimezone(v)
 		return nil
+	case user.FieldQueuePriority:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQueuePriority(v)
+		return nil
+	case user.FieldQueueWeight:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQueueWeight(v)
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
@@ -15981,6 +18444,12 @@ func (m *UserMutation) AddedFields() []string {
 	if m.addconcurrency != nil {
 		fields = append(fields, user.FieldConcurrency)
 	}
+	if m.addqueue_priority != nil {
+		fields = append(fields, user.FieldQueuePriority)
+	}
+	if m.addqueue_weight != nil {
+		fields = append(fields, user.FieldQueueWeight)
+	}
 	return fields
 }
 
@@ -15993,6 +18462,10 @@ func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedBalance()
 	case user.FieldConcurrency:
 		return m.AddedConcurrency()
+	case user.FieldQueuePriority:
+		return m.AddedQueuePriority()
+	case user.FieldQueueWeight:
+		return m.AddedQueueWeight()
 	}
 	return nil, false
 }
@@ -16016,6 +18489,20 @@ func (m *UserMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddConcurrency(v)
 		return nil
+	case user.FieldQueuePriority:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddQueuePriority(v)
+		return nil
+	case user.FieldQueueWeight:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddQueueWeight(v)
+		return nil
 	}
 	return fmt.Errorf("unknown User numeric field %s", name)
 }
@@ -16118,6 +18605,12 @@ func (m *UserMutation) ResetField(name string) error {
 	case user.FieldUsageReportTimezone:
 		m.ResetUsageReportTimezone()
 		return nil
+	case user.FieldQueuePriority:
+		m.ResetQueuePriority()
+		return nil
+	case user.FieldQueueWeight:
+		m.ResetQueueWeight()
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
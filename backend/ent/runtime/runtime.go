@@ -89,6 +89,10 @@ func init() {
 	apikey.DefaultStatus = apikeyDescStatus.Default.(string)
 	// apikey.StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	apikey.StatusValidator = apikeyDescStatus.Validators[0].(func(string) error)
+	// apikeyDescUsedUsd is the schema descriptor for used_usd field.
+	apikeyDescUsedUsd := apikeyFields[8].Descriptor()
+	// apikey.DefaultUsedUsd holds the default value on creation for the used_usd field.
+	apikey.DefaultUsedUsd = apikeyDescUsedUsd.Default.(float64)
 	accountMixin := schema.Account{}.Mixin()
 	accountMixinHooks1 := accountMixin[1].Hooks()
 	account.Hooks[0] = accountMixinHooks1[0]
@@ -285,6 +289,28 @@ func init() {
 	groupDescModelRoutingEnabled := groupFields[17].Descriptor()
 	// group.DefaultModelRoutingEnabled holds the default value on creation for the model_routing_enabled field.
 	group.DefaultModelRoutingEnabled = groupDescModelRoutingEnabled.Default.(bool)
+	// groupDescResponseCacheEnabled is the schema descriptor for response_cache_enabled field.
+	groupDescResponseCacheEnabled := groupFields[20].Descriptor()
+	// group.DefaultResponseCacheEnabled holds the default value on creation for the response_cache_enabled field.
+	group.DefaultResponseCacheEnabled = groupDescResponseCacheEnabled.Default.(bool)
+	// groupDescResponseCacheRate is the schema descriptor for response_cache_rate field.
+	groupDescResponseCacheRate := groupFields[22].Descriptor()
+	// group.DefaultResponseCacheRate holds the default value on creation for the response_cache_rate field.
+	group.DefaultResponseCacheRate = groupDescResponseCacheRate.Default.(float64)
+	// groupDescSchedulingStrategy is the schema descriptor for scheduling_strategy field.
+	groupDescSchedulingStrategy := groupFields[25].Descriptor()
+	// group.DefaultSchedulingStrategy holds the default value on creation for the scheduling_strategy field.
+	group.DefaultSchedulingStrategy = groupDescSchedulingStrategy.Default.(string)
+	// group.SchedulingStrategyValidator is a validator for the "scheduling_strategy" field. It is called by the builders before save.
+	group.SchedulingStrategyValidator = groupDescSchedulingStrategy.Validators[0].(func(string) error)
+	// groupDescHedgeDelayMs is the schema descriptor for hedge_delay_ms field.
+	groupDescHedgeDelayMs := groupFields[27].Descriptor()
+	// group.DefaultHedgeDelayMs holds the default value on creation for the hedge_delay_ms field.
+	group.DefaultHedgeDelayMs = groupDescHedgeDelayMs.Default.(int)
+	// groupDescQueueWeight is the schema descriptor for queue_weight field.
+	groupDescQueueWeight := groupFields[28].Descriptor()
+	// group.DefaultQueueWeight holds the default value on creation for the queue_weight field.
+	group.DefaultQueueWeight = groupDescQueueWeight.Default.(int)
 	promocodeFields := schema.PromoCode{}.Fields()
 	_ = promocodeFields
 	// promocodeDescCode is the schema descriptor for code field.
@@ -647,8 +673,40 @@ func init() {
 	usagelogDescImageSize := usagelogFields[28].Descriptor()
 	// usagelog.ImageSizeValidator is a validator for the "image_size" field. It is called by the builders before save.
 	usagelog.ImageSizeValidator = usagelogDescImageSize.Validators[0].(func(string) error)
+	// usagelogDescCacheServed is the schema descriptor for cache_served field.
+	usagelogDescCacheServed := usagelogFields[29].Descriptor()
+	// usagelog.DefaultCacheServed holds the default value on creation for the cache_served field.
+	usagelog.DefaultCacheServed = usagelogDescCacheServed.Default.(bool)
+	// usagelogDescCacheSavedCost is the schema descriptor for cache_saved_cost field.
+	usagelogDescCacheSavedCost := usagelogFields[30].Descriptor()
+	// usagelog.DefaultCacheSavedCost holds the default value on creation for the cache_saved_cost field.
+	usagelog.DefaultCacheSavedCost = usagelogDescCacheSavedCost.Default.(float64)
+	// usagelogDescHedged is the schema descriptor for hedged field.
+	usagelogDescHedged := usagelogFields[31].Descriptor()
+	// usagelog.DefaultHedged holds the default value on creation for the hedged field.
+	usagelog.DefaultHedged = usagelogDescHedged.Default.(bool)
+	// usagelogDescHedgeBackupWon is the schema descriptor for hedge_backup_won field.
+	usagelogDescHedgeBackupWon := usagelogFields[32].Descriptor()
+	// usagelog.DefaultHedgeBackupWon holds the default value on creation for the hedge_backup_won field.
+	usagelog.DefaultHedgeBackupWon = usagelogDescHedgeBackupWon.Default.(bool)
+	// usagelogDescClientDisconnected is the schema descriptor for client_disconnected field.
+	usagelogDescClientDisconnected := usagelogFields[33].Descriptor()
+	// usagelog.DefaultClientDisconnected holds the default value on creation for the client_disconnected field.
+	usagelog.DefaultClientDisconnected = usagelogDescClientDisconnected.Default.(bool)
+	// usagelogDescPricingTier is the schema descriptor for pricing_tier field.
+	usagelogDescPricingTier := usagelogFields[34].Descriptor()
+	// usagelog.DefaultPricingTier holds the default value on creation for the pricing_tier field.
+	usagelog.DefaultPricingTier = usagelogDescPricingTier.Default.(string)
+	// usagelog.PricingTierValidator is a validator for the "pricing_tier" field. It is called by the builders before save.
+	usagelog.PricingTierValidator = usagelogDescPricingTier.Validators[0].(func(string) error)
+	// usagelogDescServiceTier is the schema descriptor for service_tier field.
+	usagelogDescServiceTier := usagelogFields[35].Descriptor()
+	// usagelog.DefaultServiceTier holds the default value on creation for the service_tier field.
+	usagelog.DefaultServiceTier = usagelogDescServiceTier.Default.(string)
+	// usagelog.ServiceTierValidator is a validator for the "service_tier" field. It is called by the builders before save.
+	usagelog.ServiceTierValidator = usagelogDescServiceTier.Validators[0].(func(string) error)
 	// usagelogDescCreatedAt is the schema descriptor for created_at field.
-	usagelogDescCreatedAt := usagelogFields[29].Descriptor()
+	usagelogDescCreatedAt := usagelogFields[38].Descriptor()
 	// usagelog.DefaultCreatedAt holds the default value on creation for the created_at field.
 	usagelog.DefaultCreatedAt = usagelogDescCreatedAt.Default.(func() time.Time)
 	userMixin := schema.User{}.Mixin()
@@ -762,6 +820,14 @@ func init() {
 	user.DefaultUsageReportTimezone = userDescUsageReportTimezone.Default.(string)
 	// user.UsageReportTimezoneValidator is a validator for the "usage_report_timezone" field. It is called by the builders before save.
 	user.UsageReportTimezoneValidator = userDescUsageReportTimezone.Validators[0].(func(string) error)
+	// userDescQueuePriority is the schema descriptor for queue_priority field.
+	userDescQueuePriority := userFields[15].Descriptor()
+	// user.DefaultQueuePriority holds the default value on creation for the queue_priority field.
+	user.DefaultQueuePriority = userDescQueuePriority.Default.(int)
+	// userDescQueueWeight is the schema descriptor for queue_weight field.
+	userDescQueueWeight := userFields[16].Descriptor()
+	// user.DefaultQueueWeight holds the default value on creation for the queue_weight field.
+	user.DefaultQueueWeight = userDescQueueWeight.Default.(int)
 	userallowedgroupFields := schema.UserAllowedGroup{}.Fields()
 	_ = userallowedgroupFields
 	// userallowedgroupDescCreatedAt is the schema descriptor for created_at field.
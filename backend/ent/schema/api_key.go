@@ -5,6 +5,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
@@ -52,6 +53,38 @@ func (APIKey) Fields() []ent.Field {
 		field.JSON("ip_blacklist", []string{}).
 			Optional().
 			Comment("Blocked IPs/CIDRs"),
+		field.Float("quota_usd").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}).
+			Comment("Spend cap in USD, nil means unlimited"),
+		field.Float("used_usd").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,10)"}).
+			Default(0).
+			Comment("Accumulated spend in USD"),
+		field.Time("expires_at").
+			Optional().
+			Nillable().
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+		field.JSON("allowed_models", []string{}).
+			Optional().
+			Comment("Allowed model patterns, e.g. [\"claude-sonnet-*\"]; empty means all models"),
+		field.Int("max_tokens_cap").
+			Optional().
+			Nillable().
+			Comment("Upper bound for max_tokens/max_output_tokens, nil means unlimited"),
+		field.Int("rpm_limit").
+			Optional().
+			Nillable().
+			Comment("Requests per minute, nil falls back to the group default"),
+		field.Int("tpm_limit").
+			Optional().
+			Nillable().
+			Comment("Tokens per minute, nil falls back to the group default"),
+		field.Int64("organization_id").
+			Optional().
+			Nillable().
+			Comment("Team API key: usage is charged to this organization"),
 	}
 }
 
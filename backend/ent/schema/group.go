@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"encoding/json"
+
 	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
@@ -106,6 +108,61 @@ func (Group) Fields() []ent.Field {
 		field.Bool("model_routing_enabled").
 			Default(false).
 			Comment("是否启用模型路由配置"),
+
+		// API Key 默认速率限制 (added by migration 049)
+		field.Int("default_rpm_limit").
+			Optional().
+			Nillable().
+			Comment("分组内 API Key 默认每分钟请求数上限"),
+		field.Int("default_tpm_limit").
+			Optional().
+			Nillable().
+			Comment("分组内 API Key 默认每分钟 token 数上限"),
+
+		// 响应缓存 (added by migration 053)
+		field.Bool("response_cache_enabled").
+			Default(false).
+			Comment("是否对确定性非流式请求启用响应缓存"),
+		field.Int("response_cache_ttl_seconds").
+			Optional().
+			Nillable().
+			Comment("响应缓存有效期（秒），为空使用全局默认值"),
+		field.Float("response_cache_rate").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(10,4)"}).
+			Default(0).
+			Comment("缓存命中计费比例：0 表示免费，1 表示原价"),
+
+		// 模型降级链 (added by migration 054)
+		field.JSON("model_fallbacks", json.RawMessage{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("模型降级链：模型模式 -> [{group_id, model}]"),
+
+		// 护栏配置 (added by migration 055)
+		field.JSON("guardrails", json.RawMessage{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("护栏配置：拒绝列表、PII 脱敏、最大提示词长度、系统提示词"),
+
+		// 调度策略 (added by migration 057)
+		field.String("scheduling_strategy").
+			MaxLen(32).
+			Default("").
+			Comment("调度策略：空为默认，lowest_cost/lowest_latency/least_outstanding/weighted_round_robin"),
+		field.JSON("scheduling_weights", map[int64]int{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("加权轮询权重：账号ID -> 权重"),
+
+		// 对冲请求 (added by migration 058)
+		field.Int("hedge_delay_ms").
+			Default(0).
+			Comment("对冲延迟（毫秒）：首字节超过该时间未到达时向第二个账号发起相同请求，0 表示关闭"),
+
+		// 公平排队 (added by migration 059)
+		field.Int("queue_weight").
+			Default(0).
+			Comment("订阅分组的公平排队权重：用户未单独设置权重时使用，0 表示默认权重 1"),
 	}
 }
 
@@ -119,6 +119,39 @@ func (UsageLog) Fields() []ent.Field {
 			Optional().
 			Nillable(),
 
+		// 响应缓存命中（未请求上游）及相对原价节省的实际费用
+		field.Bool("cache_served").
+			Default(false),
+		field.Float("cache_saved_cost").
+			Default(0).
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,10)"}),
+
+		// 对冲请求：是否发起了对冲请求，以及是否由备用账号胜出
+		field.Bool("hedged").
+			Default(false),
+		field.Bool("hedge_backup_won").
+			Default(false),
+
+		// 客户端在流式传输中途断开（usage 为断开后排空上游得到的结果）
+		field.Bool("client_disconnected").
+			Default(false),
+
+		// 阶梯计费：长上下文阶梯、服务等级及计费明细（单价、5m/1h 缓存创建拆分、折扣）
+		field.String("pricing_tier").
+			MaxLen(32).
+			Default("standard"),
+		field.String("service_tier").
+			MaxLen(16).
+			Default("standard"),
+		field.JSON("cost_detail", map[string]any{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}),
+
+		// 团队 API Key 的请求记录所扣费的组织
+		field.Int64("organization_id").
+			Optional().
+			Nillable(),
+
 		// 时间戳（只有 created_at，日志不可修改）
 		field.Time("created_at").
 			Default(time.Now).
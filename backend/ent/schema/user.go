@@ -86,6 +86,13 @@ func (User) Fields() []ent.Field {
 		field.String("usage_report_timezone").
 			MaxLen(50).
 			Default("Asia/Shanghai"),
+
+		// 公平排队参数 (added by migration 059)
+		// queue_priority: 优先级，越大越先获得账号槽位；queue_weight: 公平份额权重，0 表示使用订阅分组权重或默认值
+		field.Int("queue_priority").
+			Default(0),
+		field.Int("queue_weight").
+			Default(0),
 	}
 }
 
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -80,6 +81,24 @@ type UsageLog struct {
 	ImageCount int `json:"image_count,omitempty"`
 	// ImageSize holds the value of the "image_size" field.
 	ImageSize *string `json:"image_size,omitempty"`
+	// CacheServed holds the value of the "cache_served" field.
+	CacheServed bool `json:"cache_served,omitempty"`
+	// CacheSavedCost holds the value of the "cache_saved_cost" field.
+	CacheSavedCost float64 `json:"cache_saved_cost,omitempty"`
+	// Hedged holds the value of the "hedged" field.
+	Hedged bool `json:"hedged,omitempty"`
+	// HedgeBackupWon holds the value of the "hedge_backup_won" field.
+	HedgeBackupWon bool `json:"hedge_backup_won,omitempty"`
+	// ClientDisconnected holds the value of the "client_disconnected" field.
+	ClientDisconnected bool `json:"client_disconnected,omitempty"`
+	// PricingTier holds the value of the "pricing_tier" field.
+	PricingTier string `json:"pricing_tier,omitempty"`
+	// ServiceTier holds the value of the "service_tier" field.
+	ServiceTier string `json:"service_tier,omitempty"`
+	// CostDetail holds the value of the "cost_detail" field.
+	CostDetail map[string]interface{} `json:"cost_detail,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID *int64 `json:"organization_id,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -165,13 +184,15 @@ func (*UsageLog) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case usagelog.FieldStream:
+		case usagelog.FieldCostDetail:
+			values[i] = new([]byte)
+		case usagelog.FieldStream, usagelog.FieldCacheServed, usagelog.FieldHedged, usagelog.FieldHedgeBackupWon, usagelog.FieldClientDisconnected:
 			values[i] = new(sql.NullBool)
-		case usagelog.FieldInputCost, usagelog.FieldOutputCost, usagelog.FieldCacheCreationCost, usagelog.FieldCacheReadCost, usagelog.FieldTotalCost, usagelog.FieldActualCost, usagelog.FieldRateMultiplier, usagelog.FieldAccountRateMultiplier:
+		case usagelog.FieldInputCost, usagelog.FieldOutputCost, usagelog.FieldCacheCreationCost, usagelog.FieldCacheReadCost, usagelog.FieldTotalCost, usagelog.FieldActualCost, usagelog.FieldRateMultiplier, usagelog.FieldAccountRateMultiplier, usagelog.FieldCacheSavedCost:
 			values[i] = new(sql.NullFloat64)
-		case usagelog.FieldID, usagelog.FieldUserID, usagelog.FieldAPIKeyID, usagelog.FieldAccountID, usagelog.FieldGroupID, usagelog.FieldSubscriptionID, usagelog.FieldInputTokens, usagelog.FieldOutputTokens, usagelog.FieldCacheCreationTokens, usagelog.FieldCacheReadTokens, usagelog.FieldCacheCreation5mTokens, usagelog.FieldCacheCreation1hTokens, usagelog.FieldBillingType, usagelog.FieldDurationMs, usagelog.FieldFirstTokenMs, usagelog.FieldImageCount:
+		case usagelog.FieldID, usagelog.FieldUserID, usagelog.FieldAPIKeyID, usagelog.FieldAccountID, usagelog.FieldGroupID, usagelog.FieldSubscriptionID, usagelog.FieldInputTokens, usagelog.FieldOutputTokens, usagelog.FieldCacheCreationTokens, usagelog.FieldCacheReadTokens, usagelog.FieldCacheCreation5mTokens, usagelog.FieldCacheCreation1hTokens, usagelog.FieldBillingType, usagelog.FieldDurationMs, usagelog.FieldFirstTokenMs, usagelog.FieldImageCount, usagelog.FieldOrganizationID:
 			values[i] = new(sql.NullInt64)
-		case usagelog.FieldRequestID, usagelog.FieldModel, usagelog.FieldUserAgent, usagelog.FieldIPAddress, usagelog.FieldImageSize:
+		case usagelog.FieldRequestID, usagelog.FieldModel, usagelog.FieldUserAgent, usagelog.FieldIPAddress, usagelog.FieldImageSize, usagelog.FieldPricingTier, usagelog.FieldServiceTier:
 			values[i] = new(sql.NullString)
 		case usagelog.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
@@ -378,6 +399,63 @@ func (_m *UsageLog) assignValues(columns []string, values []any) error {
 				_m.ImageSize = new(string)
 				*_m.ImageSize = value.String
 			}
+		case usagelog.FieldCacheServed:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field cache_served", values[i])
+			} else if value.Valid {
+				_m.CacheServed = value.Bool
+			}
+		case usagelog.FieldCacheSavedCost:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field cache_saved_cost", values[i])
+			} else if value.Valid {
+				_m.CacheSavedCost = value.Float64
+			}
+		case usagelog.FieldHedged:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field hedged", values[i])
+			} else if value.Valid {
+				_m.Hedged = value.Bool
+			}
+		case usagelog.FieldHedgeBackupWon:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field hedge_backup_won", values[i])
+			} else if value.Valid {
+				_m.HedgeBackupWon = value.Bool
+			}
+		case usagelog.FieldClientDisconnected:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field client_disconnected", values[i])
+			} else if value.Valid {
+				_m.ClientDisconnected = value.Bool
+			}
+		case usagelog.FieldPricingTier:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field pricing_tier", values[i])
+			} else if value.Valid {
+				_m.PricingTier = value.String
+			}
+		case usagelog.FieldServiceTier:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field service_tier", values[i])
+			} else if value.Valid {
+				_m.ServiceTier = value.String
+			}
+		case usagelog.FieldCostDetail:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field cost_detail", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.CostDetail); err != nil {
+					return fmt.Errorf("unmarshal field cost_detail: %w", err)
+				}
+			}
+		case usagelog.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				_m.OrganizationID = new(int64)
+				*_m.OrganizationID = value.Int64
+			}
 		case usagelog.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -548,6 +626,35 @@ func (_m *UsageLog) String() string {
 		builder.WriteString(*v)
 	}
 	builder.WriteString(", ")
+	builder.WriteString("cache_served=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CacheServed))
+	builder.WriteString(", ")
+	builder.WriteString("cache_saved_cost=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CacheSavedCost))
+	builder.WriteString(", ")
+	builder.WriteString("hedged=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Hedged))
+	builder.WriteString(", ")
+	builder.WriteString("hedge_backup_won=")
+	builder.WriteString(fmt.Sprintf("%v", _m.HedgeBackupWon))
+	builder.WriteString(", ")
+	builder.WriteString("client_disconnected=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ClientDisconnected))
+	builder.WriteString(", ")
+	builder.WriteString("pricing_tier=")
+	builder.WriteString(_m.PricingTier)
+	builder.WriteString(", ")
+	builder.WriteString("service_tier=")
+	builder.WriteString(_m.ServiceTier)
+	builder.WriteString(", ")
+	builder.WriteString("cost_detail=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CostDetail))
+	builder.WriteString(", ")
+	if v := _m.OrganizationID; v != nil {
+		builder.WriteString("organization_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteByte(')')
@@ -72,6 +72,24 @@ const (
 	FieldImageCount = "image_count"
 	// FieldImageSize holds the string denoting the image_size field in the database.
 	FieldImageSize = "image_size"
+	// FieldCacheServed holds the string denoting the cache_served field in the database.
+	FieldCacheServed = "cache_served"
+	// FieldCacheSavedCost holds the string denoting the cache_saved_cost field in the database.
+	FieldCacheSavedCost = "cache_saved_cost"
+	// FieldHedged holds the string denoting the hedged field in the database.
+	FieldHedged = "hedged"
+	// FieldHedgeBackupWon holds the string denoting the hedge_backup_won field in the database.
+	FieldHedgeBackupWon = "hedge_backup_won"
+	// FieldClientDisconnected holds the string denoting the client_disconnected field in the database.
+	FieldClientDisconnected = "client_disconnected"
+	// FieldPricingTier holds the string denoting the pricing_tier field in the database.
+	FieldPricingTier = "pricing_tier"
+	// FieldServiceTier holds the string denoting the service_tier field in the database.
+	FieldServiceTier = "service_tier"
+	// FieldCostDetail holds the string denoting the cost_detail field in the database.
+	FieldCostDetail = "cost_detail"
+	// FieldOrganizationID holds the string denoting the organization_id field in the database.
+	FieldOrganizationID = "organization_id"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// EdgeUser holds the string denoting the user edge name in mutations.
@@ -155,6 +173,15 @@ var Columns = []string{
 	FieldIPAddress,
 	FieldImageCount,
 	FieldImageSize,
+	FieldCacheServed,
+	FieldCacheSavedCost,
+	FieldHedged,
+	FieldHedgeBackupWon,
+	FieldClientDisconnected,
+	FieldPricingTier,
+	FieldServiceTier,
+	FieldCostDetail,
+	FieldOrganizationID,
 	FieldCreatedAt,
 }
 
@@ -211,6 +238,24 @@ var (
 	DefaultImageCount int
 	// ImageSizeValidator is a validator for the "image_size" field. It is called by the builders before save.
 	ImageSizeValidator func(string) error
+	// DefaultCacheServed holds the default value on creation for the "cache_served" field.
+	DefaultCacheServed bool
+	// DefaultCacheSavedCost holds the default value on creation for the "cache_saved_cost" field.
+	DefaultCacheSavedCost float64
+	// DefaultHedged holds the default value on creation for the "hedged" field.
+	DefaultHedged bool
+	// DefaultHedgeBackupWon holds the default value on creation for the "hedge_backup_won" field.
+	DefaultHedgeBackupWon bool
+	// DefaultClientDisconnected holds the default value on creation for the "client_disconnected" field.
+	DefaultClientDisconnected bool
+	// DefaultPricingTier holds the default value on creation for the "pricing_tier" field.
+	DefaultPricingTier string
+	// PricingTierValidator is a validator for the "pricing_tier" field. It is called by the builders before save.
+	PricingTierValidator func(string) error
+	// DefaultServiceTier holds the default value on creation for the "service_tier" field.
+	DefaultServiceTier string
+	// ServiceTierValidator is a validator for the "service_tier" field. It is called by the builders before save.
+	ServiceTierValidator func(string) error
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 )
@@ -368,6 +413,46 @@ func ByImageSize(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldImageSize, opts...).ToFunc()
 }
 
+// ByCacheServed orders the results by the cache_served field.
+func ByCacheServed(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCacheServed, opts...).ToFunc()
+}
+
+// ByCacheSavedCost orders the results by the cache_saved_cost field.
+func ByCacheSavedCost(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCacheSavedCost, opts...).ToFunc()
+}
+
+// ByHedged orders the results by the hedged field.
+func ByHedged(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHedged, opts...).ToFunc()
+}
+
+// ByHedgeBackupWon orders the results by the hedge_backup_won field.
+func ByHedgeBackupWon(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHedgeBackupWon, opts...).ToFunc()
+}
+
+// ByClientDisconnected orders the results by the client_disconnected field.
+func ByClientDisconnected(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldClientDisconnected, opts...).ToFunc()
+}
+
+// ByPricingTier orders the results by the pricing_tier field.
+func ByPricingTier(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPricingTier, opts...).ToFunc()
+}
+
+// ByServiceTier orders the results by the service_tier field.
+func ByServiceTier(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldServiceTier, opts...).ToFunc()
+}
+
+// ByOrganizationID orders the results by the organization_id field.
+func ByOrganizationID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOrganizationID, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -200,6 +200,46 @@ func ImageSize(v string) predicate.UsageLog {
 	return predicate.UsageLog(sql.FieldEQ(FieldImageSize, v))
 }
 
+// CacheServed applies equality check predicate on the "cache_served" field. It's identical to CacheServedEQ.
+func CacheServed(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldCacheServed, v))
+}
+
+// CacheSavedCost applies equality check predicate on the "cache_saved_cost" field. It's identical to CacheSavedCostEQ.
+func CacheSavedCost(v float64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldCacheSavedCost, v))
+}
+
+// Hedged applies equality check predicate on the "hedged" field. It's identical to HedgedEQ.
+func Hedged(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldHedged, v))
+}
+
+// HedgeBackupWon applies equality check predicate on the "hedge_backup_won" field. It's identical to HedgeBackupWonEQ.
+func HedgeBackupWon(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldHedgeBackupWon, v))
+}
+
+// ClientDisconnected applies equality check predicate on the "client_disconnected" field. It's identical to ClientDisconnectedEQ.
+func ClientDisconnected(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldClientDisconnected, v))
+}
+
+// PricingTier applies equality check predicate on the "pricing_tier" field. It's identical to PricingTierEQ.
+func PricingTier(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldPricingTier, v))
+}
+
+// ServiceTier applies equality check predicate on the "service_tier" field. It's identical to ServiceTierEQ.
+func ServiceTier(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldServiceTier, v))
+}
+
+// OrganizationID applies equality check predicate on the "organization_id" field. It's identical to OrganizationIDEQ.
+func OrganizationID(v int64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldOrganizationID, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.UsageLog {
 	return predicate.UsageLog(sql.FieldEQ(FieldCreatedAt, v))
@@ -1440,6 +1480,276 @@ func ImageSizeContainsFold(v string) predicate.UsageLog {
 	return predicate.UsageLog(sql.FieldContainsFold(FieldImageSize, v))
 }
 
+// CacheServedEQ applies the EQ predicate on the "cache_served" field.
+func CacheServedEQ(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldCacheServed, v))
+}
+
+// CacheServedNEQ applies the NEQ predicate on the "cache_served" field.
+func CacheServedNEQ(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNEQ(FieldCacheServed, v))
+}
+
+// CacheSavedCostEQ applies the EQ predicate on the "cache_saved_cost" field.
+func CacheSavedCostEQ(v float64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldCacheSavedCost, v))
+}
+
+// CacheSavedCostNEQ applies the NEQ predicate on the "cache_saved_cost" field.
+func CacheSavedCostNEQ(v float64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNEQ(FieldCacheSavedCost, v))
+}
+
+// CacheSavedCostIn applies the In predicate on the "cache_saved_cost" field.
+func CacheSavedCostIn(vs ...float64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldIn(FieldCacheSavedCost, vs...))
+}
+
+// CacheSavedCostNotIn applies the NotIn predicate on the "cache_saved_cost" field.
+func CacheSavedCostNotIn(vs ...float64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNotIn(FieldCacheSavedCost, vs...))
+}
+
+// CacheSavedCostGT applies the GT predicate on the "cache_saved_cost" field.
+func CacheSavedCostGT(v float64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGT(FieldCacheSavedCost, v))
+}
+
+// CacheSavedCostGTE applies the GTE predicate on the "cache_saved_cost" field.
+func CacheSavedCostGTE(v float64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGTE(FieldCacheSavedCost, v))
+}
+
+// CacheSavedCostLT applies the LT predicate on the "cache_saved_cost" field.
+func CacheSavedCostLT(v float64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLT(FieldCacheSavedCost, v))
+}
+
+// CacheSavedCostLTE applies the LTE predicate on the "cache_saved_cost" field.
+func CacheSavedCostLTE(v float64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLTE(FieldCacheSavedCost, v))
+}
+
+// HedgedEQ applies the EQ predicate on the "hedged" field.
+func HedgedEQ(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldHedged, v))
+}
+
+// HedgedNEQ applies the NEQ predicate on the "hedged" field.
+func HedgedNEQ(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNEQ(FieldHedged, v))
+}
+
+// HedgeBackupWonEQ applies the EQ predicate on the "hedge_backup_won" field.
+func HedgeBackupWonEQ(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldHedgeBackupWon, v))
+}
+
+// HedgeBackupWonNEQ applies the NEQ predicate on the "hedge_backup_won" field.
+func HedgeBackupWonNEQ(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNEQ(FieldHedgeBackupWon, v))
+}
+
+// ClientDisconnectedEQ applies the EQ predicate on the "client_disconnected" field.
+func ClientDisconnectedEQ(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldClientDisconnected, v))
+}
+
+// ClientDisconnectedNEQ applies the NEQ predicate on the "client_disconnected" field.
+func ClientDisconnectedNEQ(v bool) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNEQ(FieldClientDisconnected, v))
+}
+
+// PricingTierEQ applies the EQ predicate on the "pricing_tier" field.
+func PricingTierEQ(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldPricingTier, v))
+}
+
+// PricingTierNEQ applies the NEQ predicate on the "pricing_tier" field.
+func PricingTierNEQ(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNEQ(FieldPricingTier, v))
+}
+
+// PricingTierIn applies the In predicate on the "pricing_tier" field.
+func PricingTierIn(vs ...string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldIn(FieldPricingTier, vs...))
+}
+
+// PricingTierNotIn applies the NotIn predicate on the "pricing_tier" field.
+func PricingTierNotIn(vs ...string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNotIn(FieldPricingTier, vs...))
+}
+
+// PricingTierGT applies the GT predicate on the "pricing_tier" field.
+func PricingTierGT(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGT(FieldPricingTier, v))
+}
+
+// PricingTierGTE applies the GTE predicate on the "pricing_tier" field.
+func PricingTierGTE(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGTE(FieldPricingTier, v))
+}
+
+// PricingTierLT applies the LT predicate on the "pricing_tier" field.
+func PricingTierLT(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLT(FieldPricingTier, v))
+}
+
+// PricingTierLTE applies the LTE predicate on the "pricing_tier" field.
+func PricingTierLTE(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLTE(FieldPricingTier, v))
+}
+
+// PricingTierContains applies the Contains predicate on the "pricing_tier" field.
+func PricingTierContains(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldContains(FieldPricingTier, v))
+}
+
+// PricingTierHasPrefix applies the HasPrefix predicate on the "pricing_tier" field.
+func PricingTierHasPrefix(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldHasPrefix(FieldPricingTier, v))
+}
+
+// PricingTierHasSuffix applies the HasSuffix predicate on the "pricing_tier" field.
+func PricingTierHasSuffix(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldHasSuffix(FieldPricingTier, v))
+}
+
+// PricingTierEqualFold applies the EqualFold predicate on the "pricing_tier" field.
+func PricingTierEqualFold(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEqualFold(FieldPricingTier, v))
+}
+
+// PricingTierContainsFold applies the ContainsFold predicate on the "pricing_tier" field.
+func PricingTierContainsFold(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldContainsFold(FieldPricingTier, v))
+}
+
+// ServiceTierEQ applies the EQ predicate on the "service_tier" field.
+func ServiceTierEQ(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldServiceTier, v))
+}
+
+// ServiceTierNEQ applies the NEQ predicate on the "service_tier" field.
+func ServiceTierNEQ(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNEQ(FieldServiceTier, v))
+}
+
+// ServiceTierIn applies the In predicate on the "service_tier" field.
+func ServiceTierIn(vs ...string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldIn(FieldServiceTier, vs...))
+}
+
+// ServiceTierNotIn applies the NotIn predicate on the "service_tier" field.
+func ServiceTierNotIn(vs ...string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNotIn(FieldServiceTier, vs...))
+}
+
+// ServiceTierGT applies the GT predicate on the "service_tier" field.
+func ServiceTierGT(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGT(FieldServiceTier, v))
+}
+
+// ServiceTierGTE applies the GTE predicate on the "service_tier" field.
+func ServiceTierGTE(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGTE(FieldServiceTier, v))
+}
+
+// ServiceTierLT applies the LT predicate on the "service_tier" field.
+func ServiceTierLT(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLT(FieldServiceTier, v))
+}
+
+// ServiceTierLTE applies the LTE predicate on the "service_tier" field.
+func ServiceTierLTE(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLTE(FieldServiceTier, v))
+}
+
+// ServiceTierContains applies the Contains predicate on the "service_tier" field.
+func ServiceTierContains(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldContains(FieldServiceTier, v))
+}
+
+// ServiceTierHasPrefix applies the HasPrefix predicate on the "service_tier" field.
+func ServiceTierHasPrefix(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldHasPrefix(FieldServiceTier, v))
+}
+
+// ServiceTierHasSuffix applies the HasSuffix predicate on the "service_tier" field.
+func ServiceTierHasSuffix(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldHasSuffix(FieldServiceTier, v))
+}
+
+// ServiceTierEqualFold applies the EqualFold predicate on the "service_tier" field.
+func ServiceTierEqualFold(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEqualFold(FieldServiceTier, v))
+}
+
+// ServiceTierContainsFold applies the ContainsFold predicate on the "service_tier" field.
+func ServiceTierContainsFold(v string) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldContainsFold(FieldServiceTier, v))
+}
+
+// CostDetailIsNil applies the IsNil predicate on the "cost_detail" field.
+func CostDetailIsNil() predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldIsNull(FieldCostDetail))
+}
+
+// CostDetailNotNil applies the NotNil predicate on the "cost_detail" field.
+func CostDetailNotNil() predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNotNull(FieldCostDetail))
+}
+
+// OrganizationIDEQ applies the EQ predicate on the "organization_id" field.
+func OrganizationIDEQ(v int64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDNEQ applies the NEQ predicate on the "organization_id" field.
+func OrganizationIDNEQ(v int64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDIn applies the In predicate on the "organization_id" field.
+func OrganizationIDIn(vs ...int64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDNotIn applies the NotIn predicate on the "organization_id" field.
+func OrganizationIDNotIn(vs ...int64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNotIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDGT applies the GT predicate on the "organization_id" field.
+func OrganizationIDGT(v int64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGT(FieldOrganizationID, v))
+}
+
+// OrganizationIDGTE applies the GTE predicate on the "organization_id" field.
+func OrganizationIDGTE(v int64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldGTE(FieldOrganizationID, v))
+}
+
+// OrganizationIDLT applies the LT predicate on the "organization_id" field.
+func OrganizationIDLT(v int64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLT(FieldOrganizationID, v))
+}
+
+// OrganizationIDLTE applies the LTE predicate on the "organization_id" field.
+func OrganizationIDLTE(v int64) predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldLTE(FieldOrganizationID, v))
+}
+
+// OrganizationIDIsNil applies the IsNil predicate on the "organization_id" field.
+func OrganizationIDIsNil() predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldIsNull(FieldOrganizationID))
+}
+
+// OrganizationIDNotNil applies the NotNil predicate on the "organization_id" field.
+func OrganizationIDNotNil() predicate.UsageLog {
+	return predicate.UsageLog(sql.FieldNotNull(FieldOrganizationID))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.UsageLog {
 	return predicate.UsageLog(sql.FieldEQ(FieldCreatedAt, v))
@@ -393,6 +393,124 @@ func (_c *UsageLogCreate) SetNillableImageSize(v *string) *UsageLogCreate {
 	return _c
 }
 
+// SetCacheServed sets the "cache_served" field.
+func (_c *UsageLogCreate) SetCacheServed(v bool) *UsageLogCreate {
+	_c.mutation.SetCacheServed(v)
+	return _c
+}
+
+// SetNillableCacheServed sets the "cache_served" field if the given value is not nil.
+func (_c *UsageLogCreate) SetNillableCacheServed(v *bool) *UsageLogCreate {
+	if v != nil {
+		_c.SetCacheServed(*v)
+	}
+	return _c
+}
+
+// SetCacheSavedCost sets the "cache_saved_cost" field.
+func (_c *UsageLogCreate) SetCacheSavedCost(v float64) *UsageLogCreate {
+	_c.mutation.SetCacheSavedCost(v)
+	return _c
+}
+
+// SetNillableCacheSavedCost sets the "cache_saved_cost" field if the given value is not nil.
+func (_c *UsageLogCreate) SetNillableCacheSavedCost(v *float64) *UsageLogCreate {
+	if v != nil {
+		_c.SetCacheSavedCost(*v)
+	}
+	return _c
+}
+
+// SetHedged sets the "hedged" field.
+func (_c *UsageLogCreate) SetHedged(v bool) *UsageLogCreate {
+	_c.mutation.SetHedged(v)
+	return _c
+}
+
+// SetNillableHedged sets the "hedged" field if the given value is not nil.
+func (_c *UsageLogCreate) SetNillableHedged(v *bool) *UsageLogCreate {
+	if v != nil {
+		_c.SetHedged(*v)
+	}
+	return _c
+}
+
+// SetHedgeBackupWon sets the "hedge_backup_won" field.
+func (_c *UsageLogCreate) SetHedgeBackupWon(v bool) *UsageLogCreate {
+	_c.mutation.SetHedgeBackupWon(v)
+	return _c
+}
+
+// SetNillableHedgeBackupWon sets the "hedge_backup_won" field if the given value is not nil.
+func (_c *UsageLogCreate) SetNillableHedgeBackupWon(v *bool) *UsageLogCreate {
+	if v != nil {
+		_c.SetHedgeBackupWon(*v)
+	}
+	return _c
+}
+
+// SetClientDisconnected sets the "client_disconnected" field.
+func (_c *UsageLogCreate) SetClientDisconnected(v bool) *UsageLogCreate {
+	_c.mutation.SetClientDisconnected(v)
+	return _c
+}
+
+// SetNillableClientDisconnected sets the "client_disconnected" field if the given value is not nil.
+func (_c *UsageLogCreate) SetNillableClientDisconnected(v *bool) *UsageLogCreate {
+	if v != nil {
+		_c.SetClientDisconnected(*v)
+	}
+	return _c
+}
+
+// SetPricingTier sets the "pricing_tier" field.
+func (_c *UsageLogCreate) SetPricingTier(v string) *UsageLogCreate {
+	_c.mutation.SetPricingTier(v)
+	return _c
+}
+
+// SetNillablePricingTier sets the "pricing_tier" field if the given value is not nil.
+func (_c *UsageLogCreate) SetNillablePricingTier(v *string) *UsageLogCreate {
+	if v != nil {
+		_c.SetPricingTier(*v)
+	}
+	return _c
+}
+
+// SetServiceTier sets the "service_tier" field.
+func (_c *UsageLogCreate) SetServiceTier(v string) *UsageLogCreate {
+	_c.mutation.SetServiceTier(v)
+	return _c
+}
+
+// SetNillableServiceTier sets the "service_tier" field if the given value is not nil.
+func (_c *UsageLogCreate) SetNillableServiceTier(v *string) *UsageLogCreate {
+	if v != nil {
+		_c.SetServiceTier(*v)
+	}
+	return _c
+}
+
+// SetCostDetail sets the "cost_detail" field.
+func (_c *UsageLogCreate) SetCostDetail(v map[string]interface{}) *UsageLogCreate {
+	_c.mutation.SetCostDetail(v)
+	return _c
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_c *UsageLogCreate) SetOrganizationID(v int64) *UsageLogCreate {
+	_c.mutation.SetOrganizationID(v)
+	return _c
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_c *UsageLogCreate) SetNillableOrganizationID(v *int64) *UsageLogCreate {
+	if v != nil {
+		_c.SetOrganizationID(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *UsageLogCreate) SetCreatedAt(v time.Time) *UsageLogCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -531,6 +649,34 @@ func (_c *UsageLogCreate) defaults() {
 		v := usagelog.DefaultImageCount
 		_c.mutation.SetImageCount(v)
 	}
+	if _, ok := _c.mutation.CacheServed(); !ok {
+		v := usagelog.DefaultCacheServed
+		_c.mutation.SetCacheServed(v)
+	}
+	if _, ok := _c.mutation.CacheSavedCost(); !ok {
+		v := usagelog.DefaultCacheSavedCost
+		_c.mutation.SetCacheSavedCost(v)
+	}
+	if _, ok := _c.mutation.Hedged(); !ok {
+		v := usagelog.DefaultHedged
+		_c.mutation.SetHedged(v)
+	}
+	if _, ok := _c.mutation.HedgeBackupWon(); !ok {
+		v := usagelog.DefaultHedgeBackupWon
+		_c.mutation.SetHedgeBackupWon(v)
+	}
+	if _, ok := _c.mutation.ClientDisconnected(); !ok {
+		v := usagelog.DefaultClientDisconnected
+		_c.mutation.SetClientDisconnected(v)
+	}
+	if _, ok := _c.mutation.PricingTier(); !ok {
+		v := usagelog.DefaultPricingTier
+		_c.mutation.SetPricingTier(v)
+	}
+	if _, ok := _c.mutation.ServiceTier(); !ok {
+		v := usagelog.DefaultServiceTier
+		_c.mutation.SetServiceTier(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := usagelog.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
@@ -627,6 +773,37 @@ func (_c *UsageLogCreate) check() error {
 			return &ValidationError{Name: "image_size", err: fmt.Errorf(`ent: validator failed for field "UsageLog.image_size": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.CacheServed(); !ok {
+		return &ValidationError{Name: "cache_served", err: errors.New(`ent: missing required field "UsageLog.cache_served"`)}
+	}
+	if _, ok := _c.mutation.CacheSavedCost(); !ok {
+		return &ValidationError{Name: "cache_saved_cost", err: errors.New(`ent: missing required field "UsageLog.cache_saved_cost"`)}
+	}
+	if _, ok := _c.mutation.Hedged(); !ok {
+		return &ValidationError{Name: "hedged", err: errors.New(`ent: missing required field "UsageLog.hedged"`)}
+	}
+	if _, ok := _c.mutation.HedgeBackupWon(); !ok {
+		return &ValidationError{Name: "hedge_backup_won", err: errors.New(`ent: missing required field "UsageLog.hedge_backup_won"`)}
+	}
+	if _, ok := _c.mutation.ClientDisconnected(); !ok {
+		return &ValidationError{Name: "client_disconnected", err: errors.New(`ent: missing required field "UsageLog.client_disconnected"`)}
+	}
+	if _, ok := _c.mutation.PricingTier(); !ok {
+		return &ValidationError{Name: "pricing_tier", err: errors.New(`ent: missing required field "UsageLog.pricing_tier"`)}
+	}
+	if v, ok := _c.mutation.PricingTier(); ok {
+		if err := usagelog.PricingTierValidator(v); err != nil {
+			return &ValidationError{Name: "pricing_tier", err: fmt.Errorf(`ent: validator failed for field "UsageLog.pricing_tier": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ServiceTier(); !ok {
+		return &ValidationError{Name: "service_tier", err: errors.New(`ent: missing required field "UsageLog.service_tier"`)}
+	}
+	if v, ok := _c.mutation.ServiceTier(); ok {
+		if err := usagelog.ServiceTierValidator(v); err != nil {
+			return &ValidationError{Name: "service_tier", err: fmt.Errorf(`ent: validator failed for field "UsageLog.service_tier": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "UsageLog.created_at"`)}
 	}
@@ -762,6 +939,42 @@ func (_c *UsageLogCreate) createSpec() (*UsageLog, *sqlgraph.CreateSpec) {
 		_spec.SetField(usagelog.FieldImageSize, field.TypeString, value)
 		_node.ImageSize = &value
 	}
+	if value, ok := _c.mutation.CacheServed(); ok {
+		_spec.SetField(usagelog.FieldCacheServed, field.TypeBool, value)
+		_node.CacheServed = value
+	}
+	if value, ok := _c.mutation.CacheSavedCost(); ok {
+		_spec.SetField(usagelog.FieldCacheSavedCost, field.TypeFloat64, value)
+		_node.CacheSavedCost = value
+	}
+	if value, ok := _c.mutation.Hedged(); ok {
+		_spec.SetField(usagelog.FieldHedged, field.TypeBool, value)
+		_node.Hedged = value
+	}
+	if value, ok := _c.mutation.HedgeBackupWon(); ok {
+		_spec.SetField(usagelog.FieldHedgeBackupWon, field.TypeBool, value)
+		_node.HedgeBackupWon = value
+	}
+	if value, ok := _c.mutation.ClientDisconnected(); ok {
+		_spec.SetField(usagelog.FieldClientDisconnected, field.TypeBool, value)
+		_node.ClientDisconnected = value
+	}
+	if value, ok := _c.mutation.PricingTier(); ok {
+		_spec.SetField(usagelog.FieldPricingTier, field.TypeString, value)
+		_node.PricingTier = value
+	}
+	if value, ok := _c.mutation.ServiceTier(); ok {
+		_spec.SetField(usagelog.FieldServiceTier, field.TypeString, value)
+		_node.ServiceTier = value
+	}
+	if value, ok := _c.mutation.CostDetail(); ok {
+		_spec.SetField(usagelog.FieldCostDetail, field.TypeJSON, value)
+		_node.CostDetail = value
+	}
+	if value, ok := _c.mutation.OrganizationID(); ok {
+		_spec.SetField(usagelog.FieldOrganizationID, field.TypeInt64, value)
+		_node.OrganizationID = &value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(usagelog.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -1407,6 +1620,138 @@ func (u *UsageLogUpsert) ClearImageSize() *UsageLogUpsert {
 	return u
 }
 
+// SetCacheServed sets the "cache_served" field.
+func (u *UsageLogUpsert) SetCacheServed(v bool) *UsageLogUpsert {
+	u.Set(usagelog.FieldCacheServed, v)
+	return u
+}
+
+// UpdateCacheServed sets the "cache_served" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdateCacheServed() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldCacheServed)
+	return u
+}
+
+// SetCacheSavedCost sets the "cache_saved_cost" field.
+func (u *UsageLogUpsert) SetCacheSavedCost(v float64) *UsageLogUpsert {
+	u.Set(usagelog.FieldCacheSavedCost, v)
+	return u
+}
+
+// UpdateCacheSavedCost sets the "cache_saved_cost" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdateCacheSavedCost() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldCacheSavedCost)
+	return u
+}
+
+// AddCacheSavedCost adds v to the "cache_saved_cost" field.
+func (u *UsageLogUpsert) AddCacheSavedCost(v float64) *UsageLogUpsert {
+	u.Add(usagelog.FieldCacheSavedCost, v)
+	return u
+}
+
+// SetHedged sets the "hedged" field.
+func (u *UsageLogUpsert) SetHedged(v bool) *UsageLogUpsert {
+	u.Set(usagelog.FieldHedged, v)
+	return u
+}
+
+// UpdateHedged sets the "hedged" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdateHedged() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldHedged)
+	return u
+}
+
+// SetHedgeBackupWon sets the "hedge_backup_won" field.
+func (u *UsageLogUpsert) SetHedgeBackupWon(v bool) *UsageLogUpsert {
+	u.Set(usagelog.FieldHedgeBackupWon, v)
+	return u
+}
+
+// UpdateHedgeBackupWon sets the "hedge_backup_won" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdateHedgeBackupWon() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldHedgeBackupWon)
+	return u
+}
+
+// SetClientDisconnected sets the "client_disconnected" field.
+func (u *UsageLogUpsert) SetClientDisconnected(v bool) *UsageLogUpsert {
+	u.Set(usagelog.FieldClientDisconnected, v)
+	return u
+}
+
+// UpdateClientDisconnected sets the "client_disconnected" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdateClientDisconnected() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldClientDisconnected)
+	return u
+}
+
+// SetPricingTier sets the "pricing_tier" field.
+func (u *UsageLogUpsert) SetPricingTier(v string) *UsageLogUpsert {
+	u.Set(usagelog.FieldPricingTier, v)
+	return u
+}
+
+// UpdatePricingTier sets the "pricing_tier" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdatePricingTier() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldPricingTier)
+	return u
+}
+
+// SetServiceTier sets the "service_tier" field.
+func (u *UsageLogUpsert) SetServiceTier(v string) *UsageLogUpsert {
+	u.Set(usagelog.FieldServiceTier, v)
+	return u
+}
+
+// UpdateServiceTier sets the "service_tier" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdateServiceTier() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldServiceTier)
+	return u
+}
+
+// SetCostDetail sets the "cost_detail" field.
+func (u *UsageLogUpsert) SetCostDetail(v map[string]interface{}) *UsageLogUpsert {
+	u.Set(usagelog.FieldCostDetail, v)
+	return u
+}
+
+// UpdateCostDetail sets the "cost_detail" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdateCostDetail() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldCostDetail)
+	return u
+}
+
+// ClearCostDetail clears the value of the "cost_detail" field.
+func (u *UsageLogUpsert) ClearCostDetail() *UsageLogUpsert {
+	u.SetNull(usagelog.FieldCostDetail)
+	return u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *UsageLogUpsert) SetOrganizationID(v int64) *UsageLogUpsert {
+	u.Set(usagelog.FieldOrganizationID, v)
+	return u
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *UsageLogUpsert) UpdateOrganizationID() *UsageLogUpsert {
+	u.SetExcluded(usagelog.FieldOrganizationID)
+	return u
+}
+
+// AddOrganizationID adds v to the "organization_id" field.
+func (u *UsageLogUpsert) AddOrganizationID(v int64) *UsageLogUpsert {
+	u.Add(usagelog.FieldOrganizationID, v)
+	return u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (u *UsageLogUpsert) ClearOrganizationID() *UsageLogUpsert {
+	u.SetNull(usagelog.FieldOrganizationID)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -2040,6 +2385,160 @@ func (u *UsageLogUpsertOne) ClearImageSize() *UsageLogUpsertOne {
 	})
 }
 
+// SetCacheServed sets the "cache_served" field.
+func (u *UsageLogUpsertOne) SetCacheServed(v bool) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetCacheServed(v)
+	})
+}
+
+// UpdateCacheServed sets the "cache_served" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdateCacheServed() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateCacheServed()
+	})
+}
+
+// SetCacheSavedCost sets the "cache_saved_cost" field.
+func (u *UsageLogUpsertOne) SetCacheSavedCost(v float64) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetCacheSavedCost(v)
+	})
+}
+
+// AddCacheSavedCost adds v to the "cache_saved_cost" field.
+func (u *UsageLogUpsertOne) AddCacheSavedCost(v float64) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.AddCacheSavedCost(v)
+	})
+}
+
+// UpdateCacheSavedCost sets the "cache_saved_cost" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdateCacheSavedCost() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateCacheSavedCost()
+	})
+}
+
+// SetHedged sets the "hedged" field.
+func (u *UsageLogUpsertOne) SetHedged(v bool) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetHedged(v)
+	})
+}
+
+// UpdateHedged sets the "hedged" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdateHedged() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateHedged()
+	})
+}
+
+// SetHedgeBackupWon sets the "hedge_backup_won" field.
+func (u *UsageLogUpsertOne) SetHedgeBackupWon(v bool) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetHedgeBackupWon(v)
+	})
+}
+
+// UpdateHedgeBackupWon sets the "hedge_backup_won" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdateHedgeBackupWon() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateHedgeBackupWon()
+	})
+}
+
+// SetClientDisconnected sets the "client_disconnected" field.
+func (u *UsageLogUpsertOne) SetClientDisconnected(v bool) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetClientDisconnected(v)
+	})
+}
+
+// UpdateClientDisconnected sets the "client_disconnected" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdateClientDisconnected() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateClientDisconnected()
+	})
+}
+
+// SetPricingTier sets the "pricing_tier" field.
+func (u *UsageLogUpsertOne) SetPricingTier(v string) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetPricingTier(v)
+	})
+}
+
+// UpdatePricingTier sets the "pricing_tier" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdatePricingTier() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdatePricingTier()
+	})
+}
+
+// SetServiceTier sets the "service_tier" field.
+func (u *UsageLogUpsertOne) SetServiceTier(v string) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetServiceTier(v)
+	})
+}
+
+// UpdateServiceTier sets the "service_tier" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdateServiceTier() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateServiceTier()
+	})
+}
+
+// SetCostDetail sets the "cost_detail" field.
+func (u *UsageLogUpsertOne) SetCostDetail(v map[string]interface{}) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetCostDetail(v)
+	})
+}
+
+// UpdateCostDetail sets the "cost_detail" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdateCostDetail() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateCostDetail()
+	})
+}
+
+// ClearCostDetail clears the value of the "cost_detail" field.
+func (u *UsageLogUpsertOne) ClearCostDetail() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.ClearCostDetail()
+	})
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *UsageLogUpsertOne) SetOrganizationID(v int64) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetOrganizationID(v)
+	})
+}
+
+// AddOrganizationID adds v to the "organization_id" field.
+func (u *UsageLogUpsertOne) AddOrganizationID(v int64) *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.AddOrganizationID(v)
+	})
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *UsageLogUpsertOne) UpdateOrganizationID() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateOrganizationID()
+	})
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (u *UsageLogUpsertOne) ClearOrganizationID() *UsageLogUpsertOne {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.ClearOrganizationID()
+	})
+}
+
 // Exec executes the query.
 func (u *UsageLogUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -2839,6 +3338,160 @@ func (u *UsageLogUpsertBulk) ClearImageSize() *UsageLogUpsertBulk {
 	})
 }
 
+// SetCacheServed sets the "cache_served" field.
+func (u *UsageLogUpsertBulk) SetCacheServed(v bool) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetCacheServed(v)
+	})
+}
+
+// UpdateCacheServed sets the "cache_served" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdateCacheServed() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateCacheServed()
+	})
+}
+
+// SetCacheSavedCost sets the "cache_saved_cost" field.
+func (u *UsageLogUpsertBulk) SetCacheSavedCost(v float64) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetCacheSavedCost(v)
+	})
+}
+
+// AddCacheSavedCost adds v to the "cache_saved_cost" field.
+func (u *UsageLogUpsertBulk) AddCacheSavedCost(v float64) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.AddCacheSavedCost(v)
+	})
+}
+
+// UpdateCacheSavedCost sets the "cache_saved_cost" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdateCacheSavedCost() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateCacheSavedCost()
+	})
+}
+
+// SetHedged sets the "hedged" field.
+func (u *UsageLogUpsertBulk) SetHedged(v bool) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetHedged(v)
+	})
+}
+
+// UpdateHedged sets the "hedged" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdateHedged() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateHedged()
+	})
+}
+
+// SetHedgeBackupWon sets the "hedge_backup_won" field.
+func (u *UsageLogUpsertBulk) SetHedgeBackupWon(v bool) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetHedgeBackupWon(v)
+	})
+}
+
+// UpdateHedgeBackupWon sets the "hedge_backup_won" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdateHedgeBackupWon() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateHedgeBackupWon()
+	})
+}
+
+// SetClientDisconnected sets the "client_disconnected" field.
+func (u *UsageLogUpsertBulk) SetClientDisconnected(v bool) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetClientDisconnected(v)
+	})
+}
+
+// UpdateClientDisconnected sets the "client_disconnected" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdateClientDisconnected() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateClientDisconnected()
+	})
+}
+
+// SetPricingTier sets the "pricing_tier" field.
+func (u *UsageLogUpsertBulk) SetPricingTier(v string) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetPricingTier(v)
+	})
+}
+
+// UpdatePricingTier sets the "pricing_tier" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdatePricingTier() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdatePricingTier()
+	})
+}
+
+// SetServiceTier sets the "service_tier" field.
+func (u *UsageLogUpsertBulk) SetServiceTier(v string) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetServiceTier(v)
+	})
+}
+
+// UpdateServiceTier sets the "service_tier" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdateServiceTier() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateServiceTier()
+	})
+}
+
+// SetCostDetail sets the "cost_detail" field.
+func (u *UsageLogUpsertBulk) SetCostDetail(v map[string]interface{}) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetCostDetail(v)
+	})
+}
+
+// UpdateCostDetail sets the "cost_detail" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdateCostDetail() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateCostDetail()
+	})
+}
+
+// ClearCostDetail clears the value of the "cost_detail" field.
+func (u *UsageLogUpsertBulk) ClearCostDetail() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.ClearCostDetail()
+	})
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (u *UsageLogUpsertBulk) SetOrganizationID(v int64) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.SetOrganizationID(v)
+	})
+}
+
+// AddOrganizationID adds v to the "organization_id" field.
+func (u *UsageLogUpsertBulk) AddOrganizationID(v int64) *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.AddOrganizationID(v)
+	})
+}
+
+// UpdateOrganizationID sets the "organization_id" field to the value that was provided on create.
+func (u *UsageLogUpsertBulk) UpdateOrganizationID() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.UpdateOrganizationID()
+	})
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (u *UsageLogUpsertBulk) ClearOrganizationID() *UsageLogUpsertBulk {
+	return u.Update(func(s *UsageLogUpsert) {
+		s.ClearOrganizationID()
+	})
+}
+
 // Exec executes the query.
 func (u *UsageLogUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
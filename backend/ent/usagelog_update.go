@@ -612,6 +612,150 @@ func (_u *UsageLogUpdate) ClearImageSize() *UsageLogUpdate {
 	return _u
 }
 
+// SetCacheServed sets the "cache_served" field.
+func (_u *UsageLogUpdate) SetCacheServed(v bool) *UsageLogUpdate {
+	_u.mutation.SetCacheServed(v)
+	return _u
+}
+
+// SetNillableCacheServed sets the "cache_served" field if the given value is not nil.
+func (_u *UsageLogUpdate) SetNillableCacheServed(v *bool) *UsageLogUpdate {
+	if v != nil {
+		_u.SetCacheServed(*v)
+	}
+	return _u
+}
+
+// SetCacheSavedCost sets the "cache_saved_cost" field.
+func (_u *UsageLogUpdate) SetCacheSavedCost(v float64) *UsageLogUpdate {
+	_u.mutation.ResetCacheSavedCost()
+	_u.mutation.SetCacheSavedCost(v)
+	return _u
+}
+
+// SetNillableCacheSavedCost sets the "cache_saved_cost" field if the given value is not nil.
+func (_u *UsageLogUpdate) SetNillableCacheSavedCost(v *float64) *UsageLogUpdate {
+	if v != nil {
+		_u.SetCacheSavedCost(*v)
+	}
+	return _u
+}
+
+// AddCacheSavedCost adds value to the "cache_saved_cost" field.
+func (_u *UsageLogUpdate) AddCacheSavedCost(v float64) *UsageLogUpdate {
+	_u.mutation.AddCacheSavedCost(v)
+	return _u
+}
+
+// SetHedged sets the "hedged" field.
+func (_u *UsageLogUpdate) SetHedged(v bool) *UsageLogUpdate {
+	_u.mutation.SetHedged(v)
+	return _u
+}
+
+// SetNillableHedged sets the "hedged" field if the given value is not nil.
+func (_u *UsageLogUpdate) SetNillableHedged(v *bool) *UsageLogUpdate {
+	if v != nil {
+		_u.SetHedged(*v)
+	}
+	return _u
+}
+
+// SetHedgeBackupWon sets the "hedge_backup_won" field.
+func (_u *UsageLogUpdate) SetHedgeBackupWon(v bool) *UsageLogUpdate {
+	_u.mutation.SetHedgeBackupWon(v)
+	return _u
+}
+
+// SetNillableHedgeBackupWon sets the "hedge_backup_won" field if the given value is not nil.
+func (_u *UsageLogUpdate) SetNillableHedgeBackupWon(v *bool) *UsageLogUpdate {
+	if v != nil {
+		_u.SetHedgeBackupWon(*v)
+	}
+	return _u
+}
+
+// SetClientDisconnected sets the "client_disconnected" field.
+func (_u *UsageLogUpdate) SetClientDisconnected(v bool) *UsageLogUpdate {
+	_u.mutation.SetClientDisconnected(v)
+	return _u
+}
+
+// SetNillableClientDisconnected sets the "client_disconnected" field if the given value is not nil.
+func (_u *UsageLogUpdate) SetNillableClientDisconnected(v *bool) *UsageLogUpdate {
+	if v != nil {
+		_u.SetClientDisconnected(*v)
+	}
+	return _u
+}
+
+// SetPricingTier sets the "pricing_tier" field.
+func (_u *UsageLogUpdate) SetPricingTier(v string) *UsageLogUpdate {
+	_u.mutation.SetPricingTier(v)
+	return _u
+}
+
+// SetNillablePricingTier sets the "pricing_tier" field if the given value is not nil.
+func (_u *UsageLogUpdate) SetNillablePricingTier(v *string) *UsageLogUpdate {
+	if v != nil {
+		_u.SetPricingTier(*v)
+	}
+	return _u
+}
+
+// SetServiceTier sets the "service_tier" field.
+func (_u *UsageLogUpdate) SetServiceTier(v string) *UsageLogUpdate {
+	_u.mutation.SetServiceTier(v)
+	return _u
+}
+
+// SetNillableServiceTier sets the "service_tier" field if the given value is not nil.
+func (_u *UsageLogUpdate) SetNillableServiceTier(v *string) *UsageLogUpdate {
+	if v != nil {
+		_u.SetServiceTier(*v)
+	}
+	return _u
+}
+
+// SetCostDetail sets the "cost_detail" field.
+func (_u *UsageLogUpdate) SetCostDetail(v map[string]interface{}) *UsageLogUpdate {
+	_u.mutation.SetCostDetail(v)
+	return _u
+}
+
+// ClearCostDetail clears the value of the "cost_detail" field.
+func (_u *UsageLogUpdate) ClearCostDetail() *UsageLogUpdate {
+	_u.mutation.ClearCostDetail()
+	return _u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_u *UsageLogUpdate) SetOrganizationID(v int64) *UsageLogUpdate {
+	_u.mutation.ResetOrganizationID()
+	_u.mutation.SetOrganizationID(v)
+	return _u
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_u *UsageLogUpdate) SetNillableOrganizationID(v *int64) *UsageLogUpdate {
+	if v != nil {
+		_u.SetOrganizationID(*v)
+	}
+	return _u
+}
+
+// AddOrganizationID adds value to the "organization_id" field.
+func (_u *UsageLogUpdate) AddOrganizationID(v int64) *UsageLogUpdate {
+	_u.mutation.AddOrganizationID(v)
+	return _u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (_u *UsageLogUpdate) ClearOrganizationID() *UsageLogUpdate {
+	_u.mutation.ClearOrganizationID()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *UsageLogUpdate) SetUser(v *User) *UsageLogUpdate {
 	return _u.SetUserID(v.ID)
@@ -726,6 +870,16 @@ func (_u *UsageLogUpdate) check() error {
 			return &ValidationError{Name: "image_size", err: fmt.Errorf(`ent: validator failed for field "UsageLog.image_size": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.PricingTier(); ok {
+		if err := usagelog.PricingTierValidator(v); err != nil {
+			return &ValidationError{Name: "pricing_tier", err: fmt.Errorf(`ent: validator failed for field "UsageLog.pricing_tier": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ServiceTier(); ok {
+		if err := usagelog.ServiceTierValidator(v); err != nil {
+			return &ValidationError{Name: "service_tier", err: fmt.Errorf(`ent: validator failed for field "UsageLog.service_tier": %w`, err)}
+		}
+	}
 	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "UsageLog.user"`)
 	}
@@ -894,6 +1048,45 @@ func (_u *UsageLogUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.ImageSizeCleared() {
 		_spec.ClearField(usagelog.FieldImageSize, field.TypeString)
 	}
+	if value, ok := _u.mutation.CacheServed(); ok {
+		_spec.SetField(usagelog.FieldCacheServed, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CacheSavedCost(); ok {
+		_spec.SetField(usagelog.FieldCacheSavedCost, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedCacheSavedCost(); ok {
+		_spec.AddField(usagelog.FieldCacheSavedCost, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Hedged(); ok {
+		_spec.SetField(usagelog.FieldHedged, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.HedgeBackupWon(); ok {
+		_spec.SetField(usagelog.FieldHedgeBackupWon, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ClientDisconnected(); ok {
+		_spec.SetField(usagelog.FieldClientDisconnected, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.PricingTier(); ok {
+		_spec.SetField(usagelog.FieldPricingTier, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ServiceTier(); ok {
+		_spec.SetField(usagelog.FieldServiceTier, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CostDetail(); ok {
+		_spec.SetField(usagelog.FieldCostDetail, field.TypeJSON, value)
+	}
+	if _u.mutation.CostDetailCleared() {
+		_spec.ClearField(usagelog.FieldCostDetail, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.OrganizationID(); ok {
+		_spec.SetField(usagelog.FieldOrganizationID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedOrganizationID(); ok {
+		_spec.AddField(usagelog.FieldOrganizationID, field.TypeInt64, value)
+	}
+	if _u.mutation.OrganizationIDCleared() {
+		_spec.ClearField(usagelog.FieldOrganizationID, field.TypeInt64)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -1639,6 +1832,150 @@ func (_u *UsageLogUpdateOne) ClearImageSize() *UsageLogUpdateOne {
 	return _u
 }
 
+// SetCacheServed sets the "cache_served" field.
+func (_u *UsageLogUpdateOne) SetCacheServed(v bool) *UsageLogUpdateOne {
+	_u.mutation.SetCacheServed(v)
+	return _u
+}
+
+// SetNillableCacheServed sets the "cache_served" field if the given value is not nil.
+func (_u *UsageLogUpdateOne) SetNillableCacheServed(v *bool) *UsageLogUpdateOne {
+	if v != nil {
+		_u.SetCacheServed(*v)
+	}
+	return _u
+}
+
+// SetCacheSavedCost sets the "cache_saved_cost" field.
+func (_u *UsageLogUpdateOne) SetCacheSavedCost(v float64) *UsageLogUpdateOne {
+	_u.mutation.ResetCacheSavedCost()
+	_u.mutation.SetCacheSavedCost(v)
+	return _u
+}
+
+// SetNillableCacheSavedCost sets the "cache_saved_cost" field if the given value is not nil.
+func (_u *UsageLogUpdateOne) SetNillableCacheSavedCost(v *float64) *UsageLogUpdateOne {
+	if v != nil {
+		_u.SetCacheSavedCost(*v)
+	}
+	return _u
+}
+
+// AddCacheSavedCost adds value to the "cache_saved_cost" field.
+func (_u *UsageLogUpdateOne) AddCacheSavedCost(v float64) *UsageLogUpdateOne {
+	_u.mutation.AddCacheSavedCost(v)
+	return _u
+}
+
+// SetHedged sets the "hedged" field.
+func (_u *UsageLogUpdateOne) SetHedged(v bool) *UsageLogUpdateOne {
+	_u.mutation.SetHedged(v)
+	return _u
+}
+
+// SetNillableHedged sets the "hedged" field if the given value is not nil.
+func (_u *UsageLogUpdateOne) SetNillableHedged(v *bool) *UsageLogUpdateOne {
+	if v != nil {
+		_u.SetHedged(*v)
+	}
+	return _u
+}
+
+// SetHedgeBackupWon sets the "hedge_backup_won" field.
+func (_u *UsageLogUpdateOne) SetHedgeBackupWon(v bool) *UsageLogUpdateOne {
+	_u.mutation.SetHedgeBackupWon(v)
+	return _u
+}
+
+// SetNillableHedgeBackupWon sets the "hedge_backup_won" field if the given value is not nil.
+func (_u *UsageLogUpdateOne) SetNillableHedgeBackupWon(v *bool) *UsageLogUpdateOne {
+	if v != nil {
+		_u.SetHedgeBackupWon(*v)
+	}
+	return _u
+}
+
+// SetClientDisconnected sets the "client_disconnected" field.
+func (_u *UsageLogUpdateOne) SetClientDisconnected(v bool) *UsageLogUpdateOne {
+	_u.mutation.SetClientDisconnected(v)
+	return _u
+}
+
+// SetNillableClientDisconnected sets the "client_disconnected" field if the given value is not nil.
+func (_u *UsageLogUpdateOne) SetNillableClientDisconnected(v *bool) *UsageLogUpdateOne {
+	if v != nil {
+		_u.SetClientDisconnected(*v)
+	}
+	return _u
+}
+
+// SetPricingTier sets the "pricing_tier" field.
+func (_u *UsageLogUpdateOne) SetPricingTier(v string) *UsageLogUpdateOne {
+	_u.mutation.SetPricingTier(v)
+	return _u
+}
+
+// SetNillablePricingTier sets the "pricing_tier" field if the given value is not nil.
+func (_u *UsageLogUpdateOne) SetNillablePricingTier(v *string) *UsageLogUpdateOne {
+	if v != nil {
+		_u.SetPricingTier(*v)
+	}
+	return _u
+}
+
+// SetServiceTier sets the "service_tier" field.
+func (_u *UsageLogUpdateOne) SetServiceTier(v string) *UsageLogUpdateOne {
+	_u.mutation.SetServiceTier(v)
+	return _u
+}
+
+// SetNillableServiceTier sets the "service_tier" field if the given value is not nil.
+func (_u *UsageLogUpdateOne) SetNillableServiceTier(v *string) *UsageLogUpdateOne {
+	if v != nil {
+		_u.SetServiceTier(*v)
+	}
+	return _u
+}
+
+// SetCostDetail sets the "cost_detail" field.
+func (_u *UsageLogUpdateOne) SetCostDetail(v map[string]interface{}) *UsageLogUpdateOne {
+	_u.mutation.SetCostDetail(v)
+	return _u
+}
+
+// ClearCostDetail clears the value of the "cost_detail" field.
+func (_u *UsageLogUpdateOne) ClearCostDetail() *UsageLogUpdateOne {
+	_u.mutation.ClearCostDetail()
+	return _u
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (_u *UsageLogUpdateOne) SetOrganizationID(v int64) *UsageLogUpdateOne {
+	_u.mutation.ResetOrganizationID()
+	_u.mutation.SetOrganizationID(v)
+	return _u
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (_u *UsageLogUpdateOne) SetNillableOrganizationID(v *int64) *UsageLogUpdateOne {
+	if v != nil {
+		_u.SetOrganizationID(*v)
+	}
+	return _u
+}
+
+// AddOrganizationID adds value to the "organization_id" field.
+func (_u *UsageLogUpdateOne) AddOrganizationID(v int64) *UsageLogUpdateOne {
+	_u.mutation.AddOrganizationID(v)
+	return _u
+}
+
+// ClearOrganizationID clears the value of the "organization_id" field.
+func (_u *UsageLogUpdateOne) ClearOrganizationID() *UsageLogUpdateOne {
+	_u.mutation.ClearOrganizationID()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *UsageLogUpdateOne) SetUser(v *User) *UsageLogUpdateOne {
 	return _u.SetUserID(v.ID)
@@ -1766,6 +2103,16 @@ func (_u *UsageLogUpdateOne) check() error {
 			return &ValidationError{Name: "image_size", err: fmt.Errorf(`ent: validator failed for field "UsageLog.image_size": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.PricingTier(); ok {
+		if err := usagelog.PricingTierValidator(v); err != nil {
+			return &ValidationError{Name: "pricing_tier", err: fmt.Errorf(`ent: validator failed for field "UsageLog.pricing_tier": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ServiceTier(); ok {
+		if err := usagelog.ServiceTierValidator(v); err != nil {
+			return &ValidationError{Name: "service_tier", err: fmt.Errorf(`ent: validator failed for field "UsageLog.service_tier": %w`, err)}
+		}
+	}
 	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "UsageLog.user"`)
 	}
@@ -1951,6 +2298,45 @@ func (_u *UsageLogUpdateOne) sqlSave(ctx context.Context) (_node *UsageLog, err
 	if _u.mutation.ImageSizeCleared() {
 		_spec.ClearField(usagelog.FieldImageSize, field.TypeString)
 	}
+	if value, ok := _u.mutation.CacheServed(); ok {
+		_spec.SetField(usagelog.FieldCacheServed, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CacheSavedCost(); ok {
+		_spec.SetField(usagelog.FieldCacheSavedCost, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedCacheSavedCost(); ok {
+		_spec.AddField(usagelog.FieldCacheSavedCost, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.Hedged(); ok {
+		_spec.SetField(usagelog.FieldHedged, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.HedgeBackupWon(); ok {
+		_spec.SetField(usagelog.FieldHedgeBackupWon, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ClientDisconnected(); ok {
+		_spec.SetField(usagelog.FieldClientDisconnected, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.PricingTier(); ok {
+		_spec.SetField(usagelog.FieldPricingTier, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ServiceTier(); ok {
+		_spec.SetField(usagelog.FieldServiceTier, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CostDetail(); ok {
+		_spec.SetField(usagelog.FieldCostDetail, field.TypeJSON, value)
+	}
+	if _u.mutation.CostDetailCleared() {
+		_spec.ClearField(usagelog.FieldCostDetail, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.OrganizationID(); ok {
+		_spec.SetField(usagelog.FieldOrganizationID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedOrganizationID(); ok {
+		_spec.AddField(usagelog.FieldOrganizationID, field.TypeInt64, value)
+	}
+	if _u.mutation.OrganizationIDCleared() {
+		_spec.ClearField(usagelog.FieldOrganizationID, field.TypeInt64)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
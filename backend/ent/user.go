@@ -53,6 +53,10 @@ type User struct {
 	UsageReportSchedule string `json:"usage_report_schedule,omitempty"`
 	// UsageReportTimezone holds the value of the "usage_report_timezone" field.
 	UsageReportTimezone string `json:"usage_report_timezone,omitempty"`
+	// QueuePriority holds the value of the "queue_priority" field.
+	QueuePriority int `json:"queue_priority,omitempty"`
+	// QueueWeight holds the value of the "queue_weight" field.
+	QueueWeight int `json:"queue_weight,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the UserQuery when eager-loading is set.
 	Edges        UserEdges `json:"edges"`
@@ -174,7 +178,7 @@ func (*User) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullBool)
 		case user.FieldBalance:
 			values[i] = new(sql.NullFloat64)
-		case user.FieldID, user.FieldConcurrency:
+		case user.FieldID, user.FieldConcurrency, user.FieldQueuePriority, user.FieldQueueWeight:
 			values[i] = new(sql.NullInt64)
 		case user.FieldEmail, user.FieldPasswordHash, user.FieldRole, user.FieldStatus, user.FieldUsername, user.FieldNotes, user.FieldWechatOpenid, user.FieldTotpSecretEncrypted, user.FieldUsageReportSchedule, user.FieldUsageReportTimezone:
 			values[i] = new(sql.NullString)
@@ -312,6 +316,18 @@ func (_m *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.UsageReportTimezone = value.String
 			}
+		case user.FieldQueuePriority:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field queue_priority", values[i])
+			} else if value.Valid {
+				_m.QueuePriority = int(value.Int64)
+			}
+		case user.FieldQueueWeight:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field queue_weight", values[i])
+			} else if value.Valid {
+				_m.QueueWeight = int(value.Int64)
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -452,6 +468,12 @@ func (_m *User) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("usage_report_timezone=")
 	builder.WriteString(_m.UsageReportTimezone)
+	builder.WriteString(", ")
+	builder.WriteString("queue_priority=")
+	builder.WriteString(fmt.Sprintf("%v", _m.QueuePriority))
+	builder.WriteString(", ")
+	builder.WriteString("queue_weight=")
+	builder.WriteString(fmt.Sprintf("%v", _m.QueueWeight))
 	builder.WriteByte(')')
 	return builder.String()
 }
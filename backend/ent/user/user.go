@@ -51,6 +51,10 @@ const (
 	FieldUsageReportSchedule = "usage_report_schedule"
 	// FieldUsageReportTimezone holds the string denoting the usage_report_timezone field in the database.
 	FieldUsageReportTimezone = "usage_report_timezone"
+	// FieldQueuePriority holds the string denoting the queue_priority field in the database.
+	FieldQueuePriority = "queue_priority"
+	// FieldQueueWeight holds the string denoting the queue_weight field in the database.
+	FieldQueueWeight = "queue_weight"
 	// EdgeAPIKeys holds the string denoting the api_keys edge name in mutations.
 	EdgeAPIKeys = "api_keys"
 	// EdgeRedeemCodes holds the string denoting the redeem_codes edge name in mutations.
@@ -155,6 +159,8 @@ var Columns = []string{
 	FieldUsageReportEnabled,
 	FieldUsageReportSchedule,
 	FieldUsageReportTimezone,
+	FieldQueuePriority,
+	FieldQueueWeight,
 }
 
 var (
@@ -225,6 +231,10 @@ var (
 	DefaultUsageReportTimezone string
 	// UsageReportTimezoneValidator is a validator for the "usage_report_timezone" field. It is called by the builders before save.
 	UsageReportTimezoneValidator func(string) error
+	// DefaultQueuePriority holds the default value on creation for the "queue_priority" field.
+	DefaultQueuePriority int
+	// DefaultQueueWeight holds the default value on creation for the "queue_weight" field.
+	DefaultQueueWeight int
 )
 
 // OrderOption defines the ordering options for the User queries.
@@ -325,6 +335,16 @@ func ByUsageReportTimezone(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldUsageReportTimezone, opts...).ToFunc()
 }
 
+// ByQueuePriority orders the results by the queue_priority field.
+func ByQueuePriority(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldQueuePriority, opts...).ToFunc()
+}
+
+// ByQueueWeight orders the results by the queue_weight field.
+func ByQueueWeight(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldQueueWeight, opts...).ToFunc()
+}
+
 // ByAPIKeysCount orders the results by api_keys count.
 func ByAPIKeysCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
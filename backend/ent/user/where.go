@@ -145,6 +145,16 @@ func UsageReportTimezone(v string) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldUsageReportTimezone, v))
 }
 
+// QueuePriority applies equality check predicate on the "queue_priority" field. It's identical to QueuePriorityEQ.
+func QueuePriority(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldQueuePriority, v))
+}
+
+// QueueWeight applies equality check predicate on the "queue_weight" field. It's identical to QueueWeightEQ.
+func QueueWeight(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldQueueWeight, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldCreatedAt, v))
@@ -1085,6 +1095,86 @@ func UsageReportTimezoneContainsFold(v string) predicate.User {
 	return predicate.User(sql.FieldContainsFold(FieldUsageReportTimezone, v))
 }
 
+// QueuePriorityEQ applies the EQ predicate on the "queue_priority" field.
+func QueuePriorityEQ(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldQueuePriority, v))
+}
+
+// QueuePriorityNEQ applies the NEQ predicate on the "queue_priority" field.
+func QueuePriorityNEQ(v int) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldQueuePriority, v))
+}
+
+// QueuePriorityIn applies the In predicate on the "queue_priority" field.
+func QueuePriorityIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldIn(FieldQueuePriority, vs...))
+}
+
+// QueuePriorityNotIn applies the NotIn predicate on the "queue_priority" field.
+func QueuePriorityNotIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldQueuePriority, vs...))
+}
+
+// QueuePriorityGT applies the GT predicate on the "queue_priority" field.
+func QueuePriorityGT(v int) predicate.User {
+	return predicate.User(sql.FieldGT(FieldQueuePriority, v))
+}
+
+// QueuePriorityGTE applies the GTE predicate on the "queue_priority" field.
+func QueuePriorityGTE(v int) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldQueuePriority, v))
+}
+
+// QueuePriorityLT applies the LT predicate on the "queue_priority" field.
+func QueuePriorityLT(v int) predicate.User {
+	return predicate.User(sql.FieldLT(FieldQueuePriority, v))
+}
+
+// QueuePriorityLTE applies the LTE predicate on the "queue_priority" field.
+func QueuePriorityLTE(v int) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldQueuePriority, v))
+}
+
+// QueueWeightEQ applies the EQ predicate on the "queue_weight" field.
+func QueueWeightEQ(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldQueueWeight, v))
+}
+
+// QueueWeightNEQ applies the NEQ predicate on the "queue_weight" field.
+func QueueWeightNEQ(v int) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldQueueWeight, v))
+}
+
+// QueueWeightIn applies the In predicate on the "queue_weight" field.
+func QueueWeightIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldIn(FieldQueueWeight, vs...))
+}
+
+// QueueWeightNotIn applies the NotIn predicate on the "queue_weight" field.
+func QueueWeightNotIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldQueueWeight, vs...))
+}
+
+// QueueWeightGT applies the GT predicate on the "queue_weight" field.
+func QueueWeightGT(v int) predicate.User {
+	return predicate.User(sql.FieldGT(FieldQueueWeight, v))
+}
+
+// QueueWeightGTE applies the GTE predicate on the "queue_weight" field.
+func QueueWeightGTE(v int) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldQueueWeight, v))
+}
+
+// QueueWeightLT applies the LT predicate on the "queue_weight" field.
+func QueueWeightLT(v int) predicate.User {
+	return predicate.User(sql.FieldLT(FieldQueueWeight, v))
+}
+
+// QueueWeightLTE applies the LTE predicate on the "queue_weight" field.
+func QueueWeightLTE(v int) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldQueueWeight, v))
+}
+
 // HasAPIKeys applies the HasEdge predicate on the "api_keys" edge.
 func HasAPIKeys() predicate.User {
 	return predicate.User(func(s *sql.Selector) {
@@ -57,6 +57,10 @@ type Config struct {
 	Dashboard    DashboardCacheConfig       `mapstructure:"dashboard_cache"`
 	DashboardAgg DashboardAggregationConfig `mapstructure:"dashboard_aggregation"`
 	UsageCleanup UsageCleanupConfig         `mapstructure:"usage_cleanup"`
+	MessageBatch MessageBatchConfig         `mapstructure:"message_batch"`
+	Invoice      InvoiceConfig              `mapstructure:"invoice"`
+	Metrics      MetricsConfig              `mapstructure:"metrics"`
+	Webhook      WebhookConfig              `mapstructure:"webhook"`
 	Concurrency  ConcurrencyConfig          `mapstructure:"concurrency"`
 	TokenRefresh TokenRefreshConfig         `mapstructure:"token_refresh"`
 	RunMode      string                     `mapstructure:"run_mode" yaml:"run_mode"`
@@ -190,7 +194,33 @@ type ProxyProbeConfig struct {
 }
 
 type BillingConfig struct {
-	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	CircuitBreaker CircuitBreakerConfig     `mapstructure:"circuit_breaker"`
+	Reservation    BillingReservationConfig `mapstructure:"reservation"`
+	ServiceTiers   BillingServiceTierConfig `mapstructure:"service_tiers"`
+}
+
+// BillingServiceTierConfig 服务等级计费配置
+// 价格数据未提供对应服务等级的单价时，按以下系数换算标准价格；
+// 批处理（batch）沿用 message_batch.discount。
+type BillingServiceTierConfig struct {
+	// PriorityMultiplier 优先级（priority）请求价格系数
+	PriorityMultiplier float64 `mapstructure:"priority_multiplier"`
+	// FlexDiscount 弹性（flex）请求价格系数
+	FlexDiscount float64 `mapstructure:"flex_discount"`
+}
+
+// BillingReservationConfig 请求前费用预占配置
+// 转发前按输入 token 估算 + max_tokens 预估最大费用并在缓存中预占，
+// 用量落库后释放预占，避免并发请求在扣费前把余额/订阅额度透支。
+type BillingReservationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// TTLSeconds 预占过期时间（进程崩溃时预占最迟在此时间后自动失效）
+	TTLSeconds int `mapstructure:"ttl_seconds"`
+	// Strict 为 true 时要求 已预占+本次预估 <= 可用额度；
+	// 为 false 时只要求 已预占 < 可用额度（最多透支一个请求的费用，避免小余额用户无法发起长输出请求）
+	Strict bool `mapstructure:"strict"`
+	// DefaultOutputTokens 请求未声明 max_tokens 时按此输出 token 数估算
+	DefaultOutputTokens int `mapstructure:"default_output_tokens"`
 }
 
 type CircuitBreakerConfig struct {
@@ -198,6 +228,12 @@ type CircuitBreakerConfig struct {
 	FailureThreshold    int  `mapstructure:"failure_threshold"`
 	ResetTimeoutSeconds int  `mapstructure:"reset_timeout_seconds"`
 	HalfOpenRequests    int  `mapstructure:"half_open_requests"`
+
+	// 滚动窗口错误率熔断（仅 gateway.account_circuit_breaker 使用）：
+	// 窗口内请求数 >= MinRequests、失败数 >= FailureThreshold 且错误率 >= ErrorRateThreshold 时熔断
+	WindowSeconds      int     `mapstructure:"window_seconds"`
+	MinRequests        int     `mapstructure:"min_requests"`
+	ErrorRateThreshold float64 `mapstructure:"error_rate_threshold"`
 }
 
 type ConcurrencyConfig struct {
@@ -245,6 +281,9 @@ type GatewayConfig struct {
 	StreamDataIntervalTimeout int `mapstructure:"stream_data_interval_timeout"`
 	// StreamKeepaliveInterval: 流式 keepalive 间隔（秒），0表示禁用
 	StreamKeepaliveInterval int `mapstructure:"stream_keepalive_interval"`
+	// StreamDrainOnDisconnectTimeout: 客户端断开后继续读取上游流以获取最终 usage 的最长时间（秒），0表示禁用（断开即取消上游）；
+	// 排空期间继续占用用户与账号并发槽位
+	StreamDrainOnDisconnectTimeout int `mapstructure:"stream_drain_on_disconnect_timeout"`
 	// MaxLineSize: 上游 SSE 单行最大字节数（0使用默认值）
 	MaxLineSize int `mapstructure:"max_line_size"`
 
@@ -270,8 +309,24 @@ type GatewayConfig struct {
 	// Scheduling: 账号调度相关配置
 	Scheduling GatewaySchedulingConfig `mapstructure:"scheduling"`
 
+	// AccountCircuitBreaker: 按账号滚动错误率熔断（Redis 共享状态）
+	AccountCircuitBreaker CircuitBreakerConfig `mapstructure:"account_circuit_breaker"`
+
 	// TLSFingerprint: TLS指纹伪装配置
 	TLSFingerprint TLSFingerprintConfig `mapstructure:"tls_fingerprint"`
+
+	// ResponseCache: 响应缓存容量与有效期（是否启用由分组配置决定）
+	ResponseCache GatewayResponseCacheConfig `mapstructure:"response_cache"`
+}
+
+// GatewayResponseCacheConfig 确定性非流式请求的响应缓存配置
+type GatewayResponseCacheConfig struct {
+	// MaxEntryBytes: 单条缓存响应体最大字节数，超过则不缓存
+	MaxEntryBytes int `mapstructure:"max_entry_bytes"`
+	// DefaultTTLSeconds: 分组未配置有效期时使用的默认值（秒）
+	DefaultTTLSeconds int `mapstructure:"default_ttl_seconds"`
+	// MaxTTLSeconds: 分组可配置的最大有效期（秒）
+	MaxTTLSeconds int `mapstructure:"max_ttl_seconds"`
 }
 
 // TLSFingerprintConfig TLS指纹伪装配置
@@ -339,6 +394,10 @@ type GatewaySchedulingConfig struct {
 	// 全量重建周期配置
 	// 全量重建周期（秒），0 表示禁用
 	FullRebuildIntervalSeconds int `mapstructure:"full_rebuild_interval_seconds"`
+
+	// 公平排队：账号槽位已满时按用户公平份额与优先级在 Redis 队列中排队，
+	// 关闭时退回各请求独立轮询抢占槽位
+	FairQueueEnabled bool `mapstructure:"fair_queue_enabled"`
 }
 
 func (s *ServerConfig) Address() string {
@@ -557,6 +616,56 @@ type UsageCleanupConfig struct {
 	TaskTimeoutSeconds int `mapstructure:"task_timeout_seconds"`
 }
 
+// MessageBatchConfig Anthropic Message Batches 延迟计费配置
+type MessageBatchConfig struct {
+	// Enabled: 是否启用批处理结果轮询与结算
+	Enabled bool `mapstructure:"enabled"`
+	// PollIntervalSeconds: 后台轮询间隔（秒）
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	// PollBatchSize: 单次轮询处理的批次数量
+	PollBatchSize int `mapstructure:"poll_batch_size"`
+	// Discount: 批处理费用系数（Anthropic 批处理为标准价格的 50%）
+	Discount float64 `mapstructure:"discount"`
+	// SettleTimeoutSeconds: 单个批次结算（下载并解析结果）的最大时长（秒）
+	SettleTimeoutSeconds int `mapstructure:"settle_timeout_seconds"`
+}
+
+// InvoiceConfig 月度账单配置
+type InvoiceConfig struct {
+	// Enabled: 是否在每月初自动为上月有活动的用户生成账单
+	Enabled bool `mapstructure:"enabled"`
+	// GenerateDelayHours: 月初延迟生成的小时数（等待批处理等延迟结算的用量入账）
+	GenerateDelayHours int `mapstructure:"generate_delay_hours"`
+	// IssuerName: 账单抬头，为空时使用站点名称
+	IssuerName string `mapstructure:"issuer_name"`
+	// IssuerAddress: 抬头下方的地址/联系方式（可多行）
+	IssuerAddress string `mapstructure:"issuer_address"`
+}
+
+// MetricsConfig Prometheus 指标导出配置
+type MetricsConfig struct {
+	// Enabled: 是否暴露 /metrics 端点
+	Enabled bool `mapstructure:"enabled"`
+	// Token: 抓取令牌，请求需携带 Authorization: Bearer <token>
+	Token string `mapstructure:"token"`
+}
+
+// WebhookConfig 出站 Webhook 投递配置
+type WebhookConfig struct {
+	// Enabled: 是否启用 Webhook 事件投递
+	Enabled bool `mapstructure:"enabled"`
+	// TimeoutSeconds: 单次投递 HTTP 请求超时（秒）
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+	// MaxAttempts: 单条投递最大尝试次数（含首次），超过后标记为失败
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// RetryBaseSeconds: 重试退避基数（秒），第 n 次重试等待 base * 2^(n-1)
+	RetryBaseSeconds int `mapstructure:"retry_base_seconds"`
+	// PollIntervalSeconds: 后台扫描待重试投递的间隔（秒）
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	// RetentionDays: 投递日志保留天数（0 表示不清理）
+	RetentionDays int `mapstructure:"retention_days"`
+}
+
 func NormalizeRunMode(value string) string {
 	normalized := strings.ToLower(strings.TrimSpace(value))
 	switch normalized {
@@ -720,6 +829,12 @@ func setDefaults() {
 	viper.SetDefault("billing.circuit_breaker.failure_threshold", 5)
 	viper.SetDefault("billing.circuit_breaker.reset_timeout_seconds", 30)
 	viper.SetDefault("billing.circuit_breaker.half_open_requests", 3)
+	viper.SetDefault("billing.reservation.enabled", true)
+	viper.SetDefault("billing.reservation.ttl_seconds", 600)
+	viper.SetDefault("billing.reservation.strict", false)
+	viper.SetDefault("billing.reservation.default_output_tokens", 4096)
+	viper.SetDefault("billing.service_tiers.priority_multiplier", 1.0)
+	viper.SetDefault("billing.service_tiers.flex_discount", 0.5)
 
 	// Turnstile
 	viper.SetDefault("turnstile.required", false)
@@ -841,6 +956,31 @@ func setDefaults() {
 	viper.SetDefault("usage_cleanup.worker_interval_seconds", 10)
 	viper.SetDefault("usage_cleanup.task_timeout_seconds", 1800)
 
+	// Message batches
+	viper.SetDefault("message_batch.enabled", true)
+	viper.SetDefault("message_batch.poll_interval_seconds", 60)
+	viper.SetDefault("message_batch.poll_batch_size", 20)
+	viper.SetDefault("message_batch.discount", 0.5)
+	viper.SetDefault("message_batch.settle_timeout_seconds", 600)
+
+	// Invoice
+	viper.SetDefault("invoice.enabled", true)
+	viper.SetDefault("invoice.generate_delay_hours", 6)
+	viper.SetDefault("invoice.issuer_name", "")
+	viper.SetDefault("invoice.issuer_address", "")
+
+	// Metrics
+	viper.SetDefault("metrics.enabled", false)
+	viper.SetDefault("metrics.token", "")
+
+	// Webhook
+	viper.SetDefault("webhook.enabled", true)
+	viper.SetDefault("webhook.timeout_seconds", 10)
+	viper.SetDefault("webhook.max_attempts", 6)
+	viper.SetDefault("webhook.retry_base_seconds", 30)
+	viper.SetDefault("webhook.poll_interval_seconds", 15)
+	viper.SetDefault("webhook.retention_days", 30)
+
 	// Gateway
 	viper.SetDefault("gateway.response_header_timeout", 600) // 600秒(10分钟)等待上游响应头，LLM高负载时可能排队较久
 	viper.SetDefault("gateway.log_upstream_error_body", true)
@@ -862,6 +1002,7 @@ func setDefaults() {
 	viper.SetDefault("gateway.concurrency_slot_ttl_minutes", 30) // 并发槽位过期时间（支持超长请求）
 	viper.SetDefault("gateway.stream_data_interval_timeout", 180)
 	viper.SetDefault("gateway.stream_keepalive_interval", 10)
+	viper.SetDefault("gateway.stream_drain_on_disconnect_timeout", 0)
 	viper.SetDefault("gateway.max_line_size", 40*1024*1024)
 	viper.SetDefault("gateway.scheduling.sticky_session_max_waiting", 3)
 	viper.SetDefault("gateway.scheduling.sticky_session_wait_timeout", 120*time.Second)
@@ -879,8 +1020,21 @@ func setDefaults() {
 	viper.SetDefault("gateway.scheduling.outbox_lag_rebuild_failures", 3)
 	viper.SetDefault("gateway.scheduling.outbox_backlog_rebuild_rows", 10000)
 	viper.SetDefault("gateway.scheduling.full_rebuild_interval_seconds", 300)
+	viper.SetDefault("gateway.scheduling.fair_queue_enabled", true)
+	// 账号熔断（默认关闭）
+	viper.SetDefault("gateway.account_circuit_breaker.enabled", false)
+	viper.SetDefault("gateway.account_circuit_breaker.window_seconds", 60)
+	viper.SetDefault("gateway.account_circuit_breaker.min_requests", 20)
+	viper.SetDefault("gateway.account_circuit_breaker.failure_threshold", 5)
+	viper.SetDefault("gateway.account_circuit_breaker.error_rate_threshold", 0.3)
+	viper.SetDefault("gateway.account_circuit_breaker.reset_timeout_seconds", 60)
+	viper.SetDefault("gateway.account_circuit_breaker.half_open_requests", 3)
 	// TLS指纹伪装配置（默认关闭，需要账号级别单独启用）
 	viper.SetDefault("gateway.tls_fingerprint.enabled", true)
+	// 响应缓存（按分组开启）
+	viper.SetDefault("gateway.response_cache.max_entry_bytes", 1024*1024)
+	viper.SetDefault("gateway.response_cache.default_ttl_seconds", 3600)
+	viper.SetDefault("gateway.response_cache.max_ttl_seconds", 7*24*3600)
 	viper.SetDefault("concurrency.ping_interval", 10)
 
 	// TokenRefresh
@@ -978,6 +1132,40 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("billing.circuit_breaker.half_open_requests must be positive")
 		}
 	}
+	if r := c.Billing.Reservation; r.Enabled {
+		if r.TTLSeconds <= 0 {
+			return fmt.Errorf("billing.reservation.ttl_seconds must be positive")
+		}
+		if r.DefaultOutputTokens < 0 {
+			return fmt.Errorf("billing.reservation.default_output_tokens must be non-negative")
+		}
+	}
+	if c.Billing.ServiceTiers.PriorityMultiplier <= 0 {
+		return fmt.Errorf("billing.service_tiers.priority_multiplier must be positive")
+	}
+	if d := c.Billing.ServiceTiers.FlexDiscount; d <= 0 || d > 1 {
+		return fmt.Errorf("billing.service_tiers.flex_discount must be between 0 and 1")
+	}
+	if cb := c.Gateway.AccountCircuitBreaker; cb.Enabled {
+		if cb.WindowSeconds <= 0 {
+			return fmt.Errorf("gateway.account_circuit_breaker.window_seconds must be positive")
+		}
+		if cb.MinRequests <= 0 {
+			return fmt.Errorf("gateway.account_circuit_breaker.min_requests must be positive")
+		}
+		if cb.FailureThreshold <= 0 {
+			return fmt.Errorf("gateway.account_circuit_breaker.failure_threshold must be positive")
+		}
+		if cb.ErrorRateThreshold <= 0 || cb.ErrorRateThreshold > 1 {
+			return fmt.Errorf("gateway.account_circuit_breaker.error_rate_threshold must be in (0, 1]")
+		}
+		if cb.ResetTimeoutSeconds <= 0 {
+			return fmt.Errorf("gateway.account_circuit_breaker.reset_timeout_seconds must be positive")
+		}
+		if cb.HalfOpenRequests <= 0 {
+			return fmt.Errorf("gateway.account_circuit_breaker.half_open_requests must be positive")
+		}
+	}
 	if c.Database.MaxOpenConns <= 0 {
 		return fmt.Errorf("database.max_open_conns must be positive")
 	}
@@ -1110,6 +1298,43 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("usage_cleanup.task_timeout_seconds must be non-negative")
 		}
 	}
+	if c.MessageBatch.Enabled {
+		if c.MessageBatch.PollIntervalSeconds <= 0 {
+			return fmt.Errorf("message_batch.poll_interval_seconds must be positive")
+		}
+		if c.MessageBatch.PollBatchSize <= 0 {
+			return fmt.Errorf("message_batch.poll_batch_size must be positive")
+		}
+		if c.MessageBatch.SettleTimeoutSeconds <= 0 {
+			return fmt.Errorf("message_batch.settle_timeout_seconds must be positive")
+		}
+	}
+	if c.MessageBatch.Discount < 0 || c.MessageBatch.Discount > 1 {
+		return fmt.Errorf("message_batch.discount must be between 0 and 1")
+	}
+	if c.Invoice.GenerateDelayHours < 0 || c.Invoice.GenerateDelayHours > 24*27 {
+		return fmt.Errorf("invoice.generate_delay_hours must be between 0 and 648")
+	}
+	if c.Metrics.Enabled && strings.TrimSpace(c.Metrics.Token) == "" {
+		return fmt.Errorf("metrics.token is required when metrics.enabled=true")
+	}
+	if c.Webhook.Enabled {
+		if c.Webhook.TimeoutSeconds <= 0 {
+			return fmt.Errorf("webhook.timeout_seconds must be positive")
+		}
+		if c.Webhook.MaxAttempts <= 0 {
+			return fmt.Errorf("webhook.max_attempts must be positive")
+		}
+		if c.Webhook.RetryBaseSeconds <= 0 {
+			return fmt.Errorf("webhook.retry_base_seconds must be positive")
+		}
+		if c.Webhook.PollIntervalSeconds <= 0 {
+			return fmt.Errorf("webhook.poll_interval_seconds must be positive")
+		}
+	}
+	if c.Webhook.RetentionDays < 0 {
+		return fmt.Errorf("webhook.retention_days must be non-negative")
+	}
 	if c.Gateway.MaxBodySize <= 0 {
 		return fmt.Errorf("gateway.max_body_size must be positive")
 	}
@@ -1159,12 +1384,24 @@ func (c *Config) Validate() error {
 		(c.Gateway.StreamKeepaliveInterval < 5 || c.Gateway.StreamKeepaliveInterval > 30) {
 		return fmt.Errorf("gateway.stream_keepalive_interval must be 0 or between 5-30 seconds")
 	}
+	if c.Gateway.StreamDrainOnDisconnectTimeout < 0 || c.Gateway.StreamDrainOnDisconnectTimeout > 600 {
+		return fmt.Errorf("gateway.stream_drain_on_disconnect_timeout must be between 0-600 seconds")
+	}
 	if c.Gateway.MaxLineSize < 0 {
 		return fmt.Errorf("gateway.max_line_size must be non-negative")
 	}
 	if c.Gateway.MaxLineSize != 0 && c.Gateway.MaxLineSize < 1024*1024 {
 		return fmt.Errorf("gateway.max_line_size must be at least 1MB")
 	}
+	if c.Gateway.ResponseCache.MaxEntryBytes <= 0 {
+		return fmt.Errorf("gateway.response_cache.max_entry_bytes must be positive")
+	}
+	if c.Gateway.ResponseCache.DefaultTTLSeconds <= 0 {
+		return fmt.Errorf("gateway.response_cache.default_ttl_seconds must be positive")
+	}
+	if c.Gateway.ResponseCache.MaxTTLSeconds < c.Gateway.ResponseCache.DefaultTTLSeconds {
+		return fmt.Errorf("gateway.response_cache.max_ttl_seconds must be >= default_ttl_seconds")
+	}
 	if c.Gateway.Scheduling.StickySessionMaxWaiting <= 0 {
 		return fmt.Errorf("gateway.scheduling.sticky_session_max_waiting must be positive")
 	}
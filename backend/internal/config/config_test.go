@@ -799,6 +799,11 @@ func TestValidateConfigErrors(t *testing.T) {
 			mutate:  func(c *Config) { c.Gateway.MaxLineSize = -1 },
 			wantErr: "gateway.max_line_size must be non-negative",
 		},
+		{
+			name:    "gateway response cache ttl bounds",
+			mutate:  func(c *Config) { c.Gateway.ResponseCache.MaxTTLSeconds = 1 },
+			wantErr: "gateway.response_cache.max_ttl_seconds",
+		},
 		{
 			name:    "gateway scheduling sticky waiting",
 			mutate:  func(c *Config) { c.Gateway.Scheduling.StickySessionMaxWaiting = 0 },
@@ -837,6 +842,11 @@ func TestValidateConfigErrors(t *testing.T) {
 			mutate:  func(c *Config) { c.Ops.Cleanup.MinuteMetricsRetentionDays = -1 },
 			wantErr: "ops.cleanup.minute_metrics_retention_days",
 		},
+		{
+			name:    "metrics token required",
+			mutate:  func(c *Config) { c.Metrics.Enabled = true },
+			wantErr: "metrics.token is required",
+		},
 	}
 
 	for _, tt := range cases {
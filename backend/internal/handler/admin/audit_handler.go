@@ -0,0 +1,396 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// auditMaxBodyBytes 审计记录保存的请求体上限，超出部分不记录
+const auditMaxBodyBytes = 64 * 1024
+
+// auditSkipRoutes 使用 POST 但不修改数据的管理接口（按路由模板匹配，不含 /api/v1/admin 前缀）
+var auditSkipRoutes = map[string]struct{}{
+	"/dashboard/users-usage":    {},
+	"/dashboard/api-keys-usage": {},
+	"/user-attributes/batch":    {},
+}
+
+// auditSingletonTargets 没有 ID 的全局目标实体
+var auditSingletonTargets = map[string]struct{}{
+	"settings": {},
+}
+
+type auditSnapshotFunc func(ctx context.Context, id int64) (any, error)
+
+// AuditHandler 记录管理后台写操作并提供审计日志查询
+type AuditHandler struct {
+	auditLogService *service.AuditLogService
+	snapshots       map[string]auditSnapshotFunc
+}
+
+// NewAuditHandler creates a new admin audit handler
+func NewAuditHandler(
+	auditLogService *service.AuditLogService,
+	adminService service.AdminService,
+	promoService *service.PromoService,
+	subscriptionService *service.SubscriptionService,
+	settingService *service.SettingService,
+	webhookService *service.WebhookService,
+) *AuditHandler {
+	h := &AuditHandler{
+		auditLogService: auditLogService,
+		snapshots:       make(map[string]auditSnapshotFunc),
+	}
+
+	// 快照使用管理接口返回的 DTO，字段名与前端一致
+	if adminService != nil {
+		h.snapshots["users"] = func(ctx context.Context, id int64) (any, error) {
+			user, err := adminService.GetUser(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return dto.UserFromServiceAdmin(user), nil
+		}
+		h.snapshots["groups"] = func(ctx context.Context, id int64) (any, error) {
+			group, err := adminService.GetGroup(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return dto.GroupFromServiceAdmin(group), nil
+		}
+		h.snapshots["accounts"] = func(ctx context.Context, id int64) (any, error) {
+			account, err := adminService.GetAccount(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return dto.AccountFromService(account), nil
+		}
+		h.snapshots["proxies"] = func(ctx context.Context, id int64) (any, error) {
+			proxy, err := adminService.GetProxy(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return dto.ProxyFromService(proxy), nil
+		}
+		h.snapshots["redeem-codes"] = func(ctx context.Context, id int64) (any, error) {
+			code, err := adminService.GetRedeemCode(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return dto.RedeemCodeFromService(code), nil
+		}
+	}
+	if promoService != nil {
+		h.snapshots["promo-codes"] = func(ctx context.Context, id int64) (any, error) {
+			code, err := promoService.GetByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return dto.PromoCodeFromService(code), nil
+		}
+	}
+	if subscriptionService != nil {
+		h.snapshots["subscriptions"] = func(ctx context.Context, id int64) (any, error) {
+			sub, err := subscriptionService.GetByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return dto.UserSubscriptionFromService(sub), nil
+		}
+	}
+	if settingService != nil {
+		// 服务层结构包含明文密钥，由审计脱敏规则统一处理
+		h.snapshots["settings"] = func(ctx context.Context, _ int64) (any, error) {
+			return settingService.GetAllSettings(ctx)
+		}
+	}
+	if webhookService != nil {
+		h.snapshots["webhooks"] = func(ctx context.Context, id int64) (any, error) {
+			endpoint, err := webhookService.GetEndpoint(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return dto.WebhookEndpointFromService(endpoint), nil
+		}
+	}
+	return h
+}
+
+// Middleware 记录管理后台的写操作（POST/PUT/PATCH/DELETE），需挂在管理员认证之后
+func (h *AuditHandler) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h == nil || h.auditLogService == nil || !isAuditedMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+		route := c.FullPath()
+		if _, skip := auditSkipRoutes[adminRelativeRoute(route)]; skip {
+			c.Next()
+			return
+		}
+
+		targetType, targetID := auditTarget(route, c.Params)
+		body := captureAuditBody(c)
+
+		snapshot := h.snapshotFor(targetType, targetID)
+		var before any
+		if snapshot != nil {
+			before, _ = snapshot(c.Request.Context())
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		var after any
+		if snapshot != nil && before != nil && status < http.StatusBadRequest {
+			// 删除成功后读取失败，after 为空即表示整条记录被删除
+			after, _ = snapshot(c.Request.Context())
+		}
+
+		record := &service.AuditLogRecord{
+			AuthMethod:  c.GetString("auth_method"),
+			IP:          ip.GetClientIP(c),
+			UserAgent:   c.Request.UserAgent(),
+			Method:      c.Request.Method,
+			Route:       route,
+			Path:        c.Request.URL.Path,
+			TargetType:  targetType,
+			TargetID:    targetID,
+			StatusCode:  status,
+			RequestBody: body,
+		}
+		if subject, ok := middleware.GetAuthSubjectFromContext(c); ok {
+			actorID := subject.UserID
+			record.ActorUserID = &actorID
+		}
+		if before != nil {
+			record.Before = before
+			record.After = after
+		}
+		h.auditLogService.Record(c.Request.Context(), record)
+	}
+}
+
+func (h *AuditHandler) snapshotFor(targetType, targetID string) func(ctx context.Context) (any, error) {
+	load, ok := h.snapshots[targetType]
+	if !ok {
+		return nil
+	}
+	var id int64
+	if _, singleton := auditSingletonTargets[targetType]; !singleton {
+		parsed, err := strconv.ParseInt(targetID, 10, 64)
+		if err != nil || parsed <= 0 {
+			return nil
+		}
+		id = parsed
+	}
+	return func(ctx context.Context) (any, error) {
+		return load(ctx, id)
+	}
+}
+
+func isAuditedMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// adminRelativeRoute 去掉路由模板中 /admin 及之前的前缀
+func adminRelativeRoute(route string) string {
+	if idx := strings.Index(route, "/admin/"); idx >= 0 {
+		return route[idx+len("/admin"):]
+	}
+	return route
+}
+
+// auditTarget 从路由模板推导目标实体：
+// 取 :id 参数前的资源段（/users/:id/balance -> users），无 :id 时取第一段（/settings -> settings）。
+func auditTarget(route string, params gin.Params) (string, string) {
+	segments := strings.Split(strings.Trim(adminRelativeRoute(route), "/"), "/")
+	for i, segment := range segments {
+		if segment == ":id" && i > 0 {
+			return segments[i-1], params.ByName("id")
+		}
+	}
+	if len(segments) > 0 && !strings.HasPrefix(segments[0], ":") {
+		return segments[0], ""
+	}
+	return "", ""
+}
+
+// captureAuditBody 读取 JSON 请求体副本并还原 Body，供后续 handler 正常绑定
+func captureAuditBody(c *gin.Context) []byte {
+	if c.Request.Body == nil || !strings.Contains(c.ContentType(), "json") {
+		return nil
+	}
+	buf, err := io.ReadAll(io.LimitReader(c.Request.Body, auditMaxBodyBytes+1))
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(buf), c.Request.Body))
+	if err != nil || len(buf) > auditMaxBodyBytes {
+		return nil
+	}
+	return buf
+}
+
+// List handles listing audit logs
+// GET /api/v1/admin/audit-logs
+func (h *AuditHandler) List(c *gin.Context) {
+	filter, ok := parseAuditLogFilter(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	logs, paginationResult, err := h.auditLogService.List(c.Request.Context(), filter, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.AuditLog, 0, len(logs))
+	for i := range logs {
+		out = append(out, *dto.AuditLogFromService(&logs[i]))
+	}
+	response.Paginated(c, out, paginationResult.Total, page, pageSize)
+}
+
+// Export handles exporting audit logs as CSV (default) or JSON
+// GET /api/v1/admin/audit-logs/export?format=csv|json
+func (h *AuditHandler) Export(c *gin.Context) {
+	filter, ok := parseAuditLogFilter(c)
+	if !ok {
+		return
+	}
+	format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", "csv")))
+	if format != "csv" && format != "json" {
+		response.BadRequest(c, "Invalid format")
+		return
+	}
+
+	logs, err := h.auditLogService.Export(c.Request.Context(), filter)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	if format == "json" {
+		out := make([]dto.AuditLog, 0, len(logs))
+		for i := range logs {
+			out = append(out, *dto.AuditLogFromService(&logs[i]))
+		}
+		c.Header("Content-Disposition", "attachment; filename=audit_logs.json")
+		c.JSON(http.StatusOK, out)
+		return
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"id", "created_at", "actor_user_id", "actor_email", "auth_method", "ip", "method", "route", "path", "target_type", "target_id", "status_code", "diff", "request_body"}); err != nil {
+		response.InternalError(c, "Failed to export audit logs: "+err.Error())
+		return
+	}
+	for i := range logs {
+		l := &logs[i]
+		actorID := ""
+		if l.ActorUserID != nil {
+			actorID = strconv.FormatInt(*l.ActorUserID, 10)
+		}
+		diff := ""
+		if len(l.Diff) > 0 {
+			if raw, err := json.Marshal(l.Diff); err == nil {
+				diff = string(raw)
+			}
+		}
+		requestBody := ""
+		if l.RequestBody != nil {
+			requestBody = *l.RequestBody
+		}
+		if err := writer.Write([]string{
+			strconv.FormatInt(l.ID, 10),
+			l.CreatedAt.UTC().Format(time.RFC3339),
+			actorID,
+			l.ActorEmail,
+			l.AuthMethod,
+			l.IP,
+			l.Method,
+			l.Route,
+			l.Path,
+			l.TargetType,
+			l.TargetID,
+			strconv.Itoa(l.StatusCode),
+			diff,
+			requestBody,
+		}); err != nil {
+			response.InternalError(c, "Failed to export audit logs: "+err.Error())
+			return
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		response.InternalError(c, "Failed to export audit logs: "+err.Error())
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename=audit_logs.csv")
+	c.Data(200, "text/csv", buf.Bytes())
+}
+
+// parseAuditLogFilter 解析查询条件；失败时已写入 400 响应
+func parseAuditLogFilter(c *gin.Context) (service.AuditLogFilter, bool) {
+	filter := service.AuditLogFilter{
+		AuthMethod: strings.TrimSpace(c.Query("auth_method")),
+		TargetType: strings.TrimSpace(c.Query("target_type")),
+		TargetID:   strings.TrimSpace(c.Query("target_id")),
+		Method:     strings.TrimSpace(c.Query("method")),
+		Route:      strings.TrimSpace(c.Query("route")),
+	}
+	if raw := strings.TrimSpace(c.Query("actor_user_id")); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "Invalid actor_user_id")
+			return filter, false
+		}
+		filter.ActorUserID = &id
+	}
+	for _, item := range []struct {
+		key string
+		dst **time.Time
+	}{{"start_time", &filter.StartTime}, {"end_time", &filter.EndTime}} {
+		raw := strings.TrimSpace(c.Query(item.key))
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			response.BadRequest(c, "Invalid "+item.key+", use RFC3339 format")
+			return filter, false
+		}
+		*item.dst = &t
+	}
+	return filter, true
+}
@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type auditLogRepoStub struct {
+	created []*service.AuditLog
+}
+
+func (r *auditLogRepoStub) Create(ctx context.Context, log *service.AuditLog) error {
+	r.created = append(r.created, log)
+	return nil
+}
+
+func (r *auditLogRepoStub) List(ctx context.Context, filter service.AuditLogFilter, params pagination.PaginationParams) ([]service.AuditLog, *pagination.PaginationResult, error) {
+	return nil, &pagination.PaginationResult{}, nil
+}
+
+func (r *auditLogRepoStub) ListForExport(ctx context.Context, filter service.AuditLogFilter, limit int) ([]service.AuditLog, error) {
+	return nil, nil
+}
+
+func TestAuditTarget(t *testing.T) {
+	params := gin.Params{{Key: "id", Value: "42"}}
+	cases := []struct {
+		route      string
+		targetType string
+		targetID   string
+	}{
+		{"/api/v1/admin/users/:id/balance", "users", "42"},
+		{"/api/v1/admin/openai/accounts/:id/refresh", "accounts", "42"},
+		{"/api/v1/admin/webhooks/deliveries/:id/retry", "deliveries", "42"},
+		{"/api/v1/admin/settings/admin-api-key/regenerate", "settings", ""},
+		{"/api/v1/admin/accounts/batch-update-credentials", "accounts", ""},
+	}
+	for _, tc := range cases {
+		targetType, targetID := auditTarget(tc.route, params)
+		require.Equal(t, tc.targetType, targetType, tc.route)
+		require.Equal(t, tc.targetID, targetID, tc.route)
+	}
+}
+
+func TestAuditMiddleware_RecordsBalanceChange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &auditLogRepoStub{}
+	adminSvc := newStubAdminService()
+	adminSvc.users[0].Balance = 10
+	h := NewAuditHandler(service.NewAuditLogService(repo), adminSvc, nil, nil, nil, nil)
+
+	router := gin.New()
+	admin := router.Group("/api/v1/admin")
+	admin.Use(func(c *gin.Context) {
+		c.Set(string(middleware.ContextKeyUser), middleware.AuthSubject{UserID: 9})
+		c.Set("auth_method", "jwt")
+		c.Next()
+	})
+	admin.Use(h.Middleware())
+	admin.POST("/users/:id/balance", func(c *gin.Context) {
+		var req struct {
+			Balance float64 `json:"balance"`
+		}
+		require.NoError(t, c.ShouldBindJSON(&req))
+		adminSvc.users[0].Balance += req.Balance
+		response.Success(c, nil)
+	})
+	admin.POST("/dashboard/users-usage", func(c *gin.Context) { response.Success(c, nil) })
+	admin.GET("/users/:id", func(c *gin.Context) { response.Success(c, nil) })
+
+	body := []byte(`{"balance":5,"operation":"add","notes":"refund","password":"x"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/1/balance", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, float64(15), adminSvc.users[0].Balance)
+
+	require.Len(t, repo.created, 1)
+	log := repo.created[0]
+	require.Equal(t, int64(9), *log.ActorUserID)
+	require.Equal(t, "jwt", log.AuthMethod)
+	require.Equal(t, "/api/v1/admin/users/:id/balance", log.Route)
+	require.Equal(t, "users", log.TargetType)
+	require.Equal(t, "1", log.TargetID)
+	require.Equal(t, http.StatusOK, log.StatusCode)
+	require.Equal(t, service.AuditChange{Before: float64(10), After: float64(15)}, log.Diff["balance"])
+	require.JSONEq(t, `{"balance":5,"operation":"add","notes":"refund","password":"***"}`, *log.RequestBody)
+
+	// 只读接口与 GET 请求不记录
+	for _, r := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/api/v1/admin/dashboard/users-usage", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/1", nil),
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	require.Len(t, repo.created, 1)
+}
@@ -43,6 +43,24 @@ type CreateGroupRequest struct {
 	// 模型路由配置（仅 anthropic 平台使用）
 	ModelRouting        map[string][]int64 `json:"model_routing"`
 	ModelRoutingEnabled bool               `json:"model_routing_enabled"`
+	// API Key 默认速率限制（0 表示不限）
+	DefaultRPMLimit *int `json:"default_rpm_limit"`
+	DefaultTPMLimit *int `json:"default_tpm_limit"`
+	// 响应缓存（ttl 为空或 0 使用全局默认值，rate 为命中计费比例）
+	ResponseCacheEnabled    bool     `json:"response_cache_enabled"`
+	ResponseCacheTTLSeconds *int     `json:"response_cache_ttl_seconds" binding:"omitempty,min=0"`
+	ResponseCacheRate       *float64 `json:"response_cache_rate" binding:"omitempty,min=0,max=1"`
+	// 模型降级链（模型模式 -> [{group_id, model}]，目标分组仅支持 anthropic/gemini/antigravity）
+	ModelFallbacks map[string][]service.ModelFallbackTarget `json:"model_fallbacks"`
+	// 护栏配置
+	Guardrails *service.GuardrailConfig `json:"guardrails"`
+	// 调度策略（空为默认）与加权轮询权重（账号 ID -> 权重）
+	SchedulingStrategy string        `json:"scheduling_strategy" binding:"omitempty,oneof=lowest_cost lowest_latency least_outstanding weighted_round_robin"`
+	SchedulingWeights  map[int64]int `json:"scheduling_weights"`
+	// 对冲延迟（毫秒，0 表示关闭）
+	HedgeDelayMs int `json:"hedge_delay_ms" binding:"omitempty,min=0"`
+	// 公平排队权重（0 表示默认）
+	QueueWeight int `json:"queue_weight" binding:"omitempty,min=0"`
 }
 
 // UpdateGroupRequest represents update group request
@@ -66,6 +84,24 @@ type UpdateGroupRequest struct {
 	// 模型路由配置（仅 anthropic 平台使用）
 	ModelRouting        map[string][]int64 `json:"model_routing"`
 	ModelRoutingEnabled *bool              `json:"model_routing_enabled"`
+	// API Key 默认速率限制（0 表示清除）
+	DefaultRPMLimit *int `json:"default_rpm_limit"`
+	DefaultTPMLimit *int `json:"default_tpm_limit"`
+	// 响应缓存（ttl 为 0 表示恢复全局默认值）
+	ResponseCacheEnabled    *bool    `json:"response_cache_enabled"`
+	ResponseCacheTTLSeconds *int     `json:"response_cache_ttl_seconds" binding:"omitempty,min=0"`
+	ResponseCacheRate       *float64 `json:"response_cache_rate" binding:"omitempty,min=0,max=1"`
+	// 模型降级链（传入空对象表示清除）
+	ModelFallbacks map[string][]service.ModelFallbackTarget `json:"model_fallbacks"`
+	// 护栏配置（enabled=false 表示停用）
+	Guardrails *service.GuardrailConfig `json:"guardrails"`
+	// 调度策略（空字符串恢复默认）；权重传入空对象表示清除
+	SchedulingStrategy *string       `json:"scheduling_strategy" binding:"omitempty,oneof='' lowest_cost lowest_latency least_outstanding weighted_round_robin"`
+	SchedulingWeights  map[int64]int `json:"scheduling_weights"`
+	// 对冲延迟（毫秒，0 表示关闭）
+	HedgeDelayMs *int `json:"hedge_delay_ms" binding:"omitempty,min=0"`
+	// 公平排队权重（0 表示默认）
+	QueueWeight *int `json:"queue_weight" binding:"omitempty,min=0"`
 }
 
 // List handles listing all groups with pagination
@@ -171,6 +207,18 @@ func (h *GroupHandler) Create(c *gin.Context) {
 		FallbackGroupID:     req.FallbackGroupID,
 		ModelRouting:        req.ModelRouting,
 		ModelRoutingEnabled: req.ModelRoutingEnabled,
+		DefaultRPMLimit:     req.DefaultRPMLimit,
+		DefaultTPMLimit:     req.DefaultTPMLimit,
+		// 响应缓存
+		ResponseCacheEnabled:    req.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds: req.ResponseCacheTTLSeconds,
+		ResponseCacheRate:       req.ResponseCacheRate,
+		ModelFallbacks:          req.ModelFallbacks,
+		Guardrails:              req.Guardrails,
+		SchedulingStrategy:      req.SchedulingStrategy,
+		SchedulingWeights:       req.SchedulingWeights,
+		HedgeDelayMs:            req.HedgeDelayMs,
+		QueueWeight:             req.QueueWeight,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -213,6 +261,18 @@ func (h *GroupHandler) Update(c *gin.Context) {
 		FallbackGroupID:     req.FallbackGroupID,
 		ModelRouting:        req.ModelRouting,
 		ModelRoutingEnabled: req.ModelRoutingEnabled,
+		DefaultRPMLimit:     req.DefaultRPMLimit,
+		DefaultTPMLimit:     req.DefaultTPMLimit,
+		// 响应缓存
+		ResponseCacheEnabled:    req.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds: req.ResponseCacheTTLSeconds,
+		ResponseCacheRate:       req.ResponseCacheRate,
+		ModelFallbacks:          req.ModelFallbacks,
+		Guardrails:              req.Guardrails,
+		SchedulingStrategy:      req.SchedulingStrategy,
+		SchedulingWeights:       req.SchedulingWeights,
+		HedgeDelayMs:            req.HedgeDelayMs,
+		QueueWeight:             req.QueueWeight,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
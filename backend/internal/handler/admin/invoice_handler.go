@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvoiceHandler handles admin invoice management
+type InvoiceHandler struct {
+	invoiceService *service.InvoiceService
+}
+
+// NewInvoiceHandler creates a new admin invoice handler
+func NewInvoiceHandler(invoiceService *service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceService: invoiceService,
+	}
+}
+
+// GenerateInvoicesRequest represents generate invoices request
+type GenerateInvoicesRequest struct {
+	Period string `json:"period" binding:"required"` // YYYY-MM
+	UserID *int64 `json:"user_id"`                   // 为空表示为账期内所有有活动的用户生成
+}
+
+// VoidInvoiceRequest represents void invoice request
+type VoidInvoiceRequest struct {
+	Reason string `json:"reason"`
+}
+
+// List handles listing invoices
+// GET /api/v1/admin/invoices?user_id=&period=&status=
+func (h *InvoiceHandler) List(c *gin.Context) {
+	filter := service.InvoiceFilter{
+		Period: strings.TrimSpace(c.Query("period")),
+		Status: strings.TrimSpace(c.Query("status")),
+	}
+	if v := strings.TrimSpace(c.Query("user_id")); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			response.BadRequest(c, "Invalid user_id")
+			return
+		}
+		filter.UserID = &id
+	}
+	if filter.Status != "" && filter.Status != service.InvoiceStatusIssued && filter.Status != service.InvoiceStatusVoid {
+		response.BadRequest(c, "Invalid status")
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	invoices, paginationResult, err := h.invoiceService.List(c.Request.Context(), filter, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.AdminInvoice, 0, len(invoices))
+	for i := range invoices {
+		out = append(out, *dto.InvoiceFromServiceAdmin(&invoices[i]))
+	}
+	response.Paginated(c, out, paginationResult.Total, page, pageSize)
+}
+
+// GetByID handles getting an invoice by ID
+// GET /api/v1/admin/invoices/:id
+func (h *InvoiceHandler) GetByID(c *gin.Context) {
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoiceService.Get(c.Request.Context(), invoiceID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.InvoiceFromServiceAdmin(invoice))
+}
+
+// Download handles downloading an invoice
+// GET /api/v1/admin/invoices/:id/download?format=pdf|html|csv
+func (h *InvoiceHandler) Download(c *gin.Context) {
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoiceService.Get(c.Request.Context(), invoiceID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	rendered, err := h.invoiceService.Render(c.Request.Context(), invoice, c.Query("format"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+rendered.Filename+`"`)
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, rendered.ContentType, rendered.Body)
+}
+
+// Generate handles generating invoices for a closed month
+// POST /api/v1/admin/invoices/generate
+func (h *InvoiceHandler) Generate(c *gin.Context) {
+	var req GenerateInvoicesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	if req.UserID != nil {
+		invoice, err := h.invoiceService.Generate(c.Request.Context(), *req.UserID, req.Period)
+		if err != nil {
+			response.ErrorFrom(c, err)
+			return
+		}
+		response.Created(c, dto.InvoiceFromServiceAdmin(invoice))
+		return
+	}
+
+	result, err := h.invoiceService.GenerateForPeriod(c.Request.Context(), req.Period)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
+// Regenerate handles re-aggregating an invoice from current data
+// POST /api/v1/admin/invoices/:id/regenerate
+func (h *InvoiceHandler) Regenerate(c *gin.Context) {
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.invoiceService.Regenerate(c.Request.Context(), invoiceID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.InvoiceFromServiceAdmin(invoice))
+}
+
+// Void handles voiding an invoice
+// POST /api/v1/admin/invoices/:id/void
+func (h *InvoiceHandler) Void(c *gin.Context) {
+	invoiceID, ok := parseInvoiceID(c)
+	if !ok {
+		return
+	}
+
+	var req VoidInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	invoice, err := h.invoiceService.Void(c.Request.Context(), invoiceID, getAdminIDFromContext(c), req.Reason)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.InvoiceFromServiceAdmin(invoice))
+}
+
+func parseInvoiceID(c *gin.Context) (int64, bool) {
+	invoiceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid invoice ID")
+		return 0, false
+	}
+	return invoiceID, true
+}
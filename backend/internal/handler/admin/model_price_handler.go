@@ -0,0 +1,358 @@
+package admin
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxModelPriceImportBytes LiteLLM 完整价格表约数 MB，留出余量
+const maxModelPriceImportBytes = 32 << 20
+
+// ModelPriceHandler handles admin-managed model price overrides
+type ModelPriceHandler struct {
+	modelPriceService *service.ModelPriceService
+	billingService    *service.BillingService
+}
+
+// NewModelPriceHandler creates a new admin model price handler
+func NewModelPriceHandler(modelPriceService *service.ModelPriceService, billingService *service.BillingService) *ModelPriceHandler {
+	return &ModelPriceHandler{
+		modelPriceService: modelPriceService,
+		billingService:    billingService,
+	}
+}
+
+// CreateModelPriceRequest represents create model price request
+type CreateModelPriceRequest struct {
+	Model           string   `json:"model" binding:"required,max=255"`
+	GroupID         *int64   `json:"group_id"` // 为空表示全局价格
+	InputPrice      float64  `json:"input_price" binding:"min=0"`
+	OutputPrice     float64  `json:"output_price" binding:"min=0"`
+	CacheWritePrice float64  `json:"cache_write_price" binding:"min=0"`
+	CacheReadPrice  float64  `json:"cache_read_price" binding:"min=0"`
+	ImagePrice      *float64 `json:"image_price" binding:"omitempty,min=0"`
+	// CacheWrite1hPrice 为空表示按 5 分钟价格换算
+	CacheWrite1hPrice *float64             `json:"cache_write_1h_price" binding:"omitempty,min=0"`
+	Tiers             []dto.ModelPriceTier `json:"tiers"`
+	EffectiveFrom     *time.Time           `json:"effective_from"` // 为空表示立即生效
+	Notes             string               `json:"notes"`
+}
+
+// UpdateModelPriceRequest represents update model price request
+type UpdateModelPriceRequest struct {
+	InputPrice             *float64 `json:"input_price" binding:"omitempty,min=0"`
+	OutputPrice            *float64 `json:"output_price" binding:"omitempty,min=0"`
+	CacheWritePrice        *float64 `json:"cache_write_price" binding:"omitempty,min=0"`
+	CacheReadPrice         *float64 `json:"cache_read_price" binding:"omitempty,min=0"`
+	ImagePrice             *float64 `json:"image_price" binding:"omitempty,min=0"`
+	ClearImagePrice        bool     `json:"clear_image_price"`
+	CacheWrite1hPrice      *float64 `json:"cache_write_1h_price" binding:"omitempty,min=0"`
+	ClearCacheWrite1hPrice bool     `json:"clear_cache_write_1h_price"`
+	// Tiers 非 null 时整体替换阶梯，[] 表示清空
+	Tiers         []dto.ModelPriceTier `json:"tiers"`
+	EffectiveFrom *time.Time           `json:"effective_from"`
+	Notes         *string              `json:"notes"`
+}
+
+// ResolvedModelPriceResponse 价格查询结果
+type ResolvedModelPriceResponse struct {
+	Model           string    `json:"model"`
+	GroupID         *int64    `json:"group_id"`
+	At              time.Time `json:"at"`
+	Source          string    `json:"source"`
+	InputPrice      float64   `json:"input_price"`
+	OutputPrice     float64   `json:"output_price"`
+	CacheWritePrice float64   `json:"cache_write_price"`
+	CacheReadPrice  float64   `json:"cache_read_price"`
+	// CacheWrite1hPrice 实际使用的 1 小时缓存创建价格（未配置时为换算值）
+	CacheWrite1hPrice float64              `json:"cache_write_1h_price"`
+	Tiers             []dto.ModelPriceTier `json:"tiers"`
+	CustomPrice       *dto.ModelPrice      `json:"custom_price,omitempty"`
+}
+
+// List handles listing model prices
+// GET /api/v1/admin/model-prices?model=&group_id=&global=true
+func (h *ModelPriceHandler) List(c *gin.Context) {
+	filter := service.ModelPriceFilter{
+		Model:      strings.TrimSpace(c.Query("model")),
+		GlobalOnly: c.Query("global") == "true",
+	}
+	groupID, ok := parseOptionalGroupID(c)
+	if !ok {
+		return
+	}
+	filter.GroupID = groupID
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	prices, paginationResult, err := h.modelPriceService.List(c.Request.Context(), filter, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.ModelPrice, 0, len(prices))
+	for i := range prices {
+		out = append(out, *dto.ModelPriceFromService(&prices[i]))
+	}
+	response.Paginated(c, out, paginationResult.Total, page, pageSize)
+}
+
+// GetByID handles getting a model price by ID
+// GET /api/v1/admin/model-prices/:id
+func (h *ModelPriceHandler) GetByID(c *gin.Context) {
+	priceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid model price ID")
+		return
+	}
+
+	price, err := h.modelPriceService.Get(c.Request.Context(), priceID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.ModelPriceFromService(price))
+}
+
+// Create handles creating a model price
+// POST /api/v1/admin/model-prices
+func (h *ModelPriceHandler) Create(c *gin.Context) {
+	var req CreateModelPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	price, err := h.modelPriceService.Create(c.Request.Context(), &service.CreateModelPriceInput{
+		Model:             req.Model,
+		GroupID:           req.GroupID,
+		InputPrice:        req.InputPrice,
+		OutputPrice:       req.OutputPrice,
+		CacheWritePrice:   req.CacheWritePrice,
+		CacheReadPrice:    req.CacheReadPrice,
+		ImagePrice:        req.ImagePrice,
+		CacheWrite1hPrice: req.CacheWrite1hPrice,
+		Tiers:             modelPriceTiersFromRequest(req.Tiers),
+		EffectiveFrom:     req.EffectiveFrom,
+		Notes:             req.Notes,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.ModelPriceFromService(price))
+}
+
+// Update handles updating a model price (prices already in effect only accept notes)
+// PUT /api/v1/admin/model-prices/:id
+func (h *ModelPriceHandler) Update(c *gin.Context) {
+	priceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid model price ID")
+		return
+	}
+
+	var req UpdateModelPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	price, err := h.modelPriceService.Update(c.Request.Context(), priceID, &service.UpdateModelPriceInput{
+		InputPrice:             req.InputPrice,
+		OutputPrice:            req.OutputPrice,
+		CacheWritePrice:        req.CacheWritePrice,
+		CacheReadPrice:         req.CacheReadPrice,
+		ImagePrice:             req.ImagePrice,
+		ClearImagePrice:        req.ClearImagePrice,
+		CacheWrite1hPrice:      req.CacheWrite1hPrice,
+		ClearCacheWrite1hPrice: req.ClearCacheWrite1hPrice,
+		Tiers:                  modelPriceTiersFromRequest(req.Tiers),
+		EffectiveFrom:          req.EffectiveFrom,
+		Notes:                  req.Notes,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.ModelPriceFromService(price))
+}
+
+// Delete handles deleting a model price
+// DELETE /api/v1/admin/model-prices/:id
+func (h *ModelPriceHandler) Delete(c *gin.Context) {
+	priceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid model price ID")
+		return
+	}
+
+	if err := h.modelPriceService.Delete(c.Request.Context(), priceID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Model price deleted successfully"})
+}
+
+// Import handles importing prices from a LiteLLM-format JSON body
+// POST /api/v1/admin/model-prices/import?group_id=&effective_from=
+func (h *ModelPriceHandler) Import(c *gin.Context) {
+	groupID, ok := parseOptionalGroupID(c)
+	if !ok {
+		return
+	}
+	effectiveFrom, ok := parseOptionalRFC3339(c, "effective_from")
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxModelPriceImportBytes))
+	if err != nil {
+		response.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	result, err := h.modelPriceService.Import(c.Request.Context(), body, groupID, effectiveFrom)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Export handles exporting prices in effect as LiteLLM-format JSON
+// GET /api/v1/admin/model-prices/export?group_id=&at=
+func (h *ModelPriceHandler) Export(c *gin.Context) {
+	groupID, ok := parseOptionalGroupID(c)
+	if !ok {
+		return
+	}
+	at, ok := parseOptionalRFC3339(c, "at")
+	if !ok {
+		return
+	}
+	when := time.Now()
+	if at != nil {
+		when = *at
+	}
+
+	entries, err := h.modelPriceService.Export(c.Request.Context(), groupID, when)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="model_prices.json"`)
+	c.JSON(http.StatusOK, entries)
+}
+
+// Resolve handles looking up which price applies to a model
+// GET /api/v1/admin/model-prices/resolve?model=&group_id=&at=
+func (h *ModelPriceHandler) Resolve(c *gin.Context) {
+	model := strings.TrimSpace(c.Query("model"))
+	if model == "" {
+		response.BadRequest(c, "model is required")
+		return
+	}
+	groupID, ok := parseOptionalGroupID(c)
+	if !ok {
+		return
+	}
+	at, ok := parseOptionalRFC3339(c, "at")
+	if !ok {
+		return
+	}
+	when := time.Now()
+	if at != nil {
+		when = *at
+	}
+
+	resolved, err := h.billingService.ResolveModelPricing(model, groupID, when)
+	if err != nil {
+		response.ErrorFrom(c, service.ErrModelPriceNotFound)
+		return
+	}
+
+	tiers := make([]dto.ModelPriceTier, 0, len(resolved.Pricing.Tiers))
+	for _, t := range resolved.Pricing.Tiers {
+		tiers = append(tiers, dto.ModelPriceTier{
+			Threshold:         t.Threshold,
+			InputPrice:        t.InputPricePerToken,
+			OutputPrice:       t.OutputPricePerToken,
+			CacheWritePrice:   t.CacheCreationPricePerToken,
+			CacheWrite1hPrice: t.CacheCreation1hPricePerToken,
+			CacheReadPrice:    t.CacheReadPricePerToken,
+		})
+	}
+	response.Success(c, ResolvedModelPriceResponse{
+		Model:             model,
+		GroupID:           groupID,
+		At:                when,
+		Source:            resolved.Source,
+		InputPrice:        resolved.Pricing.InputPricePerToken,
+		OutputPrice:       resolved.Pricing.OutputPricePerToken,
+		CacheWritePrice:   resolved.Pricing.CacheCreationPricePerToken,
+		CacheReadPrice:    resolved.Pricing.CacheReadPricePerToken,
+		CacheWrite1hPrice: resolved.Pricing.CacheWrite1hPrice(),
+		Tiers:             tiers,
+		CustomPrice:       dto.ModelPriceFromService(resolved.CustomPrice),
+	})
+}
+
+// modelPriceTiersFromRequest nil 保持 nil（更新时表示不修改阶梯）
+func modelPriceTiersFromRequest(tiers []dto.ModelPriceTier) []service.ModelPriceTier {
+	if tiers == nil {
+		return nil
+	}
+	out := make([]service.ModelPriceTier, 0, len(tiers))
+	for _, t := range tiers {
+		out = append(out, service.ModelPriceTier(t))
+	}
+	return out
+}
+
+func parseOptionalGroupID(c *gin.Context) (*int64, bool) {
+	raw := strings.TrimSpace(c.Query("group_id"))
+	if raw == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		response.BadRequest(c, "Invalid group_id")
+		return nil, false
+	}
+	return &id, true
+}
+
+func parseOptionalRFC3339(c *gin.Context, key string) (*time.Time, bool) {
+	raw := strings.TrimSpace(c.Query(key))
+	if raw == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		response.BadRequest(c, "Invalid "+key+", use RFC3339 format")
+		return nil, false
+	}
+	return &t, true
+}
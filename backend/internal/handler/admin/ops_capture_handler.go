@@ -0,0 +1,200 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateOpsCaptureRuleRequest represents create capture rule request
+type CreateOpsCaptureRuleRequest struct {
+	TargetType      string  `json:"target_type" binding:"required,oneof=api_key user account"`
+	TargetID        int64   `json:"target_id" binding:"required,gt=0"`
+	SampleRate      float64 `json:"sample_rate"`                              // (0,1]，默认 1
+	DurationMinutes int     `json:"duration_minutes" binding:"required,gt=0"` // 抓取窗口，最长 7 天
+	RetentionHours  int     `json:"retention_hours"`                          // 数据保留时长，默认 72 小时
+	Note            string  `json:"note" binding:"omitempty,max=500"`
+}
+
+func (h *OpsHandler) requireCaptureService(c *gin.Context) bool {
+	if h.captureService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Capture service not available")
+		return false
+	}
+	return true
+}
+
+func parseOpsCaptureID(c *gin.Context, raw string) (int64, bool) {
+	id, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || id <= 0 {
+		response.BadRequest(c, "Invalid capture id")
+		return 0, false
+	}
+	return id, true
+}
+
+// ListCaptureRules lists debug capture rules.
+// GET /api/v1/admin/ops/capture-rules
+func (h *OpsHandler) ListCaptureRules(c *gin.Context) {
+	if !h.requireCaptureService(c) {
+		return
+	}
+	rules, err := h.captureService.ListRules(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, rules)
+}
+
+// CreateCaptureRule starts capturing full requests/responses for an API key, user or account.
+// POST /api/v1/admin/ops/capture-rules
+func (h *OpsHandler) CreateCaptureRule(c *gin.Context) {
+	if !h.requireCaptureService(c) {
+		return
+	}
+	var req CreateOpsCaptureRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	input := &service.CreateOpsCaptureRuleInput{
+		TargetType:      req.TargetType,
+		TargetID:        req.TargetID,
+		SampleRate:      req.SampleRate,
+		DurationMinutes: req.DurationMinutes,
+		RetentionHours:  req.RetentionHours,
+		Note:            req.Note,
+	}
+	if subject, ok := middleware.GetAuthSubjectFromContext(c); ok {
+		uid := subject.UserID
+		input.CreatedBy = &uid
+	}
+
+	rule, err := h.captureService.CreateRule(c.Request.Context(), input)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, rule)
+}
+
+// StopCaptureRule ends a capture window early; captured data is kept until it expires.
+// POST /api/v1/admin/ops/capture-rules/:id/stop
+func (h *OpsHandler) StopCaptureRule(c *gin.Context) {
+	if !h.requireCaptureService(c) {
+		return
+	}
+	id, ok := parseOpsCaptureID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	if err := h.captureService.StopRule(c.Request.Context(), id); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Capture rule stopped"})
+}
+
+// DeleteCaptureRule deletes a capture rule together with its captures.
+// DELETE /api/v1/admin/ops/capture-rules/:id
+func (h *OpsHandler) DeleteCaptureRule(c *gin.Context) {
+	if !h.requireCaptureService(c) {
+		return
+	}
+	id, ok := parseOpsCaptureID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	if err := h.captureService.DeleteRule(c.Request.Context(), id); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Capture rule deleted"})
+}
+
+// ListCaptures lists captured requests (metadata only).
+// GET /api/v1/admin/ops/captures
+func (h *OpsHandler) ListCaptures(c *gin.Context) {
+	if !h.requireCaptureService(c) {
+		return
+	}
+
+	var filter service.OpsCaptureFilter
+	for _, f := range []struct {
+		name string
+		dst  **int64
+	}{
+		{"rule_id", &filter.RuleID},
+		{"api_key_id", &filter.APIKeyID},
+		{"user_id", &filter.UserID},
+		{"account_id", &filter.AccountID},
+	} {
+		v := strings.TrimSpace(c.Query(f.name))
+		if v == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			response.BadRequest(c, "Invalid "+f.name)
+			return
+		}
+		*f.dst = &id
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+	captures, result, err := h.captureService.ListCaptures(c.Request.Context(), filter, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Paginated(c, captures, result.Total, page, pageSize)
+}
+
+// GetCapture returns a decrypted capture.
+// GET /api/v1/admin/ops/captures/:id
+func (h *OpsHandler) GetCapture(c *gin.Context) {
+	if !h.requireCaptureService(c) {
+		return
+	}
+	id, ok := parseOpsCaptureID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	capture, err := h.captureService.GetCapture(c.Request.Context(), id)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, capture)
+}
+
+// DiffCaptures compares two captures: request fields and reassembled response text.
+// GET /api/v1/admin/ops/captures/:id/diff?with=:otherId
+func (h *OpsHandler) DiffCaptures(c *gin.Context) {
+	if !h.requireCaptureService(c) {
+		return
+	}
+	baseID, ok := parseOpsCaptureID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	compareID, ok := parseOpsCaptureID(c, c.Query("with"))
+	if !ok {
+		return
+	}
+	diff, err := h.captureService.DiffCaptures(c.Request.Context(), baseID, compareID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, diff)
+}
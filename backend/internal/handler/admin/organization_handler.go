@@ -0,0 +1,192 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles admin organization management
+type OrganizationHandler struct {
+	orgService *service.OrganizationService
+}
+
+// NewOrganizationHandler creates a new admin organization handler
+func NewOrganizationHandler(orgService *service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService: orgService,
+	}
+}
+
+// UpdateOrganizationStatusRequest represents update organization status request
+type UpdateOrganizationStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active disabled"`
+}
+
+// AdjustOrganizationBalanceRequest represents adjust organization balance request
+type AdjustOrganizationBalanceRequest struct {
+	Amount float64 `json:"amount" binding:"required"` // 正数入账，负数扣减
+	Notes  string  `json:"notes"`
+}
+
+// List handles listing organizations
+// GET /api/v1/admin/organizations?search=&status=
+func (h *OrganizationHandler) List(c *gin.Context) {
+	filter := service.OrganizationFilter{
+		Search: strings.TrimSpace(c.Query("search")),
+		Status: strings.TrimSpace(c.Query("status")),
+	}
+	if len(filter.Search) > 100 {
+		filter.Search = filter.Search[:100]
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	orgs, result, err := h.orgService.AdminList(c.Request.Context(), filter, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.Organization, 0, len(orgs))
+	for i := range orgs {
+		out = append(out, *dto.OrganizationFromService(&orgs[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+// GetByID handles getting an organization
+// GET /api/v1/admin/organizations/:id
+func (h *OrganizationHandler) GetByID(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.orgService.AdminGet(c.Request.Context(), orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationFromService(org))
+}
+
+// ListMembers handles listing organization members
+// GET /api/v1/admin/organizations/:id/members
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.orgService.AdminListMembers(c.Request.Context(), orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.OrganizationMember, 0, len(members))
+	for i := range members {
+		out = append(out, *dto.OrganizationMemberFromService(&members[i]))
+	}
+	response.Success(c, out)
+}
+
+// ListBalanceTransactions handles listing organization balance transactions
+// GET /api/v1/admin/organizations/:id/balance/transactions
+func (h *OrganizationHandler) ListBalanceTransactions(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	txns, result, err := h.orgService.AdminListBalanceTransactions(c.Request.Context(), orgID, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.AdminOrganizationBalanceTransaction, 0, len(txns))
+	for i := range txns {
+		out = append(out, *dto.OrganizationBalanceTransactionFromServiceAdmin(&txns[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+// UpdateStatus handles enabling or disabling an organization
+// PUT /api/v1/admin/organizations/:id/status
+func (h *OrganizationHandler) UpdateStatus(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateOrganizationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	org, err := h.orgService.AdminSetStatus(c.Request.Context(), orgID, req.Status)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationFromService(org))
+}
+
+// AdjustBalance handles adjusting an organization's balance
+// POST /api/v1/admin/organizations/:id/balance
+func (h *OrganizationHandler) AdjustBalance(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req AdjustOrganizationBalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	txn, err := h.orgService.AdminAdjustBalance(c.Request.Context(), orgID, req.Amount, getAdminIDFromContext(c), req.Notes)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationBalanceTransactionFromServiceAdmin(txn))
+}
+
+// Delete handles deleting an organization
+// DELETE /api/v1/admin/organizations/:id
+func (h *OrganizationHandler) Delete(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.orgService.AdminDelete(c.Request.Context(), orgID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Organization deleted successfully"})
+}
+
+func parseOrganizationID(c *gin.Context) (int64, bool) {
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid organization ID")
+		return 0, false
+	}
+	return orgID, true
+}
@@ -121,6 +121,16 @@ func (h *UsageHandler) List(c *gin.Context) {
 		billingType = &bt
 	}
 
+	var cacheServed *bool
+	if cacheServedStr := c.Query("cache_served"); cacheServedStr != "" {
+		val, err := strconv.ParseBool(cacheServedStr)
+		if err != nil {
+			response.BadRequest(c, "Invalid cache_served value, use true or false")
+			return
+		}
+		cacheServed = &val
+	}
+
 	// Parse date range
 	var startTime, endTime *time.Time
 	userTZ := c.Query("timezone") // Get user's timezone from request
@@ -153,6 +163,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		Model:       model,
 		Stream:      stream,
 		BillingType: billingType,
+		CacheServed: cacheServed,
 		StartTime:   startTime,
 		EndTime:     endTime,
 	}
@@ -234,6 +245,16 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 		billingType = &bt
 	}
 
+	var cacheServed *bool
+	if cacheServedStr := c.Query("cache_served"); cacheServedStr != "" {
+		val, err := strconv.ParseBool(cacheServedStr)
+		if err != nil {
+			response.BadRequest(c, "Invalid cache_served value, use true or false")
+			return
+		}
+		cacheServed = &val
+	}
+
 	// Parse date range
 	userTZ := c.Query("timezone")
 	now := timezone.NowInUserLocation(userTZ)
@@ -279,6 +300,7 @@ func (h *UsageHandler) Stats(c *gin.Context) {
 		Model:       model,
 		Stream:      stream,
 		BillingType: billingType,
+		CacheServed: cacheServed,
 		StartTime:   &startTime,
 		EndTime:     &endTime,
 	}
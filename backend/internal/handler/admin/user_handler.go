@@ -5,7 +5,9 @@ import (
 	"strings"
 
 	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/server/middleware"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -13,13 +15,15 @@ import (
 
 // UserHandler handles admin user management
 type UserHandler struct {
-	adminService service.AdminService
+	adminService         service.AdminService
+	balanceLedgerService *service.BalanceLedgerService
 }
 
 // NewUserHandler creates a new admin user handler
-func NewUserHandler(adminService service.AdminService) *UserHandler {
+func NewUserHandler(adminService service.AdminService, balanceLedgerService *service.BalanceLedgerService) *UserHandler {
 	return &UserHandler{
-		adminService: adminService,
+		adminService:         adminService,
+		balanceLedgerService: balanceLedgerService,
 	}
 }
 
@@ -45,6 +49,8 @@ type UpdateUserRequest struct {
 	Concurrency   *int     `json:"concurrency"`
 	Status        string   `json:"status" binding:"omitempty,oneof=active disabled"`
 	AllowedGroups *[]int64 `json:"allowed_groups"`
+	QueuePriority *int     `json:"queue_priority"`
+	QueueWeight   *int     `json:"queue_weight" binding:"omitempty,min=0"`
 }
 
 // UpdateBalanceRequest represents balance update request
@@ -183,6 +189,8 @@ func (h *UserHandler) Update(c *gin.Context) {
 		Concurrency:   req.Concurrency,
 		Status:        req.Status,
 		AllowedGroups: req.AllowedGroups,
+		QueuePriority: req.QueuePriority,
+		QueueWeight:   req.QueueWeight,
 	})
 	if err != nil {
 		response.ErrorFrom(c, err)
@@ -225,7 +233,12 @@ func (h *UserHandler) UpdateBalance(c *gin.Context) {
 		return
 	}
 
-	user, err := h.adminService.UpdateUserBalance(c.Request.Context(), userID, req.Balance, req.Operation, req.Notes)
+	var operatorID int64
+	if subject, ok := middleware.GetAuthSubjectFromContext(c); ok {
+		operatorID = subject.UserID
+	}
+
+	user, err := h.adminService.UpdateUserBalance(c.Request.Context(), userID, req.Balance, req.Operation, req.Notes, operatorID)
 	if err != nil {
 		response.ErrorFrom(c, err)
 		return
@@ -277,3 +290,47 @@ func (h *UserHandler) GetUserUsage(c *gin.Context) {
 
 	response.Success(c, stats)
 }
+
+// GetBalanceHistory handles listing a user's balance transactions
+// GET /api/v1/admin/users/:id/balance-history
+func (h *UserHandler) GetBalanceHistory(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	txns, result, err := h.balanceLedgerService.ListUserTransactions(c.Request.Context(), userID, c.Query("type"), params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.AdminBalanceTransaction, 0, len(txns))
+	for i := range txns {
+		out = append(out, *dto.BalanceTransactionFromServiceAdmin(&txns[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+// CheckBalanceConsistency compares each user's balance with the sum of their ledger
+// GET /api/v1/admin/users/balance-consistency
+func (h *UserHandler) CheckBalanceConsistency(c *gin.Context) {
+	mismatches, err := h.balanceLedgerService.CheckConsistency(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.BalanceInconsistency, 0, len(mismatches))
+	for i := range mismatches {
+		out = append(out, *dto.BalanceInconsistencyFromService(&mismatches[i]))
+	}
+	response.Success(c, gin.H{
+		"consistent": len(out) == 0,
+		"mismatches": out,
+	})
+}
@@ -0,0 +1,236 @@
+package admin
+
+import (
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles admin webhook endpoint management
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+// NewWebhookHandler creates a new admin webhook handler
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhookEndpointRequest represents create webhook endpoint request
+type CreateWebhookEndpointRequest struct {
+	Name    string   `json:"name" binding:"required,max=100"`
+	URL     string   `json:"url" binding:"required"`
+	Secret  string   `json:"secret"`                                                               // 可选，generic 格式为空时自动生成
+	Format  string   `json:"format" binding:"omitempty,oneof=generic slack feishu dingtalk wecom"` // 默认 generic
+	Events  []string `json:"events"`                                                               // 为空表示订阅全部
+	Enabled *bool    `json:"enabled"`                                                              // 默认启用
+}
+
+// UpdateWebhookEndpointRequest represents update webhook endpoint request
+type UpdateWebhookEndpointRequest struct {
+	Name    *string  `json:"name" binding:"omitempty,max=100"`
+	URL     *string  `json:"url"`
+	Secret  *string  `json:"secret"`
+	Format  *string  `json:"format" binding:"omitempty,oneof=generic slack feishu dingtalk wecom"`
+	Events  []string `json:"events"`
+	Enabled *bool    `json:"enabled"`
+}
+
+// ListEventTypes returns subscribable event types
+// GET /api/v1/admin/webhooks/event-types
+func (h *WebhookHandler) ListEventTypes(c *gin.Context) {
+	response.Success(c, service.WebhookEventTypes)
+}
+
+// List handles listing all webhook endpoints
+// GET /api/v1/admin/webhooks
+func (h *WebhookHandler) List(c *gin.Context) {
+	endpoints, err := h.webhookService.ListEndpoints(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.WebhookEndpoint, 0, len(endpoints))
+	for i := range endpoints {
+		out = append(out, *dto.WebhookEndpointFromService(&endpoints[i]))
+	}
+	response.Success(c, out)
+}
+
+// GetByID handles getting a webhook endpoint by ID
+// GET /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	endpointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	endpoint, err := h.webhookService.GetEndpoint(c.Request.Context(), endpointID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.WebhookEndpointFromService(endpoint))
+}
+
+// Create handles creating a new webhook endpoint
+// POST /api/v1/admin/webhooks
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	input := &service.CreateWebhookEndpointInput{
+		Name:    req.Name,
+		URL:     req.URL,
+		Secret:  req.Secret,
+		Format:  req.Format,
+		Events:  req.Events,
+		Enabled: req.Enabled == nil || *req.Enabled,
+	}
+
+	endpoint, err := h.webhookService.CreateEndpoint(c.Request.Context(), input)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	// 仅在创建时返回密钥，之后只展示 has_secret
+	out := dto.WebhookEndpointFromService(endpoint)
+	out.Secret = endpoint.Secret
+	response.Success(c, out)
+}
+
+// Update handles updating a webhook endpoint
+// PUT /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) Update(c *gin.Context) {
+	endpointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	var req UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	input := &service.UpdateWebhookEndpointInput{
+		Name:    req.Name,
+		URL:     req.URL,
+		Secret:  req.Secret,
+		Format:  req.Format,
+		Events:  req.Events,
+		Enabled: req.Enabled,
+	}
+
+	endpoint, err := h.webhookService.UpdateEndpoint(c.Request.Context(), endpointID, input)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.WebhookEndpointFromService(endpoint))
+}
+
+// Delete handles deleting a webhook endpoint
+// DELETE /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	endpointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	if err := h.webhookService.DeleteEndpoint(c.Request.Context(), endpointID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// Test sends a test event to the endpoint synchronously
+// POST /api/v1/admin/webhooks/:id/test
+func (h *WebhookHandler) Test(c *gin.Context) {
+	endpointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	delivery, err := h.webhookService.TestEndpoint(c.Request.Context(), endpointID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.WebhookDeliveryFromService(delivery))
+}
+
+// ListDeliveries handles listing delivery logs of an endpoint
+// GET /api/v1/admin/webhooks/:id/deliveries
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	endpointID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", service.WebhookDeliveryStatusPending, service.WebhookDeliveryStatusSuccess, service.WebhookDeliveryStatusFailed:
+	default:
+		response.BadRequest(c, "Invalid status")
+		return
+	}
+
+	deliveries, paginationResult, err := h.webhookService.ListDeliveries(c.Request.Context(), endpointID, status, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.WebhookDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		out = append(out, *dto.WebhookDeliveryFromService(&deliveries[i]))
+	}
+	response.Paginated(c, out, paginationResult.Total, page, pageSize)
+}
+
+// RetryDelivery re-sends a delivery immediately
+// POST /api/v1/admin/webhooks/deliveries/:id/retry
+func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
+	deliveryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid delivery ID")
+		return
+	}
+
+	delivery, err := h.webhookService.RetryDelivery(c.Request.Context(), deliveryID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	response.Success(c, dto.WebhookDeliveryFromService(delivery))
+}
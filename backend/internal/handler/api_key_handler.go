@@ -3,6 +3,7 @@ package handler
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
@@ -27,20 +28,34 @@ func NewAPIKeyHandler(apiKeyService *service.APIKeyService) *APIKeyHandler {
 
 // CreateAPIKeyRequest represents the create API key request payload
 type CreateAPIKeyRequest struct {
-	Name        string   `json:"name" binding:"required"`
-	GroupID     *int64   `json:"group_id"`     // nullable
-	CustomKey   *string  `json:"custom_key"`   // 可选的自定义key
-	IPWhitelist []string `json:"ip_whitelist"` // IP 白名单
-	IPBlacklist []string `json:"ip_blacklist"` // IP 黑名单
+	Name           string     `json:"name" binding:"required"`
+	GroupID        *int64     `json:"group_id"`        // nullable
+	CustomKey      *string    `json:"custom_key"`      // 可选的自定义key
+	IPWhitelist    []string   `json:"ip_whitelist"`    // IP 白名单
+	IPBlacklist    []string   `json:"ip_blacklist"`    // IP 黑名单
+	QuotaUSD       *float64   `json:"quota_usd"`       // 额度上限（美元），为空表示不限
+	ExpiresAt      *time.Time `json:"expires_at"`      // 过期时间，为空表示永不过期
+	AllowedModels  []string   `json:"allowed_models"`  // 模型白名单，支持末尾 * 通配符
+	MaxTokensCap   *int       `json:"max_tokens_cap"`  // max_tokens 上限
+	RPMLimit       *int       `json:"rpm_limit"`       // 每分钟请求数上限，为空使用分组默认值
+	TPMLimit       *int       `json:"tpm_limit"`       // 每分钟 token 数上限，为空使用分组默认值
+	OrganizationID *int64     `json:"organization_id"` // 团队 API Key 所属组织，用量扣减组织余额（需为组织成员）
 }
 
 // UpdateAPIKeyRequest represents the update API key request payload
 type UpdateAPIKeyRequest struct {
-	Name        string   `json:"name"`
-	GroupID     *int64   `json:"group_id"`
-	Status      string   `json:"status" binding:"omitempty,oneof=active inactive"`
-	IPWhitelist []string `json:"ip_whitelist"` // IP 白名单
-	IPBlacklist []string `json:"ip_blacklist"` // IP 黑名单
+	Name           string     `json:"name"`
+	GroupID        *int64     `json:"group_id"`
+	Status         string     `json:"status" binding:"omitempty,oneof=active inactive"`
+	IPWhitelist    []string   `json:"ip_whitelist"`     // IP 白名单
+	IPBlacklist    []string   `json:"ip_blacklist"`     // IP 黑名单
+	QuotaUSD       *float64   `json:"quota_usd"`        // 额度上限，传 0 清除
+	ExpiresAt      *time.Time `json:"expires_at"`       // 过期时间
+	ClearExpiresAt bool       `json:"clear_expires_at"` // 清除过期时间
+	AllowedModels  []string   `json:"allowed_models"`   // 模型白名单（空数组清空）
+	MaxTokensCap   *int       `json:"max_tokens_cap"`   // max_tokens 上限，传 0 清除
+	RPMLimit       *int       `json:"rpm_limit"`        // 每分钟请求数上限，传 0 恢复分组默认值
+	TPMLimit       *int       `json:"tpm_limit"`        // 每分钟 token 数上限，传 0 恢复分组默认值
 }
 
 // List handles listing user's API keys with pagination
@@ -114,11 +129,18 @@ func (h *APIKeyHandler) Create(c *gin.Context) {
 	}
 
 	svcReq := service.CreateAPIKeyRequest{
-		Name:        req.Name,
-		GroupID:     req.GroupID,
-		CustomKey:   req.CustomKey,
-		IPWhitelist: req.IPWhitelist,
-		IPBlacklist: req.IPBlacklist,
+		Name:           req.Name,
+		GroupID:        req.GroupID,
+		CustomKey:      req.CustomKey,
+		IPWhitelist:    req.IPWhitelist,
+		IPBlacklist:    req.IPBlacklist,
+		QuotaUSD:       req.QuotaUSD,
+		ExpiresAt:      req.ExpiresAt,
+		AllowedModels:  req.AllowedModels,
+		MaxTokensCap:   req.MaxTokensCap,
+		RPMLimit:       req.RPMLimit,
+		TPMLimit:       req.TPMLimit,
+		OrganizationID: req.OrganizationID,
 	}
 	key, err := h.apiKeyService.Create(c.Request.Context(), subject.UserID, svcReq)
 	if err != nil {
@@ -151,8 +173,15 @@ func (h *APIKeyHandler) Update(c *gin.Context) {
 	}
 
 	svcReq := service.UpdateAPIKeyRequest{
-		IPWhitelist: req.IPWhitelist,
-		IPBlacklist: req.IPBlacklist,
+		IPWhitelist:    req.IPWhitelist,
+		IPBlacklist:    req.IPBlacklist,
+		QuotaUSD:       req.QuotaUSD,
+		ExpiresAt:      req.ExpiresAt,
+		ClearExpiresAt: req.ClearExpiresAt,
+		AllowedModels:  req.AllowedModels,
+		MaxTokensCap:   req.MaxTokensCap,
+		RPMLimit:       req.RPMLimit,
+		TPMLimit:       req.TPMLimit,
 	}
 	if req.Name != "" {
 		svcReq.Name = &req.Name
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyRateLimitCheckedKey 标记本次请求已完成 RPM/TPM 检查，
+// 避免 chat completions 等转发到其他 handler 的入口重复计数
+const apiKeyRateLimitCheckedKey = "api_key_rate_limit_checked"
+
+// RateLimitHeaderStyle 速率限制响应头风格，与调用方协议保持一致
+type RateLimitHeaderStyle int
+
+const (
+	// RateLimitHeadersAnthropic anthropic-ratelimit-*（重置时间为 RFC 3339 时间戳）
+	RateLimitHeadersAnthropic RateLimitHeaderStyle = iota
+	// RateLimitHeadersOpenAI x-ratelimit-*（重置时间为时长字符串，如 "1s"、"6m0s"）
+	RateLimitHeadersOpenAI
+)
+
+// checkAPIKeyRateLimit 检查 API Key 的 RPM/TPM 限制并写入速率限制响应头。
+// 返回 nil 表示放行；返回非 nil 时调用方需按自身协议返回 429。
+func checkAPIKeyRateLimit(c *gin.Context, limiter *service.APIKeyRateLimitService, apiKey *service.APIKey, style RateLimitHeaderStyle) *service.APIKeyRateLimitResult {
+	if limiter == nil || apiKey == nil || c.GetBool(apiKeyRateLimitCheckedKey) {
+		return nil
+	}
+	c.Set(apiKeyRateLimitCheckedKey, true)
+
+	result := limiter.Check(c.Request.Context(), apiKey)
+	setRateLimitHeaders(c, result, style, time.Now())
+	if !result.Limited {
+		return nil
+	}
+	c.Header("retry-after", strconv.FormatInt(retryAfterSeconds(result.RetryAfter), 10))
+	return result
+}
+
+// rateLimitErrorMessage 生成超限错误信息
+func rateLimitErrorMessage(result *service.APIKeyRateLimitResult) string {
+	if result.LimitedBy == service.RateLimitKindTokens && result.Tokens != nil {
+		return fmt.Sprintf("API key rate limit exceeded: %d tokens per minute", result.Tokens.Limit)
+	}
+	if result.Requests != nil {
+		return fmt.Sprintf("API key rate limit exceeded: %d requests per minute", result.Requests.Limit)
+	}
+	return "API key rate limit exceeded"
+}
+
+func setRateLimitHeaders(c *gin.Context, result *service.APIKeyRateLimitResult, style RateLimitHeaderStyle, now time.Time) {
+	write := func(kind string, quota *service.RateLimitQuota) {
+		if quota == nil {
+			return
+		}
+		switch style {
+		case RateLimitHeadersOpenAI:
+			c.Header("x-ratelimit-limit-"+kind, strconv.Itoa(quota.Limit))
+			c.Header("x-ratelimit-remaining-"+kind, strconv.FormatInt(quota.Remaining, 10))
+			c.Header("x-ratelimit-reset-"+kind, quota.ResetAfter.Round(time.Millisecond).String())
+		default:
+			c.Header("anthropic-ratelimit-"+kind+"-limit", strconv.Itoa(quota.Limit))
+			c.Header("anthropic-ratelimit-"+kind+"-remaining", strconv.FormatInt(quota.Remaining, 10))
+			c.Header("anthropic-ratelimit-"+kind+"-reset", now.Add(quota.ResetAfter).UTC().Format(time.RFC3339))
+		}
+	}
+	write(service.RateLimitKindRequests, result.Requests)
+	write(service.RateLimitKindTokens, result.Tokens)
+}
+
+// retryAfterSeconds 向上取整到秒，至少 1 秒
+func retryAfterSeconds(d time.Duration) int64 {
+	return max(int64(math.Ceil(d.Seconds())), 1)
+}
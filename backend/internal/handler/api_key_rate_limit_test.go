@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRateLimitHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	result := &service.APIKeyRateLimitResult{
+		Requests: &service.RateLimitQuota{Limit: 60, Remaining: 12, ResetAfter: 30 * time.Second},
+		Tokens:   &service.RateLimitQuota{Limit: 1000, Remaining: 0, ResetAfter: 1500 * time.Millisecond},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	setRateLimitHeaders(c, result, RateLimitHeadersAnthropic, now)
+	require.Equal(t, "60", w.Header().Get("anthropic-ratelimit-requests-limit"))
+	require.Equal(t, "12", w.Header().Get("anthropic-ratelimit-requests-remaining"))
+	require.Equal(t, "2026-01-02T03:04:35Z", w.Header().Get("anthropic-ratelimit-requests-reset"))
+	require.Equal(t, "0", w.Header().Get("anthropic-ratelimit-tokens-remaining"))
+	require.Empty(t, w.Header().Get("x-ratelimit-limit-requests"))
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	setRateLimitHeaders(c, result, RateLimitHeadersOpenAI, now)
+	require.Equal(t, "60", w.Header().Get("x-ratelimit-limit-requests"))
+	require.Equal(t, "30s", w.Header().Get("x-ratelimit-reset-requests"))
+	require.Equal(t, "1000", w.Header().Get("x-ratelimit-limit-tokens"))
+	require.Equal(t, "1.5s", w.Header().Get("x-ratelimit-reset-tokens"))
+	require.Empty(t, w.Header().Get("anthropic-ratelimit-requests-limit"))
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	require.Equal(t, int64(1), retryAfterSeconds(0))
+	require.Equal(t, int64(1), retryAfterSeconds(200*time.Millisecond))
+	require.Equal(t, int64(13), retryAfterSeconds(12100*time.Millisecond))
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BalanceAlertHandler handles low-balance alert and auto top-up endpoints
+type BalanceAlertHandler struct {
+	balanceAlertService *service.BalanceAlertService
+}
+
+// NewBalanceAlertHandler creates a new BalanceAlertHandler
+func NewBalanceAlertHandler(balanceAlertService *service.BalanceAlertService) *BalanceAlertHandler {
+	return &BalanceAlertHandler{
+		balanceAlertService: balanceAlertService,
+	}
+}
+
+// BalanceAlertResponse represents balance alert settings with registered top-up codes
+type BalanceAlertResponse struct {
+	Settings   *dto.BalanceAlertSettings `json:"settings"`
+	TopUpCodes []dto.AutoTopUpCode       `json:"topup_codes"`
+}
+
+// UpdateBalanceAlertRequest represents update balance alert settings request
+type UpdateBalanceAlertRequest struct {
+	Thresholds              *[]float64 `json:"thresholds"`
+	EmailEnabled            *bool      `json:"email_enabled"`
+	WebhookURL              *string    `json:"webhook_url"` // 空字符串表示关闭 Webhook
+	RegenerateWebhookSecret bool       `json:"regenerate_webhook_secret"`
+	AutoTopUpEnabled        *bool      `json:"auto_topup_enabled"`
+	AutoTopUpThreshold      *float64   `json:"auto_topup_threshold"`
+}
+
+// RegisterTopUpCodeRequest represents register auto top-up code request
+type RegisterTopUpCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// Get handles getting the current user's balance alert settings
+// GET /api/v1/balance-alerts
+func (h *BalanceAlertHandler) Get(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	settings, err := h.balanceAlertService.GetSettings(c.Request.Context(), subject.UserID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	codes, err := h.balanceAlertService.ListTopUpCodes(c.Request.Context(), subject.UserID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.AutoTopUpCode, 0, len(codes))
+	for i := range codes {
+		out = append(out, *dto.AutoTopUpCodeFromService(&codes[i]))
+	}
+	response.Success(c, BalanceAlertResponse{
+		Settings:   dto.BalanceAlertSettingsFromService(settings),
+		TopUpCodes: out,
+	})
+}
+
+// Update handles updating the current user's balance alert settings
+// PUT /api/v1/balance-alerts
+func (h *BalanceAlertHandler) Update(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req UpdateBalanceAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	settings, err := h.balanceAlertService.UpdateSettings(c.Request.Context(), subject.UserID, service.UpdateBalanceAlertRequest{
+		Thresholds:              req.Thresholds,
+		EmailEnabled:            req.EmailEnabled,
+		WebhookURL:              req.WebhookURL,
+		RegenerateWebhookSecret: req.RegenerateWebhookSecret,
+		AutoTopUpEnabled:        req.AutoTopUpEnabled,
+		AutoTopUpThreshold:      req.AutoTopUpThreshold,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.BalanceAlertSettingsFromService(settings))
+}
+
+// RegisterTopUpCode handles pre-registering a redeem code for auto top-up
+// POST /api/v1/balance-alerts/topup-codes
+func (h *BalanceAlertHandler) RegisterTopUpCode(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req RegisterTopUpCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	code, err := h.balanceAlertService.RegisterTopUpCode(c.Request.Context(), subject.UserID, req.Code)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Created(c, dto.AutoTopUpCodeFromService(code))
+}
+
+// DeleteTopUpCode handles removing an unused auto top-up code
+// DELETE /api/v1/balance-alerts/topup-codes/:id
+func (h *BalanceAlertHandler) DeleteTopUpCode(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid top-up code ID")
+		return
+	}
+
+	if err := h.balanceAlertService.DeleteTopUpCode(c.Request.Context(), subject.UserID, id); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Top-up code removed successfully"})
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	pkgerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChatCompletionsHandler 提供 OpenAI Chat Completions 兼容入口。
+// 请求按分组平台转换为 Anthropic Messages 或 OpenAI Responses 后交给对应网关处理器，
+// 账号调度、并发控制、故障切换与使用量记录均复用原有链路，仅在响应写出时转换格式。
+// 模型降级同样沿用目标入口的规则：Messages 入口可降级到任意平台分组，
+// Responses 入口（OpenAI 分组）只能降级到 OpenAI 分组，其他平台的降级节点被跳过。
+type ChatCompletionsHandler struct {
+	gatewayHandler       *GatewayHandler
+	openaiGatewayHandler *OpenAIGatewayHandler
+}
+
+// NewChatCompletionsHandler creates a new ChatCompletionsHandler
+func NewChatCompletionsHandler(gatewayHandler *GatewayHandler, openaiGatewayHandler *OpenAIGatewayHandler) *ChatCompletionsHandler {
+	return &ChatCompletionsHandler{
+		gatewayHandler:       gatewayHandler,
+		openaiGatewayHandler: openaiGatewayHandler,
+	}
+}
+
+// ChatCompletions handles OpenAI Chat Completions compatible endpoint
+// POST /v1/chat/completions
+func (h *ChatCompletionsHandler) ChatCompletions(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+	if len(body) == 0 {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Request body is empty")
+		return
+	}
+
+	setOpsRequestContext(c, "", false, body)
+
+	chatReq, err := service.ParseChatCompletionsRequest(body)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body: "+err.Error())
+		return
+	}
+	setOpsRequestContext(c, chatReq.Model, chatReq.Stream, body)
+
+	if chatReq.Model == "" {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "model is required")
+		return
+	}
+
+	// 检查 API Key 模型白名单与 max_tokens 上限
+	if err := apiKey.CheckRequestLimits(chatReq.Model, chatReq.MaxTokens); err != nil {
+		h.errorResponse(c, http.StatusForbidden, "permission_error", pkgerrors.Message(err))
+		return
+	}
+
+	// 检查 API Key RPM/TPM 限制（按 OpenAI 协议返回响应头，后续转发的 handler 不再重复计数）
+	if limited := checkAPIKeyRateLimit(c, h.gatewayHandler.apiKeyRateLimiter, apiKey, RateLimitHeadersOpenAI); limited != nil {
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", rateLimitErrorMessage(limited))
+		return
+	}
+
+	platform := ""
+	if forcePlatform, ok := middleware2.GetForcePlatformFromContext(c); ok {
+		platform = forcePlatform
+	} else if apiKey.Group != nil {
+		platform = apiKey.Group.Platform
+	}
+
+	// OpenAI 分组走 Responses，其余平台（Anthropic/Gemini/Antigravity）走 Messages
+	format := service.ChatCompletionsUpstreamClaude
+	next := h.gatewayHandler.Messages
+	convert := service.ConvertChatCompletionsToClaude
+	if platform == service.PlatformOpenAI {
+		format = service.ChatCompletionsUpstreamResponses
+		next = h.openaiGatewayHandler.Responses
+		convert = service.ConvertChatCompletionsToResponses
+	}
+	chatReq.ApplyMaxTokensCap(apiKey.MaxTokensCap, format)
+
+	converted, err := convert(chatReq)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(converted))
+	c.Request.ContentLength = int64(len(converted))
+
+	// 下游处理器写出的响应（含错误、keepalive）统一经转换写入器输出
+	originalWriter := c.Writer
+	writer := service.NewChatCompletionsResponseWriter(originalWriter, format, chatReq.Model)
+	c.Writer = writer
+	defer func() {
+		writer.Finish()
+		c.Writer = originalWriter
+	}()
+
+	next(c)
+}
+
+// errorResponse returns OpenAI API format error response
+func (h *ChatCompletionsHandler) errorResponse(c *gin.Context, status int, errType, message string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
@@ -2,6 +2,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -59,8 +60,10 @@ func UserFromServiceAdmin(u *service.User) *AdminUser {
 		return nil
 	}
 	return &AdminUser{
-		User:  *base,
-		Notes: u.Notes,
+		User:          *base,
+		Notes:         u.Notes,
+		QueuePriority: u.QueuePriority,
+		QueueWeight:   u.QueueWeight,
 	}
 }
 
@@ -69,18 +72,26 @@ func APIKeyFromService(k *service.APIKey) *APIKey {
 		return nil
 	}
 	return &APIKey{
-		ID:          k.ID,
-		UserID:      k.UserID,
-		Key:         k.Key,
-		Name:        k.Name,
-		GroupID:     k.GroupID,
-		Status:      k.Status,
-		IPWhitelist: k.IPWhitelist,
-		IPBlacklist: k.IPBlacklist,
-		CreatedAt:   k.CreatedAt,
-		UpdatedAt:   k.UpdatedAt,
-		User:        UserFromServiceShallow(k.User),
-		Group:       GroupFromServiceShallow(k.Group),
+		ID:             k.ID,
+		UserID:         k.UserID,
+		Key:            k.Key,
+		Name:           k.Name,
+		GroupID:        k.GroupID,
+		Status:         k.Status,
+		IPWhitelist:    k.IPWhitelist,
+		IPBlacklist:    k.IPBlacklist,
+		QuotaUSD:       k.QuotaUSD,
+		UsedUSD:        k.UsedUSD,
+		ExpiresAt:      k.ExpiresAt,
+		AllowedModels:  k.AllowedModels,
+		MaxTokensCap:   k.MaxTokensCap,
+		RPMLimit:       k.RPMLimit,
+		TPMLimit:       k.TPMLimit,
+		OrganizationID: k.OrganizationID,
+		CreatedAt:      k.CreatedAt,
+		UpdatedAt:      k.UpdatedAt,
+		User:           UserFromServiceShallow(k.User),
+		Group:          GroupFromServiceShallow(k.Group),
 	}
 }
 
@@ -92,6 +103,36 @@ func GroupFromServiceShallow(g *service.Group) *Group {
 	return &out
 }
 
+func modelFallbacksFromService(fallbacks map[string][]service.ModelFallbackTarget) map[string][]ModelFallbackTarget {
+	if fallbacks == nil {
+		return nil
+	}
+	out := make(map[string][]ModelFallbackTarget, len(fallbacks))
+	for pattern, targets := range fallbacks {
+		converted := make([]ModelFallbackTarget, 0, len(targets))
+		for _, target := range targets {
+			converted = append(converted, ModelFallbackTarget{GroupID: target.GroupID, Model: target.Model})
+		}
+		out[pattern] = converted
+	}
+	return out
+}
+
+func guardrailConfigFromService(cfg *service.GuardrailConfig) *GuardrailConfig {
+	if cfg == nil {
+		return nil
+	}
+	return &GuardrailConfig{
+		Enabled:         cfg.Enabled,
+		DenyKeywords:    cfg.DenyKeywords,
+		DenyPatterns:    cfg.DenyPatterns,
+		RedactPII:       cfg.RedactPII,
+		RedactResponses: cfg.RedactResponses,
+		MaxPromptChars:  cfg.MaxPromptChars,
+		SystemPrompt:    cfg.SystemPrompt,
+	}
+}
+
 func GroupFromService(g *service.Group) *Group {
 	if g == nil {
 		return nil
@@ -110,6 +151,16 @@ func GroupFromServiceAdmin(g *service.Group) *AdminGroup {
 		ModelRouting:        g.ModelRouting,
 		ModelRoutingEnabled: g.ModelRoutingEnabled,
 		AccountCount:        g.AccountCount,
+
+		ResponseCacheEnabled:    g.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds: g.ResponseCacheTTLSeconds,
+		ResponseCacheRate:       g.ResponseCacheRate,
+		ModelFallbacks:          modelFallbacksFromService(g.ModelFallbacks),
+		Guardrails:              guardrailConfigFromService(g.Guardrails),
+		SchedulingStrategy:      g.SchedulingStrategy,
+		SchedulingWeights:       g.SchedulingWeights,
+		HedgeDelayMs:            g.HedgeDelayMs,
+		QueueWeight:             g.QueueWeight,
 	}
 	if len(g.AccountGroups) > 0 {
 		out.AccountGroups = make([]AccountGroup, 0, len(g.AccountGroups))
@@ -139,6 +190,8 @@ func groupFromServiceBase(g *service.Group) Group {
 		ImagePrice4K:     g.ImagePrice4K,
 		ClaudeCodeOnly:   g.ClaudeCodeOnly,
 		FallbackGroupID:  g.FallbackGroupID,
+		DefaultRPMLimit:  g.DefaultRPMLimit,
+		DefaultTPMLimit:  g.DefaultTPMLimit,
 		CreatedAt:        g.CreatedAt,
 		UpdatedAt:        g.UpdatedAt,
 	}
@@ -361,6 +414,7 @@ func usageLogFromServiceUser(l *service.UsageLog) UsageLog {
 		Model:                 l.Model,
 		GroupID:               l.GroupID,
 		SubscriptionID:        l.SubscriptionID,
+		OrganizationID:        l.OrganizationID,
 		InputTokens:           l.InputTokens,
 		OutputTokens:          l.OutputTokens,
 		CacheCreationTokens:   l.CacheCreationTokens,
@@ -380,6 +434,14 @@ func usageLogFromServiceUser(l *service.UsageLog) UsageLog {
 		FirstTokenMs:          l.FirstTokenMs,
 		ImageCount:            l.ImageCount,
 		ImageSize:             l.ImageSize,
+		CacheServed:           l.CacheServed,
+		CacheSavedCost:        l.CacheSavedCost,
+		Hedged:                l.Hedged,
+		HedgeBackupWon:        l.HedgeBackupWon,
+		ClientDisconnected:    l.ClientDisconnected,
+		PricingTier:           l.PricingTier,
+		ServiceTier:           l.ServiceTier,
+		CostDetail:            (*UsageCostDetail)(l.CostDetail),
 		UserAgent:             l.UserAgent,
 		CreatedAt:             l.CreatedAt,
 		User:                  UserFromServiceShallow(l.User),
@@ -546,3 +608,371 @@ func PromoCodeUsageFromService(u *service.PromoCodeUsage) *PromoCodeUsage {
 		User:        UserFromServiceShallow(u.User),
 	}
 }
+
+func WebhookEndpointFromService(e *service.WebhookEndpoint) *WebhookEndpoint {
+	if e == nil {
+		return nil
+	}
+	events := e.Events
+	if events == nil {
+		events = []string{}
+	}
+	return &WebhookEndpoint{
+		ID:        e.ID,
+		Name:      e.Name,
+		URL:       e.URL,
+		Format:    e.Format,
+		Events:    events,
+		Enabled:   e.Enabled,
+		HasSecret: e.Secret != "",
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func WebhookDeliveryFromService(d *service.WebhookDelivery) *WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+	return &WebhookDelivery{
+		ID:             d.ID,
+		EndpointID:     d.EndpointID,
+		EventType:      d.EventType,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		ErrorMessage:   d.ErrorMessage,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+func ModelPriceFromService(p *service.ModelPrice) *ModelPrice {
+	if p == nil {
+		return nil
+	}
+	tiers := make([]ModelPriceTier, 0, len(p.Tiers))
+	for _, t := range p.Tiers {
+		tiers = append(tiers, ModelPriceTier(t))
+	}
+	return &ModelPrice{
+		ID:                p.ID,
+		Model:             p.Model,
+		GroupID:           p.GroupID,
+		InputPrice:        p.InputPrice,
+		OutputPrice:       p.OutputPrice,
+		CacheWritePrice:   p.CacheWritePrice,
+		CacheReadPrice:    p.CacheReadPrice,
+		ImagePrice:        p.ImagePrice,
+		CacheWrite1hPrice: p.CacheWrite1hPrice,
+		Tiers:             tiers,
+		EffectiveFrom:     p.EffectiveFrom,
+		InEffect:          p.InEffect(time.Now()),
+		Notes:             p.Notes,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+	}
+}
+
+func AuditLogFromService(l *service.AuditLog) *AuditLog {
+	if l == nil {
+		return nil
+	}
+	var diff map[string]AuditChange
+	if len(l.Diff) > 0 {
+		diff = make(map[string]AuditChange, len(l.Diff))
+		for path, change := range l.Diff {
+			diff[path] = AuditChange{Before: change.Before, After: change.After}
+		}
+	}
+	return &AuditLog{
+		ID:          l.ID,
+		ActorUserID: l.ActorUserID,
+		ActorEmail:  l.ActorEmail,
+		AuthMethod:  l.AuthMethod,
+		IP:          l.IP,
+		UserAgent:   l.UserAgent,
+		Method:      l.Method,
+		Route:       l.Route,
+		Path:        l.Path,
+		TargetType:  l.TargetType,
+		TargetID:    l.TargetID,
+		StatusCode:  l.StatusCode,
+		RequestBody: l.RequestBody,
+		Diff:        diff,
+		CreatedAt:   l.CreatedAt,
+	}
+}
+
+// BalanceTransactionFromService converts a balance transaction for user-facing endpoints.
+// It omits admin notes and operator.
+func BalanceTransactionFromService(t *service.BalanceTransaction) *BalanceTransaction {
+	if t == nil {
+		return nil
+	}
+	return &BalanceTransaction{
+		ID:           t.ID,
+		Type:         t.Type,
+		Amount:       t.Amount,
+		BalanceAfter: t.BalanceAfter,
+		UsageLogID:   t.UsageLogID,
+		RedeemCodeID: t.RedeemCodeID,
+		PromoCodeID:  t.PromoCodeID,
+		CreatedAt:    t.CreatedAt,
+	}
+}
+
+func BalanceTransactionFromServiceAdmin(t *service.BalanceTransaction) *AdminBalanceTransaction {
+	base := BalanceTransactionFromService(t)
+	if base == nil {
+		return nil
+	}
+	return &AdminBalanceTransaction{
+		BalanceTransaction: *base,
+		UserID:             t.UserID,
+		AdminUserID:        t.AdminUserID,
+		Notes:              t.Notes,
+	}
+}
+
+func BalanceInconsistencyFromService(b *service.BalanceInconsistency) *BalanceInconsistency {
+	if b == nil {
+		return nil
+	}
+	return &BalanceInconsistency{
+		UserID:     b.UserID,
+		Email:      b.Email,
+		Balance:    b.Balance,
+		LedgerSum:  b.LedgerSum,
+		Difference: b.Difference(),
+		TxCount:    b.TxCount,
+	}
+}
+
+// InvoiceFromService converts an invoice for user-facing endpoints.
+func InvoiceFromService(inv *service.Invoice) *Invoice {
+	if inv == nil {
+		return nil
+	}
+	items := make([]InvoiceLineItem, 0, len(inv.LineItems))
+	for _, item := range inv.LineItems {
+		items = append(items, InvoiceLineItem{
+			Kind:         item.Kind,
+			Description:  item.Description,
+			Model:        item.Model,
+			GroupID:      item.GroupID,
+			GroupName:    item.GroupName,
+			Subscription: item.Subscription,
+			Requests:     item.Requests,
+			InputTokens:  item.InputTokens,
+			OutputTokens: item.OutputTokens,
+			CacheTokens:  item.CacheTokens,
+			StandardCost: item.StandardCost,
+			Amount:       item.Amount,
+			Date:         item.Date,
+			ExpiresAt:    item.ExpiresAt,
+			Reference:    item.Reference,
+		})
+	}
+	return &Invoice{
+		ID:                inv.ID,
+		Number:            inv.Number(),
+		Period:            inv.Period(),
+		PeriodStart:       inv.PeriodStart,
+		PeriodEnd:         inv.PeriodEnd,
+		Status:            inv.Status,
+		Currency:          inv.Currency,
+		OpeningBalance:    inv.OpeningBalance,
+		ClosingBalance:    inv.ClosingBalance,
+		UsageCharges:      inv.UsageCharges,
+		SubscriptionUsage: inv.SubscriptionUsage,
+		Credits:           inv.Credits,
+		Adjustments:       inv.Adjustments,
+		LineItems:         items,
+		Revision:          inv.Revision,
+		GeneratedAt:       inv.GeneratedAt,
+		VoidedAt:          inv.VoidedAt,
+	}
+}
+
+func InvoiceFromServiceAdmin(inv *service.Invoice) *AdminInvoice {
+	base := InvoiceFromService(inv)
+	if base == nil {
+		return nil
+	}
+	return &AdminInvoice{
+		Invoice:    *base,
+		UserID:     inv.UserID,
+		User:       UserFromServiceShallow(inv.User),
+		VoidedBy:   inv.VoidedBy,
+		VoidReason: inv.VoidReason,
+		CreatedAt:  inv.CreatedAt,
+		UpdatedAt:  inv.UpdatedAt,
+	}
+}
+
+func OrganizationFromService(o *service.Organization) *Organization {
+	if o == nil {
+		return nil
+	}
+	out := &Organization{
+		ID:                  o.ID,
+		Name:                o.Name,
+		OwnerUserID:         o.OwnerUserID,
+		Balance:             o.Balance,
+		SharedSubscriptions: o.SharedSubscriptions,
+		Status:              o.Status,
+		MemberCount:         o.MemberCount,
+		CreatedAt:           o.CreatedAt,
+		UpdatedAt:           o.UpdatedAt,
+	}
+	if o.Owner != nil {
+		out.OwnerEmail = o.Owner.Email
+	}
+	return out
+}
+
+func OrganizationMembershipFromService(m *service.OrganizationMember) *OrganizationMembership {
+	if m == nil || m.Organization == nil {
+		return nil
+	}
+	return &OrganizationMembership{
+		Organization:       *OrganizationFromService(m.Organization),
+		Role:               m.Role,
+		SpendLimitUSD:      m.SpendLimitUSD,
+		CurrentPeriodSpend: m.CurrentPeriodSpend(time.Now()),
+	}
+}
+
+func OrganizationMemberFromService(m *service.OrganizationMember) *OrganizationMember {
+	if m == nil {
+		return nil
+	}
+	out := &OrganizationMember{
+		UserID:             m.UserID,
+		Role:               m.Role,
+		SpendLimitUSD:      m.SpendLimitUSD,
+		CurrentPeriodSpend: m.CurrentPeriodSpend(time.Now()),
+		JoinedAt:           m.CreatedAt,
+	}
+	if m.User != nil {
+		out.Email = m.User.Email
+		out.Username = m.User.Username
+	}
+	return out
+}
+
+func OrganizationInvitationFromService(inv *service.OrganizationInvitation) *OrganizationInvitation {
+	if inv == nil {
+		return nil
+	}
+	out := &OrganizationInvitation{
+		ID:             inv.ID,
+		OrganizationID: inv.OrganizationID,
+		Email:          inv.Email,
+		Role:           inv.Role,
+		Status:         inv.Status,
+		InvitedBy:      inv.InvitedBy,
+		ExpiresAt:      inv.ExpiresAt,
+		AcceptedBy:     inv.AcceptedBy,
+		AcceptedAt:     inv.AcceptedAt,
+		CreatedAt:      inv.CreatedAt,
+	}
+	if inv.Organization != nil {
+		out.OrganizationName = inv.Organization.Name
+	}
+	return out
+}
+
+func OrganizationBalanceTransactionFromService(t *service.OrganizationBalanceTransaction) *OrganizationBalanceTransaction {
+	if t == nil {
+		return nil
+	}
+	return &OrganizationBalanceTransaction{
+		ID:           t.ID,
+		UserID:       t.UserID,
+		Type:         t.Type,
+		Amount:       t.Amount,
+		BalanceAfter: t.BalanceAfter,
+		UsageLogID:   t.UsageLogID,
+		CreatedAt:    t.CreatedAt,
+	}
+}
+
+func OrganizationBalanceTransactionFromServiceAdmin(t *service.OrganizationBalanceTransaction) *AdminOrganizationBalanceTransaction {
+	base := OrganizationBalanceTransactionFromService(t)
+	if base == nil {
+		return nil
+	}
+	return &AdminOrganizationBalanceTransaction{
+		OrganizationBalanceTransaction: *base,
+		AdminUserID:                    t.AdminUserID,
+		Notes:                          t.Notes,
+	}
+}
+
+func OrganizationAPIKeyFromService(k *service.APIKey) *OrganizationAPIKey {
+	if k == nil {
+		return nil
+	}
+	out := &OrganizationAPIKey{
+		ID:        k.ID,
+		UserID:    k.UserID,
+		Name:      k.Name,
+		GroupID:   k.GroupID,
+		Status:    k.Status,
+		QuotaUSD:  k.QuotaUSD,
+		UsedUSD:   k.UsedUSD,
+		ExpiresAt: k.ExpiresAt,
+		CreatedAt: k.CreatedAt,
+	}
+	if k.User != nil {
+		out.Email = k.User.Email
+	}
+	if k.Group != nil {
+		out.GroupName = k.Group.Name
+	}
+	return out
+}
+
+func BalanceAlertSettingsFromService(s *service.BalanceAlertSettings) *BalanceAlertSettings {
+	if s == nil {
+		return nil
+	}
+	thresholds := s.Thresholds
+	if thresholds == nil {
+		thresholds = []float64{}
+	}
+	return &BalanceAlertSettings{
+		Thresholds:         thresholds,
+		EmailEnabled:       s.EmailEnabled,
+		WebhookURL:         s.WebhookURL,
+		WebhookSecret:      s.WebhookSecret,
+		NotifiedThreshold:  s.NotifiedThreshold,
+		AutoTopUpEnabled:   s.AutoTopUpEnabled,
+		AutoTopUpThreshold: s.AutoTopUpThreshold,
+		AutoTopUpLastAt:    s.AutoTopUpLastAt,
+	}
+}
+
+func AutoTopUpCodeFromService(c *service.AutoTopUpCode) *AutoTopUpCode {
+	if c == nil {
+		return nil
+	}
+	return &AutoTopUpCode{
+		ID:           c.ID,
+		Code:         c.Code,
+		Type:         c.Type,
+		Value:        c.Value,
+		GroupID:      c.GroupID,
+		ValidityDays: c.ValidityDays,
+		Status:       c.Status,
+		ErrorMessage: c.ErrorMessage,
+		UsedAt:       c.UsedAt,
+		CreatedAt:    c.CreatedAt,
+	}
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type User struct {
 	ID            int64     `json:"id"`
@@ -24,20 +27,31 @@ type User struct {
 type AdminUser struct {
 	User
 
-	Notes string `json:"notes"`
+	Notes         string `json:"notes"`
+	QueuePriority int    `json:"queue_priority"`
+	QueueWeight   int    `json:"queue_weight"`
 }
 
 type APIKey struct {
-	ID          int64     `json:"id"`
-	UserID      int64     `json:"user_id"`
-	Key         string    `json:"key"`
-	Name        string    `json:"name"`
-	GroupID     *int64    `json:"group_id"`
-	Status      string    `json:"status"`
-	IPWhitelist []string  `json:"ip_whitelist"`
-	IPBlacklist []string  `json:"ip_blacklist"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"user_id"`
+	Key           string     `json:"key"`
+	Name          string     `json:"name"`
+	GroupID       *int64     `json:"group_id"`
+	Status        string     `json:"status"`
+	IPWhitelist   []string   `json:"ip_whitelist"`
+	IPBlacklist   []string   `json:"ip_blacklist"`
+	QuotaUSD      *float64   `json:"quota_usd"`
+	UsedUSD       float64    `json:"used_usd"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	AllowedModels []string   `json:"allowed_models"`
+	MaxTokensCap  *int       `json:"max_tokens_cap"`
+	RPMLimit      *int       `json:"rpm_limit"`
+	TPMLimit      *int       `json:"tpm_limit"`
+	// OrganizationID 团队 API Key 所属组织
+	OrganizationID *int64    `json:"organization_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 
 	User  *User  `json:"user,omitempty"`
 	Group *Group `json:"group,omitempty"`
@@ -66,6 +80,10 @@ type Group struct {
 	ClaudeCodeOnly  bool   `json:"claude_code_only"`
 	FallbackGroupID *int64 `json:"fallback_group_id"`
 
+	// API Key 默认速率限制
+	DefaultRPMLimit *int `json:"default_rpm_limit"`
+	DefaultTPMLimit *int `json:"default_tpm_limit"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -79,10 +97,46 @@ type AdminGroup struct {
 	ModelRouting        map[string][]int64 `json:"model_routing"`
 	ModelRoutingEnabled bool               `json:"model_routing_enabled"`
 
+	// 响应缓存配置
+	ResponseCacheEnabled    bool    `json:"response_cache_enabled"`
+	ResponseCacheTTLSeconds *int    `json:"response_cache_ttl_seconds"`
+	ResponseCacheRate       float64 `json:"response_cache_rate"`
+
+	// 模型降级链：模型模式 -> 依次尝试的目标分组与模型
+	ModelFallbacks map[string][]ModelFallbackTarget `json:"model_fallbacks"`
+
+	// 护栏配置
+	Guardrails *GuardrailConfig `json:"guardrails"`
+
+	// 调度策略与加权轮询权重
+	SchedulingStrategy string        `json:"scheduling_strategy"`
+	SchedulingWeights  map[int64]int `json:"scheduling_weights"`
+
+	// 对冲延迟（毫秒）
+	HedgeDelayMs int `json:"hedge_delay_ms"`
+
+	// 公平排队权重
+	QueueWeight int `json:"queue_weight"`
+
 	AccountGroups []AccountGroup `json:"account_groups,omitempty"`
 	AccountCount  int64          `json:"account_count,omitempty"`
 }
 
+type ModelFallbackTarget struct {
+	GroupID int64  `json:"group_id"`
+	Model   string `json:"model"`
+}
+
+type GuardrailConfig struct {
+	Enabled         bool     `json:"enabled"`
+	DenyKeywords    []string `json:"deny_keywords"`
+	DenyPatterns    []string `json:"deny_patterns"`
+	RedactPII       []string `json:"redact_pii"`
+	RedactResponses bool     `json:"redact_responses"`
+	MaxPromptChars  int      `json:"max_prompt_chars"`
+	SystemPrompt    string   `json:"system_prompt"`
+}
+
 type Account struct {
 	ID                 int64          `json:"id"`
 	Name               string         `json:"name"`
@@ -222,6 +276,7 @@ type UsageLog struct {
 
 	GroupID        *int64 `json:"group_id"`
 	SubscriptionID *int64 `json:"subscription_id"`
+	OrganizationID *int64 `json:"organization_id"`
 
 	InputTokens         int `json:"input_tokens"`
 	OutputTokens        int `json:"output_tokens"`
@@ -248,6 +303,22 @@ type UsageLog struct {
 	ImageCount int     `json:"image_count"`
 	ImageSize  *string `json:"image_size"`
 
+	// 响应缓存命中（未请求上游）及节省的实际费用
+	CacheServed    bool    `json:"cache_served"`
+	CacheSavedCost float64 `json:"cache_saved_cost"`
+
+	// 对冲请求：是否发起过对冲请求、是否由备用账号胜出
+	Hedged         bool `json:"hedged"`
+	HedgeBackupWon bool `json:"hedge_backup_won"`
+
+	// 客户端在流式传输中途断开
+	ClientDisconnected bool `json:"client_disconnected"`
+
+	// 阶梯计费：长上下文阶梯、服务等级与计费明细（历史记录 cost_detail 为 null）
+	PricingTier string           `json:"pricing_tier"`
+	ServiceTier string           `json:"service_tier"`
+	CostDetail  *UsageCostDetail `json:"cost_detail"`
+
 	// User-Agent
 	UserAgent *string `json:"user_agent"`
 
@@ -381,3 +452,295 @@ type PromoCodeUsage struct {
 
 	User *User `json:"user,omitempty"`
 }
+
+// WebhookEndpoint 出站 Webhook 端点（密钥仅在创建时返回）
+type WebhookEndpoint struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Format    string    `json:"format"`
+	Events    []string  `json:"events"`
+	Enabled   bool      `json:"enabled"`
+	HasSecret bool      `json:"has_secret"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery Webhook 投递日志
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	EndpointID     int64           `json:"endpoint_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus *int            `json:"response_status"`
+	ResponseBody   *string         `json:"response_body"`
+	ErrorMessage   *string         `json:"error_message"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// ModelPrice 管理员自定义模型价格（每 token / 每张图片 USD）
+type ModelPrice struct {
+	ID              int64    `json:"id"`
+	Model           string   `json:"model"`
+	GroupID         *int64   `json:"group_id"`
+	InputPrice      float64  `json:"input_price"`
+	OutputPrice     float64  `json:"output_price"`
+	CacheWritePrice float64  `json:"cache_write_price"`
+	CacheReadPrice  float64  `json:"cache_read_price"`
+	ImagePrice      *float64 `json:"image_price"`
+	// CacheWrite1hPrice 为 null 表示按 5 分钟价格换算
+	CacheWrite1hPrice *float64         `json:"cache_write_1h_price"`
+	Tiers             []ModelPriceTier `json:"tiers"`
+	EffectiveFrom     time.Time        `json:"effective_from"`
+	InEffect          bool             `json:"in_effect"`
+	Notes             string           `json:"notes"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
+
+// UsageCostDetail 用量计费明细：实际单价（已应用长上下文阶梯与服务等级）及 5m/1h 缓存创建费用
+type UsageCostDetail struct {
+	PriceSource       string   `json:"price_source,omitempty"`
+	ContextTokens     int      `json:"context_tokens"`
+	TierThreshold     int      `json:"tier_threshold,omitempty"`
+	InputPrice        float64  `json:"input_price"`
+	OutputPrice       float64  `json:"output_price"`
+	CacheWrite5mPrice float64  `json:"cache_write_5m_price"`
+	CacheWrite1hPrice float64  `json:"cache_write_1h_price"`
+	CacheReadPrice    float64  `json:"cache_read_price"`
+	CacheWrite5mCost  float64  `json:"cache_write_5m_cost"`
+	CacheWrite1hCost  float64  `json:"cache_write_1h_cost"`
+	CacheServedRate   *float64 `json:"cache_served_rate,omitempty"`
+}
+
+// ModelPriceTier 长上下文阶梯价格（上下文 token 超过 threshold 时整个请求按该价格计费）
+type ModelPriceTier struct {
+	Threshold         int     `json:"threshold"`
+	InputPrice        float64 `json:"input_price"`
+	OutputPrice       float64 `json:"output_price"`
+	CacheWritePrice   float64 `json:"cache_write_price"`
+	CacheWrite1hPrice float64 `json:"cache_write_1h_price"`
+	CacheReadPrice    float64 `json:"cache_read_price"`
+}
+
+// AuditLog 管理后台审计日志
+type AuditLog struct {
+	ID          int64                  `json:"id"`
+	ActorUserID *int64                 `json:"actor_user_id"`
+	ActorEmail  string                 `json:"actor_email"`
+	AuthMethod  string                 `json:"auth_method"`
+	IP          string                 `json:"ip"`
+	UserAgent   string                 `json:"user_agent"`
+	Method      string                 `json:"method"`
+	Route       string                 `json:"route"`
+	Path        string                 `json:"path"`
+	TargetType  string                 `json:"target_type"`
+	TargetID    string                 `json:"target_id"`
+	StatusCode  int                    `json:"status_code"`
+	RequestBody *string                `json:"request_body"`
+	Diff        map[string]AuditChange `json:"diff"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// AuditChange 字段变更前后值（敏感字段已脱敏）
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// BalanceTransaction 用户余额流水
+type BalanceTransaction struct {
+	ID           int64     `json:"id"`
+	Type         string    `json:"type"`
+	Amount       float64   `json:"amount"`
+	BalanceAfter float64   `json:"balance_after"`
+	UsageLogID   *int64    `json:"usage_log_id"`
+	RedeemCodeID *int64    `json:"redeem_code_id"`
+	PromoCodeID  *int64    `json:"promo_code_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AdminBalanceTransaction 管理员接口使用的余额流水（包含操作人与备注）
+type AdminBalanceTransaction struct {
+	BalanceTransaction
+
+	UserID      int64  `json:"user_id"`
+	AdminUserID *int64 `json:"admin_user_id"`
+	Notes       string `json:"notes"`
+}
+
+// BalanceInconsistency 账本合计与余额不一致的用户
+type BalanceInconsistency struct {
+	UserID     int64   `json:"user_id"`
+	Email      string  `json:"email"`
+	Balance    float64 `json:"balance"`
+	LedgerSum  float64 `json:"ledger_sum"`
+	Difference float64 `json:"difference"`
+	TxCount    int64   `json:"tx_count"`
+}
+
+// Invoice 用户月度账单
+type Invoice struct {
+	ID                int64             `json:"id"`
+	Number            string            `json:"number"`
+	Period            string            `json:"period"`
+	PeriodStart       time.Time         `json:"period_start"`
+	PeriodEnd         time.Time         `json:"period_end"`
+	Status            string            `json:"status"`
+	Currency          string            `json:"currency"`
+	OpeningBalance    float64           `json:"opening_balance"`
+	ClosingBalance    float64           `json:"closing_balance"`
+	UsageCharges      float64           `json:"usage_charges"`
+	SubscriptionUsage float64           `json:"subscription_usage"`
+	Credits           float64           `json:"credits"`
+	Adjustments       float64           `json:"adjustments"`
+	LineItems         []InvoiceLineItem `json:"line_items"`
+	Revision          int               `json:"revision"`
+	GeneratedAt       time.Time         `json:"generated_at"`
+	VoidedAt          *time.Time        `json:"voided_at"`
+}
+
+// InvoiceLineItem 账单明细
+type InvoiceLineItem struct {
+	Kind         string     `json:"kind"`
+	Description  string     `json:"description"`
+	Model        string     `json:"model,omitempty"`
+	GroupID      *int64     `json:"group_id,omitempty"`
+	GroupName    string     `json:"group_name,omitempty"`
+	Subscription bool       `json:"subscription,omitempty"`
+	Requests     int64      `json:"requests,omitempty"`
+	InputTokens  int64      `json:"input_tokens,omitempty"`
+	OutputTokens int64      `json:"output_tokens,omitempty"`
+	CacheTokens  int64      `json:"cache_tokens,omitempty"`
+	StandardCost float64    `json:"standard_cost,omitempty"`
+	Amount       float64    `json:"amount"`
+	Date         *time.Time `json:"date,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	Reference    string     `json:"reference,omitempty"`
+}
+
+// AdminInvoice 管理员接口使用的账单（包含用户与作废信息）
+type AdminInvoice struct {
+	Invoice
+
+	UserID     int64     `json:"user_id"`
+	User       *User     `json:"user,omitempty"`
+	VoidedBy   *int64    `json:"voided_by"`
+	VoidReason string    `json:"void_reason"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Organization 组织（团队）
+type Organization struct {
+	ID                  int64     `json:"id"`
+	Name                string    `json:"name"`
+	OwnerUserID         int64     `json:"owner_user_id"`
+	OwnerEmail          string    `json:"owner_email,omitempty"`
+	Balance             float64   `json:"balance"`
+	SharedSubscriptions bool      `json:"shared_subscriptions"`
+	Status              string    `json:"status"`
+	MemberCount         int64     `json:"member_count"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// OrganizationMembership 当前用户所在的组织及其角色
+type OrganizationMembership struct {
+	Organization
+	Role               string   `json:"role"`
+	SpendLimitUSD      *float64 `json:"spend_limit_usd"`
+	CurrentPeriodSpend float64  `json:"current_period_spend"`
+}
+
+// OrganizationMember 组织成员
+type OrganizationMember struct {
+	UserID             int64     `json:"user_id"`
+	Email              string    `json:"email"`
+	Username           string    `json:"username"`
+	Role               string    `json:"role"`
+	SpendLimitUSD      *float64  `json:"spend_limit_usd"`
+	CurrentPeriodSpend float64   `json:"current_period_spend"`
+	JoinedAt           time.Time `json:"joined_at"`
+}
+
+// OrganizationInvitation 组织邀请（不包含令牌）
+type OrganizationInvitation struct {
+	ID               int64      `json:"id"`
+	OrganizationID   int64      `json:"organization_id"`
+	OrganizationName string     `json:"organization_name,omitempty"`
+	Email            string     `json:"email"`
+	Role             string     `json:"role"`
+	Status           string     `json:"status"`
+	InvitedBy        int64      `json:"invited_by"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	AcceptedBy       *int64     `json:"accepted_by"`
+	AcceptedAt       *time.Time `json:"accepted_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// OrganizationBalanceTransaction 组织余额流水
+type OrganizationBalanceTransaction struct {
+	ID           int64     `json:"id"`
+	UserID       *int64    `json:"user_id"`
+	Type         string    `json:"type"`
+	Amount       float64   `json:"amount"`
+	BalanceAfter float64   `json:"balance_after"`
+	UsageLogID   *int64    `json:"usage_log_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AdminOrganizationBalanceTransaction 管理员接口使用的组织余额流水（包含操作管理员与备注）
+type AdminOrganizationBalanceTransaction struct {
+	OrganizationBalanceTransaction
+
+	AdminUserID *int64 `json:"admin_user_id"`
+	Notes       string `json:"notes"`
+}
+
+// OrganizationAPIKey 团队 API Key（不包含 key 本身）
+type OrganizationAPIKey struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	Email     string     `json:"email"`
+	Name      string     `json:"name"`
+	GroupID   *int64     `json:"group_id"`
+	GroupName string     `json:"group_name,omitempty"`
+	Status    string     `json:"status"`
+	QuotaUSD  *float64   `json:"quota_usd"`
+	UsedUSD   float64    `json:"used_usd"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// BalanceAlertSettings 用户低余额提醒与自动充值规则
+type BalanceAlertSettings struct {
+	Thresholds         []float64  `json:"thresholds"`
+	EmailEnabled       bool       `json:"email_enabled"`
+	WebhookURL         string     `json:"webhook_url"`
+	WebhookSecret      string     `json:"webhook_secret"`
+	NotifiedThreshold  *float64   `json:"notified_threshold"`
+	AutoTopUpEnabled   bool       `json:"auto_topup_enabled"`
+	AutoTopUpThreshold float64    `json:"auto_topup_threshold"`
+	AutoTopUpLastAt    *time.Time `json:"auto_topup_last_at"`
+}
+
+// AutoTopUpCode 预登记的自动充值兑换码
+type AutoTopUpCode struct {
+	ID           int64      `json:"id"`
+	Code         string     `json:"code"`
+	Type         string     `json:"type"`
+	Value        float64    `json:"value"`
+	GroupID      *int64     `json:"group_id"`
+	ValidityDays int        `json:"validity_days"`
+	Status       string     `json:"status"`
+	ErrorMessage *string    `json:"error_message"`
+	UsedAt       *time.Time `json:"used_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
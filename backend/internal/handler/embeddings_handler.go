@@ -0,0 +1,342 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	pkgerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmbeddingsHandler handles OpenAI compatible embeddings requests
+type EmbeddingsHandler struct {
+	gatewayService       *service.GatewayService
+	geminiCompatService  *service.GeminiMessagesCompatService
+	openaiGatewayService *service.OpenAIGatewayService
+	billingCacheService  *service.BillingCacheService
+	apiKeyRateLimiter    *service.APIKeyRateLimitService
+	concurrencyHelper    *ConcurrencyHelper
+	maxAccountSwitches   int
+}
+
+// NewEmbeddingsHandler creates a new EmbeddingsHandler
+func NewEmbeddingsHandler(
+	gatewayService *service.GatewayService,
+	geminiCompatService *service.GeminiMessagesCompatService,
+	openaiGatewayService *service.OpenAIGatewayService,
+	concurrencyService *service.ConcurrencyService,
+	billingCacheService *service.BillingCacheService,
+	apiKeyRateLimiter *service.APIKeyRateLimitService,
+	cfg *config.Config,
+) *EmbeddingsHandler {
+	maxAccountSwitches := 3
+	if cfg != nil && cfg.Gateway.MaxAccountSwitches > 0 {
+		maxAccountSwitches = cfg.Gateway.MaxAccountSwitches
+	}
+	return &EmbeddingsHandler{
+		gatewayService:       gatewayService,
+		geminiCompatService:  geminiCompatService,
+		openaiGatewayService: openaiGatewayService,
+		billingCacheService:  billingCacheService,
+		apiKeyRateLimiter:    apiKeyRateLimiter,
+		// Embeddings 为非流式请求，等待槽位期间无需发送 keepalive
+		concurrencyHelper:  NewConcurrencyHelper(concurrencyService, SSEPingFormatNone, 0),
+		maxAccountSwitches: maxAccountSwitches,
+	}
+}
+
+// Embeddings handles OpenAI Embeddings API endpoint
+// POST /v1/embeddings
+func (h *EmbeddingsHandler) Embeddings(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusInternalServerError, "api_error", "User context not found")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+	if len(body) == 0 {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Request body is empty")
+		return
+	}
+
+	setOpsRequestContext(c, "", false, body)
+
+	embeddingsReq, err := service.ParseEmbeddingsRequest(body)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body: "+err.Error())
+		return
+	}
+	reqModel := embeddingsReq.Model
+	setOpsRequestContext(c, reqModel, false, body)
+
+	if reqModel == "" {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "model is required")
+		return
+	}
+
+	// 检查 API Key 模型白名单（embeddings 无输出 token）
+	if err := apiKey.CheckRequestLimits(reqModel, 0); err != nil {
+		h.errorResponse(c, http.StatusForbidden, "permission_error", pkgerrors.Message(err))
+		return
+	}
+
+	// 检查 API Key RPM/TPM 限制
+	if limited := checkAPIKeyRateLimit(c, h.apiKeyRateLimiter, apiKey, RateLimitHeadersOpenAI); limited != nil {
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", rateLimitErrorMessage(limited))
+		return
+	}
+
+	platform := ""
+	if forcePlatform, ok := middleware2.GetForcePlatformFromContext(c); ok {
+		platform = forcePlatform
+	} else if apiKey.Group != nil {
+		platform = apiKey.Group.Platform
+	}
+	if platform != service.PlatformOpenAI && platform != service.PlatformGemini {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Embeddings are only available for OpenAI or Gemini groups")
+		return
+	}
+
+	streamStarted := false
+	subscription, _ := middleware2.GetSubscriptionFromContext(c)
+
+	// 0. 检查wait队列是否已满
+	maxWait := service.CalculateMaxWait(subject.Concurrency)
+	canWait, err := h.concurrencyHelper.IncrementWaitCount(c.Request.Context(), subject.UserID, maxWait)
+	waitCounted := false
+	if err != nil {
+		log.Printf("Increment wait count failed: %v", err)
+	} else if !canWait {
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later")
+		return
+	}
+	if err == nil && canWait {
+		waitCounted = true
+	}
+	defer func() {
+		if waitCounted {
+			h.concurrencyHelper.DecrementWaitCount(c.Request.Context(), subject.UserID)
+		}
+	}()
+
+	// 1. 获取用户并发槽位
+	userReleaseFunc, err := h.concurrencyHelper.AcquireUserSlotWithWait(c, subject.UserID, subject.Concurrency, false, &streamStarted)
+	if err != nil {
+		log.Printf("User concurrency acquire failed: %v", err)
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Concurrency limit exceeded for user, please retry later")
+		return
+	}
+	if waitCounted {
+		h.concurrencyHelper.DecrementWaitCount(c.Request.Context(), subject.UserID)
+		waitCounted = false
+	}
+	userReleaseFunc = wrapReleaseOnDone(c.Request.Context(), userReleaseFunc)
+	if userReleaseFunc != nil {
+		defer userReleaseFunc()
+	}
+
+	// 2. 检查余额/订阅（订阅窗口限额与对话请求一致）
+	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
+		log.Printf("Billing eligibility check failed after wait: %v", err)
+		status, code, message := billingErrorDetails(err)
+		h.errorResponse(c, status, code, message)
+		return
+	}
+
+	// 预占本次请求的估算费用，防止并发请求在扣费前透支；转发失败时由 defer 释放
+	reservation, err := h.billingCacheService.ReserveEmbeddingsCost(c.Request.Context(), apiKey, subscription, embeddingsReq)
+	if err != nil {
+		status, code, message := billingErrorDetails(err)
+		h.errorResponse(c, status, code, message)
+		return
+	}
+	defer reservation.Release()
+
+	switchCount := 0
+	failedAccountIDs := make(map[int64]struct{})
+	lastFailoverStatus := 0
+
+	for {
+		var selection *service.AccountSelectionResult
+		if platform == service.PlatformOpenAI {
+			selection, err = h.openaiGatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, "", reqModel, failedAccountIDs)
+		} else {
+			selection, err = h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, "", reqModel, failedAccountIDs, "")
+		}
+		if err != nil {
+			if lastFailoverStatus == 0 {
+				h.errorResponse(c, http.StatusServiceUnavailable, "api_error", "No available accounts supporting embeddings: "+err.Error())
+				return
+			}
+			h.handleFailoverExhausted(c, lastFailoverStatus)
+			return
+		}
+		account := selection.Account
+
+		// OAuth（ChatGPT / Code Assist）账号不提供 embeddings 接口，直接排除后重新选择
+		if !account.SupportsEmbeddings() {
+			if selection.Acquired && selection.ReleaseFunc != nil {
+				selection.ReleaseFunc()
+			}
+			failedAccountIDs[account.ID] = struct{}{}
+			continue
+		}
+		setOpsSelectedAccount(c, account.ID)
+
+		// 3. 获取账号并发槽位
+		accountReleaseFunc := selection.ReleaseFunc
+		if !selection.Acquired {
+			if selection.WaitPlan == nil {
+				h.errorResponse(c, http.StatusServiceUnavailable, "api_error", "No available accounts")
+				return
+			}
+			accountWaitCounted := false
+			canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+			if err != nil {
+				log.Printf("Increment account wait count failed: %v", err)
+			} else if !canWait {
+				log.Printf("Account wait queue full: account=%d", account.ID)
+				h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later")
+				return
+			}
+			if err == nil && canWait {
+				accountWaitCounted = true
+			}
+			defer func() {
+				if accountWaitCounted {
+					h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				}
+			}()
+
+			accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
+				c,
+				account.ID,
+				selection.WaitPlan.MaxConcurrency,
+				selection.WaitPlan.Timeout,
+				false,
+				&streamStarted,
+			)
+			if err != nil {
+				log.Printf("Account concurrency acquire failed: %v", err)
+				h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Concurrency limit exceeded for account, please retry later")
+				return
+			}
+			if accountWaitCounted {
+				h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				accountWaitCounted = false
+			}
+		}
+		accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
+
+		var openaiResult *service.OpenAIForwardResult
+		var geminiResult *service.ForwardResult
+		if platform == service.PlatformOpenAI {
+			openaiResult, err = h.openaiGatewayService.ForwardEmbeddings(c.Request.Context(), c, account, embeddingsReq)
+		} else {
+			geminiResult, err = h.geminiCompatService.ForwardEmbeddings(c.Request.Context(), c, account, embeddingsReq)
+		}
+		h.gatewayService.RecordAccountResult(c, account, err)
+		if accountReleaseFunc != nil {
+			accountReleaseFunc()
+		}
+		if err != nil {
+			var failoverErr *service.UpstreamFailoverError
+			if errors.As(err, &failoverErr) {
+				failedAccountIDs[account.ID] = struct{}{}
+				lastFailoverStatus = failoverErr.StatusCode
+				if switchCount >= h.maxAccountSwitches {
+					h.handleFailoverExhausted(c, lastFailoverStatus)
+					return
+				}
+				switchCount++
+				service.RecordAccountSwitch(account.Platform, failoverErr.StatusCode)
+				log.Printf("Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, h.maxAccountSwitches)
+				continue
+			}
+			log.Printf("Account %d: Forward embeddings failed: %v", account.ID, err)
+			return
+		}
+
+		userAgent := c.GetHeader("User-Agent")
+		clientIP := ip.GetClientIP(c)
+
+		// 异步记录使用量（输出 token 为 0，仅计输入费用）
+		go func(usedAccount *service.Account, ua, clientIP string, reservation *service.BillingReservation) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			var err error
+			if openaiResult != nil {
+				err = h.openaiGatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
+					Result:       openaiResult,
+					APIKey:       apiKey,
+					User:         apiKey.User,
+					Account:      usedAccount,
+					Subscription: subscription,
+					UserAgent:    ua,
+					IPAddress:    clientIP,
+					Reservation:  reservation,
+				})
+			} else {
+				err = h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
+					Result:       geminiResult,
+					APIKey:       apiKey,
+					User:         apiKey.User,
+					Account:      usedAccount,
+					Subscription: subscription,
+					UserAgent:    ua,
+					IPAddress:    clientIP,
+					Reservation:  reservation,
+				})
+			}
+			if err != nil {
+				log.Printf("Record usage failed: %v", err)
+			}
+		}(account, userAgent, clientIP, reservation.Detach())
+		return
+	}
+}
+
+func (h *EmbeddingsHandler) handleFailoverExhausted(c *gin.Context, statusCode int) {
+	switch statusCode {
+	case 429:
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Upstream rate limit exceeded, please retry later")
+	case 529:
+		h.errorResponse(c, http.StatusServiceUnavailable, "upstream_error", "Upstream service overloaded, please retry later")
+	default:
+		h.errorResponse(c, http.StatusBadGateway, "upstream_error", "Upstream request failed")
+	}
+}
+
+// errorResponse returns OpenAI API format error response
+func (h *EmbeddingsHandler) errorResponse(c *gin.Context, status int, errType, message string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
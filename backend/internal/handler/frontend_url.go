@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// frontendBaseURL 返回邮件链接使用的前端地址。
+// 优先使用站点设置中的 API 端点地址；未配置时才根据当前请求推导，
+// 此时 Host 与 X-Forwarded-Proto 由客户端控制，需要反向代理负责改写。
+func frontendBaseURL(c *gin.Context, settingService *service.SettingService) string {
+	if settingService != nil {
+		if settings, err := settingService.GetPublicSettings(c.Request.Context()); err == nil {
+			if baseURL := strings.TrimRight(strings.TrimSpace(settings.APIBaseURL), "/"); baseURL != "" {
+				return baseURL
+			}
+		}
+	}
+
+	scheme := "https"
+	if c.Request.TLS == nil {
+		// Check X-Forwarded-Proto header (common in reverse proxy setups)
+		if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+			scheme = proto
+		} else {
+			scheme = "http"
+		}
+	}
+	return scheme + "://" + c.Request.Host
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type frontendURLSettingRepoStub struct {
+	service.SettingRepository
+	values map[string]string
+}
+
+func (s *frontendURLSettingRepoStub) GetMultiple(ctx context.Context, keys []string) (map[string]string, error) {
+	return s.values, nil
+}
+
+func TestFrontendBaseURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newContext := func() *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("POST", "/api/v1/auth/forgot-password", nil)
+		c.Request.Host = "attacker.example"
+		c.Request.Header.Set("X-Forwarded-Proto", "https")
+		return c
+	}
+
+	// 配置了站点地址时不使用客户端提供的 Host
+	repo := &frontendURLSettingRepoStub{values: map[string]string{service.SettingKeyAPIBaseURL: "https://api.example.com/"}}
+	settings := service.NewSettingService(repo, &config.Config{})
+	require.Equal(t, "https://api.example.com", frontendBaseURL(newContext(), settings))
+
+	// 未配置时回退到请求推导
+	repo.values = map[string]string{}
+	require.Equal(t, "https://attacker.example", frontendBaseURL(newContext(), settings))
+	require.Equal(t, "https://attacker.example", frontendBaseURL(newContext(), nil))
+}
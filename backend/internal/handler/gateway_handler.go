@@ -28,11 +28,17 @@ type GatewayHandler struct {
 	gatewayService            *service.GatewayService
 	geminiCompatService       *service.GeminiMessagesCompatService
 	antigravityGatewayService *service.AntigravityGatewayService
+	openaiGatewayService      *service.OpenAIGatewayService
 	userService               *service.UserService
 	billingCacheService       *service.BillingCacheService
+	apiKeyRateLimiter         *service.APIKeyRateLimitService
+	responseCache             *service.ResponseCacheService
+	guardrails                *service.GuardrailService
+	opsCaptures               *service.OpsCaptureService
 	concurrencyHelper         *ConcurrencyHelper
 	maxAccountSwitches        int
 	maxAccountSwitchesGemini  int
+	streamDrainTimeout        time.Duration // 流式请求客户端断开后排空上游的最长时间
 }
 
 // NewGatewayHandler creates a new GatewayHandler
@@ -40,14 +46,20 @@ func NewGatewayHandler(
 	gatewayService *service.GatewayService,
 	geminiCompatService *service.GeminiMessagesCompatService,
 	antigravityGatewayService *service.AntigravityGatewayService,
+	openaiGatewayService *service.OpenAIGatewayService,
 	userService *service.UserService,
 	concurrencyService *service.ConcurrencyService,
 	billingCacheService *service.BillingCacheService,
+	apiKeyRateLimiter *service.APIKeyRateLimitService,
+	responseCache *service.ResponseCacheService,
+	guardrails *service.GuardrailService,
+	opsCaptures *service.OpsCaptureService,
 	cfg *config.Config,
 ) *GatewayHandler {
 	pingInterval := time.Duration(0)
 	maxAccountSwitches := 10
 	maxAccountSwitchesGemini := 3
+	streamDrainTimeout := time.Duration(0)
 	if cfg != nil {
 		pingInterval = time.Duration(cfg.Concurrency.PingInterval) * time.Second
 		if cfg.Gateway.MaxAccountSwitches > 0 {
@@ -56,16 +68,23 @@ func NewGatewayHandler(
 		if cfg.Gateway.MaxAccountSwitchesGemini > 0 {
 			maxAccountSwitchesGemini = cfg.Gateway.MaxAccountSwitchesGemini
 		}
+		streamDrainTimeout = time.Duration(cfg.Gateway.StreamDrainOnDisconnectTimeout) * time.Second
 	}
 	return &GatewayHandler{
 		gatewayService:            gatewayService,
 		geminiCompatService:       geminiCompatService,
 		antigravityGatewayService: antigravityGatewayService,
+		openaiGatewayService:      openaiGatewayService,
 		userService:               userService,
 		billingCacheService:       billingCacheService,
+		apiKeyRateLimiter:         apiKeyRateLimiter,
+		responseCache:             responseCache,
+		guardrails:                guardrails,
+		opsCaptures:               opsCaptures,
 		concurrencyHelper:         NewConcurrencyHelper(concurrencyService, SSEPingFormatClaude, pingInterval),
 		maxAccountSwitches:        maxAccountSwitches,
 		maxAccountSwitchesGemini:  maxAccountSwitchesGemini,
+		streamDrainTimeout:        streamDrainTimeout,
 	}
 }
 
@@ -122,6 +141,32 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		return
 	}
 
+	// 检查 API Key 模型白名单与 max_tokens 上限
+	if err := apiKey.CheckRequestLimits(reqModel, parsedReq.MaxTokens); err != nil {
+		h.errorResponse(c, http.StatusForbidden, "permission_error", pkgerrors.Message(err))
+		return
+	}
+
+	// 分组护栏：转发前执行长度检查、拒绝列表、PII 脱敏与系统提示词注入
+	guarded, violation := applyRequestGuardrails(c, h.guardrails, apiKey.Group, service.GuardrailFormatMessages, body)
+	if violation != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", violation.Message)
+		return
+	}
+	if guarded != nil {
+		body = guarded
+		if parsedReq, err = service.ParseGatewayRequest(body); err != nil {
+			h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+			return
+		}
+	}
+
+	// 检查 API Key RPM/TPM 限制
+	if limited := checkAPIKeyRateLimit(c, h.apiKeyRateLimiter, apiKey, RateLimitHeadersAnthropic); limited != nil {
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", rateLimitErrorMessage(limited))
+		return
+	}
+
 	// Track if we've started streaming (for error handling)
 	streamStarted := false
 
@@ -162,7 +207,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		waitCounted = false
 	}
 	// 在请求结束或 Context 取消时确保释放槽位，避免客户端断开造成泄漏
-	userReleaseFunc = wrapReleaseOnDone(c.Request.Context(), userReleaseFunc)
+	userReleaseFunc = wrapReleaseOnDoneAfter(c.Request.Context(), userReleaseFunc, streamDrainHold(h.streamDrainTimeout, reqStream))
 	if userReleaseFunc != nil {
 		defer userReleaseFunc()
 	}
@@ -175,30 +220,360 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		return
 	}
 
+	// 响应缓存：确定性非流式请求命中时不再选择账号与请求上游
+	cacheKey := h.responseCache.Key(apiKey.Group, service.ResponseCacheKindMessages, reqModel, body)
+	if entry := serveResponseCache(c, h.responseCache, service.ResponseCacheKindMessages, cacheKey); entry != nil {
+		h.recordCacheServedUsage(c, entry, apiKey, subscription)
+		return
+	}
+
+	// 预占本次请求的最大可能费用，防止并发请求在扣费前透支；转发失败时由 defer 释放
+	reservation, err := h.billingCacheService.ReserveRequestCost(c.Request.Context(), apiKey, subscription, reqModel, body)
+	if err != nil {
+		status, code, message := billingErrorDetails(err)
+		h.handleStreamingAwareError(c, status, code, message, streamStarted)
+		return
+	}
+	defer reservation.Release()
+
 	// 计算粘性会话hash
 	sessionHash := h.gatewayService.GenerateSessionHash(parsedReq)
 
 	// 获取平台：优先使用强制平台（/antigravity 路由，中间件已设置 request.Context），否则使用分组平台
 	platform := ""
-	if forcePlatform, ok := middleware2.GetForcePlatformFromContext(c); ok {
+	forcePlatform, hasForcePlatform := middleware2.GetForcePlatformFromContext(c)
+	if hasForcePlatform {
 		platform = forcePlatform
 	} else if apiKey.Group != nil {
 		platform = apiKey.Group.Platform
 	}
-	sessionKey := sessionHash
-	if platform == service.PlatformGemini && sessionHash != "" {
-		sessionKey = "gemini:" + sessionHash
-	}
 
-	if platform == service.PlatformGemini {
-		maxAccountSwitches := h.maxAccountSwitchesGemini
+	// 模型降级链：当前分组账号全部不可用时切换到下一个目标分组与模型（强制平台路由不参与降级）
+	route := newMessagesRoute(apiKey, platform, reqModel, !hasForcePlatform, body, parsedReq)
+
+routeLoop:
+	for {
+		if route.fallbackUsed {
+			sessionHash = h.gatewayService.GenerateSessionHash(route.parsedReq)
+		}
+		sessionKey := sessionHash
+		if route.platform == service.PlatformGemini && sessionHash != "" {
+			sessionKey = "gemini:" + sessionHash
+		}
+		c.Header(modelUsedHeader, route.model)
+
+		if route.responsesBody != nil {
+			// 降级到 OpenAI 分组：请求经 Chat Completions 转换为 Responses，响应再逐级转换回 Messages 格式
+			openaiSessionHash := h.openaiGatewayService.GenerateSessionHash(c, route.responsesReq)
+			maxAccountSwitches := h.maxAccountSwitches
+			switchCount := 0
+			failedAccountIDs := make(map[int64]struct{})
+			lastFailoverStatus := 0
+
+			for {
+				selection, err := h.openaiGatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), route.groupID, openaiSessionHash, route.model, failedAccountIDs)
+				if err != nil {
+					if route.advance(c.Request.Context(), h.gatewayService) {
+						continue routeLoop
+					}
+					if len(failedAccountIDs) == 0 {
+						h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
+						return
+					}
+					h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
+					return
+				}
+				account := selection.Account
+				setOpsSelectedAccount(c, account.ID)
+
+				// 3. 获取账号并发槽位
+				accountReleaseFunc := selection.ReleaseFunc
+				if !selection.Acquired {
+					if selection.WaitPlan == nil {
+						h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts", streamStarted)
+						return
+					}
+					accountWaitCounted := false
+					canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+					if err != nil {
+						log.Printf("Increment account wait count failed: %v", err)
+					} else if !canWait {
+						log.Printf("Account wait queue full: account=%d", account.ID)
+						h.handleStreamingAwareError(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later", streamStarted)
+						return
+					}
+					if err == nil && canWait {
+						accountWaitCounted = true
+					}
+					defer func() {
+						if accountWaitCounted {
+							h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+						}
+					}()
+
+					accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
+						c,
+						account.ID,
+						selection.WaitPlan.MaxConcurrency,
+						selection.WaitPlan.Timeout,
+						reqStream,
+						&streamStarted,
+					)
+					if err != nil {
+						log.Printf("Account concurrency acquire failed: %v", err)
+						h.handleConcurrencyError(c, err, "account", streamStarted)
+						return
+					}
+					if accountWaitCounted {
+						h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+						accountWaitCounted = false
+					}
+					if err := h.openaiGatewayService.BindStickySession(c.Request.Context(), route.groupID, openaiSessionHash, account.ID); err != nil {
+						log.Printf("Bind sticky session failed: %v", err)
+					}
+				}
+				// 账号槽位/等待计数需要在超时或断开时安全回收
+				accountReleaseFunc = wrapReleaseOnDoneAfter(c.Request.Context(), accountReleaseFunc, streamDrainHold(h.streamDrainTimeout, reqStream))
+
+				// 降级后的响应不写入响应缓存，转换写入器最先接收上游响应
+				debugCapture := beginOpsCapture(c, h.opsCaptures, apiKey, account, route.model, reqStream, route.responsesBody)
+				guard := beginGuardrailResponse(c, h.guardrails, apiKey.Group, reqStream)
+				converter := beginMessagesResponsesWriter(c, route.model)
+				result, hedge, err := service.ForwardHedged(c, account, openAIHedgeOptions(h.openaiGatewayService, route.group, route.groupID, route.model, failedAccountIDs),
+					func(ctx context.Context, c *gin.Context, account *service.Account) (*service.OpenAIForwardResult, error) {
+						return h.openaiGatewayService.Forward(ctx, c, account, route.responsesBody)
+					})
+				account = hedge.Account
+				converter.end(c)
+				guard.end(c, h.guardrails, apiKey.Group)
+				debugCapture.end(c, h.opsCaptures, err)
+				h.openaiGatewayService.RecordAccountResult(c, account, err)
+				if accountReleaseFunc != nil {
+					accountReleaseFunc()
+				}
+				if err != nil {
+					var failoverErr *service.UpstreamFailoverError
+					if errors.As(err, &failoverErr) {
+						failedAccountIDs[account.ID] = struct{}{}
+						lastFailoverStatus = failoverErr.StatusCode
+						if switchCount >= maxAccountSwitches {
+							if route.advance(c.Request.Context(), h.gatewayService) {
+								continue routeLoop
+							}
+							h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
+							return
+						}
+						switchCount++
+						service.RecordAccountSwitch(account.Platform, failoverErr.StatusCode)
+						log.Printf("Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
+						continue
+					}
+					// 错误响应已在Forward中处理，这里只记录日志
+					log.Printf("Account %d: Forward request failed: %v", account.ID, err)
+					return
+				}
+
+				// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
+				userAgent := c.GetHeader("User-Agent")
+				clientIP := ip.GetClientIP(c)
+				servingGroup := route.servingGroup()
+
+				// 异步记录使用量（按 OpenAI 分组与降级后的模型计费）
+				go func(result *service.OpenAIForwardResult, usedAccount *service.Account, ua, clientIP string, reservation *service.BillingReservation) {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+					if err := h.openaiGatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
+						Result:       result,
+						APIKey:       apiKey,
+						User:         apiKey.User,
+						Account:      usedAccount,
+						Subscription: subscription,
+						UserAgent:    ua,
+						IPAddress:    clientIP,
+						Hedge:        hedge,
+						Reservation:  reservation,
+						ServingGroup: servingGroup,
+					}); err != nil {
+						log.Printf("Record usage failed: %v", err)
+					}
+				}(result, account, userAgent, clientIP, reservation.Detach())
+				return
+			}
+		}
+
+		if route.platform == service.PlatformGemini {
+			maxAccountSwitches := h.maxAccountSwitchesGemini
+			switchCount := 0
+			failedAccountIDs := make(map[int64]struct{})
+			lastFailoverStatus := 0
+
+			for {
+				selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), route.groupID, sessionKey, route.model, failedAccountIDs, "") // Gemini 不使用会话限制
+				if err != nil {
+					if route.advance(c.Request.Context(), h.gatewayService) {
+						continue routeLoop
+					}
+					if len(failedAccountIDs) == 0 {
+						h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
+						return
+					}
+					h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
+					return
+				}
+				account := selection.Account
+				setOpsSelectedAccount(c, account.ID)
+
+				// 检查请求拦截（预热请求、SUGGESTION MODE等）
+				if account.IsInterceptWarmupEnabled() {
+					interceptType := detectInterceptType(body)
+					if interceptType != InterceptTypeNone {
+						if selection.Acquired && selection.ReleaseFunc != nil {
+							selection.ReleaseFunc()
+						}
+						if reqStream {
+							sendMockInterceptStream(c, reqModel, interceptType)
+						} else {
+							sendMockInterceptResponse(c, reqModel, interceptType)
+						}
+						return
+					}
+				}
+
+				// 3. 获取账号并发槽位
+				accountReleaseFunc := selection.ReleaseFunc
+				if !selection.Acquired {
+					if selection.WaitPlan == nil {
+						h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts", streamStarted)
+						return
+					}
+					accountWaitCounted := false
+					canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+					if err != nil {
+						log.Printf("Increment account wait count failed: %v", err)
+					} else if !canWait {
+						log.Printf("Account wait queue full: account=%d", account.ID)
+						h.handleStreamingAwareError(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later", streamStarted)
+						return
+					}
+					if err == nil && canWait {
+						accountWaitCounted = true
+					}
+					// Ensure the wait counter is decremented if we exit before acquiring the slot.
+					defer func() {
+						if accountWaitCounted {
+							h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+						}
+					}()
+
+					accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
+						c,
+						account.ID,
+						selection.WaitPlan.MaxConcurrency,
+						selection.WaitPlan.Timeout,
+						reqStream,
+						&streamStarted,
+					)
+					if err != nil {
+						log.Printf("Account concurrency acquire failed: %v", err)
+						h.handleConcurrencyError(c, err, "account", streamStarted)
+						return
+					}
+					// Slot acquired: no longer waiting in queue.
+					if accountWaitCounted {
+						h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+						accountWaitCounted = false
+					}
+					if err := h.gatewayService.BindStickySession(c.Request.Context(), route.groupID, sessionKey, account.ID); err != nil {
+						log.Printf("Bind sticky session failed: %v", err)
+					}
+				}
+				// 账号槽位/等待计数需要在超时或断开时安全回收
+				accountReleaseFunc = wrapReleaseOnDoneAfter(c.Request.Context(), accountReleaseFunc, streamDrainHold(h.streamDrainTimeout, reqStream))
+
+				// 转发请求 - 根据账号平台分流
+				debugCapture := beginOpsCapture(c, h.opsCaptures, apiKey, account, route.model, reqStream, route.body)
+				capture := beginResponseCapture(c, route.cacheKey(cacheKey), h.responseCache.MaxEntryBytes())
+				guard := beginGuardrailResponse(c, h.guardrails, apiKey.Group, reqStream)
+				result, hedge, err := service.ForwardHedged(c, account, h.hedgeOptions(route.group, route.groupID, route.model, failedAccountIDs),
+					func(ctx context.Context, c *gin.Context, account *service.Account) (*service.ForwardResult, error) {
+						if account.Platform == service.PlatformAntigravity {
+							return h.antigravityGatewayService.ForwardGemini(ctx, c, account, route.model, "generateContent", reqStream, route.body)
+						}
+						return h.geminiCompatService.Forward(ctx, c, account, route.body)
+					})
+				account = hedge.Account
+				guard.end(c, h.guardrails, apiKey.Group)
+				capturedBody, capturedType, captured := capture.end(c)
+				debugCapture.end(c, h.opsCaptures, err)
+				h.gatewayService.RecordAccountResult(c, account, err)
+				if accountReleaseFunc != nil {
+					accountReleaseFunc()
+				}
+				if err != nil {
+					var failoverErr *service.UpstreamFailoverError
+					if errors.As(err, &failoverErr) {
+						failedAccountIDs[account.ID] = struct{}{}
+						lastFailoverStatus = failoverErr.StatusCode
+						if switchCount >= maxAccountSwitches {
+							if route.advance(c.Request.Context(), h.gatewayService) {
+								continue routeLoop
+							}
+							h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
+							return
+						}
+						switchCount++
+						service.RecordAccountSwitch(account.Platform, failoverErr.StatusCode)
+						log.Printf("Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
+						continue
+					}
+					// 错误响应已在Forward中处理，这里只记录日志
+					log.Printf("Forward request failed: %v", err)
+					return
+				}
+				if captured && !result.Stream && result.ImageCount == 0 {
+					storeResponseCache(h.responseCache, apiKey.Group, cacheKey, newCachedResponse(capturedBody, capturedType, result, account.ID))
+				}
+
+				// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
+				userAgent := c.GetHeader("User-Agent")
+				clientIP := ip.GetClientIP(c)
+				servingGroup := route.servingGroup()
+
+				// 异步记录使用量（subscription已在函数开头获取）
+				go func(result *service.ForwardResult, usedAccount *service.Account, ua, clientIP string, reservation *service.BillingReservation) {
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					defer cancel()
+					if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
+						Result:       result,
+						APIKey:       apiKey,
+						User:         apiKey.User,
+						Account:      usedAccount,
+						Subscription: subscription,
+						UserAgent:    ua,
+						IPAddress:    clientIP,
+						Hedge:        hedge,
+						Reservation:  reservation,
+						ServingGroup: servingGroup,
+					}); err != nil {
+						log.Printf("Record usage failed: %v", err)
+					}
+				}(result, account, userAgent, clientIP, reservation.Detach())
+				return
+			}
+		}
+
+		maxAccountSwitches := h.maxAccountSwitches
 		switchCount := 0
 		failedAccountIDs := make(map[int64]struct{})
 		lastFailoverStatus := 0
 
 		for {
-			selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionKey, reqModel, failedAccountIDs, "") // Gemini 不使用会话限制
+			// 选择支持该模型的账号
+			selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), route.groupID, sessionKey, route.model, failedAccountIDs, route.parsedReq.MetadataUserID)
 			if err != nil {
+				if route.advance(c.Request.Context(), h.gatewayService) {
+					continue routeLoop
+				}
 				if len(failedAccountIDs) == 0 {
 					h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
 					return
@@ -244,7 +619,6 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 				if err == nil && canWait {
 					accountWaitCounted = true
 				}
-				// Ensure the wait counter is decremented if we exit before acquiring the slot.
 				defer func() {
 					if accountWaitCounted {
 						h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
@@ -264,25 +638,33 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 					h.handleConcurrencyError(c, err, "account", streamStarted)
 					return
 				}
-				// Slot acquired: no longer waiting in queue.
 				if accountWaitCounted {
 					h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
 					accountWaitCounted = false
 				}
-				if err := h.gatewayService.BindStickySession(c.Request.Context(), apiKey.GroupID, sessionKey, account.ID); err != nil {
+				if err := h.gatewayService.BindStickySession(c.Request.Context(), route.groupID, sessionKey, account.ID); err != nil {
 					log.Printf("Bind sticky session failed: %v", err)
 				}
 			}
 			// 账号槽位/等待计数需要在超时或断开时安全回收
-			accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
+			accountReleaseFunc = wrapReleaseOnDoneAfter(c.Request.Context(), accountReleaseFunc, streamDrainHold(h.streamDrainTimeout, reqStream))
 
 			// 转发请求 - 根据账号平台分流
-			var result *service.ForwardResult
-			if account.Platform == service.PlatformAntigravity {
-				result, err = h.antigravityGatewayService.ForwardGemini(c.Request.Context(), c, account, reqModel, "generateContent", reqStream, body)
-			} else {
-				result, err = h.geminiCompatService.Forward(c.Request.Context(), c, account, body)
-			}
+			debugCapture := beginOpsCapture(c, h.opsCaptures, apiKey, account, route.model, reqStream, route.body)
+			capture := beginResponseCapture(c, route.cacheKey(cacheKey), h.responseCache.MaxEntryBytes())
+			guard := beginGuardrailResponse(c, h.guardrails, apiKey.Group, reqStream)
+			result, hedge, err := service.ForwardHedged(c, account, h.hedgeOptions(route.group, route.groupID, route.model, failedAccountIDs),
+				func(ctx context.Context, c *gin.Context, account *service.Account) (*service.ForwardResult, error) {
+					if account.Platform == service.PlatformAntigravity {
+						return h.antigravityGatewayService.Forward(ctx, c, account, route.body)
+					}
+					return h.gatewayService.Forward(ctx, c, account, route.parsedReq)
+				})
+			account = hedge.Account
+			guard.end(c, h.guardrails, apiKey.Group)
+			capturedBody, capturedType, captured := capture.end(c)
+			debugCapture.end(c, h.opsCaptures, err)
+			h.gatewayService.RecordAccountResult(c, account, err)
 			if accountReleaseFunc != nil {
 				accountReleaseFunc()
 			}
@@ -292,24 +674,32 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 					failedAccountIDs[account.ID] = struct{}{}
 					lastFailoverStatus = failoverErr.StatusCode
 					if switchCount >= maxAccountSwitches {
+						if route.advance(c.Request.Context(), h.gatewayService) {
+							continue routeLoop
+						}
 						h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
 						return
 					}
 					switchCount++
+					service.RecordAccountSwitch(account.Platform, failoverErr.StatusCode)
 					log.Printf("Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
 					continue
 				}
 				// 错误响应已在Forward中处理，这里只记录日志
-				log.Printf("Forward request failed: %v", err)
+				log.Printf("Account %d: Forward request failed: %v", account.ID, err)
 				return
 			}
+			if captured && !result.Stream && result.ImageCount == 0 {
+				storeResponseCache(h.responseCache, apiKey.Group, cacheKey, newCachedResponse(capturedBody, capturedType, result, account.ID))
+			}
 
 			// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
 			userAgent := c.GetHeader("User-Agent")
 			clientIP := ip.GetClientIP(c)
+			servingGroup := route.servingGroup()
 
 			// 异步记录使用量（subscription已在函数开头获取）
-			go func(result *service.ForwardResult, usedAccount *service.Account, ua, clientIP string) {
+			go func(result *service.ForwardResult, usedAccount *service.Account, ua, clientIP string, reservation *service.BillingReservation) {
 				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 				defer cancel()
 				if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
@@ -320,147 +710,15 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 					Subscription: subscription,
 					UserAgent:    ua,
 					IPAddress:    clientIP,
+					Hedge:        hedge,
+					Reservation:  reservation,
+					ServingGroup: servingGroup,
 				}); err != nil {
 					log.Printf("Record usage failed: %v", err)
 				}
-			}(result, account, userAgent, clientIP)
-			return
-		}
-	}
-
-	maxAccountSwitches := h.maxAccountSwitches
-	switchCount := 0
-	failedAccountIDs := make(map[int64]struct{})
-	lastFailoverStatus := 0
-
-	for {
-		// 选择支持该模型的账号
-		selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionKey, reqModel, failedAccountIDs, parsedReq.MetadataUserID)
-		if err != nil {
-			if len(failedAccountIDs) == 0 {
-				h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
-				return
-			}
-			h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
-			return
-		}
-		account := selection.Account
-		setOpsSelectedAccount(c, account.ID)
-
-		// 检查请求拦截（预热请求、SUGGESTION MODE等）
-		if account.IsInterceptWarmupEnabled() {
-			interceptType := detectInterceptType(body)
-			if interceptType != InterceptTypeNone {
-				if selection.Acquired && selection.ReleaseFunc != nil {
-					selection.ReleaseFunc()
-				}
-				if reqStream {
-					sendMockInterceptStream(c, reqModel, interceptType)
-				} else {
-					sendMockInterceptResponse(c, reqModel, interceptType)
-				}
-				return
-			}
-		}
-
-		// 3. 获取账号并发槽位
-		accountReleaseFunc := selection.ReleaseFunc
-		if !selection.Acquired {
-			if selection.WaitPlan == nil {
-				h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts", streamStarted)
-				return
-			}
-			accountWaitCounted := false
-			canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
-			if err != nil {
-				log.Printf("Increment account wait count failed: %v", err)
-			} else if !canWait {
-				log.Printf("Account wait queue full: account=%d", account.ID)
-				h.handleStreamingAwareError(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later", streamStarted)
-				return
-			}
-			if err == nil && canWait {
-				accountWaitCounted = true
-			}
-			defer func() {
-				if accountWaitCounted {
-					h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
-				}
-			}()
-
-			accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
-				c,
-				account.ID,
-				selection.WaitPlan.MaxConcurrency,
-				selection.WaitPlan.Timeout,
-				reqStream,
-				&streamStarted,
-			)
-			if err != nil {
-				log.Printf("Account concurrency acquire failed: %v", err)
-				h.handleConcurrencyError(c, err, "account", streamStarted)
-				return
-			}
-			if accountWaitCounted {
-				h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
-				accountWaitCounted = false
-			}
-			if err := h.gatewayService.BindStickySession(c.Request.Context(), apiKey.GroupID, sessionKey, account.ID); err != nil {
-				log.Printf("Bind sticky session failed: %v", err)
-			}
-		}
-		// 账号槽位/等待计数需要在超时或断开时安全回收
-		accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
-
-		// 转发请求 - 根据账号平台分流
-		var result *service.ForwardResult
-		if account.Platform == service.PlatformAntigravity {
-			result, err = h.antigravityGatewayService.Forward(c.Request.Context(), c, account, body)
-		} else {
-			result, err = h.gatewayService.Forward(c.Request.Context(), c, account, parsedReq)
-		}
-		if accountReleaseFunc != nil {
-			accountReleaseFunc()
-		}
-		if err != nil {
-			var failoverErr *service.UpstreamFailoverError
-			if errors.As(err, &failoverErr) {
-				failedAccountIDs[account.ID] = struct{}{}
-				lastFailoverStatus = failoverErr.StatusCode
-				if switchCount >= maxAccountSwitches {
-					h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
-					return
-				}
-				switchCount++
-				log.Printf("Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
-				continue
-			}
-			// 错误响应已在Forward中处理，这里只记录日志
-			log.Printf("Account %d: Forward request failed: %v", account.ID, err)
+			}(result, account, userAgent, clientIP, reservation.Detach())
 			return
 		}
-
-		// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
-		userAgent := c.GetHeader("User-Agent")
-		clientIP := ip.GetClientIP(c)
-
-		// 异步记录使用量（subscription已在函数开头获取）
-		go func(result *service.ForwardResult, usedAccount *service.Account, ua, clientIP string) {
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
-			if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
-				Result:       result,
-				APIKey:       apiKey,
-				User:         apiKey.User,
-				Account:      usedAccount,
-				Subscription: subscription,
-				UserAgent:    ua,
-				IPAddress:    clientIP,
-			}); err != nil {
-				log.Printf("Record usage failed: %v", err)
-			}
-		}(result, account, userAgent, clientIP)
-		return
 	}
 }
 
@@ -741,6 +999,26 @@ func (h *GatewayHandler) CountTokens(c *gin.Context) {
 
 	setOpsRequestContext(c, parsedReq.Model, parsedReq.Stream, body)
 
+	// 检查 API Key 模型白名单（count_tokens 不产生输出，无需校验 max_tokens）
+	if err := apiKey.CheckRequestLimits(parsedReq.Model, 0); err != nil {
+		h.errorResponse(c, http.StatusForbidden, "permission_error", pkgerrors.Message(err))
+		return
+	}
+
+	// 分组护栏：与 /v1/messages 一致，按实际转发的请求体计数（被拒绝的请求同样拒绝计数）
+	guarded, violation := applyRequestGuardrails(c, h.guardrails, apiKey.Group, service.GuardrailFormatMessages, body)
+	if violation != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", violation.Message)
+		return
+	}
+	if guarded != nil {
+		body = guarded
+		if parsedReq, err = service.ParseGatewayRequest(body); err != nil {
+			h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+			return
+		}
+	}
+
 	// 获取订阅信息（可能为nil）
 	subscription, _ := middleware2.GetSubscriptionFromContext(c)
 
@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"math/rand"
 	"net/http"
 	"sync"
 	"time"
 
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -102,10 +104,28 @@ func NewConcurrencyHelper(concurrencyService *service.ConcurrencyService, pingFo
 	}
 }
 
+// streamDrainReleaseGrace 排空结束后 Forward 收尾（解析 usage、写回结果）的余量
+const streamDrainReleaseGrace = 30 * time.Second
+
+// streamDrainHold 返回客户端断开后槽位的保留时长：流式请求启用断开排空时为排空上限加收尾余量，否则为 0
+func streamDrainHold(drainTimeout time.Duration, stream bool) time.Duration {
+	if !stream || drainTimeout <= 0 {
+		return 0
+	}
+	return drainTimeout + streamDrainReleaseGrace
+}
+
 // wrapReleaseOnDone ensures release runs at most once and still triggers on context cancellation.
 // 用于避免客户端断开或上游超时导致的并发槽位泄漏。
 // 修复：添加 quit channel 确保 goroutine 及时退出，避免泄露
 func wrapReleaseOnDone(ctx context.Context, releaseFunc func()) func() {
+	return wrapReleaseOnDoneAfter(ctx, releaseFunc, 0)
+}
+
+// wrapReleaseOnDoneAfter 与 wrapReleaseOnDone 相同，但 context 取消后再等待 hold 才兜底释放。
+// 启用流式断开排空时，客户端断开后 Forward 仍会继续读取上游至多排空时长，
+// 槽位需保留到 Forward 返回后由处理器显式释放，避免排空期间超出用户/账号并发限制。
+func wrapReleaseOnDoneAfter(ctx context.Context, releaseFunc func(), hold time.Duration) func() {
 	if releaseFunc == nil {
 		return nil
 	}
@@ -122,6 +142,15 @@ func wrapReleaseOnDone(ctx context.Context, releaseFunc func()) func() {
 	go func() {
 		select {
 		case <-ctx.Done():
+			if hold > 0 {
+				timer := time.NewTimer(hold)
+				defer timer.Stop()
+				select {
+				case <-timer.C:
+				case <-quit:
+					return
+				}
+			}
 			// Context 取消时释放资源
 			release()
 		case <-quit:
@@ -219,6 +248,38 @@ func (h *ConcurrencyHelper) waitForSlotWithPingTimeout(c *gin.Context, slotType
 		return result.ReleaseFunc, nil
 	}
 
+	// 账号槽位启用公平排队时加入队列：按优先级与用户权重排序，而不是靠轮询运气
+	acquire := func() (*service.AcquireResult, error) {
+		if slotType == "user" {
+			return h.concurrencyService.AcquireUserSlot(ctx, id, maxConcurrency)
+		}
+		return h.concurrencyService.AcquireAccountSlot(ctx, id, maxConcurrency)
+	}
+	var ticket *service.AccountSlotTicket
+	if slotType == "account" && h.concurrencyService.FairQueueEnabled() {
+		apiKey, _ := middleware2.GetAPIKeyFromContext(c)
+		ticket, err = h.concurrencyService.JoinAccountQueue(ctx, id, service.NewSlotWaiter(apiKey))
+		if err != nil {
+			// 排队队列不可用时退回普通轮询
+			log.Printf("Warning: join account queue failed for %d: %v", id, err)
+			ticket = nil
+		} else {
+			acquired := false
+			defer func() {
+				if !acquired {
+					h.concurrencyService.LeaveAccountQueue(ticket)
+				}
+			}()
+			acquire = func() (*service.AcquireResult, error) {
+				result, err := h.concurrencyService.TryAcquireQueuedAccountSlot(ctx, ticket, maxConcurrency)
+				if err == nil && result.Acquired {
+					acquired = true
+				}
+				return result, err
+			}
+		}
+	}
+
 	// Determine if ping is needed (streaming + ping format defined)
 	needPing := isStream && h.pingFormat != ""
 
@@ -243,6 +304,10 @@ func (h *ConcurrencyHelper) waitForSlotWithPingTimeout(c *gin.Context, slotType
 	timer := time.NewTimer(backoff)
 	defer timer.Stop()
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	lastPosition := 0
+	if ticket != nil {
+		lastPosition = ticket.Position
+	}
 
 	for {
 		select {
@@ -261,6 +326,12 @@ func (h *ConcurrencyHelper) waitForSlotWithPingTimeout(c *gin.Context, slotType
 				c.Header("X-Accel-Buffering", "no")
 				*streamStarted = true
 			}
+			// 排队位置以 SSE 注释形式发送，客户端解析器会忽略注释行
+			if ticket != nil && ticket.Position > 0 {
+				if _, err := fmt.Fprintf(c.Writer, ": queue position %d\n\n", ticket.Position); err != nil {
+					return nil, err
+				}
+			}
 			if _, err := fmt.Fprint(c.Writer, string(h.pingFormat)); err != nil {
 				return nil, err
 			}
@@ -268,15 +339,7 @@ func (h *ConcurrencyHelper) waitForSlotWithPingTimeout(c *gin.Context, slotType
 
 		case <-timer.C:
 			// Try to acquire slot
-			var result *service.AcquireResult
-			var err error
-
-			if slotType == "user" {
-				result, err = h.concurrencyService.AcquireUserSlot(ctx, id, maxConcurrency)
-			} else {
-				result, err = h.concurrencyService.AcquireAccountSlot(ctx, id, maxConcurrency)
-			}
-
+			result, err := acquire()
 			if err != nil {
 				return nil, err
 			}
@@ -284,7 +347,15 @@ func (h *ConcurrencyHelper) waitForSlotWithPingTimeout(c *gin.Context, slotType
 			if result.Acquired {
 				return result.ReleaseFunc, nil
 			}
-			backoff = nextBackoff(backoff, rng)
+			if ticket != nil && ticket.Position > 0 && ticket.Position < lastPosition {
+				// 排队位置前移说明有槽位释放，缩短轮询间隔
+				backoff = initialBackoff
+			} else {
+				backoff = nextBackoff(backoff, rng)
+			}
+			if ticket != nil {
+				lastPosition = ticket.Position
+			}
 			timer.Reset(backoff)
 		}
 	}
@@ -139,3 +139,47 @@ func BenchmarkWrapReleaseOnDone(b *testing.B) {
 		release()
 	}
 }
+
+// TestWrapReleaseOnDoneAfter_HoldsSlotWhileDraining 验证启用排空时 context 取消后槽位保留到显式释放或 hold 到期
+func TestWrapReleaseOnDoneAfter_HoldsSlotWhileDraining(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var releaseCount int32
+	release := wrapReleaseOnDoneAfter(ctx, func() {
+		atomic.AddInt32(&releaseCount, 1)
+	}, time.Minute)
+
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	if count := atomic.LoadInt32(&releaseCount); count != 0 {
+		t.Fatalf("slot must stay held while the upstream drains, got %d releases", count)
+	}
+	release()
+	release()
+	if count := atomic.LoadInt32(&releaseCount); count != 1 {
+		t.Fatalf("expected release count to be 1, got %d", count)
+	}
+
+	// Forward 未返回时，hold 到期后兜底释放
+	ctx, cancel = context.WithCancel(context.Background())
+	atomic.StoreInt32(&releaseCount, 0)
+	_ = wrapReleaseOnDoneAfter(ctx, func() {
+		atomic.AddInt32(&releaseCount, 1)
+	}, 20*time.Millisecond)
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	if count := atomic.LoadInt32(&releaseCount); count != 1 {
+		t.Fatalf("expected fallback release after hold, got %d", count)
+	}
+}
+
+func TestStreamDrainHold(t *testing.T) {
+	if hold := streamDrainHold(time.Minute, true); hold != time.Minute+streamDrainReleaseGrace {
+		t.Fatalf("unexpected hold: %v", hold)
+	}
+	if hold := streamDrainHold(time.Minute, false); hold != 0 {
+		t.Fatalf("non-stream requests must not hold slots: %v", hold)
+	}
+	if hold := streamDrainHold(0, true); hold != 0 {
+		t.Fatalf("drain disabled must not hold slots: %v", hold)
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"time"
+
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GatewayMetricsMiddleware 记录网关请求的 Prometheus 计数与耗时。
+// 模型名取自 setOpsRequestContext 写入的上下文并按价格表归一化（未知模型归入 other），分组与平台取自 API Key。
+func GatewayMetricsMiddleware(billingService *service.BillingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		apiKey, _ := middleware2.GetAPIKeyFromContext(c)
+		platform := resolveOpsPlatform(apiKey, guessPlatformFromPath(c.Request.URL.Path))
+		group := ""
+		if apiKey != nil && apiKey.Group != nil {
+			group = apiKey.Group.Name
+		}
+		model := ""
+		if v, ok := c.Get(opsModelKey); ok {
+			model, _ = v.(string)
+		}
+		service.RecordGatewayRequestMetrics(platform, billingService.MetricsModelLabel(model), group, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/antigravity"
+	pkgerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/gemini"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/googleapi"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
@@ -21,6 +22,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
 )
 
 // geminiCLITmpDirRegex 用于从 Gemini CLI 请求体中提取 tmp 目录的哈希值
@@ -179,6 +181,33 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 
 	setOpsRequestContext(c, modelName, stream, body)
 
+	// 检查 API Key 模型白名单与 maxOutputTokens 上限
+	maxOutputTokens := int(gjson.GetBytes(body, "generationConfig.maxOutputTokens").Int())
+	if err := apiKey.CheckRequestLimits(modelName, maxOutputTokens); err != nil {
+		googleError(c, http.StatusForbidden, pkgerrors.Message(err))
+		return
+	}
+	// 未指定 maxOutputTokens 时写入 API Key 上限
+	if capped, ok := apiKey.ApplyMaxTokensCap(body, "generationConfig.maxOutputTokens"); ok {
+		body = capped
+	}
+
+	// 分组护栏：转发前执行长度检查、拒绝列表、PII 脱敏与系统提示词注入
+	guarded, violation := applyRequestGuardrails(c, h.guardrails, apiKey.Group, service.GuardrailFormatGemini, body)
+	if violation != nil {
+		googleError(c, http.StatusBadRequest, violation.Message)
+		return
+	}
+	if guarded != nil {
+		body = guarded
+	}
+
+	// 检查 API Key RPM/TPM 限制
+	if limited := checkAPIKeyRateLimit(c, h.apiKeyRateLimiter, apiKey, RateLimitHeadersOpenAI); limited != nil {
+		googleError(c, http.StatusTooManyRequests, rateLimitErrorMessage(limited))
+		return
+	}
+
 	// Get subscription (may be nil)
 	subscription, _ := middleware.GetSubscriptionFromContext(c)
 
@@ -216,7 +245,7 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 		waitCounted = false
 	}
 	// 确保请求取消时也会释放槽位，避免长连接被动中断造成泄漏
-	userReleaseFunc = wrapReleaseOnDone(c.Request.Context(), userReleaseFunc)
+	userReleaseFunc = wrapReleaseOnDoneAfter(c.Request.Context(), userReleaseFunc, streamDrainHold(h.streamDrainTimeout, stream))
 	if userReleaseFunc != nil {
 		defer userReleaseFunc()
 	}
@@ -228,6 +257,25 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 		return
 	}
 
+	// 响应缓存：仅 generateContent（非流式）可命中
+	cacheKey := ""
+	if !stream {
+		cacheKey = h.responseCache.Key(apiKey.Group, service.ResponseCacheKindGemini, modelName, body)
+	}
+	if entry := serveResponseCache(c, h.responseCache, service.ResponseCacheKindGemini, cacheKey); entry != nil {
+		h.recordCacheServedUsage(c, entry, apiKey, subscription)
+		return
+	}
+
+	// 预占本次请求的最大可能费用，防止并发请求在扣费前透支；转发失败时由 defer 释放
+	reservation, err := h.billingCacheService.ReserveRequestCost(c.Request.Context(), apiKey, subscription, modelName, body)
+	if err != nil {
+		status, _, message := billingErrorDetails(err)
+		googleError(c, status, message)
+		return
+	}
+	defer reservation.Release()
+
 	// 3) select account (sticky session based on request body)
 	// 优先使用 Gemini CLI 的会话标识（privileged-user-id + tmp 目录哈希）
 	sessionHash := extractGeminiCLISessionHash(c, body)
@@ -249,140 +297,174 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 	isCLI := isGeminiCLIRequest(c, body)
 	cleanedForUnknownBinding := false
 
-	maxAccountSwitches := h.maxAccountSwitchesGemini
-	switchCount := 0
-	failedAccountIDs := make(map[int64]struct{})
-	lastFailoverStatus := 0
+	// 模型降级链：当前分组账号全部不可用时切换到下一个 Gemini/Antigravity 分组与模型（强制平台路由不参与降级）
+	platform, hasForcePlatform := middleware.GetForcePlatformFromContext(c)
+	if !hasForcePlatform {
+		platform = service.PlatformGemini
+	}
+	route := newModelFallbackRoute(service.ModelFallbackEntryGemini, apiKey, platform, modelName, !hasForcePlatform)
 
+routeLoop:
 	for {
-		selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionKey, modelName, failedAccountIDs, "") // Gemini 不使用会话限制
-		if err != nil {
-			if len(failedAccountIDs) == 0 {
-				googleError(c, http.StatusServiceUnavailable, "No available Gemini accounts: "+err.Error())
-				return
-			}
-			handleGeminiFailoverExhausted(c, lastFailoverStatus)
-			return
-		}
-		account := selection.Account
-		setOpsSelectedAccount(c, account.ID)
-
-		// 检测账号切换：如果粘性会话绑定的账号与当前选择的账号不同，清除 thoughtSignature
-		// 注意：Gemini 原生 API 的 thoughtSignature 与具体上游账号强相关；跨账号透传会导致 400。
-		if sessionBoundAccountID > 0 && sessionBoundAccountID != account.ID {
-			log.Printf("[Gemini] Sticky session account switched: %d -> %d, cleaning thoughtSignature", sessionBoundAccountID, account.ID)
-			body = service.CleanGeminiNativeThoughtSignatures(body)
-			sessionBoundAccountID = account.ID
-		} else if sessionKey != "" && sessionBoundAccountID == 0 && isCLI && !cleanedForUnknownBinding && bytes.Contains(body, []byte(`"thoughtSignature"`)) {
-			// 无缓存绑定但请求里已有 thoughtSignature：常见于缓存丢失/TTL 过期后，CLI 继续携带旧签名。
-			// 为避免第一次转发就 400，这里做一次确定性清理，让新账号重新生成签名链路。
-			log.Printf("[Gemini] Sticky session binding missing for CLI request, cleaning thoughtSignature proactively")
-			body = service.CleanGeminiNativeThoughtSignatures(body)
-			cleanedForUnknownBinding = true
-			sessionBoundAccountID = account.ID
-		} else if sessionBoundAccountID == 0 {
-			// 记录本次请求中首次选择到的账号，便于同一请求内 failover 时检测切换。
-			sessionBoundAccountID = account.ID
-		}
+		c.Header(modelUsedHeader, route.model)
 
-		// 4) account concurrency slot
-		accountReleaseFunc := selection.ReleaseFunc
-		if !selection.Acquired {
-			if selection.WaitPlan == nil {
-				googleError(c, http.StatusServiceUnavailable, "No available Gemini accounts")
-				return
-			}
-			accountWaitCounted := false
-			canWait, err := geminiConcurrency.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+		maxAccountSwitches := h.maxAccountSwitchesGemini
+		switchCount := 0
+		failedAccountIDs := make(map[int64]struct{})
+		lastFailoverStatus := 0
+
+		for {
+			selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), route.groupID, sessionKey, route.model, failedAccountIDs, "") // Gemini 不使用会话限制
 			if err != nil {
-				log.Printf("Increment account wait count failed: %v", err)
-			} else if !canWait {
-				log.Printf("Account wait queue full: account=%d", account.ID)
-				googleError(c, http.StatusTooManyRequests, "Too many pending requests, please retry later")
+				if route.advance(c.Request.Context(), h.gatewayService, nil) {
+					continue routeLoop
+				}
+				if len(failedAccountIDs) == 0 {
+					googleError(c, http.StatusServiceUnavailable, "No available Gemini accounts: "+err.Error())
+					return
+				}
+				handleGeminiFailoverExhausted(c, lastFailoverStatus)
 				return
 			}
-			if err == nil && canWait {
-				accountWaitCounted = true
+			account := selection.Account
+			setOpsSelectedAccount(c, account.ID)
+
+			// 检测账号切换：如果粘性会话绑定的账号与当前选择的账号不同，清除 thoughtSignature
+			// 注意：Gemini 原生 API 的 thoughtSignature 与具体上游账号强相关；跨账号透传会导致 400。
+			if sessionBoundAccountID > 0 && sessionBoundAccountID != account.ID {
+				log.Printf("[Gemini] Sticky session account switched: %d -> %d, cleaning thoughtSignature", sessionBoundAccountID, account.ID)
+				body = service.CleanGeminiNativeThoughtSignatures(body)
+				sessionBoundAccountID = account.ID
+			} else if sessionKey != "" && sessionBoundAccountID == 0 && isCLI && !cleanedForUnknownBinding && bytes.Contains(body, []byte(`"thoughtSignature"`)) {
+				// 无缓存绑定但请求里已有 thoughtSignature：常见于缓存丢失/TTL 过期后，CLI 继续携带旧签名。
+				// 为避免第一次转发就 400，这里做一次确定性清理，让新账号重新生成签名链路。
+				log.Printf("[Gemini] Sticky session binding missing for CLI request, cleaning thoughtSignature proactively")
+				body = service.CleanGeminiNativeThoughtSignatures(body)
+				cleanedForUnknownBinding = true
+				sessionBoundAccountID = account.ID
+			} else if sessionBoundAccountID == 0 {
+				// 记录本次请求中首次选择到的账号，便于同一请求内 failover 时检测切换。
+				sessionBoundAccountID = account.ID
 			}
-			defer func() {
+
+			// 4) account concurrency slot
+			accountReleaseFunc := selection.ReleaseFunc
+			if !selection.Acquired {
+				if selection.WaitPlan == nil {
+					googleError(c, http.StatusServiceUnavailable, "No available Gemini accounts")
+					return
+				}
+				accountWaitCounted := false
+				canWait, err := geminiConcurrency.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+				if err != nil {
+					log.Printf("Increment account wait count failed: %v", err)
+				} else if !canWait {
+					log.Printf("Account wait queue full: account=%d", account.ID)
+					googleError(c, http.StatusTooManyRequests, "Too many pending requests, please retry later")
+					return
+				}
+				if err == nil && canWait {
+					accountWaitCounted = true
+				}
+				defer func() {
+					if accountWaitCounted {
+						geminiConcurrency.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+					}
+				}()
+
+				accountReleaseFunc, err = geminiConcurrency.AcquireAccountSlotWithWaitTimeout(
+					c,
+					account.ID,
+					selection.WaitPlan.MaxConcurrency,
+					selection.WaitPlan.Timeout,
+					stream,
+					&streamStarted,
+				)
+				if err != nil {
+					googleError(c, http.StatusTooManyRequests, err.Error())
+					return
+				}
 				if accountWaitCounted {
 					geminiConcurrency.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+					accountWaitCounted = false
+				}
+				if err := h.gatewayService.BindStickySession(c.Request.Context(), route.groupID, sessionKey, account.ID); err != nil {
+					log.Printf("Bind sticky session failed: %v", err)
 				}
-			}()
-
-			accountReleaseFunc, err = geminiConcurrency.AcquireAccountSlotWithWaitTimeout(
-				c,
-				account.ID,
-				selection.WaitPlan.MaxConcurrency,
-				selection.WaitPlan.Timeout,
-				stream,
-				&streamStarted,
-			)
-			if err != nil {
-				googleError(c, http.StatusTooManyRequests, err.Error())
-				return
-			}
-			if accountWaitCounted {
-				geminiConcurrency.DecrementAccountWaitCount(c.Request.Context(), account.ID)
-				accountWaitCounted = false
 			}
-			if err := h.gatewayService.BindStickySession(c.Request.Context(), apiKey.GroupID, sessionKey, account.ID); err != nil {
-				log.Printf("Bind sticky session failed: %v", err)
+			// 账号槽位/等待计数需要在超时或断开时安全回收
+			accountReleaseFunc = wrapReleaseOnDoneAfter(c.Request.Context(), accountReleaseFunc, streamDrainHold(h.streamDrainTimeout, stream))
+
+			// 5) forward (根据平台分流)
+			debugCapture := beginOpsCapture(c, h.opsCaptures, apiKey, account, route.model, stream, body)
+			capture := beginResponseCapture(c, route.cacheKey(cacheKey), h.responseCache.MaxEntryBytes())
+			guard := beginGuardrailResponse(c, h.guardrails, apiKey.Group, stream)
+			result, hedge, err := service.ForwardHedged(c, account, h.hedgeOptions(route.group, route.groupID, route.model, failedAccountIDs),
+				func(ctx context.Context, c *gin.Context, account *service.Account) (*service.ForwardResult, error) {
+					if account.Platform == service.PlatformAntigravity {
+						return h.antigravityGatewayService.ForwardGemini(ctx, c, account, route.model, action, stream, body)
+					}
+					return h.geminiCompatService.ForwardNative(ctx, c, account, route.model, action, stream, body)
+				})
+			account = hedge.Account
+			guard.end(c, h.guardrails, apiKey.Group)
+			capturedBody, capturedType, captured := capture.end(c)
+			debugCapture.end(c, h.opsCaptures, err)
+			h.gatewayService.RecordAccountResult(c, account, err)
+			if accountReleaseFunc != nil {
+				accountReleaseFunc()
 			}
-		}
-		// 账号槽位/等待计数需要在超时或断开时安全回收
-		accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
-
-		// 5) forward (根据平台分流)
-		var result *service.ForwardResult
-		if account.Platform == service.PlatformAntigravity {
-			result, err = h.antigravityGatewayService.ForwardGemini(c.Request.Context(), c, account, modelName, action, stream, body)
-		} else {
-			result, err = h.geminiCompatService.ForwardNative(c.Request.Context(), c, account, modelName, action, stream, body)
-		}
-		if accountReleaseFunc != nil {
-			accountReleaseFunc()
-		}
-		if err != nil {
-			var failoverErr *service.UpstreamFailoverError
-			if errors.As(err, &failoverErr) {
-				failedAccountIDs[account.ID] = struct{}{}
-				if switchCount >= maxAccountSwitches {
+			if err != nil {
+				var failoverErr *service.UpstreamFailoverError
+				if errors.As(err, &failoverErr) {
+					failedAccountIDs[account.ID] = struct{}{}
 					lastFailoverStatus = failoverErr.StatusCode
-					handleGeminiFailoverExhausted(c, lastFailoverStatus)
-					return
+					if switchCount >= maxAccountSwitches {
+						if route.advance(c.Request.Context(), h.gatewayService, nil) {
+							continue routeLoop
+						}
+						handleGeminiFailoverExhausted(c, lastFailoverStatus)
+						return
+					}
+					switchCount++
+					service.RecordAccountSwitch(account.Platform, failoverErr.StatusCode)
+					log.Printf("Gemini account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
+					continue
 				}
-				lastFailoverStatus = failoverErr.StatusCode
-				switchCount++
-				log.Printf("Gemini account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
-				continue
+				// ForwardNative already wrote the response
+				log.Printf("Gemini native forward failed: %v", err)
+				return
+			}
+			if captured && result.ImageCount == 0 {
+				storeResponseCache(h.responseCache, apiKey.Group, cacheKey, newCachedResponse(capturedBody, capturedType, result, account.ID))
 			}
-			// ForwardNative already wrote the response
-			log.Printf("Gemini native forward failed: %v", err)
+
+			// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
+			userAgent := c.GetHeader("User-Agent")
+			clientIP := ip.GetClientIP(c)
+			servingGroup := route.servingGroup()
+
+			// 6) record usage async
+			go func(result *service.ForwardResult, usedAccount *service.Account, ua, ip string, reservation *service.BillingReservation) {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
+					Result:       result,
+					APIKey:       apiKey,
+					User:         apiKey.User,
+					Account:      usedAccount,
+					Subscription: subscription,
+					UserAgent:    ua,
+					IPAddress:    ip,
+					Hedge:        hedge,
+					Reservation:  reservation,
+					ServingGroup: servingGroup,
+				}); err != nil {
+					log.Printf("Record usage failed: %v", err)
+				}
+			}(result, account, userAgent, clientIP, reservation.Detach())
 			return
 		}
-
-		// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
-		userAgent := c.GetHeader("User-Agent")
-		clientIP := ip.GetClientIP(c)
-
-		// 6) record usage async
-		go func(result *service.ForwardResult, usedAccount *service.Account, ua, ip string) {
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
-			if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
-				Result:       result,
-				APIKey:       apiKey,
-				User:         apiKey.User,
-				Account:      usedAccount,
-				Subscription: subscription,
-				UserAgent:    ua,
-				IPAddress:    ip,
-			}); err != nil {
-				log.Printf("Record usage failed: %v", err)
-			}
-		}(result, account, userAgent, clientIP)
-		return
 	}
 }
 
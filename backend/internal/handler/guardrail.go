@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// opsGuardrailKey 护栏拦截信息，运维错误日志据此将错误归入 guardrail 阶段
+const opsGuardrailKey = "ops_guardrail_violation"
+
+// applyRequestGuardrails 执行分组护栏链，返回改写后的请求体（未改写时为 nil）；拦截时返回 violation 并写入运维上下文
+func applyRequestGuardrails(c *gin.Context, guardrails *service.GuardrailService, group *service.Group, format string, body []byte) ([]byte, *service.GuardrailViolation) {
+	out, violation, err := guardrails.ApplyRequest(group, format, body)
+	if err != nil {
+		// 请求体无法按 JSON 对象解析时上游同样会拒绝，这里放行原请求，避免护栏影响错误信息
+		log.Printf("Guardrail apply failed: %v", err)
+		return nil, nil
+	}
+	if violation != nil {
+		c.Set(opsGuardrailKey, violation)
+	}
+	return out, violation
+}
+
+// guardrailResponseWriter 缓冲非流式响应，转发结束后整体脱敏再写回客户端；
+// 流式响应按 SSE 事件切分，经 GuardrailStreamRedactor 脱敏后即时写出
+type guardrailResponseWriter struct {
+	gin.ResponseWriter
+	buf    bytes.Buffer
+	status int
+
+	stream *service.GuardrailStreamRedactor
+}
+
+func (w *guardrailResponseWriter) WriteHeader(code int) {
+	if w.stream != nil {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+func (w *guardrailResponseWriter) WriteHeaderNow() {
+	if w.stream != nil {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *guardrailResponseWriter) Write(data []byte) (int, error) {
+	if w.stream != nil {
+		return w.writeStream(data)
+	}
+	return w.buf.Write(data)
+}
+
+func (w *guardrailResponseWriter) WriteString(s string) (int, error) {
+	if w.stream != nil {
+		return w.writeStream([]byte(s))
+	}
+	return w.buf.WriteString(s)
+}
+
+// writeStream 缓冲不完整的 SSE 事件，完整事件脱敏后写出；错误响应原样透传
+func (w *guardrailResponseWriter) writeStream(data []byte) (int, error) {
+	if w.ResponseWriter.Status() != http.StatusOK {
+		return w.ResponseWriter.Write(data)
+	}
+	w.buf.Write(data)
+	for {
+		block, delim, rest, ok := cutSSEEvent(w.buf.Bytes())
+		if !ok {
+			break
+		}
+		for _, event := range w.stream.Process(block) {
+			if err := w.writeEvent(event, delim); err != nil {
+				return 0, err
+			}
+		}
+		remaining := append([]byte(nil), rest...)
+		w.buf.Reset()
+		w.buf.Write(remaining)
+	}
+	return len(data), nil
+}
+
+func (w *guardrailResponseWriter) writeEvent(event, delim []byte) error {
+	if _, err := w.ResponseWriter.Write(event); err != nil {
+		return err
+	}
+	_, err := w.ResponseWriter.Write(delim)
+	return err
+}
+
+// cutSSEEvent 从缓冲中切出第一个完整事件，兼容 \n\n 与 \r\n\r\n 分隔
+func cutSSEEvent(buf []byte) (block, delim, rest []byte, ok bool) {
+	lf := bytes.Index(buf, []byte("\n\n"))
+	crlf := bytes.Index(buf, []byte("\r\n\r\n"))
+	switch {
+	case crlf >= 0 && (lf < 0 || crlf < lf):
+		return buf[:crlf], buf[crlf : crlf+4], buf[crlf+4:], true
+	case lf >= 0:
+		return buf[:lf], buf[lf : lf+2], buf[lf+2:], true
+	}
+	return nil, nil, nil, false
+}
+
+func (w *guardrailResponseWriter) Status() int {
+	if w.stream != nil {
+		return w.ResponseWriter.Status()
+	}
+	if w.status != 0 {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+func (w *guardrailResponseWriter) Size() int {
+	if w.stream != nil {
+		return w.ResponseWriter.Size()
+	}
+	return w.buf.Len()
+}
+
+func (w *guardrailResponseWriter) Written() bool {
+	if w.stream != nil {
+		return w.ResponseWriter.Written()
+	}
+	return w.status != 0 || w.buf.Len() > 0
+}
+
+// Flush 非流式缓冲期间不向客户端刷新；流式响应刷新已脱敏输出的事件
+func (w *guardrailResponseWriter) Flush() {
+	if w.stream != nil {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// beginGuardrailResponse 替换 c.Writer 以缓冲（非流式）或逐事件改写（流式）响应；分组未启用响应脱敏时返回 nil
+func beginGuardrailResponse(c *gin.Context, guardrails *service.GuardrailService, group *service.Group, stream bool) *guardrailResponseWriter {
+	if !guardrails.RedactsResponses(group) {
+		return nil
+	}
+	w := &guardrailResponseWriter{ResponseWriter: c.Writer}
+	if stream {
+		w.stream = guardrails.NewStreamRedactor(group)
+	}
+	c.Writer = w
+	return w
+}
+
+// end 恢复原始 Writer，并将（成功响应经脱敏后的）缓冲内容写回客户端
+func (w *guardrailResponseWriter) end(c *gin.Context, guardrails *service.GuardrailService, group *service.Group) {
+	if w == nil {
+		return
+	}
+	c.Writer = w.ResponseWriter
+	if w.stream != nil {
+		w.endStream()
+		return
+	}
+	if !w.Written() {
+		return
+	}
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	body := w.buf.Bytes()
+	if status == http.StatusOK {
+		body = guardrails.RedactResponse(group, body)
+	}
+	c.Writer.Header().Del("Content-Length")
+	c.Writer.WriteHeader(status)
+	if _, err := c.Writer.Write(body); err != nil {
+		log.Printf("Guardrail response write failed: %v", err)
+	}
+}
+
+// endStream 写出尚未输出的文本增量；不完整的尾部事件客户端不会处理，原样写出
+func (w *guardrailResponseWriter) endStream() {
+	for _, event := range w.stream.Flush() {
+		if err := w.writeEvent(event, []byte("\n\n")); err != nil {
+			log.Printf("Guardrail stream write failed: %v", err)
+			return
+		}
+	}
+	if w.buf.Len() > 0 {
+		if _, err := w.ResponseWriter.Write(w.buf.Bytes()); err != nil {
+			log.Printf("Guardrail stream write failed: %v", err)
+			return
+		}
+	}
+	w.ResponseWriter.Flush()
+}
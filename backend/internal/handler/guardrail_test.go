@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardrailResponseWriter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	guardrails := service.NewGuardrailService()
+	group := &service.Group{Guardrails: &service.GuardrailConfig{
+		Enabled:         true,
+		RedactPII:       []string{service.GuardrailPIIEmail},
+		RedactResponses: true,
+	}}
+
+	cases := []struct {
+		name   string
+		group  *service.Group
+		stream bool
+		status int
+		body   string
+		want   string
+	}{
+		{name: "redacts ok response", group: group, status: http.StatusOK, body: `{"text":"a@b.com"}`, want: `{"text":"[REDACTED_EMAIL]"}`},
+		{name: "keeps error response", group: group, status: http.StatusBadRequest, body: `{"text":"a@b.com"}`, want: `{"text":"a@b.com"}`},
+		{name: "keeps stream error response", group: group, stream: true, status: http.StatusBadRequest, body: `{"text":"a@b.com"}`, want: `{"text":"a@b.com"}`},
+		{name: "disabled group", group: &service.Group{}, status: http.StatusOK, body: `{"text":"a@b.com"}`, want: `{"text":"a@b.com"}`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			original := c.Writer
+
+			guard := beginGuardrailResponse(c, guardrails, tt.group, tt.stream)
+			c.Data(tt.status, "application/json", []byte(tt.body))
+			guard.end(c, guardrails, tt.group)
+
+			require.Equal(t, original, c.Writer)
+			require.Equal(t, tt.status, recorder.Code)
+			require.Equal(t, tt.want, recorder.Body.String())
+			require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestGuardrailResponseWriter_Stream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	guardrails := service.NewGuardrailService()
+	group := &service.Group{Guardrails: &service.GuardrailConfig{
+		Enabled:         true,
+		RedactPII:       []string{service.GuardrailPIIEmail},
+		RedactResponses: true,
+	}}
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	original := c.Writer
+
+	guard := beginGuardrailResponse(c, guardrails, group, true)
+	c.Header("Content-Type", "text/event-stream")
+	c.Status(http.StatusOK)
+	delta := func(text string) string {
+		return "event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"" + text + "\"}}\n\n"
+	}
+	// 邮箱被拆分到多个增量，且事件边界与写入边界不一致
+	stream := delta("mail ") + delta("a@b") + delta(".com") + delta(" now") +
+		"event: content_block_stop\ndata: {\"type\":\"content_block_stop\",\"index\":0}\n\n"
+	split := len(stream) - 20
+	_, _ = c.Writer.WriteString(stream[:split])
+	require.Empty(t, recorder.Body.String(), "text deltas are held until the block ends")
+	_, _ = c.Writer.WriteString(stream[split:])
+	guard.end(c, guardrails, group)
+
+	require.Equal(t, original, c.Writer)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, delta("mail [REDACTED_EMAIL] now")+
+		"event: content_block_stop\ndata: {\"type\":\"content_block_stop\",\"index\":0}\n\n", recorder.Body.String())
+}
+
+func TestApplyRequestGuardrails_SetsOpsViolation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	group := &service.Group{Guardrails: &service.GuardrailConfig{Enabled: true, DenyKeywords: []string{"blocked"}}}
+
+	out, violation := applyRequestGuardrails(c, service.NewGuardrailService(), group, service.GuardrailFormatMessages,
+		[]byte(`{"messages":[{"role":"user","content":"this is blocked"}]}`))
+	require.Nil(t, out)
+	require.NotNil(t, violation)
+	v, ok := c.Get(opsGuardrailKey)
+	require.True(t, ok)
+	require.Equal(t, violation, v)
+
+	// 无法解析的请求体放行，由上游返回原始错误
+	out, violation = applyRequestGuardrails(c, service.NewGuardrailService(), group, service.GuardrailFormatMessages, []byte(`not json`))
+	require.Nil(t, out)
+	require.Nil(t, violation)
+}
@@ -23,22 +23,33 @@ type AdminHandlers struct {
 	Subscription     *admin.SubscriptionHandler
 	Usage            *admin.UsageHandler
 	UserAttribute    *admin.UserAttributeHandler
+	Webhook          *admin.WebhookHandler
+	ModelPrice       *admin.ModelPriceHandler
+	Audit            *admin.AuditHandler
+	Invoice          *admin.InvoiceHandler
+	Organization     *admin.OrganizationHandler
 }
 
 // Handlers contains all HTTP handlers
 type Handlers struct {
-	Auth          *AuthHandler
-	User          *UserHandler
-	APIKey        *APIKeyHandler
-	Usage         *UsageHandler
-	Redeem        *RedeemHandler
-	Subscription  *SubscriptionHandler
-	Admin         *AdminHandlers
-	Gateway       *GatewayHandler
-	OpenAIGateway *OpenAIGatewayHandler
-	Setting       *SettingHandler
-	Totp          *TotpHandler
-	UsageReport   *UserUsageReportHandler
+	Auth            *AuthHandler
+	User            *UserHandler
+	APIKey          *APIKeyHandler
+	Usage           *UsageHandler
+	Redeem          *RedeemHandler
+	Subscription    *SubscriptionHandler
+	Admin           *AdminHandlers
+	Gateway         *GatewayHandler
+	OpenAIGateway   *OpenAIGatewayHandler
+	ChatCompletions *ChatCompletionsHandler
+	Embeddings      *EmbeddingsHandler
+	MessageBatch    *MessageBatchHandler
+	Setting         *SettingHandler
+	Totp            *TotpHandler
+	UsageReport     *UserUsageReportHandler
+	Invoice         *InvoiceHandler
+	Organization    *OrganizationHandler
+	BalanceAlert    *BalanceAlertHandler
 }
 
 // BuildInfo contains build-time information
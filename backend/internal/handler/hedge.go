@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+// hedgeOptions 构造分组对冲参数：备用账号排除本次请求已失败的账号与主账号，
+// 未被返回的尝试同样计入账号熔断统计。分组未启用对冲时 Delay 为 0，ForwardHedged 直接转发。
+func (h *GatewayHandler) hedgeOptions(group *service.Group, groupID *int64, model string, failedAccountIDs map[int64]struct{}) service.HedgeOptions {
+	return service.HedgeOptions{
+		Delay: group.HedgeDelay(),
+		SelectBackup: func(ctx context.Context, primary *service.Account) (*service.AccountSelectionResult, error) {
+			return h.gatewayService.SelectHedgeAccount(ctx, groupID, model, failedAccountIDs, primary.ID)
+		},
+		RecordResult: h.gatewayService.RecordAccountResult,
+	}
+}
+
+// hedgeOptions 构造 OpenAI 分组对冲参数，规则同 GatewayHandler.hedgeOptions
+func (h *OpenAIGatewayHandler) hedgeOptions(group *service.Group, groupID *int64, model string, failedAccountIDs map[int64]struct{}) service.HedgeOptions {
+	return openAIHedgeOptions(h.gatewayService, group, groupID, model, failedAccountIDs)
+}
+
+// openAIHedgeOptions 供 OpenAI 分组承接的请求（含 Messages 降级到 OpenAI 分组）使用
+func openAIHedgeOptions(gatewayService *service.OpenAIGatewayService, group *service.Group, groupID *int64, model string, failedAccountIDs map[int64]struct{}) service.HedgeOptions {
+	return service.HedgeOptions{
+		Delay: group.HedgeDelay(),
+		SelectBackup: func(ctx context.Context, primary *service.Account) (*service.AccountSelectionResult, error) {
+			return gatewayService.SelectHedgeAccount(ctx, groupID, model, failedAccountIDs, primary.ID)
+		},
+		RecordResult: gatewayService.RecordAccountResult,
+	}
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvoiceHandler handles user invoice endpoints
+type InvoiceHandler struct {
+	invoiceService *service.InvoiceService
+}
+
+// NewInvoiceHandler creates a new InvoiceHandler
+func NewInvoiceHandler(invoiceService *service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceService: invoiceService,
+	}
+}
+
+// List handles listing the current user's invoices
+// GET /api/v1/invoices
+func (h *InvoiceHandler) List(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	invoices, result, err := h.invoiceService.ListByUser(c.Request.Context(), subject.UserID, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.Invoice, 0, len(invoices))
+	for i := range invoices {
+		out = append(out, *dto.InvoiceFromService(&invoices[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+// GetByID handles getting one of the current user's invoices
+// GET /api/v1/invoices/:id
+func (h *InvoiceHandler) GetByID(c *gin.Context) {
+	invoice, ok := h.loadOwnInvoice(c)
+	if !ok {
+		return
+	}
+	response.Success(c, dto.InvoiceFromService(invoice))
+}
+
+// Download handles downloading one of the current user's invoices
+// GET /api/v1/invoices/:id/download?format=pdf|html|csv
+func (h *InvoiceHandler) Download(c *gin.Context) {
+	invoice, ok := h.loadOwnInvoice(c)
+	if !ok {
+		return
+	}
+
+	rendered, err := h.invoiceService.Render(c.Request.Context(), invoice, c.Query("format"))
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	writeInvoiceFile(c, rendered)
+}
+
+func (h *InvoiceHandler) loadOwnInvoice(c *gin.Context) (*service.Invoice, bool) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return nil, false
+	}
+
+	invoiceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid invoice ID")
+		return nil, false
+	}
+
+	invoice, err := h.invoiceService.GetForUser(c.Request.Context(), subject.UserID, invoiceID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return nil, false
+	}
+	return invoice, true
+}
+
+// writeInvoiceFile 以附件形式返回渲染后的账单
+func writeInvoiceFile(c *gin.Context, rendered *service.RenderedInvoice) {
+	c.Header("Content-Disposition", `attachment; filename="`+rendered.Filename+`"`)
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, rendered.ContentType, rendered.Body)
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MessageBatchHandler handles Anthropic Message Batches API passthrough
+type MessageBatchHandler struct {
+	gatewayService      *service.GatewayService
+	messageBatchService *service.MessageBatchService
+	billingCacheService *service.BillingCacheService
+	apiKeyRateLimiter   *service.APIKeyRateLimitService
+	maxAccountSwitches  int
+}
+
+// NewMessageBatchHandler creates a new MessageBatchHandler
+func NewMessageBatchHandler(
+	gatewayService *service.GatewayService,
+	messageBatchService *service.MessageBatchService,
+	billingCacheService *service.BillingCacheService,
+	apiKeyRateLimiter *service.APIKeyRateLimitService,
+	cfg *config.Config,
+) *MessageBatchHandler {
+	maxAccountSwitches := 3
+	if cfg != nil && cfg.Gateway.MaxAccountSwitches > 0 {
+		maxAccountSwitches = cfg.Gateway.MaxAccountSwitches
+	}
+	return &MessageBatchHandler{
+		gatewayService:      gatewayService,
+		messageBatchService: messageBatchService,
+		billingCacheService: billingCacheService,
+		apiKeyRateLimiter:   apiKeyRateLimiter,
+		maxAccountSwitches:  maxAccountSwitches,
+	}
+}
+
+// Create handles batch creation
+// POST /v1/messages/batches
+func (h *MessageBatchHandler) Create(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+	if len(body) == 0 {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Request body is empty")
+		return
+	}
+	setOpsRequestContext(c, "", false, body)
+
+	if apiKey.Group == nil || apiKey.Group.Platform != service.PlatformAnthropic {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Message batches are only available for Anthropic groups")
+		return
+	}
+
+	// 检查 API Key 模型白名单与 max_tokens 上限
+	if err := service.CheckMessageBatchLimits(apiKey, body); err != nil {
+		h.errorResponse(c, http.StatusForbidden, "permission_error", infraerrors.Message(err))
+		return
+	}
+
+	// 提交批次计一次请求；批次内的 token 在异步结算时不计入 TPM 窗口
+	if limited := checkAPIKeyRateLimit(c, h.apiKeyRateLimiter, apiKey, RateLimitHeadersAnthropic); limited != nil {
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", rateLimitErrorMessage(limited))
+		return
+	}
+
+	// 批次费用在结算时扣除，提交前仅校验余额/订阅是否可用
+	subscription, _ := middleware2.GetSubscriptionFromContext(c)
+	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
+		status, code, message := billingErrorDetails(err)
+		h.errorResponse(c, status, code, message)
+		return
+	}
+
+	switchCount := 0
+	failedAccountIDs := make(map[int64]struct{})
+	lastFailoverStatus := 0
+
+	for {
+		selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, "", "", failedAccountIDs, "")
+		if err != nil {
+			if lastFailoverStatus == 0 {
+				h.errorResponse(c, http.StatusServiceUnavailable, "api_error", "No available accounts supporting message batches: "+err.Error())
+				return
+			}
+			h.handleFailoverExhausted(c, lastFailoverStatus)
+			return
+		}
+		account := selection.Account
+		if selection.Acquired && selection.ReleaseFunc != nil {
+			// 创建批次为轻量请求，不占用账号并发槽位
+			selection.ReleaseFunc()
+		}
+
+		// 仅 Anthropic API Key 账号提供 batches 接口
+		if !service.IsBatchAccount(account) {
+			failedAccountIDs[account.ID] = struct{}{}
+			continue
+		}
+		setOpsSelectedAccount(c, account.ID)
+
+		resp, err := h.messageBatchService.Create(c.Request.Context(), apiKey, account, body, c.Request.Header)
+		if err != nil {
+			var failoverErr *service.UpstreamFailoverError
+			if errors.As(err, &failoverErr) {
+				failedAccountIDs[account.ID] = struct{}{}
+				lastFailoverStatus = failoverErr.StatusCode
+				if switchCount >= h.maxAccountSwitches {
+					h.handleFailoverExhausted(c, lastFailoverStatus)
+					return
+				}
+				switchCount++
+				service.RecordAccountSwitch(account.Platform, failoverErr.StatusCode)
+				log.Printf("Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, h.maxAccountSwitches)
+				continue
+			}
+			h.serviceError(c, err)
+			return
+		}
+		h.writeUpstream(c, resp)
+		return
+	}
+}
+
+// List handles listing batches submitted by the current API key
+// GET /v1/messages/batches
+func (h *MessageBatchHandler) List(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+
+	params := service.MessageBatchListParams{
+		AfterID:  c.Query("after_id"),
+		BeforeID: c.Query("before_id"),
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "limit must be a positive integer")
+			return
+		}
+		params.Limit = limit
+	}
+	if params.AfterID != "" && params.BeforeID != "" {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "after_id and before_id cannot be used together")
+		return
+	}
+
+	out, err := h.messageBatchService.List(c.Request.Context(), apiKey, params, gatewayBaseURL(c))
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", out)
+}
+
+// Retrieve handles fetching a batch
+// GET /v1/messages/batches/:id
+func (h *MessageBatchHandler) Retrieve(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+	resp, err := h.messageBatchService.Retrieve(c.Request.Context(), apiKey, c.Param("id"), c.Request.Header)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+	h.writeUpstream(c, resp)
+}
+
+// Cancel handles canceling a batch
+// POST /v1/messages/batches/:id/cancel
+func (h *MessageBatchHandler) Cancel(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+	resp, err := h.messageBatchService.Cancel(c.Request.Context(), apiKey, c.Param("id"), c.Request.Header)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+	h.writeUpstream(c, resp)
+}
+
+// Results streams batch results (JSONL)
+// GET /v1/messages/batches/:id/results
+func (h *MessageBatchHandler) Results(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+	resp, err := h.messageBatchService.Results(c.Request.Context(), apiKey, c.Param("id"), c.Request.Header)
+	if err != nil {
+		h.serviceError(c, err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/binary"
+	}
+	c.Status(resp.StatusCode)
+	c.Header("Content-Type", contentType)
+	if _, err := io.Copy(c.Writer, resp.Body); err != nil {
+		log.Printf("Message batch results copy failed: batch=%s err=%v", c.Param("id"), err)
+	}
+}
+
+// writeUpstream 透传上游响应，批次对象中的 results_url 改写为本网关地址
+func (h *MessageBatchHandler) writeUpstream(c *gin.Context, resp *service.MessageBatchUpstreamResponse) {
+	body := resp.Body
+	if resp.StatusCode < 400 {
+		body = service.RewriteMessageBatchResultsURL(body, gatewayBaseURL(c))
+	}
+	if requestID := resp.Header.Get("request-id"); requestID != "" {
+		c.Header("request-id", requestID)
+	}
+	c.Data(resp.StatusCode, "application/json", body)
+}
+
+func (h *MessageBatchHandler) serviceError(c *gin.Context, err error) {
+	switch {
+	case infraerrors.IsNotFound(err):
+		h.errorResponse(c, http.StatusNotFound, "not_found_error", infraerrors.Message(err))
+	case infraerrors.IsBadRequest(err):
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", infraerrors.Message(err))
+	default:
+		log.Printf("Message batch request failed: %v", err)
+		h.errorResponse(c, http.StatusBadGateway, "upstream_error", "Upstream request failed")
+	}
+}
+
+func (h *MessageBatchHandler) handleFailoverExhausted(c *gin.Context, statusCode int) {
+	switch statusCode {
+	case 429:
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Upstream rate limit exceeded, please retry later")
+	case 529:
+		h.errorResponse(c, http.StatusServiceUnavailable, "overloaded_error", "Upstream service overloaded, please retry later")
+	default:
+		h.errorResponse(c, http.StatusBadGateway, "upstream_error", "Upstream request failed")
+	}
+}
+
+// errorResponse returns Claude API format error response
+func (h *MessageBatchHandler) errorResponse(c *gin.Context, status int, errType, message string) {
+	c.JSON(status, gin.H{
+		"type": "error",
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
+
+// gatewayBaseURL 根据当前请求推导客户端可访问的网关地址
+func gatewayBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	host := c.Request.Host
+	if host == "" {
+		return ""
+	}
+	return scheme + "://" + host
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/sjson"
+)
+
+// modelUsedHeader 告知客户端本次请求实际使用的模型（触发模型降级链时与请求模型不同）
+const modelUsedHeader = "X-Model-Used"
+
+// modelFallbackResolver 解析降级目标分组（由 GatewayService 实现）
+type modelFallbackResolver interface {
+	ResolveModelFallbackGroup(ctx context.Context, entry service.ModelFallbackEntry, apiKey *service.APIKey, target service.ModelFallbackTarget) (*service.Group, error)
+}
+
+// modelFallbackRoute 是请求当前的调度目标：
+// 初始为 API Key 所属分组与请求模型，当前分组账号全部不可用时切换到降级链的下一个节点。
+type modelFallbackRoute struct {
+	entry    service.ModelFallbackEntry
+	apiKey   *service.APIKey
+	group    *service.Group // 对冲等分组级参数以当前节点的分组为准
+	groupID  *int64
+	platform string
+	model    string
+
+	fallbacks    []service.ModelFallbackTarget
+	fallbackUsed bool
+}
+
+// newModelFallbackRoute 创建调度目标；withFallbacks 为 false（如强制平台路由）时不参与降级
+func newModelFallbackRoute(entry service.ModelFallbackEntry, apiKey *service.APIKey, platform, model string, withFallbacks bool) modelFallbackRoute {
+	r := modelFallbackRoute{
+		entry:    entry,
+		apiKey:   apiKey,
+		group:    apiKey.Group,
+		groupID:  apiKey.GroupID,
+		platform: platform,
+		model:    model,
+	}
+	if withFallbacks {
+		r.fallbacks = apiKey.Group.GetModelFallbacks(model)
+	}
+	return r
+}
+
+// advance 切换到下一个可用的降级节点，没有可用节点时返回 false。
+// 分组停用、入口不支持目标平台或 API Key 不允许目标模型的节点被跳过；
+// prepare 按目标分组与模型改写请求体，返回 false 时同样跳过该节点。
+// 计费使用 Forward 结果中的模型与 servingGroup 返回的分组倍率。
+func (r *modelFallbackRoute) advance(ctx context.Context, resolver modelFallbackResolver, prepare func(group *service.Group, model string) bool) bool {
+	for len(r.fallbacks) > 0 {
+		target := r.fallbacks[0]
+		r.fallbacks = r.fallbacks[1:]
+
+		group, err := resolver.ResolveModelFallbackGroup(ctx, r.entry, r.apiKey, target)
+		if err != nil {
+			log.Printf("Model fallback target skipped: group=%d model=%s err=%v", target.GroupID, target.Model, err)
+			continue
+		}
+		if prepare != nil && !prepare(group, target.Model) {
+			continue
+		}
+
+		log.Printf("Model fallback: %s (%s) -> %s (group=%d platform=%s)", r.model, r.platform, target.Model, group.ID, group.Platform)
+		service.RecordModelFallback(r.platform, group.Platform)
+
+		groupID := group.ID
+		r.group = group
+		r.groupID = &groupID
+		r.platform = group.Platform
+		r.model = target.Model
+		r.fallbackUsed = true
+		return true
+	}
+	return false
+}
+
+// servingGroup 降级后返回实际服务请求的分组，用于按该分组的费率倍数计费；未降级时返回 nil
+func (r *modelFallbackRoute) servingGroup() *service.Group {
+	if r.fallbackUsed {
+		return r.group
+	}
+	return nil
+}
+
+// cacheKey 降级后的响应来自其他模型，不写入原请求的响应缓存
+func (r *modelFallbackRoute) cacheKey(key string) string {
+	if r.fallbackUsed {
+		return ""
+	}
+	return key
+}
+
+// messagesRoute 是 /v1/messages 请求的调度目标，额外保存按目标平台改写后的请求体
+type messagesRoute struct {
+	modelFallbackRoute
+
+	body      []byte
+	parsedReq *service.ParsedRequest
+	// responsesBody 降级到 OpenAI 分组时经 Chat Completions 转换得到的 Responses 请求体
+	responsesBody []byte
+	responsesReq  map[string]any
+
+	originalBody []byte
+}
+
+func newMessagesRoute(apiKey *service.APIKey, platform, model string, withFallbacks bool, body []byte, parsedReq *service.ParsedRequest) *messagesRoute {
+	return &messagesRoute{
+		modelFallbackRoute: newModelFallbackRoute(service.ModelFallbackEntryMessages, apiKey, platform, model, withFallbacks),
+		body:               body,
+		parsedReq:          parsedReq,
+		originalBody:       body,
+	}
+}
+
+// advance 切换到下一个降级节点：Claude 系平台仅改写 model 字段，由各 Forward 内置的 Claude↔Gemini 转换器处理；
+// OpenAI 平台经 Chat Completions 转换为 Responses 请求体
+func (r *messagesRoute) advance(ctx context.Context, resolver modelFallbackResolver) bool {
+	return r.modelFallbackRoute.advance(ctx, resolver, func(group *service.Group, model string) bool {
+		body, err := sjson.SetBytes(r.originalBody, "model", model)
+		if err != nil {
+			log.Printf("Model fallback rewrite failed: model=%s err=%v", model, err)
+			return false
+		}
+		if group.Platform == service.PlatformOpenAI {
+			responsesBody, responsesReq, err := convertMessagesToResponses(body)
+			if err != nil {
+				log.Printf("Model fallback convert failed: model=%s err=%v", model, err)
+				return false
+			}
+			r.body, r.parsedReq = body, nil
+			r.responsesBody, r.responsesReq = responsesBody, responsesReq
+			return true
+		}
+		parsedReq, err := service.ParseGatewayRequest(body)
+		if err != nil {
+			log.Printf("Model fallback parse failed: model=%s err=%v", model, err)
+			return false
+		}
+		r.body, r.parsedReq = body, parsedReq
+		r.responsesBody, r.responsesReq = nil, nil
+		return true
+	})
+}
+
+// responsesRoute 是 /v1/responses 请求的调度目标，降级时仅改写 model 字段
+type responsesRoute struct {
+	modelFallbackRoute
+
+	body         []byte
+	originalBody []byte
+}
+
+func newResponsesRoute(apiKey *service.APIKey, model string, body []byte) *responsesRoute {
+	platform := ""
+	if apiKey.Group != nil {
+		platform = apiKey.Group.Platform
+	}
+	return &responsesRoute{
+		modelFallbackRoute: newModelFallbackRoute(service.ModelFallbackEntryResponses, apiKey, platform, model, true),
+		body:               body,
+		originalBody:       body,
+	}
+}
+
+func (r *responsesRoute) advance(ctx context.Context, resolver modelFallbackResolver) bool {
+	return r.modelFallbackRoute.advance(ctx, resolver, func(group *service.Group, model string) bool {
+		body, err := sjson.SetBytes(r.originalBody, "model", model)
+		if err != nil {
+			log.Printf("Model fallback rewrite failed: model=%s err=%v", model, err)
+			return false
+		}
+		r.body = body
+		return true
+	})
+}
+
+// convertMessagesToResponses 将 Messages 请求体经 Chat Completions 转换为 Responses 请求体，
+// 并与 /v1/responses 一致地为非 Codex 客户端补齐默认 instructions
+func convertMessagesToResponses(body []byte) ([]byte, map[string]any, error) {
+	chatReq, err := service.ConvertClaudeToChatCompletions(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	converted, err := service.ConvertChatCompletionsToResponses(chatReq)
+	if err != nil {
+		return nil, nil, err
+	}
+	var reqBody map[string]any
+	if err := json.Unmarshal(converted, &reqBody); err != nil {
+		return nil, nil, err
+	}
+	if applyDefaultOpenAIInstructions(reqBody) {
+		if converted, err = json.Marshal(reqBody); err != nil {
+			return nil, nil, err
+		}
+	}
+	return converted, reqBody, nil
+}
+
+// messagesResponsesWriter 将 OpenAI Responses 响应转换回 Messages 格式（Responses → Chat Completions → Messages）
+type messagesResponsesWriter struct {
+	original gin.ResponseWriter
+	chat     *service.ChatCompletionsResponseWriter
+	claude   *service.ClaudeMessagesResponseWriter
+}
+
+// beginMessagesResponsesWriter 替换 c.Writer，须在其他响应包装之后调用，使其最先接收上游响应
+func beginMessagesResponsesWriter(c *gin.Context, model string) *messagesResponsesWriter {
+	w := &messagesResponsesWriter{original: c.Writer}
+	w.claude = service.NewClaudeMessagesResponseWriter(c.Writer, model)
+	w.chat = service.NewChatCompletionsResponseWriter(w.claude, service.ChatCompletionsUpstreamResponses, model)
+	c.Writer = w.chat
+	return w
+}
+
+// end 写出转换后的剩余响应并恢复原始 Writer
+func (w *messagesResponsesWriter) end(c *gin.Context) {
+	w.chat.Finish()
+	w.claude.Finish()
+	c.Writer = w.original
+}
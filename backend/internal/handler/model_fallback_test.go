@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type modelFallbackResolverStub struct {
+	groups map[int64]*service.Group
+}
+
+func (s *modelFallbackResolverStub) ResolveModelFallbackGroup(ctx context.Context, entry service.ModelFallbackEntry, apiKey *service.APIKey, target service.ModelFallbackTarget) (*service.Group, error) {
+	group, ok := s.groups[target.GroupID]
+	if !ok {
+		return nil, errors.New("group not found")
+	}
+	if !entry.Supports(group.Platform) {
+		return nil, errors.New("unsupported platform")
+	}
+	return group, nil
+}
+
+func TestMessagesRouteAdvance(t *testing.T) {
+	primaryID := int64(1)
+	apiKey := &service.APIKey{GroupID: &primaryID, Group: &service.Group{
+		ID:       primaryID,
+		Platform: service.PlatformAnthropic,
+		ModelFallbacks: map[string][]service.ModelFallbackTarget{
+			"claude-sonnet-4-5": {{GroupID: 9, Model: "missing"}, {GroupID: 2, Model: "gemini-2.5-pro"}, {GroupID: 3, Model: "gpt-5"}},
+		},
+	}}
+	resolver := &modelFallbackResolverStub{groups: map[int64]*service.Group{
+		2: {ID: 2, Platform: service.PlatformGemini},
+		3: {ID: 3, Platform: service.PlatformOpenAI},
+	}}
+	body := []byte(`{"model":"claude-sonnet-4-5","max_tokens":100,"messages":[{"role":"user","content":"hi"}]}`)
+	parsed, err := service.ParseGatewayRequest(body)
+	require.NoError(t, err)
+
+	route := newMessagesRoute(apiKey, service.PlatformAnthropic, "claude-sonnet-4-5", true, body, parsed)
+	require.Equal(t, "k", route.cacheKey("k"))
+	require.Nil(t, route.servingGroup())
+	ctx := context.Background()
+
+	// 不存在的分组被跳过，切换到 Gemini 分组并改写 model
+	require.True(t, route.advance(ctx, resolver))
+	require.Equal(t, int64(2), *route.groupID)
+	require.Equal(t, int64(2), route.group.ID)
+	require.Equal(t, service.PlatformGemini, route.platform)
+	require.Equal(t, "gemini-2.5-pro", route.parsedReq.Model)
+	require.Equal(t, "gemini-2.5-pro", gjson.GetBytes(route.body, "model").String())
+	require.Nil(t, route.responsesBody)
+	require.Empty(t, route.cacheKey("k"))
+	require.Equal(t, int64(2), route.servingGroup().ID)
+
+	// OpenAI 分组：请求体转换为 Responses 格式
+	require.True(t, route.advance(ctx, resolver))
+	require.Equal(t, service.PlatformOpenAI, route.platform)
+	require.Nil(t, route.parsedReq)
+	require.Equal(t, "gpt-5", gjson.GetBytes(route.responsesBody, "model").String())
+	require.EqualValues(t, 100, gjson.GetBytes(route.responsesBody, "max_output_tokens").Int())
+	require.Equal(t, "gpt-5", route.responsesReq["model"])
+
+	require.False(t, route.advance(ctx, resolver))
+
+	// 强制平台路由不参与降级
+	forced := newMessagesRoute(apiKey, service.PlatformAnthropic, "claude-sonnet-4-5", false, body, parsed)
+	require.False(t, forced.advance(ctx, resolver))
+}
+
+func TestResponsesRouteAdvance(t *testing.T) {
+	primaryID := int64(3)
+	apiKey := &service.APIKey{GroupID: &primaryID, Group: &service.Group{
+		ID:       primaryID,
+		Platform: service.PlatformOpenAI,
+		ModelFallbacks: map[string][]service.ModelFallbackTarget{
+			"gpt-5": {{GroupID: 1, Model: "claude-sonnet-4-5"}, {GroupID: 4, Model: "gpt-5-mini"}},
+		},
+	}}
+	resolver := &modelFallbackResolverStub{groups: map[int64]*service.Group{
+		1: {ID: 1, Platform: service.PlatformAnthropic},
+		4: {ID: 4, Platform: service.PlatformOpenAI},
+	}}
+	route := newResponsesRoute(apiKey, "gpt-5", []byte(`{"model":"gpt-5","input":"hi"}`))
+
+	// Responses 入口跳过非 OpenAI 分组
+	require.True(t, route.advance(context.Background(), resolver))
+	require.Equal(t, int64(4), *route.groupID)
+	require.Equal(t, "gpt-5-mini", route.model)
+	require.JSONEq(t, `{"model":"gpt-5-mini","input":"hi"}`, string(route.body))
+	require.False(t, route.advance(context.Background(), resolver))
+}
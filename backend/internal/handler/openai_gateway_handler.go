@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	pkgerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/openai"
 	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
@@ -23,31 +24,50 @@ import (
 // OpenAIGatewayHandler handles OpenAI API gateway requests
 type OpenAIGatewayHandler struct {
 	gatewayService      *service.OpenAIGatewayService
+	fallbackResolver    modelFallbackResolver
 	billingCacheService *service.BillingCacheService
+	apiKeyRateLimiter   *service.APIKeyRateLimitService
+	responseCache       *service.ResponseCacheService
+	guardrails          *service.GuardrailService
+	opsCaptures         *service.OpsCaptureService
 	concurrencyHelper   *ConcurrencyHelper
 	maxAccountSwitches  int
+	streamDrainTimeout  time.Duration // 流式请求客户端断开后排空上游的最长时间
 }
 
 // NewOpenAIGatewayHandler creates a new OpenAIGatewayHandler
 func NewOpenAIGatewayHandler(
 	gatewayService *service.OpenAIGatewayService,
+	modelFallbackService *service.GatewayService,
 	concurrencyService *service.ConcurrencyService,
 	billingCacheService *service.BillingCacheService,
+	apiKeyRateLimiter *service.APIKeyRateLimitService,
+	responseCache *service.ResponseCacheService,
+	guardrails *service.GuardrailService,
+	opsCaptures *service.OpsCaptureService,
 	cfg *config.Config,
 ) *OpenAIGatewayHandler {
 	pingInterval := time.Duration(0)
 	maxAccountSwitches := 3
+	streamDrainTimeout := time.Duration(0)
 	if cfg != nil {
 		pingInterval = time.Duration(cfg.Concurrency.PingInterval) * time.Second
 		if cfg.Gateway.MaxAccountSwitches > 0 {
 			maxAccountSwitches = cfg.Gateway.MaxAccountSwitches
 		}
+		streamDrainTimeout = time.Duration(cfg.Gateway.StreamDrainOnDisconnectTimeout) * time.Second
 	}
 	return &OpenAIGatewayHandler{
 		gatewayService:      gatewayService,
+		fallbackResolver:    modelFallbackService,
 		billingCacheService: billingCacheService,
+		apiKeyRateLimiter:   apiKeyRateLimiter,
+		responseCache:       responseCache,
+		guardrails:          guardrails,
+		opsCaptures:         opsCaptures,
 		concurrencyHelper:   NewConcurrencyHelper(concurrencyService, SSEPingFormatComment, pingInterval),
 		maxAccountSwitches:  maxAccountSwitches,
+		streamDrainTimeout:  streamDrainTimeout,
 	}
 }
 
@@ -102,19 +122,46 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		return
 	}
 
+	// 检查 API Key 模型白名单与 max_output_tokens 上限
+	reqMaxTokens, _ := reqBody["max_output_tokens"].(float64)
+	if err := apiKey.CheckRequestLimits(reqModel, int(reqMaxTokens)); err != nil {
+		h.errorResponse(c, http.StatusForbidden, "permission_error", pkgerrors.Message(err))
+		return
+	}
+	// 未指定 max_output_tokens 时写入 API Key 上限
+	if capped, ok := apiKey.ApplyMaxTokensCap(body, "max_output_tokens"); ok {
+		body = capped
+		reqBody["max_output_tokens"] = float64(*apiKey.MaxTokensCap)
+	}
+
+	// 分组护栏：转发前执行长度检查、拒绝列表、PII 脱敏与系统提示词注入
+	guarded, violation := applyRequestGuardrails(c, h.guardrails, apiKey.Group, service.GuardrailFormatResponses, body)
+	if violation != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", violation.Message)
+		return
+	}
+	if guarded != nil {
+		body = guarded
+		reqBody = nil
+		if err := json.Unmarshal(body, &reqBody); err != nil {
+			h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+			return
+		}
+	}
+
+	// 检查 API Key RPM/TPM 限制
+	if limited := checkAPIKeyRateLimit(c, h.apiKeyRateLimiter, apiKey, RateLimitHeadersOpenAI); limited != nil {
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", rateLimitErrorMessage(limited))
+		return
+	}
+
 	userAgent := c.GetHeader("User-Agent")
-	if !openai.IsCodexCLIRequest(userAgent) {
-		existingInstructions, _ := reqBody["instructions"].(string)
-		if strings.TrimSpace(existingInstructions) == "" {
-			if instructions := strings.TrimSpace(service.GetOpenCodeInstructions()); instructions != "" {
-				reqBody["instructions"] = instructions
-				// Re-serialize body
-				body, err = json.Marshal(reqBody)
-				if err != nil {
-					h.errorResponse(c, http.StatusInternalServerError, "api_error", "Failed to process request")
-					return
-				}
-			}
+	if !openai.IsCodexCLIRequest(userAgent) && applyDefaultOpenAIInstructions(reqBody) {
+		// Re-serialize body
+		body, err = json.Marshal(reqBody)
+		if err != nil {
+			h.errorResponse(c, http.StatusInternalServerError, "api_error", "Failed to process request")
+			return
 		}
 	}
 
@@ -179,7 +226,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		waitCounted = false
 	}
 	// 确保请求取消时也会释放槽位，避免长连接被动中断造成泄漏
-	userReleaseFunc = wrapReleaseOnDone(c.Request.Context(), userReleaseFunc)
+	userReleaseFunc = wrapReleaseOnDoneAfter(c.Request.Context(), userReleaseFunc, streamDrainHold(h.streamDrainTimeout, reqStream))
 	if userReleaseFunc != nil {
 		defer userReleaseFunc()
 	}
@@ -192,126 +239,199 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		return
 	}
 
+	// 响应缓存：确定性非流式请求命中时不再选择账号与请求上游
+	cacheKey := h.responseCache.Key(apiKey.Group, service.ResponseCacheKindResponses, reqModel, body)
+	if entry := serveResponseCache(c, h.responseCache, service.ResponseCacheKindResponses, cacheKey); entry != nil {
+		h.recordCacheServedUsage(c, entry, apiKey, subscription)
+		return
+	}
+
+	// 预占本次请求的最大可能费用，防止并发请求在扣费前透支；转发失败时由 defer 释放
+	reservation, err := h.billingCacheService.ReserveRequestCost(c.Request.Context(), apiKey, subscription, reqModel, body)
+	if err != nil {
+		status, code, message := billingErrorDetails(err)
+		h.handleStreamingAwareError(c, status, code, message, streamStarted)
+		return
+	}
+	defer reservation.Release()
+
 	// Generate session hash (header first; fallback to prompt_cache_key)
 	sessionHash := h.gatewayService.GenerateSessionHash(c, reqBody)
 
-	maxAccountSwitches := h.maxAccountSwitches
-	switchCount := 0
-	failedAccountIDs := make(map[int64]struct{})
-	lastFailoverStatus := 0
+	// 模型降级链：当前分组账号全部不可用时切换到下一个 OpenAI 分组与模型
+	route := newResponsesRoute(apiKey, reqModel, body)
 
+routeLoop:
 	for {
-		// Select account supporting the requested model
-		log.Printf("[OpenAI Handler] Selecting account: groupID=%v model=%s", apiKey.GroupID, reqModel)
-		selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionHash, reqModel, failedAccountIDs)
-		if err != nil {
-			log.Printf("[OpenAI Handler] SelectAccount failed: %v", err)
-			if len(failedAccountIDs) == 0 {
-				h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
-				return
-			}
-			h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
-			return
-		}
-		account := selection.Account
-		log.Printf("[OpenAI Handler] Selected account: id=%d name=%s", account.ID, account.Name)
-		setOpsSelectedAccount(c, account.ID)
-
-		// 3. Acquire account concurrency slot
-		accountReleaseFunc := selection.ReleaseFunc
-		if !selection.Acquired {
-			if selection.WaitPlan == nil {
-				h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts", streamStarted)
-				return
-			}
-			accountWaitCounted := false
-			canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+		c.Header(modelUsedHeader, route.model)
+
+		maxAccountSwitches := h.maxAccountSwitches
+		switchCount := 0
+		failedAccountIDs := make(map[int64]struct{})
+		lastFailoverStatus := 0
+
+		for {
+			// Select account supporting the requested model
+			log.Printf("[OpenAI Handler] Selecting account: groupID=%v model=%s", route.groupID, route.model)
+			selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), route.groupID, sessionHash, route.model, failedAccountIDs)
 			if err != nil {
-				log.Printf("Increment account wait count failed: %v", err)
-			} else if !canWait {
-				log.Printf("Account wait queue full: account=%d", account.ID)
-				h.handleStreamingAwareError(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later", streamStarted)
+				log.Printf("[OpenAI Handler] SelectAccount failed: %v", err)
+				if route.advance(c.Request.Context(), h.fallbackResolver) {
+					continue routeLoop
+				}
+				if len(failedAccountIDs) == 0 {
+					h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
+					return
+				}
+				h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
 				return
 			}
-			if err == nil && canWait {
-				accountWaitCounted = true
-			}
-			defer func() {
+			account := selection.Account
+			log.Printf("[OpenAI Handler] Selected account: id=%d name=%s", account.ID, account.Name)
+			setOpsSelectedAccount(c, account.ID)
+
+			// 3. Acquire account concurrency slot
+			accountReleaseFunc := selection.ReleaseFunc
+			if !selection.Acquired {
+				if selection.WaitPlan == nil {
+					h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts", streamStarted)
+					return
+				}
+				accountWaitCounted := false
+				canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+				if err != nil {
+					log.Printf("Increment account wait count failed: %v", err)
+				} else if !canWait {
+					log.Printf("Account wait queue full: account=%d", account.ID)
+					h.handleStreamingAwareError(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later", streamStarted)
+					return
+				}
+				if err == nil && canWait {
+					accountWaitCounted = true
+				}
+				defer func() {
+					if accountWaitCounted {
+						h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+					}
+				}()
+
+				accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
+					c,
+					account.ID,
+					selection.WaitPlan.MaxConcurrency,
+					selection.WaitPlan.Timeout,
+					reqStream,
+					&streamStarted,
+				)
+				if err != nil {
+					log.Printf("Account concurrency acquire failed: %v", err)
+					h.handleConcurrencyError(c, err, "account", streamStarted)
+					return
+				}
 				if accountWaitCounted {
 					h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+					accountWaitCounted = false
+				}
+				if err := h.gatewayService.BindStickySession(c.Request.Context(), route.groupID, sessionHash, account.ID); err != nil {
+					log.Printf("Bind sticky session failed: %v", err)
 				}
-			}()
-
-			accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
-				c,
-				account.ID,
-				selection.WaitPlan.MaxConcurrency,
-				selection.WaitPlan.Timeout,
-				reqStream,
-				&streamStarted,
-			)
+			}
+			// 账号槽位/等待计数需要在超时或断开时安全回收
+			accountReleaseFunc = wrapReleaseOnDoneAfter(c.Request.Context(), accountReleaseFunc, streamDrainHold(h.streamDrainTimeout, reqStream))
+
+			// Forward request
+			debugCapture := beginOpsCapture(c, h.opsCaptures, apiKey, account, route.model, reqStream, route.body)
+			capture := beginResponseCapture(c, route.cacheKey(cacheKey), h.responseCache.MaxEntryBytes())
+			guard := beginGuardrailResponse(c, h.guardrails, apiKey.Group, reqStream)
+			result, hedge, err := service.ForwardHedged(c, account, h.hedgeOptions(route.group, route.groupID, route.model, failedAccountIDs),
+				func(ctx context.Context, c *gin.Context, account *service.Account) (*service.OpenAIForwardResult, error) {
+					return h.gatewayService.Forward(ctx, c, account, route.body)
+				})
+			account = hedge.Account
+			guard.end(c, h.guardrails, apiKey.Group)
+			capturedBody, capturedType, captured := capture.end(c)
+			debugCapture.end(c, h.opsCaptures, err)
+			h.gatewayService.RecordAccountResult(c, account, err)
+			if accountReleaseFunc != nil {
+				accountReleaseFunc()
+			}
 			if err != nil {
-				log.Printf("Account concurrency acquire failed: %v", err)
-				h.handleConcurrencyError(c, err, "account", streamStarted)
+				var failoverErr *service.UpstreamFailoverError
+				if errors.As(err, &failoverErr) {
+					failedAccountIDs[account.ID] = struct{}{}
+					lastFailoverStatus = failoverErr.StatusCode
+					if switchCount >= maxAccountSwitches {
+						if route.advance(c.Request.Context(), h.fallbackResolver) {
+							continue routeLoop
+						}
+						h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
+						return
+					}
+					switchCount++
+					service.RecordAccountSwitch(account.Platform, failoverErr.StatusCode)
+					log.Printf("Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
+					continue
+				}
+				// Error response already handled in Forward, just log
+				log.Printf("Account %d: Forward request failed: %v", account.ID, err)
 				return
 			}
-			if accountWaitCounted {
-				h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
-				accountWaitCounted = false
+			if captured && !result.Stream {
+				storeResponseCache(h.responseCache, apiKey.Group, cacheKey, &service.CachedResponse{
+					ContentType: capturedType,
+					Body:        capturedBody,
+					Model:       result.Model,
+					AccountID:   account.ID,
+					Usage: service.ClaudeUsage{
+						InputTokens:              result.Usage.InputTokens,
+						OutputTokens:             result.Usage.OutputTokens,
+						CacheCreationInputTokens: result.Usage.CacheCreationInputTokens,
+						CacheReadInputTokens:     result.Usage.CacheReadInputTokens,
+					},
+				})
 			}
-			if err := h.gatewayService.BindStickySession(c.Request.Context(), apiKey.GroupID, sessionHash, account.ID); err != nil {
-				log.Printf("Bind sticky session failed: %v", err)
-			}
-		}
-		// 账号槽位/等待计数需要在超时或断开时安全回收
-		accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
 
-		// Forward request
-		result, err := h.gatewayService.Forward(c.Request.Context(), c, account, body)
-		if accountReleaseFunc != nil {
-			accountReleaseFunc()
-		}
-		if err != nil {
-			var failoverErr *service.UpstreamFailoverError
-			if errors.As(err, &failoverErr) {
-				failedAccountIDs[account.ID] = struct{}{}
-				if switchCount >= maxAccountSwitches {
-					lastFailoverStatus = failoverErr.StatusCode
-					h.handleFailoverExhausted(c, lastFailoverStatus, streamStarted)
-					return
+			// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
+			userAgent := c.GetHeader("User-Agent")
+			clientIP := ip.GetClientIP(c)
+			servingGroup := route.servingGroup()
+
+			// Async record usage
+			go func(result *service.OpenAIForwardResult, usedAccount *service.Account, ua, ip string, reservation *service.BillingReservation) {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				if err := h.gatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
+					Result:       result,
+					APIKey:       apiKey,
+					User:         apiKey.User,
+					Account:      usedAccount,
+					Subscription: subscription,
+					UserAgent:    ua,
+					IPAddress:    ip,
+					Hedge:        hedge,
+					Reservation:  reservation,
+					ServingGroup: servingGroup,
+				}); err != nil {
+					log.Printf("Record usage failed: %v", err)
 				}
-				lastFailoverStatus = failoverErr.StatusCode
-				switchCount++
-				log.Printf("Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
-				continue
-			}
-			// Error response already handled in Forward, just log
-			log.Printf("Account %d: Forward request failed: %v", account.ID, err)
+			}(result, account, userAgent, clientIP, reservation.Detach())
 			return
 		}
+	}
+}
 
-		// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
-		userAgent := c.GetHeader("User-Agent")
-		clientIP := ip.GetClientIP(c)
-
-		// Async record usage
-		go func(result *service.OpenAIForwardResult, usedAccount *service.Account, ua, ip string) {
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
-			if err := h.gatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
-				Result:       result,
-				APIKey:       apiKey,
-				User:         apiKey.User,
-				Account:      usedAccount,
-				Subscription: subscription,
-				UserAgent:    ua,
-				IPAddress:    ip,
-			}); err != nil {
-				log.Printf("Record usage failed: %v", err)
-			}
-		}(result, account, userAgent, clientIP)
-		return
+// applyDefaultOpenAIInstructions instructions 为空时写入默认指令，返回是否改写了 reqBody
+func applyDefaultOpenAIInstructions(reqBody map[string]any) bool {
+	existingInstructions, _ := reqBody["instructions"].(string)
+	if strings.TrimSpace(existingInstructions) != "" {
+		return false
+	}
+	instructions := strings.TrimSpace(service.GetOpenCodeInstructions())
+	if instructions == "" {
+		return false
 	}
+	reqBody["instructions"] = instructions
+	return true
 }
 
 // handleConcurrencyError handles concurrency-related errors with proper 429 response
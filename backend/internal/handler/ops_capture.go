@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// opsCaptureTeeWriter 透传响应（包括 SSE Flush）的同时复制前 limit 字节用于调试抓包
+type opsCaptureTeeWriter struct {
+	gin.ResponseWriter
+	buf       bytes.Buffer
+	limit     int
+	written   int
+	truncated bool
+}
+
+func (w *opsCaptureTeeWriter) tee(n int, write func()) {
+	w.written += n
+	if remaining := w.limit - w.buf.Len(); remaining > 0 {
+		write()
+		if w.buf.Len() > w.limit {
+			w.buf.Truncate(w.limit)
+			w.truncated = true
+		}
+	} else if n > 0 {
+		w.truncated = true
+	}
+}
+
+func (w *opsCaptureTeeWriter) Write(data []byte) (int, error) {
+	w.tee(len(data), func() { w.buf.Write(data) })
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *opsCaptureTeeWriter) WriteString(s string) (int, error) {
+	w.tee(len(s), func() { w.buf.WriteString(s) })
+	return w.ResponseWriter.WriteString(s)
+}
+
+// opsCaptureRecorder 一次命中抓包规则的转发尝试
+type opsCaptureRecorder struct {
+	writer *opsCaptureTeeWriter
+	record *service.OpsCaptureRecord
+	start  time.Time
+}
+
+// beginOpsCapture 在转发前匹配抓包规则；命中时替换 c.Writer 以复制响应，未命中返回 nil。
+// 需在其他响应包装（缓存、护栏）之前调用，保证抓到的是客户端实际收到的内容。
+func beginOpsCapture(c *gin.Context, captures *service.OpsCaptureService, apiKey *service.APIKey, account *service.Account, model string, stream bool, body []byte) *opsCaptureRecorder {
+	if apiKey == nil || account == nil {
+		return nil
+	}
+	rule := captures.Match(apiKey.ID, apiKey.UserID, account.ID)
+	if rule == nil {
+		return nil
+	}
+	w := &opsCaptureTeeWriter{ResponseWriter: c.Writer, limit: service.OpsCaptureMaxBodyBytes}
+	c.Writer = w
+	return &opsCaptureRecorder{
+		writer: w,
+		start:  time.Now(),
+		record: &service.OpsCaptureRecord{
+			Rule:        rule,
+			APIKeyID:    apiKey.ID,
+			UserID:      apiKey.UserID,
+			AccountID:   account.ID,
+			GroupID:     apiKey.GroupID,
+			Platform:    account.Platform,
+			Model:       model,
+			RequestPath: c.Request.URL.Path,
+			Stream:      stream,
+			RequestBody: bytes.Clone(body),
+		},
+	}
+}
+
+// end 恢复原始 Writer 并提交抓包；forwardErr 为本次转发返回的错误
+func (r *opsCaptureRecorder) end(c *gin.Context, captures *service.OpsCaptureService, forwardErr error) {
+	if r == nil {
+		return
+	}
+	c.Writer = r.writer.ResponseWriter
+
+	record := r.record
+	record.Duration = time.Since(r.start)
+	record.CreatedAt = r.start
+	record.RequestID = c.Writer.Header().Get("X-Request-Id")
+	record.ResponseBody = r.writer.buf.Bytes()
+	record.ResponseBytes = r.writer.written
+	record.Truncated = r.writer.truncated
+	if r.writer.Written() {
+		record.StatusCode = r.writer.Status()
+	}
+	if forwardErr != nil {
+		record.ErrorMessage = forwardErr.Error()
+		var failoverErr *service.UpstreamFailoverError
+		if record.StatusCode == 0 && errors.As(forwardErr, &failoverErr) {
+			record.StatusCode = failoverErr.StatusCode
+		}
+	}
+	captures.Record(record)
+}
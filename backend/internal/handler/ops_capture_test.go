@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsCaptureTeeWriter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+
+	w := &opsCaptureTeeWriter{ResponseWriter: c.Writer, limit: 8}
+	c.Writer = w
+	c.Status(http.StatusOK)
+	_, _ = c.Writer.WriteString("data: 1\n")
+	c.Writer.Flush()
+	_, _ = c.Writer.Write([]byte("data: 2\n"))
+
+	require.Equal(t, "data: 1\ndata: 2\n", recorder.Body.String(), "client response must be unaffected")
+	require.True(t, recorder.Flushed)
+	require.Equal(t, "data: 1\n", w.buf.String())
+	require.Equal(t, 16, w.written)
+	require.True(t, w.truncated)
+
+	partial := &opsCaptureTeeWriter{ResponseWriter: c.Writer, limit: 4}
+	_, _ = partial.WriteString(strings.Repeat("x", 6))
+	require.Equal(t, "xxxx", partial.buf.String())
+	require.True(t, partial.truncated)
+}
+
+func TestBeginOpsCapture_NoRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	original := c.Writer
+
+	recorder := beginOpsCapture(c, nil, nil, nil, "m", false, nil)
+	require.Nil(t, recorder)
+	recorder.end(c, nil, nil)
+	require.Equal(t, original, c.Writer)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"runtime"
 	"runtime/debug"
@@ -579,6 +580,16 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 		}
 
 		phase := classifyOpsPhase(parsed.ErrorType, parsed.Message, parsed.Code)
+		errorType := normalizeOpsErrorType(parsed.ErrorType, parsed.Code)
+		errorMessage := parsed.Message
+		// 分组护栏拦截：单独归入 guardrail 阶段，并记录命中的过滤器与规则
+		if v, ok := c.Get(opsGuardrailKey); ok {
+			if violation, ok := v.(*service.GuardrailViolation); ok && violation != nil {
+				phase = opsPhaseGuardrail
+				errorType = opsErrorTypeGuardrailBlocked
+				errorMessage = fmt.Sprintf("%s (filter=%s rule=%s)", parsed.Message, violation.Filter, violation.Rule)
+			}
+		}
 		isBusinessLimited := classifyOpsIsBusinessLimited(parsed.ErrorType, phase, parsed.Code, status, parsed.Message)
 
 		errorOwner := classifyOpsErrorOwner(phase, parsed.Message)
@@ -601,13 +612,13 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 			UserAgent: c.GetHeader("User-Agent"),
 
 			ErrorPhase:        phase,
-			ErrorType:         normalizeOpsErrorType(parsed.ErrorType, parsed.Code),
+			ErrorType:         errorType,
 			Severity:          classifyOpsSeverity(parsed.ErrorType, status),
 			StatusCode:        status,
 			IsBusinessLimited: isBusinessLimited,
 			IsCountTokens:     isCountTokensRequest(c),
 
-			ErrorMessage: parsed.Message,
+			ErrorMessage: errorMessage,
 			// Keep the full captured error body (capture is already capped at 64KB) so the
 			// service layer can sanitize JSON before truncating for storage.
 			ErrorBody:   string(body),
@@ -835,6 +846,12 @@ func normalizeOpsErrorType(errType string, code string) string {
 	}
 }
 
+const (
+	// opsPhaseGuardrail 分组护栏拦截的请求，由调用方根据 opsGuardrailKey 覆盖 classifyOpsPhase 的结果
+	opsPhaseGuardrail            = "guardrail"
+	opsErrorTypeGuardrailBlocked = "guardrail_blocked"
+)
+
 func classifyOpsPhase(errType, message, code string) string {
 	msg := strings.ToLower(message)
 	// Standardized phases: request|auth|routing|upstream|network|internal
@@ -908,7 +925,7 @@ func classifyOpsIsBusinessLimited(errType, phase, code string, status int, messa
 	case "INSUFFICIENT_BALANCE", "USAGE_LIMIT_EXCEEDED", "SUBSCRIPTION_NOT_FOUND", "SUBSCRIPTION_INVALID":
 		return true
 	}
-	if phase == "billing" || phase == "concurrency" {
+	if phase == "billing" || phase == "concurrency" || phase == opsPhaseGuardrail {
 		// SLA/错误率排除“用户级业务限制”
 		return true
 	}
@@ -925,7 +942,7 @@ func classifyOpsErrorOwner(phase string, message string) string {
 	switch phase {
 	case "upstream", "network":
 		return "provider"
-	case "request", "auth":
+	case "request", "auth", opsPhaseGuardrail:
 		return "client"
 	case "routing", "internal":
 		return "platform"
@@ -944,7 +961,7 @@ func classifyOpsErrorSource(phase string, message string) string {
 		return "upstream_http"
 	case "network":
 		return "gateway"
-	case "request", "auth":
+	case "request", "auth", opsPhaseGuardrail:
 		return "client_request"
 	case "routing", "internal":
 		return "gateway"
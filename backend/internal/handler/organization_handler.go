@@ -0,0 +1,456 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles organization (team) endpoints
+type OrganizationHandler struct {
+	orgService     *service.OrganizationService
+	settingService *service.SettingService
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(orgService *service.OrganizationService, settingService *service.SettingService) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService:     orgService,
+		settingService: settingService,
+	}
+}
+
+// CreateOrganizationRequest represents create organization request
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// UpdateOrganizationRequest represents update organization request
+type UpdateOrganizationRequest struct {
+	Name                *string `json:"name"`
+	SharedSubscriptions *bool   `json:"shared_subscriptions"` // 仅所有者可修改
+}
+
+// UpdateOrganizationMemberRequest represents update member request
+type UpdateOrganizationMemberRequest struct {
+	Role            *string  `json:"role" binding:"omitempty,oneof=admin member"`
+	SpendLimitUSD   *float64 `json:"spend_limit_usd"`   // 每月可消耗组织余额上限
+	ClearSpendLimit bool     `json:"clear_spend_limit"` // 取消上限
+}
+
+// InviteOrganizationMemberRequest represents invite member request
+type InviteOrganizationMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=admin member"`
+}
+
+// AcceptOrganizationInvitationRequest represents accept invitation request
+type AcceptOrganizationInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// TransferOrganizationBalanceRequest represents transfer request
+type TransferOrganizationBalanceRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// OrganizationDetailResponse 组织详情及当前用户的成员信息
+type OrganizationDetailResponse struct {
+	Organization *dto.Organization       `json:"organization"`
+	Membership   *dto.OrganizationMember `json:"membership"`
+}
+
+// List handles listing organizations of the current user
+// GET /api/v1/organizations
+func (h *OrganizationHandler) List(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	memberships, err := h.orgService.ListMine(c.Request.Context(), subject.UserID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.OrganizationMembership, 0, len(memberships))
+	for i := range memberships {
+		if m := dto.OrganizationMembershipFromService(&memberships[i]); m != nil {
+			out = append(out, *m)
+		}
+	}
+	response.Success(c, out)
+}
+
+// Create handles creating an organization
+// POST /api/v1/organizations
+func (h *OrganizationHandler) Create(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	org, err := h.orgService.Create(c.Request.Context(), subject.UserID, req.Name)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Created(c, dto.OrganizationFromService(org))
+}
+
+// GetByID handles getting an organization
+// GET /api/v1/organizations/:id
+func (h *OrganizationHandler) GetByID(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	org, member, err := h.orgService.Get(c.Request.Context(), userID, orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, OrganizationDetailResponse{
+		Organization: dto.OrganizationFromService(org),
+		Membership:   dto.OrganizationMemberFromService(member),
+	})
+}
+
+// Update handles updating organization settings
+// PUT /api/v1/organizations/:id
+func (h *OrganizationHandler) Update(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	org, err := h.orgService.Update(c.Request.Context(), userID, orgID, service.UpdateOrganizationRequest{
+		Name:                req.Name,
+		SharedSubscriptions: req.SharedSubscriptions,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationFromService(org))
+}
+
+// Delete handles deleting an organization
+// DELETE /api/v1/organizations/:id
+func (h *OrganizationHandler) Delete(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.orgService.Delete(c.Request.Context(), userID, orgID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Organization deleted successfully"})
+}
+
+// ListMembers handles listing organization members
+// GET /api/v1/organizations/:id/members
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.orgService.ListMembers(c.Request.Context(), userID, orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, organizationMembersToDTO(members))
+}
+
+// UpdateMember handles updating a member's role or spend limit
+// PUT /api/v1/organizations/:id/members/:user_id
+func (h *OrganizationHandler) UpdateMember(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+	targetUserID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	var req UpdateOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	member, err := h.orgService.UpdateMember(c.Request.Context(), userID, orgID, targetUserID, service.UpdateOrganizationMemberRequest{
+		Role:            req.Role,
+		SpendLimitUSD:   req.SpendLimitUSD,
+		ClearSpendLimit: req.ClearSpendLimit,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationMemberFromService(member))
+}
+
+// RemoveMember handles removing a member (or leaving the organization)
+// DELETE /api/v1/organizations/:id/members/:user_id
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+	targetUserID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	if err := h.orgService.RemoveMember(c.Request.Context(), userID, orgID, targetUserID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Member removed successfully"})
+}
+
+// ListInvitations handles listing invitations
+// GET /api/v1/organizations/:id/invitations
+func (h *OrganizationHandler) ListInvitations(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.orgService.ListInvitations(c.Request.Context(), userID, orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.OrganizationInvitation, 0, len(invitations))
+	for i := range invitations {
+		out = append(out, *dto.OrganizationInvitationFromService(&invitations[i]))
+	}
+	response.Success(c, out)
+}
+
+// Invite handles inviting a member by email
+// POST /api/v1/organizations/:id/invitations
+func (h *OrganizationHandler) Invite(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	var req InviteOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	inv, err := h.orgService.Invite(c.Request.Context(), userID, orgID, service.InviteOrganizationMemberRequest{
+		Email:   req.Email,
+		Role:    req.Role,
+		BaseURL: frontendBaseURL(c, h.settingService),
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Created(c, dto.OrganizationInvitationFromService(inv))
+}
+
+// RevokeInvitation handles revoking a pending invitation
+// DELETE /api/v1/organizations/:id/invitations/:invitation_id
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+	invitationID, err := strconv.ParseInt(c.Param("invitation_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid invitation ID")
+		return
+	}
+
+	if err := h.orgService.RevokeInvitation(c.Request.Context(), userID, orgID, invitationID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Invitation revoked successfully"})
+}
+
+// AcceptInvitation handles accepting an invitation
+// POST /api/v1/organizations/invitations/accept
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req AcceptOrganizationInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	member, err := h.orgService.AcceptInvitation(c.Request.Context(), subject.UserID, req.Token)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationMembershipFromService(member))
+}
+
+// Transfer handles transferring personal balance into the organization
+// POST /api/v1/organizations/:id/balance/transfer
+func (h *OrganizationHandler) Transfer(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	var req TransferOrganizationBalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	txn, err := h.orgService.TransferFromPersonalBalance(c.Request.Context(), userID, orgID, req.Amount)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.OrganizationBalanceTransactionFromService(txn))
+}
+
+// ListBalanceTransactions handles listing organization balance transactions
+// GET /api/v1/organizations/:id/balance/transactions
+func (h *OrganizationHandler) ListBalanceTransactions(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	txns, result, err := h.orgService.ListBalanceTransactions(c.Request.Context(), userID, orgID, params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.OrganizationBalanceTransaction, 0, len(txns))
+	for i := range txns {
+		out = append(out, *dto.OrganizationBalanceTransactionFromService(&txns[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+// ListAPIKeys handles listing team API keys
+// GET /api/v1/organizations/:id/keys
+func (h *OrganizationHandler) ListAPIKeys(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.orgService.ListAPIKeys(c.Request.Context(), userID, orgID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.OrganizationAPIKey, 0, len(keys))
+	for i := range keys {
+		out = append(out, *dto.OrganizationAPIKeyFromService(&keys[i]))
+	}
+	response.Success(c, out)
+}
+
+// DisableAPIKey handles disabling a team API key
+// POST /api/v1/organizations/:id/keys/:key_id/disable
+func (h *OrganizationHandler) DisableAPIKey(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+	keyID, err := strconv.ParseInt(c.Param("key_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid key ID")
+		return
+	}
+
+	if err := h.orgService.DisableAPIKey(c.Request.Context(), userID, orgID, keyID); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "API key disabled successfully"})
+}
+
+// Dashboard handles team usage dashboard
+// GET /api/v1/organizations/:id/dashboard?start_date=&end_date=&timezone=
+func (h *OrganizationHandler) Dashboard(c *gin.Context) {
+	userID, orgID, ok := parseOrganizationRequest(c)
+	if !ok {
+		return
+	}
+
+	startTime, endTime := parseUserTimeRange(c)
+	summary, err := h.orgService.GetUsageSummary(c.Request.Context(), userID, orgID, startTime, endTime)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, summary)
+}
+
+func parseOrganizationRequest(c *gin.Context) (int64, int64, bool) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return 0, 0, false
+	}
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid organization ID")
+		return 0, 0, false
+	}
+	return subject.UserID, orgID, true
+}
+
+func organizationMembersToDTO(members []service.OrganizationMember) []dto.OrganizationMember {
+	out := make([]dto.OrganizationMember, 0, len(members))
+	for i := range members {
+		out = append(out, *dto.OrganizationMemberFromService(&members[i]))
+	}
+	return out
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// responseCacheHeader 告知客户端本次响应是否来自响应缓存（仅在分组启用且请求可缓存时返回）
+const responseCacheHeader = "X-Response-Cache"
+
+// responseCaptureWriter 在写回客户端的同时截取响应体，超过上限后停止截取
+type responseCaptureWriter struct {
+	gin.ResponseWriter
+	buf      bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (w *responseCaptureWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseCaptureWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *responseCaptureWriter) capture(data []byte) {
+	if w.overflow {
+		return
+	}
+	if w.buf.Len()+len(data) > w.limit {
+		w.overflow = true
+		w.buf.Reset()
+		return
+	}
+	w.buf.Write(data)
+}
+
+// beginResponseCapture 替换 c.Writer 以截取本次转发的响应体；key 为空（不可缓存）时返回 nil
+func beginResponseCapture(c *gin.Context, key string, limit int) *responseCaptureWriter {
+	if key == "" {
+		return nil
+	}
+	w := &responseCaptureWriter{ResponseWriter: c.Writer, limit: limit}
+	c.Writer = w
+	return w
+}
+
+// end 恢复原始 Writer，仅在完整截取到 200 响应时返回响应体
+func (w *responseCaptureWriter) end(c *gin.Context) ([]byte, string, bool) {
+	if w == nil {
+		return nil, "", false
+	}
+	c.Writer = w.ResponseWriter
+	if w.overflow || w.buf.Len() == 0 || w.ResponseWriter.Status() != http.StatusOK {
+		return nil, "", false
+	}
+	return w.buf.Bytes(), w.ResponseWriter.Header().Get("Content-Type"), true
+}
+
+// serveResponseCache 命中时直接写回缓存响应并返回缓存条目；未命中时标记 MISS 并返回 nil
+func serveResponseCache(c *gin.Context, responseCache *service.ResponseCacheService, kind, key string) *service.CachedResponse {
+	if key == "" {
+		return nil
+	}
+	entry := responseCache.Get(c.Request.Context(), kind, key)
+	if entry == nil {
+		c.Header(responseCacheHeader, "MISS")
+		return nil
+	}
+	contentType := entry.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	c.Header(responseCacheHeader, "HIT")
+	c.Data(http.StatusOK, contentType, entry.Body)
+	return entry
+}
+
+// storeResponseCache 异步写入缓存，避免阻塞响应返回
+func storeResponseCache(responseCache *service.ResponseCacheService, group *service.Group, key string, entry *service.CachedResponse) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		responseCache.Put(ctx, group, key, entry)
+	}()
+}
+
+// cacheServedRequestID 缓存命中的使用记录需要独立的 request_id，避免与原始请求的去重键冲突
+func cacheServedRequestID() string {
+	return "cache-" + uuid.NewString()
+}
+
+func newCachedResponse(body []byte, contentType string, result *service.ForwardResult, accountID int64) *service.CachedResponse {
+	return &service.CachedResponse{
+		ContentType: contentType,
+		Body:        body,
+		Model:       result.Model,
+		AccountID:   accountID,
+		Usage:       result.Usage,
+	}
+}
+
+// recordCacheServedUsage 异步记录响应缓存命中的使用量，按原始用量计价后乘以分组缓存计费比例
+func (h *GatewayHandler) recordCacheServedUsage(c *gin.Context, entry *service.CachedResponse, apiKey *service.APIKey, subscription *service.UserSubscription) {
+	userAgent := c.GetHeader("User-Agent")
+	clientIP := ip.GetClientIP(c)
+	result := &service.ForwardResult{
+		RequestID: cacheServedRequestID(),
+		Usage:     entry.Usage,
+		Model:     entry.Model,
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
+			Result:       result,
+			APIKey:       apiKey,
+			User:         apiKey.User,
+			Account:      &service.Account{ID: entry.AccountID},
+			Subscription: subscription,
+			UserAgent:    userAgent,
+			IPAddress:    clientIP,
+			CacheServed:  true,
+		}); err != nil {
+			log.Printf("Record cache-served usage failed: %v", err)
+		}
+	}()
+}
+
+// recordCacheServedUsage 异步记录响应缓存命中的使用量（OpenAI Responses）
+func (h *OpenAIGatewayHandler) recordCacheServedUsage(c *gin.Context, entry *service.CachedResponse, apiKey *service.APIKey, subscription *service.UserSubscription) {
+	userAgent := c.GetHeader("User-Agent")
+	clientIP := ip.GetClientIP(c)
+	result := &service.OpenAIForwardResult{
+		RequestID: cacheServedRequestID(),
+		Usage: service.OpenAIUsage{
+			InputTokens:              entry.Usage.InputTokens,
+			OutputTokens:             entry.Usage.OutputTokens,
+			CacheCreationInputTokens: entry.Usage.CacheCreationInputTokens,
+			CacheReadInputTokens:     entry.Usage.CacheReadInputTokens,
+		},
+		Model: entry.Model,
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := h.gatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
+			Result:       result,
+			APIKey:       apiKey,
+			User:         apiKey.User,
+			Account:      &service.Account{ID: entry.AccountID},
+			Subscription: subscription,
+			UserAgent:    userAgent,
+			IPAddress:    clientIP,
+			CacheServed:  true,
+		}); err != nil {
+			log.Printf("Record cache-served usage failed: %v", err)
+		}
+	}()
+}
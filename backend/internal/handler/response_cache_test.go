@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCaptureWriter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		name     string
+		key      string
+		status   int
+		body     string
+		limit    int
+		captured bool
+	}{
+		{name: "captures ok response", key: "k", status: http.StatusOK, body: `{"ok":true}`, limit: 64, captured: true},
+		{name: "skips error response", key: "k", status: http.StatusBadRequest, body: `{"error":1}`, limit: 64},
+		{name: "skips oversized response", key: "k", status: http.StatusOK, body: `{"ok":true}`, limit: 4},
+		{name: "disabled without key", key: "", status: http.StatusOK, body: `{"ok":true}`, limit: 64},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			original := c.Writer
+
+			capture := beginResponseCapture(c, tt.key, tt.limit)
+			c.Data(tt.status, "application/json", []byte(tt.body))
+			body, contentType, ok := capture.end(c)
+
+			require.Equal(t, tt.captured, ok)
+			require.Equal(t, original, c.Writer)
+			require.Equal(t, tt.body, recorder.Body.String(), "client response must be unaffected")
+			if tt.captured {
+				require.Equal(t, tt.body, string(body))
+				require.Equal(t, "application/json", contentType)
+			}
+		})
+	}
+}
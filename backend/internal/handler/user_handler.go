@@ -2,6 +2,7 @@ package handler
 
 import (
 	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
 	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
 	"github.com/Wei-Shaw/sub2api/internal/service"
@@ -11,13 +12,15 @@ import (
 
 // UserHandler handles user-related requests
 type UserHandler struct {
-	userService *service.UserService
+	userService          *service.UserService
+	balanceLedgerService *service.BalanceLedgerService
 }
 
 // NewUserHandler creates a new UserHandler
-func NewUserHandler(userService *service.UserService) *UserHandler {
+func NewUserHandler(userService *service.UserService, balanceLedgerService *service.BalanceLedgerService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:          userService,
+		balanceLedgerService: balanceLedgerService,
 	}
 }
 
@@ -104,3 +107,28 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	response.Success(c, dto.UserFromService(updatedUser))
 }
+
+// GetBalanceHistory handles listing the current user's balance transactions
+// GET /api/v1/user/balance/history
+func (h *UserHandler) GetBalanceHistory(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+
+	txns, result, err := h.balanceLedgerService.ListUserTransactions(c.Request.Context(), subject.UserID, c.Query("type"), params)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.BalanceTransaction, 0, len(txns))
+	for i := range txns {
+		out = append(out, *dto.BalanceTransactionFromService(&txns[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
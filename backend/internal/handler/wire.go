@@ -26,6 +26,11 @@ func ProvideAdminHandlers(
 	subscriptionHandler *admin.SubscriptionHandler,
 	usageHandler *admin.UsageHandler,
 	userAttributeHandler *admin.UserAttributeHandler,
+	webhookHandler *admin.WebhookHandler,
+	modelPriceHandler *admin.ModelPriceHandler,
+	auditHandler *admin.AuditHandler,
+	invoiceHandler *admin.InvoiceHandler,
+	organizationHandler *admin.OrganizationHandler,
 ) *AdminHandlers {
 	return &AdminHandlers{
 		Dashboard:        dashboardHandler,
@@ -45,6 +50,11 @@ func ProvideAdminHandlers(
 		Subscription:     subscriptionHandler,
 		Usage:            usageHandler,
 		UserAttribute:    userAttributeHandler,
+		Webhook:          webhookHandler,
+		ModelPrice:       modelPriceHandler,
+		Audit:            auditHandler,
+		Invoice:          invoiceHandler,
+		Organization:     organizationHandler,
 	}
 }
 
@@ -69,23 +79,35 @@ func ProvideHandlers(
 	adminHandlers *AdminHandlers,
 	gatewayHandler *GatewayHandler,
 	openaiGatewayHandler *OpenAIGatewayHandler,
+	chatCompletionsHandler *ChatCompletionsHandler,
+	embeddingsHandler *EmbeddingsHandler,
+	messageBatchHandler *MessageBatchHandler,
 	settingHandler *SettingHandler,
 	totpHandler *TotpHandler,
 	usageReportHandler *UserUsageReportHandler,
+	invoiceHandler *InvoiceHandler,
+	organizationHandler *OrganizationHandler,
+	balanceAlertHandler *BalanceAlertHandler,
 ) *Handlers {
 	return &Handlers{
-		Auth:          authHandler,
-		User:          userHandler,
-		APIKey:        apiKeyHandler,
-		Usage:         usageHandler,
-		Redeem:        redeemHandler,
-		Subscription:  subscriptionHandler,
-		Admin:         adminHandlers,
-		Gateway:       gatewayHandler,
-		OpenAIGateway: openaiGatewayHandler,
-		Setting:       settingHandler,
-		Totp:          totpHandler,
-		UsageReport:   usageReportHandler,
+		Auth:            authHandler,
+		User:            userHandler,
+		APIKey:          apiKeyHandler,
+		Usage:           usageHandler,
+		Redeem:          redeemHandler,
+		Subscription:    subscriptionHandler,
+		Admin:           adminHandlers,
+		Gateway:         gatewayHandler,
+		OpenAIGateway:   openaiGatewayHandler,
+		ChatCompletions: chatCompletionsHandler,
+		Embeddings:      embeddingsHandler,
+		MessageBatch:    messageBatchHandler,
+		Setting:         settingHandler,
+		Totp:            totpHandler,
+		UsageReport:     usageReportHandler,
+		Invoice:         invoiceHandler,
+		Organization:    organizationHandler,
+		BalanceAlert:    balanceAlertHandler,
 	}
 }
 
@@ -100,8 +122,14 @@ var ProviderSet = wire.NewSet(
 	NewSubscriptionHandler,
 	NewGatewayHandler,
 	NewOpenAIGatewayHandler,
+	NewChatCompletionsHandler,
+	NewEmbeddingsHandler,
+	NewMessageBatchHandler,
 	NewTotpHandler,
 	NewUserUsageReportHandler,
+	NewInvoiceHandler,
+	NewOrganizationHandler,
+	NewBalanceAlertHandler,
 	ProvideSettingHandler,
 
 	// Admin handlers
@@ -122,6 +150,11 @@ var ProviderSet = wire.NewSet(
 	admin.NewSubscriptionHandler,
 	admin.NewUsageHandler,
 	admin.NewUserAttributeHandler,
+	admin.NewWebhookHandler,
+	admin.NewModelPriceHandler,
+	admin.NewAuditHandler,
+	admin.NewInvoiceHandler,
+	admin.NewOrganizationHandler,
 
 	// AdminHandlers and Handlers constructors
 	ProvideAdminHandlers,
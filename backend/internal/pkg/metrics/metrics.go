@@ -0,0 +1,386 @@
+// Package metrics 提供轻量的 Prometheus 指标实现（文本暴露格式 0.0.4）。
+//
+// 仅覆盖网关需要的 Counter / Gauge / Histogram 及带标签的向量形式，
+// 避免为一个抓取端点引入 client_golang 及其依赖树。
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ContentType Prometheus 文本格式的 Content-Type
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultLatencyBuckets 请求耗时直方图默认分桶（秒），覆盖非流式短请求到长时间流式输出
+var DefaultLatencyBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+
+// Collector 可注册到 Registry 的指标
+type Collector interface {
+	describe() (name, help, typ string)
+	writeSamples(w *bufio.Writer)
+}
+
+// Registry 指标注册表
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+	names      map[string]struct{}
+}
+
+// NewRegistry 创建空注册表
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]struct{})}
+}
+
+// Default 进程级默认注册表
+var Default = NewRegistry()
+
+// MustRegister 注册指标，名称重复时 panic（属于编程错误）
+func (r *Registry) MustRegister(cs ...Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range cs {
+		name, _, _ := c.describe()
+		if _, exists := r.names[name]; exists {
+			panic(fmt.Sprintf("metrics: duplicate metric name %q", name))
+		}
+		r.names[name] = struct{}{}
+		r.collectors = append(r.collectors, c)
+	}
+}
+
+// WriteText 以 Prometheus 文本格式输出所有指标（按名称排序）
+func (r *Registry) WriteText(out io.Writer) error {
+	r.mu.RLock()
+	collectors := make([]Collector, len(r.collectors))
+	copy(collectors, r.collectors)
+	r.mu.RUnlock()
+
+	sort.Slice(collectors, func(i, j int) bool {
+		ni, _, _ := collectors[i].describe()
+		nj, _, _ := collectors[j].describe()
+		return ni < nj
+	})
+
+	w := bufio.NewWriter(out)
+	for _, c := range collectors {
+		name, help, typ := c.describe()
+		fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(help))
+		fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+		c.writeSamples(w)
+	}
+	return w.Flush()
+}
+
+// ============================================
+// Counter / Gauge
+// ============================================
+
+// atomicFloat 以位模式存储 float64，支持无锁累加
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) Add(delta float64) {
+	for {
+		old := f.bits.Load()
+		next := math.Float64bits(math.Float64frombits(old) + delta)
+		if f.bits.CompareAndSwap(old, next) {
+			return
+		}
+	}
+}
+
+func (f *atomicFloat) Set(v float64) { f.bits.Store(math.Float64bits(v)) }
+
+func (f *atomicFloat) Load() float64 { return math.Float64frombits(f.bits.Load()) }
+
+// Counter 单调递增计数器
+type Counter struct{ v atomicFloat }
+
+// Inc 加 1
+func (c *Counter) Inc() { c.v.Add(1) }
+
+// Add 增加非负值，负值被忽略
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	c.v.Add(delta)
+}
+
+// Gauge 可增可减的瞬时值
+type Gauge struct{ v atomicFloat }
+
+// Set 设置当前值
+func (g *Gauge) Set(v float64) { g.v.Set(v) }
+
+// Add 增加（可为负）
+func (g *Gauge) Add(delta float64) { g.v.Add(delta) }
+
+// Inc 加 1
+func (g *Gauge) Inc() { g.v.Add(1) }
+
+// Dec 减 1
+func (g *Gauge) Dec() { g.v.Add(-1) }
+
+// Value 当前值
+func (g *Gauge) Value() float64 { return g.v.Load() }
+
+// ============================================
+// Histogram
+// ============================================
+
+// Histogram 累积分桶直方图
+type Histogram struct {
+	upperBounds []float64
+	counts      []atomic.Uint64 // 与 upperBounds 一一对应（非累积），最后一个为 +Inf
+	sum         atomicFloat
+	count       atomic.Uint64
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	return &Histogram{
+		upperBounds: buckets,
+		counts:      make([]atomic.Uint64, len(buckets)+1),
+	}
+}
+
+// Observe 记录一次观测值
+func (h *Histogram) Observe(v float64) {
+	idx := sort.SearchFloat64s(h.upperBounds, v)
+	h.counts[idx].Add(1)
+	h.sum.Add(v)
+	h.count.Add(1)
+}
+
+// ============================================
+// Vec
+// ============================================
+
+type series[T any] struct {
+	labelValues []string
+	metric      *T
+}
+
+// vec 按标签值组合管理子指标
+type vec[T any] struct {
+	name       string
+	help       string
+	labelNames []string
+	newMetric  func() *T
+
+	mu     sync.RWMutex
+	series map[string]*series[T]
+}
+
+func newVec[T any](name, help string, labelNames []string, newMetric func() *T) *vec[T] {
+	return &vec[T]{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		newMetric:  newMetric,
+		series:     make(map[string]*series[T]),
+	}
+}
+
+func (v *vec[T]) with(labelValues ...string) *T {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", v.name, len(v.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	v.mu.RLock()
+	s, ok := v.series[key]
+	v.mu.RUnlock()
+	if ok {
+		return s.metric
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if s, ok := v.series[key]; ok {
+		return s.metric
+	}
+	values := make([]string, len(labelValues))
+	copy(values, labelValues)
+	s = &series[T]{labelValues: values, metric: v.newMetric()}
+	v.series[key] = s
+	return s.metric
+}
+
+// sorted 返回按标签值排序的子指标快照，保证输出稳定
+func (v *vec[T]) sorted() []*series[T] {
+	v.mu.RLock()
+	keys := make([]string, 0, len(v.series))
+	for k := range v.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]*series[T], 0, len(keys))
+	for _, k := range keys {
+		out = append(out, v.series[k])
+	}
+	v.mu.RUnlock()
+	return out
+}
+
+// CounterVec 带标签的计数器
+type CounterVec struct{ v *vec[Counter] }
+
+// NewCounterVec 创建带标签的计数器（未注册）
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{v: newVec(name, help, labelNames, func() *Counter { return &Counter{} })}
+}
+
+// WithLabelValues 按标签值获取子计数器
+func (c *CounterVec) WithLabelValues(labelValues ...string) *Counter {
+	return c.v.with(labelValues...)
+}
+
+func (c *CounterVec) describe() (string, string, string) { return c.v.name, c.v.help, "counter" }
+
+func (c *CounterVec) writeSamples(w *bufio.Writer) {
+	for _, s := range c.v.sorted() {
+		writeSample(w, c.v.name, c.v.labelNames, s.labelValues, "", "", s.metric.v.Load())
+	}
+}
+
+// GaugeVec 带标签的仪表
+type GaugeVec struct{ v *vec[Gauge] }
+
+// NewGaugeVec 创建带标签的仪表（未注册）
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{v: newVec(name, help, labelNames, func() *Gauge { return &Gauge{} })}
+}
+
+// WithLabelValues 按标签值获取子仪表
+func (g *GaugeVec) WithLabelValues(labelValues ...string) *Gauge {
+	return g.v.with(labelValues...)
+}
+
+func (g *GaugeVec) describe() (string, string, string) { return g.v.name, g.v.help, "gauge" }
+
+func (g *GaugeVec) writeSamples(w *bufio.Writer) {
+	for _, s := range g.v.sorted() {
+		writeSample(w, g.v.name, g.v.labelNames, s.labelValues, "", "", s.metric.v.Load())
+	}
+}
+
+// HistogramVec 带标签的直方图
+type HistogramVec struct {
+	v       *vec[Histogram]
+	buckets []float64
+}
+
+// NewHistogramVec 创建带标签的直方图（未注册），buckets 需升序
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	bounds := make([]float64, len(buckets))
+	copy(bounds, buckets)
+	sort.Float64s(bounds)
+	return &HistogramVec{
+		v:       newVec(name, help, labelNames, func() *Histogram { return newHistogram(bounds) }),
+		buckets: bounds,
+	}
+}
+
+// WithLabelValues 按标签值获取子直方图
+func (h *HistogramVec) WithLabelValues(labelValues ...string) *Histogram {
+	return h.v.with(labelValues...)
+}
+
+func (h *HistogramVec) describe() (string, string, string) { return h.v.name, h.v.help, "histogram" }
+
+func (h *HistogramVec) writeSamples(w *bufio.Writer) {
+	for _, s := range h.v.sorted() {
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += s.metric.counts[i].Load()
+			writeSample(w, h.v.name+"_bucket", h.v.labelNames, s.labelValues, "le", formatFloat(bound), float64(cumulative))
+		}
+		cumulative += s.metric.counts[len(h.buckets)].Load()
+		writeSample(w, h.v.name+"_bucket", h.v.labelNames, s.labelValues, "le", "+Inf", float64(cumulative))
+		writeSample(w, h.v.name+"_sum", h.v.labelNames, s.labelValues, "", "", s.metric.sum.Load())
+		writeSample(w, h.v.name+"_count", h.v.labelNames, s.labelValues, "", "", float64(s.metric.count.Load()))
+	}
+}
+
+// GaugeFunc 抓取时回调取值的仪表，适合进程内已有统计（如队列长度）
+type GaugeFunc struct {
+	name string
+	help string
+	fn   func() float64
+}
+
+// NewGaugeFunc 创建回调仪表（未注册）
+func NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	return &GaugeFunc{name: name, help: help, fn: fn}
+}
+
+func (g *GaugeFunc) describe() (string, string, string) { return g.name, g.help, "gauge" }
+
+func (g *GaugeFunc) writeSamples(w *bufio.Writer) {
+	writeSample(w, g.name, nil, nil, "", "", g.fn())
+}
+
+// ============================================
+// 文本格式
+// ============================================
+
+func writeSample(w *bufio.Writer, name string, labelNames, labelValues []string, extraName, extraValue string, value float64) {
+	w.WriteString(name)
+	if len(labelNames) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, ln := range labelNames {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(ln)
+			w.WriteString(`="`)
+			w.WriteString(escapeLabelValue(labelValues[i]))
+			w.WriteByte('"')
+		}
+		if extraName != "" {
+			if len(labelNames) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(extraName)
+			w.WriteString(`="`)
+			w.WriteString(extraValue)
+			w.WriteByte('"')
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string { return helpEscaper.Replace(s) }
+
+func escapeLabelValue(s string) string { return labelValueEscaper.Replace(s) }
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryWriteText(t *testing.T) {
+	reg := NewRegistry()
+	requests := NewCounterVec("test_requests_total", "Total requests.", "platform", "status")
+	inflight := NewGaugeVec("test_inflight", "In-flight requests.", "scope")
+	latency := NewHistogramVec("test_latency_seconds", "Latency.", []float64{1, 0.5}, "platform")
+	queue := NewGaugeFunc("test_queue_length", "Queue length.", func() float64 { return 7 })
+	reg.MustRegister(requests, inflight, latency, queue)
+
+	requests.WithLabelValues("openai", "200").Inc()
+	requests.WithLabelValues("anthropic", "429").Add(2)
+	requests.WithLabelValues("anthropic", "429").Add(-5) // 负值忽略
+	inflight.WithLabelValues(`a"b\c`).Inc()
+	latency.WithLabelValues("openai").Observe(0.2)
+	latency.WithLabelValues("openai").Observe(0.7)
+	latency.WithLabelValues("openai").Observe(3)
+
+	var buf bytes.Buffer
+	require.NoError(t, reg.WriteText(&buf))
+	require.Equal(t, `# HELP test_inflight In-flight requests.
+# TYPE test_inflight gauge
+test_inflight{scope="a\"b\\c"} 1
+# HELP test_latency_seconds Latency.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{platform="openai",le="0.5"} 1
+test_latency_seconds_bucket{platform="openai",le="1"} 2
+test_latency_seconds_bucket{platform="openai",le="+Inf"} 3
+test_latency_seconds_sum{platform="openai"} 3.9
+test_latency_seconds_count{platform="openai"} 3
+# HELP test_queue_length Queue length.
+# TYPE test_queue_length gauge
+test_queue_length 7
+# HELP test_requests_total Total requests.
+# TYPE test_requests_total counter
+test_requests_total{platform="anthropic",status="429"} 2
+test_requests_total{platform="openai",status="200"} 1
+`, buf.String())
+}
+
+func TestRegistryDuplicateName(t *testing.T) {
+	reg := NewRegistry()
+	reg.MustRegister(NewCounterVec("dup_total", "x"))
+	require.Panics(t, func() { reg.MustRegister(NewGaugeVec("dup_total", "y")) })
+}
+
+func TestVecLabelCountMismatch(t *testing.T) {
+	c := NewCounterVec("mismatch_total", "x", "a", "b")
+	require.Panics(t, func() { c.WithLabelValues("only-one") })
+}
@@ -0,0 +1,239 @@
+// Package pdf is a minimal PDF 1.4 writer for simple text documents (invoices, statements).
+//
+// It only uses the standard Helvetica / Helvetica-Bold Type1 fonts, which every PDF reader
+// ships, so no font files are embedded. Text is encoded as WinAnsi; characters outside
+// Latin-1 (e.g. CJK) are rendered as '?'. Callers that may draw such text should check
+// Document.Lossy after rendering and fall back to another format.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A4 页面尺寸（单位：pt）
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Document 多页 PDF 文档，坐标原点在页面左下角
+type Document struct {
+	width  float64
+	height float64
+	pages  []*bytes.Buffer
+	lossy  bool
+}
+
+// New 创建指定页面尺寸的空文档（需先调用 AddPage）
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// Width 页面宽度
+func (d *Document) Width() float64 { return d.width }
+
+// Height 页面高度
+func (d *Document) Height() float64 { return d.height }
+
+// PageCount 当前页数
+func (d *Document) PageCount() int { return len(d.pages) }
+
+// Lossy 是否绘制过无法用 WinAnsi 编码的文本（这些字符已被替换为 '?'）
+func (d *Document) Lossy() bool { return d.lossy }
+
+// AddPage 追加新页面，之后的绘制都作用于该页
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+func (d *Document) current() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// Text 在 (x, y) 处绘制一行文本（y 为基线）
+func (d *Document) Text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	if !Encodable(s) {
+		d.lossy = true
+	}
+	fmt.Fprintf(d.current(), "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, num(size), num(x), num(y), escape(s))
+}
+
+// TextRight 文本右对齐到 x
+func (d *Document) TextRight(x, y, size float64, bold bool, s string) {
+	d.Text(x-TextWidth(s, size, bold), y, size, bold, s)
+}
+
+// Line 绘制直线
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.current(), "%s w %s %s m %s %s l S\n", num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+// FillRect 以灰度填充矩形（0 为黑色，1 为白色）
+func (d *Document) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(d.current(), "q %s g %s %s %s %s re f Q\n", num(gray), num(x), num(y), num(w), num(h))
+}
+
+// SetGray 设置后续文本的灰度（0 为黑色）
+func (d *Document) SetGray(gray float64) {
+	fmt.Fprintf(d.current(), "%s g\n", num(gray))
+}
+
+// Bytes 生成完整 PDF
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	_, _ = d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+// WriteTo 输出完整 PDF（对象：1 目录、2 页树、3/4 字体，之后每页一个页面对象与内容流）
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	var (
+		out     bytes.Buffer
+		offsets []int
+	)
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = strconv.Itoa(5+2*i) + " 0 R"
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, content := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(d.width), num(d.height), 6+2*i))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(out.Bytes())
+	return int64(n), err
+}
+
+// TextWidth 按 Helvetica 字宽估算文本宽度（pt）
+func TextWidth(s string, size float64, bold bool) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, b := range encode(s) {
+		if b >= 32 && b <= 126 {
+			total += widths[b-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Truncate 截断文本使宽度不超过 maxWidth（末尾加 "..."）
+func Truncate(s string, size float64, bold bool, maxWidth float64) string {
+	if TextWidth(s, size, bold) <= maxWidth {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := string(runes) + "..."
+		if TextWidth(candidate, size, bold) <= maxWidth {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Encodable 判断文本能否无损编码为 WinAnsi（Latin-1 子集，制表符按空格处理）
+func Encodable(s string) bool {
+	for _, r := range s {
+		if r != '\t' && (r < 32 || (r >= 127 && r < 160) || r > 255) {
+			return false
+		}
+	}
+	return true
+}
+
+// encode 转为 WinAnsi（Latin-1 子集），其余字符替换为 '?'
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r < 32 || (r >= 127 && r < 160) || r > 255:
+			out = append(out, '?')
+		default:
+			out = append(out, byte(r))
+		}
+	}
+	return out
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	for _, c := range encode(s) {
+		switch c {
+		case '\\', '(', ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			if c >= 128 {
+				fmt.Fprintf(&b, "\\%03o", c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	return b.String()
+}
+
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Helvetica / Helvetica-Bold 字宽（AFM，字符 32-126，单位 1/1000 em）
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
@@ -0,0 +1,63 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_WritesValidXref(t *testing.T) {
+	doc := New(A4Width, A4Height)
+	doc.AddPage()
+	doc.Text(50, 800, 12, true, "Invoice (draft) \\ 100%")
+	doc.Line(50, 790, 545, 790, 0.5)
+	doc.AddPage()
+	doc.TextRight(545, 800, 10, false, "$12.50")
+	out := doc.Bytes()
+
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	require.Contains(t, string(out), "/Count 2")
+	require.Contains(t, string(out), `(Invoice \(draft\) \\ 100%)`)
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n0 9\n")), "startxref must point at the xref table")
+
+	// 每个对象的偏移量都指向 "N 0 obj"
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	require.Len(t, entries, 8)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		require.True(t, bytes.HasPrefix(out[off:], []byte(strconv.Itoa(i+1)+" 0 obj")), "object %d offset", i+1)
+	}
+}
+
+func TestEncodeAndWidth(t *testing.T) {
+	require.Equal(t, []byte("caf\xe9 ??"), encode("café 中文"))
+	require.Equal(t, `caf\351`, escape("café"))
+	require.InDelta(t, 5.56*3, TextWidth("100", 10, false), 1e-9)
+	require.Greater(t, TextWidth("Total", 10, true), TextWidth("Total", 10, false))
+
+	s := Truncate("claude-sonnet-4-20250514-with-a-very-long-suffix", 9, false, 80)
+	require.LessOrEqual(t, TextWidth(s, 9, false), 80.0)
+	require.Contains(t, s, "...")
+	require.Equal(t, "short", Truncate("short", 9, false, 80))
+}
+
+func TestDocument_LossyCJK(t *testing.T) {
+	require.True(t, Encodable("café\tprice $1"))
+	require.False(t, Encodable("账单 Invoice"))
+	require.False(t, Encodable("請求書"))
+
+	doc := New(A4Width, A4Height)
+	doc.Text(50, 800, 12, false, "Invoice café")
+	require.False(t, doc.Lossy())
+	doc.Text(50, 780, 12, false, Truncate("张三 <a@example.com>", 12, false, 300))
+	require.True(t, doc.Lossy())
+}
@@ -141,6 +141,7 @@ type UsageLogFilters struct {
 	Model       string
 	Stream      *bool
 	BillingType *int8
+	CacheServed *bool
 	StartTime   *time.Time
 	EndTime     *time.Time
 }
@@ -156,6 +157,9 @@ type UsageStats struct {
 	TotalActualCost   float64  `json:"total_actual_cost"`
 	TotalAccountCost  *float64 `json:"total_account_cost,omitempty"`
 	AverageDurationMs float64  `json:"average_duration_ms"`
+	// 响应缓存命中请求数及节省的实际费用
+	CacheServedRequests int64   `json:"cache_served_requests"`
+	CacheSavedCost      float64 `json:"cache_saved_cost"`
 }
 
 // BatchUserUsageStats represents usage stats for a single user
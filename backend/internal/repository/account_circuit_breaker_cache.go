@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+// 账号熔断状态存储：每个账号一个 Hash（account_cb:{accountID}）
+//
+//	state / opened_at / trips           熔断状态、打开时间（秒）、累计熔断次数
+//	half_open_at / probes / probe_ok    半开开始时间、已发放探测名额、探测成功数
+//	b{slot} / t{slot} / f{slot}         滚动窗口环形桶：桶序号、请求数、失败数
+//
+// 窗口按 accountCircuitBuckets 个桶滚动，时间统一使用 Redis TIME，避免多实例时钟不同步。
+const (
+	accountCircuitKeyPrefix = "account_cb:"
+	accountCircuitBuckets   = 6
+)
+
+var (
+	// accountCircuitRecordScript 记录一次请求结果并推进状态机
+	// KEYS[1] = account_cb:{accountID}
+	// ARGV = failure(0/1), window, minRequests, failureThreshold, errorRate, openSeconds, halfOpenRequests, buckets
+	// 返回 {prevState, nextState}
+	accountCircuitRecordScript = redis.NewScript(`
+		local key = KEYS[1]
+		local failure = tonumber(ARGV[1]) == 1
+		local window = tonumber(ARGV[2])
+		local minRequests = tonumber(ARGV[3])
+		local failureThreshold = tonumber(ARGV[4])
+		local errorRate = tonumber(ARGV[5])
+		local openSeconds = tonumber(ARGV[6])
+		local halfOpenRequests = tonumber(ARGV[7])
+		local buckets = tonumber(ARGV[8])
+
+		local now = tonumber(redis.call('TIME')[1])
+		local ttl = math.max(window, openSeconds) * 2 + 60
+		local fields = redis.call('HMGET', key, 'state', 'opened_at')
+		local state = fields[1] or 'closed'
+
+		if state == 'open' and now - tonumber(fields[2] or '0') >= openSeconds then
+			state = 'half_open'
+			redis.call('HSET', key, 'state', state, 'half_open_at', now, 'probes', 0, 'probe_ok', 0)
+		end
+		local prev = state
+
+		if state == 'open' then
+			-- 熔断期间返回的在途请求结果不计入
+			return {prev, state}
+		end
+
+		if state == 'half_open' then
+			if failure then
+				state = 'open'
+				redis.call('HSET', key, 'state', state, 'opened_at', now, 'probes', 0, 'probe_ok', 0)
+				redis.call('HINCRBY', key, 'trips', 1)
+			elseif redis.call('HINCRBY', key, 'probe_ok', 1) >= halfOpenRequests then
+				state = 'closed'
+				redis.call('HSET', key, 'state', state, 'probes', 0, 'probe_ok', 0)
+				-- 关闭时清空窗口，避免熔断前的失败再次触发熔断
+				for i = 0, buckets - 1 do
+					redis.call('HDEL', key, 'b' .. i, 't' .. i, 'f' .. i)
+				end
+			end
+			redis.call('EXPIRE', key, ttl)
+			return {prev, state}
+		end
+
+		local size = math.max(1, math.ceil(window / buckets))
+		local current = math.floor(now / size)
+		local slot = current % buckets
+		if tonumber(redis.call('HGET', key, 'b' .. slot) or '-1') ~= current then
+			redis.call('HSET', key, 'b' .. slot, current, 't' .. slot, 0, 'f' .. slot, 0)
+		end
+		redis.call('HINCRBY', key, 't' .. slot, 1)
+
+		if failure then
+			redis.call('HINCRBY', key, 'f' .. slot, 1)
+			local total, failures = 0, 0
+			for i = 0, buckets - 1 do
+				local b = redis.call('HMGET', key, 'b' .. i, 't' .. i, 'f' .. i)
+				if tonumber(b[1] or '-1') > current - buckets then
+					total = total + tonumber(b[2] or '0')
+					failures = failures + tonumber(b[3] or '0')
+				end
+			end
+			if total >= minRequests and failures >= failureThreshold and failures / total >= errorRate then
+				state = 'open'
+				redis.call('HSET', key, 'state', state, 'opened_at', now, 'probes', 0, 'probe_ok', 0)
+				redis.call('HINCRBY', key, 'trips', 1)
+			end
+		end
+
+		redis.call('EXPIRE', key, ttl)
+		return {prev, state}
+	`)
+
+	// accountCircuitStatesScript 批量读取非 closed 账号状态，open 超时的账号切换为 half_open
+	// ARGV[1] = openSeconds
+	// ARGV[2..n] = accountIDs
+	// 返回 {accountID1, state1, accountID2, state2, ...}
+	accountCircuitStatesScript = redis.NewScript(`
+		local result = {}
+		local openSeconds = tonumber(ARGV[1])
+		local now = tonumber(redis.call('TIME')[1])
+
+		for i = 2, #ARGV do
+			local key = 'account_cb:' .. ARGV[i]
+			local fields = redis.call('HMGET', key, 'state', 'opened_at')
+			local state = fields[1]
+			if state == 'open' and now - tonumber(fields[2] or '0') >= openSeconds then
+				state = 'half_open'
+				redis.call('HSET', key, 'state', state, 'half_open_at', now, 'probes', 0, 'probe_ok', 0)
+			end
+			if state and state ~= 'closed' then
+				table.insert(result, ARGV[i])
+				table.insert(result, state)
+			end
+		end
+
+		return result
+	`)
+
+	// accountCircuitProbeScript 半开状态下申请探测名额，超过 openSeconds 未完成的探测名额重新发放
+	// KEYS[1] = account_cb:{accountID}
+	// ARGV = openSeconds, halfOpenRequests
+	accountCircuitProbeScript = redis.NewScript(`
+		local key = KEYS[1]
+		local openSeconds = tonumber(ARGV[1])
+		local halfOpenRequests = tonumber(ARGV[2])
+		local now = tonumber(redis.call('TIME')[1])
+
+		local fields = redis.call('HMGET', key, 'state', 'opened_at', 'half_open_at', 'probes')
+		local state = fields[1]
+		if state == 'open' then
+			if now - tonumber(fields[2] or '0') < openSeconds then
+				return 0
+			end
+			redis.call('HSET', key, 'state', 'half_open', 'half_open_at', now, 'probes', 0, 'probe_ok', 0)
+			fields[3] = now
+			fields[4] = 0
+		elseif state ~= 'half_open' then
+			return 1
+		end
+
+		if now - tonumber(fields[3] or '0') >= openSeconds then
+			redis.call('HSET', key, 'half_open_at', now, 'probes', 0, 'probe_ok', 0)
+			fields[4] = 0
+		end
+		if tonumber(fields[4] or '0') >= halfOpenRequests then
+			return 0
+		end
+		redis.call('HINCRBY', key, 'probes', 1)
+		return 1
+	`)
+
+	// accountCircuitStatusScript 批量读取熔断状态与窗口统计（只读）
+	// ARGV = window, openSeconds, buckets, accountIDs...
+	// 返回 {accountID, state, requests, failures, openedAt, trips, ...}
+	accountCircuitStatusScript = redis.NewScript(`
+		local result = {}
+		local window = tonumber(ARGV[1])
+		local openSeconds = tonumber(ARGV[2])
+		local buckets = tonumber(ARGV[3])
+		local now = tonumber(redis.call('TIME')[1])
+		local size = math.max(1, math.ceil(window / buckets))
+		local current = math.floor(now / size)
+
+		for i = 4, #ARGV do
+			local key = 'account_cb:' .. ARGV[i]
+			local fields = redis.call('HMGET', key, 'state', 'opened_at', 'trips')
+			local state = fields[1] or 'closed'
+			local openedAt = tonumber(fields[2] or '0')
+			if state == 'open' and now - openedAt >= openSeconds then
+				state = 'half_open'
+			end
+			local total, failures = 0, 0
+			for j = 0, buckets - 1 do
+				local b = redis.call('HMGET', key, 'b' .. j, 't' .. j, 'f' .. j)
+				if tonumber(b[1] or '-1') > current - buckets then
+					total = total + tonumber(b[2] or '0')
+					failures = failures + tonumber(b[3] or '0')
+				end
+			end
+			table.insert(result, ARGV[i])
+			table.insert(result, state)
+			table.insert(result, total)
+			table.insert(result, failures)
+			table.insert(result, openedAt)
+			table.insert(result, tonumber(fields[3] or '0'))
+		end
+
+		return result
+	`)
+)
+
+type accountCircuitBreakerCache struct {
+	rdb *redis.Client
+}
+
+// NewAccountCircuitBreakerCache 创建账号熔断状态缓存
+func NewAccountCircuitBreakerCache(rdb *redis.Client) service.AccountCircuitBreakerCache {
+	return &accountCircuitBreakerCache{rdb: rdb}
+}
+
+func accountCircuitKey(accountID int64) string {
+	return fmt.Sprintf("%s%d", accountCircuitKeyPrefix, accountID)
+}
+
+func (c *accountCircuitBreakerCache) Record(ctx context.Context, accountID int64, failure bool, params service.AccountCircuitParams) (string, string, error) {
+	failureArg := 0
+	if failure {
+		failureArg = 1
+	}
+	result, err := accountCircuitRecordScript.Run(ctx, c.rdb, []string{accountCircuitKey(accountID)},
+		failureArg,
+		params.WindowSeconds,
+		params.MinRequests,
+		params.FailureThreshold,
+		strconv.FormatFloat(params.ErrorRateThreshold, 'f', -1, 64),
+		params.OpenSeconds,
+		params.HalfOpenRequests,
+		accountCircuitBuckets,
+	).StringSlice()
+	if err != nil {
+		return "", "", fmt.Errorf("record account circuit: %w", err)
+	}
+	if len(result) != 2 {
+		return "", "", fmt.Errorf("record account circuit: unexpected result %v", result)
+	}
+	return result[0], result[1], nil
+}
+
+func (c *accountCircuitBreakerCache) GetStates(ctx context.Context, accountIDs []int64, params service.AccountCircuitParams) (map[int64]string, error) {
+	if len(accountIDs) == 0 {
+		return map[int64]string{}, nil
+	}
+	args := make([]any, 0, len(accountIDs)+1)
+	args = append(args, params.OpenSeconds)
+	for _, id := range accountIDs {
+		args = append(args, id)
+	}
+	result, err := accountCircuitStatesScript.Run(ctx, c.rdb, []string{}, args...).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("get account circuit states: %w", err)
+	}
+	states := make(map[int64]string, len(result)/2)
+	for i := 0; i+1 < len(result); i += 2 {
+		id, err := strconv.ParseInt(result[i], 10, 64)
+		if err != nil {
+			continue
+		}
+		states[id] = result[i+1]
+	}
+	return states, nil
+}
+
+func (c *accountCircuitBreakerCache) TryAcquireProbe(ctx context.Context, accountID int64, params service.AccountCircuitParams) (bool, error) {
+	allowed, err := accountCircuitProbeScript.Run(ctx, c.rdb, []string{accountCircuitKey(accountID)},
+		params.OpenSeconds, params.HalfOpenRequests).Int()
+	if err != nil {
+		return false, fmt.Errorf("acquire account circuit probe: %w", err)
+	}
+	return allowed == 1, nil
+}
+
+func (c *accountCircuitBreakerCache) GetStatuses(ctx context.Context, accountIDs []int64, params service.AccountCircuitParams) (map[int64]*service.AccountCircuitStatus, error) {
+	if len(accountIDs) == 0 {
+		return map[int64]*service.AccountCircuitStatus{}, nil
+	}
+	args := make([]any, 0, len(accountIDs)+3)
+	args = append(args, params.WindowSeconds, params.OpenSeconds, accountCircuitBuckets)
+	for _, id := range accountIDs {
+		args = append(args, id)
+	}
+	result, err := accountCircuitStatusScript.Run(ctx, c.rdb, []string{}, args...).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("get account circuit statuses: %w", err)
+	}
+
+	statuses := make(map[int64]*service.AccountCircuitStatus, len(accountIDs))
+	for i := 0; i+5 < len(result); i += 6 {
+		id, err := strconv.ParseInt(fmt.Sprintf("%v", result[i]), 10, 64)
+		if err != nil {
+			continue
+		}
+		requests, _ := strconv.ParseInt(fmt.Sprintf("%v", result[i+2]), 10, 64)
+		failures, _ := strconv.ParseInt(fmt.Sprintf("%v", result[i+3]), 10, 64)
+		openedAt, _ := strconv.ParseInt(fmt.Sprintf("%v", result[i+4]), 10, 64)
+		trips, _ := strconv.ParseInt(fmt.Sprintf("%v", result[i+5]), 10, 64)
+
+		status := &service.AccountCircuitStatus{
+			State:    fmt.Sprintf("%v", result[i+1]),
+			Requests: requests,
+			Failures: failures,
+			Trips:    trips,
+		}
+		if requests > 0 {
+			status.ErrorRate = float64(failures) / float64(requests)
+		}
+		if openedAt > 0 {
+			t := time.Unix(openedAt, 0)
+			status.OpenedAt = &t
+		}
+		statuses[id] = status
+	}
+	return statuses, nil
+}
+
+func (c *accountCircuitBreakerCache) Reset(ctx context.Context, accountID int64) error {
+	return c.rdb.Del(ctx, accountCircuitKey(accountID)).Err()
+}
@@ -0,0 +1,158 @@
+//go:build integration
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AccountCircuitBreakerCacheSuite struct {
+	IntegrationRedisSuite
+	cache  service.AccountCircuitBreakerCache
+	params service.AccountCircuitParams
+}
+
+func (s *AccountCircuitBreakerCacheSuite) SetupTest() {
+	s.IntegrationRedisSuite.SetupTest()
+	s.cache = NewAccountCircuitBreakerCache(s.rdb)
+	s.params = service.AccountCircuitParams{
+		WindowSeconds:      60,
+		MinRequests:        10,
+		FailureThreshold:   3,
+		ErrorRateThreshold: 0.3,
+		OpenSeconds:        30,
+		HalfOpenRequests:   2,
+	}
+}
+
+func (s *AccountCircuitBreakerCacheSuite) record(accountID int64, failure bool) (string, string) {
+	prev, next, err := s.cache.Record(s.ctx, accountID, failure, s.params)
+	require.NoError(s.T(), err, "Record")
+	return prev, next
+}
+
+// expireOpen 将熔断打开时间回拨，模拟 OpenSeconds 已过
+func (s *AccountCircuitBreakerCacheSuite) expireOpen(accountID int64) {
+	require.NoError(s.T(), s.rdb.HSet(s.ctx, accountCircuitKey(accountID), "opened_at", 1).Err())
+}
+
+func (s *AccountCircuitBreakerCacheSuite) TestStaysClosedBelowThresholds() {
+	// 错误率 100% 但请求数不足
+	for i := 0; i < 5; i++ {
+		_, next := s.record(1, true)
+		require.Equal(s.T(), service.AccountCircuitClosed, next)
+	}
+	// 请求数足够但错误率 20% < 30%
+	for i := 0; i < 20; i++ {
+		_, next := s.record(2, i%5 == 0)
+		require.Equal(s.T(), service.AccountCircuitClosed, next)
+	}
+
+	states, err := s.cache.GetStates(s.ctx, []int64{1, 2, 3}, s.params)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), states)
+}
+
+func (s *AccountCircuitBreakerCacheSuite) TestOpensOnErrorRate() {
+	for i := 0; i < 7; i++ {
+		s.record(10, false)
+	}
+	s.record(10, true)
+	s.record(10, true)
+	prev, next := s.record(10, true) // 10 requests, 3 failures = 30%
+	require.Equal(s.T(), service.AccountCircuitClosed, prev)
+	require.Equal(s.T(), service.AccountCircuitOpen, next)
+
+	states, err := s.cache.GetStates(s.ctx, []int64{10, 11}, s.params)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[int64]string{10: service.AccountCircuitOpen}, states)
+
+	allowed, err := s.cache.TryAcquireProbe(s.ctx, 10, s.params)
+	require.NoError(s.T(), err)
+	require.False(s.T(), allowed, "open circuit must not hand out probes")
+
+	statuses, err := s.cache.GetStatuses(s.ctx, []int64{10}, s.params)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), service.AccountCircuitOpen, statuses[10].State)
+	require.Equal(s.T(), int64(10), statuses[10].Requests)
+	require.Equal(s.T(), int64(3), statuses[10].Failures)
+	require.InDelta(s.T(), 0.3, statuses[10].ErrorRate, 1e-9)
+	require.Equal(s.T(), int64(1), statuses[10].Trips)
+	require.NotNil(s.T(), statuses[10].OpenedAt)
+
+	ttl, err := s.rdb.TTL(s.ctx, accountCircuitKey(10)).Result()
+	require.NoError(s.T(), err)
+	require.Greater(s.T(), ttl.Seconds(), 0.0)
+}
+
+func (s *AccountCircuitBreakerCacheSuite) tripAccount(accountID int64) {
+	for i := 0; i < s.params.MinRequests; i++ {
+		s.record(accountID, true)
+	}
+	states, err := s.cache.GetStates(s.ctx, []int64{accountID}, s.params)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), service.AccountCircuitOpen, states[accountID])
+}
+
+func (s *AccountCircuitBreakerCacheSuite) TestHalfOpenProbesThenCloses() {
+	s.tripAccount(20)
+	s.expireOpen(20)
+
+	states, err := s.cache.GetStates(s.ctx, []int64{20}, s.params)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), service.AccountCircuitHalfOpen, states[20])
+
+	for i := 0; i < s.params.HalfOpenRequests; i++ {
+		allowed, err := s.cache.TryAcquireProbe(s.ctx, 20, s.params)
+		require.NoError(s.T(), err)
+		require.True(s.T(), allowed)
+	}
+	allowed, err := s.cache.TryAcquireProbe(s.ctx, 20, s.params)
+	require.NoError(s.T(), err)
+	require.False(s.T(), allowed, "probe quota exhausted")
+
+	_, next := s.record(20, false)
+	require.Equal(s.T(), service.AccountCircuitHalfOpen, next)
+	prev, next := s.record(20, false)
+	require.Equal(s.T(), service.AccountCircuitHalfOpen, prev)
+	require.Equal(s.T(), service.AccountCircuitClosed, next)
+
+	statuses, err := s.cache.GetStatuses(s.ctx, []int64{20}, s.params)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), service.AccountCircuitClosed, statuses[20].State)
+	require.Zero(s.T(), statuses[20].Requests, "window is cleared on close")
+}
+
+func (s *AccountCircuitBreakerCacheSuite) TestHalfOpenFailureReopens() {
+	s.tripAccount(30)
+	s.expireOpen(30)
+
+	allowed, err := s.cache.TryAcquireProbe(s.ctx, 30, s.params)
+	require.NoError(s.T(), err)
+	require.True(s.T(), allowed)
+
+	prev, next := s.record(30, true)
+	require.Equal(s.T(), service.AccountCircuitHalfOpen, prev)
+	require.Equal(s.T(), service.AccountCircuitOpen, next)
+
+	statuses, err := s.cache.GetStatuses(s.ctx, []int64{30}, s.params)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(2), statuses[30].Trips)
+}
+
+func (s *AccountCircuitBreakerCacheSuite) TestReset() {
+	s.tripAccount(40)
+	require.NoError(s.T(), s.cache.Reset(s.ctx, 40))
+
+	states, err := s.cache.GetStates(s.ctx, []int64{40}, s.params)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), states)
+}
+
+func TestAccountCircuitBreakerCacheSuite(t *testing.T) {
+	suite.Run(t, new(AccountCircuitBreakerCacheSuite))
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+// API Key 速率限制缓存
+//
+// 与并发槽位一致使用有序集合实现滑动窗口：分数为 Redis 服务器时间（毫秒），
+// 每次读写前清理滑出窗口的成员。TPM 窗口的成员格式为 "{uniq}:{tokens}"，
+// 检查时在脚本内累加，避免额外的哈希结构。RPM 与 TPM 在同一脚本中检查，
+// 任一窗口超限时都不记录本次请求。
+const (
+	// 格式: ratelimit:apikey:rpm:{apiKeyID}
+	apiKeyRPMKeyPrefix = "ratelimit:apikey:rpm:"
+	// 格式: ratelimit:apikey:tpm:{apiKeyID}
+	apiKeyTPMKeyPrefix = "ratelimit:apikey:tpm:"
+)
+
+var (
+	// acquireRateWindowsScript 在同一脚本内检查 RPM/TPM 窗口，两者均未超限时才记录本次请求
+	// KEYS[1] = rpm 有序集合键
+	// KEYS[2] = tpm 有序集合键
+	// ARGV[1] = rpm limit（0 表示不检查）
+	// ARGV[2] = tpm limit（0 表示不检查）
+	// ARGV[3] = 窗口长度（毫秒）
+	// ARGV[4] = 成员 ID
+	// 返回 {rpmAllowed, rpmUsed, rpmResetAfterMs, tpmAllowed, tpmUsed, tpmResetAfterMs}
+	acquireRateWindowsScript = redis.NewScript(`
+		local rpmKey = KEYS[1]
+		local tpmKey = KEYS[2]
+		local rpmLimit = tonumber(ARGV[1])
+		local tpmLimit = tonumber(ARGV[2])
+		local windowMs = tonumber(ARGV[3])
+
+		local timeResult = redis.call('TIME')
+		local now = tonumber(timeResult[1]) * 1000 + math.floor(tonumber(timeResult[2]) / 1000)
+
+		local tpmAllowed, tpmUsed, tpmReset = 1, 0, windowMs
+		if tpmLimit > 0 then
+			redis.call('ZREMRANGEBYSCORE', tpmKey, '-inf', now - windowMs)
+			local members = redis.call('ZRANGE', tpmKey, 0, -1, 'WITHSCORES')
+			for i = 1, #members, 2 do
+				tpmUsed = tpmUsed + (tonumber(string.match(members[i], ':(%d+)$')) or 0)
+			end
+			if #members > 0 then
+				tpmReset = tonumber(members[2]) + windowMs - now
+			end
+			if tpmUsed >= tpmLimit then
+				tpmAllowed = 0
+			end
+		end
+
+		local rpmAllowed, rpmUsed, rpmReset = 1, 0, windowMs
+		if rpmLimit > 0 then
+			redis.call('ZREMRANGEBYSCORE', rpmKey, '-inf', now - windowMs)
+			rpmUsed = redis.call('ZCARD', rpmKey)
+			local oldest = redis.call('ZRANGE', rpmKey, 0, 0, 'WITHSCORES')
+			if #oldest > 0 then
+				rpmReset = tonumber(oldest[2]) + windowMs - now
+			end
+			if rpmUsed >= rpmLimit then
+				rpmAllowed = 0
+			elseif tpmAllowed == 1 then
+				redis.call('ZADD', rpmKey, now, ARGV[4])
+				redis.call('PEXPIRE', rpmKey, windowMs)
+				rpmUsed = rpmUsed + 1
+			end
+		end
+
+		return {rpmAllowed, rpmUsed, rpmReset, tpmAllowed, tpmUsed, tpmReset}
+	`)
+
+	// addTokensScript 记录 token 消耗
+	// KEYS[1] = tpm 有序集合键
+	// ARGV[1] = 成员（{uniq}:{tokens}）
+	// ARGV[2] = 窗口长度（毫秒）
+	addTokensScript = redis.NewScript(`
+		local timeResult = redis.call('TIME')
+		local now = tonumber(timeResult[1]) * 1000 + math.floor(tonumber(timeResult[2]) / 1000)
+		redis.call('ZADD', KEYS[1], now, ARGV[1])
+		redis.call('PEXPIRE', KEYS[1], ARGV[2])
+		return 1
+	`)
+)
+
+type apiKeyRateLimitCache struct {
+	rdb *redis.Client
+}
+
+// NewAPIKeyRateLimitCache 创建 API Key 速率限制缓存
+func NewAPIKeyRateLimitCache(rdb *redis.Client) service.APIKeyRateLimitCache {
+	return &apiKeyRateLimitCache{rdb: rdb}
+}
+
+func apiKeyRPMKey(apiKeyID int64) string {
+	return fmt.Sprintf("%s%d", apiKeyRPMKeyPrefix, apiKeyID)
+}
+
+func apiKeyTPMKey(apiKeyID int64) string {
+	return fmt.Sprintf("%s%d", apiKeyTPMKeyPrefix, apiKeyID)
+}
+
+func (c *apiKeyRateLimitCache) Acquire(ctx context.Context, apiKeyID int64, rpm, tpm int, window time.Duration) (requests, tokens *service.RateWindowState, err error) {
+	member, err := rateWindowMemberID()
+	if err != nil {
+		return nil, nil, err
+	}
+	keys := []string{apiKeyRPMKey(apiKeyID), apiKeyTPMKey(apiKeyID)}
+	values, err := acquireRateWindowsScript.Run(ctx, c.rdb, keys, rpm, tpm, window.Milliseconds(), member).Int64Slice()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(values) != 6 {
+		return nil, nil, fmt.Errorf("unexpected rate window result: %v", values)
+	}
+	if rpm > 0 {
+		requests = parseRateWindowState(values[0:3])
+	}
+	if tpm > 0 {
+		tokens = parseRateWindowState(values[3:6])
+	}
+	return requests, tokens, nil
+}
+
+func (c *apiKeyRateLimitCache) AddTokens(ctx context.Context, apiKeyID int64, tokens int, window time.Duration) error {
+	member, err := rateWindowMemberID()
+	if err != nil {
+		return err
+	}
+	member = fmt.Sprintf("%s:%d", member, tokens)
+	return addTokensScript.Run(ctx, c.rdb, []string{apiKeyTPMKey(apiKeyID)}, member, window.Milliseconds()).Err()
+}
+
+func parseRateWindowState(values []int64) *service.RateWindowState {
+	return &service.RateWindowState{
+		Allowed:    values[0] == 1,
+		Used:       values[1],
+		ResetAfter: time.Duration(max(values[2], 0)) * time.Millisecond,
+	}
+}
+
+func rateWindowMemberID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"log"
 	"time"
 
 	dbent "github.com/Wei-Shaw/sub2api/ent"
@@ -33,7 +35,13 @@ func (r *apiKeyRepository) Create(ctx context.Context, key *service.APIKey) erro
 		SetKey(key.Key).
 		SetName(key.Name).
 		SetStatus(key.Status).
-		SetNillableGroupID(key.GroupID)
+		SetNillableGroupID(key.GroupID).
+		SetNillableQuotaUsd(key.QuotaUSD).
+		SetNillableExpiresAt(key.ExpiresAt).
+		SetNillableMaxTokensCap(key.MaxTokensCap).
+		SetNillableRpmLimit(key.RPMLimit).
+		SetNillableTpmLimit(key.TPMLimit).
+		SetNillableOrganizationID(key.OrganizationID)
 
 	if len(key.IPWhitelist) > 0 {
 		builder.SetIPWhitelist(key.IPWhitelist)
@@ -41,6 +49,9 @@ func (r *apiKeyRepository) Create(ctx context.Context, key *service.APIKey) erro
 	if len(key.IPBlacklist) > 0 {
 		builder.SetIPBlacklist(key.IPBlacklist)
 	}
+	if len(key.AllowedModels) > 0 {
+		builder.SetAllowedModels(key.AllowedModels)
+	}
 
 	created, err := builder.Save(ctx)
 	if err == nil {
@@ -110,6 +121,14 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 			apikey.FieldStatus,
 			apikey.FieldIPWhitelist,
 			apikey.FieldIPBlacklist,
+			apikey.FieldQuotaUsd,
+			apikey.FieldUsedUsd,
+			apikey.FieldExpiresAt,
+			apikey.FieldAllowedModels,
+			apikey.FieldMaxTokensCap,
+			apikey.FieldRpmLimit,
+			apikey.FieldTpmLimit,
+			apikey.FieldOrganizationID,
 		).
 		WithUser(func(q *dbent.UserQuery) {
 			q.Select(
@@ -118,6 +137,8 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 				user.FieldRole,
 				user.FieldBalance,
 				user.FieldConcurrency,
+				user.FieldQueuePriority,
+				user.FieldQueueWeight,
 			)
 		}).
 		WithGroup(func(q *dbent.GroupQuery) {
@@ -138,6 +159,17 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 				group.FieldFallbackGroupID,
 				group.FieldModelRoutingEnabled,
 				group.FieldModelRouting,
+				group.FieldDefaultRpmLimit,
+				group.FieldDefaultTpmLimit,
+				group.FieldResponseCacheEnabled,
+				group.FieldResponseCacheTTLSeconds,
+				group.FieldResponseCacheRate,
+				group.FieldModelFallbacks,
+				group.FieldGuardrails,
+				group.FieldSchedulingStrategy,
+				group.FieldSchedulingWeights,
+				group.FieldHedgeDelayMs,
+				group.FieldQueueWeight,
 			)
 		}).
 		Only(ctx)
@@ -180,6 +212,42 @@ func (r *apiKeyRepository) Update(ctx context.Context, key *service.APIKey) erro
 		builder.ClearIPBlacklist()
 	}
 
+	// 额度与过期时间（used_usd 仅通过 IncrementUsedUSD 原子累加，不在此覆盖）
+	if key.QuotaUSD != nil {
+		builder.SetQuotaUsd(*key.QuotaUSD)
+	} else {
+		builder.ClearQuotaUsd()
+	}
+	if key.ExpiresAt != nil {
+		builder.SetExpiresAt(*key.ExpiresAt)
+	} else {
+		builder.ClearExpiresAt()
+	}
+
+	// 模型白名单与 max_tokens 上限
+	if len(key.AllowedModels) > 0 {
+		builder.SetAllowedModels(key.AllowedModels)
+	} else {
+		builder.ClearAllowedModels()
+	}
+	if key.MaxTokensCap != nil {
+		builder.SetMaxTokensCap(*key.MaxTokensCap)
+	} else {
+		builder.ClearMaxTokensCap()
+	}
+
+	// 速率限制（nil 时回退到分组默认值）
+	if key.RPMLimit != nil {
+		builder.SetRpmLimit(*key.RPMLimit)
+	} else {
+		builder.ClearRpmLimit()
+	}
+	if key.TPMLimit != nil {
+		builder.SetTpmLimit(*key.TPMLimit)
+	} else {
+		builder.ClearTpmLimit()
+	}
+
 	affected, err := builder.Save(ctx)
 	if err != nil {
 		return err
@@ -357,21 +425,52 @@ func (r *apiKeyRepository) ListKeysByGroupID(ctx context.Context, groupID int64)
 	return keys, nil
 }
 
+func (r *apiKeyRepository) GetUsedUSD(ctx context.Context, id int64) (float64, error) {
+	m, err := r.activeQuery().
+		Where(apikey.IDEQ(id)).
+		Select(apikey.FieldUsedUsd).
+		Only(ctx)
+	if err != nil {
+		if dbent.IsNotFound(err) {
+			return 0, service.ErrAPIKeyNotFound
+		}
+		return 0, err
+	}
+	return m.UsedUsd, nil
+}
+
+func (r *apiKeyRepository) IncrementUsedUSD(ctx context.Context, id int64, amount float64) error {
+	_, err := r.client.APIKey.Update().
+		Where(apikey.IDEQ(id)).
+		AddUsedUsd(amount).
+		Save(ctx)
+	return err
+}
+
 func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
 	if m == nil {
 		return nil
 	}
 	out := &service.APIKey{
-		ID:          m.ID,
-		UserID:      m.UserID,
-		Key:         m.Key,
-		Name:        m.Name,
-		Status:      m.Status,
-		IPWhitelist: m.IPWhitelist,
-		IPBlacklist: m.IPBlacklist,
-		CreatedAt:   m.CreatedAt,
-		UpdatedAt:   m.UpdatedAt,
-		GroupID:     m.GroupID,
+		ID:            m.ID,
+		UserID:        m.UserID,
+		Key:           m.Key,
+		Name:          m.Name,
+		Status:        m.Status,
+		IPWhitelist:   m.IPWhitelist,
+		IPBlacklist:   m.IPBlacklist,
+		QuotaUSD:      m.QuotaUsd,
+		UsedUSD:       m.UsedUsd,
+		ExpiresAt:     m.ExpiresAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+		GroupID:       m.GroupID,
+		AllowedModels: m.AllowedModels,
+		MaxTokensCap:  m.MaxTokensCap,
+		RPMLimit:      m.RpmLimit,
+		TPMLimit:      m.TpmLimit,
+
+		OrganizationID: m.OrganizationID,
 	}
 	if m.Edges.User != nil {
 		out.User = userEntityToService(m.Edges.User)
@@ -403,6 +502,8 @@ func userEntityToService(u *dbent.User) *service.User {
 		UsageReportEnabled:  u.UsageReportEnabled,
 		UsageReportSchedule: u.UsageReportSchedule,
 		UsageReportTimezone: u.UsageReportTimezone,
+		QueuePriority:       u.QueuePriority,
+		QueueWeight:         u.QueueWeight,
 		CreatedAt:           u.CreatedAt,
 		UpdatedAt:           u.UpdatedAt,
 	}
@@ -413,29 +514,66 @@ func groupEntityToService(g *dbent.Group) *service.Group {
 		return nil
 	}
 	return &service.Group{
-		ID:                  g.ID,
-		Name:                g.Name,
-		Description:         derefString(g.Description),
-		Platform:            g.Platform,
-		RateMultiplier:      g.RateMultiplier,
-		IsExclusive:         g.IsExclusive,
-		Status:              g.Status,
-		Hydrated:            true,
-		SubscriptionType:    g.SubscriptionType,
-		DailyLimitUSD:       g.DailyLimitUsd,
-		WeeklyLimitUSD:      g.WeeklyLimitUsd,
-		MonthlyLimitUSD:     g.MonthlyLimitUsd,
-		ImagePrice1K:        g.ImagePrice1k,
-		ImagePrice2K:        g.ImagePrice2k,
-		ImagePrice4K:        g.ImagePrice4k,
-		DefaultValidityDays: g.DefaultValidityDays,
-		ClaudeCodeOnly:      g.ClaudeCodeOnly,
-		FallbackGroupID:     g.FallbackGroupID,
-		ModelRouting:        g.ModelRouting,
-		ModelRoutingEnabled: g.ModelRoutingEnabled,
-		CreatedAt:           g.CreatedAt,
-		UpdatedAt:           g.UpdatedAt,
+		ID:                      g.ID,
+		Name:                    g.Name,
+		Description:             derefString(g.Description),
+		Platform:                g.Platform,
+		RateMultiplier:          g.RateMultiplier,
+		IsExclusive:             g.IsExclusive,
+		Status:                  g.Status,
+		Hydrated:                true,
+		SubscriptionType:        g.SubscriptionType,
+		DailyLimitUSD:           g.DailyLimitUsd,
+		WeeklyLimitUSD:          g.WeeklyLimitUsd,
+		MonthlyLimitUSD:         g.MonthlyLimitUsd,
+		ImagePrice1K:            g.ImagePrice1k,
+		ImagePrice2K:            g.ImagePrice2k,
+		ImagePrice4K:            g.ImagePrice4k,
+		DefaultValidityDays:     g.DefaultValidityDays,
+		ClaudeCodeOnly:          g.ClaudeCodeOnly,
+		FallbackGroupID:         g.FallbackGroupID,
+		ModelRouting:            g.ModelRouting,
+		ModelRoutingEnabled:     g.ModelRoutingEnabled,
+		DefaultRPMLimit:         g.DefaultRpmLimit,
+		DefaultTPMLimit:         g.DefaultTpmLimit,
+		ResponseCacheEnabled:    g.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds: g.ResponseCacheTTLSeconds,
+		ResponseCacheRate:       g.ResponseCacheRate,
+		ModelFallbacks:          modelFallbacksFromJSON(g.ID, g.ModelFallbacks),
+		Guardrails:              guardrailsFromJSON(g.ID, g.Guardrails),
+		SchedulingStrategy:      g.SchedulingStrategy,
+		SchedulingWeights:       g.SchedulingWeights,
+		HedgeDelayMs:            g.HedgeDelayMs,
+		QueueWeight:             g.QueueWeight,
+		CreatedAt:               g.CreatedAt,
+		UpdatedAt:               g.UpdatedAt,
+	}
+}
+
+// modelFallbacksFromJSON 解析分组的模型降级链，数据损坏时记录日志并视为未配置
+func modelFallbacksFromJSON(groupID int64, raw json.RawMessage) map[string][]service.ModelFallbackTarget {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var fallbacks map[string][]service.ModelFallbackTarget
+	if err := json.Unmarshal(raw, &fallbacks); err != nil {
+		log.Printf("[Group] invalid model_fallbacks: group=%d err=%v", groupID, err)
+		return nil
+	}
+	return fallbacks
+}
+
+// guardrailsFromJSON 解析分组的护栏配置，数据损坏时记录日志并视为未配置
+func guardrailsFromJSON(groupID int64, raw json.RawMessage) *service.GuardrailConfig {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var cfg service.GuardrailConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		log.Printf("[Group] invalid guardrails: group=%d err=%v", groupID, err)
+		return nil
 	}
+	return &cfg
 }
 
 func derefString(s *string) string {
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type auditLogRepository struct {
+	sql sqlExecutor
+}
+
+func NewAuditLogRepository(sqlDB *sql.DB) service.AuditLogRepository {
+	return &auditLogRepository{sql: sqlDB}
+}
+
+const auditLogColumns = `
+	a.id, a.actor_user_id, COALESCE(u.email, ''), a.auth_method, a.ip, a.user_agent,
+	a.method, a.route, a.path, a.target_type, a.target_id, a.status_code,
+	a.request_body, a.diff, a.created_at
+`
+
+const auditLogFrom = ` FROM audit_logs a LEFT JOIN users u ON u.id = a.actor_user_id`
+
+func (r *auditLogRepository) Create(ctx context.Context, log *service.AuditLog) error {
+	if log == nil {
+		return nil
+	}
+	var diff any
+	if len(log.Diff) > 0 {
+		raw, err := json.Marshal(log.Diff)
+		if err != nil {
+			return err
+		}
+		diff = string(raw)
+	}
+	query := `
+		INSERT INTO audit_logs (
+			actor_user_id, auth_method, ip, user_agent, method, route, path,
+			target_type, target_id, status_code, request_body, diff
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, created_at
+	`
+	args := []any{
+		nullInt64(log.ActorUserID),
+		log.AuthMethod,
+		log.IP,
+		log.UserAgent,
+		log.Method,
+		log.Route,
+		log.Path,
+		log.TargetType,
+		log.TargetID,
+		log.StatusCode,
+		nullString(log.RequestBody),
+		diff,
+	}
+	return scanSingleRow(ctx, r.sql, query, args, &log.ID, &log.CreatedAt)
+}
+
+func (r *auditLogRepository) List(ctx context.Context, filter service.AuditLogFilter, params pagination.PaginationParams) ([]service.AuditLog, *pagination.PaginationResult, error) {
+	where, args := buildAuditLogWhere(filter)
+
+	var total int64
+	if err := scanSingleRow(ctx, r.sql, `SELECT COUNT(*) FROM audit_logs a`+where, args, &total); err != nil {
+		return nil, nil, err
+	}
+
+	query := `SELECT ` + auditLogColumns + auditLogFrom + where +
+		` ORDER BY a.id DESC LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
+	logs, err := r.query(ctx, query, append(args, params.Limit(), params.Offset())...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return logs, paginationResultFromTotal(total, params), nil
+}
+
+func (r *auditLogRepository) ListForExport(ctx context.Context, filter service.AuditLogFilter, limit int) ([]service.AuditLog, error) {
+	where, args := buildAuditLogWhere(filter)
+	query := `SELECT ` + auditLogColumns + auditLogFrom + where +
+		` ORDER BY a.id DESC LIMIT $` + itoa(len(args)+1)
+	return r.query(ctx, query, append(args, limit)...)
+}
+
+func (r *auditLogRepository) query(ctx context.Context, query string, args ...any) ([]service.AuditLog, error) {
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]service.AuditLog, 0)
+	for rows.Next() {
+		log, err := scanAuditLog(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *log)
+	}
+	return out, rows.Err()
+}
+
+func buildAuditLogWhere(filter service.AuditLogFilter) (string, []any) {
+	var conds []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, strings.ReplaceAll(cond, "?", "$"+itoa(len(args))))
+	}
+
+	if filter.ActorUserID != nil {
+		add("a.actor_user_id = ?", *filter.ActorUserID)
+	}
+	if filter.AuthMethod != "" {
+		add("a.auth_method = ?", filter.AuthMethod)
+	}
+	if filter.TargetType != "" {
+		add("a.target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		add("a.target_id = ?", filter.TargetID)
+	}
+	if filter.Method != "" {
+		add("a.method = ?", strings.ToUpper(filter.Method))
+	}
+	if filter.Route != "" {
+		add("a.route ILIKE ?", "%"+filter.Route+"%")
+	}
+	if filter.StartTime != nil {
+		add("a.created_at >= ?", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		add("a.created_at < ?", *filter.EndTime)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func scanAuditLog(rows *sql.Rows) (*service.AuditLog, error) {
+	var log service.AuditLog
+	var actorUserID sql.NullInt64
+	var requestBody sql.NullString
+	var diff []byte
+	if err := rows.Scan(
+		&log.ID,
+		&actorUserID,
+		&log.ActorEmail,
+		&log.AuthMethod,
+		&log.IP,
+		&log.UserAgent,
+		&log.Method,
+		&log.Route,
+		&log.Path,
+		&log.TargetType,
+		&log.TargetID,
+		&log.StatusCode,
+		&requestBody,
+		&diff,
+		&log.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if actorUserID.Valid {
+		log.ActorUserID = &actorUserID.Int64
+	}
+	if requestBody.Valid {
+		log.RequestBody = &requestBody.String
+	}
+	if len(diff) > 0 {
+		if err := json.Unmarshal(diff, &log.Diff); err != nil {
+			return nil, err
+		}
+	}
+	return &log, nil
+}
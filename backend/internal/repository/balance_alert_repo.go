@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type balanceAlertRepository struct {
+	sql sqlExecutor
+}
+
+func NewBalanceAlertRepository(sqlDB *sql.DB) service.BalanceAlertRepository {
+	return &balanceAlertRepository{sql: sqlDB}
+}
+
+const autoTopUpCodeColumns = `
+	t.id, t.user_id, t.redeem_code_id, t.status, t.error_message, t.used_at, t.created_at,
+	COALESCE(c.code, ''), COALESCE(c.type, ''), COALESCE(c.value, 0), c.group_id, COALESCE(c.validity_days, 0)
+`
+
+const autoTopUpCodeFrom = ` FROM user_auto_topup_codes t LEFT JOIN redeem_codes c ON c.id = t.redeem_code_id`
+
+func (r *balanceAlertRepository) GetSettings(ctx context.Context, userID int64) (*service.BalanceAlertSettings, error) {
+	var (
+		settings   = service.BalanceAlertSettings{UserID: userID}
+		thresholds []byte
+		notified   sql.NullFloat64
+		lastTopUp  sql.NullTime
+	)
+	err := scanSingleRow(ctx, r.sql, `
+		SELECT thresholds, email_enabled, webhook_url, webhook_secret, notified_threshold,
+			auto_topup_enabled, auto_topup_threshold, auto_topup_last_at, created_at, updated_at
+		FROM user_balance_alerts
+		WHERE user_id = $1
+	`, []any{userID},
+		&thresholds, &settings.EmailEnabled, &settings.WebhookURL, &settings.WebhookSecret, &notified,
+		&settings.AutoTopUpEnabled, &settings.AutoTopUpThreshold, &lastTopUp, &settings.CreatedAt, &settings.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	settings.Thresholds = []float64{}
+	if len(thresholds) > 0 {
+		if err := json.Unmarshal(thresholds, &settings.Thresholds); err != nil {
+			return nil, err
+		}
+	}
+	settings.NotifiedThreshold = nullFloat64Ptr(notified)
+	if lastTopUp.Valid {
+		t := lastTopUp.Time
+		settings.AutoTopUpLastAt = &t
+	}
+	return &settings, nil
+}
+
+func (r *balanceAlertRepository) UpsertSettings(ctx context.Context, settings *service.BalanceAlertSettings) error {
+	if settings == nil {
+		return nil
+	}
+	thresholds := settings.Thresholds
+	if thresholds == nil {
+		thresholds = []float64{}
+	}
+	raw, err := json.Marshal(thresholds)
+	if err != nil {
+		return err
+	}
+	return scanSingleRow(ctx, r.sql, `
+		INSERT INTO user_balance_alerts (
+			user_id, thresholds, email_enabled, webhook_url, webhook_secret, notified_threshold,
+			auto_topup_enabled, auto_topup_threshold
+		) VALUES ($1, $2::jsonb, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET
+			thresholds = EXCLUDED.thresholds,
+			email_enabled = EXCLUDED.email_enabled,
+			webhook_url = EXCLUDED.webhook_url,
+			webhook_secret = EXCLUDED.webhook_secret,
+			notified_threshold = EXCLUDED.notified_threshold,
+			auto_topup_enabled = EXCLUDED.auto_topup_enabled,
+			auto_topup_threshold = EXCLUDED.auto_topup_threshold,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`, []any{
+		settings.UserID, string(raw), settings.EmailEnabled, settings.WebhookURL, settings.WebhookSecret,
+		nullFloat64(settings.NotifiedThreshold), settings.AutoTopUpEnabled, settings.AutoTopUpThreshold,
+	}, &settings.CreatedAt, &settings.UpdatedAt)
+}
+
+func (r *balanceAlertRepository) ListActiveUserIDs(ctx context.Context) ([]int64, error) {
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT user_id FROM user_balance_alerts
+		WHERE auto_topup_enabled
+			OR (jsonb_array_length(thresholds) > 0 AND (email_enabled OR webhook_url <> ''))
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *balanceAlertRepository) ClaimNotification(ctx context.Context, userID int64, threshold float64) (bool, error) {
+	res, err := r.sql.ExecContext(ctx, `
+		UPDATE user_balance_alerts SET notified_threshold = $2, updated_at = NOW()
+		WHERE user_id = $1 AND (notified_threshold IS NULL OR notified_threshold > $2)
+	`, userID, threshold)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *balanceAlertRepository) RearmNotification(ctx context.Context, userID int64, threshold *float64) error {
+	_, err := r.sql.ExecContext(ctx, `
+		UPDATE user_balance_alerts SET notified_threshold = $2, updated_at = NOW()
+		WHERE user_id = $1 AND notified_threshold IS NOT NULL
+			AND ($2::numeric IS NULL OR notified_threshold < $2::numeric)
+	`, userID, nullFloat64(threshold))
+	return err
+}
+
+func (r *balanceAlertRepository) ClaimAutoTopUp(ctx context.Context, userID int64, cooldown time.Duration) (bool, error) {
+	res, err := r.sql.ExecContext(ctx, `
+		UPDATE user_balance_alerts SET auto_topup_last_at = NOW()
+		WHERE user_id = $1 AND auto_topup_enabled
+			AND (auto_topup_last_at IS NULL OR auto_topup_last_at < NOW() - make_interval(secs => $2))
+	`, userID, cooldown.Seconds())
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *balanceAlertRepository) CreateTopUpCode(ctx context.Context, code *service.AutoTopUpCode) error {
+	if code == nil {
+		return nil
+	}
+	err := scanSingleRow(ctx, r.sql, `
+		INSERT INTO user_auto_topup_codes (user_id, redeem_code_id, status)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`, []any{code.UserID, code.RedeemCodeID, code.Status}, &code.ID, &code.CreatedAt)
+	return translatePersistenceError(err, nil, service.ErrAutoTopUpCodeExists)
+}
+
+func (r *balanceAlertRepository) ListTopUpCodes(ctx context.Context, userID int64) ([]service.AutoTopUpCode, error) {
+	return r.queryTopUpCodes(ctx, `SELECT `+autoTopUpCodeColumns+autoTopUpCodeFrom+`
+		WHERE t.user_id = $1
+		ORDER BY CASE t.status WHEN 'pending' THEN 0 ELSE 1 END, t.id`, userID)
+}
+
+func (r *balanceAlertRepository) ListPendingTopUpCodes(ctx context.Context, userID int64, limit int) ([]service.AutoTopUpCode, error) {
+	return r.queryTopUpCodes(ctx, `SELECT `+autoTopUpCodeColumns+autoTopUpCodeFrom+`
+		WHERE t.user_id = $1 AND t.status = $2
+		ORDER BY t.id
+		LIMIT $3`, userID, service.AutoTopUpCodePending, limit)
+}
+
+func (r *balanceAlertRepository) DeletePendingTopUpCode(ctx context.Context, userID, id int64) error {
+	res, err := r.sql.ExecContext(ctx, `
+		DELETE FROM user_auto_topup_codes WHERE id = $1 AND user_id = $2 AND status = $3
+	`, id, userID, service.AutoTopUpCodePending)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, service.ErrAutoTopUpCodeNotFound)
+}
+
+func (r *balanceAlertRepository) UpdateTopUpCodeStatus(ctx context.Context, id int64, status string, errorMessage *string) error {
+	res, err := r.sql.ExecContext(ctx, `
+		UPDATE user_auto_topup_codes
+		SET status = $2, error_message = $3,
+			used_at = CASE WHEN $2 = 'used' THEN NOW() ELSE used_at END
+		WHERE id = $1
+	`, id, status, nullString(errorMessage))
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, service.ErrAutoTopUpCodeNotFound)
+}
+
+func (r *balanceAlertRepository) queryTopUpCodes(ctx context.Context, query string, args ...any) ([]service.AutoTopUpCode, error) {
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []service.AutoTopUpCode
+	for rows.Next() {
+		var (
+			code    service.AutoTopUpCode
+			errMsg  sql.NullString
+			usedAt  sql.NullTime
+			groupID sql.NullInt64
+		)
+		if err := rows.Scan(
+			&code.ID, &code.UserID, &code.RedeemCodeID, &code.Status, &errMsg, &usedAt, &code.CreatedAt,
+			&code.Code, &code.Type, &code.Value, &groupID, &code.ValidityDays,
+		); err != nil {
+			return nil, err
+		}
+		if errMsg.Valid {
+			code.ErrorMessage = &errMsg.String
+		}
+		if usedAt.Valid {
+			t := usedAt.Time
+			code.UsedAt = &t
+		}
+		code.GroupID = nullInt64Ptr(groupID)
+		out = append(out, code)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type balanceTransactionRepository struct {
+	sql sqlExecutor
+}
+
+func NewBalanceTransactionRepository(sqlDB *sql.DB) service.BalanceTransactionRepository {
+	return &balanceTransactionRepository{sql: sqlDB}
+}
+
+const balanceTransactionColumns = `
+	id, user_id, type, amount, balance_after,
+	usage_log_id, redeem_code_id, promo_code_id, admin_user_id, notes, created_at
+`
+
+func (r *balanceTransactionRepository) ListByUser(ctx context.Context, userID int64, txType string, params pagination.PaginationParams) ([]service.BalanceTransaction, *pagination.PaginationResult, error) {
+	where := "user_id = $1"
+	args := []any{userID}
+	if txType != "" {
+		where += " AND type = $2"
+		args = append(args, txType)
+	}
+
+	var total int64
+	if err := scanSingleRow(ctx, r.sql, `SELECT COUNT(*) FROM balance_transactions WHERE `+where, args, &total); err != nil {
+		return nil, nil, err
+	}
+
+	query := `SELECT ` + balanceTransactionColumns + ` FROM balance_transactions WHERE ` + where +
+		` ORDER BY id DESC LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
+	rows, err := r.sql.QueryContext(ctx, query, append(args, params.Limit(), params.Offset())...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]service.BalanceTransaction, 0, params.Limit())
+	for rows.Next() {
+		txn, err := scanBalanceTransaction(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, *txn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return out, paginationResultFromTotal(total, params), nil
+}
+
+func (r *balanceTransactionRepository) FindInconsistencies(ctx context.Context, tolerance float64, limit int) ([]service.BalanceInconsistency, error) {
+	// 单条语句读取同一快照，不会被并发扣费误判
+	query := `
+		SELECT u.id, u.email, u.balance, COALESCE(l.total, 0), COALESCE(l.cnt, 0)
+		FROM users u
+		LEFT JOIN (
+			SELECT user_id, SUM(amount) AS total, COUNT(*) AS cnt
+			FROM balance_transactions
+			GROUP BY user_id
+		) l ON l.user_id = u.id
+		WHERE u.deleted_at IS NULL
+		  AND ABS(u.balance - COALESCE(l.total, 0)) > $1
+		ORDER BY u.id
+		LIMIT $2
+	`
+	rows, err := r.sql.QueryContext(ctx, query, tolerance, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []service.BalanceInconsistency
+	for rows.Next() {
+		var item service.BalanceInconsistency
+		if err := rows.Scan(&item.UserID, &item.Email, &item.Balance, &item.LedgerSum, &item.TxCount); err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, rows.Err()
+}
+
+func scanBalanceTransaction(rows *sql.Rows) (*service.BalanceTransaction, error) {
+	var txn service.BalanceTransaction
+	var usageLogID, redeemCodeID, promoCodeID, adminUserID sql.NullInt64
+	if err := rows.Scan(
+		&txn.ID,
+		&txn.UserID,
+		&txn.Type,
+		&txn.Amount,
+		&txn.BalanceAfter,
+		&usageLogID,
+		&redeemCodeID,
+		&promoCodeID,
+		&adminUserID,
+		&txn.Notes,
+		&txn.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	txn.UsageLogID = nullInt64Ptr(usageLogID)
+	txn.RedeemCodeID = nullInt64Ptr(redeemCodeID)
+	txn.PromoCodeID = nullInt64Ptr(promoCodeID)
+	txn.AdminUserID = nullInt64Ptr(adminUserID)
+	return &txn, nil
+}
+
+func nullInt64Ptr(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	out := v.Int64
+	return &out
+}
@@ -15,6 +15,8 @@ import (
 const (
 	billingBalanceKeyPrefix = "billing:balance:"
 	billingSubKeyPrefix     = "billing:sub:"
+	billingAPIKeyKeyPrefix  = "billing:apikey_used:"
+	billingOrgBalancePrefix = "billing:org_balance:"
 	billingCacheTTL         = 5 * time.Minute
 )
 
@@ -28,6 +30,16 @@ func billingSubKey(userID, groupID int64) string {
 	return fmt.Sprintf("%s%d:%d", billingSubKeyPrefix, userID, groupID)
 }
 
+// billingAPIKeyUsedKey generates the Redis key for API key spend cache.
+func billingAPIKeyUsedKey(apiKeyID int64) string {
+	return fmt.Sprintf("%s%d", billingAPIKeyKeyPrefix, apiKeyID)
+}
+
+// billingOrgBalanceKey generates the Redis key for organization balance cache.
+func billingOrgBalanceKey(orgID int64) string {
+	return fmt.Sprintf("%s%d", billingOrgBalancePrefix, orgID)
+}
+
 const (
 	subFieldStatus       = "status"
 	subFieldExpiresAt    = "expires_at"
@@ -49,6 +61,16 @@ var (
 		return 1
 	`)
 
+	incrAPIKeyUsedScript = redis.NewScript(`
+		local current = redis.call('GET', KEYS[1])
+		if current == false then
+			return 0
+		end
+		redis.call('INCRBYFLOAT', KEYS[1], ARGV[1])
+		redis.call('EXPIRE', KEYS[1], ARGV[2])
+		return 1
+	`)
+
 	updateSubUsageScript = redis.NewScript(`
 		local exists = redis.call('EXISTS', KEYS[1])
 		if exists == 0 then
@@ -99,6 +121,30 @@ func (c *billingCache) InvalidateUserBalance(ctx context.Context, userID int64)
 	return c.rdb.Del(ctx, key).Err()
 }
 
+func (c *billingCache) GetOrganizationBalance(ctx context.Context, orgID int64) (float64, error) {
+	val, err := c.rdb.Get(ctx, billingOrgBalanceKey(orgID)).Result()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(val, 64)
+}
+
+func (c *billingCache) SetOrganizationBalance(ctx context.Context, orgID int64, balance float64) error {
+	return c.rdb.Set(ctx, billingOrgBalanceKey(orgID), balance, billingCacheTTL).Err()
+}
+
+func (c *billingCache) DeductOrganizationBalance(ctx context.Context, orgID int64, amount float64) error {
+	_, err := deductBalanceScript.Run(ctx, c.rdb, []string{billingOrgBalanceKey(orgID)}, amount, int(billingCacheTTL.Seconds())).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		log.Printf("Warning: deduct balance cache failed for organization %d: %v", orgID, err)
+	}
+	return nil
+}
+
+func (c *billingCache) InvalidateOrganizationBalance(ctx context.Context, orgID int64) error {
+	return c.rdb.Del(ctx, billingOrgBalanceKey(orgID)).Err()
+}
+
 func (c *billingCache) GetSubscriptionCache(ctx context.Context, userID, groupID int64) (*service.SubscriptionCacheData, error) {
 	key := billingSubKey(userID, groupID)
 	result, err := c.rdb.HGetAll(ctx, key).Result()
@@ -181,3 +227,23 @@ func (c *billingCache) InvalidateSubscriptionCache(ctx context.Context, userID,
 	key := billingSubKey(userID, groupID)
 	return c.rdb.Del(ctx, key).Err()
 }
+
+func (c *billingCache) GetAPIKeyUsage(ctx context.Context, apiKeyID int64) (float64, error) {
+	val, err := c.rdb.Get(ctx, billingAPIKeyUsedKey(apiKeyID)).Result()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(val, 64)
+}
+
+func (c *billingCache) SetAPIKeyUsage(ctx context.Context, apiKeyID int64, used float64) error {
+	return c.rdb.Set(ctx, billingAPIKeyUsedKey(apiKeyID), used, billingCacheTTL).Err()
+}
+
+func (c *billingCache) IncrementAPIKeyUsage(ctx context.Context, apiKeyID int64, amount float64) error {
+	_, err := incrAPIKeyUsedScript.Run(ctx, c.rdb, []string{billingAPIKeyUsedKey(apiKeyID)}, amount, int(billingCacheTTL.Seconds())).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		log.Printf("Warning: increment api key usage cache failed for api key %d: %v", apiKeyID, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+// 费用预占缓存键：
+//   - billing:reserve:{scope}      ZSET，member=reservationID，score=过期时间（毫秒）
+//   - billing:reserve_amt:{scope}  HASH，field=reservationID，value=预占金额
+//
+// 过期的预占在每次 Reserve/GetReserved 时惰性清理，两个键的 TTL 随最新预占续期，
+// 进程崩溃遗留的预占最迟在 TTL 后失效。
+const (
+	billingReserveKeyPrefix    = "billing:reserve:"
+	billingReserveAmtKeyPrefix = "billing:reserve_amt:"
+)
+
+func billingReserveKey(scope string) string {
+	return billingReserveKeyPrefix + scope
+}
+
+func billingReserveAmtKey(scope string) string {
+	return billingReserveAmtKeyPrefix + scope
+}
+
+var (
+	// reserveBillingScript 清理过期预占后按策略判定并写入预占
+	// KEYS[1] = 预占 ZSET，KEYS[2] = 金额 HASH
+	// ARGV[1] = reservationID，ARGV[2] = 金额，ARGV[3] = 可用额度，ARGV[4] = strict(1/0)，ARGV[5] = TTL(毫秒)
+	// 返回 {1|0, 当前预占总额}（金额以字符串返回，避免 Lua number 转整数截断）
+	reserveBillingScript = redis.NewScript(`
+		local t = redis.call('TIME')
+		local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+		local expired = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', now)
+		for _, id in ipairs(expired) do
+			redis.call('HDEL', KEYS[2], id)
+		end
+		redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+
+		local outstanding = 0
+		local vals = redis.call('HVALS', KEYS[2])
+		for _, v in ipairs(vals) do
+			outstanding = outstanding + tonumber(v)
+		end
+
+		local amount = tonumber(ARGV[2])
+		local available = tonumber(ARGV[3])
+		if ARGV[4] == '1' then
+			if outstanding + amount > available then
+				return {0, tostring(outstanding)}
+			end
+		elseif outstanding >= available then
+			return {0, tostring(outstanding)}
+		end
+
+		local ttl = tonumber(ARGV[5])
+		redis.call('ZADD', KEYS[1], now + ttl, ARGV[1])
+		redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+		redis.call('PEXPIRE', KEYS[1], ttl)
+		redis.call('PEXPIRE', KEYS[2], ttl)
+		return {1, tostring(outstanding + amount)}
+	`)
+
+	// releaseBillingScript 释放单个预占
+	releaseBillingScript = redis.NewScript(`
+		redis.call('ZREM', KEYS[1], ARGV[1])
+		redis.call('HDEL', KEYS[2], ARGV[1])
+		return 1
+	`)
+
+	// reservedBillingScript 清理过期预占后返回预占总额
+	reservedBillingScript = redis.NewScript(`
+		local t = redis.call('TIME')
+		local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+		local expired = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', now)
+		for _, id in ipairs(expired) do
+			redis.call('HDEL', KEYS[2], id)
+		end
+		redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+
+		local outstanding = 0
+		local vals = redis.call('HVALS', KEYS[2])
+		for _, v in ipairs(vals) do
+			outstanding = outstanding + tonumber(v)
+		end
+		return tostring(outstanding)
+	`)
+)
+
+type billingReservationCache struct {
+	rdb *redis.Client
+}
+
+// NewBillingReservationCache 创建基于 Redis 的请求前费用预占缓存
+func NewBillingReservationCache(rdb *redis.Client) service.BillingReservationCache {
+	return &billingReservationCache{rdb: rdb}
+}
+
+func (c *billingReservationCache) Reserve(ctx context.Context, scope, reservationID string, amount, available float64, strict bool, ttl time.Duration) (bool, float64, error) {
+	strictArg := "0"
+	if strict {
+		strictArg = "1"
+	}
+	ttlMs := ttl.Milliseconds()
+	if ttlMs <= 0 {
+		ttlMs = 1
+	}
+	keys := []string{billingReserveKey(scope), billingReserveAmtKey(scope)}
+	res, err := reserveBillingScript.Run(ctx, c.rdb, keys,
+		reservationID,
+		strconv.FormatFloat(amount, 'f', -1, 64),
+		strconv.FormatFloat(available, 'f', -1, 64),
+		strictArg,
+		ttlMs,
+	).Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("reserve billing %s: %w", scope, err)
+	}
+	if len(res) != 2 {
+		return false, 0, fmt.Errorf("reserve billing %s: unexpected result %v", scope, res)
+	}
+	ok, _ := res[0].(int64)
+	outstanding, err := parseLuaFloat(res[1])
+	if err != nil {
+		return false, 0, fmt.Errorf("reserve billing %s: %w", scope, err)
+	}
+	return ok == 1, outstanding, nil
+}
+
+func (c *billingReservationCache) Release(ctx context.Context, scope, reservationID string) error {
+	keys := []string{billingReserveKey(scope), billingReserveAmtKey(scope)}
+	if err := releaseBillingScript.Run(ctx, c.rdb, keys, reservationID).Err(); err != nil {
+		return fmt.Errorf("release billing reservation %s: %w", scope, err)
+	}
+	return nil
+}
+
+func (c *billingReservationCache) GetReserved(ctx context.Context, scope string) (float64, error) {
+	keys := []string{billingReserveKey(scope), billingReserveAmtKey(scope)}
+	res, err := reservedBillingScript.Run(ctx, c.rdb, keys).Result()
+	if err != nil {
+		return 0, fmt.Errorf("get billing reservation %s: %w", scope, err)
+	}
+	return parseLuaFloat(res)
+}
+
+// parseLuaFloat 解析 Lua 脚本以字符串返回的浮点数
+func parseLuaFloat(v any) (float64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected lua value %T", v)
+	}
+	return strconv.ParseFloat(s, 64)
+}
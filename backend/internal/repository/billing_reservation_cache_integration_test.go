@@ -0,0 +1,71 @@
+//go:build integration
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BillingReservationCacheSuite struct {
+	IntegrationRedisSuite
+	cache service.BillingReservationCache
+}
+
+func (s *BillingReservationCacheSuite) SetupTest() {
+	s.IntegrationRedisSuite.SetupTest()
+	s.cache = NewBillingReservationCache(s.rdb)
+}
+
+func (s *BillingReservationCacheSuite) TestNonStrictAdmitsUntilOutstandingReachesAvailable() {
+	ok, outstanding, err := s.cache.Reserve(s.ctx, "user:1", "r1", 0.6, 1.0, false, time.Minute)
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	require.InDelta(s.T(), 0.6, outstanding, 1e-9)
+
+	// 已预占 0.6 < 1.0，允许透支一个请求
+	ok, outstanding, err = s.cache.Reserve(s.ctx, "user:1", "r2", 0.6, 1.0, false, time.Minute)
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+	require.InDelta(s.T(), 1.2, outstanding, 1e-9)
+
+	ok, _, err = s.cache.Reserve(s.ctx, "user:1", "r3", 0.01, 1.0, false, time.Minute)
+	require.NoError(s.T(), err)
+	require.False(s.T(), ok)
+
+	require.NoError(s.T(), s.cache.Release(s.ctx, "user:1", "r1"))
+	require.NoError(s.T(), s.cache.Release(s.ctx, "user:1", "r1"), "release is idempotent")
+	reserved, err := s.cache.GetReserved(s.ctx, "user:1")
+	require.NoError(s.T(), err)
+	require.InDelta(s.T(), 0.6, reserved, 1e-9)
+}
+
+func (s *BillingReservationCacheSuite) TestStrictRequiresHeadroom() {
+	ok, _, err := s.cache.Reserve(s.ctx, "sub:1:2", "r1", 1.5, 1.0, true, time.Minute)
+	require.NoError(s.T(), err)
+	require.False(s.T(), ok)
+
+	ok, _, err = s.cache.Reserve(s.ctx, "sub:1:2", "r2", 0.4, 1.0, true, time.Minute)
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+}
+
+func (s *BillingReservationCacheSuite) TestExpiredReservationsArePruned() {
+	ok, _, err := s.cache.Reserve(s.ctx, "user:9", "stale", 5, 10, false, 50*time.Millisecond)
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+
+	time.Sleep(120 * time.Millisecond)
+
+	reserved, err := s.cache.GetReserved(s.ctx, "user:9")
+	require.NoError(s.T(), err)
+	require.Zero(s.T(), reserved)
+}
+
+func TestBillingReservationCacheSuite(t *testing.T) {
+	suite.Run(t, new(BillingReservationCacheSuite))
+}
@@ -76,6 +76,54 @@ var (
 		return 0
 	`)
 
+	// acquireAccountSlotScript 账号槽位获取（感知公平排队队列）
+	// 与 acquireScript 相同，但公平排队队列非空时只能使用超出排队人数的空闲槽位，
+	// 避免新请求越过正在排队的请求（失联排队者先被清理）
+	// KEYS[1] = concurrency:account:{id}, KEYS[2] = queue, KEYS[3] = hb, KEYS[4] = meta
+	// ARGV[1] = maxConcurrency, ARGV[2] = TTL（秒）, ARGV[3] = requestID, ARGV[4] = 排队者失联阈值（毫秒）
+	acquireAccountSlotScript = redis.NewScript(`
+		local key = KEYS[1]
+		local maxConcurrency = tonumber(ARGV[1])
+		local ttl = tonumber(ARGV[2])
+		local requestID = ARGV[3]
+
+		local timeResult = redis.call('TIME')
+		local now = tonumber(timeResult[1])
+		local nowMs = now * 1000 + math.floor(tonumber(timeResult[2]) / 1000)
+
+		redis.call('ZREMRANGEBYSCORE', key, '-inf', now - ttl)
+
+		local exists = redis.call('ZSCORE', key, requestID)
+		if exists ~= false then
+			redis.call('ZADD', key, now, requestID)
+			redis.call('EXPIRE', key, ttl)
+			return 1
+		end
+
+		local queued = 0
+		if redis.call('EXISTS', KEYS[2]) == 1 then
+			local stale = redis.call('ZRANGEBYSCORE', KEYS[3], '-inf', nowMs - tonumber(ARGV[4]))
+			for _, id in ipairs(stale) do
+				redis.call('ZREM', KEYS[2], id)
+				redis.call('ZREM', KEYS[3], id)
+				redis.call('HDEL', KEYS[4], 't:' .. id)
+			end
+			queued = redis.call('ZCARD', KEYS[2])
+			if queued == 0 then
+				redis.call('DEL', KEYS[3], KEYS[4])
+			end
+		end
+
+		local count = redis.call('ZCARD', key)
+		if count + queued < maxConcurrency then
+			redis.call('ZADD', key, now, requestID)
+			redis.call('EXPIRE', key, ttl)
+			return 1
+		end
+
+		return 0
+	`)
+
 	// getCountScript 统计有序集合中的槽位数量并清理过期条目
 	// 使用 Redis TIME 命令获取服务器时间
 	// KEYS[1] = 有序集合键
@@ -250,9 +298,9 @@ func accountWaitKey(accountID int64) string {
 // Account slot operations
 
 func (c *concurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
-	key := accountSlotKey(accountID)
+	keys := []string{accountSlotKey(accountID), slotQueueKey(accountID), slotQueueHBKey(accountID), slotQueueMetaKey(accountID)}
 	// 时间戳在 Lua 脚本内使用 Redis TIME 命令获取，确保多实例时钟一致
-	result, err := acquireScript.Run(ctx, c.rdb, []string{key}, maxConcurrency, c.slotTTLSeconds, requestID).Int()
+	result, err := acquireAccountSlotScript.Run(ctx, c.rdb, keys, maxConcurrency, c.slotTTLSeconds, requestID, slotQueueStaleMs).Int()
 	if err != nil {
 		return false, err
 	}
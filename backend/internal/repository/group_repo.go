@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"log"
 
@@ -50,12 +51,37 @@ func (r *groupRepository) Create(ctx context.Context, groupIn *service.Group) er
 		SetDefaultValidityDays(groupIn.DefaultValidityDays).
 		SetClaudeCodeOnly(groupIn.ClaudeCodeOnly).
 		SetNillableFallbackGroupID(groupIn.FallbackGroupID).
-		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled)
+		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled).
+		SetNillableDefaultRpmLimit(groupIn.DefaultRPMLimit).
+		SetNillableDefaultTpmLimit(groupIn.DefaultTPMLimit).
+		SetResponseCacheEnabled(groupIn.ResponseCacheEnabled).
+		SetNillableResponseCacheTTLSeconds(groupIn.ResponseCacheTTLSeconds).
+		SetResponseCacheRate(groupIn.ResponseCacheRate).
+		SetSchedulingStrategy(groupIn.SchedulingStrategy).
+		SetHedgeDelayMs(groupIn.HedgeDelayMs).
+		SetQueueWeight(groupIn.QueueWeight)
 
 	// 设置模型路由配置
 	if groupIn.ModelRouting != nil {
 		builder = builder.SetModelRouting(groupIn.ModelRouting)
 	}
+	if len(groupIn.ModelFallbacks) > 0 {
+		raw, err := json.Marshal(groupIn.ModelFallbacks)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetModelFallbacks(raw)
+	}
+	if groupIn.Guardrails != nil {
+		raw, err := json.Marshal(groupIn.Guardrails)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetGuardrails(raw)
+	}
+	if len(groupIn.SchedulingWeights) > 0 {
+		builder = builder.SetSchedulingWeights(groupIn.SchedulingWeights)
+	}
 
 	created, err := builder.Save(ctx)
 	if err == nil {
@@ -117,6 +143,28 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 		builder = builder.ClearFallbackGroupID()
 	}
 
+	// 处理默认速率限制：nil 时清除，否则设置
+	if groupIn.DefaultRPMLimit != nil {
+		builder = builder.SetDefaultRpmLimit(*groupIn.DefaultRPMLimit)
+	} else {
+		builder = builder.ClearDefaultRpmLimit()
+	}
+	if groupIn.DefaultTPMLimit != nil {
+		builder = builder.SetDefaultTpmLimit(*groupIn.DefaultTPMLimit)
+	} else {
+		builder = builder.ClearDefaultTpmLimit()
+	}
+
+	// 响应缓存：TTL 为 nil 时清除（使用全局默认值）
+	builder = builder.
+		SetResponseCacheEnabled(groupIn.ResponseCacheEnabled).
+		SetResponseCacheRate(groupIn.ResponseCacheRate)
+	if groupIn.ResponseCacheTTLSeconds != nil {
+		builder = builder.SetResponseCacheTTLSeconds(*groupIn.ResponseCacheTTLSeconds)
+	} else {
+		builder = builder.ClearResponseCacheTTLSeconds()
+	}
+
 	// 处理 ModelRouting：nil 时清除，否则设置
 	if groupIn.ModelRouting != nil {
 		builder = builder.SetModelRouting(groupIn.ModelRouting)
@@ -124,6 +172,38 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 		builder = builder.ClearModelRouting()
 	}
 
+	// 处理 ModelFallbacks：为空时清除，否则设置
+	if len(groupIn.ModelFallbacks) > 0 {
+		raw, err := json.Marshal(groupIn.ModelFallbacks)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetModelFallbacks(raw)
+	} else {
+		builder = builder.ClearModelFallbacks()
+	}
+
+	// 处理 Guardrails：nil 时清除，否则设置
+	if groupIn.Guardrails != nil {
+		raw, err := json.Marshal(groupIn.Guardrails)
+		if err != nil {
+			return err
+		}
+		builder = builder.SetGuardrails(raw)
+	} else {
+		builder = builder.ClearGuardrails()
+	}
+
+	// 调度策略：权重为空时清除
+	builder = builder.SetSchedulingStrategy(groupIn.SchedulingStrategy)
+	builder = builder.SetHedgeDelayMs(groupIn.HedgeDelayMs)
+	builder = builder.SetQueueWeight(groupIn.QueueWeight)
+	if len(groupIn.SchedulingWeights) > 0 {
+		builder = builder.SetSchedulingWeights(groupIn.SchedulingWeights)
+	} else {
+		builder = builder.ClearSchedulingWeights()
+	}
+
 	updated, err := builder.Save(ctx)
 	if err != nil {
 		return translatePersistenceError(err, service.ErrGroupNotFound, service.ErrGroupExists)
@@ -0,0 +1,384 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type invoiceRepository struct {
+	sql sqlExecutor
+}
+
+func NewInvoiceRepository(sqlDB *sql.DB) service.InvoiceRepository {
+	return &invoiceRepository{sql: sqlDB}
+}
+
+const invoiceColumns = `
+	i.id, i.user_id, i.period_start, i.period_end, i.status, i.currency,
+	i.opening_balance, i.closing_balance, i.usage_charges, i.subscription_usage, i.credits, i.adjustments,
+	i.line_items, i.revision, i.generated_at, i.voided_at, i.voided_by, i.void_reason, i.created_at, i.updated_at,
+	COALESCE(u.email, ''), COALESCE(u.username, '')
+`
+
+const invoiceFrom = ` FROM invoices i LEFT JOIN users u ON u.id = i.user_id`
+
+func (r *invoiceRepository) Create(ctx context.Context, invoice *service.Invoice) error {
+	if invoice == nil {
+		return nil
+	}
+	query := `
+		INSERT INTO invoices (
+			user_id, period_start, period_end, status, currency,
+			opening_balance, closing_balance, usage_charges, subscription_usage, credits, adjustments,
+			line_items, revision, generated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, created_at, updated_at
+	`
+	lineItems, err := marshalInvoiceLineItems(invoice.LineItems)
+	if err != nil {
+		return err
+	}
+	args := []any{
+		invoice.UserID,
+		invoice.PeriodStart,
+		invoice.PeriodEnd,
+		invoice.Status,
+		invoice.Currency,
+		invoice.OpeningBalance,
+		invoice.ClosingBalance,
+		invoice.UsageCharges,
+		invoice.SubscriptionUsage,
+		invoice.Credits,
+		invoice.Adjustments,
+		lineItems,
+		invoice.Revision,
+		invoice.GeneratedAt,
+	}
+	err = scanSingleRow(ctx, r.sql, query, args, &invoice.ID, &invoice.CreatedAt, &invoice.UpdatedAt)
+	return translatePersistenceError(err, nil, service.ErrInvoiceExists)
+}
+
+func (r *invoiceRepository) Update(ctx context.Context, invoice *service.Invoice) error {
+	if invoice == nil {
+		return nil
+	}
+	// 仅更新未作废的账单；并发作废时返回 ErrInvoiceVoided 而不是覆盖
+	query := `
+		UPDATE invoices
+		SET opening_balance = $2, closing_balance = $3, usage_charges = $4, subscription_usage = $5,
+			credits = $6, adjustments = $7, line_items = $8, revision = $9, generated_at = $10, updated_at = NOW()
+		WHERE id = $1 AND status <> 'void'
+		RETURNING updated_at
+	`
+	lineItems, err := marshalInvoiceLineItems(invoice.LineItems)
+	if err != nil {
+		return err
+	}
+	args := []any{
+		invoice.ID,
+		invoice.OpeningBalance,
+		invoice.ClosingBalance,
+		invoice.UsageCharges,
+		invoice.SubscriptionUsage,
+		invoice.Credits,
+		invoice.Adjustments,
+		lineItems,
+		invoice.Revision,
+		invoice.GeneratedAt,
+	}
+	err = scanSingleRow(ctx, r.sql, query, args, &invoice.UpdatedAt)
+	return translatePersistenceError(err, service.ErrInvoiceVoided, nil)
+}
+
+func (r *invoiceRepository) GetByID(ctx context.Context, id int64) (*service.Invoice, error) {
+	invoices, err := r.query(ctx, `SELECT `+invoiceColumns+invoiceFrom+` WHERE i.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return nil, service.ErrInvoiceNotFound
+	}
+	return &invoices[0], nil
+}
+
+func (r *invoiceRepository) List(ctx context.Context, filter service.InvoiceFilter, params pagination.PaginationParams) ([]service.Invoice, *pagination.PaginationResult, error) {
+	where, args := buildInvoiceWhere(filter)
+
+	var total int64
+	if err := scanSingleRow(ctx, r.sql, `SELECT COUNT(*) FROM invoices i`+where, args, &total); err != nil {
+		return nil, nil, err
+	}
+
+	query := `SELECT ` + invoiceColumns + invoiceFrom + where +
+		` ORDER BY i.period_start DESC, i.id DESC LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
+	invoices, err := r.query(ctx, query, append(args, params.Limit(), params.Offset())...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return invoices, paginationResultFromTotal(total, params), nil
+}
+
+func (r *invoiceRepository) Void(ctx context.Context, id int64, adminID int64, reason string) error {
+	res, err := r.sql.ExecContext(ctx, `
+		UPDATE invoices
+		SET status = 'void', voided_at = NOW(), voided_by = $2, void_reason = $3, updated_at = NOW()
+		WHERE id = $1 AND status <> 'void'
+	`, id, adminID, reason)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return service.ErrInvoiceVoided
+}
+
+func (r *invoiceRepository) LoadSource(ctx context.Context, userID int64, start, end time.Time) (*service.InvoiceSource, error) {
+	src := &service.InvoiceSource{}
+
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT ul.model, ul.group_id, COALESCE(g.name, ''), ul.billing_type,
+			COUNT(*),
+			COALESCE(SUM(ul.input_tokens), 0),
+			COALESCE(SUM(ul.output_tokens), 0),
+			COALESCE(SUM(ul.cache_creation_tokens + ul.cache_read_tokens), 0),
+			COALESCE(SUM(ul.total_cost), 0),
+			COALESCE(SUM(ul.actual_cost), 0)
+		FROM usage_logs ul
+		LEFT JOIN groups g ON g.id = ul.group_id
+		WHERE ul.user_id = $1 AND ul.created_at >= $2 AND ul.created_at < $3 AND ul.organization_id IS NULL
+		GROUP BY ul.model, ul.group_id, g.name, ul.billing_type
+		ORDER BY ul.model, ul.group_id NULLS FIRST, ul.billing_type
+	`, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var (
+			row     service.InvoiceUsageRow
+			groupID sql.NullInt64
+		)
+		if err := rows.Scan(&row.Model, &groupID, &row.GroupName, &row.BillingType, &row.Requests,
+			&row.InputTokens, &row.OutputTokens, &row.CacheTokens, &row.TotalCost, &row.ActualCost); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		row.GroupID = nullInt64Ptr(groupID)
+		src.Usage = append(src.Usage, row)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.sql.QueryContext(ctx, `SELECT `+balanceTransactionColumns+` FROM balance_transactions
+		WHERE user_id = $1 AND created_at >= $2 AND created_at < $3 AND type <> $4
+		ORDER BY created_at, id`, userID, start, end, service.BalanceTxTypeUsage)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		txn, err := scanBalanceTransaction(rows)
+		if err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		src.Transactions = append(src.Transactions, *txn)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.sql.QueryContext(ctx, `
+		SELECT us.id, us.group_id, COALESCE(g.name, ''), us.starts_at, us.expires_at, us.assigned_at, COALESCE(us.notes, '')
+		FROM user_subscriptions us
+		LEFT JOIN groups g ON g.id = us.group_id
+		WHERE us.user_id = $1 AND us.assigned_at >= $2 AND us.assigned_at < $3 AND us.deleted_at IS NULL
+		ORDER BY us.assigned_at, us.id
+	`, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var sub service.InvoiceSubscriptionRow
+		if err := rows.Scan(&sub.SubscriptionID, &sub.GroupID, &sub.GroupName, &sub.StartsAt, &sub.ExpiresAt, &sub.AssignedAt, &sub.Notes); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		src.Subscriptions = append(src.Subscriptions, sub)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+
+	// 期初/期末余额取对应时点前最后一条流水的 balance_after
+	if src.OpeningBalance, err = r.balanceBefore(ctx, userID, start); err != nil {
+		return nil, err
+	}
+	if src.ClosingBalance, err = r.balanceBefore(ctx, userID, end); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+func (r *invoiceRepository) balanceBefore(ctx context.Context, userID int64, at time.Time) (*float64, error) {
+	var balance sql.NullFloat64
+	err := scanSingleRow(ctx, r.sql, `
+		SELECT balance_after FROM balance_transactions
+		WHERE user_id = $1 AND created_at < $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`, []any{userID, at}, &balance)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nullFloat64Ptr(balance), nil
+}
+
+func (r *invoiceRepository) ListBillableUserIDs(ctx context.Context, start, end time.Time) ([]int64, error) {
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT a.user_id FROM (
+			SELECT DISTINCT user_id FROM usage_logs WHERE created_at >= $1 AND created_at < $2 AND organization_id IS NULL
+			UNION
+			SELECT DISTINCT user_id FROM balance_transactions WHERE created_at >= $1 AND created_at < $2 AND type <> $3
+			UNION
+			SELECT DISTINCT user_id FROM user_subscriptions WHERE assigned_at >= $1 AND assigned_at < $2 AND deleted_at IS NULL
+		) a
+		JOIN users u ON u.id = a.user_id AND u.deleted_at IS NULL
+		WHERE NOT EXISTS (
+			SELECT 1 FROM invoices i WHERE i.user_id = a.user_id AND i.period_start = $1 AND i.status <> 'void'
+		)
+		ORDER BY a.user_id
+	`, start, end, service.BalanceTxTypeUsage)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *invoiceRepository) query(ctx context.Context, query string, args ...any) ([]service.Invoice, error) {
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []service.Invoice{}
+	for rows.Next() {
+		var (
+			invoice   service.Invoice
+			lineItems []byte
+			voidedAt  sql.NullTime
+			voidedBy  sql.NullInt64
+			email     string
+			username  string
+		)
+		if err := rows.Scan(
+			&invoice.ID,
+			&invoice.UserID,
+			&invoice.PeriodStart,
+			&invoice.PeriodEnd,
+			&invoice.Status,
+			&invoice.Currency,
+			&invoice.OpeningBalance,
+			&invoice.ClosingBalance,
+			&invoice.UsageCharges,
+			&invoice.SubscriptionUsage,
+			&invoice.Credits,
+			&invoice.Adjustments,
+			&lineItems,
+			&invoice.Revision,
+			&invoice.GeneratedAt,
+			&voidedAt,
+			&voidedBy,
+			&invoice.VoidReason,
+			&invoice.CreatedAt,
+			&invoice.UpdatedAt,
+			&email,
+			&username,
+		); err != nil {
+			return nil, err
+		}
+		if voidedAt.Valid {
+			t := voidedAt.Time
+			invoice.VoidedAt = &t
+		}
+		invoice.VoidedBy = nullInt64Ptr(voidedBy)
+		invoice.LineItems = []service.InvoiceLineItem{}
+		if len(lineItems) > 0 {
+			if err := json.Unmarshal(lineItems, &invoice.LineItems); err != nil {
+				return nil, err
+			}
+		}
+		if email != "" || username != "" {
+			invoice.User = &service.User{ID: invoice.UserID, Email: email, Username: username}
+		}
+		out = append(out, invoice)
+	}
+	return out, rows.Err()
+}
+
+// marshalInvoiceLineItems line_items 列非空，无明细时写入空数组
+func marshalInvoiceLineItems(items []service.InvoiceLineItem) ([]byte, error) {
+	if items == nil {
+		items = []service.InvoiceLineItem{}
+	}
+	return json.Marshal(items)
+}
+
+func buildInvoiceWhere(filter service.InvoiceFilter) (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		conds = append(conds, "i.user_id = $"+itoa(len(args)))
+	}
+	if period := strings.TrimSpace(filter.Period); period != "" {
+		args = append(args, period)
+		conds = append(conds, "to_char(i.period_start AT TIME ZONE current_setting('TimeZone'), 'YYYY-MM') = $"+itoa(len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conds = append(conds, "i.status = $"+itoa(len(args)))
+	}
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func closeRows(rows *sql.Rows) error {
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return err
+	}
+	return rows.Close()
+}
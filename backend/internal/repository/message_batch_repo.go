@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type messageBatchRepository struct {
+	sql sqlExecutor
+}
+
+func NewMessageBatchRepository(sqlDB *sql.DB) service.MessageBatchRepository {
+	return &messageBatchRepository{sql: sqlDB}
+}
+
+const messageBatchColumns = `
+	id, batch_id, user_id, api_key_id, group_id, account_id,
+	processing_status, results_url, payload,
+	expires_at, last_polled_at, settled_at, settled_requests, error_message,
+	created_at, updated_at
+`
+
+func (r *messageBatchRepository) Create(ctx context.Context, batch *service.MessageBatch) error {
+	if batch == nil {
+		return nil
+	}
+	query := `
+		INSERT INTO message_batches (
+			batch_id, user_id, api_key_id, group_id, account_id,
+			processing_status, results_url, payload, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`
+	args := []any{
+		batch.BatchID,
+		batch.UserID,
+		batch.APIKeyID,
+		nullInt64(batch.GroupID),
+		batch.AccountID,
+		batch.ProcessingStatus,
+		nullString(batch.ResultsURL),
+		messageBatchPayloadArg(batch.Payload),
+		messageBatchTimeArg(batch.ExpiresAt),
+	}
+	return scanSingleRow(ctx, r.sql, query, args, &batch.ID, &batch.CreatedAt, &batch.UpdatedAt)
+}
+
+func (r *messageBatchRepository) GetByBatchID(ctx context.Context, batchID string) (*service.MessageBatch, error) {
+	query := `SELECT ` + messageBatchColumns + ` FROM message_batches WHERE batch_id = $1`
+	rows, err := r.sql.QueryContext(ctx, query, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, service.ErrMessageBatchNotFound
+	}
+	batch, err := scanMessageBatch(rows)
+	if err != nil {
+		return nil, err
+	}
+	return batch, rows.Err()
+}
+
+func (r *messageBatchRepository) ListByAPIKey(ctx context.Context, apiKeyID int64, params service.MessageBatchListParams) ([]service.MessageBatch, bool, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	// 默认按 id 倒序（最新在前）；before_id 取游标之前（更新）的记录，需升序查询后反转
+	where := "api_key_id = $1"
+	order := "id DESC"
+	args := []any{apiKeyID}
+	reverse := false
+	switch {
+	case params.AfterID != "":
+		args = append(args, params.AfterID)
+		where += fmt.Sprintf(" AND id < (SELECT id FROM message_batches WHERE batch_id = $%d)", len(args))
+	case params.BeforeID != "":
+		args = append(args, params.BeforeID)
+		where += fmt.Sprintf(" AND id > (SELECT id FROM message_batches WHERE batch_id = $%d)", len(args))
+		order = "id ASC"
+		reverse = true
+	}
+	args = append(args, limit+1)
+	query := fmt.Sprintf(`SELECT %s FROM message_batches WHERE %s ORDER BY %s LIMIT $%d`, messageBatchColumns, where, order, len(args))
+
+	batches, err := r.queryBatches(ctx, query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	hasMore := len(batches) > limit
+	if hasMore {
+		batches = batches[:limit]
+	}
+	if reverse {
+		for i, j := 0, len(batches)-1; i < j; i, j = i+1, j-1 {
+			batches[i], batches[j] = batches[j], batches[i]
+		}
+	}
+	return batches, hasMore, nil
+}
+
+func (r *messageBatchRepository) UpdateState(ctx context.Context, batchID, status string, resultsURL *string, payload []byte) error {
+	_, err := r.sql.ExecContext(ctx, `
+		UPDATE message_batches
+		SET processing_status = $2,
+			results_url = COALESCE($3, results_url),
+			payload = COALESCE($4, payload),
+			updated_at = NOW()
+		WHERE batch_id = $1
+	`, batchID, status, nullString(resultsURL), messageBatchPayloadArg(payload))
+	return err
+}
+
+func (r *messageBatchRepository) ListUnsettled(ctx context.Context, limit int) ([]service.MessageBatch, error) {
+	query := `SELECT ` + messageBatchColumns + `
+		FROM message_batches
+		WHERE settled_at IS NULL
+		ORDER BY last_polled_at NULLS FIRST, id ASC
+		LIMIT $1`
+	return r.queryBatches(ctx, query, limit)
+}
+
+func (r *messageBatchRepository) MarkPolled(ctx context.Context, batchID string, errMsg *string) error {
+	_, err := r.sql.ExecContext(ctx, `
+		UPDATE message_batches
+		SET last_polled_at = NOW(), error_message = $2, updated_at = NOW()
+		WHERE batch_id = $1
+	`, batchID, nullString(errMsg))
+	return err
+}
+
+func (r *messageBatchRepository) MarkSettled(ctx context.Context, batchID string, settledRequests int) error {
+	_, err := r.sql.ExecContext(ctx, `
+		UPDATE message_batches
+		SET settled_at = NOW(), last_polled_at = NOW(), settled_requests = $2,
+			error_message = NULL, updated_at = NOW()
+		WHERE batch_id = $1
+	`, batchID, settledRequests)
+	return err
+}
+
+func (r *messageBatchRepository) queryBatches(ctx context.Context, query string, args ...any) ([]service.MessageBatch, error) {
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	batches := make([]service.MessageBatch, 0)
+	for rows.Next() {
+		batch, err := scanMessageBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, *batch)
+	}
+	return batches, rows.Err()
+}
+
+// messageBatchPayloadArg JSONB 参数按文本传递，空值写入 NULL
+func messageBatchPayloadArg(payload []byte) any {
+	if len(payload) == 0 {
+		return nil
+	}
+	return string(payload)
+}
+
+func messageBatchTimeArg(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+func scanMessageBatch(rows *sql.Rows) (*service.MessageBatch, error) {
+	var batch service.MessageBatch
+	var groupID sql.NullInt64
+	var resultsURL, errMsg sql.NullString
+	var expiresAt, lastPolledAt, settledAt sql.NullTime
+	if err := rows.Scan(
+		&batch.ID,
+		&batch.BatchID,
+		&batch.UserID,
+		&batch.APIKeyID,
+		&groupID,
+		&batch.AccountID,
+		&batch.ProcessingStatus,
+		&resultsURL,
+		&batch.Payload,
+		&expiresAt,
+		&lastPolledAt,
+		&settledAt,
+		&batch.SettledRequests,
+		&errMsg,
+		&batch.CreatedAt,
+		&batch.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, service.ErrMessageBatchNotFound
+		}
+		return nil, err
+	}
+	if groupID.Valid {
+		v := groupID.Int64
+		batch.GroupID = &v
+	}
+	if resultsURL.Valid {
+		v := resultsURL.String
+		batch.ResultsURL = &v
+	}
+	if errMsg.Valid {
+		v := errMsg.String
+		batch.ErrorMessage = &v
+	}
+	if expiresAt.Valid {
+		v := expiresAt.Time
+		batch.ExpiresAt = &v
+	}
+	if lastPolledAt.Valid {
+		v := lastPolledAt.Time
+		batch.LastPolledAt = &v
+	}
+	if settledAt.Valid {
+		v := settledAt.Time
+		batch.SettledAt = &v
+	}
+	return &batch, nil
+}
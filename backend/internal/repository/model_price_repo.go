@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type modelPriceRepository struct {
+	sql sqlExecutor
+}
+
+func NewModelPriceRepository(sqlDB *sql.DB) service.ModelPriceRepository {
+	return &modelPriceRepository{sql: sqlDB}
+}
+
+const modelPriceColumns = `
+	id, model, group_id, input_price, output_price, cache_write_price, cache_read_price,
+	image_price, cache_write_1h_price, tiers, effective_from, notes, created_at, updated_at
+`
+
+func (r *modelPriceRepository) List(ctx context.Context, filter service.ModelPriceFilter, params pagination.PaginationParams) ([]service.ModelPrice, *pagination.PaginationResult, error) {
+	where, args := buildModelPriceWhere(filter)
+
+	var total int64
+	if err := scanSingleRow(ctx, r.sql, `SELECT COUNT(*) FROM model_prices`+where, args, &total); err != nil {
+		return nil, nil, err
+	}
+
+	query := `SELECT ` + modelPriceColumns + ` FROM model_prices` + where +
+		` ORDER BY model, group_id NULLS FIRST, effective_from DESC LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
+	prices, err := r.query(ctx, query, append(args, params.Limit(), params.Offset())...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return prices, paginationResultFromTotal(total, params), nil
+}
+
+func (r *modelPriceRepository) ListAll(ctx context.Context) ([]service.ModelPrice, error) {
+	return r.query(ctx, `SELECT `+modelPriceColumns+` FROM model_prices ORDER BY id`)
+}
+
+func (r *modelPriceRepository) GetByID(ctx context.Context, id int64) (*service.ModelPrice, error) {
+	prices, err := r.query(ctx, `SELECT `+modelPriceColumns+` FROM model_prices WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(prices) == 0 {
+		return nil, service.ErrModelPriceNotFound
+	}
+	return &prices[0], nil
+}
+
+func (r *modelPriceRepository) Create(ctx context.Context, price *service.ModelPrice) error {
+	if price == nil {
+		return nil
+	}
+	query := `
+		INSERT INTO model_prices (
+			model, group_id, input_price, output_price, cache_write_price, cache_read_price,
+			image_price, cache_write_1h_price, tiers, effective_from, notes
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at
+	`
+	args, err := modelPriceArgs(price)
+	if err != nil {
+		return err
+	}
+	err = scanSingleRow(ctx, r.sql, query, args, &price.ID, &price.CreatedAt, &price.UpdatedAt)
+	return translatePersistenceError(err, nil, service.ErrModelPriceExists)
+}
+
+func (r *modelPriceRepository) Update(ctx context.Context, price *service.ModelPrice) error {
+	if price == nil {
+		return nil
+	}
+	query := `
+		UPDATE model_prices
+		SET input_price = $2, output_price = $3, cache_write_price = $4, cache_read_price = $5,
+			image_price = $6, cache_write_1h_price = $7, tiers = $8, effective_from = $9, notes = $10, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+	tiers, err := marshalModelPriceTiers(price.Tiers)
+	if err != nil {
+		return err
+	}
+	args := []any{
+		price.ID,
+		price.InputPrice,
+		price.OutputPrice,
+		price.CacheWritePrice,
+		price.CacheReadPrice,
+		nullFloat64(price.ImagePrice),
+		nullFloat64(price.CacheWrite1hPrice),
+		tiers,
+		price.EffectiveFrom,
+		price.Notes,
+	}
+	err = scanSingleRow(ctx, r.sql, query, args, &price.UpdatedAt)
+	return translatePersistenceError(err, service.ErrModelPriceNotFound, service.ErrModelPriceExists)
+}
+
+func (r *modelPriceRepository) Delete(ctx context.Context, id int64) error {
+	res, err := r.sql.ExecContext(ctx, `DELETE FROM model_prices WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return service.ErrModelPriceNotFound
+	}
+	return nil
+}
+
+func (r *modelPriceRepository) Upsert(ctx context.Context, price *service.ModelPrice) (bool, error) {
+	if price == nil {
+		return false, nil
+	}
+	// 冲突行已生效时 WHERE 不满足、不返回任何行：已生效的价格不被覆盖
+	query := `
+		INSERT INTO model_prices (
+			model, group_id, input_price, output_price, cache_write_price, cache_read_price,
+			image_price, cache_write_1h_price, tiers, effective_from, notes
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (model, (COALESCE(group_id, 0)), effective_from) DO UPDATE
+		SET input_price = EXCLUDED.input_price,
+			output_price = EXCLUDED.output_price,
+			cache_write_price = EXCLUDED.cache_write_price,
+			cache_read_price = EXCLUDED.cache_read_price,
+			image_price = EXCLUDED.image_price,
+			cache_write_1h_price = EXCLUDED.cache_write_1h_price,
+			tiers = EXCLUDED.tiers,
+			notes = EXCLUDED.notes,
+			updated_at = NOW()
+		WHERE model_prices.effective_from > NOW()
+		RETURNING id, created_at, updated_at, (xmax = 0)
+	`
+	args, err := modelPriceArgs(price)
+	if err != nil {
+		return false, err
+	}
+	var inserted bool
+	err = scanSingleRow(ctx, r.sql, query, args, &price.ID, &price.CreatedAt, &price.UpdatedAt, &inserted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, service.ErrModelPriceInEffect
+	}
+	if err != nil {
+		return false, err
+	}
+	return inserted, nil
+}
+
+func (r *modelPriceRepository) query(ctx context.Context, query string, args ...any) ([]service.ModelPrice, error) {
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []service.ModelPrice{}
+	for rows.Next() {
+		var (
+			price      service.ModelPrice
+			groupID    sql.NullInt64
+			imagePrice sql.NullFloat64
+			cache1h    sql.NullFloat64
+			tiers      []byte
+		)
+		if err := rows.Scan(
+			&price.ID,
+			&price.Model,
+			&groupID,
+			&price.InputPrice,
+			&price.OutputPrice,
+			&price.CacheWritePrice,
+			&price.CacheReadPrice,
+			&imagePrice,
+			&cache1h,
+			&tiers,
+			&price.EffectiveFrom,
+			&price.Notes,
+			&price.CreatedAt,
+			&price.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		price.GroupID = nullInt64Ptr(groupID)
+		price.ImagePrice = nullFloat64Ptr(imagePrice)
+		price.CacheWrite1hPrice = nullFloat64Ptr(cache1h)
+		if len(tiers) > 0 {
+			if err := json.Unmarshal(tiers, &price.Tiers); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, price)
+	}
+	return out, rows.Err()
+}
+
+func modelPriceArgs(price *service.ModelPrice) ([]any, error) {
+	tiers, err := marshalModelPriceTiers(price.Tiers)
+	if err != nil {
+		return nil, err
+	}
+	return []any{
+		price.Model,
+		nullInt64(price.GroupID),
+		price.InputPrice,
+		price.OutputPrice,
+		price.CacheWritePrice,
+		price.CacheReadPrice,
+		nullFloat64(price.ImagePrice),
+		nullFloat64(price.CacheWrite1hPrice),
+		tiers,
+		price.EffectiveFrom,
+		price.Notes,
+	}, nil
+}
+
+// marshalModelPriceTiers tiers 列非空，无阶梯时写入空数组
+func marshalModelPriceTiers(tiers []service.ModelPriceTier) ([]byte, error) {
+	if tiers == nil {
+		tiers = []service.ModelPriceTier{}
+	}
+	return json.Marshal(tiers)
+}
+
+func buildModelPriceWhere(filter service.ModelPriceFilter) (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+	if model := strings.TrimSpace(filter.Model); model != "" {
+		args = append(args, "%"+strings.ToLower(model)+"%")
+		conds = append(conds, "model LIKE $"+itoa(len(args)))
+	}
+	if filter.GroupID != nil {
+		args = append(args, *filter.GroupID)
+		conds = append(conds, "group_id = $"+itoa(len(args)))
+	} else if filter.GlobalOnly {
+		conds = append(conds, "group_id IS NULL")
+	}
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
@@ -0,0 +1,118 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/suite"
+)
+
+type ModelPriceRepoSuite struct {
+	suite.Suite
+	ctx  context.Context
+	repo *modelPriceRepository
+}
+
+func (s *ModelPriceRepoSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.repo = &modelPriceRepository{sql: testTx(s.T())}
+}
+
+func TestModelPriceRepoSuite(t *testing.T) {
+	suite.Run(t, new(ModelPriceRepoSuite))
+}
+
+func (s *ModelPriceRepoSuite) newPrice(model string, effectiveFrom time.Time) *service.ModelPrice {
+	image := 0.04
+	return &service.ModelPrice{
+		Model:           model,
+		InputPrice:      3e-6,
+		OutputPrice:     15e-6,
+		CacheWritePrice: 3.75e-6,
+		CacheReadPrice:  0.3e-6,
+		ImagePrice:      &image,
+		Tiers:           []service.ModelPriceTier{{Threshold: 200_000, InputPrice: 6e-6, OutputPrice: 22.5e-6}},
+		EffectiveFrom:   effectiveFrom.UTC().Truncate(time.Second),
+		Notes:           "test",
+	}
+}
+
+func (s *ModelPriceRepoSuite) TestCreateGetListDelete() {
+	past := time.Now().Add(-time.Hour)
+	price := s.newPrice("repo-test-model", past)
+	s.Require().NoError(s.repo.Create(s.ctx, price))
+	s.Require().NotZero(price.ID)
+
+	dup := s.newPrice("repo-test-model", past)
+	s.Require().ErrorIs(s.repo.Create(s.ctx, dup), service.ErrModelPriceExists)
+
+	got, err := s.repo.GetByID(s.ctx, price.ID)
+	s.Require().NoError(err)
+	s.Require().Equal("repo-test-model", got.Model)
+	s.Require().Nil(got.GroupID)
+	s.Require().InDelta(15e-6, got.OutputPrice, 1e-15)
+	s.Require().NotNil(got.ImagePrice)
+	s.Require().InDelta(0.04, *got.ImagePrice, 1e-9)
+	s.Require().Nil(got.CacheWrite1hPrice)
+	s.Require().Len(got.Tiers, 1)
+	s.Require().Equal(200_000, got.Tiers[0].Threshold)
+	s.Require().InDelta(22.5e-6, got.Tiers[0].OutputPrice, 1e-15)
+
+	list, page, err := s.repo.List(s.ctx, service.ModelPriceFilter{Model: "REPO-TEST", GlobalOnly: true}, pagination.PaginationParams{Page: 1, PageSize: 10})
+	s.Require().NoError(err)
+	s.Require().Len(list, 1)
+	s.Require().EqualValues(1, page.Total)
+
+	s.Require().NoError(s.repo.Delete(s.ctx, price.ID))
+	_, err = s.repo.GetByID(s.ctx, price.ID)
+	s.Require().ErrorIs(err, service.ErrModelPriceNotFound)
+	s.Require().ErrorIs(s.repo.Delete(s.ctx, price.ID), service.ErrModelPriceNotFound)
+}
+
+func (s *ModelPriceRepoSuite) TestUpsert_OverwritesOnlyFuturePrices() {
+	future := s.newPrice("repo-upsert-model", time.Now().Add(24*time.Hour))
+	created, err := s.repo.Upsert(s.ctx, future)
+	s.Require().NoError(err)
+	s.Require().True(created)
+
+	again := s.newPrice("repo-upsert-model", future.EffectiveFrom)
+	again.InputPrice = 1e-6
+	again.ImagePrice = nil
+	cache1h := 6e-6
+	again.CacheWrite1hPrice = &cache1h
+	again.Tiers = nil
+	created, err = s.repo.Upsert(s.ctx, again)
+	s.Require().NoError(err)
+	s.Require().False(created)
+	s.Require().Equal(future.ID, again.ID)
+
+	got, err := s.repo.GetByID(s.ctx, future.ID)
+	s.Require().NoError(err)
+	s.Require().InDelta(1e-6, got.InputPrice, 1e-15)
+	s.Require().Nil(got.ImagePrice)
+	s.Require().NotNil(got.CacheWrite1hPrice)
+	s.Require().InDelta(6e-6, *got.CacheWrite1hPrice, 1e-15)
+	s.Require().Empty(got.Tiers)
+
+	past := s.newPrice("repo-upsert-model", time.Now().Add(-time.Hour))
+	s.Require().NoError(s.repo.Create(s.ctx, past))
+	overwrite := s.newPrice("repo-upsert-model", past.EffectiveFrom)
+	overwrite.InputPrice = 9e-6
+	_, err = s.repo.Upsert(s.ctx, overwrite)
+	s.Require().ErrorIs(err, service.ErrModelPriceInEffect)
+
+	all, err := s.repo.ListAll(s.ctx)
+	s.Require().NoError(err)
+	count := 0
+	for _, p := range all {
+		if p.Model == "repo-upsert-model" {
+			count++
+		}
+	}
+	s.Require().Equal(2, count)
+}
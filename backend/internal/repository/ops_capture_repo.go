@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type opsCaptureRepository struct {
+	sql sqlExecutor
+}
+
+func NewOpsCaptureRepository(sqlDB *sql.DB) service.OpsCaptureRepository {
+	return &opsCaptureRepository{sql: sqlDB}
+}
+
+const opsCaptureRuleColumns = `
+	r.id, r.target_type, r.target_id, r.sample_rate, r.retention_hours, r.note,
+	r.created_by, r.expires_at, r.created_at,
+	(SELECT COUNT(*) FROM ops_captures c WHERE c.rule_id = r.id AND c.expires_at > NOW())
+`
+
+const opsCaptureColumns = `
+	id, rule_id, request_id, api_key_id, user_id, account_id, group_id,
+	platform, model, request_path, stream, status_code, duration_ms, error_message,
+	request_bytes, response_bytes, truncated, created_at, expires_at
+`
+
+func (r *opsCaptureRepository) CreateRule(ctx context.Context, rule *service.OpsCaptureRule) error {
+	if rule == nil {
+		return nil
+	}
+	query := `
+		INSERT INTO ops_capture_rules (target_type, target_id, sample_rate, retention_hours, note, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	args := []any{rule.TargetType, rule.TargetID, rule.SampleRate, rule.RetentionHours, rule.Note, nullInt64(rule.CreatedBy), rule.ExpiresAt}
+	return scanSingleRow(ctx, r.sql, query, args, &rule.ID, &rule.CreatedAt)
+}
+
+func (r *opsCaptureRepository) ListRules(ctx context.Context) ([]service.OpsCaptureRule, error) {
+	return r.queryRules(ctx, `SELECT `+opsCaptureRuleColumns+` FROM ops_capture_rules r ORDER BY r.id DESC`)
+}
+
+func (r *opsCaptureRepository) ListActiveRules(ctx context.Context, now time.Time) ([]service.OpsCaptureRule, error) {
+	return r.queryRules(ctx, `SELECT `+opsCaptureRuleColumns+` FROM ops_capture_rules r WHERE r.expires_at > $1 ORDER BY r.id`, now)
+}
+
+func (r *opsCaptureRepository) ExpireRule(ctx context.Context, id int64, at time.Time) error {
+	res, err := r.sql.ExecContext(ctx, `UPDATE ops_capture_rules SET expires_at = LEAST(expires_at, $2) WHERE id = $1`, id, at)
+	if err != nil {
+		return err
+	}
+	return requireOpsCaptureAffected(res, service.ErrOpsCaptureRuleNotFound)
+}
+
+func (r *opsCaptureRepository) DeleteRule(ctx context.Context, id int64) error {
+	res, err := r.sql.ExecContext(ctx, `DELETE FROM ops_capture_rules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return requireOpsCaptureAffected(res, service.ErrOpsCaptureRuleNotFound)
+}
+
+func (r *opsCaptureRepository) DeleteRulesExpiredBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := r.sql.ExecContext(ctx, `
+		DELETE FROM ops_capture_rules r
+		WHERE r.expires_at < $1
+		  AND NOT EXISTS (SELECT 1 FROM ops_captures c WHERE c.rule_id = r.id)
+	`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *opsCaptureRepository) CreateCapture(ctx context.Context, capture *service.OpsCapture) error {
+	if capture == nil {
+		return nil
+	}
+	query := `
+		INSERT INTO ops_captures (
+			rule_id, request_id, api_key_id, user_id, account_id, group_id,
+			platform, model, request_path, stream, status_code, duration_ms, error_message,
+			request_bytes, response_bytes, truncated,
+			request_body, response_body, response_text, created_at, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+		RETURNING id
+	`
+	args := []any{
+		capture.RuleID,
+		capture.RequestID,
+		capture.APIKeyID,
+		capture.UserID,
+		nullInt64(capture.AccountID),
+		nullInt64(capture.GroupID),
+		capture.Platform,
+		capture.Model,
+		capture.RequestPath,
+		capture.Stream,
+		capture.StatusCode,
+		capture.DurationMs,
+		capture.ErrorMessage,
+		capture.RequestBytes,
+		capture.ResponseBytes,
+		capture.Truncated,
+		capture.RequestBody,
+		capture.ResponseBody,
+		capture.ResponseText,
+		capture.CreatedAt,
+		capture.ExpiresAt,
+	}
+	return scanSingleRow(ctx, r.sql, query, args, &capture.ID)
+}
+
+func (r *opsCaptureRepository) ListCaptures(ctx context.Context, filter service.OpsCaptureFilter, params pagination.PaginationParams) ([]service.OpsCapture, *pagination.PaginationResult, error) {
+	where, args := buildOpsCaptureWhere(filter)
+
+	var total int64
+	if err := scanSingleRow(ctx, r.sql, `SELECT COUNT(*) FROM ops_captures`+where, args, &total); err != nil {
+		return nil, nil, err
+	}
+
+	query := `SELECT ` + opsCaptureColumns + ` FROM ops_captures` + where +
+		` ORDER BY id DESC LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
+	rows, err := r.sql.QueryContext(ctx, query, append(args, params.Limit(), params.Offset())...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]service.OpsCapture, 0)
+	for rows.Next() {
+		capture, err := scanOpsCapture(rows, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, *capture)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return out, paginationResultFromTotal(total, params), nil
+}
+
+func (r *opsCaptureRepository) GetCapture(ctx context.Context, id int64) (*service.OpsCapture, error) {
+	query := `SELECT ` + opsCaptureColumns + `, request_body, response_body, response_text
+		FROM ops_captures WHERE id = $1 AND expires_at > NOW()`
+	rows, err := r.sql.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, service.ErrOpsCaptureNotFound
+	}
+	capture, err := scanOpsCapture(rows, true)
+	if err != nil {
+		return nil, err
+	}
+	return capture, rows.Err()
+}
+
+func (r *opsCaptureRepository) DeleteExpiredCaptures(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.sql.ExecContext(ctx, `DELETE FROM ops_captures WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *opsCaptureRepository) queryRules(ctx context.Context, query string, args ...any) ([]service.OpsCaptureRule, error) {
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]service.OpsCaptureRule, 0)
+	for rows.Next() {
+		var rule service.OpsCaptureRule
+		var createdBy sql.NullInt64
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.TargetType,
+			&rule.TargetID,
+			&rule.SampleRate,
+			&rule.RetentionHours,
+			&rule.Note,
+			&createdBy,
+			&rule.ExpiresAt,
+			&rule.CreatedAt,
+			&rule.CaptureCount,
+		); err != nil {
+			return nil, err
+		}
+		rule.CreatedBy = nullInt64Ptr(createdBy)
+		out = append(out, rule)
+	}
+	return out, rows.Err()
+}
+
+func buildOpsCaptureWhere(filter service.OpsCaptureFilter) (string, []any) {
+	conds := []string{"expires_at > NOW()"}
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, strings.ReplaceAll(cond, "?", "$"+itoa(len(args))))
+	}
+
+	if filter.RuleID != nil {
+		add("rule_id = ?", *filter.RuleID)
+	}
+	if filter.APIKeyID != nil {
+		add("api_key_id = ?", *filter.APIKeyID)
+	}
+	if filter.UserID != nil {
+		add("user_id = ?", *filter.UserID)
+	}
+	if filter.AccountID != nil {
+		add("account_id = ?", *filter.AccountID)
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// scanOpsCapture 扫描 opsCaptureColumns；withBodies 时其后依次为三个密文列
+func scanOpsCapture(rows *sql.Rows, withBodies bool) (*service.OpsCapture, error) {
+	var capture service.OpsCapture
+	var accountID, groupID sql.NullInt64
+	dest := []any{
+		&capture.ID,
+		&capture.RuleID,
+		&capture.RequestID,
+		&capture.APIKeyID,
+		&capture.UserID,
+		&accountID,
+		&groupID,
+		&capture.Platform,
+		&capture.Model,
+		&capture.RequestPath,
+		&capture.Stream,
+		&capture.StatusCode,
+		&capture.DurationMs,
+		&capture.ErrorMessage,
+		&capture.RequestBytes,
+		&capture.ResponseBytes,
+		&capture.Truncated,
+		&capture.CreatedAt,
+		&capture.ExpiresAt,
+	}
+	if withBodies {
+		dest = append(dest, &capture.RequestBody, &capture.ResponseBody, &capture.ResponseText)
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	capture.AccountID = nullInt64Ptr(accountID)
+	capture.GroupID = nullInt64Ptr(groupID)
+	return &capture, nil
+}
+
+func requireOpsCaptureAffected(res sql.Result, notFound error) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
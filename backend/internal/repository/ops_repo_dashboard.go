@@ -28,18 +28,30 @@ func (r *opsRepository) GetDashboardOverview(ctx context.Context, filter *servic
 		mode = service.OpsQueryModeRaw
 	}
 
+	var (
+		out *service.OpsDashboardOverview
+		err error
+	)
 	switch mode {
 	case service.OpsQueryModePreagg:
-		return r.getDashboardOverviewPreaggregated(ctx, filter)
+		out, err = r.getDashboardOverviewPreaggregated(ctx, filter)
 	case service.OpsQueryModeAuto:
-		out, err := r.getDashboardOverviewPreaggregated(ctx, filter)
+		out, err = r.getDashboardOverviewPreaggregated(ctx, filter)
 		if err != nil && errors.Is(err, service.ErrOpsPreaggregatedNotPopulated) {
-			return r.getDashboardOverviewRaw(ctx, filter)
+			out, err = r.getDashboardOverviewRaw(ctx, filter)
 		}
-		return out, err
 	default:
-		return r.getDashboardOverviewRaw(ctx, filter)
+		out, err = r.getDashboardOverviewRaw(ctx, filter)
+	}
+	if err != nil {
+		return nil, err
 	}
+
+	// 对冲与中途断开统计未做预聚合，始终从 usage_logs 查询
+	if err := r.queryUsageFlagStats(ctx, filter, filter.StartTime.UTC(), filter.EndTime.UTC(), out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (r *opsRepository) getDashboardOverviewRaw(ctx context.Context, filter *service.OpsDashboardFilter) (*service.OpsDashboardOverview, error) {
@@ -734,6 +746,36 @@ FROM usage_logs ul
 	return successCount, tokenConsumed, nil
 }
 
+func (r *opsRepository) queryUsageFlagStats(ctx context.Context, filter *service.OpsDashboardFilter, start, end time.Time, out *service.OpsDashboardOverview) error {
+	join, where, args, _ := buildUsageWhere(filter, start, end, 1)
+
+	q := `
+SELECT
+  COUNT(*) FILTER (WHERE ul.hedged) AS hedge_fired_count,
+  COUNT(*) FILTER (WHERE ul.hedge_backup_won) AS hedge_backup_won_count,
+  COUNT(*) FILTER (WHERE ul.client_disconnected) AS client_disconnected_count,
+  COALESCE(SUM(ul.actual_cost) FILTER (WHERE ul.client_disconnected), 0) AS client_disconnected_cost,
+  COALESCE(SUM(ul.actual_cost), 0) AS total_actual_cost
+FROM usage_logs ul
+` + join + `
+` + where
+
+	var totalCost float64
+	if err := r.db.QueryRowContext(ctx, q, args...).Scan(
+		&out.HedgeFiredCount,
+		&out.HedgeBackupWonCount,
+		&out.ClientDisconnectedCount,
+		&out.ClientDisconnectedCost,
+		&totalCost,
+	); err != nil {
+		return err
+	}
+	if totalCost > 0 {
+		out.ClientDisconnectedCostRatio = roundTo4DP(out.ClientDisconnectedCost / totalCost)
+	}
+	return nil
+}
+
 func (r *opsRepository) queryUsageLatency(ctx context.Context, filter *service.OpsDashboardFilter, start, end time.Time) (duration service.OpsPercentiles, ttft service.OpsPercentiles, err error) {
 	{
 		join, where, args, _ := buildUsageWhere(filter, start, end, 1)
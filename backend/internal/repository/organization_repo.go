@@ -0,0 +1,646 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type organizationRepository struct {
+	sql sqlExecutor
+}
+
+func NewOrganizationRepository(sqlDB *sql.DB) service.OrganizationRepository {
+	return &organizationRepository{sql: sqlDB}
+}
+
+// executor 处于事务上下文时复用事务连接
+func (r *organizationRepository) executor(ctx context.Context) sqlExecutor {
+	if tx := dbent.TxFromContext(ctx); tx != nil {
+		return tx.Client()
+	}
+	return r.sql
+}
+
+const organizationColumns = `
+	o.id, o.name, o.owner_user_id, o.balance, o.shared_subscriptions, o.status, o.created_at, o.updated_at,
+	(SELECT COUNT(*) FROM organization_members om WHERE om.organization_id = o.id),
+	COALESCE(u.email, ''), COALESCE(u.username, '')
+`
+
+const organizationFrom = ` FROM organizations o LEFT JOIN users u ON u.id = o.owner_user_id`
+
+const organizationMemberColumns = `
+	m.id, m.organization_id, m.user_id, m.role, m.spend_limit_usd, m.period_spend_usd, m.period_start,
+	m.created_at, m.updated_at, COALESCE(u.email, ''), COALESCE(u.username, '')
+`
+
+const organizationMemberFrom = ` FROM organization_members m LEFT JOIN users u ON u.id = m.user_id`
+
+// organizationMemberOrder 所有者、管理员在前
+const organizationMemberOrder = ` ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END, m.id`
+
+const organizationInvitationColumns = `
+	i.id, i.organization_id, i.email, i.role, i.token_hash, i.invited_by, i.status, i.expires_at,
+	i.accepted_by, i.accepted_at, i.created_at, COALESCE(o.name, '')
+`
+
+const organizationInvitationFrom = ` FROM organization_invitations i LEFT JOIN organizations o ON o.id = i.organization_id`
+
+func (r *organizationRepository) Create(ctx context.Context, org *service.Organization) error {
+	if org == nil {
+		return nil
+	}
+	// 组织与所有者成员在同一语句内写入
+	query := `
+		WITH o AS (
+			INSERT INTO organizations (name, owner_user_id, shared_subscriptions, status)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, balance, created_at, updated_at
+		), m AS (
+			INSERT INTO organization_members (organization_id, user_id, role)
+			SELECT id, $2, $5 FROM o
+		)
+		SELECT id, balance, created_at, updated_at FROM o
+	`
+	args := []any{org.Name, org.OwnerUserID, org.SharedSubscriptions, org.Status, service.OrganizationRoleOwner}
+	if err := scanSingleRow(ctx, r.executor(ctx), query, args, &org.ID, &org.Balance, &org.CreatedAt, &org.UpdatedAt); err != nil {
+		return err
+	}
+	org.MemberCount = 1
+	return nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id int64) (*service.Organization, error) {
+	orgs, err := r.queryOrganizations(ctx, `SELECT `+organizationColumns+organizationFrom+` WHERE o.id = $1 AND o.deleted_at IS NULL`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(orgs) == 0 {
+		return nil, service.ErrOrganizationNotFound
+	}
+	return &orgs[0], nil
+}
+
+func (r *organizationRepository) Update(ctx context.Context, org *service.Organization) error {
+	if org == nil {
+		return nil
+	}
+	err := scanSingleRow(ctx, r.executor(ctx), `
+		UPDATE organizations
+		SET name = $2, status = $3, shared_subscriptions = $4, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING updated_at
+	`, []any{org.ID, org.Name, org.Status, org.SharedSubscriptions}, &org.UpdatedAt)
+	return translatePersistenceError(err, service.ErrOrganizationNotFound, nil)
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, id int64) error {
+	res, err := r.executor(ctx).ExecContext(ctx, `
+		UPDATE organizations SET status = $2, deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, service.StatusDisabled)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, service.ErrOrganizationNotFound)
+}
+
+func (r *organizationRepository) List(ctx context.Context, filter service.OrganizationFilter, params pagination.PaginationParams) ([]service.Organization, *pagination.PaginationResult, error) {
+	conds := []string{"o.deleted_at IS NULL"}
+	var args []any
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		args = append(args, "%"+search+"%")
+		conds = append(conds, "(o.name ILIKE $"+itoa(len(args))+" OR u.email ILIKE $"+itoa(len(args))+")")
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conds = append(conds, "o.status = $"+itoa(len(args)))
+	}
+	where := " WHERE " + strings.Join(conds, " AND ")
+
+	var total int64
+	if err := scanSingleRow(ctx, r.sql, `SELECT COUNT(*)`+organizationFrom+where, args, &total); err != nil {
+		return nil, nil, err
+	}
+
+	query := `SELECT ` + organizationColumns + organizationFrom + where +
+		` ORDER BY o.id DESC LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
+	orgs, err := r.queryOrganizations(ctx, query, append(args, params.Limit(), params.Offset())...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return orgs, paginationResultFromTotal(total, params), nil
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, orgID, userID int64) (*service.OrganizationMember, error) {
+	members, err := r.queryMembers(ctx, `SELECT `+organizationMemberColumns+organizationMemberFrom+
+		` WHERE m.organization_id = $1 AND m.user_id = $2`, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, service.ErrOrganizationMemberNotFound
+	}
+	return &members[0], nil
+}
+
+func (r *organizationRepository) ListMembers(ctx context.Context, orgID int64) ([]service.OrganizationMember, error) {
+	return r.queryMembers(ctx, `SELECT `+organizationMemberColumns+organizationMemberFrom+
+		` WHERE m.organization_id = $1`+organizationMemberOrder, orgID)
+}
+
+func (r *organizationRepository) ListMembershipsByUser(ctx context.Context, userID int64) ([]service.OrganizationMember, error) {
+	members, err := r.queryMembers(ctx, `SELECT `+organizationMemberColumns+organizationMemberFrom+`
+		JOIN organizations o ON o.id = m.organization_id AND o.deleted_at IS NULL
+		WHERE m.user_id = $1 ORDER BY m.organization_id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return members, nil
+	}
+	ids := make([]any, 0, len(members))
+	placeholders := make([]string, 0, len(members))
+	for i := range members {
+		ids = append(ids, members[i].OrganizationID)
+		placeholders = append(placeholders, "$"+itoa(len(ids)))
+	}
+	orgs, err := r.queryOrganizations(ctx, `SELECT `+organizationColumns+organizationFrom+
+		` WHERE o.id IN (`+strings.Join(placeholders, ", ")+`)`, ids...)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*service.Organization, len(orgs))
+	for i := range orgs {
+		byID[orgs[i].ID] = &orgs[i]
+	}
+	for i := range members {
+		members[i].Organization = byID[members[i].OrganizationID]
+	}
+	return members, nil
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *service.OrganizationMember) error {
+	if member == nil {
+		return nil
+	}
+	err := scanSingleRow(ctx, r.executor(ctx), `
+		INSERT INTO organization_members (organization_id, user_id, role, spend_limit_usd)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, period_spend_usd, period_start, created_at, updated_at
+	`, []any{member.OrganizationID, member.UserID, member.Role, nullFloat64(member.SpendLimitUSD)},
+		&member.ID, &member.PeriodSpendUSD, &member.PeriodStart, &member.CreatedAt, &member.UpdatedAt)
+	return translatePersistenceError(err, nil, service.ErrOrganizationMemberExists)
+}
+
+func (r *organizationRepository) UpdateMember(ctx context.Context, member *service.OrganizationMember) error {
+	if member == nil {
+		return nil
+	}
+	err := scanSingleRow(ctx, r.executor(ctx), `
+		UPDATE organization_members
+		SET role = $3, spend_limit_usd = $4, updated_at = NOW()
+		WHERE organization_id = $1 AND user_id = $2
+		RETURNING updated_at
+	`, []any{member.OrganizationID, member.UserID, member.Role, nullFloat64(member.SpendLimitUSD)}, &member.UpdatedAt)
+	return translatePersistenceError(err, service.ErrOrganizationMemberNotFound, nil)
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	res, err := r.executor(ctx).ExecContext(ctx, `
+		DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2 AND role <> $3
+	`, orgID, userID, service.OrganizationRoleOwner)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, service.ErrOrganizationMemberNotFound)
+}
+
+func (r *organizationRepository) CreateInvitation(ctx context.Context, inv *service.OrganizationInvitation) error {
+	if inv == nil {
+		return nil
+	}
+	return scanSingleRow(ctx, r.executor(ctx), `
+		INSERT INTO organization_invitations (organization_id, email, role, token_hash, invited_by, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`, []any{inv.OrganizationID, inv.Email, inv.Role, inv.TokenHash, inv.InvitedBy, inv.Status, inv.ExpiresAt},
+		&inv.ID, &inv.CreatedAt)
+}
+
+func (r *organizationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*service.OrganizationInvitation, error) {
+	invs, err := r.queryInvitations(ctx, `SELECT `+organizationInvitationColumns+organizationInvitationFrom+
+		` WHERE i.token_hash = $1`, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(invs) == 0 {
+		return nil, service.ErrOrganizationInviteNotFound
+	}
+	return &invs[0], nil
+}
+
+func (r *organizationRepository) ListInvitations(ctx context.Context, orgID int64) ([]service.OrganizationInvitation, error) {
+	return r.queryInvitations(ctx, `SELECT `+organizationInvitationColumns+organizationInvitationFrom+
+		` WHERE i.organization_id = $1 ORDER BY i.created_at DESC, i.id DESC LIMIT 200`, orgID)
+}
+
+func (r *organizationRepository) AcceptInvitation(ctx context.Context, id, userID int64) error {
+	res, err := r.executor(ctx).ExecContext(ctx, `
+		UPDATE organization_invitations
+		SET status = $3, accepted_by = $2, accepted_at = NOW()
+		WHERE id = $1 AND status = $4 AND expires_at > NOW()
+	`, id, userID, service.OrganizationInvitationAccepted, service.OrganizationInvitationPending)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, service.ErrOrganizationInviteInvalid)
+}
+
+func (r *organizationRepository) RevokeInvitation(ctx context.Context, orgID, id int64) error {
+	res, err := r.executor(ctx).ExecContext(ctx, `
+		UPDATE organization_invitations SET status = $3
+		WHERE id = $2 AND organization_id = $1 AND status = $4
+	`, orgID, id, service.OrganizationInvitationRevoked, service.OrganizationInvitationPending)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, service.ErrOrganizationInviteNotFound)
+}
+
+// ApplyBalanceTransaction 原子地变更组织余额并追加流水，回填 ID、BalanceAfter 与 CreatedAt。
+func (r *organizationRepository) ApplyBalanceTransaction(ctx context.Context, txn *service.OrganizationBalanceTransaction) error {
+	if txn == nil {
+		return nil
+	}
+	err := scanSingleRow(ctx, r.executor(ctx), `
+		WITH updated AS (
+			UPDATE organizations
+			SET balance = balance + $2::numeric, updated_at = NOW()
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING id, balance
+		)
+		INSERT INTO organization_balance_transactions (
+			organization_id, user_id, type, amount, balance_after, usage_log_id, admin_user_id, notes
+		)
+		SELECT id, $3::bigint, $4::varchar, $2::numeric, balance, $5::bigint, $6::bigint, $7::text FROM updated
+		RETURNING id, balance_after, created_at
+	`, []any{
+		txn.OrganizationID,
+		txn.Amount,
+		nullInt64(txn.UserID),
+		txn.Type,
+		nullInt64(txn.UsageLogID),
+		nullInt64(txn.AdminUserID),
+		txn.Notes,
+	}, &txn.ID, &txn.BalanceAfter, &txn.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return service.ErrOrganizationNotFound
+	}
+	return err
+}
+
+// ChargeUsage 在同一语句内扣减组织余额、追加用量流水并累计成员本月消费。
+// 用量已经发生，组织被删除后仍照常扣减，保证流水完整。
+func (r *organizationRepository) ChargeUsage(ctx context.Context, orgID, userID int64, amount float64, usageLogID int64) error {
+	var logID sql.NullInt64
+	if usageLogID > 0 {
+		logID = sql.NullInt64{Int64: usageLogID, Valid: true}
+	}
+	periodStart := service.OrganizationSpendPeriodStart(time.Now())
+	res, err := r.executor(ctx).ExecContext(ctx, `
+		WITH updated AS (
+			UPDATE organizations
+			SET balance = balance - $3::numeric, updated_at = NOW()
+			WHERE id = $1
+			RETURNING id, balance
+		), spend AS (
+			UPDATE organization_members
+			SET period_spend_usd = CASE WHEN period_start < $5 THEN $3::numeric ELSE period_spend_usd + $3::numeric END,
+				period_start = GREATEST(period_start, $5),
+				updated_at = NOW()
+			WHERE organization_id = $1 AND user_id = $2
+		)
+		INSERT INTO organization_balance_transactions (organization_id, user_id, type, amount, balance_after, usage_log_id)
+		SELECT id, $2, $6, -$3::numeric, balance, $4::bigint FROM updated
+	`, orgID, userID, amount, logID, periodStart, service.OrganizationTxTypeUsage)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res, service.ErrOrganizationNotFound)
+}
+
+func (r *organizationRepository) ListBalanceTransactions(ctx context.Context, orgID int64, params pagination.PaginationParams) ([]service.OrganizationBalanceTransaction, *pagination.PaginationResult, error) {
+	var total int64
+	if err := scanSingleRow(ctx, r.sql, `SELECT COUNT(*) FROM organization_balance_transactions WHERE organization_id = $1`,
+		[]any{orgID}, &total); err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT id, organization_id, user_id, type, amount, balance_after, usage_log_id, admin_user_id, notes, created_at
+		FROM organization_balance_transactions
+		WHERE organization_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, orgID, params.Limit(), params.Offset())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []service.OrganizationBalanceTransaction{}
+	for rows.Next() {
+		var (
+			txn                             service.OrganizationBalanceTransaction
+			userID, usageLogID, adminUserID sql.NullInt64
+		)
+		if err := rows.Scan(&txn.ID, &txn.OrganizationID, &userID, &txn.Type, &txn.Amount, &txn.BalanceAfter,
+			&usageLogID, &adminUserID, &txn.Notes, &txn.CreatedAt); err != nil {
+			return nil, nil, err
+		}
+		txn.UserID = nullInt64Ptr(userID)
+		txn.UsageLogID = nullInt64Ptr(usageLogID)
+		txn.AdminUserID = nullInt64Ptr(adminUserID)
+		out = append(out, txn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return out, paginationResultFromTotal(total, params), nil
+}
+
+func (r *organizationRepository) ListAPIKeys(ctx context.Context, orgID int64) ([]service.APIKey, error) {
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT k.id, k.user_id, k.name, k.group_id, k.status, k.quota_usd, k.used_usd, k.expires_at,
+			k.created_at, k.updated_at, COALESCE(u.email, ''), COALESCE(u.username, ''), COALESCE(g.name, '')
+		FROM api_keys k
+		LEFT JOIN users u ON u.id = k.user_id
+		LEFT JOIN groups g ON g.id = k.group_id
+		WHERE k.organization_id = $1 AND k.deleted_at IS NULL
+		ORDER BY k.id DESC
+	`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []service.APIKey{}
+	for rows.Next() {
+		var (
+			key       service.APIKey
+			groupID   sql.NullInt64
+			quota     sql.NullFloat64
+			expiresAt sql.NullTime
+			email     string
+			username  string
+			groupName string
+		)
+		if err := rows.Scan(&key.ID, &key.UserID, &key.Name, &groupID, &key.Status, &quota, &key.UsedUSD, &expiresAt,
+			&key.CreatedAt, &key.UpdatedAt, &email, &username, &groupName); err != nil {
+			return nil, err
+		}
+		id := orgID
+		key.OrganizationID = &id
+		key.GroupID = nullInt64Ptr(groupID)
+		key.QuotaUSD = nullFloat64Ptr(quota)
+		if expiresAt.Valid {
+			t := expiresAt.Time
+			key.ExpiresAt = &t
+		}
+		key.User = &service.User{ID: key.UserID, Email: email, Username: username}
+		if key.GroupID != nil {
+			key.Group = &service.Group{ID: *key.GroupID, Name: groupName}
+		}
+		out = append(out, key)
+	}
+	return out, rows.Err()
+}
+
+func (r *organizationRepository) ListAPIKeyKeys(ctx context.Context, orgID int64, userID *int64) ([]string, error) {
+	query := `SELECT key FROM api_keys WHERE organization_id = $1 AND deleted_at IS NULL`
+	args := []any{orgID}
+	if userID != nil {
+		args = append(args, *userID)
+		query += ` AND user_id = $2`
+	}
+	return r.queryKeys(ctx, r.sql, query, args...)
+}
+
+func (r *organizationRepository) DisableAPIKeys(ctx context.Context, orgID int64, userID, keyID *int64) ([]string, error) {
+	query := `UPDATE api_keys SET status = $2, updated_at = NOW()
+		WHERE organization_id = $1 AND deleted_at IS NULL AND status <> $2`
+	args := []any{orgID, service.StatusDisabled}
+	if userID != nil {
+		args = append(args, *userID)
+		query += ` AND user_id = $` + itoa(len(args))
+	}
+	if keyID != nil {
+		args = append(args, *keyID)
+		query += ` AND id = $` + itoa(len(args))
+	}
+	return r.queryKeys(ctx, r.executor(ctx), query+` RETURNING key`, args...)
+}
+
+func (r *organizationRepository) GetUsageSummary(ctx context.Context, orgID int64, start, end time.Time) (*service.OrganizationUsageSummary, error) {
+	summary := &service.OrganizationUsageSummary{
+		StartTime: start,
+		EndTime:   end,
+		Members:   []service.OrganizationMemberUsage{},
+		Models:    []service.OrganizationModelUsage{},
+		Daily:     []service.OrganizationDailyUsage{},
+	}
+	const tokens = `COALESCE(SUM(ul.input_tokens + ul.output_tokens + ul.cache_creation_tokens + ul.cache_read_tokens), 0)`
+
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT ul.user_id, COALESCE(u.email, ''), COALESCE(u.username, ''), COUNT(*), `+tokens+`,
+			COALESCE(SUM(ul.total_cost), 0), COALESCE(SUM(ul.actual_cost), 0)
+		FROM usage_logs ul
+		LEFT JOIN users u ON u.id = ul.user_id
+		WHERE ul.organization_id = $1 AND ul.created_at >= $2 AND ul.created_at < $3
+		GROUP BY ul.user_id, u.email, u.username
+		ORDER BY 7 DESC, ul.user_id
+	`, orgID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var m service.OrganizationMemberUsage
+		if err := rows.Scan(&m.UserID, &m.Email, &m.Username, &m.Requests, &m.Tokens, &m.TotalCost, &m.ActualCost); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		summary.TotalRequests += m.Requests
+		summary.TotalTokens += m.Tokens
+		summary.TotalCost += m.TotalCost
+		summary.ActualCost += m.ActualCost
+		summary.Members = append(summary.Members, m)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.sql.QueryContext(ctx, `
+		SELECT ul.model, COUNT(*), `+tokens+`, COALESCE(SUM(ul.total_cost), 0), COALESCE(SUM(ul.actual_cost), 0)
+		FROM usage_logs ul
+		WHERE ul.organization_id = $1 AND ul.created_at >= $2 AND ul.created_at < $3
+		GROUP BY ul.model
+		ORDER BY 5 DESC, ul.model
+	`, orgID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var m service.OrganizationModelUsage
+		if err := rows.Scan(&m.Model, &m.Requests, &m.Tokens, &m.TotalCost, &m.ActualCost); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		summary.Models = append(summary.Models, m)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.sql.QueryContext(ctx, `
+		SELECT TO_CHAR(ul.created_at, 'YYYY-MM-DD') AS date, COUNT(*), `+tokens+`, COALESCE(SUM(ul.actual_cost), 0)
+		FROM usage_logs ul
+		WHERE ul.organization_id = $1 AND ul.created_at >= $2 AND ul.created_at < $3
+		GROUP BY date
+		ORDER BY date
+	`, orgID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var d service.OrganizationDailyUsage
+		if err := rows.Scan(&d.Date, &d.Requests, &d.Tokens, &d.ActualCost); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		summary.Daily = append(summary.Daily, d)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func (r *organizationRepository) queryOrganizations(ctx context.Context, query string, args ...any) ([]service.Organization, error) {
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []service.Organization{}
+	for rows.Next() {
+		var (
+			org      service.Organization
+			email    string
+			username string
+		)
+		if err := rows.Scan(&org.ID, &org.Name, &org.OwnerUserID, &org.Balance, &org.SharedSubscriptions, &org.Status,
+			&org.CreatedAt, &org.UpdatedAt, &org.MemberCount, &email, &username); err != nil {
+			return nil, err
+		}
+		if email != "" || username != "" {
+			org.Owner = &service.User{ID: org.OwnerUserID, Email: email, Username: username}
+		}
+		out = append(out, org)
+	}
+	return out, rows.Err()
+}
+
+func (r *organizationRepository) queryMembers(ctx context.Context, query string, args ...any) ([]service.OrganizationMember, error) {
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []service.OrganizationMember{}
+	for rows.Next() {
+		var (
+			m        service.OrganizationMember
+			limit    sql.NullFloat64
+			email    string
+			username string
+		)
+		if err := rows.Scan(&m.ID, &m.OrganizationID, &m.UserID, &m.Role, &limit, &m.PeriodSpendUSD, &m.PeriodStart,
+			&m.CreatedAt, &m.UpdatedAt, &email, &username); err != nil {
+			return nil, err
+		}
+		m.SpendLimitUSD = nullFloat64Ptr(limit)
+		m.User = &service.User{ID: m.UserID, Email: email, Username: username}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func (r *organizationRepository) queryInvitations(ctx context.Context, query string, args ...any) ([]service.OrganizationInvitation, error) {
+	rows, err := r.sql.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := []service.OrganizationInvitation{}
+	for rows.Next() {
+		var (
+			inv        service.OrganizationInvitation
+			acceptedBy sql.NullInt64
+			acceptedAt sql.NullTime
+			orgName    string
+		)
+		if err := rows.Scan(&inv.ID, &inv.OrganizationID, &inv.Email, &inv.Role, &inv.TokenHash, &inv.InvitedBy, &inv.Status,
+			&inv.ExpiresAt, &acceptedBy, &acceptedAt, &inv.CreatedAt, &orgName); err != nil {
+			return nil, err
+		}
+		inv.AcceptedBy = nullInt64Ptr(acceptedBy)
+		if acceptedAt.Valid {
+			t := acceptedAt.Time
+			inv.AcceptedAt = &t
+		}
+		inv.Organization = &service.Organization{ID: inv.OrganizationID, Name: orgName}
+		out = append(out, inv)
+	}
+	return out, rows.Err()
+}
+
+func (r *organizationRepository) queryKeys(ctx context.Context, q sqlExecutor, query string, args ...any) ([]string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// requireAffected 语句未影响任何行时返回 notFound
+func requireAffected(res sql.Result, notFound error) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+const responseCacheKeyPrefix = "response_cache:"
+
+type responseCache struct {
+	rdb *redis.Client
+}
+
+func NewResponseCache(rdb *redis.Client) service.ResponseCache {
+	return &responseCache{rdb: rdb}
+}
+
+func (c *responseCache) Get(ctx context.Context, key string) (*service.CachedResponse, error) {
+	raw, err := c.rdb.Get(ctx, responseCacheKeyPrefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entry service.CachedResponse
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (c *responseCache) Set(ctx context.Context, key string, entry *service.CachedResponse, ttl time.Duration) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return c.rdb.Set(ctx, responseCacheKeyPrefix+key, raw, ttl).Err()
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+// 账号槽位公平排队队列
+//
+// 每个账号维护四个键：
+//   - slot_queue:account:{id}        有序集合，成员为 requestID，分数 = 优先级分段 + 公平份额标签
+//   - slot_queue_hb:account:{id}     有序集合，成员为 requestID，分数为最近一次轮询时间（毫秒），用于清理失联排队者
+//   - slot_queue_meta:account:{id}   哈希：vt（队列虚拟时间）、u:{userID}（用户最近标签）、t:{requestID}（入队时间）
+//   - slot_queue_waits:account:{id}  列表：最近获得槽位的排队耗时（毫秒），供管理端计算分位数
+//
+// 公平份额采用加权公平排队（WFQ）：用户每次入队的标签 = max(虚拟时间, 该用户上一个标签) + 单位/权重，
+// 同一优先级内标签小者先调度，因此排队较多的重度用户不会饿死轻度用户。
+// 队列清空时重置虚拟时间与用户标签。
+// 分数超过 Lua 默认数字格式（%.14g）的精度，脚本内写入前统一用 string.format('%.0f') 格式化。
+const (
+	slotQueueKeyPrefix     = "slot_queue:account:"
+	slotQueueHBKeyPrefix   = "slot_queue_hb:account:"
+	slotQueueMetaKeyPrefix = "slot_queue_meta:account:"
+	slotQueueWaitKeyPrefix = "slot_queue_waits:account:"
+
+	// 排队者超过该时间未轮询即视为失联并移出队列（毫秒）
+	slotQueueStaleMs = 15000
+	// 公平份额标签单位：权重为 w 的用户每次入队标签增加 slotQueueTagUnit / w
+	slotQueueTagUnit = 10000
+	// 优先级分段：分数 = (QueuePriorityMax - priority) * slotQueuePriorityBand + 标签
+	slotQueuePriorityBand = int64(1e14)
+	// 每个账号保留的排队耗时样本数与保留时间
+	slotQueueWaitSamples    = 500
+	slotQueueWaitTTLSeconds = 3600
+)
+
+var (
+	// enqueueSlotWaiterScript 加入队列（已在队列中时只刷新心跳），返回排队位置
+	// KEYS[1] = queue, KEYS[2] = hb, KEYS[3] = meta
+	// ARGV[1] = requestID, ARGV[2] = userID, ARGV[3] = 标签步长, ARGV[4] = 优先级分段基数, ARGV[5] = TTL（秒）
+	enqueueSlotWaiterScript = redis.NewScript(`
+		local t = redis.call('TIME')
+		local nowMs = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+		local reqID = ARGV[1]
+
+		if redis.call('ZSCORE', KEYS[1], reqID) == false then
+			local vt = tonumber(redis.call('HGET', KEYS[3], 'vt') or '0')
+			local userKey = 'u:' .. ARGV[2]
+			local last = tonumber(redis.call('HGET', KEYS[3], userKey) or '0')
+			local start = vt
+			if last > start then
+				start = last
+			end
+			local finish = start + tonumber(ARGV[3])
+			redis.call('HSET', KEYS[3], userKey, string.format('%.0f', finish))
+			if redis.call('HEXISTS', KEYS[3], 't:' .. reqID) == 0 then
+				redis.call('HSET', KEYS[3], 't:' .. reqID, string.format('%.0f', nowMs))
+			end
+			redis.call('ZADD', KEYS[1], string.format('%.0f', tonumber(ARGV[4]) + finish), reqID)
+		end
+		redis.call('ZADD', KEYS[2], string.format('%.0f', nowMs), reqID)
+
+		local ttl = tonumber(ARGV[5])
+		redis.call('EXPIRE', KEYS[1], ttl)
+		redis.call('EXPIRE', KEYS[2], ttl)
+		redis.call('EXPIRE', KEYS[3], ttl)
+		return redis.call('ZRANK', KEYS[1], reqID) + 1
+	`)
+
+	// tryAcquireQueuedSlotScript 排队者轮询：清理失联排队者，排名在空闲槽位数以内时获得槽位
+	// KEYS[1] = 槽位有序集合, KEYS[2] = queue, KEYS[3] = hb, KEYS[4] = meta, KEYS[5] = waits
+	// ARGV[1] = requestID, ARGV[2] = maxConcurrency, ARGV[3] = 槽位 TTL（秒）, ARGV[4] = 失联阈值（毫秒）
+	// ARGV[5] = 样本数上限, ARGV[6] = 样本保留时间（秒）, ARGV[7] = 优先级分段基数
+	// 返回 {1, 0} 获得槽位；{0, position} 仍在排队；{0, 0} 已不在队列中
+	tryAcquireQueuedSlotScript = redis.NewScript(`
+		local t = redis.call('TIME')
+		local nowSec = tonumber(t[1])
+		local nowMs = nowSec * 1000 + math.floor(tonumber(t[2]) / 1000)
+		local reqID = ARGV[1]
+
+		local stale = redis.call('ZRANGEBYSCORE', KEYS[3], '-inf', nowMs - tonumber(ARGV[4]))
+		for _, id in ipairs(stale) do
+			if id ~= reqID then
+				redis.call('ZREM', KEYS[2], id)
+				redis.call('ZREM', KEYS[3], id)
+				redis.call('HDEL', KEYS[4], 't:' .. id)
+			end
+		end
+
+		local score = redis.call('ZSCORE', KEYS[2], reqID)
+		if score == false then
+			return {0, 0}
+		end
+		redis.call('ZADD', KEYS[3], string.format('%.0f', nowMs), reqID)
+
+		local slotTTL = tonumber(ARGV[3])
+		redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', nowSec - slotTTL)
+		local free = tonumber(ARGV[2]) - redis.call('ZCARD', KEYS[1])
+		local rank = redis.call('ZRANK', KEYS[2], reqID)
+		if rank >= free then
+			return {0, rank + 1}
+		end
+
+		redis.call('ZADD', KEYS[1], nowSec, reqID)
+		redis.call('EXPIRE', KEYS[1], slotTTL)
+		redis.call('ZREM', KEYS[2], reqID)
+		redis.call('ZREM', KEYS[3], reqID)
+
+		local tag = math.fmod(tonumber(score), tonumber(ARGV[7]))
+		local vt = tonumber(redis.call('HGET', KEYS[4], 'vt') or '0')
+		if tag > vt then
+			redis.call('HSET', KEYS[4], 'vt', string.format('%.0f', tag))
+		end
+
+		local enqueuedAt = redis.call('HGET', KEYS[4], 't:' .. reqID)
+		if enqueuedAt then
+			redis.call('HDEL', KEYS[4], 't:' .. reqID)
+			redis.call('LPUSH', KEYS[5], string.format('%.0f', nowMs - tonumber(enqueuedAt)))
+			redis.call('LTRIM', KEYS[5], 0, tonumber(ARGV[5]) - 1)
+			redis.call('EXPIRE', KEYS[5], tonumber(ARGV[6]))
+		end
+
+		if redis.call('ZCARD', KEYS[2]) == 0 then
+			redis.call('DEL', KEYS[3], KEYS[4])
+		end
+		return {1, 0}
+	`)
+
+	// leaveSlotQueueScript 离开队列，队列为空时重置公平份额状态
+	// KEYS[1] = queue, KEYS[2] = hb, KEYS[3] = meta
+	// ARGV[1] = requestID
+	leaveSlotQueueScript = redis.NewScript(`
+		redis.call('ZREM', KEYS[1], ARGV[1])
+		redis.call('ZREM', KEYS[2], ARGV[1])
+		redis.call('HDEL', KEYS[3], 't:' .. ARGV[1])
+		if redis.call('ZCARD', KEYS[1]) == 0 then
+			redis.call('DEL', KEYS[2], KEYS[3])
+		end
+		return 1
+	`)
+)
+
+type slotWaitQueueCache struct {
+	rdb             *redis.Client
+	slotTTLSeconds  int
+	queueTTLSeconds int
+}
+
+// NewSlotWaitQueueCache 创建账号槽位公平排队队列
+// slotTTLMinutes: 槽位过期时间（分钟），需与 ConcurrencyCache 保持一致；queueTTLSeconds: 队列键过期时间（秒）
+func NewSlotWaitQueueCache(rdb *redis.Client, slotTTLMinutes int, queueTTLSeconds int) service.SlotWaitQueueCache {
+	if slotTTLMinutes <= 0 {
+		slotTTLMinutes = defaultSlotTTLMinutes
+	}
+	if queueTTLSeconds <= 0 {
+		queueTTLSeconds = slotTTLMinutes * 60
+	}
+	return &slotWaitQueueCache{
+		rdb:             rdb,
+		slotTTLSeconds:  slotTTLMinutes * 60,
+		queueTTLSeconds: queueTTLSeconds,
+	}
+}
+
+func slotQueueKey(accountID int64) string {
+	return fmt.Sprintf("%s%d", slotQueueKeyPrefix, accountID)
+}
+
+func slotQueueHBKey(accountID int64) string {
+	return fmt.Sprintf("%s%d", slotQueueHBKeyPrefix, accountID)
+}
+
+func slotQueueMetaKey(accountID int64) string {
+	return fmt.Sprintf("%s%d", slotQueueMetaKeyPrefix, accountID)
+}
+
+func slotQueueWaitKey(accountID int64) string {
+	return fmt.Sprintf("%s%d", slotQueueWaitKeyPrefix, accountID)
+}
+
+func (c *slotWaitQueueCache) EnqueueAccountWaiter(ctx context.Context, accountID int64, requestID string, waiter service.SlotWaiter) (int, error) {
+	weight := waiter.Weight
+	if weight <= 0 {
+		weight = 1
+	}
+	band := int64(service.QueuePriorityMax-waiter.Priority) * slotQueuePriorityBand
+	keys := []string{slotQueueKey(accountID), slotQueueHBKey(accountID), slotQueueMetaKey(accountID)}
+	return enqueueSlotWaiterScript.Run(ctx, c.rdb, keys,
+		requestID, waiter.UserID, slotQueueTagUnit/weight, band, c.queueTTLSeconds,
+	).Int()
+}
+
+func (c *slotWaitQueueCache) TryAcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, int, error) {
+	keys := []string{
+		accountSlotKey(accountID),
+		slotQueueKey(accountID),
+		slotQueueHBKey(accountID),
+		slotQueueMetaKey(accountID),
+		slotQueueWaitKey(accountID),
+	}
+	result, err := tryAcquireQueuedSlotScript.Run(ctx, c.rdb, keys,
+		requestID, maxConcurrency, c.slotTTLSeconds, slotQueueStaleMs,
+		slotQueueWaitSamples, slotQueueWaitTTLSeconds, slotQueuePriorityBand,
+	).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("unexpected queue acquire result: %v", result)
+	}
+	return result[0] == 1, int(result[1]), nil
+}
+
+func (c *slotWaitQueueCache) LeaveAccountQueue(ctx context.Context, accountID int64, requestID string) error {
+	keys := []string{slotQueueKey(accountID), slotQueueHBKey(accountID), slotQueueMetaKey(accountID)}
+	return leaveSlotQueueScript.Run(ctx, c.rdb, keys, requestID).Err()
+}
+
+func (c *slotWaitQueueCache) GetAccountQueueSnapshots(ctx context.Context, accountIDs []int64) (map[int64]*service.SlotQueueSnapshot, error) {
+	if len(accountIDs) == 0 {
+		return map[int64]*service.SlotQueueSnapshot{}, nil
+	}
+
+	pipe := c.rdb.Pipeline()
+	depthCmds := make([]*redis.IntCmd, len(accountIDs))
+	waitCmds := make([]*redis.StringSliceCmd, len(accountIDs))
+	for i, id := range accountIDs {
+		depthCmds[i] = pipe.ZCard(ctx, slotQueueKey(id))
+		waitCmds[i] = pipe.LRange(ctx, slotQueueWaitKey(id), 0, -1)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	out := make(map[int64]*service.SlotQueueSnapshot, len(accountIDs))
+	for i, id := range accountIDs {
+		snapshot := &service.SlotQueueSnapshot{Depth: int(depthCmds[i].Val())}
+		for _, raw := range waitCmds[i].Val() {
+			if v, err := strconv.ParseInt(raw, 10, 64); err == nil {
+				snapshot.WaitMs = append(snapshot.WaitMs, v)
+			}
+		}
+		if snapshot.Depth > 0 || len(snapshot.WaitMs) > 0 {
+			out[id] = snapshot
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,117 @@
+//go:build integration
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SlotWaitQueueCacheSuite struct {
+	IntegrationRedisSuite
+	queue service.SlotWaitQueueCache
+	slots service.ConcurrencyCache
+}
+
+func (s *SlotWaitQueueCacheSuite) SetupTest() {
+	s.IntegrationRedisSuite.SetupTest()
+	s.queue = NewSlotWaitQueueCache(s.rdb, testSlotTTLMinutes, 60)
+	s.slots = NewConcurrencyCache(s.rdb, testSlotTTLMinutes, 60)
+}
+
+func (s *SlotWaitQueueCacheSuite) enqueue(accountID int64, requestID string, waiter service.SlotWaiter) int {
+	pos, err := s.queue.EnqueueAccountWaiter(s.ctx, accountID, requestID, waiter)
+	require.NoError(s.T(), err, "EnqueueAccountWaiter")
+	return pos
+}
+
+func (s *SlotWaitQueueCacheSuite) TestFairShareInterleavesUsers() {
+	heavy := service.SlotWaiter{UserID: 1, Weight: 1}
+	light := service.SlotWaiter{UserID: 2, Weight: 1}
+	require.Equal(s.T(), 1, s.enqueue(1, "a-heavy-1", heavy))
+	require.Equal(s.T(), 2, s.enqueue(1, "a-heavy-2", heavy))
+	require.Equal(s.T(), 3, s.enqueue(1, "a-heavy-3", heavy))
+	// 轻度用户后到，但只排在重度用户第一个请求之后
+	require.Equal(s.T(), 2, s.enqueue(1, "b-light-1", light))
+
+	// 权重 2 的用户每次入队标签增量减半
+	require.Equal(s.T(), 1, s.enqueue(2, "a-user-1", service.SlotWaiter{UserID: 1, Weight: 1}))
+	require.Equal(s.T(), 1, s.enqueue(2, "b-user-2", service.SlotWaiter{UserID: 2, Weight: 2}))
+	require.Equal(s.T(), 3, s.enqueue(2, "c-user-2", service.SlotWaiter{UserID: 2, Weight: 2}))
+}
+
+func (s *SlotWaitQueueCacheSuite) TestPriorityServedFirst() {
+	require.Equal(s.T(), 1, s.enqueue(1, "normal", service.SlotWaiter{UserID: 1, Weight: 1}))
+	require.Equal(s.T(), 2, s.enqueue(1, "low", service.SlotWaiter{UserID: 2, Weight: 1, Priority: -1}))
+	require.Equal(s.T(), 1, s.enqueue(1, "high", service.SlotWaiter{UserID: 3, Weight: 1, Priority: 5}))
+}
+
+func (s *SlotWaitQueueCacheSuite) TestTryAcquireInQueueOrder() {
+	s.enqueue(1, "first", service.SlotWaiter{UserID: 1, Weight: 1})
+	s.enqueue(1, "second", service.SlotWaiter{UserID: 1, Weight: 1})
+
+	acquired, pos, err := s.queue.TryAcquireAccountSlot(s.ctx, 1, 1, "second")
+	require.NoError(s.T(), err)
+	require.False(s.T(), acquired, "second waiter must not take the only slot")
+	require.Equal(s.T(), 2, pos)
+
+	// 新请求不能越过排队者
+	ok, err := s.slots.AcquireAccountSlot(s.ctx, 1, 1, "newcomer")
+	require.NoError(s.T(), err)
+	require.False(s.T(), ok, "newcomer must not jump the queue")
+
+	acquired, _, err = s.queue.TryAcquireAccountSlot(s.ctx, 1, 1, "first")
+	require.NoError(s.T(), err)
+	require.True(s.T(), acquired)
+
+	acquired, pos, err = s.queue.TryAcquireAccountSlot(s.ctx, 1, 1, "second")
+	require.NoError(s.T(), err)
+	require.False(s.T(), acquired, "slot is held by first")
+	require.Equal(s.T(), 1, pos)
+
+	require.NoError(s.T(), s.slots.ReleaseAccountSlot(s.ctx, 1, "first"))
+	acquired, _, err = s.queue.TryAcquireAccountSlot(s.ctx, 1, 1, "second")
+	require.NoError(s.T(), err)
+	require.True(s.T(), acquired)
+
+	// 队列清空后恢复普通获取
+	require.NoError(s.T(), s.slots.ReleaseAccountSlot(s.ctx, 1, "second"))
+	ok, err = s.slots.AcquireAccountSlot(s.ctx, 1, 1, "newcomer")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+}
+
+func (s *SlotWaitQueueCacheSuite) TestLeaveAndRequeue() {
+	s.enqueue(1, "req", service.SlotWaiter{UserID: 1, Weight: 1})
+	require.NoError(s.T(), s.queue.LeaveAccountQueue(s.ctx, 1, "req"))
+
+	acquired, pos, err := s.queue.TryAcquireAccountSlot(s.ctx, 1, 1, "req")
+	require.NoError(s.T(), err)
+	require.False(s.T(), acquired)
+	require.Zero(s.T(), pos, "left waiters report position 0")
+
+	exists, err := s.rdb.Exists(s.ctx, slotQueueMetaKey(1), slotQueueHBKey(1)).Result()
+	require.NoError(s.T(), err)
+	require.Zero(s.T(), exists, "empty queue resets fair-share state")
+}
+
+func (s *SlotWaitQueueCacheSuite) TestSnapshots() {
+	s.enqueue(1, "served", service.SlotWaiter{UserID: 1, Weight: 1})
+	acquired, _, err := s.queue.TryAcquireAccountSlot(s.ctx, 1, 1, "served")
+	require.NoError(s.T(), err)
+	require.True(s.T(), acquired)
+	s.enqueue(1, "waiting", service.SlotWaiter{UserID: 2, Weight: 1})
+
+	snaps, err := s.queue.GetAccountQueueSnapshots(s.ctx, []int64{1, 2})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), snaps, 1, "accounts without queue activity are omitted")
+	require.Equal(s.T(), 1, snaps[1].Depth)
+	require.Len(s.T(), snaps[1].WaitMs, 1)
+}
+
+func TestSlotWaitQueueCacheSuite(t *testing.T) {
+	suite.Run(t, new(SlotWaitQueueCacheSuite))
+}
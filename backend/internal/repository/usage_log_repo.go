@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -22,7 +23,7 @@ import (
 	"github.com/lib/pq"
 )
 
-const usageLogSelectColumns = "id, user_id, api_key_id, account_id, request_id, model, group_id, subscription_id, input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cache_creation_5m_tokens, cache_creation_1h_tokens, input_cost, output_cost, cache_creation_cost, cache_read_cost, total_cost, actual_cost, rate_multiplier, account_rate_multiplier, billing_type, stream, duration_ms, first_token_ms, user_agent, ip_address, image_count, image_size, created_at"
+const usageLogSelectColumns = "id, user_id, api_key_id, account_id, request_id, model, group_id, subscription_id, input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cache_creation_5m_tokens, cache_creation_1h_tokens, input_cost, output_cost, cache_creation_cost, cache_read_cost, total_cost, actual_cost, rate_multiplier, account_rate_multiplier, billing_type, stream, duration_ms, first_token_ms, user_agent, ip_address, image_count, image_size, cache_served, cache_saved_cost, hedged, hedge_backup_won, client_disconnected, pricing_tier, service_tier, cost_detail, organization_id, created_at"
 
 type usageLogRepository struct {
 	client *dbent.Client
@@ -114,6 +115,15 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 			ip_address,
 			image_count,
 			image_size,
+			cache_served,
+			cache_saved_cost,
+			hedged,
+			hedge_backup_won,
+			client_disconnected,
+			pricing_tier,
+			service_tier,
+			cost_detail,
+			organization_id,
 			created_at
 		) VALUES (
 			$1, $2, $3, $4, $5,
@@ -121,7 +131,8 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 			$8, $9, $10, $11,
 			$12, $13,
 			$14, $15, $16, $17, $18, $19,
-			$20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30
+			$20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34,
+			$35, $36, $37, $38, $39
 		)
 		ON CONFLICT (request_id, api_key_id) DO NOTHING
 		RETURNING id, created_at
@@ -134,6 +145,22 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 	userAgent := nullString(log.UserAgent)
 	ipAddress := nullString(log.IPAddress)
 	imageSize := nullString(log.ImageSize)
+	pricingTier := log.PricingTier
+	if pricingTier == "" {
+		pricingTier = service.PricingTierStandard
+	}
+	serviceTier := log.ServiceTier
+	if serviceTier == "" {
+		serviceTier = service.ServiceTierStandard
+	}
+	var costDetail any
+	if log.CostDetail != nil {
+		raw, err := json.Marshal(log.CostDetail)
+		if err != nil {
+			return false, err
+		}
+		costDetail = raw
+	}
 
 	var requestIDArg any
 	if requestID != "" {
@@ -170,6 +197,15 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 		ipAddress,
 		log.ImageCount,
 		imageSize,
+		log.CacheServed,
+		log.CacheSavedCost,
+		log.Hedged,
+		log.HedgeBackupWon,
+		log.ClientDisconnected,
+		pricingTier,
+		serviceTier,
+		costDetail,
+		nullInt64(log.OrganizationID),
 		createdAt,
 	}
 	if err := scanSingleRow(ctx, sqlq, query, args, &log.ID, &log.CreatedAt); err != nil {
@@ -891,6 +927,37 @@ func (r *usageLogRepository) GetAccountWindowStats(ctx context.Context, accountI
 	return stats, nil
 }
 
+// GetAccountFirstTokenP50 获取账号自 since 起首字延迟的中位数（毫秒）
+func (r *usageLogRepository) GetAccountFirstTokenP50(ctx context.Context, accountIDs []int64, since time.Time) (map[int64]float64, error) {
+	result := make(map[int64]float64, len(accountIDs))
+	if len(accountIDs) == 0 {
+		return result, nil
+	}
+	query := `
+		SELECT account_id, percentile_cont(0.5) WITHIN GROUP (ORDER BY first_token_ms)
+		FROM usage_logs
+		WHERE account_id = ANY($1) AND created_at >= $2 AND first_token_ms IS NOT NULL
+		GROUP BY account_id
+	`
+	rows, err := r.sql.QueryContext(ctx, query, pq.Array(accountIDs), since)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var accountID int64
+		var p50 float64
+		if err := rows.Scan(&accountID, &p50); err != nil {
+			return nil, err
+		}
+		result[accountID] = p50
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // TrendDataPoint represents a single point in trend data
 type TrendDataPoint = usagestats.TrendDataPoint
 
@@ -1238,6 +1305,10 @@ func (r *usageLogRepository) ListWithFilters(ctx context.Context, params paginat
 		conditions = append(conditions, fmt.Sprintf("billing_type = $%d", len(args)+1))
 		args = append(args, int16(*filters.BillingType))
 	}
+	if filters.CacheServed != nil {
+		conditions = append(conditions, fmt.Sprintf("cache_served = $%d", len(args)+1))
+		args = append(args, *filters.CacheServed)
+	}
 	if filters.StartTime != nil {
 		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)+1))
 		args = append(args, *filters.StartTime)
@@ -1618,6 +1689,10 @@ func (r *usageLogRepository) GetStatsWithFilters(ctx context.Context, filters Us
 		conditions = append(conditions, fmt.Sprintf("billing_type = $%d", len(args)+1))
 		args = append(args, int16(*filters.BillingType))
 	}
+	if filters.CacheServed != nil {
+		conditions = append(conditions, fmt.Sprintf("cache_served = $%d", len(args)+1))
+		args = append(args, *filters.CacheServed)
+	}
 	if filters.StartTime != nil {
 		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)+1))
 		args = append(args, *filters.StartTime)
@@ -1636,7 +1711,9 @@ func (r *usageLogRepository) GetStatsWithFilters(ctx context.Context, filters Us
 			COALESCE(SUM(total_cost), 0) as total_cost,
 			COALESCE(SUM(actual_cost), 0) as total_actual_cost,
 			COALESCE(SUM(total_cost * COALESCE(account_rate_multiplier, 1)), 0) as total_account_cost,
-			COALESCE(AVG(duration_ms), 0) as avg_duration_ms
+			COALESCE(AVG(duration_ms), 0) as avg_duration_ms,
+			COUNT(*) FILTER (WHERE cache_served) as cache_served_requests,
+			COALESCE(SUM(cache_saved_cost), 0) as cache_saved_cost
 		FROM usage_logs
 		%s
 	`, buildWhere(conditions))
@@ -1656,6 +1733,8 @@ func (r *usageLogRepository) GetStatsWithFilters(ctx context.Context, filters Us
 		&stats.TotalActualCost,
 		&totalAccountCost,
 		&stats.AverageDurationMs,
+		&stats.CacheServedRequests,
+		&stats.CacheSavedCost,
 	); err != nil {
 		return nil, err
 	}
@@ -2090,6 +2169,15 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 		ipAddress             sql.NullString
 		imageCount            int
 		imageSize             sql.NullString
+		cacheServed           bool
+		cacheSavedCost        float64
+		hedged                bool
+		hedgeBackupWon        bool
+		clientDisconnected    bool
+		pricingTier           string
+		serviceTier           string
+		costDetail            []byte
+		organizationID        sql.NullInt64
 		createdAt             time.Time
 	)
 
@@ -2124,6 +2212,15 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 		&ipAddress,
 		&imageCount,
 		&imageSize,
+		&cacheServed,
+		&cacheSavedCost,
+		&hedged,
+		&hedgeBackupWon,
+		&clientDisconnected,
+		&pricingTier,
+		&serviceTier,
+		&costDetail,
+		&organizationID,
 		&createdAt,
 	); err != nil {
 		return nil, err
@@ -2152,9 +2249,23 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 		BillingType:           int8(billingType),
 		Stream:                stream,
 		ImageCount:            imageCount,
+		CacheServed:           cacheServed,
+		CacheSavedCost:        cacheSavedCost,
+		Hedged:                hedged,
+		HedgeBackupWon:        hedgeBackupWon,
+		ClientDisconnected:    clientDisconnected,
+		PricingTier:           pricingTier,
+		ServiceTier:           serviceTier,
 		CreatedAt:             createdAt,
 	}
 
+	if len(costDetail) > 0 {
+		var detail service.UsageCostDetail
+		if err := json.Unmarshal(costDetail, &detail); err == nil {
+			log.CostDetail = &detail
+		}
+	}
+
 	if requestID.Valid {
 		log.RequestID = requestID.String
 	}
@@ -2166,6 +2277,7 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 		value := subscriptionID.Int64
 		log.SubscriptionID = &value
 	}
+	log.OrganizationID = nullInt64Ptr(organizationID)
 	if durationMs.Valid {
 		value := int(durationMs.Int64)
 		log.DurationMs = &value
@@ -2255,6 +2367,13 @@ func nullInt(v *int) sql.NullInt64 {
 	return sql.NullInt64{Int64: int64(*v), Valid: true}
 }
 
+func nullFloat64(v *float64) sql.NullFloat64 {
+	if v == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *v, Valid: true}
+}
+
 func nullFloat64Ptr(v sql.NullFloat64) *float64 {
 	if !v.Valid {
 		return nil
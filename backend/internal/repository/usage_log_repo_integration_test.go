@@ -130,6 +130,49 @@ func (s *UsageLogRepoSuite) TestGetByID_ReturnsAccountRateMultiplier() {
 	s.Require().InEpsilon(0.5, *got.AccountRateMultiplier, 0.0001)
 }
 
+func (s *UsageLogRepoSuite) TestGetByID_ReturnsPricingTierAndCostDetail() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "getbyid-tier@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-getbyid-tier", Name: "k"})
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-getbyid-tier"})
+
+	log := &service.UsageLog{
+		UserID:                user.ID,
+		APIKeyID:              apiKey.ID,
+		AccountID:             account.ID,
+		RequestID:             uuid.New().String(),
+		Model:                 "claude-sonnet-4",
+		InputTokens:           250000,
+		CacheCreationTokens:   300,
+		CacheCreation5mTokens: 100,
+		CacheCreation1hTokens: 200,
+		TotalCost:             1.5,
+		ActualCost:            1.5,
+		PricingTier:           "above_200k",
+		ServiceTier:           service.ServiceTierPriority,
+		CostDetail:            &service.UsageCostDetail{ContextTokens: 250300, TierThreshold: 200000, InputPrice: 6e-6},
+		CreatedAt:             timezone.Today().Add(2 * time.Hour),
+	}
+	_, err := s.repo.Create(s.ctx, log)
+	s.Require().NoError(err)
+
+	got, err := s.repo.GetByID(s.ctx, log.ID)
+	s.Require().NoError(err)
+	s.Require().Equal("above_200k", got.PricingTier)
+	s.Require().Equal(service.ServiceTierPriority, got.ServiceTier)
+	s.Require().Equal(200, got.CacheCreation1hTokens)
+	s.Require().NotNil(got.CostDetail)
+	s.Require().Equal(200000, got.CostDetail.TierThreshold)
+	s.Require().InDelta(6e-6, got.CostDetail.InputPrice, 1e-15)
+
+	// 未指定阶梯的记录落库为 standard，明细为空
+	plain := s.createUsageLog(user, apiKey, account, 10, 20, 0.1, timezone.Today().Add(3*time.Hour))
+	got, err = s.repo.GetByID(s.ctx, plain.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(service.PricingTierStandard, got.PricingTier)
+	s.Require().Equal(service.ServiceTierStandard, got.ServiceTier)
+	s.Require().Nil(got.CostDetail)
+}
+
 // --- Delete ---
 
 func (s *UsageLogRepoSuite) TestDelete() {
@@ -60,6 +60,8 @@ func (r *userRepository) Create(ctx context.Context, userIn *service.User) error
 		SetBalance(userIn.Balance).
 		SetConcurrency(userIn.Concurrency).
 		SetStatus(userIn.Status).
+		SetQueuePriority(userIn.QueuePriority).
+		SetQueueWeight(userIn.QueueWeight).
 		Save(ctx)
 	if err != nil {
 		return translatePersistenceError(err, nil, service.ErrEmailExists)
@@ -69,6 +71,16 @@ func (r *userRepository) Create(ctx context.Context, userIn *service.User) error
 		return err
 	}
 
+	// 初始余额同样记入账本，保证账本合计与余额一致
+	if created.Balance != 0 {
+		if _, err := txClient.ExecContext(ctx, `
+			INSERT INTO balance_transactions (user_id, type, amount, balance_after)
+			VALUES ($1, $2, $3, $3)
+		`, created.ID, service.BalanceTxTypeInitial, created.Balance); err != nil {
+			return err
+		}
+	}
+
 	if tx != nil {
 		if err := tx.Commit(); err != nil {
 			return err
@@ -133,15 +145,17 @@ func (r *userRepository) Update(ctx context.Context, userIn *service.User) error
 		txClient = r.client
 	}
 
+	// 余额不在此处写入：只能经 ApplyBalanceTransaction 变更并记账，也避免覆盖并发扣费
 	updated, err := txClient.User.UpdateOneID(userIn.ID).
 		SetEmail(userIn.Email).
 		SetUsername(userIn.Username).
 		SetNotes(userIn.Notes).
 		SetPasswordHash(userIn.PasswordHash).
 		SetRole(userIn.Role).
-		SetBalance(userIn.Balance).
 		SetConcurrency(userIn.Concurrency).
 		SetStatus(userIn.Status).
+		SetQueuePriority(userIn.QueuePriority).
+		SetQueueWeight(userIn.QueueWeight).
 		Save(ctx)
 	if err != nil {
 		return translatePersistenceError(err, service.ErrUserNotFound, service.ErrEmailExists)
@@ -157,6 +171,7 @@ func (r *userRepository) Update(ctx context.Context, userIn *service.User) error
 		}
 	}
 
+	userIn.Balance = updated.Balance
 	userIn.UpdatedAt = updated.UpdatedAt
 	return nil
 }
@@ -318,34 +333,51 @@ func (r *userRepository) filterUsersByAttributes(ctx context.Context, attrs map[
 	return result, nil
 }
 
-func (r *userRepository) UpdateBalance(ctx context.Context, id int64, amount float64) error {
-	client := clientFromContext(ctx, r.client)
-	n, err := client.User.Update().Where(dbuser.IDEQ(id)).AddBalance(amount).Save(ctx)
-	if err != nil {
-		return translatePersistenceError(err, service.ErrUserNotFound, nil)
+// ApplyBalanceTransaction 原子地变更余额并追加一条余额流水，回填 ID、BalanceAfter 与 CreatedAt。
+// 处于事务上下文时复用事务连接，与调用方的其他写入（如使用日志）一同提交。
+func (r *userRepository) ApplyBalanceTransaction(ctx context.Context, txn *service.BalanceTransaction) error {
+	if txn == nil {
+		return nil
 	}
-	if n == 0 {
+	sqlq := r.sql
+	if tx := dbent.TxFromContext(ctx); tx != nil {
+		sqlq = tx.Client()
+	}
+	err := scanSingleRow(ctx, sqlq, applyBalanceTransactionSQL, balanceTransactionArgs(txn), &txn.ID, &txn.BalanceAfter, &txn.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
 		return service.ErrUserNotFound
 	}
-	return nil
+	return err
 }
 
-// DeductBalance 扣除用户余额
-// 透支策略：允许余额变为负数，确保当前请求能够完成
-// 中间件会阻止余额 <= 0 的用户发起后续请求
-func (r *userRepository) DeductBalance(ctx context.Context, id int64, amount float64) error {
-	client := clientFromContext(ctx, r.client)
-	n, err := client.User.Update().
-		Where(dbuser.IDEQ(id)).
-		AddBalance(-amount).
-		Save(ctx)
-	if err != nil {
-		return err
-	}
-	if n == 0 {
-		return service.ErrUserNotFound
+// applyBalanceTransactionSQL 在同一语句内更新余额并追加流水，保证两者原子一致。
+// 参数：$1 user_id, $2 amount, $3 type, $4 usage_log_id, $5 redeem_code_id, $6 promo_code_id, $7 admin_user_id, $8 notes
+const applyBalanceTransactionSQL = `
+	WITH updated AS (
+		UPDATE users
+		SET balance = balance + $2::numeric, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, balance
+	)
+	INSERT INTO balance_transactions (
+		user_id, type, amount, balance_after,
+		usage_log_id, redeem_code_id, promo_code_id, admin_user_id, notes
+	)
+	SELECT id, $3::varchar, $2::numeric, balance, $4::bigint, $5::bigint, $6::bigint, $7::bigint, $8::text FROM updated
+	RETURNING id, balance_after, created_at
+`
+
+func balanceTransactionArgs(txn *service.BalanceTransaction) []any {
+	return []any{
+		txn.UserID,
+		txn.Amount,
+		txn.Type,
+		nullInt64(txn.UsageLogID),
+		nullInt64(txn.RedeemCodeID),
+		nullInt64(txn.PromoCodeID),
+		nullInt64(txn.AdminUserID),
+		txn.Notes,
 	}
-	return nil
 }
 
 func (r *userRepository) UpdateConcurrency(ctx context.Context, id int64, amount int) error {
@@ -254,71 +254,43 @@ func (s *UserRepoSuite) TestListWithFilters_CombinedFilters() {
 
 // --- Balance operations ---
 
-func (s *UserRepoSuite) TestUpdateBalance() {
+func (s *UserRepoSuite) applyBalance(userID int64, amount float64) (*service.BalanceTransaction, error) {
+	txn := &service.BalanceTransaction{UserID: userID, Type: service.BalanceTxTypeAdminAdjust, Amount: amount}
+	return txn, s.repo.ApplyBalanceTransaction(s.ctx, txn)
+}
+
+func (s *UserRepoSuite) TestApplyBalanceTransaction() {
 	user := s.mustCreateUser(&service.User{Email: "bal@test.com", Balance: 10})
 
-	err := s.repo.UpdateBalance(s.ctx, user.ID, 2.5)
-	s.Require().NoError(err, "UpdateBalance")
+	txn, err := s.applyBalance(user.ID, 2.5)
+	s.Require().NoError(err, "ApplyBalanceTransaction")
+	s.Require().NotZero(txn.ID, "ledger entry should be written")
+	s.Require().InDelta(12.5, txn.BalanceAfter, 1e-6)
 
 	got, err := s.repo.GetByID(s.ctx, user.ID)
 	s.Require().NoError(err)
 	s.Require().InDelta(12.5, got.Balance, 1e-6)
 }
 
-func (s *UserRepoSuite) TestUpdateBalance_Negative() {
+func (s *UserRepoSuite) TestApplyBalanceTransaction_Negative() {
 	user := s.mustCreateUser(&service.User{Email: "balneg@test.com", Balance: 10})
 
-	err := s.repo.UpdateBalance(s.ctx, user.ID, -3)
-	s.Require().NoError(err, "UpdateBalance with negative")
+	_, err := s.applyBalance(user.ID, -3)
+	s.Require().NoError(err, "ApplyBalanceTransaction with negative")
 
 	got, err := s.repo.GetByID(s.ctx, user.ID)
 	s.Require().NoError(err)
 	s.Require().InDelta(7.0, got.Balance, 1e-6)
 }
 
-func (s *UserRepoSuite) TestDeductBalance() {
-	user := s.mustCreateUser(&service.User{Email: "deduct@test.com", Balance: 10})
-
-	err := s.repo.DeductBalance(s.ctx, user.ID, 5)
-	s.Require().NoError(err, "DeductBalance")
-
-	got, err := s.repo.GetByID(s.ctx, user.ID)
-	s.Require().NoError(err)
-	s.Require().InDelta(5.0, got.Balance, 1e-6)
-}
-
-func (s *UserRepoSuite) TestDeductBalance_InsufficientFunds() {
-	user := s.mustCreateUser(&service.User{Email: "insuf@test.com", Balance: 5})
-
-	// 透支策略：允许扣除超过余额的金额
-	err := s.repo.DeductBalance(s.ctx, user.ID, 999)
-	s.Require().NoError(err, "DeductBalance should allow overdraft")
-
-	// 验证余额变为负数
-	got, err := s.repo.GetByID(s.ctx, user.ID)
-	s.Require().NoError(err)
-	s.Require().InDelta(-994.0, got.Balance, 1e-6, "Balance should be negative after overdraft")
-}
-
-func (s *UserRepoSuite) TestDeductBalance_ExactAmount() {
-	user := s.mustCreateUser(&service.User{Email: "exact@test.com", Balance: 10})
-
-	err := s.repo.DeductBalance(s.ctx, user.ID, 10)
-	s.Require().NoError(err, "DeductBalance exact amount")
-
-	got, err := s.repo.GetByID(s.ctx, user.ID)
-	s.Require().NoError(err)
-	s.Require().InDelta(0.0, got.Balance, 1e-6)
-}
-
-func (s *UserRepoSuite) TestDeductBalance_AllowsOverdraft() {
+func (s *UserRepoSuite) TestApplyBalanceTransaction_AllowsOverdraft() {
 	user := s.mustCreateUser(&service.User{Email: "overdraft@test.com", Balance: 5.0})
 
-	// 扣除超过余额的金额 - 应该成功
-	err := s.repo.DeductBalance(s.ctx, user.ID, 10.0)
-	s.Require().NoError(err, "DeductBalance should allow overdraft")
+	// 透支策略：允许扣除超过余额的金额
+	txn, err := s.applyBalance(user.ID, -10.0)
+	s.Require().NoError(err, "ApplyBalanceTransaction should allow overdraft")
+	s.Require().InDelta(-5.0, txn.BalanceAfter, 1e-6)
 
-	// 验证余额为负
 	got, err := s.repo.GetByID(s.ctx, user.ID)
 	s.Require().NoError(err)
 	s.Require().InDelta(-5.0, got.Balance, 1e-6, "Balance should be -5.0 after overdraft")
@@ -485,19 +457,21 @@ func (s *UserRepoSuite) TestCRUD_And_Filters_And_AtomicUpdates() {
 	s.Require().NoError(err, "GetByID after update")
 	s.Require().Equal("Alice2", got2.Username, "Update did not persist")
 
-	s.Require().NoError(s.repo.UpdateBalance(s.ctx, user1.ID, 2.5), "UpdateBalance")
+	_, err = s.applyBalance(user1.ID, 2.5)
+	s.Require().NoError(err, "ApplyBalanceTransaction")
 	got3, err := s.repo.GetByID(s.ctx, user1.ID)
-	s.Require().NoError(err, "GetByID after UpdateBalance")
+	s.Require().NoError(err, "GetByID after ApplyBalanceTransaction")
 	s.Require().InDelta(12.5, got3.Balance, 1e-6)
 
-	s.Require().NoError(s.repo.DeductBalance(s.ctx, user1.ID, 5), "DeductBalance")
+	_, err = s.applyBalance(user1.ID, -5)
+	s.Require().NoError(err, "ApplyBalanceTransaction deduct")
 	got4, err := s.repo.GetByID(s.ctx, user1.ID)
-	s.Require().NoError(err, "GetByID after DeductBalance")
+	s.Require().NoError(err, "GetByID after deduct")
 	s.Require().InDelta(7.5, got4.Balance, 1e-6)
 
 	// 透支策略：允许扣除超过余额的金额
-	err = s.repo.DeductBalance(s.ctx, user1.ID, 999)
-	s.Require().NoError(err, "DeductBalance should allow overdraft")
+	_, err = s.applyBalance(user1.ID, -999)
+	s.Require().NoError(err, "ApplyBalanceTransaction should allow overdraft")
 	gotOverdraft, err := s.repo.GetByID(s.ctx, user1.ID)
 	s.Require().NoError(err, "GetByID after overdraft")
 	s.Require().Less(gotOverdraft.Balance, 0.0, "Balance should be negative after overdraft")
@@ -515,10 +489,10 @@ func (s *UserRepoSuite) TestCRUD_And_Filters_And_AtomicUpdates() {
 	s.Require().Equal(user2.ID, users[0].ID, "ListWithFilters result mismatch")
 }
 
-// --- UpdateBalance/UpdateConcurrency 影响行数校验测试 ---
+// --- ApplyBalanceTransaction/UpdateConcurrency 影响行数校验测试 ---
 
-func (s *UserRepoSuite) TestUpdateBalance_NotFound() {
-	err := s.repo.UpdateBalance(s.ctx, 999999, 10.0)
+func (s *UserRepoSuite) TestApplyBalanceTransaction_NotFound() {
+	_, err := s.applyBalance(999999, 10.0)
 	s.Require().Error(err, "expected error for non-existent user")
 	s.Require().ErrorIs(err, service.ErrUserNotFound)
 }
@@ -528,10 +502,3 @@ func (s *UserRepoSuite) TestUpdateConcurrency_NotFound() {
 	s.Require().Error(err, "expected error for non-existent user")
 	s.Require().ErrorIs(err, service.ErrUserNotFound)
 }
-
-func (s *UserRepoSuite) TestDeductBalance_NotFound() {
-	err := s.repo.DeductBalance(s.ctx, 999999, 5)
-	s.Require().Error(err, "expected error for non-existent user")
-	// DeductBalance 在用户不存在时返回 ErrUserNotFound
-	s.Require().ErrorIs(err, service.ErrUserNotFound)
-}
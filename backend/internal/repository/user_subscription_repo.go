@@ -381,8 +381,6 @@ func (r *userSubscriptionRepository) BatchUpdateExpiredStatus(ctx context.Contex
 	return int64(n), err
 }
 
-// Extra repository helpers (currently used only by integration tests).
-
 func (r *userSubscriptionRepository) ListExpired(ctx context.Context) ([]service.UserSubscription, error) {
 	client := clientFromContext(ctx, r.client)
 	subs, err := client.UserSubscription.Query().
@@ -390,6 +388,8 @@ func (r *userSubscriptionRepository) ListExpired(ctx context.Context) ([]service
 			usersubscription.StatusEQ(service.SubscriptionStatusActive),
 			usersubscription.ExpiresAtLTE(time.Now()),
 		).
+		WithUser().
+		WithGroup().
 		All(ctx)
 	if err != nil {
 		return nil, err
@@ -397,6 +397,8 @@ func (r *userSubscriptionRepository) ListExpired(ctx context.Context) ([]service
 	return userSubscriptionEntitiesToService(subs), nil
 }
 
+// Extra repository helpers (currently used only by integration tests).
+
 func (r *userSubscriptionRepository) CountByGroupID(ctx context.Context, groupID int64) (int64, error) {
 	client := clientFromContext(ctx, r.client)
 	count, err := client.UserSubscription.Query().Where(usersubscription.GroupIDEQ(groupID)).Count(ctx)
@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type webhookRepository struct {
+	sql sqlExecutor
+}
+
+func NewWebhookRepository(sqlDB *sql.DB) service.WebhookRepository {
+	return &webhookRepository{sql: sqlDB}
+}
+
+const webhookEndpointColumns = `
+	id, name, url, secret, format, events, enabled, created_at, updated_at
+`
+
+const webhookDeliveryColumns = `
+	id, endpoint_id, event_type, payload, status, attempts,
+	response_status, response_body, error_message,
+	next_attempt_at, delivered_at, created_at, updated_at
+`
+
+func (r *webhookRepository) ListEndpoints(ctx context.Context) ([]service.WebhookEndpoint, error) {
+	rows, err := r.sql.QueryContext(ctx, `SELECT `+webhookEndpointColumns+` FROM webhook_endpoints ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []service.WebhookEndpoint
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *endpoint)
+	}
+	return out, rows.Err()
+}
+
+func (r *webhookRepository) GetEndpoint(ctx context.Context, id int64) (*service.WebhookEndpoint, error) {
+	rows, err := r.sql.QueryContext(ctx, `SELECT `+webhookEndpointColumns+` FROM webhook_endpoints WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, service.ErrWebhookEndpointNotFound
+	}
+	endpoint, err := scanWebhookEndpoint(rows)
+	if err != nil {
+		return nil, err
+	}
+	return endpoint, rows.Err()
+}
+
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *service.WebhookEndpoint) error {
+	if endpoint == nil {
+		return nil
+	}
+	events, err := webhookEventsArg(endpoint.Events)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO webhook_endpoints (name, url, secret, format, events, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+	args := []any{endpoint.Name, endpoint.URL, endpoint.Secret, endpoint.Format, events, endpoint.Enabled}
+	return scanSingleRow(ctx, r.sql, query, args, &endpoint.ID, &endpoint.CreatedAt, &endpoint.UpdatedAt)
+}
+
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *service.WebhookEndpoint) error {
+	if endpoint == nil {
+		return nil
+	}
+	events, err := webhookEventsArg(endpoint.Events)
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE webhook_endpoints
+		SET name = $2, url = $3, secret = $4, format = $5, events = $6, enabled = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+	args := []any{endpoint.ID, endpoint.Name, endpoint.URL, endpoint.Secret, endpoint.Format, events, endpoint.Enabled}
+	if err := scanSingleRow(ctx, r.sql, query, args, &endpoint.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return service.ErrWebhookEndpointNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id int64) error {
+	res, err := r.sql.ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return service.ErrWebhookEndpointNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *service.WebhookDelivery) error {
+	if delivery == nil {
+		return nil
+	}
+	query := `
+		INSERT INTO webhook_deliveries (endpoint_id, event_type, payload, status, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	args := []any{
+		delivery.EndpointID,
+		delivery.EventType,
+		string(delivery.Payload),
+		delivery.Status,
+		webhookTimeArg(delivery.NextAttemptAt),
+	}
+	return scanSingleRow(ctx, r.sql, query, args, &delivery.ID, &delivery.CreatedAt, &delivery.UpdatedAt)
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id int64) (*service.WebhookDelivery, error) {
+	rows, err := r.sql.QueryContext(ctx, `SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, service.ErrWebhookDeliveryNotFound
+	}
+	delivery, err := scanWebhookDelivery(rows)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, rows.Err()
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, endpointID int64, status string, params pagination.PaginationParams) ([]service.WebhookDelivery, *pagination.PaginationResult, error) {
+	where := "endpoint_id = $1"
+	args := []any{endpointID}
+	if status != "" {
+		where += " AND status = $2"
+		args = append(args, status)
+	}
+
+	var total int64
+	if err := scanSingleRow(ctx, r.sql, `SELECT COUNT(*) FROM webhook_deliveries WHERE `+where, args, &total); err != nil {
+		return nil, nil, err
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE ` + where +
+		` ORDER BY id DESC LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
+	rows, err := r.sql.QueryContext(ctx, query, append(args, params.Limit(), params.Offset())...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]service.WebhookDelivery, 0, params.Limit())
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, *delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return out, paginationResultFromTotal(total, params), nil
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]service.WebhookDelivery, error) {
+	// SKIP LOCKED + 推后 next_attempt_at：多实例并发领取时互不重复
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+	rows, err := r.sql.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []service.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *delivery)
+	}
+	return out, rows.Err()
+}
+
+func (r *webhookRepository) UpdateDeliveryAttempt(ctx context.Context, delivery *service.WebhookDelivery) error {
+	if delivery == nil {
+		return nil
+	}
+	var responseStatus sql.NullInt64
+	if delivery.ResponseStatus != nil {
+		responseStatus = sql.NullInt64{Int64: int64(*delivery.ResponseStatus), Valid: true}
+	}
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_status = $4, response_body = $5, error_message = $6,
+			next_attempt_at = $7, delivered_at = $8, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+	args := []any{
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		responseStatus,
+		nullString(delivery.ResponseBody),
+		nullString(delivery.ErrorMessage),
+		webhookTimeArg(delivery.NextAttemptAt),
+		webhookTimeArg(delivery.DeliveredAt),
+	}
+	if err := scanSingleRow(ctx, r.sql, query, args, &delivery.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return service.ErrWebhookDeliveryNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := r.sql.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE created_at < $1 AND status <> 'pending'`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func webhookTimeArg(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+func webhookEventsArg(events []string) (string, error) {
+	if events == nil {
+		events = []string{}
+	}
+	raw, err := json.Marshal(events)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func scanWebhookEndpoint(rows *sql.Rows) (*service.WebhookEndpoint, error) {
+	var endpoint service.WebhookEndpoint
+	var events []byte
+	if err := rows.Scan(
+		&endpoint.ID,
+		&endpoint.Name,
+		&endpoint.URL,
+		&endpoint.Secret,
+		&endpoint.Format,
+		&events,
+		&endpoint.Enabled,
+		&endpoint.CreatedAt,
+		&endpoint.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if len(events) > 0 {
+		if err := json.Unmarshal(events, &endpoint.Events); err != nil {
+			return nil, err
+		}
+	}
+	return &endpoint, nil
+}
+
+func scanWebhookDelivery(rows *sql.Rows) (*service.WebhookDelivery, error) {
+	var delivery service.WebhookDelivery
+	var responseStatus sql.NullInt64
+	var responseBody, errMsg sql.NullString
+	var nextAttemptAt, deliveredAt sql.NullTime
+	if err := rows.Scan(
+		&delivery.ID,
+		&delivery.EndpointID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&responseStatus,
+		&responseBody,
+		&errMsg,
+		&nextAttemptAt,
+		&deliveredAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if responseStatus.Valid {
+		v := int(responseStatus.Int64)
+		delivery.ResponseStatus = &v
+	}
+	if responseBody.Valid {
+		delivery.ResponseBody = &responseBody.String
+	}
+	if errMsg.Valid {
+		delivery.ErrorMessage = &errMsg.String
+	}
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+	return &delivery, nil
+}
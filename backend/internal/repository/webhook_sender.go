@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/httpclient"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+// webhookResponseReadLimit 投递日志仅保留响应体前若干字节
+const webhookResponseReadLimit = 4096
+
+type webhookSender struct {
+	httpClient *http.Client
+}
+
+// NewWebhookSender 创建出站 Webhook HTTP 客户端
+// 启用 URL 白名单时校验解析后的 IP，防止通过 DNS Rebinding 访问内网
+func NewWebhookSender(cfg *config.Config) service.WebhookSender {
+	timeout := 10 * time.Second
+	if cfg.Webhook.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.Webhook.TimeoutSeconds) * time.Second
+	}
+	sharedClient, err := httpclient.GetClient(httpclient.Options{
+		Timeout:            timeout,
+		ValidateResolvedIP: cfg.Security.URLAllowlist.Enabled,
+		AllowPrivateHosts:  cfg.Security.URLAllowlist.AllowPrivateHosts,
+	})
+	if err != nil {
+		sharedClient = &http.Client{Timeout: timeout}
+	}
+	return &webhookSender{httpClient: sharedClient}
+}
+
+func (s *webhookSender) Send(ctx context.Context, targetURL string, header http.Header, body []byte) (*service.WebhookSendResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, values := range header {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseReadLimit))
+	return &service.WebhookSendResult{StatusCode: resp.StatusCode, Body: string(respBody)}, nil
+}
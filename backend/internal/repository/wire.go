@@ -15,6 +15,16 @@ import (
 // ProvideConcurrencyCache 创建并发控制缓存，从配置读取 TTL 参数
 // 性能优化：TTL 可配置，支持长时间运行的 LLM 请求场景
 func ProvideConcurrencyCache(rdb *redis.Client, cfg *config.Config) service.ConcurrencyCache {
+	return NewConcurrencyCache(rdb, cfg.Gateway.ConcurrencySlotTTLMinutes, concurrencyWaitTTLSeconds(cfg))
+}
+
+// ProvideSlotWaitQueueCache 创建账号槽位公平排队队列，TTL 与并发控制缓存一致
+func ProvideSlotWaitQueueCache(rdb *redis.Client, cfg *config.Config) service.SlotWaitQueueCache {
+	return NewSlotWaitQueueCache(rdb, cfg.Gateway.ConcurrencySlotTTLMinutes, concurrencyWaitTTLSeconds(cfg))
+}
+
+// concurrencyWaitTTLSeconds 等待队列过期时间：取粘性会话与兜底排队超时中的较大者
+func concurrencyWaitTTLSeconds(cfg *config.Config) int {
 	waitTTLSeconds := int(cfg.Gateway.Scheduling.StickySessionWaitTimeout.Seconds())
 	if cfg.Gateway.Scheduling.FallbackWaitTimeout > cfg.Gateway.Scheduling.StickySessionWaitTimeout {
 		waitTTLSeconds = int(cfg.Gateway.Scheduling.FallbackWaitTimeout.Seconds())
@@ -22,7 +32,7 @@ func ProvideConcurrencyCache(rdb *redis.Client, cfg *config.Config) service.Conc
 	if waitTTLSeconds <= 0 {
 		waitTTLSeconds = cfg.Gateway.ConcurrencySlotTTLMinutes * 60
 	}
-	return NewConcurrencyCache(rdb, cfg.Gateway.ConcurrencySlotTTLMinutes, waitTTLSeconds)
+	return waitTTLSeconds
 }
 
 // ProvideGitHubReleaseClient 创建 GitHub Release 客户端
@@ -58,6 +68,15 @@ var ProviderSet = wire.NewSet(
 	NewPromoCodeRepository,
 	NewUsageLogRepository,
 	NewUsageCleanupRepository,
+	NewMessageBatchRepository,
+	NewWebhookRepository,
+	NewModelPriceRepository,
+	NewAuditLogRepository,
+	NewOpsCaptureRepository,
+	NewBalanceTransactionRepository,
+	NewInvoiceRepository,
+	NewOrganizationRepository,
+	NewBalanceAlertRepository,
 	NewDashboardAggregationRepository,
 	NewSettingRepository,
 	NewOpsRepository,
@@ -69,11 +88,16 @@ var ProviderSet = wire.NewSet(
 	// Cache implementations
 	NewGatewayCache,
 	NewBillingCache,
+	NewBillingReservationCache,
 	NewAPIKeyCache,
 	NewTempUnschedCache,
 	NewTimeoutCounterCache,
+	NewAccountCircuitBreakerCache,
 	ProvideConcurrencyCache,
+	ProvideSlotWaitQueueCache,
 	ProvideSessionLimitCache,
+	NewAPIKeyRateLimitCache,
+	NewResponseCache,
 	NewDashboardCache,
 	NewEmailCache,
 	NewIdentityCache,
@@ -100,6 +124,7 @@ var ProviderSet = wire.NewSet(
 	NewGeminiOAuthClient,
 	NewGeminiCliCodeAssistClient,
 	NewWeChatAPIClient,
+	NewWebhookSender,
 
 	ProvideEnt,
 	ProvideSQLDB,
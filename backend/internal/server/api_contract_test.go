@@ -83,6 +83,14 @@ func TestAPIContracts(t *testing.T) {
 					"status": "active",
 					"ip_whitelist": null,
 					"ip_blacklist": null,
+					"quota_usd": null,
+					"used_usd": 0,
+					"expires_at": null,
+					"allowed_models": null,
+					"max_tokens_cap": null,
+					"rpm_limit": null,
+					"tpm_limit": null,
+					"organization_id": null,
 					"created_at": "2025-01-02T03:04:05Z",
 					"updated_at": "2025-01-02T03:04:05Z"
 				}
@@ -119,6 +127,15 @@ func TestAPIContracts(t *testing.T) {
 							"status": "active",
 							"ip_whitelist": null,
 							"ip_blacklist": null,
+							"quota_usd": null,
+							"used_usd": 0,
+							"expires_at": null,
+							"allowed_models": null,
+							"max_tokens_cap": null,
+							"rpm_limit": null,
+							"tpm_limit": null,
+							"organization_id": null,
+					"organization_id": null,
 							"created_at": "2025-01-02T03:04:05Z",
 							"updated_at": "2025-01-02T03:04:05Z"
 						}
@@ -180,6 +197,8 @@ func TestAPIContracts(t *testing.T) {
 						"image_price_4k": null,
 						"claude_code_only": false,
 						"fallback_group_id": null,
+						"default_rpm_limit": null,
+						"default_tpm_limit": null,
 						"created_at": "2025-01-02T03:04:05Z",
 						"updated_at": "2025-01-02T03:04:05Z"
 					}
@@ -354,6 +373,8 @@ func TestAPIContracts(t *testing.T) {
 						Stream:                true,
 						DurationMs:            ptr(100),
 						FirstTokenMs:          ptr(50),
+						PricingTier:           service.PricingTierStandard,
+						ServiceTier:           service.ServiceTierStandard,
 						CreatedAt:             deps.now,
 					},
 				})
@@ -375,6 +396,7 @@ func TestAPIContracts(t *testing.T) {
 							"model": "claude-3",
 							"group_id": null,
 							"subscription_id": null,
+							"organization_id": null,
 							"input_tokens": 10,
 							"output_tokens": 20,
 							"cache_creation_tokens": 1,
@@ -394,6 +416,14 @@ func TestAPIContracts(t *testing.T) {
 							"first_token_ms": 50,
 							"image_count": 0,
 							"image_size": null,
+							"cache_served": false,
+							"cache_saved_cost": 0,
+							"hedged": false,
+							"hedge_backup_won": false,
+							"client_disconnected": false,
+							"pricing_tier": "standard",
+							"service_tier": "standard",
+							"cost_detail": null,
 							"created_at": "2025-01-02T03:04:05Z",
 							"user_agent": null
 						}
@@ -739,11 +769,7 @@ func (r *stubUserRepo) ListWithFilters(ctx context.Context, params pagination.Pa
 	return nil, nil, errors.New("not implemented")
 }
 
-func (r *stubUserRepo) UpdateBalance(ctx context.Context, id int64, amount float64) error {
-	return errors.New("not implemented")
-}
-
-func (r *stubUserRepo) DeductBalance(ctx context.Context, id int64, amount float64) error {
+func (r *stubUserRepo) ApplyBalanceTransaction(ctx context.Context, txn *service.BalanceTransaction) error {
 	return errors.New("not implemented")
 }
 
@@ -952,8 +978,8 @@ func (s *stubAccountRepo) SetSchedulable(ctx context.Context, id int64, schedula
 	return errors.New("not implemented")
 }
 
-func (s *stubAccountRepo) AutoPauseExpiredAccounts(ctx context.Context, now time.Time) (int64, error) {
-	return 0, errors.New("not implemented")
+func (s *stubAccountRepo) AutoPauseExpiredAccounts(ctx context.Context, now time.Time) ([]int64, error) {
+	return nil, errors.New("not implemented")
 }
 
 func (s *stubAccountRepo) BindGroups(ctx context.Context, accountID int64, groupIDs []int64) error {
@@ -1223,6 +1249,9 @@ func (stubUserSubscriptionRepo) ResetMonthlyUsage(ctx context.Context, id int64,
 func (stubUserSubscriptionRepo) IncrementUsage(ctx context.Context, id int64, costUSD float64) error {
 	return errors.New("not implemented")
 }
+func (stubUserSubscriptionRepo) ListExpired(ctx context.Context) ([]service.UserSubscription, error) {
+	return nil, errors.New("not implemented")
+}
 func (stubUserSubscriptionRepo) BatchUpdateExpiredStatus(ctx context.Context) (int64, error) {
 	return 0, errors.New("not implemented")
 }
@@ -1425,6 +1454,14 @@ func (r *stubApiKeyRepo) ListKeysByGroupID(ctx context.Context, groupID int64) (
 	return nil, errors.New("not implemented")
 }
 
+func (r *stubApiKeyRepo) GetUsedUSD(ctx context.Context, id int64) (float64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (r *stubApiKeyRepo) IncrementUsedUSD(ctx context.Context, id int64, amount float64) error {
+	return errors.New("not implemented")
+}
+
 type stubUsageLogRepo struct {
 	userLogs map[int64][]service.UsageLog
 }
@@ -1485,6 +1522,10 @@ func (r *stubUsageLogRepo) GetAccountWindowStats(ctx context.Context, accountID
 	return nil, errors.New("not implemented")
 }
 
+func (r *stubUsageLogRepo) GetAccountFirstTokenP50(ctx context.Context, accountIDs []int64, since time.Time) (map[int64]float64, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (r *stubUsageLogRepo) GetAccountTodayStats(ctx context.Context, accountID int64) (*usagestats.AccountStats, error) {
 	return nil, errors.New("not implemented")
 }
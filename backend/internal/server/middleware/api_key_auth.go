@@ -15,12 +15,12 @@ import (
 )
 
 // NewAPIKeyAuthMiddleware 创建 API Key 认证中间件
-func NewAPIKeyAuthMiddleware(apiKeyService *service.APIKeyService, subscriptionService *service.SubscriptionService, cfg *config.Config) APIKeyAuthMiddleware {
-	return APIKeyAuthMiddleware(apiKeyAuthWithSubscription(apiKeyService, subscriptionService, cfg))
+func NewAPIKeyAuthMiddleware(apiKeyService *service.APIKeyService, subscriptionService *service.SubscriptionService, billingCacheService *service.BillingCacheService, cfg *config.Config) APIKeyAuthMiddleware {
+	return APIKeyAuthMiddleware(apiKeyAuthWithSubscription(apiKeyService, subscriptionService, billingCacheService, cfg))
 }
 
-// apiKeyAuthWithSubscription API Key认证中间件（支持订阅验证）
-func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscriptionService *service.SubscriptionService, cfg *config.Config) gin.HandlerFunc {
+// apiKeyAuthWithSubscription API Key认证中间件（支持订阅验证、API Key 额度与过期校验）
+func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscriptionService *service.SubscriptionService, billingCacheService *service.BillingCacheService, cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		queryKey := strings.TrimSpace(c.Query("key"))
 		queryApiKey := strings.TrimSpace(c.Query("api_key"))
@@ -74,6 +74,12 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 			return
 		}
 
+		// 检查API key是否过期
+		if apiKey.IsExpired() {
+			AbortWithError(c, 401, "API_KEY_EXPIRED", "API key has expired")
+			return
+		}
+
 		// 检查 IP 限制（白名单/黑名单）
 		// 注意：错误信息故意模糊，避免暴露具体的 IP 限制机制
 		if len(apiKey.IPWhitelist) > 0 || len(apiKey.IPBlacklist) > 0 {
@@ -97,6 +103,12 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 			return
 		}
 
+		// 团队 API Key：组织被禁用/删除或用户已退出组织时不可用
+		if !apiKey.IsTeamKeyUsable() {
+			AbortWithError(c, 403, "ORGANIZATION_DISABLED", "Organization for this API key is unavailable")
+			return
+		}
+
 		if cfg.RunMode == config.RunModeSimple {
 			// 简易模式：跳过余额和订阅检查，但仍需设置必要的上下文
 			c.Set(string(ContextKeyAPIKey), apiKey)
@@ -110,14 +122,21 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 			return
 		}
 
+		// 检查API key额度上限（已用额度取自计费缓存）
+		if status, code, message, ok := checkAPIKeyQuota(c.Request.Context(), billingCacheService, apiKey); !ok {
+			AbortWithError(c, status, code, message)
+			return
+		}
+
 		// 判断计费方式：订阅模式 vs 余额模式
 		isSubscriptionType := apiKey.Group != nil && apiKey.Group.IsSubscriptionType()
 
 		if isSubscriptionType && subscriptionService != nil {
 			// 订阅模式：验证订阅
+			// 团队 API Key 在组织开启共享订阅时使用所有者的订阅
 			subscription, err := subscriptionService.GetActiveSubscription(
 				c.Request.Context(),
-				apiKey.User.ID,
+				apiKey.SubscriptionUserID(),
 				apiKey.Group.ID,
 			)
 			if err != nil {
@@ -149,6 +168,12 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 
 			// 将订阅信息存入上下文
 			c.Set(string(ContextKeySubscription), subscription)
+		} else if apiKey.IsTeamKey() {
+			// 余额模式（团队 API Key）：检查组织余额
+			if apiKey.Organization.Balance <= 0 {
+				AbortWithError(c, 403, "INSUFFICIENT_BALANCE", "Insufficient organization balance")
+				return
+			}
 		} else {
 			// 余额模式：检查用户余额
 			if apiKey.User.Balance <= 0 {
@@ -170,6 +195,21 @@ func apiKeyAuthWithSubscription(apiKeyService *service.APIKeyService, subscripti
 	}
 }
 
+// checkAPIKeyQuota 检查 API Key 额度上限，是网关请求唯一的额度校验点（各处理器不再重复检查）。
+// 超限或计费服务不可用时 ok 为 false，并返回对应的 HTTP 状态码与错误信息
+func checkAPIKeyQuota(ctx context.Context, billingCacheService *service.BillingCacheService, apiKey *service.APIKey) (status int, code, message string, ok bool) {
+	if !apiKey.HasQuota() || billingCacheService == nil {
+		return 0, "", "", true
+	}
+	if err := billingCacheService.CheckAPIKeyLimits(ctx, apiKey); err != nil {
+		if errors.Is(err, service.ErrAPIKeyQuotaExceeded) {
+			return 403, "API_KEY_QUOTA_EXCEEDED", "API key quota exceeded", false
+		}
+		return 503, "BILLING_SERVICE_ERROR", "Billing service temporarily unavailable. Please retry later.", false
+	}
+	return 0, "", "", true
+}
+
 // GetAPIKeyFromContext 从上下文中获取API key
 func GetAPIKeyFromContext(c *gin.Context) (*service.APIKey, bool) {
 	value, exists := c.Get(string(ContextKeyAPIKey))
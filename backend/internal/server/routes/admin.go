@@ -16,6 +16,8 @@ func RegisterAdminRoutes(
 ) {
 	admin := v1.Group("/admin")
 	admin.Use(gin.HandlerFunc(adminAuth))
+	// 审计写操作，需在认证之后以获取操作者
+	admin.Use(h.Admin.Audit.Middleware())
 	{
 		// 仪表盘
 		registerDashboardRoutes(admin, h)
@@ -64,6 +66,21 @@ func RegisterAdminRoutes(
 
 		// 用户属性管理
 		registerUserAttributeRoutes(admin, h)
+
+		// 出站 Webhook
+		registerWebhookRoutes(admin, h)
+
+		// 自定义模型价格
+		registerModelPriceRoutes(admin, h)
+
+		// 审计日志
+		registerAuditLogRoutes(admin, h)
+
+		// 月度账单
+		registerInvoiceRoutes(admin, h)
+
+		// 组织管理
+		registerOrganizationRoutes(admin, h)
 	}
 }
 
@@ -72,7 +89,9 @@ func registerOpsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 	{
 		// Realtime ops signals
 		ops.GET("/concurrency", h.Admin.Ops.GetConcurrencyStats)
+		ops.GET("/wait-queue", h.Admin.Ops.GetWaitQueueStats)
 		ops.GET("/account-availability", h.Admin.Ops.GetAccountAvailability)
+		ops.POST("/account-availability/:id/reset-circuit", h.Admin.Ops.ResetAccountCircuit)
 		ops.GET("/realtime-traffic", h.Admin.Ops.GetRealtimeTrafficSummary)
 
 		// Alerts (rules + events)
@@ -137,6 +156,15 @@ func registerOpsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		// Request drilldown (success + error)
 		ops.GET("/requests", h.Admin.Ops.ListRequestDetails)
 
+		// Debug request/response capture (encrypted, auto-expiring)
+		ops.GET("/capture-rules", h.Admin.Ops.ListCaptureRules)
+		ops.POST("/capture-rules", h.Admin.Ops.CreateCaptureRule)
+		ops.POST("/capture-rules/:id/stop", h.Admin.Ops.StopCaptureRule)
+		ops.DELETE("/capture-rules/:id", h.Admin.Ops.DeleteCaptureRule)
+		ops.GET("/captures", h.Admin.Ops.ListCaptures)
+		ops.GET("/captures/:id", h.Admin.Ops.GetCapture)
+		ops.GET("/captures/:id/diff", h.Admin.Ops.DiffCaptures)
+
 		// Dashboard (vNext - raw path for MVP)
 		ops.GET("/dashboard/overview", h.Admin.Ops.GetDashboardOverview)
 		ops.GET("/dashboard/throughput-trend", h.Admin.Ops.GetDashboardThroughputTrend)
@@ -165,11 +193,13 @@ func registerUserManagementRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 	users := admin.Group("/users")
 	{
 		users.GET("", h.Admin.User.List)
+		users.GET("/balance-consistency", h.Admin.User.CheckBalanceConsistency)
 		users.GET("/:id", h.Admin.User.GetByID)
 		users.POST("", h.Admin.User.Create)
 		users.PUT("/:id", h.Admin.User.Update)
 		users.DELETE("/:id", h.Admin.User.Delete)
 		users.POST("/:id/balance", h.Admin.User.UpdateBalance)
+		users.GET("/:id/balance-history", h.Admin.User.GetBalanceHistory)
 		users.GET("/:id/api-keys", h.Admin.User.GetUserAPIKeys)
 		users.GET("/:id/usage", h.Admin.User.GetUserUsage)
 
@@ -288,6 +318,55 @@ func registerRedeemCodeRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 	}
 }
 
+func registerWebhookRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	webhooks := admin.Group("/webhooks")
+	{
+		webhooks.GET("", h.Admin.Webhook.List)
+		webhooks.GET("/event-types", h.Admin.Webhook.ListEventTypes)
+		webhooks.GET("/:id", h.Admin.Webhook.GetByID)
+		webhooks.POST("", h.Admin.Webhook.Create)
+		webhooks.PUT("/:id", h.Admin.Webhook.Update)
+		webhooks.DELETE("/:id", h.Admin.Webhook.Delete)
+		webhooks.POST("/:id/test", h.Admin.Webhook.Test)
+		webhooks.GET("/:id/deliveries", h.Admin.Webhook.ListDeliveries)
+		webhooks.POST("/deliveries/:id/retry", h.Admin.Webhook.RetryDelivery)
+	}
+}
+
+func registerModelPriceRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	prices := admin.Group("/model-prices")
+	{
+		prices.GET("", h.Admin.ModelPrice.List)
+		prices.GET("/resolve", h.Admin.ModelPrice.Resolve)
+		prices.GET("/export", h.Admin.ModelPrice.Export)
+		prices.POST("/import", h.Admin.ModelPrice.Import)
+		prices.GET("/:id", h.Admin.ModelPrice.GetByID)
+		prices.POST("", h.Admin.ModelPrice.Create)
+		prices.PUT("/:id", h.Admin.ModelPrice.Update)
+		prices.DELETE("/:id", h.Admin.ModelPrice.Delete)
+	}
+}
+
+func registerAuditLogRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	auditLogs := admin.Group("/audit-logs")
+	{
+		auditLogs.GET("", h.Admin.Audit.List)
+		auditLogs.GET("/export", h.Admin.Audit.Export)
+	}
+}
+
+func registerInvoiceRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	invoices := admin.Group("/invoices")
+	{
+		invoices.GET("", h.Admin.Invoice.List)
+		invoices.POST("/generate", h.Admin.Invoice.Generate)
+		invoices.GET("/:id", h.Admin.Invoice.GetByID)
+		invoices.GET("/:id/download", h.Admin.Invoice.Download)
+		invoices.POST("/:id/regenerate", h.Admin.Invoice.Regenerate)
+		invoices.POST("/:id/void", h.Admin.Invoice.Void)
+	}
+}
+
 func registerPromoCodeRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 	promoCodes := admin.Group("/promo-codes")
 	{
@@ -373,3 +452,16 @@ func registerUserAttributeRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		attrs.DELETE("/:id", h.Admin.UserAttribute.DeleteDefinition)
 	}
 }
+
+func registerOrganizationRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	organizations := admin.Group("/organizations")
+	{
+		organizations.GET("", h.Admin.Organization.List)
+		organizations.GET("/:id", h.Admin.Organization.GetByID)
+		organizations.DELETE("/:id", h.Admin.Organization.Delete)
+		organizations.PUT("/:id/status", h.Admin.Organization.UpdateStatus)
+		organizations.GET("/:id/members", h.Admin.Organization.ListMembers)
+		organizations.POST("/:id/balance", h.Admin.Organization.AdjustBalance)
+		organizations.GET("/:id/balance/transactions", h.Admin.Organization.ListBalanceTransactions)
+	}
+}
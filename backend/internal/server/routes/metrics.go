@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterMetricsRoutes 注册 Prometheus 抓取端点（需开启 metrics.enabled）
+func RegisterMetricsRoutes(r *gin.Engine, cfg *config.Config) {
+	if cfg == nil || !cfg.Metrics.Enabled {
+		return
+	}
+	token := []byte(strings.TrimSpace(cfg.Metrics.Token))
+
+	r.GET("/metrics", func(c *gin.Context) {
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(provided)), token) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="metrics"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Header("Content-Type", metrics.ContentType)
+		c.Status(http.StatusOK)
+		_ = metrics.Default.WriteText(c.Writer)
+	})
+}
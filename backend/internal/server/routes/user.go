@@ -22,6 +22,7 @@ func RegisterUserRoutes(
 			user.GET("/profile", h.User.GetProfile)
 			user.PUT("/password", h.User.ChangePassword)
 			user.PUT("", h.User.UpdateProfile)
+			user.GET("/balance/history", h.User.GetBalanceHistory)
 
 			// TOTP 双因素认证
 			totp := user.Group("/totp")
@@ -87,5 +88,44 @@ func RegisterUserRoutes(
 			usageReport.PUT("/config", h.UsageReport.UpdateConfig)
 			usageReport.POST("/test", h.UsageReport.SendTestReport)
 		}
+
+		// 月度账单
+		invoices := authenticated.Group("/invoices")
+		{
+			invoices.GET("", h.Invoice.List)
+			invoices.GET("/:id", h.Invoice.GetByID)
+			invoices.GET("/:id/download", h.Invoice.Download)
+		}
+
+		// 低余额提醒与自动充值
+		balanceAlerts := authenticated.Group("/balance-alerts")
+		{
+			balanceAlerts.GET("", h.BalanceAlert.Get)
+			balanceAlerts.PUT("", h.BalanceAlert.Update)
+			balanceAlerts.POST("/topup-codes", h.BalanceAlert.RegisterTopUpCode)
+			balanceAlerts.DELETE("/topup-codes/:id", h.BalanceAlert.DeleteTopUpCode)
+		}
+
+		// 组织（团队）
+		organizations := authenticated.Group("/organizations")
+		{
+			organizations.GET("", h.Organization.List)
+			organizations.POST("", h.Organization.Create)
+			organizations.POST("/invitations/accept", h.Organization.AcceptInvitation)
+			organizations.GET("/:id", h.Organization.GetByID)
+			organizations.PUT("/:id", h.Organization.Update)
+			organizations.DELETE("/:id", h.Organization.Delete)
+			organizations.GET("/:id/members", h.Organization.ListMembers)
+			organizations.PUT("/:id/members/:user_id", h.Organization.UpdateMember)
+			organizations.DELETE("/:id/members/:user_id", h.Organization.RemoveMember)
+			organizations.GET("/:id/invitations", h.Organization.ListInvitations)
+			organizations.POST("/:id/invitations", h.Organization.Invite)
+			organizations.DELETE("/:id/invitations/:invitation_id", h.Organization.RevokeInvitation)
+			organizations.POST("/:id/balance/transfer", h.Organization.Transfer)
+			organizations.GET("/:id/balance/transactions", h.Organization.ListBalanceTransactions)
+			organizations.GET("/:id/keys", h.Organization.ListAPIKeys)
+			organizations.POST("/:id/keys/:key_id/disable", h.Organization.DisableAPIKey)
+			organizations.GET("/:id/dashboard", h.Organization.Dashboard)
+		}
 	}
 }
@@ -486,6 +486,19 @@ func (a *Account) IsOpenAIApiKey() bool {
 	return a.IsOpenAI() && a.Type == AccountTypeAPIKey
 }
 
+// SupportsEmbeddings 账号是否可转发 Embeddings 请求
+// OpenAI 仅 API Key 账号（ChatGPT OAuth 无 embeddings 接口）；Gemini 需为 AI Studio 账号（Code Assist 不支持 embedContent）
+func (a *Account) SupportsEmbeddings() bool {
+	switch a.Platform {
+	case PlatformOpenAI:
+		return a.Type == AccountTypeAPIKey
+	case PlatformGemini:
+		return a.Type == AccountTypeAPIKey || (a.Type == AccountTypeOAuth && !a.IsGeminiCodeAssist())
+	default:
+		return false
+	}
+}
+
 func (a *Account) GetOpenAIBaseURL() string {
 	if !a.IsOpenAI() {
 		return ""
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// 账号熔断状态
+const (
+	AccountCircuitClosed   = "closed"
+	AccountCircuitOpen     = "open"
+	AccountCircuitHalfOpen = "half_open"
+)
+
+// accountCircuitRecordTimeout 异步写入请求结果的超时时间
+const accountCircuitRecordTimeout = 3 * time.Second
+
+// AccountCircuitParams 账号熔断判定参数
+type AccountCircuitParams struct {
+	WindowSeconds      int     // 滚动窗口长度
+	MinRequests        int     // 窗口内最少请求数
+	FailureThreshold   int     // 窗口内最少失败数
+	ErrorRateThreshold float64 // 错误率阈值
+	OpenSeconds        int     // 熔断打开时长，之后进入半开
+	HalfOpenRequests   int     // 半开状态探测请求数
+}
+
+// AccountCircuitStatus 账号熔断状态快照（用于运维展示）
+type AccountCircuitStatus struct {
+	State     string     `json:"state"`
+	Requests  int64      `json:"requests"`
+	Failures  int64      `json:"failures"`
+	ErrorRate float64    `json:"error_rate"`
+	OpenedAt  *time.Time `json:"opened_at,omitempty"`
+	Trips     int64      `json:"trips"`
+}
+
+// AccountCircuitBreakerCache 账号熔断状态存储（Redis），多实例共享。
+// 未记录过的账号视为 closed。
+type AccountCircuitBreakerCache interface {
+	// Record 记录一次请求结果并推进状态机，返回记录前后的状态
+	Record(ctx context.Context, accountID int64, failure bool, params AccountCircuitParams) (prev string, next string, err error)
+	// GetStates 批量读取非 closed 账号的状态；open 超过 OpenSeconds 的账号会原子地切换为 half_open
+	GetStates(ctx context.Context, accountIDs []int64, params AccountCircuitParams) (map[int64]string, error)
+	// TryAcquireProbe 半开状态下申请一个探测名额；名额在 OpenSeconds 后过期重新发放
+	TryAcquireProbe(ctx context.Context, accountID int64, params AccountCircuitParams) (bool, error)
+	// GetStatuses 批量读取熔断状态与窗口统计
+	GetStatuses(ctx context.Context, accountIDs []int64, params AccountCircuitParams) (map[int64]*AccountCircuitStatus, error)
+	// Reset 清除账号熔断状态与窗口统计
+	Reset(ctx context.Context, accountID int64) error
+}
+
+// accountCircuitHint 本实例最近一次观察到的非 closed 状态，
+// 用于槽位获取时判断是否需要访问 Redis（closed 账号不产生额外开销）
+type accountCircuitHint struct {
+	state string
+	until time.Time
+}
+
+// AccountCircuitBreakerService 按账号滚动错误率熔断。
+//
+// closed：正常调度，窗口内请求数、失败数、错误率均达到阈值后熔断；
+// open：从调度候选中剔除，OpenSeconds 后进入 half_open；
+// half_open：仅放行 HalfOpenRequests 个探测请求，全部成功则关闭，任一失败重新打开。
+//
+// Redis 不可用时放行（fail open），不影响正常调度。
+type AccountCircuitBreakerService struct {
+	cache   AccountCircuitBreakerCache
+	enabled bool
+	params  AccountCircuitParams
+	hints   sync.Map // accountID -> accountCircuitHint
+	now     func() time.Time
+}
+
+// NewAccountCircuitBreakerService 创建账号熔断服务
+func NewAccountCircuitBreakerService(cache AccountCircuitBreakerCache, cfg *config.Config) *AccountCircuitBreakerService {
+	s := &AccountCircuitBreakerService{cache: cache, now: time.Now}
+	if cfg == nil {
+		return s
+	}
+	cb := cfg.Gateway.AccountCircuitBreaker
+	s.enabled = cb.Enabled && cache != nil
+	s.params = AccountCircuitParams{
+		WindowSeconds:      cb.WindowSeconds,
+		MinRequests:        cb.MinRequests,
+		FailureThreshold:   cb.FailureThreshold,
+		ErrorRateThreshold: cb.ErrorRateThreshold,
+		OpenSeconds:        cb.ResetTimeoutSeconds,
+		HalfOpenRequests:   cb.HalfOpenRequests,
+	}
+	return s
+}
+
+// Enabled 是否启用账号熔断
+func (s *AccountCircuitBreakerService) Enabled() bool {
+	return s != nil && s.enabled
+}
+
+// FilterSchedulable 剔除熔断打开的账号（一次批量 Redis 读取），半开账号保留并等待探测名额
+func (s *AccountCircuitBreakerService) FilterSchedulable(ctx context.Context, accounts []Account) []Account {
+	if !s.Enabled() || len(accounts) == 0 {
+		return accounts
+	}
+	ids := make([]int64, 0, len(accounts))
+	for i := range accounts {
+		ids = append(ids, accounts[i].ID)
+	}
+	states, err := s.cache.GetStates(ctx, ids, s.params)
+	if err != nil {
+		log.Printf("[CircuitBreaker] get account states failed: %v", err)
+		return accounts
+	}
+
+	until := s.now().Add(time.Duration(s.params.OpenSeconds) * time.Second)
+	filtered := accounts[:0:0]
+	for i := range accounts {
+		id := accounts[i].ID
+		state := states[id]
+		switch state {
+		case AccountCircuitOpen, AccountCircuitHalfOpen:
+			s.hints.Store(id, accountCircuitHint{state: state, until: until})
+		default:
+			s.hints.Delete(id)
+		}
+		if state == AccountCircuitOpen {
+			continue
+		}
+		filtered = append(filtered, accounts[i])
+	}
+	return filtered
+}
+
+// AllowRequest 在获取并发槽位后（或返回等待计划前）调用：熔断打开的账号拒绝，半开账号需申请探测名额
+func (s *AccountCircuitBreakerService) AllowRequest(ctx context.Context, accountID int64) bool {
+	if !s.Enabled() {
+		return true
+	}
+	v, ok := s.hints.Load(accountID)
+	if !ok {
+		return true
+	}
+	hint := v.(accountCircuitHint)
+	if s.now().After(hint.until) {
+		return true
+	}
+	if hint.state == AccountCircuitOpen {
+		return false
+	}
+	allowed, err := s.cache.TryAcquireProbe(ctx, accountID, s.params)
+	if err != nil {
+		log.Printf("[CircuitBreaker] acquire probe failed: account=%d err=%v", accountID, err)
+		return true
+	}
+	return allowed
+}
+
+// guardWaitPlan 对返回等待计划的调度结果补做放行检查：等待计划在排队结束后直接使用槽位，
+// 不经过 tryAcquireAccountSlot，半开账号须在此占用探测名额（排队超时的名额随 OpenSeconds 过期）。
+// 被拒绝的账号加入排除列表后重新调度，不修改调用方的 excludedIDs。
+func (s *AccountCircuitBreakerService) guardWaitPlan(ctx context.Context, excludedIDs map[int64]struct{}, selectFn func(excluded map[int64]struct{}) (*AccountSelectionResult, error)) (*AccountSelectionResult, error) {
+	for {
+		result, err := selectFn(excludedIDs)
+		if err != nil || result == nil || result.WaitPlan == nil || result.Account == nil {
+			return result, err
+		}
+		if s.AllowRequest(ctx, result.Account.ID) {
+			return result, nil
+		}
+		excludedIDs = hedgeExclusions(excludedIDs, result.Account.ID)
+	}
+}
+
+// RecordResult 异步记录一次转发结果，不阻塞请求路径
+func (s *AccountCircuitBreakerService) RecordResult(c *gin.Context, account *Account, err error) {
+	if !s.Enabled() || account == nil {
+		return
+	}
+	failure, counted := classifyAccountCircuitResult(c, err)
+	if !counted {
+		return
+	}
+	accountID := account.ID
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), accountCircuitRecordTimeout)
+		defer cancel()
+		s.record(ctx, accountID, failure)
+	}()
+}
+
+func (s *AccountCircuitBreakerService) record(ctx context.Context, accountID int64, failure bool) {
+	prev, next, err := s.cache.Record(ctx, accountID, failure, s.params)
+	if err != nil {
+		log.Printf("[CircuitBreaker] record result failed: account=%d err=%v", accountID, err)
+		return
+	}
+	if next == AccountCircuitClosed {
+		s.hints.Delete(accountID)
+	} else {
+		s.hints.Store(accountID, accountCircuitHint{
+			state: next,
+			until: s.now().Add(time.Duration(s.params.OpenSeconds) * time.Second),
+		})
+	}
+	if prev != next {
+		recordAccountCircuitTransition(prev, next)
+		log.Printf("[CircuitBreaker] account %d circuit %s -> %s", accountID, prev, next)
+	}
+}
+
+// GetStatuses 批量读取账号熔断状态；未启用时返回 nil
+func (s *AccountCircuitBreakerService) GetStatuses(ctx context.Context, accountIDs []int64) (map[int64]*AccountCircuitStatus, error) {
+	if !s.Enabled() || len(accountIDs) == 0 {
+		return nil, nil
+	}
+	return s.cache.GetStatuses(ctx, accountIDs, s.params)
+}
+
+// Reset 手动关闭账号熔断并清空窗口统计
+func (s *AccountCircuitBreakerService) Reset(ctx context.Context, accountID int64) error {
+	if !s.Enabled() {
+		return nil
+	}
+	s.hints.Delete(accountID)
+	return s.cache.Reset(ctx, accountID)
+}
+
+// classifyAccountCircuitResult 判断一次转发结果是否计入熔断统计。
+// 5xx（529 过载除外）、流读取错误、超时等无上游状态码的错误计为失败；
+// 401/403/429/529 及其他 4xx 由限流与错误处理逻辑负责，不计入；客户端主动断开不计入。
+func classifyAccountCircuitResult(c *gin.Context, err error) (failure bool, counted bool) {
+	if err == nil {
+		return false, true
+	}
+	if c != nil && c.Request != nil && errors.Is(c.Request.Context().Err(), context.Canceled) {
+		return false, false
+	}
+	status := 0
+	var failoverErr *UpstreamFailoverError
+	if errors.As(err, &failoverErr) {
+		status = failoverErr.StatusCode
+	} else if c != nil {
+		if v, ok := c.Get(OpsUpstreamStatusCodeKey); ok {
+			status, _ = v.(int)
+		}
+	}
+	if status == 0 {
+		return true, true
+	}
+	if status >= 500 && status != 529 {
+		return true, true
+	}
+	return false, false
+}
@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCircuitCache 内存版熔断状态机（与 Redis 脚本语义一致，窗口不分桶）
+type fakeCircuitCache struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	accounts map[int64]*fakeCircuitEntry
+	err      error
+}
+
+type fakeCircuitEntry struct {
+	state      string
+	openedAt   time.Time
+	halfOpenAt time.Time
+	requests   int64
+	failures   int64
+	probes     int
+	probeOK    int
+	trips      int64
+}
+
+func newFakeCircuitCache(now func() time.Time) *fakeCircuitCache {
+	return &fakeCircuitCache{now: now, accounts: make(map[int64]*fakeCircuitEntry)}
+}
+
+func (c *fakeCircuitCache) entry(accountID int64, params AccountCircuitParams) *fakeCircuitEntry {
+	e, ok := c.accounts[accountID]
+	if !ok {
+		e = &fakeCircuitEntry{state: AccountCircuitClosed}
+		c.accounts[accountID] = e
+	}
+	if e.state == AccountCircuitOpen && c.now().Sub(e.openedAt) >= time.Duration(params.OpenSeconds)*time.Second {
+		e.state = AccountCircuitHalfOpen
+		e.halfOpenAt = c.now()
+		e.probes, e.probeOK = 0, 0
+	}
+	return e
+}
+
+func (c *fakeCircuitCache) open(e *fakeCircuitEntry) {
+	e.state = AccountCircuitOpen
+	e.openedAt = c.now()
+	e.probes, e.probeOK = 0, 0
+	e.trips++
+}
+
+func (c *fakeCircuitCache) Record(ctx context.Context, accountID int64, failure bool, params AccountCircuitParams) (string, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return "", "", c.err
+	}
+	e := c.entry(accountID, params)
+	prev := e.state
+	switch e.state {
+	case AccountCircuitHalfOpen:
+		if failure {
+			c.open(e)
+		} else if e.probeOK++; e.probeOK >= params.HalfOpenRequests {
+			e.state = AccountCircuitClosed
+			e.requests, e.failures = 0, 0
+		}
+	case AccountCircuitClosed:
+		e.requests++
+		if failure {
+			e.failures++
+			if e.requests >= int64(params.MinRequests) && e.failures >= int64(params.FailureThreshold) &&
+				float64(e.failures)/float64(e.requests) >= params.ErrorRateThreshold {
+				c.open(e)
+			}
+		}
+	}
+	return prev, e.state, nil
+}
+
+func (c *fakeCircuitCache) GetStates(ctx context.Context, accountIDs []int64, params AccountCircuitParams) (map[int64]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	states := make(map[int64]string)
+	for _, id := range accountIDs {
+		if e := c.entry(id, params); e.state != AccountCircuitClosed {
+			states[id] = e.state
+		}
+	}
+	return states, nil
+}
+
+func (c *fakeCircuitCache) TryAcquireProbe(ctx context.Context, accountID int64, params AccountCircuitParams) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return false, c.err
+	}
+	e := c.entry(accountID, params)
+	switch e.state {
+	case AccountCircuitOpen:
+		return false, nil
+	case AccountCircuitClosed:
+		return true, nil
+	}
+	if c.now().Sub(e.halfOpenAt) >= time.Duration(params.OpenSeconds)*time.Second {
+		e.halfOpenAt = c.now()
+		e.probes, e.probeOK = 0, 0
+	}
+	if e.probes >= params.HalfOpenRequests {
+		return false, nil
+	}
+	e.probes++
+	return true, nil
+}
+
+func (c *fakeCircuitCache) GetStatuses(ctx context.Context, accountIDs []int64, params AccountCircuitParams) (map[int64]*AccountCircuitStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	statuses := make(map[int64]*AccountCircuitStatus)
+	for _, id := range accountIDs {
+		e := c.entry(id, params)
+		status := &AccountCircuitStatus{State: e.state, Requests: e.requests, Failures: e.failures, Trips: e.trips}
+		if e.requests > 0 {
+			status.ErrorRate = float64(e.failures) / float64(e.requests)
+		}
+		statuses[id] = status
+	}
+	return statuses, nil
+}
+
+func (c *fakeCircuitCache) Reset(ctx context.Context, accountID int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.accounts, accountID)
+	return nil
+}
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestCircuitBreaker(t *testing.T) (*AccountCircuitBreakerService, *fakeCircuitCache, *testClock) {
+	t.Helper()
+	clock := &testClock{now: time.Unix(1_700_000_000, 0)}
+	cache := newFakeCircuitCache(clock.Now)
+	cfg := &config.Config{}
+	cfg.Gateway.AccountCircuitBreaker = config.CircuitBreakerConfig{
+		Enabled:             true,
+		WindowSeconds:       60,
+		MinRequests:         10,
+		FailureThreshold:    3,
+		ErrorRateThreshold:  0.3,
+		ResetTimeoutSeconds: 30,
+		HalfOpenRequests:    2,
+	}
+	breaker := NewAccountCircuitBreakerService(cache, cfg)
+	breaker.now = clock.Now
+	return breaker, cache, clock
+}
+
+func TestAccountCircuitBreaker_DisabledAndNilArePassthrough(t *testing.T) {
+	accounts := []Account{{ID: 1}, {ID: 2}}
+
+	var nilBreaker *AccountCircuitBreakerService
+	require.False(t, nilBreaker.Enabled())
+	require.Equal(t, accounts, nilBreaker.FilterSchedulable(context.Background(), accounts))
+	require.True(t, nilBreaker.AllowRequest(context.Background(), 1))
+	nilBreaker.RecordResult(nil, &accounts[0], errors.New("boom"))
+
+	disabled := NewAccountCircuitBreakerService(newFakeCircuitCache(time.Now), &config.Config{})
+	require.False(t, disabled.Enabled())
+	require.Equal(t, accounts, disabled.FilterSchedulable(context.Background(), accounts))
+}
+
+func TestAccountCircuitBreaker_OpenHalfOpenClose(t *testing.T) {
+	ctx := context.Background()
+	breaker, _, clock := newTestCircuitBreaker(t)
+	accounts := []Account{{ID: 1}, {ID: 2}}
+
+	// 10 次请求中 3 次失败（30%）触发熔断
+	for i := 0; i < 10; i++ {
+		breaker.record(ctx, 1, i >= 7)
+	}
+	filtered := breaker.FilterSchedulable(ctx, accounts)
+	require.Equal(t, []Account{{ID: 2}}, filtered)
+	require.Len(t, accounts, 2, "input slice must not be modified")
+	require.False(t, breaker.AllowRequest(ctx, 1), "sticky sessions must not reach an open account")
+	require.True(t, breaker.AllowRequest(ctx, 2))
+
+	// 熔断时长过后进入半开：重新参与调度，但只放行 HalfOpenRequests 个探测请求
+	clock.Advance(31 * time.Second)
+	require.Equal(t, accounts, breaker.FilterSchedulable(ctx, accounts))
+	require.True(t, breaker.AllowRequest(ctx, 1))
+	require.True(t, breaker.AllowRequest(ctx, 1))
+	require.False(t, breaker.AllowRequest(ctx, 1), "probe quota exhausted")
+
+	breaker.record(ctx, 1, false)
+	breaker.record(ctx, 1, false)
+	require.True(t, breaker.AllowRequest(ctx, 1), "closed after successful probes")
+	require.Equal(t, accounts, breaker.FilterSchedulable(ctx, accounts))
+}
+
+func TestAccountCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	ctx := context.Background()
+	breaker, cache, clock := newTestCircuitBreaker(t)
+	for i := 0; i < 10; i++ {
+		breaker.record(ctx, 1, true)
+	}
+	clock.Advance(31 * time.Second)
+	breaker.FilterSchedulable(ctx, []Account{{ID: 1}})
+	require.True(t, breaker.AllowRequest(ctx, 1))
+
+	breaker.record(ctx, 1, true)
+	require.False(t, breaker.AllowRequest(ctx, 1))
+	require.Empty(t, breaker.FilterSchedulable(ctx, []Account{{ID: 1}}))
+	require.Equal(t, int64(2), cache.accounts[1].trips)
+}
+
+func TestAccountCircuitBreaker_FailsOpenOnCacheError(t *testing.T) {
+	ctx := context.Background()
+	breaker, cache, _ := newTestCircuitBreaker(t)
+	for i := 0; i < 10; i++ {
+		breaker.record(ctx, 1, true)
+	}
+	cache.err = errors.New("redis down")
+	accounts := []Account{{ID: 1}, {ID: 2}}
+	require.Equal(t, accounts, breaker.FilterSchedulable(ctx, accounts))
+}
+
+func TestAccountCircuitBreaker_Reset(t *testing.T) {
+	ctx := context.Background()
+	breaker, _, _ := newTestCircuitBreaker(t)
+	for i := 0; i < 10; i++ {
+		breaker.record(ctx, 1, true)
+	}
+	require.False(t, breaker.AllowRequest(ctx, 1))
+	require.NoError(t, breaker.Reset(ctx, 1))
+	require.True(t, breaker.AllowRequest(ctx, 1))
+	require.Len(t, breaker.FilterSchedulable(ctx, []Account{{ID: 1}}), 1)
+}
+
+func TestClassifyAccountCircuitResult(t *testing.T) {
+	newCtx := func(status int) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+		if status > 0 {
+			c.Set(OpsUpstreamStatusCodeKey, status)
+		}
+		return c
+	}
+
+	cases := []struct {
+		name    string
+		c       *gin.Context
+		err     error
+		failure bool
+		counted bool
+	}{
+		{"success", newCtx(0), nil, false, true},
+		{"failover 500", newCtx(0), &UpstreamFailoverError{StatusCode: 500}, true, true},
+		{"failover 502", newCtx(0), &UpstreamFailoverError{StatusCode: 502}, true, true},
+		{"failover 429", newCtx(0), &UpstreamFailoverError{StatusCode: 429}, false, false},
+		{"failover 401", newCtx(0), &UpstreamFailoverError{StatusCode: 401}, false, false},
+		{"failover 529", newCtx(0), &UpstreamFailoverError{StatusCode: 529}, false, false},
+		{"stream read error", newCtx(0), errors.New("stream read error: unexpected EOF"), true, true},
+		{"passthrough 400", newCtx(400), errors.New("upstream error: 400"), false, false},
+		{"passthrough 503", newCtx(503), errors.New("upstream error: 503"), true, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			failure, counted := classifyAccountCircuitResult(tc.c, tc.err)
+			require.Equal(t, tc.failure, failure)
+			require.Equal(t, tc.counted, counted)
+		})
+	}
+
+	t.Run("client disconnect", func(t *testing.T) {
+		c := newCtx(0)
+		reqCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+		c.Request = c.Request.WithContext(reqCtx)
+		_, counted := classifyAccountCircuitResult(c, errors.New("stream read error: context canceled"))
+		require.False(t, counted)
+	})
+}
+
+// 模拟：账号 1 间歇性返回 5xx（40%），熔断后流量全部切到账号 2，
+// 熔断时长过后以少量探测请求恢复。
+func TestAccountCircuitBreakerSimulation_OpenAI(t *testing.T) {
+	ctx := context.Background()
+	breaker, _, clock := newTestCircuitBreaker(t)
+	accounts := []Account{
+		schedulingTestAccount(1, PlatformOpenAI, 100),
+		schedulingTestAccount(2, PlatformOpenAI, 100),
+	}
+	svc := newSchedulingOpenAIService(accounts, nil)
+	svc.circuitBreaker = breaker
+	groupID := int64(7)
+
+	send := func(n int, flaky bool) map[int64]int {
+		counts := make(map[int64]int)
+		for i := 0; i < n; i++ {
+			selection, err := svc.SelectAccountWithLoadAwareness(ctx, &groupID, "", "", nil)
+			require.NoError(t, err)
+			require.True(t, selection.Acquired)
+			id := selection.Account.ID
+			counts[id]++
+			breaker.record(ctx, id, flaky && id == 1 && counts[id]%5 < 2)
+			selection.ReleaseFunc()
+		}
+		return counts
+	}
+
+	before := send(40, true)
+	t.Logf("distribution while tripping: %v", before)
+	require.Less(t, before[1], 40)
+
+	during := send(50, true)
+	t.Logf("distribution while open: %v", during)
+	require.Equal(t, map[int64]int{2: 50}, during)
+
+	// 半开：账号 1 只接收 HalfOpenRequests 个探测请求，成功后关闭
+	clock.Advance(31 * time.Second)
+	probe := send(20, false)
+	t.Logf("distribution after recovery: %v", probe)
+	require.Positive(t, probe[1])
+	require.True(t, breaker.AllowRequest(ctx, 1))
+	states, err := breaker.cache.GetStates(ctx, []int64{1}, breaker.params)
+	require.NoError(t, err)
+	require.Empty(t, states, "circuit closed after successful probes")
+}
+
+func TestAccountCircuitBreaker_WaitPlanRequiresProbe(t *testing.T) {
+	ctx := context.Background()
+	breaker, _, clock := newTestCircuitBreaker(t)
+	accounts := []Account{
+		schedulingTestAccount(1, PlatformOpenAI, 1),
+		schedulingTestAccount(2, PlatformOpenAI, 1),
+	}
+	svc := newSchedulingOpenAIService(accounts, nil)
+	svc.circuitBreaker = breaker
+	groupID := int64(7)
+
+	for i := 0; i < 10; i++ {
+		breaker.record(ctx, 1, true)
+	}
+	clock.Advance(31 * time.Second)
+	breaker.FilterSchedulable(ctx, accounts)
+
+	// 两个账号槽位都已占满，调度只能返回等待计划
+	for _, id := range []int64{1, 2} {
+		result, err := svc.concurrencyService.AcquireAccountSlot(ctx, id, 1)
+		require.NoError(t, err)
+		require.True(t, result.Acquired)
+	}
+
+	// 半开账号有探测名额：等待计划占用一个名额
+	excluded := map[int64]struct{}{2: {}}
+	selection, err := svc.SelectAccountWithLoadAwareness(ctx, &groupID, "", "", excluded)
+	require.NoError(t, err)
+	require.NotNil(t, selection.WaitPlan)
+	require.Equal(t, int64(1), selection.Account.ID)
+	require.True(t, breaker.AllowRequest(ctx, 1))
+	require.False(t, breaker.AllowRequest(ctx, 1), "wait plan consumed a probe")
+
+	// 探测名额用尽后不再为半开账号生成等待计划
+	_, err = svc.SelectAccountWithLoadAwareness(ctx, &groupID, "", "", excluded)
+	require.Error(t, err)
+	selection, err = svc.SelectAccountWithLoadAwareness(ctx, &groupID, "", "", nil)
+	require.NoError(t, err)
+	require.NotNil(t, selection.WaitPlan)
+	require.Equal(t, int64(2), selection.Account.ID)
+	require.Equal(t, map[int64]struct{}{2: {}}, excluded, "caller exclusions must not be modified")
+}
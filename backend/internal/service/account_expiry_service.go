@@ -9,18 +9,20 @@ import (
 
 // AccountExpiryService periodically pauses expired accounts when auto-pause is enabled.
 type AccountExpiryService struct {
-	accountRepo AccountRepository
-	interval    time.Duration
-	stopCh      chan struct{}
-	stopOnce    sync.Once
-	wg          sync.WaitGroup
+	accountRepo    AccountRepository
+	webhookService *WebhookService
+	interval       time.Duration
+	stopCh         chan struct{}
+	stopOnce       sync.Once
+	wg             sync.WaitGroup
 }
 
-func NewAccountExpiryService(accountRepo AccountRepository, interval time.Duration) *AccountExpiryService {
+func NewAccountExpiryService(accountRepo AccountRepository, webhookService *WebhookService, interval time.Duration) *AccountExpiryService {
 	return &AccountExpiryService{
-		accountRepo: accountRepo,
-		interval:    interval,
-		stopCh:      make(chan struct{}),
+		accountRepo:    accountRepo,
+		webhookService: webhookService,
+		interval:       interval,
+		stopCh:         make(chan struct{}),
 	}
 }
 
@@ -60,12 +62,29 @@ func (s *AccountExpiryService) runOnce() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	updated, err := s.accountRepo.AutoPauseExpiredAccounts(ctx, time.Now())
+	ids, err := s.accountRepo.AutoPauseExpiredAccounts(ctx, time.Now())
 	if err != nil {
 		log.Printf("[AccountExpiry] Auto pause expired accounts failed: %v", err)
 		return
 	}
-	if updated > 0 {
-		log.Printf("[AccountExpiry] Auto paused %d expired accounts", updated)
+	if len(ids) == 0 {
+		return
+	}
+	log.Printf("[AccountExpiry] Auto paused %d expired accounts", len(ids))
+
+	if !s.webhookService.enabled() {
+		return
+	}
+	accounts, err := s.accountRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		log.Printf("[AccountExpiry] Load paused accounts for webhook failed: %v", err)
+		return
+	}
+	for _, account := range accounts {
+		extra := map[string]any{}
+		if account.ExpiresAt != nil {
+			extra["expires_at"] = *account.ExpiresAt
+		}
+		s.webhookService.NotifyAccountEvent(WebhookEventAccountExpired, account, "Account expired and was paused from scheduling.", extra)
 	}
 }
@@ -39,7 +39,7 @@ type AccountRepository interface {
 	SetError(ctx context.Context, id int64, errorMsg string) error
 	ClearError(ctx context.Context, id int64) error
 	SetSchedulable(ctx context.Context, id int64, schedulable bool) error
-	AutoPauseExpiredAccounts(ctx context.Context, now time.Time) (int64, error)
+	AutoPauseExpiredAccounts(ctx context.Context, now time.Time) ([]int64, error)
 	BindGroups(ctx context.Context, accountID int64, groupIDs []int64) error
 
 	ListSchedulable(ctx context.Context) ([]Account, error)
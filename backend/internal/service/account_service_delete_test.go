@@ -107,7 +107,7 @@ func (s *accountRepoStub) SetSchedulable(ctx context.Context, id int64, schedula
 	panic("unexpected SetSchedulable call")
 }
 
-func (s *accountRepoStub) AutoPauseExpiredAccounts(ctx context.Context, now time.Time) (int64, error) {
+func (s *accountRepoStub) AutoPauseExpiredAccounts(ctx context.Context, now time.Time) ([]int64, error) {
 	panic("unexpected AutoPauseExpiredAccounts call")
 }
 
@@ -29,6 +29,8 @@ type UsageLogRepository interface {
 
 	GetAccountWindowStats(ctx context.Context, accountID int64, startTime time.Time) (*usagestats.AccountStats, error)
 	GetAccountTodayStats(ctx context.Context, accountID int64) (*usagestats.AccountStats, error)
+	// GetAccountFirstTokenP50 返回各账号自 since 起首字延迟（毫秒）的中位数，无样本的账号不在结果中
+	GetAccountFirstTokenP50(ctx context.Context, accountIDs []int64, since time.Time) (map[int64]float64, error)
 
 	// Admin dashboard stats
 	GetDashboardStats(ctx context.Context) (*usagestats.DashboardStats, error)
@@ -19,7 +19,8 @@ type AdminService interface {
 	CreateUser(ctx context.Context, input *CreateUserInput) (*User, error)
 	UpdateUser(ctx context.Context, id int64, input *UpdateUserInput) (*User, error)
 	DeleteUser(ctx context.Context, id int64) error
-	UpdateUserBalance(ctx context.Context, userID int64, balance float64, operation string, notes string) (*User, error)
+	// UpdateUserBalance operatorID 为执行调整的管理员（0 表示未知），写入余额流水
+	UpdateUserBalance(ctx context.Context, userID int64, balance float64, operation string, notes string, operatorID int64) (*User, error)
 	GetUserAPIKeys(ctx context.Context, userID int64, page, pageSize int) ([]APIKey, int64, error)
 	GetUserUsageStats(ctx context.Context, userID int64, period string) (any, error)
 
@@ -89,6 +90,8 @@ type UpdateUserInput struct {
 	Concurrency   *int     // 使用指针区分"未提供"和"设置为0"
 	Status        string
 	AllowedGroups *[]int64 // 使用指针区分"未提供"和"设置为空数组"
+	QueuePriority *int     // 公平排队优先级
+	QueueWeight   *int     // 公平排队权重（0 表示使用订阅分组权重或默认值）
 }
 
 type CreateGroupInput struct {
@@ -110,6 +113,24 @@ type CreateGroupInput struct {
 	// 模型路由配置（仅 anthropic 平台使用）
 	ModelRouting        map[string][]int64
 	ModelRoutingEnabled bool // 是否启用模型路由
+	// API Key 默认速率限制（0 表示不限）
+	DefaultRPMLimit *int
+	DefaultTPMLimit *int
+	// 响应缓存（TTL 为空或 0 使用全局默认值）
+	ResponseCacheEnabled    bool
+	ResponseCacheTTLSeconds *int
+	ResponseCacheRate       *float64
+	// 模型降级链（模型模式 -> 目标分组与模型列表）
+	ModelFallbacks map[string][]ModelFallbackTarget
+	// 护栏配置（nil 表示不配置）
+	Guardrails *GuardrailConfig
+	// 调度策略（空为默认）与加权轮询权重（账号 ID -> 权重）
+	SchedulingStrategy string
+	SchedulingWeights  map[int64]int
+	// 对冲延迟（毫秒，0 表示关闭）
+	HedgeDelayMs int
+	// 公平排队权重（0 表示默认）
+	QueueWeight int
 }
 
 type UpdateGroupInput struct {
@@ -132,6 +153,24 @@ type UpdateGroupInput struct {
 	// 模型路由配置（仅 anthropic 平台使用）
 	ModelRouting        map[string][]int64
 	ModelRoutingEnabled *bool // 是否启用模型路由
+	// API Key 默认速率限制（0 或负数表示清除）
+	DefaultRPMLimit *int
+	DefaultTPMLimit *int
+	// 响应缓存（TTL 为 0 或负数表示恢复全局默认值）
+	ResponseCacheEnabled    *bool
+	ResponseCacheTTLSeconds *int
+	ResponseCacheRate       *float64
+	// 模型降级链（传入空对象表示清除）
+	ModelFallbacks map[string][]ModelFallbackTarget
+	// 护栏配置（nil 表示不修改，enabled=false 表示停用）
+	Guardrails *GuardrailConfig
+	// 调度策略（nil 表示不修改，空字符串恢复默认）；权重传入空对象表示清除
+	SchedulingStrategy *string
+	SchedulingWeights  map[int64]int
+	// 对冲延迟（nil 表示不修改，0 表示关闭）
+	HedgeDelayMs *int
+	// 公平排队权重（nil 表示不修改，0 表示默认）
+	QueueWeight *int
 }
 
 type CreateAccountInput struct {
@@ -356,6 +395,8 @@ func (s *adminServiceImpl) UpdateUser(ctx context.Context, id int64, input *Upda
 	oldConcurrency := user.Concurrency
 	oldStatus := user.Status
 	oldRole := user.Role
+	oldQueuePriority := user.QueuePriority
+	oldQueueWeight := user.QueueWeight
 
 	if input.Email != "" {
 		user.Email = input.Email
@@ -385,11 +426,25 @@ func (s *adminServiceImpl) UpdateUser(ctx context.Context, id int64, input *Upda
 		user.AllowedGroups = *input.AllowedGroups
 	}
 
+	if input.QueuePriority != nil {
+		if err := validateQueuePriority(*input.QueuePriority); err != nil {
+			return nil, err
+		}
+		user.QueuePriority = *input.QueuePriority
+	}
+	if input.QueueWeight != nil {
+		if err := validateQueueWeight(*input.QueueWeight); err != nil {
+			return nil, err
+		}
+		user.QueueWeight = *input.QueueWeight
+	}
+
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
 	if s.authCacheInvalidator != nil {
-		if user.Concurrency != oldConcurrency || user.Status != oldStatus || user.Role != oldRole {
+		if user.Concurrency != oldConcurrency || user.Status != oldStatus || user.Role != oldRole ||
+			user.QueuePriority != oldQueuePriority || user.QueueWeight != oldQueueWeight {
 			s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, user.ID)
 		}
 	}
@@ -437,31 +492,45 @@ func (s *adminServiceImpl) DeleteUser(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (s *adminServiceImpl) UpdateUserBalance(ctx context.Context, userID int64, balance float64, operation string, notes string) (*User, error) {
+func (s *adminServiceImpl) UpdateUserBalance(ctx context.Context, userID int64, balance float64, operation string, notes string, operatorID int64) (*User, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	oldBalance := user.Balance
+	newBalance := oldBalance
 
 	switch operation {
 	case "set":
-		user.Balance = balance
+		newBalance = balance
 	case "add":
-		user.Balance += balance
+		newBalance += balance
 	case "subtract":
-		user.Balance -= balance
+		newBalance -= balance
 	}
 
-	if user.Balance < 0 {
-		return nil, fmt.Errorf("balance cannot be negative, current balance: %.2f, requested operation would result in: %.2f", oldBalance, user.Balance)
+	if newBalance < 0 {
+		return nil, fmt.Errorf("balance cannot be negative, current balance: %.2f, requested operation would result in: %.2f", oldBalance, newBalance)
 	}
 
-	if err := s.userRepo.Update(ctx, user); err != nil {
-		return nil, err
+	// 以差额记账（而非直接覆盖余额），不会吞掉读取之后发生的并发扣费
+	balanceDiff := newBalance - oldBalance
+	if balanceDiff != 0 {
+		txn := &BalanceTransaction{
+			UserID: userID,
+			Type:   BalanceTxTypeAdminAdjust,
+			Amount: balanceDiff,
+			Notes:  notes,
+		}
+		if operatorID > 0 {
+			txn.AdminUserID = &operatorID
+		}
+		if err := s.userRepo.ApplyBalanceTransaction(ctx, txn); err != nil {
+			return nil, err
+		}
+		user.Balance = txn.BalanceAfter
 	}
-	balanceDiff := user.Balance - oldBalance
 	if s.authCacheInvalidator != nil && balanceDiff != 0 {
 		s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, userID)
 	}
@@ -571,6 +640,21 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 			return nil, err
 		}
 	}
+	if err := s.validateModelFallbacks(ctx, 0, input.ModelFallbacks); err != nil {
+		return nil, err
+	}
+	if err := input.Guardrails.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateSchedulingConfig(input.SchedulingStrategy, input.SchedulingWeights); err != nil {
+		return nil, err
+	}
+	if err := validateHedgeDelay(input.HedgeDelayMs); err != nil {
+		return nil, err
+	}
+	if err := validateQueueWeight(input.QueueWeight); err != nil {
+		return nil, err
+	}
 
 	group := &Group{
 		Name:             input.Name,
@@ -589,6 +673,20 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 		ClaudeCodeOnly:   input.ClaudeCodeOnly,
 		FallbackGroupID:  input.FallbackGroupID,
 		ModelRouting:     input.ModelRouting,
+		DefaultRPMLimit:  normalizeRateLimit(input.DefaultRPMLimit),
+		DefaultTPMLimit:  normalizeRateLimit(input.DefaultTPMLimit),
+
+		ResponseCacheEnabled:    input.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds: normalizeRateLimit(input.ResponseCacheTTLSeconds),
+		ModelFallbacks:          input.ModelFallbacks,
+		Guardrails:              input.Guardrails,
+		SchedulingStrategy:      input.SchedulingStrategy,
+		SchedulingWeights:       input.SchedulingWeights,
+		HedgeDelayMs:            input.HedgeDelayMs,
+		QueueWeight:             input.QueueWeight,
+	}
+	if input.ResponseCacheRate != nil {
+		group.ResponseCacheRate = *input.ResponseCacheRate
 	}
 	if err := s.groupRepo.Create(ctx, group); err != nil {
 		return nil, err
@@ -596,6 +694,14 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 	return group, nil
 }
 
+// normalizeRateLimit 将 0 或负数转换为 nil（表示不限速）
+func normalizeRateLimit(limit *int) *int {
+	if limit == nil || *limit <= 0 {
+		return nil
+	}
+	return limit
+}
+
 // normalizeLimit 将 0 或负数转换为 nil（表示无限制）
 func normalizeLimit(limit *float64) *float64 {
 	if limit == nil || *limit <= 0 {
@@ -650,6 +756,35 @@ func (s *adminServiceImpl) validateFallbackGroup(ctx context.Context, currentGro
 	}
 }
 
+// validateModelFallbacks 校验模型降级链配置：模型必填、链长度受限、目标分组需存在且为已知平台
+// （各入口可承接的平台不同，运行时由 ModelFallbackEntry.Supports 过滤）
+func (s *adminServiceImpl) validateModelFallbacks(ctx context.Context, groupID int64, fallbacks map[string][]ModelFallbackTarget) error {
+	for pattern, targets := range fallbacks {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("model fallback pattern is required")
+		}
+		if len(targets) > MaxModelFallbackChainLen {
+			return fmt.Errorf("model fallback chain for %q exceeds %d targets", pattern, MaxModelFallbackChainLen)
+		}
+		for _, target := range targets {
+			if strings.TrimSpace(target.Model) == "" {
+				return fmt.Errorf("model fallback target model is required for %q", pattern)
+			}
+			if target.GroupID == groupID && target.Model == pattern {
+				return fmt.Errorf("model fallback for %q cannot target itself", pattern)
+			}
+			targetGroup, err := s.groupRepo.GetByIDLite(ctx, target.GroupID)
+			if err != nil {
+				return fmt.Errorf("model fallback group not found: %w", err)
+			}
+			if !isModelFallbackPlatform(targetGroup.Platform) {
+				return fmt.Errorf("model fallback group %d has unsupported platform %s", target.GroupID, targetGroup.Platform)
+			}
+		}
+	}
+	return nil
+}
+
 func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *UpdateGroupInput) (*Group, error) {
 	group, err := s.groupRepo.GetByID(ctx, id)
 	if err != nil {
@@ -725,6 +860,68 @@ func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *Upd
 		group.ModelRoutingEnabled = *input.ModelRoutingEnabled
 	}
 
+	// API Key 默认速率限制
+	if input.DefaultRPMLimit != nil {
+		group.DefaultRPMLimit = normalizeRateLimit(input.DefaultRPMLimit)
+	}
+	if input.DefaultTPMLimit != nil {
+		group.DefaultTPMLimit = normalizeRateLimit(input.DefaultTPMLimit)
+	}
+
+	// 响应缓存
+	if input.ResponseCacheEnabled != nil {
+		group.ResponseCacheEnabled = *input.ResponseCacheEnabled
+	}
+	if input.ResponseCacheTTLSeconds != nil {
+		group.ResponseCacheTTLSeconds = normalizeRateLimit(input.ResponseCacheTTLSeconds)
+	}
+	if input.ResponseCacheRate != nil {
+		group.ResponseCacheRate = *input.ResponseCacheRate
+	}
+
+	// 模型降级链：传入空对象表示清除
+	if input.ModelFallbacks != nil {
+		if err := s.validateModelFallbacks(ctx, id, input.ModelFallbacks); err != nil {
+			return nil, err
+		}
+		group.ModelFallbacks = input.ModelFallbacks
+	}
+
+	// 护栏配置
+	if input.Guardrails != nil {
+		if err := input.Guardrails.Validate(); err != nil {
+			return nil, err
+		}
+		group.Guardrails = input.Guardrails
+	}
+
+	// 调度策略
+	if input.SchedulingStrategy != nil {
+		group.SchedulingStrategy = *input.SchedulingStrategy
+	}
+	if input.SchedulingWeights != nil {
+		group.SchedulingWeights = input.SchedulingWeights
+	}
+	if err := validateSchedulingConfig(group.SchedulingStrategy, group.SchedulingWeights); err != nil {
+		return nil, err
+	}
+
+	// 对冲请求
+	if input.HedgeDelayMs != nil {
+		if err := validateHedgeDelay(*input.HedgeDelayMs); err != nil {
+			return nil, err
+		}
+		group.HedgeDelayMs = *input.HedgeDelayMs
+	}
+
+	// 公平排队权重
+	if input.QueueWeight != nil {
+		if err := validateQueueWeight(*input.QueueWeight); err != nil {
+			return nil, err
+		}
+		group.QueueWeight = *input.QueueWeight
+	}
+
 	if err := s.groupRepo.Update(ctx, group); err != nil {
 		return nil, err
 	}
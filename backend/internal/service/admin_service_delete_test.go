@@ -70,12 +70,8 @@ func (s *userRepoStub) ListWithFilters(ctx context.Context, params pagination.Pa
 	panic("unexpected ListWithFilters call")
 }
 
-func (s *userRepoStub) UpdateBalance(ctx context.Context, id int64, amount float64) error {
-	panic("unexpected UpdateBalance call")
-}
-
-func (s *userRepoStub) DeductBalance(ctx context.Context, id int64, amount float64) error {
-	panic("unexpected DeductBalance call")
+func (s *userRepoStub) ApplyBalanceTransaction(ctx context.Context, txn *BalanceTransaction) error {
+	panic("unexpected ApplyBalanceTransaction call")
 }
 
 func (s *userRepoStub) UpdateConcurrency(ctx context.Context, id int64, amount int) error {
@@ -303,6 +299,22 @@ func (s *billingCacheStub) InvalidateUserBalance(ctx context.Context, userID int
 	panic("unexpected InvalidateUserBalance call")
 }
 
+func (s *billingCacheStub) GetOrganizationBalance(ctx context.Context, orgID int64) (float64, error) {
+	panic("unexpected GetOrganizationBalance call")
+}
+
+func (s *billingCacheStub) SetOrganizationBalance(ctx context.Context, orgID int64, balance float64) error {
+	panic("unexpected SetOrganizationBalance call")
+}
+
+func (s *billingCacheStub) DeductOrganizationBalance(ctx context.Context, orgID int64, amount float64) error {
+	panic("unexpected DeductOrganizationBalance call")
+}
+
+func (s *billingCacheStub) InvalidateOrganizationBalance(ctx context.Context, orgID int64) error {
+	panic("unexpected InvalidateOrganizationBalance call")
+}
+
 func (s *billingCacheStub) GetSubscriptionCache(ctx context.Context, userID, groupID int64) (*SubscriptionCacheData, error) {
 	panic("unexpected GetSubscriptionCache call")
 }
@@ -320,6 +332,18 @@ func (s *billingCacheStub) InvalidateSubscriptionCache(ctx context.Context, user
 	return nil
 }
 
+func (s *billingCacheStub) GetAPIKeyUsage(ctx context.Context, apiKeyID int64) (float64, error) {
+	return 0, nil
+}
+
+func (s *billingCacheStub) SetAPIKeyUsage(ctx context.Context, apiKeyID int64, used float64) error {
+	return nil
+}
+
+func (s *billingCacheStub) IncrementAPIKeyUsage(ctx context.Context, apiKeyID int64, amount float64) error {
+	return nil
+}
+
 func waitForInvalidations(t *testing.T, ch <-chan subscriptionInvalidateCall, expected int) []subscriptionInvalidateCall {
 	t.Helper()
 	calls := make([]subscriptionInvalidateCall, 0, expected)
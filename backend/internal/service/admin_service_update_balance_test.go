@@ -11,22 +11,23 @@ import (
 
 type balanceUserRepoStub struct {
 	*userRepoStub
-	updateErr error
-	updated   []*User
+	applyErr error
+	applied  []*BalanceTransaction
 }
 
-func (s *balanceUserRepoStub) Update(ctx context.Context, user *User) error {
-	if s.updateErr != nil {
-		return s.updateErr
+func (s *balanceUserRepoStub) ApplyBalanceTransaction(ctx context.Context, txn *BalanceTransaction) error {
+	if s.applyErr != nil {
+		return s.applyErr
 	}
-	if user == nil {
+	if txn == nil {
 		return nil
 	}
-	clone := *user
-	s.updated = append(s.updated, &clone)
-	if s.userRepoStub != nil {
-		s.userRepoStub.user = &clone
+	if s.userRepoStub != nil && s.userRepoStub.user != nil {
+		s.userRepoStub.user.Balance += txn.Amount
+		txn.BalanceAfter = s.userRepoStub.user.Balance
 	}
+	clone := *txn
+	s.applied = append(s.applied, &clone)
 	return nil
 }
 
@@ -73,10 +74,16 @@ func TestAdminService_UpdateUserBalance_InvalidatesAuthCache(t *testing.T) {
 		authCacheInvalidator: invalidator,
 	}
 
-	_, err := svc.UpdateUserBalance(context.Background(), 7, 5, "add", "")
+	user, err := svc.UpdateUserBalance(context.Background(), 7, 5, "add", "refund", 1)
 	require.NoError(t, err)
 	require.Equal(t, []int64{7}, invalidator.userIDs)
 	require.Len(t, redeemRepo.created, 1)
+	require.Equal(t, 15.0, user.Balance)
+	require.Len(t, repo.applied, 1)
+	require.Equal(t, BalanceTxTypeAdminAdjust, repo.applied[0].Type)
+	require.Equal(t, 5.0, repo.applied[0].Amount)
+	require.Equal(t, int64(1), *repo.applied[0].AdminUserID)
+	require.Equal(t, "refund", repo.applied[0].Notes)
 }
 
 func TestAdminService_UpdateUserBalance_NoChangeNoInvalidate(t *testing.T) {
@@ -90,8 +97,9 @@ func TestAdminService_UpdateUserBalance_NoChangeNoInvalidate(t *testing.T) {
 		authCacheInvalidator: invalidator,
 	}
 
-	_, err := svc.UpdateUserBalance(context.Background(), 7, 10, "set", "")
+	_, err := svc.UpdateUserBalance(context.Background(), 7, 10, "set", "", 0)
 	require.NoError(t, err)
 	require.Empty(t, invalidator.userIDs)
 	require.Empty(t, redeemRepo.created)
+	require.Empty(t, repo.applied)
 }
@@ -17,6 +17,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/Wei-Shaw/sub2api/internal/config"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/antigravity"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -333,6 +334,14 @@ func NewAntigravityGatewayService(
 	}
 }
 
+// config 返回全局配置（经 SettingService 持有），未注入时返回 nil
+func (s *AntigravityGatewayService) config() *config.Config {
+	if s.settingService == nil {
+		return nil
+	}
+	return s.settingService.cfg
+}
+
 // GetTokenProvider 返回 token provider
 func (s *AntigravityGatewayService) GetTokenProvider() *AntigravityTokenProvider {
 	return s.tokenProvider
@@ -708,6 +717,10 @@ func (s *AntigravityGatewayService) Forward(ctx context.Context, c *gin.Context,
 		return nil, fmt.Errorf("missing model")
 	}
 
+	// 流式请求：客户端断开后可按配置继续排空上游以获取完整 usage
+	ctx, cancelUpstream := detachUpstreamContext(ctx, streamDrainTimeout(s.config(), claudeReq.Stream))
+	defer cancelUpstream()
+
 	originalModel := claudeReq.Model
 	mappedModel := s.getMappedModel(account, claudeReq.Model)
 	quotaScope, _ := resolveAntigravityQuotaScope(originalModel)
@@ -948,15 +961,17 @@ func (s *AntigravityGatewayService) Forward(ctx context.Context, c *gin.Context,
 
 	var usage *ClaudeUsage
 	var firstTokenMs *int
+	var clientDisconnect bool
 	if claudeReq.Stream {
 		// 客户端要求流式，直接透传转换
-		streamRes, err := s.handleClaudeStreamingResponse(c, resp, startTime, originalModel)
+		streamRes, err := s.handleClaudeStreamingResponse(c, resp, account, startTime, originalModel)
 		if err != nil {
 			log.Printf("%s status=stream_error error=%v", prefix, err)
 			return nil, err
 		}
 		usage = streamRes.usage
 		firstTokenMs = streamRes.firstTokenMs
+		clientDisconnect = streamRes.clientDisconnect
 	} else {
 		// 客户端要求非流式，收集流式响应后转换返回
 		streamRes, err := s.handleClaudeStreamToNonStreaming(c, resp, startTime, originalModel)
@@ -969,12 +984,13 @@ func (s *AntigravityGatewayService) Forward(ctx context.Context, c *gin.Context,
 	}
 
 	return &ForwardResult{
-		RequestID:    requestID,
-		Usage:        *usage,
-		Model:        originalModel, // 使用原始模型用于计费和日志
-		Stream:       claudeReq.Stream,
-		Duration:     time.Since(startTime),
-		FirstTokenMs: firstTokenMs,
+		RequestID:        requestID,
+		Usage:            *usage,
+		Model:            originalModel, // 使用原始模型用于计费和日志
+		Stream:           claudeReq.Stream,
+		Duration:         time.Since(startTime),
+		FirstTokenMs:     firstTokenMs,
+		ClientDisconnect: clientDisconnect,
 	}, nil
 }
 
@@ -1258,6 +1274,10 @@ func (s *AntigravityGatewayService) ForwardGemini(ctx context.Context, c *gin.Co
 	}
 	quotaScope, _ := resolveAntigravityQuotaScope(originalModel)
 
+	// 流式请求：客户端断开后可按配置继续排空上游以获取完整 usage
+	ctx, cancelUpstream := detachUpstreamContext(ctx, streamDrainTimeout(s.config(), stream))
+	defer cancelUpstream()
+
 	// 解析请求以获取 image_size（用于图片计费）
 	imageSize := s.extractImageSize(body)
 
@@ -1451,16 +1471,18 @@ handleSuccess:
 
 	var usage *ClaudeUsage
 	var firstTokenMs *int
+	var clientDisconnect bool
 
 	if stream {
 		// 客户端要求流式，直接透传
-		streamRes, err := s.handleGeminiStreamingResponse(c, resp, startTime)
+		streamRes, err := s.handleGeminiStreamingResponse(c, resp, account, startTime)
 		if err != nil {
 			log.Printf("%s status=stream_error error=%v", prefix, err)
 			return nil, err
 		}
 		usage = streamRes.usage
 		firstTokenMs = streamRes.firstTokenMs
+		clientDisconnect = streamRes.clientDisconnect
 	} else {
 		// 客户端要求非流式，收集流式响应后返回
 		streamRes, err := s.handleGeminiStreamToNonStreaming(c, resp, startTime)
@@ -1484,14 +1506,15 @@ handleSuccess:
 	}
 
 	return &ForwardResult{
-		RequestID:    requestID,
-		Usage:        *usage,
-		Model:        originalModel,
-		Stream:       stream,
-		Duration:     time.Since(startTime),
-		FirstTokenMs: firstTokenMs,
-		ImageCount:   imageCount,
-		ImageSize:    imageSize,
+		RequestID:        requestID,
+		Usage:            *usage,
+		Model:            originalModel,
+		Stream:           stream,
+		Duration:         time.Since(startTime),
+		FirstTokenMs:     firstTokenMs,
+		ImageCount:       imageCount,
+		ImageSize:        imageSize,
+		ClientDisconnect: clientDisconnect,
 	}, nil
 }
 
@@ -1584,11 +1607,12 @@ func (s *AntigravityGatewayService) handleUpstreamError(ctx context.Context, pre
 }
 
 type antigravityStreamResult struct {
-	usage        *ClaudeUsage
-	firstTokenMs *int
+	usage            *ClaudeUsage
+	firstTokenMs     *int
+	clientDisconnect bool // 客户端是否在流式传输过程中断开
 }
 
-func (s *AntigravityGatewayService) handleGeminiStreamingResponse(c *gin.Context, resp *http.Response, startTime time.Time) (*antigravityStreamResult, error) {
+func (s *AntigravityGatewayService) handleGeminiStreamingResponse(c *gin.Context, resp *http.Response, account *Account, startTime time.Time) (*antigravityStreamResult, error) {
 	c.Status(resp.StatusCode)
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
@@ -1672,13 +1696,43 @@ func (s *AntigravityGatewayService) handleGeminiStreamingResponse(c *gin.Context
 		flusher.Flush()
 	}
 
+	// 客户端断开后停止写入，继续读取上游以获取完整 usage（上游读取时长受 stream_drain_on_disconnect_timeout 限制）
+	clientDisconnected := false
+	clientGone := clientRequestDone(c)
+	write := func(format string, args ...any) {
+		if clientDisconnected {
+			return
+		}
+		if _, err := fmt.Fprintf(c.Writer, format, args...); err != nil {
+			clientDisconnected = true
+			log.Printf("Client disconnected during streaming (antigravity), continuing to drain upstream for billing")
+			return
+		}
+		flusher.Flush()
+	}
+	disconnectResult := func(completed bool) *antigravityStreamResult {
+		finishClientDisconnect(c, account.Platform, account.ID, resp, completed)
+		return &antigravityStreamResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: true}
+	}
+
 	for {
 		select {
+		case <-clientGone:
+			clientGone = nil
+			clientDisconnected = true
+
 		case ev, ok := <-events:
 			if !ok {
+				if clientDisconnected || isClientDisconnected(c) {
+					return disconnectResult(true), nil
+				}
 				return &antigravityStreamResult{usage: usage, firstTokenMs: firstTokenMs}, nil
 			}
 			if ev.err != nil {
+				// 客户端已断开（上游随之取消或排空超时），返回已收集的 usage
+				if clientDisconnected || isClientDisconnected(c) {
+					return disconnectResult(false), nil
+				}
 				if errors.Is(ev.err, bufio.ErrTooLong) {
 					log.Printf("SSE line too long (antigravity): max_size=%d error=%v", maxLineSize, ev.err)
 					sendErrorEvent("response_too_large")
@@ -1693,11 +1747,7 @@ func (s *AntigravityGatewayService) handleGeminiStreamingResponse(c *gin.Context
 			if strings.HasPrefix(trimmed, "data:") {
 				payload := strings.TrimSpace(strings.TrimPrefix(trimmed, "data:"))
 				if payload == "" || payload == "[DONE]" {
-					if _, err := fmt.Fprintf(c.Writer, "%s\n", line); err != nil {
-						sendErrorEvent("write_failed")
-						return &antigravityStreamResult{usage: usage, firstTokenMs: firstTokenMs}, err
-					}
-					flusher.Flush()
+					write("%s\n", line)
 					continue
 				}
 
@@ -1733,27 +1783,23 @@ func (s *AntigravityGatewayService) handleGeminiStreamingResponse(c *gin.Context
 					firstTokenMs = &ms
 				}
 
-				if _, err := fmt.Fprintf(c.Writer, "data: %s\n\n", payload); err != nil {
-					sendErrorEvent("write_failed")
-					return &antigravityStreamResult{usage: usage, firstTokenMs: firstTokenMs}, err
-				}
-				flusher.Flush()
+				write("data: %s\n\n", payload)
 				continue
 			}
 
-			if _, err := fmt.Fprintf(c.Writer, "%s\n", line); err != nil {
-				sendErrorEvent("write_failed")
-				return &antigravityStreamResult{usage: usage, firstTokenMs: firstTokenMs}, err
-			}
-			flusher.Flush()
+			write("%s\n", line)
 
 		case <-intervalCh:
 			lastRead := time.Unix(0, atomic.LoadInt64(&lastReadAt))
 			if time.Since(lastRead) < streamInterval {
 				continue
 			}
+			if clientDisconnected {
+				log.Printf("Upstream timeout after client disconnect (antigravity), returning collected usage")
+				return disconnectResult(false), nil
+			}
 			log.Printf("Stream data interval timeout (antigravity)")
-			// 注意：此函数没有 account 上下文，无法调用 HandleStreamTimeout
+			// 注意：Antigravity 流超时不调用 HandleStreamTimeout，不影响账号调度状态
 			sendErrorEvent("stream_timeout")
 			return &antigravityStreamResult{usage: usage, firstTokenMs: firstTokenMs}, fmt.Errorf("stream data interval timeout")
 		}
@@ -2375,7 +2421,7 @@ returnResponse:
 }
 
 // handleClaudeStreamingResponse 处理 Claude 流式响应（Gemini SSE → Claude SSE 转换）
-func (s *AntigravityGatewayService) handleClaudeStreamingResponse(c *gin.Context, resp *http.Response, startTime time.Time, originalModel string) (*antigravityStreamResult, error) {
+func (s *AntigravityGatewayService) handleClaudeStreamingResponse(c *gin.Context, resp *http.Response, account *Account, startTime time.Time, originalModel string) (*antigravityStreamResult, error) {
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
@@ -2466,19 +2512,47 @@ func (s *AntigravityGatewayService) handleClaudeStreamingResponse(c *gin.Context
 		flusher.Flush()
 	}
 
+	// 客户端断开后停止写入，继续读取上游以获取完整 usage（上游读取时长受 stream_drain_on_disconnect_timeout 限制）
+	clientDisconnected := false
+	clientGone := clientRequestDone(c)
+	write := func(data []byte) {
+		if clientDisconnected || len(data) == 0 {
+			return
+		}
+		if _, err := c.Writer.Write(data); err != nil {
+			clientDisconnected = true
+			log.Printf("Client disconnected during streaming (antigravity), continuing to drain upstream for billing")
+			return
+		}
+		flusher.Flush()
+	}
+	disconnectResult := func(agUsage *antigravity.ClaudeUsage, completed bool) *antigravityStreamResult {
+		finishClientDisconnect(c, account.Platform, account.ID, resp, completed)
+		return &antigravityStreamResult{usage: convertUsage(agUsage), firstTokenMs: firstTokenMs, clientDisconnect: true}
+	}
+
 	for {
 		select {
+		case <-clientGone:
+			clientGone = nil
+			clientDisconnected = true
+
 		case ev, ok := <-events:
 			if !ok {
 				// 发送结束事件
 				finalEvents, agUsage := processor.Finish()
-				if len(finalEvents) > 0 {
-					_, _ = c.Writer.Write(finalEvents)
-					flusher.Flush()
+				write(finalEvents)
+				if clientDisconnected || isClientDisconnected(c) {
+					return disconnectResult(agUsage, true), nil
 				}
 				return &antigravityStreamResult{usage: convertUsage(agUsage), firstTokenMs: firstTokenMs}, nil
 			}
 			if ev.err != nil {
+				// 客户端已断开（上游随之取消或排空超时），返回已收集的 usage
+				if clientDisconnected || isClientDisconnected(c) {
+					_, agUsage := processor.Finish()
+					return disconnectResult(agUsage, false), nil
+				}
 				if errors.Is(ev.err, bufio.ErrTooLong) {
 					log.Printf("SSE line too long (antigravity): max_size=%d error=%v", maxLineSize, ev.err)
 					sendErrorEvent("response_too_large")
@@ -2497,16 +2571,7 @@ func (s *AntigravityGatewayService) handleClaudeStreamingResponse(c *gin.Context
 					ms := int(time.Since(startTime).Milliseconds())
 					firstTokenMs = &ms
 				}
-
-				if _, writeErr := c.Writer.Write(claudeEvents); writeErr != nil {
-					finalEvents, agUsage := processor.Finish()
-					if len(finalEvents) > 0 {
-						_, _ = c.Writer.Write(finalEvents)
-					}
-					sendErrorEvent("write_failed")
-					return &antigravityStreamResult{usage: convertUsage(agUsage), firstTokenMs: firstTokenMs}, writeErr
-				}
-				flusher.Flush()
+				write(claudeEvents)
 			}
 
 		case <-intervalCh:
@@ -2514,8 +2579,13 @@ func (s *AntigravityGatewayService) handleClaudeStreamingResponse(c *gin.Context
 			if time.Since(lastRead) < streamInterval {
 				continue
 			}
+			if clientDisconnected {
+				log.Printf("Upstream timeout after client disconnect (antigravity), returning collected usage")
+				_, agUsage := processor.Finish()
+				return disconnectResult(agUsage, false), nil
+			}
 			log.Printf("Stream data interval timeout (antigravity)")
-			// 注意：此函数没有 account 上下文，无法调用 HandleStreamTimeout
+			// 注意：Antigravity 流超时不调用 HandleStreamTimeout，不影响账号调度状态
 			sendErrorEvent("stream_timeout")
 			return &antigravityStreamResult{usage: convertUsage(nil), firstTokenMs: firstTokenMs}, fmt.Errorf("stream data interval timeout")
 		}
@@ -1,22 +1,124 @@
 package service
 
-import "time"
+import (
+	"net/http"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
 
 type APIKey struct {
-	ID          int64
-	UserID      int64
-	Key         string
-	Name        string
-	GroupID     *int64
-	Status      string
-	IPWhitelist []string
-	IPBlacklist []string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	User        *User
-	Group       *Group
+	ID            int64
+	UserID        int64
+	Key           string
+	Name          string
+	GroupID       *int64
+	Status        string
+	IPWhitelist   []string
+	IPBlacklist   []string
+	QuotaUSD      *float64   // 额度上限（USD），nil 表示不限
+	UsedUSD       float64    // 已用额度（USD）
+	ExpiresAt     *time.Time // 过期时间，nil 表示永不过期
+	AllowedModels []string   // 允许的模型模式（支持末尾 * 通配符），为空表示不限
+	MaxTokensCap  *int       // max_tokens/max_output_tokens 上限，nil 表示不限
+	RPMLimit      *int       // 每分钟请求数上限，nil 时使用分组默认值
+	TPMLimit      *int       // 每分钟 token 数上限，nil 时使用分组默认值
+	// OrganizationID 团队 API Key 所属组织，用量扣减组织余额
+	OrganizationID *int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	User           *User
+	Group          *Group
+
+	// 认证时加载：团队 API Key 的组织与 Key 所属用户的成员信息（已不是成员时为 nil）
+	Organization       *Organization
+	OrganizationMember *OrganizationMember
 }
 
 func (k *APIKey) IsActive() bool {
 	return k.Status == StatusActive
 }
+
+// IsTeamKey 是否为团队 API Key
+func (k *APIKey) IsTeamKey() bool {
+	return k.OrganizationID != nil
+}
+
+// IsTeamKeyUsable 团队 API Key 的组织是否可用且 Key 所属用户仍是成员（非团队 Key 恒为 true）
+func (k *APIKey) IsTeamKeyUsable() bool {
+	if !k.IsTeamKey() {
+		return true
+	}
+	return k.Organization != nil && k.Organization.IsActive() && k.OrganizationMember != nil
+}
+
+// SubscriptionUserID 订阅分组下校验与计量所使用的订阅所属用户：
+// 组织开启共享订阅时为组织所有者，否则为 Key 所属用户
+func (k *APIKey) SubscriptionUserID() int64 {
+	if k.IsTeamKey() && k.Organization != nil && k.Organization.SharedSubscriptions {
+		return k.Organization.OwnerUserID
+	}
+	if k.User != nil {
+		return k.User.ID
+	}
+	return k.UserID
+}
+
+// IsExpired 是否已过期
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && !time.Now().Before(*k.ExpiresAt)
+}
+
+// HasQuota 是否设置了额度上限
+func (k *APIKey) HasQuota() bool {
+	return k.QuotaUSD != nil
+}
+
+// IsQuotaExhausted 按给定已用额度判断是否超出上限
+func (k *APIKey) IsQuotaExhausted(usedUSD float64) bool {
+	return k.QuotaUSD != nil && usedUSD >= *k.QuotaUSD
+}
+
+// IsModelAllowed 检查模型是否在 API Key 的白名单内（未配置白名单时放行）
+func (k *APIKey) IsModelAllowed(model string) bool {
+	if len(k.AllowedModels) == 0 {
+		return true
+	}
+	for _, pattern := range k.AllowedModels {
+		if matchModelPattern(pattern, model) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExceedsMaxTokens 检查请求的输出 token 上限是否超过 API Key 限制
+func (k *APIKey) ExceedsMaxTokens(maxTokens int) bool {
+	return k.MaxTokensCap != nil && maxTokens > *k.MaxTokensCap
+}
+
+// ApplyMaxTokensCap 请求体未指定输出上限（path 字段缺失或不大于 0）时写入 API Key 的上限，
+// 避免省略字段绕过 MaxTokensCap。返回 false 表示请求体未修改。
+func (k *APIKey) ApplyMaxTokensCap(body []byte, path string) ([]byte, bool) {
+	if k == nil || k.MaxTokensCap == nil || gjson.GetBytes(body, path).Int() > 0 {
+		return body, false
+	}
+	out, err := sjson.SetBytes(body, path, *k.MaxTokensCap)
+	if err != nil {
+		return body, false
+	}
+	return out, true
+}
+
+// CheckRequestLimits 校验请求模型与输出 token 上限是否符合 API Key 限制
+func (k *APIKey) CheckRequestLimits(model string, maxTokens int) error {
+	if model != "" && !k.IsModelAllowed(model) {
+		return infraerrors.Newf(http.StatusForbidden, ErrAPIKeyModelNotAllowed.Reason, "model %s is not allowed for this API key", model)
+	}
+	if k.ExceedsMaxTokens(maxTokens) {
+		return infraerrors.Newf(http.StatusForbidden, ErrAPIKeyMaxTokensExceeded.Reason, "max tokens %d exceeds the limit of %d for this API key", maxTokens, *k.MaxTokensCap)
+	}
+	return nil
+}
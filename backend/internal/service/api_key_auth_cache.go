@@ -1,24 +1,50 @@
 package service
 
+import "time"
+
 // APIKeyAuthSnapshot API Key 认证缓存快照（仅包含认证所需字段）
 type APIKeyAuthSnapshot struct {
-	APIKeyID    int64                    `json:"api_key_id"`
-	UserID      int64                    `json:"user_id"`
-	GroupID     *int64                   `json:"group_id,omitempty"`
-	Status      string                   `json:"status"`
-	IPWhitelist []string                 `json:"ip_whitelist,omitempty"`
-	IPBlacklist []string                 `json:"ip_blacklist,omitempty"`
-	User        APIKeyAuthUserSnapshot   `json:"user"`
-	Group       *APIKeyAuthGroupSnapshot `json:"group,omitempty"`
+	APIKeyID      int64                    `json:"api_key_id"`
+	UserID        int64                    `json:"user_id"`
+	GroupID       *int64                   `json:"group_id,omitempty"`
+	Status        string                   `json:"status"`
+	IPWhitelist   []string                 `json:"ip_whitelist,omitempty"`
+	IPBlacklist   []string                 `json:"ip_blacklist,omitempty"`
+	QuotaUSD      *float64                 `json:"quota_usd,omitempty"`
+	UsedUSD       float64                  `json:"used_usd,omitempty"`
+	ExpiresAt     *time.Time               `json:"expires_at,omitempty"`
+	AllowedModels []string                 `json:"allowed_models,omitempty"`
+	MaxTokensCap  *int                     `json:"max_tokens_cap,omitempty"`
+	RPMLimit      *int                     `json:"rpm_limit,omitempty"`
+	TPMLimit      *int                     `json:"tpm_limit,omitempty"`
+	User          APIKeyAuthUserSnapshot   `json:"user"`
+	Group         *APIKeyAuthGroupSnapshot `json:"group,omitempty"`
+
+	OrganizationID *int64                          `json:"organization_id,omitempty"`
+	Organization   *APIKeyAuthOrganizationSnapshot `json:"organization,omitempty"`
+}
+
+// APIKeyAuthOrganizationSnapshot 团队 API Key 的组织快照
+type APIKeyAuthOrganizationSnapshot struct {
+	ID                  int64   `json:"id"`
+	Status              string  `json:"status"`
+	OwnerUserID         int64   `json:"owner_user_id"`
+	Balance             float64 `json:"balance"`
+	SharedSubscriptions bool    `json:"shared_subscriptions"`
+	// MemberRole 为空表示 Key 所属用户已不是组织成员
+	MemberRole          string   `json:"member_role,omitempty"`
+	MemberSpendLimitUSD *float64 `json:"member_spend_limit_usd,omitempty"`
 }
 
 // APIKeyAuthUserSnapshot 用户快照
 type APIKeyAuthUserSnapshot struct {
-	ID          int64   `json:"id"`
-	Status      string  `json:"status"`
-	Role        string  `json:"role"`
-	Balance     float64 `json:"balance"`
-	Concurrency int     `json:"concurrency"`
+	ID            int64   `json:"id"`
+	Status        string  `json:"status"`
+	Role          string  `json:"role"`
+	Balance       float64 `json:"balance"`
+	Concurrency   int     `json:"concurrency"`
+	QueuePriority int     `json:"queue_priority,omitempty"`
+	QueueWeight   int     `json:"queue_weight,omitempty"`
 }
 
 // APIKeyAuthGroupSnapshot 分组快照
@@ -42,6 +68,31 @@ type APIKeyAuthGroupSnapshot struct {
 	// Only anthropic groups use these fields; others may leave them empty.
 	ModelRouting        map[string][]int64 `json:"model_routing,omitempty"`
 	ModelRoutingEnabled bool               `json:"model_routing_enabled"`
+
+	// API Key 默认速率限制，Key 未单独配置时在网关入口生效
+	DefaultRPMLimit *int `json:"default_rpm_limit,omitempty"`
+	DefaultTPMLimit *int `json:"default_tpm_limit,omitempty"`
+
+	// 响应缓存配置，在网关入口判断是否查询缓存
+	ResponseCacheEnabled    bool    `json:"response_cache_enabled"`
+	ResponseCacheTTLSeconds *int    `json:"response_cache_ttl_seconds,omitempty"`
+	ResponseCacheRate       float64 `json:"response_cache_rate"`
+
+	// 模型降级链，在网关入口账号全部不可用时使用
+	ModelFallbacks map[string][]ModelFallbackTarget `json:"model_fallbacks,omitempty"`
+
+	// 护栏配置，在网关入口转发前执行
+	Guardrails *GuardrailConfig `json:"guardrails,omitempty"`
+
+	// 调度策略，账号选择时从上下文分组读取
+	SchedulingStrategy string        `json:"scheduling_strategy,omitempty"`
+	SchedulingWeights  map[int64]int `json:"scheduling_weights,omitempty"`
+
+	// 对冲延迟（毫秒），0 表示关闭
+	HedgeDelayMs int `json:"hedge_delay_ms,omitempty"`
+
+	// 公平排队权重
+	QueueWeight int `json:"queue_weight,omitempty"`
 }
 
 // APIKeyAuthCacheEntry 缓存条目，支持负缓存
@@ -168,7 +168,7 @@ func (s *APIKeyService) deleteAuthCache(ctx context.Context, cacheKey string) {
 }
 
 func (s *APIKeyService) loadAuthCacheEntry(ctx context.Context, key, cacheKey string) (*APIKeyAuthCacheEntry, error) {
-	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, key)
+	apiKey, err := s.getByKeyForAuth(ctx, key)
 	if err != nil {
 		if errors.Is(err, ErrAPIKeyNotFound) {
 			entry := &APIKeyAuthCacheEntry{NotFound: true}
@@ -207,38 +207,74 @@ func (s *APIKeyService) snapshotFromAPIKey(apiKey *APIKey) *APIKeyAuthSnapshot {
 		return nil
 	}
 	snapshot := &APIKeyAuthSnapshot{
-		APIKeyID:    apiKey.ID,
-		UserID:      apiKey.UserID,
-		GroupID:     apiKey.GroupID,
-		Status:      apiKey.Status,
-		IPWhitelist: apiKey.IPWhitelist,
-		IPBlacklist: apiKey.IPBlacklist,
+		APIKeyID:      apiKey.ID,
+		UserID:        apiKey.UserID,
+		GroupID:       apiKey.GroupID,
+		Status:        apiKey.Status,
+		IPWhitelist:   apiKey.IPWhitelist,
+		IPBlacklist:   apiKey.IPBlacklist,
+		QuotaUSD:      apiKey.QuotaUSD,
+		UsedUSD:       apiKey.UsedUSD,
+		ExpiresAt:     apiKey.ExpiresAt,
+		AllowedModels: apiKey.AllowedModels,
+		MaxTokensCap:  apiKey.MaxTokensCap,
+		RPMLimit:      apiKey.RPMLimit,
+		TPMLimit:      apiKey.TPMLimit,
 		User: APIKeyAuthUserSnapshot{
-			ID:          apiKey.User.ID,
-			Status:      apiKey.User.Status,
-			Role:        apiKey.User.Role,
-			Balance:     apiKey.User.Balance,
-			Concurrency: apiKey.User.Concurrency,
+			ID:            apiKey.User.ID,
+			Status:        apiKey.User.Status,
+			Role:          apiKey.User.Role,
+			Balance:       apiKey.User.Balance,
+			Concurrency:   apiKey.User.Concurrency,
+			QueuePriority: apiKey.User.QueuePriority,
+			QueueWeight:   apiKey.User.QueueWeight,
 		},
 	}
+	if apiKey.OrganizationID != nil {
+		snapshot.OrganizationID = apiKey.OrganizationID
+		if org := apiKey.Organization; org != nil {
+			snapshot.Organization = &APIKeyAuthOrganizationSnapshot{
+				ID:                  org.ID,
+				Status:              org.Status,
+				OwnerUserID:         org.OwnerUserID,
+				Balance:             org.Balance,
+				SharedSubscriptions: org.SharedSubscriptions,
+			}
+			if member := apiKey.OrganizationMember; member != nil {
+				snapshot.Organization.MemberRole = member.Role
+				snapshot.Organization.MemberSpendLimitUSD = member.SpendLimitUSD
+			}
+		}
+	}
 	if apiKey.Group != nil {
 		snapshot.Group = &APIKeyAuthGroupSnapshot{
-			ID:                  apiKey.Group.ID,
-			Name:                apiKey.Group.Name,
-			Platform:            apiKey.Group.Platform,
-			Status:              apiKey.Group.Status,
-			SubscriptionType:    apiKey.Group.SubscriptionType,
-			RateMultiplier:      apiKey.Group.RateMultiplier,
-			DailyLimitUSD:       apiKey.Group.DailyLimitUSD,
-			WeeklyLimitUSD:      apiKey.Group.WeeklyLimitUSD,
-			MonthlyLimitUSD:     apiKey.Group.MonthlyLimitUSD,
-			ImagePrice1K:        apiKey.Group.ImagePrice1K,
-			ImagePrice2K:        apiKey.Group.ImagePrice2K,
-			ImagePrice4K:        apiKey.Group.ImagePrice4K,
-			ClaudeCodeOnly:      apiKey.Group.ClaudeCodeOnly,
-			FallbackGroupID:     apiKey.Group.FallbackGroupID,
-			ModelRouting:        apiKey.Group.ModelRouting,
-			ModelRoutingEnabled: apiKey.Group.ModelRoutingEnabled,
+			ID:                      apiKey.Group.ID,
+			Name:                    apiKey.Group.Name,
+			Platform:                apiKey.Group.Platform,
+			Status:                  apiKey.Group.Status,
+			SubscriptionType:        apiKey.Group.SubscriptionType,
+			RateMultiplier:          apiKey.Group.RateMultiplier,
+			DailyLimitUSD:           apiKey.Group.DailyLimitUSD,
+			WeeklyLimitUSD:          apiKey.Group.WeeklyLimitUSD,
+			MonthlyLimitUSD:         apiKey.Group.MonthlyLimitUSD,
+			ImagePrice1K:            apiKey.Group.ImagePrice1K,
+			ImagePrice2K:            apiKey.Group.ImagePrice2K,
+			ImagePrice4K:            apiKey.Group.ImagePrice4K,
+			ClaudeCodeOnly:          apiKey.Group.ClaudeCodeOnly,
+			FallbackGroupID:         apiKey.Group.FallbackGroupID,
+			ModelRouting:            apiKey.Group.ModelRouting,
+			ModelRoutingEnabled:     apiKey.Group.ModelRoutingEnabled,
+			DefaultRPMLimit:         apiKey.Group.DefaultRPMLimit,
+			DefaultTPMLimit:         apiKey.Group.DefaultTPMLimit,
+			ResponseCacheEnabled:    apiKey.Group.ResponseCacheEnabled,
+			ResponseCacheTTLSeconds: apiKey.Group.ResponseCacheTTLSeconds,
+			ResponseCacheRate:       apiKey.Group.ResponseCacheRate,
+			ModelFallbacks:          apiKey.Group.ModelFallbacks,
+			Guardrails:              apiKey.Group.Guardrails,
+			SchedulingStrategy:      apiKey.Group.SchedulingStrategy,
+			SchedulingWeights:       apiKey.Group.SchedulingWeights,
+			HedgeDelayMs:            apiKey.Group.HedgeDelayMs,
+			QueueWeight:             apiKey.Group.QueueWeight,
 		}
 	}
 	return snapshot
@@ -249,40 +285,80 @@ func (s *APIKeyService) snapshotToAPIKey(key string, snapshot *APIKeyAuthSnapsho
 		return nil
 	}
 	apiKey := &APIKey{
-		ID:          snapshot.APIKeyID,
-		UserID:      snapshot.UserID,
-		GroupID:     snapshot.GroupID,
-		Key:         key,
-		Status:      snapshot.Status,
-		IPWhitelist: snapshot.IPWhitelist,
-		IPBlacklist: snapshot.IPBlacklist,
+		ID:            snapshot.APIKeyID,
+		UserID:        snapshot.UserID,
+		GroupID:       snapshot.GroupID,
+		Key:           key,
+		Status:        snapshot.Status,
+		IPWhitelist:   snapshot.IPWhitelist,
+		IPBlacklist:   snapshot.IPBlacklist,
+		QuotaUSD:      snapshot.QuotaUSD,
+		UsedUSD:       snapshot.UsedUSD,
+		ExpiresAt:     snapshot.ExpiresAt,
+		AllowedModels: snapshot.AllowedModels,
+		MaxTokensCap:  snapshot.MaxTokensCap,
+		RPMLimit:      snapshot.RPMLimit,
+		TPMLimit:      snapshot.TPMLimit,
 		User: &User{
-			ID:          snapshot.User.ID,
-			Status:      snapshot.User.Status,
-			Role:        snapshot.User.Role,
-			Balance:     snapshot.User.Balance,
-			Concurrency: snapshot.User.Concurrency,
+			ID:            snapshot.User.ID,
+			Status:        snapshot.User.Status,
+			Role:          snapshot.User.Role,
+			Balance:       snapshot.User.Balance,
+			Concurrency:   snapshot.User.Concurrency,
+			QueuePriority: snapshot.User.QueuePriority,
+			QueueWeight:   snapshot.User.QueueWeight,
 		},
 	}
+	if snapshot.OrganizationID != nil {
+		apiKey.OrganizationID = snapshot.OrganizationID
+		if org := snapshot.Organization; org != nil {
+			apiKey.Organization = &Organization{
+				ID:                  org.ID,
+				Status:              org.Status,
+				OwnerUserID:         org.OwnerUserID,
+				Balance:             org.Balance,
+				SharedSubscriptions: org.SharedSubscriptions,
+			}
+			if org.MemberRole != "" {
+				apiKey.OrganizationMember = &OrganizationMember{
+					OrganizationID: org.ID,
+					UserID:         snapshot.UserID,
+					Role:           org.MemberRole,
+					SpendLimitUSD:  org.MemberSpendLimitUSD,
+				}
+			}
+		}
+	}
 	if snapshot.Group != nil {
 		apiKey.Group = &Group{
-			ID:                  snapshot.Group.ID,
-			Name:                snapshot.Group.Name,
-			Platform:            snapshot.Group.Platform,
-			Status:              snapshot.Group.Status,
-			Hydrated:            true,
-			SubscriptionType:    snapshot.Group.SubscriptionType,
-			RateMultiplier:      snapshot.Group.RateMultiplier,
-			DailyLimitUSD:       snapshot.Group.DailyLimitUSD,
-			WeeklyLimitUSD:      snapshot.Group.WeeklyLimitUSD,
-			MonthlyLimitUSD:     snapshot.Group.MonthlyLimitUSD,
-			ImagePrice1K:        snapshot.Group.ImagePrice1K,
-			ImagePrice2K:        snapshot.Group.ImagePrice2K,
-			ImagePrice4K:        snapshot.Group.ImagePrice4K,
-			ClaudeCodeOnly:      snapshot.Group.ClaudeCodeOnly,
-			FallbackGroupID:     snapshot.Group.FallbackGroupID,
-			ModelRouting:        snapshot.Group.ModelRouting,
-			ModelRoutingEnabled: snapshot.Group.ModelRoutingEnabled,
+			ID:                      snapshot.Group.ID,
+			Name:                    snapshot.Group.Name,
+			Platform:                snapshot.Group.Platform,
+			Status:                  snapshot.Group.Status,
+			Hydrated:                true,
+			SubscriptionType:        snapshot.Group.SubscriptionType,
+			RateMultiplier:          snapshot.Group.RateMultiplier,
+			DailyLimitUSD:           snapshot.Group.DailyLimitUSD,
+			WeeklyLimitUSD:          snapshot.Group.WeeklyLimitUSD,
+			MonthlyLimitUSD:         snapshot.Group.MonthlyLimitUSD,
+			ImagePrice1K:            snapshot.Group.ImagePrice1K,
+			ImagePrice2K:            snapshot.Group.ImagePrice2K,
+			ImagePrice4K:            snapshot.Group.ImagePrice4K,
+			ClaudeCodeOnly:          snapshot.Group.ClaudeCodeOnly,
+			FallbackGroupID:         snapshot.Group.FallbackGroupID,
+			ModelRouting:            snapshot.Group.ModelRouting,
+			ModelRoutingEnabled:     snapshot.Group.ModelRoutingEnabled,
+			DefaultRPMLimit:         snapshot.Group.DefaultRPMLimit,
+			DefaultTPMLimit:         snapshot.Group.DefaultTPMLimit,
+			ResponseCacheEnabled:    snapshot.Group.ResponseCacheEnabled,
+			ResponseCacheTTLSeconds: snapshot.Group.ResponseCacheTTLSeconds,
+			ResponseCacheRate:       snapshot.Group.ResponseCacheRate,
+			ModelFallbacks:          snapshot.Group.ModelFallbacks,
+			Guardrails:              snapshot.Group.Guardrails,
+			SchedulingStrategy:      snapshot.Group.SchedulingStrategy,
+			SchedulingWeights:       snapshot.Group.SchedulingWeights,
+			HedgeDelayMs:            snapshot.Group.HedgeDelayMs,
+			QueueWeight:             snapshot.Group.QueueWeight,
 		}
 	}
 	return apiKey
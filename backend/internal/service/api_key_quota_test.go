@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type apiKeyUsageCacheStub struct {
+	billingCacheWorkerStub
+	used float64
+	err  error
+}
+
+func (s *apiKeyUsageCacheStub) GetAPIKeyUsage(ctx context.Context, apiKeyID int64) (float64, error) {
+	return s.used, s.err
+}
+
+func TestAPIKeyExpiryAndQuota(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	quota := 10.0
+
+	require.False(t, (&APIKey{}).IsExpired())
+	require.True(t, (&APIKey{ExpiresAt: &past}).IsExpired())
+	require.False(t, (&APIKey{ExpiresAt: &future}).IsExpired())
+
+	unlimited := &APIKey{}
+	require.False(t, unlimited.HasQuota())
+	require.False(t, unlimited.IsQuotaExhausted(1e9))
+
+	limited := &APIKey{QuotaUSD: &quota}
+	require.True(t, limited.HasQuota())
+	require.False(t, limited.IsQuotaExhausted(9.99))
+	require.True(t, limited.IsQuotaExhausted(10))
+}
+
+func TestBillingCacheServiceCheckAPIKeyLimits(t *testing.T) {
+	cache := &apiKeyUsageCacheStub{used: 4}
+	svc := NewBillingCacheService(cache, nil, nil, nil, &config.Config{})
+	t.Cleanup(svc.Stop)
+	ctx := context.Background()
+
+	quota := 5.0
+	key := &APIKey{ID: 1, QuotaUSD: &quota}
+	require.NoError(t, svc.CheckAPIKeyLimits(ctx, key))
+
+	cache.used = 5
+	require.ErrorIs(t, svc.CheckAPIKeyLimits(ctx, key), ErrAPIKeyQuotaExceeded)
+
+	// 未设置额度时不读取用量
+	require.NoError(t, svc.CheckAPIKeyLimits(ctx, &APIKey{ID: 2}))
+
+	past := time.Now().Add(-time.Second)
+	require.ErrorIs(t, svc.CheckAPIKeyLimits(ctx, &APIKey{ID: 3, ExpiresAt: &past}), ErrAPIKeyExpired)
+
+	// 缓存不可用且无数据库回源时视为计费服务不可用
+	cache.err = errors.New("redis down")
+	require.ErrorIs(t, svc.CheckAPIKeyLimits(ctx, key), ErrBillingServiceUnavailable)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// APIKeyRateLimitWindow API Key 速率限制滑动窗口长度
+const APIKeyRateLimitWindow = time.Minute
+
+const (
+	// RateLimitKindRequests 按请求数限流（RPM）
+	RateLimitKindRequests = "requests"
+	// RateLimitKindTokens 按 token 数限流（TPM）
+	RateLimitKindTokens = "tokens"
+)
+
+// RateWindowState 滑动窗口状态
+type RateWindowState struct {
+	Allowed    bool
+	Used       int64         // 窗口内已用量（请求被放行时，请求窗口包含本次请求）
+	ResetAfter time.Duration // 最早一条记录滑出窗口的剩余时间
+}
+
+// APIKeyRateLimitCache API Key RPM/TPM 滑动窗口缓存
+type APIKeyRateLimitCache interface {
+	// Acquire 原子地检查请求窗口与 token 窗口，两者均未超限时才在请求窗口中记录本次请求。
+	// rpm/tpm 为 0 时跳过对应窗口，返回的对应状态为 nil
+	Acquire(ctx context.Context, apiKeyID int64, rpm, tpm int, window time.Duration) (requests, tokens *RateWindowState, err error)
+	// AddTokens 在 token 窗口中记录实际消耗
+	AddTokens(ctx context.Context, apiKeyID int64, tokens int, window time.Duration) error
+}
+
+// RateLimitQuota 单个维度的限额状态，用于生成响应头
+type RateLimitQuota struct {
+	Limit      int
+	Remaining  int64
+	ResetAfter time.Duration
+}
+
+// APIKeyRateLimitResult 速率限制检查结果
+type APIKeyRateLimitResult struct {
+	Limited    bool
+	LimitedBy  string // RateLimitKindRequests / RateLimitKindTokens
+	RetryAfter time.Duration
+	Requests   *RateLimitQuota // 未配置 RPM 时为 nil
+	Tokens     *RateLimitQuota // 未配置 TPM 时为 nil
+}
+
+// APIKeyRateLimitService API Key 级别 RPM/TPM 限流
+//
+// 请求进入时在同一原子操作中检查 RPM 与 TPM，两者均未超限时才计入 RPM；
+// TPM 在请求进入时仅检查窗口内已消耗的 token，实际用量在 RecordUsage 中回写（上游返回 usage 之前无法得知真实 token 数）。
+type APIKeyRateLimitService struct {
+	cache APIKeyRateLimitCache
+}
+
+// NewAPIKeyRateLimitService 创建 API Key 限流服务
+func NewAPIKeyRateLimitService(cache APIKeyRateLimitCache) *APIKeyRateLimitService {
+	return &APIKeyRateLimitService{cache: cache}
+}
+
+// EffectiveRateLimits 返回 API Key 生效的 RPM/TPM（Key 未配置时回退到分组默认值，0 表示不限）
+func (k *APIKey) EffectiveRateLimits() (rpm, tpm int) {
+	if k.RPMLimit != nil {
+		rpm = *k.RPMLimit
+	} else if k.Group != nil && k.Group.DefaultRPMLimit != nil {
+		rpm = *k.Group.DefaultRPMLimit
+	}
+	if k.TPMLimit != nil {
+		tpm = *k.TPMLimit
+	} else if k.Group != nil && k.Group.DefaultTPMLimit != nil {
+		tpm = *k.Group.DefaultTPMLimit
+	}
+	return max(rpm, 0), max(tpm, 0)
+}
+
+// Check 检查并占用一次请求配额。Redis 异常时放行（fail-open），仅记录日志。
+func (s *APIKeyRateLimitService) Check(ctx context.Context, apiKey *APIKey) *APIKeyRateLimitResult {
+	result := &APIKeyRateLimitResult{}
+	if s == nil || s.cache == nil || apiKey == nil {
+		return result
+	}
+	rpm, tpm := apiKey.EffectiveRateLimits()
+
+	if rpm <= 0 && tpm <= 0 {
+		return result
+	}
+
+	requests, tokens, err := s.cache.Acquire(ctx, apiKey.ID, rpm, tpm, APIKeyRateLimitWindow)
+	if err != nil {
+		log.Printf("Warning: api key rate limit check failed for api key %d: %v", apiKey.ID, err)
+		return result
+	}
+	if requests != nil {
+		result.Requests = quotaFromState(rpm, requests)
+	}
+	if tokens != nil {
+		result.Tokens = quotaFromState(tpm, tokens)
+	}
+
+	switch {
+	case tokens != nil && !tokens.Allowed:
+		result.Limited = true
+		result.LimitedBy = RateLimitKindTokens
+		result.RetryAfter = tokens.ResetAfter
+	case requests != nil && !requests.Allowed:
+		result.Limited = true
+		result.LimitedBy = RateLimitKindRequests
+		result.RetryAfter = requests.ResetAfter
+	}
+	return result
+}
+
+// RecordTokens 回写实际消耗的 token（仅在配置了 TPM 时记录）
+func (s *APIKeyRateLimitService) RecordTokens(ctx context.Context, apiKey *APIKey, tokens int) {
+	if s == nil || s.cache == nil || apiKey == nil || tokens <= 0 {
+		return
+	}
+	if _, tpm := apiKey.EffectiveRateLimits(); tpm <= 0 {
+		return
+	}
+	if err := s.cache.AddTokens(ctx, apiKey.ID, tokens, APIKeyRateLimitWindow); err != nil {
+		log.Printf("Warning: api key tpm record failed for api key %d: %v", apiKey.ID, err)
+	}
+}
+
+func quotaFromState(limit int, state *RateWindowState) *RateLimitQuota {
+	return &RateLimitQuota{
+		Limit:      limit,
+		Remaining:  max(int64(limit)-state.Used, 0),
+		ResetAfter: state.ResetAfter,
+	}
+}
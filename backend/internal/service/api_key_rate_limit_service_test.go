@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type apiKeyRateLimitCacheStub struct {
+	requestState *RateWindowState
+	tokenState   *RateWindowState
+	err          error
+
+	acquireCalls int
+	recorded     int
+	addedTokens  []int
+}
+
+// Acquire 模拟脚本语义：仅当两个已配置的窗口都放行时才记录请求
+func (s *apiKeyRateLimitCacheStub) Acquire(ctx context.Context, apiKeyID int64, rpm, tpm int, window time.Duration) (requests, tokens *RateWindowState, err error) {
+	s.acquireCalls++
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	if rpm > 0 {
+		requests = s.requestState
+	}
+	if tpm > 0 {
+		tokens = s.tokenState
+	}
+	if (requests == nil || requests.Allowed) && (tokens == nil || tokens.Allowed) {
+		s.recorded++
+	}
+	return requests, tokens, nil
+}
+
+func (s *apiKeyRateLimitCacheStub) AddTokens(ctx context.Context, apiKeyID int64, tokens int, window time.Duration) error {
+	s.addedTokens = append(s.addedTokens, tokens)
+	return nil
+}
+
+func TestAPIKeyEffectiveRateLimits(t *testing.T) {
+	group := &Group{DefaultRPMLimit: intPtr(60), DefaultTPMLimit: intPtr(100000)}
+
+	rpm, tpm := (&APIKey{}).EffectiveRateLimits()
+	require.Zero(t, rpm)
+	require.Zero(t, tpm)
+
+	rpm, tpm = (&APIKey{Group: group}).EffectiveRateLimits()
+	require.Equal(t, 60, rpm)
+	require.Equal(t, 100000, tpm)
+
+	rpm, tpm = (&APIKey{Group: group, RPMLimit: intPtr(5)}).EffectiveRateLimits()
+	require.Equal(t, 5, rpm)
+	require.Equal(t, 100000, tpm)
+}
+
+func TestAPIKeyRateLimitService_Check(t *testing.T) {
+	ctx := context.Background()
+	key := &APIKey{ID: 1, RPMLimit: intPtr(10), TPMLimit: intPtr(1000)}
+
+	t.Run("allowed", func(t *testing.T) {
+		cache := &apiKeyRateLimitCacheStub{
+			requestState: &RateWindowState{Allowed: true, Used: 3, ResetAfter: 20 * time.Second},
+			tokenState:   &RateWindowState{Allowed: true, Used: 400, ResetAfter: 30 * time.Second},
+		}
+		result := NewAPIKeyRateLimitService(cache).Check(ctx, key)
+		require.False(t, result.Limited)
+		require.Equal(t, int64(7), result.Requests.Remaining)
+		require.Equal(t, int64(600), result.Tokens.Remaining)
+		require.Equal(t, 1, cache.acquireCalls)
+		require.Equal(t, 1, cache.recorded)
+	})
+
+	t.Run("tpm exceeded does not consume rpm", func(t *testing.T) {
+		cache := &apiKeyRateLimitCacheStub{
+			requestState: &RateWindowState{Allowed: true, Used: 1},
+			tokenState:   &RateWindowState{Allowed: false, Used: 1200, ResetAfter: 12 * time.Second},
+		}
+		result := NewAPIKeyRateLimitService(cache).Check(ctx, key)
+		require.True(t, result.Limited)
+		require.Equal(t, RateLimitKindTokens, result.LimitedBy)
+		require.Equal(t, 12*time.Second, result.RetryAfter)
+		require.Zero(t, result.Tokens.Remaining)
+		require.Equal(t, int64(9), result.Requests.Remaining)
+		require.Equal(t, 1, cache.acquireCalls)
+		require.Zero(t, cache.recorded)
+	})
+
+	t.Run("both exceeded reports tpm", func(t *testing.T) {
+		cache := &apiKeyRateLimitCacheStub{
+			requestState: &RateWindowState{Allowed: false, Used: 10, ResetAfter: 5 * time.Second},
+			tokenState:   &RateWindowState{Allowed: false, Used: 1000, ResetAfter: 8 * time.Second},
+		}
+		result := NewAPIKeyRateLimitService(cache).Check(ctx, key)
+		require.True(t, result.Limited)
+		require.Equal(t, RateLimitKindTokens, result.LimitedBy)
+		require.Equal(t, 8*time.Second, result.RetryAfter)
+		require.Zero(t, cache.recorded)
+	})
+
+	t.Run("rpm exceeded", func(t *testing.T) {
+		cache := &apiKeyRateLimitCacheStub{
+			requestState: &RateWindowState{Allowed: false, Used: 10, ResetAfter: 5 * time.Second},
+			tokenState:   &RateWindowState{Allowed: true},
+		}
+		result := NewAPIKeyRateLimitService(cache).Check(ctx, key)
+		require.True(t, result.Limited)
+		require.Equal(t, RateLimitKindRequests, result.LimitedBy)
+		require.Equal(t, 5*time.Second, result.RetryAfter)
+		require.Zero(t, cache.recorded)
+	})
+
+	t.Run("rpm only", func(t *testing.T) {
+		cache := &apiKeyRateLimitCacheStub{requestState: &RateWindowState{Allowed: true, Used: 1}}
+		result := NewAPIKeyRateLimitService(cache).Check(ctx, &APIKey{ID: 3, RPMLimit: intPtr(10)})
+		require.False(t, result.Limited)
+		require.NotNil(t, result.Requests)
+		require.Nil(t, result.Tokens)
+	})
+
+	t.Run("cache errors fail open", func(t *testing.T) {
+		cache := &apiKeyRateLimitCacheStub{err: errors.New("redis down")}
+		result := NewAPIKeyRateLimitService(cache).Check(ctx, key)
+		require.False(t, result.Limited)
+		require.Nil(t, result.Requests)
+		require.Nil(t, result.Tokens)
+	})
+
+	t.Run("no limits configured", func(t *testing.T) {
+		cache := &apiKeyRateLimitCacheStub{}
+		result := NewAPIKeyRateLimitService(cache).Check(ctx, &APIKey{ID: 2})
+		require.False(t, result.Limited)
+		require.Zero(t, cache.acquireCalls)
+	})
+}
+
+func TestAPIKeyRateLimitService_RecordTokens(t *testing.T) {
+	ctx := context.Background()
+	cache := &apiKeyRateLimitCacheStub{}
+	svc := NewAPIKeyRateLimitService(cache)
+
+	svc.RecordTokens(ctx, &APIKey{ID: 1}, 100)
+	svc.RecordTokens(ctx, &APIKey{ID: 1, TPMLimit: intPtr(1000)}, 0)
+	require.Empty(t, cache.addedTokens)
+
+	svc.RecordTokens(ctx, &APIKey{ID: 1, Group: &Group{DefaultTPMLimit: intPtr(1000)}}, 250)
+	require.Equal(t, []int{250}, cache.addedTokens)
+
+	var nilSvc *APIKeyRateLimitService
+	require.NotPanics(t, func() { nilSvc.RecordTokens(ctx, &APIKey{TPMLimit: intPtr(1)}, 1) })
+}
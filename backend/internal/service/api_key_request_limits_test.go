@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestAPIKeyCheckRequestLimits(t *testing.T) {
+	unrestricted := &APIKey{}
+	require.NoError(t, unrestricted.CheckRequestLimits("claude-opus-4-5", 1<<20))
+
+	limit := 4096
+	key := &APIKey{AllowedModels: []string{"claude-sonnet-*", "gpt-4o"}, MaxTokensCap: &limit}
+	require.NoError(t, key.CheckRequestLimits("claude-sonnet-4-5", 4096))
+	require.NoError(t, key.CheckRequestLimits("gpt-4o", 0))
+	require.ErrorIs(t, key.CheckRequestLimits("claude-opus-4-5", 100), ErrAPIKeyModelNotAllowed)
+	require.ErrorIs(t, key.CheckRequestLimits("gpt-4o-mini", 100), ErrAPIKeyModelNotAllowed)
+	require.ErrorIs(t, key.CheckRequestLimits("gpt-4o", 4097), ErrAPIKeyMaxTokensExceeded)
+}
+
+func TestAPIKeyApplyMaxTokensCap(t *testing.T) {
+	body := []byte(`{"model":"gpt-5","input":"hi"}`)
+	out, ok := (&APIKey{}).ApplyMaxTokensCap(body, "max_output_tokens")
+	require.False(t, ok)
+	require.Equal(t, body, out)
+
+	limit := 2048
+	key := &APIKey{MaxTokensCap: &limit}
+
+	// Responses：省略 max_output_tokens 时写入上限
+	out, ok = key.ApplyMaxTokensCap(body, "max_output_tokens")
+	require.True(t, ok)
+	require.EqualValues(t, limit, gjson.GetBytes(out, "max_output_tokens").Int())
+	require.Equal(t, "hi", gjson.GetBytes(out, "input").String())
+
+	// Gemini：generationConfig 缺失或未设置 maxOutputTokens 时写入上限
+	out, ok = key.ApplyMaxTokensCap([]byte(`{"contents":[]}`), "generationConfig.maxOutputTokens")
+	require.True(t, ok)
+	require.EqualValues(t, limit, gjson.GetBytes(out, "generationConfig.maxOutputTokens").Int())
+	out, ok = key.ApplyMaxTokensCap([]byte(`{"generationConfig":{"temperature":0}}`), "generationConfig.maxOutputTokens")
+	require.True(t, ok)
+	require.EqualValues(t, limit, gjson.GetBytes(out, "generationConfig.maxOutputTokens").Int())
+	require.True(t, gjson.GetBytes(out, "generationConfig.temperature").Exists())
+
+	// 客户端显式指定时保持原值（超限由 CheckRequestLimits 拒绝）
+	explicit := []byte(`{"max_output_tokens":100}`)
+	out, ok = key.ApplyMaxTokensCap(explicit, "max_output_tokens")
+	require.False(t, ok)
+	require.Equal(t, explicit, out)
+}
+
+func TestNormalizeModelPatterns(t *testing.T) {
+	out, err := normalizeModelPatterns([]string{" claude-sonnet-* ", "gpt-4o", "gpt-4o"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"claude-sonnet-*", "gpt-4o"}, out)
+
+	out, err = normalizeModelPatterns(nil)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	for _, invalid := range []string{"", "*", "claude-*-sonnet"} {
+		_, err = normalizeModelPatterns([]string{invalid})
+		require.ErrorIs(t, err, ErrInvalidModelPattern, invalid)
+	}
+}
+
+func TestCheckMessageBatchLimits(t *testing.T) {
+	limit := 1000
+	key := &APIKey{AllowedModels: []string{"claude-haiku-*"}, MaxTokensCap: &limit}
+	ok := []byte(`{"requests":[{"custom_id":"1","params":{"model":"claude-haiku-4-5","max_tokens":1000}}]}`)
+	require.NoError(t, CheckMessageBatchLimits(key, ok))
+
+	badModel := []byte(`{"requests":[{"custom_id":"1","params":{"model":"claude-haiku-4-5","max_tokens":10}},{"custom_id":"2","params":{"model":"claude-opus-4-5","max_tokens":10}}]}`)
+	require.ErrorIs(t, CheckMessageBatchLimits(key, badModel), ErrAPIKeyModelNotAllowed)
+
+	badTokens := []byte(`{"requests":[{"custom_id":"1","params":{"model":"claude-haiku-4-5","max_tokens":2000}}]}`)
+	require.ErrorIs(t, CheckMessageBatchLimits(key, badTokens), ErrAPIKeyMaxTokensExceeded)
+}
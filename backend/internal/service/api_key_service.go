@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
@@ -24,6 +26,17 @@ var (
 	ErrAPIKeyInvalidChars = infraerrors.BadRequest("API_KEY_INVALID_CHARS", "api key can only contain letters, numbers, underscores, and hyphens")
 	ErrAPIKeyRateLimited  = infraerrors.TooManyRequests("API_KEY_RATE_LIMITED", "too many failed attempts, please try again later")
 	ErrInvalidIPPattern   = infraerrors.BadRequest("INVALID_IP_PATTERN", "invalid IP or CIDR pattern")
+
+	ErrAPIKeyExpired       = infraerrors.Unauthorized("API_KEY_EXPIRED", "api key has expired")
+	ErrAPIKeyQuotaExceeded = infraerrors.Forbidden("API_KEY_QUOTA_EXCEEDED", "api key quota exceeded")
+	ErrInvalidAPIKeyQuota  = infraerrors.BadRequest("INVALID_API_KEY_QUOTA", "quota_usd must be greater than 0")
+	ErrInvalidAPIKeyExpiry = infraerrors.BadRequest("INVALID_API_KEY_EXPIRY", "expires_at must be in the future")
+
+	ErrAPIKeyModelNotAllowed   = infraerrors.Forbidden("API_KEY_MODEL_NOT_ALLOWED", "model is not allowed for this api key")
+	ErrAPIKeyMaxTokensExceeded = infraerrors.Forbidden("API_KEY_MAX_TOKENS_EXCEEDED", "max_tokens exceeds the limit of this api key")
+	ErrInvalidModelPattern     = infraerrors.BadRequest("INVALID_MODEL_PATTERN", "model pattern must be non-empty and may only end with *")
+	ErrInvalidMaxTokensCap     = infraerrors.BadRequest("INVALID_MAX_TOKENS_CAP", "max_tokens_cap must be greater than 0")
+	ErrInvalidAPIKeyRateLimit  = infraerrors.BadRequest("INVALID_API_KEY_RATE_LIMIT", "rpm_limit and tpm_limit must be greater than 0")
 )
 
 const (
@@ -51,6 +64,11 @@ type APIKeyRepository interface {
 	CountByGroupID(ctx context.Context, groupID int64) (int64, error)
 	ListKeysByUserID(ctx context.Context, userID int64) ([]string, error)
 	ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error)
+
+	// GetUsedUSD 获取已用额度（缓存未命中时回源）
+	GetUsedUSD(ctx context.Context, id int64) (float64, error)
+	// IncrementUsedUSD 原子累加已用额度
+	IncrementUsedUSD(ctx context.Context, id int64, amount float64) error
 }
 
 // APIKeyCache defines cache operations for API key service
@@ -80,20 +98,35 @@ type APIKeyAuthCacheInvalidator interface {
 
 // CreateAPIKeyRequest 创建API Key请求
 type CreateAPIKeyRequest struct {
-	Name        string   `json:"name"`
-	GroupID     *int64   `json:"group_id"`
-	CustomKey   *string  `json:"custom_key"`   // 可选的自定义key
-	IPWhitelist []string `json:"ip_whitelist"` // IP 白名单
-	IPBlacklist []string `json:"ip_blacklist"` // IP 黑名单
+	Name          string     `json:"name"`
+	GroupID       *int64     `json:"group_id"`
+	CustomKey     *string    `json:"custom_key"`     // 可选的自定义key
+	IPWhitelist   []string   `json:"ip_whitelist"`   // IP 白名单
+	IPBlacklist   []string   `json:"ip_blacklist"`   // IP 黑名单
+	QuotaUSD      *float64   `json:"quota_usd"`      // 额度上限（美元），nil 表示不限
+	ExpiresAt     *time.Time `json:"expires_at"`     // 过期时间，nil 表示永不过期
+	AllowedModels []string   `json:"allowed_models"` // 模型白名单（支持末尾 *）
+	MaxTokensCap  *int       `json:"max_tokens_cap"` // max_tokens 上限，nil 表示不限
+	RPMLimit      *int       `json:"rpm_limit"`      // 每分钟请求数上限，nil 使用分组默认值
+	TPMLimit      *int       `json:"tpm_limit"`      // 每分钟 token 数上限，nil 使用分组默认值
+
+	OrganizationID *int64 `json:"organization_id"` // 团队 API Key 所属组织（需为该组织成员）
 }
 
 // UpdateAPIKeyRequest 更新API Key请求
 type UpdateAPIKeyRequest struct {
-	Name        *string  `json:"name"`
-	GroupID     *int64   `json:"group_id"`
-	Status      *string  `json:"status"`
-	IPWhitelist []string `json:"ip_whitelist"` // IP 白名单（空数组清空）
-	IPBlacklist []string `json:"ip_blacklist"` // IP 黑名单（空数组清空）
+	Name           *string    `json:"name"`
+	GroupID        *int64     `json:"group_id"`
+	Status         *string    `json:"status"`
+	IPWhitelist    []string   `json:"ip_whitelist"`     // IP 白名单（空数组清空）
+	IPBlacklist    []string   `json:"ip_blacklist"`     // IP 黑名单（空数组清空）
+	QuotaUSD       *float64   `json:"quota_usd"`        // 额度上限（0 清除，nil 不修改）
+	ExpiresAt      *time.Time `json:"expires_at"`       // 过期时间（nil 不修改）
+	ClearExpiresAt bool       `json:"clear_expires_at"` // 清除过期时间
+	AllowedModels  []string   `json:"allowed_models"`   // 模型白名单（空数组清空）
+	MaxTokensCap   *int       `json:"max_tokens_cap"`   // max_tokens 上限（0 清除，nil 不修改）
+	RPMLimit       *int       `json:"rpm_limit"`        // 每分钟请求数上限（0 恢复分组默认值，nil 不修改）
+	TPMLimit       *int       `json:"tpm_limit"`        // 每分钟 token 数上限（0 恢复分组默认值，nil 不修改）
 }
 
 // APIKeyService API Key服务
@@ -107,6 +140,9 @@ type APIKeyService struct {
 	authCacheL1 *ristretto.Cache
 	authCfg     apiKeyAuthCacheConfig
 	authGroup   singleflight.Group
+
+	// 团队 API Key（可选，由 ProvideAPIKeyService 注入）
+	orgRepo OrganizationRepository
 }
 
 // NewAPIKeyService 创建API Key服务实例
@@ -210,6 +246,37 @@ func (s *APIKeyService) canUserBindGroup(ctx context.Context, user *User, group
 	return user.CanBindGroup(group.ID, group.IsExclusive)
 }
 
+// canKeyBindGroup 检查 API Key 是否可以绑定指定分组：
+// 团队 API Key 在组织开启共享订阅时按所有者的订阅判断，否则按 Key 所属用户判断
+func (s *APIKeyService) canKeyBindGroup(ctx context.Context, user *User, org *Organization, group *Group) bool {
+	if org != nil && org.SharedSubscriptions && group.IsSubscriptionType() {
+		_, err := s.userSubRepo.GetActiveByUserIDAndGroupID(ctx, org.OwnerUserID, group.ID)
+		return err == nil
+	}
+	return s.canUserBindGroup(ctx, user, group)
+}
+
+// teamKeyOrganization 校验用户是否为组织成员且组织可用，返回组织
+func (s *APIKeyService) teamKeyOrganization(ctx context.Context, userID, orgID int64) (*Organization, error) {
+	if s.orgRepo == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.IsActive() {
+		return nil, ErrOrganizationDisabled
+	}
+	if _, err := s.orgRepo.GetMember(ctx, orgID, userID); err != nil {
+		if errors.Is(err, ErrOrganizationMemberNotFound) {
+			return nil, ErrOrganizationForbidden
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
 // Create 创建API Key
 func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIKeyRequest) (*APIKey, error) {
 	// 验证用户存在
@@ -232,6 +299,35 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 		}
 	}
 
+	// 验证额度与过期时间
+	if req.QuotaUSD != nil && *req.QuotaUSD <= 0 {
+		return nil, ErrInvalidAPIKeyQuota
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidAPIKeyExpiry
+	}
+
+	// 验证模型白名单与 max_tokens 上限
+	allowedModels, err := normalizeModelPatterns(req.AllowedModels)
+	if err != nil {
+		return nil, err
+	}
+	if req.MaxTokensCap != nil && *req.MaxTokensCap <= 0 {
+		return nil, ErrInvalidMaxTokensCap
+	}
+	if (req.RPMLimit != nil && *req.RPMLimit <= 0) || (req.TPMLimit != nil && *req.TPMLimit <= 0) {
+		return nil, ErrInvalidAPIKeyRateLimit
+	}
+
+	// 团队 API Key：验证组织成员身份
+	var org *Organization
+	if req.OrganizationID != nil {
+		org, err = s.teamKeyOrganization(ctx, userID, *req.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// 验证分组权限（如果指定了分组）
 	if req.GroupID != nil {
 		group, err := s.groupRepo.GetByID(ctx, *req.GroupID)
@@ -240,7 +336,7 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 		}
 
 		// 检查用户是否可以绑定该分组
-		if !s.canUserBindGroup(ctx, user, group) {
+		if !s.canKeyBindGroup(ctx, user, org, group) {
 			return nil, ErrGroupNotAllowed
 		}
 	}
@@ -282,13 +378,21 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 
 	// 创建API Key记录
 	apiKey := &APIKey{
-		UserID:      userID,
-		Key:         key,
-		Name:        req.Name,
-		GroupID:     req.GroupID,
-		Status:      StatusActive,
-		IPWhitelist: req.IPWhitelist,
-		IPBlacklist: req.IPBlacklist,
+		UserID:        userID,
+		Key:           key,
+		Name:          req.Name,
+		GroupID:       req.GroupID,
+		Status:        StatusActive,
+		IPWhitelist:   req.IPWhitelist,
+		IPBlacklist:   req.IPBlacklist,
+		QuotaUSD:      req.QuotaUSD,
+		ExpiresAt:     req.ExpiresAt,
+		AllowedModels: allowedModels,
+		MaxTokensCap:  req.MaxTokensCap,
+		RPMLimit:      req.RPMLimit,
+		TPMLimit:      req.TPMLimit,
+
+		OrganizationID: req.OrganizationID,
 	}
 
 	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
@@ -370,7 +474,7 @@ func (s *APIKeyService) GetByKey(ctx context.Context, key string) (*APIKey, erro
 		}
 	}
 
-	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, key)
+	apiKey, err := s.getByKeyForAuth(ctx, key)
 	if err != nil {
 		return nil, fmt.Errorf("get api key: %w", err)
 	}
@@ -378,6 +482,32 @@ func (s *APIKeyService) GetByKey(ctx context.Context, key string) (*APIKey, erro
 	return apiKey, nil
 }
 
+// getByKeyForAuth 认证查询，团队 API Key 同时加载组织与成员信息
+func (s *APIKeyService) getByKeyForAuth(ctx context.Context, key string) (*APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey.OrganizationID == nil || s.orgRepo == nil {
+		return apiKey, nil
+	}
+	org, err := s.orgRepo.GetByID(ctx, *apiKey.OrganizationID)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationNotFound) {
+			// 组织已删除：保留 OrganizationID、不填充组织，由认证中间件拒绝
+			return apiKey, nil
+		}
+		return nil, fmt.Errorf("get organization: %w", err)
+	}
+	apiKey.Organization = org
+	member, err := s.orgRepo.GetMember(ctx, org.ID, apiKey.UserID)
+	if err != nil && !errors.Is(err, ErrOrganizationMemberNotFound) {
+		return nil, fmt.Errorf("get organization member: %w", err)
+	}
+	apiKey.OrganizationMember = member
+	return apiKey, nil
+}
+
 // Update 更新API Key
 func (s *APIKeyService) Update(ctx context.Context, id int64, userID int64, req UpdateAPIKeyRequest) (*APIKey, error) {
 	apiKey, err := s.apiKeyRepo.GetByID(ctx, id)
@@ -421,7 +551,13 @@ func (s *APIKeyService) Update(ctx context.Context, id int64, userID int64, req
 			return nil, fmt.Errorf("get group: %w", err)
 		}
 
-		if !s.canUserBindGroup(ctx, user, group) {
+		var org *Organization
+		if apiKey.OrganizationID != nil {
+			if org, err = s.teamKeyOrganization(ctx, userID, *apiKey.OrganizationID); err != nil {
+				return nil, err
+			}
+		}
+		if !s.canKeyBindGroup(ctx, user, org, group) {
 			return nil, ErrGroupNotAllowed
 		}
 
@@ -440,6 +576,61 @@ func (s *APIKeyService) Update(ctx context.Context, id int64, userID int64, req
 	apiKey.IPWhitelist = req.IPWhitelist
 	apiKey.IPBlacklist = req.IPBlacklist
 
+	// 更新额度上限（0 清除），已用额度保持不变
+	if req.QuotaUSD != nil {
+		if *req.QuotaUSD < 0 {
+			return nil, ErrInvalidAPIKeyQuota
+		}
+		if *req.QuotaUSD == 0 {
+			apiKey.QuotaUSD = nil
+		} else {
+			apiKey.QuotaUSD = req.QuotaUSD
+		}
+	}
+
+	// 更新过期时间
+	if req.ClearExpiresAt {
+		apiKey.ExpiresAt = nil
+	} else if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			return nil, ErrInvalidAPIKeyExpiry
+		}
+		apiKey.ExpiresAt = req.ExpiresAt
+	}
+
+	// 更新模型白名单（空数组会清空设置）
+	allowedModels, err := normalizeModelPatterns(req.AllowedModels)
+	if err != nil {
+		return nil, err
+	}
+	apiKey.AllowedModels = allowedModels
+
+	// 更新 max_tokens 上限（0 清除）
+	if req.MaxTokensCap != nil {
+		if *req.MaxTokensCap < 0 {
+			return nil, ErrInvalidMaxTokensCap
+		}
+		if *req.MaxTokensCap == 0 {
+			apiKey.MaxTokensCap = nil
+		} else {
+			apiKey.MaxTokensCap = req.MaxTokensCap
+		}
+	}
+
+	// 更新速率限制（0 恢复为分组默认值）
+	if req.RPMLimit != nil {
+		if *req.RPMLimit < 0 {
+			return nil, ErrInvalidAPIKeyRateLimit
+		}
+		apiKey.RPMLimit = normalizeRateLimit(req.RPMLimit)
+	}
+	if req.TPMLimit != nil {
+		if *req.TPMLimit < 0 {
+			return nil, ErrInvalidAPIKeyRateLimit
+		}
+		apiKey.TPMLimit = normalizeRateLimit(req.TPMLimit)
+	}
+
 	if err := s.apiKeyRepo.Update(ctx, apiKey); err != nil {
 		return nil, fmt.Errorf("update api key: %w", err)
 	}
@@ -572,3 +763,24 @@ func (s *APIKeyService) SearchAPIKeys(ctx context.Context, userID int64, keyword
 	}
 	return keys, nil
 }
+
+// normalizeModelPatterns 去除空白与重复项，并校验通配符只出现在末尾
+func normalizeModelPatterns(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	seen := make(map[string]struct{}, len(patterns))
+	out := make([]string, 0, len(patterns))
+	for _, raw := range patterns {
+		pattern := strings.TrimSpace(raw)
+		if pattern == "" || pattern == "*" || strings.Contains(strings.TrimSuffix(pattern, "*"), "*") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidModelPattern, raw)
+		}
+		if _, ok := seen[pattern]; ok {
+			continue
+		}
+		seen[pattern] = struct{}{}
+		out = append(out, pattern)
+	}
+	return out, nil
+}
@@ -99,6 +99,14 @@ func (s *authRepoStub) ListKeysByGroupID(ctx context.Context, groupID int64) ([]
 	return s.listKeysByGroupID(ctx, groupID)
 }
 
+func (s *authRepoStub) GetUsedUSD(ctx context.Context, id int64) (float64, error) {
+	panic("unexpected GetUsedUSD call")
+}
+
+func (s *authRepoStub) IncrementUsedUSD(ctx context.Context, id int64, amount float64) error {
+	panic("unexpected IncrementUsedUSD call")
+}
+
 type authCacheStub struct {
 	getAuthCache   func(ctx context.Context, key string) (*APIKeyAuthCacheEntry, error)
 	setAuthKeys    []string
@@ -118,6 +118,14 @@ func (s *apiKeyRepoStub) ListKeysByGroupID(ctx context.Context, groupID int64) (
 	panic("unexpected ListKeysByGroupID call")
 }
 
+func (s *apiKeyRepoStub) GetUsedUSD(ctx context.Context, id int64) (float64, error) {
+	panic("unexpected GetUsedUSD call")
+}
+
+func (s *apiKeyRepoStub) IncrementUsedUSD(ctx context.Context, id int64, amount float64) error {
+	panic("unexpected IncrementUsedUSD call")
+}
+
 // apiKeyCacheStub 是 APIKeyCache 接口的测试桩实现。
 // 用于验证删除操作时缓存清理逻辑是否被正确调用。
 //
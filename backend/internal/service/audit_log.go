@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/util/logredact"
+)
+
+// auditRedactedValue 敏感字段在审计日志中的占位值
+const auditRedactedValue = "***"
+
+// auditMaxDiffDepth 差异展开的最大嵌套深度，超过后整体比较
+const auditMaxDiffDepth = 8
+
+// auditSensitiveKeys 审计日志额外脱敏的字段（在 logredact 默认字段之上）。
+// 同时覆盖 DTO 的 snake_case 字段与未带 json tag 的服务层字段名。
+var auditSensitiveKeys = []string{
+	"api_key", "apikey", "admin_api_key", "key",
+	"secret", "webhook_secret", "totp_secret",
+	"token", "session_key", "session_token", "cookie", "cookies",
+	"private_key", "credentials",
+	"smtp_password", "smtppassword",
+	"turnstile_secret_key", "turnstilesecretkey",
+	"linuxdo_connect_client_secret", "linuxdoconnectclientsecret",
+	"wechat_app_secret", "wechatappsecret",
+}
+
+// auditIgnoredDiffKeys 不计入差异的字段（每次写操作都会变化的噪声）
+var auditIgnoredDiffKeys = map[string]struct{}{
+	"updated_at": {},
+	"UpdatedAt":  {},
+}
+
+// AuditLog 管理后台写操作审计记录
+type AuditLog struct {
+	ID          int64
+	ActorUserID *int64
+	// ActorEmail 查询时关联 users 表得到
+	ActorEmail  string
+	AuthMethod  string
+	IP          string
+	UserAgent   string
+	Method      string
+	Route       string
+	Path        string
+	TargetType  string
+	TargetID    string
+	StatusCode  int
+	RequestBody *string
+	Diff        map[string]AuditChange
+	CreatedAt   time.Time
+}
+
+// AuditChange 单个字段的变更前后值（敏感字段为 "***"）
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditLogFilter 审计日志查询条件
+type AuditLogFilter struct {
+	ActorUserID *int64
+	AuthMethod  string
+	TargetType  string
+	TargetID    string
+	Method      string
+	// Route 按路由模板模糊匹配
+	Route     string
+	StartTime *time.Time
+	EndTime   *time.Time
+}
+
+// AuditLogRecord 中间件采集到的一次写操作
+type AuditLogRecord struct {
+	ActorUserID *int64
+	AuthMethod  string
+	IP          string
+	UserAgent   string
+	Method      string
+	Route       string
+	Path        string
+	TargetType  string
+	TargetID    string
+	StatusCode  int
+	RequestBody []byte
+	// Before/After 目标实体变更前后的快照（任意可 JSON 序列化的值）
+	Before any
+	After  any
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *AuditLog) error
+	List(ctx context.Context, filter AuditLogFilter, params pagination.PaginationParams) ([]AuditLog, *pagination.PaginationResult, error)
+	// ListForExport 按 id 倒序返回最多 limit 条
+	ListForExport(ctx context.Context, filter AuditLogFilter, limit int) ([]AuditLog, error)
+}
+
+// RedactAuditBody 对请求体做脱敏，返回可直接落库的 JSON 文本
+func RedactAuditBody(raw []byte) *string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil
+	}
+	redacted := logredact.RedactJSON(raw, auditSensitiveKeys...)
+	return &redacted
+}
+
+// BuildAuditDiff 比较变更前后的快照，返回按字段路径（a.b.c）索引的差异。
+// 先比较原始值再脱敏，因此凭证被修改时仍会出现 "***" -> "***" 的记录。
+func BuildAuditDiff(before, after any) map[string]AuditChange {
+	beforeFlat := flattenAuditValue(normalizeAuditValue(before))
+	afterFlat := flattenAuditValue(normalizeAuditValue(after))
+
+	diff := make(map[string]AuditChange)
+	for path, b := range beforeFlat {
+		a, ok := afterFlat[path]
+		if ok && auditValuesEqual(a, b) {
+			continue
+		}
+		diff[path] = AuditChange{Before: b, After: a}
+	}
+	for path, a := range afterFlat {
+		if _, ok := beforeFlat[path]; ok {
+			continue
+		}
+		diff[path] = AuditChange{Before: nil, After: a}
+	}
+
+	for path, change := range diff {
+		if isSensitiveAuditPath(path) {
+			diff[path] = AuditChange{Before: redactAuditLeaf(change.Before), After: redactAuditLeaf(change.After)}
+			continue
+		}
+		// 数组等未展开的值内部仍可能包含敏感字段
+		diff[path] = AuditChange{Before: redactAuditNested(change.Before), After: redactAuditNested(change.After)}
+	}
+	return diff
+}
+
+// AuditDiffPaths 返回排序后的差异字段路径，便于展示与导出
+func AuditDiffPaths(diff map[string]AuditChange) []string {
+	paths := make([]string, 0, len(diff))
+	for path := range diff {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// normalizeAuditValue 通过 JSON 往返把任意结构体转换为 map/slice/标量
+func normalizeAuditValue(v any) any {
+	if v == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+func flattenAuditValue(v any) map[string]any {
+	out := make(map[string]any)
+	if v == nil {
+		return out
+	}
+	flattenAuditInto(out, "", v, 0)
+	return out
+}
+
+func flattenAuditInto(out map[string]any, prefix string, v any, depth int) {
+	m, ok := v.(map[string]any)
+	if !ok || depth >= auditMaxDiffDepth {
+		out[prefix] = v
+		return
+	}
+	if len(m) == 0 && prefix != "" {
+		out[prefix] = v
+		return
+	}
+	for k, val := range m {
+		if _, ignored := auditIgnoredDiffKeys[k]; ignored {
+			continue
+		}
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		flattenAuditInto(out, path, val, depth+1)
+	}
+}
+
+func auditValuesEqual(a, b any) bool {
+	ra, errA := json.Marshal(a)
+	rb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ra, rb)
+}
+
+func isSensitiveAuditPath(path string) bool {
+	for _, segment := range strings.Split(path, ".") {
+		if logredact.IsSensitiveKey(segment, auditSensitiveKeys...) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactAuditNested(v any) any {
+	switch v.(type) {
+	case map[string]any, []any:
+		return logredact.RedactMap(map[string]any{"v": v}, auditSensitiveKeys...)["v"]
+	default:
+		return v
+	}
+}
+
+func redactAuditLeaf(v any) any {
+	if v == nil {
+		return nil
+	}
+	if s, ok := v.(string); ok && s == "" {
+		return ""
+	}
+	return auditRedactedValue
+}
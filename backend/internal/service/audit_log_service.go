@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+const (
+	// auditLogWriteTimeout 审计写入与请求解耦，客户端断开也要落库
+	auditLogWriteTimeout = 3 * time.Second
+	// AuditLogExportMaxRows 单次导出的最大行数
+	AuditLogExportMaxRows = 10000
+)
+
+// AuditLogService 管理后台审计日志
+type AuditLogService struct {
+	repo AuditLogRepository
+}
+
+// NewAuditLogService creates a new AuditLogService
+func NewAuditLogService(repo AuditLogRepository) *AuditLogService {
+	return &AuditLogService{repo: repo}
+}
+
+// Record 脱敏并写入一条审计记录。写入失败只记日志，不影响业务请求。
+func (s *AuditLogService) Record(ctx context.Context, record *AuditLogRecord) {
+	if s == nil || s.repo == nil || record == nil {
+		return
+	}
+
+	entry := &AuditLog{
+		ActorUserID: record.ActorUserID,
+		AuthMethod:  record.AuthMethod,
+		IP:          truncateString(record.IP, 64),
+		UserAgent:   truncateString(record.UserAgent, 512),
+		Method:      record.Method,
+		Route:       truncateString(record.Route, 255),
+		Path:        truncateString(record.Path, 512),
+		TargetType:  truncateString(record.TargetType, 64),
+		TargetID:    truncateString(record.TargetID, 64),
+		StatusCode:  record.StatusCode,
+		RequestBody: RedactAuditBody(record.RequestBody),
+	}
+	if record.Before != nil || record.After != nil {
+		if diff := BuildAuditDiff(record.Before, record.After); len(diff) > 0 {
+			entry.Diff = diff
+		}
+	}
+
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditLogWriteTimeout)
+	defer cancel()
+	if err := s.repo.Create(writeCtx, entry); err != nil {
+		log.Printf("[AuditLog] Failed to record %s %s: %v", record.Method, record.Path, err)
+	}
+}
+
+// List 分页查询审计日志
+func (s *AuditLogService) List(ctx context.Context, filter AuditLogFilter, params pagination.PaginationParams) ([]AuditLog, *pagination.PaginationResult, error) {
+	return s.repo.List(ctx, filter, params)
+}
+
+// Export 按条件导出审计日志（最多 AuditLogExportMaxRows 条）
+func (s *AuditLogService) Export(ctx context.Context, filter AuditLogFilter) ([]AuditLog, error) {
+	return s.repo.ListForExport(ctx, filter, AuditLogExportMaxRows)
+}
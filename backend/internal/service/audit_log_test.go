@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type auditLogRepoStub struct {
+	created []*AuditLog
+}
+
+func (r *auditLogRepoStub) Create(ctx context.Context, log *AuditLog) error {
+	log.ID = int64(len(r.created) + 1)
+	r.created = append(r.created, log)
+	return nil
+}
+
+func (r *auditLogRepoStub) List(ctx context.Context, filter AuditLogFilter, params pagination.PaginationParams) ([]AuditLog, *pagination.PaginationResult, error) {
+	return nil, &pagination.PaginationResult{}, nil
+}
+
+func (r *auditLogRepoStub) ListForExport(ctx context.Context, filter AuditLogFilter, limit int) ([]AuditLog, error) {
+	return nil, nil
+}
+
+func TestBuildAuditDiff(t *testing.T) {
+	type account struct {
+		Name        string         `json:"name"`
+		Priority    int            `json:"priority"`
+		Credentials map[string]any `json:"credentials"`
+		Extra       map[string]any `json:"extra"`
+		UpdatedAt   string         `json:"updated_at"`
+	}
+	before := account{
+		Name:        "claude-1",
+		Priority:    1,
+		Credentials: map[string]any{"access_token": "old", "base_url": "https://a"},
+		Extra:       map[string]any{"tags": []any{"x"}},
+		UpdatedAt:   "t1",
+	}
+	after := before
+	after.Priority = 5
+	after.Credentials = map[string]any{"access_token": "new", "base_url": "https://a"}
+	after.Extra = map[string]any{"tags": []any{"x"}, "note": "hi"}
+	after.UpdatedAt = "t2"
+
+	diff := BuildAuditDiff(before, after)
+	require.Equal(t, []string{"credentials.access_token", "extra.note", "priority"}, AuditDiffPaths(diff))
+	require.Equal(t, AuditChange{Before: float64(1), After: float64(5)}, diff["priority"])
+	require.Equal(t, AuditChange{Before: nil, After: "hi"}, diff["extra.note"])
+	// 凭证变化可见，但值已脱敏
+	require.Equal(t, AuditChange{Before: "***", After: "***"}, diff["credentials.access_token"])
+
+	require.Empty(t, BuildAuditDiff(before, before))
+}
+
+func TestBuildAuditDiff_DeleteAndNestedRedaction(t *testing.T) {
+	before := map[string]any{
+		"id":       7,
+		"password": "hunter2",
+		"items":    []any{map[string]any{"api_key": "sk-1", "name": "a"}},
+	}
+	diff := BuildAuditDiff(before, nil)
+	require.Equal(t, AuditChange{Before: "***", After: nil}, diff["password"])
+	require.Equal(t, AuditChange{Before: float64(7), After: nil}, diff["id"])
+	items := diff["items"].Before.([]any)
+	require.Equal(t, map[string]any{"api_key": "***", "name": "a"}, items[0])
+}
+
+func TestRedactAuditBody(t *testing.T) {
+	require.Nil(t, RedactAuditBody(nil))
+	require.Nil(t, RedactAuditBody([]byte("  ")))
+
+	out := RedactAuditBody([]byte(`{"email":"a@b.c","password":"p","smtp_password":"s","balance":10}`))
+	require.NotNil(t, out)
+	require.JSONEq(t, `{"email":"a@b.c","password":"***","smtp_password":"***","balance":10}`, *out)
+}
+
+func TestAuditLogService_Record(t *testing.T) {
+	repo := &auditLogRepoStub{}
+	svc := NewAuditLogService(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // 客户端已断开也应落库
+	actorID := int64(1)
+	svc.Record(ctx, &AuditLogRecord{
+		ActorUserID: &actorID,
+		AuthMethod:  "admin_api_key",
+		Method:      "POST",
+		Route:       "/api/v1/admin/users/:id/balance",
+		Path:        "/api/v1/admin/users/2/balance",
+		TargetType:  "users",
+		TargetID:    "2",
+		StatusCode:  200,
+		RequestBody: []byte(`{"balance":5,"operation":"add"}`),
+		Before:      map[string]any{"balance": 1},
+		After:       map[string]any{"balance": 6},
+	})
+
+	require.Len(t, repo.created, 1)
+	log := repo.created[0]
+	require.Equal(t, "users", log.TargetType)
+	require.Equal(t, AuditChange{Before: float64(1), After: float64(6)}, log.Diff["balance"])
+	require.JSONEq(t, `{"balance":5,"operation":"add"}`, *log.RequestBody)
+
+	// 无变化时不写 diff
+	svc.Record(context.Background(), &AuditLogRecord{Method: "PUT", Before: map[string]any{"a": 1}, After: map[string]any{"a": 1}})
+	require.Nil(t, repo.created[1].Diff)
+
+	var nilSvc *AuditLogService
+	require.NotPanics(t, func() { nilSvc.Record(context.Background(), &AuditLogRecord{}) })
+}
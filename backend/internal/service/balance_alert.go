@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// 余额提醒事件类型（投递到用户自己配置的 Webhook，不进入管理员端点订阅）
+const (
+	BalanceEventLow       = "balance.low"
+	BalanceEventAutoTopUp = "balance.auto_topup"
+)
+
+// 自动充值兑换码状态
+const (
+	AutoTopUpCodePending = "pending"
+	AutoTopUpCodeUsed    = "used"
+	AutoTopUpCodeFailed  = "failed"
+)
+
+const (
+	balanceAlertMaxThresholds = 5
+	autoTopUpMaxPendingCodes  = 20
+)
+
+var (
+	ErrBalanceAlertInvalidThreshold = infraerrors.BadRequest("BALANCE_ALERT_INVALID_THRESHOLD", "thresholds must be greater than 0 (at most 5)")
+	ErrAutoTopUpInvalidThreshold    = infraerrors.BadRequest("AUTO_TOPUP_INVALID_THRESHOLD", "auto top-up threshold must be greater than 0")
+	ErrAutoTopUpCodeInvalid         = infraerrors.BadRequest("AUTO_TOPUP_CODE_INVALID", "only unused balance or subscription redeem codes can be registered")
+	ErrAutoTopUpCodeExists          = infraerrors.Conflict("AUTO_TOPUP_CODE_EXISTS", "redeem code is already registered for auto top-up")
+	ErrAutoTopUpCodeNotFound        = infraerrors.NotFound("AUTO_TOPUP_CODE_NOT_FOUND", "auto top-up code not found")
+	ErrAutoTopUpTooManyCodes        = infraerrors.BadRequest("AUTO_TOPUP_TOO_MANY_CODES", "too many pending auto top-up codes (max 20)")
+)
+
+// BalanceAlertSettings 用户低余额提醒与自动充值规则
+type BalanceAlertSettings struct {
+	UserID int64
+	// Thresholds 提醒阈值（USD，降序）；每跌破一档通知一次，余额回升后重新布防
+	Thresholds    []float64
+	EmailEnabled  bool
+	WebhookURL    string
+	WebhookSecret string
+	// NotifiedThreshold 本轮已通知的最低一档，nil 表示未处于提醒状态
+	NotifiedThreshold *float64
+
+	// 余额低于 AutoTopUpThreshold 时按登记顺序消耗下一个兑换码（余额码充值，订阅码续期）
+	AutoTopUpEnabled   bool
+	AutoTopUpThreshold float64
+	AutoTopUpLastAt    *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DefaultBalanceAlertSettings 未配置时的默认值
+func DefaultBalanceAlertSettings(userID int64) *BalanceAlertSettings {
+	return &BalanceAlertSettings{UserID: userID, Thresholds: []float64{}, EmailEnabled: true}
+}
+
+// Active 是否有需要在扣费后检查的规则
+func (s *BalanceAlertSettings) Active() bool {
+	if s == nil {
+		return false
+	}
+	notify := len(s.Thresholds) > 0 && (s.EmailEnabled || s.WebhookURL != "")
+	return notify || s.AutoTopUpEnabled
+}
+
+// CrossedThreshold 当前余额已跌破的最低一档阈值；未跌破任何阈值时返回 nil
+func (s *BalanceAlertSettings) CrossedThreshold(balance float64) *float64 {
+	var crossed *float64
+	for i := range s.Thresholds {
+		t := s.Thresholds[i]
+		if balance < t && (crossed == nil || t < *crossed) {
+			crossed = &t
+		}
+	}
+	return crossed
+}
+
+// AutoTopUpCode 用户预登记的自动充值兑换码
+type AutoTopUpCode struct {
+	ID           int64
+	UserID       int64
+	RedeemCodeID int64
+	Status       string
+	ErrorMessage *string
+	UsedAt       *time.Time
+	CreatedAt    time.Time
+
+	// 以下字段来自关联的兑换码
+	Code         string
+	Type         string
+	Value        float64
+	GroupID      *int64
+	ValidityDays int
+}
+
+// BalanceAlertRepository 余额提醒规则与自动充值兑换码存储
+type BalanceAlertRepository interface {
+	// GetSettings 未配置时返回 nil, nil
+	GetSettings(ctx context.Context, userID int64) (*BalanceAlertSettings, error)
+	UpsertSettings(ctx context.Context, settings *BalanceAlertSettings) error
+	// ListActiveUserIDs 配置了提醒或自动充值的用户，用于扣费路径的内存预过滤
+	ListActiveUserIDs(ctx context.Context) ([]int64, error)
+	// ClaimNotification 原子地将 notified_threshold 下调到 threshold；
+	// 本轮已通知过同档或更低档时返回 false，保证多实例下每次跌破只通知一次
+	ClaimNotification(ctx context.Context, userID int64, threshold float64) (bool, error)
+	// RearmNotification 余额回升后将 notified_threshold 上调到 threshold（nil 表示全部重新布防）
+	RearmNotification(ctx context.Context, userID int64, threshold *float64) error
+	// ClaimAutoTopUp 距上次自动充值超过 cooldown 时领取本次执行权
+	ClaimAutoTopUp(ctx context.Context, userID int64, cooldown time.Duration) (bool, error)
+
+	CreateTopUpCode(ctx context.Context, code *AutoTopUpCode) error
+	ListTopUpCodes(ctx context.Context, userID int64) ([]AutoTopUpCode, error)
+	// ListPendingTopUpCodes 按登记顺序返回待使用的兑换码
+	ListPendingTopUpCodes(ctx context.Context, userID int64, limit int) ([]AutoTopUpCode, error)
+	// DeletePendingTopUpCode 仅可删除尚未使用的兑换码
+	DeletePendingTopUpCode(ctx context.Context, userID, id int64) error
+	UpdateTopUpCodeStatus(ctx context.Context, id int64, status string, errorMessage *string) error
+}
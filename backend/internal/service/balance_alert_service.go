@@ -0,0 +1,571 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+const (
+	// balanceAlertWatchRefresh 已启用规则用户集合的刷新周期（其他实例修改规则后最迟在此周期内生效）
+	balanceAlertWatchRefresh = time.Minute
+	balanceAlertEvalTimeout  = 30 * time.Second
+	// autoTopUpCooldown 两次自动充值的最小间隔，避免兑换后缓存未刷新时连续消耗多个兑换码
+	autoTopUpCooldown = time.Minute
+	// autoTopUpAttemptsPerRun 单次触发最多尝试的兑换码数（失效的兑换码会被跳过）
+	autoTopUpAttemptsPerRun = 3
+	// autoTopUpSubscriptionRenewWindow 订阅剩余有效期低于该值时才使用订阅兑换码续期
+	autoTopUpSubscriptionRenewWindow = 3 * 24 * time.Hour
+)
+
+// UpdateBalanceAlertRequest 更新余额提醒规则请求，nil 字段保持不变
+type UpdateBalanceAlertRequest struct {
+	Thresholds              *[]float64
+	EmailEnabled            *bool
+	WebhookURL              *string
+	RegenerateWebhookSecret bool
+	AutoTopUpEnabled        *bool
+	AutoTopUpThreshold      *float64
+}
+
+// BalanceAlertService 低余额提醒与自动充值。
+//
+// 扣费路径（BillingCacheService）只调用 ObserveBalanceChange / ObserveSubscriptionUsage 做内存去重入队，
+// 由后台协程合并同一用户的多次扣费后读取数据库余额再判断，不增加请求延迟。
+// 订阅计费不改变余额，但同样触发检查，使仅使用订阅的用户也能在到期前自动续期。
+type BalanceAlertService struct {
+	repo           BalanceAlertRepository
+	userRepo       UserRepository
+	redeemRepo     RedeemCodeRepository
+	userSubRepo    UserSubscriptionRepository
+	redeemService  *RedeemService
+	emailService   *EmailService
+	settingService *SettingService
+	webhookService *WebhookService
+
+	// watched 已启用规则的用户集合（map[int64]struct{}，写时复制），未启用的用户在入队前直接跳过
+	watched   atomic.Value
+	watchedMu sync.Mutex
+
+	pendingMu sync.Mutex
+	pending   map[int64]struct{}
+	wakeCh    chan struct{}
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewBalanceAlertService 创建余额提醒服务
+func NewBalanceAlertService(
+	repo BalanceAlertRepository,
+	userRepo UserRepository,
+	redeemRepo RedeemCodeRepository,
+	userSubRepo UserSubscriptionRepository,
+	redeemService *RedeemService,
+	emailService *EmailService,
+	settingService *SettingService,
+	webhookService *WebhookService,
+) *BalanceAlertService {
+	s := &BalanceAlertService{
+		repo:           repo,
+		userRepo:       userRepo,
+		redeemRepo:     redeemRepo,
+		userSubRepo:    userSubRepo,
+		redeemService:  redeemService,
+		emailService:   emailService,
+		settingService: settingService,
+		webhookService: webhookService,
+		pending:        make(map[int64]struct{}),
+		wakeCh:         make(chan struct{}, 1),
+		stopCh:         make(chan struct{}),
+	}
+	s.watched.Store(map[int64]struct{}{})
+	return s
+}
+
+// Start 启动后台处理协程
+func (s *BalanceAlertService) Start() {
+	if s == nil || s.repo == nil {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(balanceAlertWatchRefresh)
+		defer ticker.Stop()
+
+		s.refreshWatched()
+		for {
+			select {
+			case <-s.wakeCh:
+				s.processPending()
+			case <-ticker.C:
+				s.refreshWatched()
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止后台处理，未处理的检查会被丢弃
+func (s *BalanceAlertService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+// ObserveBalanceChange 记录一次余额变动（实现 BalanceObserver）。只做内存操作，不阻塞调用方。
+func (s *BalanceAlertService) ObserveBalanceChange(userID int64) {
+	s.enqueue(userID)
+}
+
+// ObserveSubscriptionUsage 记录一次订阅计费（实现 BalanceObserver），用于检查订阅续期
+func (s *BalanceAlertService) ObserveSubscriptionUsage(userID int64) {
+	s.enqueue(userID)
+}
+
+func (s *BalanceAlertService) enqueue(userID int64) {
+	if s == nil || !s.isWatched(userID) {
+		return
+	}
+	s.pendingMu.Lock()
+	s.pending[userID] = struct{}{}
+	s.pendingMu.Unlock()
+
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *BalanceAlertService) isWatched(userID int64) bool {
+	watched, _ := s.watched.Load().(map[int64]struct{})
+	_, ok := watched[userID]
+	return ok
+}
+
+func (s *BalanceAlertService) setWatched(userID int64, active bool) {
+	s.watchedMu.Lock()
+	defer s.watchedMu.Unlock()
+	current, _ := s.watched.Load().(map[int64]struct{})
+	if _, ok := current[userID]; ok == active {
+		return
+	}
+	next := make(map[int64]struct{}, len(current)+1)
+	for id := range current {
+		next[id] = struct{}{}
+	}
+	if active {
+		next[userID] = struct{}{}
+	} else {
+		delete(next, userID)
+	}
+	s.watched.Store(next)
+}
+
+func (s *BalanceAlertService) refreshWatched() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ids, err := s.repo.ListActiveUserIDs(ctx)
+	if err != nil {
+		log.Printf("[BalanceAlert] List active users failed: %v", err)
+		return
+	}
+	next := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		next[id] = struct{}{}
+	}
+	s.watchedMu.Lock()
+	s.watched.Store(next)
+	s.watchedMu.Unlock()
+}
+
+func (s *BalanceAlertService) processPending() {
+	s.pendingMu.Lock()
+	batch := s.pending
+	s.pending = make(map[int64]struct{})
+	s.pendingMu.Unlock()
+
+	for userID := range batch {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), balanceAlertEvalTimeout)
+		s.evaluate(ctx, userID)
+		cancel()
+	}
+}
+
+// evaluate 按数据库余额执行自动充值（含即将到期的订阅续期）与阈值提醒
+func (s *BalanceAlertService) evaluate(ctx context.Context, userID int64) {
+	settings, err := s.repo.GetSettings(ctx, userID)
+	if err != nil {
+		log.Printf("[BalanceAlert] Load settings failed: user=%d err=%v", userID, err)
+		return
+	}
+	if !settings.Active() {
+		return
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Printf("[BalanceAlert] Load user failed: user=%d err=%v", userID, err)
+		return
+	}
+
+	if settings.AutoTopUpEnabled {
+		balanceLow := user.Balance < settings.AutoTopUpThreshold
+		if code := s.autoTopUp(ctx, userID, balanceLow); code != nil {
+			s.notify(ctx, user, settings, autoTopUpEvent(user, code), "自动充值已执行", autoTopUpEmailMessage(code))
+			if refreshed, err := s.userRepo.GetByID(ctx, userID); err == nil {
+				user = refreshed
+			}
+		}
+	}
+	s.checkThresholds(ctx, user, settings)
+}
+
+// checkThresholds 每次跌破一档阈值只通知一次；余额回升到阈值以上后重新布防
+func (s *BalanceAlertService) checkThresholds(ctx context.Context, user *User, settings *BalanceAlertSettings) {
+	crossed := settings.CrossedThreshold(user.Balance)
+	notified := settings.NotifiedThreshold
+	switch {
+	case crossed == nil:
+		if notified != nil {
+			if err := s.repo.RearmNotification(ctx, user.ID, nil); err != nil {
+				log.Printf("[BalanceAlert] Rearm failed: user=%d err=%v", user.ID, err)
+			}
+		}
+	case notified == nil || *crossed < *notified:
+		claimed, err := s.repo.ClaimNotification(ctx, user.ID, *crossed)
+		if err != nil {
+			log.Printf("[BalanceAlert] Claim notification failed: user=%d err=%v", user.ID, err)
+			return
+		}
+		if claimed {
+			s.notify(ctx, user, settings, lowBalanceEvent(user, *crossed), "余额不足提醒",
+				fmt.Sprintf("您的余额 $%.2f 已低于提醒阈值 $%.2f，请及时充值以免请求失败。", user.Balance, *crossed))
+		}
+	case *crossed > *notified:
+		if err := s.repo.RearmNotification(ctx, user.ID, crossed); err != nil {
+			log.Printf("[BalanceAlert] Rearm failed: user=%d err=%v", user.ID, err)
+		}
+	}
+}
+
+// autoTopUp 按登记顺序兑换下一个可用兑换码，成功时返回该兑换码。
+// 余额兑换码只在余额低于阈值（balanceLow）时使用；订阅兑换码不改变余额，
+// 只在对应分组订阅不存在或即将到期时使用，否则保留待用。没有可用兑换码时不占用冷却期
+func (s *BalanceAlertService) autoTopUp(ctx context.Context, userID int64, balanceLow bool) *AutoTopUpCode {
+	if s.redeemService == nil {
+		return nil
+	}
+	codes, err := s.repo.ListPendingTopUpCodes(ctx, userID, autoTopUpMaxPendingCodes)
+	if err != nil {
+		log.Printf("[BalanceAlert] List auto top-up codes failed: user=%d err=%v", userID, err)
+		return nil
+	}
+
+	candidates := make([]*AutoTopUpCode, 0, autoTopUpAttemptsPerRun)
+	for i := range codes {
+		if len(candidates) >= autoTopUpAttemptsPerRun {
+			break
+		}
+		code := &codes[i]
+		if code.Type != RedeemTypeSubscription {
+			if balanceLow {
+				candidates = append(candidates, code)
+			}
+			continue
+		}
+		due, err := s.subscriptionRenewalDue(ctx, userID, code.GroupID)
+		if err != nil {
+			log.Printf("[BalanceAlert] Load subscription failed: user=%d group=%v err=%v", userID, code.GroupID, err)
+			return nil
+		}
+		if due {
+			candidates = append(candidates, code)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	claimed, err := s.repo.ClaimAutoTopUp(ctx, userID, autoTopUpCooldown)
+	if err != nil || !claimed {
+		if err != nil {
+			log.Printf("[BalanceAlert] Claim auto top-up failed: user=%d err=%v", userID, err)
+		}
+		return nil
+	}
+
+	for _, code := range candidates {
+		_, err := s.redeemService.Redeem(ctx, userID, code.Code)
+		if err == nil {
+			if err := s.repo.UpdateTopUpCodeStatus(ctx, code.ID, AutoTopUpCodeUsed, nil); err != nil {
+				log.Printf("[BalanceAlert] Mark auto top-up code used failed: id=%d err=%v", code.ID, err)
+			}
+			log.Printf("[BalanceAlert] Auto top-up succeeded: user=%d code_id=%d type=%s", userID, code.RedeemCodeID, code.Type)
+			return code
+		}
+		if !isPermanentRedeemError(err) {
+			// 临时错误（锁冲突、限流、数据库异常）：保留待用状态，冷却期后重试
+			log.Printf("[BalanceAlert] Auto top-up failed: user=%d code_id=%d err=%v", userID, code.RedeemCodeID, err)
+			return nil
+		}
+		msg := infraerrors.Message(err)
+		if err := s.repo.UpdateTopUpCodeStatus(ctx, code.ID, AutoTopUpCodeFailed, &msg); err != nil {
+			log.Printf("[BalanceAlert] Mark auto top-up code failed failed: id=%d err=%v", code.ID, err)
+		}
+	}
+	return nil
+}
+
+// subscriptionRenewalDue 用户在该分组没有有效订阅，或订阅将在续期窗口内到期
+func (s *BalanceAlertService) subscriptionRenewalDue(ctx context.Context, userID int64, groupID *int64) (bool, error) {
+	if groupID == nil || s.userSubRepo == nil {
+		return false, nil
+	}
+	sub, err := s.userSubRepo.GetActiveByUserIDAndGroupID(ctx, userID, *groupID)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return time.Until(sub.ExpiresAt) < autoTopUpSubscriptionRenewWindow, nil
+}
+
+// isPermanentRedeemError 兑换码本身不可用（已被使用、已删除、配置无效），应跳过
+func isPermanentRedeemError(err error) bool {
+	return errors.Is(err, ErrRedeemCodeUsed) ||
+		errors.Is(err, ErrRedeemCodeNotFound) ||
+		infraerrors.Reason(err) == "REDEEM_CODE_INVALID"
+}
+
+func lowBalanceEvent(user *User, threshold float64) WebhookEvent {
+	return WebhookEvent{
+		Type:     BalanceEventLow,
+		Severity: WebhookSeverityWarning,
+		Title:    "Low balance",
+		Message:  fmt.Sprintf("Your balance $%.2f has dropped below $%.2f.", user.Balance, threshold),
+		Data: map[string]any{
+			"user_id":   user.ID,
+			"balance":   user.Balance,
+			"threshold": threshold,
+		},
+	}
+}
+
+func autoTopUpEmailMessage(code *AutoTopUpCode) string {
+	if code.Type == RedeemTypeSubscription {
+		return fmt.Sprintf("您的订阅即将到期，已使用您预登记的兑换码续期订阅 %d 天。", code.ValidityDays)
+	}
+	return fmt.Sprintf("余额低于自动充值阈值，已使用您预登记的兑换码充值 $%.2f。", code.Value)
+}
+
+func autoTopUpEvent(user *User, code *AutoTopUpCode) WebhookEvent {
+	message := fmt.Sprintf("Auto top-up redeemed a $%.2f balance code.", code.Value)
+	if code.Type == RedeemTypeSubscription {
+		message = fmt.Sprintf("Auto top-up renewed a subscription for %d days.", code.ValidityDays)
+	}
+	data := map[string]any{
+		"user_id":        user.ID,
+		"balance":        user.Balance,
+		"redeem_code_id": code.RedeemCodeID,
+		"type":           code.Type,
+		"value":          code.Value,
+	}
+	if code.GroupID != nil {
+		data["group_id"] = *code.GroupID
+	}
+	return WebhookEvent{
+		Type:     BalanceEventAutoTopUp,
+		Severity: WebhookSeverityInfo,
+		Title:    "Auto top-up",
+		Message:  message,
+		Data:     data,
+	}
+}
+
+// notify 按用户配置发送邮件与 Webhook，失败只记录日志
+func (s *BalanceAlertService) notify(ctx context.Context, user *User, settings *BalanceAlertSettings, event WebhookEvent, emailTitle, emailMessage string) {
+	if settings.EmailEnabled && s.emailService != nil && user.Email != "" && !strings.HasSuffix(user.Email, ".invalid") {
+		siteName := "Code80"
+		if s.settingService != nil {
+			siteName = s.settingService.GetSiteName(ctx)
+		}
+		if err := s.emailService.SendBalanceNotificationEmail(ctx, user.Email, siteName, emailTitle, emailMessage); err != nil {
+			log.Printf("[BalanceAlert] Send email failed: user=%d event=%s err=%v", user.ID, event.Type, err)
+		}
+	}
+	if settings.WebhookURL != "" && s.webhookService != nil {
+		if err := s.webhookService.SendDirect(ctx, settings.WebhookURL, settings.WebhookSecret, event); err != nil {
+			log.Printf("[BalanceAlert] Send webhook failed: user=%d event=%s err=%v", user.ID, event.Type, err)
+		}
+	}
+}
+
+// ============================================
+// 规则管理
+// ============================================
+
+// GetSettings 获取用户规则，未配置时返回默认值
+func (s *BalanceAlertService) GetSettings(ctx context.Context, userID int64) (*BalanceAlertSettings, error) {
+	settings, err := s.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return DefaultBalanceAlertSettings(userID), nil
+	}
+	return settings, nil
+}
+
+// UpdateSettings 更新用户规则；阈值变更后重新布防，并立即按当前余额检查一次
+func (s *BalanceAlertService) UpdateSettings(ctx context.Context, userID int64, req UpdateBalanceAlertRequest) (*BalanceAlertSettings, error) {
+	settings, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Thresholds != nil {
+		thresholds, err := normalizeBalanceThresholds(*req.Thresholds)
+		if err != nil {
+			return nil, err
+		}
+		settings.Thresholds = thresholds
+		settings.NotifiedThreshold = nil
+	}
+	if req.EmailEnabled != nil {
+		settings.EmailEnabled = *req.EmailEnabled
+	}
+	if req.WebhookURL != nil {
+		raw := strings.TrimSpace(*req.WebhookURL)
+		if raw == "" {
+			settings.WebhookURL = ""
+		} else {
+			if s.webhookService == nil {
+				return nil, ErrWebhookDisabled
+			}
+			normalized, err := s.webhookService.validateURL(raw)
+			if err != nil {
+				return nil, err
+			}
+			settings.WebhookURL = normalized
+		}
+	}
+	if settings.WebhookURL != "" && (settings.WebhookSecret == "" || req.RegenerateWebhookSecret) {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		settings.WebhookSecret = secret
+	}
+	if req.AutoTopUpThreshold != nil {
+		settings.AutoTopUpThreshold = *req.AutoTopUpThreshold
+	}
+	if req.AutoTopUpEnabled != nil {
+		settings.AutoTopUpEnabled = *req.AutoTopUpEnabled
+	}
+	if settings.AutoTopUpEnabled && settings.AutoTopUpThreshold <= 0 {
+		return nil, ErrAutoTopUpInvalidThreshold
+	}
+
+	if err := s.repo.UpsertSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	s.setWatched(userID, settings.Active())
+	s.ObserveBalanceChange(userID)
+	return settings, nil
+}
+
+// normalizeBalanceThresholds 校验、去重并降序排列
+func normalizeBalanceThresholds(values []float64) ([]float64, error) {
+	seen := make(map[float64]struct{}, len(values))
+	out := make([]float64, 0, len(values))
+	for _, v := range values {
+		if v <= 0 {
+			return nil, ErrBalanceAlertInvalidThreshold
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	if len(out) > balanceAlertMaxThresholds {
+		return nil, ErrBalanceAlertInvalidThreshold
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(out)))
+	return out, nil
+}
+
+// ============================================
+// 自动充值兑换码
+// ============================================
+
+// ListTopUpCodes 列出用户登记的兑换码
+func (s *BalanceAlertService) ListTopUpCodes(ctx context.Context, userID int64) ([]AutoTopUpCode, error) {
+	return s.repo.ListTopUpCodes(ctx, userID)
+}
+
+// RegisterTopUpCode 预登记兑换码；登记时不占用兑换码，触发时才兑换
+func (s *BalanceAlertService) RegisterTopUpCode(ctx context.Context, userID int64, code string) (*AutoTopUpCode, error) {
+	redeemCode, err := s.redeemRepo.GetByCode(ctx, strings.TrimSpace(code))
+	if err != nil {
+		return nil, err
+	}
+	if !redeemCode.CanUse() {
+		return nil, ErrAutoTopUpCodeInvalid
+	}
+	if redeemCode.Type != RedeemTypeBalance && redeemCode.Type != RedeemTypeSubscription {
+		return nil, ErrAutoTopUpCodeInvalid
+	}
+	if redeemCode.Type == RedeemTypeSubscription && redeemCode.GroupID == nil {
+		return nil, ErrAutoTopUpCodeInvalid
+	}
+
+	pending, err := s.repo.ListPendingTopUpCodes(ctx, userID, autoTopUpMaxPendingCodes)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) >= autoTopUpMaxPendingCodes {
+		return nil, ErrAutoTopUpTooManyCodes
+	}
+
+	entry := &AutoTopUpCode{
+		UserID:       userID,
+		RedeemCodeID: redeemCode.ID,
+		Status:       AutoTopUpCodePending,
+		Code:         redeemCode.Code,
+		Type:         redeemCode.Type,
+		Value:        redeemCode.Value,
+		GroupID:      redeemCode.GroupID,
+		ValidityDays: redeemCode.ValidityDays,
+	}
+	if err := s.repo.CreateTopUpCode(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DeleteTopUpCode 移除尚未使用的兑换码
+func (s *BalanceAlertService) DeleteTopUpCode(ctx context.Context, userID, id int64) error {
+	return s.repo.DeletePendingTopUpCode(ctx, userID, id)
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// balanceAlertRepoStub 内存实现，模拟仓储层的原子领取语义
+type balanceAlertRepoStub struct {
+	BalanceAlertRepository
+	settings *BalanceAlertSettings
+	claims   int
+	codes    []AutoTopUpCode
+}
+
+func (r *balanceAlertRepoStub) GetSettings(ctx context.Context, userID int64) (*BalanceAlertSettings, error) {
+	if r.settings == nil {
+		return nil, nil
+	}
+	out := *r.settings
+	return &out, nil
+}
+
+func (r *balanceAlertRepoStub) UpsertSettings(ctx context.Context, settings *BalanceAlertSettings) error {
+	stored := *settings
+	r.settings = &stored
+	return nil
+}
+
+func (r *balanceAlertRepoStub) ClaimNotification(ctx context.Context, userID int64, threshold float64) (bool, error) {
+	if n := r.settings.NotifiedThreshold; n != nil && *n <= threshold {
+		return false, nil
+	}
+	r.claims++
+	r.settings.NotifiedThreshold = &threshold
+	return true, nil
+}
+
+func (r *balanceAlertRepoStub) RearmNotification(ctx context.Context, userID int64, threshold *float64) error {
+	n := r.settings.NotifiedThreshold
+	if n != nil && (threshold == nil || *n < *threshold) {
+		r.settings.NotifiedThreshold = threshold
+	}
+	return nil
+}
+
+func (r *balanceAlertRepoStub) ClaimAutoTopUp(ctx context.Context, userID int64, cooldown time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (r *balanceAlertRepoStub) ListPendingTopUpCodes(ctx context.Context, userID int64, limit int) ([]AutoTopUpCode, error) {
+	var out []AutoTopUpCode
+	for _, code := range r.codes {
+		if code.Status == AutoTopUpCodePending && len(out) < limit {
+			out = append(out, code)
+		}
+	}
+	return out, nil
+}
+
+func (r *balanceAlertRepoStub) UpdateTopUpCodeStatus(ctx context.Context, id int64, status string, errorMessage *string) error {
+	for i := range r.codes {
+		if r.codes[i].ID == id {
+			r.codes[i].Status = status
+		}
+	}
+	return nil
+}
+
+type balanceAlertUserRepoStub struct {
+	UserRepository
+	user *User
+}
+
+func (r *balanceAlertUserRepoStub) GetByID(ctx context.Context, id int64) (*User, error) {
+	out := *r.user
+	return &out, nil
+}
+
+type balanceObserverStub struct {
+	userIDs             []int64
+	subscriptionUserIDs []int64
+}
+
+func (o *balanceObserverStub) ObserveBalanceChange(userID int64) {
+	o.userIDs = append(o.userIDs, userID)
+}
+
+func (o *balanceObserverStub) ObserveSubscriptionUsage(userID int64) {
+	o.subscriptionUserIDs = append(o.subscriptionUserIDs, userID)
+}
+
+func newBalanceAlertServiceForTest(repo *balanceAlertRepoStub, user *User) (*BalanceAlertService, *webhookSenderStub) {
+	sender := &webhookSenderStub{}
+	svc := NewBalanceAlertService(repo, &balanceAlertUserRepoStub{user: user}, nil, nil, nil, nil, nil, newTestWebhookService(nil, sender))
+	return svc, sender
+}
+
+func TestBalanceAlertSettings_CrossedThreshold(t *testing.T) {
+	s := &BalanceAlertSettings{Thresholds: []float64{10, 5, 1}}
+	require.Nil(t, s.CrossedThreshold(10))
+	require.Equal(t, 10.0, *s.CrossedThreshold(9.99))
+	require.Equal(t, 5.0, *s.CrossedThreshold(4))
+	require.Equal(t, 1.0, *s.CrossedThreshold(-2))
+
+	require.False(t, (*BalanceAlertSettings)(nil).Active())
+	require.False(t, (&BalanceAlertSettings{Thresholds: []float64{1}}).Active())
+	require.True(t, (&BalanceAlertSettings{Thresholds: []float64{1}, WebhookURL: "https://example.com"}).Active())
+	require.True(t, (&BalanceAlertSettings{AutoTopUpEnabled: true}).Active())
+}
+
+func TestNormalizeBalanceThresholds(t *testing.T) {
+	out, err := normalizeBalanceThresholds([]float64{1, 10, 5, 10})
+	require.NoError(t, err)
+	require.Equal(t, []float64{10, 5, 1}, out)
+
+	_, err = normalizeBalanceThresholds([]float64{0})
+	require.ErrorIs(t, err, ErrBalanceAlertInvalidThreshold)
+	_, err = normalizeBalanceThresholds([]float64{1, 2, 3, 4, 5, 6})
+	require.ErrorIs(t, err, ErrBalanceAlertInvalidThreshold)
+}
+
+func TestBalanceAlertService_NotifiesOncePerCrossing(t *testing.T) {
+	repo := &balanceAlertRepoStub{settings: &BalanceAlertSettings{
+		UserID:     1,
+		Thresholds: []float64{10, 5},
+		WebhookURL: "https://example.com/hook",
+	}}
+	user := &User{ID: 1, Balance: 20}
+	svc, sender := newBalanceAlertServiceForTest(repo, user)
+	ctx := context.Background()
+
+	svc.evaluate(ctx, 1)
+	require.Empty(t, sender.urls)
+
+	// 跌破 10：通知一次，继续扣费但未跌破下一档时不重复通知
+	user.Balance = 9
+	svc.evaluate(ctx, 1)
+	user.Balance = 8
+	svc.evaluate(ctx, 1)
+	require.Equal(t, []string{"https://example.com/hook"}, sender.urls)
+
+	// 跌破 5：再通知一次
+	user.Balance = 4
+	svc.evaluate(ctx, 1)
+	require.Len(t, sender.urls, 2)
+	require.Equal(t, 5.0, *repo.settings.NotifiedThreshold)
+
+	// 充值回到 10 以上后重新布防，再次跌破 10 时通知
+	user.Balance = 50
+	svc.evaluate(ctx, 1)
+	require.Nil(t, repo.settings.NotifiedThreshold)
+	user.Balance = 9.5
+	svc.evaluate(ctx, 1)
+	require.Len(t, sender.urls, 3)
+	require.Equal(t, 3, repo.claims)
+}
+
+func TestBalanceAlertService_UpdateSettings(t *testing.T) {
+	repo := &balanceAlertRepoStub{}
+	svc, _ := newBalanceAlertServiceForTest(repo, &User{ID: 1, Balance: 100})
+	ctx := context.Background()
+
+	settings, err := svc.GetSettings(ctx, 1)
+	require.NoError(t, err)
+	require.True(t, settings.EmailEnabled)
+	require.False(t, svc.isWatched(1))
+
+	enabled := true
+	_, err = svc.UpdateSettings(ctx, 1, UpdateBalanceAlertRequest{AutoTopUpEnabled: &enabled})
+	require.ErrorIs(t, err, ErrAutoTopUpInvalidThreshold)
+
+	thresholds := []float64{5, 20}
+	webhookURL := "https://example.com/hook"
+	settings, err = svc.UpdateSettings(ctx, 1, UpdateBalanceAlertRequest{Thresholds: &thresholds, WebhookURL: &webhookURL})
+	require.NoError(t, err)
+	require.Equal(t, []float64{20, 5}, settings.Thresholds)
+	require.NotEmpty(t, settings.WebhookSecret)
+	require.True(t, svc.isWatched(1))
+
+	// 更新后立即入队检查一次
+	svc.pendingMu.Lock()
+	_, queued := svc.pending[1]
+	svc.pendingMu.Unlock()
+	require.True(t, queued)
+
+	secret := settings.WebhookSecret
+	settings, err = svc.UpdateSettings(ctx, 1, UpdateBalanceAlertRequest{RegenerateWebhookSecret: true})
+	require.NoError(t, err)
+	require.NotEqual(t, secret, settings.WebhookSecret)
+
+	empty := []float64{}
+	disabled := false
+	_, err = svc.UpdateSettings(ctx, 1, UpdateBalanceAlertRequest{Thresholds: &empty, EmailEnabled: &disabled})
+	require.NoError(t, err)
+	require.False(t, svc.isWatched(1))
+}
+
+func TestBalanceAlertService_ObserveSkipsUnwatchedUsers(t *testing.T) {
+	svc, _ := newBalanceAlertServiceForTest(&balanceAlertRepoStub{}, &User{ID: 1})
+	svc.ObserveBalanceChange(2)
+	require.Empty(t, svc.pending)
+
+	svc.setWatched(2, true)
+	svc.ObserveBalanceChange(2)
+	svc.ObserveBalanceChange(2)
+	require.Len(t, svc.pending, 1)
+	require.Len(t, svc.wakeCh, 1)
+}
+
+func TestBillingCacheService_DeductNotifiesBalanceObserver(t *testing.T) {
+	svc := NewBillingCacheService(&billingCacheWorkerStub{}, nil, nil, nil, &config.Config{})
+	t.Cleanup(svc.Stop)
+	observer := &balanceObserverStub{}
+	svc.SetBalanceObserver(observer)
+
+	svc.DeductBalanceAndSettle(7, 1.5, nil)
+	require.NoError(t, svc.InvalidateUserBalance(context.Background(), 8))
+	require.Equal(t, []int64{7, 8}, observer.userIDs)
+
+	svc.UpdateSubscriptionUsageAndSettle(9, 3, 0.5, nil)
+	require.Equal(t, []int64{9}, observer.subscriptionUserIDs)
+	require.Equal(t, []int64{7, 8}, observer.userIDs)
+}
+
+// autoTopUpRedeemRepoStub 记录兑换尝试；兑换码均返回不存在，使自动充值在标记失败后结束
+type autoTopUpRedeemRepoStub struct {
+	RedeemCodeRepository
+	attempts []string
+}
+
+func (r *autoTopUpRedeemRepoStub) GetByCode(ctx context.Context, code string) (*RedeemCode, error) {
+	r.attempts = append(r.attempts, code)
+	return nil, ErrRedeemCodeNotFound
+}
+
+type autoTopUpUserSubRepoStub struct {
+	UserSubscriptionRepository
+	expiresAt map[int64]time.Time
+}
+
+func (r *autoTopUpUserSubRepoStub) GetActiveByUserIDAndGroupID(ctx context.Context, userID, groupID int64) (*UserSubscription, error) {
+	expiresAt, ok := r.expiresAt[groupID]
+	if !ok {
+		return nil, ErrSubscriptionNotFound
+	}
+	return &UserSubscription{UserID: userID, GroupID: groupID, Status: SubscriptionStatusActive, ExpiresAt: expiresAt}, nil
+}
+
+func TestBalanceAlertService_AutoTopUpKeepsSubscriptionCodesUntilDue(t *testing.T) {
+	groupID := int64(10)
+	repo := &balanceAlertRepoStub{
+		settings: &BalanceAlertSettings{UserID: 1, AutoTopUpEnabled: true, AutoTopUpThreshold: 5},
+		codes: []AutoTopUpCode{
+			{ID: 1, Status: AutoTopUpCodePending, Code: "SUB-1", Type: RedeemTypeSubscription, GroupID: &groupID, ValidityDays: 30},
+			{ID: 2, Status: AutoTopUpCodePending, Code: "SUB-2", Type: RedeemTypeSubscription, GroupID: &groupID, ValidityDays: 30},
+		},
+	}
+	redeemRepo := &autoTopUpRedeemRepoStub{}
+	subRepo := &autoTopUpUserSubRepoStub{expiresAt: map[int64]time.Time{groupID: time.Now().Add(20 * 24 * time.Hour)}}
+	user := &User{ID: 1, Balance: 0.5}
+	svc := NewBalanceAlertService(repo, &balanceAlertUserRepoStub{user: user}, redeemRepo, subRepo,
+		&RedeemService{redeemRepo: redeemRepo}, nil, nil, nil)
+	ctx := context.Background()
+
+	// 余额持续低于阈值，但订阅远未到期：每个冷却周期都不应消耗订阅兑换码
+	for i := 0; i < 3; i++ {
+		svc.evaluate(ctx, 1)
+	}
+	require.Empty(t, redeemRepo.attempts)
+	require.Equal(t, AutoTopUpCodePending, repo.codes[0].Status)
+
+	// 余额兑换码排在订阅兑换码之后时仍会被使用
+	repo.codes = append(repo.codes, AutoTopUpCode{ID: 3, Status: AutoTopUpCodePending, Code: "BAL-1", Type: RedeemTypeBalance, Value: 10})
+	svc.evaluate(ctx, 1)
+	require.Equal(t, []string{"BAL-1"}, redeemRepo.attempts)
+	require.Equal(t, AutoTopUpCodePending, repo.codes[0].Status)
+
+	// 订阅即将到期时使用订阅兑换码续期
+	redeemRepo.attempts = nil
+	subRepo.expiresAt[groupID] = time.Now().Add(24 * time.Hour)
+	svc.evaluate(ctx, 1)
+	require.Equal(t, []string{"SUB-1", "SUB-2"}, redeemRepo.attempts)
+
+	// 订阅不存在时同样使用
+	redeemRepo.attempts = nil
+	repo.codes = append(repo.codes, AutoTopUpCode{ID: 4, Status: AutoTopUpCodePending, Code: "SUB-3", Type: RedeemTypeSubscription, GroupID: &groupID, ValidityDays: 30})
+	delete(subRepo.expiresAt, groupID)
+	svc.evaluate(ctx, 1)
+	require.Equal(t, []string{"SUB-3"}, redeemRepo.attempts)
+}
+
+func TestBalanceAlertService_RenewsSubscriptionOnSubscriptionUsage(t *testing.T) {
+	groupID := int64(10)
+	repo := &balanceAlertRepoStub{
+		settings: &BalanceAlertSettings{UserID: 1, AutoTopUpEnabled: true, AutoTopUpThreshold: 5},
+		codes: []AutoTopUpCode{
+			{ID: 1, Status: AutoTopUpCodePending, Code: "BAL-1", Type: RedeemTypeBalance, Value: 10},
+			{ID: 2, Status: AutoTopUpCodePending, Code: "SUB-1", Type: RedeemTypeSubscription, GroupID: &groupID, ValidityDays: 30},
+		},
+	}
+	redeemRepo := &autoTopUpRedeemRepoStub{}
+	subRepo := &autoTopUpUserSubRepoStub{expiresAt: map[int64]time.Time{groupID: time.Now().Add(20 * 24 * time.Hour)}}
+	// 仅使用订阅的用户：余额高于自动充值阈值且不会变动
+	user := &User{ID: 1, Balance: 100}
+	svc := NewBalanceAlertService(repo, &balanceAlertUserRepoStub{user: user}, redeemRepo, subRepo,
+		&RedeemService{redeemRepo: redeemRepo}, nil, nil, nil)
+	svc.setWatched(1, true)
+
+	billing := NewBillingCacheService(&billingCacheWorkerStub{}, nil, nil, nil, &config.Config{})
+	t.Cleanup(billing.Stop)
+	billing.SetBalanceObserver(svc)
+
+	// 订阅远未到期：不兑换
+	billing.UpdateSubscriptionUsageAndSettle(1, groupID, 0.5, nil)
+	svc.processPending()
+	require.Empty(t, redeemRepo.attempts)
+
+	// 订阅临近到期：订阅计费触发续期，余额兑换码保留
+	subRepo.expiresAt[groupID] = time.Now().Add(24 * time.Hour)
+	billing.UpdateSubscriptionUsageAndSettle(1, groupID, 0.5, nil)
+	svc.processPending()
+	require.Equal(t, []string{"SUB-1"}, redeemRepo.attempts)
+	require.Equal(t, AutoTopUpCodePending, repo.codes[0].Status)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+// 余额流水类型
+const (
+	BalanceTxTypeUsage       = "usage"        // 用量扣费
+	BalanceTxTypeRedeem      = "redeem"       // 卡密充值
+	BalanceTxTypePromo       = "promo"        // 优惠码赠送
+	BalanceTxTypeAdminAdjust = "admin_adjust" // 管理员调整
+	BalanceTxTypeInitial     = "initial"      // 创建用户时的初始余额
+	BalanceTxTypeOpening     = "opening"      // 启用账本前的期初余额（迁移写入）
+
+	BalanceTxTypeOrganizationTransfer = "org_transfer" // 转入组织余额
+)
+
+// IsValidBalanceTxType 是否为已知的余额流水类型
+func IsValidBalanceTxType(txType string) bool {
+	switch txType {
+	case BalanceTxTypeUsage, BalanceTxTypeRedeem, BalanceTxTypePromo,
+		BalanceTxTypeAdminAdjust, BalanceTxTypeInitial, BalanceTxTypeOpening,
+		BalanceTxTypeOrganizationTransfer:
+		return true
+	default:
+		return false
+	}
+}
+
+// BalanceTransaction 余额流水（只追加，不修改）
+type BalanceTransaction struct {
+	ID     int64
+	UserID int64
+	Type   string
+	// Amount 正数为入账，负数为扣减
+	Amount float64
+	// BalanceAfter 本次变更后的用户余额，由数据库在同一语句中回填
+	BalanceAfter float64
+
+	// 关联来源（按类型至多一个有值）
+	UsageLogID   *int64
+	RedeemCodeID *int64
+	PromoCodeID  *int64
+	AdminUserID  *int64
+
+	Notes     string
+	CreatedAt time.Time
+}
+
+// BalanceInconsistency 账本合计与 users.balance 不一致的用户
+type BalanceInconsistency struct {
+	UserID    int64
+	Email     string
+	Balance   float64
+	LedgerSum float64
+	TxCount   int64
+}
+
+// Difference 用户余额减去账本合计
+func (b BalanceInconsistency) Difference() float64 {
+	return b.Balance - b.LedgerSum
+}
+
+// BalanceTransactionRepository 余额流水查询（写入由 UserRepository.ApplyBalanceTransaction 与余额更新原子完成）
+type BalanceTransactionRepository interface {
+	ListByUser(ctx context.Context, userID int64, txType string, params pagination.PaginationParams) ([]BalanceTransaction, *pagination.PaginationResult, error)
+	// FindInconsistencies 返回 |balance - SUM(amount)| 超过 tolerance 的用户（最多 limit 个）
+	FindInconsistencies(ctx context.Context, tolerance float64, limit int) ([]BalanceInconsistency, error)
+}
+
+// newUsageBalanceTransaction 构造用量扣费流水；usageLogID 为 0 表示使用日志未成功落库
+func newUsageBalanceTransaction(userID int64, cost float64, usageLogID int64) *BalanceTransaction {
+	txn := &BalanceTransaction{
+		UserID: userID,
+		Type:   BalanceTxTypeUsage,
+		Amount: -cost,
+	}
+	if usageLogID > 0 {
+		txn.UsageLogID = &usageLogID
+	}
+	return txn
+}
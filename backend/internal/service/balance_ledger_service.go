@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+const (
+	balanceLedgerCheckName     = "balance_ledger:consistency"
+	balanceLedgerCheckInterval = time.Hour
+	balanceLedgerCheckTimeout  = 2 * time.Minute
+	// BalanceLedgerTolerance 账本合计与余额允许的误差（DECIMAL(20,8) 精度内的浮点误差）
+	BalanceLedgerTolerance = 1e-6
+	// balanceLedgerCheckLimit 单次检查最多返回的不一致用户数
+	balanceLedgerCheckLimit = 100
+)
+
+var ErrInvalidBalanceTxType = infraerrors.BadRequest("INVALID_BALANCE_TX_TYPE", "invalid balance transaction type")
+
+// BalanceLedgerService 余额流水查询与账本一致性检查
+type BalanceLedgerService struct {
+	repo        BalanceTransactionRepository
+	timingWheel *TimingWheelService
+}
+
+// NewBalanceLedgerService creates a new BalanceLedgerService
+func NewBalanceLedgerService(repo BalanceTransactionRepository, timingWheel *TimingWheelService) *BalanceLedgerService {
+	return &BalanceLedgerService{
+		repo:        repo,
+		timingWheel: timingWheel,
+	}
+}
+
+// Start 启动周期性一致性检查
+func (s *BalanceLedgerService) Start() {
+	if s == nil || s.repo == nil || s.timingWheel == nil {
+		return
+	}
+	s.timingWheel.ScheduleRecurring(balanceLedgerCheckName, balanceLedgerCheckInterval, s.runCheck)
+	log.Printf("[BalanceLedger] Consistency checker started (interval: %v)", balanceLedgerCheckInterval)
+}
+
+// Stop 停止周期性一致性检查
+func (s *BalanceLedgerService) Stop() {
+	if s == nil || s.timingWheel == nil {
+		return
+	}
+	s.timingWheel.Cancel(balanceLedgerCheckName)
+}
+
+// ListUserTransactions 分页查询用户余额流水，txType 为空表示全部类型
+func (s *BalanceLedgerService) ListUserTransactions(ctx context.Context, userID int64, txType string, params pagination.PaginationParams) ([]BalanceTransaction, *pagination.PaginationResult, error) {
+	if txType != "" && !IsValidBalanceTxType(txType) {
+		return nil, nil, ErrInvalidBalanceTxType
+	}
+	return s.repo.ListByUser(ctx, userID, txType, params)
+}
+
+// CheckConsistency 比较账本合计与 users.balance，返回不一致的用户
+func (s *BalanceLedgerService) CheckConsistency(ctx context.Context) ([]BalanceInconsistency, error) {
+	return s.repo.FindInconsistencies(ctx, BalanceLedgerTolerance, balanceLedgerCheckLimit)
+}
+
+func (s *BalanceLedgerService) runCheck() {
+	ctx, cancel := context.WithTimeout(context.Background(), balanceLedgerCheckTimeout)
+	defer cancel()
+
+	mismatches, err := s.CheckConsistency(ctx)
+	if err != nil {
+		log.Printf("[BalanceLedger] Consistency check failed: %v", err)
+		return
+	}
+	if len(mismatches) == 0 {
+		return
+	}
+	log.Printf("[BalanceLedger] Found %d user(s) whose balance does not match the ledger", len(mismatches))
+	for i, m := range mismatches {
+		if i >= 10 {
+			break
+		}
+		log.Printf("[BalanceLedger] user_id=%d balance=%.8f ledger_sum=%.8f diff=%.8f tx_count=%d",
+			m.UserID, m.Balance, m.LedgerSum, m.Difference(), m.TxCount)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type balanceTxRepoStub struct {
+	listedType   string
+	tolerance    float64
+	mismatches   []BalanceInconsistency
+	transactions []BalanceTransaction
+}
+
+func (r *balanceTxRepoStub) ListByUser(ctx context.Context, userID int64, txType string, params pagination.PaginationParams) ([]BalanceTransaction, *pagination.PaginationResult, error) {
+	r.listedType = txType
+	return r.transactions, &pagination.PaginationResult{Total: int64(len(r.transactions))}, nil
+}
+
+func (r *balanceTxRepoStub) FindInconsistencies(ctx context.Context, tolerance float64, limit int) ([]BalanceInconsistency, error) {
+	r.tolerance = tolerance
+	return r.mismatches, nil
+}
+
+func TestBalanceLedgerService_ListUserTransactions(t *testing.T) {
+	repo := &balanceTxRepoStub{transactions: []BalanceTransaction{{ID: 1, UserID: 2, Type: BalanceTxTypeRedeem, Amount: 10, BalanceAfter: 10}}}
+	svc := NewBalanceLedgerService(repo, nil)
+	params := pagination.PaginationParams{Page: 1, PageSize: 20}
+
+	txns, result, err := svc.ListUserTransactions(context.Background(), 2, BalanceTxTypeRedeem, params)
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	require.Equal(t, int64(1), result.Total)
+	require.Equal(t, BalanceTxTypeRedeem, repo.listedType)
+
+	_, _, err = svc.ListUserTransactions(context.Background(), 2, "bogus", params)
+	require.ErrorIs(t, err, ErrInvalidBalanceTxType)
+}
+
+func TestBalanceLedgerService_CheckConsistency(t *testing.T) {
+	repo := &balanceTxRepoStub{mismatches: []BalanceInconsistency{{UserID: 3, Balance: 12.5, LedgerSum: 10, TxCount: 2}}}
+	svc := NewBalanceLedgerService(repo, nil)
+
+	mismatches, err := svc.CheckConsistency(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mismatches, 1)
+	require.InDelta(t, 2.5, mismatches[0].Difference(), 1e-9)
+	require.Equal(t, BalanceLedgerTolerance, repo.tolerance)
+
+	require.NotPanics(t, svc.runCheck)
+}
+
+func TestNewUsageBalanceTransaction(t *testing.T) {
+	txn := newUsageBalanceTransaction(5, 0.25, 99)
+	require.Equal(t, BalanceTxTypeUsage, txn.Type)
+	require.Equal(t, -0.25, txn.Amount)
+	require.Equal(t, int64(99), *txn.UsageLogID)
+
+	// 使用日志写入失败时仍扣费，但不关联日志
+	require.Nil(t, newUsageBalanceTransaction(5, 0.25, 0).UsageLogID)
+}
@@ -38,6 +38,10 @@ const (
 	cacheWriteSetSubscription
 	cacheWriteUpdateSubscriptionUsage
 	cacheWriteDeductBalance
+	cacheWriteSetAPIKeyUsage
+	cacheWriteIncrementAPIKeyUsage
+	cacheWriteSetOrganizationBalance
+	cacheWriteDeductOrganizationBalance
 )
 
 // 异步缓存写入工作池配置
@@ -65,6 +69,8 @@ type cacheWriteTask struct {
 	kind             cacheWriteKind
 	userID           int64
 	groupID          int64
+	apiKeyID         int64
+	organizationID   int64
 	balance          float64
 	amount           float64
 	subscriptionData *subscriptionCacheData
@@ -76,9 +82,20 @@ type BillingCacheService struct {
 	cache          BillingCache
 	userRepo       UserRepository
 	subRepo        UserSubscriptionRepository
+	apiKeyRepo     APIKeyRepository
 	cfg            *config.Config
 	circuitBreaker *billingCircuitBreaker
 
+	// 请求前费用预占（可选，由 ProvideBillingCacheService 注入）
+	reservationCache BillingReservationCache
+	billingService   *BillingService
+
+	// 组织余额与成员消费上限（可选，由 ProvideBillingCacheService 注入）
+	organizationRepo OrganizationRepository
+
+	// 余额与订阅用量变动观察者（低余额提醒与自动充值，可选）
+	balanceObserver BalanceObserver
+
 	cacheWriteChan     chan cacheWriteTask
 	cacheWriteWg       sync.WaitGroup
 	cacheWriteStopOnce sync.Once
@@ -89,13 +106,22 @@ type BillingCacheService struct {
 	cacheWriteDropClosedLastLog int64
 }
 
+// BalanceObserver 用户余额与订阅用量变动观察者。
+// 在扣费与缓存失效路径中同步调用，实现方只能做内存入队，不得阻塞。
+type BalanceObserver interface {
+	ObserveBalanceChange(userID int64)
+	// ObserveSubscriptionUsage 订阅计费不改变余额，单独通知以便检查订阅续期
+	ObserveSubscriptionUsage(userID int64)
+}
+
 // NewBillingCacheService 创建计费缓存服务
-func NewBillingCacheService(cache BillingCache, userRepo UserRepository, subRepo UserSubscriptionRepository, cfg *config.Config) *BillingCacheService {
+func NewBillingCacheService(cache BillingCache, userRepo UserRepository, subRepo UserSubscriptionRepository, apiKeyRepo APIKeyRepository, cfg *config.Config) *BillingCacheService {
 	svc := &BillingCacheService{
-		cache:    cache,
-		userRepo: userRepo,
-		subRepo:  subRepo,
-		cfg:      cfg,
+		cache:      cache,
+		userRepo:   userRepo,
+		subRepo:    subRepo,
+		apiKeyRepo: apiKeyRepo,
+		cfg:        cfg,
 	}
 	svc.circuitBreaker = newBillingCircuitBreaker(cfg.Billing.CircuitBreaker)
 	svc.startCacheWriteWorkers()
@@ -165,6 +191,26 @@ func (s *BillingCacheService) cacheWriteWorker() {
 					log.Printf("Warning: deduct balance cache failed for user %d: %v", task.userID, err)
 				}
 			}
+		case cacheWriteSetAPIKeyUsage:
+			if s.cache != nil {
+				if err := s.cache.SetAPIKeyUsage(ctx, task.apiKeyID, task.amount); err != nil {
+					log.Printf("Warning: set api key usage cache failed for api key %d: %v", task.apiKeyID, err)
+				}
+			}
+		case cacheWriteIncrementAPIKeyUsage:
+			if s.cache != nil {
+				if err := s.cache.IncrementAPIKeyUsage(ctx, task.apiKeyID, task.amount); err != nil {
+					log.Printf("Warning: increment api key usage cache failed for api key %d: %v", task.apiKeyID, err)
+				}
+			}
+		case cacheWriteSetOrganizationBalance:
+			s.setOrganizationBalanceCache(ctx, task.organizationID, task.balance)
+		case cacheWriteDeductOrganizationBalance:
+			if s.cache != nil {
+				if err := s.cache.DeductOrganizationBalance(ctx, task.organizationID, task.amount); err != nil {
+					log.Printf("Warning: deduct organization balance cache failed for organization %d: %v", task.organizationID, err)
+				}
+			}
 		}
 		cancel()
 	}
@@ -181,6 +227,14 @@ func cacheWriteKindName(kind cacheWriteKind) string {
 		return "update_subscription_usage"
 	case cacheWriteDeductBalance:
 		return "deduct_balance"
+	case cacheWriteSetAPIKeyUsage:
+		return "set_api_key_usage"
+	case cacheWriteIncrementAPIKeyUsage:
+		return "increment_api_key_usage"
+	case cacheWriteSetOrganizationBalance:
+		return "set_organization_balance"
+	case cacheWriteDeductOrganizationBalance:
+		return "deduct_organization_balance"
 	default:
 		return "unknown"
 	}
@@ -203,6 +257,7 @@ func (s *BillingCacheService) logCacheWriteDrop(task cacheWriteTask, reason stri
 		return
 	}
 
+	billingCacheWriteDropsTotal.WithLabelValues(reason, cacheWriteKindName(task.kind)).Inc()
 	atomic.AddUint64(countPtr, 1)
 	now := time.Now().UnixNano()
 	last := atomic.LoadInt64(lastPtr)
@@ -306,8 +361,27 @@ func (s *BillingCacheService) QueueDeductBalance(userID int64, amount float64) {
 	}
 }
 
+// SetBalanceObserver 注入余额变动观察者（其依赖 RedeemService，无法在构造时注入）
+func (s *BillingCacheService) SetBalanceObserver(observer BalanceObserver) {
+	s.balanceObserver = observer
+}
+
+func (s *BillingCacheService) notifyBalanceChange(userID int64) {
+	if s.balanceObserver != nil {
+		s.balanceObserver.ObserveBalanceChange(userID)
+	}
+}
+
+func (s *BillingCacheService) notifySubscriptionUsage(userID int64) {
+	if s.balanceObserver != nil {
+		s.balanceObserver.ObserveSubscriptionUsage(userID)
+	}
+}
+
 // InvalidateUserBalance 失效用户余额缓存
+// 充值、兑换、管理员调整等余额变动后调用，同时通知观察者（余额回升后重新布防提醒）
 func (s *BillingCacheService) InvalidateUserBalance(ctx context.Context, userID int64) error {
+	s.notifyBalanceChange(userID)
 	if s.cache == nil {
 		return nil
 	}
@@ -318,6 +392,89 @@ func (s *BillingCacheService) InvalidateUserBalance(ctx context.Context, userID
 	return nil
 }
 
+// ============================================
+// API Key 额度缓存方法
+// ============================================
+
+// GetAPIKeyUsage 获取 API Key 已用额度（优先从缓存读取）
+func (s *BillingCacheService) GetAPIKeyUsage(ctx context.Context, apiKeyID int64) (float64, error) {
+	if s.cache != nil {
+		if used, err := s.cache.GetAPIKeyUsage(ctx, apiKeyID); err == nil {
+			return used, nil
+		}
+	}
+
+	if s.apiKeyRepo == nil {
+		return 0, fmt.Errorf("api key repository not configured")
+	}
+	used, err := s.apiKeyRepo.GetUsedUSD(ctx, apiKeyID)
+	if err != nil {
+		return 0, fmt.Errorf("get api key usage: %w", err)
+	}
+
+	if s.cache != nil {
+		_ = s.enqueueCacheWrite(cacheWriteTask{
+			kind:     cacheWriteSetAPIKeyUsage,
+			apiKeyID: apiKeyID,
+			amount:   used,
+		})
+	}
+	return used, nil
+}
+
+// RecordAPIKeyUsage 累加 API Key 已用额度（数据库 + 异步缓存）
+func (s *BillingCacheService) RecordAPIKeyUsage(ctx context.Context, apiKeyID int64, amount float64) {
+	if s == nil || amount <= 0 {
+		return
+	}
+	if s.apiKeyRepo != nil {
+		if err := s.apiKeyRepo.IncrementUsedUSD(ctx, apiKeyID, amount); err != nil {
+			log.Printf("Increment api key usage failed: api_key=%d err=%v", apiKeyID, err)
+		}
+	}
+	if s.cache == nil {
+		return
+	}
+	// 队列满时同步回退，避免额度累计被静默丢弃。
+	if s.enqueueCacheWrite(cacheWriteTask{
+		kind:     cacheWriteIncrementAPIKeyUsage,
+		apiKeyID: apiKeyID,
+		amount:   amount,
+	}) {
+		return
+	}
+	cacheCtx, cancel := context.WithTimeout(context.Background(), cacheWriteTimeout)
+	defer cancel()
+	if err := s.cache.IncrementAPIKeyUsage(cacheCtx, apiKeyID, amount); err != nil {
+		log.Printf("Warning: increment api key usage cache fallback failed for api key %d: %v", apiKeyID, err)
+	}
+}
+
+// CheckAPIKeyLimits 检查 API Key 是否过期或超出额度
+func (s *BillingCacheService) CheckAPIKeyLimits(ctx context.Context, apiKey *APIKey) error {
+	if apiKey == nil {
+		return nil
+	}
+	if apiKey.IsExpired() {
+		return ErrAPIKeyExpired
+	}
+	if !apiKey.HasQuota() {
+		return nil
+	}
+	used, err := s.GetAPIKeyUsage(ctx, apiKey.ID)
+	if err != nil {
+		if s.circuitBreaker != nil {
+			s.circuitBreaker.OnFailure(err)
+		}
+		log.Printf("ALERT: api key quota check failed for api key %d: %v", apiKey.ID, err)
+		return ErrBillingServiceUnavailable.WithCause(err)
+	}
+	if apiKey.IsQuotaExhausted(used) {
+		return ErrAPIKeyQuotaExceeded
+	}
+	return nil
+}
+
 // ============================================
 // 订阅缓存方法
 // ============================================
@@ -461,7 +618,13 @@ func (s *BillingCacheService) CheckBillingEligibility(ctx context.Context, user
 	isSubscriptionMode := group != nil && group.IsSubscriptionType() && subscription != nil
 
 	if isSubscriptionMode {
-		return s.checkSubscriptionEligibility(ctx, user.ID, group, subscription)
+		// 共享订阅时订阅属于组织所有者
+		return s.checkSubscriptionEligibility(ctx, subscriptionOwnerID(user, subscription), group, subscription)
+	}
+
+	// 团队 API Key 扣减组织余额
+	if apiKey != nil && apiKey.IsTeamKey() {
+		return s.checkOrganizationEligibility(ctx, apiKey)
 	}
 
 	return s.checkBalanceEligibility(ctx, user.ID)
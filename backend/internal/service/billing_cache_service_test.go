@@ -14,6 +14,7 @@ import (
 type billingCacheWorkerStub struct {
 	balanceUpdates      int64
 	subscriptionUpdates int64
+	organizationUpdates int64
 }
 
 func (b *billingCacheWorkerStub) GetUserBalance(ctx context.Context, userID int64) (float64, error) {
@@ -52,9 +53,39 @@ func (b *billingCacheWorkerStub) InvalidateSubscriptionCache(ctx context.Context
 	return nil
 }
 
+func (b *billingCacheWorkerStub) GetAPIKeyUsage(ctx context.Context, apiKeyID int64) (float64, error) {
+	return 0, nil
+}
+
+func (b *billingCacheWorkerStub) SetAPIKeyUsage(ctx context.Context, apiKeyID int64, used float64) error {
+	return nil
+}
+
+func (b *billingCacheWorkerStub) IncrementAPIKeyUsage(ctx context.Context, apiKeyID int64, amount float64) error {
+	return nil
+}
+
+func (b *billingCacheWorkerStub) GetOrganizationBalance(ctx context.Context, orgID int64) (float64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (b *billingCacheWorkerStub) SetOrganizationBalance(ctx context.Context, orgID int64, balance float64) error {
+	atomic.AddInt64(&b.organizationUpdates, 1)
+	return nil
+}
+
+func (b *billingCacheWorkerStub) DeductOrganizationBalance(ctx context.Context, orgID int64, amount float64) error {
+	atomic.AddInt64(&b.organizationUpdates, 1)
+	return nil
+}
+
+func (b *billingCacheWorkerStub) InvalidateOrganizationBalance(ctx context.Context, orgID int64) error {
+	return nil
+}
+
 func TestBillingCacheServiceQueueHighLoad(t *testing.T) {
 	cache := &billingCacheWorkerStub{}
-	svc := NewBillingCacheService(cache, nil, nil, &config.Config{})
+	svc := NewBillingCacheService(cache, nil, nil, nil, &config.Config{})
 	t.Cleanup(svc.Stop)
 
 	start := time.Now()
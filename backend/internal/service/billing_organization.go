@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+func billingReservationOrganizationScope(orgID int64) string {
+	return fmt.Sprintf("org:%d", orgID)
+}
+
+// subscriptionOwnerID 订阅所属用户（共享订阅时为组织所有者）
+func subscriptionOwnerID(user *User, subscription *UserSubscription) int64 {
+	if subscription != nil && subscription.UserID > 0 {
+		return subscription.UserID
+	}
+	if user == nil {
+		return 0
+	}
+	return user.ID
+}
+
+// ============================================
+// 组织余额缓存方法
+// ============================================
+
+// GetOrganizationBalance 获取组织余额（优先从缓存读取）
+func (s *BillingCacheService) GetOrganizationBalance(ctx context.Context, orgID int64) (float64, error) {
+	if s.cache != nil {
+		if balance, err := s.cache.GetOrganizationBalance(ctx, orgID); err == nil {
+			return balance, nil
+		}
+	}
+
+	if s.organizationRepo == nil {
+		return 0, fmt.Errorf("organization repository not configured")
+	}
+	org, err := s.organizationRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return 0, fmt.Errorf("get organization balance: %w", err)
+	}
+
+	if s.cache != nil {
+		_ = s.enqueueCacheWrite(cacheWriteTask{
+			kind:           cacheWriteSetOrganizationBalance,
+			organizationID: orgID,
+			balance:        org.Balance,
+		})
+	}
+	return org.Balance, nil
+}
+
+// setOrganizationBalanceCache 设置组织余额缓存
+func (s *BillingCacheService) setOrganizationBalanceCache(ctx context.Context, orgID int64, balance float64) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.SetOrganizationBalance(ctx, orgID, balance); err != nil {
+		log.Printf("Warning: set organization balance cache failed for organization %d: %v", orgID, err)
+	}
+}
+
+// InvalidateOrganizationBalance 失效组织余额缓存（充值、转入、调整后调用）
+func (s *BillingCacheService) InvalidateOrganizationBalance(ctx context.Context, orgID int64) error {
+	if s.cache == nil {
+		return nil
+	}
+	if err := s.cache.InvalidateOrganizationBalance(ctx, orgID); err != nil {
+		log.Printf("Warning: invalidate organization balance cache failed for organization %d: %v", orgID, err)
+		return err
+	}
+	return nil
+}
+
+// checkOrganizationEligibility 检查团队 API Key 的组织余额与成员月度消费上限。
+// 成员消费上限仅对设置了上限的成员回源数据库，不影响其他请求。
+func (s *BillingCacheService) checkOrganizationEligibility(ctx context.Context, apiKey *APIKey) error {
+	orgID := *apiKey.OrganizationID
+	balance, err := s.GetOrganizationBalance(ctx, orgID)
+	if err != nil {
+		if s.circuitBreaker != nil {
+			s.circuitBreaker.OnFailure(err)
+		}
+		log.Printf("ALERT: billing organization balance check failed for organization %d: %v", orgID, err)
+		return ErrBillingServiceUnavailable.WithCause(err)
+	}
+	if s.circuitBreaker != nil {
+		s.circuitBreaker.OnSuccess()
+	}
+	if balance <= 0 {
+		return ErrInsufficientBalance
+	}
+
+	if apiKey.OrganizationMember == nil || apiKey.OrganizationMember.SpendLimitUSD == nil || s.organizationRepo == nil {
+		return nil
+	}
+	member, err := s.organizationRepo.GetMember(ctx, orgID, apiKey.UserID)
+	if err != nil {
+		log.Printf("ALERT: billing organization member check failed for organization %d user %d: %v", orgID, apiKey.UserID, err)
+		return ErrBillingServiceUnavailable.WithCause(err)
+	}
+	if member.IsSpendLimitExceeded(time.Now()) {
+		return ErrOrganizationSpendLimitHit
+	}
+	return nil
+}
+
+// ChargeOrganizationAndSettle 团队 API Key 用量扣减组织余额（数据库 + 缓存）后结算预占。
+// 仅在异步用量记录协程中调用，不增加请求延迟。
+func (s *BillingCacheService) ChargeOrganizationAndSettle(ctx context.Context, orgID, userID int64, amount float64, usageLogID int64, reservation *BillingReservation) {
+	if s.organizationRepo == nil {
+		log.Printf("Charge organization failed: organization repository not configured (organization=%d)", orgID)
+		reservation.settle()
+		return
+	}
+	if err := s.organizationRepo.ChargeUsage(ctx, orgID, userID, amount, usageLogID); err != nil {
+		log.Printf("Charge organization failed: organization=%d user=%d err=%v", orgID, userID, err)
+	}
+	if s.cache == nil {
+		reservation.settle()
+		return
+	}
+	if reservation == nil {
+		// 队列满时同步回退，避免关键扣减被静默丢弃。
+		if s.enqueueCacheWrite(cacheWriteTask{
+			kind:           cacheWriteDeductOrganizationBalance,
+			organizationID: orgID,
+			amount:         amount,
+		}) {
+			return
+		}
+	}
+	cacheCtx, cancel := context.WithTimeout(context.Background(), cacheWriteTimeout)
+	defer cancel()
+	if err := s.cache.DeductOrganizationBalance(cacheCtx, orgID, amount); err != nil {
+		log.Printf("Warning: deduct organization balance cache failed for organization %d: %v", orgID, err)
+	}
+	reservation.settle()
+}
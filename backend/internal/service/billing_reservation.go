@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/google/uuid"
+	"github.com/tidwall/gjson"
+)
+
+// BillingReservationCache 请求前费用预占缓存
+//
+// 余额/订阅额度在请求入口检查、在用量落库后才扣减，并发请求在两者之间可以把额度透支。
+// 预占在转发前按最大可能费用占用额度，用量记录后释放；进程崩溃遗留的预占在 TTL 后自动失效。
+type BillingReservationCache interface {
+	// Reserve 在 scope 上为 reservationID 预占 amount。
+	// 非严格模式下 已预占 < available 即放行；严格模式要求 已预占+amount <= available。
+	// 返回是否预占成功以及当前未过期的预占总额（成功时包含本次）。
+	Reserve(ctx context.Context, scope, reservationID string, amount, available float64, strict bool, ttl time.Duration) (bool, float64, error)
+	// Release 释放预占，预占不存在（已释放或已过期）时为空操作
+	Release(ctx context.Context, scope, reservationID string) error
+	// GetReserved 返回 scope 上未过期的预占总额
+	GetReserved(ctx context.Context, scope string) (float64, error)
+}
+
+const (
+	// reservationImageTokens 图片/文档等二进制内容按固定 token 数估算（约等于一张 1092x1092 图片）
+	reservationImageTokens = 1600
+)
+
+// 预占状态
+const (
+	reservationHeld int32 = iota
+	reservationDetached
+	reservationReleased
+)
+
+// 预占结果（指标标签）
+const (
+	reservationResultReserved = "reserved"
+	reservationResultRejected = "rejected"
+	reservationResultError    = "error"
+)
+
+// BillingReservation 一次请求的费用预占
+//
+// 生命周期：handler 预占后 defer Release()；转发成功后通过 Detach() 把预占移交给异步用量记录，
+// 由 RecordUsage 在扣费写入缓存后结算释放。
+type BillingReservation struct {
+	cache BillingReservationCache
+	scope string
+	id    string
+	// Amount 预占金额（余额模式为按倍率计算后的费用，订阅模式为原始费用）
+	Amount float64
+
+	state atomic.Int32
+}
+
+func billingReservationUserScope(userID int64) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+func billingReservationSubscriptionScope(userID, groupID int64) string {
+	return fmt.Sprintf("sub:%d:%d", userID, groupID)
+}
+
+// Release 释放预占（幂等）；已 Detach 给用量记录时为空操作
+func (r *BillingReservation) Release() {
+	if r == nil || !r.state.CompareAndSwap(reservationHeld, reservationReleased) {
+		return
+	}
+	r.release()
+}
+
+// Detach 将预占移交给异步用量记录，之后 Release 不再生效。
+// 预占已释放时返回 nil。
+func (r *BillingReservation) Detach() *BillingReservation {
+	if r == nil || !r.state.CompareAndSwap(reservationHeld, reservationDetached) {
+		return nil
+	}
+	return r
+}
+
+// settle 用量已扣费（或确认无需扣费）后释放预占（幂等）
+func (r *BillingReservation) settle() {
+	if r == nil {
+		return
+	}
+	if r.state.CompareAndSwap(reservationDetached, reservationReleased) || r.state.CompareAndSwap(reservationHeld, reservationReleased) {
+		r.release()
+	}
+}
+
+func (r *BillingReservation) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), cacheWriteTimeout)
+	defer cancel()
+	if err := r.cache.Release(ctx, r.scope, r.id); err != nil {
+		log.Printf("Warning: release billing reservation %s/%s failed: %v", r.scope, r.id, err)
+	}
+}
+
+// reservationEnabled 是否启用费用预占
+func (s *BillingCacheService) reservationEnabled() bool {
+	return s.reservationCache != nil && s.billingService != nil &&
+		s.cfg.RunMode != config.RunModeSimple && s.cfg.Billing.Reservation.Enabled
+}
+
+// ReserveRequestCost 按输入 token 估算 + max_tokens 预估本次请求的最大费用并预占。
+// 额度不足时返回与资格检查一致的错误；预占缓存不可用或无法估价时不阻断请求（返回 nil 预占）。
+// 应在 CheckBillingEligibility 之后、转发之前调用。
+func (s *BillingCacheService) ReserveRequestCost(ctx context.Context, apiKey *APIKey, subscription *UserSubscription, model string, body []byte) (*BillingReservation, error) {
+	if !s.reservationEnabled() || apiKey == nil || apiKey.User == nil {
+		return nil, nil
+	}
+	inputTokens, outputTokens := estimateRequestTokens(body, s.cfg.Billing.Reservation.DefaultOutputTokens)
+	return s.reserveCost(ctx, apiKey, subscription, model, inputTokens, outputTokens)
+}
+
+// ReserveEmbeddingsCost 按输入估算 embeddings 请求的费用并预占（embeddings 没有输出 token），语义同 ReserveRequestCost
+func (s *BillingCacheService) ReserveEmbeddingsCost(ctx context.Context, apiKey *APIKey, subscription *UserSubscription, req *EmbeddingsRequest) (*BillingReservation, error) {
+	if !s.reservationEnabled() || apiKey == nil || apiKey.User == nil || req == nil {
+		return nil, nil
+	}
+	return s.reserveCost(ctx, apiKey, subscription, req.Model, estimateEmbeddingsTokens(req), 0)
+}
+
+func (s *BillingCacheService) reserveCost(ctx context.Context, apiKey *APIKey, subscription *UserSubscription, model string, inputTokens, outputTokens int) (*BillingReservation, error) {
+	rc := s.cfg.Billing.Reservation
+	user := apiKey.User
+	group := apiKey.Group
+	isSubscriptionMode := group != nil && group.IsSubscriptionType() && subscription != nil
+
+	// 与 RecordUsage 保持一致：有分组时使用分组倍率
+	multiplier := s.cfg.Default.RateMultiplier
+	if apiKey.GroupID != nil && group != nil {
+		multiplier = group.RateMultiplier
+	}
+	if !isSubscriptionMode && multiplier <= 0 {
+		// 免费分组不扣余额，无需预占
+		return nil, nil
+	}
+
+	estimate, err := s.billingService.GetEstimatedCost(model, apiKey.GroupID, inputTokens, outputTokens, multiplier)
+	if err != nil {
+		log.Printf("Warning: estimate request cost failed for model %s: %v", model, err)
+		return nil, nil
+	}
+	amount := estimate.ActualCost
+	if isSubscriptionMode {
+		// 订阅用量按原始费用累计，与 RecordUsage 口径一致
+		amount = estimate.TotalCost
+	}
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	var (
+		scope     string
+		available float64
+		limitErr  error
+	)
+	if isSubscriptionMode {
+		ownerID := subscriptionOwnerID(user, subscription)
+		subData, err := s.GetSubscriptionStatus(ctx, ownerID, group.ID)
+		if err != nil {
+			log.Printf("Warning: billing reservation skipped, load subscription failed for user %d group %d: %v", ownerID, group.ID, err)
+			return nil, nil
+		}
+		var limited bool
+		available, limited, limitErr = subscriptionHeadroom(group, subData)
+		if !limited {
+			return nil, nil
+		}
+		scope = billingReservationSubscriptionScope(ownerID, group.ID)
+	} else if apiKey.IsTeamKey() {
+		// 团队 API Key 在组织余额上预占，成员之间共享
+		balance, err := s.GetOrganizationBalance(ctx, *apiKey.OrganizationID)
+		if err != nil {
+			log.Printf("Warning: billing reservation skipped, load balance failed for organization %d: %v", *apiKey.OrganizationID, err)
+			return nil, nil
+		}
+		available = balance
+		limitErr = ErrInsufficientBalance
+		scope = billingReservationOrganizationScope(*apiKey.OrganizationID)
+	} else {
+		balance, err := s.GetUserBalance(ctx, user.ID)
+		if err != nil {
+			log.Printf("Warning: billing reservation skipped, load balance failed for user %d: %v", user.ID, err)
+			return nil, nil
+		}
+		available = balance
+		limitErr = ErrInsufficientBalance
+		scope = billingReservationUserScope(user.ID)
+	}
+
+	id := uuid.NewString()
+	ttl := time.Duration(rc.TTLSeconds) * time.Second
+	ok, outstanding, err := s.reservationCache.Reserve(ctx, scope, id, amount, available, rc.Strict, ttl)
+	if err != nil {
+		// 预占缓存不可用时降级为仅做资格检查，避免 Redis 抖动阻断全部请求
+		recordBillingReservation(reservationResultError)
+		log.Printf("Warning: billing reservation failed for %s: %v", scope, err)
+		return nil, nil
+	}
+	if !ok {
+		recordBillingReservation(reservationResultRejected)
+		log.Printf("Billing reservation rejected for %s: estimate=%.6f outstanding=%.6f available=%.6f", scope, amount, outstanding, available)
+		return nil, limitErr
+	}
+	recordBillingReservation(reservationResultReserved)
+	return &BillingReservation{
+		cache:  s.reservationCache,
+		scope:  scope,
+		id:     id,
+		Amount: amount,
+	}, nil
+}
+
+// GetReservedSubscriptionCost 返回订阅上未结算请求的预占总额（预占未启用或查询失败时返回 0）
+func (s *BillingCacheService) GetReservedSubscriptionCost(ctx context.Context, userID, groupID int64) float64 {
+	if !s.reservationEnabled() {
+		return 0
+	}
+	reserved, err := s.reservationCache.GetReserved(ctx, billingReservationSubscriptionScope(userID, groupID))
+	if err != nil {
+		log.Printf("Warning: load subscription reservation failed for user %d group %d: %v", userID, groupID, err)
+		return 0
+	}
+	return reserved
+}
+
+// DeductBalanceAndSettle 扣减余额缓存后结算预占。
+// 有预占时同步写缓存再释放，避免"预占已释放、扣减尚未写入"的空窗；无预占时沿用异步队列。
+// 仅在异步用量记录协程中调用，不增加请求延迟；扣减后通知余额观察者（只入队）。
+func (s *BillingCacheService) DeductBalanceAndSettle(userID int64, amount float64, reservation *BillingReservation) {
+	defer s.notifyBalanceChange(userID)
+	if reservation == nil {
+		s.QueueDeductBalance(userID, amount)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cacheWriteTimeout)
+	defer cancel()
+	if err := s.DeductBalanceCache(ctx, userID, amount); err != nil {
+		log.Printf("Warning: deduct balance cache failed for user %d: %v", userID, err)
+	}
+	reservation.settle()
+}
+
+// UpdateSubscriptionUsageAndSettle 更新订阅用量缓存后结算预占，语义同 DeductBalanceAndSettle
+func (s *BillingCacheService) UpdateSubscriptionUsageAndSettle(userID, groupID int64, costUSD float64, reservation *BillingReservation) {
+	defer s.notifySubscriptionUsage(userID)
+	if reservation == nil {
+		s.QueueUpdateSubscriptionUsage(userID, groupID, costUSD)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cacheWriteTimeout)
+	defer cancel()
+	if err := s.UpdateSubscriptionUsage(ctx, userID, groupID, costUSD); err != nil {
+		log.Printf("Warning: update subscription cache failed for user %d group %d: %v", userID, groupID, err)
+	}
+	reservation.settle()
+}
+
+// subscriptionHeadroom 计算订阅各窗口剩余额度的最小值及对应的超限错误；
+// 分组未配置任何限额时 limited 为 false
+func subscriptionHeadroom(group *Group, data *subscriptionCacheData) (available float64, limited bool, limitErr error) {
+	available = math.Inf(1)
+	check := func(has bool, limit *float64, usage float64, err error) {
+		if !has {
+			return
+		}
+		if remaining := *limit - usage; remaining < available {
+			available = remaining
+			limitErr = err
+		}
+		limited = true
+	}
+	check(group.HasDailyLimit(), group.DailyLimitUSD, data.DailyUsage, ErrDailyLimitExceeded)
+	check(group.HasWeeklyLimit(), group.WeeklyLimitUSD, data.WeeklyUsage, ErrWeeklyLimitExceeded)
+	check(group.HasMonthlyLimit(), group.MonthlyLimitUSD, data.MonthlyUsage, ErrMonthlyLimitExceeded)
+	return available, limited, limitErr
+}
+
+// estimateRequestTokens 粗略估算请求的输入 token 数与最大输出 token 数。
+// 输入：遍历请求体中的全部字符串按文本估算，base64/data URL/图片链接按固定 token 数计；
+// 输出：优先取请求声明的最大输出（Claude/OpenAI/Gemini 字段），未声明时使用 defaultOutput。
+func estimateRequestTokens(body []byte, defaultOutput int) (inputTokens, outputTokens int) {
+	root := gjson.ParseBytes(body)
+	inputTokens = estimateJSONTokens("", root)
+
+	for _, path := range []string{"max_tokens", "max_output_tokens", "max_completion_tokens", "generationConfig.maxOutputTokens"} {
+		if v := root.Get(path); v.Exists() && v.Int() > 0 {
+			return inputTokens, int(v.Int())
+		}
+	}
+	return inputTokens, defaultOutput
+}
+
+// estimateEmbeddingsTokens 估算 embeddings 输入 token 数：文本按字符估算，token id 数组按元素个数计
+func estimateEmbeddingsTokens(req *EmbeddingsRequest) int {
+	total := 0
+	if req.TokenInput {
+		var count func(v gjson.Result)
+		count = func(v gjson.Result) {
+			if v.IsArray() {
+				v.ForEach(func(_, child gjson.Result) bool {
+					count(child)
+					return true
+				})
+				return
+			}
+			total++
+		}
+		count(gjson.GetBytes(req.Body, "input"))
+		return total
+	}
+	for _, input := range req.Inputs {
+		total += estimateTokensForText(input)
+	}
+	return total
+}
+
+// estimateJSONTokens 递归估算 JSON 值中文本内容的 token 数
+func estimateJSONTokens(key string, v gjson.Result) int {
+	switch {
+	case v.IsObject() || v.IsArray():
+		total := 0
+		v.ForEach(func(k, child gjson.Result) bool {
+			total += estimateJSONTokens(k.String(), child)
+			return true
+		})
+		return total
+	case v.Type == gjson.String:
+		switch key {
+		case "model", "type", "role", "media_type", "mime_type", "mimeType", "id", "tool_use_id", "signature":
+			return 0
+		case "data", "file_data":
+			return reservationImageTokens
+		case "url", "image_url", "file_uri", "fileUri":
+			return reservationImageTokens
+		}
+		if strings.HasPrefix(v.Str, "data:") {
+			return reservationImageTokens
+		}
+		return estimateTokensForText(v.Str)
+	}
+	return 0
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBillingReservationCache 内存实现，语义与 Redis 实现一致（不模拟过期）
+type fakeBillingReservationCache struct {
+	mu       sync.Mutex
+	reserved map[string]map[string]float64
+	released int
+}
+
+func newFakeBillingReservationCache() *fakeBillingReservationCache {
+	return &fakeBillingReservationCache{reserved: make(map[string]map[string]float64)}
+}
+
+func (f *fakeBillingReservationCache) Reserve(ctx context.Context, scope, reservationID string, amount, available float64, strict bool, ttl time.Duration) (bool, float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	outstanding := f.sumLocked(scope)
+	if strict && outstanding+amount > available {
+		return false, outstanding, nil
+	}
+	if !strict && outstanding >= available {
+		return false, outstanding, nil
+	}
+	if f.reserved[scope] == nil {
+		f.reserved[scope] = make(map[string]float64)
+	}
+	f.reserved[scope][reservationID] = amount
+	return true, outstanding + amount, nil
+}
+
+func (f *fakeBillingReservationCache) Release(ctx context.Context, scope, reservationID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.reserved[scope][reservationID]; ok {
+		delete(f.reserved[scope], reservationID)
+		f.released++
+	}
+	return nil
+}
+
+func (f *fakeBillingReservationCache) GetReserved(ctx context.Context, scope string) (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sumLocked(scope), nil
+}
+
+func (f *fakeBillingReservationCache) sumLocked(scope string) float64 {
+	total := 0.0
+	for _, v := range f.reserved[scope] {
+		total += v
+	}
+	return total
+}
+
+// reservationBillingCacheStub 返回固定余额与订阅用量的计费缓存
+type reservationBillingCacheStub struct {
+	billingCacheWorkerStub
+	balance float64
+	sub     *SubscriptionCacheData
+}
+
+func (b *reservationBillingCacheStub) GetUserBalance(ctx context.Context, userID int64) (float64, error) {
+	return b.balance, nil
+}
+
+func (b *reservationBillingCacheStub) GetSubscriptionCache(ctx context.Context, userID, groupID int64) (*SubscriptionCacheData, error) {
+	return b.sub, nil
+}
+
+func newReservationTestService(t *testing.T, cache BillingCache, strict bool) (*BillingCacheService, *fakeBillingReservationCache) {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Default.RateMultiplier = 1
+	cfg.Billing.Reservation = config.BillingReservationConfig{
+		Enabled:             true,
+		TTLSeconds:          600,
+		Strict:              strict,
+		DefaultOutputTokens: 4096,
+	}
+	reservations := newFakeBillingReservationCache()
+	svc := ProvideBillingCacheService(cache, reservations, NewBillingService(cfg, nil), nil, nil, nil, nil, cfg)
+	t.Cleanup(svc.Stop)
+	return svc, reservations
+}
+
+func TestEstimateRequestTokens(t *testing.T) {
+	body := []byte(`{"model":"claude-sonnet-4","max_tokens":2000,"messages":[{"role":"user","content":[
+		{"type":"text","text":"hello world, this is a test"},
+		{"type":"image","source":{"type":"base64","media_type":"image/png","data":"iVBORw0KGgo="}}
+	]}]}`)
+	in, out := estimateRequestTokens(body, 4096)
+	require.Equal(t, 2000, out)
+	require.Equal(t, estimateTokensForText("hello world, this is a test")+reservationImageTokens, in)
+
+	_, out = estimateRequestTokens([]byte(`{"model":"gpt-5","input":"hi"}`), 4096)
+	require.Equal(t, 4096, out, "missing max_tokens falls back to default")
+
+	_, out = estimateRequestTokens([]byte(`{"max_output_tokens":123}`), 4096)
+	require.Equal(t, 123, out)
+
+	_, out = estimateRequestTokens([]byte(`{"contents":[],"generationConfig":{"maxOutputTokens":77}}`), 4096)
+	require.Equal(t, 77, out)
+}
+
+func TestReserveRequestCost_BalanceBoundsConcurrentOverdraft(t *testing.T) {
+	svc, reservations := newReservationTestService(t, &reservationBillingCacheStub{balance: 0.10}, false)
+	groupID := int64(1)
+	apiKey := &APIKey{ID: 1, GroupID: &groupID, User: &User{ID: 7}, Group: &Group{ID: 1, RateMultiplier: 1}}
+	body := []byte(`{"model":"claude-opus-4.5","max_tokens":200000,"messages":[{"role":"user","content":"hi"}]}`)
+
+	first, err := svc.ReserveRequestCost(context.Background(), apiKey, nil, "claude-opus-4.5", body)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	require.Greater(t, first.Amount, 0.10)
+
+	// 非严格模式：已预占超过余额后后续请求全部拒绝
+	for i := 0; i < 9; i++ {
+		r, err := svc.ReserveRequestCost(context.Background(), apiKey, nil, "claude-opus-4.5", body)
+		require.ErrorIs(t, err, ErrInsufficientBalance)
+		require.Nil(t, r)
+	}
+
+	first.Release()
+	first.Release()
+	reserved, _ := reservations.GetReserved(context.Background(), billingReservationUserScope(7))
+	require.Zero(t, reserved)
+	require.Equal(t, 1, reservations.released)
+}
+
+func TestReserveRequestCost_StrictRejectsEstimateAboveBalance(t *testing.T) {
+	svc, _ := newReservationTestService(t, &reservationBillingCacheStub{balance: 0.10}, true)
+	apiKey := &APIKey{ID: 1, User: &User{ID: 7}}
+	body := []byte(`{"max_tokens":200000,"messages":[{"role":"user","content":"hi"}]}`)
+
+	r, err := svc.ReserveRequestCost(context.Background(), apiKey, nil, "claude-opus-4.5", body)
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+	require.Nil(t, r)
+
+	small := []byte(`{"max_tokens":10,"messages":[{"role":"user","content":"hi"}]}`)
+	r, err = svc.ReserveRequestCost(context.Background(), apiKey, nil, "claude-opus-4.5", small)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+}
+
+func TestReserveEmbeddingsCost(t *testing.T) {
+	req, err := ParseEmbeddingsRequest([]byte(`{"model":"text-embedding-3-large","input":["hello world","second input"]}`))
+	require.NoError(t, err)
+	require.Equal(t, estimateTokensForText("hello world")+estimateTokensForText("second input"), estimateEmbeddingsTokens(req))
+	tokens, err := ParseEmbeddingsRequest([]byte(`{"model":"text-embedding-3-large","input":[[1,2,3],[4,5]]}`))
+	require.NoError(t, err)
+	require.Equal(t, 5, estimateEmbeddingsTokens(tokens))
+
+	// 严格模式：并发 embeddings 请求的预占总额不超过余额
+	big, err := ParseEmbeddingsRequest([]byte(`{"model":"text-embedding-3-large","input":[[` + strings.Repeat("1,", 99999) + `1]]}`))
+	require.NoError(t, err)
+	svc, reservations := newReservationTestService(t, &reservationBillingCacheStub{balance: 0.02}, true)
+	apiKey := &APIKey{ID: 1, User: &User{ID: 7}}
+	first, err := svc.ReserveEmbeddingsCost(context.Background(), apiKey, nil, big)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	// 无输出 token：预占金额只包含输入费用
+	require.InDelta(t, 100000*svc.billingService.fallbackPrices["text-embedding-3-large"].InputPricePerToken, first.Amount, 1e-12)
+
+	second, err := svc.ReserveEmbeddingsCost(context.Background(), apiKey, nil, big)
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+	require.Nil(t, second)
+
+	first.Release()
+	reserved, _ := reservations.GetReserved(context.Background(), billingReservationUserScope(7))
+	require.Zero(t, reserved)
+}
+
+func TestReserveRequestCost_SkippedForFreeGroupAndDisabled(t *testing.T) {
+	svc, _ := newReservationTestService(t, &reservationBillingCacheStub{balance: 0}, false)
+	groupID := int64(1)
+	apiKey := &APIKey{ID: 1, GroupID: &groupID, User: &User{ID: 7}, Group: &Group{ID: 1, RateMultiplier: 0}}
+	r, err := svc.ReserveRequestCost(context.Background(), apiKey, nil, "claude-opus-4.5", []byte(`{"max_tokens":100}`))
+	require.NoError(t, err)
+	require.Nil(t, r)
+
+	plain := NewBillingCacheService(&reservationBillingCacheStub{}, nil, nil, nil, &config.Config{})
+	t.Cleanup(plain.Stop)
+	r, err = plain.ReserveRequestCost(context.Background(), &APIKey{User: &User{ID: 7}}, nil, "claude-opus-4.5", []byte(`{}`))
+	require.NoError(t, err)
+	require.Nil(t, r)
+	require.Zero(t, plain.GetReservedSubscriptionCost(context.Background(), 7, 1))
+}
+
+func TestReserveRequestCost_SubscriptionUsesTightestWindow(t *testing.T) {
+	daily, weekly := 10.0, 5.0
+	cache := &reservationBillingCacheStub{sub: &SubscriptionCacheData{
+		Status:      SubscriptionStatusActive,
+		ExpiresAt:   time.Now().Add(time.Hour),
+		DailyUsage:  1,
+		WeeklyUsage: 4.99,
+	}}
+	svc, _ := newReservationTestService(t, cache, false)
+	groupID := int64(3)
+	group := &Group{ID: 3, SubscriptionType: SubscriptionTypeSubscription, DailyLimitUSD: &daily, WeeklyLimitUSD: &weekly}
+	apiKey := &APIKey{ID: 1, GroupID: &groupID, User: &User{ID: 7}, Group: group}
+	sub := &UserSubscription{ID: 9, UserID: 7, GroupID: 3}
+	body := []byte(`{"max_tokens":100000,"messages":[{"role":"user","content":"hi"}]}`)
+
+	first, err := svc.ReserveRequestCost(context.Background(), apiKey, sub, "claude-opus-4.5", body)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+
+	_, err = svc.ReserveRequestCost(context.Background(), apiKey, sub, "claude-opus-4.5", body)
+	require.ErrorIs(t, err, ErrWeeklyLimitExceeded)
+
+	require.InDelta(t, first.Amount, svc.GetReservedSubscriptionCost(context.Background(), 7, 3), 1e-9)
+
+	// CheckUsageLimits 计入在途预占
+	subSvc := NewSubscriptionService(nil, nil, svc)
+	sub.DailyUsageUSD = 1
+	sub.WeeklyUsageUSD = 4.99
+	require.ErrorIs(t, subSvc.CheckUsageLimits(context.Background(), sub, group, 0), ErrWeeklyLimitExceeded)
+
+	// 结算后释放预占
+	detached := first.Detach()
+	require.NotNil(t, detached)
+	first.Release()
+	require.InDelta(t, first.Amount, svc.GetReservedSubscriptionCost(context.Background(), 7, 3), 1e-9, "Release after Detach is a no-op")
+	svc.UpdateSubscriptionUsageAndSettle(7, 3, 0.01, detached)
+	require.Zero(t, svc.GetReservedSubscriptionCost(context.Background(), 7, 3))
+	require.NoError(t, subSvc.CheckUsageLimits(context.Background(), sub, group, 0))
+}
+
+func TestBillingReservation_DetachAfterReleaseReturnsNil(t *testing.T) {
+	var nilReservation *BillingReservation
+	require.Nil(t, nilReservation.Detach())
+	nilReservation.Release()
+	nilReservation.settle()
+
+	cache := newFakeBillingReservationCache()
+	_, _, _ = cache.Reserve(context.Background(), "user:1", "r1", 1, 10, false, time.Minute)
+	r := &BillingReservation{cache: cache, scope: "user:1", id: "r1", Amount: 1}
+	r.Release()
+	require.Nil(t, r.Detach())
+	r.settle()
+	require.Equal(t, 1, cache.released)
+}
@@ -6,6 +6,7 @@ import (
 
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
 )
@@ -23,17 +24,37 @@ type BillingCache interface {
 	SetSubscriptionCache(ctx context.Context, userID, groupID int64, data *SubscriptionCacheData) error
 	UpdateSubscriptionUsage(ctx context.Context, userID, groupID int64, cost float64) error
 	InvalidateSubscriptionCache(ctx context.Context, userID, groupID int64) error
+
+	// API Key spend operations
+	GetAPIKeyUsage(ctx context.Context, apiKeyID int64) (float64, error)
+	SetAPIKeyUsage(ctx context.Context, apiKeyID int64, used float64) error
+	IncrementAPIKeyUsage(ctx context.Context, apiKeyID int64, amount float64) error
+
+	// Organization balance operations
+	GetOrganizationBalance(ctx context.Context, orgID int64) (float64, error)
+	SetOrganizationBalance(ctx context.Context, orgID int64, balance float64) error
+	DeductOrganizationBalance(ctx context.Context, orgID int64, amount float64) error
+	InvalidateOrganizationBalance(ctx context.Context, orgID int64) error
 }
 
 // ModelPricing 模型价格配置（per-token价格，与LiteLLM格式一致）
 type ModelPricing struct {
-	InputPricePerToken         float64 // 每token输入价格 (USD)
-	OutputPricePerToken        float64 // 每token输出价格 (USD)
-	CacheCreationPricePerToken float64 // 缓存创建每token价格 (USD)
-	CacheReadPricePerToken     float64 // 缓存读取每token价格 (USD)
-	CacheCreation5mPrice       float64 // 5分钟缓存创建价格（每百万token）- 仅用于硬编码回退
-	CacheCreation1hPrice       float64 // 1小时缓存创建价格（每百万token）- 仅用于硬编码回退
-	SupportsCacheBreakdown     bool    // 是否支持详细的缓存分类
+	InputPricePerToken           float64 // 每token输入价格 (USD)
+	OutputPricePerToken          float64 // 每token输出价格 (USD)
+	CacheCreationPricePerToken   float64 // 5分钟缓存创建每token价格 (USD)
+	CacheCreation1hPricePerToken float64 // 1小时缓存创建每token价格 (USD)，0 表示按 5 分钟价格换算
+	CacheReadPricePerToken       float64 // 缓存读取每token价格 (USD)
+
+	// Tiers 长上下文阶梯价格（上下文超过阈值时整个请求按阶梯计价）
+	Tiers []ModelPricingTier
+
+	// 服务等级每token价格 (USD)，0 表示按配置系数换算标准价格
+	BatchInputPricePerToken     float64
+	BatchOutputPricePerToken    float64
+	PriorityInputPricePerToken  float64
+	PriorityOutputPricePerToken float64
+	FlexInputPricePerToken      float64
+	FlexOutputPricePerToken     float64
 }
 
 // UsageTokens 使用的token数量
@@ -54,12 +75,43 @@ type CostBreakdown struct {
 	CacheReadCost     float64
 	TotalCost         float64
 	ActualCost        float64 // 应用倍率后的实际费用
+
+	PricingTier string           // 长上下文阶梯：standard 或 above_<阈值>
+	ServiceTier string           // 服务等级：standard / batch / priority / flex
+	Detail      *UsageCostDetail // 计费明细（单价、缓存分档费用），图片计费时为 nil
+}
+
+// ApplyCacheServedRate 按响应缓存命中计费比例缩放各项费用，返回节省的实际费用
+func (c *CostBreakdown) ApplyCacheServedRate(rate float64) float64 {
+	if c == nil {
+		return 0
+	}
+	if rate < 0 {
+		rate = 0
+	}
+	if rate >= 1 {
+		return 0
+	}
+	saved := c.ActualCost * (1 - rate)
+	c.InputCost *= rate
+	c.OutputCost *= rate
+	c.CacheCreationCost *= rate
+	c.CacheReadCost *= rate
+	c.TotalCost *= rate
+	c.ActualCost *= rate
+	if c.Detail != nil {
+		c.Detail.CacheWrite5mCost *= rate
+		c.Detail.CacheWrite1hCost *= rate
+		c.Detail.CacheServedRate = &rate
+	}
+	return saved
 }
 
 // BillingService 计费服务
 type BillingService struct {
 	cfg            *config.Config
 	pricingService *PricingService
+	modelPrices    *ModelPriceService       // 管理员自定义价格（可选，优先于 LiteLLM）
 	fallbackPrices map[string]*ModelPricing // 硬编码回退价格
 }
 
@@ -86,16 +138,21 @@ func (s *BillingService) initFallbackPricing() {
 		OutputPricePerToken:        25e-6,   // $25 per MTok
 		CacheCreationPricePerToken: 6.25e-6, // $6.25 per MTok
 		CacheReadPricePerToken:     0.5e-6,  // $0.50 per MTok
-		SupportsCacheBreakdown:     false,
 	}
 
-	// Claude 4 Sonnet
+	// Claude 4 Sonnet（1M 上下文，超过 200K 输入按长上下文价格计）
 	s.fallbackPrices["claude-sonnet-4"] = &ModelPricing{
 		InputPricePerToken:         3e-6,    // $3 per MTok
 		OutputPricePerToken:        15e-6,   // $15 per MTok
 		CacheCreationPricePerToken: 3.75e-6, // $3.75 per MTok
 		CacheReadPricePerToken:     0.3e-6,  // $0.30 per MTok
-		SupportsCacheBreakdown:     false,
+		Tiers: []ModelPricingTier{{
+			Threshold:                  200_000,
+			InputPricePerToken:         6e-6,    // $6 per MTok
+			OutputPricePerToken:        22.5e-6, // $22.50 per MTok
+			CacheCreationPricePerToken: 7.5e-6,  // $7.50 per MTok
+			CacheReadPricePerToken:     0.6e-6,  // $0.60 per MTok
+		}},
 	}
 
 	// Claude 3.5 Sonnet
@@ -104,7 +161,6 @@ func (s *BillingService) initFallbackPricing() {
 		OutputPricePerToken:        15e-6,   // $15 per MTok
 		CacheCreationPricePerToken: 3.75e-6, // $3.75 per MTok
 		CacheReadPricePerToken:     0.3e-6,  // $0.30 per MTok
-		SupportsCacheBreakdown:     false,
 	}
 
 	// Claude 3.5 Haiku
@@ -113,7 +169,6 @@ func (s *BillingService) initFallbackPricing() {
 		OutputPricePerToken:        5e-6,    // $5 per MTok
 		CacheCreationPricePerToken: 1.25e-6, // $1.25 per MTok
 		CacheReadPricePerToken:     0.1e-6,  // $0.10 per MTok
-		SupportsCacheBreakdown:     false,
 	}
 
 	// Claude 3 Opus
@@ -122,7 +177,6 @@ func (s *BillingService) initFallbackPricing() {
 		OutputPricePerToken:        75e-6,    // $75 per MTok
 		CacheCreationPricePerToken: 18.75e-6, // $18.75 per MTok
 		CacheReadPricePerToken:     1.5e-6,   // $1.50 per MTok
-		SupportsCacheBreakdown:     false,
 	}
 
 	// Claude 3 Haiku
@@ -131,7 +185,17 @@ func (s *BillingService) initFallbackPricing() {
 		OutputPricePerToken:        1.25e-6, // $1.25 per MTok
 		CacheCreationPricePerToken: 0.3e-6,  // $0.30 per MTok
 		CacheReadPricePerToken:     0.03e-6, // $0.03 per MTok
-		SupportsCacheBreakdown:     false,
+	}
+
+	// Embeddings（仅按输入计费）
+	s.fallbackPrices["text-embedding-3-small"] = &ModelPricing{
+		InputPricePerToken: 0.02e-6, // $0.02 per MTok
+	}
+	s.fallbackPrices["text-embedding-3-large"] = &ModelPricing{
+		InputPricePerToken: 0.13e-6, // $0.13 per MTok
+	}
+	s.fallbackPrices["gemini-embedding"] = &ModelPricing{
+		InputPricePerToken: 0.15e-6, // $0.15 per MTok
 	}
 }
 
@@ -139,6 +203,18 @@ func (s *BillingService) initFallbackPricing() {
 func (s *BillingService) getFallbackPricing(model string) *ModelPricing {
 	modelLower := strings.ToLower(model)
 
+	// Embedding 模型不能回退到对话模型价格
+	if strings.Contains(modelLower, "embedding") {
+		switch {
+		case strings.Contains(modelLower, "gemini"):
+			return s.fallbackPrices["gemini-embedding"]
+		case strings.Contains(modelLower, "large"):
+			return s.fallbackPrices["text-embedding-3-large"]
+		default:
+			return s.fallbackPrices["text-embedding-3-small"]
+		}
+	}
+
 	// 按模型系列匹配
 	if strings.Contains(modelLower, "opus") {
 		if strings.Contains(modelLower, "4.5") || strings.Contains(modelLower, "4-5") {
@@ -163,61 +239,108 @@ func (s *BillingService) getFallbackPricing(model string) *ModelPricing {
 	return s.fallbackPrices["claude-sonnet-4"]
 }
 
+// ResolvedModelPricing 模型在指定分组、指定时刻实际使用的价格及来源
+type ResolvedModelPricing struct {
+	Pricing *ModelPricing
+	// Source 价格来源：group_override / custom / litellm / fallback
+	Source string
+	// CustomPrice 来源为自定义价格时对应的价格记录
+	CustomPrice *ModelPrice
+}
+
 // GetModelPricing 获取模型价格配置
 func (s *BillingService) GetModelPricing(model string) (*ModelPricing, error) {
+	resolved, err := s.ResolveModelPricing(model, nil, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return resolved.Pricing, nil
+}
+
+// ResolveModelPricing 解析模型价格：管理员自定义价格（分组覆盖 > 全局）> LiteLLM 动态价格 > 硬编码回退价格
+func (s *BillingService) ResolveModelPricing(model string, groupID *int64, at time.Time) (*ResolvedModelPricing, error) {
 	// 标准化模型名称（转小写）
 	model = strings.ToLower(model)
 
-	// 1. 优先从动态价格服务获取
+	// 1. 管理员自定义价格（按生效时间取价）
+	if custom, source := s.modelPrices.Lookup(model, groupID, at); custom != nil {
+		return &ResolvedModelPricing{Pricing: custom.toModelPricing(), Source: source, CustomPrice: custom}, nil
+	}
+
+	// 2. 从动态价格服务获取
 	if s.pricingService != nil {
 		litellmPricing := s.pricingService.GetModelPricing(model)
 		if litellmPricing != nil {
-			return &ModelPricing{
-				InputPricePerToken:         litellmPricing.InputCostPerToken,
-				OutputPricePerToken:        litellmPricing.OutputCostPerToken,
-				CacheCreationPricePerToken: litellmPricing.CacheCreationInputTokenCost,
-				CacheReadPricePerToken:     litellmPricing.CacheReadInputTokenCost,
-				SupportsCacheBreakdown:     false,
+			return &ResolvedModelPricing{
+				Pricing: modelPricingFromLiteLLM(litellmPricing),
+				Source:  ModelPriceSourceLiteLLM,
 			}, nil
 		}
 	}
 
-	// 2. 使用硬编码回退价格
+	// 3. 使用硬编码回退价格
 	fallback := s.getFallbackPricing(model)
 	if fallback != nil {
 		log.Printf("[Billing] Using fallback pricing for model: %s", model)
-		return fallback, nil
+		return &ResolvedModelPricing{Pricing: fallback, Source: ModelPriceSourceFallback}, nil
 	}
 
 	return nil, fmt.Errorf("pricing not found for model: %s", model)
 }
 
-// CalculateCost 计算使用费用
+// CalculateCost 计算使用费用（不考虑分组覆盖价格）
 func (s *BillingService) CalculateCost(model string, tokens UsageTokens, rateMultiplier float64) (*CostBreakdown, error) {
-	pricing, err := s.GetModelPricing(model)
+	return s.CalculateCostForGroup(model, nil, tokens, rateMultiplier)
+}
+
+// CalculateCostForGroup 按分组计算使用费用，分组配置了自定义覆盖价格时优先使用
+func (s *BillingService) CalculateCostForGroup(model string, groupID *int64, tokens UsageTokens, rateMultiplier float64) (*CostBreakdown, error) {
+	return s.CalculateCostWithOptions(model, groupID, tokens, rateMultiplier, CostOptions{})
+}
+
+// CalculateCostWithOptions 按分组与服务等级计算使用费用：
+// 上下文超过阶梯阈值时整个请求按长上下文价格计，缓存创建按 5 分钟/1 小时分档计价，
+// 非标准服务等级按价格数据中的等级单价（或配置系数）换算。
+func (s *BillingService) CalculateCostWithOptions(model string, groupID *int64, tokens UsageTokens, rateMultiplier float64, opts CostOptions) (*CostBreakdown, error) {
+	at := opts.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	resolved, err := s.ResolveModelPricing(model, groupID, at)
 	if err != nil {
 		return nil, err
 	}
-
-	breakdown := &CostBreakdown{}
-
-	// 计算输入token费用（使用per-token价格）
-	breakdown.InputCost = float64(tokens.InputTokens) * pricing.InputPricePerToken
-
-	// 计算输出token费用
-	breakdown.OutputCost = float64(tokens.OutputTokens) * pricing.OutputPricePerToken
-
-	// 计算缓存费用
-	if pricing.SupportsCacheBreakdown && (pricing.CacheCreation5mPrice > 0 || pricing.CacheCreation1hPrice > 0) {
-		// 支持详细缓存分类的模型（5分钟/1小时缓存）
-		breakdown.CacheCreationCost = float64(tokens.CacheCreation5mTokens)/1_000_000*pricing.CacheCreation5mPrice +
-			float64(tokens.CacheCreation1hTokens)/1_000_000*pricing.CacheCreation1hPrice
-	} else {
-		// 标准缓存创建价格（per-token）
-		breakdown.CacheCreationCost = float64(tokens.CacheCreationTokens) * pricing.CacheCreationPricePerToken
+	pricing := resolved.Pricing
+
+	// 长上下文阶梯按整个请求的上下文长度（输入 + 缓存创建 + 缓存读取）判定
+	cache5m, cache1h := tokens.cacheCreationSplit()
+	contextTokens := tokens.InputTokens + cache5m + cache1h + tokens.CacheReadTokens
+	prices, threshold := pricing.unitPricesFor(contextTokens)
+	serviceTier := NormalizeServiceTier(opts.ServiceTier)
+	inputFactor, outputFactor := s.serviceTierFactors(pricing, serviceTier)
+	prices.scale(inputFactor, outputFactor)
+
+	breakdown := &CostBreakdown{
+		PricingTier: pricingTierLabel(threshold),
+		ServiceTier: serviceTier,
+		Detail: &UsageCostDetail{
+			PriceSource:       resolved.Source,
+			ContextTokens:     contextTokens,
+			TierThreshold:     threshold,
+			InputPrice:        prices.Input,
+			OutputPrice:       prices.Output,
+			CacheWrite5mPrice: prices.CacheWrite5m,
+			CacheWrite1hPrice: prices.CacheWrite1h,
+			CacheReadPrice:    prices.CacheRead,
+			CacheWrite5mCost:  float64(cache5m) * prices.CacheWrite5m,
+			CacheWrite1hCost:  float64(cache1h) * prices.CacheWrite1h,
+		},
 	}
 
-	breakdown.CacheReadCost = float64(tokens.CacheReadTokens) * pricing.CacheReadPricePerToken
+	breakdown.InputCost = float64(tokens.InputTokens) * prices.Input
+	breakdown.OutputCost = float64(tokens.OutputTokens) * prices.Output
+	breakdown.CacheCreationCost = breakdown.Detail.CacheWrite5mCost + breakdown.Detail.CacheWrite1hCost
+	breakdown.CacheReadCost = float64(tokens.CacheReadTokens) * prices.CacheRead
 
 	// 计算总费用
 	breakdown.TotalCost = breakdown.InputCost + breakdown.OutputCost +
@@ -251,6 +374,29 @@ func (s *BillingService) ListSupportedModels() []string {
 	return models
 }
 
+// MetricsModelLabel 返回模型在 Prometheus 指标中的标签：价格表（自定义价格、LiteLLM、回退价格）中
+// 精确存在的模型使用价格表中的名称，其余归入 "other"，避免客户端传入任意模型名导致时间序列基数膨胀。
+func (s *BillingService) MetricsModelLabel(model string) string {
+	if strings.TrimSpace(model) == "" {
+		return ""
+	}
+	if s == nil {
+		return MetricsModelOther
+	}
+	if name := s.modelPrices.CanonicalModel(model); name != "" {
+		return name
+	}
+	if s.pricingService != nil {
+		if name := s.pricingService.CanonicalModel(model); name != "" {
+			return name
+		}
+	}
+	if lower := strings.ToLower(model); s.fallbackPrices[lower] != nil {
+		return lower
+	}
+	return MetricsModelOther
+}
+
 // IsModelSupported 检查模型是否支持（现在总是返回true，因为有模糊匹配回退）
 func (s *BillingService) IsModelSupported(model string) bool {
 	// 所有Claude模型都有回退价格支持
@@ -261,19 +407,17 @@ func (s *BillingService) IsModelSupported(model string) bool {
 		strings.Contains(modelLower, "haiku")
 }
 
-// GetEstimatedCost 估算费用（用于前端展示）
-func (s *BillingService) GetEstimatedCost(model string, estimatedInputTokens, estimatedOutputTokens int) (float64, error) {
+// GetEstimatedCost 按预估 token 数估算费用（用于前端展示与请求前费用预占）
+// rateMultiplier <= 0 时使用配置中的默认倍率
+func (s *BillingService) GetEstimatedCost(model string, groupID *int64, estimatedInputTokens, estimatedOutputTokens int, rateMultiplier float64) (*CostBreakdown, error) {
 	tokens := UsageTokens{
 		InputTokens:  estimatedInputTokens,
 		OutputTokens: estimatedOutputTokens,
 	}
-
-	breakdown, err := s.CalculateCostWithConfig(model, tokens)
-	if err != nil {
-		return 0, err
+	if rateMultiplier <= 0 {
+		rateMultiplier = s.cfg.Default.RateMultiplier
 	}
-
-	return breakdown.ActualCost, nil
+	return s.CalculateCostForGroup(model, groupID, tokens, rateMultiplier)
 }
 
 // GetPricingServiceStatus 获取价格服务状态
@@ -310,12 +454,18 @@ type ImagePriceConfig struct {
 // groupConfig: 分组配置的价格（可能为 nil，表示使用默认值）
 // rateMultiplier: 费率倍数
 func (s *BillingService) CalculateImageCost(model string, imageSize string, imageCount int, groupConfig *ImagePriceConfig, rateMultiplier float64) *CostBreakdown {
+	return s.CalculateImageCostForGroup(model, nil, imageSize, imageCount, groupConfig, rateMultiplier)
+}
+
+// CalculateImageCostForGroup 按分组计算图片生成费用，单价优先级：
+// 分组尺寸价格 > 自定义模型价格（分组覆盖 > 全局）> LiteLLM 默认价格
+func (s *BillingService) CalculateImageCostForGroup(model string, groupID *int64, imageSize string, imageCount int, groupConfig *ImagePriceConfig, rateMultiplier float64) *CostBreakdown {
 	if imageCount <= 0 {
 		return &CostBreakdown{}
 	}
 
 	// 获取单价
-	unitPrice := s.getImageUnitPrice(model, imageSize, groupConfig)
+	unitPrice := s.getImageUnitPriceForGroup(model, groupID, imageSize, groupConfig)
 
 	// 计算总费用
 	totalCost := unitPrice * float64(imageCount)
@@ -327,13 +477,19 @@ func (s *BillingService) CalculateImageCost(model string, imageSize string, imag
 	actualCost := totalCost * rateMultiplier
 
 	return &CostBreakdown{
-		TotalCost:  totalCost,
-		ActualCost: actualCost,
+		TotalCost:   totalCost,
+		ActualCost:  actualCost,
+		PricingTier: PricingTierStandard,
+		ServiceTier: ServiceTierStandard,
 	}
 }
 
 // getImageUnitPrice 获取图片单价
 func (s *BillingService) getImageUnitPrice(model string, imageSize string, groupConfig *ImagePriceConfig) float64 {
+	return s.getImageUnitPriceForGroup(model, nil, imageSize, groupConfig)
+}
+
+func (s *BillingService) getImageUnitPriceForGroup(model string, groupID *int64, imageSize string, groupConfig *ImagePriceConfig) float64 {
 	// 优先使用分组配置的价格
 	if groupConfig != nil {
 		switch imageSize {
@@ -352,6 +508,14 @@ func (s *BillingService) getImageUnitPrice(model string, imageSize string, group
 		}
 	}
 
+	// 管理员自定义的模型图片价格（4K 尺寸与默认价格一致按两倍计）
+	if custom, _ := s.modelPrices.Lookup(model, groupID, time.Now()); custom != nil && custom.ImagePrice != nil {
+		if imageSize == "4K" {
+			return *custom.ImagePrice * 2
+		}
+		return *custom.ImagePrice
+	}
+
 	// 回退到 LiteLLM 默认价格
 	return s.getDefaultImagePrice(model, imageSize)
 }
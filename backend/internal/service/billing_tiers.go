@@ -0,0 +1,246 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 服务等级（上游响应 usage.service_tier / service_tier，批处理由调用方指定）
+const (
+	ServiceTierStandard = "standard"
+	ServiceTierBatch    = "batch"
+	ServiceTierPriority = "priority"
+	ServiceTierFlex     = "flex"
+)
+
+// PricingTierStandard 未命中长上下文阶梯
+const PricingTierStandard = "standard"
+
+// cacheWrite1hTo5mRatio 价格数据未提供 1 小时缓存创建价格时相对 5 分钟价格的倍数
+// （Anthropic：5 分钟为输入价格的 1.25 倍，1 小时为 2 倍）
+const cacheWrite1hTo5mRatio = 1.6
+
+// NormalizeServiceTier 归一化服务等级，OpenAI default/auto/scale 与 Anthropic standard 均视为标准等级
+func NormalizeServiceTier(tier string) string {
+	switch strings.ToLower(strings.TrimSpace(tier)) {
+	case ServiceTierBatch:
+		return ServiceTierBatch
+	case ServiceTierPriority:
+		return ServiceTierPriority
+	case ServiceTierFlex:
+		return ServiceTierFlex
+	default:
+		return ServiceTierStandard
+	}
+}
+
+// ModelPricingTier 长上下文阶梯价格：请求上下文（输入+缓存创建+缓存读取 token）超过 Threshold 时，
+// 整个请求按该阶梯计价。缓存价格为 0 时按输入价格的涨幅换算基础缓存价格，输出价格为 0 时沿用基础价格。
+type ModelPricingTier struct {
+	Threshold                    int
+	InputPricePerToken           float64
+	OutputPricePerToken          float64
+	CacheCreationPricePerToken   float64
+	CacheCreation1hPricePerToken float64
+	CacheReadPricePerToken       float64
+}
+
+// CostOptions 计费可选参数
+type CostOptions struct {
+	// ServiceTier 服务等级，空表示标准等级
+	ServiceTier string
+	// At 计价时刻（选择已生效的自定义价格），零值表示当前时间
+	At time.Time
+}
+
+// appliedUnitPrices 实际计费使用的每 token 单价
+type appliedUnitPrices struct {
+	Input        float64
+	Output       float64
+	CacheWrite5m float64
+	CacheWrite1h float64
+	CacheRead    float64
+}
+
+func (u *appliedUnitPrices) scale(inputFactor, outputFactor float64) {
+	u.Input *= inputFactor
+	u.CacheWrite5m *= inputFactor
+	u.CacheWrite1h *= inputFactor
+	u.CacheRead *= inputFactor
+	u.Output *= outputFactor
+}
+
+// cacheWrite1hPrice 1 小时缓存创建单价，未配置时按 5 分钟单价换算
+func cacheWrite1hPrice(price1h, price5m float64) float64 {
+	if price1h > 0 {
+		return price1h
+	}
+	return price5m * cacheWrite1hTo5mRatio
+}
+
+// CacheWrite1hPrice 1 小时缓存创建单价（未配置时为换算值）
+func (p *ModelPricing) CacheWrite1hPrice() float64 {
+	return cacheWrite1hPrice(p.CacheCreation1hPricePerToken, p.CacheCreationPricePerToken)
+}
+
+// unitPricesFor 返回上下文 token 数对应的单价及命中的阶梯阈值（0 表示标准价格）
+func (p *ModelPricing) unitPricesFor(contextTokens int) (appliedUnitPrices, int) {
+	base := appliedUnitPrices{
+		Input:        p.InputPricePerToken,
+		Output:       p.OutputPricePerToken,
+		CacheWrite5m: p.CacheCreationPricePerToken,
+		CacheWrite1h: p.CacheWrite1hPrice(),
+		CacheRead:    p.CacheReadPricePerToken,
+	}
+
+	var tier *ModelPricingTier
+	for i := range p.Tiers {
+		t := &p.Tiers[i]
+		if t.Threshold > 0 && contextTokens > t.Threshold && (tier == nil || t.Threshold > tier.Threshold) {
+			tier = t
+		}
+	}
+	if tier == nil {
+		return base, 0
+	}
+
+	ratio := 1.0
+	if base.Input > 0 && tier.InputPricePerToken > 0 {
+		ratio = tier.InputPricePerToken / base.Input
+	}
+	out := appliedUnitPrices{
+		Input:        tier.InputPricePerToken,
+		Output:       tier.OutputPricePerToken,
+		CacheWrite5m: tier.CacheCreationPricePerToken,
+		CacheWrite1h: tier.CacheCreation1hPricePerToken,
+		CacheRead:    tier.CacheReadPricePerToken,
+	}
+	if out.Input <= 0 {
+		out.Input = base.Input
+	}
+	if out.Output <= 0 {
+		out.Output = base.Output
+	}
+	if out.CacheWrite5m <= 0 {
+		out.CacheWrite5m = base.CacheWrite5m * ratio
+	}
+	if out.CacheWrite1h <= 0 {
+		if tier.CacheCreationPricePerToken > 0 {
+			out.CacheWrite1h = tier.CacheCreationPricePerToken * cacheWrite1hTo5mRatio
+		} else {
+			out.CacheWrite1h = base.CacheWrite1h * ratio
+		}
+	}
+	if out.CacheRead <= 0 {
+		out.CacheRead = base.CacheRead * ratio
+	}
+	return out, tier.Threshold
+}
+
+// pricingTierLabel 阶梯标签：standard 或 above_200k 等
+func pricingTierLabel(threshold int) string {
+	if threshold <= 0 {
+		return PricingTierStandard
+	}
+	if threshold%1000 == 0 {
+		return "above_" + strconv.Itoa(threshold/1000) + "k"
+	}
+	return "above_" + strconv.Itoa(threshold)
+}
+
+// cacheCreationSplit 拆分 5 分钟/1 小时缓存创建 token（上游未返回分档时全部按 5 分钟计）
+func (t UsageTokens) cacheCreationSplit() (cache5m, cache1h int) {
+	cache1h = t.CacheCreation1hTokens
+	if cache1h < 0 {
+		cache1h = 0
+	}
+	total := t.CacheCreationTokens
+	if sum := t.CacheCreation5mTokens + cache1h; sum > total {
+		total = sum
+	}
+	if cache1h > total {
+		cache1h = total
+	}
+	return total - cache1h, cache1h
+}
+
+// serviceTierFactors 服务等级相对标准价格的输入/输出系数：
+// 价格数据提供了等级单价时按单价换算，否则使用配置系数（缓存价格随输入系数缩放）
+func (s *BillingService) serviceTierFactors(p *ModelPricing, tier string) (inputFactor, outputFactor float64) {
+	var explicitInput, explicitOutput, fallback float64
+	switch tier {
+	case ServiceTierBatch:
+		explicitInput, explicitOutput, fallback = p.BatchInputPricePerToken, p.BatchOutputPricePerToken, s.batchDiscount()
+	case ServiceTierPriority:
+		explicitInput, explicitOutput, fallback = p.PriorityInputPricePerToken, p.PriorityOutputPricePerToken, s.priorityMultiplier()
+	case ServiceTierFlex:
+		explicitInput, explicitOutput, fallback = p.FlexInputPricePerToken, p.FlexOutputPricePerToken, s.flexDiscount()
+	default:
+		return 1, 1
+	}
+	inputFactor, outputFactor = fallback, fallback
+	if explicitInput > 0 && p.InputPricePerToken > 0 {
+		inputFactor = explicitInput / p.InputPricePerToken
+	}
+	if explicitOutput > 0 && p.OutputPricePerToken > 0 {
+		outputFactor = explicitOutput / p.OutputPricePerToken
+	}
+	return inputFactor, outputFactor
+}
+
+// batchDiscount 批处理价格系数（沿用 message_batch.discount）
+func (s *BillingService) batchDiscount() float64 {
+	if s.cfg != nil && s.cfg.MessageBatch.Discount > 0 {
+		return s.cfg.MessageBatch.Discount
+	}
+	return 0.5
+}
+
+func (s *BillingService) priorityMultiplier() float64 {
+	if s.cfg != nil && s.cfg.Billing.ServiceTiers.PriorityMultiplier > 0 {
+		return s.cfg.Billing.ServiceTiers.PriorityMultiplier
+	}
+	return 1
+}
+
+func (s *BillingService) flexDiscount() float64 {
+	if s.cfg != nil && s.cfg.Billing.ServiceTiers.FlexDiscount > 0 {
+		return s.cfg.Billing.ServiceTiers.FlexDiscount
+	}
+	return 0.5
+}
+
+// modelPricingFromLiteLLM 转换 LiteLLM 价格（含缓存分档、长上下文阶梯与服务等级价格）
+func modelPricingFromLiteLLM(l *LiteLLMModelPricing) *ModelPricing {
+	p := &ModelPricing{
+		InputPricePerToken:           l.InputCostPerToken,
+		OutputPricePerToken:          l.OutputCostPerToken,
+		CacheCreationPricePerToken:   l.CacheCreationInputTokenCost,
+		CacheCreation1hPricePerToken: l.CacheCreationInputTokenCostAbove1hr,
+		CacheReadPricePerToken:       l.CacheReadInputTokenCost,
+		BatchInputPricePerToken:      l.InputCostPerTokenBatches,
+		BatchOutputPricePerToken:     l.OutputCostPerTokenBatches,
+		PriorityInputPricePerToken:   l.InputCostPerTokenPriority,
+		PriorityOutputPricePerToken:  l.OutputCostPerTokenPriority,
+		FlexInputPricePerToken:       l.InputCostPerTokenFlex,
+		FlexOutputPricePerToken:      l.OutputCostPerTokenFlex,
+	}
+	if l.InputCostPerTokenAbove128k > 0 || l.OutputCostPerTokenAbove128k > 0 {
+		p.Tiers = append(p.Tiers, ModelPricingTier{
+			Threshold:           128_000,
+			InputPricePerToken:  l.InputCostPerTokenAbove128k,
+			OutputPricePerToken: l.OutputCostPerTokenAbove128k,
+		})
+	}
+	if l.InputCostPerTokenAbove200k > 0 || l.OutputCostPerTokenAbove200k > 0 {
+		p.Tiers = append(p.Tiers, ModelPricingTier{
+			Threshold:                  200_000,
+			InputPricePerToken:         l.InputCostPerTokenAbove200k,
+			OutputPricePerToken:        l.OutputCostPerTokenAbove200k,
+			CacheCreationPricePerToken: l.CacheCreationInputTokenCostAbove200k,
+			CacheReadPricePerToken:     l.CacheReadInputTokenCostAbove200k,
+		})
+	}
+	return p
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateCost_LongContextTier(t *testing.T) {
+	svc := NewBillingService(&config.Config{}, nil)
+
+	cost, err := svc.CalculateCost("claude-sonnet-4", UsageTokens{InputTokens: 100_000, OutputTokens: 1000}, 1)
+	require.NoError(t, err)
+	require.Equal(t, PricingTierStandard, cost.PricingTier)
+	require.InDelta(t, 100_000*3e-6+1000*15e-6, cost.TotalCost, 1e-12)
+
+	// 上下文按输入+缓存创建+缓存读取判定，超过 200K 后整个请求按长上下文价格计费
+	cost, err = svc.CalculateCost("claude-sonnet-4", UsageTokens{InputTokens: 150_000, CacheReadTokens: 60_000, OutputTokens: 1000}, 2)
+	require.NoError(t, err)
+	require.Equal(t, "above_200k", cost.PricingTier)
+	require.Equal(t, 210_000, cost.Detail.ContextTokens)
+	require.Equal(t, 200_000, cost.Detail.TierThreshold)
+	require.InDelta(t, 150_000*6e-6, cost.InputCost, 1e-12)
+	require.InDelta(t, 60_000*0.6e-6, cost.CacheReadCost, 1e-12)
+	require.InDelta(t, 1000*22.5e-6, cost.OutputCost, 1e-12)
+	require.InDelta(t, cost.TotalCost*2, cost.ActualCost, 1e-12)
+}
+
+func TestCalculateCost_CacheWrite1hPricedSeparately(t *testing.T) {
+	svc := NewBillingService(&config.Config{}, nil)
+
+	tokens := UsageTokens{CacheCreationTokens: 1000, CacheCreation5mTokens: 400, CacheCreation1hTokens: 600}
+	cost, err := svc.CalculateCost("claude-3-5-haiku", tokens, 1)
+	require.NoError(t, err)
+	// 未配置 1 小时价格时按 5 分钟价格 × 1.6 换算（即输入价格 × 2）
+	require.InDelta(t, 2e-6, cost.Detail.CacheWrite1hPrice, 1e-15)
+	require.InDelta(t, 400*1.25e-6, cost.Detail.CacheWrite5mCost, 1e-12)
+	require.InDelta(t, 600*2e-6, cost.Detail.CacheWrite1hCost, 1e-12)
+	require.InDelta(t, cost.Detail.CacheWrite5mCost+cost.Detail.CacheWrite1hCost, cost.CacheCreationCost, 1e-12)
+
+	// 上游未返回分档时全部按 5 分钟计费
+	cost, err = svc.CalculateCost("claude-3-5-haiku", UsageTokens{CacheCreationTokens: 1000}, 1)
+	require.NoError(t, err)
+	require.InDelta(t, 1000*1.25e-6, cost.CacheCreationCost, 1e-12)
+	require.Zero(t, cost.Detail.CacheWrite1hCost)
+
+	pricing := &ModelPricing{InputPricePerToken: 1e-6, CacheCreationPricePerToken: 1.25e-6, CacheCreation1hPricePerToken: 3e-6}
+	prices, _ := pricing.unitPricesFor(0)
+	require.InDelta(t, 3e-6, prices.CacheWrite1h, 1e-15, "explicit 1h price wins")
+}
+
+func TestCalculateCost_ServiceTiers(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.MessageBatch.Discount = 0.5
+	cfg.Billing.ServiceTiers.PriorityMultiplier = 1.8
+	svc := NewBillingService(cfg, nil)
+	tokens := UsageTokens{InputTokens: 1000, OutputTokens: 1000, CacheReadTokens: 1000}
+
+	standard, err := svc.CalculateCostWithOptions("claude-3-5-haiku", nil, tokens, 1, CostOptions{ServiceTier: "default"})
+	require.NoError(t, err)
+	require.Equal(t, ServiceTierStandard, standard.ServiceTier)
+
+	batch, err := svc.CalculateCostWithOptions("claude-3-5-haiku", nil, tokens, 1, CostOptions{ServiceTier: ServiceTierBatch})
+	require.NoError(t, err)
+	require.Equal(t, ServiceTierBatch, batch.ServiceTier)
+	require.InDelta(t, standard.TotalCost*0.5, batch.TotalCost, 1e-12)
+
+	priority, err := svc.CalculateCostWithOptions("claude-3-5-haiku", nil, tokens, 1, CostOptions{ServiceTier: "Priority"})
+	require.NoError(t, err)
+	require.InDelta(t, standard.TotalCost*1.8, priority.TotalCost, 1e-12)
+
+	// 价格数据提供了等级单价时优先使用（缓存价格随输入单价同比例缩放）
+	pricing := &ModelPricing{
+		InputPricePerToken:         2e-6,
+		OutputPricePerToken:        8e-6,
+		CacheReadPricePerToken:     0.5e-6,
+		FlexInputPricePerToken:     1e-6,
+		FlexOutputPricePerToken:    2e-6,
+		PriorityInputPricePerToken: 3.5e-6,
+	}
+	in, out := svc.serviceTierFactors(pricing, ServiceTierFlex)
+	require.InDelta(t, 0.5, in, 1e-12)
+	require.InDelta(t, 0.25, out, 1e-12)
+	in, out = svc.serviceTierFactors(pricing, ServiceTierPriority)
+	require.InDelta(t, 1.75, in, 1e-12)
+	require.InDelta(t, 1.8, out, 1e-12, "missing output price falls back to the configured multiplier")
+}
+
+func TestModelPricingFromLiteLLM_Tiers(t *testing.T) {
+	p := modelPricingFromLiteLLM(&LiteLLMModelPricing{
+		InputCostPerToken:                   3e-6,
+		OutputCostPerToken:                  15e-6,
+		CacheCreationInputTokenCost:         3.75e-6,
+		CacheCreationInputTokenCostAbove1hr: 6e-6,
+		CacheReadInputTokenCost:             0.3e-6,
+		InputCostPerTokenAbove200k:          6e-6,
+		OutputCostPerTokenAbove200k:         22.5e-6,
+	})
+	require.InDelta(t, 6e-6, p.CacheCreation1hPricePerToken, 1e-15)
+	require.Len(t, p.Tiers, 1)
+
+	prices, threshold := p.unitPricesFor(200_001)
+	require.Equal(t, 200_000, threshold)
+	// 阶梯未提供缓存价格时按输入价格涨幅换算
+	require.InDelta(t, 7.5e-6, prices.CacheWrite5m, 1e-15)
+	require.InDelta(t, 12e-6, prices.CacheWrite1h, 1e-15)
+	require.InDelta(t, 0.6e-6, prices.CacheRead, 1e-15)
+
+	_, threshold = p.unitPricesFor(200_000)
+	require.Zero(t, threshold)
+	require.Equal(t, "above_128k", pricingTierLabel(128_000))
+	require.Equal(t, "above_1500", pricingTierLabel(1500))
+}
@@ -58,7 +58,8 @@ const (
 
 // ConcurrencyService manages concurrent request limiting for accounts and users
 type ConcurrencyService struct {
-	cache ConcurrencyCache
+	cache     ConcurrencyCache
+	waitQueue SlotWaitQueueCache // 账号槽位公平排队，nil 表示未启用（退回轮询抢占）
 }
 
 // NewConcurrencyService creates a new ConcurrencyService
@@ -105,15 +106,10 @@ func (s *ConcurrencyService) AcquireAccountSlot(ctx context.Context, accountID i
 	}
 
 	if acquired {
+		concurrencySlotsInUse.WithLabelValues(metricsScopeAccount).Inc()
 		return &AcquireResult{
-			Acquired: true,
-			ReleaseFunc: func() {
-				bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-				defer cancel()
-				if err := s.cache.ReleaseAccountSlot(bgCtx, accountID, requestID); err != nil {
-					log.Printf("Warning: failed to release account slot for %d (req=%s): %v", accountID, requestID, err)
-				}
-			},
+			Acquired:    true,
+			ReleaseFunc: s.accountSlotReleaseFunc(accountID, requestID),
 		}, nil
 	}
 
@@ -123,6 +119,17 @@ func (s *ConcurrencyService) AcquireAccountSlot(ctx context.Context, accountID i
 	}, nil
 }
 
+func (s *ConcurrencyService) accountSlotReleaseFunc(accountID int64, requestID string) func() {
+	return func() {
+		concurrencySlotsInUse.WithLabelValues(metricsScopeAccount).Dec()
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.cache.ReleaseAccountSlot(bgCtx, accountID, requestID); err != nil {
+			log.Printf("Warning: failed to release account slot for %d (req=%s): %v", accountID, requestID, err)
+		}
+	}
+}
+
 // AcquireUserSlot attempts to acquire a concurrency slot for a user.
 // If the user is at max concurrency, it waits until a slot is available or timeout.
 // Returns a release function that MUST be called when the request completes.
@@ -144,9 +151,11 @@ func (s *ConcurrencyService) AcquireUserSlot(ctx context.Context, userID int64,
 	}
 
 	if acquired {
+		concurrencySlotsInUse.WithLabelValues(metricsScopeUser).Inc()
 		return &AcquireResult{
 			Acquired: true,
 			ReleaseFunc: func() {
+				concurrencySlotsInUse.WithLabelValues(metricsScopeUser).Dec()
 				bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
 				if err := s.cache.ReleaseUserSlot(bgCtx, userID, requestID); err != nil {
@@ -172,6 +181,7 @@ func (s *ConcurrencyService) AcquireUserSlot(ctx context.Context, userID int64,
 func (s *ConcurrencyService) IncrementWaitCount(ctx context.Context, userID int64, maxWait int) (bool, error) {
 	if s.cache == nil {
 		// Redis not available, allow request
+		concurrencyWaitQueueDepth.WithLabelValues(metricsScopeUser).Inc()
 		return true, nil
 	}
 
@@ -179,7 +189,10 @@ func (s *ConcurrencyService) IncrementWaitCount(ctx context.Context, userID int6
 	if err != nil {
 		// On error, allow the request to proceed (fail open)
 		log.Printf("Warning: increment wait count failed for user %d: %v", userID, err)
-		return true, nil
+		result = true
+	}
+	if result {
+		concurrencyWaitQueueDepth.WithLabelValues(metricsScopeUser).Inc()
 	}
 	return result, nil
 }
@@ -187,6 +200,7 @@ func (s *ConcurrencyService) IncrementWaitCount(ctx context.Context, userID int6
 // DecrementWaitCount decrements the wait queue counter for a user.
 // Should be called when a request completes or exits the wait queue.
 func (s *ConcurrencyService) DecrementWaitCount(ctx context.Context, userID int64) {
+	concurrencyWaitQueueDepth.WithLabelValues(metricsScopeUser).Dec()
 	if s.cache == nil {
 		return
 	}
@@ -203,19 +217,24 @@ func (s *ConcurrencyService) DecrementWaitCount(ctx context.Context, userID int6
 // IncrementAccountWaitCount increments the wait queue counter for an account.
 func (s *ConcurrencyService) IncrementAccountWaitCount(ctx context.Context, accountID int64, maxWait int) (bool, error) {
 	if s.cache == nil {
+		concurrencyWaitQueueDepth.WithLabelValues(metricsScopeAccount).Inc()
 		return true, nil
 	}
 
 	result, err := s.cache.IncrementAccountWaitCount(ctx, accountID, maxWait)
 	if err != nil {
 		log.Printf("Warning: increment wait count failed for account %d: %v", accountID, err)
-		return true, nil
+		result = true
+	}
+	if result {
+		concurrencyWaitQueueDepth.WithLabelValues(metricsScopeAccount).Inc()
 	}
 	return result, nil
 }
 
 // DecrementAccountWaitCount decrements the wait queue counter for an account.
 func (s *ConcurrencyService) DecrementAccountWaitCount(ctx context.Context, accountID int64) {
+	concurrencyWaitQueueDepth.WithLabelValues(metricsScopeAccount).Dec()
 	if s.cache == nil {
 		return
 	}
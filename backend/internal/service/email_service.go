@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"encoding/hex"
 	"fmt"
+	"html"
 	"log"
 	"math/big"
 	"net/smtp"
@@ -539,3 +540,111 @@ func (s *EmailService) buildPasswordResetEmailBody(resetURL, siteName string) st
 </html>
 `, siteName, resetURL, resetURL)
 }
+
+// SendOrganizationInvitationEmail 发送组织邀请邮件
+func (s *EmailService) SendOrganizationInvitationEmail(ctx context.Context, email, siteName, orgName, inviteURL string) error {
+	subject := fmt.Sprintf("[%s] 组织邀请：%s", siteName, orgName)
+	body := s.buildOrganizationInvitationEmailBody(inviteURL, siteName, orgName)
+	if err := s.SendEmail(ctx, email, subject, body); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+func (s *EmailService) buildOrganizationInvitationEmailBody(inviteURL, siteName, orgName string) string {
+	siteName = html.EscapeString(siteName)
+	orgName = html.EscapeString(orgName)
+	inviteURL = html.EscapeString(inviteURL)
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, sans-serif; background-color: #f5f5f5; margin: 0; padding: 20px; }
+        .container { max-width: 600px; margin: 0 auto; background-color: #ffffff; border-radius: 8px; overflow: hidden; box-shadow: 0 2px 8px rgba(0,0,0,0.1); }
+        .header { background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); color: white; padding: 30px; text-align: center; }
+        .header h1 { margin: 0; font-size: 24px; }
+        .content { padding: 40px 30px; text-align: center; }
+        .button { display: inline-block; background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); color: white; padding: 14px 32px; text-decoration: none; border-radius: 8px; font-size: 16px; font-weight: 600; margin: 20px 0; }
+        .info { color: #666; font-size: 14px; line-height: 1.6; margin-top: 20px; }
+        .link-fallback { color: #666; font-size: 12px; word-break: break-all; margin-top: 20px; padding: 15px; background-color: #f8f9fa; border-radius: 4px; }
+        .footer { background-color: #f8f9fa; padding: 20px; text-align: center; color: #999; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p style="font-size: 18px; color: #333;">组织邀请</p>
+            <p style="color: #666;">您被邀请加入组织 <strong>%s</strong>。请登录后点击下方按钮接受邀请：</p>
+            <a href="%s" class="button">接受邀请</a>
+            <div class="info">
+                <p>此链接将在 <strong>7 天</strong>后失效，且只能由受邀邮箱对应的账号接受。</p>
+                <p>如果您不认识该组织，请忽略此邮件。</p>
+            </div>
+            <div class="link-fallback">
+                <p>如果按钮无法点击，请复制以下链接到浏览器中打开：</p>
+                <p>%s</p>
+            </div>
+        </div>
+        <div class="footer">
+            <p>这是一封自动发送的邮件，请勿回复。</p>
+        </div>
+    </div>
+</body>
+</html>
+`, siteName, orgName, inviteURL, inviteURL)
+}
+
+// SendBalanceNotificationEmail 发送余额通知邮件（低余额提醒、自动充值结果）
+func (s *EmailService) SendBalanceNotificationEmail(ctx context.Context, email, siteName, title, message string) error {
+	subject := fmt.Sprintf("[%s] %s", siteName, title)
+	body := s.buildBalanceNotificationEmailBody(siteName, title, message)
+	if err := s.SendEmail(ctx, email, subject, body); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+func (s *EmailService) buildBalanceNotificationEmailBody(siteName, title, message string) string {
+	siteName = html.EscapeString(siteName)
+	title = html.EscapeString(title)
+	message = html.EscapeString(message)
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, sans-serif; background-color: #f5f5f5; margin: 0; padding: 20px; }
+        .container { max-width: 600px; margin: 0 auto; background-color: #ffffff; border-radius: 8px; overflow: hidden; box-shadow: 0 2px 8px rgba(0,0,0,0.1); }
+        .header { background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); color: white; padding: 30px; text-align: center; }
+        .header h1 { margin: 0; font-size: 24px; }
+        .content { padding: 40px 30px; text-align: center; }
+        .info { color: #666; font-size: 14px; line-height: 1.6; margin-top: 20px; }
+        .footer { background-color: #f8f9fa; padding: 20px; text-align: center; color: #999; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p style="font-size: 18px; color: #333;">%s</p>
+            <p style="color: #666;">%s</p>
+            <div class="info">
+                <p>您可以在个人设置的“余额提醒”中调整提醒阈值与自动充值规则。</p>
+            </div>
+        </div>
+        <div class="footer">
+            <p>这是一封自动发送的邮件，请勿回复。</p>
+        </div>
+    </div>
+</body>
+</html>
+`, siteName, title, message)
+}
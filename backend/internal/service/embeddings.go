@@ -0,0 +1,451 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/geminicli"
+	"github.com/Wei-Shaw/sub2api/internal/util/responseheaders"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAI Platform Embeddings API（API Key 账号未配置 base_url 时使用）
+const openaiEmbeddingsAPIURL = "https://api.openai.com/v1/embeddings"
+
+// EmbeddingsRequest 保存 /v1/embeddings 请求的预解析结果
+type EmbeddingsRequest struct {
+	Body           []byte
+	Model          string
+	Inputs         []string // 文本输入（token 数组输入时为空）
+	TokenInput     bool     // input 为 token id 数组（仅 OpenAI 上游支持）
+	Dimensions     int
+	EncodingFormat string
+}
+
+// ParseEmbeddingsRequest 解析 OpenAI Embeddings 请求体
+func ParseEmbeddingsRequest(body []byte) (*EmbeddingsRequest, error) {
+	var raw struct {
+		Model          string          `json:"model"`
+		Input          json.RawMessage `json:"input"`
+		Dimensions     int             `json:"dimensions"`
+		EncodingFormat string          `json:"encoding_format"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	req := &EmbeddingsRequest{
+		Body:           body,
+		Model:          strings.TrimSpace(raw.Model),
+		Dimensions:     raw.Dimensions,
+		EncodingFormat: raw.EncodingFormat,
+	}
+	switch req.EncodingFormat {
+	case "", "float", "base64":
+	default:
+		return nil, fmt.Errorf("unsupported encoding_format: %s", req.EncodingFormat)
+	}
+
+	input := bytes.TrimSpace(raw.Input)
+	if len(input) == 0 || bytes.Equal(input, []byte("null")) {
+		return nil, errors.New("input is required")
+	}
+
+	// input 支持: string | []string | []int | [][]int
+	var single string
+	if err := json.Unmarshal(input, &single); err == nil {
+		req.Inputs = []string{single}
+		return req, nil
+	}
+	var list []string
+	if err := json.Unmarshal(input, &list); err == nil {
+		if len(list) == 0 {
+			return nil, errors.New("input must not be empty")
+		}
+		req.Inputs = list
+		return req, nil
+	}
+	var tokens []int
+	if err := json.Unmarshal(input, &tokens); err == nil && len(tokens) > 0 {
+		req.TokenInput = true
+		return req, nil
+	}
+	var tokenLists [][]int
+	if err := json.Unmarshal(input, &tokenLists); err == nil && len(tokenLists) > 0 {
+		req.TokenInput = true
+		return req, nil
+	}
+	return nil, errors.New("invalid input field type")
+}
+
+// ForwardEmbeddings 将 Embeddings 请求转发到 OpenAI API Key 账号
+func (s *OpenAIGatewayService) ForwardEmbeddings(ctx context.Context, c *gin.Context, account *Account, req *EmbeddingsRequest) (*OpenAIForwardResult, error) {
+	startTime := time.Now()
+
+	body := req.Body
+	originalModel := req.Model
+	mappedModel := account.GetMappedModel(originalModel)
+	if mappedModel != originalModel {
+		log.Printf("[OpenAI] Embeddings model mapping applied: %s -> %s (account: %s)", originalModel, mappedModel, account.Name)
+		var reqBody map[string]any
+		if err := json.Unmarshal(body, &reqBody); err != nil {
+			return nil, fmt.Errorf("parse request: %w", err)
+		}
+		reqBody["model"] = mappedModel
+		var err error
+		body, err = json.Marshal(reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("serialize request body: %w", err)
+		}
+	}
+
+	token, _, err := s.GetAccessToken(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	targetURL := openaiEmbeddingsAPIURL
+	if baseURL := strings.TrimSpace(account.GetCredential("base_url")); baseURL != "" {
+		validatedURL, err := s.validateUpstreamBaseURL(baseURL)
+		if err != nil {
+			return nil, err
+		}
+		targetURL = validatedURL + "/embeddings"
+	}
+
+	upstreamReq, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	upstreamReq.Header.Set("authorization", "Bearer "+token)
+	upstreamReq.Header.Set("content-type", "application/json")
+	if customUA := account.GetOpenAIUserAgent(); customUA != "" {
+		upstreamReq.Header.Set("user-agent", customUA)
+	}
+
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+
+	if c != nil {
+		c.Set(OpsUpstreamRequestBodyKey, string(body))
+	}
+
+	resp, err := s.httpUpstream.Do(upstreamReq, proxyURL, account.ID, account.Concurrency)
+	if err != nil {
+		safeErr := sanitizeUpstreamErrorMessage(err.Error())
+		setOpsUpstreamError(c, 0, safeErr, "")
+		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+			Platform:           account.Platform,
+			AccountID:          account.ID,
+			AccountName:        account.Name,
+			UpstreamStatusCode: 0,
+			Kind:               "request_error",
+			Message:            safeErr,
+		})
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": gin.H{
+				"type":    "upstream_error",
+				"message": "Upstream request failed",
+			},
+		})
+		return nil, fmt.Errorf("upstream request failed: %s", safeErr)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		if s.shouldFailoverUpstreamError(resp.StatusCode) {
+			respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+			_ = resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+			upstreamMsg := sanitizeUpstreamErrorMessage(strings.TrimSpace(extractUpstreamErrorMessage(respBody)))
+			appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+				Platform:           account.Platform,
+				AccountID:          account.ID,
+				AccountName:        account.Name,
+				UpstreamStatusCode: resp.StatusCode,
+				UpstreamRequestID:  resp.Header.Get("x-request-id"),
+				Kind:               "failover",
+				Message:            upstreamMsg,
+			})
+
+			s.handleFailoverSideEffects(ctx, resp, account)
+			return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode}
+		}
+		return s.handleErrorResponse(ctx, resp, c, account)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		Usage struct {
+			PromptTokens int `json:"prompt_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+
+	if originalModel != mappedModel {
+		respBody = s.replaceModelInResponseBody(respBody, mappedModel, originalModel)
+	}
+
+	responseheaders.WriteFilteredHeaders(c.Writer.Header(), resp.Header, s.cfg.Security.ResponseHeaders)
+	c.Data(resp.StatusCode, "application/json", respBody)
+
+	return &OpenAIForwardResult{
+		RequestID: resp.Header.Get("x-request-id"),
+		Usage:     OpenAIUsage{InputTokens: parsed.Usage.PromptTokens},
+		Model:     originalModel,
+		Duration:  time.Since(startTime),
+	}, nil
+}
+
+// ForwardEmbeddings 将 OpenAI Embeddings 请求转换为 Gemini embedContent/batchEmbedContents，
+// 并将响应转换回 OpenAI 格式。Gemini 不返回 token 用量，输入 token 数按文本估算。
+func (s *GeminiMessagesCompatService) ForwardEmbeddings(ctx context.Context, c *gin.Context, account *Account, req *EmbeddingsRequest) (*ForwardResult, error) {
+	startTime := time.Now()
+
+	if req.TokenInput {
+		return nil, writeEmbeddingsError(c, http.StatusBadRequest, "invalid_request_error", "Token array input is not supported for Gemini embeddings")
+	}
+	if !account.SupportsEmbeddings() {
+		return nil, writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "Account does not support embeddings")
+	}
+
+	originalModel := req.Model
+	mappedModel := originalModel
+	if account.Type == AccountTypeAPIKey {
+		mappedModel = account.GetMappedModel(originalModel)
+	}
+	mappedModel = strings.TrimPrefix(mappedModel, "models/")
+
+	action := "embedContent"
+	var payload any
+	buildItem := func(text string) map[string]any {
+		item := map[string]any{
+			"model":   "models/" + mappedModel,
+			"content": map[string]any{"parts": []any{map[string]any{"text": text}}},
+		}
+		if req.Dimensions > 0 {
+			item["outputDimensionality"] = req.Dimensions
+		}
+		return item
+	}
+	if len(req.Inputs) == 1 {
+		payload = buildItem(req.Inputs[0])
+	} else {
+		action = "batchEmbedContents"
+		requests := make([]any, 0, len(req.Inputs))
+		for _, text := range req.Inputs {
+			requests = append(requests, buildItem(text))
+		}
+		payload = map[string]any{"requests": requests}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := strings.TrimSpace(account.GetCredential("base_url"))
+	if baseURL == "" {
+		baseURL = geminicli.AIStudioBaseURL
+	}
+	normalizedBaseURL, err := s.validateUpstreamBaseURL(baseURL)
+	if err != nil {
+		return nil, writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", err.Error())
+	}
+	fullURL := fmt.Sprintf("%s/v1beta/models/%s:%s", strings.TrimRight(normalizedBaseURL, "/"), mappedModel, action)
+
+	upstreamReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	upstreamReq.Header.Set("Content-Type", "application/json")
+	switch account.Type {
+	case AccountTypeAPIKey:
+		apiKey := strings.TrimSpace(account.GetCredential("api_key"))
+		if apiKey == "" {
+			return nil, writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "gemini api_key not configured")
+		}
+		upstreamReq.Header.Set("x-goog-api-key", apiKey)
+	case AccountTypeOAuth:
+		if s.tokenProvider == nil {
+			return nil, writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "gemini token provider not configured")
+		}
+		accessToken, err := s.tokenProvider.GetAccessToken(ctx, account)
+		if err != nil {
+			return nil, writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", err.Error())
+		}
+		upstreamReq.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+
+	if c != nil {
+		c.Set(OpsUpstreamRequestBodyKey, string(body))
+	}
+
+	resp, err := s.httpUpstream.Do(upstreamReq, proxyURL, account.ID, account.Concurrency)
+	if err != nil {
+		safeErr := sanitizeUpstreamErrorMessage(err.Error())
+		setOpsUpstreamError(c, 0, safeErr, "")
+		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+			Platform:           account.Platform,
+			AccountID:          account.ID,
+			AccountName:        account.Name,
+			UpstreamStatusCode: 0,
+			Kind:               "request_error",
+			Message:            safeErr,
+		})
+		return nil, writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "Upstream request failed")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	requestID := resp.Header.Get("x-request-id")
+	if requestID == "" {
+		requestID = resp.Header.Get("x-goog-request-id")
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if resp.StatusCode >= 400 {
+		s.handleGeminiUpstreamError(ctx, account, resp.StatusCode, resp.Header, respBody)
+		upstreamMsg := sanitizeUpstreamErrorMessage(strings.TrimSpace(extractUpstreamErrorMessage(respBody)))
+		kind := "http_error"
+		if s.shouldFailoverGeminiUpstreamError(resp.StatusCode) {
+			kind = "failover"
+		}
+		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+			Platform:           account.Platform,
+			AccountID:          account.ID,
+			AccountName:        account.Name,
+			UpstreamStatusCode: resp.StatusCode,
+			UpstreamRequestID:  requestID,
+			Kind:               kind,
+			Message:            upstreamMsg,
+		})
+		if kind == "failover" {
+			return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode}
+		}
+		setOpsUpstreamError(c, resp.StatusCode, upstreamMsg, "")
+		if upstreamMsg == "" {
+			upstreamMsg = "Upstream request failed"
+		}
+		status := resp.StatusCode
+		if status != http.StatusBadRequest && status != http.StatusNotFound {
+			status = http.StatusBadGateway
+		}
+		return nil, writeEmbeddingsError(c, status, "upstream_error", upstreamMsg)
+	}
+
+	vectors, err := parseGeminiEmbeddings(respBody)
+	if err != nil {
+		return nil, writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "Failed to parse upstream response")
+	}
+
+	inputTokens := 0
+	for _, text := range req.Inputs {
+		inputTokens += estimateTokensForText(text)
+	}
+
+	out, err := buildOpenAIEmbeddingsResponse(vectors, originalModel, inputTokens, req.EncodingFormat)
+	if err != nil {
+		return nil, writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "Failed to build response")
+	}
+	if requestID != "" {
+		c.Header("x-request-id", requestID)
+	}
+	c.Data(http.StatusOK, "application/json", out)
+
+	return &ForwardResult{
+		RequestID: requestID,
+		Usage:     ClaudeUsage{InputTokens: inputTokens},
+		Model:     originalModel,
+		Duration:  time.Since(startTime),
+	}, nil
+}
+
+// parseGeminiEmbeddings 解析 embedContent（embedding）或 batchEmbedContents（embeddings）响应
+func parseGeminiEmbeddings(body []byte) ([][]float64, error) {
+	var parsed struct {
+		Embedding *struct {
+			Values []float64 `json:"values"`
+		} `json:"embedding"`
+		Embeddings []struct {
+			Values []float64 `json:"values"`
+		} `json:"embeddings"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, err
+	}
+	if parsed.Embedding != nil {
+		return [][]float64{parsed.Embedding.Values}, nil
+	}
+	if len(parsed.Embeddings) == 0 {
+		return nil, errors.New("empty embeddings")
+	}
+	vectors := make([][]float64, 0, len(parsed.Embeddings))
+	for _, e := range parsed.Embeddings {
+		vectors = append(vectors, e.Values)
+	}
+	return vectors, nil
+}
+
+// buildOpenAIEmbeddingsResponse 构造 OpenAI 格式的 embeddings 响应
+// encoding_format=base64 时按 little-endian float32 编码，与 OpenAI 行为一致
+func buildOpenAIEmbeddingsResponse(vectors [][]float64, model string, promptTokens int, encodingFormat string) ([]byte, error) {
+	data := make([]any, 0, len(vectors))
+	for i, vec := range vectors {
+		var embedding any = vec
+		if encodingFormat == "base64" {
+			buf := make([]byte, 4*len(vec))
+			for j, v := range vec {
+				binary.LittleEndian.PutUint32(buf[j*4:], math.Float32bits(float32(v)))
+			}
+			embedding = base64.StdEncoding.EncodeToString(buf)
+		}
+		data = append(data, map[string]any{
+			"object":    "embedding",
+			"index":     i,
+			"embedding": embedding,
+		})
+	}
+	return json.Marshal(map[string]any{
+		"object": "list",
+		"data":   data,
+		"model":  model,
+		"usage": map[string]any{
+			"prompt_tokens": promptTokens,
+			"total_tokens":  promptTokens,
+		},
+	})
+}
+
+func writeEmbeddingsError(c *gin.Context, status int, errType, message string) error {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+	return fmt.Errorf("%s", message)
+}
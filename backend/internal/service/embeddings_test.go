@@ -0,0 +1,106 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEmbeddingsRequest(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		wantInputs []string
+		wantTokens bool
+		wantErr    bool
+	}{
+		{name: "string", body: `{"model":"m","input":"hello"}`, wantInputs: []string{"hello"}},
+		{name: "string_list", body: `{"model":"m","input":["a","b"]}`, wantInputs: []string{"a", "b"}},
+		{name: "token_ids", body: `{"model":"m","input":[1,2,3]}`, wantTokens: true},
+		{name: "token_id_lists", body: `{"model":"m","input":[[1,2],[3]]}`, wantTokens: true},
+		{name: "empty_list", body: `{"model":"m","input":[]}`, wantErr: true},
+		{name: "missing", body: `{"model":"m"}`, wantErr: true},
+		{name: "bad_format", body: `{"model":"m","input":"x","encoding_format":"int8"}`, wantErr: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := ParseEmbeddingsRequest([]byte(tt.body))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "m", req.Model)
+			require.Equal(t, tt.wantInputs, req.Inputs)
+			require.Equal(t, tt.wantTokens, req.TokenInput)
+		})
+	}
+}
+
+func TestAccountSupportsEmbeddings(t *testing.T) {
+	require.True(t, (&Account{Platform: PlatformOpenAI, Type: AccountTypeAPIKey}).SupportsEmbeddings())
+	require.False(t, (&Account{Platform: PlatformOpenAI, Type: AccountTypeOAuth}).SupportsEmbeddings())
+	require.True(t, (&Account{Platform: PlatformGemini, Type: AccountTypeAPIKey}).SupportsEmbeddings())
+	require.True(t, (&Account{Platform: PlatformGemini, Type: AccountTypeOAuth, Credentials: map[string]any{"oauth_type": "ai_studio"}}).SupportsEmbeddings())
+	require.False(t, (&Account{Platform: PlatformGemini, Type: AccountTypeOAuth, Credentials: map[string]any{"project_id": "p"}}).SupportsEmbeddings())
+	require.False(t, (&Account{Platform: PlatformAnthropic, Type: AccountTypeAPIKey}).SupportsEmbeddings())
+}
+
+func TestParseGeminiEmbeddings(t *testing.T) {
+	single, err := parseGeminiEmbeddings([]byte(`{"embedding":{"values":[0.1,0.2]}}`))
+	require.NoError(t, err)
+	require.Equal(t, [][]float64{{0.1, 0.2}}, single)
+
+	batch, err := parseGeminiEmbeddings([]byte(`{"embeddings":[{"values":[1]},{"values":[2]}]}`))
+	require.NoError(t, err)
+	require.Equal(t, [][]float64{{1}, {2}}, batch)
+
+	_, err = parseGeminiEmbeddings([]byte(`{}`))
+	require.Error(t, err)
+}
+
+func TestBuildOpenAIEmbeddingsResponse(t *testing.T) {
+	out, err := buildOpenAIEmbeddingsResponse([][]float64{{0.5, -1}}, "text-embedding-004", 7, "")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"object":"list",
+		"data":[{"object":"embedding","index":0,"embedding":[0.5,-1]}],
+		"model":"text-embedding-004",
+		"usage":{"prompt_tokens":7,"total_tokens":7}
+	}`, string(out))
+
+	// base64 编码为 little-endian float32
+	out, err = buildOpenAIEmbeddingsResponse([][]float64{{0.5, -1}}, "m", 1, "base64")
+	require.NoError(t, err)
+	var resp struct {
+		Data []struct {
+			Embedding string `json:"embedding"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(out, &resp))
+	raw, err := base64.StdEncoding.DecodeString(resp.Data[0].Embedding)
+	require.NoError(t, err)
+	require.Len(t, raw, 8)
+	require.Equal(t, float32(0.5), math.Float32frombits(binary.LittleEndian.Uint32(raw[0:])))
+	require.Equal(t, float32(-1), math.Float32frombits(binary.LittleEndian.Uint32(raw[4:])))
+}
+
+func TestEmbeddingFallbackPricingIsInputOnly(t *testing.T) {
+	svc := NewBillingService(&config.Config{}, nil)
+
+	cost, err := svc.CalculateCost("text-embedding-3-large", UsageTokens{InputTokens: 1_000_000}, 2)
+	require.NoError(t, err)
+	require.InDelta(t, 0.13, cost.TotalCost, 1e-9)
+	require.InDelta(t, 0.26, cost.ActualCost, 1e-9)
+	require.Zero(t, cost.OutputCost)
+
+	cost, err = svc.CalculateCost("gemini-embedding-001", UsageTokens{InputTokens: 1_000_000}, 1)
+	require.NoError(t, err)
+	require.InDelta(t, 0.15, cost.TotalCost, 1e-9)
+}
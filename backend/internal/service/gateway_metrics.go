@@ -0,0 +1,172 @@
+package service
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+)
+
+// Prometheus 指标定义（通过 /metrics 暴露，需开启 metrics.enabled）
+//
+// 并发槽位与等待队列为本实例视角的计数，多实例部署时在 Prometheus 侧按实例求和。
+var (
+	gatewayRequestsTotal = metrics.NewCounterVec(
+		"sub2api_gateway_requests_total",
+		"Gateway requests by platform, model, group and response status.",
+		"platform", "model", "group", "status",
+	)
+	gatewayRequestDuration = metrics.NewHistogramVec(
+		"sub2api_gateway_request_duration_seconds",
+		"Gateway request latency in seconds, including the full streaming duration.",
+		metrics.DefaultLatencyBuckets,
+		"platform", "model", "group", "status",
+	)
+	gatewayAccountSwitchesTotal = metrics.NewCounterVec(
+		"sub2api_gateway_account_switches_total",
+		"Upstream failovers that switched a request to another account.",
+		"platform", "upstream_status",
+	)
+	concurrencySlotsInUse = metrics.NewGaugeVec(
+		"sub2api_concurrency_slots_in_use",
+		"Concurrency slots currently held by this instance.",
+		"scope",
+	)
+	concurrencyWaitQueueDepth = metrics.NewGaugeVec(
+		"sub2api_concurrency_wait_queue_depth",
+		"Requests of this instance currently waiting for a concurrency slot.",
+		"scope",
+	)
+	schedulerOutboxLagSeconds = metrics.NewGaugeVec(
+		"sub2api_scheduler_outbox_lag_seconds",
+		"Age of the oldest scheduler outbox event handled in the last poll.",
+	)
+	billingCacheWriteDropsTotal = metrics.NewCounterVec(
+		"sub2api_billing_cache_write_drops_total",
+		"Billing cache write tasks dropped because the queue was full or closed.",
+		"reason", "kind",
+	)
+	tokenRefreshTotal = metrics.NewCounterVec(
+		"sub2api_token_refresh_total",
+		"OAuth token refresh outcomes by platform.",
+		"platform", "result",
+	)
+	responseCacheLookupsTotal = metrics.NewCounterVec(
+		"sub2api_response_cache_lookups_total",
+		"Response cache lookups in cache-enabled groups by request kind and result (hit, miss, skip).",
+		"kind", "result",
+	)
+	modelFallbacksTotal = metrics.NewCounterVec(
+		"sub2api_model_fallbacks_total",
+		"Requests switched to the next model fallback target because the current platform had no usable account.",
+		"from_platform", "to_platform",
+	)
+	guardrailActionsTotal = metrics.NewCounterVec(
+		"sub2api_guardrail_actions_total",
+		"Guardrail filter actions by filter and action (blocked, modified, response_modified).",
+		"filter", "action",
+	)
+	accountCircuitTransitionsTotal = metrics.NewCounterVec(
+		"sub2api_account_circuit_transitions_total",
+		"Account circuit breaker state transitions observed by this instance.",
+		"from", "to",
+	)
+	accountQueueWaitSeconds = metrics.NewHistogramVec(
+		"sub2api_account_queue_wait_seconds",
+		"Time requests spent in the fair-share account slot queue before acquiring a slot.",
+		metrics.DefaultLatencyBuckets,
+		"priority",
+	)
+	gatewayHedgesTotal = metrics.NewCounterVec(
+		"sub2api_gateway_hedges_total",
+		"Hedged request events by platform and outcome (fired, no_backup, primary_won, backup_won).",
+		"platform", "outcome",
+	)
+	streamClientDisconnectsTotal = metrics.NewCounterVec(
+		"sub2api_stream_client_disconnects_total",
+		"Streams whose client disconnected mid-stream by platform and upstream outcome (drained, capped, aborted).",
+		"platform", "outcome",
+	)
+	billingReservationsTotal = metrics.NewCounterVec(
+		"sub2api_billing_reservations_total",
+		"Pre-flight billing reservations by result (reserved, rejected, error).",
+		"result",
+	)
+)
+
+const (
+	metricsScopeUser    = "user"
+	metricsScopeAccount = "account"
+)
+
+// MetricsModelOther 不在价格表中的模型统一使用的指标标签
+const MetricsModelOther = "other"
+
+func init() {
+	metrics.Default.MustRegister(
+		gatewayRequestsTotal,
+		gatewayRequestDuration,
+		gatewayAccountSwitchesTotal,
+		concurrencySlotsInUse,
+		concurrencyWaitQueueDepth,
+		schedulerOutboxLagSeconds,
+		billingCacheWriteDropsTotal,
+		tokenRefreshTotal,
+		responseCacheLookupsTotal,
+		modelFallbacksTotal,
+		guardrailActionsTotal,
+		accountCircuitTransitionsTotal,
+		gatewayHedgesTotal,
+		accountQueueWaitSeconds,
+		streamClientDisconnectsTotal,
+		billingReservationsTotal,
+	)
+}
+
+// RecordGatewayRequestMetrics 记录一次网关请求的计数与耗时。
+// model 应为 BillingService.MetricsModelLabel 归一化后的标签，不能直接使用客户端传入的模型名。
+func RecordGatewayRequestMetrics(platform, model, group string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	gatewayRequestsTotal.WithLabelValues(platform, model, group, statusLabel).Inc()
+	gatewayRequestDuration.WithLabelValues(platform, model, group, statusLabel).Observe(duration.Seconds())
+}
+
+// RecordAccountSwitch 记录一次因上游错误触发的账号切换
+func RecordAccountSwitch(platform string, upstreamStatus int) {
+	gatewayAccountSwitchesTotal.WithLabelValues(platform, strconv.Itoa(upstreamStatus)).Inc()
+}
+
+// recordResponseCacheLookup 记录一次响应缓存查询结果
+func recordResponseCacheLookup(kind, result string) {
+	responseCacheLookupsTotal.WithLabelValues(kind, result).Inc()
+}
+
+// RecordModelFallback 记录一次模型降级链切换
+func RecordModelFallback(fromPlatform, toPlatform string) {
+	modelFallbacksTotal.WithLabelValues(fromPlatform, toPlatform).Inc()
+}
+
+// recordGuardrailAction 记录一次护栏过滤动作
+func recordGuardrailAction(filter, action string) {
+	guardrailActionsTotal.WithLabelValues(filter, action).Inc()
+}
+
+// recordAccountCircuitTransition 记录一次账号熔断状态切换
+func recordAccountCircuitTransition(from, to string) {
+	accountCircuitTransitionsTotal.WithLabelValues(from, to).Inc()
+}
+
+// recordHedgeEvent 记录一次对冲请求事件
+func recordHedgeEvent(platform, outcome string) {
+	gatewayHedgesTotal.WithLabelValues(platform, outcome).Inc()
+}
+
+// recordStreamClientDisconnect 记录一次流式传输中途客户端断开
+func recordStreamClientDisconnect(platform, outcome string) {
+	streamClientDisconnectsTotal.WithLabelValues(platform, outcome).Inc()
+}
+
+// recordBillingReservation 记录一次请求前费用预占结果
+func recordBillingReservation(result string) {
+	billingReservationsTotal.WithLabelValues(result).Inc()
+}
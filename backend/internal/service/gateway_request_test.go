@@ -17,6 +17,11 @@ func TestParseGatewayRequest(t *testing.T) {
 	require.True(t, parsed.HasSystem)
 	require.NotNil(t, parsed.System)
 	require.Len(t, parsed.Messages, 1)
+	require.Zero(t, parsed.MaxTokens)
+
+	parsed, err = ParseGatewayRequest([]byte(`{"model":"claude-3-7-sonnet","max_tokens":2048}`))
+	require.NoError(t, err)
+	require.Equal(t, 2048, parsed.MaxTokens)
 }
 
 func TestParseGatewayRequest_SystemNull(t *testing.T) {
@@ -169,6 +169,28 @@ type ClaudeUsage struct {
 	OutputTokens             int `json:"output_tokens"`
 	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
 	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+	// CacheCreation 5 分钟/1 小时缓存创建 token 拆分（上游未返回时为 0，全部按 5 分钟计费）
+	CacheCreation ClaudeCacheCreationUsage `json:"cache_creation"`
+	// ServiceTier 上游实际使用的服务等级（standard / priority / batch）
+	ServiceTier string `json:"service_tier,omitempty"`
+}
+
+// usageTokens 转换为计费 token 数（含缓存创建分档）
+func (u ClaudeUsage) usageTokens() UsageTokens {
+	return UsageTokens{
+		InputTokens:           u.InputTokens,
+		OutputTokens:          u.OutputTokens,
+		CacheCreationTokens:   u.CacheCreationInputTokens,
+		CacheReadTokens:       u.CacheReadInputTokens,
+		CacheCreation5mTokens: u.CacheCreation.Ephemeral5mInputTokens,
+		CacheCreation1hTokens: u.CacheCreation.Ephemeral1hInputTokens,
+	}
+}
+
+// ClaudeCacheCreationUsage usage.cache_creation
+type ClaudeCacheCreationUsage struct {
+	Ephemeral5mInputTokens int `json:"ephemeral_5m_input_tokens"`
+	Ephemeral1hInputTokens int `json:"ephemeral_1h_input_tokens"`
 }
 
 // ForwardResult 转发结果
@@ -197,23 +219,26 @@ func (e *UpstreamFailoverError) Error() string {
 
 // GatewayService handles API gateway operations
 type GatewayService struct {
-	accountRepo         AccountRepository
-	groupRepo           GroupRepository
-	usageLogRepo        UsageLogRepository
-	userRepo            UserRepository
-	userSubRepo         UserSubscriptionRepository
-	cache               GatewayCache
-	cfg                 *config.Config
-	schedulerSnapshot   *SchedulerSnapshotService
-	billingService      *BillingService
-	rateLimitService    *RateLimitService
-	billingCacheService *BillingCacheService
-	identityService     *IdentityService
-	httpUpstream        HTTPUpstream
-	deferredService     *DeferredService
-	concurrencyService  *ConcurrencyService
-	claudeTokenProvider *ClaudeTokenProvider
-	sessionLimitCache   SessionLimitCache // 会话数量限制缓存（仅 Anthropic OAuth/SetupToken）
+	accountRepo          AccountRepository
+	groupRepo            GroupRepository
+	usageLogRepo         UsageLogRepository
+	userRepo             UserRepository
+	userSubRepo          UserSubscriptionRepository
+	cache                GatewayCache
+	cfg                  *config.Config
+	schedulerSnapshot    *SchedulerSnapshotService
+	billingService       *BillingService
+	rateLimitService     *RateLimitService
+	billingCacheService  *BillingCacheService
+	apiKeyRateLimiter    *APIKeyRateLimitService
+	identityService      *IdentityService
+	httpUpstream         HTTPUpstream
+	deferredService      *DeferredService
+	concurrencyService   *ConcurrencyService
+	claudeTokenProvider  *ClaudeTokenProvider
+	sessionLimitCache    SessionLimitCache // 会话数量限制缓存（仅 Anthropic OAuth/SetupToken）
+	schedulingStrategies *SchedulingStrategyService
+	circuitBreaker       *AccountCircuitBreakerService
 }
 
 // NewGatewayService creates a new GatewayService
@@ -230,30 +255,36 @@ func NewGatewayService(
 	billingService *BillingService,
 	rateLimitService *RateLimitService,
 	billingCacheService *BillingCacheService,
+	apiKeyRateLimiter *APIKeyRateLimitService,
 	identityService *IdentityService,
 	httpUpstream HTTPUpstream,
 	deferredService *DeferredService,
 	claudeTokenProvider *ClaudeTokenProvider,
 	sessionLimitCache SessionLimitCache,
+	schedulingStrategies *SchedulingStrategyService,
+	circuitBreaker *AccountCircuitBreakerService,
 ) *GatewayService {
 	return &GatewayService{
-		accountRepo:         accountRepo,
-		groupRepo:           groupRepo,
-		usageLogRepo:        usageLogRepo,
-		userRepo:            userRepo,
-		userSubRepo:         userSubRepo,
-		cache:               cache,
-		cfg:                 cfg,
-		schedulerSnapshot:   schedulerSnapshot,
-		concurrencyService:  concurrencyService,
-		billingService:      billingService,
-		rateLimitService:    rateLimitService,
-		billingCacheService: billingCacheService,
-		identityService:     identityService,
-		httpUpstream:        httpUpstream,
-		deferredService:     deferredService,
-		claudeTokenProvider: claudeTokenProvider,
-		sessionLimitCache:   sessionLimitCache,
+		accountRepo:          accountRepo,
+		groupRepo:            groupRepo,
+		usageLogRepo:         usageLogRepo,
+		userRepo:             userRepo,
+		userSubRepo:          userSubRepo,
+		cache:                cache,
+		cfg:                  cfg,
+		schedulerSnapshot:    schedulerSnapshot,
+		concurrencyService:   concurrencyService,
+		billingService:       billingService,
+		rateLimitService:     rateLimitService,
+		billingCacheService:  billingCacheService,
+		apiKeyRateLimiter:    apiKeyRateLimiter,
+		identityService:      identityService,
+		httpUpstream:         httpUpstream,
+		deferredService:      deferredService,
+		claudeTokenProvider:  claudeTokenProvider,
+		sessionLimitCache:    sessionLimitCache,
+		schedulingStrategies: schedulingStrategies,
+		circuitBreaker:       circuitBreaker,
 	}
 }
 
@@ -273,14 +304,14 @@ func (s *GatewayService) GenerateSessionHash(parsed *ParsedRequest) string {
 	// 2. 提取带 cache_control: {type: "ephemeral"} 的内容
 	cacheableContent := s.extractCacheableContent(parsed)
 	if cacheableContent != "" {
-		return s.hashContent(cacheableContent)
+		return hashContent(cacheableContent)
 	}
 
 	// 3. Fallback: 使用 system 内容
 	if parsed.System != nil {
 		systemText := s.extractTextFromSystem(parsed.System)
 		if systemText != "" {
-			return s.hashContent(systemText)
+			return hashContent(systemText)
 		}
 	}
 
@@ -289,7 +320,7 @@ func (s *GatewayService) GenerateSessionHash(parsed *ParsedRequest) string {
 		if firstMsg, ok := parsed.Messages[0].(map[string]any); ok {
 			msgText := s.extractTextFromContent(firstMsg["content"])
 			if msgText != "" {
-				return s.hashContent(msgText)
+				return hashContent(msgText)
 			}
 		}
 	}
@@ -399,7 +430,7 @@ func (s *GatewayService) extractTextFromContent(content any) string {
 	return ""
 }
 
-func (s *GatewayService) hashContent(content string) string {
+func hashContent(content string) string {
 	hash := sha256.Sum256([]byte(content))
 	return hex.EncodeToString(hash[:16]) // 32字符
 }
@@ -462,6 +493,12 @@ func (s *GatewayService) SelectAccountForModelWithExclusions(ctx context.Context
 // SelectAccountWithLoadAwareness selects account with load-awareness and wait plan.
 // metadataUserID: 已废弃参数，会话限制现在统一使用 sessionHash
 func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, groupID *int64, sessionHash string, requestedModel string, excludedIDs map[int64]struct{}, metadataUserID string) (*AccountSelectionResult, error) {
+	return s.circuitBreaker.guardWaitPlan(ctx, excludedIDs, func(excluded map[int64]struct{}) (*AccountSelectionResult, error) {
+		return s.selectAccountWithLoadAwareness(ctx, groupID, sessionHash, requestedModel, excluded)
+	})
+}
+
+func (s *GatewayService) selectAccountWithLoadAwareness(ctx context.Context, groupID *int64, sessionHash string, requestedModel string, excludedIDs map[int64]struct{}) (*AccountSelectionResult, error) {
 	// 调试日志：记录调度入口参数
 	excludedIDsList := make([]int64, 0, len(excludedIDs))
 	for id := range excludedIDs {
@@ -723,10 +760,6 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 			routingLoadMap, _ := s.concurrencyService.GetAccountsLoadBatch(ctx, routingLoads)
 
 			// 3. 按负载感知排序
-			type accountWithLoad struct {
-				account  *Account
-				loadInfo *AccountLoadInfo
-			}
 			var routingAvailable []accountWithLoad
 			for _, acc := range routingCandidates {
 				loadInfo := routingLoadMap[acc.ID]
@@ -759,6 +792,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 						return a.account.LastUsedAt.Before(*b.account.LastUsedAt)
 					}
 				})
+				s.schedulingStrategies.Order(ctx, group, routingAvailable)
 
 				// 4. 尝试获取槽位
 				for _, item := range routingAvailable {
@@ -913,10 +947,6 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 			return result, nil
 		}
 	} else {
-		type accountWithLoad struct {
-			account  *Account
-			loadInfo *AccountLoadInfo
-		}
 		var available []accountWithLoad
 		for _, acc := range candidates {
 			loadInfo := loadMap[acc.ID]
@@ -954,6 +984,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 					return a.account.LastUsedAt.Before(*b.account.LastUsedAt)
 				}
 			})
+			s.schedulingStrategies.Order(ctx, group, available)
 
 			for _, item := range available {
 				result, err := s.tryAcquireAccountSlot(ctx, item.account.ID, item.account.Concurrency)
@@ -1160,6 +1191,15 @@ func (s *GatewayService) resolvePlatform(ctx context.Context, groupID *int64, gr
 }
 
 func (s *GatewayService) listSchedulableAccounts(ctx context.Context, groupID *int64, platform string, hasForcePlatform bool) ([]Account, bool, error) {
+	accounts, useMixed, err := s.listSchedulableAccountsUnfiltered(ctx, groupID, platform, hasForcePlatform)
+	if err != nil {
+		return nil, useMixed, err
+	}
+	// 剔除熔断打开的账号
+	return s.circuitBreaker.FilterSchedulable(ctx, accounts), useMixed, nil
+}
+
+func (s *GatewayService) listSchedulableAccountsUnfiltered(ctx context.Context, groupID *int64, platform string, hasForcePlatform bool) ([]Account, bool, error) {
 	if s.schedulerSnapshot != nil {
 		accounts, useMixed, err := s.schedulerSnapshot.ListSchedulableAccounts(ctx, groupID, platform, hasForcePlatform)
 		if err == nil {
@@ -1285,10 +1325,29 @@ func (s *GatewayService) isAccountInGroup(account *Account, groupID *int64) bool
 }
 
 func (s *GatewayService) tryAcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int) (*AcquireResult, error) {
+	var result *AcquireResult
 	if s.concurrencyService == nil {
-		return &AcquireResult{Acquired: true, ReleaseFunc: func() {}}, nil
+		result = &AcquireResult{Acquired: true, ReleaseFunc: func() {}}
+	} else {
+		var err error
+		result, err = s.concurrencyService.AcquireAccountSlot(ctx, accountID, maxConcurrency)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return s.concurrencyService.AcquireAccountSlot(ctx, accountID, maxConcurrency)
+	// 熔断打开或半开探测名额已满时放弃槽位
+	if result.Acquired && !s.circuitBreaker.AllowRequest(ctx, accountID) {
+		if result.ReleaseFunc != nil {
+			result.ReleaseFunc()
+		}
+		return &AcquireResult{Acquired: false}, nil
+	}
+	return result, nil
+}
+
+// RecordAccountResult 记录一次转发结果，用于账号熔断统计
+func (s *GatewayService) RecordAccountResult(c *gin.Context, account *Account, err error) {
+	s.circuitBreaker.RecordResult(c, account, err)
 }
 
 // isAccountSchedulableForWindowCost 检查账号是否可根据窗口费用进行调度
@@ -2277,6 +2336,10 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 	reqModel := parsed.Model
 	reqStream := parsed.Stream
 
+	// 流式请求：客户端断开后可按配置继续排空上游以获取完整 usage
+	ctx, cancelUpstream := detachUpstreamContext(ctx, streamDrainTimeout(s.cfg, reqStream))
+	defer cancelUpstream()
+
 	// 智能注入 Claude Code 系统提示词（仅 OAuth/SetupToken 账号需要）
 	// 条件：1) OAuth/SetupToken 账号  2) 不是 Claude Code 客户端  3) 不是 Haiku 模型  4) system 中还没有 Claude Code 提示词
 	if account.IsOAuth() &&
@@ -3220,23 +3283,34 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 
 	needModelReplace := originalModel != mappedModel
 	clientDisconnected := false // 客户端断开标志，断开后继续读取上游以获取完整usage
+	clientGone := clientRequestDone(c)
 
 	for {
 		select {
+		case <-clientGone:
+			clientGone = nil
+			clientDisconnected = true
+
 		case ev, ok := <-events:
 			if !ok {
 				// 上游完成，返回结果
+				clientDisconnected = clientDisconnected || isClientDisconnected(c)
+				if clientDisconnected {
+					finishClientDisconnect(c, account.Platform, account.ID, resp, true)
+				}
 				return &streamingResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: clientDisconnected}, nil
 			}
 			if ev.err != nil {
 				// 检测 context 取消（客户端断开会导致 context 取消，进而影响上游读取）
 				if errors.Is(ev.err, context.Canceled) || errors.Is(ev.err, context.DeadlineExceeded) {
 					log.Printf("Context canceled during streaming, returning collected usage")
+					finishClientDisconnect(c, account.Platform, account.ID, resp, false)
 					return &streamingResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: true}, nil
 				}
 				// 客户端已通过写入失败检测到断开，上游也出错了，返回已收集的 usage
 				if clientDisconnected {
 					log.Printf("Upstream read error after client disconnect: %v, returning collected usage", ev.err)
+					finishClientDisconnect(c, account.Platform, account.ID, resp, false)
 					return &streamingResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: true}, nil
 				}
 				// 客户端未断开，正常的错误处理
@@ -3252,6 +3326,7 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 			if line == "event: error" {
 				// 上游返回错误事件，如果客户端已断开仍返回已收集的 usage
 				if clientDisconnected {
+					finishClientDisconnect(c, account.Platform, account.ID, resp, false)
 					return &streamingResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: true}, nil
 				}
 				return nil, errors.New("have error in stream")
@@ -3294,6 +3369,7 @@ func (s *GatewayService) handleStreamingResponse(ctx context.Context, resp *http
 			if clientDisconnected {
 				// 客户端已断开，上游也超时了，返回已收集的 usage
 				log.Printf("Upstream timeout after client disconnect, returning collected usage")
+				finishClientDisconnect(c, account.Platform, account.ID, resp, false)
 				return &streamingResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: true}, nil
 			}
 			log.Printf("Stream data interval timeout: account=%d model=%s interval=%s", account.ID, originalModel, streamInterval)
@@ -3359,6 +3435,8 @@ func (s *GatewayService) parseSSEUsage(data string, usage *ClaudeUsage) {
 		usage.InputTokens = msgStart.Message.Usage.InputTokens
 		usage.CacheCreationInputTokens = msgStart.Message.Usage.CacheCreationInputTokens
 		usage.CacheReadInputTokens = msgStart.Message.Usage.CacheReadInputTokens
+		usage.CacheCreation = msgStart.Message.Usage.CacheCreation
+		usage.ServiceTier = msgStart.Message.Usage.ServiceTier
 	}
 
 	// 解析message_delta获取tokens（兼容GLM等把所有usage放在delta中的API）
@@ -3369,6 +3447,9 @@ func (s *GatewayService) parseSSEUsage(data string, usage *ClaudeUsage) {
 			OutputTokens             int `json:"output_tokens"`
 			CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
 			CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+
+			CacheCreation ClaudeCacheCreationUsage `json:"cache_creation"`
+			ServiceTier   string                   `json:"service_tier"`
 		} `json:"usage"`
 	}
 	if json.Unmarshal([]byte(data), &msgDelta) == nil && msgDelta.Type == "message_delta" {
@@ -3387,6 +3468,12 @@ func (s *GatewayService) parseSSEUsage(data string, usage *ClaudeUsage) {
 		if msgDelta.Usage.CacheReadInputTokens > 0 {
 			usage.CacheReadInputTokens = msgDelta.Usage.CacheReadInputTokens
 		}
+		if msgDelta.Usage.CacheCreation.Ephemeral5mInputTokens > 0 || msgDelta.Usage.CacheCreation.Ephemeral1hInputTokens > 0 {
+			usage.CacheCreation = msgDelta.Usage.CacheCreation
+		}
+		if msgDelta.Usage.ServiceTier != "" {
+			usage.ServiceTier = msgDelta.Usage.ServiceTier
+		}
 	}
 }
 
@@ -3454,54 +3541,75 @@ type RecordUsageInput struct {
 	APIKey       *APIKey
 	User         *User
 	Account      *Account
-	Subscription *UserSubscription // 可选：订阅信息
-	UserAgent    string            // 请求的 User-Agent
-	IPAddress    string            // 请求的客户端 IP 地址
+	Subscription *UserSubscription   // 可选：订阅信息
+	UserAgent    string              // 请求的 User-Agent
+	IPAddress    string              // 请求的客户端 IP 地址
+	ServiceTier  string              // 可选：计费服务等级（如批处理 batch），为空时使用上游返回的等级
+	Deferred     bool                // 可选：异步结算（如批处理），不计入 API Key TPM 窗口
+	CacheServed  bool                // 可选：响应缓存命中，按分组缓存计费比例计费且不计入账号成本
+	Hedge        HedgeOutcome        // 可选：对冲请求结果（仅胜出请求计费）
+	Reservation  *BillingReservation // 可选：请求前费用预占，扣费写入缓存后结算释放
+	ServingGroup *Group              // 可选：模型降级后实际服务请求的分组，费率倍数与分组定价按该分组计算
+}
+
+// usageBillingGroup 返回计费使用的分组：模型降级到其他分组时为实际服务的分组，否则为 API Key 所属分组
+func usageBillingGroup(apiKey *APIKey, serving *Group) (*Group, *int64) {
+	if serving != nil {
+		id := serving.ID
+		return serving, &id
+	}
+	return apiKey.Group, apiKey.GroupID
 }
 
 // RecordUsage 记录使用量并扣费（或更新订阅用量）
 func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInput) error {
+	// 未扣费（简易模式、免费、落库去重等）时同样结算释放预占
+	defer input.Reservation.settle()
+
 	result := input.Result
 	apiKey := input.APIKey
 	user := input.User
 	account := input.Account
 	subscription := input.Subscription
 
-	// 获取费率倍数
+	// 获取费率倍数（降级时按实际服务的分组）
+	billingGroup, billingGroupID := usageBillingGroup(apiKey, input.ServingGroup)
 	multiplier := s.cfg.Default.RateMultiplier
-	if apiKey.GroupID != nil && apiKey.Group != nil {
-		multiplier = apiKey.Group.RateMultiplier
+	if billingGroupID != nil && billingGroup != nil {
+		multiplier = billingGroup.RateMultiplier
 	}
 
 	var cost *CostBreakdown
+	serviceTier := input.ServiceTier
+	if serviceTier == "" {
+		serviceTier = result.Usage.ServiceTier
+	}
 
 	// 根据请求类型选择计费方式
 	if result.ImageCount > 0 {
 		// 图片生成计费
 		var groupConfig *ImagePriceConfig
-		if apiKey.Group != nil {
+		if billingGroup != nil {
 			groupConfig = &ImagePriceConfig{
-				Price1K: apiKey.Group.ImagePrice1K,
-				Price2K: apiKey.Group.ImagePrice2K,
-				Price4K: apiKey.Group.ImagePrice4K,
+				Price1K: billingGroup.ImagePrice1K,
+				Price2K: billingGroup.ImagePrice2K,
+				Price4K: billingGroup.ImagePrice4K,
 			}
 		}
-		cost = s.billingService.CalculateImageCost(result.Model, result.ImageSize, result.ImageCount, groupConfig, multiplier)
+		cost = s.billingService.CalculateImageCostForGroup(result.Model, billingGroupID, result.ImageSize, result.ImageCount, groupConfig, multiplier)
 	} else {
 		// Token 计费
-		tokens := UsageTokens{
-			InputTokens:         result.Usage.InputTokens,
-			OutputTokens:        result.Usage.OutputTokens,
-			CacheCreationTokens: result.Usage.CacheCreationInputTokens,
-			CacheReadTokens:     result.Usage.CacheReadInputTokens,
-		}
 		var err error
-		cost, err = s.billingService.CalculateCost(result.Model, tokens, multiplier)
+		cost, err = s.billingService.CalculateCostWithOptions(result.Model, billingGroupID, result.Usage.usageTokens(), multiplier, CostOptions{ServiceTier: serviceTier})
 		if err != nil {
 			log.Printf("Calculate cost failed: %v", err)
 			cost = &CostBreakdown{ActualCost: 0}
 		}
 	}
+	var cacheSavedCost float64
+	if input.CacheServed {
+		cacheSavedCost = cost.ApplyCacheServedRate(responseCacheRate(apiKey.Group))
+	}
 
 	// 判断计费方式：订阅模式 vs 余额模式
 	isSubscriptionBilling := subscription != nil && apiKey.Group != nil && apiKey.Group.IsSubscriptionType()
@@ -3517,6 +3625,10 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 		imageSize = &result.ImageSize
 	}
 	accountRateMultiplier := account.BillingRateMultiplier()
+	if input.CacheServed {
+		// 缓存命中未消耗上游账号额度
+		accountRateMultiplier = 0
+	}
 	usageLog := &UsageLog{
 		UserID:                user.ID,
 		APIKeyID:              apiKey.ID,
@@ -3541,8 +3653,17 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 		FirstTokenMs:          result.FirstTokenMs,
 		ImageCount:            result.ImageCount,
 		ImageSize:             imageSize,
+		CacheServed:           input.CacheServed,
+		CacheSavedCost:        cacheSavedCost,
+		Hedged:                input.Hedge.Hedged,
+		HedgeBackupWon:        input.Hedge.BackupWon,
+		ClientDisconnected:    result.ClientDisconnect,
+		PricingTier:           cost.PricingTier,
+		ServiceTier:           cost.ServiceTier,
+		CostDetail:            cost.Detail,
 		CreatedAt:             time.Now(),
 	}
+	usageLog.CacheCreation5mTokens, usageLog.CacheCreation1hTokens = result.Usage.usageTokens().cacheCreationSplit()
 
 	// 添加 UserAgent
 	if input.UserAgent != "" {
@@ -3561,6 +3682,9 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 	if subscription != nil {
 		usageLog.SubscriptionID = &subscription.ID
 	}
+	if apiKey.IsTeamKey() {
+		usageLog.OrganizationID = apiKey.OrganizationID
+	}
 
 	inserted, err := s.usageLogRepo.Create(ctx, usageLog)
 	if err != nil {
@@ -3569,7 +3693,9 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 
 	if s.cfg != nil && s.cfg.RunMode == config.RunModeSimple {
 		log.Printf("[SIMPLE MODE] Usage recorded (not billed): user=%d, tokens=%d", usageLog.UserID, usageLog.TotalTokens())
-		s.deferredService.ScheduleLastUsedUpdate(account.ID)
+		if !input.CacheServed {
+			s.deferredService.ScheduleLastUsedUpdate(account.ID)
+		}
 		return nil
 	}
 
@@ -3582,22 +3708,44 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 			if err := s.userSubRepo.IncrementUsage(ctx, subscription.ID, cost.TotalCost); err != nil {
 				log.Printf("Increment subscription usage failed: %v", err)
 			}
-			// 异步更新订阅缓存
-			s.billingCacheService.QueueUpdateSubscriptionUsage(user.ID, *apiKey.GroupID, cost.TotalCost)
+			// 更新订阅缓存并结算预占（无预占时异步更新）
+			s.billingCacheService.UpdateSubscriptionUsageAndSettle(subscriptionOwnerID(user, subscription), *apiKey.GroupID, cost.TotalCost, input.Reservation)
+		}
+	} else if apiKey.IsTeamKey() {
+		// 团队 API Key：扣除组织余额并累计成员本月消费
+		if shouldBill && cost.ActualCost > 0 {
+			s.billingCacheService.ChargeOrganizationAndSettle(ctx, *apiKey.OrganizationID, user.ID, cost.ActualCost, usageLog.ID, input.Reservation)
 		}
 	} else {
 		// 余额模式：扣除用户余额（使用 ActualCost 考虑倍率后的费用）
 		if shouldBill && cost.ActualCost > 0 {
-			if err := s.userRepo.DeductBalance(ctx, user.ID, cost.ActualCost); err != nil {
+			if err := s.userRepo.ApplyBalanceTransaction(ctx, newUsageBalanceTransaction(user.ID, cost.ActualCost, usageLog.ID)); err != nil {
 				log.Printf("Deduct balance failed: %v", err)
 			}
-			// 异步更新余额缓存
-			s.billingCacheService.QueueDeductBalance(user.ID, cost.ActualCost)
+			// 扣减余额缓存并结算预占（无预占时异步扣减）
+			s.billingCacheService.DeductBalanceAndSettle(user.ID, cost.ActualCost, input.Reservation)
+		}
+	}
+
+	// 累计 API Key 已用额度（按实际扣费口径）
+	if shouldBill {
+		billedCost := cost.ActualCost
+		if isSubscriptionBilling {
+			billedCost = cost.TotalCost
 		}
+		s.billingCacheService.RecordAPIKeyUsage(ctx, apiKey.ID, billedCost)
+	}
+
+	// 回写 API Key TPM 窗口（请求入口只能检查，实际 token 数在此处得知）
+	if !input.Deferred {
+		s.apiKeyRateLimiter.RecordTokens(ctx, apiKey, result.Usage.InputTokens+result.Usage.OutputTokens+
+			result.Usage.CacheCreationInputTokens+result.Usage.CacheReadInputTokens)
 	}
 
 	// Schedule batch update for account last_used_at
-	s.deferredService.ScheduleLastUsedUpdate(account.ID)
+	if !input.CacheServed {
+		s.deferredService.ScheduleLastUsedUpdate(account.ID)
+	}
 
 	return nil
 }
@@ -36,6 +36,34 @@ type Group struct {
 	ModelRouting        map[string][]int64
 	ModelRoutingEnabled bool
 
+	// 分组内 API Key 默认速率限制（Key 未单独配置时生效）
+	DefaultRPMLimit *int
+	DefaultTPMLimit *int
+
+	// 响应缓存：确定性非流式请求命中缓存时不请求上游，按 ResponseCacheRate 比例计费
+	ResponseCacheEnabled    bool
+	ResponseCacheTTLSeconds *int
+	ResponseCacheRate       float64
+
+	// 模型降级链
+	// key: 模型匹配模式（支持 * 通配符）
+	// value: 当前分组账号全部不可用时依次尝试的目标分组与模型
+	ModelFallbacks map[string][]ModelFallbackTarget
+
+	// 护栏配置：转发前的内容拒绝/脱敏/长度检查与系统提示词注入，nil 表示未配置
+	Guardrails *GuardrailConfig
+
+	// 调度策略：负载感知选择中同优先级账号的排序方式，空字符串为默认（负载率 > 最后使用时间）
+	// SchedulingWeights 为 weighted_round_robin 策略的账号权重，未配置的账号权重为 1
+	SchedulingStrategy string
+	SchedulingWeights  map[int64]int
+
+	// 对冲延迟（毫秒）：选中账号超过该时间未返回首字节时向第二个账号发起相同请求，0 表示关闭
+	HedgeDelayMs int
+
+	// 公平排队权重：订阅分组按套餐等级设置，用户未单独设置权重时生效，0 表示默认权重 1
+	QueueWeight int
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
@@ -83,6 +111,14 @@ func (g *Group) GetImagePrice(imageSize string) *float64 {
 	}
 }
 
+// SchedulingWeight 返回账号在加权轮询中的权重，未配置时为 1
+func (g *Group) SchedulingWeight(accountID int64) int {
+	if weight, ok := g.SchedulingWeights[accountID]; ok {
+		return weight
+	}
+	return 1
+}
+
 // IsGroupContextValid reports whether a group from context has the fields required for routing decisions.
 func IsGroupContextValid(group *Group) bool {
 	if group == nil {
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// 护栏请求格式：决定自定义系统提示词的注入位置，文本字段的遍历对所有格式通用
+const (
+	GuardrailFormatMessages  = "messages"  // Anthropic Messages（含 Chat Completions 转换后的请求）
+	GuardrailFormatResponses = "responses" // OpenAI Responses
+	GuardrailFormatGemini    = "gemini"    // Gemini generateContent
+)
+
+// PII 脱敏类型
+const (
+	GuardrailPIIEmail    = "email"
+	GuardrailPIIPhone    = "phone"
+	GuardrailPIIIDNumber = "id_number"
+)
+
+// 护栏配置上限，避免单个分组的规则拖慢网关入口
+const (
+	maxGuardrailDenyRules       = 200
+	maxGuardrailSystemPromptLen = 16 * 1024
+)
+
+// GuardrailConfig 分组级护栏配置，按 最大提示词长度 → 拒绝列表 → PII 脱敏 → 系统提示词 的顺序执行
+type GuardrailConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// DenyKeywords 关键词拒绝列表（大小写不敏感的子串匹配）
+	DenyKeywords []string `json:"deny_keywords,omitempty"`
+	// DenyPatterns 正则拒绝列表（RE2 语法）
+	DenyPatterns []string `json:"deny_patterns,omitempty"`
+
+	// RedactPII 需要脱敏的 PII 类型：email / phone / id_number
+	RedactPII []string `json:"redact_pii,omitempty"`
+	// RedactResponses 同时对最终响应执行 PII 脱敏：非流式响应整体改写，流式响应按内容块缓冲文本增量后改写
+	RedactResponses bool `json:"redact_responses"`
+
+	// MaxPromptChars 请求中全部文本内容的最大字符数，0 表示不限制
+	MaxPromptChars int `json:"max_prompt_chars,omitempty"`
+
+	// SystemPrompt 转发前插入到系统提示词最前面的内容
+	SystemPrompt string `json:"system_prompt,omitempty"`
+}
+
+// GuardrailViolation 描述一次被护栏拦截的请求
+type GuardrailViolation struct {
+	Filter  string // 触发拦截的过滤器名称
+	Rule    string // 命中的规则（关键词 / 正则 / 限制值）
+	Message string // 返回给客户端的错误信息
+}
+
+// GuardrailRequest 是护栏链处理中的请求：Payload 为解析后的请求体，过滤器可原地改写
+type GuardrailRequest struct {
+	Format   string
+	Payload  map[string]any
+	Modified bool
+}
+
+// GuardrailFilter 是护栏链中的一个过滤器，返回非 nil 的 GuardrailViolation 表示拦截请求
+type GuardrailFilter interface {
+	Name() string
+	FilterRequest(req *GuardrailRequest) *GuardrailViolation
+}
+
+// GuardrailResponseFilter 由需要处理最终响应的过滤器实现，对响应中的每段文本做改写
+type GuardrailResponseFilter interface {
+	FilterResponseText(text string) string
+}
+
+// Validate 校验护栏配置：正则需可编译、PII 类型需受支持、规则数量与提示词长度受限
+func (c *GuardrailConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if len(c.DenyKeywords)+len(c.DenyPatterns) > maxGuardrailDenyRules {
+		return fmt.Errorf("guardrail deny rules exceed %d", maxGuardrailDenyRules)
+	}
+	for _, pattern := range c.DenyPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid guardrail deny pattern %q: %w", pattern, err)
+		}
+	}
+	for _, kind := range c.RedactPII {
+		if _, ok := guardrailPIIPatterns[kind]; !ok {
+			return fmt.Errorf("unsupported guardrail pii type: %s", kind)
+		}
+	}
+	if c.MaxPromptChars < 0 {
+		return fmt.Errorf("guardrail max_prompt_chars must be non-negative")
+	}
+	if len(c.SystemPrompt) > maxGuardrailSystemPromptLen {
+		return fmt.Errorf("guardrail system_prompt exceeds %d bytes", maxGuardrailSystemPromptLen)
+	}
+	return nil
+}
+
+// guardrailTextKeys 各请求/响应格式中承载文本内容的字段名
+// Claude: system/content/text；OpenAI: instructions/input/content/text；Gemini: parts[].text
+var guardrailTextKeys = map[string]bool{
+	"text":         true,
+	"content":      true,
+	"system":       true,
+	"input":        true,
+	"instructions": true,
+	"prompt":       true,
+}
+
+// walkGuardrailText 遍历文本字段并用 fn 的返回值替换原文本，返回是否有改动。
+// 仅处理 guardrailTextKeys 中的字段（及其字符串数组元素），不会进入 base64 图片、工具参数定义等非文本字段。
+func walkGuardrailText(node any, fn func(string) string) bool {
+	return walkGuardrailNode(node, false, fn)
+}
+
+func walkGuardrailNode(node any, inText bool, fn func(string) string) bool {
+	changed := false
+	switch v := node.(type) {
+	case map[string]any:
+		for key, child := range v {
+			isText := guardrailTextKeys[key]
+			if s, ok := child.(string); ok {
+				if !isText {
+					continue
+				}
+				if out := fn(s); out != s {
+					v[key] = out
+					changed = true
+				}
+				continue
+			}
+			if walkGuardrailNode(child, isText, fn) {
+				changed = true
+			}
+		}
+	case []any:
+		for i, child := range v {
+			if s, ok := child.(string); ok {
+				if !inText {
+					continue
+				}
+				if out := fn(s); out != s {
+					v[i] = out
+					changed = true
+				}
+				continue
+			}
+			if walkGuardrailNode(child, inText, fn) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// maxPromptFilter 限制请求文本总字符数
+type maxPromptFilter struct {
+	maxChars int
+}
+
+func (f *maxPromptFilter) Name() string { return "max_prompt_size" }
+
+func (f *maxPromptFilter) FilterRequest(req *GuardrailRequest) *GuardrailViolation {
+	total := 0
+	walkGuardrailText(req.Payload, func(s string) string {
+		total += utf8.RuneCountInString(s)
+		return s
+	})
+	if total <= f.maxChars {
+		return nil
+	}
+	return &GuardrailViolation{
+		Filter:  f.Name(),
+		Rule:    fmt.Sprintf("%d", f.maxChars),
+		Message: fmt.Sprintf("Prompt exceeds the maximum of %d characters allowed for this group", f.maxChars),
+	}
+}
+
+// denyListFilter 关键词与正则拒绝列表
+type denyListFilter struct {
+	keywords []string
+	patterns []*regexp.Regexp
+}
+
+func (f *denyListFilter) Name() string { return "deny_list" }
+
+func (f *denyListFilter) FilterRequest(req *GuardrailRequest) *GuardrailViolation {
+	var violation *GuardrailViolation
+	walkGuardrailText(req.Payload, func(s string) string {
+		if violation != nil {
+			return s
+		}
+		lower := strings.ToLower(s)
+		for _, keyword := range f.keywords {
+			if strings.Contains(lower, keyword) {
+				violation = &GuardrailViolation{Filter: f.Name(), Rule: keyword}
+				return s
+			}
+		}
+		for _, pattern := range f.patterns {
+			if pattern.MatchString(s) {
+				violation = &GuardrailViolation{Filter: f.Name(), Rule: pattern.String()}
+				return s
+			}
+		}
+		return s
+	})
+	if violation != nil {
+		// 不向客户端暴露命中的具体规则，规则记录在运维错误日志中
+		violation.Message = "Request blocked by content policy"
+	}
+	return violation
+}
+
+// guardrailPIIPatterns PII 识别规则与替换占位符
+var guardrailPIIPatterns = map[string]struct {
+	patterns    []*regexp.Regexp
+	replacement string
+}{
+	GuardrailPIIEmail: {
+		patterns:    []*regexp.Regexp{regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)},
+		replacement: "[REDACTED_EMAIL]",
+	},
+	GuardrailPIIIDNumber: {
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`\b[1-9]\d{5}(?:18|19|20)\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])\d{3}[\dXx]\b`), // 中国居民身份证
+			regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),                                                              // US SSN
+		},
+		replacement: "[REDACTED_ID]",
+	},
+	GuardrailPIIPhone: {
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?:\+86[\s\-]?)?\b1[3-9]\d{9}\b`),                             // 中国大陆手机号
+			regexp.MustCompile(`\+\d{1,3}[\s\-]?\(?\d{1,4}\)?[\s\-]?\d{3,4}[\s\-]?\d{3,4}\b`), // 国际格式
+			regexp.MustCompile(`\(?\b\d{3}\)?[\s.\-]\d{3}[\s.\-]\d{4}\b`),                     // 北美格式
+		},
+		replacement: "[REDACTED_PHONE]",
+	},
+}
+
+// guardrailPIIOrder 身份证号需先于手机号处理，避免长数字串被部分识别为手机号
+var guardrailPIIOrder = []string{GuardrailPIIEmail, GuardrailPIIIDNumber, GuardrailPIIPhone}
+
+// piiRedactionFilter 对请求（以及可选的最终响应）中的 PII 做脱敏替换
+type piiRedactionFilter struct {
+	kinds map[string]bool
+}
+
+func (f *piiRedactionFilter) Name() string { return "pii_redaction" }
+
+func (f *piiRedactionFilter) FilterRequest(req *GuardrailRequest) *GuardrailViolation {
+	if walkGuardrailText(req.Payload, f.FilterResponseText) {
+		req.Modified = true
+	}
+	return nil
+}
+
+func (f *piiRedactionFilter) FilterResponseText(text string) string {
+	for _, kind := range guardrailPIIOrder {
+		if !f.kinds[kind] {
+			continue
+		}
+		rule := guardrailPIIPatterns[kind]
+		for _, pattern := range rule.patterns {
+			text = pattern.ReplaceAllString(text, rule.replacement)
+		}
+	}
+	return text
+}
+
+// systemPromptFilter 在系统提示词最前面插入分组自定义内容
+type systemPromptFilter struct {
+	prompt string
+}
+
+func (f *systemPromptFilter) Name() string { return "system_prompt" }
+
+func (f *systemPromptFilter) FilterRequest(req *GuardrailRequest) *GuardrailViolation {
+	switch req.Format {
+	case GuardrailFormatMessages:
+		switch system := req.Payload["system"].(type) {
+		case string:
+			if strings.TrimSpace(system) == "" {
+				req.Payload["system"] = f.prompt
+			} else {
+				req.Payload["system"] = f.prompt + "\n\n" + system
+			}
+		case []any:
+			block := map[string]any{"type": "text", "text": f.prompt}
+			req.Payload["system"] = append([]any{block}, system...)
+		default:
+			req.Payload["system"] = f.prompt
+		}
+	case GuardrailFormatResponses:
+		// instructions 可能被上游按固定内容校验（Codex），分组提示词以 developer 消息插入 input 最前面
+		message := map[string]any{
+			"type":    "message",
+			"role":    "developer",
+			"content": []any{map[string]any{"type": "input_text", "text": f.prompt}},
+		}
+		switch input := req.Payload["input"].(type) {
+		case []any:
+			req.Payload["input"] = append([]any{message}, input...)
+		case string:
+			user := map[string]any{
+				"type":    "message",
+				"role":    "user",
+				"content": []any{map[string]any{"type": "input_text", "text": input}},
+			}
+			req.Payload["input"] = []any{message, user}
+		default:
+			req.Payload["input"] = []any{message}
+		}
+	case GuardrailFormatGemini:
+		key := "systemInstruction"
+		if _, ok := req.Payload["system_instruction"]; ok {
+			key = "system_instruction"
+		}
+		part := map[string]any{"text": f.prompt}
+		instruction, _ := req.Payload[key].(map[string]any)
+		if instruction == nil {
+			instruction = map[string]any{}
+		}
+		parts, _ := instruction["parts"].([]any)
+		instruction["parts"] = append([]any{part}, parts...)
+		req.Payload[key] = instruction
+	default:
+		return nil
+	}
+	req.Modified = true
+	return nil
+}
+
+// decodeGuardrailPayload 解析请求体，数字保留原始文本以免改写后精度丢失
+func decodeGuardrailPayload(body []byte) (map[string]any, error) {
+	var payload map[string]any
+	if err := decodeGuardrailJSON(body, &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func decodeGuardrailJSON(body []byte, out any) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	return dec.Decode(out)
+}
+
+// encodeGuardrailJSON 序列化改写后的内容，不转义 HTML 字符以保持文本原样
+func encodeGuardrailJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// GuardrailService 根据分组护栏配置构建过滤器链，在转发前检查/改写请求，并对最终响应脱敏
+type GuardrailService struct {
+	patterns sync.Map // 正则拒绝规则编译缓存：pattern -> *regexp.Regexp
+}
+
+// NewGuardrailService creates a new GuardrailService
+func NewGuardrailService() *GuardrailService {
+	return &GuardrailService{}
+}
+
+func guardrailConfigForGroup(group *Group) *GuardrailConfig {
+	if group == nil || group.Guardrails == nil || !group.Guardrails.Enabled {
+		return nil
+	}
+	return group.Guardrails
+}
+
+// buildChain 按固定顺序构建过滤器链：最大提示词长度 → 拒绝列表 → PII 脱敏 → 系统提示词。
+// 拒绝列表先于脱敏执行，保证规则匹配的是用户原文；系统提示词最后注入，不参与长度与规则检查。
+func (s *GuardrailService) buildChain(cfg *GuardrailConfig) []GuardrailFilter {
+	chain := make([]GuardrailFilter, 0, 4)
+	if cfg.MaxPromptChars > 0 {
+		chain = append(chain, &maxPromptFilter{maxChars: cfg.MaxPromptChars})
+	}
+	if deny := s.buildDenyList(cfg); deny != nil {
+		chain = append(chain, deny)
+	}
+	if pii := buildPIIRedaction(cfg); pii != nil {
+		chain = append(chain, pii)
+	}
+	if prompt := strings.TrimSpace(cfg.SystemPrompt); prompt != "" {
+		chain = append(chain, &systemPromptFilter{prompt: prompt})
+	}
+	return chain
+}
+
+func (s *GuardrailService) buildDenyList(cfg *GuardrailConfig) *denyListFilter {
+	filter := &denyListFilter{}
+	for _, keyword := range cfg.DenyKeywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+			filter.keywords = append(filter.keywords, keyword)
+		}
+	}
+	for _, pattern := range cfg.DenyPatterns {
+		if re := s.compile(pattern); re != nil {
+			filter.patterns = append(filter.patterns, re)
+		}
+	}
+	if len(filter.keywords) == 0 && len(filter.patterns) == 0 {
+		return nil
+	}
+	return filter
+}
+
+func buildPIIRedaction(cfg *GuardrailConfig) *piiRedactionFilter {
+	if len(cfg.RedactPII) == 0 {
+		return nil
+	}
+	kinds := make(map[string]bool, len(cfg.RedactPII))
+	for _, kind := range cfg.RedactPII {
+		kinds[kind] = true
+	}
+	return &piiRedactionFilter{kinds: kinds}
+}
+
+func (s *GuardrailService) compile(pattern string) *regexp.Regexp {
+	if cached, ok := s.patterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		// 配置保存时已校验，这里仅防御历史脏数据
+		log.Printf("[Guardrail] skip invalid deny pattern %q: %v", pattern, err)
+		return nil
+	}
+	s.patterns.Store(pattern, re)
+	return re
+}
+
+// ApplyRequest 对请求执行分组护栏链。
+// 返回值：改写后的请求体（未改写时为 nil）、拦截信息（非 nil 表示应拒绝请求）、请求体解析错误。
+func (s *GuardrailService) ApplyRequest(group *Group, format string, body []byte) ([]byte, *GuardrailViolation, error) {
+	cfg := guardrailConfigForGroup(group)
+	if s == nil || cfg == nil {
+		return nil, nil, nil
+	}
+	chain := s.buildChain(cfg)
+	if len(chain) == 0 {
+		return nil, nil, nil
+	}
+
+	payload, err := decodeGuardrailPayload(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req := &GuardrailRequest{Format: format, Payload: payload}
+	for _, filter := range chain {
+		if violation := filter.FilterRequest(req); violation != nil {
+			recordGuardrailAction(filter.Name(), "blocked")
+			return nil, violation, nil
+		}
+	}
+	if !req.Modified {
+		return nil, nil, nil
+	}
+	out, err := encodeGuardrailJSON(req.Payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	recordGuardrailAction("chain", "modified")
+	return out, nil, nil
+}
+
+// RedactsResponses 判断分组是否需要对最终响应脱敏
+func (s *GuardrailService) RedactsResponses(group *Group) bool {
+	cfg := guardrailConfigForGroup(group)
+	return s != nil && cfg != nil && cfg.RedactResponses && len(cfg.RedactPII) > 0
+}
+
+// RedactResponse 让护栏链中实现了 GuardrailResponseFilter 的过滤器依次改写非流式 JSON 响应的文本字段；
+// 非 JSON 响应原样返回
+func (s *GuardrailService) RedactResponse(group *Group, body []byte) []byte {
+	if !s.RedactsResponses(group) {
+		return body
+	}
+	var filters []GuardrailResponseFilter
+	for _, filter := range s.buildChain(group.Guardrails) {
+		if rf, ok := filter.(GuardrailResponseFilter); ok {
+			filters = append(filters, rf)
+		}
+	}
+	var payload any
+	if len(filters) == 0 || decodeGuardrailJSON(body, &payload) != nil {
+		return body
+	}
+	changed := walkGuardrailText(payload, func(text string) string {
+		for _, filter := range filters {
+			text = filter.FilterResponseText(text)
+		}
+		return text
+	})
+	if !changed {
+		return body
+	}
+	out, err := encodeGuardrailJSON(payload)
+	if err != nil {
+		return body
+	}
+	recordGuardrailAction("chain", "response_modified")
+	return out
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// maxGuardrailStreamPending 单个内容块缓冲的文本上限，超过后即使没有换行也整体脱敏输出
+const maxGuardrailStreamPending = 2048
+
+// GuardrailStreamRedactor 对流式（SSE）响应逐事件脱敏。
+// 模型按 token 输出，邮箱、手机号等 PII 往往被拆分到多个文本增量中，因此文本增量事件按内容块缓冲，
+// 遇到换行、内容块切换、其他事件或缓冲超过上限时才合并脱敏，并以最后一个增量事件为模板输出；
+// 其他事件（块开始/结束、完成事件中的全文等）按非流式规则整体脱敏后输出。
+type GuardrailStreamRedactor struct {
+	svc   *GuardrailService
+	group *Group
+
+	pendingKey string
+	pending    strings.Builder
+	template   *guardrailStreamEvent
+}
+
+// guardrailStreamEvent 是一个 SSE 事件：lines 为事件的各行，dataIdx 指向 data 行
+type guardrailStreamEvent struct {
+	lines    [][]byte
+	dataIdx  int
+	data     []byte
+	textPath string
+}
+
+// NewStreamRedactor 为分组创建流式响应脱敏器；分组未启用响应脱敏时返回 nil
+func (s *GuardrailService) NewStreamRedactor(group *Group) *GuardrailStreamRedactor {
+	if !s.RedactsResponses(group) {
+		return nil
+	}
+	return &GuardrailStreamRedactor{svc: s, group: group}
+}
+
+// Process 处理一个完整的 SSE 事件（不含结尾空行），返回应写出的事件列表（可能为空）
+func (r *GuardrailStreamRedactor) Process(block []byte) [][]byte {
+	event := parseGuardrailStreamEvent(block)
+	if event == nil {
+		return append(r.Flush(), block)
+	}
+
+	key, textPath, ok := guardrailStreamTextDelta(event.data)
+	if !ok {
+		out := r.Flush()
+		event.data = r.svc.RedactResponse(r.group, event.data)
+		return append(out, event.render())
+	}
+
+	var out [][]byte
+	if r.pendingKey != key {
+		out = r.Flush()
+	}
+	event.textPath = textPath
+	r.pendingKey = key
+	r.template = event
+	r.pending.WriteString(gjson.GetBytes(event.data, textPath).String())
+
+	pending := r.pending.String()
+	if idx := strings.LastIndexByte(pending, '\n'); idx >= 0 {
+		// PII 规则不跨行，换行之前的文本可以安全输出
+		r.pending.Reset()
+		r.pending.WriteString(pending[idx+1:])
+		return append(out, r.renderText(pending[:idx+1]))
+	}
+	if len(pending) > maxGuardrailStreamPending {
+		return append(out, r.Flush()...)
+	}
+	return out
+}
+
+// Flush 输出当前内容块缓冲的文本；响应结束时必须调用
+func (r *GuardrailStreamRedactor) Flush() [][]byte {
+	if r.template == nil {
+		return nil
+	}
+	text := r.pending.String()
+	r.pending.Reset()
+	r.pendingKey = ""
+	var out [][]byte
+	if text != "" {
+		out = append(out, r.renderText(text))
+	}
+	r.template = nil
+	return out
+}
+
+func (r *GuardrailStreamRedactor) renderText(text string) []byte {
+	redacted := r.svc.redactText(r.group, text)
+	event := *r.template
+	data, err := sjson.SetBytes(event.data, event.textPath, redacted)
+	if err == nil {
+		event.data = data
+	}
+	return event.render()
+}
+
+// redactText 用护栏链中的响应过滤器改写单段文本
+func (s *GuardrailService) redactText(group *Group, text string) string {
+	for _, filter := range s.buildChain(group.Guardrails) {
+		if rf, ok := filter.(GuardrailResponseFilter); ok {
+			text = rf.FilterResponseText(text)
+		}
+	}
+	return text
+}
+
+// guardrailStreamTextDelta 识别各协议的文本增量事件，返回内容块标识与文本字段路径。
+// 思考内容、工具参数等非文本增量不在此列，按普通事件处理。
+func guardrailStreamTextDelta(data []byte) (key, path string, ok bool) {
+	switch gjson.GetBytes(data, "type").String() {
+	case "content_block_delta": // Anthropic Messages
+		if gjson.GetBytes(data, "delta.type").String() == "text_delta" {
+			return "messages:" + gjson.GetBytes(data, "index").Raw, "delta.text", true
+		}
+		return "", "", false
+	case "response.output_text.delta": // OpenAI Responses
+		return "responses:" + gjson.GetBytes(data, "item_id").String() + ":" + gjson.GetBytes(data, "content_index").Raw, "delta", true
+	}
+
+	// OpenAI Chat Completions
+	if choices := gjson.GetBytes(data, "choices").Array(); len(choices) == 1 {
+		if content := choices[0].Get("delta.content"); content.Type == gjson.String {
+			return "chat:" + strconv.FormatInt(choices[0].Get("index").Int(), 10), "choices.0.delta.content", true
+		}
+		return "", "", false
+	}
+
+	// Gemini：仅处理单候选、单个非思考文本分片的增量
+	if candidates := gjson.GetBytes(data, "candidates").Array(); len(candidates) == 1 {
+		parts := candidates[0].Get("content.parts").Array()
+		if len(parts) == 1 && parts[0].Get("text").Type == gjson.String && !parts[0].Get("thought").Bool() {
+			return "gemini", "candidates.0.content.parts.0.text", true
+		}
+	}
+	return "", "", false
+}
+
+// parseGuardrailStreamEvent 解析 SSE 事件，没有 JSON data 行（注释、[DONE] 等）时返回 nil。
+// 文本增量事件会被缓冲为模板，因此复制一份，避免引用调用方复用的缓冲区
+func parseGuardrailStreamEvent(block []byte) *guardrailStreamEvent {
+	lines := bytes.Split(bytes.Clone(block), []byte("\n"))
+	for i, line := range lines {
+		payload, ok := bytes.CutPrefix(bytes.TrimRight(line, "\r"), []byte("data:"))
+		if !ok {
+			continue
+		}
+		payload = bytes.TrimSpace(payload)
+		if !gjson.ValidBytes(payload) || !bytes.HasPrefix(payload, []byte("{")) {
+			return nil
+		}
+		return &guardrailStreamEvent{lines: lines, dataIdx: i, data: payload}
+	}
+	return nil
+}
+
+func (e *guardrailStreamEvent) render() []byte {
+	lines := make([][]byte, len(e.lines))
+	copy(lines, e.lines)
+	line := append([]byte("data: "), e.data...)
+	if bytes.HasSuffix(e.lines[e.dataIdx], []byte("\r")) {
+		line = append(line, '\r')
+	}
+	lines[e.dataIdx] = line
+	return bytes.Join(lines, []byte("\n"))
+}
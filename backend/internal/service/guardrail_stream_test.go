@@ -0,0 +1,78 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// streamText 将脱敏器输出的事件按文本字段拼接
+func streamText(events [][]byte, path string) string {
+	var sb strings.Builder
+	for _, event := range events {
+		data, _ := strings.CutPrefix(string(event), "data: ")
+		sb.WriteString(gjson.Get(data, path).String())
+	}
+	return sb.String()
+}
+
+func TestGuardrailStreamRedactor(t *testing.T) {
+	svc := NewGuardrailService()
+	require.Nil(t, svc.NewStreamRedactor(guardrailGroup(GuardrailConfig{RedactPII: []string{GuardrailPIIEmail}})))
+	group := guardrailGroup(GuardrailConfig{RedactPII: []string{GuardrailPIIEmail, GuardrailPIIPhone}, RedactResponses: true})
+
+	t.Run("responses deltas flush at newline", func(t *testing.T) {
+		r := svc.NewStreamRedactor(group)
+		delta := func(text string) []byte {
+			return []byte(`data: {"type":"response.output_text.delta","item_id":"msg_1","content_index":0,"delta":` + jsonString(text) + `}`)
+		}
+		require.Empty(t, r.Process(delta("call 1380013")))
+		out := r.Process(delta("8000\nmail a@"))
+		require.Len(t, out, 1)
+		require.Equal(t, "call [REDACTED_PHONE]\n", gjson.GetBytes(out[0][len("data: "):], "delta").String())
+
+		out = r.Process(delta("b.com"))
+		require.Empty(t, out)
+		out = r.Process([]byte(`data: {"type":"response.output_text.done","item_id":"msg_1","text":"call 13800138000\nmail a@b.com"}`))
+		require.Len(t, out, 2)
+		require.Equal(t, "mail [REDACTED_EMAIL]", gjson.GetBytes(out[0][len("data: "):], "delta").String())
+		require.Equal(t, "call [REDACTED_PHONE]\nmail [REDACTED_EMAIL]", gjson.GetBytes(out[1][len("data: "):], "text").String())
+	})
+
+	t.Run("gemini chunks", func(t *testing.T) {
+		r := svc.NewStreamRedactor(group)
+		var events [][]byte
+		for _, text := range []string{"x@", "y.o", "rg"} {
+			events = append(events, r.Process([]byte(`data: {"candidates":[{"content":{"role":"model","parts":[{"text":"`+text+`"}]}}]}`))...)
+		}
+		events = append(events, r.Flush()...)
+		require.Len(t, events, 1)
+		require.Equal(t, "[REDACTED_EMAIL]", streamText(events, "candidates.0.content.parts.0.text"))
+	})
+
+	t.Run("chat chunks and non json events", func(t *testing.T) {
+		r := svc.NewStreamRedactor(group)
+		var events [][]byte
+		for _, text := range []string{"a@", "b.com"} {
+			events = append(events, r.Process([]byte(`data: {"choices":[{"index":0,"delta":{"content":"`+text+`"}}]}`))...)
+		}
+		events = append(events, r.Process([]byte(`data: {"choices":[{"index":0,"delta":{},"finish_reason":"stop"}]}`))...)
+		events = append(events, r.Process([]byte(`data: [DONE]`))...)
+		require.Len(t, events, 3)
+		require.Equal(t, "[REDACTED_EMAIL]", streamText(events[:1], "choices.0.delta.content"))
+		require.Equal(t, "data: [DONE]", string(events[2]))
+	})
+
+	t.Run("thinking deltas pass through", func(t *testing.T) {
+		r := svc.NewStreamRedactor(group)
+		block := []byte("event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"thinking_delta\",\"thinking\":\"a@b.com\"}}")
+		require.Equal(t, [][]byte{block}, r.Process(block))
+	})
+}
+
+func jsonString(s string) string {
+	out, _ := encodeGuardrailJSON(s)
+	return string(out)
+}
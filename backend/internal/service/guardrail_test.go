@@ -0,0 +1,237 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func guardrailGroup(cfg GuardrailConfig) *Group {
+	cfg.Enabled = true
+	return &Group{ID: 1, Guardrails: &cfg}
+}
+
+func TestGuardrailService_ApplyRequest_Disabled(t *testing.T) {
+	svc := NewGuardrailService()
+	body := []byte(`{"messages":[{"role":"user","content":"secret"}]}`)
+
+	out, violation, err := svc.ApplyRequest(nil, GuardrailFormatMessages, body)
+	require.NoError(t, err)
+	require.Nil(t, violation)
+	require.Nil(t, out)
+
+	group := &Group{Guardrails: &GuardrailConfig{DenyKeywords: []string{"secret"}}}
+	out, violation, err = svc.ApplyRequest(group, GuardrailFormatMessages, body)
+	require.NoError(t, err)
+	require.Nil(t, violation, "disabled config is ignored")
+	require.Nil(t, out)
+
+	var nilSvc *GuardrailService
+	out, violation, err = nilSvc.ApplyRequest(guardrailGroup(GuardrailConfig{DenyKeywords: []string{"secret"}}), GuardrailFormatMessages, body)
+	require.NoError(t, err)
+	require.Nil(t, violation)
+	require.Nil(t, out)
+}
+
+func TestGuardrailService_ApplyRequest_DenyList(t *testing.T) {
+	svc := NewGuardrailService()
+	group := guardrailGroup(GuardrailConfig{
+		DenyKeywords: []string{"  Forbidden Topic "},
+		DenyPatterns: []string{`(?i)drop\s+table`},
+	})
+
+	_, violation, err := svc.ApplyRequest(group, GuardrailFormatMessages,
+		[]byte(`{"messages":[{"role":"user","content":[{"type":"text","text":"tell me about the FORBIDDEN topic"}]}]}`))
+	require.NoError(t, err)
+	require.NotNil(t, violation)
+	require.Equal(t, "deny_list", violation.Filter)
+	require.Equal(t, "forbidden topic", violation.Rule)
+	require.NotContains(t, violation.Message, "forbidden", "matched rule is not exposed to the client")
+
+	_, violation, err = svc.ApplyRequest(group, GuardrailFormatResponses, []byte(`{"input":"please Drop   Table users"}`))
+	require.NoError(t, err)
+	require.NotNil(t, violation)
+	require.Equal(t, `(?i)drop\s+table`, violation.Rule)
+
+	// 非文本字段（模型名、工具定义）不参与匹配
+	out, violation, err := svc.ApplyRequest(group, GuardrailFormatMessages,
+		[]byte(`{"model":"forbidden topic","tools":[{"name":"drop table"}],"messages":[{"role":"user","content":"hello"}]}`))
+	require.NoError(t, err)
+	require.Nil(t, violation)
+	require.Nil(t, out)
+}
+
+func TestGuardrailService_ApplyRequest_MaxPrompt(t *testing.T) {
+	svc := NewGuardrailService()
+	group := guardrailGroup(GuardrailConfig{MaxPromptChars: 10, DenyKeywords: []string{"x"}})
+
+	_, violation, err := svc.ApplyRequest(group, GuardrailFormatGemini,
+		[]byte(`{"contents":[{"role":"user","parts":[{"text":"你好你好你好"},{"text":"hello"}]}]}`))
+	require.NoError(t, err)
+	require.NotNil(t, violation)
+	require.Equal(t, "max_prompt_size", violation.Filter, "size check runs before the deny list")
+
+	_, violation, err = svc.ApplyRequest(group, GuardrailFormatGemini,
+		[]byte(`{"contents":[{"role":"user","parts":[{"text":"你好你好你好"}]}]}`))
+	require.NoError(t, err)
+	require.Nil(t, violation, "limit counts runes, not bytes")
+}
+
+func TestGuardrailService_ApplyRequest_PIIRedaction(t *testing.T) {
+	svc := NewGuardrailService()
+	group := guardrailGroup(GuardrailConfig{RedactPII: []string{GuardrailPIIEmail, GuardrailPIIPhone, GuardrailPIIIDNumber}})
+
+	cases := map[string]string{
+		"mail me at john.doe+work@example.co.uk":   "mail me at [REDACTED_EMAIL]",
+		"身份证 11010519491231002X 请核对":               "身份证 [REDACTED_ID] 请核对",
+		"ssn 123-45-6789":                          "ssn [REDACTED_ID]",
+		"手机 13812345678":                           "手机 [REDACTED_PHONE]",
+		"call +86 138-1234-5678 now":               "call [REDACTED_PHONE] now",
+		"office (415) 555-2671":                    "office [REDACTED_PHONE]",
+		"version 1.2.3 costs 42 dollars, no pii!":  "version 1.2.3 costs 42 dollars, no pii!",
+		"order 202401011234 shipped on 2024-01-01": "order 202401011234 shipped on 2024-01-01",
+	}
+	for input, want := range cases {
+		t.Run(input, func(t *testing.T) {
+			filter := buildPIIRedaction(group.Guardrails)
+			require.Equal(t, want, filter.FilterResponseText(input))
+		})
+	}
+
+	out, violation, err := svc.ApplyRequest(group, GuardrailFormatMessages,
+		[]byte(`{"model":"claude-sonnet-4","max_tokens":1024,"messages":[{"role":"user","content":"my email is a@b.com <ok>"}]}`))
+	require.NoError(t, err)
+	require.Nil(t, violation)
+	require.NotNil(t, out)
+	require.Equal(t, "my email is [REDACTED_EMAIL] <ok>", gjson.GetBytes(out, "messages.0.content").String())
+	require.Equal(t, "1024", gjson.GetBytes(out, "max_tokens").Raw, "numbers keep their original form")
+	require.NotContains(t, string(out), `\u003c`, "html characters are not escaped")
+
+	// 仅启用邮箱时不处理手机号
+	emailOnly := guardrailGroup(GuardrailConfig{RedactPII: []string{GuardrailPIIEmail}})
+	out, _, err = svc.ApplyRequest(emailOnly, GuardrailFormatMessages, []byte(`{"messages":[{"role":"user","content":"13812345678"}]}`))
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestGuardrailService_ApplyRequest_SystemPrompt(t *testing.T) {
+	svc := NewGuardrailService()
+	group := guardrailGroup(GuardrailConfig{SystemPrompt: "Follow company policy."})
+
+	out, _, err := svc.ApplyRequest(group, GuardrailFormatMessages, []byte(`{"system":"You are helpful.","messages":[]}`))
+	require.NoError(t, err)
+	require.Equal(t, "Follow company policy.\n\nYou are helpful.", gjson.GetBytes(out, "system").String())
+
+	out, _, err = svc.ApplyRequest(group, GuardrailFormatMessages,
+		[]byte(`{"system":[{"type":"text","text":"You are helpful.","cache_control":{"type":"ephemeral"}}],"messages":[]}`))
+	require.NoError(t, err)
+	require.Equal(t, "Follow company policy.", gjson.GetBytes(out, "system.0.text").String())
+	require.Equal(t, "ephemeral", gjson.GetBytes(out, "system.1.cache_control.type").String())
+
+	out, _, err = svc.ApplyRequest(group, GuardrailFormatMessages, []byte(`{"messages":[]}`))
+	require.NoError(t, err)
+	require.Equal(t, "Follow company policy.", gjson.GetBytes(out, "system").String())
+
+	out, _, err = svc.ApplyRequest(group, GuardrailFormatResponses, []byte(`{"instructions":"codex","input":"hi"}`))
+	require.NoError(t, err)
+	require.Equal(t, "codex", gjson.GetBytes(out, "instructions").String(), "instructions are left untouched")
+	require.Equal(t, "developer", gjson.GetBytes(out, "input.0.role").String())
+	require.Equal(t, "Follow company policy.", gjson.GetBytes(out, "input.0.content.0.text").String())
+	require.Equal(t, "hi", gjson.GetBytes(out, "input.1.content.0.text").String())
+
+	out, _, err = svc.ApplyRequest(group, GuardrailFormatResponses, []byte(`{"input":[{"type":"message","role":"user","content":"hi"}]}`))
+	require.NoError(t, err)
+	require.Len(t, gjson.GetBytes(out, "input").Array(), 2)
+
+	out, _, err = svc.ApplyRequest(group, GuardrailFormatGemini,
+		[]byte(`{"system_instruction":{"parts":[{"text":"be brief"}]},"contents":[]}`))
+	require.NoError(t, err)
+	require.Equal(t, "Follow company policy.", gjson.GetBytes(out, "system_instruction.parts.0.text").String())
+	require.Equal(t, "be brief", gjson.GetBytes(out, "system_instruction.parts.1.text").String())
+	require.False(t, gjson.GetBytes(out, "systemInstruction").Exists())
+
+	out, _, err = svc.ApplyRequest(group, GuardrailFormatGemini, []byte(`{"contents":[]}`))
+	require.NoError(t, err)
+	require.Equal(t, "Follow company policy.", gjson.GetBytes(out, "systemInstruction.parts.0.text").String())
+}
+
+func TestGuardrailService_ApplyRequest_ChainOrder(t *testing.T) {
+	svc := NewGuardrailService()
+	// 系统提示词最后注入，不受拒绝列表与长度限制影响；拒绝列表匹配脱敏前的原文
+	group := guardrailGroup(GuardrailConfig{
+		DenyKeywords:   []string{"forbidden", "@internal.example"},
+		RedactPII:      []string{GuardrailPIIEmail},
+		MaxPromptChars: 40,
+		SystemPrompt:   "This prompt mentions forbidden words and is longer than forty characters.",
+	})
+
+	out, violation, err := svc.ApplyRequest(group, GuardrailFormatMessages, []byte(`{"messages":[{"role":"user","content":"hi a@b.com"}]}`))
+	require.NoError(t, err)
+	require.Nil(t, violation)
+	require.Equal(t, "hi [REDACTED_EMAIL]", gjson.GetBytes(out, "messages.0.content").String())
+	require.True(t, strings.HasPrefix(gjson.GetBytes(out, "system").String(), "This prompt"))
+
+	_, violation, err = svc.ApplyRequest(group, GuardrailFormatMessages, []byte(`{"messages":[{"role":"user","content":"bob@internal.example"}]}`))
+	require.NoError(t, err)
+	require.NotNil(t, violation)
+	require.Equal(t, "@internal.example", violation.Rule)
+}
+
+func TestGuardrailService_ApplyRequest_InvalidBody(t *testing.T) {
+	svc := NewGuardrailService()
+	_, _, err := svc.ApplyRequest(guardrailGroup(GuardrailConfig{MaxPromptChars: 1}), GuardrailFormatMessages, []byte(`[1,2]`))
+	require.Error(t, err)
+}
+
+func TestGuardrailService_RedactResponse(t *testing.T) {
+	svc := NewGuardrailService()
+	body := []byte(`{"id":"msg_1","content":[{"type":"text","text":"contact a@b.com"}],"usage":{"input_tokens":3}}`)
+
+	noResponses := guardrailGroup(GuardrailConfig{RedactPII: []string{GuardrailPIIEmail}})
+	require.False(t, svc.RedactsResponses(noResponses))
+	require.Equal(t, body, svc.RedactResponse(noResponses, body))
+
+	group := guardrailGroup(GuardrailConfig{RedactPII: []string{GuardrailPIIEmail}, RedactResponses: true})
+	require.True(t, svc.RedactsResponses(group))
+	out := svc.RedactResponse(group, body)
+	require.Equal(t, "contact [REDACTED_EMAIL]", gjson.GetBytes(out, "content.0.text").String())
+	require.Equal(t, "msg_1", gjson.GetBytes(out, "id").String())
+
+	gemini := []byte(`{"candidates":[{"content":{"parts":[{"text":"x@y.org"}]}}]}`)
+	require.Equal(t, "[REDACTED_EMAIL]", gjson.GetBytes(svc.RedactResponse(group, gemini), "candidates.0.content.parts.0.text").String())
+
+	clean := []byte(`{"content":[{"type":"text","text":"nothing here"}]}`)
+	require.Equal(t, clean, svc.RedactResponse(group, clean))
+	require.Equal(t, []byte("not json"), svc.RedactResponse(group, []byte("not json")))
+}
+
+func TestGuardrailConfig_Validate(t *testing.T) {
+	var nilCfg *GuardrailConfig
+	require.NoError(t, nilCfg.Validate())
+	require.NoError(t, (&GuardrailConfig{
+		Enabled:        true,
+		DenyPatterns:   []string{`\bfoo\b`},
+		RedactPII:      []string{GuardrailPIIEmail, GuardrailPIIPhone, GuardrailPIIIDNumber},
+		MaxPromptChars: 1000,
+	}).Validate())
+
+	tooMany := make([]string, maxGuardrailDenyRules+1)
+	for i := range tooMany {
+		tooMany[i] = "k"
+	}
+	cases := map[string]*GuardrailConfig{
+		"invalid pattern":   {DenyPatterns: []string{"(unclosed"}},
+		"unknown pii":       {RedactPII: []string{"passport"}},
+		"negative max":      {MaxPromptChars: -1},
+		"too many rules":    {DenyKeywords: tooMany},
+		"prompt too long":   {SystemPrompt: strings.Repeat("a", maxGuardrailSystemPromptLen+1)},
+		"pattern lookahead": {DenyPatterns: []string{`foo(?=bar)`}},
+	}
+	for name, cfg := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, cfg.Validate())
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HedgeDelayMaxMs 分组对冲延迟上限（毫秒）
+const HedgeDelayMaxMs = 60000
+
+// 对冲事件（sub2api_gateway_hedges_total 的 outcome 标签）
+const (
+	hedgeOutcomeFired      = "fired"       // 已向备用账号发起对冲请求
+	hedgeOutcomeNoBackup   = "no_backup"   // 需要对冲但没有可立即使用的备用账号
+	hedgeOutcomePrimaryWon = "primary_won" // 对冲后主账号先返回
+	hedgeOutcomeBackupWon  = "backup_won"  // 对冲后备用账号先返回
+)
+
+// errHedgeLost 对冲竞争失败的请求写入客户端时返回，使其尽快结束
+var errHedgeLost = errors.New("hedged request lost the race")
+
+// validateHedgeDelay 校验分组对冲延迟：0 表示关闭
+func validateHedgeDelay(ms int) error {
+	if ms < 0 || ms > HedgeDelayMaxMs {
+		return fmt.Errorf("hedge_delay_ms must be between 0 and %d", HedgeDelayMaxMs)
+	}
+	return nil
+}
+
+// HedgeDelay 返回分组对冲延迟，0 表示未启用
+func (g *Group) HedgeDelay() time.Duration {
+	if g == nil || g.HedgeDelayMs <= 0 {
+		return 0
+	}
+	return time.Duration(g.HedgeDelayMs) * time.Millisecond
+}
+
+// HedgeOptions 对冲转发参数
+type HedgeOptions struct {
+	// Delay 主账号在该时间内没有向客户端输出任何内容时发起对冲请求，0 表示不对冲
+	Delay time.Duration
+	// SelectBackup 选择备用账号并立即获取槽位（不排队），无可用账号时返回 nil；
+	// 槽位在备用请求结束后由 ForwardHedged 释放
+	SelectBackup func(ctx context.Context, primary *Account) (*AccountSelectionResult, error)
+	// RecordResult 记录未被返回的那次尝试的结果（用于账号熔断统计），可为 nil
+	RecordResult func(c *gin.Context, account *Account, err error)
+}
+
+// HedgeOutcome 对冲转发结果
+type HedgeOutcome struct {
+	Account   *Account // 返回结果对应的账号，计费与故障转移以此为准
+	Hedged    bool     // 是否发起了对冲请求
+	BackupWon bool     // 是否由备用账号胜出
+}
+
+type hedgeAttempt[T any] struct {
+	index   int
+	account *Account
+	ctx     *gin.Context
+	cancel  context.CancelCauseFunc
+	result  T
+	err     error
+}
+
+// ForwardHedged 以对冲方式执行 forward：主账号超过 Delay 未输出首字节时，
+// 向 SelectBackup 选出的第二个账号发起相同请求，先向客户端输出的一方胜出并继续流式返回，另一方被取消。
+//
+// 每次尝试使用独立的 gin.Context 副本（独立 Writer 与 Keys），胜出尝试的 Keys 会合并回 c；
+// 两次尝试都未输出时返回主账号的结果。返回前会等待所有尝试结束，不会在返回后继续写入客户端。
+func ForwardHedged[T any](
+	c *gin.Context,
+	primary *Account,
+	opts HedgeOptions,
+	forward func(ctx context.Context, c *gin.Context, account *Account) (T, error),
+) (T, HedgeOutcome, error) {
+	if opts.Delay <= 0 || opts.SelectBackup == nil {
+		result, err := forward(c.Request.Context(), c, primary)
+		return result, HedgeOutcome{Account: primary}, err
+	}
+
+	arbiter := &hedgeArbiter{winner: -1, claimed: make(chan struct{})}
+	done := make(chan *hedgeAttempt[T], 2)
+	var attempts []*hedgeAttempt[T]
+	start := func(account *Account, release func()) {
+		// 以 errHedgeLost 取消失败方，与客户端断开区分（客户端断开时上游可继续排空）
+		ctx, cancel := context.WithCancelCause(c.Request.Context())
+		hc := c.Copy()
+		hc.Request = c.Request.WithContext(ctx)
+		hc.Writer = newHedgeResponseWriter(c.Writer, arbiter, len(attempts))
+		attempt := &hedgeAttempt[T]{index: len(attempts), account: account, ctx: hc, cancel: cancel}
+		attempts = append(attempts, attempt)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("[Hedge] forward panic: account=%d panic=%v\n%s", account.ID, r, debug.Stack())
+					attempt.err = fmt.Errorf("hedged forward panic: %v", r)
+				}
+				if release != nil {
+					release()
+				}
+				done <- attempt
+			}()
+			attempt.result, attempt.err = forward(ctx, hc, account)
+		}()
+	}
+	cancelLosers := func() {
+		winner := arbiter.winnerIndex()
+		for _, a := range attempts {
+			if a.index != winner {
+				a.cancel(errHedgeLost)
+			}
+		}
+	}
+
+	start(primary, nil)
+	timer := time.NewTimer(opts.Delay)
+	defer timer.Stop()
+	timerC := timer.C
+	claimedC := arbiter.claimed
+
+	for finished := 0; finished < len(attempts); {
+		select {
+		case a := <-done:
+			finished++
+			timerC = nil // 主请求已结束（成功或失败），不再发起对冲
+			if a.err == nil && arbiter.claim(a.index) {
+				// 成功但未输出任何内容（例如空响应）同样视为胜出
+				cancelLosers()
+			}
+		case <-claimedC:
+			claimedC = nil
+			timerC = nil
+			cancelLosers()
+		case <-timerC:
+			timerC = nil
+			if arbiter.winnerIndex() >= 0 {
+				continue
+			}
+			selection, err := opts.SelectBackup(c.Request.Context(), primary)
+			if err != nil || selection == nil || !selection.Acquired || selection.Account == nil {
+				recordHedgeEvent(primary.Platform, hedgeOutcomeNoBackup)
+				continue
+			}
+			recordHedgeEvent(primary.Platform, hedgeOutcomeFired)
+			start(selection.Account, selection.ReleaseFunc)
+			if arbiter.winnerIndex() >= 0 {
+				cancelLosers()
+			}
+		}
+	}
+
+	chosen := attempts[0]
+	if winner := arbiter.winnerIndex(); winner >= 0 {
+		chosen = attempts[winner]
+	}
+	for _, a := range attempts {
+		a.cancel(errHedgeLost)
+		if a != chosen && opts.RecordResult != nil {
+			opts.RecordResult(a.ctx, a.account, a.err)
+		}
+	}
+	for k, v := range chosen.ctx.Keys {
+		c.Set(k, v)
+	}
+
+	outcome := HedgeOutcome{
+		Account:   chosen.account,
+		Hedged:    len(attempts) > 1,
+		BackupWon: chosen.index > 0,
+	}
+	if outcome.Hedged {
+		if outcome.BackupWon {
+			recordHedgeEvent(primary.Platform, hedgeOutcomeBackupWon)
+		} else {
+			recordHedgeEvent(primary.Platform, hedgeOutcomePrimaryWon)
+		}
+		log.Printf("[Hedge] primary=%d backup=%d winner=%d", primary.ID, attempts[1].account.ID, chosen.account.ID)
+	}
+	return chosen.result, outcome, chosen.err
+}
+
+// hedgeArbiter 决定哪次尝试可以向客户端输出：第一个输出的尝试胜出
+type hedgeArbiter struct {
+	mu      sync.Mutex
+	winner  int
+	claimed chan struct{}
+}
+
+// claim 尝试成为胜出者；已是胜出者时返回 true
+func (a *hedgeArbiter) claim(index int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.winner < 0 {
+		a.winner = index
+		close(a.claimed)
+		return true
+	}
+	return a.winner == index
+}
+
+func (a *hedgeArbiter) winnerIndex() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.winner
+}
+
+// hedgeResponseWriter 单次对冲尝试的 Writer：胜出前响应头与状态码暂存在本地，
+// 首次写入响应体或 Flush 时向仲裁器申请输出权，胜出后直接写入客户端，失败则丢弃写入并返回错误。
+type hedgeResponseWriter struct {
+	gin.ResponseWriter
+
+	arbiter *hedgeArbiter
+	index   int
+	header  http.Header
+	status  int
+	won     bool
+}
+
+func newHedgeResponseWriter(w gin.ResponseWriter, arbiter *hedgeArbiter, index int) *hedgeResponseWriter {
+	return &hedgeResponseWriter{
+		ResponseWriter: w,
+		arbiter:        arbiter,
+		index:          index,
+		header:         w.Header().Clone(),
+	}
+}
+
+// acquire 申请输出权，胜出时将暂存的响应头与状态码应用到客户端 Writer
+func (w *hedgeResponseWriter) acquire() bool {
+	if w.won {
+		return true
+	}
+	if !w.arbiter.claim(w.index) {
+		return false
+	}
+	w.won = true
+	dst := w.ResponseWriter.Header()
+	for k := range dst {
+		if _, ok := w.header[k]; !ok {
+			delete(dst, k)
+		}
+	}
+	for k, v := range w.header {
+		dst[k] = v
+	}
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	return true
+}
+
+func (w *hedgeResponseWriter) Header() http.Header {
+	if w.won {
+		return w.ResponseWriter.Header()
+	}
+	return w.header
+}
+
+func (w *hedgeResponseWriter) WriteHeader(code int) {
+	if w.won {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+func (w *hedgeResponseWriter) WriteHeaderNow() {
+	if w.acquire() {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *hedgeResponseWriter) Write(data []byte) (int, error) {
+	if !w.acquire() {
+		return 0, errHedgeLost
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *hedgeResponseWriter) WriteString(s string) (int, error) {
+	if !w.acquire() {
+		return 0, errHedgeLost
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *hedgeResponseWriter) Flush() {
+	if w.acquire() {
+		w.ResponseWriter.Flush()
+	}
+}
+
+func (w *hedgeResponseWriter) Status() int {
+	if w.won {
+		return w.ResponseWriter.Status()
+	}
+	if w.status != 0 {
+		return w.status
+	}
+	return http.StatusOK
+}
+
+func (w *hedgeResponseWriter) Size() int {
+	if w.won {
+		return w.ResponseWriter.Size()
+	}
+	return -1
+}
+
+func (w *hedgeResponseWriter) Written() bool {
+	return w.won && w.ResponseWriter.Written()
+}
+
+// SelectHedgeAccount 为对冲请求选择备用账号：复用负载感知选择与排除列表（额外排除主账号），
+// 不使用粘性会话，只接受可立即获取槽位的账号，否则返回 nil
+func (s *GatewayService) SelectHedgeAccount(ctx context.Context, groupID *int64, requestedModel string, excludedIDs map[int64]struct{}, primaryID int64) (*AccountSelectionResult, error) {
+	selection, err := s.SelectAccountWithLoadAwareness(ctx, groupID, "", requestedModel, hedgeExclusions(excludedIDs, primaryID), "")
+	if err != nil {
+		return nil, err
+	}
+	return acquiredHedgeSelection(selection), nil
+}
+
+// SelectHedgeAccount 为对冲请求选择备用账号，规则同 GatewayService.SelectHedgeAccount
+func (s *OpenAIGatewayService) SelectHedgeAccount(ctx context.Context, groupID *int64, requestedModel string, excludedIDs map[int64]struct{}, primaryID int64) (*AccountSelectionResult, error) {
+	selection, err := s.SelectAccountWithLoadAwareness(ctx, groupID, "", requestedModel, hedgeExclusions(excludedIDs, primaryID))
+	if err != nil {
+		return nil, err
+	}
+	return acquiredHedgeSelection(selection), nil
+}
+
+func hedgeExclusions(excludedIDs map[int64]struct{}, primaryID int64) map[int64]struct{} {
+	excluded := make(map[int64]struct{}, len(excludedIDs)+1)
+	for id := range excludedIDs {
+		excluded[id] = struct{}{}
+	}
+	excluded[primaryID] = struct{}{}
+	return excluded
+}
+
+func acquiredHedgeSelection(selection *AccountSelectionResult) *AccountSelectionResult {
+	if selection == nil || !selection.Acquired || selection.Account == nil {
+		return nil
+	}
+	return selection
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newHedgeTestContext() (*gin.Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+	return c, rec
+}
+
+type hedgeRecorder struct {
+	mu       sync.Mutex
+	selects  int
+	released int
+	results  map[int64]error
+}
+
+func (r *hedgeRecorder) options(delay time.Duration, backup *Account) HedgeOptions {
+	return HedgeOptions{
+		Delay: delay,
+		SelectBackup: func(ctx context.Context, primary *Account) (*AccountSelectionResult, error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.selects++
+			if backup == nil {
+				return nil, nil
+			}
+			return &AccountSelectionResult{Account: backup, Acquired: true, ReleaseFunc: func() {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				r.released++
+			}}, nil
+		},
+		RecordResult: func(c *gin.Context, account *Account, err error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if r.results == nil {
+				r.results = make(map[int64]error)
+			}
+			r.results[account.ID] = err
+		},
+	}
+}
+
+func TestForwardHedged_SlowPrimaryBackupWins(t *testing.T) {
+	c, rec := newHedgeTestContext()
+	primary := &Account{ID: 1, Platform: PlatformAnthropic}
+	backup := &Account{ID: 2, Platform: PlatformAnthropic}
+	recorder := &hedgeRecorder{}
+	backupWrote := make(chan struct{})
+
+	var primaryWriteErr error
+	result, outcome, err := ForwardHedged(c, primary, recorder.options(20*time.Millisecond, backup),
+		func(ctx context.Context, c *gin.Context, account *Account) (string, error) {
+			if account.ID == primary.ID {
+				c.Header("X-Account", "primary")
+				<-backupWrote
+				_, primaryWriteErr = c.Writer.Write([]byte("primary"))
+				<-ctx.Done()
+				return "", ctx.Err()
+			}
+			c.Header("X-Account", "backup")
+			c.Status(http.StatusCreated)
+			_, err := c.Writer.Write([]byte("backup"))
+			close(backupWrote)
+			c.Set("hedge_test_key", "backup")
+			return "backup", err
+		})
+
+	require.NoError(t, err)
+	require.Equal(t, "backup", result)
+	require.Equal(t, HedgeOutcome{Account: backup, Hedged: true, BackupWon: true}, outcome)
+	require.ErrorIs(t, primaryWriteErr, errHedgeLost)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, "backup", rec.Body.String())
+	require.Equal(t, "backup", rec.Header().Get("X-Account"))
+	v, ok := c.Get("hedge_test_key")
+	require.True(t, ok, "winner keys are merged back")
+	require.Equal(t, "backup", v)
+
+	require.Equal(t, 1, recorder.released, "backup slot released")
+	require.Len(t, recorder.results, 1)
+	require.ErrorIs(t, recorder.results[primary.ID], context.Canceled, "loser is canceled and recorded")
+}
+
+func TestForwardHedged_FastPrimaryDoesNotHedge(t *testing.T) {
+	c, rec := newHedgeTestContext()
+	primary := &Account{ID: 1}
+	recorder := &hedgeRecorder{}
+
+	result, outcome, err := ForwardHedged(c, primary, recorder.options(200*time.Millisecond, &Account{ID: 2}),
+		func(ctx context.Context, c *gin.Context, account *Account) (int64, error) {
+			c.String(http.StatusOK, "ok")
+			return account.ID, nil
+		})
+
+	require.NoError(t, err)
+	require.Equal(t, primary.ID, result)
+	require.Equal(t, HedgeOutcome{Account: primary}, outcome)
+	require.Equal(t, "ok", rec.Body.String())
+	require.Zero(t, recorder.selects)
+	require.Empty(t, recorder.results)
+}
+
+func TestForwardHedged_NoBackupWaitsForPrimary(t *testing.T) {
+	c, rec := newHedgeTestContext()
+	primary := &Account{ID: 1}
+	recorder := &hedgeRecorder{}
+
+	result, outcome, err := ForwardHedged(c, primary, recorder.options(10*time.Millisecond, nil),
+		func(ctx context.Context, c *gin.Context, account *Account) (int64, error) {
+			time.Sleep(40 * time.Millisecond)
+			c.String(http.StatusOK, "late")
+			return account.ID, nil
+		})
+
+	require.NoError(t, err)
+	require.Equal(t, primary.ID, result)
+	require.False(t, outcome.Hedged)
+	require.Equal(t, "late", rec.Body.String())
+	require.Equal(t, 1, recorder.selects)
+}
+
+func TestForwardHedged_BothFailReturnsPrimaryError(t *testing.T) {
+	c, rec := newHedgeTestContext()
+	primary := &Account{ID: 1}
+	backup := &Account{ID: 2}
+	recorder := &hedgeRecorder{}
+	backupErr := errors.New("backup failed")
+
+	_, outcome, err := ForwardHedged(c, primary, recorder.options(10*time.Millisecond, backup),
+		func(ctx context.Context, c *gin.Context, account *Account) (struct{}, error) {
+			if account.ID == backup.ID {
+				return struct{}{}, backupErr
+			}
+			time.Sleep(50 * time.Millisecond)
+			return struct{}{}, &UpstreamFailoverError{StatusCode: http.StatusBadGateway}
+		})
+
+	var failoverErr *UpstreamFailoverError
+	require.ErrorAs(t, err, &failoverErr)
+	require.Equal(t, primary, outcome.Account, "failover continues from the primary account")
+	require.True(t, outcome.Hedged)
+	require.False(t, outcome.BackupWon)
+	require.Zero(t, rec.Body.Len())
+	require.Equal(t, backupErr, recorder.results[backup.ID])
+	require.Equal(t, 1, recorder.released)
+}
+
+func TestForwardHedged_DisabledCallsPrimaryDirectly(t *testing.T) {
+	c, _ := newHedgeTestContext()
+	primary := &Account{ID: 1}
+
+	var gotCtx *gin.Context
+	_, outcome, err := ForwardHedged(c, primary, HedgeOptions{},
+		func(ctx context.Context, hc *gin.Context, account *Account) (struct{}, error) {
+			gotCtx = hc
+			return struct{}{}, nil
+		})
+
+	require.NoError(t, err)
+	require.Same(t, c, gotCtx)
+	require.Equal(t, HedgeOutcome{Account: primary}, outcome)
+}
+
+func TestGroupHedgeDelay(t *testing.T) {
+	var nilGroup *Group
+	require.Zero(t, nilGroup.HedgeDelay())
+	require.Zero(t, (&Group{}).HedgeDelay())
+	require.Equal(t, 1500*time.Millisecond, (&Group{HedgeDelayMs: 1500}).HedgeDelay())
+
+	require.NoError(t, validateHedgeDelay(0))
+	require.NoError(t, validateHedgeDelay(HedgeDelayMaxMs))
+	require.Error(t, validateHedgeDelay(-1))
+	require.Error(t, validateHedgeDelay(HedgeDelayMaxMs+1))
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+// 账单状态
+const (
+	InvoiceStatusIssued = "issued"
+	InvoiceStatusVoid   = "void"
+)
+
+// 账单明细类型
+const (
+	InvoiceItemUsage        = "usage"        // 按模型/分组汇总的用量
+	InvoiceItemCredit       = "credit"       // 卡密充值、优惠码赠送等入账
+	InvoiceItemAdjustment   = "adjustment"   // 管理员余额调整（可为负）
+	InvoiceItemSubscription = "subscription" // 本期分配的订阅
+)
+
+// 账单下载格式
+const (
+	InvoiceFormatPDF  = "pdf"
+	InvoiceFormatHTML = "html"
+	InvoiceFormatCSV  = "csv"
+)
+
+// InvoiceCurrency 账单币种（计费统一为美元）
+const InvoiceCurrency = "USD"
+
+// invoicePeriodLayout 账期格式
+const invoicePeriodLayout = "2006-01"
+
+var (
+	ErrInvoiceNotFound      = infraerrors.NotFound("INVOICE_NOT_FOUND", "invoice not found")
+	ErrInvoiceExists        = infraerrors.Conflict("INVOICE_EXISTS", "an invoice for this user and period already exists; regenerate it instead")
+	ErrInvoiceVoided        = infraerrors.BadRequest("INVOICE_VOIDED", "invoice has been voided")
+	ErrInvoicePeriodInvalid = infraerrors.BadRequest("INVOICE_PERIOD_INVALID", "period must use YYYY-MM format")
+	ErrInvoicePeriodOpen    = infraerrors.BadRequest("INVOICE_PERIOD_OPEN", "invoices can only be generated for months that have ended")
+	ErrInvoiceFormat        = infraerrors.BadRequest("INVOICE_FORMAT_INVALID", "format must be pdf, html or csv")
+)
+
+// Invoice 用户月度账单
+//
+// 账单生成时即汇总当期用量、入账与订阅并落库，之后的用量或调价不会改变已出具的账单；
+// 需要更正时由管理员重新生成（Revision 递增）或作废后重新出具。
+type Invoice struct {
+	ID          int64
+	UserID      int64
+	PeriodStart time.Time
+	PeriodEnd   time.Time // 不含
+	Status      string
+	Currency    string
+
+	// OpeningBalance/ClosingBalance 期初/期末余额（来自余额账本）
+	OpeningBalance float64
+	ClosingBalance float64
+	// UsageCharges 余额扣费的用量费用（含倍率）
+	UsageCharges float64
+	// SubscriptionUsage 订阅额度覆盖的用量（标准价格，不从余额扣费）
+	SubscriptionUsage float64
+	// Credits 卡密充值、优惠码赠送等入账
+	Credits float64
+	// Adjustments 管理员余额调整合计
+	Adjustments float64
+
+	LineItems []InvoiceLineItem
+
+	Revision    int
+	GeneratedAt time.Time
+	VoidedAt    *time.Time
+	VoidedBy    *int64
+	VoidReason  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	User *User
+}
+
+// Number 账单编号，如 INV-202609-000042
+func (inv *Invoice) Number() string {
+	return fmt.Sprintf("INV-%s-%06d", inv.Period(), inv.ID)
+}
+
+// Period 账期（YYYY-MM）
+func (inv *Invoice) Period() string {
+	return inv.PeriodStart.Format(invoicePeriodLayout)
+}
+
+// IsVoid 是否已作废
+func (inv *Invoice) IsVoid() bool {
+	return inv.Status == InvoiceStatusVoid
+}
+
+// InvoiceLineItem 账单明细
+type InvoiceLineItem struct {
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+
+	// 用量明细（按模型、分组、计费方式汇总）
+	Model        string `json:"model,omitempty"`
+	GroupID      *int64 `json:"group_id,omitempty"`
+	GroupName    string `json:"group_name,omitempty"`
+	Subscription bool   `json:"subscription,omitempty"`
+	Requests     int64  `json:"requests,omitempty"`
+	InputTokens  int64  `json:"input_tokens,omitempty"`
+	OutputTokens int64  `json:"output_tokens,omitempty"`
+	CacheTokens  int64  `json:"cache_tokens,omitempty"`
+	// StandardCost 标准价格（不含倍率）
+	StandardCost float64 `json:"standard_cost,omitempty"`
+
+	// Amount 用量为余额扣费金额（订阅用量为 0），入账/调整为入账金额
+	Amount float64 `json:"amount"`
+
+	// 入账与订阅明细
+	Date      *time.Time `json:"date,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Reference string     `json:"reference,omitempty"`
+}
+
+// InvoiceFilter 账单列表过滤条件
+type InvoiceFilter struct {
+	UserID *int64
+	// Period 账期（YYYY-MM），为空表示全部
+	Period string
+	Status string
+}
+
+// InvoiceUsageRow 按 模型/分组/计费方式 汇总的当期用量
+type InvoiceUsageRow struct {
+	Model        string
+	GroupID      *int64
+	GroupName    string
+	BillingType  int8
+	Requests     int64
+	InputTokens  int64
+	OutputTokens int64
+	CacheTokens  int64
+	TotalCost    float64
+	ActualCost   float64
+}
+
+// InvoiceSubscriptionRow 当期分配的订阅
+type InvoiceSubscriptionRow struct {
+	SubscriptionID int64
+	GroupID        int64
+	GroupName      string
+	StartsAt       time.Time
+	ExpiresAt      time.Time
+	AssignedAt     time.Time
+	Notes          string
+}
+
+// InvoiceSource 生成账单所需的当期原始数据
+type InvoiceSource struct {
+	Usage         []InvoiceUsageRow
+	Transactions  []BalanceTransaction // 非用量类余额流水（充值、赠送、调整等）
+	Subscriptions []InvoiceSubscriptionRow
+	// OpeningBalance/ClosingBalance 期初/期末最后一条流水的 balance_after，无流水时为 nil
+	OpeningBalance *float64
+	ClosingBalance *float64
+}
+
+// InvoiceRepository 账单存储及账单数据汇总
+type InvoiceRepository interface {
+	// Create 创建账单；同一用户同一账期已有未作废账单时返回 ErrInvoiceExists
+	Create(ctx context.Context, invoice *Invoice) error
+	// Update 覆盖账单内容（重新生成）
+	Update(ctx context.Context, invoice *Invoice) error
+	GetByID(ctx context.Context, id int64) (*Invoice, error)
+	List(ctx context.Context, filter InvoiceFilter, params pagination.PaginationParams) ([]Invoice, *pagination.PaginationResult, error)
+	// Void 作废账单，已作废时返回 ErrInvoiceVoided
+	Void(ctx context.Context, id int64, adminID int64, reason string) error
+
+	// LoadSource 汇总用户在 [start, end) 内的用量（不含团队 API Key 用量）、余额流水与订阅分配
+	LoadSource(ctx context.Context, userID int64, start, end time.Time) (*InvoiceSource, error)
+	// ListBillableUserIDs 返回在 [start, end) 内有用量、余额流水或订阅分配，且尚无未作废账单的用户
+	ListBillableUserIDs(ctx context.Context, start, end time.Time) ([]int64, error)
+}
+
+// ParseInvoicePeriod 解析账期（YYYY-MM，按系统时区），返回月初与下月初
+func ParseInvoicePeriod(period string, loc *time.Location) (time.Time, time.Time, error) {
+	t, err := time.ParseInLocation(invoicePeriodLayout, strings.TrimSpace(period), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvoicePeriodInvalid
+	}
+	return t, t.AddDate(0, 1, 0), nil
+}
+
+// NormalizeInvoiceFormat 归一化下载格式，空值默认 PDF
+func NormalizeInvoiceFormat(format string) (string, error) {
+	switch f := strings.ToLower(strings.TrimSpace(format)); f {
+	case "":
+		return InvoiceFormatPDF, nil
+	case InvoiceFormatPDF, InvoiceFormatHTML, InvoiceFormatCSV:
+		return f, nil
+	default:
+		return "", ErrInvoiceFormat
+	}
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pdf"
+)
+
+// invoiceDocument 渲染账单所需的数据
+type invoiceDocument struct {
+	Invoice       *Invoice
+	Issuer        InvoiceIssuer
+	CustomerName  string
+	CustomerEmail string
+}
+
+const invoiceDateLayout = "2006-01-02"
+
+// invoiceSummaryLine 账单汇总行
+type invoiceSummaryLine struct {
+	Label  string
+	Amount string
+	Strong bool
+}
+
+func (d *invoiceDocument) summary() []invoiceSummaryLine {
+	inv := d.Invoice
+	lines := []invoiceSummaryLine{
+		{Label: "Opening balance", Amount: formatInvoiceMoney(inv.OpeningBalance)},
+		{Label: "Credits", Amount: formatInvoiceMoney(inv.Credits)},
+	}
+	if inv.Adjustments != 0 {
+		lines = append(lines, invoiceSummaryLine{Label: "Adjustments", Amount: formatInvoiceMoney(inv.Adjustments)})
+	}
+	lines = append(lines,
+		invoiceSummaryLine{Label: "Usage charges", Amount: formatInvoiceMoney(-inv.UsageCharges)},
+		invoiceSummaryLine{Label: "Closing balance", Amount: formatInvoiceMoney(inv.ClosingBalance), Strong: true},
+	)
+	if inv.SubscriptionUsage > 0 {
+		lines = append(lines, invoiceSummaryLine{Label: "Usage covered by subscriptions (standard price)", Amount: formatInvoiceMoney(inv.SubscriptionUsage)})
+	}
+	return lines
+}
+
+func (d *invoiceDocument) periodLabel() string {
+	inv := d.Invoice
+	return inv.PeriodStart.Format(invoiceDateLayout) + " - " + inv.PeriodEnd.AddDate(0, 0, -1).Format(invoiceDateLayout)
+}
+
+func (d *invoiceDocument) customerLabel() string {
+	switch {
+	case d.CustomerName != "" && d.CustomerEmail != "":
+		return d.CustomerName + " <" + d.CustomerEmail + ">"
+	case d.CustomerEmail != "":
+		return d.CustomerEmail
+	case d.CustomerName != "":
+		return d.CustomerName
+	default:
+		return fmt.Sprintf("User #%d", d.Invoice.UserID)
+	}
+}
+
+// invoiceItemRow 明细表的一行（各格式共用）
+type invoiceItemRow struct {
+	Description string
+	Detail      string
+	Requests    string
+	Tokens      string
+	Standard    string
+	Amount      string
+}
+
+func (d *invoiceDocument) rows() []invoiceItemRow {
+	rows := make([]invoiceItemRow, 0, len(d.Invoice.LineItems))
+	for _, item := range d.Invoice.LineItems {
+		row := invoiceItemRow{Description: item.Description, Amount: formatInvoiceMoney(item.Amount)}
+		switch item.Kind {
+		case InvoiceItemUsage:
+			row.Requests = formatInvoiceInt(item.Requests)
+			row.Tokens = formatInvoiceInt(item.InputTokens + item.OutputTokens + item.CacheTokens)
+			row.Detail = fmt.Sprintf("in %s / out %s / cache %s",
+				formatInvoiceInt(item.InputTokens), formatInvoiceInt(item.OutputTokens), formatInvoiceInt(item.CacheTokens))
+			row.Standard = formatInvoiceMoney(item.StandardCost)
+		case InvoiceItemSubscription:
+			if item.Date != nil && item.ExpiresAt != nil {
+				row.Detail = item.Date.Format(invoiceDateLayout) + " - " + item.ExpiresAt.Format(invoiceDateLayout)
+			}
+			row.Amount = "-"
+		default:
+			if item.Date != nil {
+				row.Detail = item.Date.Format(invoiceDateLayout)
+			}
+		}
+		if item.Reference != "" {
+			row.Detail = strings.TrimSpace(row.Detail + " " + item.Reference)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// renderInvoiceCSV 渲染 CSV：账单头信息 + 明细表（金额保留 8 位小数以便对账）
+func renderInvoiceCSV(doc *invoiceDocument) ([]byte, error) {
+	inv := doc.Invoice
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	money := func(v float64) string { return strconv.FormatFloat(v, 'f', 8, 64) }
+
+	header := [][]string{
+		{"invoice_number", inv.Number()},
+		{"issuer", doc.Issuer.Name},
+		{"customer", doc.customerLabel()},
+		{"period", inv.Period()},
+		{"period_start", inv.PeriodStart.Format(time.RFC3339)},
+		{"period_end", inv.PeriodEnd.Format(time.RFC3339)},
+		{"status", inv.Status},
+		{"revision", strconv.Itoa(inv.Revision)},
+		{"generated_at", inv.GeneratedAt.Format(time.RFC3339)},
+		{"currency", inv.Currency},
+		{"opening_balance", money(inv.OpeningBalance)},
+		{"credits", money(inv.Credits)},
+		{"adjustments", money(inv.Adjustments)},
+		{"usage_charges", money(inv.UsageCharges)},
+		{"subscription_usage", money(inv.SubscriptionUsage)},
+		{"closing_balance", money(inv.ClosingBalance)},
+	}
+	if inv.IsVoid() {
+		header = append(header, []string{"void_reason", inv.VoidReason})
+	}
+	header = append(header, []string{},
+		[]string{"kind", "description", "model", "group", "billing", "requests", "input_tokens", "output_tokens", "cache_tokens", "standard_cost", "amount", "date", "expires_at", "reference"})
+	if err := w.WriteAll(header); err != nil {
+		return nil, err
+	}
+
+	for _, item := range inv.LineItems {
+		billing := ""
+		if item.Kind == InvoiceItemUsage {
+			billing = "balance"
+			if item.Subscription {
+				billing = "subscription"
+			}
+		}
+		record := []string{
+			item.Kind, item.Description, item.Model, item.GroupName, billing,
+			strconv.FormatInt(item.Requests, 10),
+			strconv.FormatInt(item.InputTokens, 10),
+			strconv.FormatInt(item.OutputTokens, 10),
+			strconv.FormatInt(item.CacheTokens, 10),
+			money(item.StandardCost), money(item.Amount),
+			formatInvoiceTime(item.Date), formatInvoiceTime(item.ExpiresAt), item.Reference,
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var invoiceHTMLTemplate = template.Must(template.New("invoice").Parse(invoiceHTMLSource))
+
+// renderInvoiceHTML 渲染可打印的 HTML 账单
+func renderInvoiceHTML(doc *invoiceDocument) ([]byte, error) {
+	inv := doc.Invoice
+	data := map[string]any{
+		"Issuer":     doc.Issuer,
+		"Number":     inv.Number(),
+		"Period":     doc.periodLabel(),
+		"Generated":  inv.GeneratedAt.Format(invoiceDateLayout),
+		"Customer":   doc.customerLabel(),
+		"Currency":   inv.Currency,
+		"Revision":   inv.Revision,
+		"Void":       inv.IsVoid(),
+		"VoidReason": inv.VoidReason,
+		"Summary":    doc.summary(),
+		"Rows":       doc.rows(),
+	}
+	var buf bytes.Buffer
+	if err := invoiceHTMLTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// PDF 版式参数（单位：pt）
+const (
+	invoicePDFMargin     = 50.0
+	invoicePDFRowHeight  = 14.0
+	invoicePDFFooterSafe = 70.0
+)
+
+// invoicePDFColumns 明细表各列右边界（描述列左对齐，其余右对齐）
+var invoicePDFColumns = struct {
+	Description, Requests, Tokens, Standard, Amount float64
+}{
+	Description: invoicePDFMargin,
+	Requests:    330,
+	Tokens:      410,
+	Standard:    480,
+	Amount:      pdf.A4Width - invoicePDFMargin,
+}
+
+// renderInvoicePDF 使用内置 PDF 写入器渲染账单（纯 Go，无外部依赖）。
+// 内置写入器只支持 WinAnsi 字符，账单中含中日韩等文字（抬头、用户名、模型描述等）时 ok 为 false，
+// 此时 PDF 中这些字符会显示为 '?'，调用方应改用 HTML 账单
+func renderInvoicePDF(doc *invoiceDocument) (body []byte, ok bool) {
+	inv := doc.Invoice
+	p := pdf.New(pdf.A4Width, pdf.A4Height)
+	right := p.Width() - invoicePDFMargin
+	cols := invoicePDFColumns
+
+	page := 0
+	newPage := func() float64 {
+		p.AddPage()
+		page++
+		if inv.IsVoid() {
+			p.SetGray(0.6)
+			p.TextRight(right, p.Height()-30, 14, true, "VOID")
+			p.SetGray(0)
+		}
+		p.Text(invoicePDFMargin, 30, 8, false, inv.Number())
+		return p.Height() - invoicePDFMargin
+	}
+	tableHeader := func(y float64) float64 {
+		p.FillRect(invoicePDFMargin, y-4, right-invoicePDFMargin, invoicePDFRowHeight+2, 0.92)
+		p.Text(cols.Description+4, y, 9, true, "Description")
+		p.TextRight(cols.Requests, y, 9, true, "Requests")
+		p.TextRight(cols.Tokens, y, 9, true, "Tokens")
+		p.TextRight(cols.Standard, y, 9, true, "Standard")
+		p.TextRight(cols.Amount-4, y, 9, true, "Amount")
+		return y - invoicePDFRowHeight - 4
+	}
+
+	y := newPage()
+	issuer := doc.Issuer.Name
+	if issuer == "" {
+		issuer = "Invoice"
+	}
+	p.Text(invoicePDFMargin, y, 18, true, pdf.Truncate(issuer, 18, true, 300))
+	p.TextRight(right, y, 18, true, "INVOICE")
+	y -= 16
+	for _, line := range doc.Issuer.Address {
+		p.Text(invoicePDFMargin, y, 9, false, pdf.Truncate(line, 9, false, 300))
+		y -= 12
+	}
+
+	meta := [][2]string{
+		{"Invoice number", inv.Number()},
+		{"Billing period", doc.periodLabel()},
+		{"Issue date", inv.GeneratedAt.Format(invoiceDateLayout)},
+		{"Currency", inv.Currency},
+	}
+	if inv.Revision > 1 {
+		meta = append(meta, [2]string{"Revision", strconv.Itoa(inv.Revision)})
+	}
+	metaY := p.Height() - invoicePDFMargin - 16
+	for _, m := range meta {
+		p.TextRight(right-110, metaY, 9, true, m[0])
+		p.TextRight(right, metaY, 9, false, m[1])
+		metaY -= 12
+	}
+	y = math.Min(y, metaY) - 14
+
+	p.Text(invoicePDFMargin, y, 9, true, "Bill to")
+	y -= 12
+	p.Text(invoicePDFMargin, y, 10, false, pdf.Truncate(doc.customerLabel(), 10, false, right-invoicePDFMargin))
+	y -= 24
+
+	if inv.IsVoid() {
+		text := "This invoice has been voided."
+		if inv.VoidReason != "" {
+			text += " Reason: " + inv.VoidReason
+		}
+		p.Text(invoicePDFMargin, y, 10, true, pdf.Truncate(text, 10, true, right-invoicePDFMargin))
+		y -= 20
+	}
+
+	p.Text(invoicePDFMargin, y, 11, true, "Summary")
+	y -= 6
+	p.Line(invoicePDFMargin, y, right, y, 0.5)
+	y -= 14
+	for _, line := range doc.summary() {
+		p.Text(invoicePDFMargin, y, 10, line.Strong, line.Label)
+		p.TextRight(right, y, 10, line.Strong, line.Amount)
+		y -= invoicePDFRowHeight
+	}
+	y -= 16
+
+	p.Text(invoicePDFMargin, y, 11, true, "Details")
+	y -= 18
+	y = tableHeader(y)
+	rows := doc.rows()
+	if len(rows) == 0 {
+		p.Text(cols.Description+4, y, 9, false, "No activity in this period.")
+		y -= invoicePDFRowHeight
+	}
+	for _, row := range rows {
+		height := invoicePDFRowHeight
+		if row.Detail != "" {
+			height += 10
+		}
+		if y-height < invoicePDFFooterSafe {
+			y = tableHeader(newPage())
+		}
+		p.Text(cols.Description+4, y, 9, false, pdf.Truncate(row.Description, 9, false, cols.Requests-80-cols.Description))
+		p.TextRight(cols.Requests, y, 9, false, row.Requests)
+		p.TextRight(cols.Tokens, y, 9, false, row.Tokens)
+		p.TextRight(cols.Standard, y, 9, false, row.Standard)
+		p.TextRight(cols.Amount-4, y, 9, false, row.Amount)
+		if row.Detail != "" {
+			p.SetGray(0.4)
+			p.Text(cols.Description+4, y-10, 7, false, pdf.Truncate(row.Detail, 7, false, cols.Requests-cols.Description))
+			p.SetGray(0)
+		}
+		y -= height
+		p.Line(invoicePDFMargin, y+invoicePDFRowHeight-10, right, y+invoicePDFRowHeight-10, 0.25)
+	}
+
+	if y-40 < invoicePDFFooterSafe {
+		y = newPage()
+	}
+	y -= 20
+	p.Text(invoicePDFMargin, y, 8, false, "Amounts are in "+inv.Currency+". Usage covered by subscriptions is shown at standard price and is not charged to the balance.")
+	p.Text(invoicePDFMargin, y-11, 8, false, "Generated "+inv.GeneratedAt.UTC().Format(time.RFC3339)+fmt.Sprintf(" - %d page(s)", page))
+	return p.Bytes(), !p.Lossy()
+}
+
+// formatInvoiceMoney 金额保留 4 位小数（用量计费常低于 1 美分）
+func formatInvoiceMoney(v float64) string {
+	if math.Abs(v) < 0.00005 {
+		v = 0
+	}
+	if v < 0 {
+		return "-$" + strconv.FormatFloat(-v, 'f', 4, 64)
+	}
+	return "$" + strconv.FormatFloat(v, 'f', 4, 64)
+}
+
+// formatInvoiceInt 千分位分隔
+func formatInvoiceInt(v int64) string {
+	s := strconv.FormatInt(v, 10)
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	if neg {
+		return "-" + b.String()
+	}
+	return b.String()
+}
+
+func formatInvoiceTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+const invoiceHTMLSource = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Number}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2937; margin: 0; background: #f3f4f6; }
+  .page { max-width: 820px; margin: 24px auto; background: #fff; padding: 40px 48px; box-shadow: 0 1px 3px rgba(0,0,0,.1); }
+  .head { display: flex; justify-content: space-between; align-items: flex-start; }
+  .issuer h1 { margin: 0 0 4px; font-size: 22px; }
+  .issuer div, .meta td { font-size: 13px; color: #4b5563; }
+  .title { font-size: 22px; font-weight: 700; letter-spacing: 2px; text-align: right; }
+  .meta td { padding: 1px 0 1px 16px; text-align: right; }
+  .meta td:first-child { font-weight: 600; color: #1f2937; }
+  .void { margin-top: 20px; padding: 10px 14px; border: 2px solid #b91c1c; color: #b91c1c; font-weight: 600; }
+  h2 { font-size: 15px; margin: 28px 0 8px; border-bottom: 1px solid #e5e7eb; padding-bottom: 6px; }
+  table.lines { width: 100%; border-collapse: collapse; font-size: 13px; }
+  table.lines th { background: #f3f4f6; text-align: right; padding: 6px 8px; font-weight: 600; }
+  table.lines td { border-bottom: 1px solid #f3f4f6; padding: 6px 8px; text-align: right; vertical-align: top; }
+  table.lines th:first-child, table.lines td:first-child { text-align: left; }
+  .detail { color: #6b7280; font-size: 11px; }
+  .summary td { padding: 4px 0; font-size: 14px; }
+  .summary td:last-child { text-align: right; }
+  .strong td { font-weight: 700; border-top: 1px solid #e5e7eb; }
+  .foot { margin-top: 28px; font-size: 11px; color: #6b7280; }
+  @media print { body { background: #fff; } .page { box-shadow: none; margin: 0; } }
+</style>
+</head>
+<body>
+<div class="page">
+  <div class="head">
+    <div class="issuer">
+      <h1>{{.Issuer.Name}}</h1>
+      {{range .Issuer.Address}}<div>{{.}}</div>{{end}}
+    </div>
+    <div>
+      <div class="title">INVOICE</div>
+      <table class="meta">
+        <tr><td>Invoice number</td><td>{{.Number}}</td></tr>
+        <tr><td>Billing period</td><td>{{.Period}}</td></tr>
+        <tr><td>Issue date</td><td>{{.Generated}}</td></tr>
+        <tr><td>Currency</td><td>{{.Currency}}</td></tr>
+        {{if gt .Revision 1}}<tr><td>Revision</td><td>{{.Revision}}</td></tr>{{end}}
+      </table>
+    </div>
+  </div>
+
+  <h2>Bill to</h2>
+  <div>{{.Customer}}</div>
+
+  {{if .Void}}<div class="void">This invoice has been voided.{{if .VoidReason}} Reason: {{.VoidReason}}{{end}}</div>{{end}}
+
+  <h2>Summary</h2>
+  <table class="summary" width="100%">
+    {{range .Summary}}<tr{{if .Strong}} class="strong"{{end}}><td>{{.Label}}</td><td>{{.Amount}}</td></tr>
+    {{end}}
+  </table>
+
+  <h2>Details</h2>
+  <table class="lines">
+    <tr><th>Description</th><th>Requests</th><th>Tokens</th><th>Standard</th><th>Amount</th></tr>
+    {{range .Rows}}<tr>
+      <td>{{.Description}}{{if .Detail}}<div class="detail">{{.Detail}}</div>{{end}}</td>
+      <td>{{.Requests}}</td><td>{{.Tokens}}</td><td>{{.Standard}}</td><td>{{.Amount}}</td>
+    </tr>
+    {{else}}<tr><td colspan="5">No activity in this period.</td></tr>
+    {{end}}
+  </table>
+
+  <div class="foot">
+    Amounts are in {{.Currency}}. Usage covered by subscriptions is shown at standard price and is not charged to the balance.
+  </div>
+</div>
+</body>
+</html>`
@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+)
+
+const (
+	invoiceMonthlyTaskName = "invoice:monthly"
+	invoiceMonthlyInterval = time.Hour
+	invoiceMonthlyTimeout  = 30 * time.Minute
+	// maxInvoiceVoidReasonLen 作废原因最大长度
+	maxInvoiceVoidReasonLen = 500
+)
+
+// InvoiceService 月度账单生成、查询、渲染与作废
+type InvoiceService struct {
+	repo           InvoiceRepository
+	userRepo       UserRepository
+	settingService *SettingService
+	timingWheel    *TimingWheelService
+	cfg            *config.Config
+
+	mu sync.Mutex
+	// lastAutoPeriod 本实例已完成自动生成的账期，避免每小时重复扫描
+	lastAutoPeriod string
+}
+
+// NewInvoiceService creates a new InvoiceService
+func NewInvoiceService(repo InvoiceRepository, userRepo UserRepository, settingService *SettingService, timingWheel *TimingWheelService, cfg *config.Config) *InvoiceService {
+	return &InvoiceService{
+		repo:           repo,
+		userRepo:       userRepo,
+		settingService: settingService,
+		timingWheel:    timingWheel,
+		cfg:            cfg,
+	}
+}
+
+// Start 启动每月初自动生成上月账单（多实例下依赖唯一索引保证每个账期只有一张有效账单）
+func (s *InvoiceService) Start() {
+	if s == nil || s.timingWheel == nil || s.cfg == nil || !s.cfg.Invoice.Enabled {
+		return
+	}
+	s.timingWheel.ScheduleRecurring(invoiceMonthlyTaskName, invoiceMonthlyInterval, s.runMonthly)
+	log.Printf("[Invoice] Monthly generator started (delay: %dh)", s.cfg.Invoice.GenerateDelayHours)
+}
+
+// Stop 停止自动生成
+func (s *InvoiceService) Stop() {
+	if s == nil || s.timingWheel == nil {
+		return
+	}
+	s.timingWheel.Cancel(invoiceMonthlyTaskName)
+}
+
+func (s *InvoiceService) runMonthly() {
+	now := timezone.Now()
+	monthStart := timezone.StartOfMonth(now)
+	if now.Before(monthStart.Add(time.Duration(s.cfg.Invoice.GenerateDelayHours) * time.Hour)) {
+		return
+	}
+	period := monthStart.AddDate(0, -1, 0).Format(invoicePeriodLayout)
+
+	s.mu.Lock()
+	done := s.lastAutoPeriod == period
+	s.mu.Unlock()
+	if done {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), invoiceMonthlyTimeout)
+	defer cancel()
+	result, err := s.GenerateForPeriod(ctx, period)
+	if err != nil {
+		log.Printf("[Invoice] Generate invoices for %s failed: %v", period, err)
+		return
+	}
+	if result.Created > 0 || len(result.Failed) > 0 {
+		log.Printf("[Invoice] Generated %d invoice(s) for %s, %d failed", result.Created, period, len(result.Failed))
+	}
+	if len(result.Failed) == 0 {
+		s.mu.Lock()
+		s.lastAutoPeriod = period
+		s.mu.Unlock()
+	}
+}
+
+// InvoiceGenerateResult 批量生成结果
+type InvoiceGenerateResult struct {
+	Period  string  `json:"period"`
+	Created int     `json:"created"`
+	Failed  []int64 `json:"failed"`
+}
+
+// GenerateForPeriod 为账期内有活动且尚无有效账单的全部用户生成账单
+func (s *InvoiceService) GenerateForPeriod(ctx context.Context, period string) (*InvoiceGenerateResult, error) {
+	start, end, err := s.closedPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	userIDs, err := s.repo.ListBillableUserIDs(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	result := &InvoiceGenerateResult{Period: start.Format(invoicePeriodLayout), Failed: []int64{}}
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if _, err := s.generate(ctx, userID, start, end); err != nil {
+			if errors.Is(err, ErrInvoiceExists) {
+				continue
+			}
+			log.Printf("[Invoice] Generate invoice failed: user=%d period=%s err=%v", userID, result.Period, err)
+			result.Failed = append(result.Failed, userID)
+			continue
+		}
+		result.Created++
+	}
+	return result, nil
+}
+
+// Generate 为单个用户生成账期账单（已有有效账单时返回 ErrInvoiceExists）
+func (s *InvoiceService) Generate(ctx context.Context, userID int64, period string) (*Invoice, error) {
+	start, end, err := s.closedPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.generate(ctx, userID, start, end)
+}
+
+func (s *InvoiceService) generate(ctx context.Context, userID int64, start, end time.Time) (*Invoice, error) {
+	src, err := s.repo.LoadSource(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	invoice := buildInvoice(userID, start, end, src)
+	invoice.Revision = 1
+	if err := s.repo.Create(ctx, invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// Regenerate 按当前数据重新汇总账单（编号不变，Revision 递增）；已作废的账单不能重新生成
+func (s *InvoiceService) Regenerate(ctx context.Context, id int64) (*Invoice, error) {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing.IsVoid() {
+		return nil, ErrInvoiceVoided
+	}
+	src, err := s.repo.LoadSource(ctx, existing.UserID, existing.PeriodStart, existing.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	invoice := buildInvoice(existing.UserID, existing.PeriodStart, existing.PeriodEnd, src)
+	invoice.ID = existing.ID
+	invoice.Revision = existing.Revision + 1
+	invoice.CreatedAt = existing.CreatedAt
+	if err := s.repo.Update(ctx, invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// Void 作废账单；作废后可为同一账期重新生成新账单
+func (s *InvoiceService) Void(ctx context.Context, id int64, adminID int64, reason string) (*Invoice, error) {
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxInvoiceVoidReasonLen {
+		reason = reason[:maxInvoiceVoidReasonLen]
+	}
+	if err := s.repo.Void(ctx, id, adminID, reason); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// List 分页列出账单
+func (s *InvoiceService) List(ctx context.Context, filter InvoiceFilter, params pagination.PaginationParams) ([]Invoice, *pagination.PaginationResult, error) {
+	if filter.Period != "" {
+		if _, _, err := ParseInvoicePeriod(filter.Period, timezone.Location()); err != nil {
+			return nil, nil, err
+		}
+	}
+	return s.repo.List(ctx, filter, params)
+}
+
+// ListByUser 分页列出用户自己的账单
+func (s *InvoiceService) ListByUser(ctx context.Context, userID int64, params pagination.PaginationParams) ([]Invoice, *pagination.PaginationResult, error) {
+	return s.repo.List(ctx, InvoiceFilter{UserID: &userID}, params)
+}
+
+// Get 获取账单
+func (s *InvoiceService) Get(ctx context.Context, id int64) (*Invoice, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// GetForUser 获取用户自己的账单（他人的账单视为不存在）
+func (s *InvoiceService) GetForUser(ctx context.Context, userID, id int64) (*Invoice, error) {
+	invoice, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.UserID != userID {
+		return nil, ErrInvoiceNotFound
+	}
+	return invoice, nil
+}
+
+// RenderedInvoice 渲染后的账单文件
+type RenderedInvoice struct {
+	Filename    string
+	ContentType string
+	Body        []byte
+}
+
+// Render 渲染账单为 PDF / HTML / CSV。
+// PDF 无法显示账单中的非拉丁字符时回退为 HTML 账单（文件名与 Content-Type 随之改变）
+func (s *InvoiceService) Render(ctx context.Context, invoice *Invoice, format string) (*RenderedInvoice, error) {
+	format, err := NormalizeInvoiceFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	doc := &invoiceDocument{Invoice: invoice, Issuer: s.issuer(ctx)}
+	if user, err := s.userRepo.GetByID(ctx, invoice.UserID); err == nil {
+		doc.CustomerEmail = user.Email
+		doc.CustomerName = user.Username
+	}
+
+	out := &RenderedInvoice{Filename: invoice.Number() + "." + format}
+	switch format {
+	case InvoiceFormatHTML:
+		out.ContentType = "text/html; charset=utf-8"
+		out.Body, err = renderInvoiceHTML(doc)
+	case InvoiceFormatCSV:
+		out.ContentType = "text/csv; charset=utf-8"
+		out.Body, err = renderInvoiceCSV(doc)
+	default:
+		body, ok := renderInvoicePDF(doc)
+		if ok {
+			out.ContentType = "application/pdf"
+			out.Body = body
+			break
+		}
+		out.Filename = invoice.Number() + "." + InvoiceFormatHTML
+		out.ContentType = "text/html; charset=utf-8"
+		out.Body, err = renderInvoiceHTML(doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InvoiceIssuer 账单抬头
+type InvoiceIssuer struct {
+	Name    string
+	Address []string
+}
+
+func (s *InvoiceService) issuer(ctx context.Context) InvoiceIssuer {
+	issuer := InvoiceIssuer{}
+	if s.cfg != nil {
+		issuer.Name = strings.TrimSpace(s.cfg.Invoice.IssuerName)
+		for _, line := range strings.Split(s.cfg.Invoice.IssuerAddress, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				issuer.Address = append(issuer.Address, line)
+			}
+		}
+	}
+	if issuer.Name == "" && s.settingService != nil {
+		issuer.Name = s.settingService.GetSiteName(ctx)
+	}
+	return issuer
+}
+
+// closedPeriod 解析账期并校验已结束
+func (s *InvoiceService) closedPeriod(period string) (time.Time, time.Time, error) {
+	start, end, err := ParseInvoicePeriod(period, timezone.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if end.After(time.Now()) {
+		return time.Time{}, time.Time{}, ErrInvoicePeriodOpen
+	}
+	return start, end, nil
+}
+
+// buildInvoice 由当期原始数据构造账单（金额与明细均在此确定）
+func buildInvoice(userID int64, start, end time.Time, src *InvoiceSource) *Invoice {
+	invoice := &Invoice{
+		UserID:      userID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Status:      InvoiceStatusIssued,
+		Currency:    InvoiceCurrency,
+		LineItems:   []InvoiceLineItem{},
+		GeneratedAt: time.Now(),
+	}
+
+	usage := append([]InvoiceUsageRow(nil), src.Usage...)
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].ActualCost != usage[j].ActualCost {
+			return usage[i].ActualCost > usage[j].ActualCost
+		}
+		return usage[i].Model < usage[j].Model
+	})
+	for _, row := range usage {
+		item := InvoiceLineItem{
+			Kind:         InvoiceItemUsage,
+			Description:  row.Model,
+			Model:        row.Model,
+			GroupID:      row.GroupID,
+			GroupName:    row.GroupName,
+			Subscription: row.BillingType == BillingTypeSubscription,
+			Requests:     row.Requests,
+			InputTokens:  row.InputTokens,
+			OutputTokens: row.OutputTokens,
+			CacheTokens:  row.CacheTokens,
+			StandardCost: row.TotalCost,
+		}
+		if row.GroupName != "" {
+			item.Description = row.Model + " (" + row.GroupName + ")"
+		}
+		if item.Subscription {
+			// 订阅用量按标准价格计入订阅额度，不从余额扣费
+			item.Description += " - subscription"
+			invoice.SubscriptionUsage += row.TotalCost
+		} else {
+			item.Amount = row.ActualCost
+			invoice.UsageCharges += row.ActualCost
+		}
+		invoice.LineItems = append(invoice.LineItems, item)
+	}
+
+	for _, tx := range src.Transactions {
+		createdAt := tx.CreatedAt
+		item := InvoiceLineItem{
+			Kind:        InvoiceItemCredit,
+			Description: invoiceTransactionDescription(tx),
+			Amount:      tx.Amount,
+			Date:        &createdAt,
+			Reference:   fmt.Sprintf("TX-%d", tx.ID),
+		}
+		if tx.Type == BalanceTxTypeAdminAdjust || tx.Type == BalanceTxTypeOrganizationTransfer {
+			item.Kind = InvoiceItemAdjustment
+			invoice.Adjustments += tx.Amount
+		} else {
+			invoice.Credits += tx.Amount
+		}
+		invoice.LineItems = append(invoice.LineItems, item)
+	}
+
+	for _, sub := range src.Subscriptions {
+		startsAt, expiresAt := sub.StartsAt, sub.ExpiresAt
+		item := InvoiceLineItem{
+			Kind:        InvoiceItemSubscription,
+			Description: "Subscription: " + sub.GroupName,
+			GroupID:     &sub.GroupID,
+			GroupName:   sub.GroupName,
+			Date:        &startsAt,
+			ExpiresAt:   &expiresAt,
+			Reference:   fmt.Sprintf("SUB-%d", sub.SubscriptionID),
+		}
+		invoice.LineItems = append(invoice.LineItems, item)
+	}
+
+	if src.OpeningBalance != nil {
+		invoice.OpeningBalance = *src.OpeningBalance
+	}
+	invoice.ClosingBalance = invoice.OpeningBalance
+	if src.ClosingBalance != nil {
+		invoice.ClosingBalance = *src.ClosingBalance
+	}
+	return invoice
+}
+
+// invoiceTransactionDescription 入账明细描述（账单会交给用户，不包含管理员备注）
+func invoiceTransactionDescription(tx BalanceTransaction) string {
+	switch tx.Type {
+	case BalanceTxTypeRedeem:
+		return "Redeem code top-up"
+	case BalanceTxTypePromo:
+		return "Promo code credit"
+	case BalanceTxTypeAdminAdjust:
+		return "Balance adjustment"
+	case BalanceTxTypeInitial:
+		return "Initial balance"
+	case BalanceTxTypeOpening:
+		return "Opening balance"
+	case BalanceTxTypeOrganizationTransfer:
+		return "Transfer to organization"
+	default:
+		return tx.Type
+	}
+}
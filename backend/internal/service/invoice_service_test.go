@@ -0,0 +1,255 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type invoiceRepoStub struct {
+	invoices map[int64]*Invoice
+	source   *InvoiceSource
+	nextID   int64
+}
+
+func newInvoiceRepoStub(src *InvoiceSource) *invoiceRepoStub {
+	return &invoiceRepoStub{invoices: map[int64]*Invoice{}, source: src}
+}
+
+func (r *invoiceRepoStub) Create(ctx context.Context, invoice *Invoice) error {
+	for _, existing := range r.invoices {
+		if existing.UserID == invoice.UserID && existing.PeriodStart.Equal(invoice.PeriodStart) && !existing.IsVoid() {
+			return ErrInvoiceExists
+		}
+	}
+	r.nextID++
+	invoice.ID = r.nextID
+	stored := *invoice
+	r.invoices[invoice.ID] = &stored
+	return nil
+}
+
+func (r *invoiceRepoStub) Update(ctx context.Context, invoice *Invoice) error {
+	stored := *invoice
+	r.invoices[invoice.ID] = &stored
+	return nil
+}
+
+func (r *invoiceRepoStub) GetByID(ctx context.Context, id int64) (*Invoice, error) {
+	invoice, ok := r.invoices[id]
+	if !ok {
+		return nil, ErrInvoiceNotFound
+	}
+	out := *invoice
+	return &out, nil
+}
+
+func (r *invoiceRepoStub) List(ctx context.Context, filter InvoiceFilter, params pagination.PaginationParams) ([]Invoice, *pagination.PaginationResult, error) {
+	return nil, &pagination.PaginationResult{}, nil
+}
+
+func (r *invoiceRepoStub) Void(ctx context.Context, id int64, adminID int64, reason string) error {
+	invoice, ok := r.invoices[id]
+	if !ok {
+		return ErrInvoiceNotFound
+	}
+	if invoice.IsVoid() {
+		return ErrInvoiceVoided
+	}
+	invoice.Status = InvoiceStatusVoid
+	invoice.VoidedBy = &adminID
+	invoice.VoidReason = reason
+	return nil
+}
+
+func (r *invoiceRepoStub) LoadSource(ctx context.Context, userID int64, start, end time.Time) (*InvoiceSource, error) {
+	return r.source, nil
+}
+
+func (r *invoiceRepoStub) ListBillableUserIDs(ctx context.Context, start, end time.Time) ([]int64, error) {
+	return []int64{7}, nil
+}
+
+func testInvoiceSource() *InvoiceSource {
+	groupID := int64(3)
+	opening, closing := 5.0, 12.5
+	return &InvoiceSource{
+		Usage: []InvoiceUsageRow{
+			{Model: "claude-haiku", GroupID: &groupID, GroupName: "default", BillingType: BillingTypeBalance, Requests: 4, InputTokens: 1000, OutputTokens: 200, TotalCost: 0.5, ActualCost: 0.5},
+			{Model: "claude-sonnet", GroupID: &groupID, GroupName: "default", BillingType: BillingTypeBalance, Requests: 10, InputTokens: 5000, OutputTokens: 1500, CacheTokens: 300, TotalCost: 1.5, ActualCost: 2},
+			{Model: "claude-sonnet", BillingType: BillingTypeSubscription, Requests: 2, InputTokens: 100, OutputTokens: 50, TotalCost: 0.75},
+		},
+		Transactions: []BalanceTransaction{
+			{ID: 11, Type: BalanceTxTypeRedeem, Amount: 10, Notes: "通过兑换码 SECRET 兑换"},
+			{ID: 12, Type: BalanceTxTypeAdminAdjust, Amount: -0.5, Notes: "internal note"},
+		},
+		Subscriptions: []InvoiceSubscriptionRow{
+			{SubscriptionID: 21, GroupID: 4, GroupName: "pro", StartsAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), ExpiresAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		OpeningBalance: &opening,
+		ClosingBalance: &closing,
+	}
+}
+
+func TestBuildInvoice_AggregatesUsageCreditsAndSubscriptions(t *testing.T) {
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	inv := buildInvoice(7, start, start.AddDate(0, 1, 0), testInvoiceSource())
+
+	require.Equal(t, InvoiceStatusIssued, inv.Status)
+	require.InDelta(t, 2.5, inv.UsageCharges, 1e-9)
+	require.InDelta(t, 0.75, inv.SubscriptionUsage, 1e-9)
+	require.InDelta(t, 10, inv.Credits, 1e-9)
+	require.InDelta(t, -0.5, inv.Adjustments, 1e-9)
+	require.InDelta(t, 5, inv.OpeningBalance, 1e-9)
+	require.InDelta(t, 12.5, inv.ClosingBalance, 1e-9)
+
+	require.Len(t, inv.LineItems, 6)
+	// 用量按扣费金额降序
+	require.Equal(t, "claude-sonnet (default)", inv.LineItems[0].Description)
+	require.Equal(t, "claude-haiku (default)", inv.LineItems[1].Description)
+	require.True(t, inv.LineItems[2].Subscription)
+	require.Zero(t, inv.LineItems[2].Amount)
+	require.Equal(t, InvoiceItemCredit, inv.LineItems[3].Kind)
+	require.Equal(t, InvoiceItemAdjustment, inv.LineItems[4].Kind)
+	require.Equal(t, InvoiceItemSubscription, inv.LineItems[5].Kind)
+
+	// 管理员备注与兑换码不会出现在用户账单上
+	for _, item := range inv.LineItems {
+		require.NotContains(t, item.Description, "SECRET")
+		require.NotContains(t, item.Description, "internal note")
+	}
+}
+
+func TestBuildInvoice_NoLedgerKeepsZeroBalances(t *testing.T) {
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	inv := buildInvoice(7, start, start.AddDate(0, 1, 0), &InvoiceSource{})
+	require.Empty(t, inv.LineItems)
+	require.NotNil(t, inv.LineItems)
+	require.Zero(t, inv.OpeningBalance)
+	require.Zero(t, inv.ClosingBalance)
+}
+
+func TestInvoiceService_GenerateRegenerateVoid(t *testing.T) {
+	repo := newInvoiceRepoStub(testInvoiceSource())
+	svc := NewInvoiceService(repo, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	_, err := svc.GenerateForPeriod(ctx, "bogus")
+	require.ErrorIs(t, err, ErrInvoicePeriodInvalid)
+	_, err = svc.GenerateForPeriod(ctx, time.Now().Format(invoicePeriodLayout))
+	require.ErrorIs(t, err, ErrInvoicePeriodOpen)
+
+	result, err := svc.GenerateForPeriod(ctx, "2026-01")
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Created)
+	require.Empty(t, result.Failed)
+
+	// 已有有效账单时跳过
+	result, err = svc.GenerateForPeriod(ctx, "2026-01")
+	require.NoError(t, err)
+	require.Zero(t, result.Created)
+
+	regenerated, err := svc.Regenerate(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, regenerated.Revision)
+	require.Equal(t, int64(1), regenerated.ID)
+
+	voided, err := svc.Void(ctx, 1, 99, "  wrong totals  ")
+	require.NoError(t, err)
+	require.True(t, voided.IsVoid())
+	require.Equal(t, "wrong totals", voided.VoidReason)
+
+	_, err = svc.Void(ctx, 1, 99, "")
+	require.ErrorIs(t, err, ErrInvoiceVoided)
+	_, err = svc.Regenerate(ctx, 1)
+	require.ErrorIs(t, err, ErrInvoiceVoided)
+
+	// 作废后可为同一账期重新出具
+	result, err = svc.GenerateForPeriod(ctx, "2026-01")
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Created)
+
+	_, err = svc.GetForUser(ctx, 8, 2)
+	require.ErrorIs(t, err, ErrInvoiceNotFound)
+}
+
+func TestRenderInvoice_AllFormats(t *testing.T) {
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	inv := buildInvoice(7, start, start.AddDate(0, 1, 0), testInvoiceSource())
+	inv.ID = 42
+	inv.Revision = 1
+	doc := &invoiceDocument{Invoice: inv, Issuer: InvoiceIssuer{Name: "Acme <AI>"}, CustomerEmail: "a@example.com"}
+
+	html, err := renderInvoiceHTML(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(html), "INV-2026-09-000042")
+	require.Contains(t, string(html), "Acme &lt;AI&gt;")
+	require.Contains(t, string(html), "$2.5000")
+
+	out, err := renderInvoiceCSV(doc)
+	require.NoError(t, err)
+	// 头信息与明细行列数不同
+	r := csv.NewReader(bytes.NewReader(out))
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"invoice_number", "INV-2026-09-000042"}, records[0])
+	require.Equal(t, "kind", records[len(records)-7][0])
+	require.Equal(t, "2.00000000", records[len(records)-6][10])
+
+	pdfOut, ok := renderInvoicePDF(doc)
+	require.True(t, ok)
+	require.True(t, bytes.HasPrefix(pdfOut, []byte("%PDF-1.4")))
+	require.Contains(t, string(pdfOut), "INV-2026-09-000042")
+
+	_, err = NormalizeInvoiceFormat("docx")
+	require.ErrorIs(t, err, ErrInvoiceFormat)
+	format, err := NormalizeInvoiceFormat("")
+	require.NoError(t, err)
+	require.Equal(t, InvoiceFormatPDF, format)
+}
+
+func TestInvoiceService_RenderFallsBackToHTMLForCJK(t *testing.T) {
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	inv := buildInvoice(7, start, start.AddDate(0, 1, 0), testInvoiceSource())
+	inv.ID = 42
+	inv.Revision = 1
+	ctx := context.Background()
+
+	svc := NewInvoiceService(nil, &orgUserRepoStub{user: &User{ID: 7, Email: "a@example.com", Username: "alice"}}, nil, nil, &config.Config{})
+	out, err := svc.Render(ctx, inv, InvoiceFormatPDF)
+	require.NoError(t, err)
+	require.Equal(t, "application/pdf", out.ContentType)
+	require.Equal(t, "INV-2026-09-000042.pdf", out.Filename)
+
+	// 用户名为中文时 PDF 无法显示，改为输出 HTML 账单
+	svc = NewInvoiceService(nil, &orgUserRepoStub{user: &User{ID: 7, Email: "a@example.com", Username: "张三"}}, nil, nil, &config.Config{})
+	out, err = svc.Render(ctx, inv, "")
+	require.NoError(t, err)
+	require.Equal(t, "text/html; charset=utf-8", out.ContentType)
+	require.Equal(t, "INV-2026-09-000042.html", out.Filename)
+	require.Contains(t, string(out.Body), "张三")
+
+	// 抬头含中文同样回退
+	cfg := &config.Config{}
+	cfg.Invoice.IssuerName = "示例科技有限公司"
+	svc = NewInvoiceService(nil, &orgUserRepoStub{user: &User{ID: 7, Email: "a@example.com"}}, nil, nil, cfg)
+	out, err = svc.Render(ctx, inv, InvoiceFormatPDF)
+	require.NoError(t, err)
+	require.Equal(t, "text/html; charset=utf-8", out.ContentType)
+	require.Contains(t, string(out.Body), "示例科技有限公司")
+}
+
+func TestFormatInvoiceHelpers(t *testing.T) {
+	require.Equal(t, "$0.0000", formatInvoiceMoney(-0.00001))
+	require.Equal(t, "-$1.2346", formatInvoiceMoney(-1.23456))
+	require.Equal(t, "1,234,567", formatInvoiceInt(1234567))
+	require.Equal(t, "-1,000", formatInvoiceInt(-1000))
+	require.Equal(t, "999", formatInvoiceInt(999))
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// Message Batch 处理状态（与 Anthropic processing_status 保持一致）
+const (
+	MessageBatchStatusInProgress = "in_progress"
+	MessageBatchStatusCanceling  = "canceling"
+	MessageBatchStatusEnded      = "ended"
+)
+
+var ErrMessageBatchNotFound = infraerrors.NotFound("MESSAGE_BATCH_NOT_FOUND", "message batch not found")
+
+// MessageBatch 记录批次归属（提交的 API Key、固定的上游账号）与结算进度
+type MessageBatch struct {
+	ID               int64
+	BatchID          string
+	UserID           int64
+	APIKeyID         int64
+	GroupID          *int64
+	AccountID        int64
+	ProcessingStatus string
+	ResultsURL       *string
+	// Payload 最近一次从上游获取的批次对象（原样 JSON）
+	Payload         []byte
+	ExpiresAt       *time.Time
+	LastPolledAt    *time.Time
+	SettledAt       *time.Time
+	SettledRequests int
+	ErrorMessage    *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// IsSettled 批次结果是否已完成计费
+func (b *MessageBatch) IsSettled() bool {
+	return b != nil && b.SettledAt != nil
+}
+
+// MessageBatchListParams 列表分页参数（与 Anthropic before_id/after_id 语义一致，按创建时间倒序）
+type MessageBatchListParams struct {
+	Limit    int
+	AfterID  string
+	BeforeID string
+}
+
+// MessageBatchRepository 批次记录存储
+type MessageBatchRepository interface {
+	Create(ctx context.Context, batch *MessageBatch) error
+	GetByBatchID(ctx context.Context, batchID string) (*MessageBatch, error)
+	// ListByAPIKey 返回当前页记录及是否还有更多
+	ListByAPIKey(ctx context.Context, apiKeyID int64, params MessageBatchListParams) ([]MessageBatch, bool, error)
+	UpdateState(ctx context.Context, batchID, status string, resultsURL *string, payload []byte) error
+	// ListUnsettled 返回未结算批次，按上次轮询时间升序（从未轮询的优先）
+	ListUnsettled(ctx context.Context, limit int) ([]MessageBatch, error)
+	MarkPolled(ctx context.Context, batchID string, errMsg *string) error
+	MarkSettled(ctx context.Context, batchID string, settledRequests int) error
+}
+
+// MessageBatchResult 结果文件（JSONL）中单行的计费相关字段
+type MessageBatchResult struct {
+	CustomID  string
+	Type      string // succeeded / errored / canceled / expired
+	MessageID string
+	Model     string
+	Usage     ClaudeUsage
+}
+
+// parseMessageBatchResultLine 解析结果文件中的一行
+func parseMessageBatchResultLine(line []byte) (*MessageBatchResult, bool) {
+	if len(line) == 0 || !gjson.ValidBytes(line) {
+		return nil, false
+	}
+	parsed := gjson.ParseBytes(line)
+	customID := parsed.Get("custom_id").String()
+	resultType := parsed.Get("result.type").String()
+	if customID == "" || resultType == "" {
+		return nil, false
+	}
+	result := &MessageBatchResult{
+		CustomID: customID,
+		Type:     resultType,
+	}
+	message := parsed.Get("result.message")
+	if message.Exists() {
+		result.MessageID = message.Get("id").String()
+		result.Model = message.Get("model").String()
+		if usage := message.Get("usage"); usage.Exists() {
+			_ = json.Unmarshal([]byte(usage.Raw), &result.Usage)
+		}
+	}
+	return result, true
+}
+
+// messageBatchUsageRequestID 为批次内单个请求生成稳定的使用记录 request_id，
+// 重复结算时依赖 usage_logs (request_id, api_key_id) 唯一约束去重。
+func messageBatchUsageRequestID(batchID, customID string) string {
+	sum := sha256.Sum256([]byte(batchID + "/" + customID))
+	return "batch:" + hex.EncodeToString(sum[:16])
+}
+
+// RewriteMessageBatchResultsURL 将批次对象中的 results_url 指向本网关，
+// 使 SDK 拉取结果时仍经过 API Key 鉴权与归属校验，且不暴露上游地址。
+func RewriteMessageBatchResultsURL(payload []byte, gatewayBaseURL string) []byte {
+	if gatewayBaseURL == "" || gjson.GetBytes(payload, "results_url").String() == "" {
+		return payload
+	}
+	batchID := gjson.GetBytes(payload, "id").String()
+	if batchID == "" {
+		return payload
+	}
+	out, err := sjson.SetBytes(payload, "results_url", gatewayBaseURL+"/v1/messages/batches/"+batchID+"/results")
+	if err != nil {
+		return payload
+	}
+	return out
+}
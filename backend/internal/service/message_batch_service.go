@@ -0,0 +1,587 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	messageBatchWorkerName = "message_batch_settle_worker"
+
+	messageBatchMaxResultLineBytes = 64 * 1024 * 1024
+	messageBatchDefaultListLimit   = 20
+	messageBatchMaxListLimit       = 1000
+)
+
+// messageBatchPassthroughHeaders 批处理请求透传的客户端请求头
+var messageBatchPassthroughHeaders = []string{"anthropic-version", "anthropic-beta"}
+
+// MessageBatchUpstreamResponse 上游响应（非流式）
+type MessageBatchUpstreamResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// MessageBatchService 透传 Anthropic Message Batches API。
+// 批次创建后固定在接受该批次的 API Key 账号上，仅提交批次的 API Key 可读取；
+// 后台轮询批次完成状态，按结果逐条写入使用记录并按批处理折扣计费。
+type MessageBatchService struct {
+	repo           MessageBatchRepository
+	accountRepo    AccountRepository
+	apiKeyRepo     APIKeyRepository
+	userSubRepo    UserSubscriptionRepository
+	gatewayService *GatewayService
+	httpUpstream   HTTPUpstream
+	timingWheel    *TimingWheelService
+	cfg            *config.Config
+
+	running   int32
+	startOnce sync.Once
+	stopOnce  sync.Once
+
+	workerCtx    context.Context
+	workerCancel context.CancelFunc
+}
+
+func NewMessageBatchService(
+	repo MessageBatchRepository,
+	accountRepo AccountRepository,
+	apiKeyRepo APIKeyRepository,
+	userSubRepo UserSubscriptionRepository,
+	gatewayService *GatewayService,
+	httpUpstream HTTPUpstream,
+	timingWheel *TimingWheelService,
+	cfg *config.Config,
+) *MessageBatchService {
+	workerCtx, workerCancel := context.WithCancel(context.Background())
+	return &MessageBatchService{
+		repo:           repo,
+		accountRepo:    accountRepo,
+		apiKeyRepo:     apiKeyRepo,
+		userSubRepo:    userSubRepo,
+		gatewayService: gatewayService,
+		httpUpstream:   httpUpstream,
+		timingWheel:    timingWheel,
+		cfg:            cfg,
+		workerCtx:      workerCtx,
+		workerCancel:   workerCancel,
+	}
+}
+
+func (s *MessageBatchService) Start() {
+	if s == nil {
+		return
+	}
+	if s.cfg != nil && !s.cfg.MessageBatch.Enabled {
+		log.Printf("[MessageBatch] settle worker not started (disabled)")
+		return
+	}
+	if s.repo == nil || s.timingWheel == nil {
+		log.Printf("[MessageBatch] settle worker not started (missing deps)")
+		return
+	}
+
+	interval := s.pollInterval()
+	s.startOnce.Do(func() {
+		s.timingWheel.ScheduleRecurring(messageBatchWorkerName, interval, s.runOnce)
+		log.Printf("[MessageBatch] settle worker started (interval=%s batch_size=%d)", interval, s.pollBatchSize())
+	})
+}
+
+func (s *MessageBatchService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		if s.workerCancel != nil {
+			s.workerCancel()
+		}
+		if s.timingWheel != nil {
+			s.timingWheel.Cancel(messageBatchWorkerName)
+		}
+		log.Printf("[MessageBatch] settle worker stopped")
+	})
+}
+
+// IsBatchAccount 批处理仅支持 Anthropic API Key 账号（OAuth 订阅不提供 batches 接口）
+func IsBatchAccount(account *Account) bool {
+	return account != nil && account.Platform == PlatformAnthropic && account.Type == AccountTypeAPIKey
+}
+
+// Create 在指定账号上创建批次，成功后记录归属
+func (s *MessageBatchService) Create(ctx context.Context, apiKey *APIKey, account *Account, body []byte, header http.Header) (*MessageBatchUpstreamResponse, error) {
+	if !IsBatchAccount(account) {
+		return nil, infraerrors.BadRequest("MESSAGE_BATCH_UNSUPPORTED_ACCOUNT", "account does not support message batches")
+	}
+	body, err := mapMessageBatchModels(body, account)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.doUpstream(ctx, account, http.MethodPost, "/v1/messages/batches", body, header)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		if s.handleUpstreamError(ctx, account, resp) {
+			return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode}
+		}
+		return resp, nil
+	}
+
+	batchID := gjson.GetBytes(resp.Body, "id").String()
+	if batchID == "" {
+		return nil, fmt.Errorf("upstream batch response missing id")
+	}
+	batch := &MessageBatch{
+		BatchID:          batchID,
+		UserID:           apiKey.UserID,
+		APIKeyID:         apiKey.ID,
+		GroupID:          apiKey.GroupID,
+		AccountID:        account.ID,
+		ProcessingStatus: messageBatchStatusFromPayload(resp.Body),
+		ResultsURL:       messageBatchResultsURLFromPayload(resp.Body),
+		Payload:          resp.Body,
+	}
+	if expiresAt := gjson.GetBytes(resp.Body, "expires_at").Time(); !expiresAt.IsZero() {
+		batch.ExpiresAt = &expiresAt
+	}
+	if err := s.repo.Create(ctx, batch); err != nil {
+		// 上游已接受批次但本地未记录时无法计费，直接报错让调用方感知
+		log.Printf("[MessageBatch] record batch failed: batch=%s account=%d err=%v", batchID, account.ID, err)
+		return nil, fmt.Errorf("record message batch: %w", err)
+	}
+	return resp, nil
+}
+
+// Retrieve 从固定账号获取批次最新状态
+func (s *MessageBatchService) Retrieve(ctx context.Context, apiKey *APIKey, batchID string, header http.Header) (*MessageBatchUpstreamResponse, error) {
+	batch, account, err := s.getOwnedBatch(ctx, apiKey, batchID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.doUpstream(ctx, account, http.MethodGet, "/v1/messages/batches/"+url.PathEscape(batch.BatchID), nil, header)
+	if err != nil {
+		return nil, err
+	}
+	s.refreshState(ctx, batch, resp)
+	return resp, nil
+}
+
+// Cancel 取消批次
+func (s *MessageBatchService) Cancel(ctx context.Context, apiKey *APIKey, batchID string, header http.Header) (*MessageBatchUpstreamResponse, error) {
+	batch, account, err := s.getOwnedBatch(ctx, apiKey, batchID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.doUpstream(ctx, account, http.MethodPost, "/v1/messages/batches/"+url.PathEscape(batch.BatchID)+"/cancel", nil, header)
+	if err != nil {
+		return nil, err
+	}
+	s.refreshState(ctx, batch, resp)
+	return resp, nil
+}
+
+// Results 打开批次结果流（JSONL），调用方负责关闭响应体
+func (s *MessageBatchService) Results(ctx context.Context, apiKey *APIKey, batchID string, header http.Header) (*http.Response, error) {
+	batch, account, err := s.getOwnedBatch(ctx, apiKey, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return s.openResults(ctx, account, batch.BatchID, header)
+}
+
+// List 返回当前 API Key 提交的批次（Anthropic 列表格式），results_url 改写为 gatewayBaseURL
+func (s *MessageBatchService) List(ctx context.Context, apiKey *APIKey, params MessageBatchListParams, gatewayBaseURL string) ([]byte, error) {
+	if params.Limit <= 0 {
+		params.Limit = messageBatchDefaultListLimit
+	}
+	if params.Limit > messageBatchMaxListLimit {
+		params.Limit = messageBatchMaxListLimit
+	}
+	batches, hasMore, err := s.repo.ListByAPIKey(ctx, apiKey.ID, params)
+	if err != nil {
+		return nil, err
+	}
+	for i := range batches {
+		batches[i].Payload = RewriteMessageBatchResultsURL(batches[i].Payload, gatewayBaseURL)
+	}
+	return buildMessageBatchListResponse(batches, hasMore)
+}
+
+func buildMessageBatchListResponse(batches []MessageBatch, hasMore bool) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"data":[`)
+	for i := range batches {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(batches[i].Payload)
+	}
+	buf.WriteString(`]}`)
+
+	out := buf.Bytes()
+	var err error
+	if out, err = sjson.SetBytes(out, "has_more", hasMore); err != nil {
+		return nil, err
+	}
+	var firstID, lastID any
+	if len(batches) > 0 {
+		firstID = batches[0].BatchID
+		lastID = batches[len(batches)-1].BatchID
+	}
+	if out, err = sjson.SetBytes(out, "first_id", firstID); err != nil {
+		return nil, err
+	}
+	return sjson.SetBytes(out, "last_id", lastID)
+}
+
+func (s *MessageBatchService) getOwnedBatch(ctx context.Context, apiKey *APIKey, batchID string) (*MessageBatch, *Account, error) {
+	batch, err := s.repo.GetByBatchID(ctx, batchID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 非提交者一律视为不存在，避免泄露批次 ID
+	if apiKey == nil || batch.APIKeyID != apiKey.ID {
+		return nil, nil, ErrMessageBatchNotFound
+	}
+	account, err := s.accountRepo.GetByID(ctx, batch.AccountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get batch account: %w", err)
+	}
+	return batch, account, nil
+}
+
+func (s *MessageBatchService) refreshState(ctx context.Context, batch *MessageBatch, resp *MessageBatchUpstreamResponse) {
+	if resp == nil || resp.StatusCode != http.StatusOK {
+		return
+	}
+	status := messageBatchStatusFromPayload(resp.Body)
+	if err := s.repo.UpdateState(ctx, batch.BatchID, status, messageBatchResultsURLFromPayload(resp.Body), resp.Body); err != nil {
+		log.Printf("[MessageBatch] update state failed: batch=%s err=%v", batch.BatchID, err)
+	}
+}
+
+func (s *MessageBatchService) handleUpstreamError(ctx context.Context, account *Account, resp *MessageBatchUpstreamResponse) bool {
+	shouldDisable := false
+	if s.gatewayService != nil && s.gatewayService.rateLimitService != nil {
+		shouldDisable = s.gatewayService.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, resp.Body)
+	}
+	if s.gatewayService != nil && s.gatewayService.shouldFailoverUpstreamError(resp.StatusCode) {
+		return true
+	}
+	return shouldDisable
+}
+
+func (s *MessageBatchService) buildUpstreamRequest(ctx context.Context, account *Account, method, path string, body []byte, header http.Header) (*http.Request, error) {
+	baseURL, err := s.gatewayService.validateUpstreamBaseURL(account.GetBaseURL())
+	if err != nil {
+		return nil, err
+	}
+	token := account.GetCredential("api_key")
+	if token == "" {
+		return nil, errors.New("api_key not found in credentials")
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(baseURL, "/")+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-api-key", token)
+	for _, key := range messageBatchPassthroughHeaders {
+		if v := header.Get(key); v != "" {
+			req.Header.Set(key, v)
+		}
+	}
+	if req.Header.Get("anthropic-version") == "" {
+		req.Header.Set("anthropic-version", "2023-06-01")
+	}
+	if body != nil {
+		req.Header.Set("content-type", "application/json")
+	}
+	return req, nil
+}
+
+func (s *MessageBatchService) send(account *Account, req *http.Request) (*http.Response, error) {
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+	return s.httpUpstream.DoWithTLS(req, proxyURL, account.ID, account.Concurrency, account.IsTLSFingerprintEnabled())
+}
+
+func (s *MessageBatchService) doUpstream(ctx context.Context, account *Account, method, path string, body []byte, header http.Header) (*MessageBatchUpstreamResponse, error) {
+	req, err := s.buildUpstreamRequest(ctx, account, method, path, body, header)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.send(account, req)
+	if err != nil {
+		return nil, fmt.Errorf("upstream request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read upstream response: %w", err)
+	}
+	return &MessageBatchUpstreamResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: respBody}, nil
+}
+
+func (s *MessageBatchService) openResults(ctx context.Context, account *Account, batchID string, header http.Header) (*http.Response, error) {
+	req, err := s.buildUpstreamRequest(ctx, account, http.MethodGet, "/v1/messages/batches/"+url.PathEscape(batchID)+"/results", nil, header)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.send(account, req)
+	if err != nil {
+		return nil, fmt.Errorf("upstream request failed: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *MessageBatchService) runOnce() {
+	if s == nil {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		log.Printf("[MessageBatch] run_once skipped: already_running=true")
+		return
+	}
+	defer atomic.StoreInt32(&s.running, 0)
+
+	parent := context.Background()
+	if s.workerCtx != nil {
+		parent = s.workerCtx
+	}
+
+	listCtx, cancel := context.WithTimeout(parent, 30*time.Second)
+	batches, err := s.repo.ListUnsettled(listCtx, s.pollBatchSize())
+	cancel()
+	if err != nil {
+		log.Printf("[MessageBatch] list unsettled batches failed: %v", err)
+		return
+	}
+
+	for i := range batches {
+		if parent.Err() != nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(parent, s.settleTimeout())
+		s.pollBatch(ctx, &batches[i])
+		cancel()
+	}
+}
+
+// pollBatch 刷新批次状态；已结束则拉取结果逐条计费
+func (s *MessageBatchService) pollBatch(ctx context.Context, batch *MessageBatch) {
+	account, err := s.accountRepo.GetByID(ctx, batch.AccountID)
+	if err != nil {
+		s.markPolled(ctx, batch.BatchID, fmt.Errorf("get account: %w", err))
+		return
+	}
+
+	if batch.ProcessingStatus != MessageBatchStatusEnded {
+		resp, err := s.doUpstream(ctx, account, http.MethodGet, "/v1/messages/batches/"+url.PathEscape(batch.BatchID), nil, http.Header{})
+		if err != nil {
+			s.markPolled(ctx, batch.BatchID, err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			s.markPolled(ctx, batch.BatchID, fmt.Errorf("retrieve batch: upstream status %d", resp.StatusCode))
+			return
+		}
+		s.refreshState(ctx, batch, resp)
+		if messageBatchStatusFromPayload(resp.Body) != MessageBatchStatusEnded {
+			s.markPolled(ctx, batch.BatchID, nil)
+			return
+		}
+	}
+
+	settled, err := s.settleBatch(ctx, batch, account)
+	if err != nil {
+		log.Printf("[MessageBatch] settle failed: batch=%s settled=%d err=%v", batch.BatchID, settled, err)
+		s.markPolled(ctx, batch.BatchID, err)
+		return
+	}
+	if err := s.repo.MarkSettled(ctx, batch.BatchID, settled); err != nil {
+		log.Printf("[MessageBatch] mark settled failed: batch=%s err=%v", batch.BatchID, err)
+		return
+	}
+	log.Printf("[MessageBatch] batch settled: batch=%s account=%d requests=%d", batch.BatchID, account.ID, settled)
+}
+
+// settleBatch 逐行读取结果文件，为成功的请求写入使用记录。
+// 使用记录的 request_id 由批次 ID 与 custom_id 派生，中途失败后重试不会重复计费。
+func (s *MessageBatchService) settleBatch(ctx context.Context, batch *MessageBatch, account *Account) (int, error) {
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, batch.APIKeyID)
+	if err != nil {
+		return 0, fmt.Errorf("get api key: %w", err)
+	}
+	if apiKey.User == nil {
+		return 0, fmt.Errorf("api key %d has no user", apiKey.ID)
+	}
+	subscription := s.resolveSubscription(ctx, apiKey)
+
+	resp, err := s.openResults(ctx, account, batch.BatchID, http.Header{})
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("fetch results: upstream status %d", resp.StatusCode)
+	}
+
+	settled := 0
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), messageBatchMaxResultLineBytes)
+	for scanner.Scan() {
+		result, ok := parseMessageBatchResultLine(scanner.Bytes())
+		if !ok || result.Type != "succeeded" {
+			continue
+		}
+		if err := s.gatewayService.RecordUsage(ctx, &RecordUsageInput{
+			Result: &ForwardResult{
+				RequestID: messageBatchUsageRequestID(batch.BatchID, result.CustomID),
+				Usage:     result.Usage,
+				Model:     result.Model,
+			},
+			APIKey:       apiKey,
+			User:         apiKey.User,
+			Account:      account,
+			Subscription: subscription,
+			UserAgent:    "message-batch",
+			Deferred:     true,
+			ServiceTier:  ServiceTierBatch,
+		}); err != nil {
+			return settled, fmt.Errorf("record usage for %s: %w", result.CustomID, err)
+		}
+		settled++
+	}
+	if err := scanner.Err(); err != nil {
+		return settled, fmt.Errorf("read results: %w", err)
+	}
+	return settled, nil
+}
+
+func (s *MessageBatchService) resolveSubscription(ctx context.Context, apiKey *APIKey) *UserSubscription {
+	if s.userSubRepo == nil || apiKey.GroupID == nil || apiKey.Group == nil || !apiKey.Group.IsSubscriptionType() {
+		return nil
+	}
+	sub, err := s.userSubRepo.GetActiveByUserIDAndGroupID(ctx, apiKey.UserID, *apiKey.GroupID)
+	if err == nil {
+		return sub
+	}
+	// 批次可能在订阅到期后才完成，回退到最近一次订阅记录，避免转为余额扣费
+	sub, err = s.userSubRepo.GetByUserIDAndGroupID(ctx, apiKey.UserID, *apiKey.GroupID)
+	if err != nil {
+		log.Printf("[MessageBatch] subscription not found: user=%d group=%d err=%v", apiKey.UserID, *apiKey.GroupID, err)
+		return nil
+	}
+	return sub
+}
+
+func (s *MessageBatchService) markPolled(ctx context.Context, batchID string, cause error) {
+	var errMsg *string
+	if cause != nil {
+		msg := truncateString(cause.Error(), 500)
+		errMsg = &msg
+	}
+	if err := s.repo.MarkPolled(ctx, batchID, errMsg); err != nil {
+		log.Printf("[MessageBatch] mark polled failed: batch=%s err=%v", batchID, err)
+	}
+}
+
+func (s *MessageBatchService) pollInterval() time.Duration {
+	if s.cfg != nil && s.cfg.MessageBatch.PollIntervalSeconds > 0 {
+		return time.Duration(s.cfg.MessageBatch.PollIntervalSeconds) * time.Second
+	}
+	return time.Minute
+}
+
+func (s *MessageBatchService) pollBatchSize() int {
+	if s.cfg != nil && s.cfg.MessageBatch.PollBatchSize > 0 {
+		return s.cfg.MessageBatch.PollBatchSize
+	}
+	return 20
+}
+
+func (s *MessageBatchService) settleTimeout() time.Duration {
+	if s.cfg != nil && s.cfg.MessageBatch.SettleTimeoutSeconds > 0 {
+		return time.Duration(s.cfg.MessageBatch.SettleTimeoutSeconds) * time.Second
+	}
+	return 10 * time.Minute
+}
+
+// mapMessageBatchModels 按账号模型映射改写每个请求的 params.model
+func mapMessageBatchModels(body []byte, account *Account) ([]byte, error) {
+	requests := gjson.GetBytes(body, "requests")
+	if !requests.IsArray() || len(requests.Array()) == 0 {
+		return nil, infraerrors.BadRequest("MESSAGE_BATCH_INVALID_REQUESTS", "requests must be a non-empty array")
+	}
+	if len(account.GetModelMapping()) == 0 {
+		return body, nil
+	}
+	var err error
+	for i, item := range requests.Array() {
+		model := item.Get("params.model").String()
+		if model == "" {
+			continue
+		}
+		if mapped := account.GetMappedModel(model); mapped != model {
+			if body, err = sjson.SetBytes(body, fmt.Sprintf("requests.%d.params.model", i), mapped); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return body, nil
+}
+
+// CheckMessageBatchLimits 逐条校验批次请求的模型与 max_tokens 是否符合 API Key 限制
+func CheckMessageBatchLimits(apiKey *APIKey, body []byte) error {
+	if apiKey == nil || (len(apiKey.AllowedModels) == 0 && apiKey.MaxTokensCap == nil) {
+		return nil
+	}
+	for _, item := range gjson.GetBytes(body, "requests").Array() {
+		if err := apiKey.CheckRequestLimits(item.Get("params.model").String(), int(item.Get("params.max_tokens").Int())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func messageBatchStatusFromPayload(payload []byte) string {
+	status := gjson.GetBytes(payload, "processing_status").String()
+	if status == "" {
+		return MessageBatchStatusInProgress
+	}
+	return status
+}
+
+func messageBatchResultsURLFromPayload(payload []byte) *string {
+	v := gjson.GetBytes(payload, "results_url").String()
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestParseMessageBatchResultLine(t *testing.T) {
+	line := []byte(`{"custom_id":"req-1","result":{"type":"succeeded","message":{"id":"msg_1","model":"claude-sonnet-4-5","usage":{"input_tokens":10,"output_tokens":20,"cache_creation_input_tokens":3,"cache_read_input_tokens":4}}}}`)
+	result, ok := parseMessageBatchResultLine(line)
+	require.True(t, ok)
+	require.Equal(t, "req-1", result.CustomID)
+	require.Equal(t, "succeeded", result.Type)
+	require.Equal(t, "msg_1", result.MessageID)
+	require.Equal(t, "claude-sonnet-4-5", result.Model)
+	require.Equal(t, ClaudeUsage{InputTokens: 10, OutputTokens: 20, CacheCreationInputTokens: 3, CacheReadInputTokens: 4}, result.Usage)
+
+	result, ok = parseMessageBatchResultLine([]byte(`{"custom_id":"req-2","result":{"type":"errored","error":{"type":"invalid_request"}}}`))
+	require.True(t, ok)
+	require.Equal(t, "errored", result.Type)
+	require.Zero(t, result.Usage)
+
+	_, ok = parseMessageBatchResultLine([]byte(`not json`))
+	require.False(t, ok)
+	_, ok = parseMessageBatchResultLine([]byte(`{"result":{"type":"succeeded"}}`))
+	require.False(t, ok)
+}
+
+func TestMessageBatchUsageRequestID(t *testing.T) {
+	id := messageBatchUsageRequestID("msgbatch_1", "req-1")
+	require.Equal(t, id, messageBatchUsageRequestID("msgbatch_1", "req-1"))
+	require.NotEqual(t, id, messageBatchUsageRequestID("msgbatch_1", "req-2"))
+	require.NotEqual(t, id, messageBatchUsageRequestID("msgbatch_2", "req-1"))
+	// usage_logs.request_id 最长 64
+	require.LessOrEqual(t, len(id), 64)
+}
+
+func TestRewriteMessageBatchResultsURL(t *testing.T) {
+	payload := []byte(`{"id":"msgbatch_1","results_url":"https://api.anthropic.com/v1/messages/batches/msgbatch_1/results"}`)
+	out := RewriteMessageBatchResultsURL(payload, "https://gw.example.com")
+	require.Equal(t, "https://gw.example.com/v1/messages/batches/msgbatch_1/results", gjson.GetBytes(out, "results_url").String())
+
+	pending := []byte(`{"id":"msgbatch_1","results_url":null}`)
+	require.Equal(t, pending, RewriteMessageBatchResultsURL(pending, "https://gw.example.com"))
+}
+
+func TestBuildMessageBatchListResponse(t *testing.T) {
+	out, err := buildMessageBatchListResponse([]MessageBatch{
+		{BatchID: "b2", Payload: []byte(`{"id":"b2"}`)},
+		{BatchID: "b1", Payload: []byte(`{"id":"b1"}`)},
+	}, true)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data":[{"id":"b2"},{"id":"b1"}],"has_more":true,"first_id":"b2","last_id":"b1"}`, string(out))
+
+	out, err = buildMessageBatchListResponse(nil, false)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data":[],"has_more":false,"first_id":null,"last_id":null}`, string(out))
+}
+
+func TestMapMessageBatchModels(t *testing.T) {
+	account := &Account{
+		Platform:    PlatformAnthropic,
+		Type:        AccountTypeAPIKey,
+		Credentials: map[string]any{"model_mapping": map[string]any{"claude-a": "claude-b"}},
+	}
+	body := []byte(`{"requests":[{"custom_id":"1","params":{"model":"claude-a"}},{"custom_id":"2","params":{"model":"claude-c"}}]}`)
+	out, err := mapMessageBatchModels(body, account)
+	require.NoError(t, err)
+	require.Equal(t, "claude-b", gjson.GetBytes(out, "requests.0.params.model").String())
+	require.Equal(t, "claude-c", gjson.GetBytes(out, "requests.1.params.model").String())
+
+	_, err = mapMessageBatchModels([]byte(`{"requests":[]}`), account)
+	require.Error(t, err)
+}
+
+func TestIsBatchAccount(t *testing.T) {
+	require.True(t, IsBatchAccount(&Account{Platform: PlatformAnthropic, Type: AccountTypeAPIKey}))
+	require.False(t, IsBatchAccount(&Account{Platform: PlatformAnthropic, Type: AccountTypeOAuth}))
+	require.False(t, IsBatchAccount(&Account{Platform: PlatformOpenAI, Type: AccountTypeAPIKey}))
+}
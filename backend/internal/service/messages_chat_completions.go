@@ -0,0 +1,660 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Messages → OpenAI 降级转换
+//
+// Anthropic Messages 请求降级到 OpenAI 分组时，请求先经 ConvertClaudeToChatCompletions 转为
+// Chat Completions，再复用 ConvertChatCompletionsToResponses 发往上游；上游 Responses 响应经
+// ChatCompletionsResponseWriter 转为 Chat Completions 后，由 ClaudeMessagesResponseWriter 转回 Messages 格式。
+
+// ConvertClaudeToChatCompletions 将 Anthropic Messages 请求体转换为 Chat Completions 请求。
+// thinking 块、文档与服务端工具没有对应字段，转换时丢弃。
+func ConvertClaudeToChatCompletions(body []byte) (*ChatCompletionsRequest, error) {
+	var src map[string]any
+	if err := json.Unmarshal(body, &src); err != nil {
+		return nil, err
+	}
+	model, _ := src["model"].(string)
+	stream, _ := src["stream"].(bool)
+	out := map[string]any{"model": model, "stream": stream}
+
+	var messages []any
+	if system := chatContentText(src["system"]); system != "" {
+		messages = append(messages, map[string]any{"role": "system", "content": system})
+	}
+	messageList, _ := src["messages"].([]any)
+	for _, raw := range messageList {
+		msg, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch msg["role"] {
+		case "user":
+			messages = append(messages, claudeUserToChatMessages(msg["content"])...)
+		case "assistant":
+			if converted := claudeAssistantToChatMessage(msg["content"]); converted != nil {
+				messages = append(messages, converted)
+			}
+		}
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("messages must contain at least one user or assistant message")
+	}
+	out["messages"] = messages
+
+	if tools := claudeToolsToChat(src["tools"]); len(tools) > 0 {
+		out["tools"] = tools
+		if choice, ok := src["tool_choice"].(map[string]any); ok {
+			switch choice["type"] {
+			case "auto":
+				out["tool_choice"] = "auto"
+			case "any":
+				out["tool_choice"] = "required"
+			case "none":
+				out["tool_choice"] = "none"
+			case "tool":
+				out["tool_choice"] = map[string]any{"type": "function", "function": map[string]any{"name": choice["name"]}}
+			}
+			if disabled, _ := choice["disable_parallel_tool_use"].(bool); disabled {
+				out["parallel_tool_calls"] = false
+			}
+		}
+	}
+	if v, ok := src["temperature"].(float64); ok {
+		out["temperature"] = v
+	}
+	if v, ok := src["top_p"].(float64); ok {
+		out["top_p"] = v
+	}
+	if stops, ok := src["stop_sequences"].([]any); ok && len(stops) > 0 {
+		out["stop"] = stops
+	}
+	maxTokens, _ := chatOptionalInt(src, "max_tokens")
+	if maxTokens > 0 {
+		out["max_tokens"] = float64(maxTokens)
+	}
+	if thinking, ok := src["thinking"].(map[string]any); ok && thinking["type"] == "enabled" {
+		out["reasoning_effort"] = claudeThinkingBudgetToEffort(thinking["budget_tokens"])
+	}
+	if metadata, ok := src["metadata"].(map[string]any); ok {
+		if userID, _ := metadata["user_id"].(string); userID != "" {
+			out["user"] = userID
+		}
+	}
+
+	return &ChatCompletionsRequest{Body: out, Model: model, Stream: stream, MaxTokens: maxTokens}, nil
+}
+
+// claudeUserToChatMessages tool_result 块拆为独立的 tool 消息（排在用户消息之前，紧跟上一轮的 tool_calls）
+func claudeUserToChatMessages(content any) []any {
+	if text, ok := content.(string); ok {
+		if text == "" {
+			return nil
+		}
+		return []any{map[string]any{"role": "user", "content": text}}
+	}
+	blocks, _ := content.([]any)
+	var toolMessages, parts []any
+	for _, raw := range blocks {
+		block, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch block["type"] {
+		case "tool_result":
+			toolMessages = append(toolMessages, map[string]any{
+				"role":         "tool",
+				"tool_call_id": block["tool_use_id"],
+				"content":      chatContentText(block["content"]),
+			})
+		case "text":
+			if text, _ := block["text"].(string); text != "" {
+				parts = append(parts, map[string]any{"type": "text", "text": text})
+			}
+		case "image":
+			source, _ := block["source"].(map[string]any)
+			url := ""
+			switch source["type"] {
+			case "base64":
+				mediaType, _ := source["media_type"].(string)
+				data, _ := source["data"].(string)
+				url = "data:" + mediaType + ";base64," + data
+			case "url":
+				url, _ = source["url"].(string)
+			}
+			if url != "" {
+				parts = append(parts, map[string]any{"type": "image_url", "image_url": map[string]any{"url": url}})
+			}
+		}
+	}
+	if len(parts) > 0 {
+		toolMessages = append(toolMessages, map[string]any{"role": "user", "content": parts})
+	}
+	return toolMessages
+}
+
+func claudeAssistantToChatMessage(content any) map[string]any {
+	if text, ok := content.(string); ok {
+		if text == "" {
+			return nil
+		}
+		return map[string]any{"role": "assistant", "content": text}
+	}
+	blocks, _ := content.([]any)
+	var textParts []string
+	var toolCalls []any
+	for _, raw := range blocks {
+		block, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch block["type"] {
+		case "text":
+			if text, _ := block["text"].(string); text != "" {
+				textParts = append(textParts, text)
+			}
+		case "tool_use":
+			args, err := json.Marshal(block["input"])
+			if err != nil || string(args) == "null" {
+				args = []byte("{}")
+			}
+			toolCalls = append(toolCalls, map[string]any{
+				"id":       block["id"],
+				"type":     "function",
+				"function": map[string]any{"name": block["name"], "arguments": string(args)},
+			})
+		}
+	}
+	if len(textParts) == 0 && len(toolCalls) == 0 {
+		return nil
+	}
+	msg := map[string]any{"role": "assistant", "content": strings.Join(textParts, "")}
+	if len(toolCalls) > 0 {
+		msg["tool_calls"] = toolCalls
+	}
+	return msg
+}
+
+// claudeToolsToChat 仅转换带 input_schema 的自定义工具，服务端工具（web_search 等）无法由 OpenAI 执行
+func claudeToolsToChat(raw any) []any {
+	tools, _ := raw.([]any)
+	out := make([]any, 0, len(tools))
+	for _, rawTool := range tools {
+		tool, ok := rawTool.(map[string]any)
+		if !ok {
+			continue
+		}
+		schema, ok := tool["input_schema"].(map[string]any)
+		if !ok {
+			continue
+		}
+		fn := map[string]any{"name": tool["name"], "parameters": schema}
+		if desc, ok := tool["description"].(string); ok && desc != "" {
+			fn["description"] = desc
+		}
+		out = append(out, map[string]any{"type": "function", "function": fn})
+	}
+	return out
+}
+
+func claudeThinkingBudgetToEffort(raw any) string {
+	budget, _ := asInt(raw)
+	switch {
+	case budget > 0 && budget <= 4096:
+		return "low"
+	case budget > 16384:
+		return "high"
+	default:
+		return "medium"
+	}
+}
+
+// ClaudeMessagesResponseWriter 包装 gin.ResponseWriter，将 Chat Completions 响应实时转换为 Anthropic Messages 格式。
+//
+// 流式响应按 SSE 行解析并逐块转换；非流式与错误响应先缓冲，由 Finish 统一转换写出。
+// reasoning_content 没有可回传给 Anthropic 的 thinking 签名，转换时丢弃。
+type ClaudeMessagesResponseWriter struct {
+	gin.ResponseWriter
+
+	mu       sync.Mutex
+	model    string
+	status   int
+	decided  bool
+	stream   bool
+	buf      bytes.Buffer
+	line     bytes.Buffer
+	finished bool
+
+	id         string
+	started    bool
+	openBlock  string // 当前打开的内容块类型，空表示没有打开的块
+	blockIndex int    // 当前打开的内容块序号
+	nextBlock  int
+	toolBlocks map[int]int // tool_calls[].index → 内容块序号
+	stopReason string
+	usage      chatCompletionsUsage
+	done       bool
+}
+
+// NewClaudeMessagesResponseWriter 创建转换写入器，model 为写入 Messages 响应的模型名
+func NewClaudeMessagesResponseWriter(w gin.ResponseWriter, model string) *ClaudeMessagesResponseWriter {
+	return &ClaudeMessagesResponseWriter{
+		ResponseWriter: w,
+		model:          model,
+		id:             "msg_" + randomHex(12),
+		toolBlocks:     make(map[int]int),
+	}
+}
+
+// WriteHeader 记录状态码，实际写出延迟到确定响应模式之后
+func (w *ClaudeMessagesResponseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.decided {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow 延迟到 Finish 或首个流式数据块写出
+func (w *ClaudeMessagesResponseWriter) WriteHeaderNow() {}
+
+// Status 返回记录的状态码
+func (w *ClaudeMessagesResponseWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.status != 0 {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+// Written 判断是否已有响应写入
+func (w *ClaudeMessagesResponseWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.decided || w.ResponseWriter.Written()
+}
+
+func (w *ClaudeMessagesResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *ClaudeMessagesResponseWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.decided {
+		w.decided = true
+		status := w.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		contentType := strings.ToLower(w.ResponseWriter.Header().Get("Content-Type"))
+		w.stream = status < 400 && strings.Contains(contentType, "text/event-stream")
+	}
+	if !w.stream {
+		w.buf.Write(b)
+		return len(b), nil
+	}
+
+	w.line.Write(b)
+	for {
+		data := w.line.Bytes()
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			break
+		}
+		line := strings.TrimRight(string(data[:idx]), "\r")
+		w.line.Next(idx + 1)
+		if err := w.handleStreamLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *ClaudeMessagesResponseWriter) Flush() {
+	w.mu.Lock()
+	stream := w.stream
+	w.mu.Unlock()
+	if stream {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// Finish 写出缓冲的非流式/错误响应，或补齐未正常结束的流。
+// 必须在上游写入器 Finish 之后、恢复原始 Writer 之前调用。
+func (w *ClaudeMessagesResponseWriter) Finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.finished {
+		return
+	}
+	w.finished = true
+
+	if !w.decided {
+		return
+	}
+	if w.stream {
+		if w.line.Len() > 0 {
+			line := strings.TrimRight(w.line.String(), "\r\n")
+			w.line.Reset()
+			_ = w.handleStreamLine(line)
+		}
+		if !w.done {
+			_ = w.emitFinal()
+		}
+		w.ResponseWriter.Flush()
+		return
+	}
+
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	var out []byte
+	if status >= 400 {
+		out = convertUpstreamErrorToClaude(w.buf.Bytes())
+	} else {
+		out = w.convertNonStreaming(w.buf.Bytes())
+	}
+	header := w.ResponseWriter.Header()
+	header.Set("Content-Type", "application/json")
+	header.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(status)
+	_, _ = w.ResponseWriter.Write(out)
+}
+
+func (w *ClaudeMessagesResponseWriter) handleStreamLine(line string) error {
+	// SSE 注释行（keepalive）原样透传
+	if strings.HasPrefix(line, ":") {
+		_, err := w.ResponseWriter.Write([]byte(line + "\n\n"))
+		return err
+	}
+	if !sseDataRe.MatchString(line) {
+		return nil
+	}
+	data := strings.TrimSpace(sseDataRe.ReplaceAllString(line, ""))
+	if data == "" || w.done {
+		return nil
+	}
+	if data == "[DONE]" {
+		return w.emitFinal()
+	}
+	var chunk map[string]any
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return nil
+	}
+	if errObj, ok := chunk["error"]; ok {
+		return w.emitStreamError(errObj)
+	}
+	if usage, ok := chunk["usage"].(map[string]any); ok {
+		w.applyChatUsage(usage)
+	}
+	if err := w.emitStart(); err != nil {
+		return err
+	}
+	choices, _ := chunk["choices"].([]any)
+	if len(choices) == 0 {
+		return nil
+	}
+	choice, _ := choices[0].(map[string]any)
+	if delta, ok := choice["delta"].(map[string]any); ok {
+		if text, _ := delta["content"].(string); text != "" {
+			if err := w.emitText(text); err != nil {
+				return err
+			}
+		}
+		toolCalls, _ := delta["tool_calls"].([]any)
+		for _, rawCall := range toolCalls {
+			call, ok := rawCall.(map[string]any)
+			if !ok {
+				continue
+			}
+			if err := w.emitToolCall(call); err != nil {
+				return err
+			}
+		}
+	}
+	if reason, _ := choice["finish_reason"].(string); reason != "" {
+		w.stopReason = mapChatFinishReasonToClaude(reason)
+	}
+	return nil
+}
+
+func (w *ClaudeMessagesResponseWriter) applyChatUsage(usage map[string]any) {
+	w.usage.PromptTokens, _ = asInt(usage["prompt_tokens"])
+	w.usage.CompletionTokens, _ = asInt(usage["completion_tokens"])
+	if details, ok := usage["prompt_tokens_details"].(map[string]any); ok {
+		w.usage.CachedTokens, _ = asInt(details["cached_tokens"])
+	}
+}
+
+// usageMap Chat Completions 的 prompt_tokens 含缓存命中部分，Messages 的 input_tokens 不含
+func (w *ClaudeMessagesResponseWriter) usageMap() map[string]any {
+	usage := map[string]any{
+		"input_tokens":  w.usage.PromptTokens - w.usage.CachedTokens,
+		"output_tokens": w.usage.CompletionTokens,
+	}
+	if w.usage.CachedTokens > 0 {
+		usage["cache_read_input_tokens"] = w.usage.CachedTokens
+	}
+	return usage
+}
+
+func (w *ClaudeMessagesResponseWriter) emitStart() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	return w.writeEvent("message_start", map[string]any{
+		"type": "message_start",
+		"message": map[string]any{
+			"id":            w.id,
+			"type":          "message",
+			"role":          "assistant",
+			"model":         w.model,
+			"content":       []any{},
+			"stop_reason":   nil,
+			"stop_sequence": nil,
+			"usage":         map[string]any{"input_tokens": 0, "output_tokens": 0},
+		},
+	})
+}
+
+func (w *ClaudeMessagesResponseWriter) startBlock(blockType string, block map[string]any) error {
+	if err := w.closeBlock(); err != nil {
+		return err
+	}
+	w.openBlock = blockType
+	w.blockIndex = w.nextBlock
+	w.nextBlock++
+	return w.writeEvent("content_block_start", map[string]any{
+		"type":          "content_block_start",
+		"index":         w.blockIndex,
+		"content_block": block,
+	})
+}
+
+func (w *ClaudeMessagesResponseWriter) closeBlock() error {
+	if w.openBlock == "" {
+		return nil
+	}
+	w.openBlock = ""
+	return w.writeEvent("content_block_stop", map[string]any{"type": "content_block_stop", "index": w.blockIndex})
+}
+
+func (w *ClaudeMessagesResponseWriter) emitText(text string) error {
+	if w.openBlock != "text" {
+		if err := w.startBlock("text", map[string]any{"type": "text", "text": ""}); err != nil {
+			return err
+		}
+	}
+	return w.writeEvent("content_block_delta", map[string]any{
+		"type":  "content_block_delta",
+		"index": w.blockIndex,
+		"delta": map[string]any{"type": "text_delta", "text": text},
+	})
+}
+
+func (w *ClaudeMessagesResponseWriter) emitToolCall(call map[string]any) error {
+	idx, _ := asInt(call["index"])
+	fn, _ := call["function"].(map[string]any)
+	if id, _ := call["id"].(string); id != "" {
+		if err := w.startBlock("tool_use", map[string]any{
+			"type":  "tool_use",
+			"id":    id,
+			"name":  fn["name"],
+			"input": map[string]any{},
+		}); err != nil {
+			return err
+		}
+		w.toolBlocks[idx] = w.blockIndex
+	}
+	block, ok := w.toolBlocks[idx]
+	args, _ := fn["arguments"].(string)
+	if !ok || args == "" {
+		return nil
+	}
+	return w.writeEvent("content_block_delta", map[string]any{
+		"type":  "content_block_delta",
+		"index": block,
+		"delta": map[string]any{"type": "input_json_delta", "partial_json": args},
+	})
+}
+
+// emitFinal 关闭内容块并写出携带 stop_reason/usage 的 message_delta 与 message_stop
+func (w *ClaudeMessagesResponseWriter) emitFinal() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+	if err := w.emitStart(); err != nil {
+		return err
+	}
+	if err := w.closeBlock(); err != nil {
+		return err
+	}
+	reason := w.stopReason
+	if reason == "" {
+		reason = "end_turn"
+	}
+	if err := w.writeEvent("message_delta", map[string]any{
+		"type":  "message_delta",
+		"delta": map[string]any{"stop_reason": reason, "stop_sequence": nil},
+		"usage": w.usageMap(),
+	}); err != nil {
+		return err
+	}
+	return w.writeEvent("message_stop", map[string]any{"type": "message_stop"})
+}
+
+func (w *ClaudeMessagesResponseWriter) emitStreamError(raw any) error {
+	w.done = true
+	errType, message := "api_error", "Upstream stream error"
+	if v, ok := raw.(map[string]any); ok {
+		if t, _ := v["type"].(string); t != "" {
+			errType = t
+		}
+		if m, _ := v["message"].(string); m != "" {
+			message = m
+		}
+	}
+	return w.writeEvent("error", map[string]any{
+		"type":  "error",
+		"error": map[string]any{"type": errType, "message": message},
+	})
+}
+
+func (w *ClaudeMessagesResponseWriter) writeEvent(event string, payload map[string]any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.ResponseWriter, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
+func (w *ClaudeMessagesResponseWriter) convertNonStreaming(body []byte) []byte {
+	var resp map[string]any
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return convertUpstreamErrorToClaude(body)
+	}
+	choices, _ := resp["choices"].([]any)
+	if len(choices) == 0 {
+		return convertUpstreamErrorToClaude(body)
+	}
+	choice, _ := choices[0].(map[string]any)
+	message, _ := choice["message"].(map[string]any)
+
+	content := []any{}
+	if text, _ := message["content"].(string); text != "" {
+		content = append(content, map[string]any{"type": "text", "text": text})
+	}
+	toolCalls, _ := message["tool_calls"].([]any)
+	for _, rawCall := range toolCalls {
+		call, ok := rawCall.(map[string]any)
+		if !ok {
+			continue
+		}
+		fn, _ := call["function"].(map[string]any)
+		content = append(content, map[string]any{
+			"type":  "tool_use",
+			"id":    call["id"],
+			"name":  fn["name"],
+			"input": parseToolArguments(fn["arguments"]),
+		})
+	}
+	if usage, ok := resp["usage"].(map[string]any); ok {
+		w.applyChatUsage(usage)
+	}
+	reason, _ := choice["finish_reason"].(string)
+
+	out, err := json.Marshal(map[string]any{
+		"id":            w.id,
+		"type":          "message",
+		"role":          "assistant",
+		"model":         w.model,
+		"content":       content,
+		"stop_reason":   mapChatFinishReasonToClaude(reason),
+		"stop_sequence": nil,
+		"usage":         w.usageMap(),
+	})
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// convertUpstreamErrorToClaude 将上游错误体统一为 Anthropic 错误格式
+func convertUpstreamErrorToClaude(body []byte) []byte {
+	var parsed map[string]any
+	if err := json.Unmarshal(convertUpstreamErrorToChat(body), &parsed); err != nil {
+		parsed = map[string]any{"error": map[string]any{"type": "api_error", "message": "Upstream request failed"}}
+	}
+	parsed["type"] = "error"
+	out, _ := json.Marshal(parsed)
+	return out
+}
+
+func mapChatFinishReasonToClaude(reason string) string {
+	switch reason {
+	case "length":
+		return "max_tokens"
+	case "tool_calls", "function_call":
+		return "tool_use"
+	case "content_filter":
+		return "refusal"
+	default:
+		return "end_turn"
+	}
+}
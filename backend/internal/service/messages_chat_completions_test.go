@@ -0,0 +1,168 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertClaudeToChatCompletions(t *testing.T) {
+	req, err := ConvertClaudeToChatCompletions([]byte(`{
+		"model":"gpt-5",
+		"stream":true,
+		"max_tokens":1024,
+		"system":[{"type":"text","text":"be brief"}],
+		"metadata":{"user_id":"session-1"},
+		"thinking":{"type":"enabled","budget_tokens":2048},
+		"tools":[{"name":"get_weather","description":"weather","input_schema":{"type":"object"}},{"type":"web_search_20250305","name":"web_search"}],
+		"tool_choice":{"type":"any","disable_parallel_tool_use":true},
+		"messages":[
+			{"role":"user","content":"weather?"},
+			{"role":"assistant","content":[{"type":"thinking","thinking":"hmm","signature":"sig"},{"type":"text","text":"checking"},{"type":"tool_use","id":"toolu_1","name":"get_weather","input":{"city":"Paris"}}]},
+			{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":[{"type":"text","text":"sunny"}]},{"type":"image","source":{"type":"base64","media_type":"image/png","data":"AAA"}}]}
+		]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, "gpt-5", req.Model)
+	require.True(t, req.Stream)
+	require.Equal(t, 1024, req.MaxTokens)
+
+	body := req.Body
+	require.Equal(t, "required", body["tool_choice"])
+	require.Equal(t, false, body["parallel_tool_calls"])
+	require.Equal(t, "low", body["reasoning_effort"])
+	require.Equal(t, "session-1", body["user"])
+	require.Len(t, body["tools"], 1, "server tools are dropped")
+
+	messages := body["messages"].([]any)
+	require.Len(t, messages, 5)
+	require.Equal(t, map[string]any{"role": "system", "content": "be brief"}, messages[0])
+	assistant := messages[2].(map[string]any)
+	require.Equal(t, "checking", assistant["content"])
+	call := assistant["tool_calls"].([]any)[0].(map[string]any)
+	require.Equal(t, `{"city":"Paris"}`, call["function"].(map[string]any)["arguments"])
+	// tool_result 先于同一条消息中的其他内容，紧跟上一轮的 tool_calls
+	require.Equal(t, map[string]any{"role": "tool", "tool_call_id": "toolu_1", "content": "sunny"}, messages[3])
+	image := messages[4].(map[string]any)["content"].([]any)[0].(map[string]any)
+	require.Equal(t, "data:image/png;base64,AAA", image["image_url"].(map[string]any)["url"])
+
+	// 复用 Chat Completions → Responses 转换器
+	converted, err := ConvertChatCompletionsToResponses(req)
+	require.NoError(t, err)
+	var responses map[string]any
+	require.NoError(t, json.Unmarshal(converted, &responses))
+	require.Equal(t, "be brief", responses["instructions"])
+	require.EqualValues(t, 1024, responses["max_output_tokens"])
+	require.Equal(t, "session-1", responses["prompt_cache_key"])
+
+	_, err = ConvertClaudeToChatCompletions([]byte(`{"model":"gpt-5","messages":[]}`))
+	require.Error(t, err)
+}
+
+// newResponsesToClaudeTestWriter 串联 Responses → Chat Completions → Messages 两级写入器
+func newResponsesToClaudeTestWriter() (*ChatCompletionsResponseWriter, *ClaudeMessagesResponseWriter, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	claude := NewClaudeMessagesResponseWriter(c.Writer, "gpt-5")
+	return NewChatCompletionsResponseWriter(claude, ChatCompletionsUpstreamResponses, "gpt-5"), claude, rec
+}
+
+func parseClaudeEvents(t *testing.T, body string) []map[string]any {
+	t.Helper()
+	var events []map[string]any
+	for _, line := range strings.Split(body, "\n") {
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+		var event map[string]any
+		require.NoError(t, json.Unmarshal([]byte(data), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestClaudeMessagesWriterResponsesStream(t *testing.T) {
+	chat, claude, rec := newResponsesToClaudeTestWriter()
+	chat.Header().Set("Content-Type", "text/event-stream")
+
+	_, err := chat.WriteString(strings.Join([]string{
+		`data: {"type":"response.created","response":{}}`,
+		`data: {"type":"response.reasoning_summary_text.delta","delta":"think"}`,
+		`data: {"type":"response.output_text.delta","delta":"Hel"}`,
+		`data: {"type":"response.output_text.delta","delta":"lo"}`,
+		`data: {"type":"response.output_item.added","output_index":1,"item":{"type":"function_call","call_id":"call_1","name":"f"}}`,
+		`data: {"type":"response.function_call_arguments.delta","output_index":1,"delta":"{\"a\":1}"}`,
+		`data: {"type":"response.completed","response":{"status":"completed","usage":{"input_tokens":10,"output_tokens":4,"input_tokens_details":{"cached_tokens":6}}}}`,
+		``,
+	}, "\n"))
+	require.NoError(t, err)
+	chat.Finish()
+	claude.Finish()
+
+	events := parseClaudeEvents(t, rec.Body.String())
+	var types []string
+	for _, event := range events {
+		types = append(types, event["type"].(string))
+	}
+	require.Equal(t, []string{
+		"message_start",
+		"content_block_start", "content_block_delta", "content_block_delta", "content_block_stop",
+		"content_block_start", "content_block_delta", "content_block_stop",
+		"message_delta", "message_stop",
+	}, types, "reasoning is dropped, text and tool_use become separate blocks")
+	require.Contains(t, rec.Body.String(), "event: message_start\n")
+
+	require.Equal(t, "gpt-5", events[0]["message"].(map[string]any)["model"])
+	require.Equal(t, "Hel", events[2]["delta"].(map[string]any)["text"])
+	tool := events[5]["content_block"].(map[string]any)
+	require.Equal(t, "call_1", tool["id"])
+	require.EqualValues(t, 1, events[5]["index"])
+	require.Equal(t, `{"a":1}`, events[6]["delta"].(map[string]any)["partial_json"])
+
+	delta := events[8]
+	require.Equal(t, "tool_use", delta["delta"].(map[string]any)["stop_reason"])
+	usage := delta["usage"].(map[string]any)
+	require.EqualValues(t, 4, usage["input_tokens"])
+	require.EqualValues(t, 6, usage["cache_read_input_tokens"])
+	require.EqualValues(t, 4, usage["output_tokens"])
+}
+
+func TestClaudeMessagesWriterNonStreaming(t *testing.T) {
+	chat, claude, rec := newResponsesToClaudeTestWriter()
+	chat.Header().Set("Content-Type", "application/json")
+	chat.WriteHeader(http.StatusOK)
+	_, err := chat.Write([]byte(`{"status":"completed","output":[{"type":"message","content":[{"type":"output_text","text":"hi"}]},{"type":"function_call","call_id":"call_1","name":"f","arguments":"{\"x\":1}"}],"usage":{"input_tokens":5,"output_tokens":2}}`))
+	require.NoError(t, err)
+	chat.Finish()
+	claude.Finish()
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	require.Equal(t, "message", out["type"])
+	require.Equal(t, "tool_use", out["stop_reason"])
+	content := out["content"].([]any)
+	require.Equal(t, map[string]any{"type": "text", "text": "hi"}, content[0])
+	require.Equal(t, map[string]any{"x": float64(1)}, content[1].(map[string]any)["input"])
+	require.EqualValues(t, 5, out["usage"].(map[string]any)["input_tokens"])
+}
+
+func TestClaudeMessagesWriterErrorResponse(t *testing.T) {
+	chat, claude, rec := newResponsesToClaudeTestWriter()
+	chat.Header().Set("Content-Type", "application/json")
+	chat.WriteHeader(http.StatusBadRequest)
+	_, err := chat.Write([]byte(`{"error":{"type":"invalid_request_error","message":"bad input"}}`))
+	require.NoError(t, err)
+	chat.Finish()
+	claude.Finish()
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.JSONEq(t, `{"type":"error","error":{"type":"invalid_request_error","message":"bad input"}}`, rec.Body.String())
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ModelFallbackTarget 模型降级链中的一个节点：在目标分组的账号上以指定模型重试请求
+type ModelFallbackTarget struct {
+	GroupID int64  `json:"group_id"`
+	Model   string `json:"model"`
+}
+
+// MaxModelFallbackChainLen 单条降级链的最大长度，避免一次请求在过多分组间反复调度
+const MaxModelFallbackChainLen = 5
+
+// ModelFallbackEntry 触发降级链的请求入口，决定哪些平台的分组可以承接降级后的请求
+type ModelFallbackEntry string
+
+const (
+	// ModelFallbackEntryMessages Anthropic Messages 请求（/v1/messages 及非 OpenAI 分组的 Chat Completions）：
+	// Anthropic/Gemini/Antigravity 改写 model 后由各 Forward 内置的 Claude↔Gemini 转换器处理，
+	// OpenAI 经 Chat Completions↔Responses 转换器转发
+	ModelFallbackEntryMessages ModelFallbackEntry = "messages"
+	// ModelFallbackEntryResponses OpenAI Responses 请求（/v1/responses 及 OpenAI 分组的 Chat Completions）：
+	// previous_response_id、内置工具等字段无法转换为其他协议，仅 OpenAI 分组可承接
+	ModelFallbackEntryResponses ModelFallbackEntry = "responses"
+	// ModelFallbackEntryGemini Gemini 原生请求（/v1beta/models/*）：仅 Gemini/Antigravity 分组可承接
+	ModelFallbackEntryGemini ModelFallbackEntry = "gemini"
+)
+
+// Supports 判断该入口的请求能否转发到指定平台的分组
+func (e ModelFallbackEntry) Supports(platform string) bool {
+	switch e {
+	case ModelFallbackEntryMessages:
+		return isModelFallbackPlatform(platform)
+	case ModelFallbackEntryResponses:
+		return platform == PlatformOpenAI
+	case ModelFallbackEntryGemini:
+		return platform == PlatformGemini || platform == PlatformAntigravity
+	default:
+		return false
+	}
+}
+
+// isModelFallbackPlatform 判断平台能否作为降级目标（至少有一个入口可以承接）
+func isModelFallbackPlatform(platform string) bool {
+	switch platform {
+	case PlatformAnthropic, PlatformOpenAI, PlatformGemini, PlatformAntigravity:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetModelFallbacks 根据请求模型获取降级链
+// 精确匹配优先，其次按最长前缀匹配通配符规则；无匹配时返回 nil
+func (g *Group) GetModelFallbacks(requestedModel string) []ModelFallbackTarget {
+	if g == nil || len(g.ModelFallbacks) == 0 || requestedModel == "" {
+		return nil
+	}
+	if targets, ok := g.ModelFallbacks[requestedModel]; ok && len(targets) > 0 {
+		return targets
+	}
+
+	// 通配符规则按模式长度倒序匹配，保证结果稳定
+	patterns := make([]string, 0, len(g.ModelFallbacks))
+	for pattern := range g.ModelFallbacks {
+		if strings.HasSuffix(pattern, "*") {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	for _, pattern := range patterns {
+		if matchModelPattern(pattern, requestedModel) && len(g.ModelFallbacks[pattern]) > 0 {
+			return g.ModelFallbacks[pattern]
+		}
+	}
+	return nil
+}
+
+// ResolveModelFallbackGroup 加载降级目标分组；分组停用、入口不支持目标平台或 API Key 不允许目标模型时返回错误，
+// 调用方应跳过该节点
+func (s *GatewayService) ResolveModelFallbackGroup(ctx context.Context, entry ModelFallbackEntry, apiKey *APIKey, target ModelFallbackTarget) (*Group, error) {
+	if apiKey != nil && !apiKey.IsModelAllowed(target.Model) {
+		return nil, fmt.Errorf("fallback model %s is not allowed for api key %d", target.Model, apiKey.ID)
+	}
+	group, err := s.resolveGroupByID(ctx, target.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if !group.IsActive() {
+		return nil, fmt.Errorf("fallback group %d is not active", group.ID)
+	}
+	if !entry.Supports(group.Platform) {
+		return nil, fmt.Errorf("fallback group %d platform %s is not supported for %s requests", group.ID, group.Platform, entry)
+	}
+	return group, nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type fallbackGroupRepoStub struct {
+	GroupRepository
+	groups map[int64]*Group
+}
+
+func (s *fallbackGroupRepoStub) GetByIDLite(ctx context.Context, id int64) (*Group, error) {
+	if g, ok := s.groups[id]; ok {
+		return g, nil
+	}
+	return nil, errors.New("group not found")
+}
+
+func newFallbackGroupRepoStub() *fallbackGroupRepoStub {
+	return &fallbackGroupRepoStub{groups: map[int64]*Group{
+		2: {ID: 2, Platform: PlatformAntigravity, Status: StatusActive},
+		3: {ID: 3, Platform: PlatformOpenAI, Status: StatusActive},
+		4: {ID: 4, Platform: PlatformGemini, Status: "inactive"},
+		5: {ID: 5, Platform: "unknown", Status: StatusActive},
+		6: {ID: 6, Platform: PlatformGemini, Status: StatusActive},
+	}}
+}
+
+func TestGroup_GetModelFallbacks(t *testing.T) {
+	exact := []ModelFallbackTarget{{GroupID: 2, Model: "gemini-2.5-pro"}}
+	broad := []ModelFallbackTarget{{GroupID: 2, Model: "gemini-2.5-flash"}}
+	narrow := []ModelFallbackTarget{{GroupID: 2, Model: "gemini-3-pro"}}
+	g := &Group{ModelFallbacks: map[string][]ModelFallbackTarget{
+		"claude-sonnet-4":   exact,
+		"claude-*":          broad,
+		"claude-sonnet-4-*": narrow,
+	}}
+
+	require.Equal(t, exact, g.GetModelFallbacks("claude-sonnet-4"))
+	require.Equal(t, narrow, g.GetModelFallbacks("claude-sonnet-4-20250514"), "longest wildcard wins")
+	require.Equal(t, broad, g.GetModelFallbacks("claude-haiku-4-5"))
+	require.Nil(t, g.GetModelFallbacks("gpt-5"))
+	require.Nil(t, g.GetModelFallbacks(""))
+
+	var nilGroup *Group
+	require.Nil(t, nilGroup.GetModelFallbacks("claude-sonnet-4"))
+}
+
+func TestAdminService_ValidateModelFallbacks(t *testing.T) {
+	svc := &adminServiceImpl{groupRepo: newFallbackGroupRepoStub()}
+	ctx := context.Background()
+
+	require.NoError(t, svc.validateModelFallbacks(ctx, 1, nil))
+	require.NoError(t, svc.validateModelFallbacks(ctx, 1, map[string][]ModelFallbackTarget{
+		"claude-sonnet-4*": {{GroupID: 2, Model: "gemini-2.5-pro"}, {GroupID: 3, Model: "gpt-5"}},
+	}))
+
+	cases := map[string]map[string][]ModelFallbackTarget{
+		"missing model":        {"claude-*": {{GroupID: 2}}},
+		"unknown group":        {"claude-*": {{GroupID: 99, Model: "m"}}},
+		"unsupported platform": {"claude-*": {{GroupID: 5, Model: "m"}}},
+		"self target":          {"claude-sonnet-4": {{GroupID: 1, Model: "claude-sonnet-4"}}},
+		"chain too long": {"claude-*": {
+			{GroupID: 2, Model: "a"}, {GroupID: 2, Model: "b"}, {GroupID: 2, Model: "c"},
+			{GroupID: 2, Model: "d"}, {GroupID: 2, Model: "e"}, {GroupID: 2, Model: "f"},
+		}},
+	}
+	for name, fallbacks := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, svc.validateModelFallbacks(ctx, 1, fallbacks))
+		})
+	}
+}
+
+func TestModelFallbackEntry_Supports(t *testing.T) {
+	for _, platform := range []string{PlatformAnthropic, PlatformOpenAI, PlatformGemini, PlatformAntigravity} {
+		require.True(t, ModelFallbackEntryMessages.Supports(platform), platform)
+	}
+	require.True(t, ModelFallbackEntryResponses.Supports(PlatformOpenAI))
+	require.False(t, ModelFallbackEntryResponses.Supports(PlatformAnthropic))
+	require.True(t, ModelFallbackEntryGemini.Supports(PlatformAntigravity))
+	require.False(t, ModelFallbackEntryGemini.Supports(PlatformOpenAI))
+	require.False(t, ModelFallbackEntryMessages.Supports("unknown"))
+}
+
+func TestGatewayService_ResolveModelFallbackGroup(t *testing.T) {
+	svc := &GatewayService{groupRepo: newFallbackGroupRepoStub()}
+	ctx := context.Background()
+	key := &APIKey{ID: 1}
+
+	group, err := svc.ResolveModelFallbackGroup(ctx, ModelFallbackEntryMessages, key, ModelFallbackTarget{GroupID: 2, Model: "gemini-2.5-pro"})
+	require.NoError(t, err)
+	require.Equal(t, PlatformAntigravity, group.Platform)
+
+	group, err = svc.ResolveModelFallbackGroup(ctx, ModelFallbackEntryMessages, key, ModelFallbackTarget{GroupID: 3, Model: "gpt-5"})
+	require.NoError(t, err, "messages requests reach openai through the chat completions converters")
+	require.Equal(t, PlatformOpenAI, group.Platform)
+
+	_, err = svc.ResolveModelFallbackGroup(ctx, ModelFallbackEntryGemini, key, ModelFallbackTarget{GroupID: 3, Model: "gpt-5"})
+	require.Error(t, err, "gemini native requests cannot be converted to responses")
+	_, err = svc.ResolveModelFallbackGroup(ctx, ModelFallbackEntryResponses, key, ModelFallbackTarget{GroupID: 6, Model: "gemini-2.5-pro"})
+	require.Error(t, err, "responses requests stay on openai")
+	_, err = svc.ResolveModelFallbackGroup(ctx, ModelFallbackEntryMessages, key, ModelFallbackTarget{GroupID: 4, Model: "gemini-2.5-pro"})
+	require.Error(t, err, "inactive group is skipped")
+	_, err = svc.ResolveModelFallbackGroup(ctx, ModelFallbackEntryMessages, key, ModelFallbackTarget{GroupID: 9, Model: "gemini-2.5-pro"})
+	require.Error(t, err)
+
+	// API Key 模型白名单同样约束降级目标
+	restricted := &APIKey{ID: 2, AllowedModels: []string{"claude-*", "gemini-2.5-*"}}
+	_, err = svc.ResolveModelFallbackGroup(ctx, ModelFallbackEntryMessages, restricted, ModelFallbackTarget{GroupID: 3, Model: "gpt-5"})
+	require.Error(t, err)
+	_, err = svc.ResolveModelFallbackGroup(ctx, ModelFallbackEntryMessages, restricted, ModelFallbackTarget{GroupID: 2, Model: "gemini-2.5-pro"})
+	require.NoError(t, err)
+}
+
+type fallbackUsageLogRepoStub struct {
+	UsageLogRepository
+	logs []*UsageLog
+}
+
+func (s *fallbackUsageLogRepoStub) Create(ctx context.Context, log *UsageLog) (bool, error) {
+	s.logs = append(s.logs, log)
+	return true, nil
+}
+
+func TestGatewayService_RecordUsageBillsServingGroup(t *testing.T) {
+	cfg := &config.Config{RunMode: config.RunModeSimple}
+	cfg.Default.RateMultiplier = 1
+	usageLogs := &fallbackUsageLogRepoStub{}
+	svc := &GatewayService{
+		cfg:             cfg,
+		usageLogRepo:    usageLogs,
+		billingService:  NewBillingService(cfg, nil),
+		deferredService: &DeferredService{},
+	}
+
+	primaryID := int64(1)
+	apiKey := &APIKey{ID: 7, GroupID: &primaryID, Group: &Group{ID: primaryID, Platform: PlatformAnthropic, RateMultiplier: 1}}
+	fallback := &Group{ID: 2, Platform: PlatformGemini, RateMultiplier: 3}
+	record := func(serving *Group) *UsageLog {
+		err := svc.RecordUsage(context.Background(), &RecordUsageInput{
+			Result:       &ForwardResult{RequestID: "req", Model: "claude-sonnet-4", Usage: ClaudeUsage{InputTokens: 1000, OutputTokens: 1000}},
+			APIKey:       apiKey,
+			User:         &User{ID: 9},
+			Account:      &Account{ID: 11},
+			ServingGroup: serving,
+		})
+		require.NoError(t, err)
+		return usageLogs.logs[len(usageLogs.logs)-1]
+	}
+
+	direct := record(nil)
+	require.Equal(t, 1.0, direct.RateMultiplier)
+	require.Positive(t, direct.ActualCost)
+
+	// 降级到其他分组时按实际服务分组的倍率计费，API Key 所属分组保持不变
+	served := record(fallback)
+	require.Equal(t, 3.0, served.RateMultiplier)
+	require.InDelta(t, direct.ActualCost*3, served.ActualCost, 1e-12)
+	require.Equal(t, primaryID, *served.GroupID)
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+// 模型价格来源（价格查询接口返回）
+const (
+	ModelPriceSourceGroup    = "group_override" // 分组覆盖的自定义价格
+	ModelPriceSourceCustom   = "custom"         // 全局自定义价格
+	ModelPriceSourceLiteLLM  = "litellm"        // LiteLLM 动态价格
+	ModelPriceSourceFallback = "fallback"       // 硬编码回退价格（按模型系列猜测）
+)
+
+// maxModelPriceImportEntries 单次导入的最大条目数
+const maxModelPriceImportEntries = 5000
+
+var (
+	ErrModelPriceNotFound  = infraerrors.NotFound("MODEL_PRICE_NOT_FOUND", "model price not found")
+	ErrModelPriceExists    = infraerrors.Conflict("MODEL_PRICE_EXISTS", "a price for this model, group and effective time already exists")
+	ErrModelPriceInEffect  = infraerrors.BadRequest("MODEL_PRICE_IN_EFFECT", "price is already in effect; create a new price with a later effective_from instead")
+	ErrModelPriceInvalid   = infraerrors.BadRequest("MODEL_PRICE_INVALID", "prices must be finite and non-negative")
+	ErrModelPriceModelName = infraerrors.BadRequest("MODEL_PRICE_MODEL_REQUIRED", "model is required")
+	ErrModelPriceTier      = infraerrors.BadRequest("MODEL_PRICE_TIER_INVALID", "tier thresholds must be positive and unique")
+)
+
+// maxModelPriceTiers 单个价格的最大长上下文阶梯数
+const maxModelPriceTiers = 8
+
+// ModelPrice 管理员自定义的模型价格
+//
+// 价格均为每 token（图片为每张）USD，与 LiteLLM 格式一致。GroupID 为 nil 表示全局价格，
+// 否则为分组覆盖。同一模型/分组可以有多条不同 EffectiveFrom 的价格，计费时取已生效的最新一条；
+// 用量记录时即按当时价格计算并落库，后续调价不会重新定价历史用量。
+type ModelPrice struct {
+	ID              int64
+	Model           string
+	GroupID         *int64
+	InputPrice      float64
+	OutputPrice     float64
+	CacheWritePrice float64
+	CacheReadPrice  float64
+	// CacheWrite1hPrice 1 小时缓存创建价格，nil 表示按 5 分钟价格换算
+	CacheWrite1hPrice *float64
+	// ImagePrice 每张图片价格，nil 表示沿用分组图片价格或 LiteLLM 默认值
+	ImagePrice *float64
+	// Tiers 长上下文阶梯价格（上下文超过阈值时整个请求按阶梯价格计费）
+	Tiers         []ModelPriceTier
+	EffectiveFrom time.Time
+	Notes         string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// InEffect 价格在 at 时刻是否已生效
+func (p *ModelPrice) InEffect(at time.Time) bool {
+	return !p.EffectiveFrom.After(at)
+}
+
+// Validate 校验价格字段
+func (p *ModelPrice) Validate() error {
+	if strings.TrimSpace(p.Model) == "" {
+		return ErrModelPriceModelName
+	}
+	for _, v := range []float64{p.InputPrice, p.OutputPrice, p.CacheWritePrice, p.CacheReadPrice} {
+		if !validModelPriceValue(v) {
+			return ErrModelPriceInvalid
+		}
+	}
+	if p.ImagePrice != nil && !validModelPriceValue(*p.ImagePrice) {
+		return ErrModelPriceInvalid
+	}
+	if p.CacheWrite1hPrice != nil && !validModelPriceValue(*p.CacheWrite1hPrice) {
+		return ErrModelPriceInvalid
+	}
+	if len(p.Tiers) > maxModelPriceTiers {
+		return ErrModelPriceTier
+	}
+	seen := make(map[int]struct{}, len(p.Tiers))
+	for _, t := range p.Tiers {
+		if _, dup := seen[t.Threshold]; dup || t.Threshold <= 0 {
+			return ErrModelPriceTier
+		}
+		seen[t.Threshold] = struct{}{}
+		for _, v := range []float64{t.InputPrice, t.OutputPrice, t.CacheWritePrice, t.CacheWrite1hPrice, t.CacheReadPrice} {
+			if !validModelPriceValue(v) {
+				return ErrModelPriceInvalid
+			}
+		}
+	}
+	return nil
+}
+
+// ModelPriceTier 长上下文阶梯价格，价格为 0 表示沿用基础价格（缓存价格按输入价格涨幅换算）
+type ModelPriceTier struct {
+	Threshold         int     `json:"threshold"`
+	InputPrice        float64 `json:"input_price"`
+	OutputPrice       float64 `json:"output_price"`
+	CacheWritePrice   float64 `json:"cache_write_price"`
+	CacheWrite1hPrice float64 `json:"cache_write_1h_price"`
+	CacheReadPrice    float64 `json:"cache_read_price"`
+}
+
+func validModelPriceValue(v float64) bool {
+	return v >= 0 && !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// normalizeModelPriceName 价格表中的模型名统一为小写精确匹配
+func normalizeModelPriceName(model string) string {
+	return strings.ToLower(strings.TrimSpace(model))
+}
+
+// ModelPriceFilter 价格列表过滤条件
+type ModelPriceFilter struct {
+	// Model 模型名子串（大小写不敏感）
+	Model string
+	// GroupID 仅列出该分组的覆盖价格
+	GroupID *int64
+	// GlobalOnly 仅列出全局价格（GroupID 为空时生效）
+	GlobalOnly bool
+}
+
+// ModelPriceRepository 自定义模型价格存储
+type ModelPriceRepository interface {
+	List(ctx context.Context, filter ModelPriceFilter, params pagination.PaginationParams) ([]ModelPrice, *pagination.PaginationResult, error)
+	// ListAll 返回全部价格（用于构建计费内存索引）
+	ListAll(ctx context.Context) ([]ModelPrice, error)
+	GetByID(ctx context.Context, id int64) (*ModelPrice, error)
+	// Create 创建价格，同一 模型/分组/生效时间 已存在时返回 ErrModelPriceExists
+	Create(ctx context.Context, price *ModelPrice) error
+	Update(ctx context.Context, price *ModelPrice) error
+	Delete(ctx context.Context, id int64) error
+	// Upsert 按 模型/分组/生效时间 插入或覆盖尚未生效的价格，返回是否为新建；
+	// 冲突的价格已生效时返回 ErrModelPriceInEffect
+	Upsert(ctx context.Context, price *ModelPrice) (bool, error)
+}
+
+// modelPriceIndex 计费使用的只读价格索引：模型名 -> 按 EffectiveFrom 降序排列的价格
+type modelPriceIndex struct {
+	byModel map[string][]ModelPrice
+}
+
+func newModelPriceIndex(prices []ModelPrice) *modelPriceIndex {
+	idx := &modelPriceIndex{byModel: make(map[string][]ModelPrice, len(prices))}
+	for _, p := range prices {
+		key := normalizeModelPriceName(p.Model)
+		idx.byModel[key] = append(idx.byModel[key], p)
+	}
+	for _, list := range idx.byModel {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].EffectiveFrom.After(list[j].EffectiveFrom)
+		})
+	}
+	return idx
+}
+
+// canonical 返回索引中与 model 对应的模型名（不区分分组与生效时间），未配置时返回空字符串
+func (idx *modelPriceIndex) canonical(model string) string {
+	if idx == nil || len(idx.byModel) == 0 {
+		return ""
+	}
+	lower := normalizeModelPriceName(model)
+	for _, name := range []string{lower, normalizeModelNameForPricing(lower)} {
+		if len(idx.byModel[name]) > 0 {
+			return name
+		}
+	}
+	return ""
+}
+
+// lookup 返回 at 时刻对模型生效的价格：分组覆盖优先，其次全局价格
+func (idx *modelPriceIndex) lookup(model string, groupID *int64, at time.Time) (*ModelPrice, string) {
+	if idx == nil || len(idx.byModel) == 0 {
+		return nil, ""
+	}
+	lower := normalizeModelPriceName(model)
+	candidates := []string{lower}
+	if normalized := normalizeModelNameForPricing(lower); normalized != lower {
+		candidates = append(candidates, normalized)
+	}
+	for _, name := range candidates {
+		list := idx.byModel[name]
+		if len(list) == 0 {
+			continue
+		}
+		if groupID != nil {
+			for i := range list {
+				if list[i].GroupID != nil && *list[i].GroupID == *groupID && list[i].InEffect(at) {
+					return &list[i], ModelPriceSourceGroup
+				}
+			}
+		}
+		for i := range list {
+			if list[i].GroupID == nil && list[i].InEffect(at) {
+				return &list[i], ModelPriceSourceCustom
+			}
+		}
+	}
+	return nil, ""
+}
+
+// toModelPricing 转换为计费使用的价格结构
+func (p *ModelPrice) toModelPricing() *ModelPricing {
+	pricing := &ModelPricing{
+		InputPricePerToken:         p.InputPrice,
+		OutputPricePerToken:        p.OutputPrice,
+		CacheCreationPricePerToken: p.CacheWritePrice,
+		CacheReadPricePerToken:     p.CacheReadPrice,
+	}
+	if p.CacheWrite1hPrice != nil {
+		pricing.CacheCreation1hPricePerToken = *p.CacheWrite1hPrice
+	}
+	for _, t := range p.Tiers {
+		pricing.Tiers = append(pricing.Tiers, ModelPricingTier{
+			Threshold:                    t.Threshold,
+			InputPricePerToken:           t.InputPrice,
+			OutputPricePerToken:          t.OutputPrice,
+			CacheCreationPricePerToken:   t.CacheWritePrice,
+			CacheCreation1hPricePerToken: t.CacheWrite1hPrice,
+			CacheReadPricePerToken:       t.CacheReadPrice,
+		})
+	}
+	return pricing
+}
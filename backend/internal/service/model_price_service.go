@@ -0,0 +1,452 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+// modelPriceRefreshInterval 内存价格索引的刷新间隔（多实例部署时其他实例的改动在此间隔内生效）
+const modelPriceRefreshInterval = 30 * time.Second
+
+// ModelPriceService 管理员自定义模型价格：价格管理、LiteLLM 格式导入导出，
+// 并维护计费热路径使用的内存索引（计费时不访问数据库）。
+type ModelPriceService struct {
+	repo      ModelPriceRepository
+	groupRepo GroupRepository
+	index     atomic.Pointer[modelPriceIndex]
+
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// NewModelPriceService 创建自定义模型价格服务
+func NewModelPriceService(repo ModelPriceRepository, groupRepo GroupRepository) *ModelPriceService {
+	return &ModelPriceService{
+		repo:      repo,
+		groupRepo: groupRepo,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start 加载价格索引并启动定时刷新
+func (s *ModelPriceService) Start() {
+	if s == nil {
+		return
+	}
+	s.startOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := s.Reload(ctx); err != nil {
+			log.Printf("[ModelPrice] Initial load failed: %v", err)
+		}
+		cancel()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			ticker := time.NewTicker(modelPriceRefreshInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+					if err := s.Reload(ctx); err != nil {
+						log.Printf("[ModelPrice] Refresh failed: %v", err)
+					}
+					cancel()
+				case <-s.stopCh:
+					return
+				}
+			}
+		}()
+	})
+}
+
+// Stop 停止定时刷新
+func (s *ModelPriceService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		s.wg.Wait()
+	})
+}
+
+// Reload 从数据库重建内存价格索引
+func (s *ModelPriceService) Reload(ctx context.Context) error {
+	prices, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	s.index.Store(newModelPriceIndex(prices))
+	return nil
+}
+
+// Lookup 返回 at 时刻对模型生效的自定义价格及来源（分组覆盖优先于全局价格），未配置时返回 nil
+func (s *ModelPriceService) Lookup(model string, groupID *int64, at time.Time) (*ModelPrice, string) {
+	if s == nil {
+		return nil, ""
+	}
+	return s.index.Load().lookup(model, groupID, at)
+}
+
+// CanonicalModel 返回自定义价格表中与 model 对应的模型名，未配置时返回空字符串
+func (s *ModelPriceService) CanonicalModel(model string) string {
+	if s == nil {
+		return ""
+	}
+	return s.index.Load().canonical(model)
+}
+
+// checkGroup 校验分组覆盖价格的分组存在
+func (s *ModelPriceService) checkGroup(ctx context.Context, groupID *int64) error {
+	if groupID == nil || s.groupRepo == nil {
+		return nil
+	}
+	if _, err := s.groupRepo.GetByIDLite(ctx, *groupID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// reloadAfterWrite 写操作后立即刷新本实例索引
+func (s *ModelPriceService) reloadAfterWrite(ctx context.Context) {
+	if err := s.Reload(ctx); err != nil {
+		log.Printf("[ModelPrice] Reload after write failed: %v", err)
+	}
+}
+
+// ============================================
+// 管理接口
+// ============================================
+
+// CreateModelPriceInput 创建价格参数
+type CreateModelPriceInput struct {
+	Model           string
+	GroupID         *int64
+	InputPrice      float64
+	OutputPrice     float64
+	CacheWritePrice float64
+	CacheReadPrice  float64
+	// CacheWrite1hPrice 为空表示按 5 分钟价格换算
+	CacheWrite1hPrice *float64
+	ImagePrice        *float64
+	Tiers             []ModelPriceTier
+	// EffectiveFrom 为空表示立即生效
+	EffectiveFrom *time.Time
+	Notes         string
+}
+
+// UpdateModelPriceInput 更新价格参数（nil 表示不修改）
+//
+// 已生效的价格只允许修改备注，调价需新建一条更晚生效的价格，保证历史价格可追溯。
+type UpdateModelPriceInput struct {
+	InputPrice      *float64
+	OutputPrice     *float64
+	CacheWritePrice *float64
+	CacheReadPrice  *float64
+	ImagePrice      *float64
+	ClearImagePrice bool
+	// CacheWrite1hPrice/ClearCacheWrite1hPrice 同 ImagePrice 语义
+	CacheWrite1hPrice      *float64
+	ClearCacheWrite1hPrice bool
+	// Tiers 非 nil 时整体替换阶梯（空切片表示清空）
+	Tiers         []ModelPriceTier
+	EffectiveFrom *time.Time
+	Notes         *string
+}
+
+// List 分页列出价格
+func (s *ModelPriceService) List(ctx context.Context, filter ModelPriceFilter, params pagination.PaginationParams) ([]ModelPrice, *pagination.PaginationResult, error) {
+	return s.repo.List(ctx, filter, params)
+}
+
+// Get 获取价格
+func (s *ModelPriceService) Get(ctx context.Context, id int64) (*ModelPrice, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Create 创建价格
+func (s *ModelPriceService) Create(ctx context.Context, input *CreateModelPriceInput) (*ModelPrice, error) {
+	price := &ModelPrice{
+		Model:             normalizeModelPriceName(input.Model),
+		GroupID:           input.GroupID,
+		InputPrice:        input.InputPrice,
+		OutputPrice:       input.OutputPrice,
+		CacheWritePrice:   input.CacheWritePrice,
+		CacheReadPrice:    input.CacheReadPrice,
+		ImagePrice:        input.ImagePrice,
+		CacheWrite1hPrice: input.CacheWrite1hPrice,
+		Tiers:             sortedModelPriceTiers(input.Tiers),
+		EffectiveFrom:     time.Now().Truncate(time.Second),
+		Notes:             strings.TrimSpace(input.Notes),
+	}
+	if input.EffectiveFrom != nil {
+		price.EffectiveFrom = input.EffectiveFrom.Truncate(time.Second)
+	}
+	if err := price.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkGroup(ctx, price.GroupID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, price); err != nil {
+		return nil, err
+	}
+	s.reloadAfterWrite(ctx)
+	return price, nil
+}
+
+// Update 更新价格；已生效的价格只允许修改备注
+func (s *ModelPriceService) Update(ctx context.Context, id int64, input *UpdateModelPriceInput) (*ModelPrice, error) {
+	price, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changesPrice := input.InputPrice != nil || input.OutputPrice != nil || input.CacheWritePrice != nil ||
+		input.CacheReadPrice != nil || input.ImagePrice != nil || input.ClearImagePrice || input.EffectiveFrom != nil ||
+		input.CacheWrite1hPrice != nil || input.ClearCacheWrite1hPrice || input.Tiers != nil
+	if changesPrice && price.InEffect(time.Now()) {
+		return nil, ErrModelPriceInEffect
+	}
+
+	if input.InputPrice != nil {
+		price.InputPrice = *input.InputPrice
+	}
+	if input.OutputPrice != nil {
+		price.OutputPrice = *input.OutputPrice
+	}
+	if input.CacheWritePrice != nil {
+		price.CacheWritePrice = *input.CacheWritePrice
+	}
+	if input.CacheReadPrice != nil {
+		price.CacheReadPrice = *input.CacheReadPrice
+	}
+	if input.ClearImagePrice {
+		price.ImagePrice = nil
+	} else if input.ImagePrice != nil {
+		price.ImagePrice = input.ImagePrice
+	}
+	if input.ClearCacheWrite1hPrice {
+		price.CacheWrite1hPrice = nil
+	} else if input.CacheWrite1hPrice != nil {
+		price.CacheWrite1hPrice = input.CacheWrite1hPrice
+	}
+	if input.Tiers != nil {
+		price.Tiers = sortedModelPriceTiers(input.Tiers)
+	}
+	if input.EffectiveFrom != nil {
+		price.EffectiveFrom = input.EffectiveFrom.Truncate(time.Second)
+	}
+	if input.Notes != nil {
+		price.Notes = strings.TrimSpace(*input.Notes)
+	}
+	if err := price.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, price); err != nil {
+		return nil, err
+	}
+	s.reloadAfterWrite(ctx)
+	return price, nil
+}
+
+// Delete 删除价格（已记录的用量费用不受影响）
+func (s *ModelPriceService) Delete(ctx context.Context, id int64) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.reloadAfterWrite(ctx)
+	return nil
+}
+
+// ModelPriceImportResult 导入结果
+type ModelPriceImportResult struct {
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Skipped []string `json:"skipped"`
+}
+
+// Import 导入 LiteLLM 格式的价格 JSON（{"model": {"input_cost_per_token": ...}}）。
+// 全部条目使用同一 groupID 与生效时间；同一 模型/分组/生效时间 的价格尚未生效时覆盖，已生效时跳过。
+func (s *ModelPriceService) Import(ctx context.Context, data []byte, groupID *int64, effectiveFrom *time.Time) (*ModelPriceImportResult, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, infraerrors.BadRequest("MODEL_PRICE_IMPORT_INVALID", "invalid LiteLLM pricing JSON: "+err.Error())
+	}
+	if len(raw) > maxModelPriceImportEntries {
+		return nil, infraerrors.BadRequest("MODEL_PRICE_IMPORT_TOO_LARGE", fmt.Sprintf("at most %d entries per import", maxModelPriceImportEntries))
+	}
+
+	if err := s.checkGroup(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	effective := time.Now().Truncate(time.Second)
+	if effectiveFrom != nil {
+		effective = effectiveFrom.Truncate(time.Second)
+	}
+
+	models := make([]string, 0, len(raw))
+	for model := range raw {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
+	result := &ModelPriceImportResult{Skipped: []string{}}
+	for _, model := range models {
+		// 跳过 sample_spec 等文档条目
+		if model == "sample_spec" {
+			continue
+		}
+		var entry LiteLLMRawEntry
+		if err := json.Unmarshal(raw[model], &entry); err != nil || (entry.InputCostPerToken == nil && entry.OutputCostPerToken == nil && entry.OutputCostPerImage == nil) {
+			result.Skipped = append(result.Skipped, model)
+			continue
+		}
+		price := &ModelPrice{
+			Model:         normalizeModelPriceName(model),
+			GroupID:       groupID,
+			ImagePrice:    entry.OutputCostPerImage,
+			EffectiveFrom: effective,
+			Notes:         "imported",
+		}
+		if entry.InputCostPerToken != nil {
+			price.InputPrice = *entry.InputCostPerToken
+		}
+		if entry.OutputCostPerToken != nil {
+			price.OutputPrice = *entry.OutputCostPerToken
+		}
+		if entry.CacheCreationInputTokenCost != nil {
+			price.CacheWritePrice = *entry.CacheCreationInputTokenCost
+		}
+		if entry.CacheReadInputTokenCost != nil {
+			price.CacheReadPrice = *entry.CacheReadInputTokenCost
+		}
+		price.CacheWrite1hPrice = entry.CacheCreationInputTokenCostAbove1hr
+		price.Tiers = modelPriceTiersFromLiteLLM(&entry)
+		if err := price.Validate(); err != nil {
+			result.Skipped = append(result.Skipped, model)
+			continue
+		}
+		created, err := s.repo.Upsert(ctx, price)
+		if errors.Is(err, ErrModelPriceInEffect) {
+			// 同一生效时间的价格已生效，不覆盖
+			result.Skipped = append(result.Skipped, model)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+	s.reloadAfterWrite(ctx)
+	return result, nil
+}
+
+// Export 导出 at 时刻生效的自定义价格（LiteLLM 格式）。
+// groupID 为空时只导出全局价格；否则导出该分组视角下的价格（分组覆盖优先，其次全局价格）。
+func (s *ModelPriceService) Export(ctx context.Context, groupID *int64, at time.Time) (map[string]LiteLLMRawEntry, error) {
+	prices, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idx := newModelPriceIndex(prices)
+	out := make(map[string]LiteLLMRawEntry, len(idx.byModel))
+	for model := range idx.byModel {
+		price, _ := idx.lookup(model, groupID, at)
+		if price == nil {
+			continue
+		}
+		entry := LiteLLMRawEntry{
+			InputCostPerToken:           float64Ptr(price.InputPrice),
+			OutputCostPerToken:          float64Ptr(price.OutputPrice),
+			CacheCreationInputTokenCost: float64Ptr(price.CacheWritePrice),
+			CacheReadInputTokenCost:     float64Ptr(price.CacheReadPrice),
+			OutputCostPerImage:          price.ImagePrice,
+			SupportsPromptCaching:       price.CacheWritePrice > 0 || price.CacheReadPrice > 0,
+		}
+		entry.CacheCreationInputTokenCostAbove1hr = price.CacheWrite1hPrice
+		applyModelPriceTiersToLiteLLM(&entry, price.Tiers)
+		out[model] = entry
+	}
+	return out, nil
+}
+
+// sortedModelPriceTiers 阶梯按阈值升序保存，便于查看
+func sortedModelPriceTiers(tiers []ModelPriceTier) []ModelPriceTier {
+	out := append([]ModelPriceTier{}, tiers...)
+	sort.Slice(out, func(i, j int) bool { return out[i].Threshold < out[j].Threshold })
+	return out
+}
+
+// modelPriceTiersFromLiteLLM 读取 LiteLLM 的 above_128k / above_200k 价格
+func modelPriceTiersFromLiteLLM(entry *LiteLLMRawEntry) []ModelPriceTier {
+	tiers := []ModelPriceTier{}
+	if entry.InputCostPerTokenAbove128k != nil || entry.OutputCostPerTokenAbove128k != nil {
+		tiers = append(tiers, ModelPriceTier{
+			Threshold:   128_000,
+			InputPrice:  derefFloat64(entry.InputCostPerTokenAbove128k),
+			OutputPrice: derefFloat64(entry.OutputCostPerTokenAbove128k),
+		})
+	}
+	if entry.InputCostPerTokenAbove200k != nil || entry.OutputCostPerTokenAbove200k != nil {
+		tiers = append(tiers, ModelPriceTier{
+			Threshold:       200_000,
+			InputPrice:      derefFloat64(entry.InputCostPerTokenAbove200k),
+			OutputPrice:     derefFloat64(entry.OutputCostPerTokenAbove200k),
+			CacheWritePrice: derefFloat64(entry.CacheCreationInputTokenCostAbove200k),
+			CacheReadPrice:  derefFloat64(entry.CacheReadInputTokenCostAbove200k),
+		})
+	}
+	return tiers
+}
+
+// applyModelPriceTiersToLiteLLM 导出阶梯价格；LiteLLM 只有 128k/200k 两档字段，其余阈值不导出
+func applyModelPriceTiersToLiteLLM(entry *LiteLLMRawEntry, tiers []ModelPriceTier) {
+	for _, t := range tiers {
+		switch t.Threshold {
+		case 128_000:
+			entry.InputCostPerTokenAbove128k = positiveFloat64Ptr(t.InputPrice)
+			entry.OutputCostPerTokenAbove128k = positiveFloat64Ptr(t.OutputPrice)
+		case 200_000:
+			entry.InputCostPerTokenAbove200k = positiveFloat64Ptr(t.InputPrice)
+			entry.OutputCostPerTokenAbove200k = positiveFloat64Ptr(t.OutputPrice)
+			entry.CacheCreationInputTokenCostAbove200k = positiveFloat64Ptr(t.CacheWritePrice)
+			entry.CacheReadInputTokenCostAbove200k = positiveFloat64Ptr(t.CacheReadPrice)
+		}
+	}
+}
+
+func positiveFloat64Ptr(v float64) *float64 {
+	if v <= 0 {
+		return nil
+	}
+	return &v
+}
+
+func derefFloat64(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
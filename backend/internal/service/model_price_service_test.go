@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeModelPriceRepo 内存实现，唯一键与 Upsert 语义与 SQL 实现一致
+type fakeModelPriceRepo struct {
+	mu     sync.Mutex
+	nextID int64
+	prices map[int64]ModelPrice
+}
+
+func newFakeModelPriceRepo() *fakeModelPriceRepo {
+	return &fakeModelPriceRepo{prices: make(map[int64]ModelPrice)}
+}
+
+func (f *fakeModelPriceRepo) findLocked(p *ModelPrice) *ModelPrice {
+	for id, existing := range f.prices {
+		if existing.Model == p.Model && sameGroup(existing.GroupID, p.GroupID) && existing.EffectiveFrom.Equal(p.EffectiveFrom) {
+			out := f.prices[id]
+			return &out
+		}
+	}
+	return nil
+}
+
+func sameGroup(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (f *fakeModelPriceRepo) List(ctx context.Context, filter ModelPriceFilter, params pagination.PaginationParams) ([]ModelPrice, *pagination.PaginationResult, error) {
+	all, _ := f.ListAll(ctx)
+	return all, &pagination.PaginationResult{Total: int64(len(all)), Page: 1, PageSize: len(all)}, nil
+}
+
+func (f *fakeModelPriceRepo) ListAll(ctx context.Context) ([]ModelPrice, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]ModelPrice, 0, len(f.prices))
+	for _, p := range f.prices {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (f *fakeModelPriceRepo) GetByID(ctx context.Context, id int64) (*ModelPrice, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.prices[id]
+	if !ok {
+		return nil, ErrModelPriceNotFound
+	}
+	return &p, nil
+}
+
+func (f *fakeModelPriceRepo) Create(ctx context.Context, price *ModelPrice) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.findLocked(price) != nil {
+		return ErrModelPriceExists
+	}
+	f.nextID++
+	price.ID = f.nextID
+	f.prices[price.ID] = *price
+	return nil
+}
+
+func (f *fakeModelPriceRepo) Update(ctx context.Context, price *ModelPrice) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.prices[price.ID]; !ok {
+		return ErrModelPriceNotFound
+	}
+	f.prices[price.ID] = *price
+	return nil
+}
+
+func (f *fakeModelPriceRepo) Delete(ctx context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.prices[id]; !ok {
+		return ErrModelPriceNotFound
+	}
+	delete(f.prices, id)
+	return nil
+}
+
+func (f *fakeModelPriceRepo) Upsert(ctx context.Context, price *ModelPrice) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if existing := f.findLocked(price); existing != nil {
+		if existing.InEffect(time.Now()) {
+			return false, ErrModelPriceInEffect
+		}
+		price.ID = existing.ID
+		f.prices[price.ID] = *price
+		return false, nil
+	}
+	f.nextID++
+	price.ID = f.nextID
+	f.prices[price.ID] = *price
+	return true, nil
+}
+
+func TestModelPriceIndex_LookupByEffectiveDateAndGroup(t *testing.T) {
+	now := time.Now()
+	groupID := int64(5)
+	otherGroup := int64(6)
+	idx := newModelPriceIndex([]ModelPrice{
+		{ID: 1, Model: "claude-sonnet-4", InputPrice: 1, EffectiveFrom: now.Add(-48 * time.Hour)},
+		{ID: 2, Model: "claude-sonnet-4", InputPrice: 2, EffectiveFrom: now.Add(-time.Hour)},
+		{ID: 3, Model: "claude-sonnet-4", InputPrice: 3, EffectiveFrom: now.Add(time.Hour)},
+		{ID: 4, Model: "claude-sonnet-4", GroupID: &groupID, InputPrice: 4, EffectiveFrom: now.Add(-time.Hour)},
+	})
+
+	p, source := idx.lookup("Claude-Sonnet-4", nil, now)
+	require.Equal(t, int64(2), p.ID, "latest price already in effect wins")
+	require.Equal(t, ModelPriceSourceCustom, source)
+
+	p, _ = idx.lookup("claude-sonnet-4", nil, now.Add(-24*time.Hour))
+	require.Equal(t, int64(1), p.ID, "historical lookups use the price in effect at that time")
+
+	p, _ = idx.lookup("claude-sonnet-4", nil, now.Add(2*time.Hour))
+	require.Equal(t, int64(3), p.ID)
+
+	p, source = idx.lookup("claude-sonnet-4", &groupID, now)
+	require.Equal(t, int64(4), p.ID)
+	require.Equal(t, ModelPriceSourceGroup, source)
+
+	p, source = idx.lookup("claude-sonnet-4", &otherGroup, now)
+	require.Equal(t, int64(2), p.ID, "groups without an override fall back to the global price")
+	require.Equal(t, ModelPriceSourceCustom, source)
+
+	p, _ = idx.lookup("claude-sonnet-4", &groupID, now.Add(-24*time.Hour))
+	require.Equal(t, int64(1), p.ID, "group override not yet in effect falls back to global")
+
+	p, _ = idx.lookup("unknown-model", nil, now)
+	require.Nil(t, p)
+}
+
+func TestModelPriceService_UpdateRejectsPriceChangeOnceInEffect(t *testing.T) {
+	svc := NewModelPriceService(newFakeModelPriceRepo(), nil)
+	ctx := context.Background()
+
+	past := time.Now().Add(-time.Hour)
+	price, err := svc.Create(ctx, &CreateModelPriceInput{Model: " My-Model ", InputPrice: 1e-6, OutputPrice: 2e-6, EffectiveFrom: &past})
+	require.NoError(t, err)
+	require.Equal(t, "my-model", price.Model)
+
+	newInput := 5e-6
+	_, err = svc.Update(ctx, price.ID, &UpdateModelPriceInput{InputPrice: &newInput})
+	require.ErrorIs(t, err, ErrModelPriceInEffect)
+
+	notes := "contract rate"
+	updated, err := svc.Update(ctx, price.ID, &UpdateModelPriceInput{Notes: &notes})
+	require.NoError(t, err)
+	require.Equal(t, notes, updated.Notes)
+
+	future := time.Now().Add(time.Hour)
+	next, err := svc.Create(ctx, &CreateModelPriceInput{Model: "my-model", InputPrice: 3e-6, EffectiveFrom: &future})
+	require.NoError(t, err)
+	updated, err = svc.Update(ctx, next.ID, &UpdateModelPriceInput{InputPrice: &newInput})
+	require.NoError(t, err)
+	require.InDelta(t, newInput, updated.InputPrice, 1e-15)
+
+	_, err = svc.Create(ctx, &CreateModelPriceInput{Model: "my-model", InputPrice: -1})
+	require.ErrorIs(t, err, ErrModelPriceInvalid)
+
+	_, err = svc.Create(ctx, &CreateModelPriceInput{Model: "tiered", Tiers: []ModelPriceTier{{Threshold: 200_000}, {Threshold: 200_000}}})
+	require.ErrorIs(t, err, ErrModelPriceTier)
+}
+
+func TestModelPriceService_ImportExportLiteLLM(t *testing.T) {
+	svc := NewModelPriceService(newFakeModelPriceRepo(), nil)
+	ctx := context.Background()
+
+	data := []byte(`{
+		"sample_spec": {"input_cost_per_token": 0},
+		"custom-model": {"input_cost_per_token": 0.000001, "output_cost_per_token": 0.000002, "cache_read_input_token_cost": 0.0000001, "input_cost_per_token_above_200k_tokens": 0.000002, "cache_creation_input_token_cost_above_1hr": 0.000003},
+		"image-model": {"output_cost_per_image": 0.05},
+		"no-price": {"max_tokens": 100}
+	}`)
+	effective := time.Now().Add(-time.Minute)
+	result, err := svc.Import(ctx, data, nil, &effective)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Created)
+	require.Equal(t, []string{"no-price"}, result.Skipped)
+
+	// 同一生效时间已生效的价格不会被再次导入覆盖
+	again, err := svc.Import(ctx, data, nil, &effective)
+	require.NoError(t, err)
+	require.Zero(t, again.Created+again.Updated)
+	require.ElementsMatch(t, []string{"custom-model", "image-model", "no-price"}, again.Skipped)
+
+	p, source := svc.Lookup("custom-model", nil, time.Now())
+	require.NotNil(t, p)
+	require.Equal(t, ModelPriceSourceCustom, source)
+	require.InDelta(t, 1e-7, p.CacheReadPrice, 1e-15)
+	require.NotNil(t, p.CacheWrite1hPrice)
+	require.Equal(t, []ModelPriceTier{{Threshold: 200_000, InputPrice: 2e-6}}, p.Tiers)
+
+	exported, err := svc.Export(ctx, nil, time.Now())
+	require.NoError(t, err)
+	require.Len(t, exported, 2)
+	require.InDelta(t, 2e-6, *exported["custom-model"].OutputCostPerToken, 1e-15)
+	require.InDelta(t, 2e-6, *exported["custom-model"].InputCostPerTokenAbove200k, 1e-15)
+	require.Nil(t, exported["custom-model"].OutputCostPerTokenAbove200k)
+	require.InDelta(t, 0.05, *exported["image-model"].OutputCostPerImage, 1e-9)
+
+	_, err = svc.Import(ctx, []byte(`not json`), nil, nil)
+	require.Error(t, err)
+}
+
+func TestBillingService_ResolveModelPricingPriority(t *testing.T) {
+	cfg := &config.Config{}
+	modelPrices := NewModelPriceService(newFakeModelPriceRepo(), nil)
+	billing := NewBillingService(cfg, nil)
+	billing.modelPrices = modelPrices
+	ctx := context.Background()
+
+	resolved, err := billing.ResolveModelPricing("claude-sonnet-4", nil, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, ModelPriceSourceFallback, resolved.Source)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = modelPrices.Create(ctx, &CreateModelPriceInput{Model: "claude-sonnet-4", InputPrice: 1e-6, OutputPrice: 1e-6, EffectiveFrom: &past})
+	require.NoError(t, err)
+	groupID := int64(9)
+	_, err = modelPrices.Create(ctx, &CreateModelPriceInput{Model: "claude-sonnet-4", GroupID: &groupID, InputPrice: 2e-6, OutputPrice: 2e-6, EffectiveFrom: &past})
+	require.NoError(t, err)
+
+	resolved, err = billing.ResolveModelPricing("claude-sonnet-4", nil, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, ModelPriceSourceCustom, resolved.Source)
+	require.NotNil(t, resolved.CustomPrice)
+
+	cost, err := billing.CalculateCostForGroup("claude-sonnet-4", &groupID, UsageTokens{InputTokens: 1000, OutputTokens: 1000}, 1)
+	require.NoError(t, err)
+	require.InDelta(t, 0.004, cost.TotalCost, 1e-12)
+
+	// 自定义价格生效之前的用量仍按原价格来源计算
+	resolved, err = billing.ResolveModelPricing("claude-sonnet-4", &groupID, past.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, ModelPriceSourceFallback, resolved.Source)
+}
+
+func TestBillingService_MetricsModelLabel(t *testing.T) {
+	billing := NewBillingService(&config.Config{}, &PricingService{
+		pricingData: map[string]*LiteLLMModelPricing{"gemini-2.5-pro": {}},
+	})
+	billing.modelPrices = NewModelPriceService(newFakeModelPriceRepo(), nil)
+	past := time.Now().Add(-time.Hour)
+	_, err := billing.modelPrices.Create(context.Background(), &CreateModelPriceInput{Model: "custom-model", InputPrice: 1e-6, OutputPrice: 1e-6, EffectiveFrom: &past})
+	require.NoError(t, err)
+
+	require.Equal(t, "", billing.MetricsModelLabel(""))
+	require.Equal(t, "custom-model", billing.MetricsModelLabel("Custom-Model"))
+	require.Equal(t, "gemini-2.5-pro", billing.MetricsModelLabel("models/gemini-2.5-pro"))
+	require.Equal(t, "claude-sonnet-4", billing.MetricsModelLabel("claude-sonnet-4"))
+
+	// 价格表外的模型（即使能模糊匹配到计费价格）统一归入 other
+	require.Equal(t, MetricsModelOther, billing.MetricsModelLabel("claude-sonnet-4-random-suffix"))
+	require.Equal(t, MetricsModelOther, billing.MetricsModelLabel("anything-the-client-sends"))
+	require.Equal(t, MetricsModelOther, (*BillingService)(nil).MetricsModelLabel("gpt-5"))
+}
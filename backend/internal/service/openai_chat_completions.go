@@ -0,0 +1,1224 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Chat Completions 兼容层
+//
+// /v1/chat/completions 请求不会直接发往上游，而是先按分组平台转换为
+// Anthropic Messages（Anthropic/Gemini/Antigravity，Gemini 由兼容服务再转换为 generateContent）
+// 或 OpenAI Responses 请求，复用现有 Forward 链路；上游响应再经
+// ChatCompletionsResponseWriter 转换回 chat.completion / chat.completion.chunk 格式。
+
+const (
+	// chatCompletionsDefaultMaxTokens Anthropic Messages 要求必须提供 max_tokens，客户端未指定时使用该默认值
+	chatCompletionsDefaultMaxTokens = 8192
+
+	chatCompletionsJSONObjectPrompt = "Respond only with a single valid JSON object. Do not wrap it in markdown code fences."
+)
+
+// ChatCompletionsRequest 保存 Chat Completions 请求的预解析结果
+type ChatCompletionsRequest struct {
+	Body      map[string]any
+	Model     string
+	Stream    bool
+	MaxTokens int // max_completion_tokens/max_tokens（未传时为 0）
+}
+
+// ParseChatCompletionsRequest 解析 Chat Completions 请求体
+func ParseChatCompletionsRequest(body []byte) (*ChatCompletionsRequest, error) {
+	var req map[string]any
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	parsed := &ChatCompletionsRequest{Body: req}
+	if rawModel, exists := req["model"]; exists {
+		model, ok := rawModel.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid model field type")
+		}
+		parsed.Model = model
+	}
+	if rawStream, exists := req["stream"]; exists {
+		stream, ok := rawStream.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid stream field type")
+		}
+		parsed.Stream = stream
+	}
+	if _, ok := req["messages"].([]any); !ok {
+		return nil, fmt.Errorf("messages is required")
+	}
+	parsed.MaxTokens, _ = chatOptionalInt(req, "max_completion_tokens", "max_tokens")
+	return parsed, nil
+}
+
+// ApplyMaxTokensCap 客户端未指定输出上限时写入 limit，避免省略字段绕过 API Key 的限制。
+// 转换为 Messages 时若默认 max_tokens 未超出 limit 则保留默认值；Responses 无默认值，始终写入。
+func (r *ChatCompletionsRequest) ApplyMaxTokensCap(limit *int, format ChatCompletionsUpstreamFormat) {
+	if r == nil || r.Body == nil || limit == nil || r.MaxTokens > 0 {
+		return
+	}
+	if format == ChatCompletionsUpstreamClaude && *limit >= chatCompletionsDefaultMaxTokens {
+		return
+	}
+	r.Body["max_tokens"] = float64(*limit)
+	r.MaxTokens = *limit
+}
+
+// ConvertChatCompletionsToClaude 将 Chat Completions 请求转换为 Anthropic Messages 请求体
+func ConvertChatCompletionsToClaude(req *ChatCompletionsRequest) ([]byte, error) {
+	if req == nil || req.Body == nil {
+		return nil, fmt.Errorf("empty request")
+	}
+	src := req.Body
+	out := map[string]any{
+		"model":      req.Model,
+		"stream":     req.Stream,
+		"max_tokens": chatCompletionsMaxTokens(src),
+	}
+
+	var systemParts []string
+	var messages []any
+	messageList, _ := src["messages"].([]any)
+	for _, raw := range messageList {
+		msg, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		role, _ := msg["role"].(string)
+		switch role {
+		case "system", "developer":
+			if text := chatContentText(msg["content"]); text != "" {
+				systemParts = append(systemParts, text)
+			}
+		case "user":
+			blocks := chatContentToClaudeBlocks(msg["content"])
+			if len(blocks) == 0 {
+				continue
+			}
+			messages = appendClaudeMessage(messages, "user", blocks)
+		case "assistant":
+			var blocks []any
+			if text := chatContentText(msg["content"]); text != "" {
+				blocks = append(blocks, map[string]any{"type": "text", "text": text})
+			}
+			if toolCalls, ok := msg["tool_calls"].([]any); ok {
+				for _, rawCall := range toolCalls {
+					call, ok := rawCall.(map[string]any)
+					if !ok {
+						continue
+					}
+					fn, _ := call["function"].(map[string]any)
+					name, _ := fn["name"].(string)
+					id, _ := call["id"].(string)
+					blocks = append(blocks, map[string]any{
+						"type":  "tool_use",
+						"id":    id,
+						"name":  name,
+						"input": parseToolArguments(fn["arguments"]),
+					})
+				}
+			}
+			if len(blocks) == 0 {
+				continue
+			}
+			messages = appendClaudeMessage(messages, "assistant", blocks)
+		case "tool", "function":
+			toolCallID, _ := msg["tool_call_id"].(string)
+			if toolCallID == "" {
+				toolCallID, _ = msg["name"].(string)
+			}
+			messages = appendClaudeMessage(messages, "user", []any{map[string]any{
+				"type":        "tool_result",
+				"tool_use_id": toolCallID,
+				"content":     chatContentText(msg["content"]),
+			}})
+		}
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("messages must contain at least one user or assistant message")
+	}
+	out["messages"] = messages
+
+	if prompt := chatResponseFormatPrompt(src["response_format"]); prompt != "" {
+		systemParts = append(systemParts, prompt)
+	}
+	if len(systemParts) > 0 {
+		out["system"] = strings.Join(systemParts, "\n\n")
+	}
+
+	if tools := chatToolsToClaude(src["tools"]); len(tools) > 0 {
+		out["tools"] = tools
+		if choice := chatToolChoiceToClaude(src["tool_choice"]); choice != nil {
+			out["tool_choice"] = choice
+		}
+	}
+	if v, ok := src["temperature"].(float64); ok {
+		out["temperature"] = v
+	}
+	if v, ok := src["top_p"].(float64); ok {
+		out["top_p"] = v
+	}
+	if stops := chatStopSequences(src["stop"]); len(stops) > 0 {
+		out["stop_sequences"] = stops
+	}
+
+	return json.Marshal(out)
+}
+
+// ConvertChatCompletionsToResponses 将 Chat Completions 请求转换为 OpenAI Responses 请求体
+func ConvertChatCompletionsToResponses(req *ChatCompletionsRequest) ([]byte, error) {
+	if req == nil || req.Body == nil {
+		return nil, fmt.Errorf("empty request")
+	}
+	src := req.Body
+	out := map[string]any{
+		"model":  req.Model,
+		"stream": req.Stream,
+		"store":  false,
+	}
+
+	var instructions []string
+	var input []any
+	messageList, _ := src["messages"].([]any)
+	for _, raw := range messageList {
+		msg, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		role, _ := msg["role"].(string)
+		switch role {
+		case "system", "developer":
+			if text := chatContentText(msg["content"]); text != "" {
+				instructions = append(instructions, text)
+			}
+		case "user":
+			parts := chatContentToResponsesParts(msg["content"])
+			if len(parts) == 0 {
+				continue
+			}
+			input = append(input, map[string]any{"role": "user", "content": parts})
+		case "assistant":
+			if text := chatContentText(msg["content"]); text != "" {
+				input = append(input, map[string]any{
+					"role":    "assistant",
+					"content": []any{map[string]any{"type": "output_text", "text": text}},
+				})
+			}
+			if toolCalls, ok := msg["tool_calls"].([]any); ok {
+				for _, rawCall := range toolCalls {
+					call, ok := rawCall.(map[string]any)
+					if !ok {
+						continue
+					}
+					fn, _ := call["function"].(map[string]any)
+					name, _ := fn["name"].(string)
+					id, _ := call["id"].(string)
+					args, _ := fn["arguments"].(string)
+					if args == "" {
+						args = "{}"
+					}
+					input = append(input, map[string]any{
+						"type":      "function_call",
+						"call_id":   id,
+						"name":      name,
+						"arguments": args,
+					})
+				}
+			}
+		case "tool", "function":
+			toolCallID, _ := msg["tool_call_id"].(string)
+			input = append(input, map[string]any{
+				"type":    "function_call_output",
+				"call_id": toolCallID,
+				"output":  chatContentText(msg["content"]),
+			})
+		}
+	}
+	if len(input) == 0 {
+		return nil, fmt.Errorf("messages must contain at least one user or assistant message")
+	}
+	out["input"] = input
+	if len(instructions) > 0 {
+		out["instructions"] = strings.Join(instructions, "\n\n")
+	}
+
+	if tools, ok := src["tools"].([]any); ok && len(tools) > 0 {
+		converted := make([]any, 0, len(tools))
+		for _, rawTool := range tools {
+			tool, ok := rawTool.(map[string]any)
+			if !ok {
+				continue
+			}
+			fn, ok := tool["function"].(map[string]any)
+			if !ok {
+				continue
+			}
+			item := map[string]any{"type": "function", "name": fn["name"]}
+			if desc, ok := fn["description"].(string); ok && desc != "" {
+				item["description"] = desc
+			}
+			if params, ok := fn["parameters"]; ok {
+				item["parameters"] = params
+			}
+			if strict, ok := fn["strict"].(bool); ok {
+				item["strict"] = strict
+			}
+			converted = append(converted, item)
+		}
+		if len(converted) > 0 {
+			out["tools"] = converted
+		}
+	}
+	switch choice := src["tool_choice"].(type) {
+	case string:
+		out["tool_choice"] = choice
+	case map[string]any:
+		if fn, ok := choice["function"].(map[string]any); ok {
+			out["tool_choice"] = map[string]any{"type": "function", "name": fn["name"]}
+		}
+	}
+	if v, ok := src["parallel_tool_calls"].(bool); ok {
+		out["parallel_tool_calls"] = v
+	}
+
+	if format, ok := src["response_format"].(map[string]any); ok {
+		switch format["type"] {
+		case "json_object":
+			out["text"] = map[string]any{"format": map[string]any{"type": "json_object"}}
+		case "json_schema":
+			schema, _ := format["json_schema"].(map[string]any)
+			textFormat := map[string]any{"type": "json_schema"}
+			for _, key := range []string{"name", "schema", "strict", "description"} {
+				if v, ok := schema[key]; ok {
+					textFormat[key] = v
+				}
+			}
+			out["text"] = map[string]any{"format": textFormat}
+		}
+	}
+	if v, ok := src["reasoning_effort"].(string); ok && v != "" {
+		out["reasoning"] = map[string]any{"effort": v}
+	}
+	if v, ok := src["temperature"].(float64); ok {
+		out["temperature"] = v
+	}
+	if v, ok := src["top_p"].(float64); ok {
+		out["top_p"] = v
+	}
+	if v, ok := chatOptionalInt(src, "max_completion_tokens", "max_tokens"); ok {
+		out["max_output_tokens"] = v
+	}
+	if v, ok := src["user"].(string); ok && v != "" {
+		out["prompt_cache_key"] = v
+	}
+
+	return json.Marshal(out)
+}
+
+func chatCompletionsMaxTokens(src map[string]any) int {
+	if v, ok := chatOptionalInt(src, "max_completion_tokens", "max_tokens"); ok && v > 0 {
+		return v
+	}
+	return chatCompletionsDefaultMaxTokens
+}
+
+func chatOptionalInt(src map[string]any, keys ...string) (int, bool) {
+	for _, key := range keys {
+		if v, ok := src[key].(float64); ok {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+// appendClaudeMessage 追加消息，相邻同角色消息合并（Anthropic 要求 user/assistant 交替）
+func appendClaudeMessage(messages []any, role string, blocks []any) []any {
+	if n := len(messages); n > 0 {
+		if last, ok := messages[n-1].(map[string]any); ok && last["role"] == role {
+			if content, ok := last["content"].([]any); ok {
+				last["content"] = append(content, blocks...)
+				return messages
+			}
+		}
+	}
+	return append(messages, map[string]any{"role": role, "content": blocks})
+}
+
+// chatContentText 提取 content 中的纯文本（字符串或 text part 数组）
+func chatContentText(content any) string {
+	switch v := content.(type) {
+	case string:
+		return v
+	case []any:
+		var parts []string
+		for _, raw := range v {
+			part, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			if text, ok := part["text"].(string); ok && text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+	return ""
+}
+
+func chatContentToClaudeBlocks(content any) []any {
+	switch v := content.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []any{map[string]any{"type": "text", "text": v}}
+	case []any:
+		blocks := make([]any, 0, len(v))
+		for _, raw := range v {
+			part, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			switch part["type"] {
+			case "text":
+				if text, _ := part["text"].(string); text != "" {
+					blocks = append(blocks, map[string]any{"type": "text", "text": text})
+				}
+			case "image_url":
+				if block := chatImageToClaudeBlock(part["image_url"]); block != nil {
+					blocks = append(blocks, block)
+				}
+			}
+		}
+		return blocks
+	}
+	return nil
+}
+
+func chatImageToClaudeBlock(imageURL any) map[string]any {
+	url := ""
+	switch v := imageURL.(type) {
+	case string:
+		url = v
+	case map[string]any:
+		url, _ = v["url"].(string)
+	}
+	if url == "" {
+		return nil
+	}
+	// data:image/png;base64,xxxx
+	if strings.HasPrefix(url, "data:") {
+		meta, data, ok := strings.Cut(strings.TrimPrefix(url, "data:"), ",")
+		if !ok {
+			return nil
+		}
+		mediaType := strings.TrimSuffix(meta, ";base64")
+		return map[string]any{
+			"type": "image",
+			"source": map[string]any{
+				"type":       "base64",
+				"media_type": mediaType,
+				"data":       data,
+			},
+		}
+	}
+	return map[string]any{
+		"type":   "image",
+		"source": map[string]any{"type": "url", "url": url},
+	}
+}
+
+func chatContentToResponsesParts(content any) []any {
+	switch v := content.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []any{map[string]any{"type": "input_text", "text": v}}
+	case []any:
+		parts := make([]any, 0, len(v))
+		for _, raw := range v {
+			part, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			switch part["type"] {
+			case "text":
+				if text, _ := part["text"].(string); text != "" {
+					parts = append(parts, map[string]any{"type": "input_text", "text": text})
+				}
+			case "image_url":
+				item := map[string]any{"type": "input_image"}
+				switch img := part["image_url"].(type) {
+				case string:
+					item["image_url"] = img
+				case map[string]any:
+					item["image_url"] = img["url"]
+					if detail, ok := img["detail"].(string); ok && detail != "" {
+						item["detail"] = detail
+					}
+				}
+				if item["image_url"] != nil {
+					parts = append(parts, item)
+				}
+			}
+		}
+		return parts
+	}
+	return nil
+}
+
+func parseToolArguments(raw any) any {
+	switch v := raw.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return map[string]any{}
+		}
+		var parsed any
+		if err := json.Unmarshal([]byte(v), &parsed); err == nil {
+			if obj, ok := parsed.(map[string]any); ok {
+				return obj
+			}
+		}
+		return map[string]any{}
+	case map[string]any:
+		return v
+	}
+	return map[string]any{}
+}
+
+func chatToolsToClaude(raw any) []any {
+	tools, ok := raw.([]any)
+	if !ok {
+		return nil
+	}
+	out := make([]any, 0, len(tools))
+	for _, rawTool := range tools {
+		tool, ok := rawTool.(map[string]any)
+		if !ok {
+			continue
+		}
+		fn, ok := tool["function"].(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := fn["name"].(string)
+		if name == "" {
+			continue
+		}
+		schema, ok := fn["parameters"].(map[string]any)
+		if !ok {
+			schema = map[string]any{"type": "object", "properties": map[string]any{}}
+		}
+		item := map[string]any{"name": name, "input_schema": schema}
+		if desc, ok := fn["description"].(string); ok && desc != "" {
+			item["description"] = desc
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func chatToolChoiceToClaude(raw any) map[string]any {
+	switch v := raw.(type) {
+	case string:
+		switch v {
+		case "auto":
+			return map[string]any{"type": "auto"}
+		case "none":
+			return map[string]any{"type": "none"}
+		case "required":
+			return map[string]any{"type": "any"}
+		}
+	case map[string]any:
+		if fn, ok := v["function"].(map[string]any); ok {
+			if name, _ := fn["name"].(string); name != "" {
+				return map[string]any{"type": "tool", "name": name}
+			}
+		}
+	}
+	return nil
+}
+
+func chatStopSequences(raw any) []string {
+	switch v := raw.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// chatResponseFormatPrompt Anthropic 没有原生 response_format，以系统提示词约束输出
+func chatResponseFormatPrompt(raw any) string {
+	format, ok := raw.(map[string]any)
+	if !ok {
+		return ""
+	}
+	switch format["type"] {
+	case "json_object":
+		return chatCompletionsJSONObjectPrompt
+	case "json_schema":
+		schema, _ := format["json_schema"].(map[string]any)
+		if s, ok := schema["schema"]; ok {
+			if b, err := json.Marshal(s); err == nil {
+				return chatCompletionsJSONObjectPrompt + " The JSON object must conform to this JSON schema:\n" + string(b)
+			}
+		}
+		return chatCompletionsJSONObjectPrompt
+	}
+	return ""
+}
+
+// ChatCompletionsUpstreamFormat 上游响应格式
+type ChatCompletionsUpstreamFormat int
+
+const (
+	// ChatCompletionsUpstreamClaude 上游响应为 Anthropic Messages 格式
+	ChatCompletionsUpstreamClaude ChatCompletionsUpstreamFormat = iota
+	// ChatCompletionsUpstreamResponses 上游响应为 OpenAI Responses 格式
+	ChatCompletionsUpstreamResponses
+)
+
+// ChatCompletionsResponseWriter 包装 gin.ResponseWriter，将 Forward 写出的
+// Anthropic Messages / OpenAI Responses 响应实时转换为 Chat Completions 格式。
+//
+// 流式响应按 SSE 行解析并逐块转换；非流式与错误响应先缓冲，由 Finish 统一转换写出。
+type ChatCompletionsResponseWriter struct {
+	gin.ResponseWriter
+
+	mu       sync.Mutex
+	format   ChatCompletionsUpstreamFormat
+	model    string
+	status   int
+	decided  bool
+	stream   bool
+	buf      bytes.Buffer
+	line     bytes.Buffer
+	finished bool
+
+	id           string
+	created      int64
+	roleSent     bool
+	toolIndex    map[string]int
+	nextTool     int
+	finishReason string
+	usage        chatCompletionsUsage
+	done         bool
+}
+
+type chatCompletionsUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+	CachedTokens     int `json:"-"`
+}
+
+func (u chatCompletionsUsage) toMap() map[string]any {
+	m := map[string]any{
+		"prompt_tokens":     u.PromptTokens,
+		"completion_tokens": u.CompletionTokens,
+		"total_tokens":      u.PromptTokens + u.CompletionTokens,
+	}
+	if u.CachedTokens > 0 {
+		m["prompt_tokens_details"] = map[string]any{"cached_tokens": u.CachedTokens}
+	}
+	return m
+}
+
+// NewChatCompletionsResponseWriter 创建转换写入器，model 为客户端请求的模型名
+func NewChatCompletionsResponseWriter(w gin.ResponseWriter, format ChatCompletionsUpstreamFormat, model string) *ChatCompletionsResponseWriter {
+	return &ChatCompletionsResponseWriter{
+		ResponseWriter: w,
+		format:         format,
+		model:          model,
+		id:             "chatcmpl-" + randomHex(12),
+		created:        time.Now().Unix(),
+		toolIndex:      make(map[string]int),
+	}
+}
+
+// WriteHeader 记录状态码，实际写出延迟到确定响应模式之后
+func (w *ChatCompletionsResponseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.decided {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow 延迟到 Finish 或首个流式数据块写出
+func (w *ChatCompletionsResponseWriter) WriteHeaderNow() {}
+
+// Status 返回记录的状态码
+func (w *ChatCompletionsResponseWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.status != 0 {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+// Written 判断是否已有响应写入
+func (w *ChatCompletionsResponseWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.decided || w.ResponseWriter.Written()
+}
+
+func (w *ChatCompletionsResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *ChatCompletionsResponseWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.decided {
+		w.decided = true
+		status := w.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		contentType := strings.ToLower(w.ResponseWriter.Header().Get("Content-Type"))
+		w.stream = status < 400 && strings.Contains(contentType, "text/event-stream")
+	}
+	if !w.stream {
+		w.buf.Write(b)
+		return len(b), nil
+	}
+
+	w.line.Write(b)
+	for {
+		data := w.line.Bytes()
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			break
+		}
+		line := strings.TrimRight(string(data[:idx]), "\r")
+		w.line.Next(idx + 1)
+		if err := w.handleStreamLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *ChatCompletionsResponseWriter) Flush() {
+	w.mu.Lock()
+	stream := w.stream
+	w.mu.Unlock()
+	if stream {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// Finish 写出缓冲的非流式/错误响应，或补齐未正常结束的流。
+// 必须在 Forward 返回后、恢复原始 Writer 之前调用。
+func (w *ChatCompletionsResponseWriter) Finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.finished {
+		return
+	}
+	w.finished = true
+
+	if !w.decided {
+		return
+	}
+	if w.stream {
+		if w.line.Len() > 0 {
+			line := strings.TrimRight(w.line.String(), "\r\n")
+			w.line.Reset()
+			_ = w.handleStreamLine(line)
+		}
+		if !w.done {
+			_ = w.emitFinal()
+		}
+		w.ResponseWriter.Flush()
+		return
+	}
+
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	var out []byte
+	if status >= 400 {
+		out = convertUpstreamErrorToChat(w.buf.Bytes())
+	} else {
+		out = w.convertNonStreaming(w.buf.Bytes())
+	}
+	header := w.ResponseWriter.Header()
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	header.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(status)
+	_, _ = w.ResponseWriter.Write(out)
+}
+
+func (w *ChatCompletionsResponseWriter) handleStreamLine(line string) error {
+	// SSE 注释行（等待槽位期间的 keepalive）原样透传
+	if strings.HasPrefix(line, ":") {
+		_, err := w.ResponseWriter.Write([]byte(line + "\n\n"))
+		return err
+	}
+	if !sseDataRe.MatchString(line) {
+		return nil
+	}
+	data := strings.TrimSpace(sseDataRe.ReplaceAllString(line, ""))
+	if data == "" || data == "[DONE]" || w.done {
+		return nil
+	}
+	var event map[string]any
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return nil
+	}
+	eventType, _ := event["type"].(string)
+	switch {
+	case eventType == "ping":
+		_, err := w.ResponseWriter.Write([]byte(": ping\n\n"))
+		return err
+	case eventType == "" && event["error"] != nil:
+		return w.emitStreamError(event["error"])
+	}
+	if w.format == ChatCompletionsUpstreamResponses {
+		return w.handleResponsesEvent(event)
+	}
+	return w.handleClaudeEvent(event)
+}
+
+func (w *ChatCompletionsResponseWriter) handleClaudeEvent(event map[string]any) error {
+	eventType, _ := event["type"].(string)
+	switch eventType {
+	case "message_start":
+		msg, _ := event["message"].(map[string]any)
+		if usage, ok := msg["usage"].(map[string]any); ok {
+			w.applyClaudeUsage(usage)
+		}
+		return w.emitRole()
+	case "content_block_start":
+		block, _ := event["content_block"].(map[string]any)
+		if block["type"] != "tool_use" {
+			return nil
+		}
+		key := fmt.Sprintf("%v", event["index"])
+		idx := w.nextTool
+		w.nextTool++
+		w.toolIndex[key] = idx
+		return w.emitDelta(map[string]any{"tool_calls": []any{map[string]any{
+			"index":    idx,
+			"id":       block["id"],
+			"type":     "function",
+			"function": map[string]any{"name": block["name"], "arguments": ""},
+		}}})
+	case "content_block_delta":
+		delta, _ := event["delta"].(map[string]any)
+		switch delta["type"] {
+		case "text_delta":
+			if text, _ := delta["text"].(string); text != "" {
+				return w.emitDelta(map[string]any{"content": text})
+			}
+		case "thinking_delta":
+			if text, _ := delta["thinking"].(string); text != "" {
+				return w.emitDelta(map[string]any{"reasoning_content": text})
+			}
+		case "input_json_delta":
+			idx, ok := w.toolIndex[fmt.Sprintf("%v", event["index"])]
+			if !ok {
+				return nil
+			}
+			partial, _ := delta["partial_json"].(string)
+			if partial == "" {
+				return nil
+			}
+			return w.emitDelta(map[string]any{"tool_calls": []any{map[string]any{
+				"index":    idx,
+				"function": map[string]any{"arguments": partial},
+			}}})
+		}
+	case "message_delta":
+		if delta, ok := event["delta"].(map[string]any); ok {
+			if reason, _ := delta["stop_reason"].(string); reason != "" {
+				w.finishReason = mapClaudeStopReasonToChat(reason)
+			}
+		}
+		if usage, ok := event["usage"].(map[string]any); ok {
+			w.applyClaudeUsage(usage)
+		}
+	case "message_stop":
+		return w.emitFinal()
+	case "error":
+		return w.emitStreamError(event["error"])
+	}
+	return nil
+}
+
+func (w *ChatCompletionsResponseWriter) applyClaudeUsage(usage map[string]any) {
+	input, _ := asInt(usage["input_tokens"])
+	output, _ := asInt(usage["output_tokens"])
+	cacheCreation, _ := asInt(usage["cache_creation_input_tokens"])
+	cacheRead, _ := asInt(usage["cache_read_input_tokens"])
+	if prompt := input + cacheCreation + cacheRead; prompt > 0 {
+		w.usage.PromptTokens = prompt
+	}
+	if cacheRead > 0 {
+		w.usage.CachedTokens = cacheRead
+	}
+	if output > 0 {
+		w.usage.CompletionTokens = output
+	}
+}
+
+func (w *ChatCompletionsResponseWriter) handleResponsesEvent(event map[string]any) error {
+	eventType, _ := event["type"].(string)
+	switch eventType {
+	case "response.created":
+		return w.emitRole()
+	case "response.output_item.added":
+		item, _ := event["item"].(map[string]any)
+		if item["type"] != "function_call" {
+			return nil
+		}
+		key := fmt.Sprintf("%v", event["output_index"])
+		idx := w.nextTool
+		w.nextTool++
+		w.toolIndex[key] = idx
+		return w.emitDelta(map[string]any{"tool_calls": []any{map[string]any{
+			"index":    idx,
+			"id":       item["call_id"],
+			"type":     "function",
+			"function": map[string]any{"name": item["name"], "arguments": ""},
+		}}})
+	case "response.output_text.delta":
+		if text, _ := event["delta"].(string); text != "" {
+			return w.emitDelta(map[string]any{"content": text})
+		}
+	case "response.reasoning_summary_text.delta", "response.reasoning_text.delta":
+		if text, _ := event["delta"].(string); text != "" {
+			return w.emitDelta(map[string]any{"reasoning_content": text})
+		}
+	case "response.function_call_arguments.delta":
+		idx, ok := w.toolIndex[fmt.Sprintf("%v", event["output_index"])]
+		if !ok {
+			return nil
+		}
+		if partial, _ := event["delta"].(string); partial != "" {
+			return w.emitDelta(map[string]any{"tool_calls": []any{map[string]any{
+				"index":    idx,
+				"function": map[string]any{"arguments": partial},
+			}}})
+		}
+	case "response.completed", "response.incomplete":
+		resp, _ := event["response"].(map[string]any)
+		w.applyResponsesUsage(resp)
+		w.finishReason = responsesFinishReason(resp, w.nextTool > 0)
+		return w.emitFinal()
+	case "response.failed":
+		resp, _ := event["response"].(map[string]any)
+		return w.emitStreamError(resp["error"])
+	case "error":
+		if errObj, ok := event["error"]; ok {
+			return w.emitStreamError(errObj)
+		}
+		return w.emitStreamError(event)
+	}
+	return nil
+}
+
+func (w *ChatCompletionsResponseWriter) applyResponsesUsage(resp map[string]any) {
+	usage, ok := resp["usage"].(map[string]any)
+	if !ok {
+		return
+	}
+	w.usage.PromptTokens, _ = asInt(usage["input_tokens"])
+	w.usage.CompletionTokens, _ = asInt(usage["output_tokens"])
+	if details, ok := usage["input_tokens_details"].(map[string]any); ok {
+		w.usage.CachedTokens, _ = asInt(details["cached_tokens"])
+	}
+}
+
+func (w *ChatCompletionsResponseWriter) emitRole() error {
+	if w.roleSent {
+		return nil
+	}
+	w.roleSent = true
+	return w.writeChunk([]any{map[string]any{
+		"index":         0,
+		"delta":         map[string]any{"role": "assistant", "content": ""},
+		"finish_reason": nil,
+	}}, nil)
+}
+
+func (w *ChatCompletionsResponseWriter) emitDelta(delta map[string]any) error {
+	if err := w.emitRole(); err != nil {
+		return err
+	}
+	return w.writeChunk([]any{map[string]any{
+		"index":         0,
+		"delta":         delta,
+		"finish_reason": nil,
+	}}, nil)
+}
+
+// emitFinal 写出 finish_reason 块、携带 usage 的最终块与 [DONE]
+func (w *ChatCompletionsResponseWriter) emitFinal() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+	if err := w.emitRole(); err != nil {
+		return err
+	}
+	reason := w.finishReason
+	if reason == "" {
+		reason = "stop"
+	}
+	if err := w.writeChunk([]any{map[string]any{
+		"index":         0,
+		"delta":         map[string]any{},
+		"finish_reason": reason,
+	}}, nil); err != nil {
+		return err
+	}
+	usage := w.usage.toMap()
+	if err := w.writeChunk([]any{}, usage); err != nil {
+		return err
+	}
+	_, err := w.ResponseWriter.Write([]byte("data: [DONE]\n\n"))
+	return err
+}
+
+func (w *ChatCompletionsResponseWriter) emitStreamError(raw any) error {
+	w.done = true
+	errType, message := "upstream_error", "Upstream stream error"
+	switch v := raw.(type) {
+	case map[string]any:
+		if t, _ := v["type"].(string); t != "" {
+			errType = t
+		}
+		if m, _ := v["message"].(string); m != "" {
+			message = m
+		}
+	case string:
+		if v != "" {
+			message = v
+		}
+	}
+	payload, err := json.Marshal(map[string]any{"error": map[string]any{"type": errType, "message": message}})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.ResponseWriter, "data: %s\n\n", payload)
+	return err
+}
+
+func (w *ChatCompletionsResponseWriter) writeChunk(choices []any, usage map[string]any) error {
+	chunk := map[string]any{
+		"id":      w.id,
+		"object":  "chat.completion.chunk",
+		"created": w.created,
+		"model":   w.model,
+		"choices": choices,
+	}
+	if usage != nil {
+		chunk["usage"] = usage
+	}
+	payload, err := json.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.ResponseWriter, "data: %s\n\n", payload)
+	return err
+}
+
+func (w *ChatCompletionsResponseWriter) convertNonStreaming(body []byte) []byte {
+	var resp map[string]any
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return convertUpstreamErrorToChat(body)
+	}
+
+	message := map[string]any{"role": "assistant", "content": nil}
+	var textParts, reasoningParts []string
+	var toolCalls []any
+	var finishReason string
+
+	if w.format == ChatCompletionsUpstreamResponses {
+		output, _ := resp["output"].([]any)
+		for _, rawItem := range output {
+			item, ok := rawItem.(map[string]any)
+			if !ok {
+				continue
+			}
+			switch item["type"] {
+			case "message":
+				content, _ := item["content"].([]any)
+				for _, rawPart := range content {
+					part, ok := rawPart.(map[string]any)
+					if !ok {
+						continue
+					}
+					if text, _ := part["text"].(string); text != "" && part["type"] == "output_text" {
+						textParts = append(textParts, text)
+					}
+				}
+			case "reasoning":
+				summary, _ := item["summary"].([]any)
+				for _, rawPart := range summary {
+					if part, ok := rawPart.(map[string]any); ok {
+						if text, _ := part["text"].(string); text != "" {
+							reasoningParts = append(reasoningParts, text)
+						}
+					}
+				}
+			case "function_call":
+				toolCalls = append(toolCalls, map[string]any{
+					"id":       item["call_id"],
+					"type":     "function",
+					"function": map[string]any{"name": item["name"], "arguments": item["arguments"]},
+				})
+			}
+		}
+		w.applyResponsesUsage(resp)
+		finishReason = responsesFinishReason(resp, len(toolCalls) > 0)
+	} else {
+		content, _ := resp["content"].([]any)
+		for _, rawBlock := range content {
+			block, ok := rawBlock.(map[string]any)
+			if !ok {
+				continue
+			}
+			switch block["type"] {
+			case "text":
+				if text, _ := block["text"].(string); text != "" {
+					textParts = append(textParts, text)
+				}
+			case "thinking":
+				if text, _ := block["thinking"].(string); text != "" {
+					reasoningParts = append(reasoningParts, text)
+				}
+			case "tool_use":
+				args, err := json.Marshal(block["input"])
+				if err != nil {
+					args = []byte("{}")
+				}
+				toolCalls = append(toolCalls, map[string]any{
+					"id":       block["id"],
+					"type":     "function",
+					"function": map[string]any{"name": block["name"], "arguments": string(args)},
+				})
+			}
+		}
+		if usage, ok := resp["usage"].(map[string]any); ok {
+			w.applyClaudeUsage(usage)
+		}
+		reason, _ := resp["stop_reason"].(string)
+		finishReason = mapClaudeStopReasonToChat(reason)
+	}
+
+	if len(textParts) > 0 {
+		message["content"] = strings.Join(textParts, "")
+	}
+	if len(reasoningParts) > 0 {
+		message["reasoning_content"] = strings.Join(reasoningParts, "\n")
+	}
+	if len(toolCalls) > 0 {
+		message["tool_calls"] = toolCalls
+	}
+
+	out, err := json.Marshal(map[string]any{
+		"id":      w.id,
+		"object":  "chat.completion",
+		"created": w.created,
+		"model":   w.model,
+		"choices": []any{map[string]any{
+			"index":         0,
+			"message":       message,
+			"finish_reason": finishReason,
+		}},
+		"usage": w.usage.toMap(),
+	})
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// convertUpstreamErrorToChat 将 Anthropic/Responses 错误体统一为 OpenAI 错误格式
+func convertUpstreamErrorToChat(body []byte) []byte {
+	errType, message := "upstream_error", strings.TrimSpace(string(body))
+	var parsed struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil && len(parsed.Error) > 0 {
+		var obj struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(parsed.Error, &obj) == nil {
+			if obj.Type != "" {
+				errType = obj.Type
+			}
+			if obj.Message != "" {
+				message = obj.Message
+			}
+		} else {
+			var str string
+			if json.Unmarshal(parsed.Error, &str) == nil && str != "" {
+				message = str
+			}
+		}
+	}
+	if message == "" {
+		message = "Upstream request failed"
+	}
+	out, _ := json.Marshal(map[string]any{"error": map[string]any{"type": errType, "message": message}})
+	return out
+}
+
+func mapClaudeStopReasonToChat(reason string) string {
+	switch reason {
+	case "max_tokens":
+		return "length"
+	case "tool_use":
+		return "tool_calls"
+	case "refusal":
+		return "content_filter"
+	default:
+		return "stop"
+	}
+}
+
+func responsesFinishReason(resp map[string]any, hasToolCalls bool) string {
+	if hasToolCalls {
+		return "tool_calls"
+	}
+	if status, _ := resp["status"].(string); status == "incomplete" {
+		if details, ok := resp["incomplete_details"].(map[string]any); ok {
+			if reason, _ := details["reason"].(string); reason == "content_filter" {
+				return "content_filter"
+			}
+		}
+		return "length"
+	}
+	return "stop"
+}
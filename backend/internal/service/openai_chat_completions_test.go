@@ -0,0 +1,276 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChatCompletionsRequest(t *testing.T) {
+	req, err := ParseChatCompletionsRequest([]byte(`{"model":"gpt-4o","stream":true,"messages":[{"role":"user","content":"hi"}]}`))
+	require.NoError(t, err)
+	require.Equal(t, "gpt-4o", req.Model)
+	require.True(t, req.Stream)
+
+	_, err = ParseChatCompletionsRequest([]byte(`{"model":"gpt-4o"}`))
+	require.Error(t, err)
+	_, err = ParseChatCompletionsRequest([]byte(`{"model":1,"messages":[]}`))
+	require.Error(t, err)
+}
+
+func TestConvertChatCompletionsToClaude(t *testing.T) {
+	req, err := ParseChatCompletionsRequest([]byte(`{
+		"model":"claude-sonnet-4-5",
+		"max_tokens":512,
+		"stop":"END",
+		"messages":[
+			{"role":"system","content":"be brief"},
+			{"role":"user","content":[{"type":"text","text":"look"},{"type":"image_url","image_url":{"url":"data:image/png;base64,AAAA"}}]},
+			{"role":"assistant","content":null,"tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"SF\"}"}}]},
+			{"role":"tool","tool_call_id":"call_1","content":"sunny"},
+			{"role":"user","content":"thanks"}
+		],
+		"tools":[{"type":"function","function":{"name":"get_weather","parameters":{"type":"object"}}}],
+		"tool_choice":"required"
+	}`))
+	require.NoError(t, err)
+
+	body, err := ConvertChatCompletionsToClaude(req)
+	require.NoError(t, err)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(body, &out))
+	require.Equal(t, "be brief", out["system"])
+	require.EqualValues(t, 512, out["max_tokens"])
+	require.Equal(t, []any{"END"}, out["stop_sequences"])
+	require.Equal(t, map[string]any{"type": "any"}, out["tool_choice"])
+
+	messages := out["messages"].([]any)
+	// tool 结果与后续 user 消息合并为一条 user 消息，保证角色交替
+	require.Len(t, messages, 3)
+	first := messages[0].(map[string]any)["content"].([]any)
+	image := first[1].(map[string]any)
+	require.Equal(t, "image", image["type"])
+	require.Equal(t, "image/png", image["source"].(map[string]any)["media_type"])
+
+	toolUse := messages[1].(map[string]any)["content"].([]any)[0].(map[string]any)
+	require.Equal(t, "tool_use", toolUse["type"])
+	require.Equal(t, map[string]any{"city": "SF"}, toolUse["input"])
+
+	last := messages[2].(map[string]any)["content"].([]any)
+	require.Len(t, last, 2)
+	require.Equal(t, "tool_result", last[0].(map[string]any)["type"])
+}
+
+func TestConvertChatCompletionsToClaudeDefaultMaxTokens(t *testing.T) {
+	req, err := ParseChatCompletionsRequest([]byte(`{"model":"m","messages":[{"role":"user","content":"hi"}],"response_format":{"type":"json_object"}}`))
+	require.NoError(t, err)
+	body, err := ConvertChatCompletionsToClaude(req)
+	require.NoError(t, err)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(body, &out))
+	require.EqualValues(t, chatCompletionsDefaultMaxTokens, out["max_tokens"])
+	require.Equal(t, chatCompletionsJSONObjectPrompt, out["system"])
+}
+
+func TestChatCompletionsApplyMaxTokensCap(t *testing.T) {
+	limit := 1024
+	req, err := ParseChatCompletionsRequest([]byte(`{"model":"m","messages":[{"role":"user","content":"hi"}]}`))
+	require.NoError(t, err)
+	require.Zero(t, req.MaxTokens)
+	req.ApplyMaxTokensCap(&limit, ChatCompletionsUpstreamClaude)
+	body, err := ConvertChatCompletionsToClaude(req)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(body, &out))
+	require.EqualValues(t, limit, out["max_tokens"])
+
+	// Responses 没有默认上限，未指定时始终写入
+	large := 32000
+	req, err = ParseChatCompletionsRequest([]byte(`{"model":"gpt-5","messages":[{"role":"user","content":"hi"}]}`))
+	require.NoError(t, err)
+	req.ApplyMaxTokensCap(&large, ChatCompletionsUpstreamResponses)
+	body, err = ConvertChatCompletionsToResponses(req)
+	require.NoError(t, err)
+	out = nil
+	require.NoError(t, json.Unmarshal(body, &out))
+	require.EqualValues(t, large, out["max_output_tokens"])
+
+	// 客户端显式指定时保持原值，由 API Key 校验决定是否拒绝
+	req, err = ParseChatCompletionsRequest([]byte(`{"model":"m","max_completion_tokens":4096,"messages":[{"role":"user","content":"hi"}]}`))
+	require.NoError(t, err)
+	require.Equal(t, 4096, req.MaxTokens)
+	req.ApplyMaxTokensCap(&limit, ChatCompletionsUpstreamResponses)
+	require.Equal(t, 4096, req.MaxTokens)
+}
+
+func TestConvertChatCompletionsToResponses(t *testing.T) {
+	req, err := ParseChatCompletionsRequest([]byte(`{
+		"model":"gpt-5",
+		"max_completion_tokens":100,
+		"messages":[
+			{"role":"developer","content":"rules"},
+			{"role":"user","content":"hi"},
+			{"role":"assistant","tool_calls":[{"id":"call_1","function":{"name":"f","arguments":"{}"}}]},
+			{"role":"tool","tool_call_id":"call_1","content":"ok"}
+		],
+		"tools":[{"type":"function","function":{"name":"f","parameters":{"type":"object"}}}],
+		"tool_choice":{"type":"function","function":{"name":"f"}},
+		"response_format":{"type":"json_object"}
+	}`))
+	require.NoError(t, err)
+
+	body, err := ConvertChatCompletionsToResponses(req)
+	require.NoError(t, err)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(body, &out))
+	require.Equal(t, "rules", out["instructions"])
+	require.Equal(t, false, out["store"])
+	require.EqualValues(t, 100, out["max_output_tokens"])
+	require.Equal(t, map[string]any{"type": "function", "name": "f"}, out["tool_choice"])
+	require.Equal(t, map[string]any{"format": map[string]any{"type": "json_object"}}, out["text"])
+
+	input := out["input"].([]any)
+	require.Len(t, input, 3)
+	require.Equal(t, "function_call", input[1].(map[string]any)["type"])
+	require.Equal(t, "function_call_output", input[2].(map[string]any)["type"])
+	require.Equal(t, "f", out["tools"].([]any)[0].(map[string]any)["name"])
+}
+
+func newChatCompletionsTestWriter(format ChatCompletionsUpstreamFormat) (*ChatCompletionsResponseWriter, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	return NewChatCompletionsResponseWriter(c.Writer, format, "client-model"), rec
+}
+
+func parseChatChunks(t *testing.T, body string) []map[string]any {
+	t.Helper()
+	var chunks []map[string]any
+	for _, line := range strings.Split(body, "\n") {
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+		var chunk map[string]any
+		require.NoError(t, json.Unmarshal([]byte(data), &chunk))
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func TestChatCompletionsWriterClaudeStream(t *testing.T) {
+	w, rec := newChatCompletionsTestWriter(ChatCompletionsUpstreamClaude)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+
+	stream := strings.Join([]string{
+		`event: message_start`,
+		`data: {"type":"message_start","message":{"usage":{"input_tokens":10,"cache_read_input_tokens":5}}}`,
+		``,
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}`,
+		``,
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"lo"}}`,
+		``,
+		`data: {"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"f"}}`,
+		``,
+		`data: {"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"a\":1}"}}`,
+		``,
+		`data: {"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":7}}`,
+		``,
+		`data: {"type":"message_stop"}`,
+		``,
+	}, "\n")
+	// 分段写入，验证跨 Write 的半行缓冲
+	_, err := w.Write([]byte(stream[:37]))
+	require.NoError(t, err)
+	_, err = w.Write([]byte(stream[37:]))
+	require.NoError(t, err)
+	w.Finish()
+
+	body := rec.Body.String()
+	require.True(t, strings.HasSuffix(body, "data: [DONE]\n\n"))
+	chunks := parseChatChunks(t, body)
+	require.Equal(t, "chat.completion.chunk", chunks[0]["object"])
+	require.Equal(t, "client-model", chunks[0]["model"])
+	require.Equal(t, "assistant", chunks[0]["choices"].([]any)[0].(map[string]any)["delta"].(map[string]any)["role"])
+
+	var content strings.Builder
+	for _, chunk := range chunks {
+		for _, choice := range chunk["choices"].([]any) {
+			if text, ok := choice.(map[string]any)["delta"].(map[string]any)["content"].(string); ok {
+				content.WriteString(text)
+			}
+		}
+	}
+	require.Equal(t, "Hello", content.String())
+
+	finish := chunks[len(chunks)-2]["choices"].([]any)[0].(map[string]any)
+	require.Equal(t, "tool_calls", finish["finish_reason"])
+
+	usage := chunks[len(chunks)-1]["usage"].(map[string]any)
+	require.EqualValues(t, 15, usage["prompt_tokens"])
+	require.EqualValues(t, 7, usage["completion_tokens"])
+	require.EqualValues(t, 22, usage["total_tokens"])
+	require.EqualValues(t, 5, usage["prompt_tokens_details"].(map[string]any)["cached_tokens"])
+}
+
+func TestChatCompletionsWriterResponsesStream(t *testing.T) {
+	w, rec := newChatCompletionsTestWriter(ChatCompletionsUpstreamResponses)
+	w.Header().Set("Content-Type", "text/event-stream")
+
+	_, err := w.WriteString(strings.Join([]string{
+		`data: {"type":"response.created","response":{}}`,
+		`data: {"type":"response.output_text.delta","delta":"ok"}`,
+		`data: {"type":"response.completed","response":{"status":"completed","usage":{"input_tokens":3,"output_tokens":2,"input_tokens_details":{"cached_tokens":1}}}}`,
+		``,
+	}, "\n"))
+	require.NoError(t, err)
+	w.Finish()
+
+	chunks := parseChatChunks(t, rec.Body.String())
+	require.Len(t, chunks, 4)
+	require.Equal(t, "ok", chunks[1]["choices"].([]any)[0].(map[string]any)["delta"].(map[string]any)["content"])
+	require.Equal(t, "stop", chunks[2]["choices"].([]any)[0].(map[string]any)["finish_reason"])
+	require.EqualValues(t, 5, chunks[3]["usage"].(map[string]any)["total_tokens"])
+}
+
+func TestChatCompletionsWriterNonStreaming(t *testing.T) {
+	w, rec := newChatCompletionsTestWriter(ChatCompletionsUpstreamClaude)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write([]byte(`{"content":[{"type":"text","text":"hi"},{"type":"tool_use","id":"t1","name":"f","input":{"x":1}}],"stop_reason":"end_turn","usage":{"input_tokens":4,"output_tokens":2}}`))
+	require.NoError(t, err)
+	w.Finish()
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	require.Equal(t, "chat.completion", out["object"])
+	choice := out["choices"].([]any)[0].(map[string]any)
+	require.Equal(t, "stop", choice["finish_reason"])
+	message := choice["message"].(map[string]any)
+	require.Equal(t, "hi", message["content"])
+	call := message["tool_calls"].([]any)[0].(map[string]any)
+	require.Equal(t, `{"x":1}`, call["function"].(map[string]any)["arguments"])
+	require.EqualValues(t, 6, out["usage"].(map[string]any)["total_tokens"])
+}
+
+func TestChatCompletionsWriterErrorResponse(t *testing.T) {
+	w, rec := newChatCompletionsTestWriter(ChatCompletionsUpstreamClaude)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, err := w.Write([]byte(`{"type":"error","error":{"type":"rate_limit_error","message":"slow down"}}`))
+	require.NoError(t, err)
+	w.Finish()
+
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.JSONEq(t, `{"error":{"type":"rate_limit_error","message":"slow down"}}`, rec.Body.String())
+}
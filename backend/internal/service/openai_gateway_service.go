@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/openai"
 	"github.com/Wei-Shaw/sub2api/internal/util/responseheaders"
 	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
@@ -152,35 +153,41 @@ type OpenAIUsage struct {
 	OutputTokens             int `json:"output_tokens"`
 	CacheCreationInputTokens int `json:"cache_creation_input_tokens,omitempty"`
 	CacheReadInputTokens     int `json:"cache_read_input_tokens,omitempty"`
+	// ServiceTier 上游实际使用的服务等级（default / flex / priority）
+	ServiceTier string `json:"service_tier,omitempty"`
 }
 
 // OpenAIForwardResult represents the result of forwarding
 type OpenAIForwardResult struct {
-	RequestID    string
-	Usage        OpenAIUsage
-	Model        string
-	Stream       bool
-	Duration     time.Duration
-	FirstTokenMs *int
+	RequestID        string
+	Usage            OpenAIUsage
+	Model            string
+	Stream           bool
+	Duration         time.Duration
+	FirstTokenMs     *int
+	ClientDisconnect bool // 客户端是否在流式传输过程中断开
 }
 
 // OpenAIGatewayService handles OpenAI API gateway operations
 type OpenAIGatewayService struct {
-	accountRepo         AccountRepository
-	usageLogRepo        UsageLogRepository
-	userRepo            UserRepository
-	userSubRepo         UserSubscriptionRepository
-	cache               GatewayCache
-	cfg                 *config.Config
-	schedulerSnapshot   *SchedulerSnapshotService
-	concurrencyService  *ConcurrencyService
-	billingService      *BillingService
-	rateLimitService    *RateLimitService
-	billingCacheService *BillingCacheService
-	httpUpstream        HTTPUpstream
-	deferredService     *DeferredService
-	openAITokenProvider *OpenAITokenProvider
-	toolCorrector       *CodexToolCorrector
+	accountRepo          AccountRepository
+	usageLogRepo         UsageLogRepository
+	userRepo             UserRepository
+	userSubRepo          UserSubscriptionRepository
+	cache                GatewayCache
+	cfg                  *config.Config
+	schedulerSnapshot    *SchedulerSnapshotService
+	concurrencyService   *ConcurrencyService
+	billingService       *BillingService
+	rateLimitService     *RateLimitService
+	billingCacheService  *BillingCacheService
+	apiKeyRateLimiter    *APIKeyRateLimitService
+	httpUpstream         HTTPUpstream
+	deferredService      *DeferredService
+	openAITokenProvider  *OpenAITokenProvider
+	toolCorrector        *CodexToolCorrector
+	schedulingStrategies *SchedulingStrategyService
+	circuitBreaker       *AccountCircuitBreakerService
 }
 
 // NewOpenAIGatewayService creates a new OpenAIGatewayService
@@ -196,26 +203,32 @@ func NewOpenAIGatewayService(
 	billingService *BillingService,
 	rateLimitService *RateLimitService,
 	billingCacheService *BillingCacheService,
+	apiKeyRateLimiter *APIKeyRateLimitService,
 	httpUpstream HTTPUpstream,
 	deferredService *DeferredService,
 	openAITokenProvider *OpenAITokenProvider,
+	schedulingStrategies *SchedulingStrategyService,
+	circuitBreaker *AccountCircuitBreakerService,
 ) *OpenAIGatewayService {
 	return &OpenAIGatewayService{
-		accountRepo:         accountRepo,
-		usageLogRepo:        usageLogRepo,
-		userRepo:            userRepo,
-		userSubRepo:         userSubRepo,
-		cache:               cache,
-		cfg:                 cfg,
-		schedulerSnapshot:   schedulerSnapshot,
-		concurrencyService:  concurrencyService,
-		billingService:      billingService,
-		rateLimitService:    rateLimitService,
-		billingCacheService: billingCacheService,
-		httpUpstream:        httpUpstream,
-		deferredService:     deferredService,
-		openAITokenProvider: openAITokenProvider,
-		toolCorrector:       NewCodexToolCorrector(),
+		accountRepo:          accountRepo,
+		usageLogRepo:         usageLogRepo,
+		userRepo:             userRepo,
+		userSubRepo:          userSubRepo,
+		cache:                cache,
+		cfg:                  cfg,
+		schedulerSnapshot:    schedulerSnapshot,
+		concurrencyService:   concurrencyService,
+		billingService:       billingService,
+		rateLimitService:     rateLimitService,
+		billingCacheService:  billingCacheService,
+		apiKeyRateLimiter:    apiKeyRateLimiter,
+		httpUpstream:         httpUpstream,
+		deferredService:      deferredService,
+		openAITokenProvider:  openAITokenProvider,
+		toolCorrector:        NewCodexToolCorrector(),
+		schedulingStrategies: schedulingStrategies,
+		circuitBreaker:       circuitBreaker,
 	}
 }
 
@@ -428,6 +441,12 @@ func (s *OpenAIGatewayService) isBetterAccount(candidate, current *Account) bool
 
 // SelectAccountWithLoadAwareness selects an account with load-awareness and wait plan.
 func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Context, groupID *int64, sessionHash string, requestedModel string, excludedIDs map[int64]struct{}) (*AccountSelectionResult, error) {
+	return s.circuitBreaker.guardWaitPlan(ctx, excludedIDs, func(excluded map[int64]struct{}) (*AccountSelectionResult, error) {
+		return s.selectAccountWithLoadAwareness(ctx, groupID, sessionHash, requestedModel, excluded)
+	})
+}
+
+func (s *OpenAIGatewayService) selectAccountWithLoadAwareness(ctx context.Context, groupID *int64, sessionHash string, requestedModel string, excludedIDs map[int64]struct{}) (*AccountSelectionResult, error) {
 	cfg := s.schedulingConfig()
 	var stickyAccountID int64
 	if sessionHash != "" && s.cache != nil {
@@ -577,10 +596,6 @@ func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Contex
 			}
 		}
 	} else {
-		type accountWithLoad struct {
-			account  *Account
-			loadInfo *AccountLoadInfo
-		}
 		var available []accountWithLoad
 		for _, acc := range candidates {
 			loadInfo := loadMap[acc.ID]
@@ -615,6 +630,7 @@ func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Contex
 					return a.account.LastUsedAt.Before(*b.account.LastUsedAt)
 				}
 			})
+			s.schedulingStrategies.Order(ctx, s.groupFromContext(ctx, groupID), available)
 
 			for _, item := range available {
 				result, err := s.tryAcquireAccountSlot(ctx, item.account.ID, item.account.Concurrency)
@@ -652,7 +668,10 @@ func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Contex
 func (s *OpenAIGatewayService) listSchedulableAccounts(ctx context.Context, groupID *int64) ([]Account, error) {
 	if s.schedulerSnapshot != nil {
 		accounts, _, err := s.schedulerSnapshot.ListSchedulableAccounts(ctx, groupID, PlatformOpenAI, false)
-		return accounts, err
+		if err != nil {
+			return nil, err
+		}
+		return s.circuitBreaker.FilterSchedulable(ctx, accounts), nil
 	}
 	var accounts []Account
 	var err error
@@ -666,14 +685,34 @@ func (s *OpenAIGatewayService) listSchedulableAccounts(ctx context.Context, grou
 	if err != nil {
 		return nil, fmt.Errorf("query accounts failed: %w", err)
 	}
-	return accounts, nil
+	// 剔除熔断打开的账号
+	return s.circuitBreaker.FilterSchedulable(ctx, accounts), nil
 }
 
 func (s *OpenAIGatewayService) tryAcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int) (*AcquireResult, error) {
+	var result *AcquireResult
 	if s.concurrencyService == nil {
-		return &AcquireResult{Acquired: true, ReleaseFunc: func() {}}, nil
+		result = &AcquireResult{Acquired: true, ReleaseFunc: func() {}}
+	} else {
+		var err error
+		result, err = s.concurrencyService.AcquireAccountSlot(ctx, accountID, maxConcurrency)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return s.concurrencyService.AcquireAccountSlot(ctx, accountID, maxConcurrency)
+	// 熔断打开或半开探测名额已满时放弃槽位
+	if result.Acquired && !s.circuitBreaker.AllowRequest(ctx, accountID) {
+		if result.ReleaseFunc != nil {
+			result.ReleaseFunc()
+		}
+		return &AcquireResult{Acquired: false}, nil
+	}
+	return result, nil
+}
+
+// RecordAccountResult 记录一次转发结果，用于账号熔断统计
+func (s *OpenAIGatewayService) RecordAccountResult(c *gin.Context, account *Account, err error) {
+	s.circuitBreaker.RecordResult(c, account, err)
 }
 
 func (s *OpenAIGatewayService) getSchedulableAccount(ctx context.Context, accountID int64) (*Account, error) {
@@ -683,6 +722,17 @@ func (s *OpenAIGatewayService) getSchedulableAccount(ctx context.Context, accoun
 	return s.accountRepo.GetByID(ctx, accountID)
 }
 
+// groupFromContext 返回鉴权中间件注入上下文的分组（用于读取调度策略），与 groupID 不一致时返回 nil
+func (s *OpenAIGatewayService) groupFromContext(ctx context.Context, groupID *int64) *Group {
+	if groupID == nil {
+		return nil
+	}
+	if group, ok := ctx.Value(ctxkey.Group).(*Group); ok && IsGroupContextValid(group) && group.ID == *groupID {
+		return group
+	}
+	return nil
+}
+
 func (s *OpenAIGatewayService) schedulingConfig() config.GatewaySchedulingConfig {
 	if s.cfg != nil {
 		return s.cfg.Gateway.Scheduling
@@ -753,6 +803,10 @@ func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, acco
 	// Extract model and stream from parsed body
 	reqModel, _ := reqBody["model"].(string)
 	reqStream, _ := reqBody["stream"].(bool)
+
+	// 流式请求：客户端断开后可按配置继续排空上游以获取完整 usage
+	ctx, cancelUpstream := detachUpstreamContext(ctx, streamDrainTimeout(s.cfg, reqStream))
+	defer cancelUpstream()
 	promptCacheKey := ""
 	if v, ok := reqBody["prompt_cache_key"].(string); ok {
 		promptCacheKey = strings.TrimSpace(v)
@@ -937,6 +991,7 @@ func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, acco
 	// Handle normal response
 	var usage *OpenAIUsage
 	var firstTokenMs *int
+	var clientDisconnect bool
 	if reqStream {
 		streamResult, err := s.handleStreamingResponse(ctx, resp, c, account, startTime, originalModel, mappedModel)
 		if err != nil {
@@ -944,6 +999,7 @@ func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, acco
 		}
 		usage = streamResult.usage
 		firstTokenMs = streamResult.firstTokenMs
+		clientDisconnect = streamResult.clientDisconnect
 	} else {
 		usage, err = s.handleNonStreamingResponse(ctx, resp, c, account, originalModel, mappedModel)
 		if err != nil {
@@ -959,12 +1015,13 @@ func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, acco
 	}
 
 	return &OpenAIForwardResult{
-		RequestID:    resp.Header.Get("x-request-id"),
-		Usage:        *usage,
-		Model:        originalModel,
-		Stream:       reqStream,
-		Duration:     time.Since(startTime),
-		FirstTokenMs: firstTokenMs,
+		RequestID:        resp.Header.Get("x-request-id"),
+		Usage:            *usage,
+		Model:            originalModel,
+		Stream:           reqStream,
+		Duration:         time.Since(startTime),
+		FirstTokenMs:     firstTokenMs,
+		ClientDisconnect: clientDisconnect,
 	}, nil
 }
 
@@ -1162,8 +1219,9 @@ func (s *OpenAIGatewayService) handleErrorResponse(ctx context.Context, resp *ht
 
 // openaiStreamingResult streaming response result
 type openaiStreamingResult struct {
-	usage        *OpenAIUsage
-	firstTokenMs *int
+	usage            *OpenAIUsage
+	firstTokenMs     *int
+	clientDisconnect bool // 客户端是否在流式传输过程中断开
 }
 
 func (s *OpenAIGatewayService) handleStreamingResponse(ctx context.Context, resp *http.Response, c *gin.Context, account *Account, startTime time.Time, originalModel, mappedModel string) (*openaiStreamingResult, error) {
@@ -1272,14 +1330,42 @@ func (s *OpenAIGatewayService) handleStreamingResponse(ctx context.Context, resp
 	}
 
 	needModelReplace := originalModel != mappedModel
+	clientDisconnected := false // 客户端断开标志，断开后继续读取上游以获取完整usage
+	clientGone := clientRequestDone(c)
+	// writeLine 写入客户端；写入失败视为客户端断开，之后只解析 usage 不再写入
+	writeLine := func(line string) {
+		if clientDisconnected {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
+			clientDisconnected = true
+			log.Printf("Client disconnected during streaming, continuing to drain upstream for billing")
+			return
+		}
+		flusher.Flush()
+	}
 
 	for {
 		select {
+		case <-clientGone:
+			clientGone = nil
+			clientDisconnected = true
+
 		case ev, ok := <-events:
 			if !ok {
-				return &openaiStreamingResult{usage: usage, firstTokenMs: firstTokenMs}, nil
+				clientDisconnected = clientDisconnected || isClientDisconnected(c)
+				if clientDisconnected {
+					finishClientDisconnect(c, account.Platform, account.ID, resp, true)
+				}
+				return &openaiStreamingResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: clientDisconnected}, nil
 			}
 			if ev.err != nil {
+				// 客户端已断开（上游随之取消或出错），返回已收集的 usage
+				if clientDisconnected || errors.Is(ev.err, context.Canceled) {
+					log.Printf("Upstream read ended after client disconnect: %v, returning collected usage", ev.err)
+					finishClientDisconnect(c, account.Platform, account.ID, resp, false)
+					return &openaiStreamingResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: true}, nil
+				}
 				if errors.Is(ev.err, bufio.ErrTooLong) {
 					log.Printf("SSE line too long: account=%d max_size=%d error=%v", account.ID, maxLineSize, ev.err)
 					sendErrorEvent("response_too_large")
@@ -1307,11 +1393,7 @@ func (s *OpenAIGatewayService) handleStreamingResponse(ctx context.Context, resp
 				}
 
 				// Forward line
-				if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
-					sendErrorEvent("write_failed")
-					return &openaiStreamingResult{usage: usage, firstTokenMs: firstTokenMs}, err
-				}
-				flusher.Flush()
+				writeLine(line)
 
 				// Record first token time
 				if firstTokenMs == nil && data != "" && data != "[DONE]" {
@@ -1321,11 +1403,7 @@ func (s *OpenAIGatewayService) handleStreamingResponse(ctx context.Context, resp
 				s.parseSSEUsage(data, usage)
 			} else {
 				// Forward non-data lines as-is
-				if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
-					sendErrorEvent("write_failed")
-					return &openaiStreamingResult{usage: usage, firstTokenMs: firstTokenMs}, err
-				}
-				flusher.Flush()
+				writeLine(line)
 			}
 
 		case <-intervalCh:
@@ -1333,6 +1411,11 @@ func (s *OpenAIGatewayService) handleStreamingResponse(ctx context.Context, resp
 			if time.Since(lastRead) < streamInterval {
 				continue
 			}
+			if clientDisconnected {
+				log.Printf("Upstream timeout after client disconnect, returning collected usage")
+				finishClientDisconnect(c, account.Platform, account.ID, resp, false)
+				return &openaiStreamingResult{usage: usage, firstTokenMs: firstTokenMs, clientDisconnect: true}, nil
+			}
 			log.Printf("Stream data interval timeout: account=%d model=%s interval=%s", account.ID, originalModel, streamInterval)
 			// 处理流超时，可能标记账户为临时不可调度或错误状态
 			if s.rateLimitService != nil {
@@ -1342,7 +1425,7 @@ func (s *OpenAIGatewayService) handleStreamingResponse(ctx context.Context, resp
 			return &openaiStreamingResult{usage: usage, firstTokenMs: firstTokenMs}, fmt.Errorf("stream data interval timeout")
 
 		case <-keepaliveCh:
-			if time.Since(lastDataAt) < keepaliveInterval {
+			if clientDisconnected || time.Since(lastDataAt) < keepaliveInterval {
 				continue
 			}
 			if _, err := fmt.Fprint(w, ":\n\n"); err != nil {
@@ -1412,7 +1495,8 @@ func (s *OpenAIGatewayService) parseSSEUsage(data string, usage *OpenAIUsage) {
 	var event struct {
 		Type     string `json:"type"`
 		Response struct {
-			Usage struct {
+			ServiceTier string `json:"service_tier"`
+			Usage       struct {
 				InputTokens       int `json:"input_tokens"`
 				OutputTokens      int `json:"output_tokens"`
 				InputTokenDetails struct {
@@ -1426,6 +1510,7 @@ func (s *OpenAIGatewayService) parseSSEUsage(data string, usage *OpenAIUsage) {
 		usage.InputTokens = event.Response.Usage.InputTokens
 		usage.OutputTokens = event.Response.Usage.OutputTokens
 		usage.CacheReadInputTokens = event.Response.Usage.InputTokenDetails.CachedTokens
+		usage.ServiceTier = event.Response.ServiceTier
 	}
 }
 
@@ -1444,7 +1529,8 @@ func (s *OpenAIGatewayService) handleNonStreamingResponse(ctx context.Context, r
 
 	// Parse usage
 	var response struct {
-		Usage struct {
+		ServiceTier string `json:"service_tier"`
+		Usage       struct {
 			InputTokens       int `json:"input_tokens"`
 			OutputTokens      int `json:"output_tokens"`
 			InputTokenDetails struct {
@@ -1460,6 +1546,7 @@ func (s *OpenAIGatewayService) handleNonStreamingResponse(ctx context.Context, r
 		InputTokens:          response.Usage.InputTokens,
 		OutputTokens:         response.Usage.OutputTokens,
 		CacheReadInputTokens: response.Usage.InputTokenDetails.CachedTokens,
+		ServiceTier:          response.ServiceTier,
 	}
 
 	// Replace model in response if needed
@@ -1493,7 +1580,8 @@ func (s *OpenAIGatewayService) handleOAuthSSEToJSON(resp *http.Response, c *gin.
 	usage := &OpenAIUsage{}
 	if ok {
 		var response struct {
-			Usage struct {
+			ServiceTier string `json:"service_tier"`
+			Usage       struct {
 				InputTokens       int `json:"input_tokens"`
 				OutputTokens      int `json:"output_tokens"`
 				InputTokenDetails struct {
@@ -1505,6 +1593,7 @@ func (s *OpenAIGatewayService) handleOAuthSSEToJSON(resp *http.Response, c *gin.
 			usage.InputTokens = response.Usage.InputTokens
 			usage.OutputTokens = response.Usage.OutputTokens
 			usage.CacheReadInputTokens = response.Usage.InputTokenDetails.CachedTokens
+			usage.ServiceTier = response.ServiceTier
 		}
 		body = finalResponse
 		if originalModel != mappedModel {
@@ -1633,12 +1722,19 @@ type OpenAIRecordUsageInput struct {
 	User         *User
 	Account      *Account
 	Subscription *UserSubscription
-	UserAgent    string // 请求的 User-Agent
-	IPAddress    string // 请求的客户端 IP 地址
+	UserAgent    string              // 请求的 User-Agent
+	IPAddress    string              // 请求的客户端 IP 地址
+	CacheServed  bool                // 响应缓存命中，按分组缓存计费比例计费且不计入账号成本
+	Hedge        HedgeOutcome        // 对冲请求结果（仅胜出请求计费）
+	Reservation  *BillingReservation // 请求前费用预占，扣费写入缓存后结算释放
+	ServingGroup *Group              // 模型降级后实际服务请求的分组，费率倍数与分组定价按该分组计算
 }
 
 // RecordUsage records usage and deducts balance
 func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRecordUsageInput) error {
+	// 未扣费（简易模式、免费、落库去重等）时同样结算释放预占
+	defer input.Reservation.settle()
+
 	result := input.Result
 	apiKey := input.APIKey
 	user := input.User
@@ -1660,16 +1756,21 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 		CacheReadTokens:     result.Usage.CacheReadInputTokens,
 	}
 
-	// Get rate multiplier
+	// Get rate multiplier (the serving group's when the model fell back)
+	billingGroup, billingGroupID := usageBillingGroup(apiKey, input.ServingGroup)
 	multiplier := s.cfg.Default.RateMultiplier
-	if apiKey.GroupID != nil && apiKey.Group != nil {
-		multiplier = apiKey.Group.RateMultiplier
+	if billingGroupID != nil && billingGroup != nil {
+		multiplier = billingGroup.RateMultiplier
 	}
 
-	cost, err := s.billingService.CalculateCost(result.Model, tokens, multiplier)
+	cost, err := s.billingService.CalculateCostWithOptions(result.Model, billingGroupID, tokens, multiplier, CostOptions{ServiceTier: result.Usage.ServiceTier})
 	if err != nil {
 		cost = &CostBreakdown{ActualCost: 0}
 	}
+	var cacheSavedCost float64
+	if input.CacheServed {
+		cacheSavedCost = cost.ApplyCacheServedRate(responseCacheRate(apiKey.Group))
+	}
 
 	// Determine billing type
 	isSubscriptionBilling := subscription != nil && apiKey.Group != nil && apiKey.Group.IsSubscriptionType()
@@ -1681,6 +1782,10 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	// Create usage log
 	durationMs := int(result.Duration.Milliseconds())
 	accountRateMultiplier := account.BillingRateMultiplier()
+	if input.CacheServed {
+		// 缓存命中未消耗上游账号额度
+		accountRateMultiplier = 0
+	}
 	usageLog := &UsageLog{
 		UserID:                user.ID,
 		APIKeyID:              apiKey.ID,
@@ -1703,6 +1808,14 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 		Stream:                result.Stream,
 		DurationMs:            &durationMs,
 		FirstTokenMs:          result.FirstTokenMs,
+		CacheServed:           input.CacheServed,
+		CacheSavedCost:        cacheSavedCost,
+		Hedged:                input.Hedge.Hedged,
+		HedgeBackupWon:        input.Hedge.BackupWon,
+		ClientDisconnected:    result.ClientDisconnect,
+		PricingTier:           cost.PricingTier,
+		ServiceTier:           cost.ServiceTier,
+		CostDetail:            cost.Detail,
 		CreatedAt:             time.Now(),
 	}
 
@@ -1722,11 +1835,16 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	if subscription != nil {
 		usageLog.SubscriptionID = &subscription.ID
 	}
+	if apiKey.IsTeamKey() {
+		usageLog.OrganizationID = apiKey.OrganizationID
+	}
 
 	inserted, err := s.usageLogRepo.Create(ctx, usageLog)
 	if s.cfg != nil && s.cfg.RunMode == config.RunModeSimple {
 		log.Printf("[SIMPLE MODE] Usage recorded (not billed): user=%d, tokens=%d", usageLog.UserID, usageLog.TotalTokens())
-		s.deferredService.ScheduleLastUsedUpdate(account.ID)
+		if !input.CacheServed {
+			s.deferredService.ScheduleLastUsedUpdate(account.ID)
+		}
 		return nil
 	}
 
@@ -1736,17 +1854,35 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	if isSubscriptionBilling {
 		if shouldBill && cost.TotalCost > 0 {
 			_ = s.userSubRepo.IncrementUsage(ctx, subscription.ID, cost.TotalCost)
-			s.billingCacheService.QueueUpdateSubscriptionUsage(user.ID, *apiKey.GroupID, cost.TotalCost)
+			s.billingCacheService.UpdateSubscriptionUsageAndSettle(subscriptionOwnerID(user, subscription), *apiKey.GroupID, cost.TotalCost, input.Reservation)
+		}
+	} else if apiKey.IsTeamKey() {
+		if shouldBill && cost.ActualCost > 0 {
+			s.billingCacheService.ChargeOrganizationAndSettle(ctx, *apiKey.OrganizationID, user.ID, cost.ActualCost, usageLog.ID, input.Reservation)
 		}
 	} else {
 		if shouldBill && cost.ActualCost > 0 {
-			_ = s.userRepo.DeductBalance(ctx, user.ID, cost.ActualCost)
-			s.billingCacheService.QueueDeductBalance(user.ID, cost.ActualCost)
+			_ = s.userRepo.ApplyBalanceTransaction(ctx, newUsageBalanceTransaction(user.ID, cost.ActualCost, usageLog.ID))
+			s.billingCacheService.DeductBalanceAndSettle(user.ID, cost.ActualCost, input.Reservation)
 		}
 	}
 
+	// 累计 API Key 已用额度（按实际扣费口径）
+	if shouldBill {
+		billedCost := cost.ActualCost
+		if isSubscriptionBilling {
+			billedCost = cost.TotalCost
+		}
+		s.billingCacheService.RecordAPIKeyUsage(ctx, apiKey.ID, billedCost)
+	}
+
+	// 回写 API Key TPM 窗口（OpenAI input_tokens 已包含缓存命中部分）
+	s.apiKeyRateLimiter.RecordTokens(ctx, apiKey, result.Usage.InputTokens+result.Usage.OutputTokens)
+
 	// Schedule batch update for account last_used_at
-	s.deferredService.ScheduleLastUsedUpdate(account.ID)
+	if !input.CacheServed {
+		s.deferredService.ScheduleLastUsedUpdate(account.ID)
+	}
 
 	return nil
 }
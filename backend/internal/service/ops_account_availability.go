@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"log"
 	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
 )
 
 // GetAccountAvailabilityStats returns current account availability stats.
@@ -41,6 +44,18 @@ func (s *OpsService) GetAccountAvailabilityStats(ctx context.Context, platformFi
 	now := time.Now()
 	collectedAt := now
 
+	var circuits map[int64]*AccountCircuitStatus
+	if s.accountCircuitBreaker.Enabled() {
+		ids := make([]int64, 0, len(accounts))
+		for _, acc := range accounts {
+			ids = append(ids, acc.ID)
+		}
+		circuits, err = s.accountCircuitBreaker.GetStatuses(ctx, ids)
+		if err != nil {
+			log.Printf("[Ops] get account circuit statuses failed: %v", err)
+		}
+	}
+
 	platform := make(map[string]*PlatformAvailability)
 	group := make(map[int64]*GroupAvailability)
 	account := make(map[int64]*AccountAvailability)
@@ -65,7 +80,10 @@ func (s *OpsService) GetAccountAvailabilityStats(ctx context.Context, platformFi
 			isOverloaded = false
 		}
 
-		isAvailable := acc.Status == StatusActive && acc.Schedulable && !isRateLimited && !isOverloaded && !isTempUnsched
+		circuit := circuits[acc.ID]
+		isCircuitOpen := circuit != nil && circuit.State == AccountCircuitOpen
+
+		isAvailable := acc.Status == StatusActive && acc.Schedulable && !isRateLimited && !isOverloaded && !isTempUnsched && !isCircuitOpen
 
 		if acc.Platform != "" {
 			if _, ok := platform[acc.Platform]; !ok {
@@ -84,6 +102,9 @@ func (s *OpsService) GetAccountAvailabilityStats(ctx context.Context, platformFi
 			if hasError {
 				p.ErrorCount++
 			}
+			if isCircuitOpen {
+				p.CircuitOpen++
+			}
 		}
 
 		for _, grp := range acc.Groups {
@@ -108,6 +129,9 @@ func (s *OpsService) GetAccountAvailabilityStats(ctx context.Context, platformFi
 			if hasError {
 				g.ErrorCount++
 			}
+			if isCircuitOpen {
+				g.CircuitOpen++
+			}
 		}
 
 		displayGroupID := int64(0)
@@ -129,8 +153,10 @@ func (s *OpsService) GetAccountAvailabilityStats(ctx context.Context, platformFi
 			IsRateLimited: isRateLimited,
 			IsOverloaded:  isOverloaded,
 			HasError:      hasError,
+			IsCircuitOpen: isCircuitOpen,
 
 			ErrorMessage: acc.ErrorMessage,
+			Circuit:      circuit,
 		}
 
 		if isRateLimited && acc.RateLimitResetAt != nil {
@@ -192,3 +218,17 @@ func (s *OpsService) GetAccountAvailability(ctx context.Context, platformFilter
 		CollectedAt: collectedAt,
 	}, nil
 }
+
+// ResetAccountCircuit manually closes an account's error-rate circuit breaker and clears its window.
+func (s *OpsService) ResetAccountCircuit(ctx context.Context, accountID int64) error {
+	if s == nil {
+		return errors.New("ops service is nil")
+	}
+	if !s.accountCircuitBreaker.Enabled() {
+		return infraerrors.BadRequest("ACCOUNT_CIRCUIT_BREAKER_DISABLED", "account circuit breaker is disabled")
+	}
+	if _, err := s.accountRepo.GetByID(ctx, accountID); err != nil {
+		return err
+	}
+	return s.accountCircuitBreaker.Reset(ctx, accountID)
+}
@@ -36,6 +36,8 @@ type OpsAlertEvaluatorService struct {
 	opsRepo      OpsRepository
 	emailService *EmailService
 
+	webhookService *WebhookService
+
 	redisClient *redis.Client
 	cfg         *config.Config
 	instanceID  string
@@ -292,6 +294,7 @@ func (s *OpsAlertEvaluatorService) evaluateOnce(interval time.Duration) {
 				if s.maybeSendAlertEmail(ctx, runtimeCfg, rule, created) {
 					emailsSent++
 				}
+				s.emitAlertWebhook(WebhookEventAlertFired, rule, created)
 			}
 			continue
 		}
@@ -303,6 +306,9 @@ func (s *OpsAlertEvaluatorService) evaluateOnce(interval time.Duration) {
 				log.Printf("[OpsAlertEvaluator] resolve event failed (event=%d): %v", activeEvent.ID, err)
 			} else {
 				eventsResolved++
+				activeEvent.Status = OpsAlertStatusResolved
+				activeEvent.ResolvedAt = &resolvedAt
+				s.emitAlertWebhook(WebhookEventAlertResolved, rule, activeEvent)
 			}
 		}
 	}
@@ -600,6 +606,52 @@ func buildOpsAlertDescription(rule *OpsAlertRule, value float64, windowMinutes i
 	)
 }
 
+// SetWebhookService 设置 Webhook 服务（可选依赖），告警触发/恢复时推送事件
+func (s *OpsAlertEvaluatorService) SetWebhookService(webhookService *WebhookService) {
+	s.webhookService = webhookService
+}
+
+func (s *OpsAlertEvaluatorService) emitAlertWebhook(eventType string, rule *OpsAlertRule, event *OpsAlertEvent) {
+	if rule == nil || event == nil {
+		return
+	}
+	data := map[string]any{
+		"rule_id":     rule.ID,
+		"rule_name":   rule.Name,
+		"event_id":    event.ID,
+		"metric_type": rule.MetricType,
+		"fired_at":    event.FiredAt,
+	}
+	if event.MetricValue != nil {
+		data["metric_value"] = *event.MetricValue
+	}
+	if event.ThresholdValue != nil {
+		data["threshold"] = *event.ThresholdValue
+	}
+	for k, v := range event.Dimensions {
+		data[k] = v
+	}
+	title := event.Title
+	severity := opsEmailSeverityForOps(event.Severity)
+	occurredAt := event.FiredAt
+	if eventType == WebhookEventAlertResolved {
+		title = "Resolved: " + title
+		severity = WebhookSeverityInfo
+		if event.ResolvedAt != nil {
+			data["resolved_at"] = *event.ResolvedAt
+			occurredAt = *event.ResolvedAt
+		}
+	}
+	s.webhookService.Emit(WebhookEvent{
+		Type:       eventType,
+		Severity:   severity,
+		Title:      title,
+		Message:    event.Description,
+		Data:       data,
+		OccurredAt: occurredAt,
+	})
+}
+
 func (s *OpsAlertEvaluatorService) maybeSendAlertEmail(ctx context.Context, runtimeCfg *OpsAlertRuntimeSettings, rule *OpsAlertRule, event *OpsAlertEvent) bool {
 	if s == nil || s.emailService == nil || s.opsService == nil || event == nil || rule == nil {
 		return false
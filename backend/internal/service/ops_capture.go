@@ -0,0 +1,405 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/tidwall/gjson"
+)
+
+// 调试抓包目标类型
+const (
+	OpsCaptureTargetAPIKey  = "api_key"
+	OpsCaptureTargetUser    = "user"
+	OpsCaptureTargetAccount = "account"
+)
+
+const (
+	// OpsCaptureMaxBodyBytes 单个请求体/响应体的最大抓取字节数，超出部分截断
+	OpsCaptureMaxBodyBytes = 1 << 20
+	// opsCaptureMaxWindow 单条抓包规则的最长生效时间
+	opsCaptureMaxWindow = 7 * 24 * time.Hour
+	// 抓包数据保留时长（小时）的默认值与上限
+	opsCaptureDefaultRetentionHours = 72
+	opsCaptureMaxRetentionHours     = 30 * 24
+	// opsCaptureDiffMaxLines 响应文本逐行对比的最大行数，超出后整体替换
+	opsCaptureDiffMaxLines = 2000
+	// opsCaptureDiffMaxDepth 请求体字段对比的最大展开深度
+	opsCaptureDiffMaxDepth = 32
+)
+
+// OpsCaptureRule 调试抓包规则：在限定时间窗口内按采样率抓取指定 API Key / 用户 / 账号的完整请求与响应
+type OpsCaptureRule struct {
+	ID         int64   `json:"id"`
+	TargetType string  `json:"target_type"`
+	TargetID   int64   `json:"target_id"`
+	SampleRate float64 `json:"sample_rate"`
+	// RetentionHours 抓包数据的保留时长，到期后自动删除
+	RetentionHours int       `json:"retention_hours"`
+	Note           string    `json:"note"`
+	CreatedBy      *int64    `json:"created_by,omitempty"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	// CaptureCount 查询时统计的已抓取（未过期）条数
+	CaptureCount int64 `json:"capture_count"`
+}
+
+// Active 判断规则在 now 时刻是否仍在抓取窗口内
+func (r *OpsCaptureRule) Active(now time.Time) bool {
+	return r != nil && now.Before(r.ExpiresAt)
+}
+
+func (r *OpsCaptureRule) matches(apiKeyID, userID, accountID int64) bool {
+	switch r.TargetType {
+	case OpsCaptureTargetAPIKey:
+		return apiKeyID > 0 && r.TargetID == apiKeyID
+	case OpsCaptureTargetUser:
+		return userID > 0 && r.TargetID == userID
+	case OpsCaptureTargetAccount:
+		return accountID > 0 && r.TargetID == accountID
+	default:
+		return false
+	}
+}
+
+// CreateOpsCaptureRuleInput 创建抓包规则的参数
+type CreateOpsCaptureRuleInput struct {
+	TargetType string
+	TargetID   int64
+	// SampleRate 采样率 (0,1]，0 表示默认全部抓取
+	SampleRate float64
+	// DurationMinutes 抓取窗口长度
+	DurationMinutes int
+	// RetentionHours 抓包数据保留时长，0 表示默认值
+	RetentionHours int
+	Note           string
+	CreatedBy      *int64
+}
+
+// OpsCapture 一次被抓取的网关请求。
+// RequestBody/ResponseBody/ResponseText 仅在详情中返回；仓储层保存的是加密后的密文。
+type OpsCapture struct {
+	ID            int64     `json:"id"`
+	RuleID        int64     `json:"rule_id"`
+	RequestID     string    `json:"request_id"`
+	APIKeyID      int64     `json:"api_key_id"`
+	UserID        int64     `json:"user_id"`
+	AccountID     *int64    `json:"account_id,omitempty"`
+	GroupID       *int64    `json:"group_id,omitempty"`
+	Platform      string    `json:"platform"`
+	Model         string    `json:"model"`
+	RequestPath   string    `json:"request_path"`
+	Stream        bool      `json:"stream"`
+	StatusCode    int       `json:"status_code"`
+	DurationMs    int64     `json:"duration_ms"`
+	ErrorMessage  string    `json:"error_message,omitempty"`
+	RequestBytes  int       `json:"request_bytes"`
+	ResponseBytes int       `json:"response_bytes"`
+	Truncated     bool      `json:"truncated"`
+	CreatedAt     time.Time `json:"created_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+
+	RequestBody  string `json:"request_body,omitempty"`
+	ResponseBody string `json:"response_body,omitempty"`
+	// ResponseText 从响应（含 SSE 流）中重组出的模型输出文本
+	ResponseText string `json:"response_text,omitempty"`
+}
+
+// OpsCaptureFilter 抓包记录查询条件
+type OpsCaptureFilter struct {
+	RuleID    *int64
+	APIKeyID  *int64
+	UserID    *int64
+	AccountID *int64
+}
+
+// OpsCaptureRecord 网关转发完成后交给抓包服务异步加密落库的原始数据
+type OpsCaptureRecord struct {
+	Rule         *OpsCaptureRule
+	RequestID    string
+	APIKeyID     int64
+	UserID       int64
+	AccountID    int64
+	GroupID      *int64
+	Platform     string
+	Model        string
+	RequestPath  string
+	Stream       bool
+	StatusCode   int
+	Duration     time.Duration
+	ErrorMessage string
+	RequestBody  []byte
+	ResponseBody []byte
+	// ResponseBytes 实际写给客户端的字节数（可能大于抓取的 ResponseBody）
+	ResponseBytes int
+	Truncated     bool
+	CreatedAt     time.Time
+}
+
+// OpsCaptureFieldChange 请求体中一个字段路径的差异
+type OpsCaptureFieldChange struct {
+	Path   string `json:"path"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// OpsCaptureDiffLine 响应文本逐行对比结果：op 为 " "（相同）、"-"（仅 base）、"+"（仅 compare）
+type OpsCaptureDiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// OpsCaptureDiff 两次抓包的对比结果
+type OpsCaptureDiff struct {
+	Base         *OpsCapture             `json:"base"`
+	Compare      *OpsCapture             `json:"compare"`
+	Request      []OpsCaptureFieldChange `json:"request"`
+	ResponseText []OpsCaptureDiffLine    `json:"response_text"`
+}
+
+type OpsCaptureRepository interface {
+	CreateRule(ctx context.Context, rule *OpsCaptureRule) error
+	// ListRules 按 id 倒序返回全部规则（含抓包条数）
+	ListRules(ctx context.Context) ([]OpsCaptureRule, error)
+	ListActiveRules(ctx context.Context, now time.Time) ([]OpsCaptureRule, error)
+	ExpireRule(ctx context.Context, id int64, at time.Time) error
+	DeleteRule(ctx context.Context, id int64) error
+	// DeleteRulesExpiredBefore 删除在 cutoff 之前结束且已无抓包数据的规则
+	DeleteRulesExpiredBefore(ctx context.Context, cutoff time.Time) (int64, error)
+
+	CreateCapture(ctx context.Context, capture *OpsCapture) error
+	// ListCaptures 列表不返回请求/响应内容
+	ListCaptures(ctx context.Context, filter OpsCaptureFilter, params pagination.PaginationParams) ([]OpsCapture, *pagination.PaginationResult, error)
+	GetCapture(ctx context.Context, id int64) (*OpsCapture, error)
+	DeleteExpiredCaptures(ctx context.Context, now time.Time) (int64, error)
+}
+
+// ReassembleOpsCaptureResponse 从非流式 JSON 或 SSE 流中提取模型输出文本。
+// 支持 Anthropic Messages、OpenAI Responses / Chat Completions 与 Gemini 的响应格式。
+func ReassembleOpsCaptureResponse(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+	if body[0] == '{' || body[0] == '[' {
+		var sb strings.Builder
+		appendOpsCaptureText(&sb, gjson.ParseBytes(body), false)
+		return sb.String()
+	}
+
+	var sb strings.Builder
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), OpsCaptureMaxBodyBytes)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "" || data == "[DONE]" || !gjson.Valid(data) {
+			continue
+		}
+		appendOpsCaptureText(&sb, gjson.Parse(data), true)
+	}
+	return sb.String()
+}
+
+func appendOpsCaptureText(sb *strings.Builder, v gjson.Result, stream bool) {
+	if v.IsArray() {
+		// Gemini 非流式 streamGenerateContent 返回数组
+		for _, item := range v.Array() {
+			appendOpsCaptureText(sb, item, stream)
+		}
+		return
+	}
+
+	if stream {
+		switch v.Get("type").String() {
+		case "content_block_delta":
+			sb.WriteString(v.Get("delta.text").String())
+			return
+		case "response.output_text.delta":
+			sb.WriteString(v.Get("delta").String())
+			return
+		}
+		for _, choice := range v.Get("choices").Array() {
+			sb.WriteString(choice.Get("delta.content").String())
+		}
+	} else {
+		// Anthropic Messages
+		for _, block := range v.Get("content").Array() {
+			if block.Get("type").String() == "text" {
+				sb.WriteString(block.Get("text").String())
+			}
+		}
+		// OpenAI Responses
+		for _, item := range v.Get("output").Array() {
+			for _, part := range item.Get("content").Array() {
+				if part.Get("type").String() == "output_text" {
+					sb.WriteString(part.Get("text").String())
+				}
+			}
+		}
+		// OpenAI Chat Completions
+		for _, choice := range v.Get("choices").Array() {
+			sb.WriteString(choice.Get("message.content").String())
+		}
+	}
+
+	// Gemini（流式与非流式结构相同，antigravity 包在 response 下）
+	candidates := v.Get("candidates")
+	if !candidates.Exists() {
+		candidates = v.Get("response.candidates")
+	}
+	for _, candidate := range candidates.Array() {
+		for _, part := range candidate.Get("content.parts").Array() {
+			if part.Get("thought").Bool() {
+				continue
+			}
+			sb.WriteString(part.Get("text").String())
+		}
+	}
+}
+
+// DiffOpsCaptureRequests 按字段路径（数组下标作为路径段）比较两个 JSON 请求体
+func DiffOpsCaptureRequests(base, compare string) []OpsCaptureFieldChange {
+	baseFlat := flattenOpsCaptureJSON(base)
+	compareFlat := flattenOpsCaptureJSON(compare)
+
+	changes := make([]OpsCaptureFieldChange, 0)
+	for path, b := range baseFlat {
+		c, ok := compareFlat[path]
+		if ok && opsCaptureValuesEqual(b, c) {
+			continue
+		}
+		changes = append(changes, OpsCaptureFieldChange{Path: path, Before: b, After: c})
+	}
+	for path, c := range compareFlat {
+		if _, ok := baseFlat[path]; !ok {
+			changes = append(changes, OpsCaptureFieldChange{Path: path, Before: nil, After: c})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func flattenOpsCaptureJSON(raw string) map[string]any {
+	out := make(map[string]any)
+	if strings.TrimSpace(raw) == "" {
+		return out
+	}
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		// 非 JSON（或被截断）的请求体整体比较
+		out[""] = raw
+		return out
+	}
+	flattenOpsCaptureInto(out, "", v, 0)
+	return out
+}
+
+func flattenOpsCaptureInto(out map[string]any, prefix string, v any, depth int) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+	switch t := v.(type) {
+	case map[string]any:
+		if len(t) == 0 || depth >= opsCaptureDiffMaxDepth {
+			out[prefix] = v
+			return
+		}
+		for k, child := range t {
+			flattenOpsCaptureInto(out, join(k), child, depth+1)
+		}
+	case []any:
+		if len(t) == 0 || depth >= opsCaptureDiffMaxDepth {
+			out[prefix] = v
+			return
+		}
+		for i, child := range t {
+			flattenOpsCaptureInto(out, join(strconv.Itoa(i)), child, depth+1)
+		}
+	default:
+		out[prefix] = v
+	}
+}
+
+func opsCaptureValuesEqual(a, b any) bool {
+	ra, errA := json.Marshal(a)
+	rb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ra, rb)
+}
+
+// DiffOpsCaptureText 基于最长公共子序列逐行对比两段文本
+func DiffOpsCaptureText(base, compare string) []OpsCaptureDiffLine {
+	a := splitOpsCaptureLines(base)
+	b := splitOpsCaptureLines(compare)
+	out := make([]OpsCaptureDiffLine, 0, len(a)+len(b))
+
+	if len(a) > opsCaptureDiffMaxLines || len(b) > opsCaptureDiffMaxLines {
+		for _, line := range a {
+			out = append(out, OpsCaptureDiffLine{Op: "-", Text: line})
+		}
+		for _, line := range b {
+			out = append(out, OpsCaptureDiffLine{Op: "+", Text: line})
+		}
+		return out
+	}
+
+	// lcs[i][j] = a[i:] 与 b[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, OpsCaptureDiffLine{Op: " ", Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, OpsCaptureDiffLine{Op: "-", Text: a[i]})
+			i++
+		default:
+			out = append(out, OpsCaptureDiffLine{Op: "+", Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, OpsCaptureDiffLine{Op: "-", Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, OpsCaptureDiffLine{Op: "+", Text: b[j]})
+	}
+	return out
+}
+
+func splitOpsCaptureLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+const (
+	opsCaptureWorkerName      = "ops_capture:refresh"
+	opsCaptureRefreshInterval = 30 * time.Second
+	opsCaptureCleanupInterval = 10 * time.Minute
+	opsCaptureQueueSize       = 256
+	opsCaptureWriteTimeout    = 10 * time.Second
+)
+
+var (
+	ErrOpsCaptureUnavailable   = infraerrors.ServiceUnavailable("OPS_CAPTURE_UNAVAILABLE", "request capture is not available")
+	ErrOpsCaptureRuleNotFound  = infraerrors.NotFound("OPS_CAPTURE_RULE_NOT_FOUND", "capture rule not found")
+	ErrOpsCaptureNotFound      = infraerrors.NotFound("OPS_CAPTURE_NOT_FOUND", "capture not found")
+	ErrOpsCaptureInvalidRule   = infraerrors.BadRequest("OPS_CAPTURE_INVALID_RULE", "invalid capture rule")
+	ErrOpsCaptureUndecryptable = infraerrors.Conflict("OPS_CAPTURE_UNDECRYPTABLE", "capture cannot be decrypted with the current encryption key")
+)
+
+// OpsCaptureService 管理调试抓包规则，并在网关转发时按规则异步加密保存完整的请求与响应。
+// 活跃规则缓存在内存中（定期刷新、变更后立即刷新），未命中规则的请求不做任何额外处理。
+type OpsCaptureService struct {
+	repo        OpsCaptureRepository
+	encryptor   SecretEncryptor
+	timingWheel *TimingWheelService
+	cfg         *config.Config
+
+	rules         atomic.Pointer[[]OpsCaptureRule]
+	queue         chan *OpsCaptureRecord
+	lastCleanupAt time.Time
+
+	workerCtx    context.Context
+	workerCancel context.CancelFunc
+	wg           sync.WaitGroup
+	startOnce    sync.Once
+	stopOnce     sync.Once
+}
+
+// NewOpsCaptureService creates a new OpsCaptureService
+func NewOpsCaptureService(repo OpsCaptureRepository, encryptor SecretEncryptor, timingWheel *TimingWheelService, cfg *config.Config) *OpsCaptureService {
+	workerCtx, workerCancel := context.WithCancel(context.Background())
+	return &OpsCaptureService{
+		repo:         repo,
+		encryptor:    encryptor,
+		timingWheel:  timingWheel,
+		cfg:          cfg,
+		queue:        make(chan *OpsCaptureRecord, opsCaptureQueueSize),
+		workerCtx:    workerCtx,
+		workerCancel: workerCancel,
+	}
+}
+
+// Start 加载活跃规则并启动落库协程与周期刷新/清理任务
+func (s *OpsCaptureService) Start() {
+	if s == nil || s.repo == nil || s.encryptor == nil || s.timingWheel == nil {
+		return
+	}
+	s.startOnce.Do(func() {
+		if s.cfg != nil && !s.cfg.Totp.EncryptionKeyConfigured {
+			log.Printf("[OpsCapture] encryption key is auto-generated; captures become unreadable after restart (set totp.encryption_key to keep them)")
+		}
+		s.refreshRules()
+		s.wg.Add(1)
+		go s.writeLoop()
+		s.timingWheel.ScheduleRecurring(opsCaptureWorkerName, opsCaptureRefreshInterval, s.runOnce)
+	})
+}
+
+// Stop 停止后台任务；队列中未落库的抓包会被丢弃
+func (s *OpsCaptureService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		if s.workerCancel != nil {
+			s.workerCancel()
+		}
+		if s.timingWheel != nil {
+			s.timingWheel.Cancel(opsCaptureWorkerName)
+		}
+		s.wg.Wait()
+	})
+}
+
+// Match 返回命中当前请求的抓包规则（已按采样率抽样），未命中时返回 nil。
+// 在网关热路径上调用，仅读取内存中的规则快照。
+func (s *OpsCaptureService) Match(apiKeyID, userID, accountID int64) *OpsCaptureRule {
+	if s == nil {
+		return nil
+	}
+	rules := s.rules.Load()
+	if rules == nil || len(*rules) == 0 {
+		return nil
+	}
+	now := time.Now()
+	for i := range *rules {
+		rule := &(*rules)[i]
+		if !rule.Active(now) || !rule.matches(apiKeyID, userID, accountID) {
+			continue
+		}
+		if rule.SampleRate < 1 && rand.Float64() >= rule.SampleRate {
+			continue
+		}
+		return rule
+	}
+	return nil
+}
+
+// Record 异步加密保存一次抓包，队列已满时丢弃
+func (s *OpsCaptureService) Record(record *OpsCaptureRecord) {
+	if s == nil || record == nil || record.Rule == nil {
+		return
+	}
+	select {
+	case s.queue <- record:
+	default:
+		log.Printf("[OpsCapture] queue full, dropping capture for rule %d", record.Rule.ID)
+	}
+}
+
+// CreateRule 创建抓包规则并立即生效
+func (s *OpsCaptureService) CreateRule(ctx context.Context, input *CreateOpsCaptureRuleInput) (*OpsCaptureRule, error) {
+	if s == nil || s.repo == nil {
+		return nil, ErrOpsCaptureUnavailable
+	}
+	rule, err := buildOpsCaptureRule(input, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.refreshRules()
+	return rule, nil
+}
+
+func buildOpsCaptureRule(input *CreateOpsCaptureRuleInput, now time.Time) (*OpsCaptureRule, error) {
+	if input == nil {
+		return nil, ErrOpsCaptureInvalidRule
+	}
+	switch input.TargetType {
+	case OpsCaptureTargetAPIKey, OpsCaptureTargetUser, OpsCaptureTargetAccount:
+	default:
+		return nil, ErrOpsCaptureInvalidRule.WithMetadata(map[string]string{"field": "target_type"})
+	}
+	if input.TargetID <= 0 {
+		return nil, ErrOpsCaptureInvalidRule.WithMetadata(map[string]string{"field": "target_id"})
+	}
+	sampleRate := input.SampleRate
+	if sampleRate == 0 {
+		sampleRate = 1
+	}
+	if sampleRate < 0 || sampleRate > 1 {
+		return nil, ErrOpsCaptureInvalidRule.WithMetadata(map[string]string{"field": "sample_rate"})
+	}
+	window := time.Duration(input.DurationMinutes) * time.Minute
+	if window <= 0 || window > opsCaptureMaxWindow {
+		return nil, ErrOpsCaptureInvalidRule.WithMetadata(map[string]string{"field": "duration_minutes"})
+	}
+	retention := input.RetentionHours
+	if retention == 0 {
+		retention = opsCaptureDefaultRetentionHours
+	}
+	if retention < 0 || retention > opsCaptureMaxRetentionHours {
+		return nil, ErrOpsCaptureInvalidRule.WithMetadata(map[string]string{"field": "retention_hours"})
+	}
+	return &OpsCaptureRule{
+		TargetType:     input.TargetType,
+		TargetID:       input.TargetID,
+		SampleRate:     sampleRate,
+		RetentionHours: retention,
+		Note:           strings.TrimSpace(input.Note),
+		CreatedBy:      input.CreatedBy,
+		ExpiresAt:      now.Add(window),
+	}, nil
+}
+
+// ListRules 返回全部抓包规则
+func (s *OpsCaptureService) ListRules(ctx context.Context) ([]OpsCaptureRule, error) {
+	if s == nil || s.repo == nil {
+		return nil, ErrOpsCaptureUnavailable
+	}
+	return s.repo.ListRules(ctx)
+}
+
+// StopRule 立即结束规则的抓取窗口，已抓取的数据按保留时长继续保留
+func (s *OpsCaptureService) StopRule(ctx context.Context, id int64) error {
+	if s == nil || s.repo == nil {
+		return ErrOpsCaptureUnavailable
+	}
+	if err := s.repo.ExpireRule(ctx, id, time.Now()); err != nil {
+		return err
+	}
+	s.refreshRules()
+	return nil
+}
+
+// DeleteRule 删除规则及其全部抓包数据
+func (s *OpsCaptureService) DeleteRule(ctx context.Context, id int64) error {
+	if s == nil || s.repo == nil {
+		return ErrOpsCaptureUnavailable
+	}
+	if err := s.repo.DeleteRule(ctx, id); err != nil {
+		return err
+	}
+	s.refreshRules()
+	return nil
+}
+
+// ListCaptures 分页查询抓包记录（不含请求/响应内容）
+func (s *OpsCaptureService) ListCaptures(ctx context.Context, filter OpsCaptureFilter, params pagination.PaginationParams) ([]OpsCapture, *pagination.PaginationResult, error) {
+	if s == nil || s.repo == nil {
+		return nil, nil, ErrOpsCaptureUnavailable
+	}
+	return s.repo.ListCaptures(ctx, filter, params)
+}
+
+// GetCapture 返回解密后的抓包详情
+func (s *OpsCaptureService) GetCapture(ctx context.Context, id int64) (*OpsCapture, error) {
+	if s == nil || s.repo == nil || s.encryptor == nil {
+		return nil, ErrOpsCaptureUnavailable
+	}
+	capture, err := s.repo.GetCapture(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range []*string{&capture.RequestBody, &capture.ResponseBody, &capture.ResponseText} {
+		if *field == "" {
+			continue
+		}
+		plain, err := s.encryptor.Decrypt(*field)
+		if err != nil {
+			return nil, ErrOpsCaptureUndecryptable
+		}
+		*field = plain
+	}
+	return capture, nil
+}
+
+// DiffCaptures 对比两次抓包的请求体字段与重组后的响应文本
+func (s *OpsCaptureService) DiffCaptures(ctx context.Context, baseID, compareID int64) (*OpsCaptureDiff, error) {
+	base, err := s.GetCapture(ctx, baseID)
+	if err != nil {
+		return nil, err
+	}
+	compare, err := s.GetCapture(ctx, compareID)
+	if err != nil {
+		return nil, err
+	}
+	return &OpsCaptureDiff{
+		Base:         base,
+		Compare:      compare,
+		Request:      DiffOpsCaptureRequests(base.RequestBody, compare.RequestBody),
+		ResponseText: DiffOpsCaptureText(base.ResponseText, compare.ResponseText),
+	}, nil
+}
+
+func (s *OpsCaptureService) refreshRules() {
+	if s.repo == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opsCaptureWriteTimeout)
+	defer cancel()
+	rules, err := s.repo.ListActiveRules(ctx, time.Now())
+	if err != nil {
+		log.Printf("[OpsCapture] refresh rules failed: %v", err)
+		return
+	}
+	s.rules.Store(&rules)
+}
+
+func (s *OpsCaptureService) runOnce() {
+	s.refreshRules()
+
+	if time.Since(s.lastCleanupAt) < opsCaptureCleanupInterval {
+		return
+	}
+	s.lastCleanupAt = time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), opsCaptureWriteTimeout)
+	defer cancel()
+	deleted, err := s.repo.DeleteExpiredCaptures(ctx, time.Now())
+	if err != nil {
+		log.Printf("[OpsCapture] cleanup captures failed: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("[OpsCapture] cleaned up %d expired captures", deleted)
+	}
+	// 规则结束后保留到其抓包数据全部过期
+	cutoff := time.Now().Add(-time.Duration(opsCaptureMaxRetentionHours) * time.Hour)
+	if _, err := s.repo.DeleteRulesExpiredBefore(ctx, cutoff); err != nil {
+		log.Printf("[OpsCapture] cleanup rules failed: %v", err)
+	}
+}
+
+func (s *OpsCaptureService) writeLoop() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.workerCtx.Done():
+			return
+		case record := <-s.queue:
+			capture, err := s.buildCapture(record)
+			if err != nil {
+				log.Printf("[OpsCapture] encrypt capture failed: %v", err)
+				continue
+			}
+			ctx, cancel := context.WithTimeout(s.workerCtx, opsCaptureWriteTimeout)
+			if err := s.repo.CreateCapture(ctx, capture); err != nil {
+				log.Printf("[OpsCapture] save capture failed: rule=%d err=%v", record.Rule.ID, err)
+			}
+			cancel()
+		}
+	}
+}
+
+// buildCapture 重组响应文本并加密请求/响应内容
+func (s *OpsCaptureService) buildCapture(record *OpsCaptureRecord) (*OpsCapture, error) {
+	createdAt := record.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	capture := &OpsCapture{
+		RuleID:        record.Rule.ID,
+		RequestID:     record.RequestID,
+		APIKeyID:      record.APIKeyID,
+		UserID:        record.UserID,
+		GroupID:       record.GroupID,
+		Platform:      record.Platform,
+		Model:         record.Model,
+		RequestPath:   record.RequestPath,
+		Stream:        record.Stream,
+		StatusCode:    record.StatusCode,
+		DurationMs:    record.Duration.Milliseconds(),
+		ErrorMessage:  record.ErrorMessage,
+		RequestBytes:  len(record.RequestBody),
+		ResponseBytes: record.ResponseBytes,
+		Truncated:     record.Truncated,
+		CreatedAt:     createdAt,
+		ExpiresAt:     createdAt.Add(time.Duration(record.Rule.RetentionHours) * time.Hour),
+	}
+	if record.AccountID > 0 {
+		accountID := record.AccountID
+		capture.AccountID = &accountID
+	}
+
+	requestBody := record.RequestBody
+	if len(requestBody) > OpsCaptureMaxBodyBytes {
+		requestBody = requestBody[:OpsCaptureMaxBodyBytes]
+		capture.Truncated = true
+	}
+	plain := map[*string]string{
+		&capture.RequestBody:  string(requestBody),
+		&capture.ResponseBody: string(record.ResponseBody),
+		&capture.ResponseText: ReassembleOpsCaptureResponse(record.ResponseBody),
+	}
+	for field, text := range plain {
+		if text == "" {
+			continue
+		}
+		encrypted, err := s.encryptor.Encrypt(text)
+		if err != nil {
+			return nil, err
+		}
+		*field = encrypted
+	}
+	return capture, nil
+}
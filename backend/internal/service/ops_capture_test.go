@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// captureEncryptorStub 以可逆前缀模拟加密，便于断言落库内容不是明文
+type captureEncryptorStub struct{}
+
+func (captureEncryptorStub) Encrypt(plaintext string) (string, error) { return "enc:" + plaintext, nil }
+
+func (captureEncryptorStub) Decrypt(ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, "enc:") {
+		return "", errors.New("bad ciphertext")
+	}
+	return strings.TrimPrefix(ciphertext, "enc:"), nil
+}
+
+type captureRepoStub struct {
+	OpsCaptureRepository
+	captures map[int64]*OpsCapture
+}
+
+func (r *captureRepoStub) GetCapture(ctx context.Context, id int64) (*OpsCapture, error) {
+	c, ok := r.captures[id]
+	if !ok {
+		return nil, ErrOpsCaptureNotFound
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func TestReassembleOpsCaptureResponse(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"anthropic stream": {
+			body: "event: message_start\ndata: {\"type\":\"message_start\"}\n\n" +
+				"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"thinking_delta\",\"thinking\":\"hmm\"}}\n\n" +
+				"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"Hel\"}}\n\n" +
+				"event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"lo\"}}\n\n",
+			want: "Hello",
+		},
+		"openai responses stream": {
+			body: "data: {\"type\":\"response.output_text.delta\",\"delta\":\"Hi \"}\n\n" +
+				"data: {\"type\":\"response.output_text.delta\",\"delta\":\"there\"}\n\n" +
+				"data: {\"type\":\"response.completed\",\"response\":{}}\n\n",
+			want: "Hi there",
+		},
+		"chat completions stream": {
+			body: "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n" +
+				"data: {\"choices\":[{\"delta\":{\"content\":\"ok\"}}]}\n\ndata: [DONE]\n\n",
+			want: "ok",
+		},
+		"gemini stream": {
+			body: "data: {\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"plan\",\"thought\":true},{\"text\":\"A\"}]}}]}\n\n" +
+				"data: {\"response\":{\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"B\"}]}}]}}\n\n",
+			want: "AB",
+		},
+		"anthropic json": {
+			body: `{"content":[{"type":"thinking","thinking":"x"},{"type":"text","text":"done"}]}`,
+			want: "done",
+		},
+		"openai responses json": {
+			body: `{"output":[{"type":"message","content":[{"type":"output_text","text":"resp"}]}]}`,
+			want: "resp",
+		},
+		"gemini json array": {
+			body: `[{"candidates":[{"content":{"parts":[{"text":"1"}]}}]},{"candidates":[{"content":{"parts":[{"text":"2"}]}}]}]`,
+			want: "12",
+		},
+		"empty": {body: "  ", want: ""},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, ReassembleOpsCaptureResponse([]byte(tt.body)))
+		})
+	}
+}
+
+func TestDiffOpsCaptureRequests(t *testing.T) {
+	base := `{"model":"claude-sonnet-4","max_tokens":1024,"messages":[{"role":"user","content":"hi"}],"stream":true}`
+	compare := `{"model":"claude-sonnet-4","max_tokens":2048,"messages":[{"role":"user","content":"hi"},{"role":"user","content":"again"}]}`
+
+	changes := DiffOpsCaptureRequests(base, compare)
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+	}
+	require.Equal(t, []string{"max_tokens", "messages.1.content", "messages.1.role", "stream"}, paths)
+	require.Equal(t, json.Number("1024"), changes[0].Before)
+	require.Nil(t, changes[1].Before)
+	require.Equal(t, "again", changes[1].After)
+	require.Nil(t, changes[3].After)
+
+	require.Empty(t, DiffOpsCaptureRequests(base, base))
+
+	truncated := DiffOpsCaptureRequests(`{"a":1`, `{"a":1}`)
+	require.Len(t, truncated, 2, "invalid json is compared as a whole")
+}
+
+func TestDiffOpsCaptureText(t *testing.T) {
+	diff := DiffOpsCaptureText("a\nb\nc", "a\nx\nc\nd")
+	require.Equal(t, []OpsCaptureDiffLine{
+		{Op: " ", Text: "a"},
+		{Op: "-", Text: "b"},
+		{Op: "+", Text: "x"},
+		{Op: " ", Text: "c"},
+		{Op: "+", Text: "d"},
+	}, diff)
+
+	require.Empty(t, DiffOpsCaptureText("", ""))
+	require.Equal(t, []OpsCaptureDiffLine{{Op: "+", Text: "new"}}, DiffOpsCaptureText("", "new"))
+
+	long := strings.Repeat("line\n", opsCaptureDiffMaxLines+1)
+	fallback := DiffOpsCaptureText(long, "other")
+	require.Equal(t, "-", fallback[0].Op)
+	require.Equal(t, OpsCaptureDiffLine{Op: "+", Text: "other"}, fallback[len(fallback)-1])
+}
+
+func TestBuildOpsCaptureRule(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rule, err := buildOpsCaptureRule(&CreateOpsCaptureRuleInput{
+		TargetType:      OpsCaptureTargetAPIKey,
+		TargetID:        7,
+		DurationMinutes: 30,
+		Note:            "  garbage output report ",
+	}, now)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, rule.SampleRate)
+	require.Equal(t, opsCaptureDefaultRetentionHours, rule.RetentionHours)
+	require.Equal(t, now.Add(30*time.Minute), rule.ExpiresAt)
+	require.Equal(t, "garbage output report", rule.Note)
+
+	invalid := map[string]CreateOpsCaptureRuleInput{
+		"unknown target":     {TargetType: "group", TargetID: 1, DurationMinutes: 10},
+		"missing target id":  {TargetType: OpsCaptureTargetUser, DurationMinutes: 10},
+		"sample rate > 1":    {TargetType: OpsCaptureTargetUser, TargetID: 1, SampleRate: 1.5, DurationMinutes: 10},
+		"negative sample":    {TargetType: OpsCaptureTargetUser, TargetID: 1, SampleRate: -0.1, DurationMinutes: 10},
+		"missing window":     {TargetType: OpsCaptureTargetUser, TargetID: 1},
+		"window too long":    {TargetType: OpsCaptureTargetUser, TargetID: 1, DurationMinutes: 8 * 24 * 60},
+		"retention too long": {TargetType: OpsCaptureTargetUser, TargetID: 1, DurationMinutes: 10, RetentionHours: opsCaptureMaxRetentionHours + 1},
+	}
+	for name, input := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := buildOpsCaptureRule(&input, now)
+			require.ErrorIs(t, err, ErrOpsCaptureInvalidRule)
+		})
+	}
+}
+
+func TestOpsCaptureService_Match(t *testing.T) {
+	svc := NewOpsCaptureService(nil, nil, nil, nil)
+	require.Nil(t, svc.Match(1, 2, 3), "no rules loaded")
+
+	future := time.Now().Add(time.Hour)
+	rules := []OpsCaptureRule{
+		{ID: 1, TargetType: OpsCaptureTargetAPIKey, TargetID: 10, SampleRate: 1, ExpiresAt: time.Now().Add(-time.Minute)},
+		{ID: 2, TargetType: OpsCaptureTargetAccount, TargetID: 30, SampleRate: 1, ExpiresAt: future},
+		{ID: 3, TargetType: OpsCaptureTargetUser, TargetID: 20, SampleRate: 0.000001, ExpiresAt: future},
+		{ID: 4, TargetType: OpsCaptureTargetAPIKey, TargetID: 10, SampleRate: 1, ExpiresAt: future},
+	}
+	svc.rules.Store(&rules)
+
+	require.Equal(t, int64(2), svc.Match(99, 99, 30).ID)
+	require.Equal(t, int64(4), svc.Match(10, 99, 99).ID, "expired rule 1 is skipped")
+	require.Nil(t, svc.Match(99, 99, 99))
+
+	hits := 0
+	for i := 0; i < 1000; i++ {
+		if svc.Match(99, 20, 99) != nil {
+			hits++
+		}
+	}
+	require.Less(t, hits, 5, "sample rate is applied")
+
+	var nilSvc *OpsCaptureService
+	require.Nil(t, nilSvc.Match(10, 20, 30))
+}
+
+func TestOpsCaptureService_BuildCaptureEncryptsBodies(t *testing.T) {
+	svc := NewOpsCaptureService(nil, captureEncryptorStub{}, nil, nil)
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	record := &OpsCaptureRecord{
+		Rule:          &OpsCaptureRule{ID: 5, RetentionHours: 24},
+		APIKeyID:      1,
+		UserID:        2,
+		AccountID:     3,
+		Stream:        true,
+		StatusCode:    200,
+		Duration:      1500 * time.Millisecond,
+		RequestBody:   []byte(`{"model":"m"}`),
+		ResponseBody:  []byte("data: {\"type\":\"content_block_delta\",\"delta\":{\"text\":\"hi\"}}\n\n"),
+		ResponseBytes: 4096,
+		CreatedAt:     createdAt,
+	}
+
+	capture, err := svc.buildCapture(record)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), capture.RuleID)
+	require.Equal(t, int64(3), *capture.AccountID)
+	require.Equal(t, int64(1500), capture.DurationMs)
+	require.Equal(t, len(record.RequestBody), capture.RequestBytes)
+	require.Equal(t, 4096, capture.ResponseBytes)
+	require.Equal(t, createdAt.Add(24*time.Hour), capture.ExpiresAt)
+	require.Equal(t, `enc:{"model":"m"}`, capture.RequestBody)
+	require.Equal(t, "enc:hi", capture.ResponseText)
+	require.True(t, strings.HasPrefix(capture.ResponseBody, "enc:data:"))
+
+	record.RequestBody = make([]byte, OpsCaptureMaxBodyBytes+1)
+	capture, err = svc.buildCapture(record)
+	require.NoError(t, err)
+	require.True(t, capture.Truncated)
+}
+
+func TestOpsCaptureService_GetAndDiff(t *testing.T) {
+	repo := &captureRepoStub{captures: map[int64]*OpsCapture{
+		1: {ID: 1, RequestBody: `enc:{"temperature":0}`, ResponseText: "enc:hello\nworld"},
+		2: {ID: 2, RequestBody: `enc:{"temperature":1}`, ResponseText: "enc:hello\nthere"},
+		3: {ID: 3, RequestBody: "not-encrypted"},
+	}}
+	svc := NewOpsCaptureService(repo, captureEncryptorStub{}, nil, nil)
+	ctx := context.Background()
+
+	capture, err := svc.GetCapture(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, `{"temperature":0}`, capture.RequestBody)
+	require.Empty(t, capture.ResponseBody)
+
+	_, err = svc.GetCapture(ctx, 3)
+	require.ErrorIs(t, err, ErrOpsCaptureUndecryptable)
+	_, err = svc.GetCapture(ctx, 4)
+	require.ErrorIs(t, err, ErrOpsCaptureNotFound)
+
+	diff, err := svc.DiffCaptures(ctx, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, diff.Request, 1)
+	require.Equal(t, "temperature", diff.Request[0].Path)
+	require.Equal(t, []OpsCaptureDiffLine{
+		{Op: " ", Text: "hello"},
+		{Op: "-", Text: "world"},
+		{Op: "+", Text: "there"},
+	}, diff.ResponseText)
+}
@@ -62,6 +62,15 @@ type OpsDashboardOverview struct {
 	Upstream429Count             int64   `json:"upstream_429_count"`
 	Upstream529Count             int64   `json:"upstream_529_count"`
 
+	// Hedged requests (groups with hedge_delay_ms > 0) among successful requests.
+	HedgeFiredCount     int64 `json:"hedge_fired_count"`
+	HedgeBackupWonCount int64 `json:"hedge_backup_won_count"`
+
+	// Streams abandoned by the client mid-stream and the spend they still incurred (actual_cost).
+	ClientDisconnectedCount     int64   `json:"client_disconnected_count"`
+	ClientDisconnectedCost      float64 `json:"client_disconnected_cost"`
+	ClientDisconnectedCostRatio float64 `json:"client_disconnected_cost_ratio"`
+
 	QPS OpsRateSummary `json:"qps"`
 	TPS OpsRateSummary `json:"tps"`
 
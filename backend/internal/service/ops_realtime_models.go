@@ -44,6 +44,7 @@ type PlatformAvailability struct {
 	AvailableCount int64  `json:"available_count"`
 	RateLimitCount int64  `json:"rate_limit_count"`
 	ErrorCount     int64  `json:"error_count"`
+	CircuitOpen    int64  `json:"circuit_open_count"`
 }
 
 // GroupAvailability aggregates account availability by group.
@@ -55,6 +56,7 @@ type GroupAvailability struct {
 	AvailableCount int64  `json:"available_count"`
 	RateLimitCount int64  `json:"rate_limit_count"`
 	ErrorCount     int64  `json:"error_count"`
+	CircuitOpen    int64  `json:"circuit_open_count"`
 }
 
 // AccountAvailability represents current availability for a single account.
@@ -71,6 +73,7 @@ type AccountAvailability struct {
 	IsRateLimited bool `json:"is_rate_limited"`
 	IsOverloaded  bool `json:"is_overloaded"`
 	HasError      bool `json:"has_error"`
+	IsCircuitOpen bool `json:"is_circuit_open"`
 
 	RateLimitResetAt       *time.Time `json:"rate_limit_reset_at"`
 	RateLimitRemainingSec  *int64     `json:"rate_limit_remaining_sec"`
@@ -78,4 +81,40 @@ type AccountAvailability struct {
 	OverloadRemainingSec   *int64     `json:"overload_remaining_sec"`
 	ErrorMessage           string     `json:"error_message"`
 	TempUnschedulableUntil *time.Time `json:"temp_unschedulable_until,omitempty"`
+
+	// Circuit is the per-account error-rate circuit breaker state (nil when the breaker is disabled).
+	Circuit *AccountCircuitStatus `json:"circuit,omitempty"`
+}
+
+// OpsWaitQueueStats is the fair wait queue view for accounts whose slots are saturated.
+//
+// Wait-time percentiles are computed from the most recent queue waits that ended with a slot (milliseconds).
+type OpsWaitQueueStats struct {
+	Enabled bool `json:"enabled"`
+
+	TotalDepth int64          `json:"total_depth"`
+	WaitTime   OpsPercentiles `json:"wait_time"`
+
+	Group   map[int64]*GroupWaitQueueInfo   `json:"group"`
+	Account map[int64]*AccountWaitQueueInfo `json:"account"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GroupWaitQueueInfo aggregates queue depth and wait time across the accounts of a group.
+type GroupWaitQueueInfo struct {
+	GroupID   int64          `json:"group_id"`
+	GroupName string         `json:"group_name"`
+	Platform  string         `json:"platform"`
+	Depth     int64          `json:"depth"`
+	WaitTime  OpsPercentiles `json:"wait_time"`
+}
+
+// AccountWaitQueueInfo is the fair wait queue state of a single account.
+type AccountWaitQueueInfo struct {
+	AccountID   int64          `json:"account_id"`
+	AccountName string         `json:"account_name"`
+	Platform    string         `json:"platform"`
+	Depth       int64          `json:"depth"`
+	WaitTime    OpsPercentiles `json:"wait_time"`
 }
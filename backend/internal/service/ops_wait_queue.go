@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// GetWaitQueueStats returns fair wait queue depth and wait-time percentiles by group/account.
+// Only accounts with queued requests or recent queue waits are included.
+func (s *OpsService) GetWaitQueueStats(ctx context.Context, platformFilter string, groupIDFilter *int64) (*OpsWaitQueueStats, error) {
+	if err := s.RequireMonitoringEnabled(ctx); err != nil {
+		return nil, err
+	}
+
+	out := &OpsWaitQueueStats{
+		Enabled:   s.concurrencyService.FairQueueEnabled(),
+		Group:     map[int64]*GroupWaitQueueInfo{},
+		Account:   map[int64]*AccountWaitQueueInfo{},
+		Timestamp: time.Now().UTC(),
+	}
+	if !out.Enabled {
+		return out, nil
+	}
+
+	accounts, err := s.listAllAccountsForOps(ctx, platformFilter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(accounts))
+	for _, acc := range accounts {
+		if acc.ID > 0 {
+			ids = append(ids, acc.ID)
+		}
+	}
+	snapshots, err := s.concurrencyService.GetAccountQueueSnapshots(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var allWaits []int64
+	groupWaits := make(map[int64][]int64)
+	for _, acc := range accounts {
+		snap := snapshots[acc.ID]
+		if snap == nil {
+			continue
+		}
+		if groupIDFilter != nil && *groupIDFilter > 0 && !accountInGroup(&acc, *groupIDFilter) {
+			continue
+		}
+		if _, ok := out.Account[acc.ID]; ok {
+			continue
+		}
+		out.Account[acc.ID] = &AccountWaitQueueInfo{
+			AccountID:   acc.ID,
+			AccountName: acc.Name,
+			Platform:    acc.Platform,
+			Depth:       int64(snap.Depth),
+			WaitTime:    waitPercentiles(snap.WaitMs),
+		}
+		out.TotalDepth += int64(snap.Depth)
+		allWaits = append(allWaits, snap.WaitMs...)
+
+		for _, grp := range acc.Groups {
+			if grp == nil || grp.ID <= 0 {
+				continue
+			}
+			if groupIDFilter != nil && *groupIDFilter > 0 && grp.ID != *groupIDFilter {
+				continue
+			}
+			g, ok := out.Group[grp.ID]
+			if !ok {
+				g = &GroupWaitQueueInfo{GroupID: grp.ID, GroupName: grp.Name, Platform: grp.Platform}
+				out.Group[grp.ID] = g
+			}
+			g.Depth += int64(snap.Depth)
+			groupWaits[grp.ID] = append(groupWaits[grp.ID], snap.WaitMs...)
+		}
+	}
+
+	for id, waits := range groupWaits {
+		out.Group[id].WaitTime = waitPercentiles(waits)
+	}
+	out.WaitTime = waitPercentiles(allWaits)
+	return out, nil
+}
+
+func accountInGroup(acc *Account, groupID int64) bool {
+	for _, grp := range acc.Groups {
+		if grp != nil && grp.ID == groupID {
+			return true
+		}
+	}
+	return false
+}
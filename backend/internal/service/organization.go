@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+// 组织成员角色
+const (
+	OrganizationRoleOwner  = "owner"  // 创建者，唯一；可管理共享订阅与删除组织
+	OrganizationRoleAdmin  = "admin"  // 管理成员、邀请与消费上限
+	OrganizationRoleMember = "member" // 使用团队 API Key
+)
+
+// 组织邀请状态
+const (
+	OrganizationInvitationPending  = "pending"
+	OrganizationInvitationAccepted = "accepted"
+	OrganizationInvitationRevoked  = "revoked"
+)
+
+// 组织余额流水类型
+const (
+	OrganizationTxTypeUsage       = "usage"        // 团队 API Key 用量扣费
+	OrganizationTxTypeTransfer    = "transfer"     // 成员从个人余额转入
+	OrganizationTxTypeAdminAdjust = "admin_adjust" // 管理员调整
+)
+
+var (
+	ErrOrganizationNotFound         = infraerrors.NotFound("ORGANIZATION_NOT_FOUND", "organization not found")
+	ErrOrganizationDisabled         = infraerrors.Forbidden("ORGANIZATION_DISABLED", "organization is disabled")
+	ErrOrganizationForbidden        = infraerrors.Forbidden("ORGANIZATION_FORBIDDEN", "insufficient organization role for this operation")
+	ErrOrganizationMemberNotFound   = infraerrors.NotFound("ORGANIZATION_MEMBER_NOT_FOUND", "organization member not found")
+	ErrOrganizationMemberExists     = infraerrors.Conflict("ORGANIZATION_MEMBER_EXISTS", "user is already a member of this organization")
+	ErrOrganizationOwnerImmutable   = infraerrors.BadRequest("ORGANIZATION_OWNER_IMMUTABLE", "the organization owner cannot be removed or demoted")
+	ErrOrganizationInvalidRole      = infraerrors.BadRequest("ORGANIZATION_INVALID_ROLE", "role must be admin or member")
+	ErrOrganizationInvalidName      = infraerrors.BadRequest("ORGANIZATION_INVALID_NAME", "organization name must be 1-100 characters")
+	ErrOrganizationInvalidStatus    = infraerrors.BadRequest("ORGANIZATION_INVALID_STATUS", "status must be active or disabled")
+	ErrOrganizationInvalidLimit     = infraerrors.BadRequest("ORGANIZATION_INVALID_SPEND_LIMIT", "spend limit must be greater than 0")
+	ErrOrganizationInvalidAmount    = infraerrors.BadRequest("ORGANIZATION_INVALID_AMOUNT", "amount must be greater than 0")
+	ErrOrganizationBalanceNotEmpty  = infraerrors.BadRequest("ORGANIZATION_BALANCE_NOT_EMPTY", "organization balance must be zero before deletion")
+	ErrOrganizationSpendLimitHit    = infraerrors.Forbidden("ORGANIZATION_SPEND_LIMIT_EXCEEDED", "monthly spend limit for this organization member exceeded")
+	ErrOrganizationInviteNotFound   = infraerrors.NotFound("ORGANIZATION_INVITATION_NOT_FOUND", "invitation not found")
+	ErrOrganizationInviteInvalid    = infraerrors.BadRequest("ORGANIZATION_INVITATION_INVALID", "invitation is invalid or has expired")
+	ErrOrganizationInviteEmail      = infraerrors.Forbidden("ORGANIZATION_INVITATION_EMAIL_MISMATCH", "invitation was sent to a different email address")
+	ErrOrganizationInviteSendFailed = infraerrors.ServiceUnavailable("ORGANIZATION_INVITATION_SEND_FAILED", "failed to send invitation email")
+)
+
+// Organization 组织（团队）
+//
+// 团队 API Key 在余额模式下扣减组织余额；开启共享订阅时，订阅分组下的团队 Key 使用所有者的订阅额度。
+type Organization struct {
+	ID                  int64
+	Name                string
+	OwnerUserID         int64
+	Balance             float64
+	SharedSubscriptions bool
+	Status              string
+	MemberCount         int64
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+
+	Owner *User
+}
+
+// IsActive 组织是否可用
+func (o *Organization) IsActive() bool {
+	return o.Status == StatusActive
+}
+
+// OrganizationMember 组织成员
+type OrganizationMember struct {
+	ID             int64
+	OrganizationID int64
+	UserID         int64
+	Role           string
+	// SpendLimitUSD 每月可消耗组织余额的上限，nil 表示不限
+	SpendLimitUSD *float64
+	// PeriodSpendUSD 自 PeriodStart 所在月份起已消耗的组织余额
+	PeriodSpendUSD float64
+	PeriodStart    time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	User         *User
+	Organization *Organization
+}
+
+// CanManage 是否可管理成员与邀请
+func (m *OrganizationMember) CanManage() bool {
+	return m.Role == OrganizationRoleOwner || m.Role == OrganizationRoleAdmin
+}
+
+// CurrentPeriodSpend 本月已消耗金额（记录停留在上月时视为 0）
+func (m *OrganizationMember) CurrentPeriodSpend(now time.Time) float64 {
+	if m.PeriodStart.Before(OrganizationSpendPeriodStart(now)) {
+		return 0
+	}
+	return m.PeriodSpendUSD
+}
+
+// IsSpendLimitExceeded 本月消耗是否已达上限
+func (m *OrganizationMember) IsSpendLimitExceeded(now time.Time) bool {
+	return m.SpendLimitUSD != nil && m.CurrentPeriodSpend(now) >= *m.SpendLimitUSD
+}
+
+// OrganizationSpendPeriodStart 成员消费上限按自然月（服务器时区）重置
+func OrganizationSpendPeriodStart(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+}
+
+// OrganizationInvitation 组织邀请（令牌仅通过邮件发送，库中只保存哈希）
+type OrganizationInvitation struct {
+	ID             int64
+	OrganizationID int64
+	Email          string
+	Role           string
+	TokenHash      string
+	InvitedBy      int64
+	Status         string
+	ExpiresAt      time.Time
+	AcceptedBy     *int64
+	AcceptedAt     *time.Time
+	CreatedAt      time.Time
+
+	Organization *Organization
+}
+
+// IsPending 邀请是否仍可接受
+func (i *OrganizationInvitation) IsPending(now time.Time) bool {
+	return i.Status == OrganizationInvitationPending && now.Before(i.ExpiresAt)
+}
+
+// OrganizationBalanceTransaction 组织余额流水（只追加，不修改）
+type OrganizationBalanceTransaction struct {
+	ID             int64
+	OrganizationID int64
+	// UserID 用量扣费的成员或转入余额的成员
+	UserID *int64
+	Type   string
+	// Amount 正数为入账，负数为扣减
+	Amount float64
+	// BalanceAfter 本次变更后的组织余额，由数据库在同一语句中回填
+	BalanceAfter float64
+	UsageLogID   *int64
+	AdminUserID  *int64
+	Notes        string
+	CreatedAt    time.Time
+}
+
+// OrganizationFilter 管理端组织列表筛选
+type OrganizationFilter struct {
+	Search string
+	Status string
+}
+
+// OrganizationUsageSummary 团队用量看板
+type OrganizationUsageSummary struct {
+	StartTime     time.Time                 `json:"start_time"`
+	EndTime       time.Time                 `json:"end_time"`
+	TotalRequests int64                     `json:"total_requests"`
+	TotalTokens   int64                     `json:"total_tokens"`
+	TotalCost     float64                   `json:"total_cost"`
+	ActualCost    float64                   `json:"actual_cost"`
+	Members       []OrganizationMemberUsage `json:"members"`
+	Models        []OrganizationModelUsage  `json:"models"`
+	Daily         []OrganizationDailyUsage  `json:"daily"`
+}
+
+// OrganizationMemberUsage 按成员汇总
+type OrganizationMemberUsage struct {
+	UserID     int64   `json:"user_id"`
+	Email      string  `json:"email"`
+	Username   string  `json:"username"`
+	Requests   int64   `json:"requests"`
+	Tokens     int64   `json:"tokens"`
+	TotalCost  float64 `json:"total_cost"`
+	ActualCost float64 `json:"actual_cost"`
+}
+
+// OrganizationModelUsage 按模型汇总
+type OrganizationModelUsage struct {
+	Model      string  `json:"model"`
+	Requests   int64   `json:"requests"`
+	Tokens     int64   `json:"tokens"`
+	TotalCost  float64 `json:"total_cost"`
+	ActualCost float64 `json:"actual_cost"`
+}
+
+// OrganizationDailyUsage 按天汇总
+type OrganizationDailyUsage struct {
+	Date       string  `json:"date"`
+	Requests   int64   `json:"requests"`
+	Tokens     int64   `json:"tokens"`
+	ActualCost float64 `json:"actual_cost"`
+}
+
+// OrganizationRepository 组织数据访问
+//
+// 处于事务上下文（dbent.NewTxContext）时写操作复用事务连接。
+type OrganizationRepository interface {
+	// Create 创建组织并写入所有者成员
+	Create(ctx context.Context, org *Organization) error
+	GetByID(ctx context.Context, id int64) (*Organization, error)
+	// Update 更新名称、状态与共享订阅开关
+	Update(ctx context.Context, org *Organization) error
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, filter OrganizationFilter, params pagination.PaginationParams) ([]Organization, *pagination.PaginationResult, error)
+
+	GetMember(ctx context.Context, orgID, userID int64) (*OrganizationMember, error)
+	// ListMembers 返回成员（含用户邮箱、用户名）
+	ListMembers(ctx context.Context, orgID int64) ([]OrganizationMember, error)
+	// ListMembershipsByUser 返回用户所在的组织（成员的 Organization 字段已填充）
+	ListMembershipsByUser(ctx context.Context, userID int64) ([]OrganizationMember, error)
+	AddMember(ctx context.Context, member *OrganizationMember) error
+	// UpdateMember 更新角色与消费上限
+	UpdateMember(ctx context.Context, member *OrganizationMember) error
+	RemoveMember(ctx context.Context, orgID, userID int64) error
+
+	CreateInvitation(ctx context.Context, inv *OrganizationInvitation) error
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*OrganizationInvitation, error)
+	ListInvitations(ctx context.Context, orgID int64) ([]OrganizationInvitation, error)
+	// AcceptInvitation 仅在邀请仍为 pending 时标记为已接受
+	AcceptInvitation(ctx context.Context, id, userID int64) error
+	RevokeInvitation(ctx context.Context, orgID, id int64) error
+
+	// ApplyBalanceTransaction 原子地变更组织余额并追加流水，回填 ID、BalanceAfter 与 CreatedAt
+	ApplyBalanceTransaction(ctx context.Context, txn *OrganizationBalanceTransaction) error
+	// ChargeUsage 扣减组织余额、追加用量流水并累计成员本月消费
+	ChargeUsage(ctx context.Context, orgID, userID int64, amount float64, usageLogID int64) error
+	ListBalanceTransactions(ctx context.Context, orgID int64, params pagination.PaginationParams) ([]OrganizationBalanceTransaction, *pagination.PaginationResult, error)
+
+	// ListAPIKeys 返回组织的团队 API Key（含所属成员）
+	ListAPIKeys(ctx context.Context, orgID int64) ([]APIKey, error)
+	// ListAPIKeyKeys 返回团队 API Key 的 key，userID 非空时仅返回该成员的 Key（用于失效认证缓存）
+	ListAPIKeyKeys(ctx context.Context, orgID int64, userID *int64) ([]string, error)
+	// DisableAPIKeys 禁用团队 API Key，userID/keyID 非空时仅禁用对应成员/对应 Key，返回受影响的 key
+	DisableAPIKeys(ctx context.Context, orgID int64, userID, keyID *int64) ([]string, error)
+
+	GetUsageSummary(ctx context.Context, orgID int64, start, end time.Time) (*OrganizationUsageSummary, error)
+}
+
+// IsValidOrganizationMemberRole 邀请与变更角色时可指定的角色（所有者不可通过邀请产生）
+func IsValidOrganizationMemberRole(role string) bool {
+	return role == OrganizationRoleAdmin || role == OrganizationRoleMember
+}
@@ -0,0 +1,696 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+const (
+	organizationNameMaxLen   = 100
+	organizationInviteTTL    = 7 * 24 * time.Hour
+	organizationInvitePath   = "/organizations/invitations/accept"
+	organizationInviteTokenN = 32
+)
+
+// OrganizationService 组织（团队）服务
+type OrganizationService struct {
+	orgRepo              OrganizationRepository
+	userRepo             UserRepository
+	entClient            *dbent.Client
+	emailService         *EmailService
+	settingService       *SettingService
+	billingCacheService  *BillingCacheService
+	authCacheInvalidator APIKeyAuthCacheInvalidator
+}
+
+// NewOrganizationService 创建组织服务实例
+func NewOrganizationService(
+	orgRepo OrganizationRepository,
+	userRepo UserRepository,
+	entClient *dbent.Client,
+	emailService *EmailService,
+	settingService *SettingService,
+	billingCacheService *BillingCacheService,
+	authCacheInvalidator APIKeyAuthCacheInvalidator,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:              orgRepo,
+		userRepo:             userRepo,
+		entClient:            entClient,
+		emailService:         emailService,
+		settingService:       settingService,
+		billingCacheService:  billingCacheService,
+		authCacheInvalidator: authCacheInvalidator,
+	}
+}
+
+// UpdateOrganizationRequest 更新组织设置（nil 表示不修改）
+type UpdateOrganizationRequest struct {
+	Name                *string
+	SharedSubscriptions *bool
+}
+
+// UpdateOrganizationMemberRequest 更新成员（nil 表示不修改）
+type UpdateOrganizationMemberRequest struct {
+	Role *string
+	// SpendLimitUSD 与 ClearSpendLimit 互斥，ClearSpendLimit 为 true 时取消上限
+	SpendLimitUSD   *float64
+	ClearSpendLimit bool
+}
+
+// InviteOrganizationMemberRequest 邀请成员
+type InviteOrganizationMemberRequest struct {
+	Email string
+	Role  string
+	// BaseURL 前端地址，用于拼接邀请链接
+	BaseURL string
+}
+
+// ============================================
+// 组织
+// ============================================
+
+// Create 创建组织，创建者成为所有者
+func (s *OrganizationService) Create(ctx context.Context, userID int64, name string) (*Organization, error) {
+	name, err := normalizeOrganizationName(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	org := &Organization{
+		Name:        name,
+		OwnerUserID: userID,
+		Status:      StatusActive,
+	}
+	if err := s.orgRepo.Create(ctx, org); err != nil {
+		return nil, fmt.Errorf("create organization: %w", err)
+	}
+	return org, nil
+}
+
+// ListMine 返回用户所在的组织及其角色
+func (s *OrganizationService) ListMine(ctx context.Context, userID int64) ([]OrganizationMember, error) {
+	return s.orgRepo.ListMembershipsByUser(ctx, userID)
+}
+
+// Get 返回组织详情与当前用户的成员信息（非成员视为不存在）
+func (s *OrganizationService) Get(ctx context.Context, userID, orgID int64) (*Organization, *OrganizationMember, error) {
+	member, err := s.requireMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return org, member, nil
+}
+
+// Update 更新组织名称（所有者/管理员）与共享订阅开关（仅所有者）
+func (s *OrganizationService) Update(ctx context.Context, userID, orgID int64, req UpdateOrganizationRequest) (*Organization, error) {
+	member, err := s.requireManager(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name, err := normalizeOrganizationName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		org.Name = name
+	}
+	sharedChanged := false
+	if req.SharedSubscriptions != nil && *req.SharedSubscriptions != org.SharedSubscriptions {
+		if member.Role != OrganizationRoleOwner {
+			return nil, ErrOrganizationForbidden
+		}
+		org.SharedSubscriptions = *req.SharedSubscriptions
+		sharedChanged = true
+	}
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, fmt.Errorf("update organization: %w", err)
+	}
+	if sharedChanged {
+		s.invalidateOrganizationKeys(ctx, orgID, nil)
+	}
+	return org, nil
+}
+
+// Delete 删除组织（仅所有者，余额需为 0），团队 API Key 一并禁用
+func (s *OrganizationService) Delete(ctx context.Context, userID, orgID int64) error {
+	member, err := s.requireMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role != OrganizationRoleOwner {
+		return ErrOrganizationForbidden
+	}
+	return s.delete(ctx, orgID, true)
+}
+
+func (s *OrganizationService) delete(ctx context.Context, orgID int64, requireEmpty bool) error {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if requireEmpty && org.Balance > 0 {
+		return ErrOrganizationBalanceNotEmpty
+	}
+	keys, err := s.orgRepo.DisableAPIKeys(ctx, orgID, nil, nil)
+	if err != nil {
+		return fmt.Errorf("disable organization api keys: %w", err)
+	}
+	if err := s.orgRepo.Delete(ctx, orgID); err != nil {
+		return err
+	}
+	s.invalidateKeys(ctx, keys)
+	s.invalidateOrganizationBalance(orgID)
+	return nil
+}
+
+// ============================================
+// 成员
+// ============================================
+
+// ListMembers 返回组织成员（任意成员可查看）
+func (s *OrganizationService) ListMembers(ctx context.Context, userID, orgID int64) ([]OrganizationMember, error) {
+	if _, err := s.requireMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMembers(ctx, orgID)
+}
+
+// UpdateMember 修改成员角色与月度消费上限。
+// 所有者角色不可变更；管理员只能修改普通成员，且不能授予管理员角色；
+// 管理员自身与其他管理员的消费上限只能由所有者修改。
+func (s *OrganizationService) UpdateMember(ctx context.Context, userID, orgID, targetUserID int64, req UpdateOrganizationMemberRequest) (*OrganizationMember, error) {
+	actor, err := s.requireManager(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.orgRepo.GetMember(ctx, orgID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Role != nil && *req.Role != target.Role {
+		if target.Role == OrganizationRoleOwner {
+			return nil, ErrOrganizationOwnerImmutable
+		}
+		if !IsValidOrganizationMemberRole(*req.Role) {
+			return nil, ErrOrganizationInvalidRole
+		}
+	}
+	if actor.Role != OrganizationRoleOwner {
+		if target.Role != OrganizationRoleMember && target.UserID != actor.UserID {
+			return nil, ErrOrganizationForbidden
+		}
+		if req.Role != nil && *req.Role == OrganizationRoleAdmin && target.Role != OrganizationRoleAdmin {
+			return nil, ErrOrganizationForbidden
+		}
+		if (req.ClearSpendLimit || req.SpendLimitUSD != nil) && target.Role != OrganizationRoleMember {
+			return nil, ErrOrganizationForbidden
+		}
+	}
+
+	if req.Role != nil {
+		target.Role = *req.Role
+	}
+	switch {
+	case req.ClearSpendLimit:
+		target.SpendLimitUSD = nil
+	case req.SpendLimitUSD != nil:
+		if *req.SpendLimitUSD <= 0 {
+			return nil, ErrOrganizationInvalidLimit
+		}
+		limit := *req.SpendLimitUSD
+		target.SpendLimitUSD = &limit
+	}
+	if err := s.orgRepo.UpdateMember(ctx, target); err != nil {
+		return nil, fmt.Errorf("update organization member: %w", err)
+	}
+	// 认证快照中带有成员角色与消费上限
+	s.invalidateOrganizationKeys(ctx, orgID, &targetUserID)
+	return target, nil
+}
+
+// RemoveMember 移除成员（所有者/管理员）或主动退出，所有者不可移除；被移除成员的团队 API Key 会被禁用
+func (s *OrganizationService) RemoveMember(ctx context.Context, userID, orgID, targetUserID int64) error {
+	actor, err := s.requireMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	target, err := s.orgRepo.GetMember(ctx, orgID, targetUserID)
+	if err != nil {
+		return err
+	}
+	if target.Role == OrganizationRoleOwner {
+		return ErrOrganizationOwnerImmutable
+	}
+	if target.UserID != actor.UserID {
+		if !actor.CanManage() {
+			return ErrOrganizationForbidden
+		}
+		if actor.Role == OrganizationRoleAdmin && target.Role == OrganizationRoleAdmin {
+			return ErrOrganizationForbidden
+		}
+	}
+
+	if err := s.orgRepo.RemoveMember(ctx, orgID, targetUserID); err != nil {
+		return err
+	}
+	keys, err := s.orgRepo.DisableAPIKeys(ctx, orgID, &targetUserID, nil)
+	if err != nil {
+		log.Printf("disable organization api keys failed: organization=%d user=%d err=%v", orgID, targetUserID, err)
+	}
+	s.invalidateKeys(ctx, keys)
+	return nil
+}
+
+// ============================================
+// 邀请
+// ============================================
+
+// Invite 通过邮件邀请成员（所有者/管理员）。令牌仅出现在邮件中，库中只保存其哈希。
+func (s *OrganizationService) Invite(ctx context.Context, userID, orgID int64, req InviteOrganizationMemberRequest) (*OrganizationInvitation, error) {
+	actor, err := s.requireManager(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.IsActive() {
+		return nil, ErrOrganizationDisabled
+	}
+	role := req.Role
+	if role == "" {
+		role = OrganizationRoleMember
+	}
+	if !IsValidOrganizationMemberRole(role) {
+		return nil, ErrOrganizationInvalidRole
+	}
+	if role == OrganizationRoleAdmin && actor.Role != OrganizationRoleOwner {
+		return nil, ErrOrganizationForbidden
+	}
+	email := strings.TrimSpace(req.Email)
+	if existing, err := s.userRepo.GetByEmail(ctx, email); err == nil {
+		if _, err := s.orgRepo.GetMember(ctx, orgID, existing.ID); err == nil {
+			return nil, ErrOrganizationMemberExists
+		}
+	}
+	if s.emailService == nil {
+		return nil, ErrOrganizationInviteSendFailed
+	}
+
+	token, err := generateOrganizationInviteToken()
+	if err != nil {
+		return nil, fmt.Errorf("generate invitation token: %w", err)
+	}
+	inv := &OrganizationInvitation{
+		OrganizationID: orgID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      hashOrganizationInviteToken(token),
+		InvitedBy:      userID,
+		Status:         OrganizationInvitationPending,
+		ExpiresAt:      time.Now().Add(organizationInviteTTL),
+	}
+	if err := s.orgRepo.CreateInvitation(ctx, inv); err != nil {
+		return nil, fmt.Errorf("create invitation: %w", err)
+	}
+
+	siteName := s.settingService.GetSiteName(ctx)
+	inviteURL := fmt.Sprintf("%s%s?token=%s", strings.TrimRight(req.BaseURL, "/"), organizationInvitePath, url.QueryEscape(token))
+	if err := s.emailService.SendOrganizationInvitationEmail(ctx, email, siteName, org.Name, inviteURL); err != nil {
+		log.Printf("send organization invitation failed: organization=%d email=%s err=%v", orgID, email, err)
+		if revokeErr := s.orgRepo.RevokeInvitation(ctx, orgID, inv.ID); revokeErr != nil {
+			log.Printf("revoke unsent organization invitation failed: id=%d err=%v", inv.ID, revokeErr)
+		}
+		return nil, ErrOrganizationInviteSendFailed
+	}
+	inv.Organization = org
+	return inv, nil
+}
+
+// ListInvitations 返回组织邀请记录（所有者/管理员）
+func (s *OrganizationService) ListInvitations(ctx context.Context, userID, orgID int64) ([]OrganizationInvitation, error) {
+	if _, err := s.requireManager(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListInvitations(ctx, orgID)
+}
+
+// RevokeInvitation 撤销未接受的邀请（所有者/管理员）
+func (s *OrganizationService) RevokeInvitation(ctx context.Context, userID, orgID, invitationID int64) error {
+	if _, err := s.requireManager(ctx, orgID, userID); err != nil {
+		return err
+	}
+	return s.orgRepo.RevokeInvitation(ctx, orgID, invitationID)
+}
+
+// AcceptInvitation 接受邀请，登录用户邮箱需与受邀邮箱一致
+func (s *OrganizationService) AcceptInvitation(ctx context.Context, userID int64, token string) (*OrganizationMember, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, ErrOrganizationInviteNotFound
+	}
+	inv, err := s.orgRepo.GetInvitationByTokenHash(ctx, hashOrganizationInviteToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if !inv.IsPending(time.Now()) {
+		return nil, ErrOrganizationInviteInvalid
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(user.Email), inv.Email) {
+		return nil, ErrOrganizationInviteEmail
+	}
+	org, err := s.orgRepo.GetByID(ctx, inv.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.IsActive() {
+		return nil, ErrOrganizationDisabled
+	}
+
+	member := &OrganizationMember{
+		OrganizationID: inv.OrganizationID,
+		UserID:         userID,
+		Role:           inv.Role,
+	}
+	tx, err := s.entClient.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	txCtx := dbent.NewTxContext(ctx, tx)
+
+	if err := s.orgRepo.AcceptInvitation(txCtx, inv.ID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.orgRepo.AddMember(txCtx, member); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	member.Organization = org
+	member.User = user
+	return member, nil
+}
+
+// ============================================
+// 余额
+// ============================================
+
+// TransferFromPersonalBalance 成员将个人余额转入组织余额（用户流水与组织流水在同一事务内记账）
+func (s *OrganizationService) TransferFromPersonalBalance(ctx context.Context, userID, orgID int64, amount float64) (*OrganizationBalanceTransaction, error) {
+	if amount <= 0 {
+		return nil, ErrOrganizationInvalidAmount
+	}
+	if _, err := s.requireMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.IsActive() {
+		return nil, ErrOrganizationDisabled
+	}
+
+	tx, err := s.entClient.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	txCtx := dbent.NewTxContext(ctx, tx)
+
+	userTxn := &BalanceTransaction{
+		UserID: userID,
+		Type:   BalanceTxTypeOrganizationTransfer,
+		Amount: -amount,
+		Notes:  fmt.Sprintf("organization #%d", orgID),
+	}
+	if err := s.userRepo.ApplyBalanceTransaction(txCtx, userTxn); err != nil {
+		return nil, fmt.Errorf("deduct user balance: %w", err)
+	}
+	// 余额行已被本事务锁定，扣减后为负即说明余额不足
+	if userTxn.BalanceAfter < 0 {
+		return nil, ErrInsufficientBalance
+	}
+	orgTxn := &OrganizationBalanceTransaction{
+		OrganizationID: orgID,
+		UserID:         &userID,
+		Type:           OrganizationTxTypeTransfer,
+		Amount:         amount,
+	}
+	if err := s.orgRepo.ApplyBalanceTransaction(txCtx, orgTxn); err != nil {
+		return nil, fmt.Errorf("credit organization balance: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	if s.authCacheInvalidator != nil {
+		s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, userID)
+	}
+	if s.billingCacheService != nil {
+		go func() {
+			cacheCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = s.billingCacheService.InvalidateUserBalance(cacheCtx, userID)
+		}()
+	}
+	s.invalidateOrganizationBalance(orgID)
+	s.invalidateOrganizationKeys(ctx, orgID, nil)
+	return orgTxn, nil
+}
+
+// ListBalanceTransactions 返回组织余额流水（所有者/管理员）
+func (s *OrganizationService) ListBalanceTransactions(ctx context.Context, userID, orgID int64, params pagination.PaginationParams) ([]OrganizationBalanceTransaction, *pagination.PaginationResult, error) {
+	if _, err := s.requireManager(ctx, orgID, userID); err != nil {
+		return nil, nil, err
+	}
+	return s.orgRepo.ListBalanceTransactions(ctx, orgID, params)
+}
+
+// ============================================
+// 团队 API Key 与用量
+// ============================================
+
+// ListAPIKeys 返回组织的团队 API Key（所有者/管理员）
+func (s *OrganizationService) ListAPIKeys(ctx context.Context, userID, orgID int64) ([]APIKey, error) {
+	if _, err := s.requireManager(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListAPIKeys(ctx, orgID)
+}
+
+// DisableAPIKey 禁用成员的团队 API Key（所有者/管理员）
+func (s *OrganizationService) DisableAPIKey(ctx context.Context, userID, orgID, keyID int64) error {
+	if _, err := s.requireManager(ctx, orgID, userID); err != nil {
+		return err
+	}
+	keys, err := s.orgRepo.DisableAPIKeys(ctx, orgID, nil, &keyID)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return ErrAPIKeyNotFound
+	}
+	s.invalidateKeys(ctx, keys)
+	return nil
+}
+
+// GetUsageSummary 团队用量看板（所有者/管理员）
+func (s *OrganizationService) GetUsageSummary(ctx context.Context, userID, orgID int64, start, end time.Time) (*OrganizationUsageSummary, error) {
+	if _, err := s.requireManager(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.GetUsageSummary(ctx, orgID, start, end)
+}
+
+// ============================================
+// 管理员
+// ============================================
+
+// AdminList 组织列表
+func (s *OrganizationService) AdminList(ctx context.Context, filter OrganizationFilter, params pagination.PaginationParams) ([]Organization, *pagination.PaginationResult, error) {
+	return s.orgRepo.List(ctx, filter, params)
+}
+
+// AdminGet 组织详情
+func (s *OrganizationService) AdminGet(ctx context.Context, orgID int64) (*Organization, error) {
+	return s.orgRepo.GetByID(ctx, orgID)
+}
+
+// AdminListMembers 组织成员
+func (s *OrganizationService) AdminListMembers(ctx context.Context, orgID int64) ([]OrganizationMember, error) {
+	if _, err := s.orgRepo.GetByID(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMembers(ctx, orgID)
+}
+
+// AdminListBalanceTransactions 组织余额流水
+func (s *OrganizationService) AdminListBalanceTransactions(ctx context.Context, orgID int64, params pagination.PaginationParams) ([]OrganizationBalanceTransaction, *pagination.PaginationResult, error) {
+	return s.orgRepo.ListBalanceTransactions(ctx, orgID, params)
+}
+
+// AdminSetStatus 启用/禁用组织，禁用后团队 API Key 立即失效
+func (s *OrganizationService) AdminSetStatus(ctx context.Context, orgID int64, status string) (*Organization, error) {
+	if status != StatusActive && status != StatusDisabled {
+		return nil, ErrOrganizationInvalidStatus
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org.Status == status {
+		return org, nil
+	}
+	org.Status = status
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, fmt.Errorf("update organization: %w", err)
+	}
+	s.invalidateOrganizationKeys(ctx, orgID, nil)
+	return org, nil
+}
+
+// AdminDelete 删除组织（不要求余额为 0）
+func (s *OrganizationService) AdminDelete(ctx context.Context, orgID int64) error {
+	return s.delete(ctx, orgID, false)
+}
+
+// AdminAdjustBalance 管理员调整组织余额，amount 为正数入账、负数扣减
+func (s *OrganizationService) AdminAdjustBalance(ctx context.Context, orgID int64, amount float64, adminID int64, notes string) (*OrganizationBalanceTransaction, error) {
+	if amount == 0 {
+		return nil, ErrOrganizationInvalidAmount
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org.Balance+amount < 0 {
+		return nil, ErrInsufficientBalance
+	}
+	txn := &OrganizationBalanceTransaction{
+		OrganizationID: orgID,
+		Type:           OrganizationTxTypeAdminAdjust,
+		Amount:         amount,
+		Notes:          strings.TrimSpace(notes),
+	}
+	if adminID > 0 {
+		txn.AdminUserID = &adminID
+	}
+	if err := s.orgRepo.ApplyBalanceTransaction(ctx, txn); err != nil {
+		return nil, err
+	}
+	s.invalidateOrganizationBalance(orgID)
+	s.invalidateOrganizationKeys(ctx, orgID, nil)
+	return txn, nil
+}
+
+// ============================================
+// 内部方法
+// ============================================
+
+// requireMember 非成员统一返回组织不存在，不暴露组织是否存在
+func (s *OrganizationService) requireMember(ctx context.Context, orgID, userID int64) (*OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationMemberNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *OrganizationService) requireManager(ctx context.Context, orgID, userID int64) (*OrganizationMember, error) {
+	member, err := s.requireMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.CanManage() {
+		return nil, ErrOrganizationForbidden
+	}
+	return member, nil
+}
+
+// invalidateOrganizationKeys 失效团队 API Key 的认证缓存（快照中含组织状态、余额与成员信息）
+func (s *OrganizationService) invalidateOrganizationKeys(ctx context.Context, orgID int64, userID *int64) {
+	if s.authCacheInvalidator == nil {
+		return
+	}
+	keys, err := s.orgRepo.ListAPIKeyKeys(ctx, orgID, userID)
+	if err != nil {
+		log.Printf("list organization api keys failed: organization=%d err=%v", orgID, err)
+		return
+	}
+	s.invalidateKeys(ctx, keys)
+}
+
+func (s *OrganizationService) invalidateKeys(ctx context.Context, keys []string) {
+	if s.authCacheInvalidator == nil {
+		return
+	}
+	for _, key := range keys {
+		s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, key)
+	}
+}
+
+func (s *OrganizationService) invalidateOrganizationBalance(orgID int64) {
+	if s.billingCacheService == nil {
+		return
+	}
+	go func() {
+		cacheCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = s.billingCacheService.InvalidateOrganizationBalance(cacheCtx, orgID)
+	}()
+}
+
+func normalizeOrganizationName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > organizationNameMaxLen {
+		return "", ErrOrganizationInvalidName
+	}
+	return name, nil
+}
+
+func generateOrganizationInviteToken() (string, error) {
+	b := make([]byte, organizationInviteTokenN)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashOrganizationInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
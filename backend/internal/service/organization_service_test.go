@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type orgRepoStub struct {
+	org         *Organization
+	members     map[int64]*OrganizationMember
+	invitations map[string]*OrganizationInvitation
+	keys        map[int64][]string // userID -> team API keys
+	disabled    []string
+	updated     int
+}
+
+func newOrgRepoStub() *orgRepoStub {
+	return &orgRepoStub{
+		org: &Organization{ID: 1, Name: "acme", OwnerUserID: 1, Balance: 5, Status: StatusActive},
+		members: map[int64]*OrganizationMember{
+			1: {OrganizationID: 1, UserID: 1, Role: OrganizationRoleOwner},
+			2: {OrganizationID: 1, UserID: 2, Role: OrganizationRoleAdmin},
+			3: {OrganizationID: 1, UserID: 3, Role: OrganizationRoleMember},
+			4: {OrganizationID: 1, UserID: 4, Role: OrganizationRoleAdmin},
+		},
+		invitations: map[string]*OrganizationInvitation{},
+		keys:        map[int64][]string{3: {"sk-member"}, 2: {"sk-admin"}},
+	}
+}
+
+func (r *orgRepoStub) Create(ctx context.Context, org *Organization) error {
+	panic("unexpected Create call")
+}
+
+func (r *orgRepoStub) GetByID(ctx context.Context, id int64) (*Organization, error) {
+	if r.org == nil || r.org.ID != id {
+		return nil, ErrOrganizationNotFound
+	}
+	out := *r.org
+	return &out, nil
+}
+
+func (r *orgRepoStub) Update(ctx context.Context, org *Organization) error {
+	r.updated++
+	stored := *org
+	r.org = &stored
+	return nil
+}
+
+func (r *orgRepoStub) Delete(ctx context.Context, id int64) error {
+	r.org = nil
+	return nil
+}
+
+func (r *orgRepoStub) List(ctx context.Context, filter OrganizationFilter, params pagination.PaginationParams) ([]Organization, *pagination.PaginationResult, error) {
+	panic("unexpected List call")
+}
+
+func (r *orgRepoStub) GetMember(ctx context.Context, orgID, userID int64) (*OrganizationMember, error) {
+	m, ok := r.members[userID]
+	if !ok || orgID != 1 {
+		return nil, ErrOrganizationMemberNotFound
+	}
+	out := *m
+	return &out, nil
+}
+
+func (r *orgRepoStub) ListMembers(ctx context.Context, orgID int64) ([]OrganizationMember, error) {
+	out := make([]OrganizationMember, 0, len(r.members))
+	for _, m := range r.members {
+		out = append(out, *m)
+	}
+	return out, nil
+}
+
+func (r *orgRepoStub) ListMembershipsByUser(ctx context.Context, userID int64) ([]OrganizationMember, error) {
+	panic("unexpected ListMembershipsByUser call")
+}
+
+func (r *orgRepoStub) AddMember(ctx context.Context, member *OrganizationMember) error {
+	panic("unexpected AddMember call")
+}
+
+func (r *orgRepoStub) UpdateMember(ctx context.Context, member *OrganizationMember) error {
+	stored := *member
+	r.members[member.UserID] = &stored
+	return nil
+}
+
+func (r *orgRepoStub) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	delete(r.members, userID)
+	return nil
+}
+
+func (r *orgRepoStub) CreateInvitation(ctx context.Context, inv *OrganizationInvitation) error {
+	panic("unexpected CreateInvitation call")
+}
+
+func (r *orgRepoStub) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*OrganizationInvitation, error) {
+	inv, ok := r.invitations[tokenHash]
+	if !ok {
+		return nil, ErrOrganizationInviteNotFound
+	}
+	return inv, nil
+}
+
+func (r *orgRepoStub) ListInvitations(ctx context.Context, orgID int64) ([]OrganizationInvitation, error) {
+	return nil, nil
+}
+
+func (r *orgRepoStub) AcceptInvitation(ctx context.Context, id, userID int64) error {
+	panic("unexpected AcceptInvitation call")
+}
+
+func (r *orgRepoStub) RevokeInvitation(ctx context.Context, orgID, id int64) error {
+	return nil
+}
+
+func (r *orgRepoStub) ApplyBalanceTransaction(ctx context.Context, txn *OrganizationBalanceTransaction) error {
+	r.org.Balance += txn.Amount
+	txn.BalanceAfter = r.org.Balance
+	return nil
+}
+
+func (r *orgRepoStub) ChargeUsage(ctx context.Context, orgID, userID int64, amount float64, usageLogID int64) error {
+	panic("unexpected ChargeUsage call")
+}
+
+func (r *orgRepoStub) ListBalanceTransactions(ctx context.Context, orgID int64, params pagination.PaginationParams) ([]OrganizationBalanceTransaction, *pagination.PaginationResult, error) {
+	return nil, &pagination.PaginationResult{}, nil
+}
+
+func (r *orgRepoStub) ListAPIKeys(ctx context.Context, orgID int64) ([]APIKey, error) {
+	return nil, nil
+}
+
+func (r *orgRepoStub) ListAPIKeyKeys(ctx context.Context, orgID int64, userID *int64) ([]string, error) {
+	if userID != nil {
+		return r.keys[*userID], nil
+	}
+	var out []string
+	for _, keys := range r.keys {
+		out = append(out, keys...)
+	}
+	return out, nil
+}
+
+func (r *orgRepoStub) DisableAPIKeys(ctx context.Context, orgID int64, userID, keyID *int64) ([]string, error) {
+	keys, _ := r.ListAPIKeyKeys(ctx, orgID, userID)
+	r.disabled = append(r.disabled, keys...)
+	return keys, nil
+}
+
+func (r *orgRepoStub) GetUsageSummary(ctx context.Context, orgID int64, start, end time.Time) (*OrganizationUsageSummary, error) {
+	return &OrganizationUsageSummary{}, nil
+}
+
+type orgUserRepoStub struct {
+	UserRepository
+	user *User
+}
+
+func (r *orgUserRepoStub) GetByID(ctx context.Context, id int64) (*User, error) {
+	if r.user == nil || r.user.ID != id {
+		return nil, ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+type orgAuthCacheInvalidatorStub struct {
+	keys []string
+}
+
+func (s *orgAuthCacheInvalidatorStub) InvalidateAuthCacheByKey(ctx context.Context, key string) {
+	s.keys = append(s.keys, key)
+}
+
+func (s *orgAuthCacheInvalidatorStub) InvalidateAuthCacheByUserID(ctx context.Context, userID int64) {
+}
+
+func (s *orgAuthCacheInvalidatorStub) InvalidateAuthCacheByGroupID(ctx context.Context, groupID int64) {
+}
+
+func newOrganizationServiceForTest(repo *orgRepoStub, user *User) (*OrganizationService, *orgAuthCacheInvalidatorStub) {
+	invalidator := &orgAuthCacheInvalidatorStub{}
+	return NewOrganizationService(repo, &orgUserRepoStub{user: user}, nil, nil, nil, nil, invalidator), invalidator
+}
+
+func TestOrganizationService_RolePermissions(t *testing.T) {
+	repo := newOrgRepoStub()
+	svc, invalidator := newOrganizationServiceForTest(repo, nil)
+	ctx := context.Background()
+
+	// 非成员看不到组织
+	_, _, err := svc.Get(ctx, 99, 1)
+	require.ErrorIs(t, err, ErrOrganizationNotFound)
+
+	name := "renamed"
+	_, err = svc.Update(ctx, 3, 1, UpdateOrganizationRequest{Name: &name})
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+	_, _, err = svc.ListBalanceTransactions(ctx, 3, 1, pagination.PaginationParams{})
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+
+	org, err := svc.Update(ctx, 2, 1, UpdateOrganizationRequest{Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, "renamed", org.Name)
+	require.Empty(t, invalidator.keys)
+
+	// 共享订阅仅所有者可修改，修改后失效团队 Key 的认证缓存
+	shared := true
+	_, err = svc.Update(ctx, 2, 1, UpdateOrganizationRequest{SharedSubscriptions: &shared})
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+	org, err = svc.Update(ctx, 1, 1, UpdateOrganizationRequest{SharedSubscriptions: &shared})
+	require.NoError(t, err)
+	require.True(t, org.SharedSubscriptions)
+	require.ElementsMatch(t, []string{"sk-member", "sk-admin"}, invalidator.keys)
+
+	require.ErrorIs(t, svc.Delete(ctx, 2, 1), ErrOrganizationForbidden)
+	require.ErrorIs(t, svc.Delete(ctx, 1, 1), ErrOrganizationBalanceNotEmpty)
+}
+
+func TestOrganizationService_UpdateMember(t *testing.T) {
+	repo := newOrgRepoStub()
+	svc, invalidator := newOrganizationServiceForTest(repo, nil)
+	ctx := context.Background()
+
+	admin, member := OrganizationRoleAdmin, OrganizationRoleMember
+	_, err := svc.UpdateMember(ctx, 1, 1, 1, UpdateOrganizationMemberRequest{Role: &member})
+	require.ErrorIs(t, err, ErrOrganizationOwnerImmutable)
+	_, err = svc.UpdateMember(ctx, 3, 1, 3, UpdateOrganizationMemberRequest{Role: &admin})
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+
+	// 管理员不能提升成员为管理员，也不能修改其他管理员
+	_, err = svc.UpdateMember(ctx, 2, 1, 3, UpdateOrganizationMemberRequest{Role: &admin})
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+	limit := 10.0
+	_, err = svc.UpdateMember(ctx, 2, 1, 4, UpdateOrganizationMemberRequest{SpendLimitUSD: &limit})
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+
+	zero := 0.0
+	_, err = svc.UpdateMember(ctx, 2, 1, 3, UpdateOrganizationMemberRequest{SpendLimitUSD: &zero})
+	require.ErrorIs(t, err, ErrOrganizationInvalidLimit)
+
+	updated, err := svc.UpdateMember(ctx, 2, 1, 3, UpdateOrganizationMemberRequest{SpendLimitUSD: &limit})
+	require.NoError(t, err)
+	require.Equal(t, 10.0, *updated.SpendLimitUSD)
+	require.Equal(t, []string{"sk-member"}, invalidator.keys)
+
+	updated, err = svc.UpdateMember(ctx, 1, 1, 3, UpdateOrganizationMemberRequest{Role: &admin, ClearSpendLimit: true})
+	require.NoError(t, err)
+	require.Equal(t, OrganizationRoleAdmin, updated.Role)
+	require.Nil(t, updated.SpendLimitUSD)
+}
+
+func TestOrganizationService_UpdateMemberAdminSpendLimit(t *testing.T) {
+	repo := newOrgRepoStub()
+	limit := 5.0
+	repo.members[2].SpendLimitUSD = &limit
+	svc, _ := newOrganizationServiceForTest(repo, nil)
+	ctx := context.Background()
+
+	// 管理员不能解除或修改自己的消费上限
+	_, err := svc.UpdateMember(ctx, 2, 1, 2, UpdateOrganizationMemberRequest{ClearSpendLimit: true})
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+	raised := 100.0
+	_, err = svc.UpdateMember(ctx, 2, 1, 2, UpdateOrganizationMemberRequest{SpendLimitUSD: &raised})
+	require.ErrorIs(t, err, ErrOrganizationForbidden)
+	require.Equal(t, 5.0, *repo.members[2].SpendLimitUSD)
+
+	// 所有者可以修改管理员的消费上限
+	updated, err := svc.UpdateMember(ctx, 1, 1, 2, UpdateOrganizationMemberRequest{ClearSpendLimit: true})
+	require.NoError(t, err)
+	require.Nil(t, updated.SpendLimitUSD)
+}
+
+func TestOrganizationService_RemoveMember(t *testing.T) {
+	repo := newOrgRepoStub()
+	svc, invalidator := newOrganizationServiceForTest(repo, nil)
+	ctx := context.Background()
+
+	require.ErrorIs(t, svc.RemoveMember(ctx, 2, 1, 1), ErrOrganizationOwnerImmutable)
+	require.ErrorIs(t, svc.RemoveMember(ctx, 2, 1, 4), ErrOrganizationForbidden)
+	require.ErrorIs(t, svc.RemoveMember(ctx, 4, 1, 1), ErrOrganizationOwnerImmutable)
+
+	// 普通成员可以退出，但不能移除他人
+	require.ErrorIs(t, svc.RemoveMember(ctx, 3, 1, 2), ErrOrganizationForbidden)
+	require.NoError(t, svc.RemoveMember(ctx, 3, 1, 3))
+	require.NotContains(t, repo.members, int64(3))
+	require.Equal(t, []string{"sk-member"}, repo.disabled)
+	require.Equal(t, []string{"sk-member"}, invalidator.keys)
+}
+
+func TestOrganizationService_AcceptInvitationValidation(t *testing.T) {
+	repo := newOrgRepoStub()
+	repo.invitations[hashOrganizationInviteToken("expired")] = &OrganizationInvitation{
+		ID: 1, OrganizationID: 1, Email: "a@example.com", Role: OrganizationRoleMember,
+		Status: OrganizationInvitationPending, ExpiresAt: time.Now().Add(-time.Minute),
+	}
+	repo.invitations[hashOrganizationInviteToken("valid")] = &OrganizationInvitation{
+		ID: 2, OrganizationID: 1, Email: "b@example.com", Role: OrganizationRoleMember,
+		Status: OrganizationInvitationPending, ExpiresAt: time.Now().Add(time.Hour),
+	}
+	svc, _ := newOrganizationServiceForTest(repo, &User{ID: 9, Email: "a@example.com"})
+	ctx := context.Background()
+
+	_, err := svc.AcceptInvitation(ctx, 9, "unknown")
+	require.ErrorIs(t, err, ErrOrganizationInviteNotFound)
+	_, err = svc.AcceptInvitation(ctx, 9, "expired")
+	require.ErrorIs(t, err, ErrOrganizationInviteInvalid)
+	_, err = svc.AcceptInvitation(ctx, 9, "valid")
+	require.ErrorIs(t, err, ErrOrganizationInviteEmail)
+}
+
+func TestOrganizationMember_SpendLimitResetsMonthly(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	limit := 5.0
+	m := &OrganizationMember{
+		SpendLimitUSD:  &limit,
+		PeriodSpendUSD: 6,
+		PeriodStart:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.True(t, m.IsSpendLimitExceeded(now))
+
+	// 上月的消费不计入本月
+	m.PeriodStart = time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	require.Zero(t, m.CurrentPeriodSpend(now))
+	require.False(t, m.IsSpendLimitExceeded(now))
+
+	m.SpendLimitUSD = nil
+	m.PeriodStart = now
+	require.False(t, m.IsSpendLimitExceeded(now))
+}
+
+func TestAPIKey_TeamKeyHelpers(t *testing.T) {
+	orgID := int64(1)
+	personal := &APIKey{UserID: 3, User: &User{ID: 3}}
+	require.False(t, personal.IsTeamKey())
+	require.True(t, personal.IsTeamKeyUsable())
+	require.Equal(t, int64(3), personal.SubscriptionUserID())
+
+	team := &APIKey{UserID: 3, User: &User{ID: 3}, OrganizationID: &orgID}
+	require.False(t, team.IsTeamKeyUsable())
+
+	team.Organization = &Organization{ID: 1, OwnerUserID: 1, Status: StatusActive}
+	team.OrganizationMember = &OrganizationMember{UserID: 3, Role: OrganizationRoleMember}
+	require.True(t, team.IsTeamKeyUsable())
+	require.Equal(t, int64(3), team.SubscriptionUserID())
+
+	team.Organization.SharedSubscriptions = true
+	require.Equal(t, int64(1), team.SubscriptionUserID())
+
+	team.Organization.Status = StatusDisabled
+	require.False(t, team.IsTeamKeyUsable())
+}
+
+func TestBillingCacheService_OrganizationEligibility(t *testing.T) {
+	repo := newOrgRepoStub()
+	svc := NewBillingCacheService(&billingCacheWorkerStub{}, nil, nil, nil, &config.Config{})
+	svc.organizationRepo = repo
+	t.Cleanup(svc.Stop)
+	ctx := context.Background()
+
+	orgID := int64(1)
+	limit := 2.0
+	key := &APIKey{
+		ID: 1, UserID: 3, OrganizationID: &orgID,
+		OrganizationMember: &OrganizationMember{UserID: 3, SpendLimitUSD: &limit},
+	}
+	user := &User{ID: 3}
+
+	repo.members[3].SpendLimitUSD = &limit
+	repo.members[3].PeriodStart = OrganizationSpendPeriodStart(time.Now())
+	require.NoError(t, svc.CheckBillingEligibility(ctx, user, key, nil, nil))
+
+	repo.members[3].PeriodSpendUSD = 2
+	require.ErrorIs(t, svc.CheckBillingEligibility(ctx, user, key, nil, nil), ErrOrganizationSpendLimitHit)
+
+	repo.org.Balance = 0
+	require.ErrorIs(t, svc.CheckBillingEligibility(ctx, user, key, nil, nil), ErrInsufficientBalance)
+}
@@ -35,6 +35,25 @@ type LiteLLMModelPricing struct {
 	Mode                        string  `json:"mode"`
 	SupportsPromptCaching       bool    `json:"supports_prompt_caching"`
 	OutputCostPerImage          float64 `json:"output_cost_per_image"` // 图片生成模型每张图片价格
+
+	// 1 小时缓存创建价格（0 表示未提供）
+	CacheCreationInputTokenCostAbove1hr float64 `json:"cache_creation_input_token_cost_above_1hr"`
+
+	// 长上下文阶梯价格（0 表示未提供）
+	InputCostPerTokenAbove128k           float64 `json:"input_cost_per_token_above_128k_tokens"`
+	OutputCostPerTokenAbove128k          float64 `json:"output_cost_per_token_above_128k_tokens"`
+	InputCostPerTokenAbove200k           float64 `json:"input_cost_per_token_above_200k_tokens"`
+	OutputCostPerTokenAbove200k          float64 `json:"output_cost_per_token_above_200k_tokens"`
+	CacheCreationInputTokenCostAbove200k float64 `json:"cache_creation_input_token_cost_above_200k_tokens"`
+	CacheReadInputTokenCostAbove200k     float64 `json:"cache_read_input_token_cost_above_200k_tokens"`
+
+	// 服务等级价格（0 表示未提供）
+	InputCostPerTokenBatches   float64 `json:"input_cost_per_token_batches"`
+	OutputCostPerTokenBatches  float64 `json:"output_cost_per_token_batches"`
+	InputCostPerTokenPriority  float64 `json:"input_cost_per_token_priority"`
+	OutputCostPerTokenPriority float64 `json:"output_cost_per_token_priority"`
+	InputCostPerTokenFlex      float64 `json:"input_cost_per_token_flex"`
+	OutputCostPerTokenFlex     float64 `json:"output_cost_per_token_flex"`
 }
 
 // PricingRemoteClient 远程价格数据获取接口
@@ -53,6 +72,48 @@ type LiteLLMRawEntry struct {
 	Mode                        string   `json:"mode"`
 	SupportsPromptCaching       bool     `json:"supports_prompt_caching"`
 	OutputCostPerImage          *float64 `json:"output_cost_per_image"`
+
+	CacheCreationInputTokenCostAbove1hr *float64 `json:"cache_creation_input_token_cost_above_1hr,omitempty"`
+
+	InputCostPerTokenAbove128k           *float64 `json:"input_cost_per_token_above_128k_tokens,omitempty"`
+	OutputCostPerTokenAbove128k          *float64 `json:"output_cost_per_token_above_128k_tokens,omitempty"`
+	InputCostPerTokenAbove200k           *float64 `json:"input_cost_per_token_above_200k_tokens,omitempty"`
+	OutputCostPerTokenAbove200k          *float64 `json:"output_cost_per_token_above_200k_tokens,omitempty"`
+	CacheCreationInputTokenCostAbove200k *float64 `json:"cache_creation_input_token_cost_above_200k_tokens,omitempty"`
+	CacheReadInputTokenCostAbove200k     *float64 `json:"cache_read_input_token_cost_above_200k_tokens,omitempty"`
+
+	InputCostPerTokenBatches   *float64 `json:"input_cost_per_token_batches,omitempty"`
+	OutputCostPerTokenBatches  *float64 `json:"output_cost_per_token_batches,omitempty"`
+	InputCostPerTokenPriority  *float64 `json:"input_cost_per_token_priority,omitempty"`
+	OutputCostPerTokenPriority *float64 `json:"output_cost_per_token_priority,omitempty"`
+	InputCostPerTokenFlex      *float64 `json:"input_cost_per_token_flex,omitempty"`
+	OutputCostPerTokenFlex     *float64 `json:"output_cost_per_token_flex,omitempty"`
+}
+
+// applyTierPrices 复制缓存分档、长上下文阶梯与服务等级价格
+func (e *LiteLLMRawEntry) applyTierPrices(p *LiteLLMModelPricing) {
+	for _, f := range []struct {
+		src *float64
+		dst *float64
+	}{
+		{e.CacheCreationInputTokenCostAbove1hr, &p.CacheCreationInputTokenCostAbove1hr},
+		{e.InputCostPerTokenAbove128k, &p.InputCostPerTokenAbove128k},
+		{e.OutputCostPerTokenAbove128k, &p.OutputCostPerTokenAbove128k},
+		{e.InputCostPerTokenAbove200k, &p.InputCostPerTokenAbove200k},
+		{e.OutputCostPerTokenAbove200k, &p.OutputCostPerTokenAbove200k},
+		{e.CacheCreationInputTokenCostAbove200k, &p.CacheCreationInputTokenCostAbove200k},
+		{e.CacheReadInputTokenCostAbove200k, &p.CacheReadInputTokenCostAbove200k},
+		{e.InputCostPerTokenBatches, &p.InputCostPerTokenBatches},
+		{e.OutputCostPerTokenBatches, &p.OutputCostPerTokenBatches},
+		{e.InputCostPerTokenPriority, &p.InputCostPerTokenPriority},
+		{e.OutputCostPerTokenPriority, &p.OutputCostPerTokenPriority},
+		{e.InputCostPerTokenFlex, &p.InputCostPerTokenFlex},
+		{e.OutputCostPerTokenFlex, &p.OutputCostPerTokenFlex},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
 }
 
 // PricingService 动态价格服务
@@ -324,6 +385,7 @@ func (s *PricingService) parsePricingData(body []byte) (map[string]*LiteLLMModel
 		if entry.OutputCostPerImage != nil {
 			pricing.OutputCostPerImage = *entry.OutputCostPerImage
 		}
+		entry.applyTierPrices(pricing)
 
 		result[modelName] = pricing
 	}
@@ -497,6 +559,25 @@ func (s *PricingService) GetModelPricing(modelName string) *LiteLLMModelPricing
 	return nil
 }
 
+// CanonicalModel 返回价格表中与模型精确对应的条目名（不做模糊匹配），不存在时返回空字符串
+func (s *PricingService) CanonicalModel(modelName string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	modelLower := strings.ToLower(strings.TrimSpace(modelName))
+	if modelLower == "" {
+		return ""
+	}
+	for _, candidate := range s.buildModelLookupCandidates(modelLower) {
+		for _, name := range []string{candidate, strings.ReplaceAll(candidate, "-4-5-", "-4.5-")} {
+			if _, ok := s.pricingData[name]; ok {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
 func (s *PricingService) buildModelLookupCandidates(modelLower string) []string {
 	// Prefer canonical model name first (this also improves billing compatibility with "models/xxx").
 	candidates := []string{
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// 响应缓存的请求格式，分别对应 /v1/messages、/v1/responses 与 Gemini generateContent
+const (
+	ResponseCacheKindMessages  = "messages"
+	ResponseCacheKindResponses = "responses"
+	ResponseCacheKindGemini    = "gemini"
+)
+
+// responseCacheIgnoredFields 不影响输出内容的顶层字段，不参与缓存键计算
+var responseCacheIgnoredFields = map[string][]string{
+	ResponseCacheKindMessages:  {"stream", "metadata"},
+	ResponseCacheKindResponses: {"stream", "stream_options", "metadata", "user", "store", "prompt_cache_key"},
+	ResponseCacheKindGemini:    nil,
+}
+
+// CachedResponse 缓存的上游成功响应及其原始用量（命中时按原用量计费）
+type CachedResponse struct {
+	ContentType string      `json:"content_type"`
+	Body        []byte      `json:"body"`
+	Model       string      `json:"model"`
+	AccountID   int64       `json:"account_id"`
+	Usage       ClaudeUsage `json:"usage"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// ResponseCache 响应缓存存储（Redis）
+type ResponseCache interface {
+	// Get 未命中时返回 nil, nil
+	Get(ctx context.Context, key string) (*CachedResponse, error)
+	Set(ctx context.Context, key string, entry *CachedResponse, ttl time.Duration) error
+}
+
+// responseCacheRate 缓存命中计费比例，限制在 [0, 1]
+func responseCacheRate(group *Group) float64 {
+	if group == nil || group.ResponseCacheRate <= 0 {
+		return 0
+	}
+	if group.ResponseCacheRate > 1 {
+		return 1
+	}
+	return group.ResponseCacheRate
+}
+
+// isDeterministicRequest 仅缓存显式 temperature=0 的非流式请求；
+// Responses API 依赖服务端会话状态（previous_response_id）的请求不缓存。
+func isDeterministicRequest(kind string, body []byte) bool {
+	temperaturePath := "temperature"
+	if kind == ResponseCacheKindGemini {
+		temperaturePath = "generationConfig.temperature"
+	}
+	temperature := gjson.GetBytes(body, temperaturePath)
+	if temperature.Type != gjson.Number || temperature.Float() != 0 {
+		return false
+	}
+	if gjson.GetBytes(body, "stream").Bool() {
+		return false
+	}
+	if kind == ResponseCacheKindResponses && gjson.GetBytes(body, "previous_response_id").String() != "" {
+		return false
+	}
+	return true
+}
+
+// buildResponseCacheKey 计算规范化请求体的缓存键。
+// 与 extractCacheableContent 一致地忽略 cache_control（只影响上游 prompt 缓存，不影响输出），
+// 并复用 hashContent；JSON 对象按键排序序列化、数字统一为 float64，字段顺序、空白与 0/0.0 写法不影响命中。
+func buildResponseCacheKey(kind string, groupID int64, model string, body []byte) (string, error) {
+	var root map[string]any
+	if err := json.Unmarshal(body, &root); err != nil {
+		return "", err
+	}
+	for _, field := range responseCacheIgnoredFields[kind] {
+		delete(root, field)
+	}
+	stripCacheControl(root)
+
+	canonical, err := json.Marshal(root)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%d:%s", kind, groupID, hashContent(kind+"|"+model+"|"+string(canonical))), nil
+}
+
+func stripCacheControl(v any) {
+	switch node := v.(type) {
+	case map[string]any:
+		delete(node, "cache_control")
+		for _, child := range node {
+			stripCacheControl(child)
+		}
+	case []any:
+		for _, child := range node {
+			stripCacheControl(child)
+		}
+	}
+}
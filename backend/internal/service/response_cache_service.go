@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+// ResponseCacheService 确定性非流式请求的响应缓存（按分组开启）。
+// 同一分组内请求体规范化后相同即共享缓存，命中时不请求上游，按分组配置的比例计费。
+type ResponseCacheService struct {
+	cache ResponseCache
+	cfg   config.GatewayResponseCacheConfig
+}
+
+// NewResponseCacheService creates a new ResponseCacheService
+func NewResponseCacheService(cache ResponseCache, cfg *config.Config) *ResponseCacheService {
+	s := &ResponseCacheService{cache: cache}
+	if cfg != nil {
+		s.cfg = cfg.Gateway.ResponseCache
+	}
+	return s
+}
+
+// Key 返回请求的缓存键；分组未启用、请求不可缓存或解析失败时返回空串
+func (s *ResponseCacheService) Key(group *Group, kind, model string, body []byte) string {
+	if s == nil || s.cache == nil || group == nil || !group.ResponseCacheEnabled {
+		return ""
+	}
+	if !isDeterministicRequest(kind, body) {
+		recordResponseCacheLookup(kind, "skip")
+		return ""
+	}
+	key, err := buildResponseCacheKey(kind, group.ID, model, body)
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
+// MaxEntryBytes 单条缓存响应体的字节上限
+func (s *ResponseCacheService) MaxEntryBytes() int {
+	if s == nil {
+		return 0
+	}
+	return s.cfg.MaxEntryBytes
+}
+
+// Get 查询缓存，读取失败按未命中处理
+func (s *ResponseCacheService) Get(ctx context.Context, kind, key string) *CachedResponse {
+	if s == nil || s.cache == nil || key == "" {
+		return nil
+	}
+	entry, err := s.cache.Get(ctx, key)
+	if err != nil {
+		log.Printf("[ResponseCache] Get %s failed: %v", key, err)
+		entry = nil
+	}
+	if entry == nil {
+		recordResponseCacheLookup(kind, "miss")
+		return nil
+	}
+	recordResponseCacheLookup(kind, "hit")
+	return entry
+}
+
+// Put 写入缓存，超过大小上限的响应直接丢弃
+func (s *ResponseCacheService) Put(ctx context.Context, group *Group, key string, entry *CachedResponse) {
+	if s == nil || s.cache == nil || key == "" || entry == nil || len(entry.Body) == 0 {
+		return
+	}
+	if len(entry.Body) > s.cfg.MaxEntryBytes {
+		return
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	if err := s.cache.Set(ctx, key, entry, s.ttlFor(group)); err != nil {
+		log.Printf("[ResponseCache] Set %s failed: %v", key, err)
+	}
+}
+
+// ttlFor 分组未配置时使用默认有效期，且不超过全局上限
+func (s *ResponseCacheService) ttlFor(group *Group) time.Duration {
+	seconds := s.cfg.DefaultTTLSeconds
+	if group != nil && group.ResponseCacheTTLSeconds != nil && *group.ResponseCacheTTLSeconds > 0 {
+		seconds = *group.ResponseCacheTTLSeconds
+	}
+	if s.cfg.MaxTTLSeconds > 0 && seconds > s.cfg.MaxTTLSeconds {
+		seconds = s.cfg.MaxTTLSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
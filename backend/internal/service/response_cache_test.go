@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type responseCacheStub struct {
+	entries map[string]*CachedResponse
+	ttl     time.Duration
+}
+
+func (s *responseCacheStub) Get(ctx context.Context, key string) (*CachedResponse, error) {
+	return s.entries[key], nil
+}
+
+func (s *responseCacheStub) Set(ctx context.Context, key string, entry *CachedResponse, ttl time.Duration) error {
+	s.entries[key] = entry
+	s.ttl = ttl
+	return nil
+}
+
+func newTestResponseCacheService() (*ResponseCacheService, *responseCacheStub) {
+	stub := &responseCacheStub{entries: map[string]*CachedResponse{}}
+	cfg := &config.Config{}
+	cfg.Gateway.ResponseCache = config.GatewayResponseCacheConfig{
+		MaxEntryBytes:     16,
+		DefaultTTLSeconds: 60,
+		MaxTTLSeconds:     600,
+	}
+	return NewResponseCacheService(stub, cfg), stub
+}
+
+func TestBuildResponseCacheKey_Canonicalization(t *testing.T) {
+	a := []byte(`{"model":"claude","temperature":0,"messages":[{"role":"user","content":[{"type":"text","text":"hi","cache_control":{"type":"ephemeral"}}]}],"metadata":{"user_id":"u1"}}`)
+	b := []byte(`{"messages":[{"content":[{"text":"hi","type":"text"}],"role":"user"}],"temperature":0.0,"model":"claude","stream":false}`)
+
+	keyA, err := buildResponseCacheKey(ResponseCacheKindMessages, 1, "claude", a)
+	require.NoError(t, err)
+	keyB, err := buildResponseCacheKey(ResponseCacheKindMessages, 1, "claude", b)
+	require.NoError(t, err)
+	require.Equal(t, keyA, keyB)
+
+	otherGroup, err := buildResponseCacheKey(ResponseCacheKindMessages, 2, "claude", a)
+	require.NoError(t, err)
+	require.NotEqual(t, keyA, otherGroup)
+
+	changed, err := buildResponseCacheKey(ResponseCacheKindMessages, 1, "claude",
+		[]byte(`{"model":"claude","temperature":0,"messages":[{"role":"user","content":"bye"}]}`))
+	require.NoError(t, err)
+	require.NotEqual(t, keyA, changed)
+
+	_, err = buildResponseCacheKey(ResponseCacheKindMessages, 1, "claude", []byte(`not json`))
+	require.Error(t, err)
+}
+
+func TestIsDeterministicRequest(t *testing.T) {
+	require.True(t, isDeterministicRequest(ResponseCacheKindMessages, []byte(`{"temperature":0}`)))
+	require.False(t, isDeterministicRequest(ResponseCacheKindMessages, []byte(`{}`)))
+	require.False(t, isDeterministicRequest(ResponseCacheKindMessages, []byte(`{"temperature":0.2}`)))
+	require.False(t, isDeterministicRequest(ResponseCacheKindMessages, []byte(`{"temperature":0,"stream":true}`)))
+	require.False(t, isDeterministicRequest(ResponseCacheKindResponses, []byte(`{"temperature":0,"previous_response_id":"resp_1"}`)))
+	require.True(t, isDeterministicRequest(ResponseCacheKindGemini, []byte(`{"generationConfig":{"temperature":0}}`)))
+	require.False(t, isDeterministicRequest(ResponseCacheKindGemini, []byte(`{"temperature":0}`)))
+}
+
+func TestResponseCacheService_KeyRequiresGroupOptIn(t *testing.T) {
+	svc, _ := newTestResponseCacheService()
+	body := []byte(`{"model":"m","temperature":0,"messages":[]}`)
+
+	require.Empty(t, svc.Key(nil, ResponseCacheKindMessages, "m", body))
+	require.Empty(t, svc.Key(&Group{ID: 1}, ResponseCacheKindMessages, "m", body))
+	require.NotEmpty(t, svc.Key(&Group{ID: 1, ResponseCacheEnabled: true}, ResponseCacheKindMessages, "m", body))
+
+	var nilSvc *ResponseCacheService
+	require.Empty(t, nilSvc.Key(&Group{ID: 1, ResponseCacheEnabled: true}, ResponseCacheKindMessages, "m", body))
+}
+
+func TestResponseCacheService_PutAndGet(t *testing.T) {
+	svc, stub := newTestResponseCacheService()
+	ttl := 3600
+	group := &Group{ID: 1, ResponseCacheEnabled: true, ResponseCacheTTLSeconds: &ttl}
+
+	svc.Put(context.Background(), group, "k", &CachedResponse{Body: []byte(`{"ok":true}`)})
+	require.Equal(t, 600*time.Second, stub.ttl, "group TTL is capped by max_ttl_seconds")
+
+	entry := svc.Get(context.Background(), ResponseCacheKindMessages, "k")
+	require.NotNil(t, entry)
+	require.False(t, entry.CreatedAt.IsZero())
+
+	svc.Put(context.Background(), &Group{ID: 1}, "big", &CachedResponse{Body: make([]byte, 17)})
+	require.NotContains(t, stub.entries, "big")
+	require.Nil(t, svc.Get(context.Background(), ResponseCacheKindMessages, "missing"))
+
+	svc.Put(context.Background(), &Group{ID: 1}, "default", &CachedResponse{Body: []byte(`{}`)})
+	require.Equal(t, 60*time.Second, stub.ttl)
+}
+
+func TestCostBreakdown_ApplyCacheServedRate(t *testing.T) {
+	cost := &CostBreakdown{InputCost: 1, OutputCost: 3, TotalCost: 4, ActualCost: 8}
+	saved := cost.ApplyCacheServedRate(0.25)
+	require.InDelta(t, 6, saved, 1e-9)
+	require.InDelta(t, 2, cost.ActualCost, 1e-9)
+	require.InDelta(t, 1, cost.TotalCost, 1e-9)
+
+	free := &CostBreakdown{TotalCost: 4, ActualCost: 8}
+	require.InDelta(t, 8, free.ApplyCacheServedRate(0), 1e-9)
+	require.Zero(t, free.ActualCost)
+
+	full := &CostBreakdown{TotalCost: 4, ActualCost: 8}
+	require.Zero(t, full.ApplyCacheServedRate(1))
+	require.InDelta(t, 8, full.ActualCost, 1e-9)
+
+	require.Equal(t, 0.0, responseCacheRate(nil))
+	require.Equal(t, 1.0, responseCacheRate(&Group{ResponseCacheRate: 2}))
+}
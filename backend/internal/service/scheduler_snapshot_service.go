@@ -223,6 +223,7 @@ func (s *SchedulerSnapshotService) pollOutbox() {
 		return
 	}
 	if len(events) == 0 {
+		schedulerOutboxLagSeconds.WithLabelValues().Set(0)
 		return
 	}
 
@@ -475,6 +476,7 @@ func (s *SchedulerSnapshotService) checkOutboxLag(ctx context.Context, oldest Sc
 	}
 
 	lag := time.Since(oldest.CreatedAt)
+	schedulerOutboxLagSeconds.WithLabelValues().Set(lag.Seconds())
 	if lagSeconds := int(lag.Seconds()); lagSeconds >= s.cfg.Gateway.Scheduling.OutboxLagWarnSeconds && s.cfg.Gateway.Scheduling.OutboxLagWarnSeconds > 0 {
 		log.Printf("[Scheduler] outbox lag warning: %ds", lagSeconds)
 	}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 分组调度策略：决定负载感知选择（Layer 2）中同优先级候选账号的尝试顺序。
+// 空字符串表示默认规则：优先级 > 负载率 > 最后使用时间。
+const (
+	SchedulingStrategyDefault            = ""
+	SchedulingStrategyLowestCost         = "lowest_cost"
+	SchedulingStrategyLowestLatency      = "lowest_latency"
+	SchedulingStrategyLeastOutstanding   = "least_outstanding"
+	SchedulingStrategyWeightedRoundRobin = "weighted_round_robin"
+)
+
+const (
+	// SchedulingWeightMax 加权轮询单账号权重上限
+	SchedulingWeightMax = 1000
+
+	// schedulingLatencyWindow 统计首字延迟中位数的时间窗口
+	schedulingLatencyWindow = 30 * time.Minute
+	// schedulingLatencyTTL 首字延迟缓存有效期，过期后异步刷新
+	schedulingLatencyTTL = time.Minute
+	// schedulingLatencyQueryTimeout 异步刷新延迟统计的超时时间
+	schedulingLatencyQueryTimeout = 5 * time.Second
+)
+
+// IsValidSchedulingStrategy 检查调度策略名是否受支持
+func IsValidSchedulingStrategy(name string) bool {
+	switch name {
+	case SchedulingStrategyDefault,
+		SchedulingStrategyLowestCost,
+		SchedulingStrategyLowestLatency,
+		SchedulingStrategyLeastOutstanding,
+		SchedulingStrategyWeightedRoundRobin:
+		return true
+	}
+	return false
+}
+
+// validateSchedulingConfig 校验分组调度策略与加权轮询权重：账号 ID 为正数，权重在 [0, SchedulingWeightMax] 内
+func validateSchedulingConfig(strategy string, weights map[int64]int) error {
+	if !IsValidSchedulingStrategy(strategy) {
+		return fmt.Errorf("invalid scheduling_strategy: %s", strategy)
+	}
+	for accountID, weight := range weights {
+		if accountID <= 0 {
+			return fmt.Errorf("scheduling_weights: invalid account id %d", accountID)
+		}
+		if weight < 0 || weight > SchedulingWeightMax {
+			return fmt.Errorf("scheduling_weights: weight for account %d must be between 0 and %d", accountID, SchedulingWeightMax)
+		}
+	}
+	return nil
+}
+
+// accountWithLoad 负载感知选择中的候选账号及其实时负载
+type accountWithLoad struct {
+	account  *Account
+	loadInfo *AccountLoadInfo
+}
+
+// SchedulingStrategy 可插拔的账号调度策略。
+// Rank 返回与 candidates 一一对应的得分，得分越低越优先；
+// 排序时仍以账号优先级为第一关键字，得分相同保持默认顺序。
+type SchedulingStrategy interface {
+	Name() string
+	Rank(ctx context.Context, group *Group, candidates []accountWithLoad) []float64
+}
+
+// SchedulingStrategyService 按分组配置选择调度策略并对候选账号排序
+type SchedulingStrategyService struct {
+	strategies map[string]SchedulingStrategy
+}
+
+// NewSchedulingStrategyService 创建调度策略服务并注册内置策略
+func NewSchedulingStrategyService(usageLogRepo UsageLogRepository, sessionLimitCache SessionLimitCache) *SchedulingStrategyService {
+	s := &SchedulingStrategyService{strategies: make(map[string]SchedulingStrategy)}
+	s.Register(&lowestCostStrategy{sessionLimitCache: sessionLimitCache})
+	s.Register(newLowestLatencyStrategy(usageLogRepo))
+	s.Register(leastOutstandingStrategy{})
+	s.Register(newWeightedRoundRobinStrategy())
+	return s
+}
+
+// Register 注册（或替换）同名调度策略
+func (s *SchedulingStrategyService) Register(strategy SchedulingStrategy) {
+	s.strategies[strategy.Name()] = strategy
+}
+
+// strategyFor 返回分组配置的调度策略；未配置或未知策略返回 nil（沿用默认排序）
+func (s *SchedulingStrategyService) strategyFor(group *Group) SchedulingStrategy {
+	if s == nil || group == nil || group.SchedulingStrategy == SchedulingStrategyDefault {
+		return nil
+	}
+	return s.strategies[group.SchedulingStrategy]
+}
+
+// Order 按分组调度策略对已按默认规则排好序的候选账号重新排序
+func (s *SchedulingStrategyService) Order(ctx context.Context, group *Group, candidates []accountWithLoad) {
+	strategy := s.strategyFor(group)
+	if strategy == nil || len(candidates) <= 1 {
+		return
+	}
+	scores := strategy.Rank(ctx, group, candidates)
+	if len(scores) != len(candidates) {
+		return
+	}
+	indexes := make([]int, len(candidates))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := candidates[indexes[i]], candidates[indexes[j]]
+		if a.account.Priority != b.account.Priority {
+			return a.account.Priority < b.account.Priority
+		}
+		return scores[indexes[i]] < scores[indexes[j]]
+	})
+	ordered := make([]accountWithLoad, len(candidates))
+	for i, idx := range indexes {
+		ordered[i] = candidates[idx]
+	}
+	copy(candidates, ordered)
+}
+
+// lowestCostStrategy 有效成本最低优先：账号计费倍率 ×（1 + 窗口费用占用比例）。
+// 窗口费用只读缓存，未命中视为未占用，不在请求路径上查库。
+type lowestCostStrategy struct {
+	sessionLimitCache SessionLimitCache
+}
+
+func (*lowestCostStrategy) Name() string { return SchedulingStrategyLowestCost }
+
+func (st *lowestCostStrategy) Rank(ctx context.Context, _ *Group, candidates []accountWithLoad) []float64 {
+	var windowCosts map[int64]float64
+	if st.sessionLimitCache != nil {
+		ids := make([]int64, 0, len(candidates))
+		for _, c := range candidates {
+			if c.account.GetWindowCostLimit() > 0 {
+				ids = append(ids, c.account.ID)
+			}
+		}
+		if len(ids) > 0 {
+			windowCosts, _ = st.sessionLimitCache.GetWindowCostBatch(ctx, ids)
+		}
+	}
+
+	scores := make([]float64, len(candidates))
+	for i, c := range candidates {
+		utilization := 0.0
+		if limit := c.account.GetWindowCostLimit(); limit > 0 {
+			utilization = math.Min(windowCosts[c.account.ID]/limit, 1)
+		}
+		scores[i] = c.account.BillingRateMultiplier() * (1 + utilization)
+	}
+	return scores
+}
+
+// lowestLatencyStrategy 近期首字延迟（first_token_ms）中位数最低优先。
+// 统计值缓存在进程内并异步刷新；没有样本的账号按所有已知账号延迟的中位数计分，避免冷启动账号被饿死或被集中压测。
+type lowestLatencyStrategy struct {
+	usageLogRepo UsageLogRepository
+	now          func() time.Time
+
+	mu         sync.RWMutex
+	p50        map[int64]float64
+	fetchedAt  map[int64]time.Time
+	neutral    float64 // 所有已知账号 p50 的中位数，作为无样本账号的得分
+	refreshing atomic.Bool
+}
+
+func newLowestLatencyStrategy(usageLogRepo UsageLogRepository) *lowestLatencyStrategy {
+	return &lowestLatencyStrategy{
+		usageLogRepo: usageLogRepo,
+		now:          time.Now,
+		p50:          make(map[int64]float64),
+		fetchedAt:    make(map[int64]time.Time),
+	}
+}
+
+func (*lowestLatencyStrategy) Name() string { return SchedulingStrategyLowestLatency }
+
+func (st *lowestLatencyStrategy) Rank(_ context.Context, _ *Group, candidates []accountWithLoad) []float64 {
+	now := st.now()
+	scores := make([]float64, len(candidates))
+	var stale []int64
+
+	st.mu.RLock()
+	for i, c := range candidates {
+		fetchedAt, fetched := st.fetchedAt[c.account.ID]
+		if !fetched || now.Sub(fetchedAt) > schedulingLatencyTTL {
+			stale = append(stale, c.account.ID)
+		}
+		if v, ok := st.p50[c.account.ID]; ok {
+			scores[i] = v
+		} else {
+			scores[i] = st.neutral
+		}
+	}
+	st.mu.RUnlock()
+
+	if len(stale) > 0 {
+		st.refreshAsync(stale)
+	}
+	return scores
+}
+
+// refreshAsync 后台刷新延迟统计；同一时间只有一个刷新任务
+func (st *lowestLatencyStrategy) refreshAsync(accountIDs []int64) {
+	if st.usageLogRepo == nil || !st.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer st.refreshing.Store(false)
+		ctx, cancel := context.WithTimeout(context.Background(), schedulingLatencyQueryTimeout)
+		defer cancel()
+		st.refresh(ctx, accountIDs)
+	}()
+}
+
+func (st *lowestLatencyStrategy) refresh(ctx context.Context, accountIDs []int64) {
+	now := st.now()
+	stats, err := st.usageLogRepo.GetAccountFirstTokenP50(ctx, accountIDs, now.Add(-schedulingLatencyWindow))
+	if err != nil {
+		log.Printf("[Scheduling] refresh first token latency failed: %v", err)
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for _, id := range accountIDs {
+		// 查询失败也记录刷新时间，沿用旧值，避免每个请求都重试
+		st.fetchedAt[id] = now
+		if err != nil {
+			continue
+		}
+		if v, ok := stats[id]; ok {
+			st.p50[id] = v
+		} else {
+			delete(st.p50, id)
+		}
+	}
+	known := make([]float64, 0, len(st.p50))
+	for _, v := range st.p50 {
+		known = append(known, v)
+	}
+	st.neutral = median(known)
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// leastOutstandingStrategy 在途请求（并发 + 排队）最少优先，不按最大并发归一化
+type leastOutstandingStrategy struct{}
+
+func (leastOutstandingStrategy) Name() string { return SchedulingStrategyLeastOutstanding }
+
+func (leastOutstandingStrategy) Rank(_ context.Context, _ *Group, candidates []accountWithLoad) []float64 {
+	scores := make([]float64, len(candidates))
+	for i, c := range candidates {
+		if c.loadInfo != nil {
+			scores[i] = float64(c.loadInfo.CurrentConcurrency + c.loadInfo.WaitingCount)
+		}
+	}
+	return scores
+}
+
+// weightedRoundRobinStrategy 按分组配置的账号权重做平滑加权轮询（nginx smooth WRR）。
+// 每次选择只在最高优先级的候选账号中轮转，选中账号得分为 0，其余保持默认顺序；
+// 未配置权重的账号权重为 1，权重为 0 的账号仅在其他账号都不可用时使用。
+type weightedRoundRobinStrategy struct {
+	mu      sync.Mutex
+	current map[int64]map[int64]int // groupID -> accountID -> current weight
+}
+
+func newWeightedRoundRobinStrategy() *weightedRoundRobinStrategy {
+	return &weightedRoundRobinStrategy{current: make(map[int64]map[int64]int)}
+}
+
+func (*weightedRoundRobinStrategy) Name() string { return SchedulingStrategyWeightedRoundRobin }
+
+func (st *weightedRoundRobinStrategy) Rank(_ context.Context, group *Group, candidates []accountWithLoad) []float64 {
+	scores := make([]float64, len(candidates))
+	topPriority := candidates[0].account.Priority
+	for _, c := range candidates {
+		if c.account.Priority < topPriority {
+			topPriority = c.account.Priority
+		}
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	current := st.current[group.ID]
+	if current == nil {
+		current = make(map[int64]int)
+		st.current[group.ID] = current
+	}
+
+	best, total := -1, 0
+	for i, c := range candidates {
+		weight := group.SchedulingWeight(c.account.ID)
+		if weight == 0 {
+			scores[i] = 2
+			continue
+		}
+		scores[i] = 1
+		if c.account.Priority != topPriority {
+			continue
+		}
+		current[c.account.ID] += weight
+		total += weight
+		if best < 0 || current[c.account.ID] > current[candidates[best].account.ID] {
+			best = i
+		}
+	}
+	if best >= 0 {
+		current[candidates[best].account.ID] -= total
+		scores[best] = 0
+	}
+	return scores
+}
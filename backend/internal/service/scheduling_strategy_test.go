@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/stretchr/testify/require"
+)
+
+// simConcurrencyCache 按账号记录在途请求数的并发缓存，用于调度分布模拟
+type simConcurrencyCache struct {
+	ConcurrencyCache
+	mu       sync.Mutex
+	inFlight map[int64]int
+}
+
+func newSimConcurrencyCache() *simConcurrencyCache {
+	return &simConcurrencyCache{inFlight: make(map[int64]int)}
+}
+
+func (c *simConcurrencyCache) AcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.inFlight[accountID] >= maxConcurrency {
+		return false, nil
+	}
+	c.inFlight[accountID]++
+	return true, nil
+}
+
+func (c *simConcurrencyCache) ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight[accountID]--
+	return nil
+}
+
+func (c *simConcurrencyCache) GetAccountsLoadBatch(ctx context.Context, accounts []AccountWithConcurrency) (map[int64]*AccountLoadInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[int64]*AccountLoadInfo, len(accounts))
+	for _, acc := range accounts {
+		current := c.inFlight[acc.ID]
+		out[acc.ID] = &AccountLoadInfo{
+			AccountID:          acc.ID,
+			CurrentConcurrency: current,
+			LoadRate:           current * 100 / acc.MaxConcurrency,
+		}
+	}
+	return out, nil
+}
+
+func (c *simConcurrencyCache) GetAccountWaitingCount(ctx context.Context, accountID int64) (int, error) {
+	return 0, nil
+}
+
+type schedulingAccountRepoStub struct {
+	AccountRepository
+	accounts []Account
+}
+
+func (r schedulingAccountRepoStub) ListSchedulableByGroupIDAndPlatform(ctx context.Context, groupID int64, platform string) ([]Account, error) {
+	return r.ListSchedulableByGroupIDAndPlatforms(ctx, groupID, []string{platform})
+}
+
+func (r schedulingAccountRepoStub) ListSchedulableByGroupIDAndPlatforms(ctx context.Context, groupID int64, platforms []string) ([]Account, error) {
+	var out []Account
+	for _, acc := range r.accounts {
+		for _, platform := range platforms {
+			if acc.Platform == platform {
+				out = append(out, acc)
+			}
+		}
+	}
+	return out, nil
+}
+
+type schedulingUsageLogRepoStub struct {
+	UsageLogRepository
+	p50   map[int64]float64
+	calls int
+}
+
+func (r *schedulingUsageLogRepoStub) GetAccountFirstTokenP50(ctx context.Context, accountIDs []int64, since time.Time) (map[int64]float64, error) {
+	r.calls++
+	out := make(map[int64]float64)
+	for _, id := range accountIDs {
+		if v, ok := r.p50[id]; ok {
+			out[id] = v
+		}
+	}
+	return out, nil
+}
+
+type schedulingWindowCostStub struct {
+	SessionLimitCache
+	costs map[int64]float64
+}
+
+func (c schedulingWindowCostStub) GetWindowCostBatch(ctx context.Context, accountIDs []int64) (map[int64]float64, error) {
+	return c.costs, nil
+}
+
+func schedulingTestAccount(id int64, platform string, concurrency int) Account {
+	return Account{
+		ID:          id,
+		Platform:    platform,
+		Type:        AccountTypeAPIKey,
+		Status:      StatusActive,
+		Schedulable: true,
+		Concurrency: concurrency,
+		Priority:    1,
+	}
+}
+
+func schedulingTestGroup(platform, strategy string) *Group {
+	return &Group{
+		ID:                 7,
+		Platform:           platform,
+		Status:             StatusActive,
+		Hydrated:           true,
+		SchedulingStrategy: strategy,
+	}
+}
+
+// simulateSelections 连续选择 n 次并统计各账号被选中的次数；hold 为 true 时不释放槽位，模拟长时间在途请求
+func simulateSelections(t *testing.T, n int, hold bool, selectFn func() (*AccountSelectionResult, error)) map[int64]int {
+	t.Helper()
+	counts := make(map[int64]int)
+	for i := 0; i < n; i++ {
+		selection, err := selectFn()
+		require.NoError(t, err)
+		require.True(t, selection.Acquired, "selection %d should acquire a slot", i)
+		counts[selection.Account.ID]++
+		if !hold {
+			selection.ReleaseFunc()
+		}
+	}
+	return counts
+}
+
+func newSchedulingOpenAIService(accounts []Account, strategies *SchedulingStrategyService) *OpenAIGatewayService {
+	return &OpenAIGatewayService{
+		accountRepo:          schedulingAccountRepoStub{accounts: accounts},
+		concurrencyService:   NewConcurrencyService(newSimConcurrencyCache()),
+		schedulingStrategies: strategies,
+	}
+}
+
+func newSchedulingGatewayService(accounts []Account, strategies *SchedulingStrategyService) *GatewayService {
+	return &GatewayService{
+		accountRepo:          schedulingAccountRepoStub{accounts: accounts},
+		concurrencyService:   NewConcurrencyService(newSimConcurrencyCache()),
+		schedulingStrategies: strategies,
+	}
+}
+
+func TestSchedulingSimulation_WeightedRoundRobin_OpenAI(t *testing.T) {
+	accounts := []Account{
+		schedulingTestAccount(1, PlatformOpenAI, 100),
+		schedulingTestAccount(2, PlatformOpenAI, 100),
+		schedulingTestAccount(3, PlatformOpenAI, 100),
+	}
+	group := schedulingTestGroup(PlatformOpenAI, SchedulingStrategyWeightedRoundRobin)
+	group.SchedulingWeights = map[int64]int{1: 5, 2: 3, 3: 2}
+	ctx := context.WithValue(context.Background(), ctxkey.Group, group)
+	svc := newSchedulingOpenAIService(accounts, NewSchedulingStrategyService(nil, nil))
+
+	counts := simulateSelections(t, 1000, false, func() (*AccountSelectionResult, error) {
+		return svc.SelectAccountWithLoadAwareness(ctx, &group.ID, "", "", nil)
+	})
+	t.Logf("weighted_round_robin distribution: %v", counts)
+	require.Equal(t, map[int64]int{1: 500, 2: 300, 3: 200}, counts)
+}
+
+func TestSchedulingSimulation_WeightedRoundRobin_ZeroWeightIsBackupOnly(t *testing.T) {
+	accounts := []Account{
+		schedulingTestAccount(1, PlatformOpenAI, 3),
+		schedulingTestAccount(2, PlatformOpenAI, 3),
+	}
+	group := schedulingTestGroup(PlatformOpenAI, SchedulingStrategyWeightedRoundRobin)
+	group.SchedulingWeights = map[int64]int{2: 0}
+	ctx := context.WithValue(context.Background(), ctxkey.Group, group)
+	svc := newSchedulingOpenAIService(accounts, NewSchedulingStrategyService(nil, nil))
+
+	// 账号 1 满载后才使用权重为 0 的账号 2
+	counts := simulateSelections(t, 5, true, func() (*AccountSelectionResult, error) {
+		return svc.SelectAccountWithLoadAwareness(ctx, &group.ID, "", "", nil)
+	})
+	require.Equal(t, map[int64]int{1: 3, 2: 2}, counts)
+}
+
+func TestSchedulingSimulation_LeastOutstanding_Anthropic(t *testing.T) {
+	accounts := []Account{
+		schedulingTestAccount(1, PlatformAnthropic, 8),
+		schedulingTestAccount(2, PlatformAnthropic, 4),
+	}
+	run := func(strategy string) map[int64]int {
+		group := schedulingTestGroup(PlatformAnthropic, strategy)
+		ctx := context.WithValue(context.Background(), ctxkey.Group, group)
+		svc := newSchedulingGatewayService(accounts, NewSchedulingStrategyService(nil, nil))
+		return simulateSelections(t, 8, true, func() (*AccountSelectionResult, error) {
+			return svc.SelectAccountWithLoadAwareness(ctx, &group.ID, "", "", nil, "")
+		})
+	}
+
+	// 默认按负载率（在途/最大并发）均衡，大容量账号承接更多请求
+	byLoadRate := run(SchedulingStrategyDefault)
+	t.Logf("default distribution: %v", byLoadRate)
+	require.Equal(t, map[int64]int{1: 5, 2: 3}, byLoadRate)
+
+	// least_outstanding 只看在途请求数
+	byOutstanding := run(SchedulingStrategyLeastOutstanding)
+	t.Logf("least_outstanding distribution: %v", byOutstanding)
+	require.Equal(t, map[int64]int{1: 4, 2: 4}, byOutstanding)
+}
+
+func TestSchedulingSimulation_LowestCost_Gemini(t *testing.T) {
+	rate := func(v float64) *float64 { return &v }
+	accounts := []Account{
+		schedulingTestAccount(1, PlatformGemini, 3),
+		schedulingTestAccount(2, PlatformGemini, 3),
+		schedulingTestAccount(3, PlatformGemini, 3),
+	}
+	accounts[0].RateMultiplier = rate(1)
+	accounts[1].RateMultiplier = rate(0.5)
+	accounts[2].RateMultiplier = rate(0.8)
+
+	group := schedulingTestGroup(PlatformGemini, SchedulingStrategyLowestCost)
+	ctx := context.WithValue(context.Background(), ctxkey.Group, group)
+	svc := newSchedulingGatewayService(accounts, NewSchedulingStrategyService(nil, nil))
+
+	var order []int64
+	counts := simulateSelections(t, 9, true, func() (*AccountSelectionResult, error) {
+		selection, err := svc.SelectAccountWithLoadAwareness(ctx, &group.ID, "", "", nil, "")
+		if err == nil {
+			order = append(order, selection.Account.ID)
+		}
+		return selection, err
+	})
+	t.Logf("lowest_cost distribution: %v order: %v", counts, order)
+	// 最便宜的账号先用满，再依次使用更贵的账号
+	require.Equal(t, []int64{2, 2, 2, 3, 3, 3, 1, 1, 1}, order)
+}
+
+func TestLowestCostStrategy_WindowCostRaisesEffectiveCost(t *testing.T) {
+	rate := func(v float64) *float64 { return &v }
+	cheap := &Account{ID: 1, Platform: PlatformAnthropic, Type: AccountTypeOAuth, RateMultiplier: rate(0.5),
+		Extra: map[string]any{"window_cost_limit": 10.0}}
+	fresh := &Account{ID: 2, Platform: PlatformAnthropic, Type: AccountTypeOAuth, RateMultiplier: rate(0.8),
+		Extra: map[string]any{"window_cost_limit": 10.0}}
+	candidates := []accountWithLoad{{account: cheap}, {account: fresh}}
+
+	strategy := &lowestCostStrategy{sessionLimitCache: schedulingWindowCostStub{costs: map[int64]float64{1: 9}}}
+	scores := strategy.Rank(context.Background(), nil, candidates)
+	// 0.5 × (1 + 0.9) = 0.95 > 0.8 × (1 + 0)
+	require.InDelta(t, 0.95, scores[0], 1e-9)
+	require.InDelta(t, 0.8, scores[1], 1e-9)
+}
+
+func TestSchedulingSimulation_LowestLatency_OpenAI(t *testing.T) {
+	accounts := []Account{
+		schedulingTestAccount(1, PlatformOpenAI, 2),
+		schedulingTestAccount(2, PlatformOpenAI, 2),
+		schedulingTestAccount(3, PlatformOpenAI, 2),
+	}
+	repo := &schedulingUsageLogRepoStub{p50: map[int64]float64{1: 900, 2: 300}}
+	strategies := NewSchedulingStrategyService(repo, nil)
+	latency := strategies.strategies[SchedulingStrategyLowestLatency].(*lowestLatencyStrategy)
+	latency.refresh(context.Background(), []int64{1, 2, 3})
+
+	group := schedulingTestGroup(PlatformOpenAI, SchedulingStrategyLowestLatency)
+	ctx := context.WithValue(context.Background(), ctxkey.Group, group)
+	svc := newSchedulingOpenAIService(accounts, strategies)
+
+	var order []int64
+	simulateSelections(t, 6, true, func() (*AccountSelectionResult, error) {
+		selection, err := svc.SelectAccountWithLoadAwareness(ctx, &group.ID, "", "", nil)
+		if err == nil {
+			order = append(order, selection.Account.ID)
+		}
+		return selection, err
+	})
+	t.Logf("lowest_latency order: %v", order)
+	// 账号 3 无样本，按已知中位数 600ms 排在 300ms 与 900ms 之间
+	require.Equal(t, []int64{2, 2, 3, 3, 1, 1}, order)
+	require.Equal(t, 1, repo.calls, "fresh stats should not be re-queried")
+}
+
+func TestLowestLatencyStrategy_StaleStatsRefreshAsync(t *testing.T) {
+	repo := &schedulingUsageLogRepoStub{p50: map[int64]float64{1: 200}}
+	strategy := newLowestLatencyStrategy(repo)
+	candidates := []accountWithLoad{{account: &Account{ID: 1}}, {account: &Account{ID: 2}}}
+
+	// 首次无缓存：不阻塞请求，按中性分数处理并触发后台刷新
+	scores := strategy.Rank(context.Background(), nil, candidates)
+	require.Equal(t, []float64{0, 0}, scores)
+	require.Eventually(t, func() bool {
+		strategy.mu.RLock()
+		defer strategy.mu.RUnlock()
+		_, ok := strategy.p50[1]
+		return ok && !strategy.refreshing.Load()
+	}, time.Second, 5*time.Millisecond)
+
+	scores = strategy.Rank(context.Background(), nil, candidates)
+	require.Equal(t, []float64{200, 200}, scores)
+}
+
+func TestSchedulingStrategyService_PriorityStillFirst(t *testing.T) {
+	rate := func(v float64) *float64 { return &v }
+	expensiveHigh := &Account{ID: 1, Priority: 1, RateMultiplier: rate(2)}
+	cheapLow := &Account{ID: 2, Priority: 5, RateMultiplier: rate(0.1)}
+	cheapHigh := &Account{ID: 3, Priority: 1, RateMultiplier: rate(1)}
+	candidates := []accountWithLoad{{account: expensiveHigh}, {account: cheapHigh}, {account: cheapLow}}
+
+	svc := NewSchedulingStrategyService(nil, nil)
+	svc.Order(context.Background(), schedulingTestGroup(PlatformAnthropic, SchedulingStrategyLowestCost), candidates)
+	require.Equal(t, []int64{3, 1, 2}, []int64{candidates[0].account.ID, candidates[1].account.ID, candidates[2].account.ID})
+}
+
+func TestSchedulingStrategyService_DefaultAndNilAreNoop(t *testing.T) {
+	candidates := []accountWithLoad{
+		{account: &Account{ID: 1}, loadInfo: &AccountLoadInfo{CurrentConcurrency: 5}},
+		{account: &Account{ID: 2}, loadInfo: &AccountLoadInfo{CurrentConcurrency: 0}},
+	}
+	var nilSvc *SchedulingStrategyService
+	nilSvc.Order(context.Background(), schedulingTestGroup(PlatformOpenAI, SchedulingStrategyLeastOutstanding), candidates)
+	require.Equal(t, int64(1), candidates[0].account.ID)
+
+	svc := NewSchedulingStrategyService(nil, nil)
+	svc.Order(context.Background(), schedulingTestGroup(PlatformOpenAI, SchedulingStrategyDefault), candidates)
+	svc.Order(context.Background(), nil, candidates)
+	require.Equal(t, int64(1), candidates[0].account.ID)
+}
+
+func TestValidateSchedulingConfig(t *testing.T) {
+	require.NoError(t, validateSchedulingConfig("", nil))
+	require.NoError(t, validateSchedulingConfig(SchedulingStrategyWeightedRoundRobin, map[int64]int{1: 0, 2: SchedulingWeightMax}))
+	require.Error(t, validateSchedulingConfig("fastest", nil))
+	require.Error(t, validateSchedulingConfig(SchedulingStrategyWeightedRoundRobin, map[int64]int{1: -1}))
+	require.Error(t, validateSchedulingConfig(SchedulingStrategyWeightedRoundRobin, map[int64]int{0: 1}))
+	require.Error(t, validateSchedulingConfig(SchedulingStrategyWeightedRoundRobin, map[int64]int{1: SchedulingWeightMax + 1}))
+}
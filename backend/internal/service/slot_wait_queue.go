@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// 公平排队参数范围
+const (
+	QueuePriorityMin = -10
+	QueuePriorityMax = 10
+	QueueWeightMax   = 100
+)
+
+// SlotWaiter 排队请求的公平份额参数
+//
+// 同一优先级内按用户加权公平排序（权重越大，单位时间内分到的槽位越多）；
+// 优先级越高越先获得槽位，低优先级只在高优先级队列为空时调度。
+type SlotWaiter struct {
+	UserID   int64
+	Weight   int
+	Priority int
+}
+
+// SlotQueueSnapshot 单个账号的排队快照
+type SlotQueueSnapshot struct {
+	Depth  int     // 当前排队请求数
+	WaitMs []int64 // 最近获得槽位的排队耗时样本（毫秒）
+}
+
+// SlotWaitQueueCache 账号槽位公平排队队列（Redis 共享，多实例一致）
+//
+// 排队中的请求由 TryAcquireAccountSlot 轮询，只有排在可用槽位数以内的请求才能获得槽位；
+// 队列非空时 ConcurrencyCache.AcquireAccountSlot 也只能使用超出排队人数的空闲槽位，避免新请求插队。
+// 长时间未轮询的排队者（客户端断开、实例崩溃）会被自动移出队列。
+type SlotWaitQueueCache interface {
+	// EnqueueAccountWaiter 加入队列，返回当前排队位置（从 1 开始）
+	EnqueueAccountWaiter(ctx context.Context, accountID int64, requestID string, waiter SlotWaiter) (int, error)
+	// TryAcquireAccountSlot 尝试获取槽位：acquired=true 时槽位以 requestID 持有；
+	// 否则返回当前排队位置，位置为 0 表示已不在队列中（需重新入队）
+	TryAcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (acquired bool, position int, err error)
+	// LeaveAccountQueue 离开队列（超时、取消）
+	LeaveAccountQueue(ctx context.Context, accountID int64, requestID string) error
+	// GetAccountQueueSnapshots 批量查询排队深度与排队耗时样本
+	GetAccountQueueSnapshots(ctx context.Context, accountIDs []int64) (map[int64]*SlotQueueSnapshot, error)
+}
+
+// NewSlotWaiter 根据 API Key 计算排队参数：
+// 权重优先取用户设置，其次取订阅分组（套餐等级）设置，默认 1；优先级取用户设置。
+func NewSlotWaiter(apiKey *APIKey) SlotWaiter {
+	waiter := SlotWaiter{Weight: 1}
+	if apiKey == nil {
+		return waiter
+	}
+	waiter.UserID = apiKey.UserID
+	if apiKey.User != nil {
+		waiter.UserID = apiKey.User.ID
+		waiter.Priority = apiKey.User.QueuePriority
+		if apiKey.User.QueueWeight > 0 {
+			waiter.Weight = apiKey.User.QueueWeight
+		}
+	}
+	if (apiKey.User == nil || apiKey.User.QueueWeight <= 0) &&
+		apiKey.Group != nil && apiKey.Group.IsSubscriptionType() && apiKey.Group.QueueWeight > 0 {
+		waiter.Weight = apiKey.Group.QueueWeight
+	}
+	return waiter.normalized()
+}
+
+func (w SlotWaiter) normalized() SlotWaiter {
+	if w.Weight <= 0 {
+		w.Weight = 1
+	}
+	if w.Weight > QueueWeightMax {
+		w.Weight = QueueWeightMax
+	}
+	if w.Priority < QueuePriorityMin {
+		w.Priority = QueuePriorityMin
+	}
+	if w.Priority > QueuePriorityMax {
+		w.Priority = QueuePriorityMax
+	}
+	return w
+}
+
+func validateQueuePriority(priority int) error {
+	if priority < QueuePriorityMin || priority > QueuePriorityMax {
+		return fmt.Errorf("queue_priority must be between %d and %d", QueuePriorityMin, QueuePriorityMax)
+	}
+	return nil
+}
+
+func validateQueueWeight(weight int) error {
+	if weight < 0 || weight > QueueWeightMax {
+		return fmt.Errorf("queue_weight must be between 0 and %d", QueueWeightMax)
+	}
+	return nil
+}
+
+// AccountSlotTicket 账号槽位排队凭证，获得槽位后 requestID 即为槽位持有者
+type AccountSlotTicket struct {
+	AccountID int64
+	Position  int // 最近一次查询到的排队位置（从 1 开始）
+
+	requestID string
+	waiter    SlotWaiter
+	joinedAt  time.Time
+}
+
+// FairQueueEnabled 是否启用账号槽位公平排队
+func (s *ConcurrencyService) FairQueueEnabled() bool {
+	return s != nil && s.waitQueue != nil
+}
+
+// JoinAccountQueue 加入账号槽位排队队列
+func (s *ConcurrencyService) JoinAccountQueue(ctx context.Context, accountID int64, waiter SlotWaiter) (*AccountSlotTicket, error) {
+	if !s.FairQueueEnabled() {
+		return nil, fmt.Errorf("fair queue not enabled")
+	}
+	ticket := &AccountSlotTicket{
+		AccountID: accountID,
+		requestID: generateRequestID(),
+		waiter:    waiter.normalized(),
+		joinedAt:  time.Now(),
+	}
+	position, err := s.waitQueue.EnqueueAccountWaiter(ctx, accountID, ticket.requestID, ticket.waiter)
+	if err != nil {
+		return nil, err
+	}
+	ticket.Position = position
+	return ticket, nil
+}
+
+// TryAcquireQueuedAccountSlot 排队者尝试获取槽位，未获得时更新 ticket.Position；
+// 因长时间未轮询被移出队列时自动重新入队（排在队尾）
+func (s *ConcurrencyService) TryAcquireQueuedAccountSlot(ctx context.Context, ticket *AccountSlotTicket, maxConcurrency int) (*AcquireResult, error) {
+	acquired, position, err := s.waitQueue.TryAcquireAccountSlot(ctx, ticket.AccountID, maxConcurrency, ticket.requestID)
+	if err != nil {
+		return nil, err
+	}
+	if acquired {
+		accountQueueWaitSeconds.WithLabelValues(queuePriorityLabel(ticket.waiter.Priority)).Observe(time.Since(ticket.joinedAt).Seconds())
+		concurrencySlotsInUse.WithLabelValues(metricsScopeAccount).Inc()
+		return &AcquireResult{Acquired: true, ReleaseFunc: s.accountSlotReleaseFunc(ticket.AccountID, ticket.requestID)}, nil
+	}
+	if position <= 0 {
+		position, err = s.waitQueue.EnqueueAccountWaiter(ctx, ticket.AccountID, ticket.requestID, ticket.waiter)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ticket.Position = position
+	return &AcquireResult{Acquired: false}, nil
+}
+
+// LeaveAccountQueue 离开排队队列（未获得槽位时调用，获得槽位后无需调用）
+func (s *ConcurrencyService) LeaveAccountQueue(ticket *AccountSlotTicket) {
+	if !s.FairQueueEnabled() || ticket == nil {
+		return
+	}
+	bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.waitQueue.LeaveAccountQueue(bgCtx, ticket.AccountID, ticket.requestID); err != nil {
+		log.Printf("Warning: leave account queue failed for %d (req=%s): %v", ticket.AccountID, ticket.requestID, err)
+	}
+}
+
+// GetAccountQueueSnapshots 批量查询账号排队快照，未启用公平排队时返回空
+func (s *ConcurrencyService) GetAccountQueueSnapshots(ctx context.Context, accountIDs []int64) (map[int64]*SlotQueueSnapshot, error) {
+	if !s.FairQueueEnabled() || len(accountIDs) == 0 {
+		return map[int64]*SlotQueueSnapshot{}, nil
+	}
+	return s.waitQueue.GetAccountQueueSnapshots(ctx, accountIDs)
+}
+
+func queuePriorityLabel(priority int) string {
+	switch {
+	case priority > 0:
+		return "high"
+	case priority < 0:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// waitPercentiles 计算排队耗时分位数（毫秒），无样本时返回空值
+func waitPercentiles(samples []int64) OpsPercentiles {
+	if len(samples) == 0 {
+		return OpsPercentiles{}
+	}
+	sorted := append([]int64(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	at := func(q float64) *int {
+		idx := int(q*float64(len(sorted))+0.5) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		if idx >= len(sorted) {
+			idx = len(sorted) - 1
+		}
+		v := int(sorted[idx])
+		return &v
+	}
+	var sum int64
+	for _, v := range sorted {
+		sum += v
+	}
+	avg := int(sum / int64(len(sorted)))
+	return OpsPercentiles{
+		P50: at(0.50),
+		P90: at(0.90),
+		P95: at(0.95),
+		P99: at(0.99),
+		Avg: &avg,
+		Max: at(1),
+	}
+}
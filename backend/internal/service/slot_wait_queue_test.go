@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSlotWaitQueue 内存排队队列：按入队顺序排位，evicted 模拟失联后被移出队列
+type fakeSlotWaitQueue struct {
+	SlotWaitQueueCache
+	order    []string
+	waiters  map[string]SlotWaiter
+	free     int
+	evicted  bool
+	enqueues int
+}
+
+func (q *fakeSlotWaitQueue) EnqueueAccountWaiter(ctx context.Context, accountID int64, requestID string, waiter SlotWaiter) (int, error) {
+	q.enqueues++
+	if q.waiters == nil {
+		q.waiters = make(map[string]SlotWaiter)
+	}
+	q.waiters[requestID] = waiter
+	q.order = append(q.order, requestID)
+	return len(q.order), nil
+}
+
+func (q *fakeSlotWaitQueue) TryAcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, int, error) {
+	if q.evicted {
+		q.evicted = false
+		q.order = nil
+		return false, 0, nil
+	}
+	for i, id := range q.order {
+		if id != requestID {
+			continue
+		}
+		if i < q.free {
+			q.order = append(q.order[:i], q.order[i+1:]...)
+			return true, 0, nil
+		}
+		return false, i + 1, nil
+	}
+	return false, 0, nil
+}
+
+func (q *fakeSlotWaitQueue) LeaveAccountQueue(ctx context.Context, accountID int64, requestID string) error {
+	for i, id := range q.order {
+		if id == requestID {
+			q.order = append(q.order[:i], q.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func TestNewSlotWaiter(t *testing.T) {
+	require.Equal(t, SlotWaiter{Weight: 1}, NewSlotWaiter(nil))
+
+	subGroup := &Group{SubscriptionType: SubscriptionTypeSubscription, QueueWeight: 5}
+	key := &APIKey{UserID: 7, User: &User{ID: 7}, Group: subGroup}
+	require.Equal(t, SlotWaiter{UserID: 7, Weight: 5}, NewSlotWaiter(key), "subscription tier weight")
+
+	key.User.QueueWeight = 3
+	key.User.QueuePriority = 2
+	require.Equal(t, SlotWaiter{UserID: 7, Weight: 3, Priority: 2}, NewSlotWaiter(key), "user weight overrides tier")
+
+	key = &APIKey{UserID: 8, User: &User{ID: 8, QueuePriority: 99}, Group: &Group{QueueWeight: 5}}
+	require.Equal(t, SlotWaiter{UserID: 8, Weight: 1, Priority: QueuePriorityMax}, NewSlotWaiter(key), "standard groups have no tier weight")
+}
+
+func TestValidateQueueParams(t *testing.T) {
+	require.NoError(t, validateQueuePriority(QueuePriorityMin))
+	require.NoError(t, validateQueuePriority(QueuePriorityMax))
+	require.Error(t, validateQueuePriority(QueuePriorityMax+1))
+	require.NoError(t, validateQueueWeight(0))
+	require.NoError(t, validateQueueWeight(QueueWeightMax))
+	require.Error(t, validateQueueWeight(-1))
+	require.Error(t, validateQueueWeight(QueueWeightMax+1))
+}
+
+func TestConcurrencyService_QueuedAccountSlot(t *testing.T) {
+	queue := &fakeSlotWaitQueue{}
+	svc := NewConcurrencyService(stubConcurrencyCache{})
+	require.False(t, svc.FairQueueEnabled())
+	svc.waitQueue = queue
+	require.True(t, svc.FairQueueEnabled())
+
+	ctx := context.Background()
+	first, err := svc.JoinAccountQueue(ctx, 1, SlotWaiter{UserID: 1})
+	require.NoError(t, err)
+	second, err := svc.JoinAccountQueue(ctx, 1, SlotWaiter{UserID: 2, Weight: 1000})
+	require.NoError(t, err)
+	require.Equal(t, 1, first.Position)
+	require.Equal(t, 2, second.Position)
+	require.Equal(t, QueueWeightMax, second.waiter.Weight)
+
+	result, err := svc.TryAcquireQueuedAccountSlot(ctx, second, 1)
+	require.NoError(t, err)
+	require.False(t, result.Acquired)
+
+	queue.free = 1
+	result, err = svc.TryAcquireQueuedAccountSlot(ctx, first, 1)
+	require.NoError(t, err)
+	require.True(t, result.Acquired)
+	require.NotNil(t, result.ReleaseFunc)
+	result.ReleaseFunc()
+
+	// 被移出队列后自动重新入队
+	queue.evicted = true
+	queue.free = 0
+	result, err = svc.TryAcquireQueuedAccountSlot(ctx, second, 1)
+	require.NoError(t, err)
+	require.False(t, result.Acquired)
+	require.Equal(t, 1, second.Position)
+	require.Equal(t, 3, queue.enqueues)
+
+	svc.LeaveAccountQueue(second)
+	require.Empty(t, queue.order)
+}
+
+func TestWaitPercentiles(t *testing.T) {
+	require.Equal(t, OpsPercentiles{}, waitPercentiles(nil))
+
+	samples := make([]int64, 0, 100)
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, int64(i))
+	}
+	p := waitPercentiles(samples)
+	require.Equal(t, 50, *p.P50)
+	require.Equal(t, 90, *p.P90)
+	require.Equal(t, 99, *p.P99)
+	require.Equal(t, 100, *p.Max)
+	require.Equal(t, 50, *p.Avg)
+	require.Equal(t, int64(100), samples[0], "input is not reordered")
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// 客户端断开后上游流的结局（sub2api_stream_client_disconnects_total 的 outcome 标签）
+const (
+	streamDisconnectDrained = "drained" // 上游读取完毕，usage 完整
+	streamDisconnectCapped  = "capped"  // 排空超过上限被取消，usage 为已读取部分
+	streamDisconnectAborted = "aborted" // 未启用排空或上游出错，usage 为已读取部分
+)
+
+// errStreamDrainCapped 客户端断开后排空上游超过上限时的取消原因
+var errStreamDrainCapped = errors.New("stream drain timeout after client disconnect")
+
+// streamDrainTimeout 返回客户端断开后继续读取上游的最长时间，0 表示不排空
+func streamDrainTimeout(cfg *config.Config, stream bool) time.Duration {
+	if !stream || cfg == nil || cfg.Gateway.StreamDrainOnDisconnectTimeout <= 0 {
+		return 0
+	}
+	return time.Duration(cfg.Gateway.StreamDrainOnDisconnectTimeout) * time.Second
+}
+
+// detachUpstreamContext 返回上游请求使用的 context。
+//
+// 客户端断开（ctx 以 context.Canceled 结束）时不立即取消上游，而是继续读取至多 drain 时间，
+// 以便解析最终 usage 准确计费；超时、对冲竞争失败等其他取消原因仍立即传递给上游。
+// drain <= 0 时等价于 context.WithCancel。返回的 cancel 必须调用。
+func detachUpstreamContext(ctx context.Context, drain time.Duration) (context.Context, context.CancelFunc) {
+	if drain <= 0 {
+		return context.WithCancel(ctx)
+	}
+	upstreamCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		cause := context.Cause(ctx)
+		if !errors.Is(cause, context.Canceled) {
+			cancel(cause)
+			return
+		}
+		timer := time.AfterFunc(drain, func() { cancel(errStreamDrainCapped) })
+		context.AfterFunc(upstreamCtx, func() { timer.Stop() })
+	})
+	return upstreamCtx, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+// clientRequestDone 返回客户端请求结束信号（断开或对冲竞争失败），无请求时返回 nil
+func clientRequestDone(c *gin.Context) <-chan struct{} {
+	if c == nil || c.Request == nil {
+		return nil
+	}
+	return c.Request.Context().Done()
+}
+
+// isClientDisconnected 客户端请求是否已结束
+func isClientDisconnected(c *gin.Context) bool {
+	return c != nil && c.Request != nil && c.Request.Context().Err() != nil
+}
+
+// streamDisconnectOutcome 根据上游是否读取完毕与上游 context 的取消原因判断断开后的结局
+func streamDisconnectOutcome(resp *http.Response, completed bool) string {
+	if completed {
+		return streamDisconnectDrained
+	}
+	if resp != nil && resp.Request != nil && errors.Is(context.Cause(resp.Request.Context()), errStreamDrainCapped) {
+		return streamDisconnectCapped
+	}
+	return streamDisconnectAborted
+}
+
+// finishClientDisconnect 记录客户端断开后的上游结局（对冲竞争失败的请求不计入）
+func finishClientDisconnect(c *gin.Context, platform string, accountID int64, resp *http.Response, completed bool) {
+	if c != nil && c.Request != nil && errors.Is(context.Cause(c.Request.Context()), errHedgeLost) {
+		return
+	}
+	outcome := streamDisconnectOutcome(resp, completed)
+	recordStreamClientDisconnect(platform, outcome)
+	log.Printf("[Stream] client disconnected mid-stream: platform=%s account=%d upstream=%s", platform, accountID, outcome)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetachUpstreamContext_DisabledCancelsWithClient(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := detachUpstreamContext(parent, 0)
+	defer cancel()
+
+	cancelParent()
+	<-ctx.Done()
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestDetachUpstreamContext_DrainsAfterClientDisconnect(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := detachUpstreamContext(parent, 50*time.Millisecond)
+	defer cancel()
+
+	cancelParent()
+	select {
+	case <-ctx.Done():
+		t.Fatal("upstream must keep running while draining")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	<-ctx.Done()
+	require.ErrorIs(t, context.Cause(ctx), errStreamDrainCapped)
+	require.Equal(t, streamDisconnectCapped, streamDisconnectOutcome(&http.Response{Request: httptest.NewRequest(http.MethodPost, "/", nil).WithContext(ctx)}, false))
+}
+
+func TestDetachUpstreamContext_HedgeLossCancelsImmediately(t *testing.T) {
+	parent, cancelParent := context.WithCancelCause(context.Background())
+	ctx, cancel := detachUpstreamContext(parent, time.Minute)
+	defer cancel()
+
+	cancelParent(errHedgeLost)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("hedge loss must cancel the upstream immediately")
+	}
+	require.ErrorIs(t, context.Cause(ctx), errHedgeLost)
+}
+
+func TestDetachUpstreamContext_CancelStopsUpstream(t *testing.T) {
+	ctx, cancel := detachUpstreamContext(context.Background(), time.Minute)
+	cancel()
+	<-ctx.Done()
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestStreamDrainTimeout(t *testing.T) {
+	cfg := &config.Config{Gateway: config.GatewayConfig{StreamDrainOnDisconnectTimeout: 30}}
+	require.Equal(t, 30*time.Second, streamDrainTimeout(cfg, true))
+	require.Zero(t, streamDrainTimeout(cfg, false), "non-stream requests are not drained")
+	require.Zero(t, streamDrainTimeout(nil, true))
+	require.Zero(t, streamDrainTimeout(&config.Config{}, true))
+}
+
+func TestOpenAIStreamingClientDisconnectKeepsUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := &OpenAIGatewayService{cfg: &config.Config{Gateway: config.GatewayConfig{MaxLineSize: defaultMaxLineSize}}}
+
+	clientCtx, disconnect := context.WithCancel(context.Background())
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil).WithContext(clientCtx)
+
+	upstreamCtx, cancelUpstream := detachUpstreamContext(clientCtx, time.Minute)
+	defer cancelUpstream()
+	pr, pw := io.Pipe()
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       pr,
+		Header:     http.Header{},
+		Request:    httptest.NewRequest(http.MethodPost, "/", nil).WithContext(upstreamCtx),
+	}
+
+	go func() {
+		defer func() { _ = pw.Close() }()
+		_, _ = pw.Write([]byte("data: {\"type\":\"response.output_text.delta\",\"delta\":\"hi\"}\n\n"))
+		disconnect()
+		_, _ = pw.Write([]byte("data: {\"type\":\"response.completed\",\"response\":{\"usage\":{\"input_tokens\":12,\"output_tokens\":34}}}\n\n"))
+	}()
+
+	result, err := svc.handleStreamingResponse(upstreamCtx, resp, c, &Account{ID: 1, Platform: PlatformOpenAI}, time.Now(), "model", "model")
+	_ = pr.Close()
+
+	require.NoError(t, err)
+	require.True(t, result.clientDisconnect)
+	require.Equal(t, 12, result.usage.InputTokens)
+	require.Equal(t, 34, result.usage.OutputTokens, "usage after the disconnect is still parsed")
+}
+
+func TestAntigravityStreamingClientDisconnectKeepsUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := &AntigravityGatewayService{settingService: &SettingService{cfg: &config.Config{}}}
+
+	clientCtx, disconnect := context.WithCancel(context.Background())
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil).WithContext(clientCtx)
+
+	upstreamCtx, cancelUpstream := detachUpstreamContext(clientCtx, time.Minute)
+	defer cancelUpstream()
+	pr, pw := io.Pipe()
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       pr,
+		Header:     http.Header{},
+		Request:    httptest.NewRequest(http.MethodPost, "/", nil).WithContext(upstreamCtx),
+	}
+
+	go func() {
+		defer func() { _ = pw.Close() }()
+		_, _ = pw.Write([]byte("data: {\"response\":{\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"hi\"}]}}]}}\n\n"))
+		disconnect()
+		_, _ = pw.Write([]byte("data: {\"response\":{\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"!\"}]},\"finishReason\":\"STOP\"}],\"usageMetadata\":{\"promptTokenCount\":12,\"candidatesTokenCount\":34}}}\n\n"))
+	}()
+
+	result, err := svc.handleGeminiStreamingResponse(c, resp, &Account{ID: 1, Platform: PlatformAntigravity}, time.Now())
+	_ = pr.Close()
+
+	require.NoError(t, err)
+	require.True(t, result.clientDisconnect)
+	require.Equal(t, 12, result.usage.InputTokens)
+	require.Equal(t, 34, result.usage.OutputTokens, "usage after the disconnect is still parsed")
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"sync"
 	"time"
@@ -9,18 +10,20 @@ import (
 
 // SubscriptionExpiryService periodically updates expired subscription status.
 type SubscriptionExpiryService struct {
-	userSubRepo UserSubscriptionRepository
-	interval    time.Duration
-	stopCh      chan struct{}
-	stopOnce    sync.Once
-	wg          sync.WaitGroup
+	userSubRepo    UserSubscriptionRepository
+	webhookService *WebhookService
+	interval       time.Duration
+	stopCh         chan struct{}
+	stopOnce       sync.Once
+	wg             sync.WaitGroup
 }
 
-func NewSubscriptionExpiryService(userSubRepo UserSubscriptionRepository, interval time.Duration) *SubscriptionExpiryService {
+func NewSubscriptionExpiryService(userSubRepo UserSubscriptionRepository, webhookService *WebhookService, interval time.Duration) *SubscriptionExpiryService {
 	return &SubscriptionExpiryService{
-		userSubRepo: userSubRepo,
-		interval:    interval,
-		stopCh:      make(chan struct{}),
+		userSubRepo:    userSubRepo,
+		webhookService: webhookService,
+		interval:       interval,
+		stopCh:         make(chan struct{}),
 	}
 }
 
@@ -60,6 +63,15 @@ func (s *SubscriptionExpiryService) runOnce() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// 先取出即将标记为过期的订阅用于通知；查询失败不影响状态更新
+	var expired []UserSubscription
+	if s.webhookService.enabled() {
+		var err error
+		if expired, err = s.userSubRepo.ListExpired(ctx); err != nil {
+			log.Printf("[SubscriptionExpiry] List expired subscriptions failed: %v", err)
+		}
+	}
+
 	updated, err := s.userSubRepo.BatchUpdateExpiredStatus(ctx)
 	if err != nil {
 		log.Printf("[SubscriptionExpiry] Update expired subscriptions failed: %v", err)
@@ -68,4 +80,33 @@ func (s *SubscriptionExpiryService) runOnce() {
 	if updated > 0 {
 		log.Printf("[SubscriptionExpiry] Updated %d expired subscriptions", updated)
 	}
+	for i := range expired {
+		s.notifyExpired(&expired[i])
+	}
+}
+
+func (s *SubscriptionExpiryService) notifyExpired(sub *UserSubscription) {
+	data := map[string]any{
+		"subscription_id": sub.ID,
+		"user_id":         sub.UserID,
+		"group_id":        sub.GroupID,
+		"expires_at":      sub.ExpiresAt,
+	}
+	userLabel := fmt.Sprintf("user #%d", sub.UserID)
+	if sub.User != nil {
+		data["user_email"] = sub.User.Email
+		userLabel = sub.User.Email
+	}
+	groupLabel := fmt.Sprintf("group #%d", sub.GroupID)
+	if sub.Group != nil {
+		data["group_name"] = sub.Group.Name
+		groupLabel = sub.Group.Name
+	}
+	s.webhookService.Emit(WebhookEvent{
+		Type:     WebhookEventSubscriptionExpired,
+		Severity: WebhookSeverityInfo,
+		Title:    fmt.Sprintf("Subscription expired: %s", userLabel),
+		Message:  fmt.Sprintf("Subscription of %s to %s has expired.", userLabel, groupLabel),
+		Data:     data,
+	})
 }
@@ -530,8 +530,12 @@ func (s *SubscriptionService) CheckAndResetWindows(ctx context.Context, sub *Use
 }
 
 // CheckUsageLimits 检查使用限额（返回错误如果超限）
-// 用于中间件的快速预检查，additionalCost 通常为 0
+// 用于中间件的快速预检查，additionalCost 通常为 0；
+// 已预占但尚未结算的在途请求费用计入用量，避免并发请求在结算前突破窗口限额
 func (s *SubscriptionService) CheckUsageLimits(ctx context.Context, sub *UserSubscription, group *Group, additionalCost float64) error {
+	if s.billingCacheService != nil {
+		additionalCost += s.billingCacheService.GetReservedSubscriptionCost(ctx, sub.UserID, sub.GroupID)
+	}
 	if !sub.CheckDailyLimit(group, additionalCost) {
 		return ErrDailyLimitExceeded
 	}
@@ -18,6 +18,7 @@ type TokenRefreshService struct {
 	refreshers       []TokenRefresher
 	cfg              *config.TokenRefreshConfig
 	cacheInvalidator TokenCacheInvalidator
+	webhookService   *WebhookService
 
 	stopCh chan struct{}
 	wg     sync.WaitGroup
@@ -51,6 +52,11 @@ func NewTokenRefreshService(
 	return s
 }
 
+// SetWebhookService 设置 Webhook 服务（可选依赖），刷新失败时发送通知
+func (s *TokenRefreshService) SetWebhookService(webhookService *WebhookService) {
+	s.webhookService = webhookService
+}
+
 // Start 启动后台刷新服务
 func (s *TokenRefreshService) Start() {
 	if !s.cfg.Enabled {
@@ -138,9 +144,12 @@ func (s *TokenRefreshService) processRefresh() {
 			// 执行刷新
 			if err := s.refreshWithRetry(ctx, account, refresher); err != nil {
 				log.Printf("[TokenRefresh] Account %d (%s) failed: %v", account.ID, account.Name, err)
+				tokenRefreshTotal.WithLabelValues(account.Platform, "failure").Inc()
+				s.webhookService.NotifyAccountEvent(WebhookEventTokenRefreshFailed, account, err.Error(), nil)
 				failed++
 			} else {
 				log.Printf("[TokenRefresh] Account %d (%s) refreshed successfully", account.ID, account.Name)
+				tokenRefreshTotal.WithLabelValues(account.Platform, "success").Inc()
 				refreshed++
 			}
 
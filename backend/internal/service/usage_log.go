@@ -17,6 +17,8 @@ type UsageLog struct {
 
 	GroupID        *int64
 	SubscriptionID *int64
+	// OrganizationID 团队 API Key 的请求记录所扣费的组织
+	OrganizationID *int64
 
 	InputTokens         int
 	OutputTokens        int
@@ -47,6 +49,23 @@ type UsageLog struct {
 	ImageCount int
 	ImageSize  *string
 
+	// 响应缓存命中（未请求上游），CacheSavedCost 为相对原价节省的实际费用
+	CacheServed    bool
+	CacheSavedCost float64
+
+	// 对冲请求：Hedged 表示发起过对冲请求，HedgeBackupWon 表示由备用账号胜出（仅胜出请求计费）
+	Hedged         bool
+	HedgeBackupWon bool
+
+	// ClientDisconnected 客户端在流式传输中途断开（被放弃的流仍按上游实际生成计费）
+	ClientDisconnected bool
+
+	// PricingTier 长上下文阶梯（standard / above_200k 等），ServiceTier 服务等级（standard / batch / priority / flex）
+	PricingTier string
+	ServiceTier string
+	// CostDetail 计费明细（用于解释账单），历史数据为 nil
+	CostDetail *UsageCostDetail
+
 	CreatedAt time.Time
 
 	User         *User
@@ -59,3 +78,20 @@ type UsageLog struct {
 func (u *UsageLog) TotalTokens() int {
 	return u.InputTokens + u.OutputTokens + u.CacheCreationTokens + u.CacheReadTokens
 }
+
+// UsageCostDetail 计费明细：实际使用的每 token 单价（已应用长上下文阶梯与服务等级）、
+// 5 分钟/1 小时缓存创建费用拆分，随用量记录落库
+type UsageCostDetail struct {
+	PriceSource       string  `json:"price_source,omitempty"`
+	ContextTokens     int     `json:"context_tokens"`
+	TierThreshold     int     `json:"tier_threshold,omitempty"`
+	InputPrice        float64 `json:"input_price"`
+	OutputPrice       float64 `json:"output_price"`
+	CacheWrite5mPrice float64 `json:"cache_write_5m_price"`
+	CacheWrite1hPrice float64 `json:"cache_write_1h_price"`
+	CacheReadPrice    float64 `json:"cache_read_price"`
+	CacheWrite5mCost  float64 `json:"cache_write_5m_cost"`
+	CacheWrite1hCost  float64 `json:"cache_write_1h_cost"`
+	// CacheServedRate 响应缓存命中时的计费比例
+	CacheServedRate *float64 `json:"cache_served_rate,omitempty"`
+}
@@ -32,6 +32,10 @@ type User struct {
 	UsageReportSchedule string // 发送时间 (HH:MM)
 	UsageReportTimezone string // 时区
 
+	// 公平排队配置字段
+	QueuePriority int // 排队优先级，越大越先获得账号槽位
+	QueueWeight   int // 公平份额权重，0 表示使用订阅分组权重或默认值
+
 	APIKeys       []APIKey
 	Subscriptions []UserSubscription
 }
@@ -33,8 +33,8 @@ type UserRepository interface {
 	List(ctx context.Context, params pagination.PaginationParams) ([]User, *pagination.PaginationResult, error)
 	ListWithFilters(ctx context.Context, params pagination.PaginationParams, filters UserListFilters) ([]User, *pagination.PaginationResult, error)
 
-	UpdateBalance(ctx context.Context, id int64, amount float64) error
-	DeductBalance(ctx context.Context, id int64, amount float64) error
+	// ApplyBalanceTransaction 原子地变更余额并写入余额流水（余额的唯一写入口）
+	ApplyBalanceTransaction(ctx context.Context, txn *BalanceTransaction) error
 	UpdateConcurrency(ctx context.Context, id int64, amount int) error
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	RemoveGroupFromAllowedGroups(ctx context.Context, groupID int64) (int64, error)
@@ -182,7 +182,8 @@ func (s *UserService) List(ctx context.Context, params pagination.PaginationPara
 
 // UpdateBalance 更新用户余额（管理员功能）
 func (s *UserService) UpdateBalance(ctx context.Context, userID int64, amount float64) error {
-	if err := s.userRepo.UpdateBalance(ctx, userID, amount); err != nil {
+	txn := &BalanceTransaction{UserID: userID, Type: BalanceTxTypeAdminAdjust, Amount: amount}
+	if err := s.userRepo.ApplyBalanceTransaction(ctx, txn); err != nil {
 		return fmt.Errorf("update balance: %w", err)
 	}
 	if s.authCacheInvalidator != nil {
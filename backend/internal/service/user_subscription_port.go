@@ -31,5 +31,7 @@ type UserSubscriptionRepository interface {
 	ResetMonthlyUsage(ctx context.Context, id int64, newWindowStart time.Time) error
 	IncrementUsage(ctx context.Context, id int64, costUSD float64) error
 
+	// ListExpired 列出已到期但仍为 active 的订阅（在 BatchUpdateExpiredStatus 之前调用，用于到期通知）
+	ListExpired(ctx context.Context) ([]UserSubscription, error)
 	BatchUpdateExpiredStatus(ctx context.Context) (int64, error)
 }
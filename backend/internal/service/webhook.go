@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/tidwall/gjson"
+)
+
+// Webhook 事件类型
+const (
+	WebhookEventAlertFired          = "alert.fired"
+	WebhookEventAlertResolved       = "alert.resolved"
+	WebhookEventAccountError        = "account.error"
+	WebhookEventAccountRateLimited  = "account.rate_limited"
+	WebhookEventAccountExpired      = "account.expired"
+	WebhookEventTokenRefreshFailed  = "token_refresh.failed"
+	WebhookEventSubscriptionExpired = "subscription.expired"
+	// WebhookEventTest 管理后台“发送测试”使用，不参与订阅匹配
+	WebhookEventTest = "webhook.test"
+)
+
+// WebhookEventTypes 可订阅的事件类型（按展示顺序）
+var WebhookEventTypes = []string{
+	WebhookEventAlertFired,
+	WebhookEventAlertResolved,
+	WebhookEventAccountError,
+	WebhookEventAccountRateLimited,
+	WebhookEventAccountExpired,
+	WebhookEventTokenRefreshFailed,
+	WebhookEventSubscriptionExpired,
+}
+
+// IsValidWebhookEventType 是否为可订阅的事件类型
+func IsValidWebhookEventType(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook 负载格式
+const (
+	WebhookFormatGeneric  = "generic"
+	WebhookFormatSlack    = "slack"
+	WebhookFormatFeishu   = "feishu"
+	WebhookFormatDingTalk = "dingtalk"
+	WebhookFormatWeCom    = "wecom"
+)
+
+// IsValidWebhookFormat 是否为支持的负载格式
+func IsValidWebhookFormat(format string) bool {
+	switch format {
+	case WebhookFormatGeneric, WebhookFormatSlack, WebhookFormatFeishu, WebhookFormatDingTalk, WebhookFormatWeCom:
+		return true
+	}
+	return false
+}
+
+// Webhook 事件级别
+const (
+	WebhookSeverityInfo     = "info"
+	WebhookSeverityWarning  = "warning"
+	WebhookSeverityCritical = "critical"
+)
+
+// Webhook 投递状态
+const (
+	WebhookDeliveryStatusPending = "pending"
+	WebhookDeliveryStatusSuccess = "success"
+	WebhookDeliveryStatusFailed  = "failed"
+)
+
+// Webhook 签名请求头（所有格式均携带，generic 接收方据此校验）
+const (
+	WebhookHeaderSignature = "X-Sub2API-Signature"
+	WebhookHeaderTimestamp = "X-Sub2API-Timestamp"
+	WebhookHeaderEvent     = "X-Sub2API-Event"
+	WebhookHeaderDelivery  = "X-Sub2API-Delivery"
+)
+
+var (
+	ErrWebhookEndpointNotFound = infraerrors.NotFound("WEBHOOK_ENDPOINT_NOT_FOUND", "webhook endpoint not found")
+	ErrWebhookDeliveryNotFound = infraerrors.NotFound("WEBHOOK_DELIVERY_NOT_FOUND", "webhook delivery not found")
+	ErrWebhookInvalidFormat    = infraerrors.BadRequest("WEBHOOK_INVALID_FORMAT", "unsupported webhook format")
+	ErrWebhookInvalidEvent     = infraerrors.BadRequest("WEBHOOK_INVALID_EVENT", "unsupported webhook event type")
+	ErrWebhookDisabled         = infraerrors.BadRequest("WEBHOOK_DISABLED", "webhook delivery is disabled")
+)
+
+// WebhookEndpoint 管理员配置的 Webhook 接收端点
+type WebhookEndpoint struct {
+	ID     int64
+	Name   string
+	URL    string
+	Secret string
+	Format string
+	// Events 订阅的事件类型，为空表示订阅全部
+	Events    []string
+	Enabled   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Subscribes 端点是否订阅该事件
+func (e *WebhookEndpoint) Subscribes(eventType string) bool {
+	if e == nil || !e.Enabled {
+		return false
+	}
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, t := range e.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent 待投递的事件
+type WebhookEvent struct {
+	Type     string         `json:"event"`
+	Severity string         `json:"severity"`
+	Title    string         `json:"title"`
+	Message  string         `json:"message,omitempty"`
+	Data     map[string]any `json:"data,omitempty"`
+	// OccurredAt 事件发生时间，为空时取 Emit 时间
+	OccurredAt time.Time `json:"occurred_at"`
+	// DedupeKey 去重键：同一键在去重窗口内只投递一次（为空则不去重）
+	DedupeKey string `json:"-"`
+}
+
+// WebhookDelivery 单个端点的一次事件投递记录
+type WebhookDelivery struct {
+	ID         int64
+	EndpointID int64
+	EventType  string
+	// Payload 事件原始 JSON（WebhookEvent），发送时按端点格式渲染
+	Payload        []byte
+	Status         string
+	Attempts       int
+	ResponseStatus *int
+	ResponseBody   *string
+	ErrorMessage   *string
+	NextAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookRepository Webhook 端点与投递日志存储
+type WebhookRepository interface {
+	ListEndpoints(ctx context.Context) ([]WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id int64) (*WebhookEndpoint, error)
+	CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id int64) error
+
+	CreateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	GetDelivery(ctx context.Context, id int64) (*WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, endpointID int64, status string, params pagination.PaginationParams) ([]WebhookDelivery, *pagination.PaginationResult, error)
+	// ClaimDueDeliveries 领取到期的待投递记录，并将 next_attempt_at 推后 lease 防止多实例重复投递
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	// UpdateDeliveryAttempt 写回一次投递尝试的结果（status/attempts/response/error/next_attempt_at/delivered_at）
+	UpdateDeliveryAttempt(ctx context.Context, delivery *WebhookDelivery) error
+	DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// WebhookSendResult 一次 HTTP 投递的响应
+type WebhookSendResult struct {
+	StatusCode int
+	Body       string
+}
+
+// WebhookSender 执行 Webhook HTTP 请求
+type WebhookSender interface {
+	Send(ctx context.Context, targetURL string, header http.Header, body []byte) (*WebhookSendResult, error)
+}
+
+// webhookRequest 渲染后的待发送请求
+type webhookRequest struct {
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// SignWebhookPayload 计算通用签名：sha256=hex(HMAC-SHA256(secret, "{timestamp}.{body}"))
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// dingTalkSign 钉钉加签：base64(HMAC-SHA256(secret, "{timestamp_ms}\n{secret}"))
+func dingTalkSign(secret string, timestampMs int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestampMs, 10) + "\n" + secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// feishuSign 飞书签名校验：以 "{timestamp}\n{secret}" 为密钥对空串做 HMAC-SHA256 后 base64
+func feishuSign(secret string, timestampSec int64) string {
+	mac := hmac.New(sha256.New, []byte(strconv.FormatInt(timestampSec, 10)+"\n"+secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// buildWebhookRequest 按端点格式渲染请求体并签名
+func buildWebhookRequest(endpoint *WebhookEndpoint, deliveryID int64, event *WebhookEvent, now time.Time) (*webhookRequest, error) {
+	var (
+		body      any
+		targetURL = endpoint.URL
+	)
+	switch endpoint.Format {
+	case WebhookFormatSlack:
+		body = map[string]any{"text": renderWebhookText(event, "*", "*")}
+	case WebhookFormatFeishu:
+		payload := map[string]any{
+			"msg_type": "text",
+			"content":  map[string]any{"text": renderWebhookText(event, "", "")},
+		}
+		if endpoint.Secret != "" {
+			ts := now.Unix()
+			payload["timestamp"] = strconv.FormatInt(ts, 10)
+			payload["sign"] = feishuSign(endpoint.Secret, ts)
+		}
+		body = payload
+	case WebhookFormatDingTalk:
+		body = map[string]any{
+			"msgtype": "markdown",
+			"markdown": map[string]any{
+				"title": webhookTitle(event),
+				"text":  renderWebhookMarkdown(event),
+			},
+		}
+		if endpoint.Secret != "" {
+			signed, err := appendDingTalkSign(targetURL, endpoint.Secret, now.UnixMilli())
+			if err != nil {
+				return nil, err
+			}
+			targetURL = signed
+		}
+	case WebhookFormatWeCom:
+		body = map[string]any{
+			"msgtype":  "markdown",
+			"markdown": map[string]any{"content": renderWebhookMarkdown(event)},
+		}
+	default:
+		body = map[string]any{
+			"id":          deliveryID,
+			"event":       event.Type,
+			"severity":    event.Severity,
+			"title":       event.Title,
+			"message":     event.Message,
+			"occurred_at": event.OccurredAt.UTC().Format(time.RFC3339),
+			"data":        event.Data,
+		}
+	}
+
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal webhook body: %w", err)
+	}
+
+	ts := now.Unix()
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("User-Agent", "sub2api-webhook/1.0")
+	header.Set(WebhookHeaderEvent, event.Type)
+	header.Set(WebhookHeaderDelivery, strconv.FormatInt(deliveryID, 10))
+	header.Set(WebhookHeaderTimestamp, strconv.FormatInt(ts, 10))
+	if endpoint.Secret != "" {
+		header.Set(WebhookHeaderSignature, SignWebhookPayload(endpoint.Secret, ts, raw))
+	}
+	return &webhookRequest{URL: targetURL, Header: header, Body: raw}, nil
+}
+
+func appendDingTalkSign(rawURL, secret string, timestampMs int64) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parse dingtalk url: %w", err)
+	}
+	q := u.Query()
+	q.Set("timestamp", strconv.FormatInt(timestampMs, 10))
+	q.Set("sign", dingTalkSign(secret, timestampMs))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// checkWebhookResponse 判断投递是否成功。
+// 飞书/钉钉/企业微信机器人在业务失败时仍返回 200，需要检查响应体中的错误码。
+func checkWebhookResponse(format string, result *WebhookSendResult) error {
+	if result == nil {
+		return fmt.Errorf("empty response")
+	}
+	if result.StatusCode < 200 || result.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", result.StatusCode)
+	}
+	switch format {
+	case WebhookFormatDingTalk, WebhookFormatWeCom:
+		if code := gjson.Get(result.Body, "errcode"); code.Exists() && code.Int() != 0 {
+			return fmt.Errorf("errcode %d: %s", code.Int(), gjson.Get(result.Body, "errmsg").String())
+		}
+	case WebhookFormatFeishu:
+		// 新版返回 code/msg，旧版返回 StatusCode/StatusMessage
+		if code := gjson.Get(result.Body, "code"); code.Exists() && code.Int() != 0 {
+			return fmt.Errorf("code %d: %s", code.Int(), gjson.Get(result.Body, "msg").String())
+		}
+		if code := gjson.Get(result.Body, "StatusCode"); code.Exists() && code.Int() != 0 {
+			return fmt.Errorf("code %d: %s", code.Int(), gjson.Get(result.Body, "StatusMessage").String())
+		}
+	}
+	return nil
+}
+
+func webhookTitle(event *WebhookEvent) string {
+	title := strings.TrimSpace(event.Title)
+	if title == "" {
+		title = event.Type
+	}
+	return "[Sub2API] " + title
+}
+
+func webhookSeverityLabel(severity string) string {
+	switch severity {
+	case WebhookSeverityCritical:
+		return "CRITICAL"
+	case WebhookSeverityWarning:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
+
+// renderWebhookText 纯文本消息（Slack 使用 *bold*，飞书不支持格式时传空）
+func renderWebhookText(event *WebhookEvent, boldOpen, boldClose string) string {
+	var b strings.Builder
+	b.WriteString(boldOpen + webhookTitle(event) + boldClose + "\n")
+	if event.Message != "" {
+		b.WriteString(event.Message + "\n")
+	}
+	b.WriteString("Severity: " + webhookSeverityLabel(event.Severity) + "\n")
+	b.WriteString("Event: " + event.Type + "\n")
+	for _, kv := range sortedWebhookData(event.Data) {
+		b.WriteString(kv[0] + ": " + kv[1] + "\n")
+	}
+	b.WriteString("Time: " + event.OccurredAt.UTC().Format(time.RFC3339))
+	return b.String()
+}
+
+// renderWebhookMarkdown 钉钉/企业微信 Markdown 消息
+func renderWebhookMarkdown(event *WebhookEvent) string {
+	var b strings.Builder
+	b.WriteString("### " + webhookTitle(event) + "\n\n")
+	if event.Message != "" {
+		b.WriteString(event.Message + "\n\n")
+	}
+	b.WriteString("- Severity: **" + webhookSeverityLabel(event.Severity) + "**\n")
+	b.WriteString("- Event: " + event.Type + "\n")
+	for _, kv := range sortedWebhookData(event.Data) {
+		b.WriteString("- " + kv[0] + ": " + kv[1] + "\n")
+	}
+	b.WriteString("- Time: " + event.OccurredAt.UTC().Format(time.RFC3339))
+	return b.String()
+}
+
+func sortedWebhookData(data map[string]any) [][2]string {
+	if len(data) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([][2]string, 0, len(keys))
+	for _, k := range keys {
+		v := data[k]
+		if v == nil {
+			continue
+		}
+		var s string
+		switch val := v.(type) {
+		case string:
+			s = val
+		case time.Time:
+			s = val.UTC().Format(time.RFC3339)
+		case *time.Time:
+			if val == nil {
+				continue
+			}
+			s = val.UTC().Format(time.RFC3339)
+		default:
+			s = fmt.Sprint(val)
+		}
+		out = append(out, [2]string{k, s})
+	}
+	return out
+}
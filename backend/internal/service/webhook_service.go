@@ -0,0 +1,681 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
+)
+
+const (
+	webhookWorkerName = "webhook_delivery_worker"
+
+	webhookQueueSize       = 512
+	webhookDispatchWorkers = 2
+	webhookClaimBatchSize  = 50
+	// webhookClaimLease 领取后的租约：投递进程异常退出时，租约到期后由任意实例重新领取
+	webhookClaimLease       = 2 * time.Minute
+	webhookDedupeWindow     = 5 * time.Minute
+	webhookMaxBackoff       = 6 * time.Hour
+	webhookEndpointCacheTTL = 30 * time.Second
+	webhookCleanupInterval  = time.Hour
+	webhookResponseMaxBytes = 2048
+	webhookSecretPrefix     = "whsec_"
+)
+
+// WebhookService 出站 Webhook：端点管理、事件分发、签名投递与失败重试。
+//
+// Emit 只做内存入队，不阻塞调用方（网关失败切换、定时任务等路径）；
+// 投递记录先落库再发送，失败按指数退避由后台任务重试，超过最大次数后标记为 failed。
+type WebhookService struct {
+	repo        WebhookRepository
+	sender      WebhookSender
+	timingWheel *TimingWheelService
+	cfg         *config.Config
+
+	queue  chan WebhookEvent
+	dedupe sync.Map // dedupeKey -> time.Time
+
+	endpointsMu       sync.Mutex
+	endpointsCache    []WebhookEndpoint
+	endpointsCachedAt time.Time
+
+	lastCleanupAt time.Time
+
+	workerCtx    context.Context
+	workerCancel context.CancelFunc
+	wg           sync.WaitGroup
+	startOnce    sync.Once
+	stopOnce     sync.Once
+}
+
+// NewWebhookService 创建 Webhook 服务
+func NewWebhookService(repo WebhookRepository, sender WebhookSender, timingWheel *TimingWheelService, cfg *config.Config) *WebhookService {
+	workerCtx, workerCancel := context.WithCancel(context.Background())
+	return &WebhookService{
+		repo:         repo,
+		sender:       sender,
+		timingWheel:  timingWheel,
+		cfg:          cfg,
+		queue:        make(chan WebhookEvent, webhookQueueSize),
+		workerCtx:    workerCtx,
+		workerCancel: workerCancel,
+	}
+}
+
+// Start 启动分发协程与重试任务
+func (s *WebhookService) Start() {
+	if s == nil {
+		return
+	}
+	if !s.enabled() {
+		log.Printf("[Webhook] delivery worker not started (disabled)")
+		return
+	}
+	if s.repo == nil || s.sender == nil || s.timingWheel == nil {
+		log.Printf("[Webhook] delivery worker not started (missing deps)")
+		return
+	}
+
+	s.startOnce.Do(func() {
+		for i := 0; i < webhookDispatchWorkers; i++ {
+			s.wg.Add(1)
+			go s.dispatchLoop()
+		}
+		interval := time.Duration(s.cfg.Webhook.PollIntervalSeconds) * time.Second
+		s.timingWheel.ScheduleRecurring(webhookWorkerName, interval, s.runOnce)
+		log.Printf("[Webhook] delivery worker started (interval=%s max_attempts=%d)", interval, s.maxAttempts())
+	})
+}
+
+// Stop 停止后台任务；队列中未落库的事件会被丢弃
+func (s *WebhookService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		if s.workerCancel != nil {
+			s.workerCancel()
+		}
+		if s.timingWheel != nil {
+			s.timingWheel.Cancel(webhookWorkerName)
+		}
+		s.wg.Wait()
+		log.Printf("[Webhook] delivery worker stopped")
+	})
+}
+
+func (s *WebhookService) enabled() bool {
+	return s != nil && s.cfg != nil && s.cfg.Webhook.Enabled
+}
+
+// Emit 异步投递事件到所有订阅的端点。队列已满或重复事件时直接丢弃。
+func (s *WebhookService) Emit(event WebhookEvent) {
+	if !s.enabled() || event.Type == "" {
+		return
+	}
+	now := time.Now()
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = now
+	}
+	if event.Severity == "" {
+		event.Severity = WebhookSeverityInfo
+	}
+	if event.DedupeKey != "" {
+		key := event.Type + ":" + event.DedupeKey
+		if last, ok := s.dedupe.Load(key); ok && now.Sub(last.(time.Time)) < webhookDedupeWindow {
+			return
+		}
+		s.dedupe.Store(key, now)
+	}
+
+	select {
+	case s.queue <- event:
+	default:
+		log.Printf("[Webhook] queue full, dropping event: type=%s title=%q", event.Type, event.Title)
+	}
+}
+
+func (s *WebhookService) dispatchLoop() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.workerCtx.Done():
+			return
+		case event := <-s.queue:
+			s.dispatch(s.workerCtx, &event)
+		}
+	}
+}
+
+// dispatch 为每个订阅端点落库一条投递记录并立即尝试发送
+func (s *WebhookService) dispatch(ctx context.Context, event *WebhookEvent) {
+	endpoints, err := s.enabledEndpoints(ctx)
+	if err != nil {
+		log.Printf("[Webhook] list endpoints failed: %v", err)
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[Webhook] marshal event failed: type=%s err=%v", event.Type, err)
+		return
+	}
+
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		if !endpoint.Subscribes(event.Type) {
+			continue
+		}
+		delivery, err := s.createDelivery(ctx, endpoint.ID, event.Type, payload)
+		if err != nil {
+			log.Printf("[Webhook] create delivery failed: endpoint=%d type=%s err=%v", endpoint.ID, event.Type, err)
+			continue
+		}
+		_ = s.attempt(ctx, endpoint, delivery)
+	}
+	s.pruneDedupe()
+}
+
+func (s *WebhookService) createDelivery(ctx context.Context, endpointID int64, eventType string, payload []byte) (*WebhookDelivery, error) {
+	// 先占用租约，避免与后台重试任务并发发送同一条记录
+	leaseUntil := time.Now().Add(webhookClaimLease)
+	delivery := &WebhookDelivery{
+		EndpointID:    endpointID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: &leaseUntil,
+	}
+	if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// attempt 发送一次并写回结果；返回本次投递的错误（已记录到投递日志）
+func (s *WebhookService) attempt(ctx context.Context, endpoint *WebhookEndpoint, delivery *WebhookDelivery) error {
+	now := time.Now()
+	sendErr := s.send(ctx, endpoint, delivery, now)
+
+	delivery.Attempts++
+	if sendErr == nil {
+		delivery.Status = WebhookDeliveryStatusSuccess
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.ErrorMessage = nil
+	} else {
+		msg := truncateString(sendErr.Error(), webhookResponseMaxBytes)
+		delivery.ErrorMessage = &msg
+		if delivery.Attempts >= s.maxAttempts() {
+			delivery.Status = WebhookDeliveryStatusFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			next := now.Add(webhookRetryBackoff(s.retryBase(), delivery.Attempts))
+			delivery.Status = WebhookDeliveryStatusPending
+			delivery.NextAttemptAt = &next
+		}
+	}
+
+	// 写回结果不受请求 ctx 取消影响，避免“已发送但未记录”导致重复投递
+	updateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.repo.UpdateDeliveryAttempt(updateCtx, delivery); err != nil {
+		log.Printf("[Webhook] update delivery failed: delivery=%d err=%v", delivery.ID, err)
+	}
+	if sendErr != nil {
+		log.Printf("[Webhook] delivery failed: delivery=%d endpoint=%d type=%s attempt=%d status=%s err=%v",
+			delivery.ID, endpoint.ID, delivery.EventType, delivery.Attempts, delivery.Status, sendErr)
+	}
+	return sendErr
+}
+
+func (s *WebhookService) send(ctx context.Context, endpoint *WebhookEndpoint, delivery *WebhookDelivery, now time.Time) error {
+	delivery.ResponseStatus = nil
+	delivery.ResponseBody = nil
+	if !endpoint.Enabled && delivery.EventType != WebhookEventTest {
+		return fmt.Errorf("endpoint disabled")
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(delivery.Payload, &event); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	req, err := buildWebhookRequest(endpoint, delivery.ID, &event, now)
+	if err != nil {
+		return err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.timeout())
+	defer cancel()
+	result, err := s.sender.Send(sendCtx, req.URL, req.Header, req.Body)
+	if err != nil {
+		return err
+	}
+	status := result.StatusCode
+	body := truncateString(result.Body, webhookResponseMaxBytes)
+	delivery.ResponseStatus = &status
+	delivery.ResponseBody = &body
+	return checkWebhookResponse(endpoint.Format, result)
+}
+
+// runOnce 后台任务：重试到期的投递并清理过期日志
+func (s *WebhookService) runOnce() {
+	ctx, cancel := context.WithTimeout(s.workerCtx, webhookClaimLease)
+	defer cancel()
+
+	deliveries, err := s.repo.ClaimDueDeliveries(ctx, time.Now(), webhookClaimBatchSize, webhookClaimLease)
+	if err != nil {
+		log.Printf("[Webhook] claim deliveries failed: %v", err)
+	} else if len(deliveries) > 0 {
+		endpoints, err := s.repo.ListEndpoints(ctx)
+		if err != nil {
+			log.Printf("[Webhook] list endpoints failed: %v", err)
+			return
+		}
+		byID := make(map[int64]*WebhookEndpoint, len(endpoints))
+		for i := range endpoints {
+			byID[endpoints[i].ID] = &endpoints[i]
+		}
+		for i := range deliveries {
+			if ctx.Err() != nil {
+				return
+			}
+			endpoint := byID[deliveries[i].EndpointID]
+			if endpoint == nil {
+				continue
+			}
+			_ = s.attempt(ctx, endpoint, &deliveries[i])
+		}
+	}
+
+	s.cleanup(ctx)
+}
+
+func (s *WebhookService) cleanup(ctx context.Context) {
+	days := s.cfg.Webhook.RetentionDays
+	if days <= 0 || time.Since(s.lastCleanupAt) < webhookCleanupInterval {
+		return
+	}
+	s.lastCleanupAt = time.Now()
+	cutoff := time.Now().AddDate(0, 0, -days)
+	deleted, err := s.repo.DeleteDeliveriesBefore(ctx, cutoff)
+	if err != nil {
+		log.Printf("[Webhook] cleanup deliveries failed: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("[Webhook] cleaned up %d deliveries older than %d days", deleted, days)
+	}
+}
+
+func (s *WebhookService) pruneDedupe() {
+	now := time.Now()
+	s.dedupe.Range(func(key, value any) bool {
+		if now.Sub(value.(time.Time)) >= webhookDedupeWindow {
+			s.dedupe.Delete(key)
+		}
+		return true
+	})
+}
+
+// webhookRetryBackoff 第 n 次失败后的等待时间：base * 2^(n-1)，上限 webhookMaxBackoff
+func webhookRetryBackoff(base time.Duration, attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := base
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= webhookMaxBackoff {
+			return webhookMaxBackoff
+		}
+	}
+	return backoff
+}
+
+func (s *WebhookService) maxAttempts() int {
+	if s.cfg != nil && s.cfg.Webhook.MaxAttempts > 0 {
+		return s.cfg.Webhook.MaxAttempts
+	}
+	return 6
+}
+
+func (s *WebhookService) retryBase() time.Duration {
+	if s.cfg != nil && s.cfg.Webhook.RetryBaseSeconds > 0 {
+		return time.Duration(s.cfg.Webhook.RetryBaseSeconds) * time.Second
+	}
+	return 30 * time.Second
+}
+
+func (s *WebhookService) timeout() time.Duration {
+	if s.cfg != nil && s.cfg.Webhook.TimeoutSeconds > 0 {
+		return time.Duration(s.cfg.Webhook.TimeoutSeconds) * time.Second
+	}
+	return 10 * time.Second
+}
+
+func (s *WebhookService) enabledEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	s.endpointsMu.Lock()
+	defer s.endpointsMu.Unlock()
+	if s.endpointsCache != nil && time.Since(s.endpointsCachedAt) < webhookEndpointCacheTTL {
+		return s.endpointsCache, nil
+	}
+	all, err := s.repo.ListEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enabled := make([]WebhookEndpoint, 0, len(all))
+	for _, e := range all {
+		if e.Enabled {
+			enabled = append(enabled, e)
+		}
+	}
+	s.endpointsCache = enabled
+	s.endpointsCachedAt = time.Now()
+	return enabled, nil
+}
+
+func (s *WebhookService) invalidateEndpoints() {
+	s.endpointsMu.Lock()
+	s.endpointsCache = nil
+	s.endpointsMu.Unlock()
+}
+
+// ============================================
+// 管理接口
+// ============================================
+
+// CreateWebhookEndpointInput 创建端点参数
+type CreateWebhookEndpointInput struct {
+	Name    string
+	URL     string
+	Secret  string
+	Format  string
+	Events  []string
+	Enabled bool
+}
+
+// UpdateWebhookEndpointInput 更新端点参数（nil 表示不修改）
+type UpdateWebhookEndpointInput struct {
+	Name    *string
+	URL     *string
+	Secret  *string
+	Format  *string
+	Events  []string
+	Enabled *bool
+}
+
+// ListEndpoints 列出全部端点
+func (s *WebhookService) ListEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	return s.repo.ListEndpoints(ctx)
+}
+
+// GetEndpoint 获取端点
+func (s *WebhookService) GetEndpoint(ctx context.Context, id int64) (*WebhookEndpoint, error) {
+	return s.repo.GetEndpoint(ctx, id)
+}
+
+// CreateEndpoint 创建端点；generic 格式未指定密钥时自动生成
+func (s *WebhookService) CreateEndpoint(ctx context.Context, input *CreateWebhookEndpointInput) (*WebhookEndpoint, error) {
+	endpoint := &WebhookEndpoint{
+		Name:    strings.TrimSpace(input.Name),
+		Secret:  strings.TrimSpace(input.Secret),
+		Format:  strings.TrimSpace(input.Format),
+		Enabled: input.Enabled,
+	}
+	if endpoint.Format == "" {
+		endpoint.Format = WebhookFormatGeneric
+	}
+	if !IsValidWebhookFormat(endpoint.Format) {
+		return nil, ErrWebhookInvalidFormat
+	}
+	if endpoint.Name == "" {
+		return nil, infraerrors.BadRequest("WEBHOOK_NAME_REQUIRED", "name is required")
+	}
+	normalized, err := s.validateURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.URL = normalized
+	if endpoint.Events, err = normalizeWebhookEvents(input.Events); err != nil {
+		return nil, err
+	}
+	if endpoint.Secret == "" && endpoint.Format == WebhookFormatGeneric {
+		if endpoint.Secret, err = generateWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	s.invalidateEndpoints()
+	return endpoint, nil
+}
+
+// UpdateEndpoint 更新端点
+func (s *WebhookService) UpdateEndpoint(ctx context.Context, id int64, input *UpdateWebhookEndpointInput) (*WebhookEndpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, infraerrors.BadRequest("WEBHOOK_NAME_REQUIRED", "name is required")
+		}
+		endpoint.Name = name
+	}
+	if input.URL != nil {
+		normalized, err := s.validateURL(*input.URL)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.URL = normalized
+	}
+	if input.Format != nil {
+		format := strings.TrimSpace(*input.Format)
+		if !IsValidWebhookFormat(format) {
+			return nil, ErrWebhookInvalidFormat
+		}
+		endpoint.Format = format
+	}
+	if input.Secret != nil {
+		endpoint.Secret = strings.TrimSpace(*input.Secret)
+	}
+	if input.Events != nil {
+		if endpoint.Events, err = normalizeWebhookEvents(input.Events); err != nil {
+			return nil, err
+		}
+	}
+	if input.Enabled != nil {
+		endpoint.Enabled = *input.Enabled
+	}
+
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	s.invalidateEndpoints()
+	return endpoint, nil
+}
+
+// DeleteEndpoint 删除端点（投递日志级联删除）
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, id int64) error {
+	if err := s.repo.DeleteEndpoint(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateEndpoints()
+	return nil
+}
+
+// TestEndpoint 同步发送一条测试事件并返回投递记录（不受端点启用状态影响）
+func (s *WebhookService) TestEndpoint(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	event := WebhookEvent{
+		Type:       WebhookEventTest,
+		Severity:   WebhookSeverityInfo,
+		Title:      "Test notification",
+		Message:    fmt.Sprintf("Webhook endpoint %q is configured correctly.", endpoint.Name),
+		OccurredAt: time.Now(),
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := s.createDelivery(ctx, endpoint.ID, event.Type, payload)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.attempt(ctx, endpoint, delivery)
+	return delivery, nil
+}
+
+// SendDirect 以通用格式同步投递一次事件到指定 URL，不落库、不重试。
+// 用于用户自行配置的通知地址（如低余额提醒），签名使用用户自己的密钥。
+func (s *WebhookService) SendDirect(ctx context.Context, targetURL, secret string, event WebhookEvent) error {
+	if !s.enabled() {
+		return ErrWebhookDisabled
+	}
+	now := time.Now()
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = now
+	}
+	if event.Severity == "" {
+		event.Severity = WebhookSeverityInfo
+	}
+	endpoint := &WebhookEndpoint{URL: targetURL, Secret: secret, Format: WebhookFormatGeneric, Enabled: true}
+	req, err := buildWebhookRequest(endpoint, 0, &event, now)
+	if err != nil {
+		return err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.timeout())
+	defer cancel()
+	result, err := s.sender.Send(sendCtx, req.URL, req.Header, req.Body)
+	if err != nil {
+		return err
+	}
+	return checkWebhookResponse(endpoint.Format, result)
+}
+
+// ListDeliveries 分页列出端点的投递日志
+func (s *WebhookService) ListDeliveries(ctx context.Context, endpointID int64, status string, params pagination.PaginationParams) ([]WebhookDelivery, *pagination.PaginationResult, error) {
+	if _, err := s.repo.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, nil, err
+	}
+	return s.repo.ListDeliveries(ctx, endpointID, status, params)
+}
+
+// RetryDelivery 立即重新投递一条记录（无论当前状态）
+func (s *WebhookService) RetryDelivery(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	delivery, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := s.repo.GetEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.attempt(ctx, endpoint, delivery)
+	return delivery, nil
+}
+
+func (s *WebhookService) validateURL(raw string) (string, error) {
+	var (
+		normalized string
+		err        error
+	)
+	if s.cfg == nil || !s.cfg.Security.URLAllowlist.Enabled {
+		allowInsecure := s.cfg != nil && s.cfg.Security.URLAllowlist.AllowInsecureHTTP
+		normalized, err = urlvalidator.ValidateURLFormat(raw, allowInsecure)
+	} else {
+		normalized, err = urlvalidator.ValidateHTTPSURL(raw, urlvalidator.ValidationOptions{
+			AllowPrivate: s.cfg.Security.URLAllowlist.AllowPrivateHosts,
+		})
+	}
+	if err != nil {
+		return "", infraerrors.BadRequest("WEBHOOK_INVALID_URL", fmt.Sprintf("invalid webhook url: %v", err))
+	}
+	return normalized, nil
+}
+
+func normalizeWebhookEvents(events []string) ([]string, error) {
+	out := make([]string, 0, len(events))
+	seen := make(map[string]struct{}, len(events))
+	for _, e := range events {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if !IsValidWebhookEventType(e) {
+			return nil, ErrWebhookInvalidEvent
+		}
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(buf), nil
+}
+
+// ============================================
+// 事件构造
+// ============================================
+
+// NotifyAccountEvent 账号状态变化事件（异常、限流、过期）
+func (s *WebhookService) NotifyAccountEvent(eventType string, account *Account, message string, extra map[string]any) {
+	if !s.enabled() || account == nil {
+		return
+	}
+	severity := WebhookSeverityWarning
+	title := "Account error"
+	switch eventType {
+	case WebhookEventAccountRateLimited:
+		severity = WebhookSeverityInfo
+		title = "Account rate limited"
+	case WebhookEventAccountExpired:
+		title = "Account expired"
+	case WebhookEventTokenRefreshFailed:
+		title = "Token refresh failed"
+	}
+	data := map[string]any{
+		"account_id":   account.ID,
+		"account_name": account.Name,
+		"platform":     account.Platform,
+		"type":         account.Type,
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+	s.Emit(WebhookEvent{
+		Type:      eventType,
+		Severity:  severity,
+		Title:     fmt.Sprintf("%s: %s", title, account.Name),
+		Message:   message,
+		Data:      data,
+		DedupeKey: strconv.FormatInt(account.ID, 10),
+	})
+}
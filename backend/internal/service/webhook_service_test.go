@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookRepoStub struct {
+	endpoints  []WebhookEndpoint
+	deliveries []*WebhookDelivery
+	updates    []WebhookDelivery
+}
+
+func (r *webhookRepoStub) ListEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	return r.endpoints, nil
+}
+
+func (r *webhookRepoStub) GetEndpoint(ctx context.Context, id int64) (*WebhookEndpoint, error) {
+	for i := range r.endpoints {
+		if r.endpoints[i].ID == id {
+			e := r.endpoints[i]
+			return &e, nil
+		}
+	}
+	return nil, ErrWebhookEndpointNotFound
+}
+
+func (r *webhookRepoStub) CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error {
+	endpoint.ID = int64(len(r.endpoints) + 1)
+	r.endpoints = append(r.endpoints, *endpoint)
+	return nil
+}
+
+func (r *webhookRepoStub) UpdateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error {
+	return nil
+}
+
+func (r *webhookRepoStub) DeleteEndpoint(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (r *webhookRepoStub) CreateDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	delivery.ID = int64(len(r.deliveries) + 1)
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func (r *webhookRepoStub) GetDelivery(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	return nil, ErrWebhookDeliveryNotFound
+}
+
+func (r *webhookRepoStub) ListDeliveries(ctx context.Context, endpointID int64, status string, params pagination.PaginationParams) ([]WebhookDelivery, *pagination.PaginationResult, error) {
+	return nil, &pagination.PaginationResult{}, nil
+}
+
+func (r *webhookRepoStub) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (r *webhookRepoStub) UpdateDeliveryAttempt(ctx context.Context, delivery *WebhookDelivery) error {
+	r.updates = append(r.updates, *delivery)
+	return nil
+}
+
+func (r *webhookRepoStub) DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+type webhookSenderStub struct {
+	results []*WebhookSendResult
+	errs    []error
+	urls    []string
+}
+
+func (s *webhookSenderStub) Send(ctx context.Context, targetURL string, header http.Header, body []byte) (*WebhookSendResult, error) {
+	i := len(s.urls)
+	s.urls = append(s.urls, targetURL)
+	var result *WebhookSendResult
+	var err error
+	if i < len(s.results) {
+		result = s.results[i]
+	}
+	if i < len(s.errs) {
+		err = s.errs[i]
+	}
+	return result, err
+}
+
+func newTestWebhookService(repo WebhookRepository, sender WebhookSender) *WebhookService {
+	cfg := &config.Config{Webhook: config.WebhookConfig{
+		Enabled:          true,
+		TimeoutSeconds:   5,
+		MaxAttempts:      2,
+		RetryBaseSeconds: 10,
+	}}
+	return NewWebhookService(repo, sender, nil, cfg)
+}
+
+func TestWebhookService_DispatchRetriesThenFails(t *testing.T) {
+	ctx := context.Background()
+	repo := &webhookRepoStub{endpoints: []WebhookEndpoint{
+		{ID: 1, URL: "https://a.example.com", Format: WebhookFormatGeneric, Enabled: true, Events: []string{WebhookEventAccountError}},
+		{ID: 2, URL: "https://b.example.com", Format: WebhookFormatGeneric, Enabled: true, Events: []string{WebhookEventAlertFired}},
+		{ID: 3, URL: "https://c.example.com", Format: WebhookFormatGeneric, Enabled: false},
+	}}
+	sender := &webhookSenderStub{
+		results: []*WebhookSendResult{{StatusCode: 502, Body: "bad gateway"}, nil},
+		errs:    []error{nil, errors.New("connection refused")},
+	}
+	svc := newTestWebhookService(repo, sender)
+
+	svc.dispatch(ctx, &WebhookEvent{Type: WebhookEventAccountError, Title: "x", OccurredAt: time.Now()})
+	require.Equal(t, []string{"https://a.example.com"}, sender.urls)
+	require.Len(t, repo.deliveries, 1)
+
+	first := repo.updates[0]
+	require.Equal(t, WebhookDeliveryStatusPending, first.Status)
+	require.Equal(t, 1, first.Attempts)
+	require.Equal(t, 502, *first.ResponseStatus)
+	require.NotNil(t, first.NextAttemptAt)
+	require.WithinDuration(t, time.Now().Add(10*time.Second), *first.NextAttemptAt, 2*time.Second)
+
+	endpoint := repo.endpoints[0]
+	require.Error(t, svc.attempt(ctx, &endpoint, repo.deliveries[0]))
+	second := repo.updates[1]
+	require.Equal(t, WebhookDeliveryStatusFailed, second.Status)
+	require.Equal(t, 2, second.Attempts)
+	require.Nil(t, second.NextAttemptAt)
+	require.Nil(t, second.ResponseStatus)
+	require.Contains(t, *second.ErrorMessage, "connection refused")
+}
+
+func TestWebhookService_AttemptSuccess(t *testing.T) {
+	repo := &webhookRepoStub{}
+	sender := &webhookSenderStub{results: []*WebhookSendResult{{StatusCode: 200, Body: "ok"}}}
+	svc := newTestWebhookService(repo, sender)
+
+	endpoint := &WebhookEndpoint{ID: 1, URL: "https://a.example.com", Format: WebhookFormatGeneric, Enabled: true}
+	delivery := &WebhookDelivery{ID: 9, EndpointID: 1, EventType: WebhookEventAlertFired, Payload: []byte(`{"event":"alert.fired"}`)}
+	require.NoError(t, svc.attempt(context.Background(), endpoint, delivery))
+	require.Equal(t, WebhookDeliveryStatusSuccess, delivery.Status)
+	require.NotNil(t, delivery.DeliveredAt)
+	require.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestWebhookService_EmitDedupe(t *testing.T) {
+	svc := newTestWebhookService(&webhookRepoStub{}, &webhookSenderStub{})
+
+	svc.Emit(WebhookEvent{Type: WebhookEventAccountError, DedupeKey: "1"})
+	svc.Emit(WebhookEvent{Type: WebhookEventAccountError, DedupeKey: "1"})
+	svc.Emit(WebhookEvent{Type: WebhookEventAccountError, DedupeKey: "2"})
+	svc.Emit(WebhookEvent{Type: WebhookEventAccountRateLimited, DedupeKey: "1"})
+	require.Len(t, svc.queue, 3)
+
+	var disabled *WebhookService
+	require.NotPanics(t, func() { disabled.Emit(WebhookEvent{Type: WebhookEventAccountError}) })
+}
+
+func TestWebhookService_CreateEndpoint(t *testing.T) {
+	ctx := context.Background()
+	repo := &webhookRepoStub{}
+	svc := newTestWebhookService(repo, &webhookSenderStub{})
+
+	endpoint, err := svc.CreateEndpoint(ctx, &CreateWebhookEndpointInput{
+		Name:    "ops",
+		URL:     "https://hooks.example.com/x",
+		Events:  []string{WebhookEventAlertFired, WebhookEventAlertFired, " "},
+		Enabled: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, WebhookFormatGeneric, endpoint.Format)
+	require.True(t, strings.HasPrefix(endpoint.Secret, webhookSecretPrefix))
+	require.Equal(t, []string{WebhookEventAlertFired}, endpoint.Events)
+
+	// 聊天机器人格式不自动生成密钥（钉钉/飞书密钥由平台下发）
+	endpoint, err = svc.CreateEndpoint(ctx, &CreateWebhookEndpointInput{Name: "dt", URL: "https://oapi.dingtalk.com/robot/send", Format: WebhookFormatDingTalk})
+	require.NoError(t, err)
+	require.Empty(t, endpoint.Secret)
+
+	_, err = svc.CreateEndpoint(ctx, &CreateWebhookEndpointInput{Name: "bad", URL: "ftp://x"})
+	require.Error(t, err)
+	_, err = svc.CreateEndpoint(ctx, &CreateWebhookEndpointInput{Name: "bad", URL: "https://x.example.com", Events: []string{"nope"}})
+	require.ErrorIs(t, err, ErrWebhookInvalidEvent)
+	_, err = svc.CreateEndpoint(ctx, &CreateWebhookEndpointInput{Name: "bad", URL: "https://x.example.com", Format: "teams"})
+	require.ErrorIs(t, err, ErrWebhookInvalidFormat)
+}
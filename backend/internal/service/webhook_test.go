@@ -0,0 +1,141 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func testWebhookEvent() *WebhookEvent {
+	return &WebhookEvent{
+		Type:       WebhookEventAccountRateLimited,
+		Severity:   WebhookSeverityWarning,
+		Title:      "Account rate limited: claude-1",
+		Message:    "paused until reset",
+		Data:       map[string]any{"account_id": int64(7), "platform": "anthropic"},
+		OccurredAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"event":"alert.fired"}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	require.Equal(t, expected, SignWebhookPayload("s3cret", 1700000000, body))
+	require.NotEqual(t, expected, SignWebhookPayload("other", 1700000000, body))
+	require.NotEqual(t, expected, SignWebhookPayload("s3cret", 1700000001, body))
+}
+
+func TestBuildWebhookRequest_Generic(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	endpoint := &WebhookEndpoint{ID: 1, URL: "https://example.com/hook", Secret: "whsec_x", Format: WebhookFormatGeneric}
+
+	req, err := buildWebhookRequest(endpoint, 42, testWebhookEvent(), now)
+	require.NoError(t, err)
+	require.Equal(t, endpoint.URL, req.URL)
+	require.Equal(t, int64(42), gjson.GetBytes(req.Body, "id").Int())
+	require.Equal(t, WebhookEventAccountRateLimited, gjson.GetBytes(req.Body, "event").String())
+	require.Equal(t, "2026-01-02T03:04:05Z", gjson.GetBytes(req.Body, "occurred_at").String())
+	require.Equal(t, int64(7), gjson.GetBytes(req.Body, "data.account_id").Int())
+
+	require.Equal(t, "1700000000", req.Header.Get(WebhookHeaderTimestamp))
+	require.Equal(t, "42", req.Header.Get(WebhookHeaderDelivery))
+	require.Equal(t, WebhookEventAccountRateLimited, req.Header.Get(WebhookHeaderEvent))
+	require.Equal(t, SignWebhookPayload("whsec_x", 1700000000, req.Body), req.Header.Get(WebhookHeaderSignature))
+
+	endpoint.Secret = ""
+	req, err = buildWebhookRequest(endpoint, 42, testWebhookEvent(), now)
+	require.NoError(t, err)
+	require.Empty(t, req.Header.Get(WebhookHeaderSignature))
+}
+
+func TestBuildWebhookRequest_ChatTemplates(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	event := testWebhookEvent()
+
+	t.Run("slack", func(t *testing.T) {
+		req, err := buildWebhookRequest(&WebhookEndpoint{URL: "https://hooks.slack.com/x", Format: WebhookFormatSlack}, 1, event, now)
+		require.NoError(t, err)
+		text := gjson.GetBytes(req.Body, "text").String()
+		require.Contains(t, text, "*[Sub2API] Account rate limited: claude-1*")
+		require.Contains(t, text, "account_id: 7")
+		require.Contains(t, text, "Severity: WARNING")
+	})
+
+	t.Run("feishu signed", func(t *testing.T) {
+		req, err := buildWebhookRequest(&WebhookEndpoint{URL: "https://open.feishu.cn/x", Secret: "fs", Format: WebhookFormatFeishu}, 1, event, now)
+		require.NoError(t, err)
+		require.Equal(t, "text", gjson.GetBytes(req.Body, "msg_type").String())
+		require.Contains(t, gjson.GetBytes(req.Body, "content.text").String(), "platform: anthropic")
+		require.Equal(t, "1700000000", gjson.GetBytes(req.Body, "timestamp").String())
+
+		mac := hmac.New(sha256.New, []byte("1700000000\nfs"))
+		require.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), gjson.GetBytes(req.Body, "sign").String())
+	})
+
+	t.Run("dingtalk signed", func(t *testing.T) {
+		req, err := buildWebhookRequest(&WebhookEndpoint{URL: "https://oapi.dingtalk.com/robot/send?access_token=abc", Secret: "SEC1", Format: WebhookFormatDingTalk}, 1, event, now)
+		require.NoError(t, err)
+		require.Equal(t, "markdown", gjson.GetBytes(req.Body, "msgtype").String())
+		require.Equal(t, "[Sub2API] Account rate limited: claude-1", gjson.GetBytes(req.Body, "markdown.title").String())
+
+		u, err := url.Parse(req.URL)
+		require.NoError(t, err)
+		require.Equal(t, "abc", u.Query().Get("access_token"))
+		require.Equal(t, "1700000000000", u.Query().Get("timestamp"))
+		mac := hmac.New(sha256.New, []byte("SEC1"))
+		mac.Write([]byte("1700000000000\nSEC1"))
+		require.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), u.Query().Get("sign"))
+	})
+
+	t.Run("wecom", func(t *testing.T) {
+		req, err := buildWebhookRequest(&WebhookEndpoint{URL: "https://qyapi.weixin.qq.com/x", Format: WebhookFormatWeCom}, 1, event, now)
+		require.NoError(t, err)
+		require.Equal(t, "markdown", gjson.GetBytes(req.Body, "msgtype").String())
+		content := gjson.GetBytes(req.Body, "markdown.content").String()
+		require.Contains(t, content, "### [Sub2API] Account rate limited: claude-1")
+		require.Contains(t, content, "- Time: 2026-01-02T03:04:05Z")
+	})
+}
+
+func TestCheckWebhookResponse(t *testing.T) {
+	require.NoError(t, checkWebhookResponse(WebhookFormatGeneric, &WebhookSendResult{StatusCode: 204}))
+	require.Error(t, checkWebhookResponse(WebhookFormatGeneric, &WebhookSendResult{StatusCode: 500}))
+	require.Error(t, checkWebhookResponse(WebhookFormatGeneric, nil))
+
+	require.NoError(t, checkWebhookResponse(WebhookFormatDingTalk, &WebhookSendResult{StatusCode: 200, Body: `{"errcode":0,"errmsg":"ok"}`}))
+	require.Error(t, checkWebhookResponse(WebhookFormatDingTalk, &WebhookSendResult{StatusCode: 200, Body: `{"errcode":310000,"errmsg":"sign not match"}`}))
+	require.Error(t, checkWebhookResponse(WebhookFormatWeCom, &WebhookSendResult{StatusCode: 200, Body: `{"errcode":93000,"errmsg":"invalid webhook url"}`}))
+	require.NoError(t, checkWebhookResponse(WebhookFormatFeishu, &WebhookSendResult{StatusCode: 200, Body: `{"code":0,"msg":"success"}`}))
+	require.Error(t, checkWebhookResponse(WebhookFormatFeishu, &WebhookSendResult{StatusCode: 200, Body: `{"code":19021,"msg":"sign match fail"}`}))
+	require.Error(t, checkWebhookResponse(WebhookFormatFeishu, &WebhookSendResult{StatusCode: 200, Body: `{"StatusCode":9499,"StatusMessage":"Bad Request"}`}))
+}
+
+func TestWebhookEndpointSubscribes(t *testing.T) {
+	all := &WebhookEndpoint{Enabled: true}
+	require.True(t, all.Subscribes(WebhookEventAlertFired))
+
+	some := &WebhookEndpoint{Enabled: true, Events: []string{WebhookEventAccountError}}
+	require.True(t, some.Subscribes(WebhookEventAccountError))
+	require.False(t, some.Subscribes(WebhookEventAlertFired))
+
+	disabled := &WebhookEndpoint{Events: nil}
+	require.False(t, disabled.Subscribes(WebhookEventAlertFired))
+}
+
+func TestWebhookRetryBackoff(t *testing.T) {
+	base := 30 * time.Second
+	require.Equal(t, 30*time.Second, webhookRetryBackoff(base, 1))
+	require.Equal(t, 60*time.Second, webhookRetryBackoff(base, 2))
+	require.Equal(t, 4*time.Minute, webhookRetryBackoff(base, 4))
+	require.Equal(t, webhookMaxBackoff, webhookRetryBackoff(base, 30))
+}
@@ -44,9 +44,11 @@ func ProvideTokenRefreshService(
 	geminiOAuthService *GeminiOAuthService,
 	antigravityOAuthService *AntigravityOAuthService,
 	cacheInvalidator TokenCacheInvalidator,
+	webhookService *WebhookService,
 	cfg *config.Config,
 ) *TokenRefreshService {
 	svc := NewTokenRefreshService(accountRepo, oauthService, openaiOAuthService, geminiOAuthService, antigravityOAuthService, cacheInvalidator, cfg)
+	svc.SetWebhookService(webhookService)
 	svc.Start()
 	return svc
 }
@@ -65,16 +67,78 @@ func ProvideUsageCleanupService(repo UsageCleanupRepository, timingWheel *Timing
 	return svc
 }
 
+// ProvideMessageBatchService 创建并启动批处理结算服务
+func ProvideMessageBatchService(
+	repo MessageBatchRepository,
+	accountRepo AccountRepository,
+	apiKeyRepo APIKeyRepository,
+	userSubRepo UserSubscriptionRepository,
+	gatewayService *GatewayService,
+	httpUpstream HTTPUpstream,
+	timingWheel *TimingWheelService,
+	cfg *config.Config,
+) *MessageBatchService {
+	svc := NewMessageBatchService(repo, accountRepo, apiKeyRepo, userSubRepo, gatewayService, httpUpstream, timingWheel, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideWebhookService 创建并启动出站 Webhook 投递服务
+func ProvideWebhookService(repo WebhookRepository, sender WebhookSender, timingWheel *TimingWheelService, cfg *config.Config) *WebhookService {
+	svc := NewWebhookService(repo, sender, timingWheel, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideOpsCaptureService 创建调试抓包服务并启动规则刷新与落库任务
+func ProvideOpsCaptureService(repo OpsCaptureRepository, encryptor SecretEncryptor, timingWheel *TimingWheelService, cfg *config.Config) *OpsCaptureService {
+	svc := NewOpsCaptureService(repo, encryptor, timingWheel, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideBalanceLedgerService 创建余额账本服务并启动周期性一致性检查
+func ProvideBalanceLedgerService(repo BalanceTransactionRepository, timingWheel *TimingWheelService) *BalanceLedgerService {
+	svc := NewBalanceLedgerService(repo, timingWheel)
+	svc.Start()
+	return svc
+}
+
+// ProvideInvoiceService 创建月度账单服务并启动每月自动生成
+func ProvideInvoiceService(repo InvoiceRepository, userRepo UserRepository, settingService *SettingService, timingWheel *TimingWheelService, cfg *config.Config) *InvoiceService {
+	svc := NewInvoiceService(repo, userRepo, settingService, timingWheel, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideBalanceAlertService 创建低余额提醒/自动充值服务，挂到计费缓存的扣费路径并启动
+func ProvideBalanceAlertService(
+	repo BalanceAlertRepository,
+	userRepo UserRepository,
+	redeemRepo RedeemCodeRepository,
+	userSubRepo UserSubscriptionRepository,
+	redeemService *RedeemService,
+	emailService *EmailService,
+	settingService *SettingService,
+	webhookService *WebhookService,
+	billingCacheService *BillingCacheService,
+) *BalanceAlertService {
+	svc := NewBalanceAlertService(repo, userRepo, redeemRepo, userSubRepo, redeemService, emailService, settingService, webhookService)
+	billingCacheService.SetBalanceObserver(svc)
+	svc.Start()
+	return svc
+}
+
 // ProvideAccountExpiryService creates and starts AccountExpiryService.
-func ProvideAccountExpiryService(accountRepo AccountRepository) *AccountExpiryService {
-	svc := NewAccountExpiryService(accountRepo, time.Minute)
+func ProvideAccountExpiryService(accountRepo AccountRepository, webhookService *WebhookService) *AccountExpiryService {
+	svc := NewAccountExpiryService(accountRepo, webhookService, time.Minute)
 	svc.Start()
 	return svc
 }
 
 // ProvideSubscriptionExpiryService creates and starts SubscriptionExpiryService.
-func ProvideSubscriptionExpiryService(userSubRepo UserSubscriptionRepository) *SubscriptionExpiryService {
-	svc := NewSubscriptionExpiryService(userSubRepo, time.Minute)
+func ProvideSubscriptionExpiryService(userSubRepo UserSubscriptionRepository, webhookService *WebhookService) *SubscriptionExpiryService {
+	svc := NewSubscriptionExpiryService(userSubRepo, webhookService, time.Minute)
 	svc.Start()
 	return svc
 }
@@ -97,14 +161,55 @@ func ProvideDeferredService(accountRepo AccountRepository, timingWheel *TimingWh
 }
 
 // ProvideConcurrencyService creates ConcurrencyService and starts slot cleanup worker.
-func ProvideConcurrencyService(cache ConcurrencyCache, accountRepo AccountRepository, cfg *config.Config) *ConcurrencyService {
+func ProvideConcurrencyService(cache ConcurrencyCache, waitQueue SlotWaitQueueCache, accountRepo AccountRepository, cfg *config.Config) *ConcurrencyService {
 	svc := NewConcurrencyService(cache)
 	if cfg != nil {
+		if cfg.Gateway.Scheduling.FairQueueEnabled {
+			svc.waitQueue = waitQueue
+		}
 		svc.StartSlotCleanupWorker(accountRepo, cfg.Gateway.Scheduling.SlotCleanupInterval)
 	}
 	return svc
 }
 
+// ProvideModelPriceService 创建自定义模型价格服务并启动价格索引周期刷新
+func ProvideModelPriceService(repo ModelPriceRepository, groupRepo GroupRepository) *ModelPriceService {
+	svc := NewModelPriceService(repo, groupRepo)
+	svc.Start()
+	return svc
+}
+
+// ProvideBillingService creates BillingService with admin-managed price overrides.
+func ProvideBillingService(cfg *config.Config, pricingService *PricingService, modelPrices *ModelPriceService) *BillingService {
+	svc := NewBillingService(cfg, pricingService)
+	svc.modelPrices = modelPrices
+	return svc
+}
+
+// ProvideBillingCacheService creates BillingCacheService with pre-flight cost reservation.
+func ProvideBillingCacheService(cache BillingCache, reservationCache BillingReservationCache, billingService *BillingService, userRepo UserRepository, subRepo UserSubscriptionRepository, apiKeyRepo APIKeyRepository, orgRepo OrganizationRepository, cfg *config.Config) *BillingCacheService {
+	svc := NewBillingCacheService(cache, userRepo, subRepo, apiKeyRepo, cfg)
+	svc.reservationCache = reservationCache
+	svc.billingService = billingService
+	svc.organizationRepo = orgRepo
+	return svc
+}
+
+// ProvideAPIKeyService creates APIKeyService with team API key support.
+func ProvideAPIKeyService(
+	apiKeyRepo APIKeyRepository,
+	userRepo UserRepository,
+	groupRepo GroupRepository,
+	userSubRepo UserSubscriptionRepository,
+	cache APIKeyCache,
+	orgRepo OrganizationRepository,
+	cfg *config.Config,
+) *APIKeyService {
+	svc := NewAPIKeyService(apiKeyRepo, userRepo, groupRepo, userSubRepo, cache, cfg)
+	svc.orgRepo = orgRepo
+	return svc
+}
+
 // ProvideSchedulerSnapshotService creates and starts SchedulerSnapshotService.
 func ProvideSchedulerSnapshotService(
 	cache SchedulerCache,
@@ -128,11 +233,13 @@ func ProvideRateLimitService(
 	timeoutCounterCache TimeoutCounterCache,
 	settingService *SettingService,
 	tokenCacheInvalidator TokenCacheInvalidator,
+	webhookService *WebhookService,
 ) *RateLimitService {
 	svc := NewRateLimitService(accountRepo, usageRepo, cfg, geminiQuotaService, tempUnschedCache)
 	svc.SetTimeoutCounterCache(timeoutCounterCache)
 	svc.SetSettingService(settingService)
 	svc.SetTokenCacheInvalidator(tokenCacheInvalidator)
+	svc.SetWebhookService(webhookService)
 	return svc
 }
 
@@ -170,9 +277,11 @@ func ProvideOpsAlertEvaluatorService(
 	opsRepo OpsRepository,
 	emailService *EmailService,
 	redisClient *redis.Client,
+	webhookService *WebhookService,
 	cfg *config.Config,
 ) *OpsAlertEvaluatorService {
 	svc := NewOpsAlertEvaluatorService(opsService, opsRepo, emailService, redisClient, cfg)
+	svc.SetWebhookService(webhookService)
 	svc.Start()
 	return svc
 }
@@ -226,7 +335,7 @@ var ProviderSet = wire.NewSet(
 	// Core services
 	NewAuthService,
 	NewUserService,
-	NewAPIKeyService,
+	ProvideAPIKeyService,
 	ProvideAPIKeyAuthCacheInvalidator,
 	NewGroupService,
 	NewAccountService,
@@ -236,9 +345,15 @@ var ProviderSet = wire.NewSet(
 	NewUsageService,
 	NewDashboardService,
 	ProvidePricingService,
-	NewBillingService,
-	NewBillingCacheService,
+	ProvideModelPriceService,
+	ProvideBillingService,
+	ProvideBillingCacheService,
+	NewAPIKeyRateLimitService,
+	NewResponseCacheService,
+	NewGuardrailService,
 	NewAdminService,
+	NewSchedulingStrategyService,
+	NewAccountCircuitBreakerService,
 	NewGatewayService,
 	NewOpenAIGatewayService,
 	NewOAuthService,
@@ -280,6 +395,14 @@ var ProviderSet = wire.NewSet(
 	ProvideTimingWheelService,
 	ProvideDashboardAggregationService,
 	ProvideUsageCleanupService,
+	ProvideMessageBatchService,
+	ProvideWebhookService,
+	ProvideOpsCaptureService,
+	NewAuditLogService,
+	ProvideBalanceLedgerService,
+	ProvideInvoiceService,
+	NewOrganizationService,
+	ProvideBalanceAlertService,
 	ProvideDeferredService,
 	NewAntigravityQuotaFetcher,
 	NewUserAttributeService,
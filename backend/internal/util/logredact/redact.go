@@ -48,6 +48,11 @@ func RedactJSON(raw []byte, extraKeys ...string) string {
 	return string(encoded)
 }
 
+// IsSensitiveKey 判断字段名是否属于敏感字段（默认敏感字段 + extraKeys）
+func IsSensitiveKey(key string, extraKeys ...string) bool {
+	return isSensitiveKey(key, buildKeySet(extraKeys))
+}
+
 func buildKeySet(extraKeys []string) map[string]struct{} {
 	keys := make(map[string]struct{}, len(defaultSensitiveKeys)+len(extraKeys))
 	for k := range defaultSensitiveKeys {
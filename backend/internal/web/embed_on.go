@@ -89,6 +89,7 @@ func (s *FrontendServer) Middleware() gin.HandlerFunc {
 			strings.HasPrefix(path, "/antigravity/") ||
 			strings.HasPrefix(path, "/setup/") ||
 			path == "/health" ||
+			path == "/metrics" ||
 			path == "/responses" {
 			c.Next()
 			return
@@ -212,6 +213,7 @@ func ServeEmbeddedFrontend() gin.HandlerFunc {
 			strings.HasPrefix(path, "/antigravity/") ||
 			strings.HasPrefix(path, "/setup/") ||
 			path == "/health" ||
+			path == "/metrics" ||
 			path == "/responses" {
 			c.Next()
 			return
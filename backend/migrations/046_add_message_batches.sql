@@ -0,0 +1,32 @@
+-- 046_add_message_batches.sql
+-- Anthropic Message Batches 归属与延迟计费记录
+
+CREATE TABLE IF NOT EXISTS message_batches (
+    id BIGSERIAL PRIMARY KEY,
+    batch_id VARCHAR(128) NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    api_key_id BIGINT NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    group_id BIGINT REFERENCES groups(id) ON DELETE SET NULL,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    processing_status VARCHAR(20) NOT NULL DEFAULT 'in_progress',
+    results_url TEXT,
+    payload JSONB,
+    expires_at TIMESTAMPTZ,
+    last_polled_at TIMESTAMPTZ,
+    settled_at TIMESTAMPTZ,
+    settled_requests INT NOT NULL DEFAULT 0,
+    error_message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_message_batches_batch_id
+    ON message_batches(batch_id);
+
+CREATE INDEX IF NOT EXISTS idx_message_batches_api_key_id
+    ON message_batches(api_key_id, id DESC);
+
+-- 后台轮询：仅扫描未结算批次
+CREATE INDEX IF NOT EXISTS idx_message_batches_unsettled
+    ON message_batches(last_polled_at NULLS FIRST)
+    WHERE settled_at IS NULL;
@@ -0,0 +1,9 @@
+-- Migration: Add API key spend quota and expiry
+-- Description: Optional per-key USD quota, accumulated spend and expiry time
+
+ALTER TABLE api_keys
+ADD COLUMN IF NOT EXISTS quota_usd DECIMAL(20,8),
+ADD COLUMN IF NOT EXISTS used_usd DECIMAL(20,10) NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_expires_at ON api_keys(expires_at) WHERE expires_at IS NOT NULL AND deleted_at IS NULL;
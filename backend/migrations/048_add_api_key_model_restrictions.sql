@@ -0,0 +1,9 @@
+-- Migration: Add API key model allowlist and max_tokens cap
+-- Description: Optional per-key model pattern allowlist and output token ceiling
+
+ALTER TABLE api_keys
+ADD COLUMN IF NOT EXISTS allowed_models JSONB,
+ADD COLUMN IF NOT EXISTS max_tokens_cap INTEGER;
+
+COMMENT ON COLUMN api_keys.allowed_models IS 'Allowed model patterns (supports trailing *), NULL or empty means all models';
+COMMENT ON COLUMN api_keys.max_tokens_cap IS 'Upper bound for max_tokens/max_output_tokens, NULL means unlimited';
@@ -0,0 +1,13 @@
+-- Migration: Add API key RPM/TPM rate limits
+-- Description: Per-key requests/tokens per minute with group-level defaults
+
+ALTER TABLE api_keys
+ADD COLUMN IF NOT EXISTS rpm_limit INTEGER,
+ADD COLUMN IF NOT EXISTS tpm_limit INTEGER;
+
+ALTER TABLE groups
+ADD COLUMN IF NOT EXISTS default_rpm_limit INTEGER,
+ADD COLUMN IF NOT EXISTS default_tpm_limit INTEGER;
+
+COMMENT ON COLUMN api_keys.rpm_limit IS 'Requests per minute, NULL falls back to groups.default_rpm_limit';
+COMMENT ON COLUMN api_keys.tpm_limit IS 'Tokens per minute, NULL falls back to groups.default_tpm_limit';
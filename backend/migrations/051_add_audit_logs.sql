@@ -0,0 +1,27 @@
+-- 051_add_audit_logs.sql
+-- 管理后台写操作审计日志
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    -- 操作者（管理员 API Key 认证时为首个管理员用户）
+    actor_user_id BIGINT,
+    -- jwt / admin_api_key
+    auth_method VARCHAR(20) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    method VARCHAR(10) NOT NULL,
+    -- 路由模板（如 /api/v1/admin/accounts/:id）与实际路径
+    route VARCHAR(255) NOT NULL,
+    path VARCHAR(512) NOT NULL,
+    target_type VARCHAR(64) NOT NULL DEFAULT '',
+    target_id VARCHAR(64) NOT NULL DEFAULT '',
+    status_code INT NOT NULL DEFAULT 0,
+    -- 已脱敏的请求体与变更前后差异
+    request_body TEXT,
+    diff JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs (target_type, target_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs (actor_user_id, id DESC);
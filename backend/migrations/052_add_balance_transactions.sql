@@ -0,0 +1,28 @@
+-- 052_add_balance_transactions.sql
+-- 用户余额流水账本（只追加），每次余额变更与流水在同一语句中写入
+
+CREATE TABLE IF NOT EXISTS balance_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- usage / redeem / promo / admin_adjust / initial / opening
+    type VARCHAR(20) NOT NULL,
+    amount DECIMAL(20, 8) NOT NULL,
+    balance_after DECIMAL(20, 8) NOT NULL,
+    -- 关联来源（按类型至多一个有值）
+    usage_log_id BIGINT,
+    redeem_code_id BIGINT,
+    promo_code_id BIGINT,
+    admin_user_id BIGINT,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_transactions_user_id ON balance_transactions (user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_balance_transactions_usage_log_id ON balance_transactions (usage_log_id) WHERE usage_log_id IS NOT NULL;
+
+-- 期初余额：为已有余额的用户写入一条 opening 流水，使账本合计与当前余额一致
+INSERT INTO balance_transactions (user_id, type, amount, balance_after, notes)
+SELECT u.id, 'opening', u.balance, u.balance, 'ledger opening balance'
+FROM users u
+WHERE u.balance <> 0
+  AND NOT EXISTS (SELECT 1 FROM balance_transactions bt WHERE bt.user_id = u.id);
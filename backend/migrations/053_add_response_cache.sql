@@ -0,0 +1,16 @@
+-- 053_add_response_cache.sql
+-- 分组级响应缓存配置，以及使用日志中的缓存命中标记与节省费用
+
+ALTER TABLE groups
+ADD COLUMN IF NOT EXISTS response_cache_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS response_cache_ttl_seconds INTEGER,
+ADD COLUMN IF NOT EXISTS response_cache_rate DECIMAL(10, 4) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN groups.response_cache_ttl_seconds IS 'Response cache TTL in seconds, NULL falls back to gateway.response_cache.default_ttl_seconds';
+COMMENT ON COLUMN groups.response_cache_rate IS 'Fraction of the normal price billed for cache hits (0 = free)';
+
+ALTER TABLE usage_logs
+ADD COLUMN IF NOT EXISTS cache_served BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS cache_saved_cost DECIMAL(20, 10) NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_usage_logs_cache_served_created_at ON usage_logs (created_at) WHERE cache_served;
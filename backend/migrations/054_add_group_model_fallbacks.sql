@@ -0,0 +1,7 @@
+-- 054_add_group_model_fallbacks.sql
+-- 分组级模型降级链：当前分组账号全部不可用时按配置切换到其他分组的模型
+
+ALTER TABLE groups
+ADD COLUMN IF NOT EXISTS model_fallbacks JSONB;
+
+COMMENT ON COLUMN groups.model_fallbacks IS 'Model fallback chains: {"model_pattern": [{"group_id": 1, "model": "gemini-2.5-pro"}, ...]}, supports trailing * wildcard';
@@ -0,0 +1,7 @@
+-- 055_add_group_guardrails.sql
+-- 分组级护栏配置：转发前的关键词/正则拒绝、PII 脱敏、最大提示词长度与自定义系统提示词
+
+ALTER TABLE groups
+ADD COLUMN IF NOT EXISTS guardrails JSONB;
+
+COMMENT ON COLUMN groups.guardrails IS 'Guardrail config: {"enabled", "deny_keywords", "deny_patterns", "redact_pii", "redact_responses", "max_prompt_chars", "system_prompt"}';
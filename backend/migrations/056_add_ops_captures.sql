@@ -0,0 +1,53 @@
+-- 056_add_ops_captures.sql
+-- 调试抓包：按 API Key / 用户 / 账号在限定时间窗口内抓取完整请求与响应（内容加密存储，到期自动删除）
+
+CREATE TABLE IF NOT EXISTS ops_capture_rules (
+    id BIGSERIAL PRIMARY KEY,
+    -- 目标类型：api_key / user / account
+    target_type VARCHAR(20) NOT NULL,
+    target_id BIGINT NOT NULL,
+    -- 采样率 (0,1]
+    sample_rate DOUBLE PRECISION NOT NULL DEFAULT 1,
+    -- 抓包数据保留时长（小时）
+    retention_hours INT NOT NULL DEFAULT 72,
+    note TEXT NOT NULL DEFAULT '',
+    created_by BIGINT,
+    -- 抓取窗口结束时间
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ops_capture_rules_expires_at
+    ON ops_capture_rules(expires_at);
+
+CREATE TABLE IF NOT EXISTS ops_captures (
+    id BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT NOT NULL REFERENCES ops_capture_rules(id) ON DELETE CASCADE,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    api_key_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    account_id BIGINT,
+    group_id BIGINT,
+    platform VARCHAR(32) NOT NULL DEFAULT '',
+    model VARCHAR(128) NOT NULL DEFAULT '',
+    request_path VARCHAR(256) NOT NULL DEFAULT '',
+    stream BOOLEAN NOT NULL DEFAULT FALSE,
+    status_code INT NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    error_message TEXT NOT NULL DEFAULT '',
+    request_bytes INT NOT NULL DEFAULT 0,
+    response_bytes INT NOT NULL DEFAULT 0,
+    truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    -- 以下内容均为 AES-256-GCM 密文（base64）
+    request_body TEXT NOT NULL DEFAULT '',
+    response_body TEXT NOT NULL DEFAULT '',
+    response_text TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ops_captures_rule_id
+    ON ops_captures(rule_id, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_ops_captures_expires_at
+    ON ops_captures(expires_at);
@@ -0,0 +1,12 @@
+-- 057_add_group_scheduling_strategy.sql
+-- 分组级调度策略：最低成本、最低首字延迟、最少在途请求、加权轮询
+
+ALTER TABLE groups
+ADD COLUMN IF NOT EXISTS scheduling_strategy VARCHAR(32) NOT NULL DEFAULT '';
+
+ALTER TABLE groups
+ADD COLUMN IF NOT EXISTS scheduling_weights JSONB;
+
+COMMENT ON COLUMN groups.scheduling_strategy IS 'Account scheduling strategy: '''' (default), lowest_cost, lowest_latency, least_outstanding, weighted_round_robin';
+COMMENT ON COLUMN groups.scheduling_weights IS 'Weighted round-robin weights: {"<account_id>": weight}';
+
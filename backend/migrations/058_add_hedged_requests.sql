@@ -0,0 +1,16 @@
+-- 058_add_hedged_requests.sql
+-- 分组级对冲请求：首字节超时后向第二个账号发起相同请求，仅胜出请求计费
+
+ALTER TABLE groups
+ADD COLUMN IF NOT EXISTS hedge_delay_ms INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN groups.hedge_delay_ms IS 'Fire a hedged request to a second account when no first byte arrives within this many ms (0 = disabled)';
+
+ALTER TABLE usage_logs
+ADD COLUMN IF NOT EXISTS hedged BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE usage_logs
+ADD COLUMN IF NOT EXISTS hedge_backup_won BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN usage_logs.hedged IS 'A hedged request was fired for this request';
+COMMENT ON COLUMN usage_logs.hedge_backup_won IS 'The hedged (backup) account answered first and was billed';
@@ -0,0 +1,16 @@
+-- 059_add_fair_queue.sql
+-- 账号槽位公平排队：用户优先级与权重、订阅分组（套餐等级）默认权重
+
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS queue_priority INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS queue_weight INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN users.queue_priority IS 'Priority class when waiting for saturated account slots (-10..10, higher is served first)';
+COMMENT ON COLUMN users.queue_weight IS 'Fair-share weight when waiting for account slots (0 = inherit from subscription group, then 1)';
+
+ALTER TABLE groups
+ADD COLUMN IF NOT EXISTS queue_weight INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN groups.queue_weight IS 'Default fair-share weight for subscribers of this group (0 = 1)';
@@ -0,0 +1,9 @@
+-- 060_add_usage_client_disconnected.sql
+-- 流式请求中途客户端断开标记：断开后可继续排空上游并按实际生成计费，供看板统计被放弃流的消耗
+
+ALTER TABLE usage_logs
+ADD COLUMN IF NOT EXISTS client_disconnected BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN usage_logs.client_disconnected IS 'The client disconnected mid-stream; usage reflects what the upstream generated (drained up to gateway.stream_drain_on_disconnect_timeout)';
+
+CREATE INDEX IF NOT EXISTS idx_usage_logs_client_disconnected_created_at ON usage_logs(created_at) WHERE client_disconnected = true;
@@ -0,0 +1,25 @@
+-- 061_add_model_prices.sql
+-- 管理员自定义模型价格：全局价格与分组覆盖，按生效时间取价，历史用量不被重新定价
+
+CREATE TABLE IF NOT EXISTS model_prices (
+    id BIGSERIAL PRIMARY KEY,
+    -- 模型名（小写，精确匹配）
+    model VARCHAR(255) NOT NULL,
+    -- 分组覆盖，NULL 表示全局价格
+    group_id BIGINT REFERENCES groups(id) ON DELETE CASCADE,
+    -- 每 token 价格（USD，与 LiteLLM 格式一致）
+    input_price DECIMAL(20,12) NOT NULL DEFAULT 0,
+    output_price DECIMAL(20,12) NOT NULL DEFAULT 0,
+    cache_write_price DECIMAL(20,12) NOT NULL DEFAULT 0,
+    cache_read_price DECIMAL(20,12) NOT NULL DEFAULT 0,
+    -- 每张图片价格（USD），NULL 表示沿用分组图片价格或 LiteLLM 默认值
+    image_price DECIMAL(20,8),
+    -- 生效时间：计费时取 effective_from <= 当前时间 的最新一条
+    effective_from TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_model_prices_scope_effective ON model_prices (model, COALESCE(group_id, 0), effective_from);
+CREATE INDEX IF NOT EXISTS idx_model_prices_group_id ON model_prices (group_id) WHERE group_id IS NOT NULL;
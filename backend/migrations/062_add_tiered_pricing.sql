@@ -0,0 +1,18 @@
+-- 062_add_tiered_pricing.sql
+-- 阶梯计费：用量记录落库长上下文阶梯、服务等级与计费明细；自定义价格支持 1 小时缓存创建价格与长上下文阶梯
+
+ALTER TABLE usage_logs
+ADD COLUMN IF NOT EXISTS pricing_tier VARCHAR(32) NOT NULL DEFAULT 'standard',
+ADD COLUMN IF NOT EXISTS service_tier VARCHAR(16) NOT NULL DEFAULT 'standard',
+ADD COLUMN IF NOT EXISTS cost_detail JSONB;
+
+COMMENT ON COLUMN usage_logs.pricing_tier IS 'Context pricing tier applied to the whole request: standard, or above_<threshold> for long-context prices';
+COMMENT ON COLUMN usage_logs.service_tier IS 'Service tier the request was billed at: standard, batch, priority or flex';
+COMMENT ON COLUMN usage_logs.cost_detail IS 'Unit prices, 5m/1h cache write split and discounts used to compute the cost';
+
+ALTER TABLE model_prices
+ADD COLUMN IF NOT EXISTS cache_write_1h_price DECIMAL(20,12),
+ADD COLUMN IF NOT EXISTS tiers JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+COMMENT ON COLUMN model_prices.cache_write_1h_price IS '1h cache write price per token; NULL derives it from cache_write_price';
+COMMENT ON COLUMN model_prices.tiers IS 'Long-context tiers: [{threshold, input_price, output_price, cache_write_price, cache_write_1h_price, cache_read_price}]';
@@ -0,0 +1,39 @@
+-- 063_add_invoices.sql
+-- 月度账单：按用户、账期汇总用量（按模型/分组）、充值赠送与订阅分配，生成后落库，供下载 PDF / HTML / CSV
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    -- issued / void
+    status VARCHAR(16) NOT NULL DEFAULT 'issued',
+    currency VARCHAR(8) NOT NULL DEFAULT 'USD',
+    opening_balance DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    closing_balance DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    usage_charges DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    subscription_usage DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    credits DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    adjustments DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    line_items JSONB NOT NULL DEFAULT '[]'::jsonb,
+    revision INT NOT NULL DEFAULT 1,
+    generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    voided_at TIMESTAMPTZ,
+    voided_by BIGINT,
+    void_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 同一用户同一账期至多一张有效账单；作废后可重新出具
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_user_period_active ON invoices (user_id, period_start) WHERE status <> 'void';
+CREATE INDEX IF NOT EXISTS idx_invoices_user_id ON invoices (user_id, period_start DESC);
+CREATE INDEX IF NOT EXISTS idx_invoices_period_start ON invoices (period_start);
+
+COMMENT ON COLUMN invoices.period_end IS 'Exclusive end of the billing period (start of the next month)';
+COMMENT ON COLUMN invoices.usage_charges IS 'Usage charged to the balance (actual_cost, including rate multipliers)';
+COMMENT ON COLUMN invoices.subscription_usage IS 'Usage covered by subscriptions at standard price (total_cost), not charged to the balance';
+COMMENT ON COLUMN invoices.credits IS 'Redeem code top-ups, promo credits and initial balance in the period';
+COMMENT ON COLUMN invoices.adjustments IS 'Admin balance adjustments in the period';
+COMMENT ON COLUMN invoices.line_items IS 'Line items: usage by model/group/billing type, credits, adjustments and subscription assignments';
+COMMENT ON COLUMN invoices.revision IS 'Incremented each time an admin regenerates the invoice';
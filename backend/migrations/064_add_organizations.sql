@@ -0,0 +1,86 @@
+-- 064_add_organizations.sql
+-- 组织/团队：共享余额与可选共享订阅、owner/admin/member 角色、成员月度消费上限、团队 API Key 与邮件邀请
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    owner_user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    balance DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    shared_subscriptions BOOLEAN NOT NULL DEFAULT FALSE,
+    -- active / disabled
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_organizations_owner_user_id ON organizations (owner_user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_organizations_status ON organizations (status);
+
+COMMENT ON COLUMN organizations.balance IS 'Shared balance charged by team API keys in balance mode';
+COMMENT ON COLUMN organizations.shared_subscriptions IS 'Team API keys in subscription groups use the owner''s subscription';
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- owner / admin / member
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    spend_limit_usd DECIMAL(20, 8),
+    period_spend_usd DECIMAL(20, 10) NOT NULL DEFAULT 0,
+    period_start TIMESTAMPTZ NOT NULL DEFAULT date_trunc('month', NOW()),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (organization_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members (user_id);
+
+COMMENT ON COLUMN organization_members.spend_limit_usd IS 'Monthly spend cap on the organization balance for this member, NULL means unlimited';
+COMMENT ON COLUMN organization_members.period_spend_usd IS 'Spend charged to the organization in the month starting at period_start';
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    invited_by BIGINT NOT NULL,
+    -- pending / accepted / revoked
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_by BIGINT,
+    accepted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_org ON organization_invitations (organization_id, created_at DESC);
+
+COMMENT ON COLUMN organization_invitations.token_hash IS 'SHA-256 of the invitation token; the token itself is only sent by email';
+
+-- 组织余额流水（只追加），与 organizations.balance 在同一语句内原子更新
+CREATE TABLE IF NOT EXISTS organization_balance_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    -- 用量扣费对应的成员 / 转入余额的成员
+    user_id BIGINT,
+    type VARCHAR(20) NOT NULL,
+    amount DECIMAL(20, 10) NOT NULL,
+    balance_after DECIMAL(20, 10) NOT NULL,
+    usage_log_id BIGINT,
+    admin_user_id BIGINT,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_balance_transactions_org ON organization_balance_transactions (organization_id, created_at DESC);
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS organization_id BIGINT;
+CREATE INDEX IF NOT EXISTS idx_api_keys_organization_id ON api_keys (organization_id) WHERE organization_id IS NOT NULL;
+
+COMMENT ON COLUMN api_keys.organization_id IS 'Team API key: usage is charged to this organization';
+
+ALTER TABLE usage_logs ADD COLUMN IF NOT EXISTS organization_id BIGINT;
+CREATE INDEX IF NOT EXISTS idx_usage_logs_organization_created_at ON usage_logs (organization_id, created_at) WHERE organization_id IS NOT NULL;
+
+COMMENT ON COLUMN usage_logs.organization_id IS 'Organization charged for this request (team API keys)';
@@ -0,0 +1,34 @@
+-- 065_add_balance_alerts.sql
+-- 低余额提醒（邮件/用户 Webhook，每次跌破阈值只通知一次）与自动充值（消耗用户预登记的兑换码）
+
+CREATE TABLE IF NOT EXISTS user_balance_alerts (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    thresholds JSONB NOT NULL DEFAULT '[]',
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    webhook_url TEXT NOT NULL DEFAULT '',
+    webhook_secret VARCHAR(100) NOT NULL DEFAULT '',
+    notified_threshold DECIMAL(20, 8),
+    auto_topup_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    auto_topup_threshold DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    auto_topup_last_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON COLUMN user_balance_alerts.thresholds IS 'Low-balance thresholds in USD (JSON array)';
+COMMENT ON COLUMN user_balance_alerts.notified_threshold IS 'Lowest threshold already notified in the current crossing, reset when the balance recovers';
+COMMENT ON COLUMN user_balance_alerts.auto_topup_last_at IS 'Last auto top-up attempt, used as a cross-instance cooldown';
+
+CREATE TABLE IF NOT EXISTS user_auto_topup_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    redeem_code_id BIGINT NOT NULL REFERENCES redeem_codes(id) ON DELETE CASCADE,
+    -- pending / used / failed
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error_message TEXT,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (redeem_code_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_auto_topup_codes_user ON user_auto_topup_codes (user_id, status, id);
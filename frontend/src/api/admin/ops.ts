@@ -108,6 +108,13 @@ export interface OpsDashboardOverview {
   upstream_429_count: number
   upstream_529_count: number
 
+  hedge_fired_count: number
+  hedge_backup_won_count: number
+
+  client_disconnected_count: number
+  client_disconnected_cost: number
+  client_disconnected_cost_ratio: number
+
   qps: {
     current: number
     peak: number
@@ -348,6 +355,44 @@ export async function getConcurrencyStats(platform?: string, groupId?: number |
   return data
 }
 
+export interface GroupWaitQueueInfo {
+  group_id: number
+  group_name: string
+  platform: string
+  depth: number
+  wait_time: OpsPercentiles
+}
+
+export interface AccountWaitQueueInfo {
+  account_id: number
+  account_name: string
+  platform: string
+  depth: number
+  wait_time: OpsPercentiles
+}
+
+export interface OpsWaitQueueStatsResponse {
+  enabled: boolean
+  total_depth: number
+  wait_time: OpsPercentiles
+  group: Record<string, GroupWaitQueueInfo>
+  account: Record<string, AccountWaitQueueInfo>
+  timestamp: string
+}
+
+export async function getWaitQueueStats(platform?: string, groupId?: number | null): Promise<OpsWaitQueueStatsResponse> {
+  const params: Record<string, any> = {}
+  if (platform) {
+    params.platform = platform
+  }
+  if (typeof groupId === 'number' && groupId > 0) {
+    params.group_id = groupId
+  }
+
+  const { data } = await apiClient.get<OpsWaitQueueStatsResponse>('/admin/ops/wait-queue', { params })
+  return data
+}
+
 export interface PlatformAvailability {
   platform: string
   total_accounts: number
@@ -1165,6 +1210,7 @@ export const opsAPI = {
   getErrorTrend,
   getErrorDistribution,
   getConcurrencyStats,
+  getWaitQueueStats,
   getAccountAvailabilityStats,
   getRealtimeTrafficSummary,
   subscribeQPS,
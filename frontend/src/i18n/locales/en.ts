@@ -2385,6 +2385,7 @@ export default {
         phase: {
           request: 'Request',
           auth: 'Auth',
+          guardrail: 'Guardrail',
           routing: 'Routing',
           upstream: 'Upstream',
           network: 'Network',
@@ -2538,6 +2538,7 @@ export default {
         phase: {
           request: '请求',
           auth: '认证',
+          guardrail: '护栏',
           routing: '路由',
           upstream: '上游',
           network: '网络',